@@ -0,0 +1,34 @@
+// Package api holds the hand-maintained OpenAPI specification for the HTTP API
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// SpecJSON returns the OpenAPI specification encoded as JSON. The conversion
+// runs once and the result is shared by every caller.
+func SpecJSON() ([]byte, error) {
+	specOnce.Do(func() {
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(specYAML, &doc); err != nil {
+			specErr = fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+			return
+		}
+		specJSON, specErr = json.Marshal(doc)
+	})
+	return specJSON, specErr
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"ecommerce-backend/pkg/money"
+)
+
+// seedEmailDomain marks seeded accounts so a later run can find and remove
+// them
+const seedEmailDomain = "seed.example.com"
+
+// seedSKUPrefix marks seeded products the same way
+const seedSKUPrefix = "SEED-"
+
+var firstNames = []string{
+	"Aarav", "Aisha", "Alex", "Amelia", "Ananya", "Ben", "Carlos", "Chloe", "Daniel", "Diya",
+	"Elena", "Ethan", "Fatima", "Grace", "Hannah", "Isaac", "Ishaan", "Jack", "Kavya", "Leo",
+	"Liam", "Maya", "Mei", "Noah", "Olivia", "Priya", "Rahul", "Sara", "Sofia", "Tom",
+	"Vikram", "Yusuf", "Zara", "Lucas", "Emma", "Arjun", "Nina", "Omar", "Rosa", "Kenji",
+}
+
+var lastNames = []string{
+	"Sharma", "Patel", "Smith", "Johnson", "Garcia", "Khan", "Chen", "Williams", "Brown", "Singh",
+	"Martinez", "Lee", "Gupta", "Davis", "Lopez", "Wilson", "Nguyen", "Anderson", "Kumar", "Taylor",
+	"Thomas", "Moore", "Reddy", "Jackson", "Martin", "Iyer", "Clark", "Lewis", "Walker", "Hall",
+}
+
+type seedCity struct {
+	City       string
+	State      string
+	Country    string
+	PostalCode string
+}
+
+var cities = []seedCity{
+	{"Mumbai", "Maharashtra", "India", "400001"},
+	{"Pune", "Maharashtra", "India", "411001"},
+	{"Bengaluru", "Karnataka", "India", "560001"},
+	{"Delhi", "Delhi", "India", "110001"},
+	{"Hyderabad", "Telangana", "India", "500001"},
+	{"Chennai", "Tamil Nadu", "India", "600001"},
+	{"New York", "NY", "USA", "10001"},
+	{"San Francisco", "CA", "USA", "94105"},
+	{"Austin", "TX", "USA", "73301"},
+	{"Seattle", "WA", "USA", "98101"},
+	{"London", "England", "UK", "EC1A 1BB"},
+	{"Manchester", "England", "UK", "M1 1AE"},
+}
+
+var streets = []string{
+	"MG Road", "Park Street", "Main Street", "High Street", "Oak Avenue", "Station Road",
+	"Lake View Road", "Church Street", "Market Road", "Hill Road", "Maple Drive", "River Lane",
+}
+
+// seedCategory describes the products generated for one category: the price
+// range they fall in, the nouns they are named after, and the variant axis,
+// if products in it come in sizes or colours
+type seedCategory struct {
+	Name      string
+	Code      string
+	MinPrice  money.Money
+	MaxPrice  money.Money
+	Nouns     []string
+	Variants  []map[string]string
+	ImageURLs []string
+}
+
+var adjectives = []string{
+	"Classic", "Premium", "Essential", "Pro", "Lite", "Ultra", "Eco", "Smart", "Compact", "Deluxe",
+	"Everyday", "Signature", "Urban", "Vintage", "Studio",
+}
+
+var sizeVariants = []map[string]string{
+	{"size": "S"}, {"size": "M"}, {"size": "L"}, {"size": "XL"},
+}
+
+var colorVariants = []map[string]string{
+	{"color": "Black"}, {"color": "White"}, {"color": "Blue"},
+}
+
+var categories = []seedCategory{
+	{
+		Name: "Electronics", Code: "ELE", MinPrice: money.FromMajor(49), MaxPrice: money.FromMajor(1999),
+		Nouns:     []string{"Laptop", "Smartphone", "Tablet", "Smartwatch", "Monitor", "Keyboard", "Webcam", "Power Bank"},
+		Variants:  colorVariants,
+		ImageURLs: []string{"https://images.unsplash.com/photo-1603302576837-37561b2e2302", "https://images.unsplash.com/photo-1592899677977-9c10ca588bbd"},
+	},
+	{
+		Name: "Audio", Code: "AUD", MinPrice: money.FromMajor(19), MaxPrice: money.FromMajor(499),
+		Nouns:     []string{"Headphones", "Earbuds", "Speaker", "Soundbar", "Turntable"},
+		Variants:  colorVariants,
+		ImageURLs: []string{"https://images.unsplash.com/photo-1505740420928-5e560c06d30e"},
+	},
+	{
+		Name: "Books", Code: "BOK", MinPrice: money.FromMajor(8), MaxPrice: money.FromMajor(65),
+		Nouns:     []string{"Cookbook", "Novel", "Programming Guide", "Biography", "Travel Journal", "Design Handbook"},
+		ImageURLs: []string{"https://images.unsplash.com/photo-1544716278-ca5e3f4abd8c"},
+	},
+	{
+		Name: "Clothing", Code: "CLO", MinPrice: money.FromMajor(12), MaxPrice: money.FromMajor(180),
+		Nouns:     []string{"T-Shirt", "Hoodie", "Jacket", "Jeans", "Sneakers", "Dress"},
+		Variants:  sizeVariants,
+		ImageURLs: []string{"https://images.unsplash.com/photo-1521572163474-6864f9cf17ab"},
+	},
+	{
+		Name: "Home", Code: "HOM", MinPrice: money.FromMajor(9), MaxPrice: money.FromMajor(350),
+		Nouns:     []string{"Lamp", "Blender", "Coffee Maker", "Throw Blanket", "Cookware Set", "Vase"},
+		ImageURLs: []string{"https://images.unsplash.com/photo-1556911220-bff31c812dba"},
+	},
+	{
+		Name: "Sports", Code: "SPO", MinPrice: money.FromMajor(15), MaxPrice: money.FromMajor(600),
+		Nouns:     []string{"Yoga Mat", "Dumbbell Set", "Running Shoes", "Bicycle Helmet", "Tennis Racket"},
+		Variants:  sizeVariants,
+		ImageURLs: []string{"https://images.unsplash.com/photo-1517836357463-d25dfeac3438"},
+	},
+	{
+		Name: "Beauty", Code: "BEA", MinPrice: money.FromMajor(5), MaxPrice: money.FromMajor(120),
+		Nouns:     []string{"Face Serum", "Moisturizer", "Shampoo", "Perfume", "Lip Balm"},
+		ImageURLs: []string{"https://images.unsplash.com/photo-1596462502278-27bfdc403348"},
+	},
+}
+
+var returnReasons = []string{
+	"Item arrived damaged",
+	"Wrong size",
+	"Not as described",
+	"Changed my mind",
+	"Received the wrong item",
+	"Quality not as expected",
+	"Arrived too late",
+}
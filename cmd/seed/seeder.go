@@ -0,0 +1,621 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type seedOptions struct {
+	Users       int
+	Products    int
+	Orders      int
+	HistoryDays int
+	Password    string
+	RandSeed    int64
+}
+
+type seedStats struct {
+	users, products, variants, carts, orders, payments, returns int
+}
+
+type seedVariant struct {
+	ID    uuid.UUID
+	Price money.Money
+}
+
+type seedProduct struct {
+	ID       uuid.UUID
+	Price    money.Money
+	Variants []seedVariant
+}
+
+type seedUser struct {
+	ID        uuid.UUID
+	FirstName string
+	LastName  string
+	CreatedAt time.Time
+	Address   models.Address
+}
+
+type seeder struct {
+	tx   pgx.Tx
+	opts seedOptions
+	rng  *rand.Rand
+	now  time.Time
+
+	users    []seedUser
+	products []seedProduct
+
+	// Cumulative weights for picking buyers and products, so a few customers
+	// place most orders and a few products sell most units
+	userWeights    []float64
+	productWeights []float64
+
+	stats seedStats
+}
+
+func newSeeder(tx pgx.Tx, opts seedOptions) *seeder {
+	return &seeder{
+		tx:   tx,
+		opts: opts,
+		rng:  rand.New(rand.NewSource(opts.RandSeed)),
+		now:  time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func (s *seeder) run(ctx context.Context) error {
+	steps := []struct {
+		name string
+		fn   func(context.Context) error
+	}{
+		{"users", s.seedUsers},
+		{"products", s.seedProducts},
+		{"orders", s.seedOrders},
+		{"carts", s.seedCarts},
+	}
+
+	for _, step := range steps {
+		if err := step.fn(ctx); err != nil {
+			return fmt.Errorf("%s: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+// resetSeedData removes everything a previous run created. Orders go first
+// since their items reference seeded products; payments, items and refunds
+// cascade with them, carts with their users.
+func resetSeedData(ctx context.Context, tx pgx.Tx) error {
+	seededUsers := "SELECT id FROM users WHERE email LIKE '%@" + seedEmailDomain + "'"
+	statements := []string{
+		"DELETE FROM returns WHERE user_id IN (" + seededUsers + ")",
+		"DELETE FROM orders WHERE user_id IN (" + seededUsers + ")",
+		"DELETE FROM users WHERE id IN (" + seededUsers + ")",
+		"DELETE FROM products WHERE sku LIKE '" + seedSKUPrefix + "%'",
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *seeder) seedUsers(ctx context.Context) error {
+	// Every account shares one hash; bcrypt per user would dominate the run
+	hash, err := models.HashPassword(s.opts.Password)
+	if err != nil {
+		return err
+	}
+
+	adminCreated := s.daysAgo(s.opts.HistoryDays)
+	if _, err := s.tx.Exec(ctx, `
+        INSERT INTO users (id, email, password_hash, first_name, last_name, role, email_verified, email_verified_at, created_at, updated_at)
+        VALUES ($1, $2, $3, 'Demo', 'Admin', 'admin', TRUE, $4, $4, $4)
+    `, uuid.New(), "admin@"+seedEmailDomain, hash, adminCreated); err != nil {
+		return err
+	}
+
+	rows := make([][]any, 0, s.opts.Users)
+	for i := 0; i < s.opts.Users; i++ {
+		user := seedUser{
+			ID:        uuid.New(),
+			FirstName: pick(s.rng, firstNames),
+			LastName:  pick(s.rng, lastNames),
+			// Sign-ups grow over time: more recent accounts than old ones
+			CreatedAt: s.daysAgo(int(float64(s.opts.HistoryDays) * (1 - math.Sqrt(s.rng.Float64())))),
+		}
+		user.Address = s.address(user.FirstName + " " + user.LastName)
+		s.users = append(s.users, user)
+
+		email := fmt.Sprintf("%s.%s%d@%s", strings.ToLower(user.FirstName), strings.ToLower(user.LastName), i+1, seedEmailDomain)
+		// Most accounts verified their email; the rest never clicked the link
+		verified := s.rng.Float64() < 0.9
+		var verifiedAt *time.Time
+		if verified {
+			at := user.CreatedAt.Add(time.Duration(s.rng.Intn(48)) * time.Hour)
+			verifiedAt = &at
+		}
+
+		rows = append(rows, []any{
+			user.ID, email, hash, user.FirstName, user.LastName, "customer",
+			verified, verifiedAt, user.CreatedAt, user.CreatedAt,
+		})
+		// Pareto-like spend: most customers order rarely, a few often
+		s.userWeights = appendWeight(s.userWeights, math.Pow(1-s.rng.Float64(), -1/1.5))
+	}
+
+	if _, err := s.tx.CopyFrom(ctx, pgx.Identifier{"users"},
+		[]string{"id", "email", "password_hash", "first_name", "last_name", "role", "email_verified", "email_verified_at", "created_at", "updated_at"},
+		pgx.CopyFromRows(rows)); err != nil {
+		return err
+	}
+
+	s.stats.users = len(rows) + 1
+	return nil
+}
+
+func (s *seeder) seedProducts(ctx context.Context) error {
+	for i := 0; i < s.opts.Products; i++ {
+		category := categories[i%len(categories)]
+		noun := pick(s.rng, category.Nouns)
+		name := pick(s.rng, adjectives) + " " + noun
+
+		// Prices cluster toward the low end of the category's range and end in .99
+		span := float64(category.MaxPrice - category.MinPrice)
+		price := category.MinPrice + money.Money(span*math.Pow(s.rng.Float64(), 2))
+		price = price - price%100 + 99
+
+		product := seedProduct{ID: uuid.New(), Price: price}
+		stock := s.stockLevel()
+		createdAt := s.daysAgo(s.rng.Intn(s.opts.HistoryDays))
+		sku := fmt.Sprintf("%s%s-%04d", seedSKUPrefix, category.Code, i+1)
+
+		if _, err := s.tx.Exec(ctx, `
+            INSERT INTO products (id, sku, name, description, price, stock_quantity, category, image_url, created_at, updated_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+        `, product.ID, sku, name,
+			fmt.Sprintf("%s from our %s range. Demo product generated by the seed tool.", name, strings.ToLower(category.Name)),
+			price, stock, category.Name, pick(s.rng, category.ImageURLs), createdAt); err != nil {
+			return err
+		}
+
+		// Roughly half the products in a category with variants offer them
+		if len(category.Variants) > 0 && s.rng.Float64() < 0.5 {
+			for j, attributes := range category.Variants {
+				variant := seedVariant{ID: uuid.New(), Price: price}
+				// Larger sizes and some colours cost a little more
+				if j > 0 && s.rng.Float64() < 0.3 {
+					variant.Price += money.FromMajor(int64(j * 5))
+				}
+				if _, err := s.tx.Exec(ctx, `
+                    INSERT INTO product_variants (id, product_id, sku, price, stock_quantity, attributes, created_at, updated_at)
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+                `, variant.ID, product.ID, fmt.Sprintf("%s-%d", sku, j+1), variant.Price, s.stockLevel(), attributes, createdAt); err != nil {
+					return err
+				}
+				product.Variants = append(product.Variants, variant)
+				s.stats.variants++
+			}
+		}
+
+		s.products = append(s.products, product)
+	}
+
+	// Popularity follows a Zipf-like curve over a shuffled catalogue
+	order := s.rng.Perm(len(s.products))
+	weights := make([]float64, len(s.products))
+	for rank, index := range order {
+		weights[index] = 1 / math.Pow(float64(rank+1), 0.8)
+	}
+	for _, weight := range weights {
+		s.productWeights = appendWeight(s.productWeights, weight)
+	}
+
+	// All stock starts in the default warehouse, as migration 017 does for
+	// existing products
+	if _, err := s.tx.Exec(ctx, `
+        INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+        SELECT w.id, p.id, NULL, p.stock_quantity
+        FROM products p CROSS JOIN warehouses w
+        WHERE w.is_default AND p.sku LIKE $1
+        ON CONFLICT DO NOTHING
+    `, seedSKUPrefix+"%"); err != nil {
+		return err
+	}
+	if _, err := s.tx.Exec(ctx, `
+        INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+        SELECT w.id, v.product_id, v.id, v.stock_quantity
+        FROM product_variants v JOIN products p ON p.id = v.product_id CROSS JOIN warehouses w
+        WHERE w.is_default AND p.sku LIKE $1
+        ON CONFLICT DO NOTHING
+    `, seedSKUPrefix+"%"); err != nil {
+		return err
+	}
+
+	s.stats.products = len(s.products)
+	return nil
+}
+
+// stockLevel is mostly healthy stock, with some items running low and a few
+// sold out so the storefront's stock states all show up
+func (s *seeder) stockLevel() int {
+	switch r := s.rng.Float64(); {
+	case r < 0.08:
+		return 0
+	case r < 0.2:
+		return 1 + s.rng.Intn(5)
+	default:
+		return 20 + s.rng.Intn(180)
+	}
+}
+
+func (s *seeder) seedOrders(ctx context.Context) error {
+	orders := make([]time.Time, 0, s.opts.Orders)
+	buyers := make([]int, 0, s.opts.Orders)
+	for i := 0; i < s.opts.Orders; i++ {
+		buyer := pickWeighted(s.rng, s.userWeights)
+		user := s.users[buyer]
+
+		// Orders lean toward recent dates, as a growing store's would
+		window := s.now.Sub(user.CreatedAt)
+		placed := user.CreatedAt.Add(time.Duration(float64(window) * math.Sqrt(s.rng.Float64())))
+		orders = append(orders, placed)
+		buyers = append(buyers, buyer)
+	}
+
+	// Insert in date order so order numbers and timestamps line up
+	index := make([]int, len(orders))
+	for i := range index {
+		index[i] = i
+	}
+	sort.Slice(index, func(a, b int) bool { return orders[index[a]].Before(orders[index[b]]) })
+
+	for _, i := range index {
+		if err := s.seedOrder(ctx, s.users[buyers[i]], orders[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *seeder) seedOrder(ctx context.Context, user seedUser, placed time.Time) error {
+	orderID := uuid.New()
+	status := s.orderStatus(placed)
+	method := pickWeightedString(s.rng, []string{"cc", "dc", "cod"}, []float64{0.6, 0.25, 0.15})
+
+	// 1-5 distinct lines, usually one or two
+	lines := 1
+	for lines < 5 && s.rng.Float64() < 0.45 {
+		lines++
+	}
+
+	type line struct {
+		productID uuid.UUID
+		variantID *uuid.UUID
+		quantity  int
+		price     money.Money
+	}
+	var items []line
+	seen := map[uuid.UUID]bool{}
+	var total money.Money
+	for len(items) < lines {
+		product := s.products[pickWeighted(s.rng, s.productWeights)]
+		if seen[product.ID] {
+			if len(seen) >= len(s.products) {
+				break
+			}
+			continue
+		}
+		seen[product.ID] = true
+
+		item := line{productID: product.ID, price: product.Price, quantity: 1}
+		if len(product.Variants) > 0 {
+			variant := product.Variants[s.rng.Intn(len(product.Variants))]
+			item.variantID = &variant.ID
+			item.price = variant.Price
+		}
+		if r := s.rng.Float64(); r > 0.9 {
+			item.quantity = 3
+		} else if r > 0.7 {
+			item.quantity = 2
+		}
+		total += item.price.Mul(item.quantity)
+		items = append(items, item)
+	}
+
+	orderNumber := fmt.Sprintf("ORD-%d-%s", placed.Unix(), uuid.New().String()[:8])
+	updated := s.statusTime(placed, status)
+	if _, err := s.tx.Exec(ctx, `
+        INSERT INTO orders (id, user_id, order_number, total_amount, status, payment_method, shipping_address, billing_address, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $8, $9)
+    `, orderID, user.ID, orderNumber, total, status, method, user.Address, placed, updated); err != nil {
+		return err
+	}
+
+	fulfillment, shippedAt, deliveredAt := s.itemFulfillment(placed, status)
+	for i, item := range items {
+		var tracking *string
+		if shippedAt != nil {
+			number := fmt.Sprintf("TRK%09d", s.rng.Intn(1_000_000_000))
+			tracking = &number
+		}
+		if _, err := s.tx.Exec(ctx, `
+            INSERT INTO order_items (order_id, product_id, variant_id, quantity, price_at_time, fulfillment_status, tracking_number, shipped_at, delivered_at, created_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        `, orderID, item.productID, item.variantID, item.quantity, item.price, fulfillment, tracking, shippedAt, deliveredAt,
+			placed.Add(time.Duration(i)*time.Millisecond)); err != nil {
+			return err
+		}
+	}
+	s.stats.orders++
+
+	paymentID, paymentStatus, err := s.seedPayment(ctx, orderID, total, method, status, placed, updated)
+	if err != nil {
+		return err
+	}
+
+	if paymentStatus == models.PaymentCompleted && (status == models.OrderDelivered || status == models.OrderCompleted) &&
+		s.now.Sub(placed) > 5*24*time.Hour && s.rng.Float64() < 0.07 {
+		return s.seedReturn(ctx, orderID, user.ID, paymentID, total, *deliveredAt)
+	}
+	return nil
+}
+
+// orderStatus picks a status plausible for the order's age: recent orders
+// are still moving, older ones have arrived. A few of any age were cancelled.
+func (s *seeder) orderStatus(placed time.Time) models.OrderStatus {
+	if s.rng.Float64() < 0.06 {
+		return models.OrderCancelled
+	}
+
+	age := s.now.Sub(placed)
+	switch {
+	case age < 2*24*time.Hour:
+		return pickWeightedString(s.rng, []models.OrderStatus{models.OrderPending, models.OrderProcessing}, []float64{0.4, 0.6})
+	case age < 7*24*time.Hour:
+		return pickWeightedString(s.rng, []models.OrderStatus{models.OrderProcessing, models.OrderShipped, models.OrderDelivered}, []float64{0.2, 0.5, 0.3})
+	case age < 30*24*time.Hour:
+		return pickWeightedString(s.rng, []models.OrderStatus{models.OrderDelivered, models.OrderCompleted}, []float64{0.6, 0.4})
+	default:
+		return pickWeightedString(s.rng, []models.OrderStatus{models.OrderDelivered, models.OrderCompleted}, []float64{0.2, 0.8})
+	}
+}
+
+// statusTime is when the order last changed, bounded by now
+func (s *seeder) statusTime(placed time.Time, status models.OrderStatus) time.Time {
+	var after time.Duration
+	switch status {
+	case models.OrderPending:
+		return placed
+	case models.OrderProcessing, models.OrderCancelled:
+		after = time.Duration(1+s.rng.Intn(12)) * time.Hour
+	case models.OrderShipped:
+		after = time.Duration(1+s.rng.Intn(3)) * 24 * time.Hour
+	default:
+		after = time.Duration(3+s.rng.Intn(6)) * 24 * time.Hour
+	}
+
+	if at := placed.Add(after); at.Before(s.now) {
+		return at
+	}
+	return s.now
+}
+
+func (s *seeder) itemFulfillment(placed time.Time, status models.OrderStatus) (models.FulfillmentStatus, *time.Time, *time.Time) {
+	shipped := placed.Add(time.Duration(1+s.rng.Intn(2)) * 24 * time.Hour)
+	if shipped.After(s.now) {
+		shipped = s.now
+	}
+	delivered := shipped.Add(time.Duration(1+s.rng.Intn(5)) * 24 * time.Hour)
+	if delivered.After(s.now) {
+		delivered = s.now
+	}
+
+	switch status {
+	case models.OrderCancelled:
+		return models.FulfillmentCancelled, nil, nil
+	case models.OrderShipped:
+		return models.FulfillmentShipped, &shipped, nil
+	case models.OrderDelivered, models.OrderCompleted:
+		return models.FulfillmentDelivered, &shipped, &delivered
+	default:
+		return models.FulfillmentPending, nil, nil
+	}
+}
+
+// seedPayment records the payment an order of this status would have. Card
+// orders are paid up front; cash on delivery is collected on delivery.
+// Cancelled card orders are mostly failed payments. Returns uuid.Nil when
+// there is no payment.
+func (s *seeder) seedPayment(ctx context.Context, orderID uuid.UUID, amount money.Money, method string, status models.OrderStatus, placed, updated time.Time) (uuid.UUID, models.PaymentStatus, error) {
+	var paymentStatus models.PaymentStatus
+	switch {
+	case status == models.OrderCancelled && method == "cod":
+		return uuid.Nil, "", nil
+	case status == models.OrderCancelled:
+		if s.rng.Float64() < 0.3 {
+			return uuid.Nil, "", nil
+		}
+		paymentStatus = models.PaymentFailed
+	case method == "cod":
+		paymentStatus = models.PaymentPending
+		if status == models.OrderDelivered || status == models.OrderCompleted {
+			paymentStatus = models.PaymentCompleted
+		}
+	case status == models.OrderPending:
+		paymentStatus = models.PaymentPending
+	default:
+		paymentStatus = models.PaymentCompleted
+	}
+
+	paymentID := uuid.New()
+	if _, err := s.tx.Exec(ctx, `
+        INSERT INTO payments (id, order_id, amount, status, payment_method, transaction_id, payment_details, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `, paymentID, orderID, amount, paymentStatus, method, "TXN-"+uuid.New().String()[:8],
+		map[string]interface{}{"seeded": true}, placed, updated); err != nil {
+		return uuid.Nil, "", err
+	}
+
+	s.stats.payments++
+	return paymentID, paymentStatus, nil
+}
+
+// seedReturn files a return against a delivered order and moves it somewhere
+// along the return lifecycle. Completed returns are refunded in full.
+func (s *seeder) seedReturn(ctx context.Context, orderID, userID, paymentID uuid.UUID, total money.Money, delivered time.Time) error {
+	status := pickWeightedString(s.rng,
+		[]models.ReturnStatus{models.ReturnRequested, models.ReturnApproved, models.ReturnInTransit, models.ReturnReceived, models.ReturnRejected, models.ReturnCompleted},
+		[]float64{0.15, 0.1, 0.1, 0.1, 0.1, 0.45})
+
+	requested := delivered.Add(time.Duration(1+s.rng.Intn(72)) * time.Hour)
+	if requested.After(s.now) {
+		requested = s.now
+	}
+
+	var rmaNumber *string
+	if status != models.ReturnRequested && status != models.ReturnRejected {
+		rma := fmt.Sprintf("RMA-%s-%s", requested.Format("20060102"), strings.ToUpper(uuid.New().String()[:8]))
+		rmaNumber = &rma
+	}
+
+	var receivedAt *time.Time
+	if status == models.ReturnReceived || status == models.ReturnCompleted {
+		at := requested.Add(time.Duration(2+s.rng.Intn(5)) * 24 * time.Hour)
+		if at.After(s.now) {
+			at = s.now
+		}
+		receivedAt = &at
+	}
+
+	var refundAmount *money.Money
+	if status == models.ReturnCompleted {
+		refundAmount = &total
+	}
+
+	returnID := uuid.New()
+	if _, err := s.tx.Exec(ctx, `
+        INSERT INTO returns (id, order_id, user_id, reason, status, refund_amount, rma_number, received_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+    `, returnID, orderID, userID, pick(s.rng, returnReasons), status, refundAmount, rmaNumber, receivedAt, requested); err != nil {
+		return err
+	}
+	s.stats.returns++
+
+	switch status {
+	case models.ReturnRequested:
+		_, err := s.tx.Exec(ctx, "UPDATE orders SET status = $1 WHERE id = $2", models.OrderReturnRequested, orderID)
+		return err
+	case models.ReturnCompleted:
+		if _, err := s.tx.Exec(ctx, "UPDATE orders SET status = $1 WHERE id = $2", models.OrderRefunded, orderID); err != nil {
+			return err
+		}
+		if _, err := s.tx.Exec(ctx, "UPDATE payments SET status = $1, refunded_amount = amount WHERE id = $2", models.PaymentRefunded, paymentID); err != nil {
+			return err
+		}
+		_, err := s.tx.Exec(ctx, `
+            INSERT INTO refunds (payment_id, order_id, return_id, amount, reason, created_at)
+            VALUES ($1, $2, $3, $4, 'Return completed', $5)
+        `, paymentID, orderID, returnID, total, *receivedAt)
+		return err
+	}
+	return nil
+}
+
+// seedCarts gives about a quarter of the customers an active cart, without
+// stock reservations, which would have expired anyway
+func (s *seeder) seedCarts(ctx context.Context) error {
+	for _, user := range s.users {
+		if s.rng.Float64() >= 0.25 {
+			continue
+		}
+
+		cartID := uuid.New()
+		updated := s.now.Add(-time.Duration(s.rng.Intn(14*24)) * time.Hour)
+		if updated.Before(user.CreatedAt) {
+			updated = user.CreatedAt
+		}
+		if _, err := s.tx.Exec(ctx, `
+            INSERT INTO carts (id, user_id, created_at, updated_at) VALUES ($1, $2, $3, $3)
+        `, cartID, user.ID, updated); err != nil {
+			return err
+		}
+
+		seen := map[uuid.UUID]bool{}
+		for n := 1 + s.rng.Intn(3); n > 0; n-- {
+			product := s.products[pickWeighted(s.rng, s.productWeights)]
+			if seen[product.ID] {
+				continue
+			}
+			seen[product.ID] = true
+
+			var variantID *uuid.UUID
+			if len(product.Variants) > 0 {
+				variantID = &product.Variants[s.rng.Intn(len(product.Variants))].ID
+			}
+			if _, err := s.tx.Exec(ctx, `
+                INSERT INTO cart_items (cart_id, product_id, variant_id, quantity, created_at) VALUES ($1, $2, $3, $4, $5)
+            `, cartID, product.ID, variantID, 1+s.rng.Intn(2), updated); err != nil {
+				return err
+			}
+		}
+		s.stats.carts++
+	}
+	return nil
+}
+
+func (s *seeder) address(fullName string) models.Address {
+	city := pick(s.rng, cities)
+	return models.Address{
+		FullName:   fullName,
+		Street:     fmt.Sprintf("%d %s", 1+s.rng.Intn(250), pick(s.rng, streets)),
+		City:       city.City,
+		State:      city.State,
+		Country:    city.Country,
+		PostalCode: city.PostalCode,
+		Phone:      fmt.Sprintf("+1555%07d", s.rng.Intn(10_000_000)),
+	}
+}
+
+func (s *seeder) daysAgo(days int) time.Time {
+	return s.now.Add(-time.Duration(days)*24*time.Hour - time.Duration(s.rng.Intn(24*60))*time.Minute)
+}
+
+func pick[T any](rng *rand.Rand, items []T) T {
+	return items[rng.Intn(len(items))]
+}
+
+func appendWeight(cumulative []float64, weight float64) []float64 {
+	if n := len(cumulative); n > 0 {
+		weight += cumulative[n-1]
+	}
+	return append(cumulative, weight)
+}
+
+// pickWeighted returns an index with probability proportional to its weight,
+// given cumulative weights
+func pickWeighted(rng *rand.Rand, cumulative []float64) int {
+	target := rng.Float64() * cumulative[len(cumulative)-1]
+	return sort.SearchFloat64s(cumulative, target)
+}
+
+func pickWeightedString[T ~string](rng *rand.Rand, values []T, weights []float64) T {
+	var cumulative []float64
+	for _, weight := range weights {
+		cumulative = appendWeight(cumulative, weight)
+	}
+	return values[pickWeighted(rng, cumulative)]
+}
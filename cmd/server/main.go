@@ -1,29 +1,57 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"ecommerce-backend/internal/config"
 	"ecommerce-backend/internal/handlers"
+	"ecommerce-backend/internal/health"
 	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/routes"
+	"ecommerce-backend/internal/tokens"
 	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 func main() {
 	// Load configuration
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("❌ ", err)
+	}
+
+	// Keys tokens are signed and verified with
+	tokenKeys, err := tokens.NewKeySet(cfg)
+	if err != nil {
+		log.Fatal("❌ Failed to load JWT keys:", err)
+	}
+
+	// Readiness fails until every startup task has finished
+	startup := health.NewStartup()
 
-	// Initialize database
+	// Initialize database; migrations run as part of it
+	migrated := startup.Begin("migrations")
 	db, err := database.InitDB(cfg)
 	if err != nil {
 		log.Fatal("❌ Failed to connect to database:", err)
 	}
 	defer db.Close()
+	migrated()
 
 	log.Println("✅ Database connection established")
 
+	// Optional read replica for listings and analytics
+	replica, err := database.InitReplica(cfg)
+	if err != nil {
+		log.Fatal("❌ Failed to set up read replica:", err)
+	}
+	defer replica.Close()
+
 	// Set Gin mode
 	if cfg.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -31,109 +59,74 @@ func main() {
 		gin.SetMode(gin.DebugMode)
 	}
 
+	// Request bodies are validated by the same rules as utils.ValidateStruct
+	binding.Validator = utils.GinValidator{}
+
+	// Initialize repositories, services, and handlers
+	repos := handlers.InitRepositories(db, replica, cfg, startup, tokenKeys)
+
 	// Create Gin router
 	router := gin.Default()
 
 	// Apply global middleware
-	router.Use(middleware.GinCORSMiddleware(cfg.AllowedOrigins))
+	router.Use(middleware.GinCORSMiddleware(cfg.AllowedOrigins,
+		// Admin routes only answer the dashboard's origins
+		middleware.CORSOverride{Path: "/api/*/admin", AllowedOrigins: cfg.AdminAllowedOrigins},
+		middleware.CORSOverride{Path: "/stores/*/api/*/admin", AllowedOrigins: cfg.AdminAllowedOrigins},
+		// Gateways call webhooks server to server; no browser needs to
+		middleware.CORSOverride{Path: "/api/*/payments/webhook"},
+		middleware.CORSOverride{Path: "/stores/*/api/*/payments/webhook"},
+	))
 	router.Use(middleware.NoCacheMiddleware())
+	router.Use(middleware.GinGzip())
+	router.Use(middleware.GinRequestCapture(repos.RequestCapture))
 	router.Use(middleware.GinRecovery())
 	router.Use(middleware.GinLogging())
 	router.Use(middleware.GinRequestID())
-
-	// Initialize repositories, services, and handlers
-	repos := handlers.InitRepositories(db, cfg)
+	router.Use(middleware.GinErrorHandler())
+	router.Use(middleware.GinRequestTimeout(cfg.ReadTimeout, cfg.WriteTimeout))
+	router.Use(middleware.GinBodyLimit(cfg.MaxBodyBytes))
+	router.Use(middleware.GinRateLimit(repos.SettingsService, models.SettingRateLimit))
+
+	// Start background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	repos.ReservationCleanup.Start(workerCtx, cfg.ReservationCleanupInterval)
+	repos.EventDispatcher.Start(workerCtx, cfg.OutboxDispatchInterval)
+	repos.AbandonedCarts.Start(workerCtx, cfg.AbandonedCartCheckInterval)
+	repos.UnpaidOrders.Start(workerCtx, cfg.UnpaidOrderCheckInterval)
+	repos.ScheduledPublishing.Start(workerCtx, cfg.ProductPublishCheckInterval)
+	repos.Segments.Start(workerCtx, cfg.SegmentRefreshInterval)
+	repos.ERPConsumer.Start(workerCtx)
+	replica.Start(workerCtx, cfg.DBReplicaCheckInterval)
+	go warmCaches(workerCtx, repos, startup.Begin("cache_warmup"))
 
 	// Health check endpoints (public, legacy)
 	router.GET("/health", repos.HealthHandler.HealthCheck)
+	router.GET("/live", repos.HealthHandler.LivenessCheck)
 	router.GET("/ready", repos.HealthHandler.ReadinessCheck)
+	router.GET("/startup", repos.HealthHandler.StartupCheck)
 	router.GET("/metrics", repos.HealthHandler.Metrics)
 
-	// API version prefix
-	api := router.Group("/api/v1")
-	{
-		// Health check endpoints (public)
-		api.GET("/health", repos.HealthHandler.HealthCheck)
-		api.GET("/ready", repos.HealthHandler.ReadinessCheck)
-		api.GET("/metrics", repos.HealthHandler.Metrics)
-	}
+	// Public keys for verifying tokens
+	router.GET("/.well-known/jwks.json", repos.JWKSHandler.GetJWKS)
 
-	// Public routes
-	{
-		// Auth routes
-		api.POST("/auth/register", repos.AuthHandler.Register)
-		api.POST("/auth/login", repos.AuthHandler.Login)
-		api.POST("/auth/refresh", repos.AuthHandler.RefreshToken)
+	// Carrier tracking callbacks (authenticated by signature; carriers report
+	// shipments of every store to one URL)
+	router.POST("/webhooks/carriers/:carrier", repos.CarrierHandler.TrackingWebhook)
 
-		// Product routes (public read access)
-		api.GET("/products", repos.ProductHandler.GetProducts)
-		api.GET("/products/:id", repos.ProductHandler.GetProduct)
-	}
+	// API documentation
+	router.GET("/openapi.json", repos.DocsHandler.OpenAPISpec)
+	router.GET("/docs", repos.DocsHandler.SwaggerUI)
 
-	// Protected routes (require authentication)
-	protected := api.Group("")
-	protected.Use(middleware.GinAuthMiddleware(repos.AuthHandler.AuthService))
-	{
-		// User routes
-		protected.GET("/users/profile", repos.AuthHandler.GetProfile)
-		protected.PUT("/users/profile", repos.AuthHandler.UpdateProfile)
-		protected.PUT("/users/change-password", repos.AuthHandler.ChangePassword)
-
-		// Cart routes
-		protected.GET("/cart", repos.CartHandler.GetCart)
-		protected.GET("/cart/validate", repos.CartHandler.ValidateCart)
-		protected.POST("/cart/items", repos.CartHandler.AddToCart)
-		protected.PUT("/cart/items/:itemId", repos.CartHandler.UpdateCartItem)
-		protected.DELETE("/cart/items/:itemId", repos.CartHandler.RemoveFromCart)
-		protected.DELETE("/cart", repos.CartHandler.ClearCart)
-
-		// Order routes
-		protected.POST("/orders", repos.OrderHandler.CreateOrder)
-		protected.GET("/orders", repos.OrderHandler.GetUserOrders)
-		protected.GET("/orders/:id/payment", repos.PaymentHandler.GetPaymentByOrder)
-		protected.GET("/orders/:id", repos.OrderHandler.GetOrder)
-		protected.PUT("/orders/:id/cancel", repos.OrderHandler.CancelOrder)
-
-		// Payment routes
-		protected.POST("/payments", repos.PaymentHandler.CreatePayment)
-		protected.POST("/payments/:id/verify", repos.PaymentHandler.VerifyPayment)
-
-		// Return routes
-		protected.POST("/returns", repos.ReturnHandler.CreateReturn)
-		protected.GET("/returns", repos.ReturnHandler.GetUserReturns)
-		protected.GET("/returns/:id", repos.ReturnHandler.GetReturn)
-	}
-
-	// Admin routes (require admin role)
-	admin := api.Group("/admin")
-	admin.Use(middleware.GinAuthMiddleware(repos.AuthHandler.AuthService))
-	admin.Use(middleware.GinAdminMiddleware())
-	{
-		// Product management
-		admin.POST("/products", repos.ProductHandler.CreateProduct)
-		admin.GET("/products", repos.ProductHandler.GetAdminProducts)
-		admin.PUT("/products/:id", repos.ProductHandler.UpdateProduct)
-		admin.DELETE("/products/:id", repos.ProductHandler.DeleteProduct)
-		admin.GET("/products/top", repos.ProductHandler.GetTopProducts)
-
-		// Order management
-		admin.GET("/orders", repos.OrderHandler.GetAllOrders)
-		admin.GET("/orders/recent", repos.OrderHandler.GetRecentOrders)
-		admin.GET("/orders/:id", repos.OrderHandler.GetAdminOrder)
-		admin.PUT("/orders/:id/status", repos.OrderHandler.UpdateOrderStatus)
-		admin.GET("/analytics", repos.OrderHandler.GetAnalytics)
-
-		// User management
-		admin.GET("/users", repos.AuthHandler.GetAllUsers)
-		admin.PUT("/users/:id/role", repos.AuthHandler.UpdateUserRole)
-
-		// Return management
-		admin.GET("/returns", repos.ReturnHandler.GetAllReturns)
-		admin.POST("/returns/:returnId/process", repos.ReturnHandler.ProcessReturn)
-	}
+	// Versioned APIs share services; each version picks its own handlers
+	routes.Mount(router, repos, cfg,
+		routes.V1(repos, cfg),
+		routes.V2(repos),
+	)
 
 	// Print API documentation
-	log.Println("📚 API Documentation available at http://localhost:" + cfg.Port)
+	log.Println("📚 API Documentation available at http://localhost:" + cfg.Port + "/docs")
 	log.Println("🚀 Environment: " + cfg.Env)
 
 	// Start server
@@ -141,3 +134,13 @@ func main() {
 		log.Fatal("❌ Failed to start server:", err)
 	}
 }
+
+// warmCaches loads the runtime settings and feature flags that every request
+// consults, so the first requests after startup do not all wait on the
+// database for them
+func warmCaches(ctx context.Context, repos *handlers.Repositories, done func()) {
+	defer done()
+
+	repos.SettingsService.Int(ctx, models.SettingRateLimit)
+	repos.FlagService.IsEnabled(ctx, models.FlagAPIV2, "")
+}
@@ -0,0 +1,115 @@
+// Package apperrors defines the domain error kinds services return. The HTTP
+// layer maps each kind to a status code and shows the error's message to the
+// client; any other error is treated as internal and its message is hidden.
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("too many requests")
+)
+
+// Error is a domain error of one kind with a message that is safe to show to
+// clients. errors.Is matches it against its kind.
+type Error struct {
+	kind    error
+	code    string
+	message string
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+func NotFound(message string) error {
+	return &Error{kind: ErrNotFound, message: message}
+}
+
+func Conflict(message string) error {
+	return &Error{kind: ErrConflict, message: message}
+}
+
+func Conflictf(format string, args ...interface{}) error {
+	return Conflict(fmt.Sprintf(format, args...))
+}
+
+func Forbidden(message string) error {
+	return &Error{kind: ErrForbidden, message: message}
+}
+
+func Unauthorized(message string) error {
+	return &Error{kind: ErrUnauthorized, message: message}
+}
+
+func Validation(message string) error {
+	return &Error{kind: ErrValidation, message: message}
+}
+
+func Validationf(format string, args ...interface{}) error {
+	return Validation(fmt.Sprintf(format, args...))
+}
+
+func RateLimited(message string) error {
+	return &Error{kind: ErrRateLimited, message: message}
+}
+
+// Coded is a domain error of the given kind that reports code instead of the
+// kind's generic error code, for failures clients handle on their own
+func Coded(kind error, code, message string) error {
+	return &Error{kind: kind, code: code, message: message}
+}
+
+// HTTPStatus returns the status code, error code and client message for err.
+// Errors that are not domain errors become a 500 with a generic message.
+func HTTPStatus(err error) (int, string, string) {
+	var appErr *Error
+	if !errors.As(err, &appErr) {
+		return http.StatusInternalServerError, "internal_error", "Internal server error"
+	}
+
+	status, code := http.StatusInternalServerError, "internal_error"
+	switch appErr.kind {
+	case ErrNotFound:
+		status, code = http.StatusNotFound, "not_found"
+	case ErrConflict:
+		status, code = http.StatusConflict, "conflict"
+	case ErrForbidden:
+		status, code = http.StatusForbidden, "forbidden"
+	case ErrUnauthorized:
+		status, code = http.StatusUnauthorized, "unauthorized"
+	case ErrValidation:
+		status, code = http.StatusUnprocessableEntity, "validation_error"
+	case ErrRateLimited:
+		status, code = http.StatusTooManyRequests, "rate_limited"
+	default:
+		return status, code, "Internal server error"
+	}
+
+	if appErr.code != "" {
+		code = appErr.code
+	}
+	return status, code, capitalize(appErr.message)
+}
+
+func capitalize(message string) string {
+	r, size := utf8.DecodeRuneInString(message)
+	if r == utf8.RuneError {
+		return message
+	}
+	return string(unicode.ToUpper(r)) + message[size:]
+}
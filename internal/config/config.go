@@ -1,11 +1,16 @@
 package config
 
 import (
-	"os"
-	"strconv"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
+	"ecommerce-backend/pkg/money"
+
 	"github.com/joho/godotenv"
 )
 
@@ -20,61 +25,477 @@ type Config struct {
 	DBName     string
 	DBSSLMode  string
 
-	JWTSecret string
-	JWTExpiry time.Duration
+	DBMaxConns         int
+	DBMinConns         int
+	DBMaxConnLifetime  time.Duration
+	DBMaxConnIdleTime  time.Duration
+	DBStatementTimeout time.Duration
+
+	DBReplicaHost          string
+	DBReplicaPort          string
+	DBReplicaUser          string
+	DBReplicaPassword      string
+	DBReplicaCheckInterval time.Duration
+
+	JWTSecret             string
+	JWTAlgorithm          string
+	JWTPrivateKey         string
+	JWTPreviousPublicKeys string
+	JWTAcceptHS256        bool
+	JWTExpiry             time.Duration
+	ImpersonationTTL      time.Duration
+
+	GoogleClientIDs []string
+	AppleClientIDs  []string
+
+	AllowedOrigins      []string
+	AdminAllowedOrigins []string
+
+	RateLimitPerMinute     int
+	AuthRateLimitPerMinute int
+
+	ReadTimeout        time.Duration
+	WriteTimeout       time.Duration
+	LongRequestTimeout time.Duration
+	MaxBodyBytes       int64
+	MaxUploadBytes     int64
+
+	DebugCaptureRequests int
+
+	StockReservationTTL        time.Duration
+	ReservationCleanupInterval time.Duration
+
+	RequireEmailVerification bool
+	EmailVerificationTTL     time.Duration
+	AppBaseURL               string
+
+	PaymentGateway      string
+	PaymentCurrency     string
+	StripeSecretKey     string
+	StripeWebhookSecret string
+	PaymentSimDelay     time.Duration
+	APIBaseURL          string
+
+	GatewayFeeBasisPoints int64
+	GatewayFeeFixed       money.Money
+
+	ReturnAddress string
+
+	OutboxDispatchInterval time.Duration
+	EventWebhookURL        string
+	EventQueueBroker       string
+	EventQueueURL          string
+	EventQueueExchange     string
+
+	SearchEngine string
+	SearchURL    string
+	SearchAPIKey string
+	SearchIndex  string
+
+	ERPAMQPURL       string
+	ERPStockQueue    string
+	ERPOrderExchange string
+
+	MediaBaseURL        string
+	MediaSigningKey     string
+	MediaURLTTL         time.Duration
+	MediaThumbnailSizes map[string]int
+	CatalogEdgeTTL      time.Duration
+
+	MailProvider   string
+	MailFrom       string
+	MailFromName   string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUsername   string
+	SMTPPassword   string
+	SendGridAPIKey string
+
+	SMSProvider      string
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	TwilioFrom       string
+	PhoneOTPTTL      time.Duration
+
+	CarrierWebhookSecrets map[string]string
+
+	LowStockThreshold int
+
+	CheckoutSessionTTL       time.Duration
+	CheckoutPaymentMode      string
+	CheckoutAuthorizationTTL time.Duration
+	PaymentCapture           string
+
+	OrderCancelWindow        time.Duration
+	UnpaidOrderTTL           time.Duration
+	UnpaidOrderCheckInterval time.Duration
+
+	ProductPublishCheckInterval time.Duration
+
+	FraudReviewThreshold     int
+	FraudVelocityWindow      time.Duration
+	FraudMaxOrdersPerUser    int
+	FraudMaxOrdersPerIP      int
+	FraudHighValueFirstOrder money.Money
+
+	AbandonedCartAfter         time.Duration
+	AbandonedCartCheckInterval time.Duration
 
-	AllowedOrigins []string
+	SegmentRefreshInterval time.Duration
 
-	StockReservationTTL time.Duration
+	TaxRateBasisPoints  int64
+	ShippingFee         money.Money
+	ShippingRatePerKg   money.Money
+	FreeShippingMinimum money.Money
+	MinimumOrderValue   money.Money
+	VolumetricDivisor   int
+
+	CODMaxOrderValue money.Money
+	CODPostalCodes   []string
+	CODOTPTTL        time.Duration
 }
 
-func LoadConfig() *Config {
+// devJWTSecret signs tokens outside production when JWT_SECRET is unset
+const devJWTSecret = "default-secret-key-change-in-production"
+
+// JWT signing algorithms, as JWT_ALGORITHM reads them
+const (
+	JWTAlgorithmHS256 = "hs256"
+	JWTAlgorithmRS256 = "rs256"
+	JWTAlgorithmEdDSA = "eddsa"
+)
+
+// Checkout payment modes, as CHECKOUT_PAYMENT_MODE reads them. Orders are
+// placed before payment by default; in authorize-then-capture mode a card
+// order only holds its stock until the payment is authorized.
+const (
+	CheckoutOrderFirst       = "order_first"
+	CheckoutAuthorizeCapture = "authorize_capture"
+)
+
+// minJWTSecretLength is the shortest JWT secret production accepts: 256 bits,
+// the HS256 key size
+const minJWTSecretLength = 32
+
+// IsProduction reports whether ENV is production, where configuration is
+// held to the strictest rules
+func (c *Config) IsProduction() bool {
+	return c.Env == "production"
+}
+
+// LoadConfig reads the configuration from the environment and a .env file.
+// Every value that cannot be parsed or is out of range is reported in one
+// *ValidationError rather than replaced by its default. In production missing
+// or weak secrets are errors too; elsewhere they fall back to development
+// defaults with a warning. Secrets may be read from files, see
+// envReader.secret.
+func LoadConfig() (*Config, error) {
 	// Load .env file
 	godotenv.Load()
 
-	// Parse JWT expiry
-	jwtExpiryHours, _ := strconv.Atoi(getEnv("JWT_EXPIRY_HOURS", "24"))
+	r := &envReader{}
 
-	// Parse stock reservation TTL
-	stockTTLMinutes, _ := strconv.Atoi(getEnv("STOCK_RESERVATION_TTL_MINUTES", "10"))
+	// Read database settings (the read replica defaults to the primary's
+	// port and credentials; no host means no replica)
+	dbPort := r.string("DB_PORT", "5432")
+	dbUser := r.string("DB_USER", "postgres")
+	dbPassword := r.secret("DB_PASSWORD", "")
 
-	// Parse allowed origins (comma-separated)
-	allowedOrigins := getEnv("ALLOWED_ORIGINS", "http://localhost:3000")
-	origins := []string{}
-	for _, o := range strings.Split(allowedOrigins, ",") {
-		trimmed := strings.TrimSpace(o)
-		if trimmed != "" {
-			origins = append(origins, trimmed)
-		}
-	}
+	// Parse allowed origins (comma-separated); admin routes default to the
+	// same list
+	origins := r.origins("ALLOWED_ORIGINS", "http://localhost:3000")
 	if len(origins) == 0 {
 		origins = []string{"http://localhost:3000"}
 	}
+	adminOrigins := r.origins("CORS_ADMIN_ORIGINS", "")
+	if len(adminOrigins) == 0 {
+		adminOrigins = origins
+	}
+
+	// Parse cash-on-delivery postal codes (comma-separated prefixes, empty
+	// means everywhere)
+	codPostalCodes := []string{}
+	for _, code := range r.list("COD_POSTAL_CODES", "") {
+		codPostalCodes = append(codPostalCodes, strings.ToUpper(strings.ReplaceAll(code, " ", "")))
+	}
+
+	cfg := &Config{
+		Port: r.string("PORT", "8080"),
+		Env:  r.string("ENV", "development"),
+
+		DBHost:     r.string("DB_HOST", "localhost"),
+		DBPort:     dbPort,
+		DBUser:     dbUser,
+		DBPassword: dbPassword,
+		DBName:     r.string("DB_NAME", "ecommerce_db"),
+		DBSSLMode:  r.string("DB_SSLMODE", "disable"),
+
+		// Connection pool, shared by the primary and the replica. A statement
+		// timeout of 0 lets queries run as long as the server allows.
+		DBMaxConns:         r.count("DB_MAX_CONNS", "25", 1),
+		DBMinConns:         r.count("DB_MIN_CONNS", "5", 0),
+		DBMaxConnLifetime:  r.duration("DB_MAX_CONN_LIFETIME_MINUTES", "60", time.Minute, 1),
+		DBMaxConnIdleTime:  r.duration("DB_MAX_CONN_IDLE_MINUTES", "30", time.Minute, 1),
+		DBStatementTimeout: r.duration("DB_STATEMENT_TIMEOUT_SECONDS", "0", time.Second, 0),
+
+		DBReplicaHost:          r.string("DB_REPLICA_HOST", ""),
+		DBReplicaPort:          r.string("DB_REPLICA_PORT", dbPort),
+		DBReplicaUser:          r.string("DB_REPLICA_USER", dbUser),
+		DBReplicaPassword:      r.secret("DB_REPLICA_PASSWORD", dbPassword),
+		DBReplicaCheckInterval: r.duration("DB_REPLICA_CHECK_INTERVAL_SECONDS", "10", time.Second, 0),
+
+		// JWT signing keys: the HMAC secret, or a PEM private key for RS256 and
+		// EdDSA plus the public keys it replaced, which are still accepted.
+		// JWT_ACCEPT_HS256 keeps HMAC tokens valid while moving off HS256.
+		JWTSecret:             r.secret("JWT_SECRET", ""),
+		JWTAlgorithm:          r.oneOf("JWT_ALGORITHM", JWTAlgorithmHS256, JWTAlgorithmHS256, JWTAlgorithmRS256, JWTAlgorithmEdDSA),
+		JWTPrivateKey:         r.secret("JWT_PRIVATE_KEY", ""),
+		JWTPreviousPublicKeys: r.secret("JWT_PREVIOUS_PUBLIC_KEYS", ""),
+		JWTAcceptHS256:        r.bool("JWT_ACCEPT_HS256", "false"),
+
+		// JWT expiry and the shorter lifetime of support impersonation tokens
+		JWTExpiry:        r.duration("JWT_EXPIRY_HOURS", "24", time.Hour, 1),
+		ImpersonationTTL: r.duration("IMPERSONATION_TTL_MINUTES", "15", time.Minute, 1),
+
+		// OAuth client IDs that social sign-in tokens must be issued to; a
+		// provider without any is disabled
+		GoogleClientIDs: r.list("GOOGLE_CLIENT_IDS", ""),
+		AppleClientIDs:  r.list("APPLE_CLIENT_IDS", ""),
+
+		AllowedOrigins:      origins,
+		AdminAllowedOrigins: adminOrigins,
+
+		// Requests per minute, 0 disables
+		RateLimitPerMinute:     r.count("RATE_LIMIT_PER_MINUTE", "100", 0),
+		AuthRateLimitPerMinute: r.count("AUTH_RATE_LIMIT_PER_MINUTE", "10", 0),
+
+		// Request deadlines (reads, writes, and imports/exports) and body size
+		// limits (JSON bodies, file uploads); 0 removes a limit
+		ReadTimeout:        r.duration("READ_TIMEOUT_SECONDS", "10", time.Second, 0),
+		WriteTimeout:       r.duration("WRITE_TIMEOUT_SECONDS", "30", time.Second, 0),
+		LongRequestTimeout: r.duration("LONG_REQUEST_TIMEOUT_SECONDS", "300", time.Second, 0),
+		MaxBodyBytes:       r.int64("MAX_BODY_KB", "1024", 0) << 10,
+		MaxUploadBytes:     r.int64("MAX_UPLOAD_MB", "21", 0) << 20,
+
+		// Failing requests kept in memory for triage (0 disables capture)
+		DebugCaptureRequests: r.count("DEBUG_CAPTURE_REQUESTS", "0", 0),
 
-	return &Config{
-		Port: getEnv("PORT", "8080"),
-		Env:  getEnv("ENV", "development"),
+		StockReservationTTL:        r.duration("STOCK_RESERVATION_TTL_MINUTES", "10", time.Minute, 1),
+		ReservationCleanupInterval: r.duration("RESERVATION_CLEANUP_INTERVAL_MINUTES", "5", time.Minute, 0),
 
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBUser:     getEnv("DB_USER", "postgres"),
-		DBPassword: getEnv("DB_PASSWORD", ""),
-		DBName:     getEnv("DB_NAME", "ecommerce_db"),
-		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
+		RequireEmailVerification: r.bool("REQUIRE_EMAIL_VERIFICATION", "false"),
+		EmailVerificationTTL:     r.duration("EMAIL_VERIFICATION_TTL_HOURS", "24", time.Hour, 1),
+		AppBaseURL:               r.string("APP_BASE_URL", "http://localhost:3000"),
 
-		JWTSecret: getEnv("JWT_SECRET", "default-secret-key-change-in-production"),
-		JWTExpiry: time.Duration(jwtExpiryHours) * time.Hour,
+		// A simulated payment delay of 0 completes payments at once, e.g. for
+		// load tests
+		PaymentGateway:      r.oneOf("PAYMENT_GATEWAY", "simulated", "simulated", "stripe"),
+		PaymentCurrency:     r.string("PAYMENT_CURRENCY", "inr"),
+		StripeSecretKey:     r.secret("STRIPE_SECRET_KEY", ""),
+		StripeWebhookSecret: r.secret("STRIPE_WEBHOOK_SECRET", ""),
+		PaymentSimDelay:     r.duration("PAYMENT_SIMULATION_DELAY_MS", "3000", time.Millisecond, 0),
+		APIBaseURL:          r.string("API_BASE_URL", "http://localhost:8080"),
 
-		AllowedOrigins: origins,
+		// What the gateway charges per card payment, as a percentage plus a
+		// fixed amount; only used to estimate fees in the accounting export
+		GatewayFeeBasisPoints: int64(math.Round(r.float("GATEWAY_FEE_PERCENT", "0", 0, 100) * 100)),
+		GatewayFeeFixed:       r.amount("GATEWAY_FEE_FIXED", "0"),
 
-		StockReservationTTL: time.Duration(stockTTLMinutes) * time.Minute,
+		ReturnAddress: r.string("RETURN_ADDRESS", "Returns Department, Main Warehouse"),
+
+		OutboxDispatchInterval: r.duration("OUTBOX_DISPATCH_INTERVAL_SECONDS", "5", time.Second, 0),
+		EventWebhookURL:        r.string("EVENT_WEBHOOK_URL", ""),
+
+		// Order, payment and return events can also go to a message broker
+		// for other services
+		EventQueueBroker:   r.oneOf("EVENT_QUEUE_BROKER", "none", "none", "rabbitmq"),
+		EventQueueURL:      r.secret("EVENT_QUEUE_URL", ""),
+		EventQueueExchange: r.string("EVENT_QUEUE_EXCHANGE", "ecommerce.events"),
+
+		// Product searches go through a search engine when one is set;
+		// otherwise the database matches them
+		SearchEngine: r.oneOf("SEARCH_ENGINE", "none", "none", "meilisearch"),
+		SearchURL:    r.string("SEARCH_URL", ""),
+		SearchAPIKey: r.secret("SEARCH_API_KEY", ""),
+		SearchIndex:  r.string("SEARCH_INDEX", "products"),
+
+		// Stock levels from the ERP arrive on a RabbitMQ queue and order
+		// events go back on an exchange; no URL leaves the ERP link off
+		ERPAMQPURL:       r.secret("ERP_AMQP_URL", ""),
+		ERPStockQueue:    r.string("ERP_STOCK_QUEUE", "erp.stock-levels"),
+		ERPOrderExchange: r.string("ERP_ORDER_EXCHANGE", "erp.sales-orders"),
+
+		// Product images are object keys served through a CDN; a signing
+		// key makes their URLs expire for buckets that are not public
+		MediaBaseURL:        r.string("MEDIA_BASE_URL", ""),
+		MediaSigningKey:     r.secret("MEDIA_SIGNING_KEY", ""),
+		MediaURLTTL:         r.duration("MEDIA_URL_TTL_MINUTES", "60", time.Minute, 1),
+		MediaThumbnailSizes: r.sizes("MEDIA_THUMBNAIL_SIZES", "thumbnail=200,medium=600"),
+		CatalogEdgeTTL:      r.duration("CATALOG_EDGE_TTL_SECONDS", "60", time.Second, 0),
+
+		MailProvider:   r.oneOf("MAIL_PROVIDER", "log", "log", "smtp", "sendgrid"),
+		MailFrom:       r.string("MAIL_FROM", "no-reply@example.com"),
+		MailFromName:   r.string("MAIL_FROM_NAME", "E-Commerce Store"),
+		SMTPHost:       r.string("SMTP_HOST", "localhost"),
+		SMTPPort:       r.string("SMTP_PORT", "587"),
+		SMTPUsername:   r.string("SMTP_USERNAME", ""),
+		SMTPPassword:   r.secret("SMTP_PASSWORD", ""),
+		SendGridAPIKey: r.secret("SENDGRID_API_KEY", ""),
+
+		// Text messages carry phone verification and login codes
+		SMSProvider:      r.oneOf("SMS_PROVIDER", "log", "log", "twilio"),
+		TwilioAccountSID: r.string("TWILIO_ACCOUNT_SID", ""),
+		TwilioAuthToken:  r.secret("TWILIO_AUTH_TOKEN", ""),
+		TwilioFrom:       r.string("TWILIO_FROM", ""),
+		PhoneOTPTTL:      r.duration("PHONE_OTP_TTL_MINUTES", "10", time.Minute, 1),
+
+		// Carriers sign tracking callbacks with their shared secret; a carrier
+		// without one cannot post them
+		CarrierWebhookSecrets: r.secretPairs("CARRIER_WEBHOOK_SECRETS"),
+
+		LowStockThreshold: r.count("LOW_STOCK_THRESHOLD", "5", 0),
+
+		// How long a checkout session's prices hold before checkout has to
+		// start again
+		CheckoutSessionTTL: r.duration("CHECKOUT_SESSION_TTL_MINUTES", "30", time.Minute, 1),
+
+		// How card orders are paid for, and how long a card order placed in
+		// authorize-then-capture mode holds its stock waiting for the payment
+		// to be authorized
+		CheckoutPaymentMode:      r.oneOf("CHECKOUT_PAYMENT_MODE", CheckoutOrderFirst, CheckoutOrderFirst, CheckoutAuthorizeCapture),
+		CheckoutAuthorizationTTL: r.duration("CHECKOUT_AUTHORIZATION_TTL_MINUTES", "15", time.Minute, 1),
+
+		// Authorized payments are captured as soon as their order is
+		// finalized, or left for an admin to capture, e.g. at shipment
+		PaymentCapture: r.oneOf("PAYMENT_CAPTURE", "automatic", "automatic", "manual"),
+
+		// How long customers may cancel after paying and how long an order may
+		// wait for payment; 0 means no limit
+		OrderCancelWindow:        r.duration("ORDER_CANCEL_WINDOW_HOURS", "24", time.Hour, 0),
+		UnpaidOrderTTL:           r.duration("UNPAID_ORDER_TTL_MINUTES", "60", time.Minute, 0),
+		UnpaidOrderCheckInterval: r.duration("UNPAID_ORDER_CHECK_INTERVAL_MINUTES", "10", time.Minute, 0),
+
+		// How close to their scheduled times products are published; 0
+		// disables scheduled publishing
+		ProductPublishCheckInterval: r.duration("PRODUCT_PUBLISH_CHECK_INTERVAL_SECONDS", "60", time.Second, 0),
+
+		// Orders scoring at least the threshold are held for review; 0
+		// disables a rule or holding
+		FraudReviewThreshold:     r.count("FRAUD_REVIEW_THRESHOLD", "60", 0),
+		FraudVelocityWindow:      r.duration("FRAUD_VELOCITY_WINDOW_MINUTES", "60", time.Minute, 0),
+		FraudMaxOrdersPerUser:    r.count("FRAUD_MAX_ORDERS_PER_USER", "5", 0),
+		FraudMaxOrdersPerIP:      r.count("FRAUD_MAX_ORDERS_PER_IP", "10", 0),
+		FraudHighValueFirstOrder: r.amount("FRAUD_HIGH_VALUE_FIRST_ORDER", "50000"),
+
+		AbandonedCartAfter:         r.duration("ABANDONED_CART_AFTER_HOURS", "24", time.Hour, 0),
+		AbandonedCartCheckInterval: r.duration("ABANDONED_CART_CHECK_INTERVAL_MINUTES", "30", time.Minute, 0),
+
+		// How often customer segment members are recomputed (0 disables)
+		SegmentRefreshInterval: r.duration("SEGMENT_REFRESH_INTERVAL_MINUTES", "60", time.Minute, 0),
+
+		// Cart pricing estimates: tax rate as a percentage, flat shipping fee
+		// plus a rate per kilogram, and the subtotal from which shipping is
+		// free (0 disables)
+		TaxRateBasisPoints:  int64(math.Round(r.float("TAX_RATE_PERCENT", "0", 0, 100) * 100)),
+		ShippingFee:         r.amount("SHIPPING_FEE", "0"),
+		ShippingRatePerKg:   r.amount("SHIPPING_RATE_PER_KG", "0"),
+		FreeShippingMinimum: r.amount("FREE_SHIPPING_MINIMUM", "0"),
+
+		// Carts below this subtotal, after discounts, cannot be checked out
+		// (0 disables)
+		MinimumOrderValue: r.amount("MINIMUM_ORDER_VALUE", "0"),
+
+		// Cubic centimetres per kilogram of cubic weight; bulky parcels ship
+		// as their volume over this when that is more than they weigh
+		VolumetricDivisor: r.count("SHIPPING_VOLUMETRIC_DIVISOR", "5000", 1),
+
+		// A max order value of 0 means no limit
+		CODMaxOrderValue: r.amount("COD_MAX_ORDER_VALUE", "0"),
+		CODPostalCodes:   codPostalCodes,
+		CODOTPTTL:        r.duration("COD_OTP_TTL_HOURS", "72", time.Hour, 1),
+	}
+
+	cfg.validate(r)
+	if err := r.err(); err != nil {
+		return nil, err
 	}
+
+	return cfg, nil
 }
 
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// validate checks settings that depend on each other or on the environment
+func (c *Config) validate(r *envReader) {
+	if c.JWTAlgorithm != JWTAlgorithmHS256 && c.JWTPrivateKey == "" {
+		r.fail("JWT_PRIVATE_KEY", ErrMissing, "required by JWT_ALGORITHM="+c.JWTAlgorithm)
+	}
+
+	// The secret only matters while HS256 tokens are signed or accepted
+	hmac := c.JWTAlgorithm == JWTAlgorithmHS256 || c.JWTAcceptHS256
+	switch {
+	case !hmac:
+	case c.JWTSecret == "" && c.IsProduction():
+		r.fail("JWT_SECRET", ErrMissing, "")
+	case c.JWTSecret == "":
+		log.Println("⚠️ JWT_SECRET is not set; signing tokens with an insecure development secret")
+		c.JWTSecret = devJWTSecret
+	case c.IsProduction() && c.JWTSecret == devJWTSecret:
+		r.fail("JWT_SECRET", ErrInsecure, "the development default cannot be used in production")
+	case c.IsProduction() && len(c.JWTSecret) < minJWTSecretLength:
+		r.fail("JWT_SECRET", ErrInsecure, fmt.Sprintf("must be at least %d characters in production", minJWTSecretLength))
+	}
+
+	if c.PaymentGateway == "stripe" {
+		if c.StripeSecretKey == "" {
+			r.fail("STRIPE_SECRET_KEY", ErrMissing, "required by PAYMENT_GATEWAY=stripe")
+		}
+		if c.StripeWebhookSecret == "" {
+			r.fail("STRIPE_WEBHOOK_SECRET", ErrMissing, "required by PAYMENT_GATEWAY=stripe")
+		}
+	}
+
+	if c.IsProduction() && slices.Contains(c.AdminAllowedOrigins, "*") {
+		r.fail("CORS_ADMIN_ORIGINS", ErrInsecure, "admin routes cannot allow every origin in production; list the dashboard's origin")
+	}
+
+	if c.DBMinConns > c.DBMaxConns {
+		r.fail("DB_MIN_CONNS", ErrInvalid, "must not exceed DB_MAX_CONNS")
+	}
+
+	if c.MailProvider == "sendgrid" && c.SendGridAPIKey == "" {
+		r.fail("SENDGRID_API_KEY", ErrMissing, "required by MAIL_PROVIDER=sendgrid")
+	}
+
+	if c.SearchEngine == "meilisearch" && c.SearchURL == "" {
+		r.fail("SEARCH_URL", ErrMissing, "required by SEARCH_ENGINE=meilisearch")
+	}
+
+	if c.MediaBaseURL != "" {
+		if u, err := url.Parse(c.MediaBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			r.fail("MEDIA_BASE_URL", ErrInvalid, "must be an http(s) URL like https://cdn.example.com/media")
+		}
+	}
+
+	// A response cached at the edge must not outlive the image URLs in it
+	if c.MediaSigningKey != "" && c.MediaURLTTL <= 2*c.CatalogEdgeTTL {
+		r.fail("MEDIA_URL_TTL_MINUTES", ErrInvalid, "must exceed twice CATALOG_EDGE_TTL_SECONDS when URLs are signed")
+	}
+
+	if c.EventQueueBroker == "rabbitmq" && c.EventQueueURL == "" {
+		r.fail("EVENT_QUEUE_URL", ErrMissing, "required by EVENT_QUEUE_BROKER=rabbitmq")
+	}
+
+	if c.IsProduction() && c.SMSProvider == "log" {
+		r.fail("SMS_PROVIDER", ErrInsecure, "the log provider writes sign-in codes to the log; use twilio in production")
+	}
+	if c.SMSProvider == "twilio" {
+		if c.TwilioAccountSID == "" {
+			r.fail("TWILIO_ACCOUNT_SID", ErrMissing, "required by SMS_PROVIDER=twilio")
+		}
+		if c.TwilioAuthToken == "" {
+			r.fail("TWILIO_AUTH_TOKEN", ErrMissing, "required by SMS_PROVIDER=twilio")
+		}
+		if c.TwilioFrom == "" {
+			r.fail("TWILIO_FROM", ErrMissing, "required by SMS_PROVIDER=twilio")
+		}
 	}
-	return value
 }
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce-backend/pkg/money"
+)
+
+// envReader reads settings from the environment and collects a FieldError for
+// every value it cannot use instead of silently falling back to the default
+type envReader struct {
+	errs []*FieldError
+}
+
+func (r *envReader) fail(key string, err error, detail string) {
+	r.errs = append(r.errs, &FieldError{Key: key, Err: err, Detail: detail})
+}
+
+func (r *envReader) err() error {
+	if len(r.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: r.errs}
+}
+
+func (r *envReader) string(key, defaultValue string) string {
+	return getEnv(key, defaultValue)
+}
+
+// secret reads key from the environment or, when <key>_FILE is set instead,
+// from that file, as Docker and Kubernetes secrets are mounted. A trailing
+// newline in the file is dropped.
+func (r *envReader) secret(key, defaultValue string) string {
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return getEnv(key, defaultValue)
+	}
+	if os.Getenv(key) != "" {
+		r.fail(key, ErrInvalid, "set either "+key+" or "+key+"_FILE, not both")
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		r.fail(key+"_FILE", ErrInvalid, err.Error())
+		return ""
+	}
+	return strings.TrimRight(string(data), "\r\n")
+}
+
+// oneOf reads a lower-cased value that must be one of allowed
+func (r *envReader) oneOf(key, defaultValue string, allowed ...string) string {
+	value := strings.ToLower(getEnv(key, defaultValue))
+	for _, a := range allowed {
+		if value == a {
+			return value
+		}
+	}
+	r.fail(key, ErrInvalid, "must be one of "+strings.Join(allowed, ", "))
+	return value
+}
+
+func (r *envReader) bool(key, defaultValue string) bool {
+	value, err := strconv.ParseBool(getEnv(key, defaultValue))
+	if err != nil {
+		r.fail(key, ErrInvalid, "must be true or false")
+	}
+	return value
+}
+
+func (r *envReader) int64(key, defaultValue string, min int64) int64 {
+	value, err := strconv.ParseInt(getEnv(key, defaultValue), 10, 64)
+	if err != nil {
+		r.fail(key, ErrInvalid, "must be a whole number")
+		return 0
+	}
+	if value < min {
+		r.fail(key, ErrInvalid, "must be at least "+strconv.FormatInt(min, 10))
+	}
+	return value
+}
+
+// count reads a whole number of at least min, where 0 usually disables
+// whatever it limits
+func (r *envReader) count(key, defaultValue string, min int) int {
+	return int(r.int64(key, defaultValue, int64(min)))
+}
+
+// duration reads a whole number of units, e.g. minutes, of at least min units
+func (r *envReader) duration(key, defaultValue string, unit time.Duration, min int) time.Duration {
+	return time.Duration(r.count(key, defaultValue, min)) * unit
+}
+
+func (r *envReader) float(key, defaultValue string, min, max float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, defaultValue), 64)
+	if err != nil {
+		r.fail(key, ErrInvalid, "must be a number")
+		return 0
+	}
+	if value < min || value > max {
+		r.fail(key, ErrInvalid, "must be between "+strconv.FormatFloat(min, 'f', -1, 64)+
+			" and "+strconv.FormatFloat(max, 'f', -1, 64))
+	}
+	return value
+}
+
+// amount reads a non-negative money amount
+func (r *envReader) amount(key, defaultValue string) money.Money {
+	value, err := money.Parse(getEnv(key, defaultValue))
+	if err != nil {
+		r.fail(key, ErrInvalid, "must be an amount")
+		return 0
+	}
+	if value < 0 {
+		r.fail(key, ErrInvalid, "must not be negative")
+	}
+	return value
+}
+
+// list reads a comma-separated list, dropping blank entries
+func (r *envReader) list(key, defaultValue string) []string {
+	values := []string{}
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// secretPairs reads a comma-separated list of name=secret pairs, e.g.
+// bluedart=s3cret,delhivery=0ther, keyed by lower-cased name
+func (r *envReader) secretPairs(key string) map[string]string {
+	pairs := make(map[string]string)
+	for _, entry := range strings.Split(r.secret(key, ""), ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" || value == "" {
+			r.fail(key, ErrInvalid, "entries must look like name=secret")
+			continue
+		}
+		pairs[name] = value
+	}
+	return pairs
+}
+
+// sizes reads a comma-separated list of name=width pairs in pixels, e.g.
+// thumbnail=200,medium=600
+func (r *envReader) sizes(key, defaultValue string) map[string]int {
+	sizes := make(map[string]int)
+	for _, entry := range r.list(key, defaultValue) {
+		name, value, _ := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		width, err := strconv.Atoi(strings.TrimSpace(value))
+		if name == "" || err != nil || width < 1 {
+			r.fail(key, ErrInvalid, "entries must look like name=width")
+			continue
+		}
+		sizes[name] = width
+	}
+	return sizes
+}
+
+// origins reads a list of CORS origins: "*", exact origins such as
+// https://shop.example.com, or subdomain wildcards such as
+// https://*.example.com
+func (r *envReader) origins(key, defaultValue string) []string {
+	origins := r.list(key, defaultValue)
+	for _, origin := range origins {
+		if origin == "*" {
+			continue
+		}
+
+		u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			strings.TrimRight(u.Path, "/") != "" || u.RawQuery != "" || strings.Contains(u.Host, "*") {
+			r.fail(key, ErrInvalid, origin+" is not an origin like https://shop.example.com or https://*.example.com")
+		}
+	}
+	return origins
+}
+
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Why a setting was rejected; match them with errors.Is on a FieldError or
+// on the ValidationError holding it
+var (
+	ErrMissing  = errors.New("required but not set")
+	ErrInvalid  = errors.New("invalid value")
+	ErrInsecure = errors.New("insecure value")
+)
+
+// FieldError is one setting LoadConfig could not use
+type FieldError struct {
+	Key    string
+	Err    error
+	Detail string
+}
+
+func (e *FieldError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%s: %v", e.Key, e.Err)
+	}
+	return fmt.Sprintf("%s: %v: %s", e.Key, e.Err, e.Detail)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every setting LoadConfig rejected, so all of them can
+// be fixed in one go
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return "invalid configuration: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
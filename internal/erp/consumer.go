@@ -0,0 +1,108 @@
+package erp
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	reconnectDelay = 5 * time.Second
+	redeliverDelay = 5 * time.Second
+)
+
+// Handler processes one message body. Returning an error asks for the
+// message to be delivered again, so it is only for failures on our side.
+type Handler func(ctx context.Context, body []byte) error
+
+// Consumer reads the ERP's stock levels from a durable RabbitMQ queue, one
+// message at a time, acknowledging each once it is handled
+type Consumer struct {
+	url     string
+	queue   string
+	handler Handler
+}
+
+func NewConsumer(url, queue string, handler Handler) *Consumer {
+	return &Consumer{url: url, queue: queue, handler: handler}
+}
+
+// Start consumes until ctx is cancelled, reconnecting whenever the broker
+// goes away. Without a broker URL the sync is disabled.
+func (c *Consumer) Start(ctx context.Context) {
+	if c.url == "" {
+		return
+	}
+
+	go func() {
+		for {
+			if err := c.consume(ctx); err != nil {
+				log.Printf("⚠️ ERP stock consumer stopped: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectDelay):
+			}
+		}
+	}()
+}
+
+func (c *Consumer) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if _, err := ch.QueueDeclare(c.queue, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	// Stock levels are absolute, so they must be applied in order
+	if err := ch.Qos(1, 0, false); err != nil {
+		return err
+	}
+
+	deliveries, err := ch.ConsumeWithContext(ctx, c.queue, "", false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+
+			if err := c.handler(ctx, delivery.Body); err != nil {
+				log.Printf("⚠️ ERP stock message %s failed, redelivering: %v", delivery.MessageId, err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(redeliverDelay):
+				}
+				if err := delivery.Nack(false, true); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := delivery.Ack(false); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package erp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/money"
+)
+
+// The ERP's message formats belong to the ERP and change on its schedule.
+// They are translated here, at the boundary, and never passed further in.
+
+// stockLevel is the ERP's stock level message for one item at one plant
+type stockLevel struct {
+	MessageID    string   `json:"messageId"`
+	ItemNo       string   `json:"itemNo"`
+	Plant        string   `json:"plant"`
+	QtyOnHand    float64  `json:"qtyOnHand"`
+	QtyAllocated float64  `json:"qtyAllocated"`
+	QtyAvailable *float64 `json:"qtyAvailable"`
+	UoM          string   `json:"uom"`
+	ChangedAt    string   `json:"changedAt"`
+}
+
+// unitsOfMeasure are the ERP units that count single sellable units
+var unitsOfMeasure = map[string]bool{"": true, "EA": true, "PC": true, "PCS": true}
+
+// ErrInvalidMessage marks a message that cannot be translated; redelivering
+// it will not help
+var ErrInvalidMessage = errors.New("invalid ERP message")
+
+// TranslateStockUpdate reads the ERP's stock level message. The store sells
+// what the ERP has not allocated, so the quantity is the available quantity,
+// or on hand less allocated when the ERP leaves it out.
+func TranslateStockUpdate(body []byte) (models.ERPStockUpdate, error) {
+	var msg stockLevel
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return models.ERPStockUpdate{}, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+
+	update := models.ERPStockUpdate{
+		MessageID:     strings.TrimSpace(msg.MessageID),
+		SKU:           strings.TrimSpace(msg.ItemNo),
+		WarehouseCode: strings.ToUpper(strings.TrimSpace(msg.Plant)),
+	}
+	if update.SKU == "" {
+		return update, fmt.Errorf("%w: itemNo is required", ErrInvalidMessage)
+	}
+	if !unitsOfMeasure[strings.ToUpper(msg.UoM)] {
+		return update, fmt.Errorf("%w: unsupported unit of measure %s", ErrInvalidMessage, msg.UoM)
+	}
+
+	available := msg.QtyOnHand - msg.QtyAllocated
+	if msg.QtyAvailable != nil {
+		available = *msg.QtyAvailable
+	}
+	if available != math.Trunc(available) {
+		return update, fmt.Errorf("%w: quantity %v is not a whole number", ErrInvalidMessage, available)
+	}
+	update.Quantity = max(int(available), 0)
+
+	if msg.ChangedAt != "" {
+		changedAt, err := time.Parse(time.RFC3339, msg.ChangedAt)
+		if err != nil {
+			return update, fmt.Errorf("%w: changedAt must be RFC 3339", ErrInvalidMessage)
+		}
+		changedAt = changedAt.UTC()
+		update.ChangedAt = &changedAt
+	}
+
+	return update, nil
+}
+
+// salesOrder is the ERP's sales order document. The ERP keys it on
+// ExternalRef and drops repeated MessageIDs, so resending one is harmless.
+type salesOrder struct {
+	MessageID   string           `json:"messageId"`
+	Action      string           `json:"action"`
+	ExternalRef string           `json:"externalRef"`
+	Status      string           `json:"status"`
+	PaymentTerm string           `json:"paymentTerm"`
+	TotalAmount money.Money      `json:"totalAmount"`
+	ShipTo      salesOrderParty  `json:"shipTo"`
+	Lines       []salesOrderLine `json:"lines"`
+	OccurredAt  time.Time        `json:"occurredAt"`
+}
+
+type salesOrderParty struct {
+	Name       string `json:"name"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	Country    string `json:"country"`
+	PostalCode string `json:"postalCode"`
+}
+
+type salesOrderLine struct {
+	LineNo    int         `json:"lineNo"`
+	ItemNo    string      `json:"itemNo"`
+	Qty       int         `json:"qty"`
+	UnitPrice money.Money `json:"unitPrice"`
+	Status    string      `json:"status"`
+}
+
+// Sales order actions and the routing keys they are published under
+const (
+	actionCreate       = "CREATE"
+	actionStatusChange = "STATUS_CHANGE"
+)
+
+var routingKeys = map[string]string{
+	actionCreate:       "sales_order.created",
+	actionStatusChange: "sales_order.status_changed",
+}
+
+// orderStatuses maps order statuses onto the ERP's sales order statuses
+var orderStatuses = map[models.OrderStatus]string{
+	models.OrderPending:          "OPEN",
+	models.OrderOnHold:           "BLOCKED",
+	models.OrderUnderReview:      "BLOCKED",
+	models.OrderProcessing:       "RELEASED",
+	models.OrderPartiallyShipped: "PARTIALLY_SHIPPED",
+	models.OrderShipped:          "SHIPPED",
+	models.OrderDelivered:        "COMPLETED",
+	models.OrderCompleted:        "COMPLETED",
+	models.OrderReturnRequested:  "COMPLETED",
+	models.OrderCancelled:        "CANCELLED",
+	models.OrderRefunded:         "CANCELLED",
+}
+
+// lineStatuses maps item fulfillment onto the ERP's line statuses
+var lineStatuses = map[models.FulfillmentStatus]string{
+	models.FulfillmentPending:     "OPEN",
+	models.FulfillmentBackordered: "BACKORDERED",
+	models.FulfillmentShipped:     "SHIPPED",
+	models.FulfillmentDelivered:   "SHIPPED",
+	models.FulfillmentCancelled:   "CANCELLED",
+}
+
+// paymentTerms maps payment methods onto the ERP's payment terms
+var paymentTerms = map[string]string{
+	"cc":  "PREPAID",
+	"dc":  "PREPAID",
+	"cod": "COD",
+}
+
+// translateOrder builds the sales order document for an order as it stands,
+// with status as of the event being sent
+func translateOrder(messageID, action string, order *models.Order, status models.OrderStatus, occurredAt time.Time) ([]byte, error) {
+	doc := salesOrder{
+		MessageID:   messageID,
+		Action:      action,
+		ExternalRef: order.OrderNumber,
+		Status:      orderStatuses[status],
+		PaymentTerm: paymentTerms[order.PaymentMethod],
+		TotalAmount: order.TotalAmount,
+		ShipTo: salesOrderParty{
+			Name:       order.ShippingAddress.FullName,
+			City:       order.ShippingAddress.City,
+			Region:     order.ShippingAddress.State,
+			Country:    order.ShippingAddress.Country,
+			PostalCode: order.ShippingAddress.PostalCode,
+		},
+		Lines:      make([]salesOrderLine, 0, len(order.Items)),
+		OccurredAt: occurredAt.UTC(),
+	}
+
+	for i, item := range order.Items {
+		sku := item.Product.SKU
+		if item.Variant != nil {
+			sku = item.Variant.SKU
+		}
+		doc.Lines = append(doc.Lines, salesOrderLine{
+			LineNo:    (i + 1) * 10,
+			ItemNo:    sku,
+			Qty:       item.Quantity,
+			UnitPrice: item.PriceAtTime,
+			Status:    lineStatuses[item.FulfillmentStatus],
+		})
+	}
+
+	return json.Marshal(doc)
+}
@@ -0,0 +1,76 @@
+package erp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/queue"
+)
+
+// Sink publishes order events to the ERP as sales order documents. It is an
+// events.Sink, so it sees every event and skips those the ERP has no use for.
+type Sink struct {
+	publisher queue.Publisher
+	orderRepo repository.OrderRepository
+}
+
+func NewSink(publisher queue.Publisher, orderRepo repository.OrderRepository) *Sink {
+	return &Sink{publisher: publisher, orderRepo: orderRepo}
+}
+
+func (s *Sink) Name() string {
+	return "erp"
+}
+
+// Deliver publishes the order event and waits for the broker to accept it
+func (s *Sink) Deliver(ctx context.Context, event models.OutboxEvent) error {
+	action, body, err := s.translate(ctx, event)
+	if err != nil || body == nil {
+		return err
+	}
+
+	return s.publisher.Publish(ctx, queue.Message{
+		Key:         routingKeys[action],
+		ID:          event.ID.String(),
+		ContentType: "application/json",
+		Timestamp:   event.CreatedAt,
+		Body:        body,
+	})
+}
+
+// translate turns an order event into a sales order document, nil for events
+// the ERP does not take. Documents carry the order's lines as they are now; a
+// new order's status is its current one.
+func (s *Sink) translate(ctx context.Context, event models.OutboxEvent) (string, []byte, error) {
+	var action string
+	var status models.OrderStatus
+
+	switch event.EventType {
+	case events.OrderCreated:
+		action = actionCreate
+	case events.OrderStatusChanged:
+		var payload events.OrderStatusChangedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return "", nil, fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+		}
+		action = actionStatusChange
+		status = payload.ToStatus
+	default:
+		return "", nil, nil
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, event.AggregateID)
+	if err != nil || order == nil {
+		return "", nil, err
+	}
+	if status == "" {
+		status = order.Status
+	}
+
+	body, err := translateOrder(event.ID.String(), action, order, status, event.CreatedAt)
+	return action, body, err
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"ecommerce-backend/internal/models"
+)
+
+// AllEvents subscribes a handler to every event type
+const AllEvents = "*"
+
+// Handler consumes a delivered event. Delivery is at-least-once, so handlers
+// must tolerate seeing the same event more than once.
+type Handler func(ctx context.Context, event models.OutboxEvent) error
+
+// Sink delivers events to a system outside the process
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, event models.OutboxEvent) error
+}
+
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *Bus) AddSink(sink Sink) {
+	b.Subscribe(AllEvents, func(ctx context.Context, event models.OutboxEvent) error {
+		if err := sink.Deliver(ctx, event); err != nil {
+			return fmt.Errorf("sink %s: %w", sink.Name(), err)
+		}
+		return nil
+	})
+}
+
+// Dispatch runs every handler for the event and joins their errors
+func (b *Bus) Dispatch(ctx context.Context, event models.OutboxEvent) error {
+	b.mu.RLock()
+	handlers := append([]Handler{}, b.handlers[event.EventType]...)
+	handlers = append(handlers, b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
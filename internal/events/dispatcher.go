@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"ecommerce-backend/internal/repository"
+)
+
+const (
+	dispatchBatchSize   = 50
+	dispatchMaxAttempts = 10
+	dispatchLease       = time.Minute
+	maxRetryBackoff     = time.Hour
+)
+
+// Dispatcher delivers recorded outbox events to the bus
+type Dispatcher interface {
+	DispatchPending(ctx context.Context) (int, error)
+	Start(ctx context.Context, interval time.Duration)
+}
+
+type dispatcher struct {
+	outboxRepo repository.OutboxRepository
+	bus        *Bus
+}
+
+func NewDispatcher(outboxRepo repository.OutboxRepository, bus *Bus) Dispatcher {
+	return &dispatcher{outboxRepo: outboxRepo, bus: bus}
+}
+
+// DispatchPending delivers one batch of due events and returns how many succeeded
+func (d *dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	pending, err := d.outboxRepo.ClaimPending(ctx, dispatchBatchSize, dispatchMaxAttempts, dispatchLease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range pending {
+		if err := d.bus.Dispatch(ctx, event); err != nil {
+			retryAt := time.Now().Add(retryBackoff(event.Attempts + 1))
+			log.Printf("⚠️ Event %s (%s) delivery failed, attempt %d: %v",
+				event.ID, event.EventType, event.Attempts+1, err)
+			if markErr := d.outboxRepo.MarkFailed(ctx, event.ID, err.Error(), retryAt); markErr != nil {
+				return delivered, markErr
+			}
+			continue
+		}
+
+		if err := d.outboxRepo.MarkProcessed(ctx, event.ID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+// Start polls the outbox on a fixed interval until ctx is cancelled
+func (d *dispatcher) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⚠️ Outbox dispatcher disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := d.DispatchPending(ctx); err != nil {
+					log.Printf("⚠️ Outbox dispatch failed: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("📤 Outbox dispatcher running every %s", interval)
+}
+
+// retryBackoff doubles the wait per attempt starting at 10 seconds
+func retryBackoff(attempt int) time.Duration {
+	backoff := 10 * time.Second
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// Domain event types
+const (
+	CartAbandoned      = "cart.abandoned"
+	OrderCreated       = "order.created"
+	OrderStatusChanged = "order.status_changed"
+	OrderMessagePosted = "order.message_posted"
+	PaymentAuthorized  = "payment.authorized"
+	PaymentCompleted   = "payment.completed"
+	PaymentFailed      = "payment.failed"
+	PaymentRefunded    = "payment.refunded"
+	ReturnApproved     = "return.approved"
+	ProductCreated     = "product.created"
+	ProductUpdated     = "product.updated"
+	ProductDeleted     = "product.deleted"
+	StockLow           = "inventory.stock_low"
+)
+
+// Aggregate types events are recorded against
+const (
+	AggregateOrder   = "order"
+	AggregatePayment = "payment"
+	AggregateReturn  = "return"
+	AggregateProduct = "product"
+	AggregateCart    = "cart"
+)
+
+type CartAbandonedPayload struct {
+	AbandonedCartID uuid.UUID   `json:"abandoned_cart_id"`
+	CartID          uuid.UUID   `json:"cart_id"`
+	UserID          uuid.UUID   `json:"user_id"`
+	ItemCount       int         `json:"item_count"`
+	CartValue       money.Money `json:"cart_value"`
+	LastActivityAt  time.Time   `json:"last_activity_at"`
+}
+
+type OrderCreatedPayload struct {
+	OrderID       uuid.UUID   `json:"order_id"`
+	OrderNumber   string      `json:"order_number"`
+	UserID        uuid.UUID   `json:"user_id"`
+	TotalAmount   money.Money `json:"total_amount"`
+	PaymentMethod string      `json:"payment_method"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+type OrderStatusChangedPayload struct {
+	OrderID     uuid.UUID          `json:"order_id"`
+	OrderNumber string             `json:"order_number"`
+	UserID      uuid.UUID          `json:"user_id"`
+	FromStatus  models.OrderStatus `json:"from_status"`
+	ToStatus    models.OrderStatus `json:"to_status"`
+}
+
+// OrderMessagePostedPayload is raised when a customer asks a question on an
+// order; support replies notify the customer directly instead
+type OrderMessagePostedPayload struct {
+	MessageID   uuid.UUID `json:"message_id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	OrderNumber string    `json:"order_number"`
+	UserID      uuid.UUID `json:"user_id"`
+	Preview     string    `json:"preview"`
+}
+
+// PaymentAuthorizedPayload is sent when funds are held for an order placed in
+// authorize-then-capture mode, ready for the order to be finalized
+type PaymentAuthorizedPayload struct {
+	PaymentID     uuid.UUID   `json:"payment_id"`
+	OrderID       uuid.UUID   `json:"order_id"`
+	Amount        money.Money `json:"amount"`
+	PaymentMethod string      `json:"payment_method"`
+	TransactionID string      `json:"transaction_id"`
+}
+
+type PaymentCompletedPayload struct {
+	PaymentID     uuid.UUID   `json:"payment_id"`
+	OrderID       uuid.UUID   `json:"order_id"`
+	Amount        money.Money `json:"amount"`
+	PaymentMethod string      `json:"payment_method"`
+	TransactionID string      `json:"transaction_id"`
+}
+
+type PaymentFailedPayload struct {
+	PaymentID     uuid.UUID   `json:"payment_id"`
+	OrderID       uuid.UUID   `json:"order_id"`
+	OrderNumber   string      `json:"order_number"`
+	Amount        money.Money `json:"amount"`
+	PaymentMethod string      `json:"payment_method"`
+	Reason        string      `json:"reason,omitempty"`
+}
+
+type PaymentRefundedPayload struct {
+	PaymentID    uuid.UUID   `json:"payment_id"`
+	OrderID      uuid.UUID   `json:"order_id"`
+	RefundAmount money.Money `json:"refund_amount"`
+}
+
+type ReturnApprovedPayload struct {
+	ReturnID  uuid.UUID `json:"return_id"`
+	OrderID   uuid.UUID `json:"order_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	RMANumber string    `json:"rma_number"`
+}
+
+// ProductChangedPayload is raised when a product, or one of its variants, is
+// created, updated or deleted
+type ProductChangedPayload struct {
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+// StockLowPayload is raised when an order leaves a product or variant at or
+// below the configured low-stock threshold
+type StockLowPayload struct {
+	ProductID   uuid.UUID  `json:"product_id"`
+	VariantID   *uuid.UUID `json:"variant_id,omitempty"`
+	ProductName string     `json:"product_name"`
+	Stock       int        `json:"stock"`
+	Threshold   int        `json:"threshold"`
+}
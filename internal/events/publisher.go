@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// Publisher records domain events. Call it inside the business transaction so
+// the event is stored if and only if the change commits.
+type Publisher interface {
+	Publish(ctx context.Context, eventType, aggregateType string, aggregateID uuid.UUID, payload interface{}) error
+}
+
+type outboxPublisher struct {
+	outboxRepo repository.OutboxRepository
+}
+
+func NewOutboxPublisher(outboxRepo repository.OutboxRepository) Publisher {
+	return &outboxPublisher{outboxRepo: outboxRepo}
+}
+
+func (p *outboxPublisher) Publish(ctx context.Context, eventType, aggregateType string, aggregateID uuid.UUID, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	event := &models.OutboxEvent{
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       data,
+	}
+
+	if err := p.outboxRepo.Insert(ctx, event); err != nil {
+		return fmt.Errorf("failed to record %s event: %w", eventType, err)
+	}
+
+	return nil
+}
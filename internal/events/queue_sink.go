@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/queue"
+)
+
+// queuedAggregates are the aggregates whose events other services consume
+var queuedAggregates = map[string]bool{
+	AggregateOrder:   true,
+	AggregatePayment: true,
+	AggregateReturn:  true,
+}
+
+type queueSink struct {
+	publisher queue.Publisher
+}
+
+// NewQueueSink publishes order, payment and return events to a message
+// broker as JSON, keyed by event type (e.g. order.created) so consumers can
+// bind to the events they need
+func NewQueueSink(publisher queue.Publisher) Sink {
+	return &queueSink{publisher: publisher}
+}
+
+func (s *queueSink) Name() string {
+	return "queue:" + s.publisher.Name()
+}
+
+func (s *queueSink) Deliver(ctx context.Context, event models.OutboxEvent) error {
+	if !queuedAggregates[event.AggregateType] {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.publisher.Publish(ctx, queue.Message{
+		Key:         event.EventType,
+		ID:          event.ID.String(),
+		ContentType: "application/json",
+		Timestamp:   event.CreatedAt,
+		Headers: map[string]string{
+			"event_type":     event.EventType,
+			"aggregate_type": event.AggregateType,
+			"aggregate_id":   event.AggregateID.String(),
+		},
+		Body: body,
+	})
+}
@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/pkg/money"
+)
+
+// Event types emitted by payment gateways that drive payment and order status
+const (
+	EventPaymentSucceeded  = "payment_intent.succeeded"
+	EventPaymentFailed     = "payment_intent.payment_failed"
+	EventPaymentCanceled   = "payment_intent.canceled"
+	EventPaymentProcessing = "payment_intent.processing"
+
+	// EventPaymentAuthorized is sent when an intent captured manually has
+	// been authorized and its amount can be captured
+	EventPaymentAuthorized = "payment_intent.amount_capturable_updated"
+)
+
+// Payment intent statuses that drive payment status when the customer
+// returns from authenticating
+const (
+	IntentSucceeded             = "succeeded"
+	IntentProcessing            = "processing"
+	IntentRequiresAction        = "requires_action"
+	IntentRequiresPaymentMethod = "requires_payment_method"
+	IntentRequiresCapture       = "requires_capture"
+	IntentCanceled              = "canceled"
+)
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+type PaymentIntent struct {
+	ID           string `json:"id"`
+	ClientSecret string `json:"client_secret"`
+	Status       string `json:"status"`
+	Amount       int64  `json:"amount"`
+	Currency     string `json:"currency"`
+
+	// NextAction is set while the customer must authenticate the payment,
+	// e.g. with 3-D Secure
+	NextAction *struct {
+		RedirectToURL *struct {
+			URL string `json:"url"`
+		} `json:"redirect_to_url"`
+	} `json:"next_action"`
+}
+
+// RedirectURL is where the customer authenticates the payment, or "" when
+// no redirect is needed
+func (i *PaymentIntent) RedirectURL() string {
+	if i.Status != IntentRequiresAction || i.NextAction == nil || i.NextAction.RedirectToURL == nil {
+		return ""
+	}
+	return i.NextAction.RedirectToURL.URL
+}
+
+// Refund returns some or all of a captured intent to the customer
+type Refund struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Amount int64  `json:"amount"`
+}
+
+// PaymentMethod is a card saved with the gateway, identified by its token
+type PaymentMethod struct {
+	ID       string
+	Brand    string
+	Last4    string
+	ExpMonth int
+	ExpYear  int
+}
+
+type Event struct {
+	ID              string
+	Type            string
+	PaymentIntentID string
+	FailureMessage  string
+	Metadata        map[string]string
+}
+
+// PaymentGateway is implemented by external payment providers
+//
+// An intent created with manualCapture only authorizes the amount; the funds
+// are taken by CapturePaymentIntent, or released by CancelPaymentIntent.
+type PaymentGateway interface {
+	Name() string
+	CreatePaymentIntent(ctx context.Context, amount money.Money, currency, idempotencyKey string, manualCapture bool, metadata map[string]string) (*PaymentIntent, error)
+	GetPaymentIntent(ctx context.Context, id string) (*PaymentIntent, error)
+	CapturePaymentIntent(ctx context.Context, id string, amount money.Money, idempotencyKey string) (*PaymentIntent, error)
+	CancelPaymentIntent(ctx context.Context, id, idempotencyKey string) (*PaymentIntent, error)
+	RefundPaymentIntent(ctx context.Context, id string, amount money.Money, idempotencyKey string) (*Refund, error)
+	ParseWebhook(payload []byte, signatureHeader string) (*Event, error)
+
+	// Ping checks the provider can be reached with the configured credentials
+	Ping(ctx context.Context) error
+
+	// Saved payment methods belong to a gateway customer. A saved method is
+	// charged without the customer re-entering their details; when the card
+	// issuer asks for authentication the intent requires action and sends the
+	// customer back to returnURL afterwards.
+	CreateCustomer(ctx context.Context, email string, metadata map[string]string) (string, error)
+	AttachPaymentMethod(ctx context.Context, customerID, paymentMethodID string) (*PaymentMethod, error)
+	DetachPaymentMethod(ctx context.Context, paymentMethodID string) error
+	ChargePaymentMethod(ctx context.Context, amount money.Money, currency, idempotencyKey, customerID, paymentMethodID, returnURL string, manualCapture bool, metadata map[string]string) (*PaymentIntent, error)
+}
@@ -0,0 +1,329 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce-backend/pkg/money"
+)
+
+const (
+	stripeAPIBase          = "https://api.stripe.com/v1"
+	stripeSignatureMaxSkew = 5 * time.Minute
+)
+
+type stripeGateway struct {
+	secretKey     string
+	webhookSecret string
+	client        *http.Client
+}
+
+func NewStripeGateway(secretKey, webhookSecret string) PaymentGateway {
+	return &stripeGateway{
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		client:        &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (g *stripeGateway) Name() string {
+	return "stripe"
+}
+
+// Ping reads the account balance, the cheapest authenticated call
+func (g *stripeGateway) Ping(ctx context.Context) error {
+	return g.do(ctx, http.MethodGet, "/balance", nil, "", nil)
+}
+
+func (g *stripeGateway) CreatePaymentIntent(ctx context.Context, amount money.Money, currency, idempotencyKey string, manualCapture bool, metadata map[string]string) (*PaymentIntent, error) {
+	form := url.Values{}
+	// Stripe expects amounts in the smallest currency unit
+	form.Set("amount", strconv.FormatInt(amount.Minor(), 10))
+	form.Set("currency", strings.ToLower(currency))
+	form.Set("automatic_payment_methods[enabled]", "true")
+	setCaptureMethod(form, manualCapture)
+	for k, v := range metadata {
+		form.Set("metadata["+k+"]", v)
+	}
+
+	var intent PaymentIntent
+	if err := g.post(ctx, "/payment_intents", form, idempotencyKey, &intent); err != nil {
+		return nil, err
+	}
+
+	return &intent, nil
+}
+
+func (g *stripeGateway) GetPaymentIntent(ctx context.Context, id string) (*PaymentIntent, error) {
+	var intent PaymentIntent
+	if err := g.do(ctx, http.MethodGet, "/payment_intents/"+url.PathEscape(id), nil, "", &intent); err != nil {
+		return nil, err
+	}
+
+	return &intent, nil
+}
+
+// CapturePaymentIntent takes amount of an authorized intent; Stripe releases
+// whatever is left of the authorization
+func (g *stripeGateway) CapturePaymentIntent(ctx context.Context, id string, amount money.Money, idempotencyKey string) (*PaymentIntent, error) {
+	form := url.Values{}
+	form.Set("amount_to_capture", strconv.FormatInt(amount.Minor(), 10))
+
+	var intent PaymentIntent
+	if err := g.post(ctx, "/payment_intents/"+url.PathEscape(id)+"/capture", form, idempotencyKey, &intent); err != nil {
+		return nil, err
+	}
+
+	return &intent, nil
+}
+
+// CancelPaymentIntent cancels an intent that has not been captured, releasing
+// any authorization
+func (g *stripeGateway) CancelPaymentIntent(ctx context.Context, id, idempotencyKey string) (*PaymentIntent, error) {
+	var intent PaymentIntent
+	if err := g.post(ctx, "/payment_intents/"+url.PathEscape(id)+"/cancel", url.Values{}, idempotencyKey, &intent); err != nil {
+		return nil, err
+	}
+
+	return &intent, nil
+}
+
+// RefundPaymentIntent returns amount of a captured intent to the customer
+func (g *stripeGateway) RefundPaymentIntent(ctx context.Context, id string, amount money.Money, idempotencyKey string) (*Refund, error) {
+	form := url.Values{}
+	form.Set("payment_intent", id)
+	form.Set("amount", strconv.FormatInt(amount.Minor(), 10))
+
+	var refund Refund
+	if err := g.post(ctx, "/refunds", form, idempotencyKey, &refund); err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+func (g *stripeGateway) CreateCustomer(ctx context.Context, email string, metadata map[string]string) (string, error) {
+	form := url.Values{}
+	form.Set("email", email)
+	for k, v := range metadata {
+		form.Set("metadata["+k+"]", v)
+	}
+
+	var customer struct {
+		ID string `json:"id"`
+	}
+	if err := g.post(ctx, "/customers", form, "", &customer); err != nil {
+		return "", err
+	}
+
+	return customer.ID, nil
+}
+
+func (g *stripeGateway) AttachPaymentMethod(ctx context.Context, customerID, paymentMethodID string) (*PaymentMethod, error) {
+	form := url.Values{}
+	form.Set("customer", customerID)
+
+	var method struct {
+		ID   string `json:"id"`
+		Card *struct {
+			Brand    string `json:"brand"`
+			Last4    string `json:"last4"`
+			ExpMonth int    `json:"exp_month"`
+			ExpYear  int    `json:"exp_year"`
+		} `json:"card"`
+	}
+	if err := g.post(ctx, "/payment_methods/"+url.PathEscape(paymentMethodID)+"/attach", form, "", &method); err != nil {
+		return nil, err
+	}
+	if method.Card == nil {
+		return nil, errors.New("stripe payment method is not a card")
+	}
+
+	return &PaymentMethod{
+		ID:       method.ID,
+		Brand:    method.Card.Brand,
+		Last4:    method.Card.Last4,
+		ExpMonth: method.Card.ExpMonth,
+		ExpYear:  method.Card.ExpYear,
+	}, nil
+}
+
+func (g *stripeGateway) DetachPaymentMethod(ctx context.Context, paymentMethodID string) error {
+	return g.post(ctx, "/payment_methods/"+url.PathEscape(paymentMethodID)+"/detach", url.Values{}, "", nil)
+}
+
+func (g *stripeGateway) ChargePaymentMethod(ctx context.Context, amount money.Money, currency, idempotencyKey, customerID, paymentMethodID, returnURL string, manualCapture bool, metadata map[string]string) (*PaymentIntent, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(amount.Minor(), 10))
+	form.Set("currency", strings.ToLower(currency))
+	form.Set("customer", customerID)
+	form.Set("payment_method", paymentMethodID)
+	form.Set("confirm", "true")
+	// The customer is at checkout, so the issuer may ask them to authenticate
+	form.Set("return_url", returnURL)
+	setCaptureMethod(form, manualCapture)
+	for k, v := range metadata {
+		form.Set("metadata["+k+"]", v)
+	}
+
+	var intent PaymentIntent
+	if err := g.post(ctx, "/payment_intents", form, idempotencyKey, &intent); err != nil {
+		return nil, err
+	}
+
+	return &intent, nil
+}
+
+// setCaptureMethod makes the intent only authorize the amount when
+// manualCapture is set
+func setCaptureMethod(form url.Values, manualCapture bool) {
+	if manualCapture {
+		form.Set("capture_method", "manual")
+	}
+}
+
+// post sends a form-encoded request to the Stripe API and decodes the
+// response into out, when given
+func (g *stripeGateway) post(ctx context.Context, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	return g.do(ctx, http.MethodPost, path, form, idempotencyKey, out)
+}
+
+func (g *stripeGateway) do(ctx context.Context, method, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, stripeAPIBase+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.secretKey)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("stripe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var stripeErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&stripeErr)
+		return fmt.Errorf("stripe error (%d): %s", resp.StatusCode, stripeErr.Error.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode stripe response: %w", err)
+	}
+
+	return nil
+}
+
+func (g *stripeGateway) ParseWebhook(payload []byte, signatureHeader string) (*Event, error) {
+	if err := g.verifySignature(payload, signatureHeader, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Object struct {
+				ID               string            `json:"id"`
+				Object           string            `json:"object"`
+				Metadata         map[string]string `json:"metadata"`
+				LastPaymentError *struct {
+					Message string `json:"message"`
+				} `json:"last_payment_error"`
+			} `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	event := &Event{
+		ID:       raw.ID,
+		Type:     raw.Type,
+		Metadata: raw.Data.Object.Metadata,
+	}
+	if raw.Data.Object.Object == "payment_intent" {
+		event.PaymentIntentID = raw.Data.Object.ID
+	}
+	if raw.Data.Object.LastPaymentError != nil {
+		event.FailureMessage = raw.Data.Object.LastPaymentError.Message
+	}
+
+	return event, nil
+}
+
+// verifySignature checks the Stripe-Signature header ("t=<ts>,v1=<sig>,...")
+// against an HMAC-SHA256 of "<ts>.<payload>" keyed with the webhook secret
+func (g *stripeGateway) verifySignature(payload []byte, header string, now time.Time) error {
+	if g.webhookSecret == "" || header == "" {
+		return ErrInvalidSignature
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if now.Sub(time.Unix(ts, 0)).Abs() > stripeSignatureMaxSkew {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, sig := range signatures {
+		decoded, err := hex.DecodeString(sig)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "whsec_test"
+
+// sign computes the v1 signature Stripe sends for payload at timestamp
+func sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestStripeVerifySignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded"}`)
+	now := time.Unix(1700000000, 0)
+	ts := now.Unix()
+	t0 := strconv.FormatInt(ts, 10)
+	valid := sign(testWebhookSecret, ts, payload)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		payload []byte
+		wantErr bool
+	}{
+		{"valid", testWebhookSecret, "t=" + t0 + ",v1=" + valid, payload, false},
+		{"spaces between parts", testWebhookSecret, "t=" + t0 + ", v1=" + valid, payload, false},
+		{"v0 and unknown parts ignored", testWebhookSecret, "t=" + t0 + ",v0=abc,v1=" + valid + ",junk", payload, false},
+		{"valid among several v1 during secret rotation", testWebhookSecret, "t=" + t0 + ",v1=" + sign("whsec_old", ts, payload) + ",v1=" + valid, payload, false},
+		{"malformed hex skipped for a later match", testWebhookSecret, "t=" + t0 + ",v1=zz,v1=" + valid, payload, false},
+		{"empty header", testWebhookSecret, "", payload, true},
+		{"no secret configured", "", "t=" + t0 + ",v1=" + valid, payload, true},
+		{"missing timestamp", testWebhookSecret, "v1=" + valid, payload, true},
+		{"missing signature", testWebhookSecret, "t=" + t0, payload, true},
+		{"non-numeric timestamp", testWebhookSecret, "t=soon,v1=" + valid, payload, true},
+		{"signed with another secret", testWebhookSecret, "t=" + t0 + ",v1=" + sign("whsec_other", ts, payload), payload, true},
+		{"payload altered", testWebhookSecret, "t=" + t0 + ",v1=" + valid, []byte(`{"id":"evt_2"}`), true},
+		{"timestamp altered", testWebhookSecret, "t=" + strconv.FormatInt(ts-1, 10) + ",v1=" + valid, payload, true},
+		{"all v1 entries bad", testWebhookSecret, "t=" + t0 + ",v1=00,v1=" + sign("whsec_old", ts, payload), payload, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &stripeGateway{webhookSecret: tt.secret}
+
+			err := g.verifySignature(tt.payload, tt.header, now)
+			if tt.wantErr && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("err = %v, want ErrInvalidSignature", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestStripeVerifySignatureTolerance(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	now := time.Unix(1700000000, 0)
+	g := &stripeGateway{webhookSecret: testWebhookSecret}
+
+	tests := []struct {
+		name    string
+		age     time.Duration
+		wantErr bool
+	}{
+		{"fresh", 0, false},
+		{"at the limit", stripeSignatureMaxSkew, false},
+		{"stale", stripeSignatureMaxSkew + time.Second, true},
+		{"clock skewed ahead", -stripeSignatureMaxSkew - time.Second, true},
+		{"slightly ahead", -time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := now.Add(-tt.age).Unix()
+			header := "t=" + strconv.FormatInt(ts, 10) + ",v1=" + sign(testWebhookSecret, ts, payload)
+
+			err := g.verifySignature(payload, header, now)
+			if tt.wantErr && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("err = %v, want ErrInvalidSignature", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+		})
+	}
+}
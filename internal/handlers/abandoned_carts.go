@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AbandonedCartHandler struct {
+	abandonedCartService service.AbandonedCartService
+}
+
+func NewAbandonedCartHandler(abandonedCartService service.AbandonedCartService) *AbandonedCartHandler {
+	return &AbandonedCartHandler{abandonedCartService: abandonedCartService}
+}
+
+func (h *AbandonedCartHandler) GetStats(c *gin.Context) {
+	rangeDays := 0
+	if rd := c.Query("range_days"); rd != "" {
+		if parsed, err := strconv.Atoi(rd); err == nil && parsed > 0 {
+			rangeDays = parsed
+		}
+	}
+
+	stats, err := h.abandonedCartService.GetStats(c.Request.Context(), rangeDays)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Abandoned cart stats retrieved", stats)
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Requests that must re-authenticate but have no body carry the caller's
+// password, or for accounts without one, a fresh identity token and its
+// provider or a code texted to their phone, in these headers
+const (
+	reauthHeader         = "X-Current-Password"
+	reauthProviderHeader = "X-Reauth-Provider"
+	reauthIDTokenHeader  = "X-Reauth-ID-Token"
+	reauthOTPHeader      = "X-Reauth-OTP"
+)
+
+type AccountHandler struct {
+	authService    service.AuthService
+	accountService service.AccountService
+}
+
+func NewAccountHandler(authService service.AuthService, accountService service.AccountService) *AccountHandler {
+	return &AccountHandler{authService: authService, accountService: accountService}
+}
+
+// ExportData returns the caller's personal data as JSON, or as CSV with one
+// row per field when format=csv
+func (h *AccountHandler) ExportData(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	reauth := models.ReauthRequest{
+		Password: c.GetHeader(reauthHeader),
+		Provider: c.GetHeader(reauthProviderHeader),
+		IDToken:  c.GetHeader(reauthIDTokenHeader),
+		OTP:      c.GetHeader(reauthOTPHeader),
+	}
+	if reauth.Password == "" && reauth.IDToken == "" && reauth.OTP == "" {
+		utils.GinUnauthorizedResponse(c, "Password required in "+reauthHeader+" header, or an identity token or code for accounts without one")
+		return
+	}
+
+	export, err := h.accountService.ExportData(c.Request.Context(), userID, reauth)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if c.Query("format") != "csv" {
+		utils.GinSuccessResponse(c, "Account data exported successfully", export)
+		return
+	}
+
+	header := []string{"section", "record_id", "field", "value"}
+	streamCSV(c, "account-data", header, func(write func([]string) error) error {
+		return writeAccountExportCSV(export, write)
+	})
+}
+
+func (h *AccountHandler) CloseAccount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CloseAccountRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.authService.CloseAccount(c.Request.Context(), userID, req.ReauthRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Account closed successfully", nil)
+}
+
+// currentUserID reads the authenticated user, writing the error response and
+// returning false when the token carries no usable ID
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+// writeAccountExportCSV flattens the export into section, record, field and
+// value rows so every field appears regardless of nesting. Records are keyed
+// by their id, or their position when they have none.
+func writeAccountExportCSV(export *models.AccountDataExport, write func([]string) error) error {
+	raw, err := json.Marshal(export)
+	if err != nil {
+		return err
+	}
+
+	var sections map[string]interface{}
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch value := sections[name].(type) {
+		case []interface{}:
+			for i, record := range value {
+				recordID := strconv.Itoa(i)
+				if fields, ok := record.(map[string]interface{}); ok {
+					if id, ok := fields["id"].(string); ok {
+						recordID = id
+					}
+				}
+				if err := writeFlattened(write, name, recordID, "", record); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := writeFlattened(write, name, "", "", value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeFlattened(write func([]string) error, section, recordID, path string, value interface{}) error {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := writeFlattened(write, section, recordID, join(key), v[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		for i, item := range v {
+			if err := writeFlattened(write, section, recordID, join(strconv.Itoa(i)), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case nil:
+		return write([]string{section, recordID, path, ""})
+	default:
+		return write([]string{section, recordID, path, fmt.Sprint(v)})
+	}
+}
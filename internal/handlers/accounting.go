@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountingHandler struct {
+	accountingService service.AccountingService
+}
+
+func NewAccountingHandler(accountingService service.AccountingService) *AccountingHandler {
+	return &AccountingHandler{accountingService: accountingService}
+}
+
+// ExportJournal sends the journal entries for a date range as CSV, in the
+// column layout accounting tools import journals from. The range defaults to
+// the current month so far.
+func (h *AccountingHandler) ExportJournal(c *gin.Context) {
+	filter, err := parseAccountingFilter(c)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid filter parameters", err)
+		return
+	}
+
+	lines, err := h.accountingService.GetJournal(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	header := []string{"JournalNo", "JournalDate", "AccountName", "Debits", "Credits", "Description"}
+	streamCSV(c, "journal", header, func(write func([]string) error) error {
+		for _, line := range lines {
+			err := write([]string{
+				line.JournalNo,
+				line.Date.Format(time.DateOnly),
+				line.Account,
+				formatJournalAmount(line.Debit),
+				formatJournalAmount(line.Credit),
+				line.Description,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// parseAccountingFilter reads the journal range and period. Dates are
+// YYYY-MM-DD in UTC and both ends of the range are inclusive.
+func parseAccountingFilter(c *gin.Context) (models.AccountingFilter, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	filter := models.AccountingFilter{
+		From:   time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC),
+		To:     today.AddDate(0, 0, 1),
+		Period: c.Query("period"),
+	}
+
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return filter, errors.New("from must be a date in YYYY-MM-DD format")
+		}
+		filter.From = from
+	}
+
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return filter, errors.New("to must be a date in YYYY-MM-DD format")
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	return filter, nil
+}
+
+// formatJournalAmount leaves the side of a line that is not used blank, as
+// journal imports expect
+func formatJournalAmount(amount money.Money) string {
+	if amount.IsZero() {
+		return ""
+	}
+	return formatCSVAmount(amount)
+}
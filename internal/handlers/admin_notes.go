@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminNoteHandler serves staff's internal notes and tags on orders and users
+type AdminNoteHandler struct {
+	noteService service.AdminNoteService
+}
+
+func NewAdminNoteHandler(noteService service.AdminNoteService) *AdminNoteHandler {
+	return &AdminNoteHandler{noteService: noteService}
+}
+
+func (h *AdminNoteHandler) GetOrderNotes(c *gin.Context) {
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	notes, err := h.noteService.GetOrderNotes(c.Request.Context(), orderID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Notes retrieved successfully", gin.H{"notes": notes})
+}
+
+func (h *AdminNoteHandler) AddOrderNote(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AddAdminNoteRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	note, err := h.noteService.AddOrderNote(c.Request.Context(), orderID, adminID, req.Body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Note added", note)
+}
+
+func (h *AdminNoteHandler) DeleteOrderNote(c *gin.Context) {
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	noteID, ok := utils.ParseUUIDParam(c, "noteId")
+	if !ok {
+		return
+	}
+
+	if err := h.noteService.DeleteOrderNote(c.Request.Context(), orderID, noteID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Note deleted", nil)
+}
+
+func (h *AdminNoteHandler) AddOrderTags(c *gin.Context) {
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AddTagsRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	tags, err := h.noteService.AddOrderTags(c.Request.Context(), orderID, req.Tags)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Tags added", gin.H{"tags": tags})
+}
+
+func (h *AdminNoteHandler) RemoveOrderTag(c *gin.Context) {
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	tags, err := h.noteService.RemoveOrderTag(c.Request.Context(), orderID, c.Param("tag"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Tag removed", gin.H{"tags": tags})
+}
+
+func (h *AdminNoteHandler) GetUserNotes(c *gin.Context) {
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	notes, err := h.noteService.GetUserNotes(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Notes retrieved successfully", gin.H{"notes": notes})
+}
+
+func (h *AdminNoteHandler) AddUserNote(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AddAdminNoteRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	note, err := h.noteService.AddUserNote(c.Request.Context(), userID, adminID, req.Body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Note added", note)
+}
+
+func (h *AdminNoteHandler) DeleteUserNote(c *gin.Context) {
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	noteID, ok := utils.ParseUUIDParam(c, "noteId")
+	if !ok {
+		return
+	}
+
+	if err := h.noteService.DeleteUserNote(c.Request.Context(), userID, noteID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Note deleted", nil)
+}
+
+func (h *AdminNoteHandler) AddUserTags(c *gin.Context) {
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AddTagsRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	tags, err := h.noteService.AddUserTags(c.Request.Context(), userID, req.Tags)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Tags added", gin.H{"tags": tags})
+}
+
+func (h *AdminNoteHandler) RemoveUserTag(c *gin.Context) {
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	tags, err := h.noteService.RemoveUserTag(c.Request.Context(), userID, c.Param("tag"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Tag removed", gin.H{"tags": tags})
+}
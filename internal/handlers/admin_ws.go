@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/realtime"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type AdminWSHandler struct {
+	authService service.AuthService
+	hub         *realtime.Hub
+	upgrader    websocket.Upgrader
+}
+
+func NewAdminWSHandler(authService service.AuthService, hub *realtime.Hub, allowedOrigins []string) *AdminWSHandler {
+	origins := middleware.NewOriginMatcher(allowedOrigins)
+	return &AdminWSHandler{
+		authService: authService,
+		hub:         hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" {
+					return true
+				}
+				allowed, _ := origins.Allows(origin)
+				return allowed
+			},
+		},
+	}
+}
+
+// Connect upgrades an admin connection to the live dashboard feed. Browsers
+// cannot set headers on a WebSocket handshake, so the JWT may also be passed
+// as the token query parameter.
+func (h *AdminWSHandler) Connect(c *gin.Context) {
+	token := c.Query("token")
+	if header := c.GetHeader("Authorization"); header != "" {
+		parts := strings.Split(header, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			utils.GinUnauthorizedResponse(c, "Invalid token format")
+			return
+		}
+		token = parts[1]
+	}
+	if token == "" {
+		utils.GinUnauthorizedResponse(c, "Missing token")
+		return
+	}
+
+	user, err := h.authService.ValidateToken(c.Request.Context(), token)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, "Invalid token")
+		return
+	}
+	if user.Role != "admin" {
+		utils.GinForbiddenResponse(c, "Admin access required")
+		return
+	}
+
+	// Topics may be preselected with ?topics=orders,payments; default is all
+	topics := realtime.AllTopics()
+	if v := c.Query("topics"); v != "" {
+		topics = nil
+		for _, topic := range strings.Split(v, ",") {
+			topic = strings.TrimSpace(topic)
+			if !realtime.ValidTopic(topic) {
+				utils.GinBadRequestResponse(c, "Unknown topic: "+topic, nil)
+				return
+			}
+			topics = append(topics, topic)
+		}
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written the error response
+		log.Printf("⚠️ Dashboard WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	realtime.NewClient(h.hub, conn, topics).Run()
+}
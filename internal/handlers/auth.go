@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"ecommerce-backend/internal/middleware"
 	"ecommerce-backend/internal/models"
@@ -24,29 +25,22 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.RegisterRequest
 
-	// Parse request body
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
-
-	// Validate request
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	// Parse and validate request body
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	// Register user
 	user, err := h.AuthService.Register(c.Request.Context(), req)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Registration failed", err)
+		c.Error(err)
 		return
 	}
 
 	// Generate token
 	token, err := h.AuthService.GenerateToken(user)
 	if err != nil {
-		utils.GinInternalErrorResponse(c, "Failed to generate token", err)
+		c.Error(err)
 		return
 	}
 
@@ -61,22 +55,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
 
-	// Parse request body
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
-
-	// Validate request
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	// Parse and validate request body
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	// Login user
 	response, err := h.AuthService.Login(c.Request.Context(), req)
 	if err != nil {
-		utils.GinErrorResponse(c, http.StatusUnauthorized, "Login failed", err)
+		c.Error(err)
 		return
 	}
 
@@ -85,19 +72,19 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req models.RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	user, err := h.AuthService.ValidateToken(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, "Invalid refresh token")
 		return
 	}
 
-	user, err := h.AuthService.ValidateToken(req.RefreshToken)
-	if err != nil {
-		utils.GinUnauthorizedResponse(c, "Invalid refresh token")
+	// Impersonation sessions end when their token expires
+	if user.ImpersonatorID != nil {
+		utils.GinUnauthorizedResponse(c, "Impersonation tokens cannot be refreshed")
 		return
 	}
 
@@ -108,15 +95,84 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
+	if !fullUser.IsActive {
+		utils.GinForbiddenResponse(c, "Account is deactivated")
+		return
+	}
+
 	token, err := h.AuthService.GenerateToken(fullUser)
 	if err != nil {
-		utils.GinInternalErrorResponse(c, "Failed to generate token", err)
+		c.Error(err)
 		return
 	}
 
 	utils.GinSuccessResponse(c, "Token refreshed", gin.H{"access_token": token})
 }
 
+// OAuthLogin signs in with an ID token from Google or Apple, creating or
+// linking the account on first use
+func (h *AuthHandler) OAuthLogin(c *gin.Context) {
+	var req models.OAuthLoginRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.AuthService.OAuthLogin(c.Request.Context(), c.Param("provider"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Login successful", response)
+}
+
+// Logout revokes the bearer token of the request so it cannot be used again,
+// even by someone who copied it. It sits outside the protected routes so
+// maintenance mode cannot keep a customer signed in.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	parts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		utils.GinUnauthorizedResponse(c, "Missing bearer token")
+		return
+	}
+
+	if err := h.AuthService.Logout(c.Request.Context(), parts[1]); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Logged out successfully", nil)
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req models.VerifyEmailRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.AuthService.VerifyEmail(c.Request.Context(), req.Token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Email verified successfully", user)
+}
+
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req models.ResendVerificationRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.AuthService.ResendVerification(c.Request.Context(), req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "If the account exists, a verification email has been sent", nil)
+}
+
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userIDStr, err := middleware.GetUserIDFromGin(c)
@@ -142,11 +198,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	// Get user profile
 	user, err := h.AuthService.GetProfile(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"message": "User not found",
-			"error":   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -165,29 +217,10 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-		Email     string `json:"email" validate:"email"`
-	}
-
-	// Parse request body
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Invalid request body",
-			"error":   err.Error(),
-		})
-		return
-	}
+	var req models.UpdateProfileRequest
 
-	// Validate request
-	if errors := utils.ValidateStruct(req); errors != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Validation failed",
-			"errors":  errors,
-		})
+	// Parse and validate request body
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -206,20 +239,10 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		CurrentPassword string `json:"current_password" validate:"required"`
-		NewPassword     string `json:"new_password" validate:"required,min=6"`
-	}
-
-	// Parse request body
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
+	var req models.ChangePasswordRequest
 
-	// Validate request
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	// Parse and validate request body
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -242,16 +265,9 @@ func (h *AuthHandler) GetAllUsers(c *gin.Context) {
 		}
 	}
 
-	rangeDays := 0
-	if rd := c.Query("range_days"); rd != "" {
-		if parsed, err := strconv.Atoi(rd); err == nil && parsed > 0 {
-			rangeDays = parsed
-		}
-	}
-
-	users, total, err := h.AuthService.ListUsers(c.Request.Context(), page, limit, rangeDays)
+	users, total, err := h.AuthService.ListUsers(c.Request.Context(), parseUserFilter(c), page, limit)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to retrieve users", err)
+		c.Error(err)
 		return
 	}
 
@@ -268,28 +284,44 @@ func (h *AuthHandler) GetAllUsers(c *gin.Context) {
 	utils.GinSuccessResponse(c, "Users retrieved", response)
 }
 
+// ExportUsers streams the users matching the admin list filters as CSV
+func (h *AuthHandler) ExportUsers(c *gin.Context) {
+	filter := parseUserFilter(c)
+
+	header := []string{"id", "email", "first_name", "last_name", "role", "email_verified",
+		"email_verified_at", "is_active", "created_at"}
+
+	streamCSV(c, "users", header, func(write func([]string) error) error {
+		return h.AuthService.ExportUsers(c.Request.Context(), filter, func(user models.User) error {
+			return write([]string{
+				user.ID.String(),
+				user.Email,
+				user.FirstName,
+				user.LastName,
+				user.Role,
+				strconv.FormatBool(user.EmailVerified),
+				formatCSVTime(user.EmailVerifiedAt),
+				strconv.FormatBool(user.IsActive),
+				formatCSVTime(&user.CreatedAt),
+			})
+		})
+	})
+}
+
 func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
-	userID := c.Param("id")
-	userUUID, err := uuid.Parse(userID)
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+	userUUID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
 		return
 	}
 
 	var req models.UpdateUserRoleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
-
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	updatedUser, token, err := h.AuthService.UpdateUserRole(c.Request.Context(), userUUID, req.Role)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to update user role", err)
+		c.Error(err)
 		return
 	}
 
@@ -301,3 +333,180 @@ func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 
 	utils.GinSuccessResponse(c, "User role updated successfully", response)
 }
+
+func (h *AuthHandler) BulkUpdateUserRoles(c *gin.Context) {
+	adminIDStr, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req models.BulkUpdateUserRolesRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	updated, err := h.AuthService.BulkUpdateUserRoles(c.Request.Context(), adminID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "User roles updated successfully", gin.H{"updated": updated})
+}
+
+func (h *AuthHandler) UpdateUserStatus(c *gin.Context) {
+	adminIDStr, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateUserStatusRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.AuthService.SetUserActive(c.Request.Context(), adminID, userID, *req.Active)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "User status updated successfully", user)
+}
+
+func (h *AuthHandler) DeleteUser(c *gin.Context) {
+	adminIDStr, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.AuthService.DeleteUser(c.Request.Context(), adminID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "User deleted successfully", nil)
+}
+
+// RevokeUserSessions signs a user out of every device, e.g. after their
+// account was compromised
+func (h *AuthHandler) RevokeUserSessions(c *gin.Context) {
+	adminIDStr, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.AuthService.RevokeSessions(c.Request.Context(), adminID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "User sessions revoked successfully", nil)
+}
+
+// ImpersonateUser issues a support agent a short-lived token that views the
+// customer's cart and orders read-only
+func (h *AuthHandler) ImpersonateUser(c *gin.Context) {
+	adminIDStr, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	userID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ImpersonateUserRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	session, err := h.AuthService.Impersonate(c.Request.Context(), adminID, userID, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Impersonation token issued", session)
+}
+
+// parseUserFilter reads the admin user list filters: search, role,
+// status (active or inactive) and range_days
+func parseUserFilter(c *gin.Context) models.UserFilter {
+	filter := models.UserFilter{
+		Search: strings.TrimSpace(c.Query("search")),
+		Tag:    strings.TrimSpace(c.Query("tag")),
+	}
+
+	if role := c.Query("role"); role == models.RoleAdmin || role == models.RoleCustomer || role == models.RoleWarehouse {
+		filter.Role = role
+	}
+
+	switch c.Query("status") {
+	case "active":
+		active := true
+		filter.Active = &active
+	case "inactive":
+		active := false
+		filter.Active = &active
+	}
+
+	if rd := c.Query("range_days"); rd != "" {
+		if parsed, err := strconv.Atoi(rd); err == nil && parsed > 0 {
+			filter.RangeDays = parsed
+		}
+	}
+
+	return filter
+}
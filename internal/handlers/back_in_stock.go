@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BackInStockHandler struct {
+	backInStockService service.BackInStockService
+}
+
+func NewBackInStockHandler(backInStockService service.BackInStockService) *BackInStockHandler {
+	return &BackInStockHandler{backInStockService: backInStockService}
+}
+
+// NotifyMe subscribes the user to a one-off alert when an out-of-stock
+// product (or variant) becomes available. The request body is optional.
+func (h *BackInStockHandler) NotifyMe(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.NotifyMeRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.GinBadRequestResponse(c, "Invalid request body", err)
+			return
+		}
+	}
+
+	sub, err := h.backInStockService.Subscribe(c.Request.Context(), userUUID, productID, req.VariantID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Subscribed to back-in-stock alerts", sub)
+}
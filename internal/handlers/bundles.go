@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BundleHandler struct {
+	bundleService service.BundleService
+}
+
+func NewBundleHandler(bundleService service.BundleService) *BundleHandler {
+	return &BundleHandler{bundleService: bundleService}
+}
+
+// GetBundle lists a product's components, empty when it is not a bundle
+func (h *BundleHandler) GetBundle(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	components, err := h.bundleService.GetBundle(c.Request.Context(), productID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Bundle retrieved successfully", components)
+}
+
+// SetBundle replaces a product's components
+func (h *BundleHandler) SetBundle(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SetBundleRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	components, err := h.bundleService.SetBundle(c.Request.Context(), productID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Bundle updated successfully", components)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CarrierHandler struct {
+	carrierService service.CarrierService
+}
+
+func NewCarrierHandler(carrierService service.CarrierService) *CarrierHandler {
+	return &CarrierHandler{carrierService: carrierService}
+}
+
+// TrackingWebhook receives a carrier's tracking callback; the raw body is
+// required for signature verification
+func (h *CarrierHandler) TrackingWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid request body", err)
+		return
+	}
+
+	err = h.carrierService.HandleWebhook(c.Request.Context(), c.Param("carrier"), payload, c.GetHeader("X-Carrier-Signature"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Webhook processed", nil)
+}
+
+// GetEvents lists received carrier callbacks, newest first, filtered by
+// ?carrier=, ?tracking_number= and ?outcome=
+func (h *CarrierHandler) GetEvents(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	filter := models.CarrierEventFilter{
+		Carrier:        c.Query("carrier"),
+		TrackingNumber: c.Query("tracking_number"),
+		Outcome:        c.Query("outcome"),
+	}
+
+	events, total, err := h.carrierService.GetEvents(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"events": events,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "Carrier events retrieved successfully", response)
+}
@@ -35,7 +35,7 @@ func (h *CartHandler) GetCart(c *gin.Context) {
 	// Get cart from service
 	cart, err := h.cartService.GetCart(c.Request.Context(), userUUID)
 	if err != nil {
-		utils.GinNotFoundResponse(c, "Cart")
+		c.Error(err)
 		return
 	}
 
@@ -59,7 +59,7 @@ func (h *CartHandler) ValidateCart(c *gin.Context) {
 	// Get cart to validate
 	cart, err := h.cartService.GetCart(c.Request.Context(), userUUID)
 	if err != nil {
-		utils.GinNotFoundResponse(c, "Cart")
+		c.Error(err)
 		return
 	}
 
@@ -69,7 +69,7 @@ func (h *CartHandler) ValidateCart(c *gin.Context) {
 	// Validate cart via service
 	isValid, errors, err := h.cartService.ValidateCart(c.Request.Context(), cartID)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to validate cart", err)
+		c.Error(err)
 		return
 	}
 
@@ -91,21 +91,40 @@ func (h *CartHandler) ValidateCart(c *gin.Context) {
 	})
 }
 
-func (h *CartHandler) AddToCart(c *gin.Context) {
+// ResolveCart fixes the lines that would fail validation and reports what
+// changed
+func (h *CartHandler) ResolveCart(c *gin.Context) {
 	userID, err := middleware.GetUserIDFromGin(c)
 	if err != nil {
 		utils.GinUnauthorizedResponse(c, err.Error())
 		return
 	}
 
-	var req models.AddToCartRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
+	// Parse UUID
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	resolution, err := h.cartService.ResolveCart(c.Request.Context(), userUUID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	utils.GinSuccessResponse(c, "Cart resolved successfully", resolution)
+}
+
+func (h *CartHandler) AddToCart(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	var req models.AddToCartRequest
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -119,7 +138,7 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 	// Add to cart via service
 	cart, err := h.cartService.AddToCart(c.Request.Context(), userUUID, req)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to add item to cart", err)
+		c.Error(err)
 		return
 	}
 
@@ -133,40 +152,27 @@ func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 		return
 	}
 
-	itemID := c.Param("itemId")
-	if itemID == "" {
-		utils.GinBadRequestResponse(c, "Item ID is required", nil)
+	itemUUID, ok := utils.ParseUUIDParam(c, "itemId")
+	if !ok {
 		return
 	}
 
 	var req models.UpdateCartItemRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
-		return
-	}
-
-	// Parse UUIDs
+	// Parse user ID
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		utils.GinBadRequestResponse(c, "Invalid user ID", err)
 		return
 	}
 
-	itemUUID, err := uuid.Parse(itemID)
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid item ID", err)
-		return
-	}
-
 	// Update cart item via service
 	cart, err := h.cartService.UpdateCartItem(c.Request.Context(), userUUID, itemUUID, req)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to update cart item", err)
+		c.Error(err)
 		return
 	}
 
@@ -180,29 +186,22 @@ func (h *CartHandler) RemoveFromCart(c *gin.Context) {
 		return
 	}
 
-	itemID := c.Param("itemId")
-	if itemID == "" {
-		utils.GinBadRequestResponse(c, "Item ID is required", nil)
+	itemUUID, ok := utils.ParseUUIDParam(c, "itemId")
+	if !ok {
 		return
 	}
 
-	// Parse UUIDs
+	// Parse user ID
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		utils.GinBadRequestResponse(c, "Invalid user ID", err)
 		return
 	}
 
-	itemUUID, err := uuid.Parse(itemID)
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid item ID", err)
-		return
-	}
-
 	// Remove from cart via service
 	cart, err := h.cartService.RemoveFromCart(c.Request.Context(), userUUID, itemUUID)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to remove item from cart", err)
+		c.Error(err)
 		return
 	}
 
@@ -226,7 +225,7 @@ func (h *CartHandler) ClearCart(c *gin.Context) {
 	// Clear cart via service
 	err = h.cartService.ClearCart(c.Request.Context(), userUUID)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to clear cart", err)
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service/servicemock"
+
+	"github.com/google/uuid"
+)
+
+// newCartService returns cart and err from every call the cart handlers
+// make. Calls it does not set up panic.
+func newCartService(cart *models.Cart, err error) *servicemock.CartServiceMock {
+	return &servicemock.CartServiceMock{
+		GetCartFunc: func(ctx context.Context, userID uuid.UUID) (*models.Cart, error) {
+			return cart, err
+		},
+		AddToCartFunc: func(ctx context.Context, userID uuid.UUID, req models.AddToCartRequest) (*models.Cart, error) {
+			return cart, err
+		},
+		UpdateCartItemFunc: func(ctx context.Context, userID, itemID uuid.UUID, req models.UpdateCartItemRequest) (*models.Cart, error) {
+			return cart, err
+		},
+		RemoveFromCartFunc: func(ctx context.Context, userID, itemID uuid.UUID) (*models.Cart, error) {
+			return cart, err
+		},
+		ClearCartFunc: func(ctx context.Context, userID uuid.UUID) error {
+			return err
+		},
+	}
+}
+
+func TestCartHandlerGetCart(t *testing.T) {
+	cart := &models.Cart{ID: uuid.New(), UserID: uuid.MustParse(testUserID)}
+
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "malformed user ID", userID: "not-a-uuid", wantStatus: http.StatusBadRequest}, nil},
+		{handlerCase{name: "no cart", userID: testUserID, wantStatus: http.StatusNotFound, wantError: "not_found"}, apperrors.NotFound("cart not found")},
+		{handlerCase{name: "unexpected failure", userID: testUserID, wantStatus: http.StatusInternalServerError, wantError: "internal_error"}, context.DeadlineExceeded},
+		{handlerCase{name: "ok", userID: testUserID, wantStatus: http.StatusOK}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newCartService(cart, tt.err)
+			tt.method, tt.path = http.MethodGet, "/cart"
+
+			_, resp := serve(t, "/cart", NewCartHandler(svc).GetCart, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := svc.GetCartCalls()[0].UserID; got != cart.UserID {
+				t.Errorf("service asked for user %s, want %s", got, cart.UserID)
+			}
+			var got models.Cart
+			decodeData(t, resp, &got)
+			if got.ID != cart.ID {
+				t.Errorf("cart ID = %s, want %s", got.ID, cart.ID)
+			}
+		})
+	}
+}
+
+func TestCartHandlerAddToCart(t *testing.T) {
+	productID := uuid.New()
+
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", body: `{"product_id":"` + productID.String() + `","quantity":1}`, wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "malformed JSON", userID: testUserID, body: `{"product_id":`, wantStatus: http.StatusBadRequest}, nil},
+		{handlerCase{name: "missing product", userID: testUserID, body: `{"quantity":1}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "zero quantity", userID: testUserID, body: `{"product_id":"` + productID.String() + `","quantity":0}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "out of stock", userID: testUserID, body: `{"product_id":"` + productID.String() + `","quantity":3}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, apperrors.Validation("insufficient stock")},
+		{handlerCase{name: "ok", userID: testUserID, body: `{"product_id":"` + productID.String() + `","quantity":3}`, wantStatus: http.StatusCreated}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newCartService(&models.Cart{ID: uuid.New()}, tt.err)
+			tt.method, tt.path = http.MethodPost, "/cart/items"
+
+			serve(t, "/cart/items", NewCartHandler(svc).AddToCart, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			want := models.AddToCartRequest{ProductID: productID, Quantity: 3}
+			if got := svc.AddToCartCalls()[0].Req; got.ProductID != want.ProductID || got.Quantity != want.Quantity {
+				t.Errorf("service got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCartHandlerUpdateAndRemoveItem(t *testing.T) {
+	itemID := uuid.New()
+
+	tests := []struct {
+		handlerCase
+		remove bool
+		err    error
+	}{
+		{handlerCase{name: "update unauthenticated", path: "/cart/items/" + itemID.String(), body: `{"quantity":2}`, wantStatus: http.StatusUnauthorized}, false, nil},
+		{handlerCase{name: "update malformed item ID", userID: testUserID, path: "/cart/items/42", body: `{"quantity":2}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, false, nil},
+		{handlerCase{name: "update negative quantity", userID: testUserID, path: "/cart/items/" + itemID.String(), body: `{"quantity":-1}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, false, nil},
+		{handlerCase{name: "update missing item", userID: testUserID, path: "/cart/items/" + itemID.String(), body: `{"quantity":2}`, wantStatus: http.StatusNotFound, wantError: "not_found"}, false, apperrors.NotFound("cart item not found")},
+		{handlerCase{name: "update ok", userID: testUserID, path: "/cart/items/" + itemID.String(), body: `{"quantity":2}`, wantStatus: http.StatusOK}, false, nil},
+		{handlerCase{name: "remove malformed item ID", userID: testUserID, path: "/cart/items/42", wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, true, nil},
+		{handlerCase{name: "remove ok", userID: testUserID, path: "/cart/items/" + itemID.String(), wantStatus: http.StatusOK}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newCartService(&models.Cart{ID: uuid.New()}, tt.err)
+			handler := NewCartHandler(svc)
+			if tt.remove {
+				tt.method = http.MethodDelete
+				serve(t, "/cart/items/:itemId", handler.RemoveFromCart, tt.handlerCase)
+			} else {
+				tt.method = http.MethodPut
+				serve(t, "/cart/items/:itemId", handler.UpdateCartItem, tt.handlerCase)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if tt.remove {
+				if got := svc.RemoveFromCartCalls()[0].ItemID; got != itemID {
+					t.Errorf("service got item %s, want %s", got, itemID)
+				}
+				return
+			}
+			call := svc.UpdateCartItemCalls()[0]
+			if call.ItemID != itemID {
+				t.Errorf("service got item %s, want %s", call.ItemID, itemID)
+			}
+			if call.Req.Quantity != 2 {
+				t.Errorf("service got quantity %d, want 2", call.Req.Quantity)
+			}
+		})
+	}
+}
+
+func TestCartHandlerClearCart(t *testing.T) {
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "ok", userID: testUserID, wantStatus: http.StatusOK}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newCartService(nil, tt.err)
+			tt.method, tt.path = http.MethodDelete, "/cart"
+
+			serve(t, "/cart", NewCartHandler(svc).ClearCart, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := svc.ClearCartCalls()[0].UserID; got.String() != testUserID {
+				t.Errorf("service cleared user %s's cart, want %s", got, testUserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CheckoutHandler struct {
+	checkoutService service.CheckoutService
+}
+
+func NewCheckoutHandler(checkoutService service.CheckoutService) *CheckoutHandler {
+	return &CheckoutHandler{checkoutService: checkoutService}
+}
+
+// CreateSession starts checkout from the current cart
+func (h *CheckoutHandler) CreateSession(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateCheckoutSessionRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	session, err := h.checkoutService.CreateSession(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Checkout session created", session)
+}
+
+func (h *CheckoutHandler) GetSession(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	session, err := h.checkoutService.GetSession(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Checkout session retrieved", session)
+}
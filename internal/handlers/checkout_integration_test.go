@@ -0,0 +1,149 @@
+//go:build integration
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/money"
+)
+
+func TestCheckoutDeductsStock(t *testing.T) {
+	app := newTestApp(t)
+	product := app.createProduct(t, 5)
+	customer := app.createCustomer(t)
+
+	app.addToCart(t, customer.ID, product.ID, 2)
+
+	order, err := app.placeOrder(customer.ID)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if len(order.Items) != 1 || order.Items[0].Quantity != 2 {
+		t.Errorf("order items = %+v, want 2 of the product", order.Items)
+	}
+	if want := money.FromMajor(20); order.TotalAmount != want {
+		t.Errorf("total = %s, want %s", order.TotalAmount, want)
+	}
+	if got := app.stock(t, product.ID); got != 3 {
+		t.Errorf("stock = %d, want 3", got)
+	}
+
+	// The reservation was used up with the cart
+	var reserved int
+	err = app.db.QueryRow(context.Background(),
+		"SELECT COALESCE(SUM(quantity), 0) FROM stock_reservations WHERE product_id = $1", product.ID).Scan(&reserved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reserved != 0 {
+		t.Errorf("reserved = %d after checkout, want 0", reserved)
+	}
+
+	cart, err := app.repos.CartHandler.cartService.GetCart(context.Background(), customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cart.Items) != 0 {
+		t.Errorf("cart has %d items after checkout, want 0", len(cart.Items))
+	}
+}
+
+func TestCheckoutRefusesStockSoldSinceReservationExpired(t *testing.T) {
+	app := newTestApp(t)
+	product := app.createProduct(t, 1)
+	first := app.createCustomer(t)
+	second := app.createCustomer(t)
+
+	// The first customer's reservation lapses, so the second can take the
+	// last unit and check out
+	app.addToCart(t, first.ID, product.ID, 1)
+	app.expireReservations(t, first.ID)
+	app.addToCart(t, second.ID, product.ID, 1)
+	if _, err := app.placeOrder(second.ID); err != nil {
+		t.Fatalf("CreateOrder for second customer: %v", err)
+	}
+
+	_, err := app.placeOrder(first.ID)
+	if !errors.Is(err, apperrors.ErrValidation) {
+		t.Fatalf("CreateOrder for first customer: err = %v, want a validation error", err)
+	}
+	if got := app.stock(t, product.ID); got != 0 {
+		t.Errorf("stock = %d, want 0", got)
+	}
+}
+
+func TestRefundsAccumulateUpToPayment(t *testing.T) {
+	app := newTestApp(t)
+	ctx := context.Background()
+	payments := app.repos.PaymentHandler.paymentService
+
+	product := app.createProduct(t, 1)
+	customer := app.createCustomer(t)
+	app.addToCart(t, customer.ID, product.ID, 1)
+	order, err := app.placeOrder(customer.ID)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	// Cash is collected on delivery
+	payment, err := payments.CreatePaymentForOrder(ctx, order.ID, "cod", models.PaymentCompleted)
+	if err != nil {
+		t.Fatalf("CreatePaymentForOrder: %v", err)
+	}
+
+	steps := []struct {
+		name       string
+		amount     money.Money
+		wantErr    error
+		wantStatus models.PaymentStatus
+	}{
+		{"partial refund", money.FromMajor(4), nil, models.PaymentPartiallyRefunded},
+		{"more than remains", money.FromMajor(7), apperrors.ErrValidation, models.PaymentPartiallyRefunded},
+		{"zero", money.Money(0), apperrors.ErrValidation, models.PaymentPartiallyRefunded},
+		{"the rest", money.FromMajor(6), nil, models.PaymentRefunded},
+		{"after full refund", money.FromMajor(1), apperrors.ErrConflict, models.PaymentRefunded},
+	}
+	for _, step := range steps {
+		err := payments.ProcessRefund(ctx, &models.Refund{PaymentID: payment.ID, Amount: step.amount, Reason: step.name})
+		if step.wantErr == nil && err != nil {
+			t.Fatalf("%s: ProcessRefund: %v", step.name, err)
+		}
+		if step.wantErr != nil && !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: ProcessRefund: err = %v, want %v", step.name, err, step.wantErr)
+		}
+
+		refreshed, err := payments.GetPaymentByOrderID(ctx, order.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if refreshed.Status != step.wantStatus {
+			t.Errorf("%s: payment status = %s, want %s", step.name, refreshed.Status, step.wantStatus)
+		}
+	}
+
+	refunds, err := payments.GetOrderRefunds(ctx, order.ID, customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total money.Money
+	for _, refund := range refunds {
+		total = total.Add(refund.Amount)
+	}
+	if len(refunds) != 2 || total != payment.Amount {
+		t.Errorf("refunds = %d totalling %s, want 2 totalling %s", len(refunds), total, payment.Amount)
+	}
+
+	placed, err := app.repos.OrderHandler.orderService.GetOrder(ctx, order.ID, customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placed.Status != models.OrderRefunded {
+		t.Errorf("order status = %s, want %s", placed.Status, models.OrderRefunded)
+	}
+}
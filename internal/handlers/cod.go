@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CODHandler struct {
+	codService   service.CODService
+	orderService service.OrderService
+}
+
+func NewCODHandler(codService service.CODService, orderService service.OrderService) *CODHandler {
+	return &CODHandler{codService: codService, orderService: orderService}
+}
+
+// CheckEligibility reports whether the current cart can be paid cash on
+// delivery to the given postal code
+func (h *CODHandler) CheckEligibility(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	eligibility, err := h.codService.CheckCartEligibility(c.Request.Context(), userUUID, c.Query("postal_code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "COD eligibility checked", eligibility)
+}
+
+func (h *CODHandler) ConfirmDelivery(c *gin.Context) {
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ConfirmCODDeliveryRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.orderService.ConfirmCODDelivery(c.Request.Context(), orderID, req.OTP); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Delivery confirmed and payment recorded", nil)
+}
+
+func (h *CODHandler) ResendOTP(c *gin.Context) {
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.codService.ResendDeliveryOTP(c.Request.Context(), orderID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Delivery code sent to the customer", nil)
+}
+
+func (h *CODHandler) GetRemittances(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	status := c.Query("status")
+
+	rangeDays := 0
+	if rd := c.Query("range_days"); rd != "" {
+		if parsed, err := strconv.Atoi(rd); err == nil && parsed > 0 {
+			rangeDays = parsed
+		}
+	}
+
+	report, total, err := h.codService.GetRemittanceReport(c.Request.Context(), status, rangeDays, page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"totals":      report.Totals,
+		"remittances": report.Remittances,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "COD remittances retrieved", response)
+}
+
+func (h *CODHandler) MarkRemitted(c *gin.Context) {
+	var req models.RemitCODRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	updated, err := h.codService.MarkRemitted(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Remittances marked as remitted", map[string]interface{}{"updated": updated})
+}
@@ -0,0 +1,52 @@
+//go:build integration
+
+package handlers
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+)
+
+func TestConcurrentCheckoutsOfLastUnit(t *testing.T) {
+	app := newTestApp(t)
+	product := app.createProduct(t, 1)
+
+	// Both carts hold the last unit with lapsed reservations, so only the
+	// stock lock decides which checkout gets it
+	customers := []*models.User{app.createCustomer(t), app.createCustomer(t)}
+	for _, customer := range customers {
+		app.addToCart(t, customer.ID, product.ID, 1)
+		app.expireReservations(t, customer.ID)
+	}
+
+	errs := make([]error, len(customers))
+	var wg sync.WaitGroup
+	for i, customer := range customers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = app.placeOrder(customer.ID)
+		}()
+	}
+	wg.Wait()
+
+	placed := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			placed++
+		case !errors.Is(err, apperrors.ErrValidation):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if placed != 1 {
+		t.Errorf("%d orders placed for the last unit, want 1", placed)
+	}
+	if got := app.stock(t, product.ID); got != 0 {
+		t.Errorf("stock = %d, want 0", got)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondConditional sends a success response that clients and CDNs may
+// cache and revalidate. The ETag is a hash of the encoded response, so it
+// changes whenever anything in it does: an edit (which bumps updated_at), a
+// scheduled price starting or ending, or stock being reserved. Last-Modified
+// carries the newest updated_at for clients that display it. A request whose
+// If-None-Match names the current ETag gets 304 with no body.
+func respondConditional(c *gin.Context, cacheControl, message string, data interface{}, lastModified time.Time) {
+	body, err := json.Marshal(utils.GinResponseData{
+		Success: true,
+		Message: message,
+		Data:    data,
+	})
+	if err != nil {
+		utils.GinInternalErrorResponse(c, "Failed to encode response", err)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := c.Writer.Header()
+	header.Set("Cache-Control", cacheControl)
+	header.Del("Pragma")
+	header.Del("Expires")
+	header.Set("ETag", etag)
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// edgeCacheControl is the Cache-Control of a catalog response. Browsers
+// revalidate every use; with an edge TTL, shared caches such as a CDN serve
+// it that long without asking and as long again while revalidating in the
+// background. Without one they revalidate every use too.
+func edgeCacheControl(edgeTTL time.Duration) string {
+	if edgeTTL <= 0 {
+		return "public, no-cache"
+	}
+	seconds := strconv.Itoa(int(edgeTTL.Seconds()))
+	return "public, max-age=0, s-maxage=" + seconds + ", stale-while-revalidate=" + seconds
+}
+
+// etagMatches applies If-None-Match's weak comparison: any listed tag, with
+// or without the W/ prefix, or "*" matches
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// productsLastModified is the newest updated_at across products and their
+// variants
+func productsLastModified(products ...models.Product) time.Time {
+	var latest time.Time
+	for _, product := range products {
+		if product.UpdatedAt.After(latest) {
+			latest = product.UpdatedAt
+		}
+		for _, variant := range product.Variants {
+			if variant.UpdatedAt.After(latest) {
+				latest = variant.UpdatedAt
+			}
+		}
+	}
+	return latest
+}
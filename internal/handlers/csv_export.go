@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// csvFlushEvery is how many rows are buffered before being pushed to the client
+const csvFlushEvery = 200
+
+// streamCSV sends a CSV attachment whose rows are produced incrementally.
+// Errors before any bytes reach the client become a normal JSON error; after
+// that the response can only be cut short.
+func streamCSV(c *gin.Context, name string, header []string, produce func(write func([]string) error) error) {
+	filename := fmt.Sprintf("%s-%s.csv", name, time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	writer := csv.NewWriter(c.Writer)
+	rows := 0
+	write := func(record []string) error {
+		for i, field := range record {
+			record[i] = sanitizeCSVField(field)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		rows++
+		if rows%csvFlushEvery == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+			return writer.Error()
+		}
+		return nil
+	}
+
+	err := write(header)
+	if err == nil {
+		err = produce(write)
+	}
+	if err == nil {
+		writer.Flush()
+		err = writer.Error()
+	}
+
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			utils.GinInternalErrorResponse(c, "Failed to export "+name, err)
+			return
+		}
+		log.Printf("❌ CSV export %s aborted after %d rows: %v", name, rows-1, err)
+		c.Abort()
+	}
+}
+
+// sanitizeCSVField neutralises values a spreadsheet would evaluate as a formula
+func sanitizeCSVField(field string) string {
+	if field == "" || !strings.ContainsRune("=+-@\t\r", rune(field[0])) {
+		return field
+	}
+	if _, err := strconv.ParseFloat(field, 64); err == nil {
+		return field
+	}
+	return "'" + field
+}
+
+func formatCSVAmount(amount money.Money) string {
+	return amount.String()
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DebugHandler struct {
+	capture *middleware.RequestCapture
+}
+
+func NewDebugHandler(capture *middleware.RequestCapture) *DebugHandler {
+	return &DebugHandler{capture: capture}
+}
+
+// GetCapturedRequests lists the failing requests this instance captured,
+// newest first, optionally only those with the given status
+func (h *DebugHandler) GetCapturedRequests(c *gin.Context) {
+	if !h.capture.Enabled() {
+		utils.GinSuccessResponse(c, "Request capture is disabled; set DEBUG_CAPTURE_REQUESTS to enable it", []models.CapturedRequest{})
+		return
+	}
+
+	status := 0
+	if s := c.Query("status"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil {
+			utils.GinBadRequestResponse(c, "Invalid status", err)
+			return
+		}
+		status = parsed
+	}
+
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	utils.GinSuccessResponse(c, "Captured requests retrieved successfully", h.capture.Recent(status, limit))
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeliveryHandler struct {
+	deliveryService service.DeliveryService
+}
+
+func NewDeliveryHandler(deliveryService service.DeliveryService) *DeliveryHandler {
+	return &DeliveryHandler{deliveryService: deliveryService}
+}
+
+// EstimateDelivery estimates when the current cart would arrive at a postal
+// code, for the requested shipping method or each available one
+func (h *DeliveryHandler) EstimateDelivery(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.DeliveryEstimateRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	estimate, err := h.deliveryService.EstimateCart(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Delivery estimated", estimate)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ERPHandler struct {
+	erpSyncService service.ERPSyncService
+}
+
+func NewERPHandler(erpSyncService service.ERPSyncService) *ERPHandler {
+	return &ERPHandler{erpSyncService: erpSyncService}
+}
+
+// GetStockMessages lists stock messages received from the ERP, newest first,
+// filtered by ?sku= and ?outcome=
+func (h *ERPHandler) GetStockMessages(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	filter := models.ERPStockMessageFilter{
+		SKU:     c.Query("sku"),
+		Outcome: c.Query("outcome"),
+	}
+
+	messages, total, err := h.erpSyncService.GetStockMessages(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"messages": messages,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "ERP stock messages retrieved successfully", response)
+}
+
+func (h *ERPHandler) ReplayStockMessage(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	message, err := h.erpSyncService.ReplayStockMessage(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Stock message replayed", message)
+}
+
+func (h *ERPHandler) ReplayEvents(c *gin.Context) {
+	var req models.ReplayERPEventsRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.erpSyncService.ReplayEvents(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Order events replayed to the ERP", result)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeatureFlagHandler struct {
+	flagService service.FlagService
+}
+
+func NewFeatureFlagHandler(flagService service.FlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{flagService: flagService}
+}
+
+func (h *FeatureFlagHandler) CreateFlag(c *gin.Context) {
+	var req models.CreateFeatureFlagRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	flag, err := h.flagService.CreateFlag(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Feature flag created successfully", flag)
+}
+
+func (h *FeatureFlagHandler) GetFlags(c *gin.Context) {
+	flags, err := h.flagService.GetFlags(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Feature flags retrieved successfully", flags)
+}
+
+func (h *FeatureFlagHandler) GetFlag(c *gin.Context) {
+	flag, err := h.flagService.GetFlag(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Feature flag retrieved successfully", flag)
+}
+
+// UpdateFlag replaces every setting of the flag
+func (h *FeatureFlagHandler) UpdateFlag(c *gin.Context) {
+	var req models.FeatureFlagRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	flag, err := h.flagService.UpdateFlag(c.Request.Context(), c.Param("key"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Feature flag updated successfully", flag)
+}
+
+func (h *FeatureFlagHandler) DeleteFlag(c *gin.Context) {
+	if err := h.flagService.DeleteFlag(c.Request.Context(), c.Param("key")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Feature flag deleted successfully", nil)
+}
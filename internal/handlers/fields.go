@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// selectFields trims every item of a list response to the top-level fields
+// named in ?fields=id,name,price so clients on slow links fetch only what
+// they show. Without the parameter items is returned as is. id is always
+// kept so rows can still be keyed. Names are the item's JSON field names;
+// unknown ones get a 422 and ok is false.
+func selectFields(c *gin.Context, items interface{}) (interface{}, bool) {
+	param := strings.TrimSpace(c.Query("fields"))
+	if param == "" {
+		return items, true
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(items))
+	wanted := map[string]bool{"id": true}
+	var unknown []string
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		wanted[name] = true
+	}
+
+	if len(unknown) > 0 {
+		allowed := make([]string, 0, len(known))
+		for name := range known {
+			allowed = append(allowed, name)
+		}
+		sort.Strings(allowed)
+		utils.GinValidationErrorResponse(c, map[string]string{
+			"fields": "Unknown field(s) " + strings.Join(unknown, ", ") + "; allowed: " + strings.Join(allowed, ", "),
+		})
+		return nil, false
+	}
+
+	// Round-trip through JSON so the projection sees exactly what the client
+	// would, including omitted empty fields
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		utils.GinInternalErrorResponse(c, "Failed to encode response", err)
+		return nil, false
+	}
+
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &rows); err != nil {
+		utils.GinInternalErrorResponse(c, "Failed to encode response", err)
+		return nil, false
+	}
+
+	for _, row := range rows {
+		for name := range row {
+			if !wanted[name] {
+				delete(row, name)
+			}
+		}
+	}
+	if rows == nil {
+		rows = []map[string]json.RawMessage{}
+	}
+
+	return rows, true
+}
+
+// jsonFieldNames lists the JSON names of the top-level fields of a slice's
+// element struct
+func jsonFieldNames(sliceType reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	if sliceType == nil || sliceType.Kind() != reflect.Slice {
+		return names
+	}
+
+	elem := sliceType.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+
+	return names
+}
+
+// parseInclude reads ?include=items,... naming related data a list endpoint
+// should load alongside its rows. Anything outside allowed gets a 422 and ok
+// is false.
+func parseInclude(c *gin.Context, allowed ...string) (map[string]bool, bool) {
+	included := map[string]bool{}
+	param := strings.TrimSpace(c.Query("include"))
+	if param == "" {
+		return included, true
+	}
+
+	var unknown []string
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, a := range allowed {
+			if name == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			unknown = append(unknown, name)
+			continue
+		}
+		included[name] = true
+	}
+
+	if len(unknown) > 0 {
+		utils.GinValidationErrorResponse(c, map[string]string{
+			"include": "Unknown include(s) " + strings.Join(unknown, ", ") + "; allowed: " + strings.Join(allowed, ", "),
+		})
+		return nil, false
+	}
+
+	return included, true
+}
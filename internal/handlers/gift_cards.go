@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GiftCardHandler struct {
+	giftCardService service.GiftCardService
+}
+
+func NewGiftCardHandler(giftCardService service.GiftCardService) *GiftCardHandler {
+	return &GiftCardHandler{giftCardService: giftCardService}
+}
+
+func (h *GiftCardHandler) IssueGiftCard(c *gin.Context) {
+	var req models.IssueGiftCardRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	card, err := h.giftCardService.IssueGiftCard(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Gift card issued successfully", card)
+}
+
+func (h *GiftCardHandler) GetGiftCards(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	cards, total, err := h.giftCardService.GetGiftCards(c.Request.Context(), page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"gift_cards": cards,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "Gift cards retrieved successfully", response)
+}
+
+func (h *GiftCardHandler) GetGiftCard(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	card, err := h.giftCardService.GetGiftCard(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Gift card retrieved successfully", card)
+}
+
+func (h *GiftCardHandler) CheckBalance(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	balance, err := h.giftCardService.CheckBalance(c.Request.Context(), userUUID, c.Param("code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Gift card balance retrieved successfully", balance)
+}
+
+func (h *GiftCardHandler) GetStoreCredit(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	credit, err := h.giftCardService.GetStoreCredit(c.Request.Context(), userUUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Store credit retrieved successfully", credit)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	binding.Validator = utils.GinValidator{}
+}
+
+// testUserID is the user the fake auth middleware signs requests in as
+const testUserID = "6f1c2d3e-4a5b-4c6d-8e7f-901a2b3c4d5e"
+
+// handlerCase is one request to a handler and the response it should get
+type handlerCase struct {
+	name       string
+	userID     string // empty sends the request unauthenticated
+	role       string
+	method     string
+	path       string
+	body       string
+	wantStatus int
+	wantError  string // the response's error code, when checked
+}
+
+// newRouter serves route with handler behind the error middleware, as the
+// server does, and a stand-in for the auth middleware that signs requests in
+// as userID with role
+func newRouter(method, route string, handler gin.HandlerFunc, userID, role string) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.GinErrorHandler())
+	router.Use(func(c *gin.Context) {
+		if userID != "" {
+			c.Set(middleware.GinUserIDKey, userID)
+			c.Set(middleware.GinUserRoleKey, role)
+		}
+		c.Next()
+	})
+	router.Handle(method, route, handler)
+
+	return router
+}
+
+// serve sends tc to handler mounted at route and checks the status and error
+// code of the response, which it returns decoded. Redirects are returned
+// undecoded.
+func serve(t *testing.T, route string, handler gin.HandlerFunc, tc handlerCase) (*httptest.ResponseRecorder, utils.GinResponseData) {
+	t.Helper()
+
+	role := tc.role
+	if role == "" {
+		role = "customer"
+	}
+
+	req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+	if tc.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rec := httptest.NewRecorder()
+	newRouter(tc.method, route, handler, tc.userID, role).ServeHTTP(rec, req)
+
+	if rec.Code != tc.wantStatus {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, tc.wantStatus, rec.Body)
+	}
+
+	var resp utils.GinResponseData
+	if rec.Code == http.StatusFound {
+		return rec, resp
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not JSON: %v; body: %s", err, rec.Body)
+	}
+	if resp.Success != (rec.Code < 400) {
+		t.Errorf("success = %v for status %d", resp.Success, rec.Code)
+	}
+	if tc.wantError != "" && resp.Error != tc.wantError {
+		t.Errorf("error = %q, want %q", resp.Error, tc.wantError)
+	}
+
+	return rec, resp
+}
+
+// decodeData re-decodes the data of a successful response into v
+func decodeData(t *testing.T, resp utils.GinResponseData, v any) {
+	t.Helper()
+
+	raw, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		t.Fatalf("failed to decode data: %v", err)
+	}
+}
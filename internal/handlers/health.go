@@ -1,77 +1,191 @@
 package handlers
 
 import (
-	"context"
+	"math"
 	"net/http"
 	"time"
 
+	"ecommerce-backend/internal/gateway"
+	"ecommerce-backend/internal/health"
+	"ecommerce-backend/internal/notifications"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/database"
+
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type HealthHandler struct {
-	db *pgxpool.Pool
+	db                 *pgxpool.Pool
+	replica            *database.Replica
+	startup            *health.Startup
+	dependencies       []health.Dependency
+	reservationCleanup service.ReservationCleanupService
 }
 
-func NewHealthHandler(db *pgxpool.Pool) *HealthHandler {
-	return &HealthHandler{db: db}
+// NewHealthHandler reports on the database, the read replica when one is
+// configured, the payment gateway when payments are not simulated and the
+// mailer. Only the database is critical: without it no request can be served.
+func NewHealthHandler(
+	db *pgxpool.Pool,
+	replica *database.Replica,
+	paymentGateway gateway.PaymentGateway,
+	mailer notifications.Mailer,
+	startup *health.Startup,
+	reservationCleanup service.ReservationCleanupService,
+) *HealthHandler {
+	dependencies := []health.Dependency{
+		{Name: "database", Provider: "postgres", Critical: true, Check: db.Ping},
+	}
+	if replica != nil {
+		dependencies = append(dependencies, health.Dependency{Name: "replica", Provider: "postgres", Check: replica.Ping})
+	}
+	if paymentGateway != nil {
+		dependencies = append(dependencies, health.Dependency{Name: "payment_gateway", Provider: paymentGateway.Name(), Check: paymentGateway.Ping})
+	}
+	dependencies = append(dependencies, health.Dependency{Name: "mail", Provider: mailer.Name(), Check: mailer.Ping})
+
+	return &HealthHandler{
+		db:                 db,
+		replica:            replica,
+		startup:            startup,
+		dependencies:       dependencies,
+		reservationCleanup: reservationCleanup,
+	}
 }
 
+// HealthCheck checks every dependency and reports each one's status and
+// latency. It fails only when a critical dependency is down; others being
+// down degrade the status.
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
-	health := map[string]interface{}{
-		"status":    "healthy",
-		"timestamp": time.Now().UTC(),
-		"service":   "ecommerce-backend",
-		"version":   "1.0.0",
+	results := health.CheckAll(c.Request.Context(), h.dependencies)
+	status := health.Overall(results)
+
+	code := http.StatusOK
+	if status == health.StatusUnhealthy {
+		code = http.StatusServiceUnavailable
 	}
 
-	// Check database connection
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
+	c.JSON(code, map[string]interface{}{
+		"status":       status,
+		"timestamp":    time.Now().UTC(),
+		"service":      "ecommerce-backend",
+		"version":      "1.0.0",
+		"dependencies": results,
+	})
+}
+
+// LivenessCheck answers as long as the process can serve requests. It checks
+// no dependency, so an outage elsewhere does not get the instance restarted.
+func (h *HealthHandler) LivenessCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"status":    "alive",
+		"timestamp": time.Now().UTC(),
+		"uptime":    time.Since(startTime).String(),
+	})
+}
 
-	if err := h.db.Ping(ctx); err != nil {
-		health["status"] = "unhealthy"
-		health["database"] = "disconnected"
-		health["error"] = err.Error()
-		c.JSON(http.StatusServiceUnavailable, health)
+// StartupCheck fails until startup tasks such as migrations and cache warmup
+// have finished
+func (h *HealthHandler) StartupCheck(c *gin.Context) {
+	started, took := h.startup.Done()
+	if !started {
+		c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"started":   false,
+			"timestamp": time.Now().UTC(),
+			"pending":   h.startup.Pending(),
+		})
 		return
 	}
 
-	health["database"] = "connected"
-	c.JSON(http.StatusOK, health)
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"started":   true,
+		"timestamp": time.Now().UTC(),
+		"duration":  took.String(),
+	})
 }
 
+// ReadinessCheck fails while startup tasks are running or a critical
+// dependency is down. Only critical dependencies are checked, keeping the
+// probe cheap and an external outage from taking every instance out.
 func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
 	readiness := map[string]interface{}{
 		"ready":     true,
 		"timestamp": time.Now().UTC(),
 	}
 
-	// Check database
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
-
-	if err := h.db.Ping(ctx); err != nil {
+	if started, _ := h.startup.Done(); !started {
 		readiness["ready"] = false
-		readiness["database"] = "not_ready"
-		readiness["error"] = err.Error()
+		readiness["pending"] = h.startup.Pending()
 		c.JSON(http.StatusServiceUnavailable, readiness)
 		return
 	}
 
-	readiness["database"] = "ready"
+	critical := make([]health.Dependency, 0, 1)
+	for _, dependency := range h.dependencies {
+		if dependency.Critical {
+			critical = append(critical, dependency)
+		}
+	}
+
+	results := health.CheckAll(c.Request.Context(), critical)
+	readiness["dependencies"] = results
+
+	// A replica that is down does not block readiness; reads fall back to
+	// the primary
+	if h.replica != nil {
+		readiness["replica"] = "ready"
+		if !h.replica.Available() {
+			readiness["replica"] = "unavailable"
+		}
+	}
+
+	if health.Overall(results) == health.StatusUnhealthy {
+		readiness["ready"] = false
+		c.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
 	c.JSON(http.StatusOK, readiness)
 }
 
 func (h *HealthHandler) Metrics(c *gin.Context) {
+	values := map[string]interface{}{
+		"uptime":             time.Since(startTime).String(),
+		"stock_reservations": h.reservationCleanup.Stats(),
+		"db_pool":            poolStats(h.db.Stat()),
+	}
+	if stat := h.replica.Stat(); stat != nil {
+		values["db_replica_pool"] = poolStats(stat)
+	}
+
 	metrics := map[string]interface{}{
 		"timestamp": time.Now().UTC(),
-		"metrics": map[string]interface{}{
-			"uptime": time.Since(startTime).String(),
-		},
+		"metrics":   values,
 	}
 
 	c.JSON(http.StatusOK, metrics)
 }
 
+// poolStats summarises a connection pool. Utilization is the share of the
+// maximum connections checked out; empty acquires had to wait for one.
+func poolStats(stat *pgxpool.Stat) map[string]interface{} {
+	utilization := 0.0
+	if stat.MaxConns() > 0 {
+		utilization = math.Round(float64(stat.AcquiredConns())/float64(stat.MaxConns())*1000) / 10
+	}
+
+	return map[string]interface{}{
+		"max_conns":              stat.MaxConns(),
+		"total_conns":            stat.TotalConns(),
+		"acquired_conns":         stat.AcquiredConns(),
+		"idle_conns":             stat.IdleConns(),
+		"constructing_conns":     stat.ConstructingConns(),
+		"utilization_percent":    utilization,
+		"acquire_count":          stat.AcquireCount(),
+		"empty_acquire_count":    stat.EmptyAcquireCount(),
+		"canceled_acquire_count": stat.CanceledAcquireCount(),
+		"acquire_duration_ms":    stat.AcquireDuration().Milliseconds(),
+	}
+}
+
 var startTime = time.Now()
@@ -1,9 +1,26 @@
 package handlers
 
 import (
+	"strconv"
+	"time"
+
 	"ecommerce-backend/internal/config"
+	"ecommerce-backend/internal/erp"
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/gateway"
+	"ecommerce-backend/internal/health"
+	"ecommerce-backend/internal/media"
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/notifications"
+	"ecommerce-backend/internal/oauth"
+	"ecommerce-backend/internal/realtime"
 	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/internal/search"
 	"ecommerce-backend/internal/service"
+	"ecommerce-backend/internal/tokens"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/queue"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -16,33 +33,278 @@ type Repositories struct {
 	PaymentHandler *PaymentHandler
 	ReturnHandler  *ReturnHandler
 	HealthHandler  *HealthHandler
+	JWKSHandler    *JWKSHandler
+
+	ReservationHandler    *ReservationHandler
+	NotificationHandler   *NotificationHandler
+	ProductImportHandler  *ProductImportHandler
+	OrderImportHandler    *OrderImportHandler
+	DocsHandler           *DocsHandler
+	AdminWSHandler        *AdminWSHandler
+	AbandonedCartHandler  *AbandonedCartHandler
+	BackInStockHandler    *BackInStockHandler
+	PricingHandler        *PricingHandler
+	RelatedProductHandler *RelatedProductHandler
+	BundleHandler         *BundleHandler
+	PromotionHandler      *PromotionHandler
+	SegmentHandler        *SegmentHandler
+	FeatureFlagHandler    *FeatureFlagHandler
+	SettingsHandler       *SettingsHandler
+	DebugHandler          *DebugHandler
+	GiftCardHandler       *GiftCardHandler
+	CODHandler            *CODHandler
+	WarehouseHandler      *WarehouseHandler
+	AccountHandler        *AccountHandler
+	PhoneAuthHandler      *PhoneAuthHandler
+	CarrierHandler        *CarrierHandler
+	SearchHandler         *SearchHandler
+	ERPHandler            *ERPHandler
+	ProductV2Handler      *ProductV2Handler
+	OrderV2Handler        *OrderV2Handler
+	OrderMessageHandler   *OrderMessageHandler
+	AccountingHandler     *AccountingHandler
+	AdminNoteHandler      *AdminNoteHandler
+	ShippingHandler       *ShippingHandler
+	DeliveryHandler       *DeliveryHandler
+	CheckoutHandler       *CheckoutHandler
+	PaymentMethodHandler  *PaymentMethodHandler
+	StoreHandler          *StoreHandler
+	ReservationCleanup    service.ReservationCleanupService
+	AbandonedCarts        service.AbandonedCartService
+	UnpaidOrders          service.UnpaidOrderService
+	ScheduledPublishing   service.ScheduledPublishingService
+	Segments              service.SegmentService
+	AuditService          service.AuditService
+	StoreService          service.StoreService
+	FlagService           service.FlagService
+	SettingsService       service.SettingsService
+	RequestCapture        *middleware.RequestCapture
+	ERPConsumer           *erp.Consumer
+
+	EventBus        *events.Bus
+	EventDispatcher events.Dispatcher
 }
 
-func InitRepositories(db *pgxpool.Pool, cfg *config.Config) *Repositories {
+func InitRepositories(db *pgxpool.Pool, replica *database.Replica, cfg *config.Config, startup *health.Startup, tokenKeys *tokens.KeySet) *Repositories {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
-	productRepo := repository.NewProductRepository(db)
+	productRepo := repository.NewProductRepository(db, replica)
 	cartRepo := repository.NewCartRepository(db)
-	orderRepo := repository.NewOrderRepository(db)
+	orderRepo := repository.NewOrderRepository(db, replica)
 	paymentRepo := repository.NewPaymentRepository(db)
 	returnRepo := repository.NewReturnRepository(db)
+	verificationRepo := repository.NewVerificationRepository(db)
+	revocationRepo := repository.NewRevocationRepository(db)
+	identityRepo := repository.NewIdentityRepository(db)
+	phoneOTPRepo := repository.NewPhoneOTPRepository(db)
+	carrierEventRepo := repository.NewCarrierEventRepository(db)
+	erpStockMessageRepo := repository.NewERPStockMessageRepository(db)
+	variantRepo := repository.NewVariantRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	productImportRepo := repository.NewProductImportRepository(db)
+	relatedProductRepo := repository.NewRelatedProductRepository(db, replica)
+	bundleRepo := repository.NewBundleRepository(db)
+	checkoutSessionRepo := repository.NewCheckoutSessionRepository(db)
+	abandonedCartRepo := repository.NewAbandonedCartRepository(db)
+	backInStockRepo := repository.NewBackInStockRepository(db)
+	priceRepo := repository.NewPriceRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	segmentRepo := repository.NewSegmentRepository(db)
+	featureFlagRepo := repository.NewFeatureFlagRepository(db)
+	settingRepo := repository.NewSettingRepository(db)
+	giftCardRepo := repository.NewGiftCardRepository(db)
+	codRepo := repository.NewCODRepository(db)
+	warehouseRepo := repository.NewWarehouseRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	orderMessageRepo := repository.NewOrderMessageRepository(db)
+	adminNoteRepo := repository.NewAdminNoteRepository(db)
+	accountingRepo := repository.NewAccountingRepository(db, replica)
+	serviceableAreaRepo := repository.NewServiceableAreaRepository(db)
+	paymentMethodRepo := repository.NewPaymentMethodRepository(db)
+	fraudRepo := repository.NewFraudRepository(db)
+	orderHoldRepo := repository.NewOrderHoldRepository(db)
+	storeRepo := repository.NewStoreRepository(db)
+
+	// Unit of work shared by services that span several repositories
+	txManager := database.NewTxManager(db)
+
+	// Domain events are recorded in the outbox and delivered by the dispatcher
+	eventPublisher := events.NewOutboxPublisher(outboxRepo)
+	eventBus := events.NewBus()
+	if cfg.EventWebhookURL != "" {
+		eventBus.AddSink(events.NewWebhookSink(cfg.EventWebhookURL))
+	}
+	if cfg.EventQueueBroker == "rabbitmq" {
+		eventBus.AddSink(events.NewQueueSink(queue.NewRabbitMQPublisher(cfg.EventQueueURL, cfg.EventQueueExchange)))
+	}
+	var erpSink events.Sink
+	if cfg.ERPAMQPURL != "" {
+		erpSink = erp.NewSink(queue.NewRabbitMQPublisher(cfg.ERPAMQPURL, cfg.ERPOrderExchange), orderRepo)
+		eventBus.AddSink(erpSink)
+	}
+	eventDispatcher := events.NewDispatcher(outboxRepo, eventBus)
+
+	// Initialize payment gateway (nil falls back to simulated payments)
+	var paymentGateway gateway.PaymentGateway
+	if cfg.PaymentGateway == "stripe" {
+		paymentGateway = gateway.NewStripeGateway(cfg.StripeSecretKey, cfg.StripeWebhookSecret)
+	}
+
+	// Social sign-in providers, each enabled by its client IDs
+	oauthProviders := map[string]oauth.Provider{}
+	if len(cfg.GoogleClientIDs) > 0 {
+		oauthProviders["google"] = oauth.NewGoogleProvider(cfg.GoogleClientIDs)
+	}
+	if len(cfg.AppleClientIDs) > 0 {
+		oauthProviders["apple"] = oauth.NewAppleProvider(cfg.AppleClientIDs)
+	}
 
 	// Initialize services
-	authService := service.NewAuthService(userRepo, cfg.JWTSecret, cfg.JWTExpiry)
-	productService := service.NewProductService(productRepo)
-	cartService := service.NewCartService(cartRepo, productRepo, productService)
-	paymentService := service.NewPaymentService(paymentRepo, orderRepo)
-	orderService := service.NewOrderService(orderRepo, cartRepo, productRepo, cartService, paymentService)
-	returnService := service.NewReturnService(returnRepo, orderRepo, paymentService, productRepo)
+	auditService := service.NewAuditService(auditRepo)
+
+	// Verification links and customer notifications share one mail provider
+	var mailer notifications.Mailer
+	switch cfg.MailProvider {
+	case "smtp":
+		mailer = notifications.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom, cfg.MailFromName)
+	case "sendgrid":
+		mailer = notifications.NewSendGridMailer(cfg.SendGridAPIKey, cfg.MailFrom, cfg.MailFromName)
+	default:
+		mailer = notifications.NewLogMailer()
+	}
+
+	authService := service.NewAuthService(userRepo, verificationRepo, revocationRepo, identityRepo, phoneOTPRepo, orderRepo, returnRepo, txManager, auditService, mailer, tokenKeys, oauthProviders, cfg.JWTExpiry, cfg.EmailVerificationTTL, cfg.AppBaseURL, cfg.ImpersonationTTL)
+	notificationService := service.NewNotificationService(notificationRepo)
+	backInStockService := service.NewBackInStockService(backInStockRepo, productRepo, variantRepo, txManager, notificationService)
+	pricingService := service.NewPricingService(priceRepo, productRepo, variantRepo)
+	flagService := service.NewFlagService(featureFlagRepo, cfg.Env)
+
+	// Runtime settings default to the environment until an admin overrides them
+	settingsService := service.NewSettingsService(settingRepo, map[string]string{
+		models.SettingStockReservationTTL: strconv.Itoa(int(cfg.StockReservationTTL / time.Minute)),
+		models.SettingRateLimit:           strconv.Itoa(cfg.RateLimitPerMinute),
+		models.SettingAuthRateLimit:       strconv.Itoa(cfg.AuthRateLimitPerMinute),
+		models.SettingCODMaxOrderValue:    cfg.CODMaxOrderValue.String(),
+		models.SettingShippingFee:         cfg.ShippingFee.String(),
+		models.SettingShippingRatePerKg:   cfg.ShippingRatePerKg.String(),
+		models.SettingFreeShippingMinimum: cfg.FreeShippingMinimum.String(),
+		models.SettingMinimumOrderValue:   cfg.MinimumOrderValue.String(),
+		models.SettingMaintenanceMode:     "false",
+		models.SettingMaintenanceMessage:  "We're making some improvements and will be back shortly.",
+	})
+
+	promotionService := service.NewPromotionService(promotionRepo, productRepo, flagService)
+	// Optional search engine, kept in step with the catalog from product events
+	var searchEngine search.Engine
+	var searchIndexer *search.Indexer
+	if cfg.SearchEngine == "meilisearch" {
+		searchEngine = search.NewMeilisearch(cfg.SearchURL, cfg.SearchAPIKey, cfg.SearchIndex)
+		searchIndexer = search.NewIndexer(searchEngine, productRepo)
+		searchIndexer.Register(eventBus)
+	}
+
+	relatedProductService := service.NewRelatedProductService(relatedProductRepo, productRepo, pricingService)
+	bundleService := service.NewBundleService(bundleRepo, productRepo, variantRepo, txManager, eventPublisher)
+	productService := service.NewProductService(productRepo, variantRepo, backInStockService, pricingService, txManager, settingsService, eventPublisher, relatedProductService, bundleRepo, searchEngine)
+	productImportService := service.NewProductImportService(productImportRepo, productRepo)
+	orderImportService := service.NewOrderImportService(orderRepo, userRepo, productRepo, variantRepo)
+	cartService := service.NewCartService(cartRepo, productRepo, orderRepo, productService, pricingService, promotionService, settingsService, cfg.TaxRateBasisPoints, cfg.VolumetricDivisor)
+	paymentService := service.NewPaymentService(paymentRepo, orderRepo, paymentGateway, cfg.PaymentCurrency, txManager, eventPublisher, notificationService, cfg.PaymentSimDelay, cfg.APIBaseURL)
+	giftCardService := service.NewGiftCardService(giftCardRepo, orderRepo, txManager)
+	codService := service.NewCODService(codRepo, orderRepo, cartService, notificationService, settingsService, cfg.CODPostalCodes, cfg.CODOTPTTL)
+	accountService := service.NewAccountService(authService, orderRepo, returnRepo, cartRepo, notificationRepo, backInStockRepo, giftCardRepo)
+
+	// Sign-in and phone verification codes are texted by the configured provider
+	var smsSender notifications.SMSSender
+	switch cfg.SMSProvider {
+	case "twilio":
+		smsSender = notifications.NewTwilioSMSSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioFrom)
+	default:
+		smsSender = notifications.NewLogSMSSender()
+	}
+	phoneAuthService := service.NewPhoneAuthService(userRepo, phoneOTPRepo, txManager, authService, smsSender, cfg.PhoneOTPTTL)
+	warehouseService := service.NewWarehouseService(warehouseRepo, productRepo, variantRepo, txManager, backInStockService)
+	deliveryService := service.NewDeliveryService(warehouseRepo, cartService)
+	serviceabilityService := service.NewServiceabilityService(serviceableAreaRepo)
+	checkoutService := service.NewCheckoutService(checkoutSessionRepo, cartService, serviceabilityService, cfg.CheckoutSessionTTL)
+	storeService := service.NewStoreService(storeRepo)
+	paymentMethodService := service.NewPaymentMethodService(paymentMethodRepo, userRepo, paymentGateway, txManager)
+	fraudService := service.NewFraudService(fraudRepo, cfg.FraudReviewThreshold,
+		service.NewVelocityRule(fraudRepo, cfg.FraudVelocityWindow, cfg.FraudMaxOrdersPerUser, cfg.FraudMaxOrdersPerIP),
+		service.NewCountryMismatchRule(),
+		service.NewHighValueFirstOrderRule(fraudRepo, cfg.FraudHighValueFirstOrder),
+	)
+	orderService := service.NewOrderService(orderRepo, cartRepo, checkoutSessionRepo, productRepo, bundleRepo, userRepo, cartService, paymentService, txManager, eventPublisher, notificationService, backInStockService, pricingService, promotionService, giftCardService, codService, warehouseService, deliveryService, serviceabilityService, paymentMethodService, fraudService, orderHoldRepo, service.NewOrderNumberGenerator(orderRepo), cfg.RequireEmailVerification, cfg.LowStockThreshold, cfg.OrderCancelWindow, cfg.UnpaidOrderTTL, cfg.CheckoutPaymentMode == config.CheckoutAuthorizeCapture, cfg.CheckoutAuthorizationTTL, cfg.PaymentCapture == "automatic")
+	orderService.Register(eventBus)
+	reservationCleanup := service.NewReservationCleanupService(productRepo, backInStockService)
+	unpaidOrders := service.NewUnpaidOrderService(orderService)
+	carrierService := service.NewCarrierService(orderRepo, carrierEventRepo, orderService, txManager, notificationService, cfg.CarrierWebhookSecrets)
+	erpSyncService := service.NewERPSyncService(erpStockMessageRepo, outboxRepo, productRepo, variantRepo, warehouseRepo, warehouseService, erpSink)
+	erpConsumer := erp.NewConsumer(cfg.ERPAMQPURL, cfg.ERPStockQueue, erpSyncService.HandleStockMessage)
+	returnService := service.NewReturnService(returnRepo, orderRepo, variantRepo, orderService, paymentService, warehouseService, txManager, eventPublisher, notificationService, backInStockService, giftCardService, cfg.ReturnAddress)
+	orderMessageService := service.NewOrderMessageService(orderMessageRepo, orderRepo, txManager, eventPublisher, notificationService)
+	adminNoteService := service.NewAdminNoteService(adminNoteRepo, orderRepo, userRepo)
+	accountingService := service.NewAccountingService(accountingRepo, cfg.TaxRateBasisPoints, cfg.GatewayFeeBasisPoints, cfg.GatewayFeeFixed)
+	abandonedCartService := service.NewAbandonedCartService(abandonedCartRepo, txManager, eventPublisher, cfg.AbandonedCartAfter)
+	abandonedCartService.Register(eventBus)
+	segmentService := service.NewSegmentService(segmentRepo)
+
+	// Customer emails are sent from delivered events, outside the business transaction
+	notifications.NewEmailNotifier(mailer, orderRepo, userRepo, cfg.AppBaseURL).Register(eventBus)
+
+	// Live admin dashboard feed
+	dashboardHub := realtime.NewHub()
+	dashboardHub.Register(eventBus)
+
+	mediaURLs := media.NewURLBuilder(cfg.MediaBaseURL, cfg.MediaSigningKey, cfg.MediaURLTTL, cfg.MediaThumbnailSizes)
 
 	// Initialize handlers
 	authHandler := NewAuthHandler(authService)
-	productHandler := NewProductHandler(productService)
+	productHandler := NewProductHandler(productService, mediaURLs, cfg.CatalogEdgeTTL)
 	cartHandler := NewCartHandler(cartService)
 	orderHandler := NewOrderHandler(orderService)
-	paymentHandler := NewPaymentHandler(paymentService)
+	paymentHandler := NewPaymentHandler(paymentService, cfg.AppBaseURL)
 	returnHandler := NewReturnHandler(returnService)
-	healthHandler := NewHealthHandler(db)
+	healthHandler := NewHealthHandler(db, replica, paymentGateway, mailer, startup, reservationCleanup)
+	jwksHandler := NewJWKSHandler(tokenKeys)
+	reservationHandler := NewReservationHandler(reservationCleanup)
+	notificationHandler := NewNotificationHandler(notificationService)
+	productImportHandler := NewProductImportHandler(productImportService)
+	orderImportHandler := NewOrderImportHandler(orderImportService)
+	docsHandler := NewDocsHandler()
+	adminWSHandler := NewAdminWSHandler(authService, dashboardHub, cfg.AdminAllowedOrigins)
+	abandonedCartHandler := NewAbandonedCartHandler(abandonedCartService)
+	backInStockHandler := NewBackInStockHandler(backInStockService)
+	pricingHandler := NewPricingHandler(pricingService)
+	relatedProductHandler := NewRelatedProductHandler(relatedProductService)
+	bundleHandler := NewBundleHandler(bundleService)
+	promotionHandler := NewPromotionHandler(promotionService)
+	segmentHandler := NewSegmentHandler(segmentService)
+	featureFlagHandler := NewFeatureFlagHandler(flagService)
+	settingsHandler := NewSettingsHandler(settingsService)
+	requestCapture := middleware.NewRequestCapture(cfg.DebugCaptureRequests)
+	debugHandler := NewDebugHandler(requestCapture)
+	giftCardHandler := NewGiftCardHandler(giftCardService)
+	codHandler := NewCODHandler(codService, orderService)
+	warehouseHandler := NewWarehouseHandler(warehouseService)
+	accountHandler := NewAccountHandler(authService, accountService)
+	phoneAuthHandler := NewPhoneAuthHandler(phoneAuthService)
+	carrierHandler := NewCarrierHandler(carrierService)
+	searchHandler := NewSearchHandler(searchIndexer)
+	erpHandler := NewERPHandler(erpSyncService)
+	orderMessageHandler := NewOrderMessageHandler(orderMessageService)
+	adminNoteHandler := NewAdminNoteHandler(adminNoteService)
+	accountingHandler := NewAccountingHandler(accountingService)
+	deliveryHandler := NewDeliveryHandler(deliveryService)
+	checkoutHandler := NewCheckoutHandler(checkoutService)
+	shippingHandler := NewShippingHandler(serviceabilityService)
+	paymentMethodHandler := NewPaymentMethodHandler(paymentMethodService)
+	storeHandler := NewStoreHandler(storeService)
+
+	// v2 handlers share the services above and differ only in response shape
+	productV2Handler := NewProductV2Handler(productService, cfg.PaymentCurrency, mediaURLs, cfg.CatalogEdgeTTL)
+	orderV2Handler := NewOrderV2Handler(orderService, cfg.PaymentCurrency)
 
 	return &Repositories{
 		AuthHandler:    authHandler,
@@ -52,5 +314,55 @@ func InitRepositories(db *pgxpool.Pool, cfg *config.Config) *Repositories {
 		PaymentHandler: paymentHandler,
 		ReturnHandler:  returnHandler,
 		HealthHandler:  healthHandler,
+		JWKSHandler:    jwksHandler,
+
+		ReservationHandler:    reservationHandler,
+		NotificationHandler:   notificationHandler,
+		ProductImportHandler:  productImportHandler,
+		OrderImportHandler:    orderImportHandler,
+		DocsHandler:           docsHandler,
+		AdminWSHandler:        adminWSHandler,
+		AbandonedCartHandler:  abandonedCartHandler,
+		BackInStockHandler:    backInStockHandler,
+		PricingHandler:        pricingHandler,
+		RelatedProductHandler: relatedProductHandler,
+		BundleHandler:         bundleHandler,
+		PromotionHandler:      promotionHandler,
+		SegmentHandler:        segmentHandler,
+		FeatureFlagHandler:    featureFlagHandler,
+		SettingsHandler:       settingsHandler,
+		DebugHandler:          debugHandler,
+		GiftCardHandler:       giftCardHandler,
+		CODHandler:            codHandler,
+		WarehouseHandler:      warehouseHandler,
+		AccountHandler:        accountHandler,
+		PhoneAuthHandler:      phoneAuthHandler,
+		CarrierHandler:        carrierHandler,
+		SearchHandler:         searchHandler,
+		ERPHandler:            erpHandler,
+		ProductV2Handler:      productV2Handler,
+		OrderV2Handler:        orderV2Handler,
+		OrderMessageHandler:   orderMessageHandler,
+		AdminNoteHandler:      adminNoteHandler,
+		AccountingHandler:     accountingHandler,
+		DeliveryHandler:       deliveryHandler,
+		CheckoutHandler:       checkoutHandler,
+		ShippingHandler:       shippingHandler,
+		PaymentMethodHandler:  paymentMethodHandler,
+		StoreHandler:          storeHandler,
+		ReservationCleanup:    reservationCleanup,
+		AbandonedCarts:        abandonedCartService,
+		UnpaidOrders:          unpaidOrders,
+		ScheduledPublishing:   service.NewScheduledPublishingService(productService),
+		Segments:              segmentService,
+		AuditService:          auditService,
+		StoreService:          storeService,
+		FlagService:           flagService,
+		SettingsService:       settingsService,
+		RequestCapture:        requestCapture,
+		ERPConsumer:           erpConsumer,
+
+		EventBus:        eventBus,
+		EventDispatcher: eventDispatcher,
 	}
 }
@@ -0,0 +1,191 @@
+//go:build integration
+
+package handlers
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"ecommerce-backend/internal/config"
+	"ecommerce-backend/internal/health"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/testdb"
+	"ecommerce-backend/internal/tokens"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testdb.Main(m))
+}
+
+// testApp is the application wired as the server wires it, on the test
+// database. Tests call its services directly.
+type testApp struct {
+	db    *pgxpool.Pool
+	repos *Repositories
+}
+
+var (
+	appOnce sync.Once
+	app     *testApp
+	appErr  error
+)
+
+func newTestApp(t *testing.T) *testApp {
+	t.Helper()
+
+	db := testdb.Pool(t)
+	appOnce.Do(func() {
+		var cfg *config.Config
+		cfg, appErr = config.LoadConfig()
+		if appErr != nil {
+			return
+		}
+		// Payments are simulated and settle at once; mail goes to the log
+		cfg.PaymentGateway = "simulated"
+		cfg.PaymentSimDelay = 0
+		cfg.MailProvider = "log"
+
+		var keys *tokens.KeySet
+		keys, appErr = tokens.NewKeySet(cfg)
+		if appErr != nil {
+			return
+		}
+
+		app = &testApp{db: db, repos: InitRepositories(db, nil, cfg, health.NewStartup(), keys)}
+	})
+	if appErr != nil {
+		t.Fatalf("failed to set up app: %v", appErr)
+	}
+
+	return app
+}
+
+// createCustomer registers a customer with a unique email
+func (a *testApp) createCustomer(t *testing.T) *models.User {
+	t.Helper()
+
+	user, err := a.repos.AuthHandler.AuthService.Register(context.Background(), models.RegisterRequest{
+		Email:     "customer-" + uuid.NewString() + "@example.com",
+		Password:  "Passw0rd!",
+		FirstName: "Test",
+		LastName:  "Customer",
+	})
+	if err != nil {
+		t.Fatalf("failed to register customer: %v", err)
+	}
+
+	return user
+}
+
+// createProduct adds an active product priced at 10.00 with stock units, all
+// held in the default warehouse
+func (a *testApp) createProduct(t *testing.T, stock int) *models.Product {
+	t.Helper()
+	ctx := context.Background()
+
+	product, err := a.repos.ProductHandler.productService.CreateProduct(ctx, models.ProductRequest{
+		SKU:   "TEST-" + uuid.NewString(),
+		Name:  "Test product",
+		Price: money.FromMajor(10),
+	})
+	if err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+
+	warehouses, err := a.repos.WarehouseHandler.warehouseService.GetWarehouses(ctx)
+	if err != nil {
+		t.Fatalf("failed to list warehouses: %v", err)
+	}
+	for _, warehouse := range warehouses {
+		if !warehouse.IsDefault {
+			continue
+		}
+		err := a.repos.WarehouseHandler.warehouseService.SetStock(ctx, warehouse.ID, models.SetWarehouseStockRequest{
+			ProductID: product.ID,
+			Quantity:  stock,
+		})
+		if err != nil {
+			t.Fatalf("failed to stock product: %v", err)
+		}
+		return product
+	}
+
+	t.Fatal("no default warehouse")
+	return nil
+}
+
+func (a *testApp) addToCart(t *testing.T, userID, productID uuid.UUID, quantity int) {
+	t.Helper()
+
+	_, err := a.repos.CartHandler.cartService.AddToCart(context.Background(), userID, models.AddToCartRequest{
+		ProductID: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		t.Fatalf("failed to add to cart: %v", err)
+	}
+}
+
+// placeOrder checks the user's cart out for cash on delivery, so no payment
+// is taken until the order is delivered
+func (a *testApp) placeOrder(userID uuid.UUID) (*models.Order, error) {
+	address := models.Address{
+		FullName:   "Test Customer",
+		Street:     "1 Test Street",
+		City:       "Testville",
+		State:      "Test State",
+		Country:    "IN",
+		PostalCode: "400001",
+		Phone:      "+919876543210",
+	}
+
+	return a.repos.OrderHandler.orderService.CreateOrder(context.Background(), userID, models.CreateOrderRequest{
+		ShippingAddress: address,
+		BillingAddress:  address,
+		PaymentMethod:   "cod",
+	})
+}
+
+// expireReservations makes the user's cart reservations lapse, as if the
+// customer had left the cart for longer than reservations last
+func (a *testApp) expireReservations(t *testing.T, userID uuid.UUID) {
+	t.Helper()
+
+	_, err := a.db.Exec(context.Background(), `
+        UPDATE stock_reservations
+        SET expires_at = NOW() - INTERVAL '1 minute'
+        WHERE cart_id IN (SELECT id FROM carts WHERE user_id = $1)
+    `, userID)
+	if err != nil {
+		t.Fatalf("failed to expire reservations: %v", err)
+	}
+}
+
+// markDelivered moves the order straight to delivered, skipping the shipping
+// and delivery-code steps that come before it
+func (a *testApp) markDelivered(t *testing.T, orderID uuid.UUID) {
+	t.Helper()
+
+	_, err := a.db.Exec(context.Background(), "UPDATE orders SET status = $2 WHERE id = $1", orderID, models.OrderDelivered)
+	if err != nil {
+		t.Fatalf("failed to mark order delivered: %v", err)
+	}
+}
+
+func (a *testApp) stock(t *testing.T, productID uuid.UUID) int {
+	t.Helper()
+
+	var stock int
+	err := a.db.QueryRow(context.Background(), "SELECT stock_quantity FROM products WHERE id = $1", productID).Scan(&stock)
+	if err != nil {
+		t.Fatalf("failed to read stock: %v", err)
+	}
+
+	return stock
+}
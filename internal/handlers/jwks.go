@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ecommerce-backend/internal/tokens"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jwksMaxAge lets verifiers cache the key set, in seconds. A new signing key
+// must be published for at least this long before tokens are signed with it.
+const jwksMaxAge = "300"
+
+type JWKSHandler struct {
+	keys *tokens.KeySet
+}
+
+func NewJWKSHandler(keys *tokens.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// GetJWKS publishes the public keys tokens are verified with, so other
+// services can check tokens without the signing secret
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age="+jwksMaxAge)
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderImportHandler struct {
+	importService service.OrderImportService
+}
+
+func NewOrderImportHandler(importService service.OrderImportService) *OrderImportHandler {
+	return &OrderImportHandler{importService: importService}
+}
+
+// ImportOrders migrates historical orders, given as a JSON body of orders
+// with their items or as a CSV upload in the "file" form field with one row
+// per item. Orders are imported or rejected one by one; the report lists the
+// rejected rows.
+func (h *OrderImportHandler) ImportOrders(c *gin.Context) {
+	var (
+		result *models.OrderImportResult
+		err    error
+	)
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, formErr := c.FormFile("file")
+		if formErr != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(formErr, &tooLarge) {
+				utils.GinPayloadTooLargeResponse(c, tooLarge.Limit)
+				return
+			}
+			utils.GinBadRequestResponse(c, "A CSV file is required in the 'file' field", formErr)
+			return
+		}
+
+		if !strings.EqualFold(filepath.Ext(fileHeader.Filename), ".csv") {
+			utils.GinBadRequestResponse(c, "Unsupported file type", fmt.Errorf("order imports must be .csv"))
+			return
+		}
+
+		file, openErr := fileHeader.Open()
+		if openErr != nil {
+			utils.GinBadRequestResponse(c, "Failed to read upload", openErr)
+			return
+		}
+		defer file.Close()
+
+		result, err = h.importService.ImportOrdersCSV(c.Request.Context(), file)
+	} else {
+		var req models.ImportOrdersRequest
+		if !utils.BindJSON(c, &req) {
+			return
+		}
+
+		result, err = h.importService.ImportOrders(c.Request.Context(), req.Orders)
+	}
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Order import finished", result)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderMessageHandler struct {
+	messageService service.OrderMessageService
+}
+
+func NewOrderMessageHandler(messageService service.OrderMessageService) *OrderMessageHandler {
+	return &OrderMessageHandler{messageService: messageService}
+}
+
+func (h *OrderMessageHandler) GetMessages(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Support viewing the thread as the customer must not mark it read
+	_, impersonated := c.Get(middleware.GinImpersonatorIDKey)
+
+	thread, err := h.messageService.GetThread(c.Request.Context(), orderID, userID, !impersonated)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Messages retrieved successfully", thread)
+}
+
+func (h *OrderMessageHandler) PostMessage(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.PostOrderMessageRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	message, err := h.messageService.PostMessage(c.Request.Context(), orderID, userID, req.Body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Message sent", message)
+}
+
+func (h *OrderMessageHandler) GetUnread(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.messageService.GetUnread(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Unread messages retrieved successfully", gin.H{
+		"orders":       orders,
+		"unread_count": totalUnread(orders),
+	})
+}
+
+func (h *OrderMessageHandler) GetAdminMessages(c *gin.Context) {
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	thread, err := h.messageService.GetThreadAdmin(c.Request.Context(), orderID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Messages retrieved successfully", thread)
+}
+
+func (h *OrderMessageHandler) ReplyToOrder(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.PostOrderMessageRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	message, err := h.messageService.Reply(c.Request.Context(), orderID, adminID, req.Body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Reply sent", message)
+}
+
+// GetSupportQueue lists orders waiting on a support reply
+func (h *OrderMessageHandler) GetSupportQueue(c *gin.Context) {
+	orders, err := h.messageService.GetSupportQueue(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Support queue retrieved successfully", gin.H{
+		"orders":       orders,
+		"unread_count": totalUnread(orders),
+	})
+}
+
+func totalUnread(orders []models.UnreadOrderMessages) int {
+	total := 0
+	for _, order := range orders {
+		total += order.UnreadCount
+	}
+	return total
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service/servicemock"
+
+	"github.com/google/uuid"
+)
+
+// newOrderService returns order and err from every call the order handlers
+// make, totalling total orders for the user. Calls it does not set up panic.
+func newOrderService(order *models.Order, total int, err error) *servicemock.OrderServiceMock {
+	return &servicemock.OrderServiceMock{
+		CreateOrderFunc: func(ctx context.Context, userID uuid.UUID, req models.CreateOrderRequest) (*models.Order, error) {
+			return order, err
+		},
+		GetOrderFunc: func(ctx context.Context, orderID, userID uuid.UUID) (*models.Order, error) {
+			return order, err
+		},
+		GetOrderAdminFunc: func(ctx context.Context, orderID uuid.UUID) (*models.AdminOrder, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &models.AdminOrder{ID: order.ID}, nil
+		},
+		GetUserOrdersFunc: func(ctx context.Context, userID uuid.UUID, page, limit int, includeItems bool) ([]models.Order, int, error) {
+			if err != nil {
+				return nil, 0, err
+			}
+			return []models.Order{*order}, total, nil
+		},
+		CancelOrderFunc: func(ctx context.Context, orderID, userID uuid.UUID) error {
+			return err
+		},
+	}
+}
+
+func TestOrderHandlerCreateOrder(t *testing.T) {
+	sessionID := uuid.New()
+	fromSession := `{"checkout_session_id":"` + sessionID.String() + `","payment_method":"cod"}`
+	address := `{"full_name":"Test Customer","street":"1 Test Street","city":"Testville","state":"Test State","country":"IN","postal_code":"400001","phone":"+919876543210"}`
+	fromCart := `{"shipping_address":` + address + `,"billing_address":` + address + `,"payment_method":"cc"}`
+
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", body: fromSession, wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "malformed JSON", userID: testUserID, body: `[]`, wantStatus: http.StatusBadRequest}, nil},
+		{handlerCase{name: "unknown payment method", userID: testUserID, body: `{"checkout_session_id":"` + sessionID.String() + `","payment_method":"barter"}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "no address or session", userID: testUserID, body: `{"payment_method":"cod"}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "cart changed", userID: testUserID, body: fromSession, wantStatus: http.StatusConflict, wantError: "conflict"}, apperrors.Conflict("cart has changed since checkout started")},
+		{handlerCase{name: "from checkout session", userID: testUserID, body: fromSession, wantStatus: http.StatusCreated}, nil},
+		{handlerCase{name: "from cart", userID: testUserID, body: fromCart, wantStatus: http.StatusCreated}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &models.Order{ID: uuid.New(), Status: models.OrderPending}
+			svc := newOrderService(order, 0, tt.err)
+			tt.method, tt.path = http.MethodPost, "/orders"
+
+			_, resp := serve(t, "/orders", NewOrderHandler(svc).CreateOrder, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			call := svc.CreateOrderCalls()[0]
+			if call.UserID.String() != testUserID {
+				t.Errorf("order placed for user %s, want %s", call.UserID, testUserID)
+			}
+			if call.Req.ClientIP == "" {
+				t.Error("client IP was not passed to the service")
+			}
+			var got models.Order
+			decodeData(t, resp, &got)
+			if got.ID != order.ID {
+				t.Errorf("order ID = %s, want %s", got.ID, order.ID)
+			}
+		})
+	}
+}
+
+func TestOrderHandlerGetOrder(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		handlerCase
+		err       error
+		wantAdmin bool
+	}{
+		{handlerCase{name: "unauthenticated", path: "/orders/" + orderID.String(), wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil, false},
+		{handlerCase{name: "malformed order ID", userID: testUserID, path: "/orders/latest", wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil, false},
+		{handlerCase{name: "someone else's order", userID: testUserID, path: "/orders/" + orderID.String(), wantStatus: http.StatusForbidden, wantError: "forbidden"}, apperrors.Forbidden("unauthorized to view this order"), false},
+		{handlerCase{name: "own order", userID: testUserID, path: "/orders/" + orderID.String(), wantStatus: http.StatusOK}, nil, false},
+		{handlerCase{name: "admin", userID: testUserID, role: "admin", path: "/orders/" + orderID.String(), wantStatus: http.StatusOK}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newOrderService(&models.Order{ID: orderID}, 0, tt.err)
+			tt.method = http.MethodGet
+
+			serve(t, "/orders/:id", NewOrderHandler(svc).GetOrder, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			adminCalls := svc.GetOrderAdminCalls()
+			if admin := len(adminCalls) > 0; admin != tt.wantAdmin {
+				t.Fatalf("admin lookup = %v, want %v", admin, tt.wantAdmin)
+			}
+			var got uuid.UUID
+			if tt.wantAdmin {
+				got = adminCalls[0].OrderID
+			} else {
+				got = svc.GetOrderCalls()[0].OrderID
+			}
+			if got != orderID {
+				t.Errorf("service got order %s, want %s", got, orderID)
+			}
+		})
+	}
+}
+
+func TestOrderHandlerGetUserOrders(t *testing.T) {
+	tests := []struct {
+		handlerCase
+		wantPage, wantLimit int
+	}{
+		{handlerCase{name: "unauthenticated", path: "/orders", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, 0, 0},
+		{handlerCase{name: "defaults", userID: testUserID, path: "/orders", wantStatus: http.StatusOK}, 1, 10},
+		{handlerCase{name: "page and limit", userID: testUserID, path: "/orders?page=3&limit=25", wantStatus: http.StatusOK}, 3, 25},
+		{handlerCase{name: "out of range values fall back", userID: testUserID, path: "/orders?page=0&limit=500", wantStatus: http.StatusOK}, 1, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newOrderService(&models.Order{ID: uuid.New()}, 42, nil)
+			tt.method = http.MethodGet
+
+			_, resp := serve(t, "/orders", NewOrderHandler(svc).GetUserOrders, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if call := svc.GetUserOrdersCalls()[0]; call.Page != tt.wantPage || call.Limit != tt.wantLimit {
+				t.Errorf("service got page %d limit %d, want page %d limit %d", call.Page, call.Limit, tt.wantPage, tt.wantLimit)
+			}
+			var got struct {
+				Orders []models.Order `json:"orders"`
+				Meta   struct {
+					Total      int `json:"total"`
+					TotalPages int `json:"totalPages"`
+				} `json:"meta"`
+			}
+			decodeData(t, resp, &got)
+			if len(got.Orders) != 1 || got.Meta.Total != 42 {
+				t.Errorf("got %d orders of %d, want 1 of 42", len(got.Orders), got.Meta.Total)
+			}
+			if want := (42 + tt.wantLimit - 1) / tt.wantLimit; got.Meta.TotalPages != want {
+				t.Errorf("totalPages = %d, want %d", got.Meta.TotalPages, want)
+			}
+		})
+	}
+}
+
+func TestOrderHandlerCancelOrder(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", path: "/orders/" + orderID.String() + "/cancel", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "malformed order ID", userID: testUserID, path: "/orders/abc/cancel", wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "already shipped", userID: testUserID, path: "/orders/" + orderID.String() + "/cancel", wantStatus: http.StatusConflict, wantError: "conflict"}, apperrors.Conflict("order can no longer be cancelled")},
+		{handlerCase{name: "ok", userID: testUserID, path: "/orders/" + orderID.String() + "/cancel", wantStatus: http.StatusOK}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newOrderService(nil, 0, tt.err)
+			tt.method = http.MethodPut
+
+			serve(t, "/orders/:id/cancel", NewOrderHandler(svc).CancelOrder, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if call := svc.CancelOrderCalls()[0]; call.OrderID != orderID || call.UserID.String() != testUserID {
+				t.Errorf("service cancelled order %s for user %s, want %s for %s", call.OrderID, call.UserID, orderID, testUserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderV2Handler serves a customer's own orders in the v2 shape. Unlike v1
+// GetOrder it never falls back to the admin view; admins use /admin/orders.
+type OrderV2Handler struct {
+	orderService service.OrderService
+	present      v2Presenter
+}
+
+func NewOrderV2Handler(orderService service.OrderService, currency string) *OrderV2Handler {
+	return &OrderV2Handler{orderService: orderService, present: newV2Presenter(currency)}
+}
+
+func (h *OrderV2Handler) GetUserOrders(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	after, limit, ok := parseV2Page(c, 10, 50)
+	if !ok {
+		return
+	}
+
+	include, ok := parseInclude(c, "items")
+	if !ok {
+		return
+	}
+
+	orders, next, err := h.orderService.GetUserOrdersPage(c.Request.Context(), userID, after, limit, include["items"])
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	selected, ok := selectFields(c, h.present.orders(orders))
+	if !ok {
+		return
+	}
+
+	response := map[string]interface{}{
+		"orders": selected,
+		"meta":   newV2PageMeta(limit, next),
+	}
+
+	utils.GinSuccessResponse(c, "Orders retrieved successfully", response)
+}
+
+func (h *OrderV2Handler) GetOrder(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	orderID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	order, err := h.orderService.GetOrder(c.Request.Context(), orderID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Order retrieved successfully", h.present.order(*order))
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// packingSlipPage is a packing slip laid out for an A4 or letter printer
+var packingSlipPage = template.Must(template.New("packing_slip").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Packing slip {{.OrderNumber}}</title>
+  <style>
+    body { font-family: sans-serif; font-size: 12pt; margin: 2em; }
+    table { border-collapse: collapse; width: 100%; margin-top: 1em; }
+    th, td { border-bottom: 1px solid #999; padding: 6px; text-align: left; }
+    td.qty, th.qty { text-align: right; }
+    @media print { body { margin: 0; } }
+  </style>
+</head>
+<body>
+  <h1>Packing slip</h1>
+  <p>Order <strong>{{.OrderNumber}}</strong> placed {{.OrderDate.Format "2006-01-02"}}{{if .ShippingMethod}} &middot; {{.ShippingMethod}} shipping{{end}}</p>
+  <h2>Ship to</h2>
+  <address>
+    {{.ShipTo.FullName}}<br>
+    {{.ShipTo.Street}}<br>
+    {{.ShipTo.City}}, {{.ShipTo.State}} {{.ShipTo.PostalCode}}<br>
+    {{.ShipTo.Country}}<br>
+    {{.ShipTo.Phone}}
+  </address>
+  <table>
+    <tr><th>SKU</th><th>Item</th><th>Status</th><th class="qty">Qty</th></tr>
+    {{range .Items}}<tr><td>{{if .VariantSKU}}{{.VariantSKU}}{{else}}{{.SKU}}{{end}}</td><td>{{.ProductName}}</td><td>{{.FulfillmentStatus}}</td><td class="qty">{{.Quantity}}</td></tr>
+    {{end}}<tr><th colspan="3">Total units</th><th class="qty">{{.TotalUnits}}</th></tr>
+  </table>
+</body>
+</html>`))
+
+func renderPackingSlip(c *gin.Context, slip *models.PackingSlip) {
+	var page bytes.Buffer
+	if err := packingSlipPage.Execute(&page, slip); err != nil {
+		utils.GinInternalErrorResponse(c, "Failed to render packing slip", err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentMethodHandler struct {
+	methodService service.PaymentMethodService
+}
+
+func NewPaymentMethodHandler(methodService service.PaymentMethodService) *PaymentMethodHandler {
+	return &PaymentMethodHandler{methodService: methodService}
+}
+
+func (h *PaymentMethodHandler) GetPaymentMethods(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	methods, err := h.methodService.GetPaymentMethods(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Payment methods retrieved successfully", methods)
+}
+
+func (h *PaymentMethodHandler) AddPaymentMethod(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.SavePaymentMethodRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	method, err := h.methodService.AddPaymentMethod(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Payment method saved successfully", method)
+}
+
+func (h *PaymentMethodHandler) SetDefault(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	method, err := h.methodService.SetDefault(c.Request.Context(), userID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Default payment method updated successfully", method)
+}
+
+func (h *PaymentMethodHandler) DeletePaymentMethod(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.methodService.DeletePaymentMethod(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Payment method deleted successfully", nil)
+}
@@ -1,6 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/gateway"
 	"ecommerce-backend/internal/middleware"
 	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/service"
@@ -12,10 +21,14 @@ import (
 
 type PaymentHandler struct {
 	paymentService service.PaymentService
+	appBaseURL     string
 }
 
-func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
-	return &PaymentHandler{paymentService: paymentService}
+func NewPaymentHandler(paymentService service.PaymentService, appBaseURL string) *PaymentHandler {
+	return &PaymentHandler{
+		paymentService: paymentService,
+		appBaseURL:     strings.TrimRight(appBaseURL, "/"),
+	}
 }
 
 func (h *PaymentHandler) CreatePayment(c *gin.Context) {
@@ -26,13 +39,7 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 	}
 
 	var req models.CreatePaymentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
-
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -44,7 +51,7 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 
 	payment, err := h.paymentService.CreatePayment(c.Request.Context(), req, userUUID)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to create payment", err)
+		c.Error(err)
 		return
 	}
 
@@ -59,23 +66,57 @@ func (h *PaymentHandler) VerifyPayment(c *gin.Context) {
 	}
 
 	var req models.VerifyPaymentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	payment, err := h.paymentService.VerifyPayment(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	utils.GinSuccessResponse(c, "Payment verified successfully", payment)
+}
+
+// GetPaymentStatus is polled by clients waiting for a payment to settle
+func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 
-	payment, err := h.paymentService.VerifyPayment(c.Request.Context(), req)
+	paymentID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	status, err := h.paymentService.GetPaymentStatus(c.Request.Context(), paymentID, userID)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to verify payment", err)
+		c.Error(err)
 		return
 	}
 
-	utils.GinSuccessResponse(c, "Payment verified successfully", payment)
+	utils.GinSuccessResponse(c, "Payment status retrieved successfully", status)
+}
+
+// PaymentCallback is where the gateway sends the customer's browser after
+// they authenticate a payment. It settles the payment and redirects to the
+// order page in the storefront.
+func (h *PaymentHandler) PaymentCallback(c *gin.Context) {
+	paymentID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	payment, err := h.paymentService.ConfirmPayment(c.Request.Context(), paymentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	redirect := fmt.Sprintf("%s/orders/%s?payment_status=%s", h.appBaseURL, payment.OrderID, url.QueryEscape(string(payment.Status)))
+	c.Redirect(http.StatusFound, redirect)
 }
 
 func (h *PaymentHandler) GetPaymentByOrder(c *gin.Context) {
@@ -85,16 +126,14 @@ func (h *PaymentHandler) GetPaymentByOrder(c *gin.Context) {
 		return
 	}
 
-	orderID := c.Param("id")
-	orderUUID, err := uuid.Parse(orderID)
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid order ID", err)
+	orderUUID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
 		return
 	}
 
 	payment, err := h.paymentService.GetPaymentByOrderID(c.Request.Context(), orderUUID)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to retrieve payment", err)
+		c.Error(err)
 		return
 	}
 
@@ -105,3 +144,195 @@ func (h *PaymentHandler) GetPaymentByOrder(c *gin.Context) {
 
 	utils.GinSuccessResponse(c, "Payment retrieved successfully", payment)
 }
+
+func (h *PaymentHandler) GetOrderRefunds(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	orderUUID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	refunds, err := h.paymentService.GetOrderRefunds(c.Request.Context(), orderUUID, userUUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Refunds retrieved successfully", refunds)
+}
+
+func (h *PaymentHandler) GetAllPayments(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	filter, err := parsePaymentFilter(c)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid filter parameters", err)
+		return
+	}
+
+	payments, total, err := h.paymentService.GetAllPayments(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"payments": payments,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "All payments retrieved", response)
+}
+
+// parsePaymentFilter reads the admin payment list filters. Dates are
+// YYYY-MM-DD and both ends of the range are inclusive.
+func parsePaymentFilter(c *gin.Context) (models.PaymentFilter, error) {
+	filter := models.PaymentFilter{
+		Status: c.Query("status"),
+		Method: c.Query("method"),
+	}
+
+	if v := c.Query("from"); v != "" {
+		from, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return filter, errors.New("from must be a date in YYYY-MM-DD format")
+		}
+		filter.From = &from
+	}
+
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return filter, errors.New("to must be a date in YYYY-MM-DD format")
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return filter, errors.New("from must not be after to")
+	}
+
+	return filter, nil
+}
+
+// GetReconciliation reports orders whose status disagrees with their
+// payment records
+func (h *PaymentHandler) GetReconciliation(c *gin.Context) {
+	rangeDays := 30
+	if rd := c.Query("range_days"); rd != "" {
+		if parsed, err := strconv.Atoi(rd); err == nil && parsed >= 0 {
+			rangeDays = parsed
+		}
+	}
+
+	report, err := h.paymentService.ReconcilePayments(c.Request.Context(), rangeDays)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Payment reconciliation completed", report)
+}
+
+// CapturePayment captures an authorized payment, in full or in part; the
+// body is optional
+func (h *PaymentHandler) CapturePayment(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	paymentID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CapturePaymentRequest
+	if c.Request.ContentLength != 0 && !utils.BindJSON(c, &req) {
+		return
+	}
+
+	capture, err := h.paymentService.CapturePayment(c.Request.Context(), paymentID, adminID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Payment captured", capture)
+}
+
+// VoidPayment releases an authorized payment without capturing it
+func (h *PaymentHandler) VoidPayment(c *gin.Context) {
+	adminID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	paymentID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.VoidPaymentRequest
+	if c.Request.ContentLength != 0 && !utils.BindJSON(c, &req) {
+		return
+	}
+
+	capture, err := h.paymentService.VoidPayment(c.Request.Context(), paymentID, adminID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Payment voided", capture)
+}
+
+// StripeWebhook receives Stripe events; the raw body is required for signature verification
+func (h *PaymentHandler) StripeWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid request body", err)
+		return
+	}
+
+	err = h.paymentService.HandleWebhook(c.Request.Context(), "stripe", payload, c.GetHeader("Stripe-Signature"))
+	if err != nil {
+		if errors.Is(err, gateway.ErrInvalidSignature) {
+			utils.GinErrorResponse(c, http.StatusUnauthorized, "Invalid webhook signature", err)
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Webhook processed", nil)
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service/servicemock"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// newPaymentService returns payment, refunds and err from every call the
+// payment handlers make. Calls it does not set up panic.
+func newPaymentService(payment *models.Payment, refunds []models.Refund, err error) *servicemock.PaymentServiceMock {
+	return &servicemock.PaymentServiceMock{
+		CreatePaymentFunc: func(ctx context.Context, req models.CreatePaymentRequest, userID uuid.UUID) (*models.Payment, error) {
+			return payment, err
+		},
+		GetPaymentByOrderIDFunc: func(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
+			return payment, err
+		},
+		GetOrderRefundsFunc: func(ctx context.Context, orderID, userID uuid.UUID) ([]models.Refund, error) {
+			return refunds, err
+		},
+		GetPaymentStatusFunc: func(ctx context.Context, paymentID, userID uuid.UUID) (*models.PaymentStatusResponse, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &models.PaymentStatusResponse{PaymentID: payment.ID, Status: payment.Status}, nil
+		},
+		ConfirmPaymentFunc: func(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+			return payment, err
+		},
+	}
+}
+
+func TestPaymentHandlerCreatePayment(t *testing.T) {
+	orderID := uuid.New()
+	body := `{"order_id":"` + orderID.String() + `","payment_method":"cc"}`
+
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", body: body, wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "malformed JSON", userID: testUserID, body: `{`, wantStatus: http.StatusBadRequest}, nil},
+		{handlerCase{name: "missing order", userID: testUserID, body: `{"payment_method":"cc"}`, wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "already paid", userID: testUserID, body: body, wantStatus: http.StatusConflict, wantError: "conflict"}, apperrors.Conflict("order is already paid")},
+		{handlerCase{name: "someone else's order", userID: testUserID, body: body, wantStatus: http.StatusForbidden, wantError: "forbidden"}, apperrors.Forbidden("order belongs to another user")},
+		{handlerCase{name: "ok", userID: testUserID, body: body, wantStatus: http.StatusCreated}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newPaymentService(&models.Payment{ID: uuid.New(), OrderID: orderID}, nil, tt.err)
+			tt.method, tt.path = http.MethodPost, "/payments"
+
+			serve(t, "/payments", NewPaymentHandler(svc, "").CreatePayment, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			call := svc.CreatePaymentCalls()[0]
+			if call.Req.OrderID != orderID || call.Req.PaymentMethod != "cc" {
+				t.Errorf("service got %+v, want order %s paid by cc", call.Req, orderID)
+			}
+			if call.UserID.String() != testUserID {
+				t.Errorf("service got user %s, want %s", call.UserID, testUserID)
+			}
+		})
+	}
+}
+
+func TestPaymentHandlerGetPaymentByOrder(t *testing.T) {
+	orderID := uuid.New()
+	path := "/orders/" + orderID.String() + "/payment"
+
+	tests := []struct {
+		handlerCase
+		payment *models.Payment
+		err     error
+	}{
+		{handlerCase{name: "unauthenticated", path: path, wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil, nil},
+		{handlerCase{name: "malformed order ID", userID: testUserID, path: "/orders/x/payment", wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil, nil},
+		{handlerCase{name: "not paid yet", userID: testUserID, path: path, wantStatus: http.StatusNotFound, wantError: "not_found"}, nil, nil},
+		{handlerCase{name: "ok", userID: testUserID, path: path, wantStatus: http.StatusOK}, &models.Payment{ID: uuid.New(), OrderID: orderID, Amount: money.FromMajor(25)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newPaymentService(tt.payment, nil, tt.err)
+			tt.method = http.MethodGet
+
+			_, resp := serve(t, "/orders/:id/payment", NewPaymentHandler(svc, "").GetPaymentByOrder, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got models.Payment
+			decodeData(t, resp, &got)
+			if got.ID != tt.payment.ID || got.Amount != tt.payment.Amount {
+				t.Errorf("payment = %s for %s, want %s for %s", got.ID, got.Amount, tt.payment.ID, tt.payment.Amount)
+			}
+		})
+	}
+}
+
+func TestPaymentHandlerGetOrderRefunds(t *testing.T) {
+	orderID := uuid.New()
+	path := "/orders/" + orderID.String() + "/refunds"
+
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", path: path, wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "malformed order ID", userID: testUserID, path: "/orders/1/refunds", wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "someone else's order", userID: testUserID, path: path, wantStatus: http.StatusNotFound, wantError: "not_found"}, apperrors.NotFound("order not found")},
+		{handlerCase{name: "ok", userID: testUserID, path: path, wantStatus: http.StatusOK}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refunds := []models.Refund{
+				{ID: uuid.New(), OrderID: orderID, Amount: money.FromMajor(4)},
+				{ID: uuid.New(), OrderID: orderID, Amount: money.FromMajor(6)},
+			}
+			svc := newPaymentService(nil, refunds, tt.err)
+			tt.method = http.MethodGet
+
+			_, resp := serve(t, "/orders/:id/refunds", NewPaymentHandler(svc, "").GetOrderRefunds, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if call := svc.GetOrderRefundsCalls()[0]; call.OrderID != orderID || call.UserID.String() != testUserID {
+				t.Errorf("service got order %s for user %s, want %s for %s", call.OrderID, call.UserID, orderID, testUserID)
+			}
+			var got []models.Refund
+			decodeData(t, resp, &got)
+			if len(got) != len(refunds) {
+				t.Errorf("got %d refunds, want %d", len(got), len(refunds))
+			}
+		})
+	}
+}
+
+func TestPaymentHandlerGetPaymentStatus(t *testing.T) {
+	paymentID := uuid.New()
+	path := "/payments/" + paymentID.String() + "/status"
+
+	tests := []struct {
+		handlerCase
+		err error
+	}{
+		{handlerCase{name: "unauthenticated", path: path, wantStatus: http.StatusUnauthorized, wantError: "unauthorized"}, nil},
+		{handlerCase{name: "malformed user ID", userID: "nobody", path: path, wantStatus: http.StatusBadRequest}, nil},
+		{handlerCase{name: "malformed payment ID", userID: testUserID, path: "/payments/pay_123/status", wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil},
+		{handlerCase{name: "unknown payment", userID: testUserID, path: path, wantStatus: http.StatusNotFound, wantError: "not_found"}, apperrors.NotFound("payment not found")},
+		{handlerCase{name: "ok", userID: testUserID, path: path, wantStatus: http.StatusOK}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newPaymentService(&models.Payment{ID: paymentID, Status: models.PaymentPending}, nil, tt.err)
+			tt.method = http.MethodGet
+
+			_, resp := serve(t, "/payments/:id/status", NewPaymentHandler(svc, "").GetPaymentStatus, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got models.PaymentStatusResponse
+			decodeData(t, resp, &got)
+			if got.PaymentID != paymentID || got.Status != models.PaymentPending {
+				t.Errorf("status = %+v, want payment %s pending", got, paymentID)
+			}
+		})
+	}
+}
+
+func TestPaymentHandlerPaymentCallback(t *testing.T) {
+	paymentID, orderID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		handlerCase
+		err          error
+		wantLocation string
+	}{
+		{handlerCase{name: "malformed payment ID", path: "/payments/x/callback", wantStatus: http.StatusUnprocessableEntity, wantError: "validation_error"}, nil, ""},
+		{handlerCase{name: "unknown payment", path: "/payments/" + paymentID.String() + "/callback", wantStatus: http.StatusNotFound, wantError: "not_found"}, apperrors.NotFound("payment not found"), ""},
+		{
+			handlerCase{name: "redirects to the order", path: "/payments/" + paymentID.String() + "/callback", wantStatus: http.StatusFound},
+			nil,
+			"https://shop.example.com/orders/" + orderID.String() + "?payment_status=completed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newPaymentService(&models.Payment{ID: paymentID, OrderID: orderID, Status: models.PaymentCompleted}, nil, tt.err)
+			tt.method = http.MethodGet
+
+			// The callback is public; the gateway's redirect carries no token
+			rec, _ := serve(t, "/payments/:id/callback", NewPaymentHandler(svc, "https://shop.example.com/").PaymentCallback, tt.handlerCase)
+
+			if tt.wantStatus != http.StatusFound {
+				return
+			}
+			if location := rec.Header().Get("Location"); location != tt.wantLocation {
+				t.Errorf("redirected to %q, want %q", location, tt.wantLocation)
+			}
+		})
+	}
+}
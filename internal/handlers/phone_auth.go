@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PhoneAuthHandler struct {
+	phoneAuthService service.PhoneAuthService
+}
+
+func NewPhoneAuthHandler(phoneAuthService service.PhoneAuthService) *PhoneAuthHandler {
+	return &PhoneAuthHandler{phoneAuthService: phoneAuthService}
+}
+
+// UpdatePhone sets the customer's phone number and texts a code to verify it
+func (h *PhoneAuthHandler) UpdatePhone(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePhoneRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.phoneAuthService.SetPhone(c.Request.Context(), userID, req.Phone); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Verification code sent", nil)
+}
+
+func (h *PhoneAuthHandler) VerifyPhone(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req models.VerifyPhoneRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.phoneAuthService.VerifyPhone(c.Request.Context(), userID, req.OTP)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Phone number verified", user)
+}
+
+// RequestLoginOTP texts a sign-in code. The response is the same whether or
+// not the number belongs to an account.
+func (h *PhoneAuthHandler) RequestLoginOTP(c *gin.Context) {
+	var req models.RequestLoginOTPRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.phoneAuthService.RequestLoginOTP(c.Request.Context(), req.Phone); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "If the number belongs to an account, a sign-in code has been sent", nil)
+}
+
+func (h *PhoneAuthHandler) LoginWithOTP(c *gin.Context) {
+	var req models.OTPLoginRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.phoneAuthService.LoginWithOTP(c.Request.Context(), req.Phone, req.OTP)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Login successful", response)
+}
+
+// RequestReauthOTP texts the caller a code that re-authenticates them before
+// closing their account or exporting their data, for accounts without a
+// password
+func (h *PhoneAuthHandler) RequestReauthOTP(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.phoneAuthService.RequestReauthOTP(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Verification code sent", nil)
+}
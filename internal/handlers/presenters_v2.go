@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/pagination"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// The v2 API renders models through these presenters instead of serialising
+// them directly, so v1 response shapes can stay frozen while v2 evolves.
+
+// v2Amount is a money value with its currency. Amount is the exact decimal
+// string; AmountMinor the same value in minor units.
+type v2Amount struct {
+	Amount      string `json:"amount"`
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+}
+
+// v2Presenter holds what rendering needs beyond the models themselves
+type v2Presenter struct {
+	currency string
+}
+
+func newV2Presenter(currency string) v2Presenter {
+	return v2Presenter{currency: strings.ToUpper(currency)}
+}
+
+func (p v2Presenter) amount(m money.Money) v2Amount {
+	return v2Amount{Amount: m.String(), AmountMinor: m.Minor(), Currency: p.currency}
+}
+
+// v2PageMeta replaces page/total with an opaque cursor for the next page
+type v2PageMeta struct {
+	Limit      int     `json:"limit"`
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+}
+
+// parseV2Page reads the cursor and limit query parameters of a v2 list
+// endpoint, writing a 422 and returning false when the cursor is not one the
+// API issued
+func parseV2Page(c *gin.Context, defaultLimit, maxLimit int) (*pagination.Cursor, int, bool) {
+	limit := defaultLimit
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= maxLimit {
+			limit = parsed
+		}
+	}
+
+	after, err := pagination.Decode(c.Query("cursor"))
+	if err != nil {
+		utils.GinValidationErrorResponse(c, map[string]string{
+			"cursor": "Must be a next_cursor returned by a previous page",
+		})
+		return nil, 0, false
+	}
+
+	return after, limit, true
+}
+
+func newV2PageMeta(limit int, next *pagination.Cursor) v2PageMeta {
+	meta := v2PageMeta{Limit: limit}
+	if next != nil {
+		token := next.Encode()
+		meta.NextCursor = &token
+		meta.HasMore = true
+	}
+	return meta
+}
+
+type v2Sale struct {
+	OriginalPrice v2Amount  `json:"original_price"`
+	Label         string    `json:"label,omitempty"`
+	EndsAt        time.Time `json:"ends_at"`
+}
+
+func (p v2Presenter) sale(sale *models.SaleInfo) *v2Sale {
+	if sale == nil {
+		return nil
+	}
+	return &v2Sale{OriginalPrice: p.amount(sale.OriginalPrice), Label: sale.Label, EndsAt: sale.EndsAt}
+}
+
+type v2Variant struct {
+	ID         uuid.UUID         `json:"id"`
+	SKU        string            `json:"sku"`
+	Price      v2Amount          `json:"price"`
+	Stock      int               `json:"stock"`
+	Attributes map[string]string `json:"attributes"`
+	Sale       *v2Sale           `json:"sale,omitempty"`
+}
+
+func (p v2Presenter) variant(v models.ProductVariant) v2Variant {
+	return v2Variant{
+		ID:         v.ID,
+		SKU:        v.SKU,
+		Price:      p.amount(v.Price),
+		Stock:      v.Stock,
+		Attributes: v.Attributes,
+		Sale:       p.sale(v.Sale),
+	}
+}
+
+type v2Product struct {
+	ID             uuid.UUID         `json:"id"`
+	SKU            string            `json:"sku"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description"`
+	Price          v2Amount          `json:"price"`
+	Stock          int               `json:"stock"`
+	Category       string            `json:"category"`
+	ImageURL       string            `json:"image_url"`
+	ImageVariants  map[string]string `json:"image_variants,omitempty"`
+	Variants       []v2Variant       `json:"variants,omitempty"`
+	Bundle         []v2Component     `json:"bundle,omitempty"`
+	Related        *v2Related        `json:"related,omitempty"`
+	Sale           *v2Sale           `json:"sale,omitempty"`
+	MaxPerOrder    *int              `json:"max_per_order,omitempty"`
+	MaxPerCustomer *int              `json:"max_per_customer,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+func (p v2Presenter) product(product models.Product) v2Product {
+	out := v2Product{
+		ID:             product.ID,
+		SKU:            product.SKU,
+		Name:           product.Name,
+		Description:    product.Description,
+		Price:          p.amount(product.Price),
+		Stock:          product.Stock,
+		Category:       product.Category,
+		ImageURL:       product.ImageURL,
+		ImageVariants:  product.ImageVariants,
+		Sale:           p.sale(product.Sale),
+		MaxPerOrder:    product.MaxPerOrder,
+		MaxPerCustomer: product.MaxPerCustomer,
+		CreatedAt:      product.CreatedAt,
+		UpdatedAt:      product.UpdatedAt,
+	}
+	for _, v := range product.Variants {
+		out.Variants = append(out.Variants, p.variant(v))
+	}
+	for _, c := range product.Bundle {
+		out.Bundle = append(out.Bundle, v2Component{
+			ProductID: c.ProductID,
+			VariantID: c.VariantID,
+			SKU:       c.SKU,
+			Name:      c.Name,
+			Quantity:  c.Quantity,
+		})
+	}
+	if product.Related != nil {
+		out.Related = &v2Related{
+			CrossSell: p.products(product.Related.CrossSell),
+			Upsell:    p.products(product.Related.Upsell),
+		}
+	}
+	return out
+}
+
+// v2Component is one component of a bundle; quantity is per bundle
+type v2Component struct {
+	ProductID uuid.UUID  `json:"product_id"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	SKU       string     `json:"sku"`
+	Name      string     `json:"name"`
+	Quantity  int        `json:"quantity"`
+}
+
+type v2Related struct {
+	CrossSell []v2Product `json:"cross_sell"`
+	Upsell    []v2Product `json:"upsell"`
+}
+
+func (p v2Presenter) products(products []models.Product) []v2Product {
+	out := make([]v2Product, 0, len(products))
+	for _, product := range products {
+		out = append(out, p.product(product))
+	}
+	return out
+}
+
+type v2OrderItem struct {
+	ID                uuid.UUID                `json:"id"`
+	ProductID         uuid.UUID                `json:"product_id"`
+	ProductName       string                   `json:"product_name"`
+	ProductSKU        string                   `json:"product_sku"`
+	ImageURL          string                   `json:"image_url"`
+	VariantID         *uuid.UUID               `json:"variant_id,omitempty"`
+	VariantSKU        *string                  `json:"variant_sku,omitempty"`
+	Quantity          int                      `json:"quantity"`
+	UnitPrice         v2Amount                 `json:"unit_price"`
+	LineTotal         v2Amount                 `json:"line_total"`
+	FulfillmentStatus models.FulfillmentStatus `json:"fulfillment_status"`
+	TrackingNumber    *string                  `json:"tracking_number,omitempty"`
+	ShippedAt         *time.Time               `json:"shipped_at,omitempty"`
+	DeliveredAt       *time.Time               `json:"delivered_at,omitempty"`
+	BundleComponents  []v2Component            `json:"bundle_components,omitempty"`
+}
+
+type v2Order struct {
+	ID              uuid.UUID                 `json:"id"`
+	OrderNumber     string                    `json:"order_number"`
+	Status          models.OrderStatus        `json:"status"`
+	PaymentMethod   string                    `json:"payment_method"`
+	Total           v2Amount                  `json:"total"`
+	GiftCardAmount  v2Amount                  `json:"gift_card_amount"`
+	AmountDue       v2Amount                  `json:"amount_due"`
+	ShippingAddress models.Address            `json:"shipping_address"`
+	BillingAddress  models.Address            `json:"billing_address"`
+	Items           []v2OrderItem             `json:"items,omitempty"`
+	Fulfillment     models.FulfillmentSummary `json:"fulfillment,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+}
+
+func (p v2Presenter) order(order models.Order) v2Order {
+	out := v2Order{
+		ID:              order.ID,
+		OrderNumber:     order.OrderNumber,
+		Status:          order.Status,
+		PaymentMethod:   order.PaymentMethod,
+		Total:           p.amount(order.TotalAmount),
+		GiftCardAmount:  p.amount(order.GiftCardAmount),
+		AmountDue:       p.amount(order.AmountDue()),
+		ShippingAddress: order.ShippingAddress,
+		BillingAddress:  order.BillingAddress,
+		Fulfillment:     order.Fulfillment,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+	}
+	for _, item := range order.Items {
+		line := v2OrderItem{
+			ID:                item.ID,
+			ProductID:         item.ProductID,
+			ProductName:       item.Product.Name,
+			ProductSKU:        item.Product.SKU,
+			ImageURL:          item.Product.ImageURL,
+			VariantID:         item.VariantID,
+			Quantity:          item.Quantity,
+			UnitPrice:         p.amount(item.PriceAtTime),
+			LineTotal:         p.amount(item.PriceAtTime.Mul(item.Quantity)),
+			FulfillmentStatus: item.FulfillmentStatus,
+			TrackingNumber:    item.TrackingNumber,
+			ShippedAt:         item.ShippedAt,
+			DeliveredAt:       item.DeliveredAt,
+		}
+		if item.Variant != nil {
+			line.VariantSKU = &item.Variant.SKU
+		}
+		for _, c := range item.BundleComponents {
+			line.BundleComponents = append(line.BundleComponents, v2Component(c))
+		}
+		out.Items = append(out.Items, line)
+	}
+	return out
+}
+
+func (p v2Presenter) orders(orders []models.Order) []v2Order {
+	out := make([]v2Order, 0, len(orders))
+	for _, order := range orders {
+		out = append(out, p.order(order))
+	}
+	return out
+}
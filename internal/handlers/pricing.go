@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PricingHandler struct {
+	pricingService service.PricingService
+}
+
+func NewPricingHandler(pricingService service.PricingService) *PricingHandler {
+	return &PricingHandler{pricingService: pricingService}
+}
+
+func (h *PricingHandler) SchedulePrice(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.PriceScheduleRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	schedule, err := h.pricingService.SchedulePrice(c.Request.Context(), productID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Price scheduled successfully", schedule)
+}
+
+func (h *PricingHandler) GetSchedules(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	schedules, err := h.pricingService.GetSchedules(c.Request.Context(), productID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Price schedules retrieved successfully", schedules)
+}
+
+func (h *PricingHandler) DeleteSchedule(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	scheduleID, ok := utils.ParseUUIDParam(c, "priceId")
+	if !ok {
+		return
+	}
+
+	if err := h.pricingService.DeleteSchedule(c.Request.Context(), productID, scheduleID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Price schedule deleted successfully", nil)
+}
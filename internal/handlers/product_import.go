@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxImportFileSize caps product import uploads at 20 MB
+const maxImportFileSize = 20 << 20
+
+type ProductImportHandler struct {
+	importService service.ProductImportService
+}
+
+func NewProductImportHandler(importService service.ProductImportService) *ProductImportHandler {
+	return &ProductImportHandler{importService: importService}
+}
+
+// ImportProducts accepts a CSV or XLSX upload in the "file" form field and
+// starts an asynchronous import job
+func (h *ProductImportHandler) ImportProducts(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	// The route's body limit leaves room for the multipart envelope
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.GinPayloadTooLargeResponse(c, tooLarge.Limit)
+			return
+		}
+		utils.GinBadRequestResponse(c, "A CSV or XLSX file is required in the 'file' field", err)
+		return
+	}
+
+	if fileHeader.Size > maxImportFileSize {
+		utils.GinBadRequestResponse(c, "File too large", fmt.Errorf("maximum size is %d MB", maxImportFileSize>>20))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Failed to read upload", err)
+		return
+	}
+	defer file.Close()
+
+	job, err := h.importService.StartImport(c.Request.Context(), fileHeader.Filename, file, userUUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinAcceptedResponse(c, "Product import started", job)
+}
+
+func (h *ProductImportHandler) GetImportJob(c *gin.Context) {
+	jobID, ok := utils.ParseUUIDParam(c, "jobId")
+	if !ok {
+		return
+	}
+
+	job, err := h.importService.GetImportJob(c.Request.Context(), jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Import job retrieved successfully", job)
+}
@@ -1,44 +1,44 @@
 package handlers
 
 import (
-	"net/http"
+	"errors"
 	"strconv"
+	"strings"
+	"time"
 
+	"ecommerce-backend/internal/media"
 	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/money"
 	"ecommerce-backend/pkg/utils"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type ProductHandler struct {
 	productService service.ProductService
+	media          *media.URLBuilder
+	cacheControl   string
 }
 
-func NewProductHandler(productService service.ProductService) *ProductHandler {
-	return &ProductHandler{productService: productService}
+// NewProductHandler serves product images through mediaURLs; catalog reads
+// may be cached at the edge for edgeTTL
+func NewProductHandler(productService service.ProductService, mediaURLs *media.URLBuilder, edgeTTL time.Duration) *ProductHandler {
+	return &ProductHandler{productService: productService, media: mediaURLs, cacheControl: edgeCacheControl(edgeTTL)}
 }
 
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	var req models.ProductRequest
 
 	// Parse request body
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
-
-	// Validate request
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	// Create product
 	product, err := h.productService.CreateProduct(c.Request.Context(), req)
 	if err != nil {
-		utils.GinErrorResponse(c, http.StatusBadRequest, "Failed to create product", err)
+		c.Error(err)
 		return
 	}
 
@@ -47,20 +47,20 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 
 func (h *ProductHandler) GetProduct(c *gin.Context) {
 	// Get product ID from URL
-	productID, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid product ID", err)
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
 		return
 	}
 
 	// Get product
 	product, err := h.productService.GetProduct(c.Request.Context(), productID)
 	if err != nil {
-		utils.GinNotFoundResponse(c, "Product")
+		c.Error(err)
 		return
 	}
 
-	utils.GinSuccessResponse(c, "Product retrieved successfully", product)
+	h.media.Product(product)
+	respondConditional(c, h.cacheControl, "Product retrieved successfully", product, productsLastModified(*product))
 }
 
 func (h *ProductHandler) GetProducts(c *gin.Context) {
@@ -79,18 +79,30 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		}
 	}
 
-	category := c.Query("category")
-	search := c.Query("search")
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid filter parameters", err)
+		return
+	}
 
 	// Get products
-	products, total, err := h.productService.GetProducts(c.Request.Context(), page, limit, category, search)
+	products, total, err := h.productService.GetProducts(c.Request.Context(), page, limit, filter)
 	if err != nil {
-		utils.GinInternalErrorResponse(c, "Failed to get products", err)
+		c.Error(err)
+		return
+	}
+
+	for i := range products {
+		h.media.Product(&products[i])
+	}
+
+	selected, ok := selectFields(c, products)
+	if !ok {
 		return
 	}
 
 	response := map[string]interface{}{
-		"products": products,
+		"products": selected,
 		"meta": map[string]interface{}{
 			"page":       page,
 			"limit":      limit,
@@ -99,35 +111,27 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		},
 	}
 
-	utils.GinSuccessResponse(c, "Products retrieved successfully", response)
+	respondConditional(c, h.cacheControl, "Products retrieved successfully", response, productsLastModified(products...))
 }
 
 func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	// Get product ID from URL
-	productID, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid product ID", err)
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
 		return
 	}
 
 	var req models.ProductUpdateRequest
 
 	// Parse request body
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
-
-	// Validate request
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	// Update product
 	product, err := h.productService.UpdateProduct(c.Request.Context(), productID, req)
 	if err != nil {
-		utils.GinErrorResponse(c, http.StatusBadRequest, "Failed to update product", err)
+		c.Error(err)
 		return
 	}
 
@@ -136,22 +140,64 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 
 func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	// Get product ID from URL
-	productID, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid product ID", err)
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
 		return
 	}
 
 	// Delete product
-	err = h.productService.DeleteProduct(c.Request.Context(), productID)
+	err := h.productService.DeleteProduct(c.Request.Context(), productID)
 	if err != nil {
-		utils.GinErrorResponse(c, http.StatusBadRequest, "Failed to delete product", err)
+		c.Error(err)
 		return
 	}
 
 	utils.GinSuccessResponse(c, "Product deleted successfully", nil)
 }
 
+// SetProductSchedule replaces when the product is published and unpublished
+func (h *ProductHandler) SetProductSchedule(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ProductScheduleRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	product, err := h.productService.SetSchedule(c.Request.Context(), productID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Product schedule updated", product)
+}
+
+// DuplicateProduct copies a product and its variants into a new draft
+func (h *ProductHandler) DuplicateProduct(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Every field is optional, so an empty body is fine
+	var req models.DuplicateProductRequest
+	if c.Request.ContentLength != 0 && !utils.BindJSON(c, &req) {
+		return
+	}
+
+	product, err := h.productService.DuplicateProduct(c.Request.Context(), productID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Product duplicated successfully", product)
+}
+
 func (h *ProductHandler) GetAdminProducts(c *gin.Context) {
 	page := 1
 	if p := c.Query("page"); p != "" {
@@ -174,14 +220,29 @@ func (h *ProductHandler) GetAdminProducts(c *gin.Context) {
 		}
 	}
 
-	products, total, err := h.productService.GetAdminProducts(c.Request.Context(), page, limit, rangeDays)
+	status := models.ProductStatus(c.Query("status"))
+	switch status {
+	case "", models.ProductDraft, models.ProductActive, models.ProductArchived:
+	default:
+		utils.GinValidationErrorResponse(c, map[string]string{
+			"status": "Must be draft, active or archived",
+		})
+		return
+	}
+
+	products, total, err := h.productService.GetAdminProducts(c.Request.Context(), page, limit, rangeDays, status)
 	if err != nil {
-		utils.GinInternalErrorResponse(c, "Failed to get products", err)
+		c.Error(err)
+		return
+	}
+
+	selected, ok := selectFields(c, products)
+	if !ok {
 		return
 	}
 
 	response := map[string]interface{}{
-		"products": products,
+		"products": selected,
 		"meta": map[string]interface{}{
 			"page":       page,
 			"limit":      limit,
@@ -193,6 +254,38 @@ func (h *ProductHandler) GetAdminProducts(c *gin.Context) {
 	utils.GinSuccessResponse(c, "Products retrieved successfully", response)
 }
 
+// ExportProducts streams the products matching the admin list filters as CSV.
+// The columns match the bulk import format so the file can be edited and
+// re-imported.
+func (h *ProductHandler) ExportProducts(c *gin.Context) {
+	rangeDays := 30
+	if rd := c.Query("range_days"); rd != "" {
+		if val, err := strconv.Atoi(rd); err == nil && val >= 0 {
+			rangeDays = val
+		}
+	}
+
+	header := []string{"sku", "name", "description", "price", "stock_quantity", "category", "image_url",
+		"id", "created_at", "updated_at"}
+
+	streamCSV(c, "products", header, func(write func([]string) error) error {
+		return h.productService.ExportProducts(c.Request.Context(), rangeDays, func(product models.Product) error {
+			return write([]string{
+				product.SKU,
+				product.Name,
+				product.Description,
+				formatCSVAmount(product.Price),
+				strconv.Itoa(product.Stock),
+				product.Category,
+				product.ImageURL,
+				product.ID.String(),
+				formatCSVTime(&product.CreatedAt),
+				formatCSVTime(&product.UpdatedAt),
+			})
+		})
+	})
+}
+
 func (h *ProductHandler) GetTopProducts(c *gin.Context) {
 	limit := 10
 	if l := c.Query("limit"); l != "" {
@@ -210,7 +303,7 @@ func (h *ProductHandler) GetTopProducts(c *gin.Context) {
 
 	items, err := h.productService.GetTopProducts(c.Request.Context(), limit, rangeDays)
 	if err != nil {
-		utils.GinInternalErrorResponse(c, "Failed to get top products", err)
+		c.Error(err)
 		return
 	}
 
@@ -221,3 +314,150 @@ func (h *ProductHandler) GetTopProducts(c *gin.Context) {
 
 	utils.GinSuccessResponse(c, "Top products retrieved", response)
 }
+
+// GetProductByBarcode resolves a scanned EAN/UPC code to the product, and the
+// variant when the code belongs to one
+func (h *ProductHandler) GetProductByBarcode(c *gin.Context) {
+	match, err := h.productService.GetByBarcode(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Product retrieved successfully", match)
+}
+
+func (h *ProductHandler) GetProductVariants(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	variants, err := h.productService.GetVariants(c.Request.Context(), productID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Variants retrieved successfully", variants)
+}
+
+func (h *ProductHandler) CreateProductVariant(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ProductVariantRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	variant, err := h.productService.CreateVariant(c.Request.Context(), productID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Variant created successfully", variant)
+}
+
+func (h *ProductHandler) UpdateProductVariant(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	variantID, ok := utils.ParseUUIDParam(c, "variantId")
+	if !ok {
+		return
+	}
+
+	var req models.ProductVariantUpdateRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	variant, err := h.productService.UpdateVariant(c.Request.Context(), productID, variantID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Variant updated successfully", variant)
+}
+
+func (h *ProductHandler) DeleteProductVariant(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	variantID, ok := utils.ParseUUIDParam(c, "variantId")
+	if !ok {
+		return
+	}
+
+	if err := h.productService.DeleteVariant(c.Request.Context(), productID, variantID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Variant deleted successfully", nil)
+}
+
+func (h *ProductHandler) GetProductFacets(c *gin.Context) {
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid filter parameters", err)
+		return
+	}
+
+	facets, err := h.productService.GetProductFacets(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Product facets retrieved successfully", facets)
+}
+
+// parseProductFilter reads the storefront filter query parameters shared by
+// the product list and facet endpoints
+func parseProductFilter(c *gin.Context) (models.ProductFilter, error) {
+	filter := models.ProductFilter{
+		Search: c.Query("search"),
+		Sort:   c.Query("sort"),
+	}
+
+	// Categories may be repeated (?category=a&category=b) or comma-separated
+	for _, value := range c.QueryArray("category") {
+		for _, category := range strings.Split(value, ",") {
+			if category = strings.TrimSpace(category); category != "" {
+				filter.Categories = append(filter.Categories, category)
+			}
+		}
+	}
+
+	if v := c.Query("min_price"); v != "" {
+		minPrice, err := money.Parse(v)
+		if err != nil || minPrice.IsNegative() {
+			return filter, errors.New("min_price must be a non-negative number")
+		}
+		filter.MinPrice = &minPrice
+	}
+
+	if v := c.Query("max_price"); v != "" {
+		maxPrice, err := money.Parse(v)
+		if err != nil || maxPrice.IsNegative() {
+			return filter, errors.New("max_price must be a non-negative number")
+		}
+		filter.MaxPrice = &maxPrice
+	}
+
+	if v := c.Query("in_stock_only"); v != "" {
+		filter.InStockOnly, _ = strconv.ParseBool(v)
+	}
+
+	return filter, nil
+}
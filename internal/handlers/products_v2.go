@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"time"
+
+	"ecommerce-backend/internal/media"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProductV2Handler serves the v2 storefront catalogue: cursor pagination and
+// prices as currency-tagged amounts
+type ProductV2Handler struct {
+	productService service.ProductService
+	present        v2Presenter
+	media          *media.URLBuilder
+	cacheControl   string
+}
+
+func NewProductV2Handler(productService service.ProductService, currency string, mediaURLs *media.URLBuilder, edgeTTL time.Duration) *ProductV2Handler {
+	return &ProductV2Handler{
+		productService: productService,
+		present:        newV2Presenter(currency),
+		media:          mediaURLs,
+		cacheControl:   edgeCacheControl(edgeTTL),
+	}
+}
+
+func (h *ProductV2Handler) GetProducts(c *gin.Context) {
+	after, limit, ok := parseV2Page(c, 10, 100)
+	if !ok {
+		return
+	}
+
+	filter, err := parseProductFilter(c)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid filter parameters", err)
+		return
+	}
+
+	products, next, err := h.productService.GetProductsPage(c.Request.Context(), filter, after, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	for i := range products {
+		h.media.Product(&products[i])
+	}
+
+	selected, ok := selectFields(c, h.present.products(products))
+	if !ok {
+		return
+	}
+
+	response := map[string]interface{}{
+		"products": selected,
+		"meta":     newV2PageMeta(limit, next),
+	}
+
+	respondConditional(c, h.cacheControl, "Products retrieved successfully", response, productsLastModified(products...))
+}
+
+func (h *ProductV2Handler) GetProduct(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	product, err := h.productService.GetProduct(c.Request.Context(), productID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.media.Product(product)
+	respondConditional(c, h.cacheControl, "Product retrieved successfully", h.present.product(*product), productsLastModified(*product))
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionHandler struct {
+	promotionService service.PromotionService
+}
+
+func NewPromotionHandler(promotionService service.PromotionService) *PromotionHandler {
+	return &PromotionHandler{promotionService: promotionService}
+}
+
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	var req models.PromotionRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Promotion created successfully", promotion)
+}
+
+func (h *PromotionHandler) GetPromotions(c *gin.Context) {
+	promotions, err := h.promotionService.GetPromotions(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Promotions retrieved successfully", promotions)
+}
+
+func (h *PromotionHandler) GetPromotion(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	promotion, err := h.promotionService.GetPromotion(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Promotion retrieved successfully", promotion)
+}
+
+// UpdatePromotion replaces every field of the promotion
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.PromotionRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	promotion, err := h.promotionService.UpdatePromotion(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Promotion updated successfully", promotion)
+}
+
+func (h *PromotionHandler) DeletePromotion(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.promotionService.DeletePromotion(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Promotion deleted successfully", nil)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RelatedProductHandler struct {
+	relatedService service.RelatedProductService
+}
+
+func NewRelatedProductHandler(relatedService service.RelatedProductService) *RelatedProductHandler {
+	return &RelatedProductHandler{relatedService: relatedService}
+}
+
+func (h *RelatedProductHandler) AddRelated(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.RelatedProductRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	related, err := h.relatedService.AddRelated(c.Request.Context(), productID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Related product added successfully", related)
+}
+
+// GetRelated lists the products curated for a product, in every status
+func (h *RelatedProductHandler) GetRelated(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	relations, err := h.relatedService.GetRelated(c.Request.Context(), productID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Related products retrieved successfully", relations)
+}
+
+func (h *RelatedProductHandler) UpdateRelated(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	relatedID, ok := utils.ParseUUIDParam(c, "relatedId")
+	if !ok {
+		return
+	}
+
+	var req models.RelatedProductUpdateRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	related, err := h.relatedService.UpdateRelated(c.Request.Context(), productID, relatedID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Related product updated successfully", related)
+}
+
+func (h *RelatedProductHandler) RemoveRelated(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	relatedID, ok := utils.ParseUUIDParam(c, "relatedId")
+	if !ok {
+		return
+	}
+
+	if err := h.relatedService.RemoveRelated(c.Request.Context(), productID, relatedID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Related product removed successfully", nil)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReservationHandler struct {
+	cleanupService service.ReservationCleanupService
+}
+
+func NewReservationHandler(cleanupService service.ReservationCleanupService) *ReservationHandler {
+	return &ReservationHandler{cleanupService: cleanupService}
+}
+
+func (h *ReservationHandler) CleanupExpired(c *gin.Context) {
+	result, err := h.cleanupService.PurgeExpired(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Expired reservations purged successfully", result)
+}
+
+func (h *ReservationHandler) GetCleanupStats(c *gin.Context) {
+	utils.GinSuccessResponse(c, "Reservation cleanup stats retrieved successfully", h.cleanupService.Stats())
+}
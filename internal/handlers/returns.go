@@ -28,13 +28,7 @@ func (h *ReturnHandler) CreateReturn(c *gin.Context) {
 	}
 
 	var req models.CreateReturnRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
-		return
-	}
-
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -46,7 +40,7 @@ func (h *ReturnHandler) CreateReturn(c *gin.Context) {
 
 	returnReq, err := h.returnService.CreateReturn(c.Request.Context(), req, userUUID)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to create return", err)
+		c.Error(err)
 		return
 	}
 
@@ -82,7 +76,7 @@ func (h *ReturnHandler) GetUserReturns(c *gin.Context) {
 
 	returns, total, err := h.returnService.GetUserReturns(c.Request.Context(), userUUID, page, limit)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to retrieve returns", err)
+		c.Error(err)
 		return
 	}
 
@@ -112,16 +106,14 @@ func (h *ReturnHandler) GetReturn(c *gin.Context) {
 		return
 	}
 
-	returnID := c.Param("id")
-	returnUUID, err := uuid.Parse(returnID)
-	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid return ID", err)
+	returnUUID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
 		return
 	}
 
 	returnReq, err := h.returnService.GetReturn(c.Request.Context(), returnUUID, userUUID)
 	if err != nil {
-		utils.GinNotFoundResponse(c, "Return not found")
+		c.Error(err)
 		return
 	}
 
@@ -154,7 +146,7 @@ func (h *ReturnHandler) GetAllReturns(c *gin.Context) {
 
 	returns, total, err := h.returnService.GetAllReturns(c.Request.Context(), page, limit, status, rangeDays)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to retrieve returns", err)
+		c.Error(err)
 		return
 	}
 
@@ -172,32 +164,95 @@ func (h *ReturnHandler) GetAllReturns(c *gin.Context) {
 }
 
 func (h *ReturnHandler) ProcessReturn(c *gin.Context) {
-	returnID := c.Param("id")
-	if returnID == "" {
-		returnID = c.Param("returnId")
+	returnUUID, ok := utils.ParseUUIDParam(c, "returnId")
+	if !ok {
+		return
 	}
-	returnUUID, err := uuid.Parse(returnID)
+
+	var req models.ProcessReturnRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	returnReq, err := h.returnService.ProcessReturn(c.Request.Context(), returnUUID, req)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Invalid return ID", err)
+		c.Error(err)
 		return
 	}
 
-	var req models.ProcessReturnRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.GinBadRequestResponse(c, "Invalid request body", err)
+	utils.GinSuccessResponse(c, "Return processed", returnReq)
+}
+
+func (h *ReturnHandler) ShipReturn(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
 		return
 	}
 
-	if errors := utils.ValidateStruct(req); errors != nil {
-		utils.GinValidationErrorResponse(c, errors)
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
 		return
 	}
 
-	returnReq, err := h.returnService.ProcessReturn(c.Request.Context(), returnUUID, req)
+	returnUUID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ShipReturnRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	returnReq, err := h.returnService.ShipReturn(c.Request.Context(), returnUUID, userUUID, req)
 	if err != nil {
-		utils.GinBadRequestResponse(c, "Failed to process return", err)
+		c.Error(err)
 		return
 	}
 
-	utils.GinSuccessResponse(c, "Return processed", returnReq)
+	utils.GinSuccessResponse(c, "Return marked as in transit", returnReq)
+}
+
+func (h *ReturnHandler) GetReturnLabel(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	returnUUID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	label, err := h.returnService.GetReturnLabel(c.Request.Context(), returnUUID, userUUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Return label generated", label)
+}
+
+func (h *ReturnHandler) MarkReturnReceived(c *gin.Context) {
+	returnUUID, ok := utils.ParseUUIDParam(c, "returnId")
+	if !ok {
+		return
+	}
+
+	returnReq, err := h.returnService.MarkReturnReceived(c.Request.Context(), returnUUID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Return marked as received", returnReq)
 }
@@ -0,0 +1,107 @@
+//go:build integration
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+)
+
+func TestReturnIsRefundedAndRestocked(t *testing.T) {
+	app := newTestApp(t)
+	ctx := context.Background()
+	returns := app.repos.ReturnHandler.returnService
+	payments := app.repos.PaymentHandler.paymentService
+
+	product := app.createProduct(t, 2)
+	customer := app.createCustomer(t)
+	app.addToCart(t, customer.ID, product.ID, 2)
+	order, err := app.placeOrder(customer.ID)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	// Cash was collected on delivery
+	payment, err := payments.CreatePaymentForOrder(ctx, order.ID, "cod", models.PaymentCompleted)
+	if err != nil {
+		t.Fatalf("CreatePaymentForOrder: %v", err)
+	}
+	app.markDelivered(t, order.ID)
+
+	ret, err := returns.CreateReturn(ctx, models.CreateReturnRequest{OrderID: order.ID, Reason: "damaged"}, customer.ID)
+	if err != nil {
+		t.Fatalf("CreateReturn: %v", err)
+	}
+	if ret.Status != models.ReturnRequested {
+		t.Fatalf("return status = %s, want %s", ret.Status, models.ReturnRequested)
+	}
+
+	ret, err = returns.ProcessReturn(ctx, ret.ID, models.ProcessReturnRequest{Status: models.ReturnApproved})
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if ret.Status != models.ReturnApproved || ret.RMANumber == nil {
+		t.Fatalf("approved return = %s with RMA %v, want approved with an RMA", ret.Status, ret.RMANumber)
+	}
+
+	// Nothing is refunded before the items are back
+	_, err = returns.ProcessReturn(ctx, ret.ID, models.ProcessReturnRequest{Status: models.ReturnCompleted})
+	if !errors.Is(err, apperrors.ErrConflict) {
+		t.Fatalf("refund before receipt: err = %v, want conflict", err)
+	}
+	if got := app.stock(t, product.ID); got != 0 {
+		t.Fatalf("stock = %d before receipt, want 0", got)
+	}
+
+	ret, err = returns.MarkReturnReceived(ctx, ret.ID)
+	if err != nil {
+		t.Fatalf("MarkReturnReceived: %v", err)
+	}
+	if ret.Status != models.ReturnReceived {
+		t.Errorf("return status = %s, want %s", ret.Status, models.ReturnReceived)
+	}
+	if got := app.stock(t, product.ID); got != 2 {
+		t.Errorf("stock = %d after receipt, want 2", got)
+	}
+
+	// Receiving twice does not restock twice
+	if _, err := returns.MarkReturnReceived(ctx, ret.ID); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("second receipt: err = %v, want conflict", err)
+	}
+	if got := app.stock(t, product.ID); got != 2 {
+		t.Errorf("stock = %d after second receipt, want 2", got)
+	}
+
+	ret, err = returns.ProcessReturn(ctx, ret.ID, models.ProcessReturnRequest{Status: models.ReturnCompleted})
+	if err != nil {
+		t.Fatalf("refund: %v", err)
+	}
+	if ret.Status != models.ReturnCompleted || ret.RefundAmount != order.TotalAmount {
+		t.Errorf("return = %s refunding %s, want completed refunding %s", ret.Status, ret.RefundAmount, order.TotalAmount)
+	}
+
+	refunded, err := payments.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refunded.Status != models.PaymentRefunded {
+		t.Errorf("payment status = %s, want %s", refunded.Status, models.PaymentRefunded)
+	}
+	refunds, err := payments.GetOrderRefunds(ctx, order.ID, customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refunds) != 1 || refunds[0].Amount != payment.Amount {
+		t.Errorf("refunds = %+v, want one of %s", refunds, payment.Amount)
+	}
+
+	// A completed return cannot be refunded again
+	_, err = returns.ProcessReturn(ctx, ret.ID, models.ProcessReturnRequest{Status: models.ReturnCompleted})
+	if !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("second refund: err = %v, want conflict", err)
+	}
+}
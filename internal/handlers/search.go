@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/search"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	indexer *search.Indexer
+}
+
+// NewSearchHandler takes the search indexer, nil when no search engine is
+// configured
+func NewSearchHandler(indexer *search.Indexer) *SearchHandler {
+	return &SearchHandler{indexer: indexer}
+}
+
+// Reindex sends every product to the search engine, e.g. after enabling it
+// or after a bulk import
+func (h *SearchHandler) Reindex(c *gin.Context) {
+	if h.indexer == nil {
+		c.Error(apperrors.Conflict("no search engine is configured"))
+		return
+	}
+
+	indexed, err := h.indexer.Reindex(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Products sent to the search engine", gin.H{"indexed": indexed})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SegmentHandler struct {
+	segmentService service.SegmentService
+}
+
+func NewSegmentHandler(segmentService service.SegmentService) *SegmentHandler {
+	return &SegmentHandler{segmentService: segmentService}
+}
+
+func (h *SegmentHandler) CreateSegment(c *gin.Context) {
+	var req models.CustomerSegmentRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	segment, err := h.segmentService.CreateSegment(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Segment created successfully", segment)
+}
+
+func (h *SegmentHandler) GetSegments(c *gin.Context) {
+	segments, err := h.segmentService.GetSegments(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Segments retrieved successfully", segments)
+}
+
+func (h *SegmentHandler) GetSegment(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	segment, err := h.segmentService.GetSegment(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Segment retrieved successfully", segment)
+}
+
+// UpdateSegment replaces every filter of the segment
+func (h *SegmentHandler) UpdateSegment(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CustomerSegmentRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	segment, err := h.segmentService.UpdateSegment(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Segment updated successfully", segment)
+}
+
+func (h *SegmentHandler) DeleteSegment(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.segmentService.DeleteSegment(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Segment deleted successfully", nil)
+}
+
+func (h *SegmentHandler) RefreshSegment(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	segment, err := h.segmentService.RefreshSegment(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Segment refreshed successfully", segment)
+}
+
+func (h *SegmentHandler) GetSegmentCustomers(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	members, total, err := h.segmentService.GetMembers(c.Request.Context(), id, page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"customers": members,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "Segment customers retrieved successfully", response)
+}
+
+// ExportSegmentCustomers downloads the segment's members as CSV for email
+// campaign tools
+func (h *SegmentHandler) ExportSegmentCustomers(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	segment, err := h.segmentService.GetSegment(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	header := []string{"user_id", "email", "first_name", "last_name", "total_spent", "order_count", "last_order_at"}
+
+	streamCSV(c, "segment-"+segment.ID.String(), header, func(write func([]string) error) error {
+		return h.segmentService.ExportMembers(c.Request.Context(), segment.ID, func(member models.SegmentMember) error {
+			return write([]string{
+				member.UserID.String(),
+				member.Email,
+				member.FirstName,
+				member.LastName,
+				formatCSVAmount(member.TotalSpent),
+				strconv.Itoa(member.OrderCount),
+				formatCSVTime(member.LastOrderAt),
+			})
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SettingsHandler struct {
+	settingsService service.SettingsService
+}
+
+func NewSettingsHandler(settingsService service.SettingsService) *SettingsHandler {
+	return &SettingsHandler{settingsService: settingsService}
+}
+
+func (h *SettingsHandler) GetSettings(c *gin.Context) {
+	settings, err := h.settingsService.GetSettings(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Settings retrieved successfully", settings)
+}
+
+func (h *SettingsHandler) GetSetting(c *gin.Context) {
+	setting, err := h.settingsService.GetSetting(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Setting retrieved successfully", setting)
+}
+
+func (h *SettingsHandler) UpdateSetting(c *gin.Context) {
+	adminIDStr, err := middleware.GetUserIDFromGin(c)
+	if err != nil {
+		utils.GinUnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		utils.GinBadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req models.UpdateSettingRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	setting, err := h.settingsService.UpdateSetting(c.Request.Context(), adminID, c.Param("key"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Setting updated successfully", setting)
+}
+
+// ResetSetting drops the override and returns the setting at its default
+func (h *SettingsHandler) ResetSetting(c *gin.Context) {
+	setting, err := h.settingsService.ResetSetting(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Setting reset successfully", setting)
+}
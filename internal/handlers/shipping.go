@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShippingHandler struct {
+	serviceabilityService service.ServiceabilityService
+}
+
+func NewShippingHandler(serviceabilityService service.ServiceabilityService) *ShippingHandler {
+	return &ShippingHandler{serviceabilityService: serviceabilityService}
+}
+
+// CheckServiceability reports whether orders can ship to ?postal_code=
+func (h *ShippingHandler) CheckServiceability(c *gin.Context) {
+	result, err := h.serviceabilityService.Check(c.Request.Context(), c.Query("postal_code"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Serviceability checked", result)
+}
+
+func (h *ShippingHandler) CreateArea(c *gin.Context) {
+	var req models.CreateServiceableAreaRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	area, err := h.serviceabilityService.CreateArea(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Serviceable area created successfully", area)
+}
+
+func (h *ShippingHandler) GetAreas(c *gin.Context) {
+	areas, err := h.serviceabilityService.GetAreas(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Serviceable areas retrieved successfully", areas)
+}
+
+func (h *ShippingHandler) UpdateArea(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateServiceableAreaRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	area, err := h.serviceabilityService.UpdateArea(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Serviceable area updated successfully", area)
+}
+
+func (h *ShippingHandler) DeleteArea(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.serviceabilityService.DeleteArea(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Serviceable area deleted successfully", nil)
+}
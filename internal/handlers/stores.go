@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StoreHandler struct {
+	storeService service.StoreService
+}
+
+func NewStoreHandler(storeService service.StoreService) *StoreHandler {
+	return &StoreHandler{storeService: storeService}
+}
+
+func (h *StoreHandler) CreateStore(c *gin.Context) {
+	var req models.CreateStoreRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	store, err := h.storeService.CreateStore(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Store created successfully", store)
+}
+
+func (h *StoreHandler) GetStores(c *gin.Context) {
+	stores, err := h.storeService.GetStores(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Stores retrieved successfully", stores)
+}
+
+func (h *StoreHandler) UpdateStore(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateStoreRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	store, err := h.storeService.UpdateStore(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Store updated successfully", store)
+}
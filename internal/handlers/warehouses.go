@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"strconv"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WarehouseHandler struct {
+	warehouseService service.WarehouseService
+}
+
+func NewWarehouseHandler(warehouseService service.WarehouseService) *WarehouseHandler {
+	return &WarehouseHandler{warehouseService: warehouseService}
+}
+
+func (h *WarehouseHandler) CreateWarehouse(c *gin.Context) {
+	var req models.CreateWarehouseRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	warehouse, err := h.warehouseService.CreateWarehouse(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Warehouse created successfully", warehouse)
+}
+
+func (h *WarehouseHandler) GetWarehouses(c *gin.Context) {
+	warehouses, err := h.warehouseService.GetWarehouses(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Warehouses retrieved successfully", warehouses)
+}
+
+func (h *WarehouseHandler) GetWarehouse(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	warehouse, err := h.warehouseService.GetWarehouse(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Warehouse retrieved successfully", warehouse)
+}
+
+func (h *WarehouseHandler) UpdateWarehouse(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWarehouseRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	warehouse, err := h.warehouseService.UpdateWarehouse(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Warehouse updated successfully", warehouse)
+}
+
+func (h *WarehouseHandler) GetWarehouseStock(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	stock, total, err := h.warehouseService.GetWarehouseStock(c.Request.Context(), id, page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"stock": stock,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "Warehouse stock retrieved successfully", response)
+}
+
+func (h *WarehouseHandler) SetStock(c *gin.Context) {
+	id, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SetWarehouseStockRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.warehouseService.SetStock(c.Request.Context(), id, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Warehouse stock updated successfully", nil)
+}
+
+func (h *WarehouseHandler) GetProductStock(c *gin.Context) {
+	productID, ok := utils.ParseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	stock, err := h.warehouseService.GetProductStock(c.Request.Context(), productID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Product stock retrieved successfully", stock)
+}
+
+func (h *WarehouseHandler) TransferStock(c *gin.Context) {
+	var req models.CreateStockTransferRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	transfer, err := h.warehouseService.TransferStock(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinCreatedResponse(c, "Stock transferred successfully", transfer)
+}
+
+// ScanStock books a batch of handheld scanner reads against a warehouse:
+// positive quantities receive stock, negative ones remove it
+func (h *WarehouseHandler) ScanStock(c *gin.Context) {
+	var req models.ScanStockRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.warehouseService.ScanStock(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.GinSuccessResponse(c, "Scanned stock recorded successfully", response)
+}
+
+func (h *WarehouseHandler) GetTransfers(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	transfers, total, err := h.warehouseService.GetTransfers(c.Request.Context(), page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"transfers": transfers,
+		"meta": map[string]interface{}{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + limit - 1) / limit,
+		},
+	}
+
+	utils.GinSuccessResponse(c, "Stock transfers retrieved successfully", response)
+}
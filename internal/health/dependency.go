@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// checkTimeout bounds each dependency check so one slow dependency cannot
+// hold up the whole report
+const checkTimeout = 3 * time.Second
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+)
+
+// Dependency is something the API relies on. A critical dependency that is
+// down makes the instance unready; any other only degrades it.
+type Dependency struct {
+	Name     string
+	Provider string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+// Result is the outcome of checking a dependency
+type Result struct {
+	Name      string `json:"name"`
+	Provider  string `json:"provider,omitempty"`
+	Critical  bool   `json:"critical"`
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CheckAll checks the dependencies concurrently and returns their results in
+// the order given
+func CheckAll(ctx context.Context, dependencies []Dependency) []Result {
+	results := make([]Result, len(dependencies))
+
+	var wg sync.WaitGroup
+	for i, dependency := range dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check(ctx, dependency)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func check(ctx context.Context, dependency Dependency) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	result := Result{
+		Name:     dependency.Name,
+		Provider: dependency.Provider,
+		Critical: dependency.Critical,
+		Status:   StatusUp,
+	}
+
+	started := time.Now()
+	err := dependency.Check(ctx)
+	result.LatencyMS = time.Since(started).Milliseconds()
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// Overall is unhealthy when a critical dependency is down, degraded when any
+// other is and healthy otherwise
+func Overall(results []Result) string {
+	status := StatusHealthy
+	for _, result := range results {
+		if result.Status == StatusUp {
+			continue
+		}
+		if result.Critical {
+			return StatusUnhealthy
+		}
+		status = StatusDegraded
+	}
+	return status
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Startup tracks the tasks an instance runs before it should take traffic,
+// such as migrations and cache warmup. The instance is started once every
+// task begun has finished.
+type Startup struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	pending   map[string]time.Time
+	doneAt    time.Time
+}
+
+func NewStartup() *Startup {
+	return &Startup{
+		startedAt: time.Now(),
+		pending:   make(map[string]time.Time),
+	}
+}
+
+// Begin marks task as running; call the returned function when it finishes.
+// Calling it more than once has no further effect.
+func (s *Startup) Begin(task string) func() {
+	s.mu.Lock()
+	s.pending[task] = time.Now()
+	s.doneAt = time.Time{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { s.finish(task) })
+	}
+}
+
+func (s *Startup) finish(task string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	began, ok := s.pending[task]
+	if !ok {
+		return
+	}
+	delete(s.pending, task)
+	log.Printf("✅ Startup task %s finished in %s", task, time.Since(began).Round(time.Millisecond))
+
+	if len(s.pending) == 0 {
+		s.doneAt = time.Now()
+	}
+}
+
+// Pending lists the tasks still running, in name order
+func (s *Startup) Pending() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := make([]string, 0, len(s.pending))
+	for task := range s.pending {
+		tasks = append(tasks, task)
+	}
+	sort.Strings(tasks)
+	return tasks
+}
+
+// Done reports whether startup has finished and, if so, how long it took
+func (s *Startup) Done() (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) > 0 {
+		return false, 0
+	}
+	if s.doneAt.IsZero() {
+		return true, 0
+	}
+	return true, s.doneAt.Sub(s.startedAt)
+}
@@ -38,7 +38,7 @@ func AuthMiddleware(authService service.AuthService) func(http.Handler) http.Han
 			token := parts[1]
 
 			// Validate token
-			user, err := authService.ValidateToken(token)
+			user, err := authService.ValidateToken(r.Context(), token)
 			if err != nil {
 				utils.UnauthorizedResponse(w)
 				return
@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
 )
 
 func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
@@ -58,3 +61,153 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+const (
+	corsAllowMethods  = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowHeaders  = "Content-Type, Authorization, X-Request-ID, Accept, If-None-Match"
+	corsExposeHeaders = "ETag, Last-Modified, X-Request-ID, Content-Disposition, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining"
+	corsMaxAge        = "86400"
+)
+
+// OriginMatcher decides whether a browser origin may call the API. Patterns
+// are exact origins ("https://shop.example.com"), subdomain wildcards
+// ("https://*.example.com", which does not match example.com itself) or "*"
+// for any origin. Matching ignores case.
+type OriginMatcher struct {
+	any       bool
+	exact     map[string]bool
+	wildcards []originWildcard
+}
+
+// originWildcard matches origins of the form <prefix><subdomain><suffix>
+type originWildcard struct {
+	prefix string
+	suffix string
+}
+
+func NewOriginMatcher(patterns []string) *OriginMatcher {
+	m := &OriginMatcher{exact: make(map[string]bool)}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimRight(strings.TrimSpace(pattern), "/"))
+		switch {
+		case pattern == "*":
+			m.any = true
+		case strings.Contains(pattern, "://*."):
+			prefix, rest, _ := strings.Cut(pattern, "*")
+			m.wildcards = append(m.wildcards, originWildcard{prefix: prefix, suffix: rest})
+		case pattern != "":
+			m.exact[pattern] = true
+		}
+	}
+	return m
+}
+
+// Allows reports whether origin matches a pattern, and whether it matched
+// only through "*"
+func (m *OriginMatcher) Allows(origin string) (allowed, anyOrigin bool) {
+	origin = strings.ToLower(origin)
+	if m.exact[origin] {
+		return true, false
+	}
+	for _, w := range m.wildcards {
+		if !strings.HasPrefix(origin, w.prefix) || !strings.HasSuffix(origin, w.suffix) {
+			continue
+		}
+		subdomain := origin[len(w.prefix) : len(origin)-len(w.suffix)]
+		if subdomain != "" && !strings.ContainsAny(subdomain, ":/@") {
+			return true, false
+		}
+	}
+	return m.any, m.any
+}
+
+// CORSOverride applies its own allowed origins to paths starting with Path,
+// where "*" stands for any single segment, e.g. "/api/*/admin"
+type CORSOverride struct {
+	Path           string
+	AllowedOrigins []string
+}
+
+type corsRoute struct {
+	segments []string
+	origins  *OriginMatcher
+}
+
+func (r corsRoute) matches(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < len(r.segments) {
+		return false
+	}
+	for i, segment := range r.segments {
+		if segment != "*" && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// GinCORSMiddleware answers CORS for browser clients. Origins listed exactly
+// or matched by a subdomain wildcard may send credentials; "*" lets any
+// origin call without them. The first override whose path matches replaces
+// the default list, so e.g. admin routes can be limited to the dashboard's
+// origin. Preflights from origins that are not allowed get 403.
+//
+// It runs before routing, so it also answers preflights for routes that have
+// no OPTIONS handler.
+func GinCORSMiddleware(allowedOrigins []string, overrides ...CORSOverride) gin.HandlerFunc {
+	defaults := NewOriginMatcher(allowedOrigins)
+	routes := make([]corsRoute, 0, len(overrides))
+	for _, override := range overrides {
+		routes = append(routes, corsRoute{
+			segments: strings.Split(strings.Trim(override.Path, "/"), "/"),
+			origins:  NewOriginMatcher(override.AllowedOrigins),
+		})
+	}
+
+	return func(c *gin.Context) {
+		origin := c.Request.Header.Get("Origin")
+		c.Writer.Header().Add("Vary", "Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		origins := defaults
+		for _, route := range routes {
+			if route.matches(c.Request.URL.Path) {
+				origins = route.origins
+				break
+			}
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != ""
+		allowed, anyOrigin := origins.Allows(origin)
+		if !allowed {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		if anyOrigin {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			header.Set("Access-Control-Allow-Methods", corsAllowMethods)
+			header.Set("Access-Control-Allow-Headers", corsAllowHeaders)
+			header.Set("Access-Control-Max-Age", corsMaxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		header.Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		c.Next()
+	}
+}
@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"ecommerce-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// captureBodyLimit is how much of each body is kept. A JSON body cut short
+// cannot be redacted reliably, so longer ones are left out.
+const captureBodyLimit = 16 << 10
+
+// sensitiveKeys are matched against lower-cased JSON keys and query
+// parameters; any key containing one has its value redacted
+var sensitiveKeys = []string{"password", "token", "secret", "otp", "cvc", "cvv", "card_number", "api_key", "authorization"}
+
+const redacted = "REDACTED"
+
+// RequestCapture keeps the most recent failing requests (5xx and 409) in a
+// fixed-size ring buffer, in memory on this instance only, so an incident can
+// be triaged from what clients actually sent.
+type RequestCapture struct {
+	mu      sync.Mutex
+	entries []models.CapturedRequest
+	next    int
+	full    bool
+	lastID  int64
+}
+
+// NewRequestCapture keeps up to size requests. A size of zero or less
+// returns nil, which captures nothing.
+func NewRequestCapture(size int) *RequestCapture {
+	if size <= 0 {
+		return nil
+	}
+	return &RequestCapture{entries: make([]models.CapturedRequest, size)}
+}
+
+// Enabled reports whether requests are being captured
+func (rc *RequestCapture) Enabled() bool {
+	return rc != nil
+}
+
+func (rc *RequestCapture) add(entry models.CapturedRequest) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.lastID++
+	entry.ID = rc.lastID
+	rc.entries[rc.next] = entry
+	rc.next = (rc.next + 1) % len(rc.entries)
+	if rc.next == 0 {
+		rc.full = true
+	}
+}
+
+// Recent returns up to limit captured requests, newest first. A status of
+// zero matches every status.
+func (rc *RequestCapture) Recent(status, limit int) []models.CapturedRequest {
+	requests := []models.CapturedRequest{}
+	if rc == nil {
+		return requests
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	count := rc.next
+	if rc.full {
+		count = len(rc.entries)
+	}
+	for i := 1; i <= count && len(requests) < limit; i++ {
+		entry := rc.entries[(rc.next-i+len(rc.entries))%len(rc.entries)]
+		if status == 0 || entry.Status == status {
+			requests = append(requests, entry)
+		}
+	}
+	return requests
+}
+
+func captureStatus(status int) bool {
+	return status >= 500 || status == http.StatusConflict
+}
+
+// GinRequestCapture records failing requests into rc, with credentials
+// redacted from bodies and the query string. It must be registered before
+// GinRecovery, GinErrorHandler and GinBodyLimit so it sees the final response
+// and every byte the handler reads. A nil rc disables it.
+func GinRequestCapture(rc *RequestCapture) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rc == nil || c.Request.Header.Get("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		var requestBody *limitedBuffer
+		requestNote := ""
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			if c.ContentType() == "application/json" {
+				requestBody = &limitedBuffer{limit: captureBodyLimit}
+				c.Request.Body = &captureReader{ReadCloser: c.Request.Body, buf: requestBody}
+			} else {
+				requestNote = "[" + c.ContentType() + " body omitted]"
+			}
+		}
+
+		writer := &captureWriter{ResponseWriter: c.Writer, buf: &limitedBuffer{limit: captureBodyLimit}}
+		c.Writer = writer
+
+		defer func() {
+			status := writer.Status()
+			if !captureStatus(status) {
+				return
+			}
+
+			entry := models.CapturedRequest{
+				RequestID:    c.GetString("requestID"),
+				Method:       c.Request.Method,
+				Path:         c.Request.URL.Path,
+				Route:        c.FullPath(),
+				Query:        redactQuery(c.Request.URL.Query()),
+				Status:       status,
+				DurationMS:   time.Since(start).Milliseconds(),
+				ClientIP:     c.ClientIP(),
+				UserID:       c.GetString(GinUserIDKey),
+				Errors:       c.Errors.Errors(),
+				ResponseBody: redactBody(writer.buf),
+				CapturedAt:   time.Now().UTC(),
+			}
+			if requestBody != nil {
+				entry.RequestBody = redactBody(requestBody)
+			} else if requestNote != "" {
+				entry.RequestBody = requestNote
+			}
+			rc.add(entry)
+		}()
+
+		c.Next()
+	}
+}
+
+// limitedBuffer keeps the first limit bytes written to it
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) capture(p []byte) {
+	if room := b.limit - b.Len(); len(p) > room {
+		p = p[:room]
+		b.truncated = true
+	}
+	b.Write(p)
+}
+
+// captureReader copies what the handler reads from the request body
+type captureReader struct {
+	io.ReadCloser
+	buf *limitedBuffer
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.buf.capture(p[:n])
+	return n, err
+}
+
+// captureWriter copies the response body of failing requests
+type captureWriter struct {
+	gin.ResponseWriter
+	buf *limitedBuffer
+}
+
+func (w *captureWriter) Write(data []byte) (int, error) {
+	if captureStatus(w.Status()) {
+		w.buf.capture(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// redactBody decodes a captured JSON body and redacts credentials in it
+func redactBody(buf *limitedBuffer) interface{} {
+	if buf.Len() == 0 {
+		return nil
+	}
+	if buf.truncated {
+		return "[body over 16 KB omitted]"
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(buf.Bytes(), &body); err != nil {
+		return "[body is not valid JSON]"
+	}
+	return redactValue(body)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func redactQuery(query url.Values) string {
+	for key := range query {
+		if isSensitive(key) {
+			query[key] = []string{redacted}
+		}
+	}
+	return query.Encode()
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
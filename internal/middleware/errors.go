@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"log"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinErrorHandler responds for handlers that hand their error to c.Error
+// instead of writing a response. Domain errors map to their status code and
+// message; anything else is logged and returned as a generic 500.
+func GinErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status, code, message := apperrors.HTTPStatus(err)
+		if status >= 500 {
+			log.Printf("❌ %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+
+		c.JSON(status, utils.GinResponseData{
+			Success: false,
+			Message: message,
+			Error:   code,
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinFeatureFlag serves the routes behind it only while the flag is on for
+// the caller and answers 404 otherwise, so a feature that is off looks
+// absent. The caller is the authenticated user when auth has already run,
+// else the client IP.
+func GinFeatureFlag(flags service.FlagService, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, err := GetUserIDFromGin(c)
+		if err != nil {
+			subject = c.ClientIP()
+		}
+
+		if !flags.IsEnabled(c.Request.Context(), key, subject) {
+			utils.GinNotFoundResponse(c, "Route")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -5,14 +5,20 @@ import (
 	"net/http"
 	"strings"
 
+	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const (
 	GinUserIDKey   = "userID"
 	GinUserRoleKey = "userRole"
+
+	// GinImpersonatorIDKey holds the admin's ID on requests made with an
+	// impersonation token
+	GinImpersonatorIDKey = "impersonatorID"
 )
 
 // GinAuthMiddleware validates JWT tokens for Gin
@@ -45,7 +51,7 @@ func GinAuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 		token := parts[1]
 
 		// Validate token
-		user, err := authService.ValidateToken(token)
+		user, err := authService.ValidateToken(c.Request.Context(), token)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"success": false,
@@ -56,12 +62,26 @@ func GinAuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 			return
 		}
 
+		// Tokens only work at the store that issued them
+		if storeID, exists := c.Get(GinStoreIDKey); exists && storeID.(uuid.UUID) != user.StoreID {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "Unauthorized",
+				"error":   "Token was issued by another store",
+			})
+			c.Abort()
+			return
+		}
+
 		// Debug: Log user role
 		fmt.Printf("[AUTH DEBUG] User ID: %s, Role from JWT: %s\n", user.ID.String(), user.Role)
 
 		// Add user info to context
 		c.Set(GinUserIDKey, user.ID.String())
 		c.Set(GinUserRoleKey, user.Role)
+		if user.ImpersonatorID != nil {
+			c.Set(GinImpersonatorIDKey, *user.ImpersonatorID)
+		}
 
 		c.Next()
 	}
@@ -69,12 +89,18 @@ func GinAuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 
 // GinAdminMiddleware checks if user has admin role
 func GinAdminMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		role, exists := c.Get(GinUserRoleKey)
+	return GinPermissionMiddleware(models.PermissionAdmin)
+}
 
-		// Debug logging
-		fmt.Printf("[ADMIN MIDDLEWARE] Role exists: %v, Role value: %v\n", exists, role)
+// GinPermissionMiddleware only lets through users whose role holds permission
+func GinPermissionMiddleware(permission models.Permission) gin.HandlerFunc {
+	message := "Admin access required"
+	if permission != models.PermissionAdmin {
+		message = "Permission " + string(permission) + " required"
+	}
 
+	return func(c *gin.Context) {
+		role, exists := c.Get(GinUserRoleKey)
 		if !exists {
 			c.JSON(http.StatusForbidden, gin.H{
 				"success": false,
@@ -85,11 +111,11 @@ func GinAdminMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		if role != "admin" {
+		if roleName, _ := role.(string); !models.HasPermission(roleName, permission) {
 			c.JSON(http.StatusForbidden, gin.H{
 				"success": false,
 				"message": "Forbidden",
-				"error":   "Admin access required",
+				"error":   message,
 			})
 			c.Abort()
 			return
@@ -42,41 +42,3 @@ func GinRequestID() gin.HandlerFunc {
 		c.Next()
 	}
 }
-
-// GinCORSMiddleware handles CORS
-func GinCORSMiddleware(allowedOrigins []string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		origin := c.Request.Header.Get("Origin")
-
-		allowed := false
-		allowAll := false
-		for _, o := range allowedOrigins {
-			if o == "*" {
-				allowAll = true
-				break
-			}
-			if o == origin {
-				allowed = true
-				break
-			}
-		}
-
-		if allowAll && origin != "" {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-		} else if allowed {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-		}
-
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, Accept")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Max-Age", "86400")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-
-		c.Next()
-	}
-}
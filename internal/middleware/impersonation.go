@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// impersonationResources are the first path segments after the API version
+// that an impersonation token may read
+var impersonationResources = map[string]bool{
+	"cart":   true,
+	"orders": true,
+}
+
+// GinImpersonationGuard restricts requests made with an impersonation token to
+// reading the customer's cart and orders and records each of them, allowed or
+// not, in the audit log. Requests with ordinary tokens pass through. It must
+// run after GinAuthMiddleware.
+func GinImpersonationGuard(auditService service.AuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get(GinImpersonatorIDKey)
+		if !exists {
+			c.Next()
+			return
+		}
+		impersonatorID := value.(uuid.UUID)
+
+		if !impersonationAllowed(c) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "Forbidden",
+				"error":   "Impersonation sessions are read-only and limited to the cart and orders",
+			})
+			c.Abort()
+		} else {
+			c.Next()
+		}
+
+		entry := &models.AuditEntry{
+			ActorID:    impersonatorID,
+			Action:     models.AuditImpersonationRequest,
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.RequestURI(),
+			StatusCode: c.Writer.Status(),
+		}
+		if userID, err := GetUserIDFromGin(c); err == nil {
+			if id, err := uuid.Parse(userID); err == nil {
+				entry.SubjectUserID = &id
+			}
+		}
+
+		// The request context may already be cancelled once the response
+		// is written
+		if err := auditService.Record(context.WithoutCancel(c.Request.Context()), entry); err != nil {
+			log.Printf("⚠️ Failed to audit impersonated request %s %s by %s: %v", entry.Method, entry.Path, impersonatorID, err)
+		}
+	}
+}
+
+// impersonationAllowed reports whether the matched route is a read of the
+// cart or orders, e.g. GET /api/v1/orders/:id
+func impersonationAllowed(c *gin.Context) bool {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	// "", "api", version, resource, ... once any store prefix is dropped
+	path := c.FullPath()
+	if i := strings.Index(path, "/api/"); i > 0 {
+		path = path[i:]
+	}
+	segments := strings.Split(path, "/")
+	return len(segments) > 3 && impersonationResources[segments[3]]
+}
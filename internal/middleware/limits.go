@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys holding the request's context and body as they were before
+// any limit was applied, so a route-level limit replaces the global default
+// instead of nesting inside it
+const (
+	baseContextKey = "limits.baseContext"
+	baseBodyKey    = "limits.baseBody"
+)
+
+// GinRequestTimeout bounds every request: reads (GET, HEAD) get the read
+// timeout, everything else the write timeout. Routes that need longer
+// override it with GinTimeout.
+func GinRequestTimeout(read, write time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := write
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			timeout = read
+		}
+		runWithTimeout(c, timeout)
+	}
+}
+
+// GinTimeout replaces the request deadline for one route or group. A
+// timeout of zero or less removes it, e.g. for long-lived connections.
+func GinTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runWithTimeout(c, timeout)
+	}
+}
+
+// runWithTimeout runs the rest of the chain under a deadline and responds
+// with 408 when it passes before the handler wrote anything. Handlers are
+// not interrupted; they see the deadline through the request context.
+func runWithTimeout(c *gin.Context, timeout time.Duration) {
+	parent := c.Request.Context()
+	if base, ok := c.Get(baseContextKey); ok {
+		parent = base.(context.Context)
+	} else {
+		c.Set(baseContextKey, parent)
+	}
+
+	ctx := parent
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, timeout)
+		defer cancel()
+	}
+	c.Request = c.Request.WithContext(ctx)
+
+	c.Next()
+
+	// A later GinTimeout may have replaced the context; its deadline is the
+	// one that applies
+	if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+		utils.GinRequestTimeoutResponse(c)
+		c.Abort()
+	}
+}
+
+// GinBodyLimit caps request bodies at maxBytes. Reading past the cap fails
+// with *http.MaxBytesError, which utils.BindJSON and the upload handlers
+// report as 413. Applied again on a route, the new limit replaces the earlier
+// one, so the check cannot happen up front on Content-Length: a global
+// default would refuse uploads a route allows. Zero or less removes the cap.
+func GinBodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body := c.Request.Body
+		if base, ok := c.Get(baseBodyKey); ok {
+			body = base.(io.ReadCloser)
+		} else {
+			c.Set(baseBodyKey, body)
+		}
+
+		if maxBytes <= 0 || body == nil || body == http.NoBody {
+			c.Request.Body = body
+			c.Next()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, body, maxBytes)
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errMaintenance = errors.New("maintenance")
+
+// GinMaintenanceMode turns away writes with a 503 while the maintenance_mode
+// setting is on, so stock and orders hold still during migrations and
+// inventory counts. Reads keep working, and admins are let through to check
+// the storefront before it reopens; register it after GinAuthMiddleware for
+// them to be recognised.
+func GinMaintenanceMode(settings service.SettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		if !settings.Bool(ctx, models.SettingMaintenanceMode) {
+			c.Next()
+			return
+		}
+
+		role, _ := c.Get(GinUserRoleKey)
+		if roleName, _ := role.(string); models.HasPermission(roleName, models.PermissionAdmin) {
+			c.Next()
+			return
+		}
+
+		utils.GinServiceUnavailableResponse(c, settings.String(ctx, models.SettingMaintenanceMessage), errMaintenance)
+		c.Abort()
+	}
+}
@@ -1,81 +1,95 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
+	"ecommerce-backend/internal/service"
 	"ecommerce-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
 )
 
+const rateLimitWindow = time.Minute
+
+var errRateLimitExceeded = errors.New("too many requests, please retry later")
+
 type rateLimiter struct {
 	visits map[string][]time.Time
 	mu     sync.RWMutex
 }
 
 func newRateLimiter() *rateLimiter {
-	return &rateLimiter{
+	rl := &rateLimiter{
 		visits: make(map[string][]time.Time),
 	}
+
+	// Clean up old entries periodically
+	go func() {
+		for {
+			time.Sleep(rateLimitWindow)
+			rl.cleanup()
+		}
+	}()
+
+	return rl
 }
 
 func (rl *rateLimiter) cleanup() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	for ip, visits := range rl.visits {
+	for key, visits := range rl.visits {
 		var validVisits []time.Time
 		for _, visit := range visits {
-			if time.Since(visit) < time.Minute {
+			if time.Since(visit) < rateLimitWindow {
 				validVisits = append(validVisits, visit)
 			}
 		}
 		if len(validVisits) == 0 {
-			delete(rl.visits, ip)
+			delete(rl.visits, key)
 		} else {
-			rl.visits[ip] = validVisits
+			rl.visits[key] = validVisits
 		}
 	}
 }
 
-func (rl *rateLimiter) allow(ip string, limit int) bool {
+// allow records a visit for key and reports whether it is within limit. When
+// the limit is exceeded it also returns how long until the next slot frees up.
+func (rl *rateLimiter) allow(key string, limit int) (bool, int, time.Duration) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
 	now := time.Now()
-	visits := rl.visits[ip]
+	visits := rl.visits[key]
 
 	// Remove old visits
 	var recentVisits []time.Time
 	for _, visit := range visits {
-		if now.Sub(visit) < time.Minute {
+		if now.Sub(visit) < rateLimitWindow {
 			recentVisits = append(recentVisits, visit)
 		}
 	}
 
 	// Check if limit exceeded
 	if len(recentVisits) >= limit {
-		return false
+		rl.visits[key] = recentVisits
+		return false, 0, rateLimitWindow - now.Sub(recentVisits[0])
 	}
 
 	// Add new visit
 	recentVisits = append(recentVisits, now)
-	rl.visits[ip] = recentVisits
+	rl.visits[key] = recentVisits
 
-	return true
+	return true, limit - len(recentVisits), 0
 }
 
 func RateLimit(limit int) func(http.Handler) http.Handler {
 	limiter := newRateLimiter()
 
-	// Clean up old entries periodically
-	go func() {
-		for {
-			time.Sleep(time.Minute)
-			limiter.cleanup()
-		}
-	}()
-
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Get client IP
@@ -85,7 +99,7 @@ func RateLimit(limit int) func(http.Handler) http.Handler {
 			}
 
 			// Check rate limit
-			if !limiter.allow(ip, limit) {
+			if ok, _, _ := limiter.allow(ip, limit); !ok {
 				utils.ErrorResponse(w, http.StatusTooManyRequests,
 					"Rate limit exceeded",
 					http.ErrAbortHandler)
@@ -104,27 +118,69 @@ func UserRateLimit(limit int) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Try to get user ID from context for authenticated users
-			userID, ok := GetUserIDFromContext(r.Context())
-			if ok {
-				// Use user ID for rate limiting
-				if !limiter.allow(userID.String(), limit) {
-					utils.ErrorResponse(w, http.StatusTooManyRequests,
-						"Rate limit exceeded",
-						http.ErrAbortHandler)
-					return
-				}
-			} else {
-				// Use IP for unauthenticated users
-				ip := r.RemoteAddr
-				if !limiter.allow(ip, limit) {
-					utils.ErrorResponse(w, http.StatusTooManyRequests,
-						"Rate limit exceeded",
-						http.ErrAbortHandler)
-					return
-				}
+			key := r.RemoteAddr
+			if userID, ok := GetUserIDFromContext(r.Context()); ok {
+				key = userID.String()
+			}
+
+			if ok, _, _ := limiter.allow(key, limit); !ok {
+				utils.ErrorResponse(w, http.StatusTooManyRequests,
+					"Rate limit exceeded",
+					http.ErrAbortHandler)
+				return
 			}
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// GinRateLimit limits requests per client IP to the per-minute limit held by
+// the runtime setting, so operators can change it without a restart. A limit
+// of zero or less lets every request through.
+func GinRateLimit(settings service.SettingsService, setting string) gin.HandlerFunc {
+	return ginRateLimit(settings, setting, func(c *gin.Context) string {
+		return "ip:" + c.ClientIP()
+	})
+}
+
+// GinUserRateLimit limits requests per authenticated user, falling back to the
+// client IP. It must be registered after GinAuthMiddleware.
+func GinUserRateLimit(settings service.SettingsService, setting string) gin.HandlerFunc {
+	return ginRateLimit(settings, setting, func(c *gin.Context) string {
+		if userID, err := GetUserIDFromGin(c); err == nil {
+			return "user:" + userID
+		}
+		return "ip:" + c.ClientIP()
+	})
+}
+
+func ginRateLimit(settings service.SettingsService, setting string, keyFunc func(c *gin.Context) string) gin.HandlerFunc {
+	limiter := newRateLimiter()
+
+	return func(c *gin.Context) {
+		limit := settings.Int(c.Request.Context(), setting)
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		ok, remaining, retryAfter := limiter.allow(keyFunc(c), limit)
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !ok {
+			seconds := int(retryAfter.Seconds())
+			if retryAfter > time.Duration(seconds)*time.Second {
+				seconds++
+			}
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			utils.GinErrorResponse(c, http.StatusTooManyRequests, "Rate limit exceeded", errRateLimitExceeded)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GinStoreIDKey holds the ID of the store a request was resolved to
+const GinStoreIDKey = "storeID"
+
+// GinStoreResolver resolves the store a request is for from the :store path
+// parameter or the Host header and scopes the request context to it, so the
+// repositories only see that store's products, orders and users.
+func GinStoreResolver(storeService service.StoreService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store, err := storeService.Resolve(c.Request.Context(), c.Param("store"), c.Request.Host)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(GinStoreIDKey, store.ID)
+		c.Request = c.Request.WithContext(database.WithStore(c.Request.Context(), store.ID))
+
+		c.Next()
+	}
+}
+
+// GinAllStores lifts the store scope for routes that are not tied to a
+// storefront, such as payment gateway webhooks, which look records up by ID
+func GinAllStores() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(database.WithStore(c.Request.Context(), uuid.Nil))
+		c.Next()
+	}
+}
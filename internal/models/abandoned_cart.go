@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// AbandonedCart records a cart that sat with items and no activity past the
+// abandonment window
+type AbandonedCart struct {
+	ID               uuid.UUID   `json:"id"`
+	CartID           uuid.UUID   `json:"cart_id"`
+	UserID           uuid.UUID   `json:"user_id"`
+	ItemCount        int         `json:"item_count"`
+	CartValue        money.Money `json:"cart_value"`
+	LastActivityAt   time.Time   `json:"last_activity_at"`
+	NudgedAt         *time.Time  `json:"nudged_at,omitempty"`
+	RecoveredAt      *time.Time  `json:"recovered_at,omitempty"`
+	RecoveredOrderID *uuid.UUID  `json:"recovered_order_id,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+}
+
+type AbandonedCartStats struct {
+	RangeDays      int         `json:"range_days"`
+	Abandoned      int         `json:"abandoned"`
+	Nudged         int         `json:"nudged"`
+	Recovered      int         `json:"recovered"`
+	Open           int         `json:"open"`
+	RecoveryRate   float64     `json:"recovery_rate"`
+	AbandonedValue money.Money `json:"abandoned_value"`
+	RecoveredValue money.Money `json:"recovered_value"`
+}
+
+type AbandonedCartDetectionResult struct {
+	Detected int       `json:"detected"`
+	RanAt    time.Time `json:"ran_at"`
+}
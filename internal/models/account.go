@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// AccountDataExport is everything the store holds about a customer, returned
+// by the self-service data export
+type AccountDataExport struct {
+	ExportedAt    time.Time                 `json:"exported_at"`
+	Profile       *User                     `json:"profile"`
+	Orders        []Order                   `json:"orders"`
+	Returns       []Return                  `json:"returns"`
+	Cart          *Cart                     `json:"cart,omitempty"`
+	Notifications []Notification            `json:"notifications"`
+	BackInStock   []BackInStockSubscription `json:"back_in_stock_subscriptions"`
+	GiftCards     []GiftCard                `json:"gift_cards"`
+}
+
+// ReauthRequest proves the caller is still the account holder before a
+// sensitive action. Accounts with a password give it; accounts created by a
+// sign-in provider or phone code have none, so they give a fresh identity
+// token from a linked provider or a code texted to their verified phone.
+type ReauthRequest struct {
+	Password string `json:"password"`
+	Provider string `json:"provider" validate:"required_with=IDToken"`
+	IDToken  string `json:"id_token"`
+	OTP      string `json:"otp" validate:"omitempty,len=6,numeric"`
+}
+
+type CloseAccountRequest struct {
+	ReauthRequest
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+)
+
+// Ledger accounts the accounting export posts to. They are named rather
+// than numbered so each accounting tool can map them to its chart.
+const (
+	AccountGatewayClearing   = "Payment Gateway Clearing"
+	AccountCODReceivable     = "COD Receivable"
+	AccountGiftCardLiability = "Gift Card Liability"
+	AccountCash              = "Cash"
+	AccountSales             = "Sales"
+	AccountSalesReturns      = "Sales Returns"
+	AccountSalesTax          = "Sales Tax Payable"
+	AccountGatewayFees       = "Payment Processing Fees"
+)
+
+// AccountingFilter selects the journal's range, From inclusive and To
+// exclusive, and its period: one of the MarginPeriod values
+type AccountingFilter struct {
+	From   time.Time
+	To     time.Time
+	Period string
+}
+
+// AccountingTotals is what moved in one period. Card sales are captured card
+// payments; COD is cash collected by couriers and later remitted; gift card
+// tender is what orders took off gift cards.
+type AccountingTotals struct {
+	Period         time.Time
+	CardSales      money.Money
+	CardPayments   int
+	GiftCardTender money.Money
+	CODCollected   money.Money
+	CODRemitted    money.Money
+	CardRefunds    money.Money
+	OtherRefunds   money.Money
+}
+
+// JournalLine is one debit or credit of a journal entry; the lines sharing a
+// JournalNo balance
+type JournalLine struct {
+	JournalNo   string
+	Date        time.Time
+	Account     string
+	Debit       money.Money
+	Credit      money.Money
+	Description string
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"ecommerce-backend/pkg/money"
+
 	"github.com/google/uuid"
 )
 
@@ -12,26 +14,65 @@ type AdminUserSummary struct {
 }
 
 type AdminOrderItem struct {
-	ProductID   uuid.UUID `json:"product_id"`
-	ProductName string    `json:"product_name"`
-	ProductSKU  string    `json:"product_sku"`
-	Quantity    int       `json:"quantity"`
-	PriceAtTime float64   `json:"price_at_time"`
+	ID                uuid.UUID         `json:"id"`
+	ProductID         uuid.UUID         `json:"product_id"`
+	ProductName       string            `json:"product_name"`
+	ProductSKU        string            `json:"product_sku"`
+	VariantID         *uuid.UUID        `json:"variant_id,omitempty"`
+	VariantSKU        *string           `json:"variant_sku,omitempty"`
+	Quantity          int               `json:"quantity"`
+	PriceAtTime       money.Money       `json:"price_at_time"`
+	FulfillmentStatus FulfillmentStatus `json:"fulfillment_status"`
+	TrackingNumber    *string           `json:"tracking_number,omitempty"`
+
+	BundleComponents []OrderItemComponent `json:"bundle_components,omitempty"`
+
+	// The product's shipping attributes, for packing and parcel labels
+	WeightGrams *int        `json:"weight_grams,omitempty"`
+	Dimensions  *Dimensions `json:"dimensions,omitempty"`
 }
 
 type AdminOrder struct {
-	ID              uuid.UUID        `json:"id"`
-	UserID          uuid.UUID        `json:"user_id"`
-	User            AdminUserSummary `json:"user"`
-	OrderNumber     string           `json:"order_number"`
-	TotalAmount     float64          `json:"total_amount"`
-	Status          OrderStatus      `json:"status"`
-	PaymentMethod   string           `json:"payment_method"`
-	ShippingAddress Address          `json:"shipping_address"`
-	BillingAddress  Address          `json:"billing_address"`
-	Items           []AdminOrderItem `json:"items"`
-	CreatedAt       time.Time        `json:"created_at"`
-	UpdatedAt       time.Time        `json:"updated_at"`
+	ID              uuid.UUID          `json:"id"`
+	UserID          uuid.UUID          `json:"user_id"`
+	User            AdminUserSummary   `json:"user"`
+	OrderNumber     string             `json:"order_number"`
+	TotalAmount     money.Money        `json:"total_amount"`
+	GiftCardAmount  money.Money        `json:"gift_card_amount"`
+	Status          OrderStatus        `json:"status"`
+	PaymentMethod   string             `json:"payment_method"`
+	ShippingMethod  ShippingMethod     `json:"shipping_method,omitempty"`
+	ShippingAddress Address            `json:"shipping_address"`
+	BillingAddress  Address            `json:"billing_address"`
+	Items           []AdminOrderItem   `json:"items"`
+	Fulfillment     FulfillmentSummary `json:"fulfillment,omitempty"`
+	Tags            []string           `json:"tags"`
+
+	// Order detail only, like Order's
+	EstimatedDelivery *time.Time         `json:"estimated_delivery,omitempty"`
+	DiscountAmount    money.Money        `json:"discount_amount"`
+	Promotions        []AppliedPromotion `json:"promotions,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrderFilter narrows the admin order list and export. OrderNumber matches
+// as a prefix, Email as a substring of the customer's email and SKU any
+// product or variant SKU among the order's items; all are case-insensitive.
+// Tag matches one of the order's tags exactly.
+type OrderFilter struct {
+	Status      string
+	RangeDays   int
+	OrderNumber string
+	Email       string
+	SKU         string
+	Tag         string
+	MinAmount   *money.Money
+	MaxAmount   *money.Money
+
+	// Leaves out orders on hold or under review
+	ExcludeHeld bool
 }
 
 type AdminReturnOrderSummary struct {
@@ -45,17 +86,63 @@ type AdminReturn struct {
 	User         AdminUserSummary        `json:"user"`
 	Reason       string                  `json:"reason"`
 	Status       ReturnStatus            `json:"status"`
-	RefundAmount float64                 `json:"refund_amount"`
-	CreatedAt    time.Time               `json:"created_at"`
-	UpdatedAt    time.Time               `json:"updated_at"`
+	RefundAmount money.Money             `json:"refund_amount"`
+	RMANumber    *string                 `json:"rma_number,omitempty"`
+	ReceivedAt   *time.Time              `json:"received_at,omitempty"`
+
+	ExchangeItemID       *uuid.UUID `json:"exchange_item_id,omitempty"`
+	ReplacementVariantID *uuid.UUID `json:"replacement_variant_id,omitempty"`
+	ReplacementOrderID   *uuid.UUID `json:"replacement_order_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type AdminPaymentOrderSummary struct {
+	OrderNumber string      `json:"order_number"`
+	Status      OrderStatus `json:"status"`
+}
+
+type AdminPayment struct {
+	Payment
+	Order AdminPaymentOrderSummary `json:"order"`
+	User  AdminUserSummary         `json:"user"`
+}
+
+// Reconciliation mismatch types
+const (
+	MismatchPaidWithoutPayment  = "paid_without_payment"
+	MismatchAmount              = "amount_mismatch"
+	MismatchRefundWithoutReturn = "refund_without_return"
+)
+
+// ReconciliationIssue is an order whose status disagrees with its payment
+// record. Payment fields are empty when the order has no payment.
+type ReconciliationIssue struct {
+	Type           string         `json:"type"`
+	OrderID        uuid.UUID      `json:"order_id"`
+	OrderNumber    string         `json:"order_number"`
+	OrderStatus    OrderStatus    `json:"order_status"`
+	AmountDue      money.Money    `json:"amount_due"`
+	PaymentID      *uuid.UUID     `json:"payment_id,omitempty"`
+	PaymentStatus  *PaymentStatus `json:"payment_status,omitempty"`
+	PaymentAmount  *money.Money   `json:"payment_amount,omitempty"`
+	RefundedAmount *money.Money   `json:"refunded_amount,omitempty"`
+	Detail         string         `json:"detail"`
+}
+
+type PaymentReconciliation struct {
+	RangeDays int                   `json:"range_days"`
+	Counts    map[string]int        `json:"counts"`
+	Issues    []ReconciliationIssue `json:"issues"`
 }
 
 type AdminTotals struct {
-	TotalRevenue   float64 `json:"total_revenue"`
-	TotalOrders    int     `json:"total_orders"`
-	TotalProducts  int     `json:"total_products"`
-	TotalCustomers int     `json:"total_customers"`
-	AvgOrderValue  float64 `json:"avg_order_value"`
+	TotalRevenue   money.Money `json:"total_revenue"`
+	TotalOrders    int         `json:"total_orders"`
+	TotalProducts  int         `json:"total_products"`
+	TotalCustomers int         `json:"total_customers"`
+	AvgOrderValue  money.Money `json:"avg_order_value"`
 }
 
 type AdminStatusCount struct {
@@ -63,16 +150,125 @@ type AdminStatusCount struct {
 	Count  int         `json:"count"`
 }
 
+// PaymentMethodBreakdown is one payment method's share of orders in the
+// range. FailureRate is the share of its payment attempts that failed.
+type PaymentMethodBreakdown struct {
+	PaymentMethod   string      `json:"payment_method"`
+	Orders          int         `json:"orders"`
+	Revenue         money.Money `json:"revenue"`
+	PaymentAttempts int         `json:"payment_attempts"`
+	FailedPayments  int         `json:"failed_payments"`
+	FailureRate     float64     `json:"failure_rate"`
+}
+
+// PaymentMixPeriod splits one week's orders into cash on delivery and
+// prepaid. ConvertedCustomers paid up front that week after paying an
+// earlier order on delivery.
+type PaymentMixPeriod struct {
+	Period             time.Time `json:"period"`
+	CODOrders          int       `json:"cod_orders"`
+	PrepaidOrders      int       `json:"prepaid_orders"`
+	PrepaidShare       float64   `json:"prepaid_share"`
+	ConvertedCustomers int       `json:"converted_customers"`
+}
+
 type AdminAnalytics struct {
-	RangeDays      int                `json:"range_days"`
-	Totals         AdminTotals        `json:"totals"`
-	OrdersByStatus []AdminStatusCount `json:"orders_by_status"`
+	RangeDays       int                      `json:"range_days"`
+	Totals          AdminTotals              `json:"totals"`
+	OrdersByStatus  []AdminStatusCount       `json:"orders_by_status"`
+	ByPaymentMethod []PaymentMethodBreakdown `json:"by_payment_method"`
+	PaymentMixTrend []PaymentMixPeriod       `json:"payment_mix_trend"`
+}
+
+type CustomerRevenueSplit struct {
+	NewCustomerRevenue       money.Money `json:"new_customer_revenue"`
+	ReturningCustomerRevenue money.Money `json:"returning_customer_revenue"`
+	NewCustomerOrders        int         `json:"new_customer_orders"`
+	ReturningCustomerOrders  int         `json:"returning_customer_orders"`
+}
+
+type TopCustomer struct {
+	User          AdminUserSummary `json:"user"`
+	FirstName     string           `json:"first_name"`
+	LastName      string           `json:"last_name"`
+	OrderCount    int              `json:"order_count"`
+	TotalSpent    money.Money      `json:"total_spent"`
+	LifetimeValue money.Money      `json:"lifetime_value"`
+	LastOrderAt   time.Time        `json:"last_order_at"`
+}
+
+type CustomerAnalytics struct {
+	RangeDays            int                  `json:"range_days"`
+	TotalCustomers       int                  `json:"total_customers"`
+	RepeatCustomers      int                  `json:"repeat_customers"`
+	RepeatPurchaseRate   float64              `json:"repeat_purchase_rate"`
+	AverageLifetimeValue money.Money          `json:"average_lifetime_value"`
+	RevenueSplit         CustomerRevenueSplit `json:"revenue_split"`
+	TopCustomers         []TopCustomer        `json:"top_customers"`
+}
+
+// Margin report granularities
+const (
+	MarginPeriodDay   = "day"
+	MarginPeriodWeek  = "week"
+	MarginPeriodMonth = "month"
+)
+
+// MarginLine is line revenue against cost of goods sold for one period, one
+// category or the whole range. Items ordered while their product had no cost
+// price count as zero cost and are reported in UncostedItems.
+type MarginLine struct {
+	Period        *time.Time  `json:"period,omitempty"`
+	Category      string      `json:"category,omitempty"`
+	Revenue       money.Money `json:"revenue"`
+	COGS          money.Money `json:"cogs"`
+	GrossMargin   money.Money `json:"gross_margin"`
+	MarginPercent float64     `json:"margin_percent"`
+	UncostedItems int         `json:"uncosted_items"`
+}
+
+// SetMargin derives the gross margin and its percentage of revenue
+func (l *MarginLine) SetMargin() {
+	l.GrossMargin = l.Revenue.Sub(l.COGS)
+	if l.Revenue != 0 {
+		l.MarginPercent = float64(l.GrossMargin) / float64(l.Revenue) * 100
+	}
+}
+
+// MarginReport covers orders placed in the range that were not cancelled or
+// refunded
+type MarginReport struct {
+	RangeDays  int          `json:"range_days"`
+	Period     string       `json:"period"`
+	Totals     MarginLine   `json:"totals"`
+	ByPeriod   []MarginLine `json:"by_period"`
+	ByCategory []MarginLine `json:"by_category"`
+}
+
+// PromotionUsage is how one promotion performed. RevenueInfluenced totals the
+// orders it discounted; NewCustomers counts those that were the customer's
+// first order. Deleted promotions are reported by name, without an ID.
+type PromotionUsage struct {
+	PromotionID       *uuid.UUID  `json:"promotion_id,omitempty"`
+	Name              string      `json:"name"`
+	Redemptions       int         `json:"redemptions"`
+	TotalDiscount     money.Money `json:"total_discount"`
+	AverageDiscount   money.Money `json:"average_discount"`
+	RevenueInfluenced money.Money `json:"revenue_influenced"`
+	NewCustomers      int         `json:"new_customers"`
+}
+
+// PromotionAnalytics covers orders placed in the range that were not
+// cancelled or refunded, largest total discount first
+type PromotionAnalytics struct {
+	RangeDays  int              `json:"range_days"`
+	Promotions []PromotionUsage `json:"promotions"`
 }
 
 type TopProductItem struct {
-	Product       Product `json:"product"`
-	TotalQuantity int     `json:"total_quantity"`
-	TotalRevenue  float64 `json:"total_revenue"`
+	Product       Product     `json:"product"`
+	TotalQuantity int         `json:"total_quantity"`
+	TotalRevenue  money.Money `json:"total_revenue"`
 }
 
 type TopProductsResponse struct {
@@ -90,5 +286,14 @@ type RefreshTokenRequest struct {
 }
 
 type UpdateUserRoleRequest struct {
-	Role string `json:"role" validate:"required,oneof=admin customer"`
+	Role string `json:"role" validate:"required,oneof=admin customer warehouse"`
+}
+
+type BulkUpdateUserRolesRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" validate:"required,min=1,max=100"`
+	Role    string      `json:"role" validate:"required,oneof=admin customer warehouse"`
+}
+
+type UpdateUserStatusRequest struct {
+	Active *bool `json:"active" validate:"required"`
 }
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminNote is an internal note staff keep on an order or a customer; exactly
+// one of OrderID and UserID is set. Customers never see notes.
+type AdminNote struct {
+	ID          uuid.UUID  `json:"id"`
+	OrderID     *uuid.UUID `json:"order_id,omitempty"`
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	AuthorID    *uuid.UUID `json:"author_id,omitempty"`
+	AuthorEmail string     `json:"author_email,omitempty"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type AddAdminNoteRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+// AddTagsRequest adds tags such as "VIP" or "chargeback-risk"; tags already
+// present are kept once
+type AddTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=20,dive,required,max=50"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit log actions
+const (
+	AuditImpersonationStart   = "impersonation.start"
+	AuditImpersonationRequest = "impersonation.request"
+	AuditSessionsRevoked      = "sessions.revoke"
+)
+
+// AuditEntry is one row of the audit trail. SubjectUserID is the customer an
+// admin acted as; Method, Path and StatusCode describe the request for
+// request-level entries.
+type AuditEntry struct {
+	ID            uuid.UUID  `json:"id"`
+	ActorID       uuid.UUID  `json:"actor_id"`
+	SubjectUserID *uuid.UUID `json:"subject_user_id,omitempty"`
+	Action        string     `json:"action"`
+	Method        string     `json:"method,omitempty"`
+	Path          string     `json:"path,omitempty"`
+	StatusCode    int        `json:"status_code,omitempty"`
+	Details       string     `json:"details,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type ImpersonateUserRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ImpersonationResponse carries a short-lived token that acts as the customer
+// with read-only access to their cart and orders
+type ImpersonationResponse struct {
+	User        *User     `json:"user"`
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
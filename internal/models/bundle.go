@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BundleComponent is a product, or one variant of it, that every unit of a
+// bundle contains quantity of. SKU and name are the component's, its
+// variant's SKU when one is set.
+type BundleComponent struct {
+	ID              uuid.UUID  `json:"id"`
+	BundleProductID uuid.UUID  `json:"bundle_product_id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	VariantID       *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity        int        `json:"quantity"`
+	SKU             string     `json:"sku"`
+	Name            string     `json:"name"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type BundleComponentRequest struct {
+	ProductID uuid.UUID  `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID `json:"variant_id"`
+	Quantity  int        `json:"quantity" validate:"required,min=1"`
+}
+
+// SetBundleRequest replaces a product's components. No components turns a
+// bundle back into a product with stock of its own.
+type SetBundleRequest struct {
+	Components []BundleComponentRequest `json:"components" validate:"dive"`
+}
+
+// OrderItemComponent is one component of a bundle as it was sold; quantity
+// is per bundle
+type OrderItemComponent struct {
+	ProductID uuid.UUID  `json:"product_id"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	SKU       string     `json:"sku"`
+	Name      string     `json:"name"`
+	Quantity  int        `json:"quantity"`
+}
+
+// StockUnit is a product or variant an order item takes out of stock
+type StockUnit struct {
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	Name      string
+	Quantity  int
+}
+
+// IsBundle reports whether the item was sold as a bundle
+func (i OrderItem) IsBundle() bool {
+	return len(i.BundleComponents) > 0
+}
+
+// StockUnits returns what the item takes out of stock: its own product or
+// variant, or for a bundle the components it was sold with
+func (i OrderItem) StockUnits() []StockUnit {
+	if !i.IsBundle() {
+		return []StockUnit{{
+			ProductID: i.ProductID,
+			VariantID: i.VariantID,
+			Name:      i.Product.Name,
+			Quantity:  i.Quantity,
+		}}
+	}
+
+	units := make([]StockUnit, 0, len(i.BundleComponents))
+	for _, component := range i.BundleComponents {
+		units = append(units, StockUnit{
+			ProductID: component.ProductID,
+			VariantID: component.VariantID,
+			Name:      component.Name,
+			Quantity:  component.Quantity * i.Quantity,
+		})
+	}
+	return units
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CarrierStatus is a shipment's tracking status as reported by its carrier
+type CarrierStatus string
+
+const (
+	CarrierInTransit      CarrierStatus = "in_transit"
+	CarrierOutForDelivery CarrierStatus = "out_for_delivery"
+	CarrierFailedAttempt  CarrierStatus = "failed_attempt"
+	CarrierDelivered      CarrierStatus = "delivered"
+)
+
+// CarrierTrackingUpdate is the body carriers post to the tracking webhook
+type CarrierTrackingUpdate struct {
+	TrackingNumber string     `json:"tracking_number"`
+	Status         string     `json:"status"`
+	OccurredAt     *time.Time `json:"occurred_at,omitempty"`
+	Description    string     `json:"description,omitempty"`
+}
+
+// What became of a carrier callback
+const (
+	CarrierEventApplied  = "applied"
+	CarrierEventIgnored  = "ignored"
+	CarrierEventRejected = "rejected"
+	CarrierEventFailed   = "failed"
+	CarrierEventInvalid  = "invalid"
+)
+
+// CarrierEvent is a carrier callback as received, with what it changed.
+// Payload is the raw body.
+type CarrierEvent struct {
+	ID             uuid.UUID      `json:"id"`
+	Carrier        string         `json:"carrier"`
+	TrackingNumber *string        `json:"tracking_number,omitempty"`
+	Status         *CarrierStatus `json:"status,omitempty"`
+	OrderID        *uuid.UUID     `json:"order_id,omitempty"`
+	Outcome        string         `json:"outcome"`
+	Detail         *string        `json:"detail,omitempty"`
+	Payload        string         `json:"payload"`
+	ReceivedAt     time.Time      `json:"received_at"`
+}
+
+type CarrierEventFilter struct {
+	Carrier        string
+	TrackingNumber string
+	Outcome        string
+}
@@ -3,29 +3,111 @@ package models
 import (
 	"time"
 
+	"ecommerce-backend/pkg/money"
+
 	"github.com/google/uuid"
 )
 
 type Cart struct {
-	ID        uuid.UUID  `json:"id"`
-	UserID    uuid.UUID  `json:"user_id"`
-	Items     []CartItem `json:"items"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID        uuid.UUID   `json:"id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	Items     []CartItem  `json:"items"`
+	Totals    *CartTotals `json:"totals,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// CartTotals is the server-side price breakdown of a cart. Tax and shipping
+// are estimates; the final amounts are fixed when the order is placed.
+type CartTotals struct {
+	ItemCount         int         `json:"item_count"`
+	Subtotal          money.Money `json:"subtotal"`
+	Discount          money.Money `json:"discount"`
+	EstimatedTax      money.Money `json:"estimated_tax"`
+	EstimatedShipping money.Money `json:"estimated_shipping"`
+	Total             money.Money `json:"total"`
+
+	// FreeShippingRemaining is how much more the discounted subtotal needs
+	// for free shipping, and MinimumOrderRemaining how much more it needs
+	// to be checked out; each is omitted once reached or when disabled
+	FreeShippingRemaining *money.Money `json:"free_shipping_remaining,omitempty"`
+	MinimumOrderRemaining *money.Money `json:"minimum_order_remaining,omitempty"`
+
+	// ChargeableWeight is what the cart ships as in grams: each item's
+	// weight or cubic weight, whichever is more. Items without shipping
+	// attributes count as weightless.
+	ChargeableWeight int `json:"chargeable_weight_grams"`
+
+	// Promotions make up Discount
+	Promotions []AppliedPromotion `json:"promotions"`
 }
 
 type CartItem struct {
-	ID        uuid.UUID `json:"id"`
-	CartID    uuid.UUID `json:"cart_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Product   Product   `json:"product"`
-	Quantity  int       `json:"quantity"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID       `json:"id"`
+	CartID    uuid.UUID       `json:"cart_id"`
+	ProductID uuid.UUID       `json:"product_id"`
+	Product   Product         `json:"product"`
+	VariantID *uuid.UUID      `json:"variant_id,omitempty"`
+	Variant   *ProductVariant `json:"variant,omitempty"`
+	Quantity  int             `json:"quantity"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// UnitPrice returns the variant price when a variant is selected,
+// otherwise the base product price
+func (i CartItem) UnitPrice() money.Money {
+	if i.Variant != nil {
+		return i.Variant.Price
+	}
+	return i.Product.Price
+}
+
+// AvailableStock returns the stock of the selected variant or base product
+func (i CartItem) AvailableStock() int {
+	if i.Variant != nil {
+		return i.Variant.Stock
+	}
+	return i.Product.Stock
+}
+
+// CartChangeAction is what resolving a cart did to one of its lines
+type CartChangeAction string
+
+const (
+	CartChangeQuantityCapped CartChangeAction = "quantity_capped"
+	CartChangeRemoved        CartChangeAction = "removed"
+)
+
+// Reasons a cart line was changed while resolving the cart
+const (
+	CartChangeDiscontinued      = "discontinued"
+	CartChangeOutOfStock        = "out_of_stock"
+	CartChangeInsufficientStock = "insufficient_stock"
+)
+
+// CartChange describes one fix applied to a cart line. Quantity is the new
+// quantity, zero when the line was removed.
+type CartChange struct {
+	ItemID           uuid.UUID        `json:"item_id"`
+	ProductID        uuid.UUID        `json:"product_id"`
+	VariantID        *uuid.UUID       `json:"variant_id,omitempty"`
+	Name             string           `json:"name"`
+	Action           CartChangeAction `json:"action"`
+	Reason           string           `json:"reason"`
+	PreviousQuantity int              `json:"previous_quantity"`
+	Quantity         int              `json:"quantity"`
+}
+
+// CartResolution is the cart after resolving it and what changed on the way
+type CartResolution struct {
+	Cart    *Cart        `json:"cart"`
+	Changes []CartChange `json:"changes"`
 }
 
 type AddToCartRequest struct {
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required,min=1"`
+	ProductID uuid.UUID  `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID `json:"variant_id"`
+	Quantity  int        `json:"quantity" validate:"required,min=1"`
 }
 
 type UpdateCartItemRequest struct {
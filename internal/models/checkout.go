@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type CheckoutSessionStatus string
+
+const (
+	CheckoutSessionOpen      CheckoutSessionStatus = "open"
+	CheckoutSessionCompleted CheckoutSessionStatus = "completed"
+	CheckoutSessionExpired   CheckoutSessionStatus = "expired"
+)
+
+// CheckoutSessionItem is a cart line as it was priced when checkout started
+type CheckoutSessionItem struct {
+	ProductID uuid.UUID   `json:"product_id"`
+	VariantID *uuid.UUID  `json:"variant_id,omitempty"`
+	SKU       string      `json:"sku"`
+	Name      string      `json:"name"`
+	Quantity  int         `json:"quantity"`
+	UnitPrice money.Money `json:"unit_price"`
+}
+
+// CheckoutSession is a snapshot of the cart taken when checkout starts. The
+// order placed from it is charged the snapshot's prices and promotions and
+// ships to its addresses; OrderID is set once it has been placed.
+type CheckoutSession struct {
+	ID              uuid.UUID             `json:"id"`
+	UserID          uuid.UUID             `json:"user_id"`
+	CartID          uuid.UUID             `json:"cart_id"`
+	Status          CheckoutSessionStatus `json:"status"`
+	Items           []CheckoutSessionItem `json:"items"`
+	Totals          CartTotals            `json:"totals"`
+	ShippingAddress Address               `json:"shipping_address"`
+	BillingAddress  Address               `json:"billing_address"`
+	ShippingMethod  ShippingMethod        `json:"shipping_method"`
+	OrderID         *uuid.UUID            `json:"order_id,omitempty"`
+	ExpiresAt       time.Time             `json:"expires_at"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+type CreateCheckoutSessionRequest struct {
+	ShippingAddress Address `json:"shipping_address" validate:"required"`
+	BillingAddress  Address `json:"billing_address" validate:"required"`
+
+	// Defaults to standard shipping
+	ShippingMethod ShippingMethod `json:"shipping_method" validate:"omitempty,oneof=standard express"`
+}
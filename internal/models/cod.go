@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// CODVerification holds the delivery code for a cash-on-delivery order. Only
+// a hash of the code is stored.
+type CODVerification struct {
+	ID         uuid.UUID  `json:"id"`
+	OrderID    uuid.UUID  `json:"order_id"`
+	OTPHash    string     `json:"-"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Attempts   int        `json:"attempts"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CODEligibility struct {
+	Eligible      bool         `json:"eligible"`
+	Reason        string       `json:"reason,omitempty"`
+	Amount        money.Money  `json:"amount"`
+	MaxOrderValue *money.Money `json:"max_order_value,omitempty"`
+}
+
+type ConfirmCODDeliveryRequest struct {
+	OTP string `json:"otp" validate:"required,len=6,numeric"`
+}
+
+type CODRemittanceStatus string
+
+const (
+	CODRemittancePending  CODRemittanceStatus = "pending"
+	CODRemittanceRemitted CODRemittanceStatus = "remitted"
+)
+
+// CODRemittance is cash collected on delivery that the courier owes the store
+type CODRemittance struct {
+	ID          uuid.UUID           `json:"id"`
+	OrderID     uuid.UUID           `json:"order_id"`
+	OrderNumber string              `json:"order_number"`
+	PaymentID   *uuid.UUID          `json:"payment_id,omitempty"`
+	Amount      money.Money         `json:"amount"`
+	Status      CODRemittanceStatus `json:"status"`
+	Reference   *string             `json:"reference,omitempty"`
+	CollectedAt time.Time           `json:"collected_at"`
+	RemittedAt  *time.Time          `json:"remitted_at,omitempty"`
+}
+
+type CODRemittanceTotals struct {
+	Collected     money.Money `json:"collected"`
+	Remitted      money.Money `json:"remitted"`
+	Outstanding   money.Money `json:"outstanding"`
+	PendingCount  int         `json:"pending_count"`
+	RemittedCount int         `json:"remitted_count"`
+}
+
+type CODRemittanceReport struct {
+	Totals      CODRemittanceTotals `json:"totals"`
+	Remittances []CODRemittance     `json:"remittances"`
+}
+
+type RemitCODRequest struct {
+	RemittanceIDs []uuid.UUID `json:"remittance_ids" validate:"required,min=1"`
+	Reference     string      `json:"reference" validate:"required,max=100"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// CapturedRequest is a failing request kept for incident triage. Bodies are
+// decoded JSON with credentials redacted, or a note saying why they were
+// left out.
+type CapturedRequest struct {
+	ID           int64       `json:"id"`
+	RequestID    string      `json:"request_id,omitempty"`
+	Method       string      `json:"method"`
+	Path         string      `json:"path"`
+	Route        string      `json:"route,omitempty"`
+	Query        string      `json:"query,omitempty"`
+	Status       int         `json:"status"`
+	DurationMS   int64       `json:"duration_ms"`
+	ClientIP     string      `json:"client_ip"`
+	UserID       string      `json:"user_id,omitempty"`
+	Errors       []string    `json:"errors,omitempty"`
+	RequestBody  interface{} `json:"request_body,omitempty"`
+	ResponseBody interface{} `json:"response_body,omitempty"`
+	CapturedAt   time.Time   `json:"captured_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type ShippingMethod string
+
+const (
+	ShippingStandard ShippingMethod = "standard"
+	ShippingExpress  ShippingMethod = "express"
+)
+
+// ShippingMethods lists the methods offered at checkout
+var ShippingMethods = []ShippingMethod{ShippingStandard, ShippingExpress}
+
+// DeliveryEstimateRequest estimates delivery of the user's cart to a postal
+// code, for one shipping method or, when none is given, for each of them
+type DeliveryEstimateRequest struct {
+	PostalCode     string         `json:"postal_code" validate:"required,max=20"`
+	ShippingMethod ShippingMethod `json:"shipping_method" validate:"omitempty,oneof=standard express"`
+}
+
+// DeliveryEstimate is when a shipment leaves the warehouse and when it is
+// expected to arrive. With items from several warehouses it is the latest of
+// them.
+type DeliveryEstimate struct {
+	ShippingMethod    ShippingMethod `json:"shipping_method"`
+	DispatchDate      time.Time      `json:"dispatch_date"`
+	EstimatedDelivery time.Time      `json:"estimated_delivery"`
+}
+
+type DeliveryEstimateResponse struct {
+	PostalCode string             `json:"postal_code"`
+	Estimates  []DeliveryEstimate `json:"estimates"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ERPStockUpdate is a stock level reported by the ERP, translated into the
+// store's terms. An empty warehouse code means the default warehouse.
+type ERPStockUpdate struct {
+	MessageID     string
+	SKU           string
+	WarehouseCode string
+	Quantity      int
+	ChangedAt     *time.Time
+}
+
+// What became of an ERP stock message
+const (
+	ERPMessageApplied  = "applied"
+	ERPMessageIgnored  = "ignored"
+	ERPMessageRejected = "rejected"
+	ERPMessageFailed   = "failed"
+	ERPMessageInvalid  = "invalid"
+)
+
+// ERPStockMessage is a stock message as received from the queue, with what it
+// changed. Payload is the raw body.
+type ERPStockMessage struct {
+	ID            uuid.UUID  `json:"id"`
+	MessageID     *string    `json:"message_id,omitempty"`
+	SKU           *string    `json:"sku,omitempty"`
+	WarehouseCode *string    `json:"warehouse_code,omitempty"`
+	Quantity      *int       `json:"quantity,omitempty"`
+	ChangedAt     *time.Time `json:"changed_at,omitempty"`
+	ProductID     *uuid.UUID `json:"product_id,omitempty"`
+	VariantID     *uuid.UUID `json:"variant_id,omitempty"`
+	Outcome       string     `json:"outcome"`
+	Detail        *string    `json:"detail,omitempty"`
+	Payload       string     `json:"payload"`
+	Attempts      int        `json:"attempts"`
+	ReceivedAt    time.Time  `json:"received_at"`
+	ProcessedAt   time.Time  `json:"processed_at"`
+}
+
+type ERPStockMessageFilter struct {
+	SKU     string
+	Outcome string
+}
+
+// ReplayERPEventsRequest re-sends the order events recorded in a window to
+// the ERP, e.g. after it restored from a backup. To defaults to now.
+type ReplayERPEventsRequest struct {
+	From time.Time  `json:"from" validate:"required"`
+	To   *time.Time `json:"to"`
+}
+
+// ReplayERPEventsResult reports a replay. A replay stops after a batch; when
+// More is set, replay again from LastEventAt.
+type ReplayERPEventsResult struct {
+	Published   int        `json:"published"`
+	LastEventAt *time.Time `json:"last_event_at,omitempty"`
+	More        bool       `json:"more"`
+}
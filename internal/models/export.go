@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// OrderExportRow is one line of the admin order CSV export
+type OrderExportRow struct {
+	ID              uuid.UUID
+	OrderNumber     string
+	Status          OrderStatus
+	PaymentMethod   string
+	TotalAmount     money.Money
+	ItemCount       int
+	CustomerEmail   string
+	CustomerName    string
+	ShippingCity    string
+	ShippingCountry string
+	CreatedAt       time.Time
+}
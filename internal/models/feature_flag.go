@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Flags the code checks. Each is seeded enabled by its migration.
+const (
+	FlagAPIV2               = "api_v2"
+	FlagAutomaticPromotions = "automatic_promotions"
+)
+
+// FeatureFlag switches a code path on and off at runtime. It is on for a
+// subject when Enabled, the deployment environment is in Environments (empty
+// means all) and the subject falls in the first RolloutPercent of buckets.
+type FeatureFlag struct {
+	Key            string    `json:"key"`
+	Description    string    `json:"description"`
+	Enabled        bool      `json:"enabled"`
+	RolloutPercent int       `json:"rollout_percent"`
+	Environments   []string  `json:"environments"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// FeatureFlagRequest creates a flag or replaces every field of one.
+// RolloutPercent defaults to 100.
+type FeatureFlagRequest struct {
+	Description    string   `json:"description" validate:"max=500"`
+	Enabled        bool     `json:"enabled"`
+	RolloutPercent *int     `json:"rollout_percent" validate:"omitempty,min=0,max=100"`
+	Environments   []string `json:"environments" validate:"omitempty,dive,required,max=50"`
+}
+
+type CreateFeatureFlagRequest struct {
+	Key string `json:"key" validate:"required,max=100"`
+	FeatureFlagRequest
+}
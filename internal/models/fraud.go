@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// FraudCheck is what the fraud rules see of an order about to be placed
+type FraudCheck struct {
+	UserID          uuid.UUID
+	ClientIP        string
+	BillingCountry  string
+	ShippingCountry string
+	Total           money.Money
+}
+
+// FraudSignal is one rule that fired and the risk it added
+type FraudSignal struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// Review decisions on a held order
+const (
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// RiskAssessment is the fraud screening result of an order. Held orders wait
+// in review status for an admin decision.
+type RiskAssessment struct {
+	OrderID    uuid.UUID     `json:"order_id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	ClientIP   string        `json:"client_ip"`
+	Score      int           `json:"score"`
+	Signals    []FraudSignal `json:"signals"`
+	Held       bool          `json:"held"`
+	Decision   string        `json:"decision,omitempty"`
+	ReviewNote string        `json:"review_note,omitempty"`
+	ReviewedBy *uuid.UUID    `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time    `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// ReviewQueueItem is an order waiting for a fraud review decision
+type ReviewQueueItem struct {
+	OrderID       uuid.UUID      `json:"order_id"`
+	OrderNumber   string         `json:"order_number"`
+	UserEmail     string         `json:"user_email"`
+	TotalAmount   money.Money    `json:"total_amount"`
+	PaymentMethod string         `json:"payment_method"`
+	PaymentStatus *PaymentStatus `json:"payment_status,omitempty"`
+	Assessment    RiskAssessment `json:"assessment"`
+	PlacedAt      time.Time      `json:"placed_at"`
+}
+
+type ReviewOrderRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=approved rejected"`
+	Note     string `json:"note" validate:"max=1000"`
+}
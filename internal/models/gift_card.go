@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type GiftCardKind string
+
+const (
+	GiftCardKindGiftCard    GiftCardKind = "gift_card"
+	GiftCardKindStoreCredit GiftCardKind = "store_credit"
+)
+
+type GiftCardTransactionType string
+
+const (
+	GiftCardIssue        GiftCardTransactionType = "issue"
+	GiftCardRedeem       GiftCardTransactionType = "redeem"
+	GiftCardReversal     GiftCardTransactionType = "reversal"
+	GiftCardRefundCredit GiftCardTransactionType = "refund_credit"
+)
+
+type GiftCard struct {
+	ID             uuid.UUID    `json:"id"`
+	Code           string       `json:"code"`
+	Kind           GiftCardKind `json:"kind"`
+	UserID         *uuid.UUID   `json:"user_id,omitempty"`
+	InitialBalance money.Money  `json:"initial_balance"`
+	Balance        money.Money  `json:"balance"`
+	ExpiresAt      *time.Time   `json:"expires_at,omitempty"`
+	Note           string       `json:"note,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// Expired reports whether the card can no longer be redeemed
+func (g GiftCard) Expired() bool {
+	return g.ExpiresAt != nil && !g.ExpiresAt.After(time.Now())
+}
+
+type GiftCardTransaction struct {
+	ID         uuid.UUID               `json:"id"`
+	GiftCardID uuid.UUID               `json:"gift_card_id"`
+	Type       GiftCardTransactionType `json:"type"`
+	Amount     money.Money             `json:"amount"`
+	OrderID    *uuid.UUID              `json:"order_id,omitempty"`
+	ReturnID   *uuid.UUID              `json:"return_id,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+// GiftCardDetail is a card with its balance history, newest first
+type GiftCardDetail struct {
+	GiftCard
+	Transactions []GiftCardTransaction `json:"transactions"`
+}
+
+// IssueGiftCardRequest issues a gift card. Setting user_id restricts
+// redemption to that customer.
+type IssueGiftCardRequest struct {
+	Amount    money.Money `json:"amount" validate:"required,min=0"`
+	UserID    *uuid.UUID  `json:"user_id"`
+	ExpiresAt *time.Time  `json:"expires_at"`
+	Note      string      `json:"note"`
+}
+
+// GiftCardBalance is what a customer sees when checking a code
+type GiftCardBalance struct {
+	Code      string      `json:"code"`
+	Balance   money.Money `json:"balance"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationOrderStatus NotificationType = "order_status"
+	NotificationPayment     NotificationType = "payment"
+	NotificationRefund      NotificationType = "refund"
+	NotificationReturn      NotificationType = "return"
+	NotificationBackInStock NotificationType = "back_in_stock"
+	NotificationOrderReply  NotificationType = "order_message"
+)
+
+type Notification struct {
+	ID        uuid.UUID              `json:"id"`
+	UserID    uuid.UUID              `json:"user_id"`
+	Type      NotificationType       `json:"type"`
+	Title     string                 `json:"title"`
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data"`
+	ReadAt    *time.Time             `json:"read_at,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
@@ -3,62 +3,174 @@ package models
 import (
 	"time"
 
+	"ecommerce-backend/pkg/money"
+
 	"github.com/google/uuid"
 )
 
 type OrderStatus string
 
 const (
-	OrderPending    OrderStatus = "pending"
-	OrderProcessing OrderStatus = "processing"
-	OrderShipped    OrderStatus = "shipped"
-	OrderDelivered  OrderStatus = "delivered"
-	OrderCompleted  OrderStatus = "completed"
-	OrderCancelled  OrderStatus = "cancelled"
-	OrderRefunded   OrderStatus = "refunded"
-	OrderReturnRequested OrderStatus = "return_requested"
+	// OrderAwaitingPayment is a checkout attempt in authorize-then-capture
+	// mode: its stock is reserved but not taken until payment is authorized
+	OrderAwaitingPayment  OrderStatus = "awaiting_payment"
+	OrderPending          OrderStatus = "pending"
+	OrderOnHold           OrderStatus = "on_hold"
+	OrderUnderReview      OrderStatus = "under_review"
+	OrderProcessing       OrderStatus = "processing"
+	OrderPartiallyShipped OrderStatus = "partially_shipped"
+	OrderShipped          OrderStatus = "shipped"
+	OrderDelivered        OrderStatus = "delivered"
+	OrderCompleted        OrderStatus = "completed"
+	OrderCancelled        OrderStatus = "cancelled"
+	OrderRefunded         OrderStatus = "refunded"
+	OrderReturnRequested  OrderStatus = "return_requested"
 )
 
+// IsHeld reports whether the order is on hold or under review, waiting for an
+// admin before it can be fulfilled or auto-cancelled
+func (s OrderStatus) IsHeld() bool {
+	return s == OrderOnHold || s == OrderUnderReview
+}
+
+// IsFulfillment reports whether the status is a step of picking and shipping
+// an order, the only statuses warehouse staff may set
+func (s OrderStatus) IsFulfillment() bool {
+	switch s {
+	case OrderProcessing, OrderPartiallyShipped, OrderShipped, OrderDelivered:
+		return true
+	}
+	return false
+}
+
 type Order struct {
-	ID              uuid.UUID   `json:"id"`
-	UserID          uuid.UUID   `json:"user_id"`
-	OrderNumber     string      `json:"order_number"`
-	TotalAmount     float64     `json:"total_amount"`
-	Status          OrderStatus `json:"status"`
-	PaymentMethod   string      `json:"payment_method"`
-	ShippingAddress Address     `json:"shipping_address"`
-	BillingAddress  Address     `json:"billing_address"`
-	Items           []OrderItem `json:"items"`
-	CreatedAt       time.Time   `json:"created_at"`
-	UpdatedAt       time.Time   `json:"updated_at"`
+	ID              uuid.UUID          `json:"id"`
+	UserID          uuid.UUID          `json:"user_id"`
+	OrderNumber     string             `json:"order_number"`
+	TotalAmount     money.Money        `json:"total_amount"`
+	GiftCardAmount  money.Money        `json:"gift_card_amount"`
+	Status          OrderStatus        `json:"status"`
+	PaymentMethod   string             `json:"payment_method"`
+	ShippingMethod  ShippingMethod     `json:"shipping_method,omitempty"`
+	ShippingAddress Address            `json:"shipping_address"`
+	BillingAddress  Address            `json:"billing_address"`
+	Items           []OrderItem        `json:"items"`
+	Fulfillment     FulfillmentSummary `json:"fulfillment,omitempty"`
+
+	// Order detail only. EstimatedDelivery is the date promised at checkout;
+	// orders placed before delivery estimates have none.
+	EstimatedDelivery *time.Time `json:"estimated_delivery,omitempty"`
+
+	// Order detail only. TotalAmount is already net of DiscountAmount, which
+	// Promotions make up.
+	DiscountAmount money.Money        `json:"discount_amount"`
+	Promotions     []AppliedPromotion `json:"promotions,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AmountDue is the part of the total left for the payment method after gift
+// cards and store credit
+func (o Order) AmountDue() money.Money {
+	return o.TotalAmount.Sub(o.GiftCardAmount)
 }
 
 type OrderItem struct {
-	ID          uuid.UUID `json:"id"`
-	OrderID     uuid.UUID `json:"order_id"`
-	ProductID   uuid.UUID `json:"product_id"`
-	Product     Product   `json:"product"`
-	Quantity    int       `json:"quantity"`
-	PriceAtTime float64   `json:"price_at_time"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID                uuid.UUID         `json:"id"`
+	OrderID           uuid.UUID         `json:"order_id"`
+	ProductID         uuid.UUID         `json:"product_id"`
+	Product           Product           `json:"product"`
+	VariantID         *uuid.UUID        `json:"variant_id,omitempty"`
+	Variant           *ProductVariant   `json:"variant,omitempty"`
+	Quantity          int               `json:"quantity"`
+	PriceAtTime       money.Money       `json:"price_at_time"`
+	FulfillmentStatus FulfillmentStatus `json:"fulfillment_status"`
+	TrackingNumber    *string           `json:"tracking_number,omitempty"`
+	ShippedAt         *time.Time        `json:"shipped_at,omitempty"`
+	DeliveredAt       *time.Time        `json:"delivered_at,omitempty"`
+	CarrierStatus     *CarrierStatus    `json:"carrier_status,omitempty"`
+	CarrierStatusAt   *time.Time        `json:"carrier_status_at,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+
+	// BundleComponents is what a bundle item was made of when sold; nil for
+	// other items
+	BundleComponents []OrderItemComponent `json:"bundle_components,omitempty"`
+}
+
+type FulfillmentStatus string
+
+const (
+	FulfillmentPending     FulfillmentStatus = "pending"
+	FulfillmentBackordered FulfillmentStatus = "backordered"
+	FulfillmentShipped     FulfillmentStatus = "shipped"
+	FulfillmentDelivered   FulfillmentStatus = "delivered"
+	FulfillmentCancelled   FulfillmentStatus = "cancelled"
+)
+
+// FulfillmentSummary counts an order's items by fulfillment status
+type FulfillmentSummary map[FulfillmentStatus]int
+
+type UpdateItemFulfillmentRequest struct {
+	Status         FulfillmentStatus `json:"status" validate:"required,oneof=pending backordered shipped delivered"`
+	TrackingNumber string            `json:"tracking_number" validate:"omitempty,max=100"`
 }
 
 type Address struct {
-	FullName   string `json:"full_name"`
-	Street     string `json:"street"`
-	City       string `json:"city"`
-	State      string `json:"state"`
-	Country    string `json:"country"`
-	PostalCode string `json:"postal_code"`
-	Phone      string `json:"phone"`
+	FullName   string `json:"full_name" validate:"required,max=100"`
+	Street     string `json:"street" validate:"required,max=255"`
+	City       string `json:"city" validate:"required,max=100"`
+	State      string `json:"state" validate:"required,max=100"`
+	Country    string `json:"country" validate:"required,max=100"`
+	PostalCode string `json:"postal_code" validate:"required,max=20"`
+	Phone      string `json:"phone" validate:"required,phone"`
 }
 
 type CreateOrderRequest struct {
-	ShippingAddress Address `json:"shipping_address" validate:"required"`
-	BillingAddress  Address `json:"billing_address" validate:"required"`
+	// Places the order from a checkout session, which then supplies the
+	// addresses, shipping method and prices; without one they are taken
+	// from the request and the current cart
+	CheckoutSessionID *uuid.UUID `json:"checkout_session_id"`
+
+	// Required without a checkout session
+	ShippingAddress Address `json:"shipping_address" validate:"required_without=CheckoutSessionID,omitzero"`
+	BillingAddress  Address `json:"billing_address" validate:"required_without=CheckoutSessionID,omitzero"`
 	PaymentMethod   string  `json:"payment_method" validate:"required,oneof=cc dc cod"`
+
+	// Defaults to standard shipping
+	ShippingMethod ShippingMethod `json:"shipping_method" validate:"omitempty,oneof=standard express"`
+
+	// Pay with a saved card instead of entering card details; requires
+	// payment_method to match the card's type
+	SavedPaymentMethodID *uuid.UUID `json:"saved_payment_method_id"`
+
+	// Optional split tender; store credit is applied before the gift card
+	GiftCardCode   string `json:"gift_card_code"`
+	UseStoreCredit bool   `json:"use_store_credit"`
+
+	// Set by the handler for fraud screening
+	ClientIP string `json:"-"`
 }
 
 type UpdateOrderStatusRequest struct {
 	Status OrderStatus `json:"status" validate:"required"`
 }
+
+type BulkUpdateOrderStatusRequest struct {
+	OrderIDs []uuid.UUID `json:"order_ids" validate:"required,min=1,max=500"`
+	Status   OrderStatus `json:"status" validate:"required"`
+}
+
+// BulkOrderStatusResult is the outcome for one order of a bulk status update
+type BulkOrderStatusResult struct {
+	OrderID uuid.UUID `json:"order_id"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+	Message string    `json:"message,omitempty"`
+}
+
+type BulkUpdateOrderStatusResponse struct {
+	Updated int                     `json:"updated"`
+	Failed  int                     `json:"failed"`
+	Results []BulkOrderStatusResult `json:"results"`
+}
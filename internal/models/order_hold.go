@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderHold is one period an order spent on hold or under review. Releasing
+// it resumes the order from PreviousStatus.
+type OrderHold struct {
+	ID             uuid.UUID   `json:"id"`
+	OrderID        uuid.UUID   `json:"order_id"`
+	OrderNumber    string      `json:"order_number,omitempty"`
+	Status         OrderStatus `json:"status"`
+	PreviousStatus OrderStatus `json:"previous_status"`
+	Reason         string      `json:"reason"`
+	PlacedBy       *uuid.UUID  `json:"placed_by,omitempty"`
+	PlacedAt       time.Time   `json:"placed_at"`
+	ReleasedBy     *uuid.UUID  `json:"released_by,omitempty"`
+	ReleasedAt     *time.Time  `json:"released_at,omitempty"`
+	ReleaseNote    string      `json:"release_note,omitempty"`
+}
+
+type PlaceOrderHoldRequest struct {
+	Status OrderStatus `json:"status" validate:"required,oneof=on_hold under_review"`
+	Reason string      `json:"reason" validate:"required,max=500"`
+}
+
+type ReleaseOrderHoldRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+)
+
+// ImportOrder is a historical order migrated from a legacy platform. It keeps
+// its order number and timestamps and does not touch stock.
+type ImportOrder struct {
+	OrderNumber    string         `json:"order_number" validate:"required,max=50"`
+	CustomerEmail  string         `json:"customer_email" validate:"required,email"`
+	Status         OrderStatus    `json:"status" validate:"required,oneof=pending processing shipped delivered completed cancelled refunded"`
+	PaymentMethod  string         `json:"payment_method" validate:"required,oneof=cc dc cod"`
+	ShippingMethod ShippingMethod `json:"shipping_method" validate:"omitempty,oneof=standard express"`
+
+	// BillingAddress defaults to the shipping address
+	ShippingAddress Address  `json:"shipping_address" validate:"required"`
+	BillingAddress  *Address `json:"billing_address"`
+
+	// TotalAmount defaults to the sum of the items
+	TotalAmount *money.Money `json:"total_amount" validate:"omitempty,min=0"`
+
+	// UpdatedAt defaults to CreatedAt
+	CreatedAt time.Time  `json:"created_at" validate:"required"`
+	UpdatedAt *time.Time `json:"updated_at"`
+
+	Items []ImportOrderItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// ImportOrderItem names its product or variant by SKU
+type ImportOrderItem struct {
+	SKU      string      `json:"sku" validate:"required"`
+	Quantity int         `json:"quantity" validate:"required,min=1"`
+	Price    money.Money `json:"price" validate:"min=0"`
+}
+
+type ImportOrdersRequest struct {
+	Orders []ImportOrder `json:"orders" validate:"required,min=1,max=1000"`
+}
+
+// OrderImportResult reports an order import. Row is the order's position in
+// a JSON payload, or the line of a CSV file.
+type OrderImportResult struct {
+	TotalOrders   int              `json:"total_orders"`
+	ImportedCount int              `json:"imported_count"`
+	FailedCount   int              `json:"failed_count"`
+	RowErrors     []ImportRowError `json:"row_errors"`
+}
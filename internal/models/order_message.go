@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderMessage is one message in an order's support thread. FromStaff tells
+// support replies apart from the customer's own messages.
+type OrderMessage struct {
+	ID        uuid.UUID  `json:"id"`
+	OrderID   uuid.UUID  `json:"order_id"`
+	SenderID  *uuid.UUID `json:"sender_id,omitempty"`
+	FromStaff bool       `json:"from_staff"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// OrderMessageThread is an order's messages, oldest first. UnreadCount is how
+// many of the other side's messages were unread before this view.
+type OrderMessageThread struct {
+	OrderID     uuid.UUID      `json:"order_id"`
+	OrderNumber string         `json:"order_number"`
+	Messages    []OrderMessage `json:"messages"`
+	UnreadCount int            `json:"unread_count"`
+}
+
+// UnreadOrderMessages counts the unread messages on one order
+type UnreadOrderMessages struct {
+	OrderID       uuid.UUID `json:"order_id"`
+	OrderNumber   string    `json:"order_number"`
+	UnreadCount   int       `json:"unread_count"`
+	LastMessageAt time.Time `json:"last_message_at"`
+}
+
+type PostOrderMessageRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"ecommerce-backend/pkg/money"
+
 	"github.com/google/uuid"
 )
 
@@ -11,21 +13,103 @@ type PaymentStatus string
 const (
 	PaymentPending    PaymentStatus = "pending"
 	PaymentProcessing PaymentStatus = "processing"
+	PaymentAuthorized PaymentStatus = "authorized"
 	PaymentCompleted  PaymentStatus = "completed"
 	PaymentFailed     PaymentStatus = "failed"
+	PaymentVoided     PaymentStatus = "voided"
 	PaymentRefunded   PaymentStatus = "refunded"
+
+	PaymentPartiallyRefunded PaymentStatus = "partially_refunded"
 )
 
 type Payment struct {
 	ID             uuid.UUID              `json:"id"`
 	OrderID        uuid.UUID              `json:"order_id"`
-	Amount         float64                `json:"amount"`
+	Amount         money.Money            `json:"amount"`
+	RefundedAmount money.Money            `json:"refunded_amount"`
 	Status         PaymentStatus          `json:"status"`
 	PaymentMethod  string                 `json:"payment_method"`
 	TransactionID  string                 `json:"transaction_id"`
 	PaymentDetails map[string]interface{} `json:"payment_details"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+
+	// ClientAction is what the client must do to finish a pending payment
+	ClientAction *PaymentClientAction `json:"client_action,omitempty"`
+}
+
+// Client actions a pending gateway payment can require
+const (
+	// ClientActionRedirect sends the customer to RedirectURL to authenticate
+	ClientActionRedirect = "redirect"
+	// ClientActionConfirm confirms the payment with ClientSecret using the
+	// gateway's client SDK, which handles any authentication itself
+	ClientActionConfirm = "confirm"
+)
+
+type PaymentClientAction struct {
+	Type         string `json:"type"`
+	RedirectURL  string `json:"redirect_url,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// PaymentStatusResponse is polled by clients waiting for a payment to settle
+type PaymentStatusResponse struct {
+	PaymentID    uuid.UUID            `json:"payment_id"`
+	OrderID      uuid.UUID            `json:"order_id"`
+	Status       PaymentStatus        `json:"status"`
+	OrderStatus  OrderStatus          `json:"order_status"`
+	ClientAction *PaymentClientAction `json:"client_action,omitempty"`
+}
+
+// Refundable returns how much of the payment has not been refunded yet
+func (p Payment) Refundable() money.Money {
+	return p.Amount.Sub(p.RefundedAmount)
+}
+
+// Refund is one (possibly partial) refund against a payment
+type Refund struct {
+	ID        uuid.UUID   `json:"id"`
+	PaymentID uuid.UUID   `json:"payment_id"`
+	OrderID   uuid.UUID   `json:"order_id"`
+	ReturnID  *uuid.UUID  `json:"return_id,omitempty"`
+	Amount    money.Money `json:"amount"`
+	Reason    string      `json:"reason,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// PaymentCapture settles an authorized payment: CapturedAmount is taken from
+// the card and ReleasedAmount, the rest of the authorization, handed back. A
+// void captures nothing. CreatedBy is unset for automatic captures.
+type PaymentCapture struct {
+	ID             uuid.UUID   `json:"id"`
+	PaymentID      uuid.UUID   `json:"payment_id"`
+	OrderID        uuid.UUID   `json:"order_id"`
+	CapturedAmount money.Money `json:"captured_amount"`
+	ReleasedAmount money.Money `json:"released_amount"`
+	Reason         string      `json:"reason,omitempty"`
+	CreatedBy      *uuid.UUID  `json:"created_by,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+// CapturePaymentRequest captures an authorized payment; without an amount the
+// whole authorization is captured
+type CapturePaymentRequest struct {
+	Amount *money.Money `json:"amount" validate:"omitempty,gt=0"`
+	Reason string       `json:"reason" validate:"max=500"`
+}
+
+type VoidPaymentRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// PaymentFilter narrows the admin payment list; From is inclusive and To
+// exclusive
+type PaymentFilter struct {
+	Status string
+	Method string
+	From   *time.Time
+	To     *time.Time
 }
 
 type CreatePaymentRequest struct {
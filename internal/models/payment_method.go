@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedPaymentMethod is a card tokenized by the payment gateway and saved for
+// later checkouts
+type SavedPaymentMethod struct {
+	ID      uuid.UUID `json:"id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Gateway string    `json:"gateway"`
+	Token   string    `json:"-"`
+	// The gateway customer the token is attached to, when there is one
+	CustomerID string    `json:"-"`
+	Type       string    `json:"type"`
+	Brand      string    `json:"brand"`
+	Last4      string    `json:"last4"`
+	ExpMonth   int       `json:"exp_month"`
+	ExpYear    int       `json:"exp_year"`
+	IsDefault  bool      `json:"is_default"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SavePaymentMethodRequest saves a card the client tokenized with the gateway.
+// The card details are read from the gateway; the ones given here are only
+// used when payments are simulated.
+type SavePaymentMethodRequest struct {
+	Token       string `json:"token" validate:"required,max=255"`
+	Type        string `json:"type" validate:"required,oneof=cc dc"`
+	MakeDefault bool   `json:"make_default"`
+
+	Brand    string `json:"brand" validate:"max=30"`
+	Last4    string `json:"last4" validate:"omitempty,len=4,numeric"`
+	ExpMonth int    `json:"exp_month" validate:"omitempty,min=1,max=12"`
+	ExpYear  int    `json:"exp_year" validate:"omitempty,min=2000"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// User roles
+const (
+	RoleCustomer = "customer"
+	RoleAdmin    = "admin"
+	// RoleWarehouse is staff that picks, ships and counts stock but cannot
+	// see pricing, users or analytics
+	RoleWarehouse = "warehouse"
+)
+
+// Permission grants access to a group of admin endpoints
+type Permission string
+
+const (
+	// PermissionAdmin grants every admin endpoint
+	PermissionAdmin Permission = "admin"
+	// PermissionFulfillment grants order fulfillment and stock adjustments
+	PermissionFulfillment Permission = "fulfillment"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleAdmin:     {PermissionAdmin, PermissionFulfillment},
+	RoleWarehouse: {PermissionFulfillment},
+}
+
+// HasPermission reports whether users with role hold permission
+func HasPermission(role string, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OTPPurpose is what a phone one-time code is good for
+type OTPPurpose string
+
+const (
+	OTPPurposeLogin       OTPPurpose = "login"
+	OTPPurposeVerifyPhone OTPPurpose = "verify_phone"
+	OTPPurposeReauth      OTPPurpose = "reauthenticate"
+)
+
+// PhoneOTP is an outstanding one-time code sent to Phone. Only a hash of the
+// code is stored.
+type PhoneOTP struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Purpose   OTPPurpose `json:"purpose"`
+	Phone     string     `json:"phone"`
+	OTPHash   string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	Attempts  int        `json:"attempts"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// UpdatePhoneRequest sets the account's phone number, in E.164 form
+type UpdatePhoneRequest struct {
+	Phone string `json:"phone" validate:"required,e164"`
+}
+
+type VerifyPhoneRequest struct {
+	OTP string `json:"otp" validate:"required,len=6,numeric"`
+}
+
+type RequestLoginOTPRequest struct {
+	Phone string `json:"phone" validate:"required,e164"`
+}
+
+type OTPLoginRequest struct {
+	Phone string `json:"phone" validate:"required,e164"`
+	OTP   string `json:"otp" validate:"required,len=6,numeric"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PickListLine is the quantity of one product or variant to pick from one
+// warehouse for a batch of orders
+type PickListLine struct {
+	WarehouseID   uuid.UUID  `json:"-"`
+	WarehouseCode string     `json:"-"`
+	WarehouseName string     `json:"-"`
+	ProductID     uuid.UUID  `json:"product_id"`
+	ProductName   string     `json:"product_name"`
+	SKU           string     `json:"sku"`
+	VariantID     *uuid.UUID `json:"variant_id,omitempty"`
+	VariantSKU    *string    `json:"variant_sku,omitempty"`
+	Barcode       *string    `json:"barcode,omitempty"`
+	Quantity      int        `json:"quantity"`
+	OrderNumbers  []string   `json:"order_numbers"`
+}
+
+// PickListWarehouse is the part of a pick list picked at one warehouse, in
+// SKU order
+type PickListWarehouse struct {
+	WarehouseID   uuid.UUID      `json:"warehouse_id"`
+	WarehouseCode string         `json:"warehouse_code"`
+	WarehouseName string         `json:"warehouse_name"`
+	TotalUnits    int            `json:"total_units"`
+	Items         []PickListLine `json:"items"`
+}
+
+// PickList aggregates the unshipped items of processing orders by warehouse
+// and product
+type PickList struct {
+	OrderNumbers []string            `json:"order_numbers"`
+	TotalUnits   int                 `json:"total_units"`
+	Warehouses   []PickListWarehouse `json:"warehouses"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+}
+
+// PickListFilter selects the orders to pick. Without OrderIDs every order
+// ready to pick is included.
+type PickListFilter struct {
+	OrderIDs    []uuid.UUID
+	WarehouseID *uuid.UUID
+}
+
+// PackingSlipItem is one line of a packing slip. Prices are left off on
+// purpose: the slip travels in the parcel.
+type PackingSlipItem struct {
+	ProductName       string            `json:"product_name"`
+	SKU               string            `json:"sku"`
+	VariantSKU        *string           `json:"variant_sku,omitempty"`
+	Quantity          int               `json:"quantity"`
+	FulfillmentStatus FulfillmentStatus `json:"fulfillment_status"`
+
+	// Components are what goes in the parcel for a bundle, quantities per
+	// bundle
+	Components []OrderItemComponent `json:"components,omitempty"`
+
+	// Shipping attributes of one unit, when the product has them
+	WeightGrams *int        `json:"weight_grams,omitempty"`
+	Dimensions  *Dimensions `json:"dimensions,omitempty"`
+}
+
+// PackingSlip is the printable list of what goes in an order's parcel
+type PackingSlip struct {
+	OrderID        uuid.UUID         `json:"order_id"`
+	OrderNumber    string            `json:"order_number"`
+	OrderDate      time.Time         `json:"order_date"`
+	ShippingMethod ShippingMethod    `json:"shipping_method,omitempty"`
+	ShipTo         Address           `json:"ship_to"`
+	Items          []PackingSlipItem `json:"items"`
+	TotalUnits     int               `json:"total_units"`
+
+	// TotalWeight is the parcel's contents in grams, for the carrier label;
+	// items without a weight are left out
+	TotalWeight int `json:"total_weight_grams"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// PriceSchedule overrides a product or variant price for a time window
+type PriceSchedule struct {
+	ID        uuid.UUID   `json:"id"`
+	ProductID uuid.UUID   `json:"product_id"`
+	VariantID *uuid.UUID  `json:"variant_id,omitempty"`
+	Price     money.Money `json:"price"`
+	Label     string      `json:"label,omitempty"`
+	StartsAt  time.Time   `json:"starts_at"`
+	EndsAt    time.Time   `json:"ends_at"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type PriceScheduleRequest struct {
+	VariantID *uuid.UUID  `json:"variant_id"`
+	Price     money.Money `json:"price" validate:"min=0"`
+	Label     string      `json:"label" validate:"max=100"`
+	StartsAt  time.Time   `json:"starts_at" validate:"required"`
+	EndsAt    time.Time   `json:"ends_at" validate:"required"`
+}
+
+// SaleInfo is the badge attached to a product or variant while a scheduled
+// price is in effect; Price on the parent holds the current price
+type SaleInfo struct {
+	OriginalPrice money.Money `json:"original_price"`
+	Label         string      `json:"label,omitempty"`
+	EndsAt        time.Time   `json:"ends_at"`
+}
@@ -3,37 +3,191 @@ package models
 import (
 	"time"
 
+	"ecommerce-backend/pkg/money"
+
 	"github.com/google/uuid"
 )
 
+// ProductStatus is where a product is in its lifecycle. Only active products
+// are listed on the storefront and can be bought.
+type ProductStatus string
+
+const (
+	ProductDraft    ProductStatus = "draft"
+	ProductActive   ProductStatus = "active"
+	ProductArchived ProductStatus = "archived"
+)
+
 type Product struct {
-	ID          uuid.UUID `json:"id"`
-	SKU         string    `json:"sku"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int       `json:"stock"`
-	Category    string    `json:"category"`
-	ImageURL    string    `json:"image_url"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID        `json:"id"`
+	SKU         string           `json:"sku"`
+	Status      ProductStatus    `json:"status"`
+	Barcode     *string          `json:"barcode,omitempty"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Price       money.Money      `json:"price"`
+	Stock       int              `json:"stock"`
+	Category    string           `json:"category"`
+	ImageURL    string           `json:"image_url"`
+	Variants    []ProductVariant `json:"variants,omitempty"`
+	Sale        *SaleInfo        `json:"sale,omitempty"`
+
+	// ImageVariants are thumbnail URLs keyed by size name; only set on
+	// storefront responses for images the CDN serves
+	ImageVariants map[string]string `json:"image_variants,omitempty"`
+
+	// Purchase limits; nil means unlimited
+	MaxPerOrder    *int `json:"max_per_order,omitempty"`
+	MaxPerCustomer *int `json:"max_per_customer,omitempty"`
+
+	// CostPrice is only read for admin listings; nil is unknown
+	CostPrice *money.Money `json:"cost_price,omitempty"`
+
+	// Shipping attributes, used for shipping quotes and parcel labels; nil
+	// is not set
+	WeightGrams *int        `json:"weight_grams,omitempty"`
+	Dimensions  *Dimensions `json:"dimensions,omitempty"`
+
+	// Related is only set on storefront product detail
+	Related *ProductRelations `json:"related,omitempty"`
+
+	// Bundle lists what a bundle contains; only set on storefront product
+	// detail. A bundle's stock is how many complete bundles its components
+	// make up.
+	Bundle []BundleComponent `json:"bundle,omitempty"`
+
+	// Scheduled status changes, cleared once applied
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type ProductRequest struct {
-	SKU         string  `json:"sku" validate:"required"`
-	Name        string  `json:"name" validate:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,min=0"`
-	Stock       int     `json:"stock" validate:"min=0"`
-	Category    string  `json:"category"`
-	ImageURL    string  `json:"image_url"`
+	SKU         string      `json:"sku" validate:"required"`
+	Barcode     string      `json:"barcode" validate:"omitempty,barcode"`
+	Name        string      `json:"name" validate:"required"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" validate:"required,min=0"`
+	Stock       int         `json:"stock" validate:"min=0"`
+	Category    string      `json:"category"`
+	ImageURL    string      `json:"image_url"`
+
+	// Status defaults to active, or to draft when PublishAt is set
+	Status ProductStatus `json:"status" validate:"omitempty,oneof=draft active archived"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	CostPrice *money.Money `json:"cost_price" validate:"omitempty,min=0"`
+
+	MaxPerOrder    *int `json:"max_per_order" validate:"omitempty,min=1"`
+	MaxPerCustomer *int `json:"max_per_customer" validate:"omitempty,min=1"`
+
+	// Weight in grams; dimensions need all three of length, width and height
+	WeightGrams *int        `json:"weight_grams" validate:"omitempty,min=1,max=1000000"`
+	Dimensions  *Dimensions `json:"dimensions"`
 }
 
 type ProductUpdateRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"omitempty,min=0"`
-	Stock       int     `json:"stock" validate:"omitempty,min=0"`
-	Category    string  `json:"category"`
-	ImageURL    string  `json:"image_url"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" validate:"omitempty,min=0"`
+	Category    string      `json:"category"`
+	ImageURL    string      `json:"image_url"`
+
+	// A new stock total; omitted leaves stock unchanged
+	Stock *int `json:"stock,omitempty" validate:"omitempty,min=0"`
+
+	// Purchase limits; 0 removes the limit, omitted leaves it unchanged
+	MaxPerOrder    *int `json:"max_per_order" validate:"omitempty,min=0"`
+	MaxPerCustomer *int `json:"max_per_customer" validate:"omitempty,min=0"`
+
+	// Empty removes the barcode, omitted leaves it unchanged
+	Barcode *string `json:"barcode" validate:"omitempty,barcode"`
+
+	// Omitted leaves the cost price unchanged
+	CostPrice *money.Money `json:"cost_price" validate:"omitempty,min=0"`
+
+	// Omitted leaves the status unchanged
+	Status ProductStatus `json:"status" validate:"omitempty,oneof=draft active archived"`
+
+	// Shipping attributes; 0, or all three dimensions 0, clears them and
+	// omitted leaves them unchanged
+	WeightGrams *int        `json:"weight_grams" validate:"omitempty,min=0,max=1000000"`
+	Dimensions  *Dimensions `json:"dimensions"`
+}
+
+// ProductScheduleRequest replaces a product's schedule; an omitted time
+// cancels that change. PublishAt makes a draft active and UnpublishAt takes
+// an active product back to draft.
+type ProductScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// DuplicateProductRequest names the copy of a product. SKU defaults to the
+// original's with -COPY appended.
+type DuplicateProductRequest struct {
+	SKU  string `json:"sku"`
+	Name string `json:"name"`
+}
+
+// Product list sort options
+const (
+	ProductSortNewest     = "newest"
+	ProductSortPriceAsc   = "price_asc"
+	ProductSortPriceDesc  = "price_desc"
+	ProductSortPopularity = "popularity"
+
+	// ProductSortRelevance orders search engine matches by relevance; it is
+	// the default sort for searches when the engine is enabled
+	ProductSortRelevance = "relevance"
+)
+
+type ProductFilter struct {
+	Categories  []string
+	Search      string
+	MinPrice    *money.Money
+	MaxPrice    *money.Money
+	InStockOnly bool
+	Sort        string
+
+	// MatchIDs are the products the search engine matched Search to, most
+	// relevant first. When set, they replace matching Search in the database.
+	MatchIDs []uuid.UUID
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type PriceBucket struct {
+	Min   money.Money `json:"min"`
+	Max   money.Money `json:"max"`
+	Count int         `json:"count"`
+}
+
+type AvailabilityFacet struct {
+	InStock    int `json:"in_stock"`
+	OutOfStock int `json:"out_of_stock"`
+}
+
+// ProductFacets aggregates the current result set for filter sidebars. Each
+// facet ignores its own filter so the other options stay selectable.
+type ProductFacets struct {
+	Total        int               `json:"total"`
+	Categories   []FacetCount      `json:"categories"`
+	PriceBuckets []PriceBucket     `json:"price_buckets"`
+	Availability AvailabilityFacet `json:"availability"`
+}
+
+// BarcodeMatch is what a scanned barcode resolves to: a product, or one of its
+// variants along with the product
+type BarcodeMatch struct {
+	Barcode string          `json:"barcode"`
+	Product *Product        `json:"product"`
+	Variant *ProductVariant `json:"variant,omitempty"`
 }
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobPending    ImportJobStatus = "pending"
+	ImportJobProcessing ImportJobStatus = "processing"
+	ImportJobCompleted  ImportJobStatus = "completed"
+	ImportJobFailed     ImportJobStatus = "failed"
+)
+
+// ImportRowError describes why a single source row was rejected
+type ImportRowError struct {
+	Row         int    `json:"row"`
+	SKU         string `json:"sku,omitempty"`
+	OrderNumber string `json:"order_number,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Message     string `json:"message"`
+}
+
+type ProductImportJob struct {
+	ID           uuid.UUID        `json:"id"`
+	Filename     string           `json:"filename"`
+	Status       ImportJobStatus  `json:"status"`
+	TotalRows    int              `json:"total_rows"`
+	CreatedCount int              `json:"created_count"`
+	UpdatedCount int              `json:"updated_count"`
+	FailedCount  int              `json:"failed_count"`
+	RowErrors    []ImportRowError `json:"row_errors"`
+	ErrorMessage *string          `json:"error_message,omitempty"`
+	CreatedBy    *uuid.UUID       `json:"created_by,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	CompletedAt  *time.Time       `json:"completed_at,omitempty"`
+}
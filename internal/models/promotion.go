@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type PromotionType string
+
+const (
+	PromotionSpendThreshold PromotionType = "spend_threshold"
+	PromotionCategory       PromotionType = "category"
+	PromotionBuyXGetY       PromotionType = "buy_x_get_y"
+)
+
+// Promotion is a discount applied automatically to carts that qualify. The
+// discount is either PercentOff or AmountOff; which other fields apply
+// depends on Type.
+type Promotion struct {
+	ID          uuid.UUID     `json:"id"`
+	Name        string        `json:"name"`
+	Type        PromotionType `json:"type"`
+	MinSubtotal *money.Money  `json:"min_subtotal,omitempty"`
+	Category    *string       `json:"category,omitempty"`
+	ProductID   *uuid.UUID    `json:"product_id,omitempty"`
+	BuyQuantity *int          `json:"buy_quantity,omitempty"`
+	GetQuantity *int          `json:"get_quantity,omitempty"`
+	PercentOff  *int          `json:"percent_off,omitempty"`
+	AmountOff   *money.Money  `json:"amount_off,omitempty"`
+	Priority    int           `json:"priority"`
+	Stackable   bool          `json:"stackable"`
+	IsActive    bool          `json:"is_active"`
+	StartsAt    *time.Time    `json:"starts_at,omitempty"`
+	EndsAt      *time.Time    `json:"ends_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// PromotionRequest creates a promotion or replaces every field of one.
+// Stackable and IsActive default to true.
+type PromotionRequest struct {
+	Name        string        `json:"name" validate:"required,max=100"`
+	Type        PromotionType `json:"type" validate:"required,oneof=spend_threshold category buy_x_get_y"`
+	MinSubtotal *money.Money  `json:"min_subtotal" validate:"omitempty,min=0"`
+	Category    *string       `json:"category" validate:"omitempty,max=100"`
+	ProductID   *uuid.UUID    `json:"product_id"`
+	BuyQuantity *int          `json:"buy_quantity" validate:"omitempty,min=1"`
+	GetQuantity *int          `json:"get_quantity" validate:"omitempty,min=1"`
+	PercentOff  *int          `json:"percent_off" validate:"omitempty,min=1,max=100"`
+	AmountOff   *money.Money  `json:"amount_off" validate:"omitempty,gt=0"`
+	Priority    int           `json:"priority"`
+	Stackable   *bool         `json:"stackable"`
+	IsActive    *bool         `json:"is_active"`
+	StartsAt    *time.Time    `json:"starts_at"`
+	EndsAt      *time.Time    `json:"ends_at"`
+}
+
+// AppliedPromotion is the part of a cart or order discount one promotion
+// gave. PromotionID is nil once the promotion has been deleted.
+type AppliedPromotion struct {
+	PromotionID *uuid.UUID  `json:"promotion_id,omitempty"`
+	Name        string      `json:"name"`
+	Amount      money.Money `json:"amount"`
+}
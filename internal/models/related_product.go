@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RelationType is how a related product is offered alongside a product
+type RelationType string
+
+const (
+	// RelationCrossSell complements the product, e.g. a case for a phone
+	RelationCrossSell RelationType = "cross_sell"
+	// RelationUpsell is a pricier alternative to the product
+	RelationUpsell RelationType = "upsell"
+)
+
+// RelatedProduct is an admin-curated link from a product to one shown with
+// it. The related product's name, SKU and status are for the admin list.
+type RelatedProduct struct {
+	ID               uuid.UUID     `json:"id"`
+	ProductID        uuid.UUID     `json:"product_id"`
+	RelatedProductID uuid.UUID     `json:"related_product_id"`
+	Type             RelationType  `json:"type"`
+	Position         int           `json:"position"`
+	RelatedName      string        `json:"related_name"`
+	RelatedSKU       string        `json:"related_sku"`
+	RelatedStatus    ProductStatus `json:"related_status"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type RelatedProductRequest struct {
+	RelatedProductID uuid.UUID    `json:"related_product_id" validate:"required"`
+	Type             RelationType `json:"type" validate:"required,oneof=cross_sell upsell"`
+	Position         int          `json:"position" validate:"min=0"`
+}
+
+type RelatedProductUpdateRequest struct {
+	Position int `json:"position" validate:"min=0"`
+}
+
+// ProductRelations are the products shown on a product's page. Each list is
+// the curated one when it has any active products, otherwise one picked
+// automatically: products bought in the same orders for cross-sells, and
+// pricier products in the same category for upsells.
+type ProductRelations struct {
+	CrossSell []Product `json:"cross_sell"`
+	Upsell    []Product `json:"upsell"`
+}
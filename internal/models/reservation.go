@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type ReservationCleanupResult struct {
+	PurgedReservations int       `json:"purged_reservations"`
+	ReclaimedQuantity  int       `json:"reclaimed_quantity"`
+	RanAt              time.Time `json:"ran_at"`
+}
+
+type ReservationCleanupStats struct {
+	Runs                    int64                     `json:"runs"`
+	Failures                int64                     `json:"failures"`
+	TotalPurgedReservations int64                     `json:"total_purged_reservations"`
+	TotalReclaimedQuantity  int64                     `json:"total_reclaimed_quantity"`
+	LastResult              *ReservationCleanupResult `json:"last_result,omitempty"`
+	LastError               string                    `json:"last_error,omitempty"`
+}
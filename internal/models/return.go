@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"ecommerce-backend/pkg/money"
+
 	"github.com/google/uuid"
 )
 
@@ -11,6 +13,8 @@ type ReturnStatus string
 const (
 	ReturnRequested ReturnStatus = "requested"
 	ReturnApproved  ReturnStatus = "approved"
+	ReturnInTransit ReturnStatus = "in_transit"
+	ReturnReceived  ReturnStatus = "received"
 	ReturnRejected  ReturnStatus = "rejected"
 	ReturnCompleted ReturnStatus = "completed"
 )
@@ -21,17 +25,62 @@ type Return struct {
 	UserID       uuid.UUID    `json:"user_id"`
 	Reason       string       `json:"reason"`
 	Status       ReturnStatus `json:"status"`
-	RefundAmount float64      `json:"refund_amount"`
-	CreatedAt    time.Time    `json:"created_at"`
-	UpdatedAt    time.Time    `json:"updated_at"`
+	RefundAmount money.Money  `json:"refund_amount"`
+
+	RMANumber            *string    `json:"rma_number,omitempty"`
+	ReturnCarrier        *string    `json:"return_carrier,omitempty"`
+	ReturnTrackingNumber *string    `json:"return_tracking_number,omitempty"`
+	ShippedAt            *time.Time `json:"shipped_at,omitempty"`
+	ReceivedAt           *time.Time `json:"received_at,omitempty"`
+
+	// Exchanges only. The exchanged item comes back and ReplacementVariantID
+	// ships in its place on ReplacementOrderID once the return is approved.
+	ExchangeItemID       *uuid.UUID `json:"exchange_item_id,omitempty"`
+	ReplacementVariantID *uuid.UUID `json:"replacement_variant_id,omitempty"`
+	ReplacementOrderID   *uuid.UUID `json:"replacement_order_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsExchange reports whether the customer asked for a replacement instead of
+// a refund
+func (r Return) IsExchange() bool {
+	return r.ExchangeItemID != nil
 }
 
 type CreateReturnRequest struct {
 	OrderID uuid.UUID `json:"order_id" validate:"required"`
 	Reason  string    `json:"reason" validate:"required"`
+
+	// Set both to exchange one item for another variant of its product
+	// instead of returning the order for a refund
+	OrderItemID          *uuid.UUID `json:"order_item_id" validate:"required_with=ReplacementVariantID"`
+	ReplacementVariantID *uuid.UUID `json:"replacement_variant_id" validate:"required_with=OrderItemID"`
 }
 
+// Refund destinations for a completed return
+const (
+	RefundToOriginal    = "original"
+	RefundToStoreCredit = "store_credit"
+)
+
 type ProcessReturnRequest struct {
 	Status       ReturnStatus `json:"status" validate:"required"`
-	RefundAmount float64      `json:"refund_amount"`
+	RefundAmount money.Money  `json:"refund_amount"`
+	RefundMethod string       `json:"refund_method" validate:"omitempty,oneof=original store_credit"`
+}
+
+type ShipReturnRequest struct {
+	Carrier        string `json:"carrier" validate:"required"`
+	TrackingNumber string `json:"tracking_number" validate:"required"`
+}
+
+// ReturnLabel holds what the customer needs to print on the return parcel
+type ReturnLabel struct {
+	RMANumber    string  `json:"rma_number"`
+	OrderNumber  string  `json:"order_number"`
+	ShipFrom     Address `json:"ship_from"`
+	ShipTo       string  `json:"ship_to"`
+	Instructions string  `json:"instructions"`
 }
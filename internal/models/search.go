@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// ProductDocument is a product as the search engine indexes it: only the text
+// queries are matched against. Filters, sorting and facets are applied by the
+// database to the products the engine matches.
+type ProductDocument struct {
+	ID          uuid.UUID `json:"id"`
+	StoreID     uuid.UUID `json:"store_id"`
+	SKU         string    `json:"sku"`
+	VariantSKUs []string  `json:"variant_skus"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Category    string    `json:"category"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// CustomerSegment is an admin-defined group of a store's customers. A
+// customer belongs to it when every filter set matches; unset filters match
+// everyone. Members are recomputed on refresh, so MemberCount and the member
+// list are as of RefreshedAt.
+type CustomerSegment struct {
+	ID           uuid.UUID    `json:"id"`
+	Name         string       `json:"name"`
+	MinSpend     *money.Money `json:"min_spend,omitempty"`
+	InactiveDays *int         `json:"inactive_days,omitempty"`
+	Category     *string      `json:"category,omitempty"`
+	MemberCount  int          `json:"member_count"`
+	RefreshedAt  *time.Time   `json:"refreshed_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// CustomerSegmentRequest creates a segment or replaces every field of one
+type CustomerSegmentRequest struct {
+	Name         string       `json:"name" validate:"required,max=100"`
+	MinSpend     *money.Money `json:"min_spend" validate:"omitempty,min=0"`
+	InactiveDays *int         `json:"inactive_days" validate:"omitempty,min=1"`
+	Category     *string      `json:"category" validate:"omitempty,max=100"`
+}
+
+// SegmentMember is a customer in a segment with the order stats they
+// qualified on at the last refresh
+type SegmentMember struct {
+	UserID      uuid.UUID   `json:"user_id"`
+	Email       string      `json:"email"`
+	FirstName   string      `json:"first_name"`
+	LastName    string      `json:"last_name"`
+	TotalSpent  money.Money `json:"total_spent"`
+	OrderCount  int         `json:"order_count"`
+	LastOrderAt *time.Time  `json:"last_order_at,omitempty"`
+}
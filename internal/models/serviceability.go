@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceableArea is a postal code prefix orders can ship to
+type ServiceableArea struct {
+	ID         uuid.UUID `json:"id"`
+	PostalCode string    `json:"postal_code"`
+	Name       string    `json:"name"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CreateServiceableAreaRequest struct {
+	PostalCode string `json:"postal_code" validate:"required,max=10"`
+	Name       string `json:"name" validate:"max=100"`
+}
+
+type UpdateServiceableAreaRequest struct {
+	Name     *string `json:"name" validate:"omitempty,max=100"`
+	IsActive *bool   `json:"is_active"`
+}
+
+// Serviceability is whether orders can ship to a postal code. Area is the
+// matching area, absent when no areas are configured and everywhere is
+// served.
+type Serviceability struct {
+	PostalCode  string           `json:"postal_code"`
+	Serviceable bool             `json:"serviceable"`
+	Area        *ServiceableArea `json:"area,omitempty"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Runtime settings. Each takes its default from the environment until an
+// admin overrides it.
+const (
+	SettingStockReservationTTL = "stock_reservation_ttl_minutes"
+	SettingRateLimit           = "rate_limit_per_minute"
+	SettingAuthRateLimit       = "auth_rate_limit_per_minute"
+	SettingCODMaxOrderValue    = "cod_max_order_value"
+	SettingShippingFee         = "shipping_fee"
+	SettingShippingRatePerKg   = "shipping_rate_per_kg"
+	SettingFreeShippingMinimum = "free_shipping_minimum"
+	SettingMinimumOrderValue   = "minimum_order_value"
+	SettingMaintenanceMode     = "maintenance_mode"
+	SettingMaintenanceMessage  = "maintenance_message"
+)
+
+type SettingType string
+
+const (
+	SettingTypeInteger SettingType = "integer"
+	SettingTypeMinutes SettingType = "minutes"
+	SettingTypeAmount  SettingType = "amount"
+	SettingTypeBoolean SettingType = "boolean"
+	SettingTypeText    SettingType = "text"
+)
+
+// Setting is a runtime setting with the value in effect. Overridden is false
+// while it still has its default; UpdatedAt and UpdatedBy describe the last
+// override.
+type Setting struct {
+	Key          string      `json:"key"`
+	Type         SettingType `json:"type"`
+	Description  string      `json:"description"`
+	Value        string      `json:"value"`
+	DefaultValue string      `json:"default_value"`
+	Overridden   bool        `json:"overridden"`
+	UpdatedBy    *uuid.UUID  `json:"updated_by,omitempty"`
+	UpdatedAt    *time.Time  `json:"updated_at,omitempty"`
+}
+
+// SettingOverride is a stored setting value
+type SettingOverride struct {
+	Key       string
+	Value     string
+	UpdatedBy *uuid.UUID
+	UpdatedAt time.Time
+}
+
+type UpdateSettingRequest struct {
+	Value string `json:"value" validate:"required,max=100"`
+}
@@ -0,0 +1,47 @@
+package models
+
+// Dimensions is the packed size of a product in millimetres. A request with
+// all three 0 clears them.
+type Dimensions struct {
+	LengthMM int `json:"length_mm" validate:"min=0,max=5000"`
+	WidthMM  int `json:"width_mm" validate:"min=0,max=5000"`
+	HeightMM int `json:"height_mm" validate:"min=0,max=5000"`
+}
+
+// IsZero reports whether no dimension is set
+func (d Dimensions) IsZero() bool {
+	return d.LengthMM == 0 && d.WidthMM == 0 && d.HeightMM == 0
+}
+
+// IsComplete reports whether every dimension is set
+func (d Dimensions) IsComplete() bool {
+	return d.LengthMM > 0 && d.WidthMM > 0 && d.HeightMM > 0
+}
+
+// CubicWeight is the weight in grams carriers bill a parcel of this size as.
+// divisor is the volumetric divisor in cubic centimetres per kilogram,
+// commonly 5000, which makes it the volume in cubic millimetres over the
+// divisor.
+func (d Dimensions) CubicWeight(divisor int) int {
+	if divisor <= 0 {
+		return 0
+	}
+	volume := int64(d.LengthMM) * int64(d.WidthMM) * int64(d.HeightMM)
+	return int((volume + int64(divisor) - 1) / int64(divisor))
+}
+
+// ChargeableWeight is the weight in grams a unit of the product ships as:
+// its actual weight or, for bulky products, its cubic weight, whichever is
+// more. It is 0 for products with neither set.
+func (p Product) ChargeableWeight(divisor int) int {
+	weight := 0
+	if p.WeightGrams != nil {
+		weight = *p.WeightGrams
+	}
+	if p.Dimensions != nil {
+		if cubic := p.Dimensions.CubicWeight(divisor); cubic > weight {
+			weight = cubic
+		}
+	}
+	return weight
+}
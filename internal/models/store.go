@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultStoreID is the store requests fall back to when neither the host nor
+// the path names one, and the store of rows created outside a request
+var DefaultStoreID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Store is one storefront served by the deployment. Host, when set, selects
+// the store for requests to that host name.
+type Store struct {
+	ID        uuid.UUID `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	Host      *string   `json:"host,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// OrderNumberPrefix starts the store's order numbers, e.g. ORD-100042
+	OrderNumberPrefix string `json:"order_number_prefix"`
+}
+
+// DefaultOrderNumberPrefix is the order number prefix of stores created
+// without one
+const DefaultOrderNumberPrefix = "ORD"
+
+type CreateStoreRequest struct {
+	Slug              string  `json:"slug" validate:"required,max=50"`
+	Name              string  `json:"name" validate:"required,max=255"`
+	Host              *string `json:"host" validate:"omitempty,max=255"`
+	OrderNumberPrefix string  `json:"order_number_prefix" validate:"omitempty,max=10,alphanum"`
+}
+
+type UpdateStoreRequest struct {
+	Name              *string `json:"name" validate:"omitempty,max=255"`
+	Host              *string `json:"host" validate:"omitempty,max=255"`
+	IsActive          *bool   `json:"is_active"`
+	OrderNumberPrefix *string `json:"order_number_prefix" validate:"omitempty,min=1,max=10,alphanum"`
+}
@@ -8,21 +8,61 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	FirstName    string    `json:"first_name"`
-	LastName     string    `json:"last_name"`
-	Role         string    `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	StoreID         uuid.UUID  `json:"store_id"`
+	Email           string     `json:"email"`
+	PasswordHash    string     `json:"-"`
+	FirstName       string     `json:"first_name"`
+	LastName        string     `json:"last_name"`
+	Role            string     `json:"role"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	Phone           *string    `json:"phone,omitempty"`
+	PhoneVerified   bool       `json:"phone_verified"`
+	IsActive        bool       `json:"is_active"`
+	DeactivatedAt   *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Tags are internal to staff and only loaded for the admin user list
+	Tags []string `json:"tags,omitempty"`
+
+	// ImpersonatorID is the admin acting as this user; only set on users
+	// read from an impersonation token
+	ImpersonatorID *uuid.UUID `json:"-"`
+}
+
+// DeletedUserID is the placeholder account that keeps the orders and returns
+// of deleted users
+var DeletedUserID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// UserFilter narrows the admin user list. Search matches email and name;
+// Tag matches one of the user's tags exactly.
+type UserFilter struct {
+	Search    string
+	Role      string
+	Active    *bool
+	RangeDays int
+	Tag       string
 }
 
 type RegisterRequest struct {
-	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required,min=6"`
-	FirstName string `json:"first_name" validate:"required"`
-	LastName  string `json:"last_name" validate:"required"`
+	Email     string `json:"email" validate:"required,email,max=255"`
+	Password  string `json:"password" validate:"required,password,max=72"`
+	FirstName string `json:"first_name" validate:"required,max=100"`
+	LastName  string `json:"last_name" validate:"required,max=100"`
+}
+
+// UpdateProfileRequest changes only the fields that are set
+type UpdateProfileRequest struct {
+	FirstName string `json:"first_name" validate:"omitempty,max=100"`
+	LastName  string `json:"last_name" validate:"omitempty,max=100"`
+	Email     string `json:"email" validate:"omitempty,email,max=255"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,password,max=72"`
 }
 
 type LoginRequest struct {
@@ -30,11 +70,48 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// OAuthLoginRequest carries the ID token the client got by signing the
+// customer in with the provider. Apple only shares the customer's name with
+// the client, on first sign-in, so the client passes it along.
+type OAuthLoginRequest struct {
+	IDToken   string `json:"id_token" validate:"required"`
+	FirstName string `json:"first_name" validate:"omitempty,max=100"`
+	LastName  string `json:"last_name" validate:"omitempty,max=100"`
+}
+
+// UserIdentity links a user to an account at a sign-in provider
+type UserIdentity struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Provider    string    `json:"provider"`
+	Subject     string    `json:"-"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
 type LoginResponse struct {
 	User        *User  `json:"user"`
 	AccessToken string `json:"access_token"`
 }
 
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type EmailVerificationToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
 // HashPassword hashes a plain text password
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type ProductVariant struct {
+	ID         uuid.UUID         `json:"id"`
+	ProductID  uuid.UUID         `json:"product_id"`
+	SKU        string            `json:"sku"`
+	Barcode    *string           `json:"barcode,omitempty"`
+	Price      money.Money       `json:"price"`
+	Stock      int               `json:"stock"`
+	Attributes map[string]string `json:"attributes"`
+	Sale       *SaleInfo         `json:"sale,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+type ProductVariantRequest struct {
+	SKU        string            `json:"sku" validate:"required"`
+	Barcode    string            `json:"barcode" validate:"omitempty,barcode"`
+	Price      money.Money       `json:"price" validate:"required,min=0"`
+	Stock      int               `json:"stock" validate:"min=0"`
+	Attributes map[string]string `json:"attributes" validate:"required,min=1"`
+}
+
+type ProductVariantUpdateRequest struct {
+	Price      money.Money       `json:"price" validate:"omitempty,min=0"`
+	Stock      *int              `json:"stock" validate:"omitempty,min=0"`
+	Attributes map[string]string `json:"attributes"`
+
+	// Empty removes the barcode, omitted leaves it unchanged
+	Barcode *string `json:"barcode" validate:"omitempty,barcode"`
+}
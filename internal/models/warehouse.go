@@ -0,0 +1,146 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Warehouse struct {
+	ID         uuid.UUID `json:"id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Zones      []string  `json:"zones"`
+	Priority   int       `json:"priority"`
+	CutoffTime string    `json:"cutoff_time"`
+	IsActive   bool      `json:"is_active"`
+	IsDefault  bool      `json:"is_default"`
+	SKUCount   int       `json:"sku_count"`
+	TotalUnits int       `json:"total_units"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CreateWarehouseRequest struct {
+	Code      string   `json:"code" validate:"required,max=20"`
+	Name      string   `json:"name" validate:"required,max=100"`
+	Zones     []string `json:"zones" validate:"omitempty,dive,required,max=10"`
+	Priority  int      `json:"priority" validate:"min=0"`
+	IsDefault bool     `json:"is_default"`
+
+	// Daily dispatch cut-off as HH:MM UTC; defaults to 15:00
+	CutoffTime string `json:"cutoff_time" validate:"omitempty,datetime=15:04"`
+}
+
+type UpdateWarehouseRequest struct {
+	Name       string    `json:"name" validate:"omitempty,max=100"`
+	Zones      *[]string `json:"zones" validate:"omitempty,dive,required,max=10"`
+	Priority   *int      `json:"priority" validate:"omitempty,min=0"`
+	CutoffTime string    `json:"cutoff_time" validate:"omitempty,datetime=15:04"`
+	IsActive   *bool     `json:"is_active"`
+	IsDefault  *bool     `json:"is_default"`
+}
+
+// WarehouseStock is the stock of one product or variant at one warehouse
+type WarehouseStock struct {
+	WarehouseID   uuid.UUID  `json:"warehouse_id"`
+	WarehouseCode string     `json:"warehouse_code"`
+	ProductID     uuid.UUID  `json:"product_id"`
+	ProductName   string     `json:"product_name"`
+	ProductSKU    string     `json:"product_sku"`
+	VariantID     *uuid.UUID `json:"variant_id,omitempty"`
+	VariantSKU    *string    `json:"variant_sku,omitempty"`
+	Quantity      int        `json:"quantity"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type SetWarehouseStockRequest struct {
+	ProductID uuid.UUID  `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID `json:"variant_id"`
+	Quantity  int        `json:"quantity" validate:"min=0"`
+}
+
+// StockLevel is the network-wide stock of a product or one of its variants
+// with its per-warehouse breakdown
+type StockLevel struct {
+	VariantID  *uuid.UUID       `json:"variant_id,omitempty"`
+	VariantSKU *string          `json:"variant_sku,omitempty"`
+	Total      int              `json:"total"`
+	Available  int              `json:"available"`
+	Locations  []WarehouseStock `json:"locations"`
+}
+
+type ProductStock struct {
+	ProductID  uuid.UUID    `json:"product_id"`
+	ProductSKU string       `json:"product_sku"`
+	Total      int          `json:"total"`
+	Levels     []StockLevel `json:"levels"`
+}
+
+// OrderItemAllocation records how many units of an order item were taken
+// from a warehouse
+type OrderItemAllocation struct {
+	ID          uuid.UUID `json:"id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    int       `json:"quantity"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// The component taken, for items sold as bundles
+	ComponentProductID *uuid.UUID `json:"component_product_id,omitempty"`
+	ComponentVariantID *uuid.UUID `json:"component_variant_id,omitempty"`
+}
+
+type StockTransfer struct {
+	ID                uuid.UUID  `json:"id"`
+	FromWarehouseID   uuid.UUID  `json:"from_warehouse_id"`
+	FromWarehouseCode string     `json:"from_warehouse_code,omitempty"`
+	ToWarehouseID     uuid.UUID  `json:"to_warehouse_id"`
+	ToWarehouseCode   string     `json:"to_warehouse_code,omitempty"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	VariantID         *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity          int        `json:"quantity"`
+	Note              string     `json:"note,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+type CreateStockTransferRequest struct {
+	FromWarehouseID uuid.UUID  `json:"from_warehouse_id" validate:"required"`
+	ToWarehouseID   uuid.UUID  `json:"to_warehouse_id" validate:"required"`
+	ProductID       uuid.UUID  `json:"product_id" validate:"required"`
+	VariantID       *uuid.UUID `json:"variant_id"`
+	Quantity        int        `json:"quantity" validate:"required,min=1"`
+	Note            string     `json:"note" validate:"max=255"`
+}
+
+// ScanStockRequest is a batch of handheld scanner reads. Each item adds its
+// quantity to (receiving) or, when negative, removes it from (picking,
+// write-offs) the warehouse's stock; the warehouse defaults to the default
+// one.
+type ScanStockRequest struct {
+	WarehouseID *uuid.UUID      `json:"warehouse_id"`
+	Items       []ScanStockItem `json:"items" validate:"required,min=1,max=200,dive"`
+}
+
+type ScanStockItem struct {
+	Barcode  string `json:"barcode" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,min=-10000,max=10000"`
+}
+
+// ScanStockResult is the stock of one scanned item after the scan
+type ScanStockResult struct {
+	Barcode           string     `json:"barcode"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	ProductName       string     `json:"product_name"`
+	SKU               string     `json:"sku"`
+	VariantID         *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity          int        `json:"quantity"`
+	WarehouseQuantity int        `json:"warehouse_quantity"`
+	TotalStock        int        `json:"total_stock"`
+}
+
+type ScanStockResponse struct {
+	WarehouseID   uuid.UUID         `json:"warehouse_id"`
+	WarehouseCode string            `json:"warehouse_code"`
+	Items         []ScanStockResult `json:"items"`
+}
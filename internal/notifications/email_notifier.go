@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// EmailNotifier turns order lifecycle events into customer emails
+type EmailNotifier struct {
+	mailer     Mailer
+	orderRepo  repository.OrderRepository
+	userRepo   repository.UserRepository
+	appBaseURL string
+}
+
+func NewEmailNotifier(mailer Mailer, orderRepo repository.OrderRepository, userRepo repository.UserRepository, appBaseURL string) *EmailNotifier {
+	return &EmailNotifier{
+		mailer:     mailer,
+		orderRepo:  orderRepo,
+		userRepo:   userRepo,
+		appBaseURL: strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// Register subscribes the notifier to the events it sends mail for
+func (n *EmailNotifier) Register(bus *events.Bus) {
+	bus.Subscribe(events.OrderCreated, n.handleOrderCreated)
+	bus.Subscribe(events.OrderStatusChanged, n.handleOrderStatusChanged)
+	bus.Subscribe(events.PaymentRefunded, n.handlePaymentRefunded)
+	bus.Subscribe(events.CartAbandoned, n.handleCartAbandoned)
+}
+
+func (n *EmailNotifier) handleOrderCreated(ctx context.Context, event models.OutboxEvent) error {
+	var payload events.OrderCreatedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+	}
+
+	return n.sendOrderEmail(ctx, TemplateOrderConfirmation, payload.OrderID, 0)
+}
+
+func (n *EmailNotifier) handleOrderStatusChanged(ctx context.Context, event models.OutboxEvent) error {
+	var payload events.OrderStatusChangedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+	}
+
+	var template string
+	switch payload.ToStatus {
+	case models.OrderShipped:
+		template = TemplateOrderShipped
+	case models.OrderDelivered:
+		template = TemplateOrderDelivered
+	case models.OrderCancelled:
+		template = TemplateOrderCancelled
+	default:
+		return nil
+	}
+
+	return n.sendOrderEmail(ctx, template, payload.OrderID, 0)
+}
+
+func (n *EmailNotifier) handlePaymentRefunded(ctx context.Context, event models.OutboxEvent) error {
+	var payload events.PaymentRefundedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+	}
+
+	return n.sendOrderEmail(ctx, TemplateRefundIssued, payload.OrderID, payload.RefundAmount)
+}
+
+func (n *EmailNotifier) handleCartAbandoned(ctx context.Context, event models.OutboxEvent) error {
+	var payload events.CartAbandonedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+	}
+
+	return n.sendUserEmail(ctx, TemplateCartReminder, payload.UserID, EmailData{
+		ItemCount: payload.ItemCount,
+		CartValue: payload.CartValue,
+		CartURL:   n.appBaseURL + "/cart",
+	})
+}
+
+// sendOrderEmail renders template for the order's owner and sends it
+func (n *EmailNotifier) sendOrderEmail(ctx context.Context, template string, orderID uuid.UUID, refundAmount money.Money) error {
+	order, err := n.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		log.Printf("⚠️ Skipping %s email: order %s not found", template, orderID)
+		return nil
+	}
+
+	return n.sendUserEmail(ctx, template, order.UserID, EmailData{
+		Order:        order,
+		RefundAmount: refundAmount,
+		OrderURL:     fmt.Sprintf("%s/orders/%s", n.appBaseURL, order.ID),
+	})
+}
+
+// sendUserEmail fills in the recipient's details, renders template and sends it
+func (n *EmailNotifier) sendUserEmail(ctx context.Context, template string, userID uuid.UUID, data EmailData) error {
+	user, err := n.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		log.Printf("⚠️ Skipping %s email: user %s not found", template, userID)
+		return nil
+	}
+
+	data.CustomerName = user.FirstName
+	msg, err := RenderEmail(template, data)
+	if err != nil {
+		return err
+	}
+	msg.To = user.Email
+	msg.ToName = strings.TrimSpace(user.FirstName + " " + user.LastName)
+
+	if err := n.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send %s email via %s: %w", template, n.mailer.Name(), err)
+	}
+
+	return nil
+}
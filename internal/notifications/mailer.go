@@ -0,0 +1,45 @@
+package notifications
+
+import (
+	"context"
+	"log"
+)
+
+// Message is a rendered email ready for delivery
+type Message struct {
+	To       string
+	ToName   string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Mailer delivers email through a provider such as SMTP or SendGrid
+type Mailer interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+
+	// Ping checks the provider can be reached without sending anything
+	Ping(ctx context.Context) error
+}
+
+type logMailer struct{}
+
+// NewLogMailer returns a mailer that writes messages to the log instead of
+// sending them. It is the default for local development.
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Name() string {
+	return "log"
+}
+
+func (m *logMailer) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("📧 Email to %s: %s\n%s", msg.To, msg.Subject, msg.TextBody)
+	return nil
+}
@@ -0,0 +1,120 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	sendGridEndpoint       = "https://api.sendgrid.com/v3/mail/send"
+	sendGridScopesEndpoint = "https://api.sendgrid.com/v3/scopes"
+)
+
+type sendGridMailer struct {
+	apiKey   string
+	from     string
+	fromName string
+	client   *http.Client
+}
+
+// NewSendGridMailer sends mail through the SendGrid v3 API
+func NewSendGridMailer(apiKey, from, fromName string) Mailer {
+	return &sendGridMailer{
+		apiKey:   apiKey,
+		from:     from,
+		fromName: fromName,
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (m *sendGridMailer) Name() string {
+	return "sendgrid"
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+// Ping lists the API key's scopes, which fails when the key is not accepted
+func (m *sendGridMailer) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sendGridScopesEndpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sendgrid request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sendgrid returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (m *sendGridMailer) Send(ctx context.Context, msg Message) error {
+	payload := sendGridRequest{
+		Personalizations: []sendGridPersonalization{
+			{To: []sendGridAddress{{Email: msg.To, Name: msg.ToName}}},
+		},
+		From:    sendGridAddress{Email: m.from, Name: m.fromName},
+		Subject: msg.Subject,
+	}
+	// SendGrid requires text/plain to precede text/html
+	if msg.TextBody != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/plain", Value: msg.TextBody})
+	}
+	if msg.HTMLBody != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/html", Value: msg.HTMLBody})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sendgrid request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sendgrid returned status %d: %s", resp.StatusCode, detail)
+	}
+
+	return nil
+}
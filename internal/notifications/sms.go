@@ -0,0 +1,30 @@
+package notifications
+
+import (
+	"context"
+	"log"
+)
+
+// SMSSender delivers text messages through a provider such as Twilio.
+// Numbers are in E.164 form, e.g. +919876543210.
+type SMSSender interface {
+	Name() string
+	Send(ctx context.Context, to, body string) error
+}
+
+type logSMSSender struct{}
+
+// NewLogSMSSender returns a sender that writes messages to the log instead
+// of sending them. It is the default for local development.
+func NewLogSMSSender() SMSSender {
+	return &logSMSSender{}
+}
+
+func (s *logSMSSender) Name() string {
+	return "log"
+}
+
+func (s *logSMSSender) Send(ctx context.Context, to, body string) error {
+	log.Printf("📱 SMS to %s: %s", to, body)
+	return nil
+}
@@ -0,0 +1,190 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/money"
+)
+
+// Email template names
+const (
+	TemplateOrderConfirmation = "order_confirmation"
+	TemplateOrderShipped      = "order_shipped"
+	TemplateOrderDelivered    = "order_delivered"
+	TemplateOrderCancelled    = "order_cancelled"
+	TemplateRefundIssued      = "refund_issued"
+	TemplateCartReminder      = "cart_reminder"
+	TemplateVerifyEmail       = "verify_email"
+)
+
+// EmailData is the view model every template renders against
+type EmailData struct {
+	CustomerName string
+	Order        *models.Order
+	RefundAmount money.Money
+	OrderURL     string
+
+	// Cart reminder fields
+	ItemCount int
+	CartValue money.Money
+	CartURL   string
+
+	// Email verification fields
+	VerifyURL string
+}
+
+type emailTemplate struct {
+	subject string
+	html    string
+	text    string
+}
+
+// Subjects and bodies are kept in code so they ship with the binary
+var emailTemplates = map[string]emailTemplate{
+	TemplateOrderConfirmation: {
+		subject: "Order {{.Order.OrderNumber}} confirmed",
+		html: `<p>Hi {{.CustomerName}},</p>
+<p>Thanks for your order! We've received order <strong>{{.Order.OrderNumber}}</strong> and will let you know when it ships.</p>
+<table cellpadding="4">
+{{range .Order.Items}}<tr><td>{{.Product.Name}}</td><td>× {{.Quantity}}</td><td>{{money .PriceAtTime}}</td></tr>
+{{end}}<tr><td colspan="2"><strong>Total</strong></td><td><strong>{{money .Order.TotalAmount}}</strong></td></tr>
+</table>
+<p>Shipping to: {{.Order.ShippingAddress.FullName}}, {{.Order.ShippingAddress.Street}}, {{.Order.ShippingAddress.City}} {{.Order.ShippingAddress.PostalCode}}</p>
+<p><a href="{{.OrderURL}}">View your order</a></p>`,
+		text: `Hi {{.CustomerName}},
+
+Thanks for your order! We've received order {{.Order.OrderNumber}} and will let you know when it ships.
+{{range .Order.Items}}
+- {{.Product.Name}} x {{.Quantity}} @ {{money .PriceAtTime}}{{end}}
+
+Total: {{money .Order.TotalAmount}}
+
+View your order: {{.OrderURL}}`,
+	},
+	TemplateOrderShipped: {
+		subject: "Order {{.Order.OrderNumber}} has shipped",
+		html: `<p>Hi {{.CustomerName}},</p>
+<p>Good news — order <strong>{{.Order.OrderNumber}}</strong> is on its way to {{.Order.ShippingAddress.City}}.</p>
+<p><a href="{{.OrderURL}}">Track your order</a></p>`,
+		text: `Hi {{.CustomerName}},
+
+Good news - order {{.Order.OrderNumber}} is on its way to {{.Order.ShippingAddress.City}}.
+
+Track your order: {{.OrderURL}}`,
+	},
+	TemplateOrderDelivered: {
+		subject: "Order {{.Order.OrderNumber}} was delivered",
+		html: `<p>Hi {{.CustomerName}},</p>
+<p>Order <strong>{{.Order.OrderNumber}}</strong> has been delivered. We hope you enjoy it!</p>
+<p>Something not right? You can request a return from <a href="{{.OrderURL}}">your order page</a>.</p>`,
+		text: `Hi {{.CustomerName}},
+
+Order {{.Order.OrderNumber}} has been delivered. We hope you enjoy it!
+
+Something not right? You can request a return from your order page: {{.OrderURL}}`,
+	},
+	TemplateOrderCancelled: {
+		subject: "Order {{.Order.OrderNumber}} was cancelled",
+		html: `<p>Hi {{.CustomerName}},</p>
+<p>Order <strong>{{.Order.OrderNumber}}</strong> has been cancelled. If you paid online, any captured amount will be refunded to your original payment method.</p>
+<p><a href="{{.OrderURL}}">View order details</a></p>`,
+		text: `Hi {{.CustomerName}},
+
+Order {{.Order.OrderNumber}} has been cancelled. If you paid online, any captured amount will be refunded to your original payment method.
+
+View order details: {{.OrderURL}}`,
+	},
+	TemplateRefundIssued: {
+		subject: "Refund issued for order {{.Order.OrderNumber}}",
+		html: `<p>Hi {{.CustomerName}},</p>
+<p>We've issued a refund of <strong>{{money .RefundAmount}}</strong> for order <strong>{{.Order.OrderNumber}}</strong>. It may take 5–7 business days to appear on your statement.</p>
+<p><a href="{{.OrderURL}}">View order details</a></p>`,
+		text: `Hi {{.CustomerName}},
+
+We've issued a refund of {{money .RefundAmount}} for order {{.Order.OrderNumber}}. It may take 5-7 business days to appear on your statement.
+
+View order details: {{.OrderURL}}`,
+	},
+	TemplateCartReminder: {
+		subject: "You left something in your cart",
+		html: `<p>Hi {{.CustomerName}},</p>
+<p>You still have {{.ItemCount}} item(s) worth <strong>{{money .CartValue}}</strong> waiting in your cart. Items aren't reserved, so check out soon before they sell out.</p>
+<p><a href="{{.CartURL}}">Return to your cart</a></p>`,
+		text: `Hi {{.CustomerName}},
+
+You still have {{.ItemCount}} item(s) worth {{money .CartValue}} waiting in your cart. Items aren't reserved, so check out soon before they sell out.
+
+Return to your cart: {{.CartURL}}`,
+	},
+	TemplateVerifyEmail: {
+		subject: "Confirm your email address",
+		html: `<p>Hi {{.CustomerName}},</p>
+<p>Please confirm your email address to finish setting up your account.</p>
+<p><a href="{{.VerifyURL}}">Confirm email address</a></p>
+<p>If you didn't create an account, you can ignore this email.</p>`,
+		text: `Hi {{.CustomerName}},
+
+Please confirm your email address to finish setting up your account.
+
+Confirm email address: {{.VerifyURL}}
+
+If you didn't create an account, you can ignore this email.`,
+	},
+}
+
+var templateFuncs = map[string]interface{}{
+	"money": func(amount money.Money) string {
+		return amount.String()
+	},
+}
+
+// RenderEmail builds the message for the named template
+func RenderEmail(name string, data EmailData) (Message, error) {
+	tmpl, ok := emailTemplates[name]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown email template: %s", name)
+	}
+
+	subject, err := renderText(name+".subject", tmpl.subject, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	text, err := renderText(name+".text", tmpl.text, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	html, err := htmltemplate.New(name + ".html").Funcs(templateFuncs).Parse(tmpl.html)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+	var htmlBody bytes.Buffer
+	if err := html.Execute(&htmlBody, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+
+	return Message{
+		Subject:  subject,
+		HTMLBody: htmlBody.String(),
+		TextBody: text,
+	}, nil
+}
+
+func renderText(name, source string, data EmailData) (string, error) {
+	tmpl, err := texttemplate.New(name).Funcs(templateFuncs).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,34 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidToken is returned for identity tokens that are malformed,
+// expired, or not issued by the provider to one of our client IDs
+var ErrInvalidToken = errors.New("invalid identity token")
+
+// Identity is the account a provider vouches for. Subject is the provider's
+// stable ID for it; the email may change and, with Apple, may be a relay
+// address.
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+
+	// When the provider issued the token, i.e. when the customer last
+	// signed in with it
+	IssuedAt time.Time
+}
+
+// Provider verifies identity tokens (OpenID Connect ID tokens) that a client
+// obtained by signing the customer in with the provider
+type Provider interface {
+	Name() string
+	Verify(ctx context.Context, idToken string) (*Identity, error)
+}
@@ -0,0 +1,194 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// providerKeysTTL is how long a provider's signing keys are cached.
+	// Providers publish new keys well before signing with them.
+	providerKeysTTL = time.Hour
+
+	// providerKeysRetry limits refetching the keys for an unknown kid, so
+	// forged tokens cannot make every request call the provider
+	providerKeysRetry = time.Minute
+)
+
+type oidcProvider struct {
+	name      string
+	issuers   []string
+	clientIDs []string
+	keysURL   string
+	client    *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewGoogleProvider verifies Google ID tokens issued to any of clientIDs,
+// the OAuth client IDs of the web and mobile apps
+func NewGoogleProvider(clientIDs []string) Provider {
+	return &oidcProvider{
+		name:      "google",
+		issuers:   []string{"https://accounts.google.com", "accounts.google.com"},
+		clientIDs: clientIDs,
+		keysURL:   "https://www.googleapis.com/oauth2/v3/certs",
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewAppleProvider verifies Sign in with Apple identity tokens issued to any
+// of clientIDs, the app's bundle ID and Services IDs
+func NewAppleProvider(clientIDs []string) Provider {
+	return &oidcProvider{
+		name:      "apple",
+		issuers:   []string{"https://appleid.apple.com"},
+		clientIDs: clientIDs,
+		keysURL:   "https://appleid.apple.com/auth/keys",
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+func (p *oidcProvider) Verify(ctx context.Context, idToken string) (*Identity, error) {
+	var keyErr error
+	token, err := jwt.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.key(ctx, kid)
+		if err != nil {
+			keyErr = err
+		}
+		return key, err
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}), jwt.WithExpirationRequired())
+	if keyErr != nil {
+		return nil, keyErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	issuer, _ := claims.GetIssuer()
+	if !slices.Contains(p.issuers, issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, issuer)
+	}
+	audience, _ := claims.GetAudience()
+	if !slices.ContainsFunc(audience, func(aud string) bool { return slices.Contains(p.clientIDs, aud) }) {
+		return nil, fmt.Errorf("%w: issued to another client", ErrInvalidToken)
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+
+	identity := &Identity{Provider: p.name, Subject: subject}
+	if issuedAt, _ := claims.GetIssuedAt(); issuedAt != nil {
+		identity.IssuedAt = issuedAt.Time
+	}
+	identity.Email, _ = claims["email"].(string)
+	identity.FirstName, _ = claims["given_name"].(string)
+	identity.LastName, _ = claims["family_name"].(string)
+
+	// Google sends a boolean, Apple a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+
+	return identity, nil
+}
+
+// key returns the provider's signing key kid, fetching the key set when it
+// is stale or does not have it. A key that cannot be found is an invalid
+// token; a key set that cannot be fetched is an error.
+func (p *oidcProvider) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	age := time.Since(p.fetchedAt)
+	key, ok := p.keys[kid]
+	if age > providerKeysTTL || (!ok && age > providerKeysRetry) {
+		keys, err := p.fetchKeys(ctx)
+		if err != nil {
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+		p.keys, p.fetchedAt = keys, time.Now()
+		key, ok = p.keys[kid]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+func (p *oidcProvider) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.keysURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: fetching signing keys: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: fetching signing keys: status %d", p.name, resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("%s: decoding signing keys: %w", p.name, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
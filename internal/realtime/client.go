@@ -0,0 +1,170 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 4096
+	sendBufferSize = 64
+)
+
+// control is a subscription change sent by the dashboard
+type control struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+// Client is a single admin dashboard connection
+type Client struct {
+	hub  *Hub
+	conn *websocket.Conn
+	send chan []byte
+
+	mu     sync.RWMutex
+	topics map[string]bool
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func NewClient(hub *Hub, conn *websocket.Conn, topics []string) *Client {
+	client := &Client{
+		hub:    hub,
+		conn:   conn,
+		send:   make(chan []byte, sendBufferSize),
+		topics: make(map[string]bool),
+		done:   make(chan struct{}),
+	}
+	client.subscribe(topics)
+	return client
+}
+
+// Run registers the client with the hub and pumps messages until the
+// connection closes
+func (c *Client) Run() {
+	c.hub.add(c)
+	defer c.Close()
+
+	go c.writePump()
+	c.acknowledge()
+	c.readPump()
+}
+
+// Subscribed reports whether the client wants messages for topic
+func (c *Client) Subscribed(topic string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.topics[topic]
+}
+
+// Close unregisters the client and closes the connection; safe to call twice
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		c.hub.remove(c)
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+func (c *Client) subscribe(topics []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, topic := range topics {
+		if ValidTopic(topic) {
+			c.topics[topic] = true
+		}
+	}
+}
+
+func (c *Client) unsubscribe(topics []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, topic := range topics {
+		delete(c.topics, topic)
+	}
+}
+
+// acknowledge tells the dashboard which topics it is now subscribed to
+func (c *Client) acknowledge() {
+	c.mu.RLock()
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	c.mu.RUnlock()
+	sort.Strings(topics)
+
+	data, _ := json.Marshal(map[string]interface{}{"topics": topics})
+	msg, _ := json.Marshal(Message{
+		Topic:      "system",
+		Event:      "subscriptions",
+		Data:       data,
+		OccurredAt: time.Now().UTC(),
+	})
+
+	select {
+	case c.send <- msg:
+	default:
+	}
+}
+
+func (c *Client) readPump() {
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var msg control
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if _, ok := err.(*json.SyntaxError); ok {
+				continue
+			}
+			return
+		}
+
+		switch msg.Action {
+		case "subscribe":
+			c.subscribe(msg.Topics)
+		case "unsubscribe":
+			c.unsubscribe(msg.Topics)
+		default:
+			continue
+		}
+		c.acknowledge()
+	}
+}
+
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				c.Close()
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.Close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+)
+
+// Topics an admin dashboard can subscribe to
+const (
+	TopicOrders    = "orders"
+	TopicPayments  = "payments"
+	TopicInventory = "inventory"
+)
+
+// topicsByEvent maps the domain events pushed to the dashboard onto topics
+var topicsByEvent = map[string]string{
+	events.OrderCreated:       TopicOrders,
+	events.OrderMessagePosted: TopicOrders,
+	events.PaymentFailed:      TopicPayments,
+	events.StockLow:           TopicInventory,
+}
+
+// ValidTopic reports whether topic is one clients may subscribe to
+func ValidTopic(topic string) bool {
+	switch topic {
+	case TopicOrders, TopicPayments, TopicInventory:
+		return true
+	}
+	return false
+}
+
+// AllTopics lists every topic, used when a client does not pick any
+func AllTopics() []string {
+	return []string{TopicOrders, TopicPayments, TopicInventory}
+}
+
+// Message is the envelope written to every subscribed connection
+type Message struct {
+	Topic      string          `json:"topic"`
+	Event      string          `json:"event"`
+	Data       json.RawMessage `json:"data"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Hub fans dashboard messages out to connected admin clients
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[*Client]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{clients: make(map[*Client]struct{})}
+}
+
+// Register subscribes the hub to the events it forwards to the dashboard
+func (h *Hub) Register(bus *events.Bus) {
+	for eventType := range topicsByEvent {
+		bus.Subscribe(eventType, h.handleEvent)
+	}
+}
+
+func (h *Hub) handleEvent(ctx context.Context, event models.OutboxEvent) error {
+	h.Broadcast(Message{
+		Topic:      topicsByEvent[event.EventType],
+		Event:      event.EventType,
+		Data:       event.Payload,
+		OccurredAt: event.CreatedAt,
+	})
+	return nil
+}
+
+// Broadcast queues msg for every client subscribed to its topic. Clients whose
+// buffer is full are dropped rather than blocking the event dispatcher.
+func (h *Hub) Broadcast(msg Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("⚠️ Failed to encode dashboard message: %v", err)
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.clients {
+		if !client.Subscribed(msg.Topic) {
+			continue
+		}
+		select {
+		case client.send <- data:
+		default:
+			go client.Close()
+		}
+	}
+}
+
+// ClientCount returns the number of connected dashboard clients
+func (h *Hub) ClientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+func (h *Hub) add(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[client] = struct{}{}
+}
+
+func (h *Hub) remove(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, client)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AbandonedCartRepository interface {
+	RecordAbandoned(ctx context.Context, idleFor time.Duration) ([]models.AbandonedCart, error)
+	MarkNudged(ctx context.Context, id uuid.UUID) error
+	MarkRecoveredByUser(ctx context.Context, userID, orderID uuid.UUID) error
+	GetStats(ctx context.Context, rangeDays int) (*models.AbandonedCartStats, error)
+}
+
+type abandonedCartRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAbandonedCartRepository(db *pgxpool.Pool) AbandonedCartRepository {
+	return &abandonedCartRepository{db: db}
+}
+
+// RecordAbandoned snapshots every cart that has items and has been idle for
+// idleFor. Carts that already have an open record are skipped, so each
+// abandonment is recorded (and nudged) once until the cart converts.
+func (r *abandonedCartRepository) RecordAbandoned(ctx context.Context, idleFor time.Duration) ([]models.AbandonedCart, error) {
+	query := `
+        INSERT INTO abandoned_carts (cart_id, user_id, item_count, cart_value, last_activity_at)
+        SELECT c.id, c.user_id, SUM(ci.quantity), SUM(COALESCE(v.price, p.price) * ci.quantity), c.updated_at
+        FROM carts c
+        JOIN cart_items ci ON ci.cart_id = c.id
+        JOIN products p ON p.id = ci.product_id
+        LEFT JOIN product_variants v ON v.id = ci.variant_id
+        WHERE c.updated_at < NOW() - $1::interval
+        GROUP BY c.id, c.user_id, c.updated_at
+        ON CONFLICT (cart_id) WHERE recovered_at IS NULL DO NOTHING
+        RETURNING id, cart_id, user_id, item_count, cart_value, last_activity_at, created_at
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, idleFor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var carts []models.AbandonedCart
+	for rows.Next() {
+		var cart models.AbandonedCart
+		err := rows.Scan(
+			&cart.ID,
+			&cart.CartID,
+			&cart.UserID,
+			&cart.ItemCount,
+			&cart.CartValue,
+			&cart.LastActivityAt,
+			&cart.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		carts = append(carts, cart)
+	}
+
+	return carts, rows.Err()
+}
+
+func (r *abandonedCartRepository) MarkNudged(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE abandoned_carts SET nudged_at = NOW() WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+// MarkRecoveredByUser closes the user's open abandonment record, if any. Each
+// user has a single cart, so the user identifies the cart that converted.
+func (r *abandonedCartRepository) MarkRecoveredByUser(ctx context.Context, userID, orderID uuid.UUID) error {
+	query := `
+        UPDATE abandoned_carts
+        SET recovered_at = NOW(), recovered_order_id = $2
+        WHERE user_id = $1 AND recovered_at IS NULL
+    `
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, userID, orderID)
+	return err
+}
+
+func (r *abandonedCartRepository) GetStats(ctx context.Context, rangeDays int) (*models.AbandonedCartStats, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	if rangeDays > 0 {
+		whereClause += " AND a.created_at >= NOW() - $1 * INTERVAL '1 day'"
+		args = append(args, rangeDays)
+	}
+
+	query := `
+        SELECT
+            COUNT(*),
+            COUNT(*) FILTER (WHERE a.nudged_at IS NOT NULL),
+            COUNT(*) FILTER (WHERE a.recovered_at IS NOT NULL),
+            COALESCE(SUM(a.cart_value), 0),
+            COALESCE(SUM(o.total_amount), 0)
+        FROM abandoned_carts a
+        LEFT JOIN orders o ON o.id = a.recovered_order_id
+    ` + whereClause
+
+	stats := &models.AbandonedCartStats{RangeDays: rangeDays}
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, args...).Scan(
+		&stats.Abandoned,
+		&stats.Nudged,
+		&stats.Recovered,
+		&stats.AbandonedValue,
+		&stats.RecoveredValue,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Open = stats.Abandoned - stats.Recovered
+	if stats.Abandoned > 0 {
+		stats.RecoveryRate = float64(stats.Recovered) / float64(stats.Abandoned)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AccountingRepository interface {
+	GetPeriodTotals(ctx context.Context, filter models.AccountingFilter) ([]models.AccountingTotals, error)
+}
+
+// Reads go to the replica when one is configured and up
+type accountingRepository struct {
+	db      *pgxpool.Pool
+	replica *database.Replica
+}
+
+func NewAccountingRepository(db *pgxpool.Pool, replica *database.Replica) AccountingRepository {
+	return &accountingRepository{db: db, replica: replica}
+}
+
+// GetPeriodTotals sums the money movements in the context's store by period,
+// oldest first; periods without any are left out. A card payment counts when
+// it was captured, or created for payments captured at once. Gift card
+// tender counts when the order was placed, unless it was cancelled or never
+// got past payment.
+func (r *accountingRepository) GetPeriodTotals(ctx context.Context, filter models.AccountingFilter) ([]models.AccountingTotals, error) {
+	query := `
+        WITH movements AS (
+            SELECT 'card_sale' AS kind, COALESCE(pc.created_at, p.created_at) AS at, p.amount
+            FROM payments p
+            JOIN orders o ON o.id = p.order_id
+            LEFT JOIN payment_captures pc ON pc.payment_id = p.id
+            WHERE p.payment_method IN ('cc', 'dc')
+              AND p.status IN ('completed', 'partially_refunded', 'refunded')
+              AND ($4::uuid IS NULL OR o.store_id = $4)
+            UNION ALL
+            SELECT 'gift_card_tender', o.created_at, o.gift_card_amount
+            FROM orders o
+            WHERE o.gift_card_amount > 0
+              AND o.status NOT IN ('cancelled', 'awaiting_payment')
+              AND ($4::uuid IS NULL OR o.store_id = $4)
+            UNION ALL
+            SELECT 'cod_collected', c.collected_at, c.amount
+            FROM cod_remittances c
+            JOIN orders o ON o.id = c.order_id
+            WHERE ($4::uuid IS NULL OR o.store_id = $4)
+            UNION ALL
+            SELECT 'cod_remitted', c.remitted_at, c.amount
+            FROM cod_remittances c
+            JOIN orders o ON o.id = c.order_id
+            WHERE c.status = 'remitted'
+              AND ($4::uuid IS NULL OR o.store_id = $4)
+            UNION ALL
+            SELECT CASE WHEN p.payment_method IN ('cc', 'dc') THEN 'card_refund' ELSE 'other_refund' END,
+                   rf.created_at, rf.amount
+            FROM refunds rf
+            JOIN payments p ON p.id = rf.payment_id
+            JOIN orders o ON o.id = rf.order_id
+            WHERE ($4::uuid IS NULL OR o.store_id = $4)
+        )
+        SELECT date_trunc($1, at) AS period, kind, SUM(amount), COUNT(*)
+        FROM movements
+        WHERE at >= $2 AND at < $3
+        GROUP BY period, kind
+        ORDER BY period
+    `
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, query,
+		filter.Period, filter.From, filter.To, database.StoreArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []models.AccountingTotals{}
+	for rows.Next() {
+		var period time.Time
+		var kind string
+		var amount money.Money
+		var count int
+		if err := rows.Scan(&period, &kind, &amount, &count); err != nil {
+			return nil, err
+		}
+
+		if len(totals) == 0 || !totals[len(totals)-1].Period.Equal(period) {
+			totals = append(totals, models.AccountingTotals{Period: period})
+		}
+		current := &totals[len(totals)-1]
+
+		switch kind {
+		case "card_sale":
+			current.CardSales = amount
+			current.CardPayments = count
+		case "gift_card_tender":
+			current.GiftCardTender = amount
+		case "cod_collected":
+			current.CODCollected = amount
+		case "cod_remitted":
+			current.CODRemitted = amount
+		case "card_refund":
+			current.CardRefunds = amount
+		case "other_refund":
+			current.OtherRefunds = amount
+		}
+	}
+
+	return totals, rows.Err()
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AdminNoteRepository interface {
+	Create(ctx context.Context, note *models.AdminNote) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.AdminNote, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.AdminNote, error)
+	DeleteFromOrder(ctx context.Context, orderID, noteID uuid.UUID) error
+	DeleteFromUser(ctx context.Context, userID, noteID uuid.UUID) error
+}
+
+type adminNoteRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAdminNoteRepository(db *pgxpool.Pool) AdminNoteRepository {
+	return &adminNoteRepository{db: db}
+}
+
+func (r *adminNoteRepository) Create(ctx context.Context, note *models.AdminNote) error {
+	query := `
+        INSERT INTO admin_notes (id, order_id, user_id, author_id, body)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		note.ID,
+		note.OrderID,
+		note.UserID,
+		note.AuthorID,
+		note.Body,
+	).Scan(&note.CreatedAt)
+}
+
+// GetByOrderID returns the order's notes, newest first
+func (r *adminNoteRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.AdminNote, error) {
+	return r.list(ctx, "n.order_id = $1", orderID)
+}
+
+// GetByUserID returns the notes on the customer, newest first
+func (r *adminNoteRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.AdminNote, error) {
+	return r.list(ctx, "n.user_id = $1", userID)
+}
+
+func (r *adminNoteRepository) list(ctx context.Context, cond string, id uuid.UUID) ([]models.AdminNote, error) {
+	query := `
+        SELECT n.id, n.order_id, n.user_id, n.author_id, COALESCE(a.email, ''), n.body, n.created_at
+        FROM admin_notes n
+        LEFT JOIN users a ON a.id = n.author_id
+        WHERE ` + cond + `
+        ORDER BY n.created_at DESC, n.id
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []models.AdminNote{}
+	for rows.Next() {
+		var note models.AdminNote
+		err := rows.Scan(
+			&note.ID,
+			&note.OrderID,
+			&note.UserID,
+			&note.AuthorID,
+			&note.AuthorEmail,
+			&note.Body,
+			&note.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+// DeleteFromOrder deletes a note, provided it is on the order
+func (r *adminNoteRepository) DeleteFromOrder(ctx context.Context, orderID, noteID uuid.UUID) error {
+	return r.delete(ctx, `DELETE FROM admin_notes WHERE id = $1 AND order_id = $2`, noteID, orderID)
+}
+
+// DeleteFromUser deletes a note, provided it is on the user
+func (r *adminNoteRepository) DeleteFromUser(ctx context.Context, userID, noteID uuid.UUID) error {
+	return r.delete(ctx, `DELETE FROM admin_notes WHERE id = $1 AND user_id = $2`, noteID, userID)
+}
+
+func (r *adminNoteRepository) delete(ctx context.Context, query string, noteID, subjectID uuid.UUID) error {
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, noteID, subjectID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("note not found")
+	}
+
+	return nil
+}
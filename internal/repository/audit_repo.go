@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+}
+
+type auditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	query := `
+        INSERT INTO audit_log (actor_id, subject_user_id, action, method, path, status_code, details)
+        VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, 0), NULLIF($7, ''))
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		entry.ActorID,
+		entry.SubjectUserID,
+		entry.Action,
+		entry.Method,
+		entry.Path,
+		entry.StatusCode,
+		entry.Details,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BackInStockRepository interface {
+	Subscribe(ctx context.Context, sub *models.BackInStockSubscription) error
+	ClaimAvailable(ctx context.Context, productID *uuid.UUID) ([]models.BackInStockSubscription, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.BackInStockSubscription, error)
+}
+
+type backInStockRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBackInStockRepository(db *pgxpool.Pool) BackInStockRepository {
+	return &backInStockRepository{db: db}
+}
+
+// Subscribe stores a pending subscription. Subscribing twice is a no-op that
+// returns the existing pending subscription.
+func (r *backInStockRepository) Subscribe(ctx context.Context, sub *models.BackInStockSubscription) error {
+	query := `
+        INSERT INTO back_in_stock_subscriptions (user_id, product_id, variant_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid))
+            WHERE notified_at IS NULL
+        DO UPDATE SET created_at = back_in_stock_subscriptions.created_at
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		sub.UserID,
+		sub.ProductID,
+		sub.VariantID,
+	).Scan(&sub.ID, &sub.CreatedAt)
+}
+
+// ClaimAvailable marks pending subscriptions whose product or variant has
+// available stock again, for bundles enough components for one, as notified
+// and returns them. A nil productID sweeps every product. Rows locked by a
+// concurrent claim are skipped so each subscriber is notified once.
+func (r *backInStockRepository) ClaimAvailable(ctx context.Context, productID *uuid.UUID) ([]models.BackInStockSubscription, error) {
+	query := `
+        WITH available AS (
+            SELECT s.id
+            FROM back_in_stock_subscriptions s
+            JOIN products p ON p.id = s.product_id
+            LEFT JOIN product_variants v ON v.id = s.variant_id
+            WHERE s.notified_at IS NULL
+              AND ($1::uuid IS NULL OR s.product_id = $1)
+              AND COALESCE(
+                  bundle_available_stock(s.product_id, NULL),
+                  COALESCE(v.stock_quantity, p.stock_quantity) - COALESCE((
+                      SELECT SUM(sr.quantity)
+                      FROM stock_reservations sr
+                      WHERE sr.product_id = s.product_id
+                        AND sr.variant_id IS NOT DISTINCT FROM s.variant_id
+                        AND sr.expires_at > NOW()
+                  ), 0) - bundle_reserved_stock(s.product_id, s.variant_id, NULL)
+              ) > 0
+            FOR UPDATE OF s SKIP LOCKED
+        )
+        UPDATE back_in_stock_subscriptions s
+        SET notified_at = NOW()
+        FROM available a, products p
+        WHERE s.id = a.id AND p.id = s.product_id
+        RETURNING s.id, s.user_id, s.product_id, s.variant_id, p.name, s.notified_at, s.created_at
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []models.BackInStockSubscription
+	for rows.Next() {
+		var sub models.BackInStockSubscription
+		err := rows.Scan(
+			&sub.ID,
+			&sub.UserID,
+			&sub.ProductID,
+			&sub.VariantID,
+			&sub.ProductName,
+			&sub.NotifiedAt,
+			&sub.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func (r *backInStockRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.BackInStockSubscription, error) {
+	query := `
+        SELECT s.id, s.user_id, s.product_id, s.variant_id, p.name, s.notified_at, s.created_at
+        FROM back_in_stock_subscriptions s
+        JOIN products p ON p.id = s.product_id
+        WHERE s.user_id = $1
+        ORDER BY s.created_at DESC
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []models.BackInStockSubscription
+	for rows.Next() {
+		var sub models.BackInStockSubscription
+		err := rows.Scan(
+			&sub.ID,
+			&sub.UserID,
+			&sub.ProductID,
+			&sub.VariantID,
+			&sub.ProductName,
+			&sub.NotifiedAt,
+			&sub.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ecommerce-backend/internal/models"
+)
+
+func BenchmarkSetReservationQuantity(b *testing.B) {
+	f := newFixture(b)
+	product := f.product(b, 100)
+	cart := f.cart(b)
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Alternate so every call changes the reservation
+		if err := f.reserve(product.ID, cart.ID, 1+i%2, expiresAt); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetAvailableStock(b *testing.B) {
+	f := newFixture(b)
+	product := f.product(b, 100)
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	// Availability subtracts reservations, so give it some to add up
+	for i := 0; i < 10; i++ {
+		if err := f.reserve(product.ID, f.cart(b).ID, 1, expiresAt); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.available(b, product.ID)
+	}
+}
+
+func BenchmarkCommitReservation(b *testing.B) {
+	f := newFixture(b)
+	ctx := context.Background()
+	product := f.product(b, b.N)
+	cart := f.cart(b)
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		if err := f.reserve(product.ID, cart.ID, 1, expiresAt); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		if _, err := f.products.CommitReservation(ctx, product.ID, cart.ID, nil, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkListProducts(b *testing.B) {
+	f := newFixture(b)
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		f.product(b, 10)
+	}
+
+	benchmarks := []struct {
+		name   string
+		filter models.ProductFilter
+	}{
+		{"all", models.ProductFilter{}},
+		{"in stock", models.ProductFilter{InStockOnly: true}},
+		{"search", models.ProductFilter{Search: "test"}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := f.products.GetAll(ctx, 1, 20, bm.filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BundleRepository interface {
+	GetComponents(ctx context.Context, bundleID uuid.UUID) ([]models.BundleComponent, error)
+	ReplaceComponents(ctx context.Context, bundleID uuid.UUID, components []models.BundleComponent) error
+	IsComponent(ctx context.Context, productID uuid.UUID) (bool, error)
+}
+
+type bundleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBundleRepository(db *pgxpool.Pool) BundleRepository {
+	return &bundleRepository{db: db}
+}
+
+// GetComponents lists a bundle's components in the order they were added,
+// empty for a product that is not a bundle
+func (r *bundleRepository) GetComponents(ctx context.Context, bundleID uuid.UUID) ([]models.BundleComponent, error) {
+	query := `
+        SELECT bc.id, bc.bundle_product_id, bc.component_product_id, bc.component_variant_id,
+               bc.quantity, COALESCE(v.sku, p.sku), p.name, bc.created_at
+        FROM bundle_components bc
+        JOIN products p ON p.id = bc.component_product_id
+        LEFT JOIN product_variants v ON v.id = bc.component_variant_id
+        WHERE bc.bundle_product_id = $1
+        ORDER BY bc.created_at, bc.id
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	components := []models.BundleComponent{}
+	for rows.Next() {
+		var component models.BundleComponent
+		err := rows.Scan(
+			&component.ID,
+			&component.BundleProductID,
+			&component.ProductID,
+			&component.VariantID,
+			&component.Quantity,
+			&component.SKU,
+			&component.Name,
+			&component.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+
+	return components, rows.Err()
+}
+
+// ReplaceComponents swaps a bundle's components for the given ones
+func (r *bundleRepository) ReplaceComponents(ctx context.Context, bundleID uuid.UUID, components []models.BundleComponent) error {
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM bundle_components WHERE bundle_product_id = $1", bundleID); err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO bundle_components (bundle_product_id, component_product_id, component_variant_id, quantity)
+        VALUES ($1, $2, $3, $4)
+    `
+	for _, component := range components {
+		if _, err := tx.Exec(ctx, query, bundleID, component.ProductID, component.VariantID, component.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// IsComponent reports whether any bundle contains the product
+func (r *bundleRepository) IsComponent(ctx context.Context, productID uuid.UUID) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM bundle_components WHERE component_product_id = $1)"
+
+	var exists bool
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, productID).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CarrierEventRepository interface {
+	Create(ctx context.Context, event *models.CarrierEvent) error
+	GetAll(ctx context.Context, filter models.CarrierEventFilter, page, limit int) ([]models.CarrierEvent, int, error)
+}
+
+type carrierEventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCarrierEventRepository(db *pgxpool.Pool) CarrierEventRepository {
+	return &carrierEventRepository{db: db}
+}
+
+// Create records a callback under the store of the order it matched, nil
+// when it matched none
+func (r *carrierEventRepository) Create(ctx context.Context, event *models.CarrierEvent) error {
+	query := `
+        INSERT INTO carrier_events (store_id, carrier, tracking_number, status, order_id, outcome, detail, payload)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, received_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		storeID,
+		event.Carrier,
+		event.TrackingNumber,
+		event.Status,
+		event.OrderID,
+		event.Outcome,
+		event.Detail,
+		event.Payload,
+	).Scan(&event.ID, &event.ReceivedAt)
+}
+
+func (r *carrierEventRepository) GetAll(ctx context.Context, filter models.CarrierEventFilter, page, limit int) ([]models.CarrierEvent, int, error) {
+	offset := (page - 1) * limit
+
+	where := &database.Where{}
+	where.ScopeStore(ctx, "store_id")
+	if filter.Carrier != "" {
+		where.And("carrier = ?", filter.Carrier)
+	}
+	if filter.TrackingNumber != "" {
+		where.And("tracking_number = ?", filter.TrackingNumber)
+	}
+	if filter.Outcome != "" {
+		where.And("outcome = ?", filter.Outcome)
+	}
+
+	var total int
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, "SELECT COUNT(*) FROM carrier_events "+where.String(), where.Args()...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT id, carrier, tracking_number, status, order_id, outcome, detail, payload, received_at
+        FROM carrier_events
+        %s
+        ORDER BY received_at DESC
+        LIMIT %s OFFSET %s
+    `, where, where.Bind(limit), where.Bind(offset))
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []models.CarrierEvent{}
+	for rows.Next() {
+		var event models.CarrierEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.Carrier,
+			&event.TrackingNumber,
+			&event.Status,
+			&event.OrderID,
+			&event.Outcome,
+			&event.Detail,
+			&event.Payload,
+			&event.ReceivedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+
+	return events, total, rows.Err()
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -15,7 +18,7 @@ type CartRepository interface {
 	Create(ctx context.Context, userID uuid.UUID) (*models.Cart, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Cart, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Cart, error)
-	AddItem(ctx context.Context, cartID, productID uuid.UUID, quantity int) error
+	AddItem(ctx context.Context, cartID, productID uuid.UUID, variantID *uuid.UUID, quantity int) error
 	UpdateItem(ctx context.Context, cartID, itemID uuid.UUID, quantity int) error
 	RemoveItem(ctx context.Context, cartID, itemID uuid.UUID) error
 	ClearCart(ctx context.Context, cartID uuid.UUID) error
@@ -38,7 +41,7 @@ func (r *cartRepository) Create(ctx context.Context, userID uuid.UUID) (*models.
     `
 
 	var cart models.Cart
-	err := r.db.QueryRow(ctx, query, userID).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(
 		&cart.ID,
 		&cart.CreatedAt,
 		&cart.UpdatedAt,
@@ -62,7 +65,7 @@ func (r *cartRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Car
     `
 
 	var cart models.Cart
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&cart.ID,
 		&cart.UserID,
 		&cart.CreatedAt,
@@ -87,7 +90,7 @@ func (r *cartRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*mo
     `
 
 	var cart models.Cart
-	err := r.db.QueryRow(ctx, query, userID).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(
 		&cart.ID,
 		&cart.UserID,
 		&cart.CreatedAt,
@@ -112,28 +115,31 @@ func (r *cartRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*mo
 	return cartWithItems, nil
 }
 
-func (r *cartRepository) AddItem(ctx context.Context, cartID, productID uuid.UUID, quantity int) error {
-	// First, try to get existing item
-	checkQuery := `SELECT id FROM cart_items WHERE cart_id = $1 AND product_id = $2`
+func (r *cartRepository) AddItem(ctx context.Context, cartID, productID uuid.UUID, variantID *uuid.UUID, quantity int) error {
+	// First, try to get existing item for the same product and variant
+	checkQuery := `
+		SELECT id FROM cart_items
+		WHERE cart_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3::uuid
+	`
 	var existingID string
-	err := r.db.QueryRow(ctx, checkQuery, cartID, productID).Scan(&existingID)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, checkQuery, cartID, productID, variantID).Scan(&existingID)
 
 	if err == nil {
 		// Item exists, update it
 		updateQuery := `
 			UPDATE cart_items 
 			SET quantity = quantity + $1
-			WHERE cart_id = $2 AND product_id = $3
+			WHERE id = $2
 		`
-		_, err := r.db.Exec(ctx, updateQuery, quantity, cartID, productID)
+		_, err := database.Conn(ctx, r.db).Exec(ctx, updateQuery, quantity, existingID)
 		return err
 	} else if errors.Is(err, pgx.ErrNoRows) {
 		// Item doesn't exist, insert it
 		insertQuery := `
-			INSERT INTO cart_items (cart_id, product_id, quantity)
-			VALUES ($1, $2, $3)
+			INSERT INTO cart_items (cart_id, product_id, variant_id, quantity)
+			VALUES ($1, $2, $3, $4)
 		`
-		_, err := r.db.Exec(ctx, insertQuery, cartID, productID, quantity)
+		_, err := database.Conn(ctx, r.db).Exec(ctx, insertQuery, cartID, productID, variantID, quantity)
 		return err
 	}
 
@@ -147,13 +153,13 @@ func (r *cartRepository) UpdateItem(ctx context.Context, cartID, itemID uuid.UUI
         WHERE id = $2 AND cart_id = $3
     `
 
-	result, err := r.db.Exec(ctx, query, quantity, itemID, cartID)
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, quantity, itemID, cartID)
 	if err != nil {
 		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("cart item not found")
+		return apperrors.NotFound("cart item not found")
 	}
 
 	return nil
@@ -165,13 +171,13 @@ func (r *cartRepository) RemoveItem(ctx context.Context, cartID, itemID uuid.UUI
         WHERE id = $1 AND cart_id = $2
     `
 
-	result, err := r.db.Exec(ctx, query, itemID, cartID)
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, itemID, cartID)
 	if err != nil {
 		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("cart item not found")
+		return apperrors.NotFound("cart item not found")
 	}
 
 	return nil
@@ -179,7 +185,7 @@ func (r *cartRepository) RemoveItem(ctx context.Context, cartID, itemID uuid.UUI
 
 func (r *cartRepository) ClearCart(ctx context.Context, cartID uuid.UUID) error {
 	query := `DELETE FROM cart_items WHERE cart_id = $1`
-	_, err := r.db.Exec(ctx, query, cartID)
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, cartID)
 	return err
 }
 
@@ -192,7 +198,7 @@ func (r *cartRepository) GetCartWithItems(ctx context.Context, cartID uuid.UUID)
     `
 
 	var cart models.Cart
-	err := r.db.QueryRow(ctx, cartQuery, cartID).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, cartQuery, cartID).Scan(
 		&cart.ID,
 		&cart.UserID,
 		&cart.CreatedAt,
@@ -203,19 +209,24 @@ func (r *cartRepository) GetCartWithItems(ctx context.Context, cartID uuid.UUID)
 		return nil, err
 	}
 
-	// Get cart items with product details
+	// Get cart items with product details; a bundle's stock is what its
+	// components make up for this cart
 	itemsQuery := `
         SELECT 
             ci.id, ci.cart_id, ci.product_id, ci.quantity, ci.created_at,
-            p.id, p.sku, p.name, p.description, p.price, p.stock_quantity, 
-            p.category, p.image_url, p.created_at, p.updated_at
+            p.id, p.sku, p.name, p.description, p.price,
+            COALESCE(bundle_available_stock(p.id, ci.cart_id), p.stock_quantity),
+            p.category, p.image_url, p.created_at, p.updated_at,
+            p.weight_grams, p.length_mm, p.width_mm, p.height_mm,
+            v.id, v.sku, v.price, v.stock_quantity, v.attributes
         FROM cart_items ci
         JOIN products p ON ci.product_id = p.id
+        LEFT JOIN product_variants v ON ci.variant_id = v.id
         WHERE ci.cart_id = $1
         ORDER BY ci.created_at DESC
     `
 
-	rows, err := r.db.Query(ctx, itemsQuery, cartID)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, itemsQuery, cartID)
 	if err != nil {
 		return nil, err
 	}
@@ -225,6 +236,11 @@ func (r *cartRepository) GetCartWithItems(ctx context.Context, cartID uuid.UUID)
 	for rows.Next() {
 		var item models.CartItem
 		var product models.Product
+		var variantSKU *string
+		var variantPrice *money.Money
+		var variantStock *int
+		var variantAttributes map[string]string
+		var length, width, height *int
 
 		err := rows.Scan(
 			&item.ID,
@@ -242,13 +258,33 @@ func (r *cartRepository) GetCartWithItems(ctx context.Context, cartID uuid.UUID)
 			&product.ImageURL,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.WeightGrams,
+			&length,
+			&width,
+			&height,
+			&item.VariantID,
+			&variantSKU,
+			&variantPrice,
+			&variantStock,
+			&variantAttributes,
 		)
 
 		if err != nil {
 			return nil, err
 		}
 
+		product.Dimensions = dimensionsFrom(length, width, height)
 		item.Product = product
+		if item.VariantID != nil {
+			item.Variant = &models.ProductVariant{
+				ID:         *item.VariantID,
+				ProductID:  product.ID,
+				SKU:        *variantSKU,
+				Price:      *variantPrice,
+				Stock:      *variantStock,
+				Attributes: variantAttributes,
+			}
+		}
 		items = append(items, item)
 	}
 
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CheckoutSessionRepository interface {
+	Create(ctx context.Context, session *models.CheckoutSession) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CheckoutSession, error)
+	Complete(ctx context.Context, id, orderID uuid.UUID) error
+}
+
+type checkoutSessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCheckoutSessionRepository(db *pgxpool.Pool) CheckoutSessionRepository {
+	return &checkoutSessionRepository{db: db}
+}
+
+// Create stores a new open session, expiring any the customer still had open
+func (r *checkoutSessionRepository) Create(ctx context.Context, session *models.CheckoutSession) error {
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	expireQuery := `
+        UPDATE checkout_sessions SET status = 'expired', updated_at = NOW()
+        WHERE user_id = $1 AND status = 'open'
+    `
+	if _, err := tx.Exec(ctx, expireQuery, session.UserID); err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO checkout_sessions (user_id, cart_id, status, items, totals, shipping_address,
+                                       billing_address, shipping_method, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at, updated_at
+    `
+	err = tx.QueryRow(ctx, query,
+		session.UserID,
+		session.CartID,
+		session.Status,
+		session.Items,
+		session.Totals,
+		session.ShippingAddress,
+		session.BillingAddress,
+		session.ShippingMethod,
+		session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetByID returns the session as stored, nil when there is none. An open
+// session past its expiry is still reported as open.
+func (r *checkoutSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CheckoutSession, error) {
+	query := `
+        SELECT id, user_id, cart_id, status, items, totals, shipping_address, billing_address,
+               shipping_method, order_id, expires_at, created_at, updated_at
+        FROM checkout_sessions
+        WHERE id = $1
+    `
+
+	var session models.CheckoutSession
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.CartID,
+		&session.Status,
+		&session.Items,
+		&session.Totals,
+		&session.ShippingAddress,
+		&session.BillingAddress,
+		&session.ShippingMethod,
+		&session.OrderID,
+		&session.ExpiresAt,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Complete records the order placed from an open, unexpired session. Only
+// one order can be placed from a session: a second attempt, even a
+// concurrent one, fails with a conflict.
+func (r *checkoutSessionRepository) Complete(ctx context.Context, id, orderID uuid.UUID) error {
+	query := `
+        UPDATE checkout_sessions SET status = 'completed', order_id = $2, updated_at = NOW()
+        WHERE id = $1 AND status = 'open' AND expires_at > NOW()
+    `
+
+	tag, err := database.Conn(ctx, r.db).Exec(ctx, query, id, orderID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.Conflict("checkout session is no longer open")
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CODRepository interface {
+	SaveVerification(ctx context.Context, verification *models.CODVerification) error
+	GetVerification(ctx context.Context, orderID uuid.UUID) (*models.CODVerification, error)
+	IncrementAttempts(ctx context.Context, id uuid.UUID) error
+	MarkVerified(ctx context.Context, id uuid.UUID) error
+	CreateRemittance(ctx context.Context, remittance *models.CODRemittance) error
+	GetRemittances(ctx context.Context, status string, rangeDays, page, limit int) ([]models.CODRemittance, int, error)
+	GetRemittanceTotals(ctx context.Context, rangeDays int) (*models.CODRemittanceTotals, error)
+	MarkRemitted(ctx context.Context, ids []uuid.UUID, reference string) (int64, error)
+}
+
+type codRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCODRepository(db *pgxpool.Pool) CODRepository {
+	return &codRepository{db: db}
+}
+
+// SaveVerification stores a new delivery code for the order, replacing any
+// earlier one and resetting its attempt count
+func (r *codRepository) SaveVerification(ctx context.Context, verification *models.CODVerification) error {
+	query := `
+        INSERT INTO cod_verifications (order_id, otp_hash, expires_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (order_id) DO UPDATE
+        SET otp_hash = EXCLUDED.otp_hash,
+            expires_at = EXCLUDED.expires_at,
+            attempts = 0,
+            verified_at = NULL,
+            created_at = NOW()
+        RETURNING id, attempts, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		verification.OrderID,
+		verification.OTPHash,
+		verification.ExpiresAt,
+	).Scan(&verification.ID, &verification.Attempts, &verification.CreatedAt)
+}
+
+func (r *codRepository) GetVerification(ctx context.Context, orderID uuid.UUID) (*models.CODVerification, error) {
+	query := `
+        SELECT id, order_id, otp_hash, expires_at, attempts, verified_at, created_at
+        FROM cod_verifications
+        WHERE order_id = $1
+    `
+
+	var verification models.CODVerification
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, orderID).Scan(
+		&verification.ID,
+		&verification.OrderID,
+		&verification.OTPHash,
+		&verification.ExpiresAt,
+		&verification.Attempts,
+		&verification.VerifiedAt,
+		&verification.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+func (r *codRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE cod_verifications SET attempts = attempts + 1 WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+func (r *codRepository) MarkVerified(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE cod_verifications
+        SET verified_at = NOW()
+        WHERE id = $1 AND verified_at IS NULL
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Conflict("delivery code already used")
+	}
+
+	return nil
+}
+
+func (r *codRepository) CreateRemittance(ctx context.Context, remittance *models.CODRemittance) error {
+	query := `
+        INSERT INTO cod_remittances (order_id, payment_id, amount)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (order_id) DO NOTHING
+        RETURNING id, status, collected_at
+    `
+
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query,
+		remittance.OrderID,
+		remittance.PaymentID,
+		remittance.Amount,
+	).Scan(&remittance.ID, &remittance.Status, &remittance.CollectedAt)
+
+	// Already recorded for this order
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+func (r *codRepository) GetRemittances(ctx context.Context, status string, rangeDays, page, limit int) ([]models.CODRemittance, int, error) {
+	offset := (page - 1) * limit
+
+	// Build WHERE clause
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argCount := 1
+
+	if status != "" {
+		whereClause += fmt.Sprintf(" AND cr.status = $%d", argCount)
+		args = append(args, status)
+		argCount++
+	}
+	if rangeDays > 0 {
+		whereClause += fmt.Sprintf(" AND cr.collected_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
+		args = append(args, rangeDays)
+		argCount++
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM cod_remittances cr %s", whereClause)
+	var total int
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT cr.id, cr.order_id, o.order_number, cr.payment_id, cr.amount, cr.status,
+               cr.reference, cr.collected_at, cr.remitted_at
+        FROM cod_remittances cr
+        JOIN orders o ON cr.order_id = o.id
+        %s
+        ORDER BY cr.collected_at DESC
+        LIMIT $%d OFFSET $%d
+    `, whereClause, argCount, argCount+1)
+
+	args = append(args, limit, offset)
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	remittances := []models.CODRemittance{}
+	for rows.Next() {
+		var remittance models.CODRemittance
+		err := rows.Scan(
+			&remittance.ID,
+			&remittance.OrderID,
+			&remittance.OrderNumber,
+			&remittance.PaymentID,
+			&remittance.Amount,
+			&remittance.Status,
+			&remittance.Reference,
+			&remittance.CollectedAt,
+			&remittance.RemittedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		remittances = append(remittances, remittance)
+	}
+
+	return remittances, total, rows.Err()
+}
+
+func (r *codRepository) GetRemittanceTotals(ctx context.Context, rangeDays int) (*models.CODRemittanceTotals, error) {
+	query := `
+        SELECT
+            COALESCE(SUM(amount), 0),
+            COALESCE(SUM(amount) FILTER (WHERE status = 'remitted'), 0),
+            COALESCE(SUM(amount) FILTER (WHERE status = 'pending'), 0),
+            COUNT(*) FILTER (WHERE status = 'pending'),
+            COUNT(*) FILTER (WHERE status = 'remitted')
+        FROM cod_remittances
+        WHERE $1::integer = 0 OR collected_at >= NOW() - $1::integer * INTERVAL '1 day'
+    `
+
+	var totals models.CODRemittanceTotals
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, rangeDays).Scan(
+		&totals.Collected,
+		&totals.Remitted,
+		&totals.Outstanding,
+		&totals.PendingCount,
+		&totals.RemittedCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &totals, nil
+}
+
+// MarkRemitted settles the given pending remittances under one reference and
+// returns how many were updated
+func (r *codRepository) MarkRemitted(ctx context.Context, ids []uuid.UUID, reference string) (int64, error) {
+	query := `
+        UPDATE cod_remittances
+        SET status = 'remitted', reference = $2, remitted_at = NOW()
+        WHERE id = ANY($1::uuid[]) AND status = 'pending'
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, ids, reference)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,51 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+)
+
+func TestConcurrentReservationsOfLastUnit(t *testing.T) {
+	f := newFixture(t)
+	product := f.product(t, 1)
+	expiresAt := time.Now().Add(15 * time.Minute).Unix()
+
+	carts := make([]*models.Cart, 10)
+	for i := range carts {
+		carts[i] = f.cart(t)
+	}
+
+	errs := make([]error, len(carts))
+	var wg sync.WaitGroup
+	for i, cart := range carts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = f.reserve(product.ID, cart.ID, 1, expiresAt)
+		}()
+	}
+	wg.Wait()
+
+	reserved := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			reserved++
+		case !errors.Is(err, apperrors.ErrValidation):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if reserved != 1 {
+		t.Errorf("%d carts reserved the last unit, want 1", reserved)
+	}
+	if got := f.available(t, product.ID); got != 0 {
+		t.Errorf("available = %d, want 0", got)
+	}
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ERPStockMessageRepository interface {
+	Create(ctx context.Context, message *models.ERPStockMessage) error
+	UpdateOutcome(ctx context.Context, message *models.ERPStockMessage) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ERPStockMessage, error)
+	GetByMessageID(ctx context.Context, messageID string) (*models.ERPStockMessage, error)
+	GetAll(ctx context.Context, filter models.ERPStockMessageFilter, page, limit int) ([]models.ERPStockMessage, int, error)
+	LatestApplied(ctx context.Context, sku, warehouseCode string) (*time.Time, error)
+}
+
+type erpStockMessageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewERPStockMessageRepository(db *pgxpool.Pool) ERPStockMessageRepository {
+	return &erpStockMessageRepository{db: db}
+}
+
+const erpStockMessageColumns = `
+        id, message_id, sku, warehouse_code, quantity, changed_at, product_id, variant_id,
+        outcome, detail, payload, attempts, received_at, processed_at
+`
+
+func scanERPStockMessage(row pgx.Row, message *models.ERPStockMessage) error {
+	return row.Scan(
+		&message.ID,
+		&message.MessageID,
+		&message.SKU,
+		&message.WarehouseCode,
+		&message.Quantity,
+		&message.ChangedAt,
+		&message.ProductID,
+		&message.VariantID,
+		&message.Outcome,
+		&message.Detail,
+		&message.Payload,
+		&message.Attempts,
+		&message.ReceivedAt,
+		&message.ProcessedAt,
+	)
+}
+
+func (r *erpStockMessageRepository) Create(ctx context.Context, message *models.ERPStockMessage) error {
+	query := `
+        INSERT INTO erp_stock_messages (
+            message_id, sku, warehouse_code, quantity, changed_at, product_id, variant_id,
+            outcome, detail, payload
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, attempts, received_at, processed_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		message.MessageID,
+		message.SKU,
+		message.WarehouseCode,
+		message.Quantity,
+		message.ChangedAt,
+		message.ProductID,
+		message.VariantID,
+		message.Outcome,
+		message.Detail,
+		message.Payload,
+	).Scan(&message.ID, &message.Attempts, &message.ReceivedAt, &message.ProcessedAt)
+}
+
+// UpdateOutcome records the result of processing a message again and counts
+// the attempt
+func (r *erpStockMessageRepository) UpdateOutcome(ctx context.Context, message *models.ERPStockMessage) error {
+	query := `
+        UPDATE erp_stock_messages
+        SET warehouse_code = $2, product_id = $3, variant_id = $4, outcome = $5, detail = $6,
+            attempts = attempts + 1, processed_at = NOW()
+        WHERE id = $1
+        RETURNING attempts, processed_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		message.ID,
+		message.WarehouseCode,
+		message.ProductID,
+		message.VariantID,
+		message.Outcome,
+		message.Detail,
+	).Scan(&message.Attempts, &message.ProcessedAt)
+}
+
+func (r *erpStockMessageRepository) getOne(ctx context.Context, where string, arg interface{}) (*models.ERPStockMessage, error) {
+	query := `SELECT ` + erpStockMessageColumns + ` FROM erp_stock_messages WHERE ` + where
+
+	var message models.ERPStockMessage
+	err := scanERPStockMessage(database.Conn(ctx, r.db).QueryRow(ctx, query, arg), &message)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+func (r *erpStockMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ERPStockMessage, error) {
+	return r.getOne(ctx, "id = $1", id)
+}
+
+func (r *erpStockMessageRepository) GetByMessageID(ctx context.Context, messageID string) (*models.ERPStockMessage, error) {
+	return r.getOne(ctx, "message_id = $1", messageID)
+}
+
+func (r *erpStockMessageRepository) GetAll(ctx context.Context, filter models.ERPStockMessageFilter, page, limit int) ([]models.ERPStockMessage, int, error) {
+	offset := (page - 1) * limit
+
+	where := &database.Where{}
+	if filter.SKU != "" {
+		where.And("sku = ?", filter.SKU)
+	}
+	if filter.Outcome != "" {
+		where.And("outcome = ?", filter.Outcome)
+	}
+
+	var total int
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, "SELECT COUNT(*) FROM erp_stock_messages "+where.String(), where.Args()...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM erp_stock_messages
+        %s
+        ORDER BY received_at DESC
+        LIMIT %s OFFSET %s
+    `, erpStockMessageColumns, where, where.Bind(limit), where.Bind(offset))
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	messages := []models.ERPStockMessage{}
+	for rows.Next() {
+		var message models.ERPStockMessage
+		if err := scanERPStockMessage(rows, &message); err != nil {
+			return nil, 0, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, total, rows.Err()
+}
+
+// LatestApplied returns when the ERP counted the stock level last applied to
+// the SKU at the warehouse, nil when none with a count time was
+func (r *erpStockMessageRepository) LatestApplied(ctx context.Context, sku, warehouseCode string) (*time.Time, error) {
+	query := `
+        SELECT MAX(changed_at)
+        FROM erp_stock_messages
+        WHERE sku = $1 AND warehouse_code = $2 AND outcome = 'applied'
+    `
+
+	var changedAt *time.Time
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, sku, warehouseCode).Scan(&changedAt)
+	return changedAt, err
+}
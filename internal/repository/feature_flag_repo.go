@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type FeatureFlagRepository interface {
+	Create(ctx context.Context, flag *models.FeatureFlag) error
+	GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error)
+	GetAll(ctx context.Context) ([]models.FeatureFlag, error)
+	Update(ctx context.Context, flag *models.FeatureFlag) error
+	Delete(ctx context.Context, key string) error
+}
+
+type featureFlagRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFeatureFlagRepository(db *pgxpool.Pool) FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+const featureFlagColumns = `key, description, enabled, rollout_percent, environments, created_at, updated_at`
+
+func scanFeatureFlag(row pgx.Row) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	err := row.Scan(
+		&flag.Key,
+		&flag.Description,
+		&flag.Enabled,
+		&flag.RolloutPercent,
+		&flag.Environments,
+		&flag.CreatedAt,
+		&flag.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *featureFlagRepository) Create(ctx context.Context, flag *models.FeatureFlag) error {
+	query := `
+        INSERT INTO feature_flags (key, description, enabled, rollout_percent, environments)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING created_at, updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		flag.Key,
+		flag.Description,
+		flag.Enabled,
+		flag.RolloutPercent,
+		flag.Environments,
+	).Scan(&flag.CreatedAt, &flag.UpdatedAt)
+}
+
+func (r *featureFlagRepository) GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	query := `SELECT ` + featureFlagColumns + ` FROM feature_flags WHERE key = $1`
+	return scanFeatureFlag(database.Conn(ctx, r.db).QueryRow(ctx, query, key))
+}
+
+func (r *featureFlagRepository) GetAll(ctx context.Context) ([]models.FeatureFlag, error) {
+	query := `SELECT ` + featureFlagColumns + ` FROM feature_flags ORDER BY key`
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flags := []models.FeatureFlag{}
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, *flag)
+	}
+
+	return flags, rows.Err()
+}
+
+func (r *featureFlagRepository) Update(ctx context.Context, flag *models.FeatureFlag) error {
+	query := `
+        UPDATE feature_flags
+        SET description = $1, enabled = $2, rollout_percent = $3, environments = $4
+        WHERE key = $5
+        RETURNING updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		flag.Description,
+		flag.Enabled,
+		flag.RolloutPercent,
+		flag.Environments,
+		flag.Key,
+	).Scan(&flag.UpdatedAt)
+}
+
+func (r *featureFlagRepository) Delete(ctx context.Context, key string) error {
+	query := `DELETE FROM feature_flags WHERE key = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, key)
+	return err
+}
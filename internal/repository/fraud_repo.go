@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type FraudRepository interface {
+	CountRecentOrdersByUser(ctx context.Context, userID uuid.UUID, within time.Duration) (int, error)
+	CountRecentOrdersByIP(ctx context.Context, clientIP string, within time.Duration) (int, error)
+	CountPriorOrders(ctx context.Context, userID uuid.UUID) (int, error)
+	CreateAssessment(ctx context.Context, assessment *models.RiskAssessment) error
+	GetAssessment(ctx context.Context, orderID uuid.UUID) (*models.RiskAssessment, error)
+	GetReviewQueue(ctx context.Context, page, limit int) ([]models.ReviewQueueItem, int, error)
+	RecordDecision(ctx context.Context, orderID uuid.UUID, decision, note string, reviewerID uuid.UUID) error
+}
+
+type fraudRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFraudRepository(db *pgxpool.Pool) FraudRepository {
+	return &fraudRepository{db: db}
+}
+
+func (r *fraudRepository) CountRecentOrdersByUser(ctx context.Context, userID uuid.UUID, within time.Duration) (int, error) {
+	query := `
+        SELECT COUNT(*) FROM orders
+        WHERE user_id = $1 AND created_at >= NOW() - $2::interval
+    `
+
+	var count int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID, within).Scan(&count)
+	return count, err
+}
+
+// CountRecentOrdersByIP counts screened orders placed from clientIP
+func (r *fraudRepository) CountRecentOrdersByIP(ctx context.Context, clientIP string, within time.Duration) (int, error) {
+	query := `
+        SELECT COUNT(*) FROM order_risk_assessments
+        WHERE client_ip = $1 AND created_at >= NOW() - $2::interval
+    `
+
+	var count int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, clientIP, within).Scan(&count)
+	return count, err
+}
+
+// CountPriorOrders counts the user's orders that were not cancelled
+func (r *fraudRepository) CountPriorOrders(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM orders WHERE user_id = $1 AND status <> 'cancelled'`
+
+	var count int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *fraudRepository) CreateAssessment(ctx context.Context, assessment *models.RiskAssessment) error {
+	query := `
+        INSERT INTO order_risk_assessments (order_id, user_id, client_ip, score, signals, held)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		assessment.OrderID,
+		assessment.UserID,
+		assessment.ClientIP,
+		assessment.Score,
+		assessment.Signals,
+		assessment.Held,
+	).Scan(&assessment.CreatedAt)
+}
+
+func (r *fraudRepository) GetAssessment(ctx context.Context, orderID uuid.UUID) (*models.RiskAssessment, error) {
+	query := `
+        SELECT order_id, user_id, client_ip, score, signals, held, COALESCE(decision, ''),
+               review_note, reviewed_by, reviewed_at, created_at
+        FROM order_risk_assessments
+        WHERE order_id = $1
+    `
+
+	var a models.RiskAssessment
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, orderID).Scan(
+		&a.OrderID,
+		&a.UserID,
+		&a.ClientIP,
+		&a.Score,
+		&a.Signals,
+		&a.Held,
+		&a.Decision,
+		&a.ReviewNote,
+		&a.ReviewedBy,
+		&a.ReviewedAt,
+		&a.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// GetReviewQueue lists orders waiting in review, oldest first
+func (r *fraudRepository) GetReviewQueue(ctx context.Context, page, limit int) ([]models.ReviewQueueItem, int, error) {
+	offset := (page - 1) * limit
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM orders WHERE status = 'under_review' AND ($1::uuid IS NULL OR store_id = $1)`
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, database.StoreArg(ctx)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT o.id, o.order_number, u.email, o.total_amount, o.payment_method,
+               (SELECT p.status FROM payments p WHERE p.order_id = o.id ORDER BY p.created_at DESC LIMIT 1),
+               COALESCE(ra.user_id, o.user_id), COALESCE(ra.client_ip, ''), COALESCE(ra.score, 0),
+               COALESCE(ra.signals, '[]'::jsonb), COALESCE(ra.held, TRUE), o.created_at
+        FROM orders o
+        JOIN users u ON u.id = o.user_id
+        LEFT JOIN order_risk_assessments ra ON ra.order_id = o.id
+        WHERE o.status = 'under_review' AND ($1::uuid IS NULL OR o.store_id = $1)
+        ORDER BY o.created_at
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, database.StoreArg(ctx), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.ReviewQueueItem{}
+	for rows.Next() {
+		var item models.ReviewQueueItem
+		err := rows.Scan(
+			&item.OrderID,
+			&item.OrderNumber,
+			&item.UserEmail,
+			&item.TotalAmount,
+			&item.PaymentMethod,
+			&item.PaymentStatus,
+			&item.Assessment.UserID,
+			&item.Assessment.ClientIP,
+			&item.Assessment.Score,
+			&item.Assessment.Signals,
+			&item.Assessment.Held,
+			&item.PlacedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		item.Assessment.OrderID = item.OrderID
+		item.Assessment.CreatedAt = item.PlacedAt
+		items = append(items, item)
+	}
+
+	return items, total, rows.Err()
+}
+
+func (r *fraudRepository) RecordDecision(ctx context.Context, orderID uuid.UUID, decision, note string, reviewerID uuid.UUID) error {
+	query := `
+        UPDATE order_risk_assessments
+        SET decision = $1, review_note = $2, reviewed_by = $3, reviewed_at = NOW()
+        WHERE order_id = $4
+    `
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, decision, note, reviewerID, orderID)
+	return err
+}
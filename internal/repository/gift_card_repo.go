@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GiftCardRepository interface {
+	Create(ctx context.Context, card *models.GiftCard) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.GiftCard, error)
+	GetByCode(ctx context.Context, code string) (*models.GiftCard, error)
+	GetAll(ctx context.Context, page, limit int) ([]models.GiftCard, int, error)
+	GetOrCreateStoreCredit(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCard, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.GiftCard, error)
+	Debit(ctx context.Context, id uuid.UUID, amount money.Money) error
+	Credit(ctx context.Context, id uuid.UUID, amount money.Money) error
+	AddTransaction(ctx context.Context, txn *models.GiftCardTransaction) error
+	GetTransactions(ctx context.Context, giftCardID uuid.UUID) ([]models.GiftCardTransaction, error)
+	GetOrderRedemptions(ctx context.Context, orderID uuid.UUID) (map[uuid.UUID]money.Money, error)
+}
+
+type giftCardRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewGiftCardRepository(db *pgxpool.Pool) GiftCardRepository {
+	return &giftCardRepository{db: db}
+}
+
+const giftCardColumns = `id, code, kind, user_id, initial_balance, balance, expires_at, COALESCE(note, ''), created_at, updated_at`
+
+func scanGiftCard(row pgx.Row) (*models.GiftCard, error) {
+	var card models.GiftCard
+	err := row.Scan(
+		&card.ID,
+		&card.Code,
+		&card.Kind,
+		&card.UserID,
+		&card.InitialBalance,
+		&card.Balance,
+		&card.ExpiresAt,
+		&card.Note,
+		&card.CreatedAt,
+		&card.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *giftCardRepository) Create(ctx context.Context, card *models.GiftCard) error {
+	query := `
+        INSERT INTO gift_cards (code, kind, user_id, initial_balance, balance, expires_at, note)
+        VALUES ($1, $2, $3, $4, $4, $5, NULLIF($6, ''))
+        RETURNING id, balance, created_at, updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		card.Code,
+		card.Kind,
+		card.UserID,
+		card.InitialBalance,
+		card.ExpiresAt,
+		card.Note,
+	).Scan(&card.ID, &card.Balance, &card.CreatedAt, &card.UpdatedAt)
+}
+
+func (r *giftCardRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.GiftCard, error) {
+	query := `SELECT ` + giftCardColumns + ` FROM gift_cards WHERE id = $1`
+	return scanGiftCard(database.Conn(ctx, r.db).QueryRow(ctx, query, id))
+}
+
+func (r *giftCardRepository) GetByCode(ctx context.Context, code string) (*models.GiftCard, error) {
+	query := `SELECT ` + giftCardColumns + ` FROM gift_cards WHERE code = $1`
+	return scanGiftCard(database.Conn(ctx, r.db).QueryRow(ctx, query, code))
+}
+
+func (r *giftCardRepository) GetAll(ctx context.Context, page, limit int) ([]models.GiftCard, int, error) {
+	offset := (page - 1) * limit
+
+	var total int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, `SELECT COUNT(*) FROM gift_cards`).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT ` + giftCardColumns + `
+        FROM gift_cards
+        ORDER BY created_at DESC
+        LIMIT $1 OFFSET $2
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var cards []models.GiftCard
+	for rows.Next() {
+		card, err := scanGiftCard(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		cards = append(cards, *card)
+	}
+
+	return cards, total, rows.Err()
+}
+
+func (r *giftCardRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.GiftCard, error) {
+	query := `
+        SELECT ` + giftCardColumns + `
+        FROM gift_cards
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []models.GiftCard
+	for rows.Next() {
+		card, err := scanGiftCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, *card)
+	}
+
+	return cards, rows.Err()
+}
+
+// GetOrCreateStoreCredit returns the user's store credit account, opening an
+// empty one under code if they do not have one yet
+func (r *giftCardRepository) GetOrCreateStoreCredit(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCard, error) {
+	insertQuery := `
+        INSERT INTO gift_cards (code, kind, user_id, initial_balance, balance)
+        VALUES ($1, 'store_credit', $2, 0, 0)
+        ON CONFLICT (user_id) WHERE kind = 'store_credit' DO NOTHING
+    `
+	if _, err := database.Conn(ctx, r.db).Exec(ctx, insertQuery, code, userID); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + giftCardColumns + ` FROM gift_cards WHERE user_id = $1 AND kind = 'store_credit'`
+	return scanGiftCard(database.Conn(ctx, r.db).QueryRow(ctx, query, userID))
+}
+
+// Debit takes amount off the balance, failing if the card has expired or the
+// balance would go negative
+func (r *giftCardRepository) Debit(ctx context.Context, id uuid.UUID, amount money.Money) error {
+	query := `
+        UPDATE gift_cards
+        SET balance = balance - $2
+        WHERE id = $1 AND balance >= $2
+            AND (expires_at IS NULL OR expires_at > NOW())
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id, amount)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Validation("insufficient gift card balance")
+	}
+
+	return nil
+}
+
+func (r *giftCardRepository) Credit(ctx context.Context, id uuid.UUID, amount money.Money) error {
+	query := `UPDATE gift_cards SET balance = balance + $2 WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id, amount)
+	return err
+}
+
+func (r *giftCardRepository) AddTransaction(ctx context.Context, txn *models.GiftCardTransaction) error {
+	query := `
+        INSERT INTO gift_card_transactions (gift_card_id, type, amount, order_id, return_id)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		txn.GiftCardID,
+		txn.Type,
+		txn.Amount,
+		txn.OrderID,
+		txn.ReturnID,
+	).Scan(&txn.ID, &txn.CreatedAt)
+}
+
+func (r *giftCardRepository) GetTransactions(ctx context.Context, giftCardID uuid.UUID) ([]models.GiftCardTransaction, error) {
+	query := `
+        SELECT id, gift_card_id, type, amount, order_id, return_id, created_at
+        FROM gift_card_transactions
+        WHERE gift_card_id = $1
+        ORDER BY created_at DESC
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, giftCardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := []models.GiftCardTransaction{}
+	for rows.Next() {
+		var txn models.GiftCardTransaction
+		err := rows.Scan(
+			&txn.ID,
+			&txn.GiftCardID,
+			&txn.Type,
+			&txn.Amount,
+			&txn.OrderID,
+			&txn.ReturnID,
+			&txn.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, txn)
+	}
+
+	return transactions, rows.Err()
+}
+
+// GetOrderRedemptions returns, per card, how much of an order's gift card
+// tender is still outstanding (redeemed minus already reversed)
+func (r *giftCardRepository) GetOrderRedemptions(ctx context.Context, orderID uuid.UUID) (map[uuid.UUID]money.Money, error) {
+	query := `
+        SELECT gift_card_id, -SUM(amount)
+        FROM gift_card_transactions
+        WHERE order_id = $1 AND type IN ('redeem', 'reversal')
+        GROUP BY gift_card_id
+        HAVING SUM(amount) < 0
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	redemptions := make(map[uuid.UUID]money.Money)
+	for rows.Next() {
+		var cardID uuid.UUID
+		var amount money.Money
+		if err := rows.Scan(&cardID, &amount); err != nil {
+			return nil, err
+		}
+		redemptions[cardID] = amount
+	}
+
+	return redemptions, rows.Err()
+}
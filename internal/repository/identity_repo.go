@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdentityRepository interface {
+	Create(ctx context.Context, identity *models.UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	TouchLogin(ctx context.Context, id uuid.UUID, email string) error
+}
+
+type identityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdentityRepository(db *pgxpool.Pool) IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+const identityColumns = `id, user_id, provider, subject, COALESCE(email, ''), created_at, last_login_at`
+
+func scanIdentity(row pgx.Row) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *identityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	query := `
+        INSERT INTO user_identities (store_id, user_id, provider, subject, email)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+        RETURNING id, created_at, last_login_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		storeID(ctx),
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).Scan(&identity.ID, &identity.CreatedAt, &identity.LastLoginAt)
+}
+
+func (r *identityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	query := `
+        SELECT ` + identityColumns + `
+        FROM user_identities
+        WHERE store_id = $1 AND provider = $2 AND subject = $3
+    `
+
+	identity, err := scanIdentity(database.Conn(ctx, r.db).QueryRow(ctx, query, storeID(ctx), provider, subject))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return identity, err
+}
+
+// TouchLogin records a sign-in and the email the provider reports now
+func (r *identityRepository) TouchLogin(ctx context.Context, id uuid.UUID, email string) error {
+	query := `
+        UPDATE user_identities
+        SET last_login_at = NOW(), email = COALESCE(NULLIF($2, ''), email)
+        WHERE id = $1
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id, email)
+	return err
+}
@@ -0,0 +1,105 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/internal/testdb"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testdb.Main(m))
+}
+
+// fixture holds the repositories the tests and benchmarks exercise, on the
+// test database
+type fixture struct {
+	db        *pgxpool.Pool
+	txManager database.TxManager
+	products  repository.ProductRepository
+	carts     repository.CartRepository
+	users     repository.UserRepository
+}
+
+func newFixture(tb testing.TB) *fixture {
+	tb.Helper()
+
+	db := testdb.Pool(tb)
+	return &fixture{
+		db:        db,
+		txManager: database.NewTxManager(db),
+		products:  repository.NewProductRepository(db, nil),
+		carts:     repository.NewCartRepository(db),
+		users:     repository.NewUserRepository(db),
+	}
+}
+
+// product adds an active product priced at 10.00 with stock units
+func (f *fixture) product(tb testing.TB, stock int) *models.Product {
+	tb.Helper()
+
+	product := &models.Product{
+		SKU:    "TEST-" + uuid.NewString(),
+		Name:   "Test product",
+		Price:  money.FromMajor(10),
+		Stock:  stock,
+		Status: models.ProductActive,
+	}
+	if err := f.products.Create(context.Background(), product); err != nil {
+		tb.Fatalf("failed to create product: %v", err)
+	}
+
+	return product
+}
+
+// cart creates a customer with an empty cart
+func (f *fixture) cart(tb testing.TB) *models.Cart {
+	tb.Helper()
+	ctx := context.Background()
+
+	user := &models.User{
+		Email:     "customer-" + uuid.NewString() + "@example.com",
+		FirstName: "Test",
+		LastName:  "Customer",
+		Role:      models.RoleCustomer,
+	}
+	if err := f.users.Create(ctx, user); err != nil {
+		tb.Fatalf("failed to create user: %v", err)
+	}
+
+	cart, err := f.carts.Create(ctx, user.ID)
+	if err != nil {
+		tb.Fatalf("failed to create cart: %v", err)
+	}
+
+	return cart
+}
+
+// reserve sets the cart's reservation of the product, lasting until
+// expiresAt, in a transaction of its own as the cart service does
+func (f *fixture) reserve(productID, cartID uuid.UUID, quantity int, expiresAt int64) error {
+	return f.txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		return f.products.SetReservationQuantity(ctx, productID, cartID, nil, quantity, expiresAt)
+	})
+}
+
+func (f *fixture) available(tb testing.TB, productID uuid.UUID) int {
+	tb.Helper()
+
+	available, err := f.products.GetAvailableStock(context.Background(), productID, nil)
+	if err != nil {
+		tb.Fatalf("GetAvailableStock: %v", err)
+	}
+
+	return available
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int, unreadOnly bool) ([]models.Notification, int, int, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID) (*models.Notification, error)
+}
+
+type notificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	query := `
+        INSERT INTO notifications (id, user_id, type, title, message, data)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		notification.ID,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		notification.Data,
+	).Scan(&notification.CreatedAt)
+}
+
+// GetByUserID returns a page of the user's notifications, newest first, with
+// the total matching count and the user's overall unread count
+func (r *notificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int, unreadOnly bool) ([]models.Notification, int, int, error) {
+	offset := (page - 1) * limit
+
+	countQuery := `
+        SELECT
+            COUNT(*) FILTER (WHERE NOT $2 OR read_at IS NULL),
+            COUNT(*) FILTER (WHERE read_at IS NULL)
+        FROM notifications
+        WHERE user_id = $1
+    `
+	var total, unread int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, userID, unreadOnly).Scan(&total, &unread)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	query := `
+        SELECT id, user_id, type, title, message, data, read_at, created_at
+        FROM notifications
+        WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+        ORDER BY created_at DESC
+        LIMIT $3 OFFSET $4
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var notification models.Notification
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Message,
+			&notification.Data,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, total, unread, rows.Err()
+}
+
+// MarkRead stamps the notification as read if it belongs to userID; reading an
+// already-read notification keeps the original timestamp
+func (r *notificationRepository) MarkRead(ctx context.Context, id, userID uuid.UUID) (*models.Notification, error) {
+	query := `
+        UPDATE notifications
+        SET read_at = COALESCE(read_at, NOW())
+        WHERE id = $1 AND user_id = $2
+        RETURNING id, user_id, type, title, message, data, read_at, created_at
+    `
+
+	var notification models.Notification
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id, userID).Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Type,
+		&notification.Title,
+		&notification.Message,
+		&notification.Data,
+		&notification.ReadAt,
+		&notification.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
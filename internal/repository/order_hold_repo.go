@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OrderHoldRepository interface {
+	Create(ctx context.Context, hold *models.OrderHold) error
+	GetActive(ctx context.Context, orderID uuid.UUID) (*models.OrderHold, error)
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.OrderHold, error)
+	GetActiveHolds(ctx context.Context, status models.OrderStatus, page, limit int) ([]models.OrderHold, int, error)
+	ReleaseActive(ctx context.Context, orderID uuid.UUID, releasedBy *uuid.UUID, note string) error
+}
+
+type orderHoldRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOrderHoldRepository(db *pgxpool.Pool) OrderHoldRepository {
+	return &orderHoldRepository{db: db}
+}
+
+const orderHoldColumns = `h.id, h.order_id, o.order_number, h.status, h.previous_status, h.reason,
+        h.placed_by, h.placed_at, h.released_by, h.released_at, h.release_note`
+
+func scanOrderHold(row pgx.Row) (*models.OrderHold, error) {
+	var hold models.OrderHold
+	err := row.Scan(
+		&hold.ID,
+		&hold.OrderID,
+		&hold.OrderNumber,
+		&hold.Status,
+		&hold.PreviousStatus,
+		&hold.Reason,
+		&hold.PlacedBy,
+		&hold.PlacedAt,
+		&hold.ReleasedBy,
+		&hold.ReleasedAt,
+		&hold.ReleaseNote,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *orderHoldRepository) Create(ctx context.Context, hold *models.OrderHold) error {
+	query := `
+        INSERT INTO order_holds (order_id, status, previous_status, reason, placed_by)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, placed_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		hold.OrderID,
+		hold.Status,
+		hold.PreviousStatus,
+		hold.Reason,
+		hold.PlacedBy,
+	).Scan(&hold.ID, &hold.PlacedAt)
+}
+
+func (r *orderHoldRepository) GetActive(ctx context.Context, orderID uuid.UUID) (*models.OrderHold, error) {
+	query := `
+        SELECT ` + orderHoldColumns + `
+        FROM order_holds h
+        JOIN orders o ON o.id = h.order_id
+        WHERE h.order_id = $1 AND h.released_at IS NULL
+    `
+	return scanOrderHold(database.Conn(ctx, r.db).QueryRow(ctx, query, orderID))
+}
+
+// GetByOrderID returns an order's hold history, newest first
+func (r *orderHoldRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.OrderHold, error) {
+	query := `
+        SELECT ` + orderHoldColumns + `
+        FROM order_holds h
+        JOIN orders o ON o.id = h.order_id
+        WHERE h.order_id = $1
+        ORDER BY h.placed_at DESC
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds := []models.OrderHold{}
+	for rows.Next() {
+		hold, err := scanOrderHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, *hold)
+	}
+
+	return holds, rows.Err()
+}
+
+// GetActiveHolds lists unreleased holds, oldest first, optionally of one
+// status only
+func (r *orderHoldRepository) GetActiveHolds(ctx context.Context, status models.OrderStatus, page, limit int) ([]models.OrderHold, int, error) {
+	offset := (page - 1) * limit
+
+	where := &database.Where{}
+	where.And("h.released_at IS NULL")
+	where.ScopeStore(ctx, "o.store_id")
+	if status != "" {
+		where.And("h.status = ?", status)
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM order_holds h JOIN orders o ON o.id = h.order_id " + where.String()
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, where.Args()...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT ` + orderHoldColumns + `
+        FROM order_holds h
+        JOIN orders o ON o.id = h.order_id
+        ` + where.String() + `
+        ORDER BY h.placed_at
+        LIMIT ` + where.Bind(limit) + ` OFFSET ` + where.Bind(offset)
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	holds := []models.OrderHold{}
+	for rows.Next() {
+		hold, err := scanOrderHold(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		holds = append(holds, *hold)
+	}
+
+	return holds, total, rows.Err()
+}
+
+// ReleaseActive closes the order's active hold, if it has one
+func (r *orderHoldRepository) ReleaseActive(ctx context.Context, orderID uuid.UUID, releasedBy *uuid.UUID, note string) error {
+	query := `
+        UPDATE order_holds
+        SET released_by = $1, released_at = NOW(), release_note = $2
+        WHERE order_id = $3 AND released_at IS NULL
+    `
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, releasedBy, note, orderID)
+	return err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OrderMessageRepository interface {
+	Create(ctx context.Context, message *models.OrderMessage) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.OrderMessage, error)
+	MarkRead(ctx context.Context, orderID uuid.UUID, fromStaff bool) error
+	GetUnread(ctx context.Context, userID *uuid.UUID, fromStaff bool) ([]models.UnreadOrderMessages, error)
+}
+
+type orderMessageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOrderMessageRepository(db *pgxpool.Pool) OrderMessageRepository {
+	return &orderMessageRepository{db: db}
+}
+
+func (r *orderMessageRepository) Create(ctx context.Context, message *models.OrderMessage) error {
+	query := `
+        INSERT INTO order_messages (id, order_id, sender_id, from_staff, body)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		message.ID,
+		message.OrderID,
+		message.SenderID,
+		message.FromStaff,
+		message.Body,
+	).Scan(&message.CreatedAt)
+}
+
+// GetByOrderID returns the order's thread, oldest first
+func (r *orderMessageRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.OrderMessage, error) {
+	query := `
+        SELECT id, order_id, sender_id, from_staff, body, read_at, created_at
+        FROM order_messages
+        WHERE order_id = $1
+        ORDER BY created_at, id
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []models.OrderMessage{}
+	for rows.Next() {
+		var message models.OrderMessage
+		err := rows.Scan(
+			&message.ID,
+			&message.OrderID,
+			&message.SenderID,
+			&message.FromStaff,
+			&message.Body,
+			&message.ReadAt,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// MarkRead stamps the unread messages on the order written by one side
+// (support when fromStaff, otherwise the customer) and returns how many
+func (r *orderMessageRepository) MarkRead(ctx context.Context, orderID uuid.UUID, fromStaff bool) error {
+	query := `
+        UPDATE order_messages
+        SET read_at = NOW()
+        WHERE order_id = $1 AND from_staff = $2 AND read_at IS NULL
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, orderID, fromStaff)
+	return err
+}
+
+// GetUnread counts unread messages written by one side per order, most
+// recently active first. A nil userID covers every customer's orders.
+func (r *orderMessageRepository) GetUnread(ctx context.Context, userID *uuid.UUID, fromStaff bool) ([]models.UnreadOrderMessages, error) {
+	query := `
+        SELECT o.id, o.order_number, COUNT(*), MAX(m.created_at)
+        FROM order_messages m
+        JOIN orders o ON o.id = m.order_id
+        WHERE m.read_at IS NULL AND m.from_staff = $1
+          AND ($2::uuid IS NULL OR o.user_id = $2)
+        GROUP BY o.id, o.order_number
+        ORDER BY MAX(m.created_at) DESC
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, fromStaff, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unread := []models.UnreadOrderMessages{}
+	for rows.Next() {
+		var entry models.UnreadOrderMessages
+		if err := rows.Scan(&entry.OrderID, &entry.OrderNumber, &entry.UnreadCount, &entry.LastMessageAt); err != nil {
+			return nil, err
+		}
+
+		unread = append(unread, entry)
+	}
+
+	return unread, rows.Err()
+}
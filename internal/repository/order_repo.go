@@ -5,8 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/pagination"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -15,112 +21,267 @@ import (
 
 type OrderRepository interface {
 	Create(ctx context.Context, order *models.Order) error
-	CreateWithTx(ctx context.Context, tx pgx.Tx, order *models.Order) error
+	NextOrderNumber(ctx context.Context) (prefix string, seq int64, err error)
+	Import(ctx context.Context, order *models.Order) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Order, error)
 	GetAdminByID(ctx context.Context, id uuid.UUID) (*models.AdminOrder, error)
 	GetByOrderNumber(ctx context.Context, orderNumber string) (*models.Order, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]models.Order, int, error)
-	GetAll(ctx context.Context, page, limit int, status string, rangeDays int) ([]models.AdminOrder, int, error)
+	GetPageByUserID(ctx context.Context, userID uuid.UUID, after *pagination.Cursor, limit int) ([]models.Order, *pagination.Cursor, error)
+	LoadItems(ctx context.Context, orders []models.Order) error
+	GetAll(ctx context.Context, page, limit int, filter models.OrderFilter) ([]models.AdminOrder, int, error)
+	ExportAll(ctx context.Context, filter models.OrderFilter, fn func(models.OrderExportRow) error) error
 	GetRecent(ctx context.Context, limit, rangeDays int) ([]models.AdminOrder, error)
 	GetAnalytics(ctx context.Context, rangeDays int) (*models.AdminAnalytics, error)
+	GetCustomerAnalytics(ctx context.Context, rangeDays, topLimit int) (*models.CustomerAnalytics, error)
+	GetMarginReport(ctx context.Context, rangeDays int, period string) (*models.MarginReport, error)
+	GetPromotionAnalytics(ctx context.Context, rangeDays int) (*models.PromotionAnalytics, error)
+	GetPickList(ctx context.Context, filter models.PickListFilter) ([]models.PickListLine, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.OrderStatus) error
+	UpdateStatusFrom(ctx context.Context, id uuid.UUID, from, to models.OrderStatus) error
 	CancelOrder(ctx context.Context, id uuid.UUID) error
-	BeginTx(ctx context.Context) (pgx.Tx, error)
+	GetPurchasedQuantity(ctx context.Context, userID, productID uuid.UUID) (int, error)
+	SetGiftCardAmount(ctx context.Context, id uuid.UUID, amount money.Money) error
+	UpdateItemFulfillment(ctx context.Context, orderID, itemID uuid.UUID, status models.FulfillmentStatus, trackingNumber *string) error
+	GetByTrackingNumber(ctx context.Context, trackingNumber string) (*models.Order, error)
+	SetCarrierStatus(ctx context.Context, orderID uuid.UUID, trackingNumber string, status models.CarrierStatus, at time.Time) error
+	SetItemsFulfillment(ctx context.Context, orderID uuid.UUID, from []models.FulfillmentStatus, to models.FulfillmentStatus) error
+	CountOpenByUser(ctx context.Context, userID uuid.UUID) (int, error)
+	GetUnpaidBefore(ctx context.Context, unpaidFor time.Duration, limit int) ([]uuid.UUID, error)
+	GetUnauthorizedBefore(ctx context.Context, unauthorizedFor time.Duration, limit int) ([]uuid.UUID, error)
+	SetEstimatedDelivery(ctx context.Context, id uuid.UUID, date time.Time) error
+	AnonymizeUser(ctx context.Context, userID, toUserID uuid.UUID) error
+	AddTags(ctx context.Context, id uuid.UUID, tags []string) ([]string, error)
+	RemoveTag(ctx context.Context, id uuid.UUID, tag string) ([]string, error)
 }
 
+// Analytics read from replica when one is configured and up; everything else
+// uses db
 type orderRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	replica *database.Replica
 }
 
-func NewOrderRepository(db *pgxpool.Pool) OrderRepository {
-	return &orderRepository{db: db}
-}
-
-func (r *orderRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
-	return r.db.Begin(ctx)
+func NewOrderRepository(db *pgxpool.Pool, replica *database.Replica) OrderRepository {
+	return &orderRepository{db: db, replica: replica}
 }
 
+// Create inserts the order and its items atomically. Inside a unit of work the
+// inserts run in a savepoint of the caller's transaction. It fails with a
+// conflict, leaving the transaction usable, when the order number is taken.
 func (r *orderRepository) Create(ctx context.Context, order *models.Order) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	err = r.CreateWithTx(ctx, tx, order)
+	// Insert order
+	orderQuery := `
+        INSERT INTO orders (id, store_id, user_id, order_number, total_amount, discount_amount, status,
+                          payment_method, shipping_method, shipping_address, billing_address)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (order_number) DO NOTHING
+    `
+
+	tag, err := tx.Exec(ctx, orderQuery,
+		order.ID,
+		storeID(ctx),
+		order.UserID,
+		order.OrderNumber,
+		order.TotalAmount,
+		order.DiscountAmount,
+		order.Status,
+		order.PaymentMethod,
+		order.ShippingMethod,
+		order.ShippingAddress,
+		order.BillingAddress,
+	)
+
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.Conflict("order number already exists")
+	}
+
+	// Insert order items, recording the product's current cost price
+	itemQuery := `
+        INSERT INTO order_items (id, order_id, product_id, variant_id, quantity, price_at_time, cost_at_time,
+                                 bundle_components)
+        VALUES ($1, $2, $3, $4, $5, $6, (SELECT cost_price FROM products WHERE id = $3), $7)
+    `
+
+	// Keeps the items' IDs, which warehouse allocations refer to
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.ID == uuid.Nil {
+			item.ID = uuid.New()
+		}
+		_, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			order.ID,
+			item.ProductID,
+			item.VariantID,
+			item.Quantity,
+			item.PriceAtTime,
+			item.BundleComponents,
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to create order item: %w", err)
+		}
+	}
+
+	promotionQuery := `
+        INSERT INTO order_promotions (order_id, promotion_id, name, amount)
+        VALUES ($1, $2, $3, $4)
+    `
+	for _, promotion := range order.Promotions {
+		_, err := tx.Exec(ctx, promotionQuery, order.ID, promotion.PromotionID, promotion.Name, promotion.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to record order promotion: %w", err)
+		}
 	}
 
 	return tx.Commit(ctx)
 }
 
-func (r *orderRepository) CreateWithTx(ctx context.Context, tx pgx.Tx, order *models.Order) error {
-	// Insert order
+// getPromotions loads the promotions an order was placed with
+func (r *orderRepository) getPromotions(ctx context.Context, orderID uuid.UUID) ([]models.AppliedPromotion, error) {
+	query := `
+        SELECT promotion_id, name, amount
+        FROM order_promotions
+        WHERE order_id = $1
+        ORDER BY amount DESC
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := []models.AppliedPromotion{}
+	for rows.Next() {
+		var promotion models.AppliedPromotion
+		if err := rows.Scan(&promotion.PromotionID, &promotion.Name, &promotion.Amount); err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, rows.Err()
+}
+
+// NextOrderNumber draws the next order number sequence value and returns it
+// with the prefix of the context's store
+func (r *orderRepository) NextOrderNumber(ctx context.Context) (string, int64, error) {
+	query := `
+        SELECT COALESCE((SELECT order_number_prefix FROM stores WHERE id = $1), $2),
+               nextval('order_number_seq')
+    `
+
+	var prefix string
+	var seq int64
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, storeID(ctx), models.DefaultOrderNumberPrefix).Scan(&prefix, &seq)
+	return prefix, seq, err
+}
+
+// Import inserts a historical order as given: its order number, status,
+// timestamps and item fulfillment statuses are kept. It fails with a conflict
+// when the order number is taken, so re-running an import skips orders
+// already brought over.
+func (r *orderRepository) Import(ctx context.Context, order *models.Order) error {
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	orderQuery := `
-        INSERT INTO orders (id, user_id, order_number, total_amount, status, payment_method,
-                          shipping_address, billing_address)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-        RETURNING created_at, updated_at
+        INSERT INTO orders (id, store_id, user_id, order_number, total_amount, status, payment_method,
+                          shipping_method, shipping_address, billing_address, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+        ON CONFLICT (order_number) DO NOTHING
     `
 
-	_, err := tx.Exec(ctx, orderQuery,
+	tag, err := tx.Exec(ctx, orderQuery,
 		order.ID,
+		storeID(ctx),
 		order.UserID,
 		order.OrderNumber,
 		order.TotalAmount,
 		order.Status,
 		order.PaymentMethod,
+		order.ShippingMethod,
 		order.ShippingAddress,
 		order.BillingAddress,
+		order.CreatedAt,
+		order.UpdatedAt,
 	)
-
 	if err != nil {
-		return fmt.Errorf("failed to create order: %w", err)
+		return fmt.Errorf("failed to import order: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.Conflict("order number already exists")
 	}
 
-	// Insert order items
+	// Costs were not recorded by the legacy platform; today's cost price is
+	// the best estimate for margin reporting
 	itemQuery := `
-        INSERT INTO order_items (id, order_id, product_id, quantity, price_at_time)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO order_items (id, order_id, product_id, variant_id, quantity, price_at_time, cost_at_time,
+                                 fulfillment_status, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, (SELECT cost_price FROM products WHERE id = $3), $7, $8)
     `
 
-	for _, item := range order.Items {
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.ID == uuid.Nil {
+			item.ID = uuid.New()
+		}
 		_, err := tx.Exec(ctx, itemQuery,
-			uuid.New(),
+			item.ID,
 			order.ID,
 			item.ProductID,
+			item.VariantID,
 			item.Quantity,
 			item.PriceAtTime,
+			item.FulfillmentStatus,
+			order.CreatedAt,
 		)
-
 		if err != nil {
-			return fmt.Errorf("failed to create order item: %w", err)
+			return fmt.Errorf("failed to import order item: %w", err)
 		}
 	}
 
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Order, error) {
 	// Get order
 	orderQuery := `
-        SELECT id, user_id, order_number, total_amount, status, payment_method,
-               shipping_address, billing_address, created_at, updated_at
+        SELECT id, user_id, order_number, total_amount, gift_card_amount, discount_amount, status,
+               payment_method, shipping_method, shipping_address, billing_address, estimated_delivery,
+               created_at, updated_at
         FROM orders
-        WHERE id = $1
+        WHERE id = $1 AND ($2::uuid IS NULL OR store_id = $2)
     `
 
 	var order models.Order
-	err := r.db.QueryRow(ctx, orderQuery, id).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, orderQuery, id, database.StoreArg(ctx)).Scan(
 		&order.ID,
 		&order.UserID,
 		&order.OrderNumber,
 		&order.TotalAmount,
+		&order.GiftCardAmount,
+		&order.DiscountAmount,
 		&order.Status,
 		&order.PaymentMethod,
+		&order.ShippingMethod,
 		&order.ShippingAddress,
 		&order.BillingAddress,
+		&order.EstimatedDelivery,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
@@ -133,84 +294,46 @@ func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Or
 		return nil, err
 	}
 
-	// Get order items
-	itemsQuery := `
-        SELECT 
-            oi.id, oi.order_id, oi.product_id, oi.quantity, oi.price_at_time, oi.created_at,
-            p.id, p.sku, p.name, p.description, p.price, p.stock_quantity, 
-            p.category, p.image_url, p.created_at, p.updated_at
-        FROM order_items oi
-        JOIN products p ON oi.product_id = p.id
-        WHERE oi.order_id = $1
-        ORDER BY oi.created_at
-    `
-
-	rows, err := r.db.Query(ctx, itemsQuery, order.ID)
-	if err != nil {
+	if order.Promotions, err = r.getPromotions(ctx, order.ID); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var items []models.OrderItem
-	for rows.Next() {
-		var item models.OrderItem
-		var product models.Product
-
-		err := rows.Scan(
-			&item.ID,
-			&item.OrderID,
-			&item.ProductID,
-			&item.Quantity,
-			&item.PriceAtTime,
-			&item.CreatedAt,
-			&product.ID,
-			&product.SKU,
-			&product.Name,
-			&product.Description,
-			&product.Price,
-			&product.Stock,
-			&product.Category,
-			&product.ImageURL,
-			&product.CreatedAt,
-			&product.UpdatedAt,
-		)
-
-		if err != nil {
-			return nil, err
-		}
 
-		item.Product = product
-		items = append(items, item)
+	orders := []models.Order{order}
+	if err := r.LoadItems(ctx, orders); err != nil {
+		return nil, err
 	}
-
-	order.Items = items
-	return &order, nil
+	return &orders[0], nil
 }
 
 func (r *orderRepository) GetAdminByID(ctx context.Context, id uuid.UUID) (*models.AdminOrder, error) {
 	fmt.Printf("[ORDER REPO] GetAdminByID called for orderID: %s\n", id.String())
 	query := `
         SELECT 
-            o.id, o.user_id, o.order_number, o.total_amount, o.status, o.payment_method,
-            o.shipping_address, o.billing_address,
-            o.created_at, o.updated_at,
+            o.id, o.user_id, o.order_number, o.total_amount, o.gift_card_amount, o.discount_amount, o.status,
+            o.payment_method, o.shipping_method, o.shipping_address, o.billing_address, o.estimated_delivery,
+            o.tags, o.created_at, o.updated_at,
             u.id, u.email
         FROM orders o
         JOIN users u ON o.user_id = u.id
-        WHERE o.id = $1
+        WHERE o.id = $1 AND ($2::uuid IS NULL OR o.store_id = $2)
     `
 
 	var order models.AdminOrder
 	var shippingJSON, billingJSON []byte
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id, database.StoreArg(ctx)).Scan(
 		&order.ID,
 		&order.UserID,
 		&order.OrderNumber,
 		&order.TotalAmount,
+		&order.GiftCardAmount,
+		&order.DiscountAmount,
 		&order.Status,
 		&order.PaymentMethod,
+		&order.ShippingMethod,
 		&shippingJSON,
 		&billingJSON,
+		&order.EstimatedDelivery,
+		&order.Tags,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 		&order.User.ID,
@@ -240,28 +363,37 @@ func (r *orderRepository) GetAdminByID(ctx context.Context, id uuid.UUID) (*mode
 
 	fmt.Printf("[ORDER REPO SUCCESS] Order found: %s\n", order.OrderNumber)
 
+	if order.Promotions, err = r.getPromotions(ctx, order.ID); err != nil {
+		return nil, err
+	}
+
 	itemsQuery := `
-        SELECT oi.order_id, oi.product_id, oi.quantity, oi.price_at_time,
-               p.name, p.sku
+        SELECT oi.id, oi.product_id, oi.quantity, oi.price_at_time,
+               p.name, p.sku, oi.variant_id, v.sku, oi.fulfillment_status, oi.tracking_number,
+               oi.bundle_components, p.weight_grams, p.length_mm, p.width_mm, p.height_mm
         FROM order_items oi
         JOIN products p ON oi.product_id = p.id
+        LEFT JOIN product_variants v ON oi.variant_id = v.id
         WHERE oi.order_id = $1
         ORDER BY oi.created_at
     `
 
-	rows, err := r.db.Query(ctx, itemsQuery, order.ID)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, itemsQuery, order.ID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	order.Fulfillment = models.FulfillmentSummary{}
 	for rows.Next() {
 		var item models.AdminOrderItem
-		var orderID uuid.UUID
-		if err := rows.Scan(&orderID, &item.ProductID, &item.Quantity, &item.PriceAtTime, &item.ProductName, &item.ProductSKU); err != nil {
+		var length, width, height *int
+		if err := rows.Scan(&item.ID, &item.ProductID, &item.Quantity, &item.PriceAtTime, &item.ProductName, &item.ProductSKU, &item.VariantID, &item.VariantSKU, &item.FulfillmentStatus, &item.TrackingNumber, &item.BundleComponents, &item.WeightGrams, &length, &width, &height); err != nil {
 			return nil, err
 		}
+		item.Dimensions = dimensionsFrom(length, width, height)
 		order.Items = append(order.Items, item)
+		order.Fulfillment[item.FulfillmentStatus]++
 	}
 
 	return &order, nil
@@ -269,22 +401,25 @@ func (r *orderRepository) GetAdminByID(ctx context.Context, id uuid.UUID) (*mode
 
 func (r *orderRepository) GetByOrderNumber(ctx context.Context, orderNumber string) (*models.Order, error) {
 	orderQuery := `
-        SELECT id, user_id, order_number, total_amount, status, payment_method,
-               shipping_address, billing_address, created_at, updated_at
+        SELECT id, user_id, order_number, total_amount, gift_card_amount, status, payment_method,
+               shipping_method, shipping_address, billing_address, estimated_delivery, created_at, updated_at
         FROM orders
-        WHERE order_number = $1
+        WHERE order_number = $1 AND ($2::uuid IS NULL OR store_id = $2)
     `
 
 	var order models.Order
-	err := r.db.QueryRow(ctx, orderQuery, orderNumber).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, orderQuery, orderNumber, database.StoreArg(ctx)).Scan(
 		&order.ID,
 		&order.UserID,
 		&order.OrderNumber,
 		&order.TotalAmount,
+		&order.GiftCardAmount,
 		&order.Status,
 		&order.PaymentMethod,
+		&order.ShippingMethod,
 		&order.ShippingAddress,
 		&order.BillingAddress,
+		&order.EstimatedDelivery,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
@@ -306,14 +441,14 @@ func (r *orderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, pag
 	// Count total orders
 	countQuery := `SELECT COUNT(*) FROM orders WHERE user_id = $1`
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, userID).Scan(&total)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, userID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get orders with pagination
 	ordersQuery := `
-        SELECT id, user_id, order_number, total_amount, status, payment_method,
+        SELECT id, user_id, order_number, total_amount, gift_card_amount, status, payment_method,
                shipping_address, billing_address, created_at, updated_at
         FROM orders
         WHERE user_id = $1
@@ -321,7 +456,7 @@ func (r *orderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, pag
         LIMIT $2 OFFSET $3
     `
 
-	rows, err := r.db.Query(ctx, ordersQuery, userID, limit, offset)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, ordersQuery, userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -335,6 +470,7 @@ func (r *orderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, pag
 			&order.UserID,
 			&order.OrderNumber,
 			&order.TotalAmount,
+			&order.GiftCardAmount,
 			&order.Status,
 			&order.PaymentMethod,
 			&order.ShippingAddress,
@@ -353,29 +489,239 @@ func (r *orderRepository) GetByUserID(ctx context.Context, userID uuid.UUID, pag
 	return orders, total, nil
 }
 
-func (r *orderRepository) GetAll(ctx context.Context, page, limit int, status string, rangeDays int) ([]models.AdminOrder, int, error) {
-	offset := (page - 1) * limit
+// orderItemsQuery loads the items of a set of orders with their products and
+// variants. Every order view runs it, so it is prepared on each connection.
+var orderItemsQuery = database.Statement("order_items_by_order", `
+        SELECT 
+            oi.id, oi.order_id, oi.product_id, oi.quantity, oi.price_at_time,
+            oi.fulfillment_status, oi.tracking_number, oi.shipped_at, oi.delivered_at,
+            oi.carrier_status, oi.carrier_status_at, oi.created_at, oi.bundle_components,
+            p.id, p.sku, p.name, p.description, p.price, p.stock_quantity, 
+            p.category, p.image_url, p.created_at, p.updated_at,
+            v.id, v.sku, v.price, v.stock_quantity, v.attributes
+        FROM order_items oi
+        JOIN products p ON oi.product_id = p.id
+        LEFT JOIN product_variants v ON oi.variant_id = v.id
+        WHERE oi.order_id = ANY($1)
+        ORDER BY oi.created_at
+    `)
 
-	// Build WHERE clause
-	whereClause := "WHERE 1=1"
-	args := []interface{}{}
-	argCount := 1
+// LoadItems fills in the items, with their products and variants, and the
+// fulfillment summary of every order in one query, so list endpoints can
+// include items without a query per order
+func (r *orderRepository) LoadItems(ctx context.Context, orders []models.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
 
-	if status != "" {
-		whereClause += fmt.Sprintf(" AND o.status = $%d", argCount)
-		args = append(args, status)
-		argCount++
+	orderIDs := make([]uuid.UUID, 0, len(orders))
+	orderIndex := make(map[uuid.UUID]*models.Order, len(orders))
+	for i := range orders {
+		orders[i].Items = []models.OrderItem{}
+		orders[i].Fulfillment = models.FulfillmentSummary{}
+		orderIDs = append(orderIDs, orders[i].ID)
+		orderIndex[orders[i].ID] = &orders[i]
 	}
-	if rangeDays > 0 {
-		whereClause += fmt.Sprintf(" AND o.created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
-		args = append(args, rangeDays)
-		argCount++
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, orderItemsQuery, orderIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.OrderItem
+		var product models.Product
+		var variantSKU *string
+		var variantPrice *money.Money
+		var variantStock *int
+		var variantAttributes map[string]string
+
+		err := rows.Scan(
+			&item.ID,
+			&item.OrderID,
+			&item.ProductID,
+			&item.Quantity,
+			&item.PriceAtTime,
+			&item.FulfillmentStatus,
+			&item.TrackingNumber,
+			&item.ShippedAt,
+			&item.DeliveredAt,
+			&item.CarrierStatus,
+			&item.CarrierStatusAt,
+			&item.CreatedAt,
+			&item.BundleComponents,
+			&product.ID,
+			&product.SKU,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Stock,
+			&product.Category,
+			&product.ImageURL,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&item.VariantID,
+			&variantSKU,
+			&variantPrice,
+			&variantStock,
+			&variantAttributes,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		item.Product = product
+		if item.VariantID != nil {
+			item.Variant = &models.ProductVariant{
+				ID:         *item.VariantID,
+				ProductID:  product.ID,
+				SKU:        *variantSKU,
+				Price:      *variantPrice,
+				Stock:      *variantStock,
+				Attributes: variantAttributes,
+			}
+		}
+
+		if order, ok := orderIndex[item.OrderID]; ok {
+			order.Items = append(order.Items, item)
+			order.Fulfillment[item.FulfillmentStatus]++
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetPageByUserID returns up to limit of the user's orders, newest first,
+// after the cursor, and the cursor for the following page (nil on the last
+// page)
+func (r *orderRepository) GetPageByUserID(ctx context.Context, userID uuid.UUID, after *pagination.Cursor, limit int) ([]models.Order, *pagination.Cursor, error) {
+	where := &database.Where{}
+	where.And("user_id = ?", userID)
+	if after != nil {
+		where.And("(created_at, id) < (?::timestamp, ?)", after.Key, after.ID)
+	}
+
+	// One extra row tells whether another page follows
+	query := fmt.Sprintf(`
+        SELECT id, user_id, order_number, total_amount, gift_card_amount, status, payment_method,
+               shipping_address, billing_address, created_at, updated_at, created_at::text
+        FROM orders
+        %s
+        ORDER BY created_at DESC, id DESC
+        LIMIT %s
+    `, where, where.Bind(limit+1))
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var orders []models.Order
+	var keys []string
+	for rows.Next() {
+		var order models.Order
+		var key string
+		err := rows.Scan(
+			&order.ID,
+			&order.UserID,
+			&order.OrderNumber,
+			&order.TotalAmount,
+			&order.GiftCardAmount,
+			&order.Status,
+			&order.PaymentMethod,
+			&order.ShippingAddress,
+			&order.BillingAddress,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+			&key,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		orders = append(orders, order)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(orders) <= limit {
+		return orders, nil, nil
+	}
+
+	orders = orders[:limit]
+	next := &pagination.Cursor{Key: keys[limit-1], ID: orders[limit-1].ID}
+	return orders, next, nil
+}
+
+// buildOrderFilter returns the WHERE clause for the admin order filters over
+// orders o joined to users u, within the context's store
+func buildOrderFilter(ctx context.Context, filter models.OrderFilter) *database.Where {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "o.store_id")
+
+	if filter.Status != "" {
+		where.And("o.status = ?", filter.Status)
+	}
+
+	if filter.RangeDays > 0 {
+		where.And("o.created_at >= NOW() - ? * INTERVAL '1 day'", filter.RangeDays)
+	}
+
+	if filter.OrderNumber != "" {
+		where.And("lower(o.order_number) LIKE lower(?)", escapeLike(filter.OrderNumber)+"%")
+	}
+
+	if filter.Email != "" {
+		where.And("u.email ILIKE ?", "%"+escapeLike(filter.Email)+"%")
+	}
+
+	if filter.SKU != "" {
+		sku := escapeLike(filter.SKU)
+		where.And(`EXISTS (
+            SELECT 1 FROM order_items oi
+            JOIN products p ON p.id = oi.product_id
+            LEFT JOIN product_variants pv ON pv.id = oi.variant_id
+            WHERE oi.order_id = o.id AND (p.sku ILIKE ? OR pv.sku ILIKE ?)
+        )`, sku, sku)
 	}
 
+	if filter.Tag != "" {
+		where.And("o.tags @> ARRAY[?]::text[]", filter.Tag)
+	}
+
+	if filter.MinAmount != nil {
+		where.And("o.total_amount >= ?", *filter.MinAmount)
+	}
+
+	if filter.MaxAmount != nil {
+		where.And("o.total_amount <= ?", *filter.MaxAmount)
+	}
+
+	if filter.ExcludeHeld {
+		where.And("o.status NOT IN ('on_hold', 'under_review')")
+	}
+
+	return where
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (r *orderRepository) GetAll(ctx context.Context, page, limit int, filter models.OrderFilter) ([]models.AdminOrder, int, error) {
+	offset := (page - 1) * limit
+
+	where := buildOrderFilter(ctx, filter)
+
 	// Count total orders
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM orders o %s", whereClause)
+	countQuery := "SELECT COUNT(*) FROM orders o JOIN users u ON o.user_id = u.id " + where.String()
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, where.Args()...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -384,17 +730,15 @@ func (r *orderRepository) GetAll(ctx context.Context, page, limit int, status st
 	ordersQuery := fmt.Sprintf(`
         SELECT 
             o.id, o.user_id, o.order_number, o.total_amount, o.status, o.payment_method,
-            o.created_at, o.updated_at, u.id, u.email
+            o.tags, o.created_at, o.updated_at, u.id, u.email
         FROM orders o
         JOIN users u ON o.user_id = u.id
         %s
         ORDER BY created_at DESC
-        LIMIT $%d OFFSET $%d
-    `, whereClause, argCount, argCount+1)
-
-	args = append(args, limit, offset)
+        LIMIT %s OFFSET %s
+    `, where, where.Bind(limit), where.Bind(offset))
 
-	rows, err := r.db.Query(ctx, ordersQuery, args...)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, ordersQuery, where.Args()...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -410,6 +754,7 @@ func (r *orderRepository) GetAll(ctx context.Context, page, limit int, status st
 			&order.TotalAmount,
 			&order.Status,
 			&order.PaymentMethod,
+			&order.Tags,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 			&order.User.ID,
@@ -433,13 +778,13 @@ func (r *orderRepository) GetAll(ctx context.Context, page, limit int, status st
 	}
 
 	itemsQuery := `
-        SELECT order_id, product_id, quantity
+        SELECT id, order_id, product_id, variant_id, quantity, fulfillment_status
         FROM order_items
         WHERE order_id = ANY($1)
         ORDER BY created_at
     `
 
-	itemRows, err := r.db.Query(ctx, itemsQuery, orderIDs)
+	itemRows, err := database.Conn(ctx, r.db).Query(ctx, itemsQuery, orderIDs)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -447,51 +792,87 @@ func (r *orderRepository) GetAll(ctx context.Context, page, limit int, status st
 
 	orderIndex := make(map[uuid.UUID]*models.AdminOrder, len(orders))
 	for i := range orders {
+		orders[i].Fulfillment = models.FulfillmentSummary{}
 		orderIndex[orders[i].ID] = &orders[i]
 	}
 
 	for itemRows.Next() {
 		var orderID uuid.UUID
 		var item models.AdminOrderItem
-		if err := itemRows.Scan(&orderID, &item.ProductID, &item.Quantity); err != nil {
+		if err := itemRows.Scan(&item.ID, &orderID, &item.ProductID, &item.VariantID, &item.Quantity, &item.FulfillmentStatus); err != nil {
 			return nil, 0, err
 		}
 		if orderPtr, ok := orderIndex[orderID]; ok {
 			orderPtr.Items = append(orderPtr.Items, item)
+			orderPtr.Fulfillment[item.FulfillmentStatus]++
 		}
 	}
 
 	return orders, total, nil
 }
 
-func (r *orderRepository) GetRecent(ctx context.Context, limit, rangeDays int) ([]models.AdminOrder, error) {
-	whereClause := "WHERE 1=1"
-	args := []interface{}{}
-	argCount := 1
-
-	if rangeDays > 0 {
-		whereClause += fmt.Sprintf(" AND o.created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
-		args = append(args, rangeDays)
-		argCount++
-	}
+// ExportAll streams every order matching the admin list filters to fn, oldest
+// first, through a server-side cursor
+func (r *orderRepository) ExportAll(ctx context.Context, filter models.OrderFilter, fn func(models.OrderExportRow) error) error {
+	where := buildOrderFilter(ctx, filter)
 
 	query := fmt.Sprintf(`
-        SELECT 
-            o.id, o.user_id, o.order_number, o.total_amount, o.status, o.payment_method,
-            o.created_at, o.updated_at, u.id, u.email
+        SELECT
+            o.id, o.order_number, o.status, o.payment_method, o.total_amount,
+            COALESCE((SELECT SUM(oi.quantity) FROM order_items oi WHERE oi.order_id = o.id), 0),
+            u.email, u.first_name || ' ' || u.last_name,
+            COALESCE(o.shipping_address->>'city', ''), COALESCE(o.shipping_address->>'country', ''),
+            o.created_at
         FROM orders o
         JOIN users u ON o.user_id = u.id
         %s
-        ORDER BY o.created_at DESC
-        LIMIT $%d
-    `, whereClause, argCount)
+        ORDER BY o.created_at, o.id
+    `, where)
 
-	args = append(args, limit)
-
-	rows, err := r.db.Query(ctx, query, args...)
-	if err != nil {
-		return nil, err
-	}
+	return database.ForEachRow(ctx, r.db, database.DefaultCursorBatchSize, query, where.Args(), func(rows pgx.Rows) error {
+		var row models.OrderExportRow
+		err := rows.Scan(
+			&row.ID,
+			&row.OrderNumber,
+			&row.Status,
+			&row.PaymentMethod,
+			&row.TotalAmount,
+			&row.ItemCount,
+			&row.CustomerEmail,
+			&row.CustomerName,
+			&row.ShippingCity,
+			&row.ShippingCountry,
+			&row.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		return fn(row)
+	})
+}
+
+func (r *orderRepository) GetRecent(ctx context.Context, limit, rangeDays int) ([]models.AdminOrder, error) {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "o.store_id")
+	if rangeDays > 0 {
+		where.And("o.created_at >= NOW() - ? * INTERVAL '1 day'", rangeDays)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT 
+            o.id, o.user_id, o.order_number, o.total_amount, o.status, o.payment_method,
+            o.tags, o.created_at, o.updated_at, u.id, u.email
+        FROM orders o
+        JOIN users u ON o.user_id = u.id
+        %s
+        ORDER BY o.created_at DESC
+        LIMIT %s
+    `, where, where.Bind(limit))
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var orders []models.AdminOrder
@@ -504,6 +885,7 @@ func (r *orderRepository) GetRecent(ctx context.Context, limit, rangeDays int) (
 			&order.TotalAmount,
 			&order.Status,
 			&order.PaymentMethod,
+			&order.Tags,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 			&order.User.ID,
@@ -524,13 +906,13 @@ func (r *orderRepository) GetRecent(ctx context.Context, limit, rangeDays int) (
 	}
 
 	itemsQuery := `
-        SELECT order_id, product_id, quantity
+        SELECT id, order_id, product_id, variant_id, quantity, fulfillment_status
         FROM order_items
         WHERE order_id = ANY($1)
         ORDER BY created_at
     `
 
-	itemRows, err := r.db.Query(ctx, itemsQuery, orderIDs)
+	itemRows, err := database.Conn(ctx, r.db).Query(ctx, itemsQuery, orderIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -538,17 +920,19 @@ func (r *orderRepository) GetRecent(ctx context.Context, limit, rangeDays int) (
 
 	orderIndex := make(map[uuid.UUID]*models.AdminOrder, len(orders))
 	for i := range orders {
+		orders[i].Fulfillment = models.FulfillmentSummary{}
 		orderIndex[orders[i].ID] = &orders[i]
 	}
 
 	for itemRows.Next() {
 		var orderID uuid.UUID
 		var item models.AdminOrderItem
-		if err := itemRows.Scan(&orderID, &item.ProductID, &item.Quantity); err != nil {
+		if err := itemRows.Scan(&item.ID, &orderID, &item.ProductID, &item.VariantID, &item.Quantity, &item.FulfillmentStatus); err != nil {
 			return nil, err
 		}
 		if orderPtr, ok := orderIndex[orderID]; ok {
 			orderPtr.Items = append(orderPtr.Items, item)
+			orderPtr.Fulfillment[item.FulfillmentStatus]++
 		}
 	}
 
@@ -563,22 +947,32 @@ func (r *orderRepository) GetAnalytics(ctx context.Context, rangeDays int) (*mod
 	orderWhere := "WHERE 1=1"
 	orderArgs := []interface{}{}
 	orderArgCount := 1
+	if storeID, ok := database.StoreFromContext(ctx); ok {
+		orderWhere += fmt.Sprintf(" AND store_id = $%d", orderArgCount)
+		orderArgs = append(orderArgs, storeID)
+		orderArgCount++
+	}
 	if rangeDays > 0 {
 		orderWhere += fmt.Sprintf(" AND created_at >= NOW() - $%d * INTERVAL '1 day'", orderArgCount)
 		orderArgs = append(orderArgs, rangeDays)
 		orderArgCount++
 	}
 
-	var totalRevenue float64
+	var totalRevenue money.Money
 	var totalOrders int
 	totalQuery := "SELECT COALESCE(SUM(total_amount), 0), COUNT(*) FROM orders " + orderWhere
-	if err := r.db.QueryRow(ctx, totalQuery, orderArgs...).Scan(&totalRevenue, &totalOrders); err != nil {
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, totalQuery, orderArgs...).Scan(&totalRevenue, &totalOrders); err != nil {
 		return nil, err
 	}
 
 	productWhere := "WHERE 1=1"
 	productArgs := []interface{}{}
 	productArgCount := 1
+	if storeID, ok := database.StoreFromContext(ctx); ok {
+		productWhere += fmt.Sprintf(" AND store_id = $%d", productArgCount)
+		productArgs = append(productArgs, storeID)
+		productArgCount++
+	}
 	if rangeDays > 0 {
 		productWhere += fmt.Sprintf(" AND created_at >= NOW() - $%d * INTERVAL '1 day'", productArgCount)
 		productArgs = append(productArgs, rangeDays)
@@ -586,13 +980,18 @@ func (r *orderRepository) GetAnalytics(ctx context.Context, rangeDays int) (*mod
 	}
 
 	var totalProducts int
-	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM products "+productWhere, productArgs...).Scan(&totalProducts); err != nil {
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, "SELECT COUNT(*) FROM products "+productWhere, productArgs...).Scan(&totalProducts); err != nil {
 		return nil, err
 	}
 
 	userWhere := "WHERE role = 'customer'"
 	userArgs := []interface{}{}
 	userArgCount := 1
+	if storeID, ok := database.StoreFromContext(ctx); ok {
+		userWhere += fmt.Sprintf(" AND store_id = $%d", userArgCount)
+		userArgs = append(userArgs, storeID)
+		userArgCount++
+	}
 	if rangeDays > 0 {
 		userWhere += fmt.Sprintf(" AND created_at >= NOW() - $%d * INTERVAL '1 day'", userArgCount)
 		userArgs = append(userArgs, rangeDays)
@@ -600,13 +999,13 @@ func (r *orderRepository) GetAnalytics(ctx context.Context, rangeDays int) (*mod
 	}
 
 	var totalCustomers int
-	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM users "+userWhere, userArgs...).Scan(&totalCustomers); err != nil {
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, "SELECT COUNT(*) FROM users "+userWhere, userArgs...).Scan(&totalCustomers); err != nil {
 		return nil, err
 	}
 
 	var ordersByStatus []models.AdminStatusCount
 	statusQuery := "SELECT status, COUNT(*) FROM orders " + orderWhere + " GROUP BY status"
-	rows, err := r.db.Query(ctx, statusQuery, orderArgs...)
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, statusQuery, orderArgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -624,9 +1023,9 @@ func (r *orderRepository) GetAnalytics(ctx context.Context, rangeDays int) (*mod
 		})
 	}
 
-	avgOrderValue := 0.0
+	var avgOrderValue money.Money
 	if totalOrders > 0 {
-		avgOrderValue = totalRevenue / float64(totalOrders)
+		avgOrderValue = totalRevenue.MulRat(1, int64(totalOrders))
 	}
 
 	analytics.Totals = models.AdminTotals{
@@ -638,9 +1037,432 @@ func (r *orderRepository) GetAnalytics(ctx context.Context, rangeDays int) (*mod
 	}
 	analytics.OrdersByStatus = ordersByStatus
 
+	if analytics.ByPaymentMethod, err = r.paymentMethodBreakdown(ctx, orderWhere, orderArgs); err != nil {
+		return nil, err
+	}
+	if analytics.PaymentMixTrend, err = r.paymentMixTrend(ctx, orderWhere, orderArgs); err != nil {
+		return nil, err
+	}
+
+	return analytics, nil
+}
+
+// paymentMethodBreakdown totals orders and revenue per payment method and
+// counts the payment attempts made for those orders that failed
+func (r *orderRepository) paymentMethodBreakdown(ctx context.Context, orderWhere string, orderArgs []interface{}) ([]models.PaymentMethodBreakdown, error) {
+	query := `
+        WITH ranged AS (
+            SELECT id, payment_method, total_amount FROM orders ` + orderWhere + `
+        )
+        SELECT o.payment_method, COUNT(*), COALESCE(SUM(o.total_amount), 0),
+               COALESCE(SUM(p.attempts), 0), COALESCE(SUM(p.failed), 0)
+        FROM ranged o
+        LEFT JOIN (
+            SELECT order_id, COUNT(*) AS attempts, COUNT(*) FILTER (WHERE status = 'failed') AS failed
+            FROM payments
+            WHERE order_id IN (SELECT id FROM ranged)
+            GROUP BY order_id
+        ) p ON p.order_id = o.id
+        GROUP BY o.payment_method
+        ORDER BY o.payment_method
+    `
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, query, orderArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	breakdown := []models.PaymentMethodBreakdown{}
+	for rows.Next() {
+		var line models.PaymentMethodBreakdown
+		if err := rows.Scan(&line.PaymentMethod, &line.Orders, &line.Revenue, &line.PaymentAttempts, &line.FailedPayments); err != nil {
+			return nil, err
+		}
+		if line.PaymentAttempts > 0 {
+			line.FailureRate = float64(line.FailedPayments) / float64(line.PaymentAttempts)
+		}
+		breakdown = append(breakdown, line)
+	}
+
+	return breakdown, rows.Err()
+}
+
+// paymentMixTrend splits each week's orders into cash on delivery and prepaid,
+// and counts customers who paid up front after an earlier COD order
+func (r *orderRepository) paymentMixTrend(ctx context.Context, orderWhere string, orderArgs []interface{}) ([]models.PaymentMixPeriod, error) {
+	query := `
+        SELECT date_trunc('week', created_at) AS period,
+               COUNT(*) FILTER (WHERE payment_method = 'cod'),
+               COUNT(*) FILTER (WHERE payment_method <> 'cod'),
+               COUNT(DISTINCT user_id) FILTER (WHERE payment_method <> 'cod' AND paid_cod_before)
+        FROM (
+            SELECT store_id, user_id, payment_method, created_at,
+                   BOOL_OR(payment_method = 'cod') OVER (
+                       PARTITION BY user_id ORDER BY created_at
+                       ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+                   ) AS paid_cod_before
+            FROM orders
+        ) orders
+        ` + orderWhere + `
+        GROUP BY period
+        ORDER BY period
+    `
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, query, orderArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trend := []models.PaymentMixPeriod{}
+	for rows.Next() {
+		var period models.PaymentMixPeriod
+		if err := rows.Scan(&period.Period, &period.CODOrders, &period.PrepaidOrders, &period.ConvertedCustomers); err != nil {
+			return nil, err
+		}
+		if total := period.CODOrders + period.PrepaidOrders; total > 0 {
+			period.PrepaidShare = float64(period.PrepaidOrders) / float64(total)
+		}
+		trend = append(trend, period)
+	}
+
+	return trend, rows.Err()
+}
+
+// GetCustomerAnalytics reports purchasing behaviour of customers who ordered in
+// the range. Cancelled and refunded orders are not counted as revenue, and an
+// order is attributed to a new customer when it is that customer's first order.
+func (r *orderRepository) GetCustomerAnalytics(ctx context.Context, rangeDays, topLimit int) (*models.CustomerAnalytics, error) {
+	analytics := &models.CustomerAnalytics{
+		RangeDays:    rangeDays,
+		TopCustomers: []models.TopCustomer{},
+	}
+
+	storeWhere := ""
+	rangeWhere := "WHERE 1=1"
+	args := []interface{}{}
+	argCount := 1
+	if storeID, ok := database.StoreFromContext(ctx); ok {
+		storeWhere = fmt.Sprintf(" AND store_id = $%d", argCount)
+		args = append(args, storeID)
+		argCount++
+	}
+	if rangeDays > 0 {
+		rangeWhere += fmt.Sprintf(" AND created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
+		args = append(args, rangeDays)
+		argCount++
+	}
+
+	baseCTE := `
+        WITH valid_orders AS (
+            SELECT id, user_id, total_amount, created_at,
+                   ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at) AS order_seq
+            FROM orders
+            WHERE status NOT IN ('cancelled', 'refunded')` + storeWhere + `
+        ),
+        lifetime AS (
+            SELECT user_id, SUM(total_amount) AS lifetime_value
+            FROM valid_orders
+            GROUP BY user_id
+        ),
+        ranged AS (
+            SELECT user_id, COUNT(*) AS order_count, SUM(total_amount) AS total_spent,
+                   MAX(created_at) AS last_order_at
+            FROM valid_orders
+            ` + rangeWhere + `
+            GROUP BY user_id
+        )
+    `
+
+	summaryQuery := baseCTE + `
+        SELECT
+            COUNT(*),
+            COUNT(*) FILTER (WHERE r.order_count > 1),
+            COALESCE(AVG(l.lifetime_value), 0)
+        FROM ranged r
+        JOIN lifetime l ON l.user_id = r.user_id
+    `
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, summaryQuery, args...).Scan(
+		&analytics.TotalCustomers,
+		&analytics.RepeatCustomers,
+		&analytics.AverageLifetimeValue,
+	); err != nil {
+		return nil, err
+	}
+
+	if analytics.TotalCustomers > 0 {
+		analytics.RepeatPurchaseRate = float64(analytics.RepeatCustomers) / float64(analytics.TotalCustomers)
+	}
+
+	splitQuery := baseCTE + `
+        SELECT
+            COALESCE(SUM(total_amount) FILTER (WHERE order_seq = 1), 0),
+            COALESCE(SUM(total_amount) FILTER (WHERE order_seq > 1), 0),
+            COUNT(*) FILTER (WHERE order_seq = 1),
+            COUNT(*) FILTER (WHERE order_seq > 1)
+        FROM valid_orders
+    ` + rangeWhere
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, splitQuery, args...).Scan(
+		&analytics.RevenueSplit.NewCustomerRevenue,
+		&analytics.RevenueSplit.ReturningCustomerRevenue,
+		&analytics.RevenueSplit.NewCustomerOrders,
+		&analytics.RevenueSplit.ReturningCustomerOrders,
+	); err != nil {
+		return nil, err
+	}
+
+	topQuery := baseCTE + fmt.Sprintf(`
+        SELECT
+            u.id, u.email, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''),
+            r.order_count, r.total_spent, l.lifetime_value, r.last_order_at
+        FROM ranged r
+        JOIN lifetime l ON l.user_id = r.user_id
+        JOIN users u ON u.id = r.user_id
+        ORDER BY r.total_spent DESC
+        LIMIT $%d
+    `, argCount)
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, topQuery, append(args, topLimit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var customer models.TopCustomer
+		if err := rows.Scan(
+			&customer.User.ID,
+			&customer.User.Email,
+			&customer.FirstName,
+			&customer.LastName,
+			&customer.OrderCount,
+			&customer.TotalSpent,
+			&customer.LifetimeValue,
+			&customer.LastOrderAt,
+		); err != nil {
+			return nil, err
+		}
+		analytics.TopCustomers = append(analytics.TopCustomers, customer)
+	}
+
 	return analytics, nil
 }
 
+// GetMarginReport totals line revenue and the cost recorded on each order item
+// by period and by category. period must be one of the MarginPeriod values.
+func (r *orderRepository) GetMarginReport(ctx context.Context, rangeDays int, period string) (*models.MarginReport, error) {
+	report := &models.MarginReport{
+		RangeDays:  rangeDays,
+		Period:     period,
+		ByPeriod:   []models.MarginLine{},
+		ByCategory: []models.MarginLine{},
+	}
+
+	marginWhere := func() *database.Where {
+		where := &database.Where{}
+		where.And("o.status NOT IN ('cancelled', 'refunded')")
+		where.ScopeStore(ctx, "o.store_id")
+		if rangeDays > 0 {
+			where.And("o.created_at >= NOW() - ? * INTERVAL '1 day'", rangeDays)
+		}
+		return where
+	}
+
+	const measures = `
+            COALESCE(SUM(oi.quantity * oi.price_at_time), 0),
+            COALESCE(SUM(oi.quantity * oi.cost_at_time), 0),
+            COUNT(*) FILTER (WHERE oi.cost_at_time IS NULL)`
+
+	where := marginWhere()
+	periodQuery := fmt.Sprintf(`
+        SELECT date_trunc(%s, o.created_at) AS period,%s
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        %s
+        GROUP BY period
+        ORDER BY period
+    `, where.Bind(period), measures, where)
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, periodQuery, where.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var line models.MarginLine
+		var start time.Time
+		if err := rows.Scan(&start, &line.Revenue, &line.COGS, &line.UncostedItems); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		line.Period = &start
+		line.SetMargin()
+		report.ByPeriod = append(report.ByPeriod, line)
+
+		report.Totals.Revenue += line.Revenue
+		report.Totals.COGS += line.COGS
+		report.Totals.UncostedItems += line.UncostedItems
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	report.Totals.SetMargin()
+
+	where = marginWhere()
+	categoryQuery := fmt.Sprintf(`
+        SELECT COALESCE(NULLIF(p.category, ''), 'uncategorized') AS category,%s
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        JOIN products p ON p.id = oi.product_id
+        %s
+        GROUP BY category
+        ORDER BY 2 DESC, category
+    `, measures, where)
+
+	rows, err = database.ReadConn(ctx, r.db, r.replica).Query(ctx, categoryQuery, where.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var line models.MarginLine
+		if err := rows.Scan(&line.Category, &line.Revenue, &line.COGS, &line.UncostedItems); err != nil {
+			return nil, err
+		}
+		line.SetMargin()
+		report.ByCategory = append(report.ByCategory, line)
+	}
+
+	return report, rows.Err()
+}
+
+// GetPromotionAnalytics reports redemptions of each promotion. Whether an
+// order was the customer's first is judged against all their orders, not
+// only those in the range.
+func (r *orderRepository) GetPromotionAnalytics(ctx context.Context, rangeDays int) (*models.PromotionAnalytics, error) {
+	analytics := &models.PromotionAnalytics{
+		RangeDays:  rangeDays,
+		Promotions: []models.PromotionUsage{},
+	}
+
+	where := &database.Where{}
+	where.And("status NOT IN ('cancelled', 'refunded')")
+	where.ScopeStore(ctx, "store_id")
+
+	rangeWhere := ""
+	if rangeDays > 0 {
+		rangeWhere = "WHERE o.created_at >= NOW() - " + where.Bind(rangeDays) + " * INTERVAL '1 day'"
+	}
+
+	query := fmt.Sprintf(`
+        WITH valid_orders AS (
+            SELECT id, total_amount, created_at,
+                   ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at) AS order_seq
+            FROM orders
+            %s
+        )
+        SELECT op.promotion_id, COALESCE(p.name, op.name) AS name,
+               COUNT(*), SUM(op.amount), AVG(op.amount), SUM(o.total_amount),
+               COUNT(*) FILTER (WHERE o.order_seq = 1)
+        FROM order_promotions op
+        JOIN valid_orders o ON o.id = op.order_id
+        LEFT JOIN promotions p ON p.id = op.promotion_id
+        %s
+        GROUP BY op.promotion_id, COALESCE(p.name, op.name)
+        ORDER BY 4 DESC, name
+    `, where, rangeWhere)
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var usage models.PromotionUsage
+		if err := rows.Scan(
+			&usage.PromotionID,
+			&usage.Name,
+			&usage.Redemptions,
+			&usage.TotalDiscount,
+			&usage.AverageDiscount,
+			&usage.RevenueInfluenced,
+			&usage.NewCustomers,
+		); err != nil {
+			return nil, err
+		}
+		analytics.Promotions = append(analytics.Promotions, usage)
+	}
+
+	return analytics, rows.Err()
+}
+
+// GetPickList sums the pending items of processing and partially shipped
+// orders by the warehouse they were allocated to and product. Bundles are
+// picked as their components. Items without allocations, from before
+// warehouses, are picked at the default warehouse.
+func (r *orderRepository) GetPickList(ctx context.Context, filter models.PickListFilter) ([]models.PickListLine, error) {
+	where := &database.Where{}
+	where.And("o.status IN ('processing', 'partially_shipped')")
+	where.And("oi.fulfillment_status = 'pending'")
+	where.ScopeStore(ctx, "o.store_id")
+	if len(filter.OrderIDs) > 0 {
+		where.And("o.id = ANY(?::uuid[])", filter.OrderIDs)
+	}
+	if filter.WarehouseID != nil {
+		where.And("w.id = ?", *filter.WarehouseID)
+	}
+
+	query := `
+        SELECT w.id, w.code, w.name,
+               p.id, p.name, p.sku, v.id, v.sku, COALESCE(v.barcode, p.barcode),
+               SUM(COALESCE(a.quantity, oi.quantity)),
+               array_agg(DISTINCT o.order_number ORDER BY o.order_number)
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        LEFT JOIN order_item_allocations a ON a.order_item_id = oi.id
+        JOIN products p ON p.id = COALESCE(a.component_product_id, oi.product_id)
+        LEFT JOIN product_variants v ON v.id = CASE
+            WHEN a.component_product_id IS NULL THEN oi.variant_id
+            ELSE a.component_variant_id
+        END
+        JOIN warehouses w ON w.id = COALESCE(a.warehouse_id, (SELECT id FROM warehouses WHERE is_default LIMIT 1))
+        ` + where.String() + `
+        GROUP BY w.id, w.code, w.name, p.id, p.name, p.sku, v.id, v.sku, v.barcode, p.barcode
+        ORDER BY w.code, p.sku, v.sku NULLS FIRST
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []models.PickListLine{}
+	for rows.Next() {
+		var line models.PickListLine
+		if err := rows.Scan(
+			&line.WarehouseID,
+			&line.WarehouseCode,
+			&line.WarehouseName,
+			&line.ProductID,
+			&line.ProductName,
+			&line.SKU,
+			&line.VariantID,
+			&line.VariantSKU,
+			&line.Barcode,
+			&line.Quantity,
+			&line.OrderNumbers,
+		); err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, rows.Err()
+}
+
 func (r *orderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.OrderStatus) error {
 	query := `
         UPDATE orders
@@ -648,13 +1470,34 @@ func (r *orderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
         WHERE id = $2
     `
 
-	result, err := r.db.Exec(ctx, query, status, id)
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, status, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("order not found")
+	}
+
+	return nil
+}
+
+// UpdateStatusFrom moves the order to status to only while it is in status
+// from, failing with a conflict when a concurrent change got there first
+func (r *orderRepository) UpdateStatusFrom(ctx context.Context, id uuid.UUID, from, to models.OrderStatus) error {
+	query := `
+        UPDATE orders
+        SET status = $3, updated_at = NOW()
+        WHERE id = $1 AND status = $2
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id, from, to)
 	if err != nil {
 		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("order not found")
+		return apperrors.Conflictf("order is no longer %s", from)
 	}
 
 	return nil
@@ -664,17 +1507,230 @@ func (r *orderRepository) CancelOrder(ctx context.Context, id uuid.UUID) error {
 	query := `
         UPDATE orders
         SET status = 'cancelled', updated_at = NOW()
-        WHERE id = $1 AND status IN ('pending', 'processing')
+        WHERE id = $1 AND status IN ('awaiting_payment', 'pending', 'on_hold', 'under_review', 'processing')
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Conflict("order cannot be cancelled or not found")
+	}
+
+	return nil
+}
+
+// GetPurchasedQuantity returns how many units of a product (any variant) the
+// user has ordered, excluding cancelled orders
+func (r *orderRepository) GetPurchasedQuantity(ctx context.Context, userID, productID uuid.UUID) (int, error) {
+	query := `
+        SELECT COALESCE(SUM(oi.quantity), 0)
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE o.user_id = $1 AND oi.product_id = $2 AND o.status != 'cancelled'
+    `
+
+	var quantity int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID, productID).Scan(&quantity)
+	return quantity, err
+}
+
+// SetGiftCardAmount records how much of the order was paid with gift cards
+func (r *orderRepository) SetGiftCardAmount(ctx context.Context, id uuid.UUID, amount money.Money) error {
+	query := `UPDATE orders SET gift_card_amount = $1, updated_at = NOW() WHERE id = $2`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, amount, id)
+	return err
+}
+
+// UpdateItemFulfillment moves one item of the order to a new fulfillment
+// status, stamping when it shipped and was delivered
+func (r *orderRepository) UpdateItemFulfillment(ctx context.Context, orderID, itemID uuid.UUID, status models.FulfillmentStatus, trackingNumber *string) error {
+	query := `
+        UPDATE order_items
+        SET fulfillment_status = $3,
+            tracking_number = COALESCE($4, tracking_number),
+            shipped_at = CASE WHEN $3::text IN ('shipped', 'delivered') THEN COALESCE(shipped_at, NOW()) ELSE shipped_at END,
+            delivered_at = CASE WHEN $3::text = 'delivered' THEN COALESCE(delivered_at, NOW()) ELSE delivered_at END
+        WHERE id = $2 AND order_id = $1
     `
 
-	result, err := r.db.Exec(ctx, query, id)
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, orderID, itemID, status, trackingNumber)
 	if err != nil {
 		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("order cannot be cancelled or not found")
+		return apperrors.NotFound("order item not found")
 	}
 
 	return nil
 }
+
+// GetByTrackingNumber finds the order whose items shipped under the tracking
+// number, the most recent one should a carrier reuse numbers
+func (r *orderRepository) GetByTrackingNumber(ctx context.Context, trackingNumber string) (*models.Order, error) {
+	query := `
+        SELECT oi.order_id
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE oi.tracking_number = $1 AND ($2::uuid IS NULL OR o.store_id = $2)
+        ORDER BY oi.shipped_at DESC NULLS LAST
+        LIMIT 1
+    `
+
+	var orderID uuid.UUID
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, trackingNumber, database.StoreArg(ctx)).Scan(&orderID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, orderID)
+}
+
+// SetCarrierStatus records the carrier's latest status on the order's items
+// shipped under the tracking number
+func (r *orderRepository) SetCarrierStatus(ctx context.Context, orderID uuid.UUID, trackingNumber string, status models.CarrierStatus, at time.Time) error {
+	query := `
+        UPDATE order_items
+        SET carrier_status = $3, carrier_status_at = $4
+        WHERE order_id = $1 AND tracking_number = $2
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, orderID, trackingNumber, status, at)
+	return err
+}
+
+// SetItemsFulfillment moves every item of the order that is in one of the from
+// statuses to the to status, used when the whole order changes at once
+func (r *orderRepository) SetItemsFulfillment(ctx context.Context, orderID uuid.UUID, from []models.FulfillmentStatus, to models.FulfillmentStatus) error {
+	query := `
+        UPDATE order_items
+        SET fulfillment_status = $3,
+            shipped_at = CASE WHEN $3::text IN ('shipped', 'delivered') THEN COALESCE(shipped_at, NOW()) ELSE shipped_at END,
+            delivered_at = CASE WHEN $3::text = 'delivered' THEN COALESCE(delivered_at, NOW()) ELSE delivered_at END
+        WHERE order_id = $1 AND fulfillment_status = ANY($2::text[])
+    `
+
+	statuses := make([]string, 0, len(from))
+	for _, status := range from {
+		statuses = append(statuses, string(status))
+	}
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, orderID, statuses, to)
+	return err
+}
+
+// CountOpenByUser counts the user's orders that are still being fulfilled or
+// have a return in progress
+func (r *orderRepository) CountOpenByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM orders
+        WHERE user_id = $1 AND status IN ('pending', 'processing', 'partially_shipped', 'shipped', 'return_requested')
+    `
+
+	var count int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *orderRepository) SetEstimatedDelivery(ctx context.Context, id uuid.UUID, date time.Time) error {
+	query := `UPDATE orders SET estimated_delivery = $2 WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id, date)
+	return err
+}
+
+// AddTags adds internal tags to the order and returns all of its tags
+func (r *orderRepository) AddTags(ctx context.Context, id uuid.UUID, tags []string) ([]string, error) {
+	return addTags(ctx, r.db, orderTags, id, tags)
+}
+
+// RemoveTag removes an internal tag from the order and returns the rest
+func (r *orderRepository) RemoveTag(ctx context.Context, id uuid.UUID, tag string) ([]string, error) {
+	return removeTag(ctx, r.db, orderTags, id, tag)
+}
+
+// GetUnpaidBefore returns the oldest pending orders placed more than
+// unpaidFor ago that are still waiting for payment. Cash-on-delivery orders
+// are paid on delivery, and orders with a payment in flight or captured are
+// left for the payment to settle. Held orders are not pending and so never
+// picked up.
+func (r *orderRepository) GetUnpaidBefore(ctx context.Context, unpaidFor time.Duration, limit int) ([]uuid.UUID, error) {
+	query := `
+        SELECT o.id
+        FROM orders o
+        WHERE o.status = 'pending'
+          AND o.payment_method <> 'cod'
+          AND o.created_at < NOW() - $1::interval
+          AND NOT EXISTS (
+              SELECT 1 FROM payments p
+              WHERE p.order_id = o.id AND p.status IN ('processing', 'authorized', 'completed')
+          )
+        ORDER BY o.created_at
+        LIMIT $2
+    `
+
+	return r.queryIDs(ctx, query, unpaidFor, limit)
+}
+
+// GetUnauthorizedBefore returns the oldest orders that have been awaiting
+// payment for more than unauthorizedFor without it being authorized. Orders
+// with a payment in flight are left for the payment to settle.
+func (r *orderRepository) GetUnauthorizedBefore(ctx context.Context, unauthorizedFor time.Duration, limit int) ([]uuid.UUID, error) {
+	query := `
+        SELECT o.id
+        FROM orders o
+        WHERE o.status = 'awaiting_payment'
+          AND o.created_at < NOW() - $1::interval
+          AND NOT EXISTS (
+              SELECT 1 FROM payments p
+              WHERE p.order_id = o.id AND p.status IN ('processing', 'authorized', 'completed')
+          )
+        ORDER BY o.created_at
+        LIMIT $2
+    `
+
+	return r.queryIDs(ctx, query, unauthorizedFor, limit)
+}
+
+// queryIDs runs a query selecting order IDs
+func (r *orderRepository) queryIDs(ctx context.Context, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// AnonymizeUser hands the user's orders to toUserID and strips the personal
+// fields from their addresses. City, state, country and postal code are kept
+// for tax and sales reporting.
+func (r *orderRepository) AnonymizeUser(ctx context.Context, userID, toUserID uuid.UUID) error {
+	query := `
+        UPDATE orders
+        SET user_id = $2,
+            shipping_address = shipping_address || '{"full_name": "", "street": "", "phone": ""}'::jsonb,
+            billing_address = billing_address || '{"full_name": "", "street": "", "phone": ""}'::jsonb,
+            updated_at = NOW()
+        WHERE user_id = $1
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, userID, toUserID)
+	return err
+}
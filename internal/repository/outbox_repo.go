@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository interface {
+	Insert(ctx context.Context, event *models.OutboxEvent) error
+	ClaimPending(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]models.OutboxEvent, error)
+	MarkProcessed(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, errMsg string, retryAt time.Time) error
+	GetRange(ctx context.Context, eventTypes []string, from, to time.Time, limit int) ([]models.OutboxEvent, error)
+}
+
+type outboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Insert writes the event through the caller's transaction when there is one,
+// so it is only visible once the business change commits
+func (r *outboxRepository) Insert(ctx context.Context, event *models.OutboxEvent) error {
+	query := `
+        INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		event.EventType,
+		event.AggregateType,
+		event.AggregateID,
+		event.Payload,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+// ClaimPending leases up to limit due events by pushing their next attempt past
+// the lease, so concurrent dispatchers skip them while they are delivered
+func (r *outboxRepository) ClaimPending(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]models.OutboxEvent, error) {
+	query := `
+        UPDATE outbox_events
+        SET next_attempt_at = NOW() + $3 * INTERVAL '1 second'
+        WHERE id IN (
+            SELECT id FROM outbox_events
+            WHERE processed_at IS NULL
+              AND attempts < $2
+              AND next_attempt_at <= NOW()
+            ORDER BY created_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id, event_type, aggregate_type, aggregate_id, payload, attempts, last_error, created_at
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, limit, maxAttempts, int(lease.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.OutboxEvent
+	for rows.Next() {
+		var event models.OutboxEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.EventType,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.Payload,
+			&event.Attempts,
+			&event.LastError,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not preserve the subquery order
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	return events, nil
+}
+
+func (r *outboxRepository) MarkProcessed(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE outbox_events
+        SET processed_at = NOW(), last_error = NULL
+        WHERE id = $1
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, errMsg string, retryAt time.Time) error {
+	query := `
+        UPDATE outbox_events
+        SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2
+        WHERE id = $3
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, errMsg, retryAt, id)
+	return err
+}
+
+// GetRange returns up to limit events of the given types recorded between
+// from and to, oldest first, whether or not they were delivered. It is used
+// to send events again to a consumer that lost them.
+func (r *outboxRepository) GetRange(ctx context.Context, eventTypes []string, from, to time.Time, limit int) ([]models.OutboxEvent, error) {
+	query := `
+        SELECT id, event_type, aggregate_type, aggregate_id, payload, attempts, last_error, created_at
+        FROM outbox_events
+        WHERE event_type = ANY($1) AND created_at >= $2 AND created_at <= $3
+        ORDER BY created_at, id
+        LIMIT $4
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, eventTypes, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.OutboxEvent
+	for rows.Next() {
+		var event models.OutboxEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.EventType,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.Payload,
+			&event.Attempts,
+			&event.LastError,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
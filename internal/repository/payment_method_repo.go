@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PaymentMethodRepository interface {
+	Create(ctx context.Context, method *models.SavedPaymentMethod) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.SavedPaymentMethod, error)
+	GetByToken(ctx context.Context, userID uuid.UUID, gateway, token string) (*models.SavedPaymentMethod, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.SavedPaymentMethod, error)
+	SetDefault(ctx context.Context, userID, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetCustomerID(ctx context.Context, userID uuid.UUID, gateway string) (string, error)
+	SaveCustomerID(ctx context.Context, userID uuid.UUID, gateway, customerID string) error
+}
+
+type paymentMethodRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentMethodRepository(db *pgxpool.Pool) PaymentMethodRepository {
+	return &paymentMethodRepository{db: db}
+}
+
+const paymentMethodColumns = `pm.id, pm.user_id, pm.gateway, pm.token, COALESCE(pc.customer_id, ''),
+        pm.type, pm.brand, pm.last4, pm.exp_month, pm.exp_year, pm.is_default, pm.created_at`
+
+const paymentMethodFrom = `
+        FROM payment_methods pm
+        LEFT JOIN payment_customers pc ON pc.user_id = pm.user_id AND pc.gateway = pm.gateway`
+
+func scanPaymentMethod(row pgx.Row) (*models.SavedPaymentMethod, error) {
+	var method models.SavedPaymentMethod
+	err := row.Scan(
+		&method.ID,
+		&method.UserID,
+		&method.Gateway,
+		&method.Token,
+		&method.CustomerID,
+		&method.Type,
+		&method.Brand,
+		&method.Last4,
+		&method.ExpMonth,
+		&method.ExpYear,
+		&method.IsDefault,
+		&method.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+func (r *paymentMethodRepository) Create(ctx context.Context, method *models.SavedPaymentMethod) error {
+	query := `
+        INSERT INTO payment_methods (user_id, gateway, token, type, brand, last4, exp_month, exp_year, is_default)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		method.UserID,
+		method.Gateway,
+		method.Token,
+		method.Type,
+		method.Brand,
+		method.Last4,
+		method.ExpMonth,
+		method.ExpYear,
+		method.IsDefault,
+	).Scan(&method.ID, &method.CreatedAt)
+}
+
+func (r *paymentMethodRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SavedPaymentMethod, error) {
+	query := `SELECT ` + paymentMethodColumns + paymentMethodFrom + ` WHERE pm.id = $1`
+	return scanPaymentMethod(database.Conn(ctx, r.db).QueryRow(ctx, query, id))
+}
+
+func (r *paymentMethodRepository) GetByToken(ctx context.Context, userID uuid.UUID, gateway, token string) (*models.SavedPaymentMethod, error) {
+	query := `SELECT ` + paymentMethodColumns + paymentMethodFrom + `
+        WHERE pm.user_id = $1 AND pm.gateway = $2 AND pm.token = $3`
+	return scanPaymentMethod(database.Conn(ctx, r.db).QueryRow(ctx, query, userID, gateway, token))
+}
+
+// GetByUserID lists a user's saved methods, the default first
+func (r *paymentMethodRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]models.SavedPaymentMethod, error) {
+	query := `SELECT ` + paymentMethodColumns + paymentMethodFrom + `
+        WHERE pm.user_id = $1
+        ORDER BY pm.is_default DESC, pm.created_at DESC`
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods := []models.SavedPaymentMethod{}
+	for rows.Next() {
+		method, err := scanPaymentMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, *method)
+	}
+
+	return methods, rows.Err()
+}
+
+// SetDefault makes id the user's only default method. Call it inside a
+// transaction so the one-default-per-user index is never seen violated.
+func (r *paymentMethodRepository) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	conn := database.Conn(ctx, r.db)
+
+	if _, err := conn.Exec(ctx, `UPDATE payment_methods SET is_default = FALSE WHERE user_id = $1 AND is_default AND id <> $2`, userID, id); err != nil {
+		return err
+	}
+
+	_, err := conn.Exec(ctx, `UPDATE payment_methods SET is_default = TRUE WHERE id = $1 AND user_id = $2`, id, userID)
+	return err
+}
+
+func (r *paymentMethodRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM payment_methods WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+// GetCustomerID returns the user's customer ID with the gateway, or "" when
+// none has been created yet
+func (r *paymentMethodRepository) GetCustomerID(ctx context.Context, userID uuid.UUID, gateway string) (string, error) {
+	query := `SELECT customer_id FROM payment_customers WHERE user_id = $1 AND gateway = $2`
+
+	var customerID string
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID, gateway).Scan(&customerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return customerID, err
+}
+
+func (r *paymentMethodRepository) SaveCustomerID(ctx context.Context, userID uuid.UUID, gateway, customerID string) error {
+	query := `
+        INSERT INTO payment_customers (user_id, gateway, customer_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, gateway) DO NOTHING
+    `
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, userID, gateway, customerID)
+	return err
+}
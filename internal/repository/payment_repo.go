@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -14,8 +18,13 @@ type PaymentRepository interface {
 	Create(ctx context.Context, payment *models.Payment) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Payment, error)
 	GetByOrderID(ctx context.Context, orderID uuid.UUID) (*models.Payment, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status models.PaymentStatus, transactionID string) error
-	UpdateStatusWithRefund(ctx context.Context, id uuid.UUID, status models.PaymentStatus, refundAmount float64) error
+	GetByTransactionID(ctx context.Context, transactionID string) (*models.Payment, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.PaymentStatus, transactionID string, from []models.PaymentStatus) (bool, error)
+	RecordRefund(ctx context.Context, refund *models.Refund) error
+	RecordCapture(ctx context.Context, capture *models.PaymentCapture) error
+	GetRefundsByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.Refund, error)
+	GetAll(ctx context.Context, filter models.PaymentFilter, page, limit int) ([]models.AdminPayment, int, error)
+	FindReconciliationIssues(ctx context.Context, rangeDays int) ([]models.ReconciliationIssue, error)
 }
 
 type paymentRepository struct {
@@ -33,7 +42,7 @@ func (r *paymentRepository) Create(ctx context.Context, payment *models.Payment)
         RETURNING created_at, updated_at
     `
 
-	return r.db.QueryRow(ctx, query,
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
 		payment.ID,
 		payment.OrderID,
 		payment.Amount,
@@ -46,17 +55,19 @@ func (r *paymentRepository) Create(ctx context.Context, payment *models.Payment)
 
 func (r *paymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
 	query := `
-        SELECT id, order_id, amount, status, payment_method, transaction_id, 
-               payment_details, created_at, updated_at
-        FROM payments
-        WHERE id = $1
+        SELECT p.id, p.order_id, p.amount, p.refunded_amount, p.status, p.payment_method, p.transaction_id,
+               p.payment_details, p.created_at, p.updated_at
+        FROM payments p
+        JOIN orders o ON o.id = p.order_id
+        WHERE p.id = $1 AND ($2::uuid IS NULL OR o.store_id = $2)
     `
 
 	var payment models.Payment
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id, database.StoreArg(ctx)).Scan(
 		&payment.ID,
 		&payment.OrderID,
 		&payment.Amount,
+		&payment.RefundedAmount,
 		&payment.Status,
 		&payment.PaymentMethod,
 		&payment.TransactionID,
@@ -74,19 +85,55 @@ func (r *paymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 
 func (r *paymentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
 	query := `
-        SELECT id, order_id, amount, status, payment_method, transaction_id, 
+        SELECT p.id, p.order_id, p.amount, p.refunded_amount, p.status, p.payment_method, p.transaction_id,
+               p.payment_details, p.created_at, p.updated_at
+        FROM payments p
+        JOIN orders o ON o.id = p.order_id
+        WHERE p.order_id = $1 AND ($2::uuid IS NULL OR o.store_id = $2)
+        ORDER BY p.created_at DESC
+        LIMIT 1
+    `
+
+	var payment models.Payment
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, orderID, database.StoreArg(ctx)).Scan(
+		&payment.ID,
+		&payment.OrderID,
+		&payment.Amount,
+		&payment.RefundedAmount,
+		&payment.Status,
+		&payment.PaymentMethod,
+		&payment.TransactionID,
+		&payment.PaymentDetails,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (r *paymentRepository) GetByTransactionID(ctx context.Context, transactionID string) (*models.Payment, error) {
+	query := `
+        SELECT id, order_id, amount, refunded_amount, status, payment_method, transaction_id,
                payment_details, created_at, updated_at
         FROM payments
-        WHERE order_id = $1
+        WHERE transaction_id = $1
         ORDER BY created_at DESC
         LIMIT 1
     `
 
 	var payment models.Payment
-	err := r.db.QueryRow(ctx, query, orderID).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, transactionID).Scan(
 		&payment.ID,
 		&payment.OrderID,
 		&payment.Amount,
+		&payment.RefundedAmount,
 		&payment.Status,
 		&payment.PaymentMethod,
 		&payment.TransactionID,
@@ -105,24 +152,297 @@ func (r *paymentRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID)
 	return &payment, nil
 }
 
-func (r *paymentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.PaymentStatus, transactionID string) error {
+// UpdateStatus moves a payment to status if it is still in one of the from
+// statuses, and reports whether it did. Webhooks are retried and may arrive
+// together, so only the call that makes the transition should act on it.
+func (r *paymentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.PaymentStatus, transactionID string, from []models.PaymentStatus) (bool, error) {
 	query := `
         UPDATE payments
         SET status = $1, transaction_id = $2, updated_at = NOW()
-        WHERE id = $3
+        WHERE id = $3 AND status = ANY($4)
     `
 
-	_, err := r.db.Exec(ctx, query, status, transactionID, id)
+	fromStatuses := make([]string, len(from))
+	for i, status := range from {
+		fromStatuses[i] = string(status)
+	}
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, status, transactionID, id, fromStatuses)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// RecordRefund adds refund to the payment's running refunded total and logs
+// it. The payment is marked refunded once nothing is left to refund. Fails
+// if the payment is not refundable or the refund would exceed what was paid.
+// The refund keeps its ID when it already has one.
+func (r *paymentRepository) RecordRefund(ctx context.Context, refund *models.Refund) error {
+	query := `
+        WITH updated AS (
+            UPDATE payments
+            SET refunded_amount = refunded_amount + $2,
+                status = CASE WHEN refunded_amount + $2 >= amount THEN 'refunded' ELSE 'partially_refunded' END,
+                updated_at = NOW()
+            WHERE id = $1
+                AND status IN ('completed', 'partially_refunded')
+                AND refunded_amount + $2 <= amount
+            RETURNING id, order_id
+        )
+        INSERT INTO refunds (id, payment_id, order_id, return_id, amount, reason)
+        SELECT $5, id, order_id, $3, $2, NULLIF($4, '')
+        FROM updated
+        RETURNING order_id, created_at
+    `
+
+	if refund.ID == uuid.Nil {
+		refund.ID = uuid.New()
+	}
+
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query,
+		refund.PaymentID,
+		refund.Amount,
+		refund.ReturnID,
+		refund.Reason,
+		refund.ID,
+	).Scan(&refund.OrderID, &refund.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.Validation("refund exceeds the refundable amount")
+	}
 	return err
 }
 
-func (r *paymentRepository) UpdateStatusWithRefund(ctx context.Context, id uuid.UUID, status models.PaymentStatus, refundAmount float64) error {
+// RecordCapture settles an authorized payment as capture describes and logs
+// it: the payment's amount becomes what was captured, and it is completed,
+// or voided when nothing was. Fails with a conflict if the payment is no
+// longer authorized for the captured and released amounts together.
+func (r *paymentRepository) RecordCapture(ctx context.Context, capture *models.PaymentCapture) error {
 	query := `
-        UPDATE payments
-        SET status = $1, updated_at = NOW()
-        WHERE id = $2
+        WITH updated AS (
+            UPDATE payments
+            SET amount = $2,
+                status = CASE WHEN $2 > 0 THEN 'completed' ELSE 'voided' END,
+                updated_at = NOW()
+            WHERE id = $1
+                AND status = 'authorized'
+                AND amount = $2 + $3
+            RETURNING id, order_id
+        )
+        INSERT INTO payment_captures (payment_id, order_id, captured_amount, released_amount, reason, created_by)
+        SELECT id, order_id, $2, $3, NULLIF($4, ''), $5
+        FROM updated
+        RETURNING id, order_id, created_at
     `
 
-	_, err := r.db.Exec(ctx, query, status, id)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query,
+		capture.PaymentID,
+		capture.CapturedAmount,
+		capture.ReleasedAmount,
+		capture.Reason,
+		capture.CreatedBy,
+	).Scan(&capture.ID, &capture.OrderID, &capture.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.Conflict("payment is no longer authorized")
+	}
 	return err
 }
+
+func (r *paymentRepository) GetRefundsByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.Refund, error) {
+	query := `
+        SELECT id, payment_id, order_id, return_id, amount, COALESCE(reason, ''), created_at
+        FROM refunds
+        WHERE order_id = $1
+        ORDER BY created_at
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []models.Refund{}
+	for rows.Next() {
+		var refund models.Refund
+		err := rows.Scan(
+			&refund.ID,
+			&refund.PaymentID,
+			&refund.OrderID,
+			&refund.ReturnID,
+			&refund.Amount,
+			&refund.Reason,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, rows.Err()
+}
+
+func (r *paymentRepository) GetAll(ctx context.Context, filter models.PaymentFilter, page, limit int) ([]models.AdminPayment, int, error) {
+	offset := (page - 1) * limit
+
+	// Build WHERE clause
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argCount := 1
+
+	if filter.Status != "" {
+		whereClause += fmt.Sprintf(" AND p.status = $%d", argCount)
+		args = append(args, filter.Status)
+		argCount++
+	}
+	if filter.Method != "" {
+		whereClause += fmt.Sprintf(" AND p.payment_method = $%d", argCount)
+		args = append(args, filter.Method)
+		argCount++
+	}
+	if filter.From != nil {
+		whereClause += fmt.Sprintf(" AND p.created_at >= $%d", argCount)
+		args = append(args, *filter.From)
+		argCount++
+	}
+	if filter.To != nil {
+		whereClause += fmt.Sprintf(" AND p.created_at < $%d", argCount)
+		args = append(args, *filter.To)
+		argCount++
+	}
+	if storeID, ok := database.StoreFromContext(ctx); ok {
+		whereClause += fmt.Sprintf(" AND o.store_id = $%d", argCount)
+		args = append(args, storeID)
+		argCount++
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM payments p JOIN orders o ON p.order_id = o.id %s", whereClause)
+	var total int
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT p.id, p.order_id, p.amount, p.refunded_amount, p.status, p.payment_method,
+               p.transaction_id, p.payment_details, p.created_at, p.updated_at,
+               o.order_number, o.status, u.id, u.email
+        FROM payments p
+        JOIN orders o ON p.order_id = o.id
+        JOIN users u ON o.user_id = u.id
+        %s
+        ORDER BY p.created_at DESC
+        LIMIT $%d OFFSET $%d
+    `, whereClause, argCount, argCount+1)
+
+	args = append(args, limit, offset)
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	payments := []models.AdminPayment{}
+	for rows.Next() {
+		var payment models.AdminPayment
+		err := rows.Scan(
+			&payment.ID,
+			&payment.OrderID,
+			&payment.Amount,
+			&payment.RefundedAmount,
+			&payment.Status,
+			&payment.PaymentMethod,
+			&payment.TransactionID,
+			&payment.PaymentDetails,
+			&payment.CreatedAt,
+			&payment.UpdatedAt,
+			&payment.Order.OrderNumber,
+			&payment.Order.Status,
+			&payment.User.ID,
+			&payment.User.Email,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, total, rows.Err()
+}
+
+// FindReconciliationIssues cross-checks orders placed within rangeDays (all
+// orders when 0) against their latest payment. It reports orders in a paid
+// status without a settled payment, settled payments that do not match the
+// amount due, and refunds on orders that have no completed return. COD
+// orders only count as paid once delivered, and free exchange replacements
+// need no payment.
+func (r *paymentRepository) FindReconciliationIssues(ctx context.Context, rangeDays int) ([]models.ReconciliationIssue, error) {
+	query := `
+        WITH latest AS (
+            SELECT DISTINCT ON (order_id) id, order_id, status, amount, refunded_amount
+            FROM payments
+            ORDER BY order_id, created_at DESC
+        ),
+        scoped AS (
+            SELECT o.id, o.order_number, o.status, o.payment_method,
+                   o.total_amount - o.gift_card_amount AS amount_due,
+                   p.id AS payment_id, p.status AS payment_status,
+                   p.amount AS payment_amount, p.refunded_amount
+            FROM orders o
+            LEFT JOIN latest p ON p.order_id = o.id
+            WHERE $1::integer = 0 OR o.created_at >= NOW() - $1::integer * INTERVAL '1 day'
+        )
+        SELECT 'paid_without_payment', id, order_number, status, amount_due,
+               payment_id, payment_status, payment_amount, refunded_amount
+        FROM scoped
+        WHERE status IN ('processing', 'partially_shipped', 'shipped', 'delivered', 'completed')
+            AND (payment_method <> 'cod' OR status IN ('delivered', 'completed'))
+            AND (payment_id IS NULL OR payment_status NOT IN ('completed', 'partially_refunded', 'refunded'))
+            AND NOT EXISTS (SELECT 1 FROM returns r WHERE r.replacement_order_id = scoped.id)
+        UNION ALL
+        SELECT 'amount_mismatch', id, order_number, status, amount_due,
+               payment_id, payment_status, payment_amount, refunded_amount
+        FROM scoped
+        WHERE payment_status IN ('completed', 'partially_refunded', 'refunded')
+            AND payment_amount <> amount_due
+        UNION ALL
+        SELECT 'refund_without_return', s.id, s.order_number, s.status, s.amount_due,
+               s.payment_id, s.payment_status, s.payment_amount, s.refunded_amount
+        FROM scoped s
+        WHERE s.refunded_amount > 0
+            AND NOT EXISTS (
+                SELECT 1 FROM returns r WHERE r.order_id = s.id AND r.status = 'completed'
+            )
+        ORDER BY 1, 3
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, rangeDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	issues := []models.ReconciliationIssue{}
+	for rows.Next() {
+		var issue models.ReconciliationIssue
+		err := rows.Scan(
+			&issue.Type,
+			&issue.OrderID,
+			&issue.OrderNumber,
+			&issue.OrderStatus,
+			&issue.AmountDue,
+			&issue.PaymentID,
+			&issue.PaymentStatus,
+			&issue.PaymentAmount,
+			&issue.RefundedAmount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, rows.Err()
+}
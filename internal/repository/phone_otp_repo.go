@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PhoneOTPRepository interface {
+	Save(ctx context.Context, otp *models.PhoneOTP) error
+	Get(ctx context.Context, userID uuid.UUID, purpose models.OTPPurpose) (*models.PhoneOTP, error)
+	ClaimAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) (string, bool, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type phoneOTPRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPhoneOTPRepository(db *pgxpool.Pool) PhoneOTPRepository {
+	return &phoneOTPRepository{db: db}
+}
+
+// Save stores a new code for the user and purpose, replacing any earlier one
+// and resetting its attempt count. CreatedAt is when the code was sent.
+func (r *phoneOTPRepository) Save(ctx context.Context, otp *models.PhoneOTP) error {
+	query := `
+        INSERT INTO phone_otps (user_id, purpose, phone, otp_hash, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (user_id, purpose) DO UPDATE
+        SET phone = EXCLUDED.phone,
+            otp_hash = EXCLUDED.otp_hash,
+            expires_at = EXCLUDED.expires_at,
+            attempts = 0,
+            created_at = EXCLUDED.created_at
+        RETURNING id, attempts
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		otp.UserID,
+		otp.Purpose,
+		otp.Phone,
+		otp.OTPHash,
+		otp.ExpiresAt,
+		otp.CreatedAt,
+	).Scan(&otp.ID, &otp.Attempts)
+}
+
+func (r *phoneOTPRepository) Get(ctx context.Context, userID uuid.UUID, purpose models.OTPPurpose) (*models.PhoneOTP, error) {
+	query := `
+        SELECT id, user_id, purpose, phone, otp_hash, expires_at, attempts, created_at
+        FROM phone_otps
+        WHERE user_id = $1 AND purpose = $2
+    `
+
+	var otp models.PhoneOTP
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID, purpose).Scan(
+		&otp.ID,
+		&otp.UserID,
+		&otp.Purpose,
+		&otp.Phone,
+		&otp.OTPHash,
+		&otp.ExpiresAt,
+		&otp.Attempts,
+		&otp.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &otp, nil
+}
+
+// ClaimAttempt counts an attempt at the code and returns its hash to check
+// against. It returns false once maxAttempts have been made, so concurrent
+// guesses cannot get past the limit.
+func (r *phoneOTPRepository) ClaimAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) (string, bool, error) {
+	query := `
+        UPDATE phone_otps
+        SET attempts = attempts + 1
+        WHERE id = $1 AND attempts < $2
+        RETURNING otp_hash
+    `
+
+	var otpHash string
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id, maxAttempts).Scan(&otpHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return otpHash, true, nil
+}
+
+// Delete uses up a code. It fails when another request used it first.
+func (r *phoneOTPRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM phone_otps WHERE id = $1`
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Conflict("code already used")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PriceRepository interface {
+	Create(ctx context.Context, schedule *models.PriceSchedule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PriceSchedule, error)
+	GetByProductID(ctx context.Context, productID uuid.UUID) ([]models.PriceSchedule, error)
+	GetActive(ctx context.Context, productIDs []uuid.UUID) ([]models.PriceSchedule, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type priceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPriceRepository(db *pgxpool.Pool) PriceRepository {
+	return &priceRepository{db: db}
+}
+
+const priceScheduleColumns = `id, product_id, variant_id, price, COALESCE(label, ''), starts_at, ends_at, created_at`
+
+func scanPriceSchedule(row pgx.Row) (models.PriceSchedule, error) {
+	var schedule models.PriceSchedule
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.ProductID,
+		&schedule.VariantID,
+		&schedule.Price,
+		&schedule.Label,
+		&schedule.StartsAt,
+		&schedule.EndsAt,
+		&schedule.CreatedAt,
+	)
+	return schedule, err
+}
+
+func (r *priceRepository) Create(ctx context.Context, schedule *models.PriceSchedule) error {
+	query := `
+        INSERT INTO product_prices (product_id, variant_id, price, label, starts_at, ends_at)
+        VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		schedule.ProductID,
+		schedule.VariantID,
+		schedule.Price,
+		schedule.Label,
+		schedule.StartsAt,
+		schedule.EndsAt,
+	).Scan(&schedule.ID, &schedule.CreatedAt)
+}
+
+func (r *priceRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PriceSchedule, error) {
+	query := `SELECT ` + priceScheduleColumns + ` FROM product_prices WHERE id = $1`
+
+	schedule, err := scanPriceSchedule(database.Conn(ctx, r.db).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *priceRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]models.PriceSchedule, error) {
+	query := `
+        SELECT ` + priceScheduleColumns + `
+        FROM product_prices
+        WHERE product_id = $1
+        ORDER BY starts_at DESC
+    `
+
+	return r.query(ctx, query, productID)
+}
+
+// GetActive returns the schedule in effect right now for each product and
+// variant in productIDs. When windows overlap, the lowest price wins.
+func (r *priceRepository) GetActive(ctx context.Context, productIDs []uuid.UUID) ([]models.PriceSchedule, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+        SELECT DISTINCT ON (product_id, variant_id) ` + priceScheduleColumns + `
+        FROM product_prices
+        WHERE product_id = ANY($1::uuid[]) AND starts_at <= NOW() AND ends_at > NOW()
+        ORDER BY product_id, variant_id, price ASC, starts_at DESC
+    `
+
+	return r.query(ctx, query, productIDs)
+}
+
+func (r *priceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := "DELETE FROM product_prices WHERE id = $1"
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+func (r *priceRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.PriceSchedule, error) {
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []models.PriceSchedule
+	for rows.Next() {
+		schedule, err := scanPriceSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, rows.Err()
+}
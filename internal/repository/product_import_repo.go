@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ProductImportRepository interface {
+	Create(ctx context.Context, job *models.ProductImportJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ProductImportJob, error)
+	UpdateProgress(ctx context.Context, job *models.ProductImportJob) error
+}
+
+type productImportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewProductImportRepository(db *pgxpool.Pool) ProductImportRepository {
+	return &productImportRepository{db: db}
+}
+
+func (r *productImportRepository) Create(ctx context.Context, job *models.ProductImportJob) error {
+	query := `
+        INSERT INTO product_import_jobs (id, filename, status, created_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		job.ID,
+		job.Filename,
+		job.Status,
+		job.CreatedBy,
+	).Scan(&job.CreatedAt)
+}
+
+func (r *productImportRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ProductImportJob, error) {
+	query := `
+        SELECT id, filename, status, total_rows, created_count, updated_count, failed_count,
+               row_errors, error_message, created_by, created_at, completed_at
+        FROM product_import_jobs
+        WHERE id = $1
+    `
+
+	var job models.ProductImportJob
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&job.ID,
+		&job.Filename,
+		&job.Status,
+		&job.TotalRows,
+		&job.CreatedCount,
+		&job.UpdatedCount,
+		&job.FailedCount,
+		&job.RowErrors,
+		&job.ErrorMessage,
+		&job.CreatedBy,
+		&job.CreatedAt,
+		&job.CompletedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// UpdateProgress saves the job's counters, errors and status; completed_at is
+// stamped once the job reaches a terminal status
+func (r *productImportRepository) UpdateProgress(ctx context.Context, job *models.ProductImportJob) error {
+	query := `
+        UPDATE product_import_jobs
+        SET status = $1, total_rows = $2, created_count = $3, updated_count = $4,
+            failed_count = $5, row_errors = $6, error_message = $7,
+            completed_at = CASE WHEN $1 IN ('completed', 'failed') THEN NOW() ELSE NULL END
+        WHERE id = $8
+    `
+
+	rowErrors := job.RowErrors
+	if rowErrors == nil {
+		rowErrors = []models.ImportRowError{}
+	}
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query,
+		job.Status,
+		job.TotalRows,
+		job.CreatedCount,
+		job.UpdatedCount,
+		job.FailedCount,
+		rowErrors,
+		job.ErrorMessage,
+		job.ID,
+	)
+	return err
+}
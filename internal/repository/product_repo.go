@@ -5,8 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/pagination"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -15,38 +20,68 @@ import (
 
 type ProductRepository interface {
 	Create(ctx context.Context, product *models.Product) error
+	UpsertBySKU(ctx context.Context, products []models.Product) (int, int, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Product, error)
 	GetBySKU(ctx context.Context, sku string) (*models.Product, error)
-	GetAll(ctx context.Context, page, limit int, category, search string) ([]models.Product, int, error)
-	GetAllAdmin(ctx context.Context, page, limit, rangeDays int) ([]models.Product, int, error)
+	GetByBarcode(ctx context.Context, barcode string) (*models.Product, error)
+	GetAll(ctx context.Context, page, limit int, filter models.ProductFilter) ([]models.Product, int, error)
+	GetPage(ctx context.Context, filter models.ProductFilter, after *pagination.Cursor, limit int) ([]models.Product, *pagination.Cursor, error)
+	GetFacets(ctx context.Context, filter models.ProductFilter, priceBuckets int) (*models.ProductFacets, error)
+	GetAllAdmin(ctx context.Context, page, limit, rangeDays int, status models.ProductStatus) ([]models.Product, int, error)
+	ExportAll(ctx context.Context, rangeDays int, fn func(models.Product) error) error
 	GetTopProducts(ctx context.Context, limit, rangeDays int) ([]models.TopProductItem, error)
 	Update(ctx context.Context, id uuid.UUID, updateData *models.ProductUpdateRequest) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error
-	UpdateStockWithTx(ctx context.Context, tx pgx.Tx, id uuid.UUID, quantity int) error
+	SetSchedule(ctx context.Context, id uuid.UUID, publishAt, unpublishAt *time.Time) error
+	PublishDue(ctx context.Context) ([]uuid.UUID, error)
+	UnpublishDue(ctx context.Context) ([]uuid.UUID, error)
+	GetSearchDocuments(ctx context.Context, ids []uuid.UUID) ([]models.ProductDocument, error)
+	GetSearchDocumentPage(ctx context.Context, after uuid.UUID, limit int) ([]models.ProductDocument, error)
+	UpdateStock(ctx context.Context, id uuid.UUID, quantity int) (int, error)
 	GetStock(ctx context.Context, id uuid.UUID) (int, error)
-	ReserveStock(ctx context.Context, productID, cartID uuid.UUID, quantity int, expiresAt int64) error
-	ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID) error
-	GetAvailableStock(ctx context.Context, productID uuid.UUID) (int, error)
-	GetAvailableStockExcludingCart(ctx context.Context, productID, cartID uuid.UUID) (int, error)
+	LockStock(ctx context.Context, productIDs []uuid.UUID) error
+	ReserveStock(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int, expiresAt int64) error
+	SetReservationQuantity(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int, expiresAt int64) error
+	ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID) error
+	ReleaseCartReservations(ctx context.Context, cartID uuid.UUID) error
+	TransferReservationsToOrder(ctx context.Context, cartID, orderID uuid.UUID, expiresAt int64) error
+	ReleaseOrderReservations(ctx context.Context, orderID uuid.UUID) error
+	CommitReservation(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) (int, error)
+	PurgeExpiredReservations(ctx context.Context) (int, int, error)
+	GetAvailableStock(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID) (int, error)
+	GetAvailableStockExcludingCart(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID) (int, error)
 }
 
+// Storefront listings, facets and top products read from replica when one is
+// configured and up; everything else uses db
 type productRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	replica *database.Replica
 }
 
-func NewProductRepository(db *pgxpool.Pool) ProductRepository {
-	return &productRepository{db: db}
+func NewProductRepository(db *pgxpool.Pool, replica *database.Replica) ProductRepository {
+	return &productRepository{db: db, replica: replica}
 }
 
 func (r *productRepository) Create(ctx context.Context, product *models.Product) error {
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-        INSERT INTO products (sku, name, description, price, stock_quantity, category, image_url)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        INSERT INTO products (store_id, sku, name, description, price, stock_quantity, category, image_url,
+                              max_per_order, max_per_customer, barcode, cost_price, status,
+                              publish_at, unpublish_at, weight_grams, length_mm, width_mm, height_mm)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
         RETURNING id, created_at, updated_at
     `
 
-	return r.db.QueryRow(ctx, query,
+	length, width, height := dimensionColumns(product.Dimensions)
+
+	err = tx.QueryRow(ctx, query,
+		storeID(ctx),
 		product.SKU,
 		product.Name,
 		product.Description,
@@ -54,65 +89,192 @@ func (r *productRepository) Create(ctx context.Context, product *models.Product)
 		product.Stock,
 		product.Category,
 		product.ImageURL,
+		product.MaxPerOrder,
+		product.MaxPerCustomer,
+		product.Barcode,
+		product.CostPrice,
+		product.Status,
+		product.PublishAt,
+		product.UnpublishAt,
+		product.WeightGrams,
+		length,
+		width,
+		height,
 	).Scan(&product.ID, &product.CreatedAt, &product.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Initial stock is received into the default warehouse
+	if err := syncDefaultWarehouseStock(ctx, tx, []uuid.UUID{product.ID}, nil); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
-	query := `
-        SELECT 
-            p.id, p.sku, p.name, p.description, p.price,
-            p.stock_quantity - COALESCE(SUM(sr.quantity), 0) as available_stock,
-            p.category, p.image_url, p.created_at, p.updated_at
-        FROM products p
-        LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
-            AND sr.expires_at > NOW()
-        WHERE p.id = $1
-        GROUP BY p.id, p.sku, p.name, p.description, p.price, p.stock_quantity,
-                 p.category, p.image_url, p.created_at, p.updated_at
+// UpsertBySKU bulk loads products through COPY into a staging table and merges
+// them on SKU into the context's store. It returns how many products were
+// created and updated. SKUs must be unique within the batch and may not belong
+// to another store.
+func (r *productRepository) UpsertBySKU(ctx context.Context, products []models.Product) (int, int, error) {
+	if len(products) == 0 {
+		return 0, 0, nil
+	}
+
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	stagingQuery := `
+        CREATE TEMP TABLE product_import_staging (
+            sku VARCHAR(100) NOT NULL,
+            name VARCHAR(255) NOT NULL,
+            description TEXT,
+            price DECIMAL(10, 2) NOT NULL,
+            stock_quantity INTEGER NOT NULL,
+            category VARCHAR(100),
+            image_url VARCHAR(500)
+        ) ON COMMIT DROP
     `
+	if _, err := tx.Exec(ctx, stagingQuery); err != nil {
+		return 0, 0, err
+	}
 
-	var product models.Product
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&product.ID,
-		&product.SKU,
-		&product.Name,
-		&product.Description,
-		&product.Price,
-		&product.Stock,
-		&product.Category,
-		&product.ImageURL,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-	)
+	columns := []string{"sku", "name", "description", "price", "stock_quantity", "category", "image_url"}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"product_import_staging"}, columns,
+		pgx.CopyFromSlice(len(products), func(i int) ([]any, error) {
+			p := products[i]
+			return []any{p.SKU, p.Name, p.Description, p.Price, p.Stock, p.Category, p.ImageURL}, nil
+		}))
+	if err != nil {
+		return 0, 0, err
+	}
 
+	mergeQuery := `
+        INSERT INTO products (store_id, sku, name, description, price, stock_quantity, category, image_url)
+        SELECT $1, sku, name, description, price, stock_quantity, category, image_url
+        FROM product_import_staging
+        ON CONFLICT (sku) DO UPDATE SET
+            name = EXCLUDED.name,
+            description = EXCLUDED.description,
+            price = EXCLUDED.price,
+            stock_quantity = EXCLUDED.stock_quantity,
+            category = EXCLUDED.category,
+            image_url = EXCLUDED.image_url,
+            updated_at = NOW()
+        WHERE products.store_id = EXCLUDED.store_id
+        RETURNING id, (xmax = 0) AS inserted
+    `
+
+	rows, err := tx.Query(ctx, mergeQuery, storeID(ctx))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
+		return 0, 0, err
+	}
+
+	created, updated := 0, 0
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for rows.Next() {
+		var id uuid.UUID
+		var inserted bool
+		if err := rows.Scan(&id, &inserted); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		productIDs = append(productIDs, id)
+		if inserted {
+			created++
+		} else {
+			updated++
 		}
-		return nil, err
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
 	}
 
-	return &product, nil
+	// Rows whose SKU another store owns were neither inserted nor updated
+	if len(productIDs) < len(products) {
+		return 0, 0, apperrors.Conflict("some SKUs already belong to another store")
+	}
+
+	// Drop explicitly: inside an outer transaction this is only a savepoint,
+	// and the next batch recreates the table
+	if _, err := tx.Exec(ctx, `DROP TABLE product_import_staging`); err != nil {
+		return 0, 0, err
+	}
+
+	// Imported stock levels are totals; the default warehouse absorbs the change
+	if err := syncDefaultWarehouseStock(ctx, tx, productIDs, nil); err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	return created, updated, nil
 }
 
-func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
-	query := `
+// availableStockColumn is a product's unreserved stock in queries joining its
+// live reservations as sr and grouping by p.id. Units reserved through
+// bundles are not free either, and a bundle has as many as its components
+// make up.
+const availableStockColumn = `COALESCE(
+                bundle_available_stock(p.id, NULL),
+                p.stock_quantity - COALESCE(SUM(sr.quantity), 0) - bundle_reserved_stock(p.id, NULL, NULL)
+            )`
+
+// productDetailQuery selects one product with its unreserved stock; %s is
+// the WHERE condition on p
+const productDetailQuery = `
         SELECT 
-            p.id, p.sku, p.name, p.description, p.price,
-            p.stock_quantity - COALESCE(SUM(sr.quantity), 0) as available_stock,
-            p.category, p.image_url, p.created_at, p.updated_at
+            p.id, p.sku, p.status, p.barcode, p.name, p.description, p.price,
+            ` + availableStockColumn + ` as available_stock,
+            p.category, p.image_url, p.created_at, p.updated_at,
+            p.max_per_order, p.max_per_customer, p.publish_at, p.unpublish_at,
+            p.weight_grams, p.length_mm, p.width_mm, p.height_mm
         FROM products p
         LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
+            AND sr.variant_id IS NULL
             AND sr.expires_at > NOW()
-        WHERE p.sku = $1
-        GROUP BY p.id, p.sku, p.name, p.description, p.price, p.stock_quantity,
-                 p.category, p.image_url, p.created_at, p.updated_at
+        WHERE %s
+        GROUP BY p.id
     `
 
+// productByIDQuery runs for every cart and checkout line, so it is prepared
+// on each connection
+var productByIDQuery = database.Statement("product_by_id", fmt.Sprintf(productDetailQuery, "p.id = $1 AND ($2::uuid IS NULL OR p.store_id = $2)"))
+
+func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	return scanProductDetail(database.Conn(ctx, r.db).QueryRow(ctx, productByIDQuery, id, database.StoreArg(ctx)))
+}
+
+// GetBySKU is not scoped to a store: SKUs are unique across the deployment
+func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	query := fmt.Sprintf(productDetailQuery, "p.sku = $1")
+	return scanProductDetail(database.Conn(ctx, r.db).QueryRow(ctx, query, sku))
+}
+
+// GetByBarcode finds a product by its normalized barcode. Like SKUs, barcodes
+// are unique across stores, so the lookup is not scoped.
+func (r *productRepository) GetByBarcode(ctx context.Context, barcode string) (*models.Product, error) {
+	query := fmt.Sprintf(productDetailQuery, "p.barcode = $1")
+	return scanProductDetail(database.Conn(ctx, r.db).QueryRow(ctx, query, barcode))
+}
+
+// scanProductDetail reads a productDetailQuery row, returning nil when there
+// is none
+func scanProductDetail(row pgx.Row) (*models.Product, error) {
 	var product models.Product
-	err := r.db.QueryRow(ctx, query, sku).Scan(
+	var length, width, height *int
+	err := row.Scan(
 		&product.ID,
 		&product.SKU,
+		&product.Status,
+		&product.Barcode,
 		&product.Name,
 		&product.Description,
 		&product.Price,
@@ -121,6 +283,14 @@ func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*models.P
 		&product.ImageURL,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.MaxPerOrder,
+		&product.MaxPerCustomer,
+		&product.PublishAt,
+		&product.UnpublishAt,
+		&product.WeightGrams,
+		&length,
+		&width,
+		&height,
 	)
 
 	if err != nil {
@@ -129,57 +299,143 @@ func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*models.P
 		}
 		return nil, err
 	}
+	product.Dimensions = dimensionsFrom(length, width, height)
 
 	return &product, nil
 }
 
-func (r *productRepository) GetAll(ctx context.Context, page, limit int, category, search string) ([]models.Product, int, error) {
+// dimensionColumns splits dimensions into their nullable columns
+func dimensionColumns(dimensions *models.Dimensions) (*int, *int, *int) {
+	if dimensions == nil {
+		return nil, nil, nil
+	}
+	return &dimensions.LengthMM, &dimensions.WidthMM, &dimensions.HeightMM
+}
+
+// dimensionsFrom reads dimensions back from their columns, nil when unset
+func dimensionsFrom(length, width, height *int) *models.Dimensions {
+	if length == nil || width == nil || height == nil {
+		return nil
+	}
+	return &models.Dimensions{LengthMM: *length, WidthMM: *width, HeightMM: *height}
+}
+
+// productSortClauses whitelists the ORDER BY expressions a client may request
+var productSortClauses = map[string]string{
+	models.ProductSortNewest:     "p.created_at DESC",
+	models.ProductSortPriceAsc:   "p.price ASC",
+	models.ProductSortPriceDesc:  "p.price DESC",
+	models.ProductSortPopularity: "COALESCE(pop.units_sold, 0) DESC",
+}
+
+// inStockCondition matches products where the base product or any variant
+// has unreserved units, and bundles whose components make up at least one
+const inStockCondition = `(
+                COALESCE(bundle_available_stock(p.id, NULL), p.stock_quantity - COALESCE((
+                    SELECT SUM(r.quantity) FROM stock_reservations r
+                    WHERE r.product_id = p.id AND r.variant_id IS NULL AND r.expires_at > NOW()
+                ), 0) - bundle_reserved_stock(p.id, NULL, NULL)) > 0
+                OR EXISTS (
+                    SELECT 1 FROM product_variants v
+                    WHERE v.product_id = p.id
+                      AND v.stock_quantity - COALESCE((
+                          SELECT SUM(r.quantity) FROM stock_reservations r
+                          WHERE r.variant_id = v.id AND r.expires_at > NOW()
+                      ), 0) - bundle_reserved_stock(p.id, v.id, NULL) > 0
+                )
+            )`
+
+// productFilterWhere builds the WHERE clause for a product filter against the
+// products table aliased as p, within the context's store
+func productFilterWhere(ctx context.Context, filter models.ProductFilter) *database.Where {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "p.store_id")
+	where.And("p.status = ?", models.ProductActive)
+
+	if len(filter.Categories) > 0 {
+		where.And("p.category = ANY(?)", filter.Categories)
+	}
+
+	if filter.MatchIDs != nil {
+		where.And("p.id = ANY(?)", filter.MatchIDs)
+	} else if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		where.And("(p.name ILIKE ? OR p.description ILIKE ?)", search, search)
+	}
+
+	if filter.MinPrice != nil {
+		where.And("p.price >= ?", *filter.MinPrice)
+	}
+
+	if filter.MaxPrice != nil {
+		where.And("p.price <= ?", *filter.MaxPrice)
+	}
+
+	if filter.InStockOnly {
+		where.And(inStockCondition)
+	}
+
+	return where
+}
+
+func (r *productRepository) GetAll(ctx context.Context, page, limit int, filter models.ProductFilter) ([]models.Product, int, error) {
 	offset := (page - 1) * limit
 
 	// Build WHERE clause
-	whereClause := "WHERE 1=1"
-	args := []interface{}{}
-	argCount := 1
+	where := productFilterWhere(ctx, filter)
 
-	if category != "" {
-		whereClause += fmt.Sprintf(" AND category = $%d", argCount)
-		args = append(args, category)
-		argCount++
+	orderBy, ok := productSortClauses[filter.Sort]
+	if !ok {
+		orderBy = productSortClauses[models.ProductSortNewest]
 	}
 
-	if search != "" {
-		whereClause += fmt.Sprintf(" AND (name ILIKE $%d OR description ILIKE $%d)", argCount, argCount)
-		args = append(args, "%"+search+"%")
-		argCount++
+	popularityJoin := ""
+	if filter.Sort == models.ProductSortPopularity {
+		popularityJoin = `
+        LEFT JOIN (
+            SELECT product_id, SUM(quantity) AS units_sold
+            FROM order_items
+            GROUP BY product_id
+        ) pop ON pop.product_id = p.id`
 	}
 
 	// Count total products
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM products %s", whereClause)
+	countQuery := "SELECT COUNT(*) FROM products p " + where.String()
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, countQuery, where.Args()...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	// Search engine matches keep the engine's order; bound after the count,
+	// which does not use it
+	if filter.Sort == models.ProductSortRelevance && filter.MatchIDs != nil {
+		orderBy = fmt.Sprintf("array_position(%s::uuid[], p.id)", where.Bind(filter.MatchIDs))
+	}
+
+	groupByExtra := ""
+	if popularityJoin != "" {
+		groupByExtra = ", pop.units_sold"
+	}
+
 	// Get products with pagination
 	productsQuery := fmt.Sprintf(`
         SELECT 
-            p.id, p.sku, p.name, p.description, p.price, 
-            p.stock_quantity - COALESCE(SUM(sr.quantity), 0) as available_stock,
-            p.category, p.image_url, p.created_at, p.updated_at
+            p.id, p.sku, p.status, p.name, p.description, p.price, 
+            %s as available_stock,
+            p.category, p.image_url, p.created_at, p.updated_at,
+            p.max_per_order, p.max_per_customer
         FROM products p
         LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
-            AND sr.expires_at > NOW()
+            AND sr.variant_id IS NULL
+            AND sr.expires_at > NOW()%s
         %s
-        GROUP BY p.id, p.sku, p.name, p.description, p.price, p.stock_quantity, 
-                 p.category, p.image_url, p.created_at, p.updated_at
-        ORDER BY p.created_at DESC
-        LIMIT $%d OFFSET $%d
-    `, whereClause, argCount, argCount+1)
-
-	args = append(args, limit, offset)
+        GROUP BY p.id%s
+        ORDER BY %s, p.id
+        LIMIT %s OFFSET %s
+    `, availableStockColumn, popularityJoin, where, groupByExtra, orderBy, where.Bind(limit), where.Bind(offset))
 
-	rows, err := r.db.Query(ctx, productsQuery, args...)
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, productsQuery, where.Args()...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -191,6 +447,7 @@ func (r *productRepository) GetAll(ctx context.Context, page, limit int, categor
 		err := rows.Scan(
 			&product.ID,
 			&product.SKU,
+			&product.Status,
 			&product.Name,
 			&product.Description,
 			&product.Price,
@@ -199,6 +456,8 @@ func (r *productRepository) GetAll(ctx context.Context, page, limit int, categor
 			&product.ImageURL,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.MaxPerOrder,
+			&product.MaxPerCustomer,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -209,44 +468,292 @@ func (r *productRepository) GetAll(ctx context.Context, page, limit int, categor
 	return products, total, nil
 }
 
-func (r *productRepository) GetAllAdmin(ctx context.Context, page, limit, rangeDays int) ([]models.Product, int, error) {
-	offset := (page - 1) * limit
+// productKeyset is the keyset form of a product sort: the expression a
+// cursor resumes from and the type its text key is cast back to. Ties break
+// on p.id ascending, as in GetAll.
+type productKeyset struct {
+	expr string
+	cast string
+	desc bool
+}
 
-	whereClause := "WHERE 1=1"
-	args := []interface{}{}
-	argCount := 1
+var productKeysets = map[string]productKeyset{
+	models.ProductSortNewest:     {expr: "p.created_at", cast: "timestamp", desc: true},
+	models.ProductSortPriceAsc:   {expr: "p.price", cast: "numeric", desc: false},
+	models.ProductSortPriceDesc:  {expr: "p.price", cast: "numeric", desc: true},
+	models.ProductSortPopularity: {expr: "COALESCE(pop.units_sold, 0)", cast: "bigint", desc: true},
+}
+
+// GetPage returns up to limit products after the cursor in the filter's sort
+// order, and the cursor for the following page (nil on the last page)
+func (r *productRepository) GetPage(ctx context.Context, filter models.ProductFilter, after *pagination.Cursor, limit int) ([]models.Product, *pagination.Cursor, error) {
+	where := productFilterWhere(ctx, filter)
+
+	keyset, ok := productKeysets[filter.Sort]
+	if !ok {
+		keyset = productKeysets[models.ProductSortNewest]
+	}
+
+	popularityJoin := ""
+	groupByExtra := ""
+	if filter.Sort == models.ProductSortPopularity {
+		popularityJoin = `
+        LEFT JOIN (
+            SELECT product_id, SUM(quantity) AS units_sold
+            FROM order_items
+            GROUP BY product_id
+        ) pop ON pop.product_id = p.id`
+		groupByExtra = ", pop.units_sold"
+	}
+
+	if after != nil {
+		cmp := ">"
+		if keyset.desc {
+			cmp = "<"
+		}
+		where.And(fmt.Sprintf("(%s %s ?::%s OR (%s = ?::%s AND p.id > ?))",
+			keyset.expr, cmp, keyset.cast, keyset.expr, keyset.cast), after.Key, after.Key, after.ID)
+	}
+
+	direction := "ASC"
+	if keyset.desc {
+		direction = "DESC"
+	}
+
+	// One extra row tells whether another page follows
+	query := fmt.Sprintf(`
+        SELECT 
+            p.id, p.sku, p.status, p.name, p.description, p.price, 
+            %s as available_stock,
+            p.category, p.image_url, p.created_at, p.updated_at,
+            p.max_per_order, p.max_per_customer,
+            (%s)::text AS sort_key
+        FROM products p
+        LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
+            AND sr.variant_id IS NULL
+            AND sr.expires_at > NOW()%s
+        %s
+        GROUP BY p.id%s
+        ORDER BY %s %s, p.id
+        LIMIT %s
+    `, availableStockColumn, keyset.expr, popularityJoin, where, groupByExtra, keyset.expr, direction, where.Bind(limit+1))
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, query, where.Args()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var products []models.Product
+	var keys []string
+	for rows.Next() {
+		var product models.Product
+		var key string
+		err := rows.Scan(
+			&product.ID,
+			&product.SKU,
+			&product.Status,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Stock,
+			&product.Category,
+			&product.ImageURL,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.MaxPerOrder,
+			&product.MaxPerCustomer,
+			&key,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		products = append(products, product)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(products) <= limit {
+		return products, nil, nil
+	}
+
+	products = products[:limit]
+	next := &pagination.Cursor{Key: keys[limit-1], ID: products[limit-1].ID}
+	return products, next, nil
+}
+
+func (r *productRepository) GetFacets(ctx context.Context, filter models.ProductFilter, priceBuckets int) (*models.ProductFacets, error) {
+	facets := &models.ProductFacets{
+		Categories:   []models.FacetCount{},
+		PriceBuckets: []models.PriceBucket{},
+	}
+
+	// Total for the full filter set
+	where := productFilterWhere(ctx, filter)
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, "SELECT COUNT(*) FROM products p "+where.String(), where.Args()...).Scan(&facets.Total); err != nil {
+		return nil, err
+	}
+
+	// Category counts ignore the category filter
+	categoryFilter := filter
+	categoryFilter.Categories = nil
+	where = productFilterWhere(ctx, categoryFilter)
+	where.And("COALESCE(p.category, '') <> ''")
+	categoryQuery := `
+        SELECT p.category, COUNT(*)
+        FROM products p
+    ` + where.String() + `
+        GROUP BY p.category
+        ORDER BY COUNT(*) DESC, p.category
+    `
+
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, categoryQuery, where.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var facet models.FacetCount
+		if err := rows.Scan(&facet.Value, &facet.Count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		facets.Categories = append(facets.Categories, facet)
+	}
+	rows.Close()
+
+	// Price histogram ignores the price range filter
+	priceFilter := filter
+	priceFilter.MinPrice = nil
+	priceFilter.MaxPrice = nil
+	where = productFilterWhere(ctx, priceFilter)
+
+	var minPrice, maxPrice *money.Money
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, "SELECT MIN(p.price), MAX(p.price) FROM products p "+where.String(), where.Args()...).Scan(&minPrice, &maxPrice); err != nil {
+		return nil, err
+	}
+
+	if minPrice != nil && maxPrice != nil {
+		width := priceBucketWidth(*minPrice, *maxPrice, priceBuckets)
+		start := (*minPrice / width) * width
+
+		bucketQuery := fmt.Sprintf(`
+        SELECT FLOOR((p.price - %s) / %s)::int AS bucket, COUNT(*)
+        FROM products p
+        %s
+        GROUP BY bucket
+        ORDER BY bucket
+    `, where.Bind(start), where.Bind(width), where)
+
+		rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, bucketQuery, where.Args()...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var bucket, count int
+			if err := rows.Scan(&bucket, &count); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			lower := start + width.Mul(bucket)
+			facets.PriceBuckets = append(facets.PriceBuckets, models.PriceBucket{
+				Min:   lower,
+				Max:   lower + width,
+				Count: count,
+			})
+		}
+		rows.Close()
+	}
+
+	// Availability ignores the in-stock filter
+	availabilityFilter := filter
+	availabilityFilter.InStockOnly = false
+	where = productFilterWhere(ctx, availabilityFilter)
+	availabilityQuery := fmt.Sprintf(`
+        SELECT
+            COUNT(*) FILTER (WHERE %s),
+            COUNT(*) FILTER (WHERE NOT %s)
+        FROM products p
+        %s
+    `, inStockCondition, inStockCondition, where)
+
+	if err := database.ReadConn(ctx, r.db, r.replica).QueryRow(ctx, availabilityQuery, where.Args()...).Scan(
+		&facets.Availability.InStock,
+		&facets.Availability.OutOfStock,
+	); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// priceBucketWidth picks a round bucket width (1, 2 or 5 times a power of ten)
+// that splits the price range into roughly the requested number of buckets
+func priceBucketWidth(minPrice, maxPrice money.Money, buckets int) money.Money {
+	if buckets < 1 {
+		buckets = 1
+	}
+
+	// Round up so the buckets always cover the full range
+	raw := (maxPrice - minPrice + money.Money(buckets) - 1) / money.Money(buckets)
+	if raw <= 0 {
+		return money.FromMajor(1)
+	}
+
+	magnitude := money.FromMinor(1)
+	for magnitude*10 <= raw {
+		magnitude *= 10
+	}
+	for _, step := range []money.Money{1, 2, 5, 10} {
+		if raw <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// GetAllAdmin lists products in every status unless one is given
+func (r *productRepository) GetAllAdmin(ctx context.Context, page, limit, rangeDays int, status models.ProductStatus) ([]models.Product, int, error) {
+	offset := (page - 1) * limit
 
+	where := &database.Where{}
+	where.ScopeStore(ctx, "p.store_id")
 	if rangeDays > 0 {
-		whereClause += fmt.Sprintf(" AND created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
-		args = append(args, rangeDays)
-		argCount++
+		where.And("p.created_at >= NOW() - ? * INTERVAL '1 day'", rangeDays)
+	}
+	if status != "" {
+		where.And("p.status = ?", status)
 	}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM products %s", whereClause)
+	countQuery := "SELECT COUNT(*) FROM products p " + where.String()
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, where.Args()...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	query := fmt.Sprintf(`
         SELECT 
-            p.id, p.sku, p.name, p.description, p.price,
-            p.stock_quantity - COALESCE(SUM(sr.quantity), 0) as available_stock,
-            p.category, p.image_url, p.created_at, p.updated_at
+            p.id, p.sku, p.status, p.name, p.description, p.price,
+            %s as available_stock,
+            p.category, p.image_url, p.created_at, p.updated_at,
+            p.max_per_order, p.max_per_customer, p.cost_price, p.publish_at, p.unpublish_at,
+            p.weight_grams, p.length_mm, p.width_mm, p.height_mm
         FROM products p
         LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
+            AND sr.variant_id IS NULL
             AND sr.expires_at > NOW()
         %s
-        GROUP BY p.id, p.sku, p.name, p.description, p.price, p.stock_quantity,
-                 p.category, p.image_url, p.created_at, p.updated_at
+        GROUP BY p.id, p.sku, p.status, p.name, p.description, p.price, p.stock_quantity,
+                 p.category, p.image_url, p.created_at, p.updated_at,
+                 p.max_per_order, p.max_per_customer, p.cost_price, p.publish_at, p.unpublish_at,
+                 p.weight_grams, p.length_mm, p.width_mm, p.height_mm
         ORDER BY p.created_at DESC
-        LIMIT $%d OFFSET $%d
-    `, whereClause, argCount, argCount+1)
+        LIMIT %s OFFSET %s
+    `, availableStockColumn, where, where.Bind(limit), where.Bind(offset))
 
-	args = append(args, limit, offset)
-
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, where.Args()...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -255,9 +762,11 @@ func (r *productRepository) GetAllAdmin(ctx context.Context, page, limit, rangeD
 	var products []models.Product
 	for rows.Next() {
 		var product models.Product
+		var length, width, height *int
 		err := rows.Scan(
 			&product.ID,
 			&product.SKU,
+			&product.Status,
 			&product.Name,
 			&product.Description,
 			&product.Price,
@@ -266,25 +775,70 @@ func (r *productRepository) GetAllAdmin(ctx context.Context, page, limit, rangeD
 			&product.ImageURL,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.MaxPerOrder,
+			&product.MaxPerCustomer,
+			&product.CostPrice,
+			&product.PublishAt,
+			&product.UnpublishAt,
+			&product.WeightGrams,
+			&length,
+			&width,
+			&height,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
+		product.Dimensions = dimensionsFrom(length, width, height)
 		products = append(products, product)
 	}
 
 	return products, total, nil
 }
 
-func (r *productRepository) GetTopProducts(ctx context.Context, limit, rangeDays int) ([]models.TopProductItem, error) {
-	whereClause := "WHERE 1=1"
-	args := []interface{}{}
-	argCount := 1
+// ExportAll streams every product matching the admin list filters to fn
+// through a server-side cursor. Stock is the on-hand quantity, not net of
+// reservations.
+func (r *productRepository) ExportAll(ctx context.Context, rangeDays int, fn func(models.Product) error) error {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "store_id")
+	if rangeDays > 0 {
+		where.And("created_at >= NOW() - ? * INTERVAL '1 day'", rangeDays)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT id, sku, name, COALESCE(description, ''), price, stock_quantity,
+               COALESCE(category, ''), COALESCE(image_url, ''), created_at, updated_at
+        FROM products
+        %s
+        ORDER BY created_at, id
+    `, where)
+
+	return database.ForEachRow(ctx, r.db, database.DefaultCursorBatchSize, query, where.Args(), func(rows pgx.Rows) error {
+		var product models.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.SKU,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Stock,
+			&product.Category,
+			&product.ImageURL,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		return fn(product)
+	})
+}
 
+func (r *productRepository) GetTopProducts(ctx context.Context, limit, rangeDays int) ([]models.TopProductItem, error) {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "o.store_id")
 	if rangeDays > 0 {
-		whereClause += fmt.Sprintf(" AND o.created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
-		args = append(args, rangeDays)
-		argCount++
+		where.And("o.created_at >= NOW() - ? * INTERVAL '1 day'", rangeDays)
 	}
 
 	query := fmt.Sprintf(`
@@ -299,12 +853,10 @@ func (r *productRepository) GetTopProducts(ctx context.Context, limit, rangeDays
         %s
         GROUP BY p.id
         ORDER BY total_quantity DESC, total_revenue DESC
-        LIMIT $%d
-    `, whereClause, argCount)
-
-	args = append(args, limit)
+        LIMIT %s
+    `, where, where.Bind(limit))
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, query, where.Args()...)
 	if err != nil {
 		return nil, err
 	}
@@ -362,9 +914,9 @@ func (r *productRepository) Update(ctx context.Context, id uuid.UUID, updateData
 		argCount++
 	}
 
-	if updateData.Stock >= 0 {
+	if updateData.Stock != nil {
 		updates = append(updates, fmt.Sprintf("stock_quantity = $%d", argCount))
-		args = append(args, updateData.Stock)
+		args = append(args, *updateData.Stock)
 		argCount++
 	}
 
@@ -380,6 +932,54 @@ func (r *productRepository) Update(ctx context.Context, id uuid.UUID, updateData
 		argCount++
 	}
 
+	// A limit of 0 clears it
+	if updateData.MaxPerOrder != nil {
+		updates = append(updates, fmt.Sprintf("max_per_order = NULLIF($%d::integer, 0)", argCount))
+		args = append(args, *updateData.MaxPerOrder)
+		argCount++
+	}
+
+	if updateData.MaxPerCustomer != nil {
+		updates = append(updates, fmt.Sprintf("max_per_customer = NULLIF($%d::integer, 0)", argCount))
+		args = append(args, *updateData.MaxPerCustomer)
+		argCount++
+	}
+
+	// An empty barcode clears it
+	if updateData.Barcode != nil {
+		updates = append(updates, fmt.Sprintf("barcode = NULLIF($%d, '')", argCount))
+		args = append(args, *updateData.Barcode)
+		argCount++
+	}
+
+	if updateData.CostPrice != nil {
+		updates = append(updates, fmt.Sprintf("cost_price = $%d", argCount))
+		args = append(args, *updateData.CostPrice)
+		argCount++
+	}
+
+	if updateData.Status != "" {
+		updates = append(updates, fmt.Sprintf("status = $%d", argCount))
+		args = append(args, updateData.Status)
+		argCount++
+	}
+
+	// A weight of 0, or dimensions of all 0, clears them
+	if updateData.WeightGrams != nil {
+		updates = append(updates, fmt.Sprintf("weight_grams = NULLIF($%d::integer, 0)", argCount))
+		args = append(args, *updateData.WeightGrams)
+		argCount++
+	}
+
+	if updateData.Dimensions != nil {
+		updates = append(updates, fmt.Sprintf(
+			"length_mm = NULLIF($%d::integer, 0), width_mm = NULLIF($%d::integer, 0), height_mm = NULLIF($%d::integer, 0)",
+			argCount, argCount+1, argCount+2,
+		))
+		args = append(args, updateData.Dimensions.LengthMM, updateData.Dimensions.WidthMM, updateData.Dimensions.HeightMM)
+		argCount += 3
+	}
+
 	if len(updates) == 0 {
 		return nil // Nothing to update
 	}
@@ -389,30 +989,172 @@ func (r *productRepository) Update(ctx context.Context, id uuid.UUID, updateData
 	query += fmt.Sprintf(" WHERE id = $%d", argCount)
 	args = append(args, id)
 
-	_, err := r.db.Exec(ctx, query, args...)
-	return err
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if updateData.Stock != nil {
+		if err := checkStockTotal(ctx, tx, id, nil, *updateData.Stock); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	// A stock level set on the product is a total; the default warehouse
+	// absorbs the change
+	if updateData.Stock != nil {
+		if err := syncDefaultWarehouseStock(ctx, tx, []uuid.UUID{id}, nil); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := "DELETE FROM products WHERE id = $1"
-	_, err := r.db.Exec(ctx, query, id)
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
 	return err
 }
 
-func (r *productRepository) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error {
+func (r *productRepository) SetSchedule(ctx context.Context, id uuid.UUID, publishAt, unpublishAt *time.Time) error {
 	query := `
-        UPDATE products 
-        SET stock_quantity = stock_quantity + $1, updated_at = NOW()
-        WHERE id = $2 AND stock_quantity + $1 >= 0
-        RETURNING stock_quantity
+        UPDATE products
+        SET publish_at = $2, unpublish_at = $3, updated_at = NOW()
+        WHERE id = $1
     `
-
-	var newStock int
-	err := r.db.QueryRow(ctx, query, quantity, id).Scan(&newStock)
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id, publishAt, unpublishAt)
 	return err
 }
 
-func (r *productRepository) UpdateStockWithTx(ctx context.Context, tx pgx.Tx, id uuid.UUID, quantity int) error {
+// PublishDue makes active the drafts whose publish time has come, in every
+// store, and returns them. The publish time is cleared on every product it
+// has passed for, so it applies once even if the product is no longer a
+// draft.
+func (r *productRepository) PublishDue(ctx context.Context) ([]uuid.UUID, error) {
+	return r.applyDue(ctx, `
+        UPDATE products
+        SET status = 'active', publish_at = NULL, updated_at = NOW()
+        WHERE publish_at <= NOW() AND status = 'draft'
+        RETURNING id
+    `, `UPDATE products SET publish_at = NULL WHERE publish_at <= NOW()`)
+}
+
+// UnpublishDue takes back to draft the active products whose unpublish time
+// has come, in every store, and returns them
+func (r *productRepository) UnpublishDue(ctx context.Context) ([]uuid.UUID, error) {
+	return r.applyDue(ctx, `
+        UPDATE products
+        SET status = 'draft', unpublish_at = NULL, updated_at = NOW()
+        WHERE unpublish_at <= NOW() AND status = 'active'
+        RETURNING id
+    `, `UPDATE products SET unpublish_at = NULL WHERE unpublish_at <= NOW()`)
+}
+
+// applyDue runs a scheduled status change and then clears the schedule of
+// products it did not apply to
+func (r *productRepository) applyDue(ctx context.Context, apply, clear string) ([]uuid.UUID, error) {
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, apply)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, clear); err != nil {
+		return nil, err
+	}
+
+	return ids, tx.Commit(ctx)
+}
+
+// searchDocumentQuery selects products as the search engine indexes them,
+// with their variants' SKUs
+const searchDocumentQuery = `
+        SELECT p.id, p.store_id, p.sku,
+            COALESCE(ARRAY_AGG(v.sku ORDER BY v.sku) FILTER (WHERE v.id IS NOT NULL), '{}'),
+            p.name, COALESCE(p.description, ''), COALESCE(p.category, '')
+        FROM products p
+        LEFT JOIN product_variants v ON v.product_id = p.id
+        %s
+        GROUP BY p.id
+        ORDER BY p.id
+        %s
+`
+
+// GetSearchDocuments returns the search documents of the products that still
+// exist among ids
+func (r *productRepository) GetSearchDocuments(ctx context.Context, ids []uuid.UUID) ([]models.ProductDocument, error) {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "p.store_id")
+	where.And("p.id = ANY(?)", ids)
+
+	return r.searchDocuments(ctx, fmt.Sprintf(searchDocumentQuery, where, ""), where.Args())
+}
+
+// GetSearchDocumentPage walks every product's search document in ID order,
+// limit at a time after the given ID
+func (r *productRepository) GetSearchDocumentPage(ctx context.Context, after uuid.UUID, limit int) ([]models.ProductDocument, error) {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "p.store_id")
+	where.And("p.id > ?", after)
+
+	query := fmt.Sprintf(searchDocumentQuery, where, "LIMIT "+where.Bind(limit))
+	return r.searchDocuments(ctx, query, where.Args())
+}
+
+func (r *productRepository) searchDocuments(ctx context.Context, query string, args []interface{}) ([]models.ProductDocument, error) {
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []models.ProductDocument{}
+	for rows.Next() {
+		var doc models.ProductDocument
+		if err := rows.Scan(
+			&doc.ID,
+			&doc.StoreID,
+			&doc.SKU,
+			&doc.VariantSKUs,
+			&doc.Name,
+			&doc.Description,
+			&doc.Category,
+		); err != nil {
+			return nil, err
+		}
+		documents = append(documents, doc)
+	}
+
+	return documents, rows.Err()
+}
+
+// UpdateStock adjusts stock by quantity and returns the resulting level
+func (r *productRepository) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) (int, error) {
 	query := `
         UPDATE products 
         SET stock_quantity = stock_quantity + $1, updated_at = NOW()
@@ -421,15 +1163,15 @@ func (r *productRepository) UpdateStockWithTx(ctx context.Context, tx pgx.Tx, id
     `
 
 	var newStock int
-	err := tx.QueryRow(ctx, query, quantity, id).Scan(&newStock)
-	return err
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, quantity, id).Scan(&newStock)
+	return newStock, err
 }
 
 func (r *productRepository) GetStock(ctx context.Context, id uuid.UUID) (int, error) {
 	query := "SELECT stock_quantity FROM products WHERE id = $1"
 
 	var stock int
-	err := r.db.QueryRow(ctx, query, id).Scan(&stock)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(&stock)
 	if err != nil {
 		return 0, err
 	}
@@ -437,103 +1179,303 @@ func (r *productRepository) GetStock(ctx context.Context, id uuid.UUID) (int, er
 	return stock, nil
 }
 
-func (r *productRepository) ReserveStock(ctx context.Context, productID, cartID uuid.UUID, quantity int, expiresAt int64) error {
-	// Use PostgreSQL advisory lock to prevent race conditions
-	lockQuery := `SELECT pg_advisory_xact_lock(hashtext($1))`
-	lockKey := fmt.Sprintf("product_%s", productID.String())
+// LockStock locks the rows of the given products, variants included, until
+// the transaction ends. Whatever checks availability before reserving or
+// deducting stock takes these locks first, so two carts after the last unit
+// queue up instead of both passing the check. A bundle's components are
+// locked with it. Rows are locked in id order so checkouts of overlapping
+// carts cannot deadlock.
+func (r *productRepository) LockStock(ctx context.Context, productIDs []uuid.UUID) error {
+	query := `
+        SELECT id FROM products
+        WHERE id = ANY($1)
+            OR id IN (SELECT component_product_id FROM bundle_components WHERE bundle_product_id = ANY($1))
+        ORDER BY id
+        FOR UPDATE
+    `
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, productIDs)
+	return err
+}
 
-	_, err := r.db.Exec(ctx, lockQuery, lockKey)
+// ReserveStock must run inside a transaction, which holds the product lock
+// until the reservation is written
+func (r *productRepository) ReserveStock(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int, expiresAt int64) error {
+	err := r.LockStock(ctx, []uuid.UUID{productID})
 	if err != nil {
 		return fmt.Errorf("failed to acquire lock: %w", err)
 	}
 
 	// First check if reservation exists and get current quantity
-	checkQuery := `SELECT quantity FROM stock_reservations WHERE product_id = $1::uuid AND cart_id = $2::uuid`
+	checkQuery := `
+		SELECT quantity FROM stock_reservations
+		WHERE product_id = $1::uuid AND cart_id = $2::uuid AND variant_id IS NOT DISTINCT FROM $3::uuid
+	`
 	var currentQuantity int
-	err = r.db.QueryRow(ctx, checkQuery, productID, cartID).Scan(&currentQuantity)
+	err = database.Conn(ctx, r.db).QueryRow(ctx, checkQuery, productID, cartID, variantID).Scan(&currentQuantity)
 
 	if err == nil {
 		// Reservation exists, check if new total would exceed available stock
 		newTotalQuantity := currentQuantity + quantity
 
-		// Check available stock with new total
-		availQuery := `
-			SELECT p.stock_quantity - COALESCE(SUM(sr.quantity), 0) + COALESCE((SELECT quantity FROM stock_reservations WHERE product_id = $1::uuid AND cart_id = $2::uuid), 0) as available
-			FROM products p
-			LEFT JOIN stock_reservations sr ON p.id = sr.product_id AND sr.expires_at > NOW()
-			WHERE p.id = $1::uuid
-			GROUP BY p.id, p.stock_quantity
-		`
-		var available int
-		availErr := r.db.QueryRow(ctx, availQuery, productID, cartID).Scan(&available)
+		// Check available stock ignoring this cart's own reservation
+		available, availErr := r.GetAvailableStockExcludingCart(ctx, productID, cartID, variantID)
 		if availErr != nil && !errors.Is(availErr, pgx.ErrNoRows) {
 			return fmt.Errorf("failed to check available stock: %w", availErr)
 		}
 
 		if available < newTotalQuantity {
-			return fmt.Errorf("insufficient stock available for reservation")
+			return apperrors.Validation("insufficient stock available for reservation")
 		}
 
 		// Update existing reservation
 		updateQuery := `
 			UPDATE stock_reservations 
 			SET quantity = $1::integer, expires_at = to_timestamp($2)
-			WHERE product_id = $3::uuid AND cart_id = $4::uuid
+			WHERE product_id = $3::uuid AND cart_id = $4::uuid AND variant_id IS NOT DISTINCT FROM $5::uuid
 		`
-		_, err := r.db.Exec(ctx, updateQuery, newTotalQuantity, expiresAt, productID, cartID)
+		_, err := database.Conn(ctx, r.db).Exec(ctx, updateQuery, newTotalQuantity, expiresAt, productID, cartID, variantID)
 		return err
 	} else if errors.Is(err, pgx.ErrNoRows) {
-		// No existing reservation, insert new one
-		// Check available stock for new reservation
-		query := `
-			WITH available_stock AS (
-				SELECT 
-					p.stock_quantity - COALESCE(SUM(sr.quantity), 0) as available
-				FROM products p
-				LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
-					AND sr.expires_at > NOW()
-				WHERE p.id = $1::uuid
-				GROUP BY p.id, p.stock_quantity
-			)
-			INSERT INTO stock_reservations (product_id, cart_id, quantity, expires_at)
-			SELECT $1::uuid, $2::uuid, $3::integer, to_timestamp($4)
-			FROM available_stock
-			WHERE available >= $3::integer
-			RETURNING id
+		// No existing reservation, check available stock for new reservation
+		available, availErr := r.GetAvailableStock(ctx, productID, variantID)
+		if availErr != nil {
+			if errors.Is(availErr, pgx.ErrNoRows) {
+				return apperrors.Validation("insufficient stock available for reservation")
+			}
+			return fmt.Errorf("failed to check available stock: %w", availErr)
+		}
+
+		if available < quantity {
+			return apperrors.Validation("insufficient stock available for reservation")
+		}
+
+		insertQuery := `
+			INSERT INTO stock_reservations (product_id, cart_id, variant_id, quantity, expires_at)
+			VALUES ($1::uuid, $2::uuid, $3::uuid, $4::integer, to_timestamp($5))
 		`
+		_, err = database.Conn(ctx, r.db).Exec(ctx, insertQuery, productID, cartID, variantID, quantity, expiresAt)
+		return err
+	}
 
-		var reservationID uuid.UUID
-		err = r.db.QueryRow(ctx, query, productID, cartID, quantity, expiresAt).Scan(&reservationID)
+	return err
+}
 
-		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("insufficient stock available for reservation")
-		}
+// SetReservationQuantity sets the cart's reservation to quantity units and
+// renews it. Shrinking always succeeds; growing needs the extra units free of
+// other carts' reservations. Like ReserveStock it must run inside a
+// transaction.
+func (r *productRepository) SetReservationQuantity(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int, expiresAt int64) error {
+	if err := r.LockStock(ctx, []uuid.UUID{productID}); err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	if quantity <= 0 {
+		return r.ReleaseStockReservation(ctx, productID, cartID, variantID)
+	}
 
+	// An expired reservation no longer holds anything, so it counts as zero
+	currentQuery := `
+        SELECT quantity FROM stock_reservations
+        WHERE product_id = $1 AND cart_id = $2 AND variant_id IS NOT DISTINCT FROM $3::uuid
+            AND expires_at > NOW()
+    `
+	var current int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, currentQuery, productID, cartID, variantID).Scan(&current)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return err
 	}
 
+	if quantity > current {
+		available, err := r.GetAvailableStockExcludingCart(ctx, productID, cartID, variantID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to check available stock: %w", err)
+		}
+		if available < quantity {
+			return apperrors.Validation("insufficient stock available for reservation")
+		}
+	}
+
+	upsertQuery := `
+        INSERT INTO stock_reservations (product_id, cart_id, variant_id, quantity, expires_at)
+        VALUES ($1, $2, $3::uuid, $4, to_timestamp($5))
+        ON CONFLICT (product_id, cart_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid))
+        DO UPDATE SET quantity = EXCLUDED.quantity, expires_at = EXCLUDED.expires_at
+    `
+	_, err = database.Conn(ctx, r.db).Exec(ctx, upsertQuery, productID, cartID, variantID, quantity, expiresAt)
 	return err
 }
 
-func (r *productRepository) ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID) error {
-	query := `DELETE FROM stock_reservations WHERE product_id = $1 AND cart_id = $2`
-	_, err := r.db.Exec(ctx, query, productID, cartID)
+func (r *productRepository) ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID) error {
+	query := `
+        DELETE FROM stock_reservations
+        WHERE product_id = $1 AND cart_id = $2 AND variant_id IS NOT DISTINCT FROM $3::uuid
+    `
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, productID, cartID, variantID)
 	return err
 }
 
-func (r *productRepository) GetAvailableStock(ctx context.Context, productID uuid.UUID) (int, error) {
+// ReleaseCartReservations drops every reservation held by a cart, used when
+// the reserved quantities are converted into a real stock deduction
+func (r *productRepository) ReleaseCartReservations(ctx context.Context, cartID uuid.UUID) error {
+	query := `DELETE FROM stock_reservations WHERE cart_id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, cartID)
+	return err
+}
+
+// TransferReservationsToOrder hands the cart's live reservations over to the
+// order, which holds them until expiresAt while its payment is authorized.
+// The cart is then free to reserve stock again.
+func (r *productRepository) TransferReservationsToOrder(ctx context.Context, cartID, orderID uuid.UUID, expiresAt int64) error {
 	query := `
+        UPDATE stock_reservations
+        SET cart_id = NULL, order_id = $2, expires_at = to_timestamp($3)
+        WHERE cart_id = $1 AND expires_at > NOW()
+    `
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, cartID, orderID, expiresAt)
+	return err
+}
+
+// ReleaseOrderReservations drops every reservation held by an order, once its
+// stock is committed or the order is abandoned
+func (r *productRepository) ReleaseOrderReservations(ctx context.Context, orderID uuid.UUID) error {
+	query := `DELETE FROM stock_reservations WHERE order_id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, orderID)
+	return err
+}
+
+// CommitReservation converts a cart's reservation into a permanent stock
+// deduction in a single statement: the reservation row is deleted and stock is
+// reduced by quantity, provided enough remains to cover other carts' live
+// reservations, bundle reservations holding it included. Returns the resulting
+// stock level. Bundles hold no stock: their components are committed instead.
+func (r *productRepository) CommitReservation(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) (int, error) {
+	// The DELETE is not visible to the UPDATE's snapshot, so the cart's own
+	// reservation is excluded from the availability check explicitly
+	query := `
+        WITH released AS (
+            DELETE FROM stock_reservations
+            WHERE product_id = $1 AND cart_id = $2 AND variant_id IS NOT DISTINCT FROM $3::uuid
+            RETURNING quantity
+        )
+        UPDATE products
+        SET stock_quantity = stock_quantity - $4, updated_at = NOW()
+        WHERE id = $1
+            AND stock_quantity - $4 >= (
+                SELECT COALESCE(SUM(sr.quantity), 0)
+                FROM stock_reservations sr
+                WHERE sr.product_id = $1 AND sr.variant_id IS NULL
+                    AND sr.cart_id IS DISTINCT FROM $2 AND sr.expires_at > NOW()
+            ) + bundle_reserved_stock($1, NULL, $2)
+        RETURNING stock_quantity
+    `
+	if variantID != nil {
+		query = `
+        WITH released AS (
+            DELETE FROM stock_reservations
+            WHERE product_id = $1 AND cart_id = $2 AND variant_id IS NOT DISTINCT FROM $3::uuid
+            RETURNING quantity
+        )
+        UPDATE product_variants
+        SET stock_quantity = stock_quantity - $4, updated_at = NOW()
+        WHERE id = $3 AND product_id = $1
+            AND stock_quantity - $4 >= (
+                SELECT COALESCE(SUM(sr.quantity), 0)
+                FROM stock_reservations sr
+                WHERE sr.variant_id = $3
+                    AND sr.cart_id IS DISTINCT FROM $2 AND sr.expires_at > NOW()
+            ) + bundle_reserved_stock($1, $3, $2)
+        RETURNING stock_quantity
+    `
+	}
+
+	var newStock int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, productID, cartID, variantID, quantity).Scan(&newStock)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, apperrors.Validation("insufficient stock to commit reservation")
+	}
+	return newStock, err
+}
+
+// PurgeExpiredReservations deletes expired reservations and returns how many
+// rows were removed and the total quantity they were holding
+func (r *productRepository) PurgeExpiredReservations(ctx context.Context) (int, int, error) {
+	query := `
+        WITH purged AS (
+            DELETE FROM stock_reservations
+            WHERE expires_at <= NOW()
+            RETURNING quantity
+        )
+        SELECT COUNT(*), COALESCE(SUM(quantity), 0) FROM purged
+    `
+
+	var count, quantity int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query).Scan(&count, &quantity)
+	return count, quantity, err
+}
+
+// availableStockStatements holds every form of buildAvailableStockQuery,
+// prepared on each connection since stock is checked for every cart line
+var availableStockStatements = map[[2]bool]string{
+	{false, false}: database.Statement("available_stock", buildAvailableStockQuery(false, false)),
+	{false, true}:  database.Statement("available_stock_excluding_cart", buildAvailableStockQuery(false, true)),
+	{true, false}:  database.Statement("variant_available_stock", buildAvailableStockQuery(true, false)),
+	{true, true}:   database.Statement("variant_available_stock_excluding_cart", buildAvailableStockQuery(true, true)),
+}
+
+// availableStockQuery returns the query computing available stock for the base
+// product ($1 = product id) or for a variant ($1 = variant id). When excludeCart
+// is set, reservations held by the cart in $2 are not subtracted. A bundle's
+// stock is the number of complete bundles its components make up.
+func availableStockQuery(forVariant, excludeCart bool) string {
+	return availableStockStatements[[2]bool{forVariant, excludeCart}]
+}
+
+func buildAvailableStockQuery(forVariant, excludeCart bool) string {
+	cartFilter := ""
+	cartArg := "NULL"
+	if excludeCart {
+		cartFilter = "AND sr.cart_id IS DISTINCT FROM $2"
+		cartArg = "$2::uuid"
+	}
+
+	if forVariant {
+		return fmt.Sprintf(`
+        SELECT 
+            v.stock_quantity - COALESCE(SUM(sr.quantity), 0)
+                - bundle_reserved_stock(v.product_id, v.id, %[2]s) as available
+        FROM product_variants v
+        LEFT JOIN stock_reservations sr ON v.id = sr.variant_id 
+            AND sr.expires_at > NOW()
+            %[1]s
+        WHERE v.id = $1
+        GROUP BY v.id, v.stock_quantity
+    `, cartFilter, cartArg)
+	}
+
+	return fmt.Sprintf(`
         SELECT 
-            p.stock_quantity - COALESCE(SUM(sr.quantity), 0) as available
+            COALESCE(
+                bundle_available_stock(p.id, %[2]s),
+                p.stock_quantity - COALESCE(SUM(sr.quantity), 0) - bundle_reserved_stock(p.id, NULL, %[2]s)
+            ) as available
         FROM products p
         LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
+            AND sr.variant_id IS NULL
             AND sr.expires_at > NOW()
+            %[1]s
         WHERE p.id = $1
         GROUP BY p.id, p.stock_quantity
-    `
+    `, cartFilter, cartArg)
+}
+
+func (r *productRepository) GetAvailableStock(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID) (int, error) {
+	stockID := productID
+	if variantID != nil {
+		stockID = *variantID
+	}
 
 	var available int
-	err := r.db.QueryRow(ctx, query, productID).Scan(&available)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, availableStockQuery(variantID != nil, false), stockID).Scan(&available)
 	if err != nil {
 		return 0, err
 	}
@@ -541,20 +1483,14 @@ func (r *productRepository) GetAvailableStock(ctx context.Context, productID uui
 	return available, nil
 }
 
-func (r *productRepository) GetAvailableStockExcludingCart(ctx context.Context, productID, cartID uuid.UUID) (int, error) {
-	query := `
-        SELECT 
-            p.stock_quantity - COALESCE(SUM(sr.quantity), 0) as available
-        FROM products p
-        LEFT JOIN stock_reservations sr ON p.id = sr.product_id 
-            AND sr.expires_at > NOW()
-            AND sr.cart_id != $2
-        WHERE p.id = $1
-        GROUP BY p.id, p.stock_quantity
-    `
+func (r *productRepository) GetAvailableStockExcludingCart(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID) (int, error) {
+	stockID := productID
+	if variantID != nil {
+		stockID = *variantID
+	}
 
 	var available int
-	err := r.db.QueryRow(ctx, query, productID, cartID).Scan(&available)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, availableStockQuery(variantID != nil, true), stockID, cartID).Scan(&available)
 	if err != nil {
 		return 0, err
 	}
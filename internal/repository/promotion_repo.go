@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *models.Promotion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Promotion, error)
+	GetAll(ctx context.Context) ([]models.Promotion, error)
+	GetActive(ctx context.Context) ([]models.Promotion, error)
+	Update(ctx context.Context, promotion *models.Promotion) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type promotionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPromotionRepository(db *pgxpool.Pool) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+const promotionColumns = `id, name, type, min_subtotal, category, product_id, buy_quantity, get_quantity,
+        percent_off, amount_off, priority, stackable, is_active, starts_at, ends_at, created_at, updated_at`
+
+func scanPromotion(row pgx.Row) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Type,
+		&promotion.MinSubtotal,
+		&promotion.Category,
+		&promotion.ProductID,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.PercentOff,
+		&promotion.AmountOff,
+		&promotion.Priority,
+		&promotion.Stackable,
+		&promotion.IsActive,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	query := `
+        INSERT INTO promotions (name, type, min_subtotal, category, product_id, buy_quantity, get_quantity,
+                                percent_off, amount_off, priority, stackable, is_active, starts_at, ends_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+        RETURNING id, created_at, updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		promotion.Name,
+		promotion.Type,
+		promotion.MinSubtotal,
+		promotion.Category,
+		promotion.ProductID,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.PercentOff,
+		promotion.AmountOff,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.IsActive,
+		promotion.StartsAt,
+		promotion.EndsAt,
+	).Scan(&promotion.ID, &promotion.CreatedAt, &promotion.UpdatedAt)
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions WHERE id = $1`
+	return scanPromotion(database.Conn(ctx, r.db).QueryRow(ctx, query, id))
+}
+
+func (r *promotionRepository) GetAll(ctx context.Context) ([]models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions ORDER BY priority DESC, created_at`
+	return r.query(ctx, query)
+}
+
+// GetActive returns the promotions in effect now in the order they apply:
+// highest priority first, older first among equals
+func (r *promotionRepository) GetActive(ctx context.Context) ([]models.Promotion, error) {
+	query := `
+        SELECT ` + promotionColumns + `
+        FROM promotions
+        WHERE is_active
+            AND (starts_at IS NULL OR starts_at <= NOW())
+            AND (ends_at IS NULL OR ends_at > NOW())
+        ORDER BY priority DESC, created_at
+    `
+	return r.query(ctx, query)
+}
+
+func (r *promotionRepository) query(ctx context.Context, query string, args ...any) ([]models.Promotion, error) {
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := []models.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, *promotion)
+	}
+
+	return promotions, rows.Err()
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *models.Promotion) error {
+	query := `
+        UPDATE promotions
+        SET name = $1, type = $2, min_subtotal = $3, category = $4, product_id = $5, buy_quantity = $6,
+            get_quantity = $7, percent_off = $8, amount_off = $9, priority = $10, stackable = $11,
+            is_active = $12, starts_at = $13, ends_at = $14
+        WHERE id = $15
+        RETURNING updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		promotion.Name,
+		promotion.Type,
+		promotion.MinSubtotal,
+		promotion.Category,
+		promotion.ProductID,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.PercentOff,
+		promotion.AmountOff,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.IsActive,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.ID,
+	).Scan(&promotion.UpdatedAt)
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM promotions WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
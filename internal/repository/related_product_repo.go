@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RelatedProductRepository interface {
+	Create(ctx context.Context, related *models.RelatedProduct) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.RelatedProduct, error)
+	GetByProductID(ctx context.Context, productID uuid.UUID) ([]models.RelatedProduct, error)
+	Exists(ctx context.Context, productID, relatedProductID uuid.UUID, relationType models.RelationType) (bool, error)
+	UpdatePosition(ctx context.Context, id uuid.UUID, position int) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetCurated(ctx context.Context, productID uuid.UUID, relationType models.RelationType, limit int) ([]models.Product, error)
+	GetBoughtTogether(ctx context.Context, productID uuid.UUID, limit int) ([]models.Product, error)
+	GetPricierInCategory(ctx context.Context, product *models.Product, limit int) ([]models.Product, error)
+}
+
+// Storefront lists read from replica when one is configured and up; curation
+// uses db
+type relatedProductRepository struct {
+	db      *pgxpool.Pool
+	replica *database.Replica
+}
+
+func NewRelatedProductRepository(db *pgxpool.Pool, replica *database.Replica) RelatedProductRepository {
+	return &relatedProductRepository{db: db, replica: replica}
+}
+
+const relatedProductQuery = `
+        SELECT rp.id, rp.product_id, rp.related_product_id, rp.relation_type, rp.position,
+               p.name, p.sku, p.status, rp.created_at
+        FROM related_products rp
+        JOIN products p ON p.id = rp.related_product_id
+`
+
+func scanRelatedProduct(row pgx.Row, related *models.RelatedProduct) error {
+	return row.Scan(
+		&related.ID,
+		&related.ProductID,
+		&related.RelatedProductID,
+		&related.Type,
+		&related.Position,
+		&related.RelatedName,
+		&related.RelatedSKU,
+		&related.RelatedStatus,
+		&related.CreatedAt,
+	)
+}
+
+func (r *relatedProductRepository) Create(ctx context.Context, related *models.RelatedProduct) error {
+	query := `
+        INSERT INTO related_products (product_id, related_product_id, relation_type, position)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		related.ProductID,
+		related.RelatedProductID,
+		related.Type,
+		related.Position,
+	).Scan(&related.ID, &related.CreatedAt)
+}
+
+func (r *relatedProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.RelatedProduct, error) {
+	var related models.RelatedProduct
+	err := scanRelatedProduct(database.Conn(ctx, r.db).QueryRow(ctx, relatedProductQuery+" WHERE rp.id = $1", id), &related)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &related, nil
+}
+
+// GetByProductID lists a product's curated products in every status, by type
+// and position
+func (r *relatedProductRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]models.RelatedProduct, error) {
+	query := relatedProductQuery + `
+        WHERE rp.product_id = $1
+        ORDER BY rp.relation_type, rp.position, rp.created_at
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	relations := []models.RelatedProduct{}
+	for rows.Next() {
+		var related models.RelatedProduct
+		if err := scanRelatedProduct(rows, &related); err != nil {
+			return nil, err
+		}
+		relations = append(relations, related)
+	}
+
+	return relations, rows.Err()
+}
+
+func (r *relatedProductRepository) Exists(ctx context.Context, productID, relatedProductID uuid.UUID, relationType models.RelationType) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM related_products
+            WHERE product_id = $1 AND related_product_id = $2 AND relation_type = $3
+        )
+    `
+
+	var exists bool
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, productID, relatedProductID, relationType).Scan(&exists)
+	return exists, err
+}
+
+func (r *relatedProductRepository) UpdatePosition(ctx context.Context, id uuid.UUID, position int) error {
+	_, err := database.Conn(ctx, r.db).Exec(ctx, "UPDATE related_products SET position = $2 WHERE id = $1", id, position)
+	return err
+}
+
+func (r *relatedProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := database.Conn(ctx, r.db).Exec(ctx, "DELETE FROM related_products WHERE id = $1", id)
+	return err
+}
+
+// relatedListColumns are the product fields a related product is shown with;
+// stock is on hand, not net of reservations
+const relatedListColumns = `
+            p.id, p.sku, p.status, p.name, p.description, p.price, p.stock_quantity,
+            p.category, p.image_url, p.created_at, p.updated_at
+`
+
+// GetCurated returns the active products curated for the product as the
+// given type, in position order
+func (r *relatedProductRepository) GetCurated(ctx context.Context, productID uuid.UUID, relationType models.RelationType, limit int) ([]models.Product, error) {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "p.store_id")
+	where.And("rp.product_id = ?", productID)
+	where.And("rp.relation_type = ?", relationType)
+	where.And("p.status = ?", models.ProductActive)
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM related_products rp
+        JOIN products p ON p.id = rp.related_product_id
+        %s
+        ORDER BY rp.position, rp.created_at
+        LIMIT %s
+    `, relatedListColumns, where, where.Bind(limit))
+
+	return r.list(ctx, query, where.Args())
+}
+
+// GetBoughtTogether returns the active products that most often appear in
+// orders with the product
+func (r *relatedProductRepository) GetBoughtTogether(ctx context.Context, productID uuid.UUID, limit int) ([]models.Product, error) {
+	where := &database.Where{}
+	where.ScopeStore(ctx, "p.store_id")
+	where.And("p.status = ?", models.ProductActive)
+	productArg := where.Bind(productID)
+	where.And("p.id <> " + productArg)
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM (
+            SELECT other.product_id, COUNT(DISTINCT other.order_id) AS orders
+            FROM order_items mine
+            JOIN order_items other ON other.order_id = mine.order_id
+            WHERE mine.product_id = %s
+            GROUP BY other.product_id
+        ) together
+        JOIN products p ON p.id = together.product_id
+        %s
+        ORDER BY together.orders DESC, p.id
+        LIMIT %s
+    `, relatedListColumns, productArg, where, where.Bind(limit))
+
+	return r.list(ctx, query, where.Args())
+}
+
+// GetPricierInCategory returns active products in the product's category
+// that cost more, cheapest first
+func (r *relatedProductRepository) GetPricierInCategory(ctx context.Context, product *models.Product, limit int) ([]models.Product, error) {
+	if product.Category == "" {
+		return []models.Product{}, nil
+	}
+
+	where := &database.Where{}
+	where.ScopeStore(ctx, "p.store_id")
+	where.And("p.status = ?", models.ProductActive)
+	where.And("p.category = ?", product.Category)
+	where.And("p.price > ?", product.Price)
+	where.And("p.id <> ?", product.ID)
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM products p
+        %s
+        ORDER BY p.price, p.id
+        LIMIT %s
+    `, relatedListColumns, where, where.Bind(limit))
+
+	return r.list(ctx, query, where.Args())
+}
+
+func (r *relatedProductRepository) list(ctx context.Context, query string, args []interface{}) ([]models.Product, error) {
+	rows, err := database.ReadConn(ctx, r.db, r.replica).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []models.Product{}
+	for rows.Next() {
+		var product models.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.SKU,
+			&product.Status,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.Stock,
+			&product.Category,
+			&product.ImageURL,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
@@ -0,0 +1,114 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+)
+
+func TestReservationsHoldStockFromOtherCarts(t *testing.T) {
+	f := newFixture(t)
+	product := f.product(t, 3)
+	first, second := f.cart(t), f.cart(t)
+	expiresAt := time.Now().Add(15 * time.Minute).Unix()
+
+	if err := f.reserve(product.ID, first.ID, 2, expiresAt); err != nil {
+		t.Fatalf("first cart: %v", err)
+	}
+	if got := f.available(t, product.ID); got != 1 {
+		t.Errorf("available = %d, want 1", got)
+	}
+
+	if err := f.reserve(product.ID, second.ID, 2, expiresAt); !errors.Is(err, apperrors.ErrValidation) {
+		t.Errorf("second cart reserving 2: err = %v, want a validation error", err)
+	}
+	if err := f.reserve(product.ID, second.ID, 1, expiresAt); err != nil {
+		t.Errorf("second cart reserving 1: %v", err)
+	}
+
+	// A cart can always shrink its own reservation, and what it gives up is
+	// free again
+	if err := f.reserve(product.ID, first.ID, 1, expiresAt); err != nil {
+		t.Errorf("first cart shrinking: %v", err)
+	}
+	if got := f.available(t, product.ID); got != 1 {
+		t.Errorf("available after shrinking = %d, want 1", got)
+	}
+}
+
+func TestExpiredReservationsHoldNothing(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	product := f.product(t, 1)
+	first, second := f.cart(t), f.cart(t)
+
+	if err := f.reserve(product.ID, first.ID, 1, time.Now().Add(-time.Minute).Unix()); err != nil {
+		t.Fatalf("first cart: %v", err)
+	}
+	if got := f.available(t, product.ID); got != 1 {
+		t.Errorf("available with only an expired reservation = %d, want 1", got)
+	}
+
+	if err := f.reserve(product.ID, second.ID, 1, time.Now().Add(15*time.Minute).Unix()); err != nil {
+		t.Errorf("second cart: %v", err)
+	}
+
+	count, quantity, err := f.products.PurgeExpiredReservations(ctx)
+	if err != nil {
+		t.Fatalf("PurgeExpiredReservations: %v", err)
+	}
+	// Other tests' expired reservations may be purged too
+	if count < 1 || quantity < 1 {
+		t.Errorf("purged %d reservations of %d units, want at least the first cart's", count, quantity)
+	}
+
+	var remaining int
+	err = f.db.QueryRow(ctx, "SELECT COUNT(*) FROM stock_reservations WHERE product_id = $1", product.ID).Scan(&remaining)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 1 {
+		t.Errorf("%d reservations left, want the second cart's only", remaining)
+	}
+}
+
+func TestCommitReservationDeductsStock(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	product := f.product(t, 3)
+	buyer, other := f.cart(t), f.cart(t)
+	expiresAt := time.Now().Add(15 * time.Minute).Unix()
+
+	if err := f.reserve(product.ID, buyer.ID, 2, expiresAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.reserve(product.ID, other.ID, 1, expiresAt); err != nil {
+		t.Fatal(err)
+	}
+
+	remaining, err := f.products.CommitReservation(ctx, product.ID, buyer.ID, nil, 2)
+	if err != nil {
+		t.Fatalf("CommitReservation: %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("stock = %d, want 1", remaining)
+	}
+
+	// The last unit is the other cart's, so nobody else can commit it
+	third := f.cart(t)
+	if _, err := f.products.CommitReservation(ctx, product.ID, third.ID, nil, 1); !errors.Is(err, apperrors.ErrValidation) {
+		t.Errorf("committing a unit another cart holds: err = %v, want a validation error", err)
+	}
+
+	if _, err := f.products.CommitReservation(ctx, product.ID, other.ID, nil, 1); err != nil {
+		t.Errorf("other cart committing its unit: %v", err)
+	}
+	if got := f.available(t, product.ID); got != 0 {
+		t.Errorf("available = %d, want 0", got)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,8 +18,14 @@ type ReturnRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Return, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]models.Return, int, error)
 	GetAll(ctx context.Context, page, limit int, status string, rangeDays int) ([]models.AdminReturn, int, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status models.ReturnStatus, refundAmount float64) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.ReturnStatus, refundAmount money.Money) error
 	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.Return, error)
+	Approve(ctx context.Context, id uuid.UUID, rmaNumber string) error
+	SetReplacementOrder(ctx context.Context, id, orderID uuid.UUID) error
+	MarkInTransit(ctx context.Context, id uuid.UUID, carrier, trackingNumber string) error
+	MarkReceived(ctx context.Context, id uuid.UUID) error
+	CountOpenByUser(ctx context.Context, userID uuid.UUID) (int, error)
+	ReassignUser(ctx context.Context, userID, toUserID uuid.UUID) error
 }
 
 type returnRepository struct {
@@ -29,36 +38,51 @@ func NewReturnRepository(db *pgxpool.Pool) ReturnRepository {
 
 func (r *returnRepository) Create(ctx context.Context, returnReq *models.Return) error {
 	query := `
-        INSERT INTO returns (id, order_id, user_id, reason, status, refund_amount)
-        VALUES ($1, $2, $3, $4, $5, $6)
+        INSERT INTO returns (id, order_id, user_id, reason, status, refund_amount,
+                             exchange_item_id, replacement_variant_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
         RETURNING created_at, updated_at
     `
 
-	return r.db.QueryRow(ctx, query,
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
 		returnReq.ID,
 		returnReq.OrderID,
 		returnReq.UserID,
 		returnReq.Reason,
 		returnReq.Status,
 		returnReq.RefundAmount,
+		returnReq.ExchangeItemID,
+		returnReq.ReplacementVariantID,
 	).Scan(&returnReq.CreatedAt, &returnReq.UpdatedAt)
 }
 
 func (r *returnRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Return, error) {
 	query := `
-        SELECT id, order_id, user_id, reason, status, refund_amount, created_at, updated_at
-        FROM returns
-        WHERE id = $1
+        SELECT r.id, r.order_id, r.user_id, r.reason, r.status, r.refund_amount,
+               r.rma_number, r.return_carrier, r.return_tracking_number, r.shipped_at, r.received_at,
+               r.exchange_item_id, r.replacement_variant_id, r.replacement_order_id,
+               r.created_at, r.updated_at
+        FROM returns r
+        JOIN orders o ON o.id = r.order_id
+        WHERE r.id = $1 AND ($2::uuid IS NULL OR o.store_id = $2)
     `
 
 	var returnReq models.Return
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id, database.StoreArg(ctx)).Scan(
 		&returnReq.ID,
 		&returnReq.OrderID,
 		&returnReq.UserID,
 		&returnReq.Reason,
 		&returnReq.Status,
 		&returnReq.RefundAmount,
+		&returnReq.RMANumber,
+		&returnReq.ReturnCarrier,
+		&returnReq.ReturnTrackingNumber,
+		&returnReq.ShippedAt,
+		&returnReq.ReceivedAt,
+		&returnReq.ExchangeItemID,
+		&returnReq.ReplacementVariantID,
+		&returnReq.ReplacementOrderID,
 		&returnReq.CreatedAt,
 		&returnReq.UpdatedAt,
 	)
@@ -76,21 +100,24 @@ func (r *returnRepository) GetByUserID(ctx context.Context, userID uuid.UUID, pa
 	// Count total returns
 	countQuery := `SELECT COUNT(*) FROM returns WHERE user_id = $1`
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, userID).Scan(&total)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, userID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get returns with pagination
 	returnsQuery := `
-        SELECT id, order_id, user_id, reason, status, refund_amount, created_at, updated_at
+        SELECT id, order_id, user_id, reason, status, refund_amount,
+               rma_number, return_carrier, return_tracking_number, shipped_at, received_at,
+               exchange_item_id, replacement_variant_id, replacement_order_id,
+               created_at, updated_at
         FROM returns
         WHERE user_id = $1
         ORDER BY created_at DESC
         LIMIT $2 OFFSET $3
     `
 
-	rows, err := r.db.Query(ctx, returnsQuery, userID, limit, offset)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, returnsQuery, userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -106,6 +133,14 @@ func (r *returnRepository) GetByUserID(ctx context.Context, userID uuid.UUID, pa
 			&returnReq.Reason,
 			&returnReq.Status,
 			&returnReq.RefundAmount,
+			&returnReq.RMANumber,
+			&returnReq.ReturnCarrier,
+			&returnReq.ReturnTrackingNumber,
+			&returnReq.ShippedAt,
+			&returnReq.ReceivedAt,
+			&returnReq.ExchangeItemID,
+			&returnReq.ReplacementVariantID,
+			&returnReq.ReplacementOrderID,
 			&returnReq.CreatedAt,
 			&returnReq.UpdatedAt,
 		)
@@ -124,42 +159,39 @@ func (r *returnRepository) GetAll(ctx context.Context, page, limit int, status s
 	offset := (page - 1) * limit
 
 	// Build WHERE clause
-	whereClause := "WHERE 1=1"
-	args := []interface{}{}
-	argCount := 1
-
+	where := &database.Where{}
 	if status != "" {
-		whereClause += " AND r.status = $1"
-		args = append(args, status)
-		argCount++
+		where.And("r.status = ?", status)
 	}
 	if rangeDays > 0 {
-		whereClause += fmt.Sprintf(" AND r.created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
-		args = append(args, rangeDays)
-		argCount++
+		where.And("r.created_at >= NOW() - ? * INTERVAL '1 day'", rangeDays)
 	}
+	where.ScopeStore(ctx, "o.store_id")
 
 	// Count total returns
-	countQuery := "SELECT COUNT(*) FROM returns r " + whereClause
+	countQuery := "SELECT COUNT(*) FROM returns r JOIN orders o ON r.order_id = o.id " + where.String()
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, where.Args()...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get returns with pagination
-	returnsQuery := `
+	returnsQuery := fmt.Sprintf(`
         SELECT 
-            r.id, r.order_id, r.user_id, r.reason, r.status, r.refund_amount, r.created_at, r.updated_at,
+            r.id, r.order_id, r.reason, r.status, r.refund_amount, r.rma_number, r.received_at,
+            r.exchange_item_id, r.replacement_variant_id, r.replacement_order_id,
+            r.created_at, r.updated_at,
             o.order_number, u.id, u.email
         FROM returns r
         JOIN orders o ON r.order_id = o.id
         JOIN users u ON r.user_id = u.id
-    ` + whereClause + ` ORDER BY r.created_at DESC LIMIT $` + fmt.Sprintf("%d", argCount) + ` OFFSET $` + fmt.Sprintf("%d", argCount+1)
-
-	args = append(args, limit, offset)
+        %s
+        ORDER BY r.created_at DESC
+        LIMIT %s OFFSET %s
+    `, where, where.Bind(limit), where.Bind(offset))
 
-	rows, err := r.db.Query(ctx, returnsQuery, args...)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, returnsQuery, where.Args()...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -174,6 +206,11 @@ func (r *returnRepository) GetAll(ctx context.Context, page, limit int, status s
 			&returnReq.Reason,
 			&returnReq.Status,
 			&returnReq.RefundAmount,
+			&returnReq.RMANumber,
+			&returnReq.ReceivedAt,
+			&returnReq.ExchangeItemID,
+			&returnReq.ReplacementVariantID,
+			&returnReq.ReplacementOrderID,
 			&returnReq.CreatedAt,
 			&returnReq.UpdatedAt,
 			&returnReq.Order.OrderNumber,
@@ -191,26 +228,29 @@ func (r *returnRepository) GetAll(ctx context.Context, page, limit int, status s
 	return returns, total, nil
 }
 
-func (r *returnRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.ReturnStatus, refundAmount float64) error {
+func (r *returnRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.ReturnStatus, refundAmount money.Money) error {
 	query := `
         UPDATE returns
         SET status = $1, refund_amount = $2, updated_at = NOW()
         WHERE id = $3
     `
 
-	_, err := r.db.Exec(ctx, query, status, refundAmount, id)
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, status, refundAmount, id)
 	return err
 }
 
 func (r *returnRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.Return, error) {
 	query := `
-        SELECT id, order_id, user_id, reason, status, refund_amount, created_at, updated_at
+        SELECT id, order_id, user_id, reason, status, refund_amount,
+               rma_number, return_carrier, return_tracking_number, shipped_at, received_at,
+               exchange_item_id, replacement_variant_id, replacement_order_id,
+               created_at, updated_at
         FROM returns
         WHERE order_id = $1
         ORDER BY created_at DESC
     `
 
-	rows, err := r.db.Query(ctx, query, orderID)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, orderID)
 	if err != nil {
 		return nil, err
 	}
@@ -226,6 +266,14 @@ func (r *returnRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID)
 			&returnReq.Reason,
 			&returnReq.Status,
 			&returnReq.RefundAmount,
+			&returnReq.RMANumber,
+			&returnReq.ReturnCarrier,
+			&returnReq.ReturnTrackingNumber,
+			&returnReq.ShippedAt,
+			&returnReq.ReceivedAt,
+			&returnReq.ExchangeItemID,
+			&returnReq.ReplacementVariantID,
+			&returnReq.ReplacementOrderID,
 			&returnReq.CreatedAt,
 			&returnReq.UpdatedAt,
 		)
@@ -239,3 +287,72 @@ func (r *returnRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID)
 
 	return returns, nil
 }
+
+func (r *returnRepository) Approve(ctx context.Context, id uuid.UUID, rmaNumber string) error {
+	query := `
+        UPDATE returns
+        SET status = $1, rma_number = $2, updated_at = NOW()
+        WHERE id = $3
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, models.ReturnApproved, rmaNumber, id)
+	return err
+}
+
+func (r *returnRepository) SetReplacementOrder(ctx context.Context, id, orderID uuid.UUID) error {
+	query := `UPDATE returns SET replacement_order_id = $1, updated_at = NOW() WHERE id = $2`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, orderID, id)
+	return err
+}
+
+func (r *returnRepository) MarkInTransit(ctx context.Context, id uuid.UUID, carrier, trackingNumber string) error {
+	query := `
+        UPDATE returns
+        SET status = $1, return_carrier = $2, return_tracking_number = $3,
+            shipped_at = NOW(), updated_at = NOW()
+        WHERE id = $4
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, models.ReturnInTransit, carrier, trackingNumber, id)
+	return err
+}
+
+// MarkReceived records that the parcel arrived. It fails with a conflict when
+// the return is no longer awaiting receipt, e.g. because another request
+// received it first.
+func (r *returnRepository) MarkReceived(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE returns
+        SET status = $1, received_at = NOW(), updated_at = NOW()
+        WHERE id = $2 AND status IN ($3, $4)
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, models.ReturnReceived, id, models.ReturnApproved, models.ReturnInTransit)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Conflict("return is not awaiting receipt")
+	}
+
+	return nil
+}
+
+func (r *returnRepository) CountOpenByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM returns
+        WHERE user_id = $1 AND status IN ('requested', 'approved', 'in_transit', 'received')
+    `
+
+	var count int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *returnRepository) ReassignUser(ctx context.Context, userID, toUserID uuid.UUID) error {
+	query := `UPDATE returns SET user_id = $2, updated_at = NOW() WHERE user_id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, userID, toUserID)
+	return err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RevocationRepository interface {
+	RevokeToken(ctx context.Context, tokenID string, userID uuid.UUID, expiresAt time.Time) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) (bool, error)
+	IsRevoked(ctx context.Context, tokenID string, userID uuid.UUID, issuedAt time.Time) (bool, error)
+}
+
+type revocationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRevocationRepository(db *pgxpool.Pool) RevocationRepository {
+	return &revocationRepository{db: db}
+}
+
+// RevokeToken denylists one token until it expires. Tokens that have since
+// expired are pruned at the same time, which keeps the list short.
+func (r *revocationRepository) RevokeToken(ctx context.Context, tokenID string, userID uuid.UUID, expiresAt time.Time) error {
+	query := `
+        WITH pruned AS (
+            DELETE FROM revoked_tokens WHERE expires_at < NOW()
+        )
+        INSERT INTO revoked_tokens (token_id, user_id, expires_at)
+        VALUES ($1, $2, to_timestamp($3))
+        ON CONFLICT (token_id) DO NOTHING
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, tokenID, userID, expiresAt.Unix())
+	return err
+}
+
+// RevokeUserSessions rejects every token issued to the user so far. It
+// reports false when the user does not exist.
+func (r *revocationRepository) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (bool, error) {
+	query := `UPDATE users SET sessions_revoked_at = NOW() WHERE id = $1`
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, userID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// IsRevoked reports whether the token was revoked by itself or issued no
+// later than its user's sessions were revoked. Token times are whole
+// seconds, so a token issued in the same second as the revocation counts as
+// revoked.
+func (r *revocationRepository) IsRevoked(ctx context.Context, tokenID string, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	query := `
+        SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE token_id = $1)
+            OR EXISTS (
+                SELECT 1 FROM users
+                WHERE id = $2 AND sessions_revoked_at >= to_timestamp($3)
+            )
+    `
+
+	var revoked bool
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, tokenID, userID, issuedAt.Unix()).Scan(&revoked)
+	return revoked, err
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SegmentRepository interface {
+	Create(ctx context.Context, segment *models.CustomerSegment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error)
+	GetAll(ctx context.Context) ([]models.CustomerSegment, error)
+	Update(ctx context.Context, segment *models.CustomerSegment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Refresh(ctx context.Context, segment *models.CustomerSegment) error
+	GetMembers(ctx context.Context, segmentID uuid.UUID, page, limit int) ([]models.SegmentMember, int, error)
+	ExportMembers(ctx context.Context, segmentID uuid.UUID, fn func(models.SegmentMember) error) error
+}
+
+type segmentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSegmentRepository(db *pgxpool.Pool) SegmentRepository {
+	return &segmentRepository{db: db}
+}
+
+const segmentColumns = `id, name, min_spend, inactive_days, category, member_count, refreshed_at, created_at, updated_at`
+
+func scanSegment(row pgx.Row) (*models.CustomerSegment, error) {
+	var segment models.CustomerSegment
+	err := row.Scan(
+		&segment.ID,
+		&segment.Name,
+		&segment.MinSpend,
+		&segment.InactiveDays,
+		&segment.Category,
+		&segment.MemberCount,
+		&segment.RefreshedAt,
+		&segment.CreatedAt,
+		&segment.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+func (r *segmentRepository) Create(ctx context.Context, segment *models.CustomerSegment) error {
+	query := `
+        INSERT INTO customer_segments (store_id, name, min_spend, inactive_days, category)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at, updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		storeID(ctx),
+		segment.Name,
+		segment.MinSpend,
+		segment.InactiveDays,
+		segment.Category,
+	).Scan(&segment.ID, &segment.CreatedAt, &segment.UpdatedAt)
+}
+
+func (r *segmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error) {
+	query := `SELECT ` + segmentColumns + ` FROM customer_segments WHERE id = $1 AND ($2::uuid IS NULL OR store_id = $2)`
+	return scanSegment(database.Conn(ctx, r.db).QueryRow(ctx, query, id, database.StoreArg(ctx)))
+}
+
+// GetAll lists the context's segments, or every store's for background jobs
+func (r *segmentRepository) GetAll(ctx context.Context) ([]models.CustomerSegment, error) {
+	query := `SELECT ` + segmentColumns + ` FROM customer_segments WHERE ($1::uuid IS NULL OR store_id = $1) ORDER BY name`
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, database.StoreArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	segments := []models.CustomerSegment{}
+	for rows.Next() {
+		segment, err := scanSegment(rows)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, *segment)
+	}
+
+	return segments, rows.Err()
+}
+
+func (r *segmentRepository) Update(ctx context.Context, segment *models.CustomerSegment) error {
+	query := `
+        UPDATE customer_segments
+        SET name = $1, min_spend = $2, inactive_days = $3, category = $4
+        WHERE id = $5
+        RETURNING updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		segment.Name,
+		segment.MinSpend,
+		segment.InactiveDays,
+		segment.Category,
+		segment.ID,
+	).Scan(&segment.UpdatedAt)
+}
+
+func (r *segmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM customer_segments WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+// Refresh replaces the segment's members with the customers of its store that
+// match its filters now, and records the new member count. Spend and recency
+// count orders that were not cancelled or refunded.
+func (r *segmentRepository) Refresh(ctx context.Context, segment *models.CustomerSegment) error {
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var storeID uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT store_id FROM customer_segments WHERE id = $1 FOR UPDATE`, segment.ID).Scan(&storeID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM customer_segment_members WHERE segment_id = $1`, segment.ID); err != nil {
+		return fmt.Errorf("failed to clear segment members: %w", err)
+	}
+
+	membersQuery := `
+        INSERT INTO customer_segment_members (segment_id, user_id, total_spent, order_count, last_order_at)
+        SELECT $1, u.id, COALESCE(s.total_spent, 0), COALESCE(s.order_count, 0), s.last_order_at
+        FROM users u
+        LEFT JOIN (
+            SELECT user_id, SUM(total_amount) AS total_spent, COUNT(*) AS order_count,
+                   MAX(created_at) AS last_order_at
+            FROM orders
+            WHERE store_id = $2 AND status NOT IN ('cancelled', 'refunded')
+            GROUP BY user_id
+        ) s ON s.user_id = u.id
+        WHERE u.store_id = $2 AND u.role = 'customer' AND u.is_active
+            AND ($3::numeric IS NULL OR COALESCE(s.total_spent, 0) > $3)
+            AND ($4::int IS NULL OR COALESCE(s.last_order_at, u.created_at) < NOW() - $4 * INTERVAL '1 day')
+            AND ($5::text IS NULL OR EXISTS (
+                SELECT 1
+                FROM orders o
+                JOIN order_items oi ON oi.order_id = o.id
+                JOIN products p ON p.id = oi.product_id
+                WHERE o.user_id = u.id AND o.status NOT IN ('cancelled', 'refunded') AND p.category = $5
+            ))
+    `
+
+	tag, err := tx.Exec(ctx, membersQuery,
+		segment.ID,
+		storeID,
+		segment.MinSpend,
+		segment.InactiveDays,
+		segment.Category,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to materialize segment members: %w", err)
+	}
+
+	query := `
+        UPDATE customer_segments
+        SET member_count = $1, refreshed_at = NOW()
+        WHERE id = $2
+        RETURNING member_count, refreshed_at, updated_at
+    `
+	err = tx.QueryRow(ctx, query, tag.RowsAffected(), segment.ID).
+		Scan(&segment.MemberCount, &segment.RefreshedAt, &segment.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+const segmentMemberQuery = `
+        SELECT u.id, u.email, u.first_name, u.last_name, m.total_spent, m.order_count, m.last_order_at
+        FROM customer_segment_members m
+        JOIN users u ON u.id = m.user_id
+        WHERE m.segment_id = $1
+        ORDER BY m.total_spent DESC, u.id
+    `
+
+func scanSegmentMember(row pgx.Row) (models.SegmentMember, error) {
+	var member models.SegmentMember
+	err := row.Scan(
+		&member.UserID,
+		&member.Email,
+		&member.FirstName,
+		&member.LastName,
+		&member.TotalSpent,
+		&member.OrderCount,
+		&member.LastOrderAt,
+	)
+	return member, err
+}
+
+// GetMembers pages through a segment's members, biggest spenders first
+func (r *segmentRepository) GetMembers(ctx context.Context, segmentID uuid.UUID, page, limit int) ([]models.SegmentMember, int, error) {
+	offset := (page - 1) * limit
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM customer_segment_members WHERE segment_id = $1`
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, segmentID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, segmentMemberQuery+` LIMIT $2 OFFSET $3`, segmentID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	members := []models.SegmentMember{}
+	for rows.Next() {
+		member, err := scanSegmentMember(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		members = append(members, member)
+	}
+
+	return members, total, rows.Err()
+}
+
+// ExportMembers streams every member of a segment to fn through a
+// server-side cursor
+func (r *segmentRepository) ExportMembers(ctx context.Context, segmentID uuid.UUID, fn func(models.SegmentMember) error) error {
+	return database.ForEachRow(ctx, r.db, database.DefaultCursorBatchSize, segmentMemberQuery, []any{segmentID}, func(rows pgx.Rows) error {
+		member, err := scanSegmentMember(rows)
+		if err != nil {
+			return err
+		}
+		return fn(member)
+	})
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ServiceableAreaRepository interface {
+	Create(ctx context.Context, area *models.ServiceableArea) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ServiceableArea, error)
+	GetByPostalCode(ctx context.Context, postalCode string) (*models.ServiceableArea, error)
+	GetAll(ctx context.Context) ([]models.ServiceableArea, error)
+	Update(ctx context.Context, area *models.ServiceableArea) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Match(ctx context.Context, postalCode string) (*models.ServiceableArea, error)
+	HasActive(ctx context.Context) (bool, error)
+}
+
+type serviceableAreaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewServiceableAreaRepository(db *pgxpool.Pool) ServiceableAreaRepository {
+	return &serviceableAreaRepository{db: db}
+}
+
+const serviceableAreaColumns = `id, postal_code, name, is_active, created_at, updated_at`
+
+func scanServiceableArea(row pgx.Row) (*models.ServiceableArea, error) {
+	var area models.ServiceableArea
+	err := row.Scan(
+		&area.ID,
+		&area.PostalCode,
+		&area.Name,
+		&area.IsActive,
+		&area.CreatedAt,
+		&area.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+func (r *serviceableAreaRepository) Create(ctx context.Context, area *models.ServiceableArea) error {
+	query := `
+        INSERT INTO serviceable_areas (postal_code, name, is_active)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at, updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		area.PostalCode,
+		area.Name,
+		area.IsActive,
+	).Scan(&area.ID, &area.CreatedAt, &area.UpdatedAt)
+}
+
+func (r *serviceableAreaRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ServiceableArea, error) {
+	query := `SELECT ` + serviceableAreaColumns + ` FROM serviceable_areas WHERE id = $1`
+	return scanServiceableArea(database.Conn(ctx, r.db).QueryRow(ctx, query, id))
+}
+
+func (r *serviceableAreaRepository) GetByPostalCode(ctx context.Context, postalCode string) (*models.ServiceableArea, error) {
+	query := `SELECT ` + serviceableAreaColumns + ` FROM serviceable_areas WHERE postal_code = $1`
+	return scanServiceableArea(database.Conn(ctx, r.db).QueryRow(ctx, query, postalCode))
+}
+
+func (r *serviceableAreaRepository) GetAll(ctx context.Context) ([]models.ServiceableArea, error) {
+	query := `SELECT ` + serviceableAreaColumns + ` FROM serviceable_areas ORDER BY postal_code`
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	areas := []models.ServiceableArea{}
+	for rows.Next() {
+		area, err := scanServiceableArea(rows)
+		if err != nil {
+			return nil, err
+		}
+		areas = append(areas, *area)
+	}
+
+	return areas, rows.Err()
+}
+
+func (r *serviceableAreaRepository) Update(ctx context.Context, area *models.ServiceableArea) error {
+	query := `
+        UPDATE serviceable_areas
+        SET name = $1, is_active = $2
+        WHERE id = $3
+        RETURNING updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		area.Name,
+		area.IsActive,
+		area.ID,
+	).Scan(&area.UpdatedAt)
+}
+
+func (r *serviceableAreaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM serviceable_areas WHERE id = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	return err
+}
+
+// Match returns the active area with the longest prefix of postalCode, or nil
+// when none covers it
+func (r *serviceableAreaRepository) Match(ctx context.Context, postalCode string) (*models.ServiceableArea, error) {
+	query := `
+        SELECT ` + serviceableAreaColumns + `
+        FROM serviceable_areas
+        WHERE is_active AND $1::text LIKE postal_code || '%'
+        ORDER BY length(postal_code) DESC
+        LIMIT 1
+    `
+	return scanServiceableArea(database.Conn(ctx, r.db).QueryRow(ctx, query, postalCode))
+}
+
+func (r *serviceableAreaRepository) HasActive(ctx context.Context) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM serviceable_areas WHERE is_active)`
+
+	var exists bool
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SettingRepository interface {
+	GetAll(ctx context.Context) ([]models.SettingOverride, error)
+	Set(ctx context.Context, override *models.SettingOverride) error
+	Delete(ctx context.Context, key string) error
+}
+
+type settingRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSettingRepository(db *pgxpool.Pool) SettingRepository {
+	return &settingRepository{db: db}
+}
+
+func (r *settingRepository) GetAll(ctx context.Context) ([]models.SettingOverride, error) {
+	query := `SELECT key, value, updated_by, updated_at FROM settings ORDER BY key`
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := []models.SettingOverride{}
+	for rows.Next() {
+		var override models.SettingOverride
+		if err := rows.Scan(&override.Key, &override.Value, &override.UpdatedBy, &override.UpdatedAt); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+
+	return overrides, rows.Err()
+}
+
+// Set stores the override, replacing any earlier one for the key
+func (r *settingRepository) Set(ctx context.Context, override *models.SettingOverride) error {
+	query := `
+        INSERT INTO settings (key, value, updated_by, updated_at)
+        VALUES ($1, $2, $3, NOW())
+        ON CONFLICT (key) DO UPDATE
+        SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+        RETURNING updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		override.Key,
+		override.Value,
+		override.UpdatedBy,
+	).Scan(&override.UpdatedAt)
+}
+
+func (r *settingRepository) Delete(ctx context.Context, key string) error {
+	query := `DELETE FROM settings WHERE key = $1`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, key)
+	return err
+}
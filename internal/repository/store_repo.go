@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StoreRepository interface {
+	Create(ctx context.Context, store *models.Store) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Store, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Store, error)
+	GetByHost(ctx context.Context, host string) (*models.Store, error)
+	GetAll(ctx context.Context) ([]models.Store, error)
+	Update(ctx context.Context, store *models.Store) error
+}
+
+type storeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStoreRepository(db *pgxpool.Pool) StoreRepository {
+	return &storeRepository{db: db}
+}
+
+// storeID is the store new rows are created in: the context's, or the default
+// store for work outside a storefront request
+func storeID(ctx context.Context) uuid.UUID {
+	if id, ok := database.StoreFromContext(ctx); ok {
+		return id
+	}
+	return models.DefaultStoreID
+}
+
+const storeColumns = `id, slug, name, host, is_active, order_number_prefix, created_at, updated_at`
+
+func scanStore(row pgx.Row) (*models.Store, error) {
+	var store models.Store
+	err := row.Scan(
+		&store.ID,
+		&store.Slug,
+		&store.Name,
+		&store.Host,
+		&store.IsActive,
+		&store.OrderNumberPrefix,
+		&store.CreatedAt,
+		&store.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+func (r *storeRepository) Create(ctx context.Context, store *models.Store) error {
+	query := `
+        INSERT INTO stores (slug, name, host, is_active, order_number_prefix)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at, updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		store.Slug,
+		store.Name,
+		store.Host,
+		store.IsActive,
+		store.OrderNumberPrefix,
+	).Scan(&store.ID, &store.CreatedAt, &store.UpdatedAt)
+}
+
+func (r *storeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Store, error) {
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE id = $1`
+	return scanStore(database.Conn(ctx, r.db).QueryRow(ctx, query, id))
+}
+
+func (r *storeRepository) GetBySlug(ctx context.Context, slug string) (*models.Store, error) {
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE slug = $1`
+	return scanStore(database.Conn(ctx, r.db).QueryRow(ctx, query, slug))
+}
+
+func (r *storeRepository) GetByHost(ctx context.Context, host string) (*models.Store, error) {
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE host = $1`
+	return scanStore(database.Conn(ctx, r.db).QueryRow(ctx, query, host))
+}
+
+func (r *storeRepository) GetAll(ctx context.Context) ([]models.Store, error) {
+	query := `SELECT ` + storeColumns + ` FROM stores ORDER BY created_at`
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stores := []models.Store{}
+	for rows.Next() {
+		store, err := scanStore(rows)
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, *store)
+	}
+
+	return stores, rows.Err()
+}
+
+func (r *storeRepository) Update(ctx context.Context, store *models.Store) error {
+	query := `
+        UPDATE stores
+        SET name = $1, host = $2, is_active = $3, order_number_prefix = $4
+        WHERE id = $5
+        RETURNING updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		store.Name,
+		store.Host,
+		store.IsActive,
+		store.OrderNumberPrefix,
+		store.ID,
+	).Scan(&store.UpdatedAt)
+}
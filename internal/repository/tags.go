@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// taggedTable is a table with a tags column whose rows belong to a store;
+// subject names a row in errors
+type taggedTable struct {
+	name    string
+	subject string
+}
+
+var (
+	orderTags = taggedTable{name: "orders", subject: "order"}
+	userTags  = taggedTable{name: "users", subject: "user"}
+)
+
+// addTags merges tags into a row's tags and returns them, sorted and without
+// duplicates
+func addTags(ctx context.Context, db *pgxpool.Pool, table taggedTable, id uuid.UUID, tags []string) ([]string, error) {
+	query := fmt.Sprintf(`
+        UPDATE %s
+        SET tags = ARRAY(SELECT DISTINCT t FROM unnest(tags || $2::text[]) AS t ORDER BY t)
+        WHERE id = $1 AND ($3::uuid IS NULL OR store_id = $3)
+        RETURNING tags
+    `, table.name)
+
+	return updateTags(ctx, db, table, query, id, tags)
+}
+
+// removeTag drops tag from a row's tags and returns the tags left
+func removeTag(ctx context.Context, db *pgxpool.Pool, table taggedTable, id uuid.UUID, tag string) ([]string, error) {
+	query := fmt.Sprintf(`
+        UPDATE %s
+        SET tags = array_remove(tags, $2)
+        WHERE id = $1 AND ($3::uuid IS NULL OR store_id = $3)
+        RETURNING tags
+    `, table.name)
+
+	return updateTags(ctx, db, table, query, id, tag)
+}
+
+func updateTags(ctx context.Context, db *pgxpool.Pool, table taggedTable, query string, id uuid.UUID, arg any) ([]string, error) {
+	var tags []string
+	err := database.Conn(ctx, db).QueryRow(ctx, query, id, arg, database.StoreArg(ctx)).Scan(&tags)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.NotFound(table.subject + " not found")
+	}
+	return tags, err
+}
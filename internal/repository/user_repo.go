@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"log"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -17,10 +19,19 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
-	GetAll(ctx context.Context, page, limit, rangeDays int) ([]models.User, int, error)
+	GetAll(ctx context.Context, filter models.UserFilter, page, limit int) ([]models.User, int, error)
+	ExportAll(ctx context.Context, filter models.UserFilter, fn func(models.User) error) error
 	Update(ctx context.Context, user *models.User) error
 	UpdateRole(ctx context.Context, id uuid.UUID, role string) error
+	UpdateRoles(ctx context.Context, ids []uuid.UUID, role string) (int64, error)
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) error
+	GetByVerifiedPhone(ctx context.Context, phone string) (*models.User, error)
+	SetPhone(ctx context.Context, id uuid.UUID, phone string) error
+	MarkPhoneVerified(ctx context.Context, id uuid.UUID, phone string) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	AddTags(ctx context.Context, id uuid.UUID, tags []string) ([]string, error)
+	RemoveTag(ctx context.Context, id uuid.UUID, tag string) ([]string, error)
 }
 
 type userRepository struct {
@@ -33,17 +44,20 @@ func NewUserRepository(db *pgxpool.Pool) UserRepository {
 
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	query := `
-        INSERT INTO users (email, password_hash, first_name, last_name, role, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+        INSERT INTO users (store_id, email, password_hash, first_name, last_name, role, email_verified, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
         RETURNING id, created_at, updated_at
     `
 
-	err := r.db.QueryRow(ctx, query,
+	user.StoreID = storeID(ctx)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query,
+		user.StoreID,
 		user.Email,
 		user.PasswordHash,
 		user.FirstName,
 		user.LastName,
 		user.Role,
+		user.EmailVerified,
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
@@ -57,19 +71,27 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
-        SELECT id, email, password_hash, first_name, last_name, role, created_at, updated_at
+        SELECT id, store_id, email, password_hash, first_name, last_name, role, email_verified, email_verified_at,
+               phone, phone_verified, is_active, deactivated_at, created_at, updated_at
         FROM users
-        WHERE id = $1
+        WHERE id = $1 AND ($2::uuid IS NULL OR store_id = $2)
     `
 
 	var user models.User
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id, database.StoreArg(ctx)).Scan(
 		&user.ID,
+		&user.StoreID,
 		&user.Email,
 		&user.PasswordHash,
 		&user.FirstName,
 		&user.LastName,
 		&user.Role,
+		&user.EmailVerified,
+		&user.EmailVerifiedAt,
+		&user.Phone,
+		&user.PhoneVerified,
+		&user.IsActive,
+		&user.DeactivatedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -87,19 +109,27 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-        SELECT id, email, password_hash, first_name, last_name, role, created_at, updated_at
+        SELECT id, store_id, email, password_hash, first_name, last_name, role, email_verified, email_verified_at,
+               phone, phone_verified, is_active, deactivated_at, created_at, updated_at
         FROM users
-        WHERE email = $1
+        WHERE email = $1 AND store_id = $2
     `
 
 	var user models.User
-	err := r.db.QueryRow(ctx, query, email).Scan(
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, email, storeID(ctx)).Scan(
 		&user.ID,
+		&user.StoreID,
 		&user.Email,
 		&user.PasswordHash,
 		&user.FirstName,
 		&user.LastName,
 		&user.Role,
+		&user.EmailVerified,
+		&user.EmailVerifiedAt,
+		&user.Phone,
+		&user.PhoneVerified,
+		&user.IsActive,
+		&user.DeactivatedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -122,7 +152,7 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
         WHERE id = $4
     `
 
-	_, err := r.db.Exec(ctx, query,
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query,
 		user.Email,
 		user.FirstName,
 		user.LastName,
@@ -136,41 +166,155 @@ func (r *userRepository) UpdateRole(ctx context.Context, id uuid.UUID, role stri
 	query := `
         UPDATE users
         SET role = $1, updated_at = NOW()
-        WHERE id = $2
+        WHERE id = $2 AND ($3::uuid IS NULL OR store_id = $3)
     `
 
-	_, err := r.db.Exec(ctx, query, role, id)
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, role, id, database.StoreArg(ctx))
 	return err
 }
 
-func (r *userRepository) GetAll(ctx context.Context, page, limit, rangeDays int) ([]models.User, int, error) {
-	offset := (page - 1) * limit
+func (r *userRepository) UpdateRoles(ctx context.Context, ids []uuid.UUID, role string) (int64, error) {
+	query := `
+        UPDATE users
+        SET role = $1, updated_at = NOW()
+        WHERE id = ANY($2::uuid[]) AND role <> $1 AND ($3::uuid IS NULL OR store_id = $3)
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, role, ids, database.StoreArg(ctx))
+	if err != nil {
+		return 0, err
+	}
 
-	whereClause := "WHERE 1=1"
-	args := []interface{}{}
-	argCount := 1
+	return result.RowsAffected(), nil
+}
 
-	if rangeDays > 0 {
-		whereClause += fmt.Sprintf(" AND created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
-		args = append(args, rangeDays)
-		argCount++
+// SetActive enables or disables login for a user. deactivated_at keeps the
+// time of the latest deactivation.
+func (r *userRepository) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	query := `
+        UPDATE users
+        SET is_active = $1,
+            deactivated_at = CASE WHEN $1 THEN NULL WHEN is_active THEN NOW() ELSE deactivated_at END,
+            updated_at = NOW()
+        WHERE id = $2 AND ($3::uuid IS NULL OR store_id = $3)
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, active, id, database.StoreArg(ctx))
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("user not found")
+	}
+
+	return nil
+}
+
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE users
+        SET email_verified = TRUE, email_verified_at = NOW(), updated_at = NOW()
+        WHERE id = $1
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("user not found")
+	}
+
+	return nil
+}
+
+// GetByVerifiedPhone finds the account in the current store that has
+// verified phone
+func (r *userRepository) GetByVerifiedPhone(ctx context.Context, phone string) (*models.User, error) {
+	query := `
+        SELECT id
+        FROM users
+        WHERE phone = $1 AND phone_verified AND store_id = $2
+    `
+
+	var id uuid.UUID
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, phone, storeID(ctx)).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// SetPhone changes the account's phone number, which is unverified until
+// MarkPhoneVerified
+func (r *userRepository) SetPhone(ctx context.Context, id uuid.UUID, phone string) error {
+	query := `
+        UPDATE users
+        SET phone = $1, phone_verified = FALSE, phone_verified_at = NULL, updated_at = NOW()
+        WHERE id = $2
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, phone, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("user not found")
 	}
 
+	return nil
+}
+
+// MarkPhoneVerified verifies the account's phone, provided it is still the
+// number the code was sent to
+func (r *userRepository) MarkPhoneVerified(ctx context.Context, id uuid.UUID, phone string) error {
+	query := `
+        UPDATE users
+        SET phone_verified = TRUE, phone_verified_at = NOW(), updated_at = NOW()
+        WHERE id = $1 AND phone = $2
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id, phone)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Conflict("phone number has changed; request a new code")
+	}
+
+	return nil
+}
+
+func (r *userRepository) GetAll(ctx context.Context, filter models.UserFilter, page, limit int) ([]models.User, int, error) {
+	offset := (page - 1) * limit
+
+	whereClause, args := buildUserFilter(ctx, filter)
+	argCount := len(args) + 1
+
 	countQuery := "SELECT COUNT(*) FROM users " + whereClause
 	var total int
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	query := `
-        SELECT id, email, password_hash, first_name, last_name, role, created_at, updated_at
+        SELECT id, store_id, email, password_hash, first_name, last_name, role, email_verified, email_verified_at,
+               phone, phone_verified, is_active, deactivated_at, tags, created_at, updated_at
         FROM users
     ` + whereClause + ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", argCount) + ` OFFSET $` + fmt.Sprintf("%d", argCount+1)
 
 	args = append(args, limit, offset)
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -181,11 +325,19 @@ func (r *userRepository) GetAll(ctx context.Context, page, limit, rangeDays int)
 		var user models.User
 		err := rows.Scan(
 			&user.ID,
+			&user.StoreID,
 			&user.Email,
 			&user.PasswordHash,
 			&user.FirstName,
 			&user.LastName,
 			&user.Role,
+			&user.EmailVerified,
+			&user.EmailVerifiedAt,
+			&user.Phone,
+			&user.PhoneVerified,
+			&user.IsActive,
+			&user.DeactivatedAt,
+			&user.Tags,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -198,8 +350,96 @@ func (r *userRepository) GetAll(ctx context.Context, page, limit, rangeDays int)
 	return users, total, nil
 }
 
+// ExportAll streams every user matching the admin list filters to fn through
+// a server-side cursor
+func (r *userRepository) ExportAll(ctx context.Context, filter models.UserFilter, fn func(models.User) error) error {
+	whereClause, args := buildUserFilter(ctx, filter)
+
+	query := `
+        SELECT id, email, first_name, last_name, role, email_verified, email_verified_at,
+               is_active, deactivated_at, created_at, updated_at
+        FROM users
+    ` + whereClause + ` ORDER BY created_at, id`
+
+	return database.ForEachRow(ctx, r.db, database.DefaultCursorBatchSize, query, args, func(rows pgx.Rows) error {
+		var user models.User
+		err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.FirstName,
+			&user.LastName,
+			&user.Role,
+			&user.EmailVerified,
+			&user.EmailVerifiedAt,
+			&user.IsActive,
+			&user.DeactivatedAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		return fn(user)
+	})
+}
+
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
 	return err
 }
+
+// AddTags adds internal tags to the user and returns all of their tags
+func (r *userRepository) AddTags(ctx context.Context, id uuid.UUID, tags []string) ([]string, error) {
+	return addTags(ctx, r.db, userTags, id, tags)
+}
+
+// RemoveTag removes an internal tag from the user and returns the rest
+func (r *userRepository) RemoveTag(ctx context.Context, id uuid.UUID, tag string) ([]string, error) {
+	return removeTag(ctx, r.db, userTags, id, tag)
+}
+
+// buildUserFilter returns the WHERE clause shared by the admin user list and
+// export. The deleted-user placeholder is never listed.
+func buildUserFilter(ctx context.Context, filter models.UserFilter) (string, []interface{}) {
+	whereClause := "WHERE id <> $1"
+	args := []interface{}{models.DeletedUserID}
+	argCount := 2
+
+	if storeID, ok := database.StoreFromContext(ctx); ok {
+		whereClause += fmt.Sprintf(" AND store_id = $%d", argCount)
+		args = append(args, storeID)
+		argCount++
+	}
+
+	if filter.Search != "" {
+		whereClause += fmt.Sprintf(" AND (email ILIKE $%d OR first_name ILIKE $%d OR last_name ILIKE $%d OR first_name || ' ' || last_name ILIKE $%d)", argCount, argCount, argCount, argCount)
+		args = append(args, "%"+filter.Search+"%")
+		argCount++
+	}
+
+	if filter.Role != "" {
+		whereClause += fmt.Sprintf(" AND role = $%d", argCount)
+		args = append(args, filter.Role)
+		argCount++
+	}
+
+	if filter.Active != nil {
+		whereClause += fmt.Sprintf(" AND is_active = $%d", argCount)
+		args = append(args, *filter.Active)
+		argCount++
+	}
+
+	if filter.RangeDays > 0 {
+		whereClause += fmt.Sprintf(" AND created_at >= NOW() - $%d * INTERVAL '1 day'", argCount)
+		args = append(args, filter.RangeDays)
+		argCount++
+	}
+
+	if filter.Tag != "" {
+		whereClause += fmt.Sprintf(" AND tags @> ARRAY[$%d]::text[]", argCount)
+		args = append(args, filter.Tag)
+	}
+
+	return whereClause, args
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type VariantRepository interface {
+	Create(ctx context.Context, variant *models.ProductVariant) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ProductVariant, error)
+	GetBySKU(ctx context.Context, sku string) (*models.ProductVariant, error)
+	GetByBarcode(ctx context.Context, barcode string) (*models.ProductVariant, error)
+	GetByProductID(ctx context.Context, productID uuid.UUID) ([]models.ProductVariant, error)
+	Update(ctx context.Context, id uuid.UUID, updateData *models.ProductVariantUpdateRequest) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	UpdateStock(ctx context.Context, id uuid.UUID, quantity int) (int, error)
+}
+
+type variantRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewVariantRepository(db *pgxpool.Pool) VariantRepository {
+	return &variantRepository{db: db}
+}
+
+func (r *variantRepository) Create(ctx context.Context, variant *models.ProductVariant) error {
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        INSERT INTO product_variants (product_id, sku, price, stock_quantity, attributes, barcode)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at, updated_at
+    `
+
+	err = tx.QueryRow(ctx, query,
+		variant.ProductID,
+		variant.SKU,
+		variant.Price,
+		variant.Stock,
+		variant.Attributes,
+		variant.Barcode,
+	).Scan(&variant.ID, &variant.CreatedAt, &variant.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Initial stock is received into the default warehouse
+	if err := syncDefaultWarehouseStock(ctx, tx, nil, []uuid.UUID{variant.ID}); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *variantRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ProductVariant, error) {
+	query := `
+        SELECT
+            v.id, v.product_id, v.sku, v.barcode, v.price,
+            v.stock_quantity - COALESCE(SUM(sr.quantity), 0)
+                - bundle_reserved_stock(v.product_id, v.id, NULL) as available_stock,
+            v.attributes, v.created_at, v.updated_at
+        FROM product_variants v
+        LEFT JOIN stock_reservations sr ON v.id = sr.variant_id
+            AND sr.expires_at > NOW()
+        WHERE v.id = $1
+        GROUP BY v.id
+    `
+
+	var variant models.ProductVariant
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&variant.SKU,
+		&variant.Barcode,
+		&variant.Price,
+		&variant.Stock,
+		&variant.Attributes,
+		&variant.CreatedAt,
+		&variant.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+func (r *variantRepository) GetBySKU(ctx context.Context, sku string) (*models.ProductVariant, error) {
+	query := `
+        SELECT id, product_id, sku, barcode, price, stock_quantity, attributes, created_at, updated_at
+        FROM product_variants
+        WHERE sku = $1
+    `
+
+	var variant models.ProductVariant
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, sku).Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&variant.SKU,
+		&variant.Barcode,
+		&variant.Price,
+		&variant.Stock,
+		&variant.Attributes,
+		&variant.CreatedAt,
+		&variant.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+// GetByBarcode finds a variant by its normalized barcode
+func (r *variantRepository) GetByBarcode(ctx context.Context, barcode string) (*models.ProductVariant, error) {
+	query := `
+        SELECT id, product_id, sku, barcode, price, stock_quantity, attributes, created_at, updated_at
+        FROM product_variants
+        WHERE barcode = $1
+    `
+
+	var variant models.ProductVariant
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, barcode).Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&variant.SKU,
+		&variant.Barcode,
+		&variant.Price,
+		&variant.Stock,
+		&variant.Attributes,
+		&variant.CreatedAt,
+		&variant.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+func (r *variantRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]models.ProductVariant, error) {
+	query := `
+        SELECT
+            v.id, v.product_id, v.sku, v.barcode, v.price,
+            v.stock_quantity - COALESCE(SUM(sr.quantity), 0)
+                - bundle_reserved_stock(v.product_id, v.id, NULL) as available_stock,
+            v.attributes, v.created_at, v.updated_at
+        FROM product_variants v
+        LEFT JOIN stock_reservations sr ON v.id = sr.variant_id
+            AND sr.expires_at > NOW()
+        WHERE v.product_id = $1
+        GROUP BY v.id
+        ORDER BY v.created_at
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := []models.ProductVariant{}
+	for rows.Next() {
+		var variant models.ProductVariant
+		if err := rows.Scan(
+			&variant.ID,
+			&variant.ProductID,
+			&variant.SKU,
+			&variant.Barcode,
+			&variant.Price,
+			&variant.Stock,
+			&variant.Attributes,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, nil
+}
+
+func (r *variantRepository) Update(ctx context.Context, id uuid.UUID, updateData *models.ProductVariantUpdateRequest) error {
+	query := "UPDATE product_variants SET "
+	args := []interface{}{}
+	argCount := 1
+
+	updates := []string{}
+
+	if updateData.Price > 0 {
+		updates = append(updates, fmt.Sprintf("price = $%d", argCount))
+		args = append(args, updateData.Price)
+		argCount++
+	}
+
+	if updateData.Stock != nil {
+		updates = append(updates, fmt.Sprintf("stock_quantity = $%d", argCount))
+		args = append(args, *updateData.Stock)
+		argCount++
+	}
+
+	if len(updateData.Attributes) > 0 {
+		updates = append(updates, fmt.Sprintf("attributes = $%d", argCount))
+		args = append(args, updateData.Attributes)
+		argCount++
+	}
+
+	// An empty barcode clears it
+	if updateData.Barcode != nil {
+		updates = append(updates, fmt.Sprintf("barcode = NULLIF($%d, '')", argCount))
+		args = append(args, *updateData.Barcode)
+		argCount++
+	}
+
+	if len(updates) == 0 {
+		return nil // Nothing to update
+	}
+
+	updates = append(updates, "updated_at = NOW()")
+	query += strings.Join(updates, ", ")
+	query += fmt.Sprintf(" WHERE id = $%d", argCount)
+	args = append(args, id)
+
+	tx, err := database.Conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if updateData.Stock != nil {
+		var productID uuid.UUID
+		if err := tx.QueryRow(ctx, "SELECT product_id FROM product_variants WHERE id = $1", id).Scan(&productID); err != nil {
+			return err
+		}
+		if err := checkStockTotal(ctx, tx, productID, &id, *updateData.Stock); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	// A stock level set on the variant is a total; the default warehouse
+	// absorbs the change
+	if updateData.Stock != nil {
+		if err := syncDefaultWarehouseStock(ctx, tx, nil, []uuid.UUID{id}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete removes a variant. It fails with a conflict when the variant has
+// been ordered, since order lines keep the variant that was bought.
+func (r *variantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+        DELETE FROM product_variants
+        WHERE id = $1
+            AND NOT EXISTS (SELECT 1 FROM order_items WHERE variant_id = $1)
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Conflict("variant has been ordered and cannot be deleted")
+	}
+
+	return nil
+}
+
+// UpdateStock adjusts stock by quantity and returns the resulting level
+func (r *variantRepository) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) (int, error) {
+	query := `
+        UPDATE product_variants
+        SET stock_quantity = stock_quantity + $1, updated_at = NOW()
+        WHERE id = $2 AND stock_quantity + $1 >= 0
+        RETURNING stock_quantity
+    `
+
+	var newStock int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, quantity, id).Scan(&newStock)
+	return newStock, err
+}
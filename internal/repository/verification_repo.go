@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type VerificationRepository interface {
+	Create(ctx context.Context, token *models.EmailVerificationToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type verificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewVerificationRepository(db *pgxpool.Pool) VerificationRepository {
+	return &verificationRepository{db: db}
+}
+
+func (r *verificationRepository) Create(ctx context.Context, token *models.EmailVerificationToken) error {
+	query := `
+        INSERT INTO email_verification_tokens (user_id, token_hash, expires_at)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		token.UserID,
+		token.TokenHash,
+		token.ExpiresAt,
+	).Scan(&token.ID, &token.CreatedAt)
+}
+
+func (r *verificationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
+	query := `
+        SELECT id, user_id, token_hash, expires_at, used_at, created_at
+        FROM email_verification_tokens
+        WHERE token_hash = $1
+    `
+
+	var token models.EmailVerificationToken
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *verificationRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE email_verification_tokens
+        SET used_at = NOW()
+        WHERE id = $1 AND used_at IS NULL
+    `
+
+	result, err := database.Conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.Conflict("verification token already used")
+	}
+
+	return nil
+}
+
+func (r *verificationRepository) DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM email_verification_tokens WHERE user_id = $1 AND used_at IS NULL`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,563 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WarehouseRepository interface {
+	Create(ctx context.Context, warehouse *models.Warehouse) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Warehouse, error)
+	GetByCode(ctx context.Context, code string) (*models.Warehouse, error)
+	GetAll(ctx context.Context) ([]models.Warehouse, error)
+	GetDefault(ctx context.Context) (*models.Warehouse, error)
+	Update(ctx context.Context, warehouse *models.Warehouse) error
+	ClearDefault(ctx context.Context) error
+	GetStock(ctx context.Context, warehouseID uuid.UUID, page, limit int) ([]models.WarehouseStock, int, error)
+	GetProductStock(ctx context.Context, productID uuid.UUID) ([]models.WarehouseStock, error)
+	GetStockLevel(ctx context.Context, warehouseID, productID uuid.UUID, variantID *uuid.UUID) (int, error)
+	AdjustStock(ctx context.Context, warehouseID, productID uuid.UUID, variantID *uuid.UUID, delta int) error
+	GetAllocationCandidates(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, postalCode string, quantity int) ([]models.WarehouseStock, error)
+	GetShippingCandidates(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, postalCode string, quantity int) ([]models.WarehouseStock, error)
+	CreateAllocation(ctx context.Context, allocation *models.OrderItemAllocation) error
+	GetAllocations(ctx context.Context, orderItemIDs []uuid.UUID) ([]models.OrderItemAllocation, error)
+	CreateTransfer(ctx context.Context, transfer *models.StockTransfer) error
+	GetTransfers(ctx context.Context, page, limit int) ([]models.StockTransfer, int, error)
+}
+
+type warehouseRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWarehouseRepository(db *pgxpool.Pool) WarehouseRepository {
+	return &warehouseRepository{db: db}
+}
+
+// warehouseColumns selects a warehouse with the number of SKUs it stocks and
+// its total units
+const warehouseColumns = `
+            w.id, w.code, w.name, w.zones, w.priority, to_char(w.cutoff_time, 'HH24:MI'),
+            w.is_active, w.is_default,
+            COUNT(ws.id) FILTER (WHERE ws.quantity > 0),
+            COALESCE(SUM(ws.quantity), 0),
+            w.created_at, w.updated_at
+`
+
+func scanWarehouse(row pgx.Row, warehouse *models.Warehouse) error {
+	return row.Scan(
+		&warehouse.ID,
+		&warehouse.Code,
+		&warehouse.Name,
+		&warehouse.Zones,
+		&warehouse.Priority,
+		&warehouse.CutoffTime,
+		&warehouse.IsActive,
+		&warehouse.IsDefault,
+		&warehouse.SKUCount,
+		&warehouse.TotalUnits,
+		&warehouse.CreatedAt,
+		&warehouse.UpdatedAt,
+	)
+}
+
+func (r *warehouseRepository) Create(ctx context.Context, warehouse *models.Warehouse) error {
+	query := `
+        INSERT INTO warehouses (code, name, zones, priority, cutoff_time, is_active, is_default)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at, updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		warehouse.Code,
+		warehouse.Name,
+		warehouse.Zones,
+		warehouse.Priority,
+		warehouse.CutoffTime,
+		warehouse.IsActive,
+		warehouse.IsDefault,
+	).Scan(&warehouse.ID, &warehouse.CreatedAt, &warehouse.UpdatedAt)
+}
+
+func (r *warehouseRepository) getOne(ctx context.Context, where string, arg interface{}) (*models.Warehouse, error) {
+	query := `
+        SELECT ` + warehouseColumns + `
+        FROM warehouses w
+        LEFT JOIN warehouse_stock ws ON ws.warehouse_id = w.id
+        WHERE ` + where + `
+        GROUP BY w.id
+    `
+
+	var warehouse models.Warehouse
+	err := scanWarehouse(database.Conn(ctx, r.db).QueryRow(ctx, query, arg), &warehouse)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &warehouse, nil
+}
+
+func (r *warehouseRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Warehouse, error) {
+	return r.getOne(ctx, "w.id = $1", id)
+}
+
+func (r *warehouseRepository) GetByCode(ctx context.Context, code string) (*models.Warehouse, error) {
+	return r.getOne(ctx, "w.code = $1", code)
+}
+
+func (r *warehouseRepository) GetDefault(ctx context.Context) (*models.Warehouse, error) {
+	return r.getOne(ctx, "w.is_default = $1", true)
+}
+
+func (r *warehouseRepository) GetAll(ctx context.Context) ([]models.Warehouse, error) {
+	query := `
+        SELECT ` + warehouseColumns + `
+        FROM warehouses w
+        LEFT JOIN warehouse_stock ws ON ws.warehouse_id = w.id
+        GROUP BY w.id
+        ORDER BY w.priority, w.code
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses := []models.Warehouse{}
+	for rows.Next() {
+		var warehouse models.Warehouse
+		if err := scanWarehouse(rows, &warehouse); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+
+	return warehouses, rows.Err()
+}
+
+func (r *warehouseRepository) Update(ctx context.Context, warehouse *models.Warehouse) error {
+	query := `
+        UPDATE warehouses
+        SET name = $1, zones = $2, priority = $3, cutoff_time = $4, is_active = $5, is_default = $6
+        WHERE id = $7
+        RETURNING updated_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		warehouse.Name,
+		warehouse.Zones,
+		warehouse.Priority,
+		warehouse.CutoffTime,
+		warehouse.IsActive,
+		warehouse.IsDefault,
+		warehouse.ID,
+	).Scan(&warehouse.UpdatedAt)
+}
+
+func (r *warehouseRepository) ClearDefault(ctx context.Context) error {
+	query := `UPDATE warehouses SET is_default = FALSE WHERE is_default`
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query)
+	return err
+}
+
+const warehouseStockColumns = `
+            ws.warehouse_id, w.code, ws.product_id, p.name, p.sku,
+            ws.variant_id, v.sku, ws.quantity, ws.updated_at
+`
+
+func scanWarehouseStock(row pgx.Row, stock *models.WarehouseStock) error {
+	return row.Scan(
+		&stock.WarehouseID,
+		&stock.WarehouseCode,
+		&stock.ProductID,
+		&stock.ProductName,
+		&stock.ProductSKU,
+		&stock.VariantID,
+		&stock.VariantSKU,
+		&stock.Quantity,
+		&stock.UpdatedAt,
+	)
+}
+
+func (r *warehouseRepository) GetStock(ctx context.Context, warehouseID uuid.UUID, page, limit int) ([]models.WarehouseStock, int, error) {
+	offset := (page - 1) * limit
+
+	countQuery := `SELECT COUNT(*) FROM warehouse_stock WHERE warehouse_id = $1`
+	var total int
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, countQuery, warehouseID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT ` + warehouseStockColumns + `
+        FROM warehouse_stock ws
+        JOIN warehouses w ON ws.warehouse_id = w.id
+        JOIN products p ON ws.product_id = p.id
+        LEFT JOIN product_variants v ON ws.variant_id = v.id
+        WHERE ws.warehouse_id = $1
+        ORDER BY p.sku, v.sku NULLS FIRST
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, warehouseID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	stock := []models.WarehouseStock{}
+	for rows.Next() {
+		var level models.WarehouseStock
+		if err := scanWarehouseStock(rows, &level); err != nil {
+			return nil, 0, err
+		}
+		stock = append(stock, level)
+	}
+
+	return stock, total, rows.Err()
+}
+
+// GetProductStock returns every warehouse's stock of the product and its
+// variants
+func (r *warehouseRepository) GetProductStock(ctx context.Context, productID uuid.UUID) ([]models.WarehouseStock, error) {
+	query := `
+        SELECT ` + warehouseStockColumns + `
+        FROM warehouse_stock ws
+        JOIN warehouses w ON ws.warehouse_id = w.id
+        JOIN products p ON ws.product_id = p.id
+        LEFT JOIN product_variants v ON ws.variant_id = v.id
+        WHERE ws.product_id = $1
+        ORDER BY w.priority, w.code
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stock := []models.WarehouseStock{}
+	for rows.Next() {
+		var level models.WarehouseStock
+		if err := scanWarehouseStock(rows, &level); err != nil {
+			return nil, err
+		}
+		stock = append(stock, level)
+	}
+
+	return stock, rows.Err()
+}
+
+func (r *warehouseRepository) GetStockLevel(ctx context.Context, warehouseID, productID uuid.UUID, variantID *uuid.UUID) (int, error) {
+	query := `
+        SELECT quantity FROM warehouse_stock
+        WHERE warehouse_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3::uuid
+        FOR UPDATE
+    `
+
+	var quantity int
+	err := database.Conn(ctx, r.db).QueryRow(ctx, query, warehouseID, productID, variantID).Scan(&quantity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return quantity, err
+}
+
+// AdjustStock changes a warehouse's stock of a product or variant by delta,
+// creating the row on the first delivery. Stock never goes below zero.
+func (r *warehouseRepository) AdjustStock(ctx context.Context, warehouseID, productID uuid.UUID, variantID *uuid.UUID, delta int) error {
+	if delta < 0 {
+		query := `
+            UPDATE warehouse_stock
+            SET quantity = quantity + $4, updated_at = NOW()
+            WHERE warehouse_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3::uuid
+                AND quantity + $4 >= 0
+        `
+
+		result, err := database.Conn(ctx, r.db).Exec(ctx, query, warehouseID, productID, variantID, delta)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return apperrors.Validation("insufficient stock at warehouse")
+		}
+
+		return nil
+	}
+
+	query := `
+        INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (warehouse_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid))
+        DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity, updated_at = NOW()
+    `
+
+	_, err := database.Conn(ctx, r.db).Exec(ctx, query, warehouseID, productID, variantID, delta)
+	return err
+}
+
+// GetAllocationCandidates locks and returns the active warehouses holding the
+// product or variant in the order they should ship from: warehouses whose
+// zones cover postalCode first, then those that can ship quantity in one
+// parcel, then by priority
+// allocationCandidatesQuery orders the warehouses holding a product or
+// variant the way orders are allocated: warehouses serving the destination
+// postal code first, then ones that can ship the whole quantity, then by
+// priority. %s is the locking clause.
+const allocationCandidatesQuery = `
+        SELECT ` + warehouseStockColumns + `
+        FROM warehouse_stock ws
+        JOIN warehouses w ON ws.warehouse_id = w.id
+        JOIN products p ON ws.product_id = p.id
+        LEFT JOIN product_variants v ON ws.variant_id = v.id
+        WHERE ws.product_id = $1 AND ws.variant_id IS NOT DISTINCT FROM $2::uuid
+            AND ws.quantity > 0 AND w.is_active
+        ORDER BY
+            EXISTS (SELECT 1 FROM unnest(w.zones) AS zone WHERE $3::text <> '' AND $3::text LIKE zone || '%%') DESC,
+            ws.quantity >= $4 DESC,
+            w.priority,
+            w.code
+        %s
+    `
+
+// GetAllocationCandidates locks the returned stock rows for the allocation
+func (r *warehouseRepository) GetAllocationCandidates(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, postalCode string, quantity int) ([]models.WarehouseStock, error) {
+	return r.getCandidates(ctx, fmt.Sprintf(allocationCandidatesQuery, "FOR UPDATE OF ws"), productID, variantID, postalCode, quantity)
+}
+
+// GetShippingCandidates is GetAllocationCandidates without the locks, for
+// estimates ahead of checkout
+func (r *warehouseRepository) GetShippingCandidates(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, postalCode string, quantity int) ([]models.WarehouseStock, error) {
+	return r.getCandidates(ctx, fmt.Sprintf(allocationCandidatesQuery, ""), productID, variantID, postalCode, quantity)
+}
+
+func (r *warehouseRepository) getCandidates(ctx context.Context, query string, productID uuid.UUID, variantID *uuid.UUID, postalCode string, quantity int) ([]models.WarehouseStock, error) {
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, productID, variantID, postalCode, quantity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []models.WarehouseStock{}
+	for rows.Next() {
+		var level models.WarehouseStock
+		if err := scanWarehouseStock(rows, &level); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, level)
+	}
+
+	return candidates, rows.Err()
+}
+
+func (r *warehouseRepository) CreateAllocation(ctx context.Context, allocation *models.OrderItemAllocation) error {
+	query := `
+        INSERT INTO order_item_allocations (order_item_id, warehouse_id, quantity,
+                                            component_product_id, component_variant_id)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		allocation.OrderItemID,
+		allocation.WarehouseID,
+		allocation.Quantity,
+		allocation.ComponentProductID,
+		allocation.ComponentVariantID,
+	).Scan(&allocation.ID, &allocation.CreatedAt)
+}
+
+func (r *warehouseRepository) GetAllocations(ctx context.Context, orderItemIDs []uuid.UUID) ([]models.OrderItemAllocation, error) {
+	query := `
+        SELECT id, order_item_id, warehouse_id, quantity, created_at,
+               component_product_id, component_variant_id
+        FROM order_item_allocations
+        WHERE order_item_id = ANY($1::uuid[])
+        ORDER BY created_at
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, orderItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocations := []models.OrderItemAllocation{}
+	for rows.Next() {
+		var allocation models.OrderItemAllocation
+		err := rows.Scan(
+			&allocation.ID,
+			&allocation.OrderItemID,
+			&allocation.WarehouseID,
+			&allocation.Quantity,
+			&allocation.CreatedAt,
+			&allocation.ComponentProductID,
+			&allocation.ComponentVariantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	return allocations, rows.Err()
+}
+
+func (r *warehouseRepository) CreateTransfer(ctx context.Context, transfer *models.StockTransfer) error {
+	query := `
+        INSERT INTO stock_transfers (from_warehouse_id, to_warehouse_id, product_id, variant_id, quantity, note)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+        RETURNING id, created_at
+    `
+
+	return database.Conn(ctx, r.db).QueryRow(ctx, query,
+		transfer.FromWarehouseID,
+		transfer.ToWarehouseID,
+		transfer.ProductID,
+		transfer.VariantID,
+		transfer.Quantity,
+		transfer.Note,
+	).Scan(&transfer.ID, &transfer.CreatedAt)
+}
+
+func (r *warehouseRepository) GetTransfers(ctx context.Context, page, limit int) ([]models.StockTransfer, int, error) {
+	offset := (page - 1) * limit
+
+	var total int
+	if err := database.Conn(ctx, r.db).QueryRow(ctx, `SELECT COUNT(*) FROM stock_transfers`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+        SELECT t.id, t.from_warehouse_id, fw.code, t.to_warehouse_id, tw.code,
+               t.product_id, t.variant_id, t.quantity, COALESCE(t.note, ''), t.created_at
+        FROM stock_transfers t
+        JOIN warehouses fw ON t.from_warehouse_id = fw.id
+        JOIN warehouses tw ON t.to_warehouse_id = tw.id
+        ORDER BY t.created_at DESC
+        LIMIT $1 OFFSET $2
+    `
+
+	rows, err := database.Conn(ctx, r.db).Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	transfers := []models.StockTransfer{}
+	for rows.Next() {
+		var transfer models.StockTransfer
+		err := rows.Scan(
+			&transfer.ID,
+			&transfer.FromWarehouseID,
+			&transfer.FromWarehouseCode,
+			&transfer.ToWarehouseID,
+			&transfer.ToWarehouseCode,
+			&transfer.ProductID,
+			&transfer.VariantID,
+			&transfer.Quantity,
+			&transfer.Note,
+			&transfer.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, total, rows.Err()
+}
+
+// checkStockTotal makes sure a product's or variant's total stock can be set
+// to total: the default warehouse cannot go negative to make up for stock
+// held elsewhere, and units reserved in carts must stay covered. The product
+// row is locked as checkouts lock it, so neither can change before the
+// caller's transaction commits.
+func checkStockTotal(ctx context.Context, conn database.Querier, productID uuid.UUID, variantID *uuid.UUID, total int) error {
+	if _, err := conn.Exec(ctx, "SELECT id FROM products WHERE id = $1 FOR UPDATE", productID); err != nil {
+		return fmt.Errorf("failed to lock stock: %w", err)
+	}
+
+	query := `
+        SELECT
+            COALESCE((
+                SELECT SUM(ws.quantity) FROM warehouse_stock ws
+                JOIN warehouses w ON w.id = ws.warehouse_id
+                WHERE ws.product_id = $1 AND ws.variant_id IS NOT DISTINCT FROM $2::uuid AND NOT w.is_default
+            ), 0),
+            COALESCE((
+                SELECT SUM(sr.quantity) FROM stock_reservations sr
+                WHERE sr.product_id = $1 AND sr.variant_id IS NOT DISTINCT FROM $2::uuid AND sr.expires_at > NOW()
+            ), 0)
+    `
+	var held, reserved int
+	if err := conn.QueryRow(ctx, query, productID, variantID).Scan(&held, &reserved); err != nil {
+		return err
+	}
+
+	if total < held {
+		return apperrors.Validationf("stock cannot be less than the %d units held in other warehouses", held)
+	}
+	if total < reserved {
+		return apperrors.Validationf("stock cannot be less than the %d units reserved in carts", reserved)
+	}
+
+	return nil
+}
+
+// syncDefaultWarehouseStock puts whatever part of a product's or variant's
+// total stock is not held by another warehouse in the default warehouse, so
+// stock set directly on a product or variant stays accounted for
+func syncDefaultWarehouseStock(ctx context.Context, conn database.Querier, productIDs, variantIDs []uuid.UUID) error {
+	if len(productIDs) > 0 {
+		query := `
+            INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+            SELECT w.id, p.id, NULL, p.stock_quantity - COALESCE((
+                    SELECT SUM(ws.quantity) FROM warehouse_stock ws
+                    WHERE ws.product_id = p.id AND ws.variant_id IS NULL AND ws.warehouse_id <> w.id
+                ), 0)
+            FROM products p
+            CROSS JOIN warehouses w
+            WHERE p.id = ANY($1::uuid[]) AND w.is_default
+            ON CONFLICT (warehouse_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid))
+            DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = NOW()
+        `
+		if _, err := conn.Exec(ctx, query, productIDs); err != nil {
+			return err
+		}
+	}
+
+	if len(variantIDs) > 0 {
+		query := `
+            INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+            SELECT w.id, v.product_id, v.id, v.stock_quantity - COALESCE((
+                    SELECT SUM(ws.quantity) FROM warehouse_stock ws
+                    WHERE ws.variant_id = v.id AND ws.warehouse_id <> w.id
+                ), 0)
+            FROM product_variants v
+            CROSS JOIN warehouses w
+            WHERE v.id = ANY($1::uuid[]) AND w.is_default
+            ON CONFLICT (warehouse_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid))
+            DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = NOW()
+        `
+		if _, err := conn.Exec(ctx, query, variantIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Package routes mounts each API version under /api/<version>, and again under
+// /stores/<slug>/api/<version> for storefronts without a host of their own.
+// Every version registers into the same public, authenticated and admin
+// groups, so auth and rate limiting are set up once and behave identically
+// across versions while each version chooses its own handlers and response
+// shapes over the shared services.
+package routes
+
+import (
+	"ecommerce-backend/internal/config"
+	"ecommerce-backend/internal/handlers"
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Groups are the route groups a version registers into
+type Groups struct {
+	// Public needs no authentication
+	Public *gin.RouterGroup
+	// Protected requires a valid access token
+	Protected *gin.RouterGroup
+	// Admin requires an admin access token and is prefixed with /admin
+	Admin *gin.RouterGroup
+	// Fulfillment requires the fulfillment permission, held by admins and
+	// warehouse staff, and is also prefixed with /admin
+	Fulfillment *gin.RouterGroup
+}
+
+// Version is one API version: the path segment it is served under and the
+// function that registers its routes. A version with a Flag is only served
+// while that feature flag is on for the client IP.
+type Version struct {
+	Name     string
+	Flag     string
+	Register func(g Groups)
+}
+
+// storePrefix selects a store by slug ahead of the API path
+const storePrefix = "/stores/:store"
+
+// Mount registers every version on the router. The per-user rate limiter is
+// shared, so a client cannot double its budget by spreading calls across
+// versions. Impersonation tokens are confined and audited the same way in
+// every version, and maintenance mode stops customer writes on the protected
+// routes while admin routes stay open. Each request is resolved to a store
+// before anything else runs, so auth and the handlers below only see that
+// store's data.
+func Mount(router *gin.Engine, repos *handlers.Repositories, cfg *config.Config, versions ...Version) {
+	resolveStore := middleware.GinStoreResolver(repos.StoreService)
+	requireAuth := middleware.GinAuthMiddleware(repos.AuthHandler.AuthService)
+	userRateLimit := middleware.GinUserRateLimit(repos.SettingsService, models.SettingRateLimit)
+	requireAdmin := middleware.GinAdminMiddleware()
+	requireFulfillment := middleware.GinPermissionMiddleware(models.PermissionFulfillment)
+	impersonationGuard := middleware.GinImpersonationGuard(repos.AuditService)
+	maintenance := middleware.GinMaintenanceMode(repos.SettingsService)
+
+	for _, prefix := range []string{"", storePrefix} {
+		for _, version := range versions {
+			api := router.Group(prefix + "/api/" + version.Name)
+			api.Use(resolveStore)
+			if version.Flag != "" {
+				api.Use(middleware.GinFeatureFlag(repos.FlagService, version.Flag))
+			}
+
+			protected := api.Group("")
+			protected.Use(requireAuth, impersonationGuard, userRateLimit, maintenance)
+
+			admin := api.Group("/admin")
+			admin.Use(requireAuth, impersonationGuard, requireAdmin)
+
+			fulfillment := api.Group("/admin")
+			fulfillment.Use(requireAuth, impersonationGuard, requireFulfillment)
+
+			version.Register(Groups{Public: api, Protected: protected, Admin: admin, Fulfillment: fulfillment})
+		}
+	}
+}
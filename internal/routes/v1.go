@@ -0,0 +1,310 @@
+package routes
+
+import (
+	"ecommerce-backend/internal/config"
+	"ecommerce-backend/internal/handlers"
+	"ecommerce-backend/internal/middleware"
+	"ecommerce-backend/internal/models"
+)
+
+// V1 is the original API. Its routes and response shapes are frozen; changes
+// that would break clients belong in a later version.
+func V1(repos *handlers.Repositories, cfg *config.Config) Version {
+	return Version{
+		Name: "v1",
+		Register: func(g Groups) {
+			registerV1(g, repos, cfg)
+		},
+	}
+}
+
+func registerV1(g Groups, repos *handlers.Repositories, cfg *config.Config) {
+	api, protected, admin, fulfillment := g.Public, g.Protected, g.Admin, g.Fulfillment
+
+	// Imports and exports move whole files and outlive the default deadlines
+	longRunning := middleware.GinTimeout(cfg.LongRequestTimeout)
+	uploadLimit := middleware.GinBodyLimit(cfg.MaxUploadBytes)
+	// Credential and SMS-sending endpoints share a stricter limit
+	authLimiter := middleware.GinRateLimit(repos.SettingsService, models.SettingAuthRateLimit)
+
+	// Health check endpoints (public)
+	{
+		api.GET("/health", repos.HealthHandler.HealthCheck)
+		api.GET("/live", repos.HealthHandler.LivenessCheck)
+		api.GET("/ready", repos.HealthHandler.ReadinessCheck)
+		api.GET("/startup", repos.HealthHandler.StartupCheck)
+		api.GET("/metrics", repos.HealthHandler.Metrics)
+	}
+
+	// Public routes
+	{
+		// Auth routes
+		api.POST("/auth/register", authLimiter, middleware.GinMaintenanceMode(repos.SettingsService), repos.AuthHandler.Register)
+		api.POST("/auth/login", authLimiter, repos.AuthHandler.Login)
+		api.POST("/auth/oauth/:provider", authLimiter, repos.AuthHandler.OAuthLogin)
+		api.POST("/auth/otp/request", authLimiter, repos.PhoneAuthHandler.RequestLoginOTP)
+		api.POST("/auth/otp/verify", authLimiter, repos.PhoneAuthHandler.LoginWithOTP)
+		api.POST("/auth/refresh", repos.AuthHandler.RefreshToken)
+		api.POST("/auth/logout", repos.AuthHandler.Logout)
+		api.POST("/auth/verify-email", repos.AuthHandler.VerifyEmail)
+		api.POST("/auth/resend-verification", repos.AuthHandler.ResendVerification)
+
+		// Product routes (public read access)
+		api.GET("/products", repos.ProductHandler.GetProducts)
+		api.GET("/products/facets", repos.ProductHandler.GetProductFacets)
+		api.GET("/products/:id", repos.ProductHandler.GetProduct)
+		api.GET("/products/:id/variants", repos.ProductHandler.GetProductVariants)
+
+		// Shipping coverage
+		api.GET("/shipping/serviceability", repos.ShippingHandler.CheckServiceability)
+
+		// Payment gateway webhooks (authenticated by signature; the gateway
+		// reports payments of every store to one URL)
+		api.POST("/payments/webhook/stripe", middleware.GinAllStores(), repos.PaymentHandler.StripeWebhook)
+
+		// Customers return here from 3-D Secure; the outcome is read from the gateway
+		api.GET("/payments/:id/callback", middleware.GinAllStores(), repos.PaymentHandler.PaymentCallback)
+
+		// Admin live dashboard (authenticates the JWT itself since browsers
+		// cannot send headers on the WebSocket handshake; the connection is
+		// long-lived, so it has no deadline)
+		api.GET("/admin/ws", middleware.GinTimeout(0), repos.AdminWSHandler.Connect)
+	}
+
+	// Protected routes (require authentication)
+	{
+		// User routes
+		protected.GET("/users/profile", repos.AuthHandler.GetProfile)
+		protected.PUT("/users/profile", repos.AuthHandler.UpdateProfile)
+		protected.PUT("/users/change-password", repos.AuthHandler.ChangePassword)
+		protected.PUT("/users/phone", authLimiter, repos.PhoneAuthHandler.UpdatePhone)
+		protected.POST("/users/phone/verify", authLimiter, repos.PhoneAuthHandler.VerifyPhone)
+		protected.POST("/users/reauth/otp", authLimiter, repos.PhoneAuthHandler.RequestReauthOTP)
+		protected.DELETE("/users/profile", repos.AccountHandler.CloseAccount)
+		protected.GET("/users/export", longRunning, repos.AccountHandler.ExportData)
+		protected.GET("/users/store-credit", repos.GiftCardHandler.GetStoreCredit)
+
+		// Cart routes
+		protected.GET("/cart", repos.CartHandler.GetCart)
+		protected.GET("/cart/validate", repos.CartHandler.ValidateCart)
+		protected.GET("/cart/cod-eligibility", repos.CODHandler.CheckEligibility)
+		protected.POST("/checkout/delivery-estimate", repos.DeliveryHandler.EstimateDelivery)
+		protected.POST("/checkout/sessions", repos.CheckoutHandler.CreateSession)
+		protected.GET("/checkout/sessions/:id", repos.CheckoutHandler.GetSession)
+		protected.POST("/cart/resolve", repos.CartHandler.ResolveCart)
+		protected.POST("/cart/items", repos.CartHandler.AddToCart)
+		protected.PUT("/cart/items/:itemId", repos.CartHandler.UpdateCartItem)
+		protected.DELETE("/cart/items/:itemId", repos.CartHandler.RemoveFromCart)
+		protected.DELETE("/cart", repos.CartHandler.ClearCart)
+
+		// Order routes
+		protected.POST("/orders", repos.OrderHandler.CreateOrder)
+		protected.GET("/orders", repos.OrderHandler.GetUserOrders)
+		protected.GET("/orders/:id/payment", repos.PaymentHandler.GetPaymentByOrder)
+		protected.GET("/orders/:id/refunds", repos.PaymentHandler.GetOrderRefunds)
+		protected.GET("/orders/:id", repos.OrderHandler.GetOrder)
+		protected.PUT("/orders/:id/cancel", repos.OrderHandler.CancelOrder)
+
+		// Order message threads with support
+		protected.GET("/orders/messages/unread", repos.OrderMessageHandler.GetUnread)
+		protected.GET("/orders/:id/messages", repos.OrderMessageHandler.GetMessages)
+		protected.POST("/orders/:id/messages", repos.OrderMessageHandler.PostMessage)
+
+		// Payment routes
+		protected.POST("/payments", repos.PaymentHandler.CreatePayment)
+		protected.POST("/payments/:id/verify", repos.PaymentHandler.VerifyPayment)
+		protected.GET("/payments/:id/status", repos.PaymentHandler.GetPaymentStatus)
+
+		// Saved payment methods
+		protected.GET("/payment-methods", repos.PaymentMethodHandler.GetPaymentMethods)
+		protected.POST("/payment-methods", repos.PaymentMethodHandler.AddPaymentMethod)
+		protected.PUT("/payment-methods/:id/default", repos.PaymentMethodHandler.SetDefault)
+		protected.DELETE("/payment-methods/:id", repos.PaymentMethodHandler.DeletePaymentMethod)
+
+		// Return routes
+		protected.POST("/returns", repos.ReturnHandler.CreateReturn)
+		protected.GET("/returns", repos.ReturnHandler.GetUserReturns)
+		protected.GET("/returns/:id", repos.ReturnHandler.GetReturn)
+		protected.GET("/returns/:id/label", repos.ReturnHandler.GetReturnLabel)
+		protected.POST("/returns/:id/ship", repos.ReturnHandler.ShipReturn)
+
+		// Notification center
+		protected.GET("/notifications", repos.NotificationHandler.GetNotifications)
+		protected.PUT("/notifications/:id/read", repos.NotificationHandler.MarkAsRead)
+
+		// Back-in-stock alerts
+		protected.POST("/products/:id/notify-me", repos.BackInStockHandler.NotifyMe)
+
+		// Gift cards
+		protected.GET("/gift-cards/:code/balance", repos.GiftCardHandler.CheckBalance)
+	}
+
+	// Fulfillment routes (admins and warehouse staff)
+	{
+		// Orders to pick and ship; warehouse staff may only set fulfillment statuses
+		fulfillment.GET("/orders", repos.OrderHandler.GetAllOrders)
+		fulfillment.GET("/orders/picklist", repos.OrderHandler.GetPickList)
+		fulfillment.GET("/orders/:id", repos.OrderHandler.GetAdminOrder)
+		fulfillment.GET("/orders/:id/packing-slip", repos.OrderHandler.GetPackingSlip)
+		fulfillment.PUT("/orders/:id/status", repos.OrderHandler.UpdateOrderStatus)
+		fulfillment.PUT("/orders/:id/items/:itemId/fulfillment", repos.OrderHandler.UpdateItemFulfillment)
+		fulfillment.GET("/carrier-events", repos.CarrierHandler.GetEvents)
+
+		// Stock levels and adjustments
+		fulfillment.GET("/products/barcode/:code", repos.ProductHandler.GetProductByBarcode)
+		fulfillment.GET("/products/:id/stock", repos.WarehouseHandler.GetProductStock)
+		fulfillment.GET("/warehouses", repos.WarehouseHandler.GetWarehouses)
+		fulfillment.GET("/warehouses/:id", repos.WarehouseHandler.GetWarehouse)
+		fulfillment.GET("/warehouses/:id/stock", repos.WarehouseHandler.GetWarehouseStock)
+		fulfillment.PUT("/warehouses/:id/stock", repos.WarehouseHandler.SetStock)
+		fulfillment.POST("/stock-transfers", repos.WarehouseHandler.TransferStock)
+		fulfillment.GET("/stock-transfers", repos.WarehouseHandler.GetTransfers)
+		fulfillment.POST("/inventory/scan", repos.WarehouseHandler.ScanStock)
+	}
+
+	// Admin routes (require admin role)
+	{
+		// Product management
+		admin.POST("/products", repos.ProductHandler.CreateProduct)
+		admin.GET("/products", repos.ProductHandler.GetAdminProducts)
+		admin.GET("/products/export", longRunning, repos.ProductHandler.ExportProducts)
+		admin.PUT("/products/:id", repos.ProductHandler.UpdateProduct)
+		admin.DELETE("/products/:id", repos.ProductHandler.DeleteProduct)
+		admin.POST("/products/:id/duplicate", repos.ProductHandler.DuplicateProduct)
+		admin.PUT("/products/:id/schedule", repos.ProductHandler.SetProductSchedule)
+		admin.GET("/products/top", repos.ProductHandler.GetTopProducts)
+		admin.POST("/products/import", longRunning, uploadLimit, repos.ProductImportHandler.ImportProducts)
+		admin.GET("/products/import/:jobId", repos.ProductImportHandler.GetImportJob)
+		admin.POST("/products/:id/variants", repos.ProductHandler.CreateProductVariant)
+		admin.PUT("/products/:id/variants/:variantId", repos.ProductHandler.UpdateProductVariant)
+		admin.DELETE("/products/:id/variants/:variantId", repos.ProductHandler.DeleteProductVariant)
+		admin.POST("/products/search/reindex", longRunning, repos.SearchHandler.Reindex)
+		admin.POST("/products/:id/prices", repos.PricingHandler.SchedulePrice)
+		admin.GET("/products/:id/prices", repos.PricingHandler.GetSchedules)
+		admin.DELETE("/products/:id/prices/:priceId", repos.PricingHandler.DeleteSchedule)
+		admin.POST("/products/:id/related", repos.RelatedProductHandler.AddRelated)
+		admin.GET("/products/:id/related", repos.RelatedProductHandler.GetRelated)
+		admin.PUT("/products/:id/related/:relatedId", repos.RelatedProductHandler.UpdateRelated)
+		admin.DELETE("/products/:id/related/:relatedId", repos.RelatedProductHandler.RemoveRelated)
+		admin.GET("/products/:id/bundle", repos.BundleHandler.GetBundle)
+		admin.PUT("/products/:id/bundle", repos.BundleHandler.SetBundle)
+
+		// Automatic promotions
+		admin.POST("/promotions", repos.PromotionHandler.CreatePromotion)
+		admin.GET("/promotions", repos.PromotionHandler.GetPromotions)
+		admin.GET("/promotions/:id", repos.PromotionHandler.GetPromotion)
+		admin.PUT("/promotions/:id", repos.PromotionHandler.UpdatePromotion)
+		admin.DELETE("/promotions/:id", repos.PromotionHandler.DeletePromotion)
+
+		// Warehouses
+		admin.POST("/warehouses", repos.WarehouseHandler.CreateWarehouse)
+		admin.PUT("/warehouses/:id", repos.WarehouseHandler.UpdateWarehouse)
+
+		// ERP stock sync
+		admin.GET("/erp/stock-messages", repos.ERPHandler.GetStockMessages)
+		admin.POST("/erp/stock-messages/:id/replay", repos.ERPHandler.ReplayStockMessage)
+		admin.POST("/erp/events/replay", repos.ERPHandler.ReplayEvents)
+
+		// Serviceable areas
+		admin.POST("/shipping/areas", repos.ShippingHandler.CreateArea)
+		admin.GET("/shipping/areas", repos.ShippingHandler.GetAreas)
+		admin.PUT("/shipping/areas/:id", repos.ShippingHandler.UpdateArea)
+		admin.DELETE("/shipping/areas/:id", repos.ShippingHandler.DeleteArea)
+
+		// Storefronts (managed from the default store)
+		admin.GET("/stores", repos.StoreHandler.GetStores)
+		admin.POST("/stores", repos.StoreHandler.CreateStore)
+		admin.PUT("/stores/:id", repos.StoreHandler.UpdateStore)
+
+		// Order management
+		admin.GET("/orders/recent", repos.OrderHandler.GetRecentOrders)
+		admin.GET("/orders/export", longRunning, repos.OrderHandler.ExportOrders)
+		admin.POST("/orders/import", longRunning, uploadLimit, repos.OrderImportHandler.ImportOrders)
+		admin.GET("/orders/review", repos.OrderHandler.GetReviewQueue)
+		admin.GET("/orders/holds", repos.OrderHandler.GetHoldQueue)
+		admin.PUT("/orders/bulk-status", repos.OrderHandler.BulkUpdateOrderStatus)
+		admin.POST("/orders/:id/review", repos.OrderHandler.ReviewOrder)
+		admin.POST("/orders/:id/hold", repos.OrderHandler.PlaceHold)
+		admin.POST("/orders/:id/release", repos.OrderHandler.ReleaseHold)
+		admin.GET("/orders/:id/holds", repos.OrderHandler.GetOrderHolds)
+		admin.POST("/orders/:id/cod/otp", repos.CODHandler.ResendOTP)
+		admin.POST("/orders/:id/cod/confirm", repos.CODHandler.ConfirmDelivery)
+		admin.GET("/orders/messages/unread", repos.OrderMessageHandler.GetSupportQueue)
+		admin.GET("/orders/:id/messages", repos.OrderMessageHandler.GetAdminMessages)
+		admin.POST("/orders/:id/messages", repos.OrderMessageHandler.ReplyToOrder)
+		admin.GET("/orders/:id/notes", repos.AdminNoteHandler.GetOrderNotes)
+		admin.POST("/orders/:id/notes", repos.AdminNoteHandler.AddOrderNote)
+		admin.DELETE("/orders/:id/notes/:noteId", repos.AdminNoteHandler.DeleteOrderNote)
+		admin.POST("/orders/:id/tags", repos.AdminNoteHandler.AddOrderTags)
+		admin.DELETE("/orders/:id/tags/:tag", repos.AdminNoteHandler.RemoveOrderTag)
+		admin.GET("/analytics", repos.OrderHandler.GetAnalytics)
+		admin.GET("/analytics/customers", repos.OrderHandler.GetCustomerAnalytics)
+		admin.GET("/analytics/margin", repos.OrderHandler.GetMarginAnalytics)
+		admin.GET("/analytics/promotions", repos.OrderHandler.GetPromotionAnalytics)
+		admin.GET("/analytics/abandoned-carts", repos.AbandonedCartHandler.GetStats)
+
+		// Feature flags
+		admin.GET("/feature-flags", repos.FeatureFlagHandler.GetFlags)
+		admin.POST("/feature-flags", repos.FeatureFlagHandler.CreateFlag)
+		admin.GET("/feature-flags/:key", repos.FeatureFlagHandler.GetFlag)
+		admin.PUT("/feature-flags/:key", repos.FeatureFlagHandler.UpdateFlag)
+		admin.DELETE("/feature-flags/:key", repos.FeatureFlagHandler.DeleteFlag)
+
+		// Failing requests captured on this instance
+		admin.GET("/debug/requests", repos.DebugHandler.GetCapturedRequests)
+
+		// Runtime settings
+		admin.GET("/settings", repos.SettingsHandler.GetSettings)
+		admin.GET("/settings/:key", repos.SettingsHandler.GetSetting)
+		admin.PUT("/settings/:key", repos.SettingsHandler.UpdateSetting)
+		admin.DELETE("/settings/:key", repos.SettingsHandler.ResetSetting)
+
+		// Customer segments
+		admin.POST("/segments", repos.SegmentHandler.CreateSegment)
+		admin.GET("/segments", repos.SegmentHandler.GetSegments)
+		admin.GET("/segments/:id", repos.SegmentHandler.GetSegment)
+		admin.PUT("/segments/:id", repos.SegmentHandler.UpdateSegment)
+		admin.DELETE("/segments/:id", repos.SegmentHandler.DeleteSegment)
+		admin.POST("/segments/:id/refresh", repos.SegmentHandler.RefreshSegment)
+		admin.GET("/segments/:id/customers", repos.SegmentHandler.GetSegmentCustomers)
+		admin.GET("/segments/:id/customers/export", longRunning, repos.SegmentHandler.ExportSegmentCustomers)
+
+		// User management
+		admin.GET("/users", repos.AuthHandler.GetAllUsers)
+		admin.GET("/users/export", longRunning, repos.AuthHandler.ExportUsers)
+		admin.PUT("/users/roles", repos.AuthHandler.BulkUpdateUserRoles)
+		admin.PUT("/users/:id/role", repos.AuthHandler.UpdateUserRole)
+		admin.PUT("/users/:id/status", repos.AuthHandler.UpdateUserStatus)
+		admin.DELETE("/users/:id", repos.AuthHandler.DeleteUser)
+		admin.POST("/users/:id/impersonate", repos.AuthHandler.ImpersonateUser)
+		admin.POST("/users/:id/revoke-sessions", repos.AuthHandler.RevokeUserSessions)
+		admin.GET("/users/:id/notes", repos.AdminNoteHandler.GetUserNotes)
+		admin.POST("/users/:id/notes", repos.AdminNoteHandler.AddUserNote)
+		admin.DELETE("/users/:id/notes/:noteId", repos.AdminNoteHandler.DeleteUserNote)
+		admin.POST("/users/:id/tags", repos.AdminNoteHandler.AddUserTags)
+		admin.DELETE("/users/:id/tags/:tag", repos.AdminNoteHandler.RemoveUserTag)
+
+		// Payment management
+		admin.GET("/payments", repos.PaymentHandler.GetAllPayments)
+		admin.GET("/payments/reconciliation", repos.PaymentHandler.GetReconciliation)
+		admin.POST("/payments/:id/capture", repos.PaymentHandler.CapturePayment)
+		admin.POST("/payments/:id/void", repos.PaymentHandler.VoidPayment)
+		admin.GET("/cod/remittances", repos.CODHandler.GetRemittances)
+		admin.POST("/cod/remittances/remit", repos.CODHandler.MarkRemitted)
+		admin.GET("/accounting/export", longRunning, repos.AccountingHandler.ExportJournal)
+
+		// Gift cards
+		admin.POST("/gift-cards", repos.GiftCardHandler.IssueGiftCard)
+		admin.GET("/gift-cards", repos.GiftCardHandler.GetGiftCards)
+		admin.GET("/gift-cards/:id", repos.GiftCardHandler.GetGiftCard)
+
+		// Return management
+		admin.GET("/returns", repos.ReturnHandler.GetAllReturns)
+		admin.POST("/returns/:returnId/process", repos.ReturnHandler.ProcessReturn)
+		admin.POST("/returns/:returnId/receive", repos.ReturnHandler.MarkReturnReceived)
+
+		// Stock reservation maintenance
+		admin.POST("/stock-reservations/cleanup", repos.ReservationHandler.CleanupExpired)
+		admin.GET("/stock-reservations/cleanup", repos.ReservationHandler.GetCleanupStats)
+	}
+}
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"ecommerce-backend/internal/handlers"
+	"ecommerce-backend/internal/models"
+)
+
+// V2 evolves response shapes: lists page by opaque cursor instead of
+// page/total, and money is an {amount, amount_minor, currency} object.
+// Endpoints not listed here are only served by v1 for now. It is served
+// while the api_v2 feature flag is on.
+func V2(repos *handlers.Repositories) Version {
+	return Version{
+		Name: "v2",
+		Flag: models.FlagAPIV2,
+		Register: func(g Groups) {
+			registerV2(g, repos)
+		},
+	}
+}
+
+func registerV2(g Groups, repos *handlers.Repositories) {
+	api, protected := g.Public, g.Protected
+
+	// Public routes
+	{
+		api.GET("/products", repos.ProductV2Handler.GetProducts)
+		api.GET("/products/:id", repos.ProductV2Handler.GetProduct)
+	}
+
+	// Protected routes (require authentication)
+	{
+		protected.GET("/orders", repos.OrderV2Handler.GetUserOrders)
+		protected.GET("/orders/:id", repos.OrderV2Handler.GetOrder)
+	}
+}
@@ -0,0 +1,31 @@
+// Package search keeps the product catalog in an external full-text search
+// engine and resolves storefront searches through it.
+package search
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MaxMatches caps the products one search resolves to. The database filters,
+// sorts, pages and facets within them.
+const MaxMatches = 1000
+
+// Query is a storefront search. StoreID limits it to one store's products,
+// nil searches every store.
+type Query struct {
+	Text    string
+	StoreID *uuid.UUID
+}
+
+// Engine is a full-text search backend for products. Matching is typo
+// tolerant, so "hedphones" finds headphones.
+type Engine interface {
+	Name() string
+	Upsert(ctx context.Context, documents []models.ProductDocument) error
+	Delete(ctx context.Context, ids []uuid.UUID) error
+	Search(ctx context.Context, query Query) ([]uuid.UUID, error)
+}
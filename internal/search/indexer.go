@@ -0,0 +1,75 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// reindexBatch is how many products a reindex sends to the engine at a time
+const reindexBatch = 500
+
+// Indexer keeps the engine's index in step with the catalog from product
+// events. Each event re-reads the product, so events applied late or twice
+// still leave the latest state indexed.
+type Indexer struct {
+	engine      Engine
+	productRepo repository.ProductRepository
+}
+
+func NewIndexer(engine Engine, productRepo repository.ProductRepository) *Indexer {
+	return &Indexer{engine: engine, productRepo: productRepo}
+}
+
+// Register subscribes the indexer to product changes
+func (i *Indexer) Register(bus *events.Bus) {
+	bus.Subscribe(events.ProductCreated, i.handleProductChanged)
+	bus.Subscribe(events.ProductUpdated, i.handleProductChanged)
+	bus.Subscribe(events.ProductDeleted, i.handleProductChanged)
+}
+
+func (i *Indexer) handleProductChanged(ctx context.Context, event models.OutboxEvent) error {
+	var payload events.ProductChangedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+	}
+
+	documents, err := i.productRepo.GetSearchDocuments(ctx, []uuid.UUID{payload.ProductID})
+	if err != nil {
+		return err
+	}
+
+	if len(documents) == 0 {
+		return i.engine.Delete(ctx, []uuid.UUID{payload.ProductID})
+	}
+	return i.engine.Upsert(ctx, documents)
+}
+
+// Reindex sends every product in the context's store, or every store, to the
+// engine and returns how many it sent. Products deleted while the engine was
+// unreachable stay indexed until they are matched; searches skip them.
+func (i *Indexer) Reindex(ctx context.Context) (int, error) {
+	indexed := 0
+	after := uuid.Nil
+	for {
+		documents, err := i.productRepo.GetSearchDocumentPage(ctx, after, reindexBatch)
+		if err != nil {
+			return indexed, err
+		}
+		if len(documents) == 0 {
+			return indexed, nil
+		}
+
+		if err := i.engine.Upsert(ctx, documents); err != nil {
+			return indexed, err
+		}
+		indexed += len(documents)
+		after = documents[len(documents)-1].ID
+	}
+}
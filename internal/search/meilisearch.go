@@ -0,0 +1,182 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"ecommerce-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type meilisearch struct {
+	baseURL string
+	apiKey  string
+	index   string
+	client  *http.Client
+
+	// The index settings are applied once, before the first request that
+	// needs them, and again after a failure
+	mu    sync.Mutex
+	ready bool
+}
+
+// NewMeilisearch returns an engine backed by the Meilisearch index at
+// baseURL. The index is created and configured on first use.
+func NewMeilisearch(baseURL, apiKey, index string) Engine {
+	return &meilisearch{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		index:   index,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (m *meilisearch) Name() string {
+	return "meilisearch"
+}
+
+// indexSettings ranks name matches above SKUs, categories and descriptions
+// and lets searches filter by store
+var indexSettings = map[string]interface{}{
+	"searchableAttributes": []string{"name", "sku", "variant_skus", "category", "description"},
+	"filterableAttributes": []string{"store_id"},
+	"typoTolerance": map[string]interface{}{
+		"enabled":             true,
+		"disableOnAttributes": []string{"sku", "variant_skus"},
+	},
+	"pagination": map[string]interface{}{"maxTotalHits": MaxMatches},
+}
+
+// ensureIndex applies the index settings, which creates the index when it
+// does not exist yet
+func (m *meilisearch) ensureIndex(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ready {
+		return nil
+	}
+
+	if err := m.do(ctx, http.MethodPatch, "/indexes/"+m.index+"/settings", indexSettings, nil); err != nil {
+		return err
+	}
+
+	m.ready = true
+	return nil
+}
+
+// Upsert queues the documents for indexing; Meilisearch applies them within
+// moments
+func (m *meilisearch) Upsert(ctx context.Context, documents []models.ProductDocument) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	if err := m.ensureIndex(ctx); err != nil {
+		return err
+	}
+
+	return m.do(ctx, http.MethodPut, "/indexes/"+m.index+"/documents?primaryKey=id", documents, nil)
+}
+
+func (m *meilisearch) Delete(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := m.ensureIndex(ctx); err != nil {
+		return err
+	}
+
+	return m.do(ctx, http.MethodPost, "/indexes/"+m.index+"/documents/delete-batch", ids, nil)
+}
+
+func (m *meilisearch) Search(ctx context.Context, query Query) ([]uuid.UUID, error) {
+	if err := m.ensureIndex(ctx); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"q":                    query.Text,
+		"limit":                MaxMatches,
+		"attributesToRetrieve": []string{"id"},
+	}
+	if query.StoreID != nil {
+		body["filter"] = fmt.Sprintf("store_id = '%s'", query.StoreID)
+	}
+
+	var result struct {
+		Hits []struct {
+			ID uuid.UUID `json:"id"`
+		} `json:"hits"`
+	}
+	if err := m.do(ctx, http.MethodPost, "/indexes/"+m.index+"/search", body, &result); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+	}
+
+	return ids, nil
+}
+
+// meilisearchError is an error response from the Meilisearch API
+type meilisearchError struct {
+	Status  int
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *meilisearchError) Error() string {
+	return fmt.Sprintf("meilisearch: %s (%d %s)", e.Message, e.Status, e.Code)
+}
+
+func (m *meilisearch) do(ctx context.Context, method, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, m.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := &meilisearchError{Status: resp.StatusCode}
+		if json.Unmarshal(data, apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(data))
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
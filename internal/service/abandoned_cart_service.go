@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+)
+
+type AbandonedCartService interface {
+	DetectAbandoned(ctx context.Context) (*models.AbandonedCartDetectionResult, error)
+	Start(ctx context.Context, interval time.Duration)
+	Register(bus *events.Bus)
+	GetStats(ctx context.Context, rangeDays int) (*models.AbandonedCartStats, error)
+}
+
+type abandonedCartService struct {
+	abandonedRepo repository.AbandonedCartRepository
+	txManager     database.TxManager
+	publisher     events.Publisher
+	idleAfter     time.Duration
+}
+
+func NewAbandonedCartService(
+	abandonedRepo repository.AbandonedCartRepository,
+	txManager database.TxManager,
+	publisher events.Publisher,
+	idleAfter time.Duration,
+) AbandonedCartService {
+	return &abandonedCartService{
+		abandonedRepo: abandonedRepo,
+		txManager:     txManager,
+		publisher:     publisher,
+		idleAfter:     idleAfter,
+	}
+}
+
+// DetectAbandoned records newly abandoned carts and queues a reminder for each
+func (s *abandonedCartService) DetectAbandoned(ctx context.Context) (*models.AbandonedCartDetectionResult, error) {
+	var detected int
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		carts, err := s.abandonedRepo.RecordAbandoned(ctx, s.idleAfter)
+		if err != nil {
+			return err
+		}
+
+		for _, cart := range carts {
+			err := s.publisher.Publish(ctx, events.CartAbandoned, events.AggregateCart, cart.CartID, events.CartAbandonedPayload{
+				AbandonedCartID: cart.ID,
+				CartID:          cart.CartID,
+				UserID:          cart.UserID,
+				ItemCount:       cart.ItemCount,
+				CartValue:       cart.CartValue,
+				LastActivityAt:  cart.LastActivityAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := s.abandonedRepo.MarkNudged(ctx, cart.ID); err != nil {
+				return err
+			}
+		}
+
+		detected = len(carts)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect abandoned carts: %w", err)
+	}
+
+	return &models.AbandonedCartDetectionResult{
+		Detected: detected,
+		RanAt:    time.Now().UTC(),
+	}, nil
+}
+
+// Start runs detection on a fixed interval until ctx is cancelled
+func (s *abandonedCartService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 || s.idleAfter <= 0 {
+		log.Println("⚠️ Abandoned cart detection worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := s.DetectAbandoned(ctx)
+				if err != nil {
+					log.Printf("⚠️ Abandoned cart detection failed: %v", err)
+					continue
+				}
+				if result.Detected > 0 {
+					log.Printf("🛒 Detected %d abandoned carts", result.Detected)
+				}
+			}
+		}
+	}()
+
+	log.Printf("🛒 Abandoned cart detection worker running every %s (idle after %s)", interval, s.idleAfter)
+}
+
+// Register marks a user's open abandonment as recovered when they order
+func (s *abandonedCartService) Register(bus *events.Bus) {
+	bus.Subscribe(events.OrderCreated, func(ctx context.Context, event models.OutboxEvent) error {
+		var payload events.OrderCreatedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+		}
+
+		return s.abandonedRepo.MarkRecoveredByUser(ctx, payload.UserID, payload.OrderID)
+	})
+}
+
+func (s *abandonedCartService) GetStats(ctx context.Context, rangeDays int) (*models.AbandonedCartStats, error) {
+	return s.abandonedRepo.GetStats(ctx, rangeDays)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// exportPageSize is how many orders, returns or notifications are read per
+// query while building a data export
+const exportPageSize = 100
+
+type AccountService interface {
+	ExportData(ctx context.Context, userID uuid.UUID, reauth models.ReauthRequest) (*models.AccountDataExport, error)
+}
+
+type accountService struct {
+	authService      AuthService
+	orderRepo        repository.OrderRepository
+	returnRepo       repository.ReturnRepository
+	cartRepo         repository.CartRepository
+	notificationRepo repository.NotificationRepository
+	backInStockRepo  repository.BackInStockRepository
+	giftCardRepo     repository.GiftCardRepository
+}
+
+func NewAccountService(
+	authService AuthService,
+	orderRepo repository.OrderRepository,
+	returnRepo repository.ReturnRepository,
+	cartRepo repository.CartRepository,
+	notificationRepo repository.NotificationRepository,
+	backInStockRepo repository.BackInStockRepository,
+	giftCardRepo repository.GiftCardRepository,
+) AccountService {
+	return &accountService{
+		authService:      authService,
+		orderRepo:        orderRepo,
+		returnRepo:       returnRepo,
+		cartRepo:         cartRepo,
+		notificationRepo: notificationRepo,
+		backInStockRepo:  backInStockRepo,
+		giftCardRepo:     giftCardRepo,
+	}
+}
+
+// ExportData collects the caller's personal data after re-authenticating them
+func (s *accountService) ExportData(ctx context.Context, userID uuid.UUID, reauth models.ReauthRequest) (*models.AccountDataExport, error) {
+	if err := s.authService.Reauthenticate(ctx, userID, reauth); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.authService.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.AccountDataExport{
+		ExportedAt:    time.Now().UTC(),
+		Profile:       profile,
+		Orders:        []models.Order{},
+		Returns:       []models.Return{},
+		Notifications: []models.Notification{},
+	}
+
+	for page := 1; ; page++ {
+		orders, total, err := s.orderRepo.GetByUserID(ctx, userID, page, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		// The list query leaves out items; load each order in full
+		for _, order := range orders {
+			full, err := s.orderRepo.GetByID(ctx, order.ID)
+			if err != nil {
+				return nil, err
+			}
+			if full != nil {
+				export.Orders = append(export.Orders, *full)
+			}
+		}
+		if page*exportPageSize >= total {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		returns, total, err := s.returnRepo.GetByUserID(ctx, userID, page, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		export.Returns = append(export.Returns, returns...)
+		if page*exportPageSize >= total {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		notifications, total, _, err := s.notificationRepo.GetByUserID(ctx, userID, page, exportPageSize, false)
+		if err != nil {
+			return nil, err
+		}
+		export.Notifications = append(export.Notifications, notifications...)
+		if page*exportPageSize >= total {
+			break
+		}
+	}
+
+	if export.Cart, err = s.cartRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if export.BackInStock, err = s.backInStockRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if export.GiftCards, err = s.giftCardRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+)
+
+type AccountingService interface {
+	GetJournal(ctx context.Context, filter models.AccountingFilter) ([]models.JournalLine, error)
+}
+
+// Orders do not carry tax separately, so the tax in what customers paid is
+// worked out at taxRateBasisPoints, as if prices include it. Gateways do not
+// report their fees either; they are estimated per card payment.
+type accountingService struct {
+	accountingRepo     repository.AccountingRepository
+	taxRateBasisPoints int64
+	feeBasisPoints     int64
+	feeFixed           money.Money
+}
+
+func NewAccountingService(
+	accountingRepo repository.AccountingRepository,
+	taxRateBasisPoints int64,
+	feeBasisPoints int64,
+	feeFixed money.Money,
+) AccountingService {
+	return &accountingService{
+		accountingRepo:     accountingRepo,
+		taxRateBasisPoints: taxRateBasisPoints,
+		feeBasisPoints:     feeBasisPoints,
+		feeFixed:           feeFixed,
+	}
+}
+
+// GetJournal turns each period's money movements into up to four balanced
+// journal entries: sales, refunds, gateway fees and COD remittances. Each is
+// dated at the start of its period, or of the range when that is later.
+func (s *accountingService) GetJournal(ctx context.Context, filter models.AccountingFilter) ([]models.JournalLine, error) {
+	switch filter.Period {
+	case "":
+		filter.Period = models.MarginPeriodMonth
+	case models.MarginPeriodDay, models.MarginPeriodWeek, models.MarginPeriodMonth:
+	default:
+		return nil, apperrors.Validationf("invalid period %q; use day, week or month", filter.Period)
+	}
+	if !filter.To.After(filter.From) {
+		return nil, apperrors.Validation("from must not be after to")
+	}
+
+	totals, err := s.accountingRepo.GetPeriodTotals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []models.JournalLine{}
+	for _, period := range totals {
+		date := period.Period
+		if date.Before(filter.From) {
+			date = filter.From
+		}
+
+		lines = append(lines, s.salesEntry(date, period)...)
+		lines = append(lines, s.refundsEntry(date, period)...)
+		lines = append(lines, s.feesEntry(date, period)...)
+		lines = append(lines, codRemittanceEntry(date, period)...)
+	}
+
+	return lines, nil
+}
+
+// salesEntry debits what each tender brought in and credits sales and the
+// tax in them
+func (s *accountingService) salesEntry(date time.Time, period models.AccountingTotals) []models.JournalLine {
+	gross := period.CardSales.Add(period.CODCollected).Add(period.GiftCardTender)
+	if !gross.IsPositive() {
+		return nil
+	}
+	tax := s.taxIn(gross)
+
+	entry := newJournalEntry("SALES", date)
+	entry.debit(models.AccountGatewayClearing, period.CardSales, "Card payments captured")
+	entry.debit(models.AccountCODReceivable, period.CODCollected, "Cash collected on delivery")
+	entry.debit(models.AccountGiftCardLiability, period.GiftCardTender, "Gift cards redeemed")
+	entry.credit(models.AccountSales, gross.Sub(tax), "Sales")
+	entry.credit(models.AccountSalesTax, tax, "Tax collected")
+	return entry.lines
+}
+
+// refundsEntry reverses sales and their tax for what was refunded, out of
+// the gateway for cards and out of cash otherwise
+func (s *accountingService) refundsEntry(date time.Time, period models.AccountingTotals) []models.JournalLine {
+	gross := period.CardRefunds.Add(period.OtherRefunds)
+	if !gross.IsPositive() {
+		return nil
+	}
+	tax := s.taxIn(gross)
+
+	entry := newJournalEntry("REFUNDS", date)
+	entry.debit(models.AccountSalesReturns, gross.Sub(tax), "Refunds")
+	entry.debit(models.AccountSalesTax, tax, "Tax refunded")
+	entry.credit(models.AccountGatewayClearing, period.CardRefunds, "Card refunds")
+	entry.credit(models.AccountCash, period.OtherRefunds, "Cash refunds")
+	return entry.lines
+}
+
+// feesEntry books the estimated gateway fees on the period's card payments
+func (s *accountingService) feesEntry(date time.Time, period models.AccountingTotals) []models.JournalLine {
+	fees := period.CardSales.MulRat(s.feeBasisPoints, 10000).Add(s.feeFixed.Mul(period.CardPayments))
+	if !fees.IsPositive() {
+		return nil
+	}
+
+	entry := newJournalEntry("FEES", date)
+	entry.debit(models.AccountGatewayFees, fees, fmt.Sprintf("Gateway fees on %d card payments (estimated)", period.CardPayments))
+	entry.credit(models.AccountGatewayClearing, fees, "Gateway fees")
+	return entry.lines
+}
+
+// codRemittanceEntry moves the COD cash couriers handed over out of
+// receivables
+func codRemittanceEntry(date time.Time, period models.AccountingTotals) []models.JournalLine {
+	if !period.CODRemitted.IsPositive() {
+		return nil
+	}
+
+	entry := newJournalEntry("COD", date)
+	entry.debit(models.AccountCash, period.CODRemitted, "COD remitted by couriers")
+	entry.credit(models.AccountCODReceivable, period.CODRemitted, "COD remitted by couriers")
+	return entry.lines
+}
+
+// taxIn is the tax included in a tax-inclusive amount
+func (s *accountingService) taxIn(gross money.Money) money.Money {
+	return gross.MulRat(s.taxRateBasisPoints, 10000+s.taxRateBasisPoints)
+}
+
+// journalEntry collects the lines of one entry, skipping zero amounts
+type journalEntry struct {
+	no    string
+	date  time.Time
+	lines []models.JournalLine
+}
+
+func newJournalEntry(kind string, date time.Time) *journalEntry {
+	return &journalEntry{no: kind + "-" + date.Format("20060102"), date: date}
+}
+
+func (e *journalEntry) debit(account string, amount money.Money, description string) {
+	if amount.IsPositive() {
+		e.lines = append(e.lines, models.JournalLine{JournalNo: e.no, Date: e.date, Account: account, Debit: amount, Description: description})
+	}
+}
+
+func (e *journalEntry) credit(account string, amount money.Money, description string) {
+	if amount.IsPositive() {
+		e.lines = append(e.lines, models.JournalLine{JournalNo: e.no, Date: e.date, Account: account, Credit: amount, Description: description})
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// AdminNoteService keeps staff's internal notes and tags on orders and
+// customers. Nothing here is exposed through customer routes.
+type AdminNoteService interface {
+	GetOrderNotes(ctx context.Context, orderID uuid.UUID) ([]models.AdminNote, error)
+	AddOrderNote(ctx context.Context, orderID, authorID uuid.UUID, body string) (*models.AdminNote, error)
+	DeleteOrderNote(ctx context.Context, orderID, noteID uuid.UUID) error
+	AddOrderTags(ctx context.Context, orderID uuid.UUID, tags []string) ([]string, error)
+	RemoveOrderTag(ctx context.Context, orderID uuid.UUID, tag string) ([]string, error)
+	GetUserNotes(ctx context.Context, userID uuid.UUID) ([]models.AdminNote, error)
+	AddUserNote(ctx context.Context, userID, authorID uuid.UUID, body string) (*models.AdminNote, error)
+	DeleteUserNote(ctx context.Context, userID, noteID uuid.UUID) error
+	AddUserTags(ctx context.Context, userID uuid.UUID, tags []string) ([]string, error)
+	RemoveUserTag(ctx context.Context, userID uuid.UUID, tag string) ([]string, error)
+}
+
+type adminNoteService struct {
+	noteRepo  repository.AdminNoteRepository
+	orderRepo repository.OrderRepository
+	userRepo  repository.UserRepository
+}
+
+func NewAdminNoteService(
+	noteRepo repository.AdminNoteRepository,
+	orderRepo repository.OrderRepository,
+	userRepo repository.UserRepository,
+) AdminNoteService {
+	return &adminNoteService{
+		noteRepo:  noteRepo,
+		orderRepo: orderRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (s *adminNoteService) GetOrderNotes(ctx context.Context, orderID uuid.UUID) ([]models.AdminNote, error) {
+	if err := s.requireOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.noteRepo.GetByOrderID(ctx, orderID)
+}
+
+func (s *adminNoteService) AddOrderNote(ctx context.Context, orderID, authorID uuid.UUID, body string) (*models.AdminNote, error) {
+	if err := s.requireOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	note := &models.AdminNote{
+		ID:       uuid.New(),
+		OrderID:  &orderID,
+		AuthorID: &authorID,
+		Body:     body,
+	}
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+func (s *adminNoteService) DeleteOrderNote(ctx context.Context, orderID, noteID uuid.UUID) error {
+	if err := s.requireOrder(ctx, orderID); err != nil {
+		return err
+	}
+
+	return s.noteRepo.DeleteFromOrder(ctx, orderID, noteID)
+}
+
+func (s *adminNoteService) AddOrderTags(ctx context.Context, orderID uuid.UUID, tags []string) ([]string, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.orderRepo.AddTags(ctx, orderID, tags)
+}
+
+func (s *adminNoteService) RemoveOrderTag(ctx context.Context, orderID uuid.UUID, tag string) ([]string, error) {
+	return s.orderRepo.RemoveTag(ctx, orderID, strings.TrimSpace(tag))
+}
+
+func (s *adminNoteService) GetUserNotes(ctx context.Context, userID uuid.UUID) ([]models.AdminNote, error) {
+	if err := s.requireUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.noteRepo.GetByUserID(ctx, userID)
+}
+
+func (s *adminNoteService) AddUserNote(ctx context.Context, userID, authorID uuid.UUID, body string) (*models.AdminNote, error) {
+	if err := s.requireUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	note := &models.AdminNote{
+		ID:       uuid.New(),
+		UserID:   &userID,
+		AuthorID: &authorID,
+		Body:     body,
+	}
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+func (s *adminNoteService) DeleteUserNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	if err := s.requireUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.noteRepo.DeleteFromUser(ctx, userID, noteID)
+}
+
+func (s *adminNoteService) AddUserTags(ctx context.Context, userID uuid.UUID, tags []string) ([]string, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.AddTags(ctx, userID, tags)
+}
+
+func (s *adminNoteService) RemoveUserTag(ctx context.Context, userID uuid.UUID, tag string) ([]string, error) {
+	return s.userRepo.RemoveTag(ctx, userID, strings.TrimSpace(tag))
+}
+
+// requireOrder checks the order exists in the current store
+func (s *adminNoteService) requireOrder(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return apperrors.NotFound("order not found")
+	}
+	return nil
+}
+
+// requireUser checks the user exists in the current store
+func (s *adminNoteService) requireUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperrors.NotFound("user not found")
+	}
+	return nil
+}
+
+// normalizeTags trims the tags; they are otherwise kept as written, so
+// "VIP" and "vip" are different tags
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, apperrors.Validation("tags cannot be blank")
+		}
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
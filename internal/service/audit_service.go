@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, entry *models.AuditEntry) error
+}
+
+type auditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// Record appends an entry to the audit log. It joins the caller's
+// transaction, if any.
+func (s *auditService) Record(ctx context.Context, entry *models.AuditEntry) error {
+	return s.auditRepo.Create(ctx, entry)
+}
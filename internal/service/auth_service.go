@@ -2,38 +2,107 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/notifications"
+	"ecommerce-backend/internal/oauth"
 	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/internal/tokens"
+	"ecommerce-backend/pkg/database"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// reauthMaxTokenAge is how recently a customer must have signed in with a
+// provider for its identity token to re-authenticate them
+const reauthMaxTokenAge = 5 * time.Minute
+
 type AuthService interface {
 	Register(ctx context.Context, req models.RegisterRequest) (*models.User, error)
 	Login(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, error)
+	OAuthLogin(ctx context.Context, provider string, req models.OAuthLoginRequest) (*models.LoginResponse, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	GenerateToken(user *models.User) (string, error)
-	ValidateToken(tokenString string) (*models.User, error)
-	ListUsers(ctx context.Context, page, limit, rangeDays int) ([]models.User, int, error)
+	ValidateToken(ctx context.Context, tokenString string) (*models.User, error)
+	Logout(ctx context.Context, tokenString string) error
+	RevokeSessions(ctx context.Context, actorID, userID uuid.UUID) error
+	ListUsers(ctx context.Context, filter models.UserFilter, page, limit int) ([]models.User, int, error)
+	ExportUsers(ctx context.Context, filter models.UserFilter, fn func(models.User) error) error
 	UpdateUserRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, string, error)
+	BulkUpdateUserRoles(ctx context.Context, actorID uuid.UUID, req models.BulkUpdateUserRolesRequest) (int64, error)
+	SetUserActive(ctx context.Context, actorID, userID uuid.UUID, active bool) (*models.User, error)
+	DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error
+	Reauthenticate(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error
+	CloseAccount(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	ResendVerification(ctx context.Context, email string) error
+	Impersonate(ctx context.Context, actorID, userID uuid.UUID, reason string) (*models.ImpersonationResponse, error)
 }
 
 type authService struct {
-	userRepo  repository.UserRepository
-	jwtSecret string
-	jwtExpiry time.Duration
+	userRepo         repository.UserRepository
+	verificationRepo repository.VerificationRepository
+	revocationRepo   repository.RevocationRepository
+	identityRepo     repository.IdentityRepository
+	otpRepo          repository.PhoneOTPRepository
+	orderRepo        repository.OrderRepository
+	returnRepo       repository.ReturnRepository
+	txManager        database.TxManager
+	auditService     AuditService
+	mailer           notifications.Mailer
+	tokenKeys        *tokens.KeySet
+	oauthProviders   map[string]oauth.Provider
+	jwtExpiry        time.Duration
+	verificationTTL  time.Duration
+	appBaseURL       string
+	impersonationTTL time.Duration
 }
 
-func NewAuthService(userRepo repository.UserRepository, jwtSecret string, jwtExpiry time.Duration) AuthService {
+func NewAuthService(
+	userRepo repository.UserRepository,
+	verificationRepo repository.VerificationRepository,
+	revocationRepo repository.RevocationRepository,
+	identityRepo repository.IdentityRepository,
+	otpRepo repository.PhoneOTPRepository,
+	orderRepo repository.OrderRepository,
+	returnRepo repository.ReturnRepository,
+	txManager database.TxManager,
+	auditService AuditService,
+	mailer notifications.Mailer,
+	tokenKeys *tokens.KeySet,
+	oauthProviders map[string]oauth.Provider,
+	jwtExpiry time.Duration,
+	verificationTTL time.Duration,
+	appBaseURL string,
+	impersonationTTL time.Duration,
+) AuthService {
 	return &authService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
-		jwtExpiry: jwtExpiry,
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		revocationRepo:   revocationRepo,
+		identityRepo:     identityRepo,
+		otpRepo:          otpRepo,
+		orderRepo:        orderRepo,
+		returnRepo:       returnRepo,
+		txManager:        txManager,
+		auditService:     auditService,
+		mailer:           mailer,
+		tokenKeys:        tokenKeys,
+		oauthProviders:   oauthProviders,
+		jwtExpiry:        jwtExpiry,
+		verificationTTL:  verificationTTL,
+		appBaseURL:       appBaseURL,
+		impersonationTTL: impersonationTTL,
 	}
 }
 
@@ -49,7 +118,7 @@ func (s *authService) Register(ctx context.Context, req models.RegisterRequest)
 
 	if existingUser != nil {
 		log.Printf("⚠️ User already exists: %s", req.Email)
-		return nil, errors.New("user already exists")
+		return nil, apperrors.Conflict("user already exists")
 	}
 
 	log.Printf("✅ User doesn't exist, proceeding with creation: %s", req.Email)
@@ -82,6 +151,11 @@ func (s *authService) Register(ctx context.Context, req models.RegisterRequest)
 
 	log.Printf("✅ User created successfully: %v", user.ID)
 
+	// Send verification email; the user can request a new one if this fails
+	if err := s.sendVerification(ctx, user); err != nil {
+		log.Printf("⚠️ Failed to send verification email to user %s: %v", user.ID, err)
+	}
+
 	// Don't return password hash
 	user.PasswordHash = ""
 	return user, nil
@@ -95,12 +169,16 @@ func (s *authService) Login(ctx context.Context, req models.LoginRequest) (*mode
 	}
 
 	if user == nil {
-		return nil, errors.New("invalid email or password")
+		return nil, apperrors.Unauthorized("invalid email or password")
 	}
 
 	// Check password
 	if !models.CheckPasswordHash(req.Password, user.PasswordHash) {
-		return nil, errors.New("invalid email or password")
+		return nil, apperrors.Unauthorized("invalid email or password")
+	}
+
+	if !user.IsActive {
+		return nil, apperrors.Forbidden("account is deactivated")
 	}
 
 	// Generate token
@@ -118,6 +196,118 @@ func (s *authService) Login(ctx context.Context, req models.LoginRequest) (*mode
 	}, nil
 }
 
+// OAuthLogin signs a customer in with an identity token from a sign-in
+// provider, issuing the same token as a password login. The first sign-in
+// links the provider account to the local account with the same email, or
+// creates one. Linking needs the provider to have verified the email, so an
+// unverified address cannot take over someone else's account.
+func (s *authService) OAuthLogin(ctx context.Context, providerName string, req models.OAuthLoginRequest) (*models.LoginResponse, error) {
+	provider, ok := s.oauthProviders[providerName]
+	if !ok {
+		return nil, apperrors.NotFound("sign-in provider not available")
+	}
+
+	identity, err := provider.Verify(ctx, req.IDToken)
+	if errors.Is(err, oauth.ErrInvalidToken) {
+		log.Printf("⚠️ Rejected %s identity token: %v", providerName, err)
+		return nil, apperrors.Unauthorized("invalid identity token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if identity.Email == "" {
+		return nil, apperrors.Unauthorized("identity token has no email")
+	}
+	if identity.FirstName == "" && identity.LastName == "" {
+		identity.FirstName, identity.LastName = req.FirstName, req.LastName
+	}
+
+	var user *models.User
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		linked, err := s.identityRepo.GetByProviderSubject(ctx, identity.Provider, identity.Subject)
+		if err != nil {
+			return err
+		}
+		if linked != nil {
+			if user, err = s.userRepo.GetByID(ctx, linked.UserID); err != nil {
+				return err
+			}
+			return s.identityRepo.TouchLogin(ctx, linked.ID, identity.Email)
+		}
+
+		if user, err = s.linkOrCreateUser(ctx, identity); err != nil {
+			return err
+		}
+		return s.identityRepo.Create(ctx, &models.UserIdentity{
+			UserID:   user.ID,
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperrors.Unauthorized("invalid identity token")
+	}
+
+	if !user.IsActive {
+		return nil, apperrors.Forbidden("account is deactivated")
+	}
+
+	token, err := s.GenerateToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordHash = ""
+	return &models.LoginResponse{
+		User:        user,
+		AccessToken: token,
+	}, nil
+}
+
+// linkOrCreateUser finds the account a new provider identity belongs to by
+// email, or creates a passwordless one
+func (s *authService) linkOrCreateUser(ctx context.Context, identity *oauth.Identity) (*models.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil {
+		if !identity.EmailVerified {
+			return nil, apperrors.Conflict("an account with this email already exists; sign in with your password")
+		}
+		log.Printf("🔗 Linked %s sign-in to user %s", identity.Provider, user.ID)
+	} else {
+		// Passwordless: an empty hash matches no password
+		user = &models.User{
+			Email:     identity.Email,
+			FirstName: identity.FirstName,
+			LastName:  identity.LastName,
+			Role:      "customer",
+			IsActive:  true,
+		}
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		log.Printf("✅ User %s created by %s sign-in", user.ID, identity.Provider)
+	}
+
+	// The provider has confirmed the customer owns the address
+	if identity.EmailVerified && !user.EmailVerified {
+		if err := s.userRepo.MarkEmailVerified(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		user.EmailVerified, user.EmailVerifiedAt = true, &now
+	}
+
+	return user, nil
+}
+
 func (s *authService) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -125,7 +315,7 @@ func (s *authService) GetProfile(ctx context.Context, userID uuid.UUID) (*models
 	}
 
 	if user == nil {
-		return nil, errors.New("user not found")
+		return nil, apperrors.NotFound("user not found")
 	}
 
 	// Don't return password hash
@@ -135,54 +325,215 @@ func (s *authService) GetProfile(ctx context.Context, userID uuid.UUID) (*models
 
 func (s *authService) GenerateToken(user *models.User) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id": user.ID.String(),
-		"email":   user.Email,
-		"role":    user.Role,
-		"exp":     time.Now().Add(s.jwtExpiry).Unix(),
-		"iat":     time.Now().Unix(),
+		"user_id":  user.ID.String(),
+		"store_id": user.StoreID.String(),
+		"email":    user.Email,
+		"role":     user.Role,
+		"exp":      time.Now().Add(s.jwtExpiry).Unix(),
+		"iat":      time.Now().Unix(),
+		"jti":      uuid.NewString(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.tokenKeys.Sign(claims)
 }
 
-func (s *authService) ValidateToken(tokenString string) (*models.User, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.jwtSecret), nil
-	})
+func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*models.User, error) {
+	user, _, err := s.validateToken(ctx, tokenString)
+	return user, err
+}
 
+// validateToken verifies the token, checks it has not been revoked and that
+// its user still exists and is active, and returns the user it was issued to
+// along with its claims
+func (s *authService) validateToken(ctx context.Context, tokenString string) (*models.User, jwt.MapClaims, error) {
+	token, err := s.tokenKeys.Parse(tokenString)
 	if err != nil {
-		return nil, err
+		return nil, nil, apperrors.Unauthorized("invalid token")
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		userIDStr, ok := claims["user_id"].(string)
 		if !ok {
-			return nil, errors.New("invalid token claims")
+			return nil, nil, apperrors.Unauthorized("invalid token claims")
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		email, _ := claims["email"].(string)
 		role, _ := claims["role"].(string)
 
-		return &models.User{
-			ID:    userID,
-			Email: email,
-			Role:  role,
-		}, nil
+		user := &models.User{
+			ID:      userID,
+			StoreID: models.DefaultStoreID,
+			Email:   email,
+			Role:    role,
+		}
+
+		// Tokens issued before stores existed belong to the default store
+		if store, ok := claims["store_id"].(string); ok {
+			storeID, err := uuid.Parse(store)
+			if err != nil {
+				return nil, nil, apperrors.Unauthorized("invalid token claims")
+			}
+			user.StoreID = storeID
+		}
+
+		if impersonator, ok := claims["impersonator_id"].(string); ok {
+			impersonatorID, err := uuid.Parse(impersonator)
+			if err != nil {
+				return nil, nil, apperrors.Unauthorized("invalid token claims")
+			}
+			user.ImpersonatorID = &impersonatorID
+		}
+
+		issuedAt, err := claims.GetIssuedAt()
+		if err != nil || issuedAt == nil {
+			return nil, nil, apperrors.Unauthorized("invalid token claims")
+		}
+		revoked, err := s.revocationRepo.IsRevoked(ctx, tokenID(tokenString, claims), user.ID, issuedAt.Time)
+		if err != nil {
+			return nil, nil, err
+		}
+		if revoked {
+			return nil, nil, apperrors.Unauthorized("token has been revoked")
+		}
+
+		// Tokens outlive deleted and deactivated accounts, so check the
+		// account is still there and active
+		account, err := s.userRepo.GetByID(ctx, user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if account == nil || !account.IsActive {
+			return nil, nil, apperrors.Unauthorized("account is deactivated or deleted")
+		}
+
+		return user, claims, nil
+	}
+
+	return nil, nil, apperrors.Unauthorized("invalid token")
+}
+
+// tokenID names a token on the revocation list: its jti claim, or for tokens
+// issued without one a hash of the token itself
+func tokenID(tokenString string, claims jwt.MapClaims) string {
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		return jti
+	}
+	sum := sha256.Sum256([]byte(tokenString))
+	return hex.EncodeToString(sum[:])
+}
+
+// Logout revokes the token it is called with; the account's other sessions
+// stay signed in
+func (s *authService) Logout(ctx context.Context, tokenString string) error {
+	user, claims, err := s.validateToken(ctx, tokenString)
+	if err != nil {
+		return err
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return apperrors.Unauthorized("invalid token claims")
+	}
+
+	return s.revocationRepo.RevokeToken(ctx, tokenID(tokenString, claims), user.ID, expiresAt.Time)
+}
+
+// RevokeSessions signs the user out everywhere: every token issued to them
+// so far, impersonation tokens included, is rejected from now on
+func (s *authService) RevokeSessions(ctx context.Context, actorID, userID uuid.UUID) error {
+	if userID == models.DeletedUserID {
+		return apperrors.NotFound("user not found")
+	}
+
+	found, err := s.revocationRepo.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperrors.NotFound("user not found")
+	}
+
+	err = s.auditService.Record(ctx, &models.AuditEntry{
+		ActorID:       actorID,
+		SubjectUserID: &userID,
+		Action:        models.AuditSessionsRevoked,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🔒 Sessions of user %s revoked by %s", userID, actorID)
+	return nil
+}
+
+// Impersonate issues a short-lived token that acts as a customer for support.
+// The token carries the admin's ID so every request made with it can be
+// audited, and GinImpersonationGuard limits it to reading the customer's
+// cart and orders. Admin accounts cannot be impersonated.
+func (s *authService) Impersonate(ctx context.Context, actorID, userID uuid.UUID, reason string) (*models.ImpersonationResponse, error) {
+	if userID == actorID {
+		return nil, apperrors.Forbidden("cannot impersonate yourself")
+	}
+	if userID == models.DeletedUserID {
+		return nil, apperrors.NotFound("user not found")
 	}
 
-	return nil, errors.New("invalid token")
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperrors.NotFound("user not found")
+	}
+	if user.Role == "admin" {
+		return nil, apperrors.Forbidden("cannot impersonate an admin")
+	}
+	if !user.IsActive {
+		return nil, apperrors.Conflict("account is deactivated")
+	}
+
+	expiresAt := time.Now().Add(s.impersonationTTL)
+	claims := jwt.MapClaims{
+		"user_id":         user.ID.String(),
+		"store_id":        user.StoreID.String(),
+		"email":           user.Email,
+		"role":            user.Role,
+		"impersonator_id": actorID.String(),
+		"exp":             expiresAt.Unix(),
+		"iat":             time.Now().Unix(),
+		"jti":             uuid.NewString(),
+	}
+	token, err := s.tokenKeys.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auditService.Record(ctx, &models.AuditEntry{
+		ActorID:       actorID,
+		SubjectUserID: &user.ID,
+		Action:        models.AuditImpersonationStart,
+		Details:       reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🕵️ Admin %s started impersonating user %s", actorID, user.ID)
+
+	user.PasswordHash = ""
+	return &models.ImpersonationResponse{
+		User:        user,
+		AccessToken: token,
+		ExpiresAt:   expiresAt,
+	}, nil
 }
 
-func (s *authService) ListUsers(ctx context.Context, page, limit, rangeDays int) ([]models.User, int, error) {
+func (s *authService) ListUsers(ctx context.Context, filter models.UserFilter, page, limit int) ([]models.User, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -190,7 +541,7 @@ func (s *authService) ListUsers(ctx context.Context, page, limit, rangeDays int)
 		limit = 10
 	}
 
-	users, total, err := s.userRepo.GetAll(ctx, page, limit, rangeDays)
+	users, total, err := s.userRepo.GetAll(ctx, filter, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -202,13 +553,17 @@ func (s *authService) ListUsers(ctx context.Context, page, limit, rangeDays int)
 	return users, total, nil
 }
 
+func (s *authService) ExportUsers(ctx context.Context, filter models.UserFilter, fn func(models.User) error) error {
+	return s.userRepo.ExportAll(ctx, filter, fn)
+}
+
 func (s *authService) UpdateUserRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, string, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, "", err
 	}
 	if user == nil {
-		return nil, "", errors.New("user not found")
+		return nil, "", apperrors.NotFound("user not found")
 	}
 
 	if err := s.userRepo.UpdateRole(ctx, userID, role); err != nil {
@@ -230,3 +585,292 @@ func (s *authService) UpdateUserRole(ctx context.Context, userID uuid.UUID, role
 
 	return updated, token, nil
 }
+
+// BulkUpdateUserRoles sets the role of every listed user and returns how many
+// changed. Admins cannot change their own role this way.
+func (s *authService) BulkUpdateUserRoles(ctx context.Context, actorID uuid.UUID, req models.BulkUpdateUserRolesRequest) (int64, error) {
+	for _, id := range req.UserIDs {
+		if id == actorID {
+			return 0, apperrors.Forbidden("cannot change your own role")
+		}
+		if id == models.DeletedUserID {
+			return 0, apperrors.NotFound("user not found")
+		}
+	}
+
+	return s.userRepo.UpdateRoles(ctx, req.UserIDs, req.Role)
+}
+
+// SetUserActive deactivates or reactivates an account. Deactivated users
+// cannot log in; their data is kept.
+func (s *authService) SetUserActive(ctx context.Context, actorID, userID uuid.UUID, active bool) (*models.User, error) {
+	if userID == actorID && !active {
+		return nil, apperrors.Forbidden("cannot deactivate your own account")
+	}
+	if userID == models.DeletedUserID {
+		return nil, apperrors.NotFound("user not found")
+	}
+
+	if err := s.userRepo.SetActive(ctx, userID, active); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperrors.NotFound("user not found")
+	}
+
+	user.PasswordHash = ""
+	return user, nil
+}
+
+// DeleteUser permanently removes another user's account
+func (s *authService) DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	if userID == actorID {
+		return apperrors.Forbidden("cannot delete your own account")
+	}
+	if userID == models.DeletedUserID {
+		return apperrors.NotFound("user not found")
+	}
+
+	if err := s.purgeUser(ctx, userID); err != nil {
+		return err
+	}
+
+	log.Printf("🗑️ User %s deleted by %s", userID, actorID)
+	return nil
+}
+
+// Reauthenticate checks a logged-in user is still the account holder before
+// a sensitive action. Accounts created by a sign-in provider or phone code
+// have no password, so a fresh identity token from a linked provider, or a
+// code texted to the verified phone, is accepted instead.
+func (s *authService) Reauthenticate(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperrors.NotFound("user not found")
+	}
+
+	switch {
+	case req.Password != "":
+		if !models.CheckPasswordHash(req.Password, user.PasswordHash) {
+			return apperrors.Unauthorized("invalid password")
+		}
+
+	case req.IDToken != "":
+		return s.reauthenticateWithProvider(ctx, userID, req.Provider, req.IDToken)
+
+	case req.OTP != "":
+		if user.Phone == nil || !user.PhoneVerified {
+			return apperrors.Validation("no verified phone number")
+		}
+		ok, err := checkPhoneOTP(ctx, s.otpRepo, userID, models.OTPPurposeReauth, *user.Phone, req.OTP)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return apperrors.Unauthorized("invalid or expired code")
+		}
+
+	default:
+		return apperrors.Unauthorized("password, identity token or code required")
+	}
+
+	return nil
+}
+
+// reauthenticateWithProvider accepts an identity token for a provider account
+// linked to the user, if the customer signed in with the provider within
+// reauthMaxTokenAge
+func (s *authService) reauthenticateWithProvider(ctx context.Context, userID uuid.UUID, providerName, idToken string) error {
+	provider, ok := s.oauthProviders[providerName]
+	if !ok {
+		return apperrors.Validation("sign-in provider not available")
+	}
+
+	identity, err := provider.Verify(ctx, idToken)
+	if errors.Is(err, oauth.ErrInvalidToken) {
+		log.Printf("⚠️ Rejected %s identity token: %v", providerName, err)
+		return apperrors.Unauthorized("invalid identity token")
+	}
+	if err != nil {
+		return err
+	}
+	if time.Since(identity.IssuedAt) > reauthMaxTokenAge {
+		return apperrors.Unauthorized("identity token is too old, sign in with the provider again")
+	}
+
+	linked, err := s.identityRepo.GetByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return err
+	}
+	if linked == nil || linked.UserID != userID {
+		return apperrors.Unauthorized("identity token belongs to another account")
+	}
+
+	return nil
+}
+
+// CloseAccount deletes the caller's own account after re-authenticating
+// them. Tokens already issued to them are rejected once the user row is gone.
+func (s *authService) CloseAccount(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error {
+	if err := s.Reauthenticate(ctx, userID, req); err != nil {
+		return err
+	}
+
+	if err := s.purgeUser(ctx, userID); err != nil {
+		return err
+	}
+
+	log.Printf("🗑️ User %s closed their account", userID)
+	return nil
+}
+
+// purgeUser removes a user with no orders or returns in progress. Orders and
+// returns are kept for accounting but moved to the deleted-user placeholder
+// with the personal address fields cleared; carts, notifications,
+// subscriptions and tokens are removed with the user.
+func (s *authService) purgeUser(ctx context.Context, userID uuid.UUID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return apperrors.NotFound("user not found")
+		}
+
+		openOrders, err := s.orderRepo.CountOpenByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		openReturns, err := s.returnRepo.CountOpenByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if openOrders > 0 || openReturns > 0 {
+			return apperrors.Conflict("user has orders or returns in progress")
+		}
+
+		if err := s.orderRepo.AnonymizeUser(ctx, userID, models.DeletedUserID); err != nil {
+			return err
+		}
+		if err := s.returnRepo.ReassignUser(ctx, userID, models.DeletedUserID); err != nil {
+			return err
+		}
+
+		return s.userRepo.Delete(ctx, userID)
+	})
+}
+
+func (s *authService) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	record, err := s.verificationRepo.GetByTokenHash(ctx, hashVerificationToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		return nil, apperrors.Validation("invalid verification token")
+	}
+
+	if record.UsedAt != nil {
+		return nil, apperrors.Conflict("verification token already used")
+	}
+
+	if time.Now().After(record.ExpiresAt) {
+		return nil, apperrors.Validation("verification token has expired")
+	}
+
+	// Using up the token and verifying the email commit together, so a
+	// failure cannot leave the token spent on an unverified account
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.verificationRepo.MarkUsed(ctx, record.ID); err != nil {
+			return err
+		}
+
+		return s.userRepo.MarkEmailVerified(ctx, record.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProfile(ctx, record.UserID)
+}
+
+func (s *authService) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	// Don't reveal whether the email is registered
+	if user == nil {
+		return nil
+	}
+
+	// Nor whether it is already verified
+	if user.EmailVerified {
+		return nil
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+// sendVerification replaces any outstanding token with a fresh one and
+// delivers the verification link to the user
+func (s *authService) sendVerification(ctx context.Context, user *models.User) error {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return err
+	}
+
+	if err := s.verificationRepo.DeleteUnusedByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+
+	record := &models.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashVerificationToken(token),
+		ExpiresAt: time.Now().Add(s.verificationTTL),
+	}
+
+	if err := s.verificationRepo.Create(ctx, record); err != nil {
+		return err
+	}
+
+	msg, err := notifications.RenderEmail(notifications.TemplateVerifyEmail, notifications.EmailData{
+		CustomerName: user.FirstName,
+		VerifyURL:    fmt.Sprintf("%s/verify-email?token=%s", strings.TrimRight(s.appBaseURL, "/"), token),
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = user.Email
+	msg.ToName = strings.TrimSpace(user.FirstName + " " + user.LastName)
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send verification email via %s: %w", s.mailer.Name(), err)
+	}
+
+	log.Printf("📧 Verification email sent to user %s", user.ID)
+	return nil
+}
+
+func generateVerificationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
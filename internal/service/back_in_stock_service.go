@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+type BackInStockService interface {
+	Subscribe(ctx context.Context, userID, productID uuid.UUID, variantID *uuid.UUID) (*models.BackInStockSubscription, error)
+	NotifyAvailable(ctx context.Context, productID *uuid.UUID) error
+}
+
+type backInStockService struct {
+	backInStockRepo repository.BackInStockRepository
+	productRepo     repository.ProductRepository
+	variantRepo     repository.VariantRepository
+	txManager       database.TxManager
+	notificationSvc NotificationService
+}
+
+func NewBackInStockService(
+	backInStockRepo repository.BackInStockRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.VariantRepository,
+	txManager database.TxManager,
+	notificationSvc NotificationService,
+) BackInStockService {
+	return &backInStockService{
+		backInStockRepo: backInStockRepo,
+		productRepo:     productRepo,
+		variantRepo:     variantRepo,
+		txManager:       txManager,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Subscribe registers interest in a product (or one of its variants) that is
+// currently out of stock
+func (s *backInStockService) Subscribe(ctx context.Context, userID, productID uuid.UUID, variantID *uuid.UUID) (*models.BackInStockSubscription, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	if variantID != nil {
+		variant, err := s.variantRepo.GetByID(ctx, *variantID)
+		if err != nil {
+			return nil, err
+		}
+		if variant == nil || variant.ProductID != productID {
+			return nil, apperrors.NotFound("variant not found")
+		}
+	}
+
+	available, err := s.productRepo.GetAvailableStock(ctx, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if available > 0 {
+		return nil, apperrors.Conflict("product is in stock")
+	}
+
+	sub := &models.BackInStockSubscription{
+		UserID:      userID,
+		ProductID:   productID,
+		VariantID:   variantID,
+		ProductName: product.Name,
+	}
+	if err := s.backInStockRepo.Subscribe(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to save subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+// NotifyAvailable notifies and fulfils every pending subscription whose stock
+// is available again. Pass nil to check all products, e.g. after expired
+// reservations have been released.
+func (s *backInStockService) NotifyAvailable(ctx context.Context, productID *uuid.UUID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		subs, err := s.backInStockRepo.ClaimAvailable(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range subs {
+			data := map[string]interface{}{"product_id": sub.ProductID}
+			if sub.VariantID != nil {
+				data["variant_id"] = *sub.VariantID
+			}
+
+			err := s.notificationSvc.Notify(ctx, sub.UserID, models.NotificationBackInStock,
+				"Back in stock",
+				fmt.Sprintf("%s is back in stock. Grab it before it sells out again.", sub.ProductName),
+				data)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// notifyRestocked runs back-in-stock notifications for every product in items,
+// and the components of bundles, once their stock has been put back. Failures
+// are logged rather than returned because the restock itself has already been
+// committed.
+func notifyRestocked(ctx context.Context, backInStockSvc BackInStockService, items []models.OrderItem) {
+	seen := make(map[uuid.UUID]bool)
+	for _, item := range items {
+		productIDs := []uuid.UUID{item.ProductID}
+		for _, component := range item.BundleComponents {
+			productIDs = append(productIDs, component.ProductID)
+		}
+
+		for _, productID := range productIDs {
+			if seen[productID] {
+				continue
+			}
+			seen[productID] = true
+
+			if err := backInStockSvc.NotifyAvailable(ctx, &productID); err != nil {
+				log.Printf("⚠️ Back-in-stock notification failed for product %s: %v", productID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// resolveBarcode finds the product or variant a scanned barcode belongs to
+func resolveBarcode(ctx context.Context, productRepo repository.ProductRepository, variantRepo repository.VariantRepository, code string) (*models.BarcodeMatch, error) {
+	barcode, ok := utils.NormalizeBarcode(code)
+	if !ok {
+		return nil, apperrors.Validationf("%q is not a valid EAN or UPC barcode", code)
+	}
+
+	variant, err := variantRepo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	productID := uuid.Nil
+	if variant != nil {
+		productID = variant.ProductID
+	} else {
+		byBarcode, err := productRepo.GetByBarcode(ctx, barcode)
+		if err != nil {
+			return nil, err
+		}
+		if byBarcode != nil {
+			productID = byBarcode.ID
+		}
+	}
+
+	// Barcodes are unique across stores; reading the product by ID keeps the
+	// match within the request's store
+	product, err := productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, apperrors.NotFound("no product with barcode " + barcode)
+	}
+
+	return &models.BarcodeMatch{Barcode: barcode, Product: product, Variant: variant}, nil
+}
+
+// claimBarcode normalizes a barcode being set on a product or variant and
+// makes sure no other product or variant has it; owner is the ID of the one
+// being updated, uuid.Nil on create. Empty stays empty, meaning none.
+func claimBarcode(ctx context.Context, productRepo repository.ProductRepository, variantRepo repository.VariantRepository, code string, owner uuid.UUID) (string, error) {
+	if code == "" {
+		return "", nil
+	}
+
+	barcode, ok := utils.NormalizeBarcode(code)
+	if !ok {
+		return "", apperrors.Validationf("%q is not a valid EAN or UPC barcode", code)
+	}
+
+	product, err := productRepo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		return "", err
+	}
+	variant, err := variantRepo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		return "", err
+	}
+	if (product != nil && product.ID != owner) || (variant != nil && variant.ID != owner) {
+		return "", apperrors.Conflict("barcode is already assigned to another product")
+	}
+
+	return barcode, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+type BundleService interface {
+	GetBundle(ctx context.Context, productID uuid.UUID) ([]models.BundleComponent, error)
+	SetBundle(ctx context.Context, productID uuid.UUID, req models.SetBundleRequest) ([]models.BundleComponent, error)
+}
+
+type bundleService struct {
+	bundleRepo  repository.BundleRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.VariantRepository
+	txManager   database.TxManager
+	publisher   events.Publisher
+}
+
+func NewBundleService(
+	bundleRepo repository.BundleRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.VariantRepository,
+	txManager database.TxManager,
+	publisher events.Publisher,
+) BundleService {
+	return &bundleService{
+		bundleRepo:  bundleRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		txManager:   txManager,
+		publisher:   publisher,
+	}
+}
+
+func (s *bundleService) GetBundle(ctx context.Context, productID uuid.UUID) ([]models.BundleComponent, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	return s.bundleRepo.GetComponents(ctx, productID)
+}
+
+// SetBundle makes the product a bundle of the given components, replacing
+// any it had. Bundles are sold whole, so they cannot have variants, and are
+// not nested: a bundle's components are never bundles themselves.
+func (s *bundleService) SetBundle(ctx context.Context, productID uuid.UUID, req models.SetBundleRequest) ([]models.BundleComponent, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	components := make([]models.BundleComponent, 0, len(req.Components))
+	if len(req.Components) > 0 {
+		if err := s.checkBundle(ctx, productID); err != nil {
+			return nil, err
+		}
+
+		seen := make(map[[2]uuid.UUID]bool, len(req.Components))
+		for _, item := range req.Components {
+			if err := s.checkComponent(ctx, productID, item); err != nil {
+				return nil, err
+			}
+
+			key := [2]uuid.UUID{item.ProductID}
+			if item.VariantID != nil {
+				key[1] = *item.VariantID
+			}
+			if seen[key] {
+				return nil, apperrors.Validationf("component %s is listed more than once", item.ProductID)
+			}
+			seen[key] = true
+
+			components = append(components, models.BundleComponent{
+				ProductID: item.ProductID,
+				VariantID: item.VariantID,
+				Quantity:  item.Quantity,
+			})
+		}
+	}
+
+	// The bundle's stock changes with its components
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.bundleRepo.ReplaceComponents(ctx, productID, components); err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, events.ProductUpdated, events.AggregateProduct, productID, events.ProductChangedPayload{
+			ProductID: productID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.bundleRepo.GetComponents(ctx, productID)
+}
+
+// checkBundle makes sure the product can be made a bundle
+func (s *bundleService) checkBundle(ctx context.Context, productID uuid.UUID) error {
+	variants, err := s.variantRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if len(variants) > 0 {
+		return apperrors.Validation("a product with variants cannot be a bundle")
+	}
+
+	isComponent, err := s.bundleRepo.IsComponent(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if isComponent {
+		return apperrors.Validation("a component of another bundle cannot be a bundle")
+	}
+
+	return nil
+}
+
+// checkComponent makes sure the component exists, is not a bundle, and that
+// the variant, when given, belongs to it
+func (s *bundleService) checkComponent(ctx context.Context, bundleID uuid.UUID, item models.BundleComponentRequest) error {
+	if item.ProductID == bundleID {
+		return apperrors.Validation("a bundle cannot contain itself")
+	}
+
+	product, err := s.productRepo.GetByID(ctx, item.ProductID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return apperrors.NotFound("component product not found")
+	}
+
+	components, err := s.bundleRepo.GetComponents(ctx, item.ProductID)
+	if err != nil {
+		return err
+	}
+	if len(components) > 0 {
+		return apperrors.Validationf("component %s is a bundle", product.SKU)
+	}
+
+	if item.VariantID != nil {
+		variant, err := s.variantRepo.GetByID(ctx, *item.VariantID)
+		if err != nil {
+			return err
+		}
+		if variant == nil || variant.ProductID != item.ProductID {
+			return apperrors.Validationf("variant does not belong to component %s", product.SKU)
+		}
+	}
+
+	return nil
+}
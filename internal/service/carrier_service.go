@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+)
+
+// carrierStatuses maps the status codes carriers report onto the tracking
+// statuses the store acts on. Codes are compared lowercased with spaces and
+// dashes as underscores.
+var carrierStatuses = map[string]models.CarrierStatus{
+	"in_transit":       models.CarrierInTransit,
+	"picked_up":        models.CarrierInTransit,
+	"out_for_delivery": models.CarrierOutForDelivery,
+	"ofd":              models.CarrierOutForDelivery,
+	"failed_attempt":   models.CarrierFailedAttempt,
+	"attempt_failed":   models.CarrierFailedAttempt,
+	"delivery_failed":  models.CarrierFailedAttempt,
+	"undelivered":      models.CarrierFailedAttempt,
+	"delivered":        models.CarrierDelivered,
+}
+
+type CarrierService interface {
+	HandleWebhook(ctx context.Context, carrier string, payload []byte, signature string) error
+	GetEvents(ctx context.Context, filter models.CarrierEventFilter, page, limit int) ([]models.CarrierEvent, int, error)
+}
+
+type carrierService struct {
+	orderRepo       repository.OrderRepository
+	eventRepo       repository.CarrierEventRepository
+	orderSvc        OrderService
+	txManager       database.TxManager
+	notificationSvc NotificationService
+	secrets         map[string]string
+}
+
+func NewCarrierService(
+	orderRepo repository.OrderRepository,
+	eventRepo repository.CarrierEventRepository,
+	orderSvc OrderService,
+	txManager database.TxManager,
+	notificationSvc NotificationService,
+	secrets map[string]string,
+) CarrierService {
+	return &carrierService{
+		orderRepo:       orderRepo,
+		eventRepo:       eventRepo,
+		orderSvc:        orderSvc,
+		txManager:       txManager,
+		notificationSvc: notificationSvc,
+		secrets:         secrets,
+	}
+}
+
+// HandleWebhook applies a carrier's tracking callback and records it with
+// what it changed. Callbacks the store cannot act on are recorded and
+// acknowledged so the carrier stops retrying; only failures on our side are
+// returned for the carrier to retry.
+func (s *carrierService) HandleWebhook(ctx context.Context, carrier string, payload []byte, signature string) error {
+	carrier = strings.ToLower(carrier)
+	secret, ok := s.secrets[carrier]
+	if !ok {
+		return apperrors.NotFound("unknown carrier")
+	}
+	if !validCarrierSignature(secret, payload, signature) {
+		return apperrors.Unauthorized("invalid webhook signature")
+	}
+
+	event := &models.CarrierEvent{
+		Carrier: carrier,
+		Payload: string(payload),
+	}
+
+	var update models.CarrierTrackingUpdate
+	if err := json.Unmarshal(payload, &update); err != nil || update.TrackingNumber == "" || update.Status == "" {
+		event.Outcome = models.CarrierEventInvalid
+		s.record(ctx, event, "tracking_number and status are required")
+		return apperrors.Validation("tracking_number and status are required")
+	}
+	event.TrackingNumber = &update.TrackingNumber
+
+	status, known := carrierStatuses[strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(update.Status))]
+	if !known {
+		event.Outcome = models.CarrierEventIgnored
+		s.record(ctx, event, "unrecognised status "+update.Status)
+		return nil
+	}
+	event.Status = &status
+
+	order, err := s.orderRepo.GetByTrackingNumber(ctx, update.TrackingNumber)
+	if err != nil {
+		event.Outcome = models.CarrierEventFailed
+		s.record(ctx, event, err.Error())
+		return err
+	}
+	if order == nil {
+		event.Outcome = models.CarrierEventIgnored
+		s.record(ctx, event, "no shipment with this tracking number")
+		return nil
+	}
+	event.OrderID = &order.ID
+
+	occurredAt := time.Now()
+	if update.OccurredAt != nil {
+		occurredAt = *update.OccurredAt
+	}
+
+	err = s.apply(ctx, order, update.TrackingNumber, status, occurredAt)
+	var appErr *apperrors.Error
+	switch {
+	case err == nil:
+		event.Outcome = models.CarrierEventApplied
+		s.record(ctx, event, "")
+		return nil
+	case errors.As(err, &appErr):
+		event.Outcome = models.CarrierEventRejected
+		s.record(ctx, event, err.Error())
+		return nil
+	default:
+		event.Outcome = models.CarrierEventFailed
+		s.record(ctx, event, err.Error())
+		return err
+	}
+}
+
+// apply records the status on the shipment's items, completes their delivery
+// and tells the customer about delivery attempts
+func (s *carrierService) apply(ctx context.Context, order *models.Order, trackingNumber string, status models.CarrierStatus, at time.Time) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.SetCarrierStatus(ctx, order.ID, trackingNumber, status, at); err != nil {
+			return err
+		}
+
+		switch status {
+		case models.CarrierDelivered:
+			for _, item := range order.Items {
+				if item.TrackingNumber == nil || *item.TrackingNumber != trackingNumber ||
+					item.FulfillmentStatus == models.FulfillmentDelivered || item.FulfillmentStatus == models.FulfillmentCancelled {
+					continue
+				}
+				_, err := s.orderSvc.UpdateItemFulfillment(ctx, order.ID, item.ID, models.UpdateItemFulfillmentRequest{
+					Status: models.FulfillmentDelivered,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+		case models.CarrierOutForDelivery:
+			return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationOrderStatus,
+				"Out for delivery",
+				fmt.Sprintf("Your order %s is out for delivery today.", order.OrderNumber),
+				map[string]interface{}{"order_id": order.ID, "tracking_number": trackingNumber})
+
+		case models.CarrierFailedAttempt:
+			return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationOrderStatus,
+				"Delivery attempt missed",
+				fmt.Sprintf("The courier couldn't deliver your order %s. They will try again.", order.OrderNumber),
+				map[string]interface{}{"order_id": order.ID, "tracking_number": trackingNumber})
+		}
+
+		return nil
+	})
+}
+
+// record keeps the callback for troubleshooting. Losing the record must not
+// fail the callback, so errors are only logged.
+func (s *carrierService) record(ctx context.Context, event *models.CarrierEvent, detail string) {
+	if detail != "" {
+		event.Detail = &detail
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		log.Printf("⚠️ Failed to record %s callback: %v", event.Carrier, err)
+	}
+}
+
+func (s *carrierService) GetEvents(ctx context.Context, filter models.CarrierEventFilter, page, limit int) ([]models.CarrierEvent, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return s.eventRepo.GetAll(ctx, filter, page, limit)
+}
+
+// validCarrierSignature checks the hex HMAC-SHA256 of the body, optionally
+// prefixed with "sha256=", against the carrier's shared secret
+func validCarrierSignature(secret string, payload []byte, signature string) bool {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
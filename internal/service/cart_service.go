@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 
+	"ecommerce-backend/internal/apperrors"
 	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
 
 	"github.com/google/uuid"
 )
@@ -18,19 +20,49 @@ type CartService interface {
 	RemoveFromCart(ctx context.Context, userID, itemID uuid.UUID) (*models.Cart, error)
 	ClearCart(ctx context.Context, userID uuid.UUID) error
 	ValidateCart(ctx context.Context, cartID uuid.UUID) (bool, []string, error)
+	ResolveCart(ctx context.Context, userID uuid.UUID) (*models.CartResolution, error)
+	EnsureMinimumOrder(ctx context.Context, subtotal money.Money) error
 }
 
+// belowMinimumOrderCode is the error code checkout fails with when the cart
+// is below the minimum order value
+const belowMinimumOrderCode = "below_minimum_order_value"
+
 type cartService struct {
-	cartRepo    repository.CartRepository
-	productRepo repository.ProductRepository
-	productSvc  ProductService
+	cartRepo     repository.CartRepository
+	productRepo  repository.ProductRepository
+	orderRepo    repository.OrderRepository
+	productSvc   ProductService
+	pricingSvc   PricingService
+	promotionSvc PromotionService
+
+	settingsSvc SettingsService
+
+	taxRateBasisPoints int64
+	volumetricDivisor  int
 }
 
-func NewCartService(cartRepo repository.CartRepository, productRepo repository.ProductRepository, productSvc ProductService) CartService {
+func NewCartService(
+	cartRepo repository.CartRepository,
+	productRepo repository.ProductRepository,
+	orderRepo repository.OrderRepository,
+	productSvc ProductService,
+	pricingSvc PricingService,
+	promotionSvc PromotionService,
+	settingsSvc SettingsService,
+	taxRateBasisPoints int64,
+	volumetricDivisor int,
+) CartService {
 	return &cartService{
-		cartRepo:    cartRepo,
-		productRepo: productRepo,
-		productSvc:  productSvc,
+		cartRepo:           cartRepo,
+		productRepo:        productRepo,
+		orderRepo:          orderRepo,
+		productSvc:         productSvc,
+		pricingSvc:         pricingSvc,
+		promotionSvc:       promotionSvc,
+		settingsSvc:        settingsSvc,
+		taxRateBasisPoints: taxRateBasisPoints,
+		volumetricDivisor:  volumetricDivisor,
 	}
 }
 
@@ -40,9 +72,97 @@ func (s *cartService) GetCart(ctx context.Context, userID uuid.UUID) (*models.Ca
 		return nil, err
 	}
 
+	return s.priceCart(ctx, cart)
+}
+
+// getPricedCart reloads the cart with its items and price breakdown
+func (s *cartService) getPricedCart(ctx context.Context, cartID uuid.UUID) (*models.Cart, error) {
+	cart, err := s.cartRepo.GetCartWithItems(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.priceCart(ctx, cart)
+}
+
+// priceCart applies scheduled prices to the cart lines, works out the
+// promotions they qualify for and computes totals
+func (s *cartService) priceCart(ctx context.Context, cart *models.Cart) (*models.Cart, error) {
+	if err := s.pricingSvc.ApplyToCartItems(ctx, cart.Items); err != nil {
+		return nil, err
+	}
+
+	promotions, discount, err := s.promotionSvc.Evaluate(ctx, cart.UserID, cart.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	cart.Totals = s.calculateTotals(ctx, cart.Items, discount)
+	cart.Totals.Promotions = promotions
 	return cart, nil
 }
 
+// calculateTotals prices the cart so every client shows the same figures.
+// Tax is charged on the discounted subtotal and shipping comes from
+// estimateShipping. The amounts still missing for free shipping and for the
+// minimum order value are included so clients can prompt for them.
+func (s *cartService) calculateTotals(ctx context.Context, items []models.CartItem, discount money.Money) *models.CartTotals {
+	totals := &models.CartTotals{Discount: discount}
+	for _, item := range items {
+		totals.ItemCount += item.Quantity
+		totals.Subtotal = totals.Subtotal.Add(item.UnitPrice().Mul(item.Quantity))
+		totals.ChargeableWeight += item.Product.ChargeableWeight(s.volumetricDivisor) * item.Quantity
+	}
+
+	taxable := totals.Subtotal.Sub(totals.Discount)
+	totals.EstimatedTax = taxable.MulRat(s.taxRateBasisPoints, 10000)
+	if len(items) > 0 {
+		totals.EstimatedShipping = s.estimateShipping(ctx, taxable, totals.ChargeableWeight)
+		totals.FreeShippingRemaining = remaining(taxable, s.settingsSvc.Amount(ctx, models.SettingFreeShippingMinimum))
+		totals.MinimumOrderRemaining = remaining(taxable, s.settingsSvc.Amount(ctx, models.SettingMinimumOrderValue))
+	}
+
+	totals.Total = taxable.Add(totals.EstimatedTax).Add(totals.EstimatedShipping)
+	return totals
+}
+
+// estimateShipping is the shipping for a cart with the given discounted
+// subtotal and chargeable weight in grams: free once the subtotal reaches
+// the free shipping minimum, otherwise the flat fee plus the rate per
+// kilogram for every started kilogram.
+func (s *cartService) estimateShipping(ctx context.Context, subtotal money.Money, weight int) money.Money {
+	freeShippingMinimum := s.settingsSvc.Amount(ctx, models.SettingFreeShippingMinimum)
+	if freeShippingMinimum.IsPositive() && subtotal >= freeShippingMinimum {
+		return 0
+	}
+
+	kilograms := (weight + 999) / 1000
+	return s.settingsSvc.Amount(ctx, models.SettingShippingFee).
+		Add(s.settingsSvc.Amount(ctx, models.SettingShippingRatePerKg).Mul(kilograms))
+}
+
+// remaining is how far amount falls short of threshold, nil when it does not
+// or the threshold is disabled
+func remaining(amount, threshold money.Money) *money.Money {
+	if !threshold.IsPositive() || amount >= threshold {
+		return nil
+	}
+	short := threshold.Sub(amount)
+	return &short
+}
+
+// EnsureMinimumOrder fails with the below_minimum_order_value error code when
+// a cart's discounted subtotal is below the minimum order value
+func (s *cartService) EnsureMinimumOrder(ctx context.Context, subtotal money.Money) error {
+	minimum := s.settingsSvc.Amount(ctx, models.SettingMinimumOrderValue)
+	short := remaining(subtotal, minimum)
+	if short == nil {
+		return nil
+	}
+	return apperrors.Coded(apperrors.ErrValidation, belowMinimumOrderCode,
+		fmt.Sprintf("orders must be at least %s; add %s more to check out", minimum, short))
+}
+
 func (s *cartService) AddToCart(ctx context.Context, userID uuid.UUID, req models.AddToCartRequest) (*models.Cart, error) {
 	// Get or create cart
 	cart, err := s.cartRepo.GetByUserID(ctx, userID)
@@ -56,36 +176,49 @@ func (s *cartService) AddToCart(ctx context.Context, userID uuid.UUID, req model
 		return nil, err
 	}
 
-	if product == nil {
-		return nil, errors.New("product not found")
+	if product == nil || product.Status != models.ProductActive {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	// Make sure the selected variant belongs to the product
+	if req.VariantID != nil {
+		if _, err := s.productSvc.GetVariant(ctx, req.ProductID, *req.VariantID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Enforce purchase limits against everything of this product in the cart
+	quantity := cartProductQuantity(cart.Items, req.ProductID) + req.Quantity
+	if err := checkPurchaseLimits(ctx, s.orderRepo, userID, product, quantity); err != nil {
+		return nil, err
 	}
 
 	// Check available stock
-	available, err := s.productSvc.CheckStock(ctx, req.ProductID, req.Quantity)
+	available, err := s.productSvc.CheckStock(ctx, req.ProductID, req.VariantID, req.Quantity)
 	if err != nil {
 		return nil, err
 	}
 
 	if !available {
-		return nil, errors.New("insufficient stock")
+		return nil, apperrors.Validation("insufficient stock")
 	}
 
 	// Reserve stock
-	err = s.productSvc.ReserveStock(ctx, req.ProductID, cart.ID, req.Quantity)
+	err = s.productSvc.ReserveStock(ctx, req.ProductID, cart.ID, req.VariantID, req.Quantity)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add to cart
-	err = s.cartRepo.AddItem(ctx, cart.ID, req.ProductID, req.Quantity)
+	err = s.cartRepo.AddItem(ctx, cart.ID, req.ProductID, req.VariantID, req.Quantity)
 	if err != nil {
 		// Release reservation if adding to cart fails
-		s.productSvc.ReleaseStockReservation(ctx, req.ProductID, cart.ID)
+		s.productSvc.ReleaseStockReservation(ctx, req.ProductID, cart.ID, req.VariantID)
 		return nil, err
 	}
 
 	// Get updated cart
-	return s.cartRepo.GetCartWithItems(ctx, cart.ID)
+	return s.getPricedCart(ctx, cart.ID)
 }
 
 func (s *cartService) UpdateCartItem(ctx context.Context, userID, itemID uuid.UUID, req models.UpdateCartItemRequest) (*models.Cart, error) {
@@ -105,31 +238,30 @@ func (s *cartService) UpdateCartItem(ctx context.Context, userID, itemID uuid.UU
 	}
 
 	if itemToUpdate == nil {
-		return nil, errors.New("cart item not found")
+		return nil, apperrors.NotFound("cart item not found")
 	}
 
 	// Calculate quantity difference
 	quantityDiff := req.Quantity - itemToUpdate.Quantity
 
 	if quantityDiff > 0 {
-		// Need more stock - check availability
-		available, err := s.productSvc.CheckStock(ctx, itemToUpdate.ProductID, quantityDiff)
+		product, err := s.productRepo.GetByID(ctx, itemToUpdate.ProductID)
 		if err != nil {
 			return nil, err
 		}
-
-		if !available {
-			return nil, errors.New("insufficient stock for additional quantity")
+		if product == nil {
+			return nil, apperrors.NotFound("product not found")
 		}
 
-		// Reserve additional stock
-		err = s.productSvc.ReserveStock(ctx, itemToUpdate.ProductID, cart.ID, quantityDiff)
-		if err != nil {
+		quantity := cartProductQuantity(cart.Items, itemToUpdate.ProductID) + quantityDiff
+		if err := checkPurchaseLimits(ctx, s.orderRepo, userID, product, quantity); err != nil {
 			return nil, err
 		}
-	} else if quantityDiff < 0 {
-		// Releasing stock
-		err = s.productSvc.ReleaseStockReservation(ctx, itemToUpdate.ProductID, cart.ID)
+	}
+
+	// Reserve exactly the new quantity, growing or shrinking the reservation
+	if quantityDiff != 0 {
+		err = s.productSvc.SetReservationQuantity(ctx, itemToUpdate.ProductID, cart.ID, itemToUpdate.VariantID, req.Quantity)
 		if err != nil {
 			return nil, err
 		}
@@ -142,7 +274,7 @@ func (s *cartService) UpdateCartItem(ctx context.Context, userID, itemID uuid.UU
 	}
 
 	// Get updated cart
-	return s.cartRepo.GetCartWithItems(ctx, cart.ID)
+	return s.getPricedCart(ctx, cart.ID)
 }
 
 func (s *cartService) RemoveFromCart(ctx context.Context, userID, itemID uuid.UUID) (*models.Cart, error) {
@@ -162,11 +294,11 @@ func (s *cartService) RemoveFromCart(ctx context.Context, userID, itemID uuid.UU
 	}
 
 	if itemToRemove == nil {
-		return nil, errors.New("cart item not found")
+		return nil, apperrors.NotFound("cart item not found")
 	}
 
 	// Release stock reservation
-	err = s.productSvc.ReleaseStockReservation(ctx, itemToRemove.ProductID, cart.ID)
+	err = s.productSvc.ReleaseStockReservation(ctx, itemToRemove.ProductID, cart.ID, itemToRemove.VariantID)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +310,7 @@ func (s *cartService) RemoveFromCart(ctx context.Context, userID, itemID uuid.UU
 	}
 
 	// Get updated cart
-	return s.cartRepo.GetCartWithItems(ctx, cart.ID)
+	return s.getPricedCart(ctx, cart.ID)
 }
 
 func (s *cartService) ClearCart(ctx context.Context, userID uuid.UUID) error {
@@ -189,7 +321,7 @@ func (s *cartService) ClearCart(ctx context.Context, userID uuid.UUID) error {
 
 	// Release all stock reservations
 	for _, item := range cart.Items {
-		s.productSvc.ReleaseStockReservation(ctx, item.ProductID, cart.ID)
+		s.productSvc.ReleaseStockReservation(ctx, item.ProductID, cart.ID, item.VariantID)
 	}
 
 	// Clear cart
@@ -207,7 +339,7 @@ func (s *cartService) ValidateCart(ctx context.Context, cartID uuid.UUID) (bool,
 
 	for _, item := range cart.Items {
 		// Check stock excluding this cart's own reservations
-		available, err := s.productSvc.CheckStockForCart(ctx, item.ProductID, cartID, item.Quantity)
+		available, err := s.productSvc.CheckStockForCart(ctx, item.ProductID, cartID, item.VariantID, item.Quantity)
 		if err != nil {
 			return false, nil, err
 		}
@@ -216,9 +348,115 @@ func (s *cartService) ValidateCart(ctx context.Context, cartID uuid.UUID) (bool,
 			valid = false
 			errors = append(errors,
 				fmt.Sprintf("Insufficient stock for %s. Available: %d",
-					item.Product.Name, item.Product.Stock))
+					cartItemName(item), item.AvailableStock()))
 		}
 	}
 
 	return valid, errors, nil
 }
+
+// ResolveCart applies the fixes ValidateCart would ask the customer for:
+// lines whose product or variant is gone are removed, and lines with more
+// than the available stock are capped to it, or removed when none is left
+func (s *cartService) ResolveCart(ctx context.Context, userID uuid.UUID) (*models.CartResolution, error) {
+	cart, err := s.cartRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []models.CartChange{}
+	for _, item := range cart.Items {
+		change := models.CartChange{
+			ItemID:           item.ID,
+			ProductID:        item.ProductID,
+			VariantID:        item.VariantID,
+			Name:             cartItemName(item),
+			PreviousQuantity: item.Quantity,
+		}
+
+		discontinued, err := s.isDiscontinued(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+
+		available := 0
+		if !discontinued {
+			// This cart's own reservation is stock it may keep
+			available, err = s.productRepo.GetAvailableStockExcludingCart(ctx, item.ProductID, cart.ID, item.VariantID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check available stock: %w", err)
+			}
+		}
+
+		switch {
+		case discontinued:
+			change.Action = models.CartChangeRemoved
+			change.Reason = models.CartChangeDiscontinued
+		case available <= 0:
+			change.Action = models.CartChangeRemoved
+			change.Reason = models.CartChangeOutOfStock
+		case available < item.Quantity:
+			change.Action = models.CartChangeQuantityCapped
+			change.Reason = models.CartChangeInsufficientStock
+			change.Quantity = available
+		default:
+			continue
+		}
+
+		if change.Action == models.CartChangeRemoved {
+			if err := s.productSvc.ReleaseStockReservation(ctx, item.ProductID, cart.ID, item.VariantID); err != nil {
+				return nil, err
+			}
+			if err := s.cartRepo.RemoveItem(ctx, cart.ID, item.ID); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := s.productSvc.SetReservationQuantity(ctx, item.ProductID, cart.ID, item.VariantID, change.Quantity); err != nil {
+				return nil, err
+			}
+			if err := s.cartRepo.UpdateItem(ctx, cart.ID, item.ID, change.Quantity); err != nil {
+				return nil, err
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	resolved, err := s.getPricedCart(ctx, cart.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CartResolution{Cart: resolved, Changes: changes}, nil
+}
+
+// isDiscontinued reports whether a cart line's product or variant can no
+// longer be bought
+func (s *cartService) isDiscontinued(ctx context.Context, item models.CartItem) (bool, error) {
+	product, err := s.productRepo.GetByID(ctx, item.ProductID)
+	if err != nil {
+		return false, err
+	}
+	if product == nil || product.Status != models.ProductActive {
+		return true, nil
+	}
+
+	if item.VariantID != nil {
+		_, err := s.productSvc.GetVariant(ctx, item.ProductID, *item.VariantID)
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// cartItemName names a cart line the way validation messages do
+func cartItemName(item models.CartItem) string {
+	if item.Variant != nil {
+		return fmt.Sprintf("%s (%s)", item.Product.Name, item.Variant.SKU)
+	}
+	return item.Product.Name
+}
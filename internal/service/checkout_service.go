@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// CheckoutService starts checkouts: it snapshots the priced cart into a
+// session the order is later placed from
+type CheckoutService interface {
+	CreateSession(ctx context.Context, userID uuid.UUID, req models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error)
+	GetSession(ctx context.Context, userID, sessionID uuid.UUID) (*models.CheckoutSession, error)
+}
+
+type checkoutService struct {
+	sessionRepo       repository.CheckoutSessionRepository
+	cartSvc           CartService
+	serviceabilitySvc ServiceabilityService
+	sessionTTL        time.Duration
+}
+
+func NewCheckoutService(
+	sessionRepo repository.CheckoutSessionRepository,
+	cartSvc CartService,
+	serviceabilitySvc ServiceabilityService,
+	sessionTTL time.Duration,
+) CheckoutService {
+	return &checkoutService{
+		sessionRepo:       sessionRepo,
+		cartSvc:           cartSvc,
+		serviceabilitySvc: serviceabilitySvc,
+		sessionTTL:        sessionTTL,
+	}
+}
+
+// CreateSession prices the cart and snapshots it with the addresses and
+// shipping method. The cart must be orderable now: stock, serviceability and
+// the minimum order value are checked as at placement. Any session the
+// customer still had open expires.
+func (s *checkoutService) CreateSession(ctx context.Context, userID uuid.UUID, req models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error) {
+	if err := s.serviceabilitySvc.EnsureServiceable(ctx, req.ShippingAddress.PostalCode); err != nil {
+		return nil, err
+	}
+
+	cart, err := s.cartSvc.GetCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, apperrors.Validation("cart is empty")
+	}
+
+	valid, validationErrors, err := s.cartSvc.ValidateCart(ctx, cart.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, apperrors.Validationf("cart validation failed: %v", validationErrors)
+	}
+
+	if err := s.cartSvc.EnsureMinimumOrder(ctx, cart.Totals.Subtotal.Sub(cart.Totals.Discount)); err != nil {
+		return nil, err
+	}
+
+	shippingMethod := req.ShippingMethod
+	if shippingMethod == "" {
+		shippingMethod = models.ShippingStandard
+	}
+
+	session := &models.CheckoutSession{
+		UserID:          userID,
+		CartID:          cart.ID,
+		Status:          models.CheckoutSessionOpen,
+		Items:           make([]models.CheckoutSessionItem, 0, len(cart.Items)),
+		Totals:          *cart.Totals,
+		ShippingAddress: req.ShippingAddress,
+		BillingAddress:  req.BillingAddress,
+		ShippingMethod:  shippingMethod,
+		ExpiresAt:       time.Now().Add(s.sessionTTL),
+	}
+	for _, item := range cart.Items {
+		sku := item.Product.SKU
+		if item.Variant != nil {
+			sku = item.Variant.SKU
+		}
+		session.Items = append(session.Items, models.CheckoutSessionItem{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			SKU:       sku,
+			Name:      cartItemName(item),
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice(),
+		})
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// GetSession returns one of the customer's sessions, so an interrupted
+// checkout can be resumed
+func (s *checkoutService) GetSession(ctx context.Context, userID, sessionID uuid.UUID) (*models.CheckoutSession, error) {
+	return loadCheckoutSession(ctx, s.sessionRepo, userID, sessionID)
+}
+
+// loadCheckoutSession reads a session of the customer, reporting an open one
+// past its expiry as expired
+func loadCheckoutSession(ctx context.Context, sessionRepo repository.CheckoutSessionRepository, userID, sessionID uuid.UUID) (*models.CheckoutSession, error) {
+	session, err := sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.UserID != userID {
+		return nil, apperrors.NotFound("checkout session not found")
+	}
+
+	if session.Status == models.CheckoutSessionOpen && !time.Now().Before(session.ExpiresAt) {
+		session.Status = models.CheckoutSessionExpired
+	}
+	return session, nil
+}
+
+// applyCheckoutPrices prices the cart's items as the session snapshotted
+// them. The cart must still hold exactly the session's lines; otherwise the
+// customer has to start checkout again to see what they would pay.
+func applyCheckoutPrices(cart *models.Cart, session *models.CheckoutSession) error {
+	changed := apperrors.Conflict("cart has changed since checkout started; start checkout again")
+	if cart.ID != session.CartID || len(cart.Items) != len(session.Items) {
+		return changed
+	}
+
+	type line struct {
+		productID uuid.UUID
+		variantID uuid.UUID
+	}
+	key := func(productID uuid.UUID, variantID *uuid.UUID) line {
+		l := line{productID: productID}
+		if variantID != nil {
+			l.variantID = *variantID
+		}
+		return l
+	}
+
+	snapshot := make(map[line]models.CheckoutSessionItem, len(session.Items))
+	for _, item := range session.Items {
+		snapshot[key(item.ProductID, item.VariantID)] = item
+	}
+
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		snapshotted, ok := snapshot[key(item.ProductID, item.VariantID)]
+		if !ok || snapshotted.Quantity != item.Quantity {
+			return changed
+		}
+
+		if item.Variant != nil {
+			item.Variant.Price = snapshotted.UnitPrice
+		} else {
+			item.Product.Price = snapshotted.UnitPrice
+		}
+	}
+
+	return nil
+}
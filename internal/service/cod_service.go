@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// codMaxOTPAttempts is how many wrong delivery codes are accepted before a
+// new code has to be issued
+const codMaxOTPAttempts = 5
+
+type CODService interface {
+	CheckEligibility(ctx context.Context, amount money.Money, postalCode string) models.CODEligibility
+	CheckCartEligibility(ctx context.Context, userID uuid.UUID, postalCode string) (*models.CODEligibility, error)
+	IssueDeliveryOTP(ctx context.Context, order *models.Order) error
+	ResendDeliveryOTP(ctx context.Context, orderID uuid.UUID) error
+	VerifyDeliveryOTP(ctx context.Context, orderID uuid.UUID, otp string) error
+	IsDeliveryVerified(ctx context.Context, orderID uuid.UUID) (bool, error)
+	RecordCollection(ctx context.Context, payment *models.Payment) error
+	GetRemittanceReport(ctx context.Context, status string, rangeDays, page, limit int) (*models.CODRemittanceReport, int, error)
+	MarkRemitted(ctx context.Context, req models.RemitCODRequest) (int64, error)
+}
+
+type codService struct {
+	codRepo         repository.CODRepository
+	orderRepo       repository.OrderRepository
+	cartSvc         CartService
+	notificationSvc NotificationService
+	settingsSvc     SettingsService
+	postalCodes     []string
+	otpTTL          time.Duration
+}
+
+// NewCODService creates the cash-on-delivery service. The largest order COD
+// is offered for is the cod_max_order_value setting, 0 meaning no limit; an
+// empty postalCodes list means COD is offered everywhere, otherwise the
+// shipping postal code must start with one of the entries.
+func NewCODService(
+	codRepo repository.CODRepository,
+	orderRepo repository.OrderRepository,
+	cartSvc CartService,
+	notificationSvc NotificationService,
+	settingsSvc SettingsService,
+	postalCodes []string,
+	otpTTL time.Duration,
+) CODService {
+	return &codService{
+		codRepo:         codRepo,
+		orderRepo:       orderRepo,
+		cartSvc:         cartSvc,
+		notificationSvc: notificationSvc,
+		settingsSvc:     settingsSvc,
+		postalCodes:     postalCodes,
+		otpTTL:          otpTTL,
+	}
+}
+
+func (s *codService) CheckEligibility(ctx context.Context, amount money.Money, postalCode string) models.CODEligibility {
+	eligibility := models.CODEligibility{Eligible: true, Amount: amount}
+	maxOrderValue := s.settingsSvc.Amount(ctx, models.SettingCODMaxOrderValue)
+	if !maxOrderValue.IsZero() {
+		eligibility.MaxOrderValue = &maxOrderValue
+	}
+
+	if !maxOrderValue.IsZero() && amount > maxOrderValue {
+		eligibility.Eligible = false
+		eligibility.Reason = fmt.Sprintf("cash on delivery is only available for orders up to %s", maxOrderValue)
+		return eligibility
+	}
+
+	if !s.servesPostalCode(postalCode) {
+		eligibility.Eligible = false
+		eligibility.Reason = "cash on delivery is not available for this postal code"
+	}
+
+	return eligibility
+}
+
+func (s *codService) servesPostalCode(postalCode string) bool {
+	if len(s.postalCodes) == 0 {
+		return true
+	}
+
+	postalCode = strings.ToUpper(strings.ReplaceAll(postalCode, " ", ""))
+	if postalCode == "" {
+		return false
+	}
+
+	for _, prefix := range s.postalCodes {
+		if strings.HasPrefix(postalCode, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCartEligibility checks whether the user's current cart could be paid
+// cash on delivery to postalCode
+func (s *codService) CheckCartEligibility(ctx context.Context, userID uuid.UUID, postalCode string) (*models.CODEligibility, error) {
+	cart, err := s.cartSvc.GetCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount money.Money
+	if cart.Totals != nil {
+		amount = cart.Totals.Total
+	}
+
+	eligibility := s.CheckEligibility(ctx, amount, postalCode)
+	return &eligibility, nil
+}
+
+// IssueDeliveryOTP generates a fresh delivery code for a COD order and sends
+// it to the customer, who reads it out to the courier on delivery
+func (s *codService) IssueDeliveryOTP(ctx context.Context, order *models.Order) error {
+	otp, err := generateOTP()
+	if err != nil {
+		return err
+	}
+
+	verification := &models.CODVerification{
+		OrderID:   order.ID,
+		OTPHash:   hashVerificationToken(otp),
+		ExpiresAt: time.Now().Add(s.otpTTL),
+	}
+	if err := s.codRepo.SaveVerification(ctx, verification); err != nil {
+		return err
+	}
+
+	return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationOrderStatus,
+		"Your delivery code",
+		fmt.Sprintf("Share code %s with the courier when you pay for order %s. Please have %s ready.",
+			otp, order.OrderNumber, order.AmountDue()),
+		map[string]interface{}{"order_id": order.ID})
+}
+
+func (s *codService) ResendDeliveryOTP(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.codOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != models.OrderProcessing && order.Status != models.OrderPartiallyShipped && order.Status != models.OrderShipped {
+		return apperrors.Conflict("order is not awaiting delivery")
+	}
+
+	return s.IssueDeliveryOTP(ctx, order)
+}
+
+// VerifyDeliveryOTP checks the code the courier collected from the customer.
+// Failed attempts are counted and the code is locked after too many.
+func (s *codService) VerifyDeliveryOTP(ctx context.Context, orderID uuid.UUID, otp string) error {
+	if _, err := s.codOrder(ctx, orderID); err != nil {
+		return err
+	}
+
+	verification, err := s.codRepo.GetVerification(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if verification == nil {
+		return apperrors.Validation("no delivery code has been issued")
+	}
+
+	if verification.VerifiedAt != nil {
+		return apperrors.Conflict("delivery code already used")
+	}
+
+	if verification.Attempts >= codMaxOTPAttempts {
+		return apperrors.RateLimited("too many incorrect attempts, issue a new delivery code")
+	}
+
+	if time.Now().After(verification.ExpiresAt) {
+		return apperrors.Validation("delivery code has expired")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashVerificationToken(otp)), []byte(verification.OTPHash)) != 1 {
+		if err := s.codRepo.IncrementAttempts(ctx, verification.ID); err != nil {
+			return err
+		}
+		return apperrors.Validation("invalid delivery code")
+	}
+
+	return s.codRepo.MarkVerified(ctx, verification.ID)
+}
+
+func (s *codService) IsDeliveryVerified(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	verification, err := s.codRepo.GetVerification(ctx, orderID)
+	if err != nil {
+		return false, err
+	}
+
+	return verification != nil && verification.VerifiedAt != nil, nil
+}
+
+// RecordCollection adds the cash collected for a COD payment to the
+// remittance ledger
+func (s *codService) RecordCollection(ctx context.Context, payment *models.Payment) error {
+	return s.codRepo.CreateRemittance(ctx, &models.CODRemittance{
+		OrderID:   payment.OrderID,
+		PaymentID: &payment.ID,
+		Amount:    payment.Amount,
+	})
+}
+
+func (s *codService) GetRemittanceReport(ctx context.Context, status string, rangeDays, page, limit int) (*models.CODRemittanceReport, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	remittances, total, err := s.codRepo.GetRemittances(ctx, status, rangeDays, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totals, err := s.codRepo.GetRemittanceTotals(ctx, rangeDays)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &models.CODRemittanceReport{Totals: *totals, Remittances: remittances}, total, nil
+}
+
+func (s *codService) MarkRemitted(ctx context.Context, req models.RemitCODRequest) (int64, error) {
+	updated, err := s.codRepo.MarkRemitted(ctx, req.RemittanceIDs, strings.TrimSpace(req.Reference))
+	if err != nil {
+		return 0, err
+	}
+
+	if updated == 0 {
+		return 0, apperrors.NotFound("no pending remittances found")
+	}
+
+	return updated, nil
+}
+
+// codOrder loads an order and checks that it is paid cash on delivery
+func (s *codService) codOrder(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	if order.PaymentMethod != "cod" {
+		return nil, apperrors.Validation("order is not cash on delivery")
+	}
+
+	return order, nil
+}
+
+// generateOTP returns a random six-digit code
+func generateOTP() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate one-time code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
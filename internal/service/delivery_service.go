@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// defaultCutoffTime is the dispatch cut-off of warehouses created without one
+const defaultCutoffTime = "15:00"
+
+// transitDays is how many business days each shipping method takes from
+// dispatch to a destination inside the shipping warehouse's zones, and to
+// anywhere else
+var transitDays = map[models.ShippingMethod]struct{ local, other int }{
+	models.ShippingStandard: {local: 2, other: 5},
+	models.ShippingExpress:  {local: 1, other: 2},
+}
+
+type DeliveryService interface {
+	EstimateCart(ctx context.Context, userID uuid.UUID, req models.DeliveryEstimateRequest) (*models.DeliveryEstimateResponse, error)
+	EstimateOrder(ctx context.Context, order *models.Order) (*models.DeliveryEstimate, error)
+}
+
+type deliveryService struct {
+	warehouseRepo repository.WarehouseRepository
+	cartSvc       CartService
+}
+
+func NewDeliveryService(warehouseRepo repository.WarehouseRepository, cartSvc CartService) DeliveryService {
+	return &deliveryService{
+		warehouseRepo: warehouseRepo,
+		cartSvc:       cartSvc,
+	}
+}
+
+// EstimateCart estimates delivery of the user's cart to a postal code from the
+// warehouses checkout would allocate it to
+func (s *deliveryService) EstimateCart(ctx context.Context, userID uuid.UUID, req models.DeliveryEstimateRequest) (*models.DeliveryEstimateResponse, error) {
+	cart, err := s.cartSvc.GetCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cart.Items) == 0 {
+		return nil, apperrors.Validation("cart is empty")
+	}
+
+	postalCode := normalizePostalCode(req.PostalCode)
+
+	warehouseIDs := []uuid.UUID{}
+	for _, item := range cart.Items {
+		candidates, err := s.warehouseRepo.GetShippingCandidates(ctx, item.ProductID, item.VariantID, postalCode, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := item.Quantity
+		for _, candidate := range candidates {
+			if remaining <= 0 {
+				break
+			}
+			warehouseIDs = append(warehouseIDs, candidate.WarehouseID)
+			remaining -= candidate.Quantity
+		}
+	}
+
+	warehouses, err := s.loadWarehouses(ctx, warehouseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := models.ShippingMethods
+	if req.ShippingMethod != "" {
+		methods = []models.ShippingMethod{req.ShippingMethod}
+	}
+
+	response := &models.DeliveryEstimateResponse{
+		PostalCode: postalCode,
+		Estimates:  make([]models.DeliveryEstimate, 0, len(methods)),
+	}
+	now := time.Now()
+	for _, method := range methods {
+		response.Estimates = append(response.Estimates, estimateDelivery(method, postalCode, warehouses, now))
+	}
+
+	return response, nil
+}
+
+// EstimateOrder estimates delivery of an order from the warehouses it was
+// allocated to. Call it after allocation, inside the same transaction.
+func (s *deliveryService) EstimateOrder(ctx context.Context, order *models.Order) (*models.DeliveryEstimate, error) {
+	itemIDs := make([]uuid.UUID, 0, len(order.Items))
+	for _, item := range order.Items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	allocations, err := s.warehouseRepo.GetAllocations(ctx, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouseIDs := make([]uuid.UUID, 0, len(allocations))
+	for _, allocation := range allocations {
+		warehouseIDs = append(warehouseIDs, allocation.WarehouseID)
+	}
+
+	warehouses, err := s.loadWarehouses(ctx, warehouseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	method := order.ShippingMethod
+	if method == "" {
+		method = models.ShippingStandard
+	}
+
+	estimate := estimateDelivery(method, normalizePostalCode(order.ShippingAddress.PostalCode), warehouses, order.CreatedAt)
+	return &estimate, nil
+}
+
+// loadWarehouses fetches each warehouse once, falling back to the default
+// warehouse when there are none, e.g. for stock not yet placed anywhere
+func (s *deliveryService) loadWarehouses(ctx context.Context, ids []uuid.UUID) ([]models.Warehouse, error) {
+	seen := make(map[uuid.UUID]bool)
+	warehouses := []models.Warehouse{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		warehouse, err := s.warehouseRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if warehouse != nil {
+			warehouses = append(warehouses, *warehouse)
+		}
+	}
+
+	if len(warehouses) == 0 {
+		warehouse, err := s.warehouseRepo.GetDefault(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if warehouse != nil {
+			warehouses = append(warehouses, *warehouse)
+		}
+	}
+
+	return warehouses, nil
+}
+
+// estimateDelivery returns the latest dispatch and delivery dates among the
+// warehouses for an order placed at placedAt. Without any warehouse it
+// assumes the default cut-off and no local zone.
+func estimateDelivery(method models.ShippingMethod, postalCode string, warehouses []models.Warehouse, placedAt time.Time) models.DeliveryEstimate {
+	if len(warehouses) == 0 {
+		warehouses = []models.Warehouse{{CutoffTime: defaultCutoffTime}}
+	}
+
+	estimate := models.DeliveryEstimate{ShippingMethod: method}
+	for _, warehouse := range warehouses {
+		dispatch := dispatchDate(placedAt, warehouse.CutoffTime)
+
+		days := transitDays[method].other
+		if servesZone(warehouse.Zones, postalCode) {
+			days = transitDays[method].local
+		}
+		delivery := addBusinessDays(dispatch, days)
+
+		if dispatch.After(estimate.DispatchDate) {
+			estimate.DispatchDate = dispatch
+		}
+		if delivery.After(estimate.EstimatedDelivery) {
+			estimate.EstimatedDelivery = delivery
+		}
+	}
+
+	return estimate
+}
+
+// dispatchDate is the business day an order placed at placedAt leaves a
+// warehouse with the given HH:MM UTC cut-off
+func dispatchDate(placedAt time.Time, cutoff string) time.Time {
+	placedAt = placedAt.UTC()
+	day := time.Date(placedAt.Year(), placedAt.Month(), placedAt.Day(), 0, 0, 0, 0, time.UTC)
+
+	at, err := time.Parse("15:04", cutoff)
+	if err != nil {
+		at, _ = time.Parse("15:04", defaultCutoffTime)
+	}
+	if !placedAt.Before(day.Add(time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute)) {
+		day = day.AddDate(0, 0, 1)
+	}
+
+	for isWeekend(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+func addBusinessDays(day time.Time, days int) time.Time {
+	for days > 0 {
+		day = day.AddDate(0, 0, 1)
+		if !isWeekend(day) {
+			days--
+		}
+	}
+	return day
+}
+
+func isWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
+
+// servesZone reports whether a normalized postal code falls in one of a
+// warehouse's zones (postal code prefixes)
+func servesZone(zones []string, postalCode string) bool {
+	if postalCode == "" {
+		return false
+	}
+	for _, zone := range zones {
+		if strings.HasPrefix(postalCode, zone) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/erp"
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// erpReplayBatch caps the events one replay sends
+const erpReplayBatch = 500
+
+// erpOrderEvents are the events the ERP is sent
+var erpOrderEvents = []string{events.OrderCreated, events.OrderStatusChanged}
+
+type ERPSyncService interface {
+	HandleStockMessage(ctx context.Context, body []byte) error
+	GetStockMessages(ctx context.Context, filter models.ERPStockMessageFilter, page, limit int) ([]models.ERPStockMessage, int, error)
+	ReplayStockMessage(ctx context.Context, id uuid.UUID) (*models.ERPStockMessage, error)
+	ReplayEvents(ctx context.Context, req models.ReplayERPEventsRequest) (*models.ReplayERPEventsResult, error)
+}
+
+type erpSyncService struct {
+	messageRepo   repository.ERPStockMessageRepository
+	outboxRepo    repository.OutboxRepository
+	productRepo   repository.ProductRepository
+	variantRepo   repository.VariantRepository
+	warehouseRepo repository.WarehouseRepository
+	warehouseSvc  WarehouseService
+
+	// sink publishes order events to the ERP; nil when there is no ERP link
+	sink events.Sink
+}
+
+func NewERPSyncService(
+	messageRepo repository.ERPStockMessageRepository,
+	outboxRepo repository.OutboxRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.VariantRepository,
+	warehouseRepo repository.WarehouseRepository,
+	warehouseSvc WarehouseService,
+	sink events.Sink,
+) ERPSyncService {
+	return &erpSyncService{
+		messageRepo:   messageRepo,
+		outboxRepo:    outboxRepo,
+		productRepo:   productRepo,
+		variantRepo:   variantRepo,
+		warehouseRepo: warehouseRepo,
+		warehouseSvc:  warehouseSvc,
+		sink:          sink,
+	}
+}
+
+// HandleStockMessage applies a stock level from the ERP's queue and records
+// the message with what it changed. Messages the store cannot act on are
+// recorded and acknowledged; only failures on our side are returned, for the
+// message to be redelivered. A redelivered message that was already
+// processed is skipped.
+func (s *erpSyncService) HandleStockMessage(ctx context.Context, body []byte) error {
+	update, translateErr := erp.TranslateStockUpdate(body)
+
+	message := &models.ERPStockMessage{Payload: string(body)}
+	if update.MessageID != "" {
+		existing, err := s.messageRepo.GetByMessageID(ctx, update.MessageID)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.Outcome != models.ERPMessageFailed {
+			return nil
+		}
+		if existing != nil {
+			message = existing
+		}
+		message.MessageID = &update.MessageID
+	}
+
+	if translateErr != nil {
+		detail := translateErr.Error()
+		message.Outcome = models.ERPMessageInvalid
+		message.Detail = &detail
+		return s.save(ctx, message)
+	}
+
+	return s.process(ctx, message, update)
+}
+
+// process applies the update and records the outcome on the message
+func (s *erpSyncService) process(ctx context.Context, message *models.ERPStockMessage, update models.ERPStockUpdate) error {
+	message.SKU = &update.SKU
+	message.WarehouseCode = &update.WarehouseCode
+	message.Quantity = &update.Quantity
+	message.ChangedAt = update.ChangedAt
+
+	ignored, err := s.apply(ctx, message, update)
+
+	var appErr *apperrors.Error
+	var detail string
+	switch {
+	case err == nil && ignored != "":
+		message.Outcome = models.ERPMessageIgnored
+		detail = ignored
+	case err == nil:
+		message.Outcome = models.ERPMessageApplied
+	case errors.As(err, &appErr):
+		message.Outcome = models.ERPMessageRejected
+		detail = err.Error()
+	default:
+		message.Outcome = models.ERPMessageFailed
+		detail = err.Error()
+	}
+
+	message.Detail = nil
+	if detail != "" {
+		message.Detail = &detail
+	}
+	if saveErr := s.save(ctx, message); saveErr != nil {
+		return saveErr
+	}
+
+	if message.Outcome == models.ERPMessageFailed {
+		return err
+	}
+	return nil
+}
+
+// apply sets the warehouse's stock of the SKU to the ERP's level. Levels are
+// absolute, so one counted before the level last applied is ignored, and the
+// reason returned, rather than winding the stock back.
+func (s *erpSyncService) apply(ctx context.Context, message *models.ERPStockMessage, update models.ERPStockUpdate) (string, error) {
+	warehouse, err := s.resolveWarehouse(ctx, update.WarehouseCode)
+	if err != nil {
+		return "", err
+	}
+	message.WarehouseCode = &warehouse.Code
+
+	if update.ChangedAt != nil {
+		latest, err := s.messageRepo.LatestApplied(ctx, update.SKU, warehouse.Code)
+		if err != nil {
+			return "", err
+		}
+		if latest != nil && update.ChangedAt.Before(*latest) {
+			return "older than the level counted at " + latest.Format(time.RFC3339), nil
+		}
+	}
+
+	req := models.SetWarehouseStockRequest{Quantity: update.Quantity}
+	variant, err := s.variantRepo.GetBySKU(ctx, update.SKU)
+	if err != nil {
+		return "", err
+	}
+	if variant != nil {
+		req.ProductID = variant.ProductID
+		req.VariantID = &variant.ID
+	} else {
+		product, err := s.productRepo.GetBySKU(ctx, update.SKU)
+		if err != nil {
+			return "", err
+		}
+		if product == nil {
+			return "", apperrors.NotFound("unknown SKU " + update.SKU)
+		}
+		req.ProductID = product.ID
+	}
+	message.ProductID = &req.ProductID
+	message.VariantID = req.VariantID
+
+	return "", s.warehouseSvc.SetStock(ctx, warehouse.ID, req)
+}
+
+// resolveWarehouse finds the warehouse an ERP plant code stands for; the
+// default warehouse when the ERP sends none
+func (s *erpSyncService) resolveWarehouse(ctx context.Context, code string) (*models.Warehouse, error) {
+	if code == "" {
+		warehouse, err := s.warehouseRepo.GetDefault(ctx)
+		if err == nil && warehouse == nil {
+			err = apperrors.NotFound("no default warehouse configured")
+		}
+		return warehouse, err
+	}
+
+	warehouse, err := s.warehouseRepo.GetByCode(ctx, code)
+	if err == nil && warehouse == nil {
+		err = apperrors.NotFound("unknown warehouse " + code)
+	}
+	return warehouse, err
+}
+
+func (s *erpSyncService) save(ctx context.Context, message *models.ERPStockMessage) error {
+	if message.ID == uuid.Nil {
+		return s.messageRepo.Create(ctx, message)
+	}
+	return s.messageRepo.UpdateOutcome(ctx, message)
+}
+
+func (s *erpSyncService) GetStockMessages(ctx context.Context, filter models.ERPStockMessageFilter, page, limit int) ([]models.ERPStockMessage, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return s.messageRepo.GetAll(ctx, filter, page, limit)
+}
+
+// ReplayStockMessage processes a recorded message again, e.g. once the SKU
+// or warehouse it named has been set up
+func (s *erpSyncService) ReplayStockMessage(ctx context.Context, id uuid.UUID) (*models.ERPStockMessage, error) {
+	message, err := s.messageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if message == nil {
+		return nil, apperrors.NotFound("stock message not found")
+	}
+
+	update, err := erp.TranslateStockUpdate([]byte(message.Payload))
+	if err != nil {
+		return nil, apperrors.Conflict("invalid messages cannot be replayed")
+	}
+
+	if err := s.process(ctx, message, update); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// ReplayEvents sends the order events recorded in a window to the ERP again,
+// oldest first, whether or not they were delivered before
+func (s *erpSyncService) ReplayEvents(ctx context.Context, req models.ReplayERPEventsRequest) (*models.ReplayERPEventsResult, error) {
+	if s.sink == nil {
+		return nil, apperrors.Conflict("the ERP integration is not configured")
+	}
+
+	to := time.Now()
+	if req.To != nil {
+		to = *req.To
+	}
+	if !to.After(req.From) {
+		return nil, apperrors.Validation("to must be after from")
+	}
+
+	pending, err := s.outboxRepo.GetRange(ctx, erpOrderEvents, req.From, to, erpReplayBatch+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ReplayERPEventsResult{More: len(pending) > erpReplayBatch}
+	if result.More {
+		pending = pending[:erpReplayBatch]
+	}
+
+	for _, event := range pending {
+		if err := s.sink.Deliver(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to replay event %s: %w", event.ID, err)
+		}
+		result.Published++
+		result.LastEventAt = &event.CreatedAt
+	}
+
+	return result, nil
+}
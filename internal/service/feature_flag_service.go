@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+)
+
+// flagCacheTTL is how long the flags are reused before they are read again,
+// so changes reach other instances within it
+const flagCacheTTL = 30 * time.Second
+
+var flagKeyPattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// FlagService manages feature flags and answers whether one is on
+type FlagService interface {
+	IsEnabled(ctx context.Context, key, subject string) bool
+	CreateFlag(ctx context.Context, req models.CreateFeatureFlagRequest) (*models.FeatureFlag, error)
+	GetFlags(ctx context.Context) ([]models.FeatureFlag, error)
+	GetFlag(ctx context.Context, key string) (*models.FeatureFlag, error)
+	UpdateFlag(ctx context.Context, key string, req models.FeatureFlagRequest) (*models.FeatureFlag, error)
+	DeleteFlag(ctx context.Context, key string) error
+}
+
+type flagService struct {
+	flagRepo    repository.FeatureFlagRepository
+	environment string
+
+	mu        sync.Mutex
+	flags     map[string]models.FeatureFlag
+	expiresAt time.Time
+}
+
+func NewFlagService(flagRepo repository.FeatureFlagRepository, environment string) FlagService {
+	return &flagService{
+		flagRepo:    flagRepo,
+		environment: environment,
+	}
+}
+
+// IsEnabled reports whether the flag is on for subject, a user ID or, for
+// anonymous callers, a client IP. The same subject always lands in the same
+// rollout bucket. A missing flag is off, and when the flags cannot be read
+// the last ones read are used.
+func (s *flagService) IsEnabled(ctx context.Context, key, subject string) bool {
+	flag, ok := s.lookup(ctx, key)
+	if !ok || !flag.Enabled {
+		return false
+	}
+
+	if len(flag.Environments) > 0 && !slices.Contains(flag.Environments, s.environment) {
+		return false
+	}
+
+	return rolloutBucket(key, subject) < flag.RolloutPercent
+}
+
+func (s *flagService) lookup(ctx context.Context, key string) (models.FeatureFlag, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.flags == nil || time.Now().After(s.expiresAt) {
+		flags, err := s.flagRepo.GetAll(ctx)
+		if err != nil {
+			log.Printf("⚠️ Failed to load feature flags: %v", err)
+		} else {
+			s.flags = make(map[string]models.FeatureFlag, len(flags))
+			for _, flag := range flags {
+				s.flags[flag.Key] = flag
+			}
+			s.expiresAt = time.Now().Add(flagCacheTTL)
+		}
+	}
+
+	flag, ok := s.flags[key]
+	return flag, ok
+}
+
+func (s *flagService) invalidate() {
+	s.mu.Lock()
+	s.expiresAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *flagService) CreateFlag(ctx context.Context, req models.CreateFeatureFlagRequest) (*models.FeatureFlag, error) {
+	key := strings.ToLower(strings.TrimSpace(req.Key))
+	if !flagKeyPattern.MatchString(key) {
+		return nil, apperrors.Validation("key may only contain lowercase letters, digits and underscores")
+	}
+
+	existing, err := s.flagRepo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.Conflict("a feature flag with this key already exists")
+	}
+
+	flag := &models.FeatureFlag{Key: key}
+	applyFlagRequest(flag, req.FeatureFlagRequest)
+
+	if err := s.flagRepo.Create(ctx, flag); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return flag, nil
+}
+
+func (s *flagService) GetFlags(ctx context.Context) ([]models.FeatureFlag, error) {
+	return s.flagRepo.GetAll(ctx)
+}
+
+func (s *flagService) GetFlag(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	flag, err := s.flagRepo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if flag == nil {
+		return nil, apperrors.NotFound("feature flag not found")
+	}
+	return flag, nil
+}
+
+func (s *flagService) UpdateFlag(ctx context.Context, key string, req models.FeatureFlagRequest) (*models.FeatureFlag, error) {
+	flag, err := s.GetFlag(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	applyFlagRequest(flag, req)
+	if err := s.flagRepo.Update(ctx, flag); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return flag, nil
+}
+
+func (s *flagService) DeleteFlag(ctx context.Context, key string) error {
+	if _, err := s.GetFlag(ctx, key); err != nil {
+		return err
+	}
+
+	if err := s.flagRepo.Delete(ctx, key); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	return nil
+}
+
+func applyFlagRequest(flag *models.FeatureFlag, req models.FeatureFlagRequest) {
+	flag.Description = strings.TrimSpace(req.Description)
+	flag.Enabled = req.Enabled
+
+	flag.RolloutPercent = 100
+	if req.RolloutPercent != nil {
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+
+	flag.Environments = []string{}
+	for _, environment := range req.Environments {
+		if environment = strings.TrimSpace(environment); environment != "" {
+			flag.Environments = append(flag.Environments, environment)
+		}
+	}
+}
+
+// rolloutBucket places subject in one of 100 buckets, independently per flag
+// so the same users are not always first to get every feature
+func rolloutBucket(key, subject string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key + ":" + subject))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// Risk each built-in rule adds when it fires. With the default review
+// threshold of 60 one signal alone never holds an order.
+const (
+	velocityScore            = 40
+	countryMismatchScore     = 30
+	highValueFirstOrderScore = 40
+)
+
+// FraudRule scores one risk factor of an order about to be placed. A rule
+// that does not fire returns nil.
+type FraudRule interface {
+	Evaluate(ctx context.Context, check models.FraudCheck) (*models.FraudSignal, error)
+}
+
+// FraudService screens orders at checkout. The scores of the rules that fire
+// add up, and orders reaching the threshold are held for review.
+type FraudService interface {
+	Screen(ctx context.Context, check models.FraudCheck) (*models.RiskAssessment, error)
+	SaveAssessment(ctx context.Context, assessment *models.RiskAssessment) error
+	GetReviewQueue(ctx context.Context, page, limit int) ([]models.ReviewQueueItem, int, error)
+	RecordDecision(ctx context.Context, orderID uuid.UUID, decision, note string, reviewerID uuid.UUID) error
+}
+
+type fraudService struct {
+	fraudRepo repository.FraudRepository
+	rules     []FraudRule
+	threshold int
+}
+
+// NewFraudService creates the screening pipeline running rules in order. A
+// threshold of 0 disables holding orders; they are still scored.
+func NewFraudService(fraudRepo repository.FraudRepository, threshold int, rules ...FraudRule) FraudService {
+	return &fraudService{fraudRepo: fraudRepo, rules: rules, threshold: threshold}
+}
+
+func (s *fraudService) Screen(ctx context.Context, check models.FraudCheck) (*models.RiskAssessment, error) {
+	assessment := &models.RiskAssessment{
+		UserID:   check.UserID,
+		ClientIP: check.ClientIP,
+		Signals:  []models.FraudSignal{},
+	}
+
+	for _, rule := range s.rules {
+		signal, err := rule.Evaluate(ctx, check)
+		if err != nil {
+			return nil, fmt.Errorf("fraud screening failed: %w", err)
+		}
+		if signal != nil {
+			assessment.Signals = append(assessment.Signals, *signal)
+			assessment.Score += signal.Score
+		}
+	}
+
+	assessment.Held = s.threshold > 0 && assessment.Score >= s.threshold
+	return assessment, nil
+}
+
+// SaveAssessment records a screening result once its order exists; call it
+// inside the transaction that creates the order
+func (s *fraudService) SaveAssessment(ctx context.Context, assessment *models.RiskAssessment) error {
+	return s.fraudRepo.CreateAssessment(ctx, assessment)
+}
+
+func (s *fraudService) GetReviewQueue(ctx context.Context, page, limit int) ([]models.ReviewQueueItem, int, error) {
+	return s.fraudRepo.GetReviewQueue(ctx, page, limit)
+}
+
+func (s *fraudService) RecordDecision(ctx context.Context, orderID uuid.UUID, decision, note string, reviewerID uuid.UUID) error {
+	return s.fraudRepo.RecordDecision(ctx, orderID, decision, strings.TrimSpace(note), reviewerID)
+}
+
+// velocityRule fires when a user, or a client IP, places more than max orders
+// within a window; each that does adds to the score
+type velocityRule struct {
+	fraudRepo  repository.FraudRepository
+	window     time.Duration
+	maxPerUser int
+	maxPerIP   int
+}
+
+// NewVelocityRule creates the rule; a max of 0 disables that check
+func NewVelocityRule(fraudRepo repository.FraudRepository, window time.Duration, maxPerUser, maxPerIP int) FraudRule {
+	return &velocityRule{
+		fraudRepo:  fraudRepo,
+		window:     window,
+		maxPerUser: maxPerUser,
+		maxPerIP:   maxPerIP,
+	}
+}
+
+func (r *velocityRule) Evaluate(ctx context.Context, check models.FraudCheck) (*models.FraudSignal, error) {
+	var reasons []string
+
+	if r.maxPerUser > 0 {
+		count, err := r.fraudRepo.CountRecentOrdersByUser(ctx, check.UserID, r.window)
+		if err != nil {
+			return nil, err
+		}
+		if count >= r.maxPerUser {
+			reasons = append(reasons, fmt.Sprintf("%d orders from this account in the last %s", count, r.window))
+		}
+	}
+
+	if r.maxPerIP > 0 && check.ClientIP != "" {
+		count, err := r.fraudRepo.CountRecentOrdersByIP(ctx, check.ClientIP, r.window)
+		if err != nil {
+			return nil, err
+		}
+		if count >= r.maxPerIP {
+			reasons = append(reasons, fmt.Sprintf("%d orders from %s in the last %s", count, check.ClientIP, r.window))
+		}
+	}
+
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+	return &models.FraudSignal{
+		Rule:   "velocity",
+		Score:  velocityScore * len(reasons),
+		Reason: strings.Join(reasons, "; "),
+	}, nil
+}
+
+// countryMismatchRule fires when the billing and shipping countries differ
+type countryMismatchRule struct{}
+
+func NewCountryMismatchRule() FraudRule {
+	return countryMismatchRule{}
+}
+
+func (countryMismatchRule) Evaluate(ctx context.Context, check models.FraudCheck) (*models.FraudSignal, error) {
+	billing := strings.TrimSpace(check.BillingCountry)
+	shipping := strings.TrimSpace(check.ShippingCountry)
+	if strings.EqualFold(billing, shipping) {
+		return nil, nil
+	}
+
+	return &models.FraudSignal{
+		Rule:   "country_mismatch",
+		Score:  countryMismatchScore,
+		Reason: fmt.Sprintf("billing country %s differs from shipping country %s", billing, shipping),
+	}, nil
+}
+
+// highValueFirstOrderRule fires when a customer's first order is worth at
+// least limit
+type highValueFirstOrderRule struct {
+	fraudRepo repository.FraudRepository
+	limit     money.Money
+}
+
+// NewHighValueFirstOrderRule creates the rule; a zero limit disables it
+func NewHighValueFirstOrderRule(fraudRepo repository.FraudRepository, limit money.Money) FraudRule {
+	return &highValueFirstOrderRule{fraudRepo: fraudRepo, limit: limit}
+}
+
+func (r *highValueFirstOrderRule) Evaluate(ctx context.Context, check models.FraudCheck) (*models.FraudSignal, error) {
+	if !r.limit.IsPositive() || check.Total.Sub(r.limit).IsNegative() {
+		return nil, nil
+	}
+
+	prior, err := r.fraudRepo.CountPriorOrders(ctx, check.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if prior > 0 {
+		return nil, nil
+	}
+
+	return &models.FraudSignal{
+		Rule:   "high_value_first_order",
+		Score:  highValueFirstOrderScore,
+		Reason: fmt.Sprintf("first order of %s is at or above %s", check.Total, r.limit),
+	}, nil
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type GiftCardService interface {
+	IssueGiftCard(ctx context.Context, req models.IssueGiftCardRequest) (*models.GiftCard, error)
+	GetGiftCards(ctx context.Context, page, limit int) ([]models.GiftCard, int, error)
+	GetGiftCard(ctx context.Context, id uuid.UUID) (*models.GiftCardDetail, error)
+	CheckBalance(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCardBalance, error)
+	GetStoreCredit(ctx context.Context, userID uuid.UUID) (*models.GiftCardDetail, error)
+	RedeemForOrder(ctx context.Context, order *models.Order, code string, useStoreCredit bool) (money.Money, error)
+	RestoreForOrder(ctx context.Context, orderID uuid.UUID, amount money.Money, returnID *uuid.UUID) (money.Money, error)
+	CreditStoreCredit(ctx context.Context, userID uuid.UUID, amount money.Money, orderID, returnID *uuid.UUID) error
+}
+
+type giftCardService struct {
+	giftCardRepo repository.GiftCardRepository
+	orderRepo    repository.OrderRepository
+	txManager    database.TxManager
+}
+
+func NewGiftCardService(giftCardRepo repository.GiftCardRepository, orderRepo repository.OrderRepository, txManager database.TxManager) GiftCardService {
+	return &giftCardService{
+		giftCardRepo: giftCardRepo,
+		orderRepo:    orderRepo,
+		txManager:    txManager,
+	}
+}
+
+func (s *giftCardService) IssueGiftCard(ctx context.Context, req models.IssueGiftCardRequest) (*models.GiftCard, error) {
+	if !req.Amount.IsPositive() {
+		return nil, apperrors.Validation("gift card amount must be greater than zero")
+	}
+
+	code, err := generateGiftCardCode("GC")
+	if err != nil {
+		return nil, err
+	}
+
+	card := &models.GiftCard{
+		Code:           code,
+		Kind:           models.GiftCardKindGiftCard,
+		UserID:         req.UserID,
+		InitialBalance: req.Amount,
+		ExpiresAt:      req.ExpiresAt,
+		Note:           req.Note,
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.giftCardRepo.Create(ctx, card); err != nil {
+			return err
+		}
+
+		return s.giftCardRepo.AddTransaction(ctx, &models.GiftCardTransaction{
+			GiftCardID: card.ID,
+			Type:       models.GiftCardIssue,
+			Amount:     req.Amount,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue gift card: %w", err)
+	}
+
+	return card, nil
+}
+
+func (s *giftCardService) GetGiftCards(ctx context.Context, page, limit int) ([]models.GiftCard, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return s.giftCardRepo.GetAll(ctx, page, limit)
+}
+
+func (s *giftCardService) GetGiftCard(ctx context.Context, id uuid.UUID) (*models.GiftCardDetail, error) {
+	card, err := s.giftCardRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		return nil, apperrors.NotFound("gift card not found")
+	}
+
+	return s.withTransactions(ctx, card)
+}
+
+// CheckBalance looks up a gift card by code. Cards assigned to another
+// customer are reported as not found.
+func (s *giftCardService) CheckBalance(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCardBalance, error) {
+	card, err := s.findRedeemable(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GiftCardBalance{
+		Code:      card.Code,
+		Balance:   card.Balance,
+		ExpiresAt: card.ExpiresAt,
+	}, nil
+}
+
+func (s *giftCardService) GetStoreCredit(ctx context.Context, userID uuid.UUID) (*models.GiftCardDetail, error) {
+	card, err := s.storeCredit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withTransactions(ctx, card)
+}
+
+// RedeemForOrder applies store credit and then the gift card to the order
+// total and records the tendered amount on the order. It must run inside the
+// transaction that created the order.
+func (s *giftCardService) RedeemForOrder(ctx context.Context, order *models.Order, code string, useStoreCredit bool) (money.Money, error) {
+	var cards []*models.GiftCard
+
+	if useStoreCredit {
+		card, err := s.storeCredit(ctx, order.UserID)
+		if err != nil {
+			return 0, err
+		}
+		cards = append(cards, card)
+	}
+
+	if code != "" {
+		card, err := s.findRedeemable(ctx, order.UserID, code)
+		if err != nil {
+			return 0, err
+		}
+		if card.Kind == models.GiftCardKindStoreCredit && useStoreCredit {
+			return 0, apperrors.Conflict("store credit is already applied")
+		}
+		if !card.Balance.IsPositive() {
+			return 0, apperrors.Validation("gift card has no remaining balance")
+		}
+		cards = append(cards, card)
+	}
+
+	var applied money.Money
+	for _, card := range cards {
+		remaining := order.TotalAmount.Sub(applied)
+		if !remaining.IsPositive() {
+			break
+		}
+
+		amount := card.Balance
+		if amount > remaining {
+			amount = remaining
+		}
+		if !amount.IsPositive() {
+			continue
+		}
+
+		if err := s.giftCardRepo.Debit(ctx, card.ID, amount); err != nil {
+			return 0, err
+		}
+
+		err := s.giftCardRepo.AddTransaction(ctx, &models.GiftCardTransaction{
+			GiftCardID: card.ID,
+			Type:       models.GiftCardRedeem,
+			Amount:     amount.Neg(),
+			OrderID:    &order.ID,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		applied = applied.Add(amount)
+	}
+
+	if applied.IsPositive() {
+		if err := s.orderRepo.SetGiftCardAmount(ctx, order.ID, applied); err != nil {
+			return 0, err
+		}
+	}
+	order.GiftCardAmount = applied
+
+	return applied, nil
+}
+
+// RestoreForOrder puts up to amount of an order's gift card tender back on
+// the cards it came from, e.g. when the order is cancelled or refunded.
+// Returns the amount restored.
+func (s *giftCardService) RestoreForOrder(ctx context.Context, orderID uuid.UUID, amount money.Money, returnID *uuid.UUID) (money.Money, error) {
+	var restored money.Money
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		redemptions, err := s.giftCardRepo.GetOrderRedemptions(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		for cardID, outstanding := range redemptions {
+			remaining := amount.Sub(restored)
+			if !remaining.IsPositive() {
+				break
+			}
+
+			credit := outstanding
+			if credit > remaining {
+				credit = remaining
+			}
+
+			if err := s.giftCardRepo.Credit(ctx, cardID, credit); err != nil {
+				return err
+			}
+
+			err := s.giftCardRepo.AddTransaction(ctx, &models.GiftCardTransaction{
+				GiftCardID: cardID,
+				Type:       models.GiftCardReversal,
+				Amount:     credit,
+				OrderID:    &orderID,
+				ReturnID:   returnID,
+			})
+			if err != nil {
+				return err
+			}
+
+			restored = restored.Add(credit)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore gift card balance: %w", err)
+	}
+
+	return restored, nil
+}
+
+// CreditStoreCredit adds amount to the user's store credit, opening the
+// account if needed
+func (s *giftCardService) CreditStoreCredit(ctx context.Context, userID uuid.UUID, amount money.Money, orderID, returnID *uuid.UUID) error {
+	if !amount.IsPositive() {
+		return apperrors.Validation("store credit amount must be greater than zero")
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		card, err := s.storeCredit(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.giftCardRepo.Credit(ctx, card.ID, amount); err != nil {
+			return err
+		}
+
+		return s.giftCardRepo.AddTransaction(ctx, &models.GiftCardTransaction{
+			GiftCardID: card.ID,
+			Type:       models.GiftCardRefundCredit,
+			Amount:     amount,
+			OrderID:    orderID,
+			ReturnID:   returnID,
+		})
+	})
+}
+
+func (s *giftCardService) storeCredit(ctx context.Context, userID uuid.UUID) (*models.GiftCard, error) {
+	code, err := generateGiftCardCode("SC")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.giftCardRepo.GetOrCreateStoreCredit(ctx, userID, code)
+}
+
+// findRedeemable returns the card for code if userID may redeem it
+func (s *giftCardService) findRedeemable(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCard, error) {
+	card, err := s.giftCardRepo.GetByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	if card == nil || (card.UserID != nil && *card.UserID != userID) {
+		return nil, apperrors.NotFound("gift card not found")
+	}
+	if card.Expired() {
+		return nil, apperrors.Validation("gift card has expired")
+	}
+
+	return card, nil
+}
+
+func (s *giftCardService) withTransactions(ctx context.Context, card *models.GiftCard) (*models.GiftCardDetail, error) {
+	transactions, err := s.giftCardRepo.GetTransactions(ctx, card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GiftCardDetail{GiftCard: *card, Transactions: transactions}, nil
+}
+
+// giftCardAlphabet leaves out characters that are easy to misread
+const giftCardAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func generateGiftCardCode(prefix string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate gift card code: %w", err)
+	}
+
+	code := make([]byte, len(buf))
+	for i, b := range buf {
+		code[i] = giftCardAlphabet[int(b)%len(giftCardAlphabet)]
+	}
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", prefix, code[0:4], code[4:8], code[8:12], code[12:16]), nil
+}
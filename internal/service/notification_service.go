@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	Notify(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title, message string, data map[string]interface{}) error
+	GetUserNotifications(ctx context.Context, userID uuid.UUID, page, limit int, unreadOnly bool) ([]models.Notification, int, int, error)
+	MarkAsRead(ctx context.Context, notificationID, userID uuid.UUID) (*models.Notification, error)
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &notificationService{notificationRepo: notificationRepo}
+}
+
+// Notify adds an entry to the user's notification center. It joins the
+// caller's transaction, so call it alongside the change being announced.
+func (s *notificationService) Notify(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title, message string, data map[string]interface{}) error {
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	notification := &models.Notification{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		Data:    data,
+	}
+
+	return s.notificationRepo.Create(ctx, notification)
+}
+
+func (s *notificationService) GetUserNotifications(ctx context.Context, userID uuid.UUID, page, limit int, unreadOnly bool) ([]models.Notification, int, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	return s.notificationRepo.GetByUserID(ctx, userID, page, limit, unreadOnly)
+}
+
+func (s *notificationService) MarkAsRead(ctx context.Context, notificationID, userID uuid.UUID) (*models.Notification, error) {
+	notification, err := s.notificationRepo.MarkRead(ctx, notificationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if notification == nil {
+		return nil, apperrors.NotFound("notification not found")
+	}
+
+	return notification, nil
+}
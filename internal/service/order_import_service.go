@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// maxOrderImportRows caps the item rows of one CSV order import
+const maxOrderImportRows = 10000
+
+// orderImportColumns are the columns of an order import CSV, one row per
+// item. Order fields are read from the first row of each order number.
+var orderImportColumns = []string{
+	"order_number", "customer_email", "status", "payment_method", "shipping_method",
+	"total_amount", "created_at", "updated_at",
+	"ship_full_name", "ship_street", "ship_city", "ship_state", "ship_country", "ship_postal_code", "ship_phone",
+	"sku", "quantity", "price",
+}
+
+var requiredOrderImportColumns = []string{"order_number", "customer_email", "status", "payment_method", "created_at", "sku", "quantity", "price"}
+
+// OrderImportService migrates historical orders from a legacy platform. Each
+// order is imported or rejected on its own, and stock is left untouched.
+type OrderImportService interface {
+	ImportOrders(ctx context.Context, orders []models.ImportOrder) (*models.OrderImportResult, error)
+	ImportOrdersCSV(ctx context.Context, src io.Reader) (*models.OrderImportResult, error)
+}
+
+type orderImportService struct {
+	orderRepo   repository.OrderRepository
+	userRepo    repository.UserRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.VariantRepository
+}
+
+func NewOrderImportService(orderRepo repository.OrderRepository, userRepo repository.UserRepository, productRepo repository.ProductRepository, variantRepo repository.VariantRepository) OrderImportService {
+	return &orderImportService{
+		orderRepo:   orderRepo,
+		userRepo:    userRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+	}
+}
+
+// importedItem is a SKU resolved to the store's product and variant
+type importedItem struct {
+	productID uuid.UUID
+	variantID *uuid.UUID
+}
+
+// orderImport is the state of one import run
+type orderImport struct {
+	result *models.OrderImportResult
+	skus   map[string]*importedItem
+}
+
+func (s *orderImportService) ImportOrders(ctx context.Context, orders []models.ImportOrder) (*models.OrderImportResult, error) {
+	run := newOrderImport()
+	for i, order := range orders {
+		if err := s.importOrder(ctx, run, order, i+1); err != nil {
+			return nil, err
+		}
+	}
+	return run.result, nil
+}
+
+// ImportOrdersCSV imports a CSV with one row per item. Rows are grouped by
+// order number and errors refer to the first row of the order, or to the row
+// of the item at fault.
+func (s *orderImportService) ImportOrdersCSV(ctx context.Context, src io.Reader) (*models.OrderImportResult, error) {
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, apperrors.Validation("file is empty")
+	}
+	if err != nil {
+		return nil, apperrors.Validationf("failed to read header: %v", err)
+	}
+
+	columns, err := mapOrderImportColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders keep the order of their first rows
+	var orders []*csvImportOrder
+	byNumber := make(map[string]*csvImportOrder)
+	rowCount := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, apperrors.Validationf("failed to read file: %v", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		rowCount++
+		if rowCount > maxOrderImportRows {
+			return nil, apperrors.Validationf("an import holds at most %d rows", maxOrderImportRows)
+		}
+
+		row, _ := reader.FieldPos(0)
+		get := func(field string) string {
+			idx, ok := columns[field]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		// Rows without an order number cannot be grouped and fail alone
+		number := get("order_number")
+		entry := byNumber[number]
+		if entry == nil || number == "" {
+			order, orderErrors := parseOrderImportRow(get, row)
+			entry = &csvImportOrder{order: order, row: row, errors: orderErrors}
+			orders = append(orders, entry)
+			if number != "" {
+				byNumber[number] = entry
+			}
+		}
+
+		item, itemErrors := parseOrderImportItem(get, row)
+		entry.order.Items = append(entry.order.Items, item)
+		entry.errors = append(entry.errors, itemErrors...)
+	}
+
+	run := newOrderImport()
+	for _, entry := range orders {
+		// A bad row fails its whole order
+		if len(entry.errors) > 0 {
+			run.result.TotalOrders++
+			run.fail(entry.errors)
+			continue
+		}
+		if err := s.importOrder(ctx, run, entry.order, entry.row); err != nil {
+			return nil, err
+		}
+	}
+
+	return run.result, nil
+}
+
+// csvImportOrder is an order gathered from the rows of an import CSV
+type csvImportOrder struct {
+	order  models.ImportOrder
+	row    int
+	errors []models.ImportRowError
+}
+
+func newOrderImport() *orderImport {
+	return &orderImport{
+		result: &models.OrderImportResult{RowErrors: []models.ImportRowError{}},
+		skus:   make(map[string]*importedItem),
+	}
+}
+
+// fail records the errors of one order
+func (run *orderImport) fail(rowErrors []models.ImportRowError) {
+	for _, rowError := range rowErrors {
+		if len(run.result.RowErrors) < maxImportRowErrors {
+			run.result.RowErrors = append(run.result.RowErrors, rowError)
+		}
+	}
+	run.result.FailedCount++
+}
+
+// importOrder validates and saves one order, recording why it was rejected.
+// Only unexpected errors are returned.
+func (s *orderImportService) importOrder(ctx context.Context, run *orderImport, req models.ImportOrder, row int) error {
+	run.result.TotalOrders++
+
+	var rowErrors []models.ImportRowError
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, models.ImportRowError{Row: row, OrderNumber: req.OrderNumber, Field: field, Message: message})
+	}
+
+	if errs := utils.ValidateStruct(req); errs != nil {
+		fields := make([]string, 0, len(errs))
+		for field := range errs {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fail(field, errs[field])
+		}
+		run.fail(rowErrors)
+		return nil
+	}
+
+	if req.CreatedAt.After(time.Now()) {
+		fail("created_at", "Created at cannot be in the future")
+	}
+
+	customer, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(req.CustomerEmail))
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		fail("customer_email", "No customer with this email; import customers first")
+	}
+
+	order := &models.Order{
+		ID:              uuid.New(),
+		OrderNumber:     req.OrderNumber,
+		Status:          req.Status,
+		PaymentMethod:   req.PaymentMethod,
+		ShippingMethod:  req.ShippingMethod,
+		ShippingAddress: req.ShippingAddress,
+		BillingAddress:  req.ShippingAddress,
+		Items:           make([]models.OrderItem, 0, len(req.Items)),
+		CreatedAt:       req.CreatedAt,
+		UpdatedAt:       req.CreatedAt,
+	}
+	if customer != nil {
+		order.UserID = customer.ID
+	}
+	if order.ShippingMethod == "" {
+		order.ShippingMethod = models.ShippingStandard
+	}
+	if req.BillingAddress != nil {
+		order.BillingAddress = *req.BillingAddress
+	}
+	if req.UpdatedAt != nil {
+		order.UpdatedAt = *req.UpdatedAt
+	}
+
+	fulfillment := importedFulfillmentStatus(req.Status)
+	var itemsTotal money.Money
+	for i, reqItem := range req.Items {
+		item, err := s.resolveSKU(ctx, run, reqItem.SKU)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			fail(fmt.Sprintf("items[%d].sku", i), "Unknown SKU "+reqItem.SKU)
+			continue
+		}
+
+		order.Items = append(order.Items, models.OrderItem{
+			ProductID:         item.productID,
+			VariantID:         item.variantID,
+			Quantity:          reqItem.Quantity,
+			PriceAtTime:       reqItem.Price,
+			FulfillmentStatus: fulfillment,
+		})
+		itemsTotal = itemsTotal.Add(reqItem.Price.Mul(reqItem.Quantity))
+	}
+
+	order.TotalAmount = itemsTotal
+	if req.TotalAmount != nil {
+		order.TotalAmount = *req.TotalAmount
+	}
+
+	if len(rowErrors) > 0 {
+		run.fail(rowErrors)
+		return nil
+	}
+
+	if err := s.orderRepo.Import(ctx, order); err != nil {
+		if !errors.Is(err, apperrors.ErrConflict) {
+			return err
+		}
+		fail("order_number", "Order number already exists")
+		run.fail(rowErrors)
+		return nil
+	}
+
+	run.result.ImportedCount++
+	return nil
+}
+
+// resolveSKU finds the store's product or variant with sku, or nil
+func (s *orderImportService) resolveSKU(ctx context.Context, run *orderImport, sku string) (*importedItem, error) {
+	if item, ok := run.skus[sku]; ok {
+		return item, nil
+	}
+
+	var item *importedItem
+	product, err := s.productRepo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	if product != nil {
+		item = &importedItem{productID: product.ID}
+	} else {
+		variant, err := s.variantRepo.GetBySKU(ctx, sku)
+		if err != nil {
+			return nil, err
+		}
+		if variant != nil {
+			item = &importedItem{productID: variant.ProductID, variantID: &variant.ID}
+		}
+	}
+
+	// SKU lookups span stores; the product must belong to this one
+	if item != nil {
+		product, err := s.productRepo.GetByID(ctx, item.productID)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			item = nil
+		}
+	}
+
+	run.skus[sku] = item
+	return item, nil
+}
+
+// importedFulfillmentStatus is the fulfillment status of the items of an
+// imported order, in line with its status
+func importedFulfillmentStatus(status models.OrderStatus) models.FulfillmentStatus {
+	switch status {
+	case models.OrderShipped:
+		return models.FulfillmentShipped
+	case models.OrderDelivered, models.OrderCompleted, models.OrderRefunded:
+		return models.FulfillmentDelivered
+	case models.OrderCancelled:
+		return models.FulfillmentCancelled
+	}
+	return models.FulfillmentPending
+}
+
+func mapOrderImportColumns(header []string) (map[string]int, error) {
+	known := make(map[string]bool, len(orderImportColumns))
+	for _, name := range orderImportColumns {
+		known[name] = true
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, exists := columns[key]; known[key] && !exists {
+			columns[key] = i
+		}
+	}
+
+	var missing []string
+	for _, field := range requiredOrderImportColumns {
+		if _, ok := columns[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, apperrors.Validationf("missing required columns: %s", strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// parseOrderImportRow reads the order fields of a CSV row; the rest are
+// checked with the order
+func parseOrderImportRow(get func(string) string, row int) (models.ImportOrder, []models.ImportRowError) {
+	order := models.ImportOrder{
+		OrderNumber:    get("order_number"),
+		CustomerEmail:  get("customer_email"),
+		Status:         models.OrderStatus(strings.ToLower(get("status"))),
+		PaymentMethod:  strings.ToLower(get("payment_method")),
+		ShippingMethod: models.ShippingMethod(strings.ToLower(get("shipping_method"))),
+		ShippingAddress: models.Address{
+			FullName:   get("ship_full_name"),
+			Street:     get("ship_street"),
+			City:       get("ship_city"),
+			State:      get("ship_state"),
+			Country:    get("ship_country"),
+			PostalCode: get("ship_postal_code"),
+			Phone:      get("ship_phone"),
+		},
+	}
+
+	var rowErrors []models.ImportRowError
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, models.ImportRowError{Row: row, OrderNumber: order.OrderNumber, Field: field, Message: message})
+	}
+
+	if order.OrderNumber == "" {
+		fail("order_number", "Order number is required")
+	}
+
+	if raw := get("total_amount"); raw != "" {
+		total, err := money.Parse(raw)
+		if err != nil {
+			fail("total_amount", "Total amount must be a number with at most 2 decimal places")
+		} else {
+			order.TotalAmount = &total
+		}
+	}
+
+	if raw := get("created_at"); raw != "" {
+		createdAt, ok := parseImportTime(raw)
+		if !ok {
+			fail("created_at", importTimeMessage)
+		} else {
+			order.CreatedAt = createdAt
+		}
+	}
+
+	if raw := get("updated_at"); raw != "" {
+		updatedAt, ok := parseImportTime(raw)
+		if !ok {
+			fail("updated_at", importTimeMessage)
+		} else {
+			order.UpdatedAt = &updatedAt
+		}
+	}
+
+	return order, rowErrors
+}
+
+func parseOrderImportItem(get func(string) string, row int) (models.ImportOrderItem, []models.ImportRowError) {
+	item := models.ImportOrderItem{SKU: get("sku")}
+
+	var rowErrors []models.ImportRowError
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, models.ImportRowError{Row: row, OrderNumber: get("order_number"), SKU: item.SKU, Field: field, Message: message})
+	}
+
+	quantity, err := strconv.Atoi(get("quantity"))
+	if err != nil {
+		fail("quantity", "Quantity must be a whole number")
+	}
+	item.Quantity = quantity
+
+	price, err := money.Parse(get("price"))
+	if err != nil {
+		fail("price", "Price must be a number with at most 2 decimal places")
+	}
+	item.Price = price
+
+	return item, rowErrors
+}
+
+const importTimeMessage = "Timestamp must be RFC 3339 or YYYY-MM-DD[ HH:MM:SS]"
+
+// parseImportTime accepts RFC 3339 timestamps, or dates and times without a
+// zone, read as UTC
+func parseImportTime(raw string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+// messagePreviewLength caps the message text carried in events
+const messagePreviewLength = 140
+
+type OrderMessageService interface {
+	GetThread(ctx context.Context, orderID, userID uuid.UUID, markRead bool) (*models.OrderMessageThread, error)
+	PostMessage(ctx context.Context, orderID, userID uuid.UUID, body string) (*models.OrderMessage, error)
+	GetUnread(ctx context.Context, userID uuid.UUID) ([]models.UnreadOrderMessages, error)
+	GetThreadAdmin(ctx context.Context, orderID uuid.UUID) (*models.OrderMessageThread, error)
+	Reply(ctx context.Context, orderID, adminID uuid.UUID, body string) (*models.OrderMessage, error)
+	GetSupportQueue(ctx context.Context) ([]models.UnreadOrderMessages, error)
+}
+
+type orderMessageService struct {
+	messageRepo     repository.OrderMessageRepository
+	orderRepo       repository.OrderRepository
+	txManager       database.TxManager
+	publisher       events.Publisher
+	notificationSvc NotificationService
+}
+
+func NewOrderMessageService(
+	messageRepo repository.OrderMessageRepository,
+	orderRepo repository.OrderRepository,
+	txManager database.TxManager,
+	publisher events.Publisher,
+	notificationSvc NotificationService,
+) OrderMessageService {
+	return &orderMessageService{
+		messageRepo:     messageRepo,
+		orderRepo:       orderRepo,
+		txManager:       txManager,
+		publisher:       publisher,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// GetThread returns the customer's view of an order's messages. With markRead
+// the support replies in it are marked read; impersonated views leave them
+// for the customer.
+func (s *orderMessageService) GetThread(ctx context.Context, orderID, userID uuid.UUID, markRead bool) (*models.OrderMessageThread, error) {
+	order, err := s.customerOrder(ctx, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.thread(ctx, order, true, markRead)
+}
+
+// PostMessage adds a customer question to their order and announces it to
+// support through an OrderMessagePosted event
+func (s *orderMessageService) PostMessage(ctx context.Context, orderID, userID uuid.UUID, body string) (*models.OrderMessage, error) {
+	order, err := s.customerOrder(ctx, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &models.OrderMessage{
+		ID:       uuid.New(),
+		OrderID:  order.ID,
+		SenderID: &userID,
+		Body:     body,
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.messageRepo.Create(ctx, message); err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, events.OrderMessagePosted, events.AggregateOrder, order.ID, events.OrderMessagePostedPayload{
+			MessageID:   message.ID,
+			OrderID:     order.ID,
+			OrderNumber: order.OrderNumber,
+			UserID:      userID,
+			Preview:     preview(body),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// GetUnread lists the customer's orders with support replies they have not
+// read
+func (s *orderMessageService) GetUnread(ctx context.Context, userID uuid.UUID) ([]models.UnreadOrderMessages, error) {
+	return s.messageRepo.GetUnread(ctx, &userID, true)
+}
+
+// GetThreadAdmin returns an order's messages for support and marks the
+// customer's messages in it read
+func (s *orderMessageService) GetThreadAdmin(ctx context.Context, orderID uuid.UUID) (*models.OrderMessageThread, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	return s.thread(ctx, order, false, true)
+}
+
+// Reply adds a support message to the order and notifies the customer
+func (s *orderMessageService) Reply(ctx context.Context, orderID, adminID uuid.UUID, body string) (*models.OrderMessage, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	message := &models.OrderMessage{
+		ID:        uuid.New(),
+		OrderID:   order.ID,
+		SenderID:  &adminID,
+		FromStaff: true,
+		Body:      body,
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.messageRepo.Create(ctx, message); err != nil {
+			return err
+		}
+
+		return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationOrderReply,
+			"New message about your order",
+			fmt.Sprintf("Support replied on order %s: %s", order.OrderNumber, preview(body)),
+			map[string]interface{}{"order_id": order.ID, "message_id": message.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// GetSupportQueue lists orders with customer messages no one in support has
+// read, most recent first
+func (s *orderMessageService) GetSupportQueue(ctx context.Context) ([]models.UnreadOrderMessages, error) {
+	return s.messageRepo.GetUnread(ctx, nil, false)
+}
+
+func (s *orderMessageService) customerOrder(ctx context.Context, orderID, userID uuid.UUID) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+	if order.UserID != userID {
+		return nil, apperrors.Forbidden("unauthorized to view this order")
+	}
+	return order, nil
+}
+
+// thread loads the order's messages and counts those from the other side
+// (support when unreadFromStaff) still unread, marking them read if asked
+func (s *orderMessageService) thread(ctx context.Context, order *models.Order, unreadFromStaff, markRead bool) (*models.OrderMessageThread, error) {
+	messages, err := s.messageRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	unread := 0
+	for _, message := range messages {
+		if message.FromStaff == unreadFromStaff && message.ReadAt == nil {
+			unread++
+		}
+	}
+
+	if markRead && unread > 0 {
+		if err := s.messageRepo.MarkRead(ctx, order.ID, unreadFromStaff); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.OrderMessageThread{
+		OrderID:     order.ID,
+		OrderNumber: order.OrderNumber,
+		Messages:    messages,
+		UnreadCount: unread,
+	}, nil
+}
+
+// preview shortens a message for notifications and events
+func preview(body string) string {
+	runes := []rune(body)
+	if len(runes) <= messagePreviewLength {
+		return body
+	}
+	return string(runes[:messagePreviewLength]) + "…"
+}
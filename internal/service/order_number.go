@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+)
+
+// maxOrderNumberAttempts bounds how many numbers an order tries before
+// checkout gives up; numbers are only taken twice by imported orders
+const maxOrderNumberAttempts = 5
+
+// OrderNumberGenerator hands out short order numbers customers can read out
+// over the phone
+type OrderNumberGenerator interface {
+	Next(ctx context.Context) (string, error)
+}
+
+// sequenceOrderNumbers numbers orders from a database sequence shared by all
+// stores, behind each store's prefix, e.g. ORD-100042
+type sequenceOrderNumbers struct {
+	orderRepo repository.OrderRepository
+}
+
+func NewOrderNumberGenerator(orderRepo repository.OrderRepository) OrderNumberGenerator {
+	return &sequenceOrderNumbers{orderRepo: orderRepo}
+}
+
+func (g *sequenceOrderNumbers) Next(ctx context.Context) (string, error) {
+	prefix, seq, err := g.orderRepo.NextOrderNumber(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate order number: %w", err)
+	}
+	return fmt.Sprintf("%s-%06d", prefix, seq), nil
+}
+
+// createNumbered numbers the order and saves it, drawing a new number while
+// the drawn one is already taken
+func (s *orderService) createNumbered(ctx context.Context, order *models.Order) error {
+	for attempt := 1; ; attempt++ {
+		number, err := s.orderNumbers.Next(ctx)
+		if err != nil {
+			return err
+		}
+		order.OrderNumber = number
+
+		err = s.orderRepo.Create(ctx, order)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, apperrors.ErrConflict) || attempt == maxOrderNumberAttempts {
+			return err
+		}
+		log.Printf("⚠️ Order number %s is taken, drawing another", number)
+	}
+}
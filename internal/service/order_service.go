@@ -2,12 +2,21 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"time"
 
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/events"
 	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/pagination"
 
 	"github.com/google/uuid"
 )
@@ -15,41 +24,188 @@ import (
 type OrderService interface {
 	CreateOrder(ctx context.Context, userID uuid.UUID, req models.CreateOrderRequest) (*models.Order, error)
 	GetOrder(ctx context.Context, orderID, userID uuid.UUID) (*models.Order, error)
-	GetUserOrders(ctx context.Context, userID uuid.UUID, page, limit int) ([]models.Order, int, error)
-	GetAllOrders(ctx context.Context, page, limit int, status string, rangeDays int) ([]models.AdminOrder, int, error)
+	GetUserOrders(ctx context.Context, userID uuid.UUID, page, limit int, includeItems bool) ([]models.Order, int, error)
+	GetUserOrdersPage(ctx context.Context, userID uuid.UUID, after *pagination.Cursor, limit int, includeItems bool) ([]models.Order, *pagination.Cursor, error)
+	GetAllOrders(ctx context.Context, page, limit int, filter models.OrderFilter) ([]models.AdminOrder, int, error)
+	ExportOrders(ctx context.Context, filter models.OrderFilter, fn func(models.OrderExportRow) error) error
 	GetOrderAdmin(ctx context.Context, orderID uuid.UUID) (*models.AdminOrder, error)
 	GetRecentOrders(ctx context.Context, limit, rangeDays int) ([]models.AdminOrder, error)
 	GetAnalytics(ctx context.Context, rangeDays int) (*models.AdminAnalytics, error)
+	GetCustomerAnalytics(ctx context.Context, rangeDays, topLimit int) (*models.CustomerAnalytics, error)
+	GetMarginReport(ctx context.Context, rangeDays int, period string) (*models.MarginReport, error)
+	GetPromotionAnalytics(ctx context.Context, rangeDays int) (*models.PromotionAnalytics, error)
 	UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error
+	BulkUpdateOrderStatus(ctx context.Context, req models.BulkUpdateOrderStatusRequest) (*models.BulkUpdateOrderStatusResponse, error)
 	CancelOrder(ctx context.Context, orderID, userID uuid.UUID) error
+	CancelUnpaidOrders(ctx context.Context) (int, error)
+	ExpireCheckoutAttempts(ctx context.Context) (int, error)
+	Register(bus *events.Bus)
 	ProcessOrderReturn(ctx context.Context, orderID uuid.UUID, returnID uuid.UUID) error
+	CreateReplacementOrder(ctx context.Context, original *models.Order, item models.OrderItem, variantID uuid.UUID) (*models.Order, error)
+	ConfirmCODDelivery(ctx context.Context, orderID uuid.UUID, otp string) error
+	UpdateItemFulfillment(ctx context.Context, orderID, itemID uuid.UUID, req models.UpdateItemFulfillmentRequest) (*models.Order, error)
+	GetReviewQueue(ctx context.Context, page, limit int) ([]models.ReviewQueueItem, int, error)
+	ReviewOrder(ctx context.Context, orderID, reviewerID uuid.UUID, req models.ReviewOrderRequest) (*models.Order, error)
+	PlaceHold(ctx context.Context, orderID, adminID uuid.UUID, req models.PlaceOrderHoldRequest) (*models.OrderHold, error)
+	ReleaseHold(ctx context.Context, orderID, adminID uuid.UUID, req models.ReleaseOrderHoldRequest) (*models.Order, error)
+	GetOrderHolds(ctx context.Context, orderID uuid.UUID) ([]models.OrderHold, error)
+	GetActiveHolds(ctx context.Context, status models.OrderStatus, page, limit int) ([]models.OrderHold, int, error)
+	GetPickList(ctx context.Context, filter models.PickListFilter) (*models.PickList, error)
+	GetPackingSlip(ctx context.Context, orderID uuid.UUID) (*models.PackingSlip, error)
 }
 
 type orderService struct {
-	orderRepo   repository.OrderRepository
-	cartRepo    repository.CartRepository
-	productRepo repository.ProductRepository
-	cartSvc     CartService
-	paymentSvc  PaymentService
+	orderRepo            repository.OrderRepository
+	cartRepo             repository.CartRepository
+	checkoutRepo         repository.CheckoutSessionRepository
+	productRepo          repository.ProductRepository
+	bundleRepo           repository.BundleRepository
+	userRepo             repository.UserRepository
+	cartSvc              CartService
+	paymentSvc           PaymentService
+	txManager            database.TxManager
+	publisher            events.Publisher
+	notificationSvc      NotificationService
+	backInStockSvc       BackInStockService
+	pricingSvc           PricingService
+	promotionSvc         PromotionService
+	giftCardSvc          GiftCardService
+	codSvc               CODService
+	warehouseSvc         WarehouseService
+	deliverySvc          DeliveryService
+	serviceabilitySvc    ServiceabilityService
+	paymentMethodSvc     PaymentMethodService
+	fraudSvc             FraudService
+	holdRepo             repository.OrderHoldRepository
+	orderNumbers         OrderNumberGenerator
+	requireVerifiedEmail bool
+	lowStockThreshold    int
+	cancelWindow         time.Duration
+	unpaidOrderTTL       time.Duration
+	authorizeCards       bool
+	authorizationTTL     time.Duration
+	autoCapture          bool
 }
 
 func NewOrderService(
 	orderRepo repository.OrderRepository,
 	cartRepo repository.CartRepository,
+	checkoutRepo repository.CheckoutSessionRepository,
 	productRepo repository.ProductRepository,
+	bundleRepo repository.BundleRepository,
+	userRepo repository.UserRepository,
 	cartSvc CartService,
 	paymentSvc PaymentService,
+	txManager database.TxManager,
+	publisher events.Publisher,
+	notificationSvc NotificationService,
+	backInStockSvc BackInStockService,
+	pricingSvc PricingService,
+	promotionSvc PromotionService,
+	giftCardSvc GiftCardService,
+	codSvc CODService,
+	warehouseSvc WarehouseService,
+	deliverySvc DeliveryService,
+	serviceabilitySvc ServiceabilityService,
+	paymentMethodSvc PaymentMethodService,
+	fraudSvc FraudService,
+	holdRepo repository.OrderHoldRepository,
+	orderNumbers OrderNumberGenerator,
+	requireVerifiedEmail bool,
+	lowStockThreshold int,
+	cancelWindow time.Duration,
+	unpaidOrderTTL time.Duration,
+	authorizeCards bool,
+	authorizationTTL time.Duration,
+	autoCapture bool,
 ) OrderService {
 	return &orderService{
-		orderRepo:   orderRepo,
-		cartRepo:    cartRepo,
-		productRepo: productRepo,
-		cartSvc:     cartSvc,
-		paymentSvc:  paymentSvc,
+		orderRepo:            orderRepo,
+		cartRepo:             cartRepo,
+		checkoutRepo:         checkoutRepo,
+		productRepo:          productRepo,
+		bundleRepo:           bundleRepo,
+		userRepo:             userRepo,
+		cartSvc:              cartSvc,
+		paymentSvc:           paymentSvc,
+		txManager:            txManager,
+		publisher:            publisher,
+		notificationSvc:      notificationSvc,
+		backInStockSvc:       backInStockSvc,
+		pricingSvc:           pricingSvc,
+		promotionSvc:         promotionSvc,
+		giftCardSvc:          giftCardSvc,
+		codSvc:               codSvc,
+		warehouseSvc:         warehouseSvc,
+		deliverySvc:          deliverySvc,
+		serviceabilitySvc:    serviceabilitySvc,
+		paymentMethodSvc:     paymentMethodSvc,
+		fraudSvc:             fraudSvc,
+		holdRepo:             holdRepo,
+		orderNumbers:         orderNumbers,
+		requireVerifiedEmail: requireVerifiedEmail,
+		lowStockThreshold:    lowStockThreshold,
+		cancelWindow:         cancelWindow,
+		unpaidOrderTTL:       unpaidOrderTTL,
+		authorizeCards:       authorizeCards,
+		authorizationTTL:     authorizationTTL,
+		autoCapture:          autoCapture,
 	}
 }
 
 func (s *orderService) CreateOrder(ctx context.Context, userID uuid.UUID, req models.CreateOrderRequest) (*models.Order, error) {
+	// Block unverified accounts when verification is enforced
+	if s.requireVerifiedEmail {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, apperrors.NotFound("user not found")
+		}
+		if !user.EmailVerified {
+			return nil, apperrors.Forbidden("email address must be verified before placing orders")
+		}
+	}
+
+	// A checkout session supplies what the customer reviewed: addresses,
+	// shipping method and, below, prices
+	var session *models.CheckoutSession
+	if req.CheckoutSessionID != nil {
+		var err error
+		session, err = loadCheckoutSession(ctx, s.checkoutRepo, userID, *req.CheckoutSessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session.Status != models.CheckoutSessionOpen {
+			return nil, apperrors.Conflictf("checkout session is %s; start checkout again", session.Status)
+		}
+		req.ShippingAddress = session.ShippingAddress
+		req.BillingAddress = session.BillingAddress
+		req.ShippingMethod = session.ShippingMethod
+	}
+
+	// Refuse addresses outside the serviceable areas before touching stock
+	if err := s.serviceabilitySvc.EnsureServiceable(ctx, req.ShippingAddress.PostalCode); err != nil {
+		return nil, err
+	}
+
+	// Resolve the saved card up front so an unusable one fails checkout early
+	var savedMethod *models.SavedPaymentMethod
+	if req.SavedPaymentMethodID != nil {
+		if req.PaymentMethod != "cc" && req.PaymentMethod != "dc" {
+			return nil, apperrors.Validation("saved payment methods can only be used for card payments")
+		}
+		method, err := s.paymentMethodSvc.GetPaymentMethod(ctx, userID, *req.SavedPaymentMethodID)
+		if err != nil {
+			return nil, err
+		}
+		if method.Type != req.PaymentMethod {
+			return nil, apperrors.Validationf("saved payment method is not a %s card", req.PaymentMethod)
+		}
+		savedMethod = method
+	}
+
 	// Get user's cart
 	cart, err := s.cartRepo.GetByUserID(ctx, userID)
 	if err != nil {
@@ -57,7 +213,7 @@ func (s *orderService) CreateOrder(ctx context.Context, userID uuid.UUID, req mo
 	}
 
 	if len(cart.Items) == 0 {
-		return nil, errors.New("cart is empty")
+		return nil, apperrors.Validation("cart is empty")
 	}
 
 	// Validate cart
@@ -67,52 +223,140 @@ func (s *orderService) CreateOrder(ctx context.Context, userID uuid.UUID, req mo
 	}
 
 	if !valid {
-		return nil, fmt.Errorf("cart validation failed: %v", validationErrors)
+		return nil, apperrors.Validationf("cart validation failed: %v", validationErrors)
 	}
 
-	// Start transaction
-	tx, err := s.orderRepo.BeginTx(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	// Re-check purchase limits; past orders may have changed since items were added
+	checked := make(map[uuid.UUID]bool)
+	for _, cartItem := range cart.Items {
+		if checked[cartItem.ProductID] {
+			continue
+		}
+		checked[cartItem.ProductID] = true
+
+		product, err := s.productRepo.GetByID(ctx, cartItem.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			return nil, apperrors.NotFound("product not found")
+		}
+		if product.Status != models.ProductActive {
+			return nil, apperrors.Conflict(product.Name + " is no longer available")
+		}
+
+		quantity := cartProductQuantity(cart.Items, cartItem.ProductID)
+		if err := checkPurchaseLimits(ctx, s.orderRepo, userID, product, quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	var promotions []models.AppliedPromotion
+	var discount money.Money
+	if session != nil {
+		// Charge the prices and promotions the session was started with
+		if err := applyCheckoutPrices(cart, session); err != nil {
+			return nil, err
+		}
+		promotions, discount = session.Totals.Promotions, session.Totals.Discount
+	} else {
+		// Charge any scheduled sale price in effect at checkout
+		if err := s.pricingSvc.ApplyToCartItems(ctx, cart.Items); err != nil {
+			return nil, fmt.Errorf("failed to apply prices: %w", err)
+		}
+
+		// Promotions are worked out on the same prices the items are charged at
+		promotions, discount, err = s.promotionSvc.Evaluate(ctx, userID, cart.Items)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply promotions: %w", err)
+		}
 	}
-	defer tx.Rollback(ctx)
 
 	// Calculate total and prepare order items
-	var totalAmount float64
+	var totalAmount money.Money
 	var orderItems []models.OrderItem
 
 	for _, cartItem := range cart.Items {
-		// Calculate item total
-		itemTotal := cartItem.Product.Price * float64(cartItem.Quantity)
-		totalAmount += itemTotal
+		// Calculate item total using the variant price when one is selected
+		unitPrice := cartItem.UnitPrice()
+		itemTotal := unitPrice.Mul(cartItem.Quantity)
+		totalAmount = totalAmount.Add(itemTotal)
 
 		// Prepare order item
 		orderItem := models.OrderItem{
-			ID:          uuid.New(),
-			ProductID:   cartItem.ProductID,
-			Product:     cartItem.Product,
-			Quantity:    cartItem.Quantity,
-			PriceAtTime: cartItem.Product.Price,
-			CreatedAt:   time.Now(),
+			ID:                uuid.New(),
+			ProductID:         cartItem.ProductID,
+			Product:           cartItem.Product,
+			VariantID:         cartItem.VariantID,
+			Variant:           cartItem.Variant,
+			Quantity:          cartItem.Quantity,
+			PriceAtTime:       unitPrice,
+			FulfillmentStatus: models.FulfillmentPending,
+			CreatedAt:         time.Now(),
 		}
-		orderItems = append(orderItems, orderItem)
 
-		// Deduct stock from inventory (within transaction)
-		err = s.productRepo.UpdateStockWithTx(ctx, tx, cartItem.ProductID, -cartItem.Quantity)
+		// Record what a bundle is made of as it is sold, so returns restock
+		// the same components
+		components, err := s.bundleRepo.GetComponents(ctx, cartItem.ProductID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to update stock for product %s: %w",
-				cartItem.ProductID, err)
+			return nil, err
+		}
+		for _, component := range components {
+			orderItem.BundleComponents = append(orderItem.BundleComponents, models.OrderItemComponent{
+				ProductID: component.ProductID,
+				VariantID: component.VariantID,
+				SKU:       component.SKU,
+				Name:      component.Name,
+				Quantity:  component.Quantity,
+			})
 		}
+		orderItems = append(orderItems, orderItem)
+	}
+	totalAmount = totalAmount.Sub(discount)
+
+	if err := s.cartSvc.EnsureMinimumOrder(ctx, totalAmount); err != nil {
+		return nil, err
+	}
+
+	shippingMethod := req.ShippingMethod
+	if shippingMethod == "" {
+		shippingMethod = models.ShippingStandard
+	}
+
+	// Risky orders are still placed, but wait in review until an admin
+	// decides on them
+	assessment, err := s.fraudSvc.Screen(ctx, models.FraudCheck{
+		UserID:          userID,
+		ClientIP:        req.ClientIP,
+		BillingCountry:  req.BillingAddress.Country,
+		ShippingCountry: req.ShippingAddress.Country,
+		Total:           totalAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// In authorize-then-capture mode a card order is only an attempt until
+	// its payment is authorized. Orders held for review are placed as usual,
+	// so the review decides on stock already committed.
+	attempt := s.authorizeCards && !assessment.Held && (req.PaymentMethod == "cc" || req.PaymentMethod == "dc")
+	status := models.OrderPending
+	if assessment.Held {
+		status = models.OrderUnderReview
+	} else if attempt {
+		status = models.OrderAwaitingPayment
 	}
 
 	// Create order
 	order := &models.Order{
 		ID:              uuid.New(),
 		UserID:          userID,
-		OrderNumber:     generateOrderNumber(),
 		TotalAmount:     totalAmount,
-		Status:          models.OrderPending,
+		DiscountAmount:  discount,
+		Promotions:      promotions,
+		Status:          status,
 		PaymentMethod:   req.PaymentMethod,
+		ShippingMethod:  shippingMethod,
 		ShippingAddress: req.ShippingAddress,
 		BillingAddress:  req.BillingAddress,
 		Items:           orderItems,
@@ -120,27 +364,122 @@ func (s *orderService) CreateOrder(ctx context.Context, userID uuid.UUID, req mo
 		UpdatedAt:       time.Now(),
 	}
 
-	// Create order in transaction
-	err = s.orderRepo.CreateWithTx(ctx, tx, order)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
-	}
+	// An attempt's stock is held until its payment is due to be authorized
+	holdUntil := time.Now().Add(s.authorizationTTL).Unix()
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// ValidateCart ran without locks, so a concurrent checkout may have
+		// taken the stock since. Hold every product of the order until commit;
+		// the deductions below then see the other checkout's result and fail
+		// instead of overselling.
+		if err := s.productRepo.LockStock(ctx, orderProductIDs(order.Items)); err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		if attempt {
+			// Hold the stock until the payment is authorized: the cart's
+			// reservations are renewed for the items it orders, then handed to
+			// the order once it exists
+			if err := s.productRepo.ReleaseCartReservations(ctx, cart.ID); err != nil {
+				return fmt.Errorf("failed to release stock reservations: %w", err)
+			}
+			for _, item := range order.Items {
+				if err := s.productRepo.SetReservationQuantity(ctx, item.ProductID, cart.ID, item.VariantID, item.Quantity, holdUntil); err != nil {
+					return fmt.Errorf("failed to reserve stock for product %s: %w", item.ProductID, err)
+				}
+			}
+		} else if err := s.commitStock(ctx, order, cart.ID); err != nil {
+			return err
+		}
+
+		if err := s.createNumbered(ctx, order); err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+
+		if attempt {
+			if err := s.productRepo.TransferReservationsToOrder(ctx, cart.ID, order.ID, holdUntil); err != nil {
+				return fmt.Errorf("failed to hold stock for order: %w", err)
+			}
+		}
+
+		// Only one order is placed per session, even on a double submit
+		if session != nil {
+			if err := s.checkoutRepo.Complete(ctx, session.ID, order.ID); err != nil {
+				return err
+			}
+		}
+
+		assessment.OrderID = order.ID
+		if err := s.fraudSvc.SaveAssessment(ctx, assessment); err != nil {
+			return err
+		}
+		if assessment.Held {
+			err := s.holdRepo.Create(ctx, &models.OrderHold{
+				OrderID:        order.ID,
+				Status:         models.OrderUnderReview,
+				PreviousStatus: models.OrderPending,
+				Reason:         fmt.Sprintf("fraud screening scored %d", assessment.Score),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Tender store credit and any gift card before the remaining balance
+		// goes to the chosen payment method
+		if req.GiftCardCode != "" || req.UseStoreCredit {
+			if _, err := s.giftCardSvc.RedeemForOrder(ctx, order, req.GiftCardCode, req.UseStoreCredit); err != nil {
+				return err
+			}
+		}
 
-	// Clear cart
-	err = s.cartSvc.ClearCart(ctx, userID)
+		// COD limits apply to the cash the courier has to collect
+		if collectsCashOnDelivery(order) {
+			eligibility := s.codSvc.CheckEligibility(ctx, order.AmountDue(), order.ShippingAddress.PostalCode)
+			if !eligibility.Eligible {
+				return apperrors.Validation(eligibility.Reason)
+			}
+		}
+
+		// Drop any reservations left over for items no longer in the cart
+		if err := s.productRepo.ReleaseCartReservations(ctx, cart.ID); err != nil {
+			return fmt.Errorf("failed to release stock reservations: %w", err)
+		}
+
+		// Clear cart
+		if err := s.cartRepo.ClearCart(ctx, cart.ID); err != nil {
+			return fmt.Errorf("failed to clear cart: %w", err)
+		}
+
+		// An attempt is confirmed once its payment is authorized
+		if attempt {
+			return nil
+		}
+		return s.confirmOrder(ctx, order)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to clear cart: %w", err)
+		return nil, err
 	}
 
-	// Commit transaction
-	err = tx.Commit(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	// Orders covered in full by gift cards are paid already. An attempt's
+	// tender is only authorized, so it is finalized like any other.
+	if order.AmountDue().IsZero() {
+		if attempt {
+			if _, err := s.paymentSvc.CreatePaymentForOrder(ctx, order.ID, "gift_card", models.PaymentAuthorized); err != nil {
+				return nil, err
+			}
+			return order, nil
+		}
+		if _, err := s.paymentSvc.CreatePaymentForOrder(ctx, order.ID, "gift_card", models.PaymentCompleted); err != nil {
+			return nil, err
+		}
+		order.Status = models.OrderProcessing
+		return order, nil
 	}
 
-	// Create payment immediately for card payments
+	// Start payment immediately for card payments
 	if req.PaymentMethod == "cc" || req.PaymentMethod == "dc" {
-		_, err = s.paymentSvc.CreatePaymentForOrder(ctx, order.ID, req.PaymentMethod, models.PaymentCompleted)
+		_, err = s.paymentSvc.InitiateCardPayment(ctx, order.ID, req.PaymentMethod, savedMethod)
 		if err != nil {
 			return nil, err
 		}
@@ -149,10 +488,152 @@ func (s *orderService) CreateOrder(ctx context.Context, userID uuid.UUID, req mo
 	return order, nil
 }
 
-func generateOrderNumber() string {
-	timestamp := time.Now().Unix()
-	random := uuid.New().String()[:8]
-	return fmt.Sprintf("ORD-%d-%s", timestamp, random)
+// commitStock deducts the order's items from stock, converting the cart's
+// reservations of them so the reserved units are not counted twice until the
+// reservation expires. A bundle's reservation holds its components, which are
+// deducted in its place. Run it inside a transaction holding the stock locks.
+func (s *orderService) commitStock(ctx context.Context, order *models.Order, cartID uuid.UUID) error {
+	for _, item := range order.Items {
+		if item.IsBundle() {
+			if err := s.productRepo.ReleaseStockReservation(ctx, item.ProductID, cartID, nil); err != nil {
+				return fmt.Errorf("failed to release stock reservation: %w", err)
+			}
+		}
+
+		for _, unit := range item.StockUnits() {
+			remaining, err := s.productRepo.CommitReservation(ctx, unit.ProductID, cartID, unit.VariantID, unit.Quantity)
+			if err != nil {
+				return fmt.Errorf("failed to update stock for product %s: %w",
+					unit.ProductID, err)
+			}
+
+			// Only alert when this order is the one that crossed the threshold
+			if remaining <= s.lowStockThreshold && remaining+unit.Quantity > s.lowStockThreshold {
+				err := s.publisher.Publish(ctx, events.StockLow, events.AggregateProduct, unit.ProductID, events.StockLowPayload{
+					ProductID:   unit.ProductID,
+					VariantID:   unit.VariantID,
+					ProductName: unit.Name,
+					Stock:       remaining,
+					Threshold:   s.lowStockThreshold,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// confirmOrder allocates a placed order to warehouses, promises a delivery
+// date and tells the customer and subscribers it was placed. Run it inside
+// the transaction that committed the order's stock.
+func (s *orderService) confirmOrder(ctx context.Context, order *models.Order) error {
+	// Pick the warehouses the items ship from now the address is known
+	if err := s.warehouseSvc.AllocateOrder(ctx, order); err != nil {
+		return err
+	}
+
+	// Promise a delivery date from the warehouses the items ship from
+	estimate, err := s.deliverySvc.EstimateOrder(ctx, order)
+	if err != nil {
+		return err
+	}
+	if err := s.orderRepo.SetEstimatedDelivery(ctx, order.ID, estimate.EstimatedDelivery); err != nil {
+		return err
+	}
+	order.EstimatedDelivery = &estimate.EstimatedDelivery
+
+	err = s.publisher.Publish(ctx, events.OrderCreated, events.AggregateOrder, order.ID, events.OrderCreatedPayload{
+		OrderID:       order.ID,
+		OrderNumber:   order.OrderNumber,
+		UserID:        order.UserID,
+		TotalAmount:   order.TotalAmount,
+		PaymentMethod: order.PaymentMethod,
+		CreatedAt:     order.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationOrderStatus,
+		"Order placed",
+		fmt.Sprintf("We've received your order %s.", order.OrderNumber),
+		map[string]interface{}{"order_id": order.ID, "status": order.Status})
+}
+
+// Register finalizes checkout attempts once their payment is authorized
+func (s *orderService) Register(bus *events.Bus) {
+	bus.Subscribe(events.PaymentAuthorized, func(ctx context.Context, event models.OutboxEvent) error {
+		var payload events.PaymentAuthorizedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+		}
+
+		return s.finalizeAuthorizedOrder(ctx, payload.OrderID)
+	})
+}
+
+// finalizeAuthorizedOrder turns an attempt whose payment was authorized into a
+// placed order and captures the payment. The stock it held is committed
+// first; when it is no longer there, e.g. because the hold expired before the
+// customer authorized, the payment is voided and the attempt cancelled
+// instead. A capture that fails is retried with the event. With automatic
+// capture off the payment stays authorized for an admin to capture.
+func (s *orderService) finalizeAuthorizedOrder(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil || order == nil {
+		return err
+	}
+
+	if order.Status == models.OrderAwaitingPayment {
+		err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := s.orderRepo.UpdateStatusFrom(ctx, order.ID, models.OrderAwaitingPayment, models.OrderPending); err != nil {
+				return err
+			}
+
+			if err := s.productRepo.LockStock(ctx, orderProductIDs(order.Items)); err != nil {
+				return fmt.Errorf("failed to lock stock: %w", err)
+			}
+
+			// The order's own holds must not count against it
+			if err := s.productRepo.ReleaseOrderReservations(ctx, order.ID); err != nil {
+				return fmt.Errorf("failed to release stock reservations: %w", err)
+			}
+			if err := s.commitStock(ctx, order, uuid.Nil); err != nil {
+				return err
+			}
+
+			order.Status = models.OrderPending
+			return s.confirmOrder(ctx, order)
+		})
+		if errors.Is(err, apperrors.ErrValidation) {
+			order.Status = models.OrderAwaitingPayment
+			return s.abandonAttempt(ctx, order)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Anything past pending was captured already, and a cancelled attempt
+	// has nothing left to capture
+	if order.Status != models.OrderPending || !s.autoCapture {
+		return nil
+	}
+
+	_, err = s.paymentSvc.CaptureOrderPayment(ctx, order.ID)
+	return err
+}
+
+// abandonAttempt cancels a checkout attempt that will not become an order,
+// voiding its payment so no funds stay held
+func (s *orderService) abandonAttempt(ctx context.Context, order *models.Order) error {
+	if err := s.paymentSvc.VoidOrderPayment(ctx, order.ID); err != nil {
+		return err
+	}
+	return s.cancelOrder(ctx, order)
 }
 
 func (s *orderService) GetOrder(ctx context.Context, orderID, userID uuid.UUID) (*models.Order, error) {
@@ -162,18 +643,20 @@ func (s *orderService) GetOrder(ctx context.Context, orderID, userID uuid.UUID)
 	}
 
 	if order == nil {
-		return nil, errors.New("order not found")
+		return nil, apperrors.NotFound("order not found")
 	}
 
 	// Check if user is authorized to view this order
 	if order.UserID != userID {
-		return nil, errors.New("unauthorized to view this order")
+		return nil, apperrors.Forbidden("unauthorized to view this order")
 	}
 
 	return order, nil
 }
 
-func (s *orderService) GetUserOrders(ctx context.Context, userID uuid.UUID, page, limit int) ([]models.Order, int, error) {
+// GetUserOrders returns a page of the user's orders. With includeItems each
+// order carries its items, loaded for the whole page in one query.
+func (s *orderService) GetUserOrders(ctx context.Context, userID uuid.UUID, page, limit int, includeItems bool) ([]models.Order, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -182,10 +665,40 @@ func (s *orderService) GetUserOrders(ctx context.Context, userID uuid.UUID, page
 		limit = 10
 	}
 
-	return s.orderRepo.GetByUserID(ctx, userID, page, limit)
+	orders, total, err := s.orderRepo.GetByUserID(ctx, userID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if includeItems {
+		if err := s.orderRepo.LoadItems(ctx, orders); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return orders, total, nil
+}
+
+func (s *orderService) GetUserOrdersPage(ctx context.Context, userID uuid.UUID, after *pagination.Cursor, limit int, includeItems bool) ([]models.Order, *pagination.Cursor, error) {
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	orders, next, err := s.orderRepo.GetPageByUserID(ctx, userID, after, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if includeItems {
+		if err := s.orderRepo.LoadItems(ctx, orders); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return orders, next, nil
 }
 
-func (s *orderService) GetAllOrders(ctx context.Context, page, limit int, status string, rangeDays int) ([]models.AdminOrder, int, error) {
+func (s *orderService) GetAllOrders(ctx context.Context, page, limit int, filter models.OrderFilter) ([]models.AdminOrder, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -194,7 +707,32 @@ func (s *orderService) GetAllOrders(ctx context.Context, page, limit int, status
 		limit = 10
 	}
 
-	return s.orderRepo.GetAll(ctx, page, limit, status, rangeDays)
+	if err := validateOrderFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	return s.orderRepo.GetAll(ctx, page, limit, filter)
+}
+
+func (s *orderService) ExportOrders(ctx context.Context, filter models.OrderFilter, fn func(models.OrderExportRow) error) error {
+	if err := validateOrderFilter(filter); err != nil {
+		return err
+	}
+
+	// Held orders must not be fulfilled, so they are only exported when asked
+	// for by status
+	if !models.OrderStatus(filter.Status).IsHeld() {
+		filter.ExcludeHeld = true
+	}
+
+	return s.orderRepo.ExportAll(ctx, filter, fn)
+}
+
+func validateOrderFilter(filter models.OrderFilter) error {
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return apperrors.Validation("min_amount cannot be greater than max_amount")
+	}
+	return nil
 }
 
 func (s *orderService) GetOrderAdmin(ctx context.Context, orderID uuid.UUID) (*models.AdminOrder, error) {
@@ -206,7 +744,7 @@ func (s *orderService) GetOrderAdmin(ctx context.Context, orderID uuid.UUID) (*m
 	}
 	if order == nil {
 		fmt.Printf("[ORDER SERVICE] Order not found in repository\n")
-		return nil, errors.New("order not found")
+		return nil, apperrors.NotFound("order not found")
 	}
 	fmt.Printf("[ORDER SERVICE SUCCESS] Order found: %s\n", order.OrderNumber)
 	return order, nil
@@ -223,6 +761,32 @@ func (s *orderService) GetAnalytics(ctx context.Context, rangeDays int) (*models
 	return s.orderRepo.GetAnalytics(ctx, rangeDays)
 }
 
+func (s *orderService) GetCustomerAnalytics(ctx context.Context, rangeDays, topLimit int) (*models.CustomerAnalytics, error) {
+	if topLimit < 1 || topLimit > 100 {
+		topLimit = 10
+	}
+
+	return s.orderRepo.GetCustomerAnalytics(ctx, rangeDays, topLimit)
+}
+
+func (s *orderService) GetPromotionAnalytics(ctx context.Context, rangeDays int) (*models.PromotionAnalytics, error) {
+	return s.orderRepo.GetPromotionAnalytics(ctx, rangeDays)
+}
+
+// GetMarginReport reports revenue, cost of goods sold and gross margin by
+// day, week or month (the default) and by category
+func (s *orderService) GetMarginReport(ctx context.Context, rangeDays int, period string) (*models.MarginReport, error) {
+	switch period {
+	case "":
+		period = models.MarginPeriodMonth
+	case models.MarginPeriodDay, models.MarginPeriodWeek, models.MarginPeriodMonth:
+	default:
+		return nil, apperrors.Validationf("invalid period %q; use day, week or month", period)
+	}
+
+	return s.orderRepo.GetMarginReport(ctx, rangeDays, period)
+}
+
 func (s *orderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error {
 	// Check if order exists
 	order, err := s.orderRepo.GetByID(ctx, orderID)
@@ -231,7 +795,7 @@ func (s *orderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 	}
 
 	if order == nil {
-		return errors.New("order not found")
+		return apperrors.NotFound("order not found")
 	}
 
 	// If status is already the same, no update needed
@@ -241,98 +805,747 @@ func (s *orderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 
 	// Validate status transition
 	if !isValidStatusTransition(order.Status, status) {
-		return fmt.Errorf("invalid status transition from %s to %s", order.Status, status)
+		return apperrors.Validationf("invalid status transition from %s to %s", order.Status, status)
 	}
 
-	if err := s.orderRepo.UpdateStatus(ctx, orderID, status); err != nil {
-		return err
+	// Holds carry a reason, so orders only become held through PlaceHold
+	if status.IsHeld() {
+		hold, err := s.holdRepo.GetActive(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if hold == nil {
+			return apperrors.Validation("orders are held through the hold endpoint with a reason")
+		}
 	}
 
-	// For COD, create payment when delivered
-	if status == models.OrderDelivered && order.PaymentMethod == "cod" {
-		existing, err := s.paymentSvc.GetPaymentByOrderID(ctx, orderID)
+	// Cash is only recorded as collected once the customer's delivery code
+	// has been confirmed
+	delivering := status == models.OrderDelivered ||
+		(status == models.OrderCompleted && order.Status != models.OrderDelivered)
+	if delivering && collectsCashOnDelivery(order) {
+		verified, err := s.codSvc.IsDeliveryVerified(ctx, orderID)
 		if err != nil {
 			return err
 		}
-		if existing == nil {
-			_, err := s.paymentSvc.CreatePaymentForOrder(ctx, orderID, "cod", models.PaymentCompleted)
-			if err != nil {
+		if !verified {
+			return apperrors.Validation("COD delivery must be confirmed with the customer's delivery code")
+		}
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.UpdateStatus(ctx, orderID, status); err != nil {
+			return err
+		}
+
+		// Moving an order out of a hold ends the hold
+		if order.Status.IsHeld() && !status.IsHeld() {
+			if err := s.holdRepo.ReleaseActive(ctx, orderID, nil, ""); err != nil {
 				return err
 			}
 		}
-	}
 
-	return nil
-}
+		if err := s.recordStatusChange(ctx, order, status); err != nil {
+			return err
+		}
 
-func isValidStatusTransition(from, to models.OrderStatus) bool {
-	transitions := map[models.OrderStatus][]models.OrderStatus{
-		models.OrderPending:    {models.OrderProcessing, models.OrderCancelled},
-		models.OrderProcessing: {models.OrderShipped, models.OrderDelivered, models.OrderCompleted, models.OrderCancelled},
-		models.OrderShipped:    {models.OrderDelivered, models.OrderCompleted},
-		models.OrderDelivered:  {models.OrderCompleted},
-		models.OrderCompleted:  {},
-		models.OrderCancelled:  {},
-		models.OrderRefunded:   {},
-	}
+		// Order-level changes carry the items that have not caught up yet
+		var err error
+		switch {
+		case status == models.OrderShipped:
+			err = s.orderRepo.SetItemsFulfillment(ctx, orderID,
+				[]models.FulfillmentStatus{models.FulfillmentPending, models.FulfillmentBackordered},
+				models.FulfillmentShipped)
+		case delivering:
+			err = s.orderRepo.SetItemsFulfillment(ctx, orderID,
+				[]models.FulfillmentStatus{models.FulfillmentPending, models.FulfillmentBackordered, models.FulfillmentShipped},
+				models.FulfillmentDelivered)
+		case status == models.OrderCancelled:
+			err = s.orderRepo.SetItemsFulfillment(ctx, orderID,
+				[]models.FulfillmentStatus{models.FulfillmentPending, models.FulfillmentBackordered},
+				models.FulfillmentCancelled)
+		}
+		if err != nil {
+			return err
+		}
 
-	allowed, ok := transitions[from]
-	if !ok {
-		return false
-	}
+		if status == models.OrderShipped && collectsCashOnDelivery(order) {
+			if err := s.codSvc.IssueDeliveryOTP(ctx, order); err != nil {
+				return err
+			}
+		}
 
-	for _, s := range allowed {
-		if s == to {
-			return true
+		// For COD, create payment when delivered
+		if delivering && order.PaymentMethod == "cod" {
+			existing, err := s.paymentSvc.GetPaymentByOrderID(ctx, orderID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				payment, err := s.paymentSvc.CreatePaymentForOrder(ctx, orderID, "cod", models.PaymentCompleted)
+				if err != nil {
+					return err
+				}
+				if err := s.codSvc.RecordCollection(ctx, payment); err != nil {
+					return err
+				}
+			}
 		}
+
+		return nil
+	})
+}
+
+// ConfirmCODDelivery checks the delivery code the courier collected and, if
+// it matches, marks the order delivered and records the cash payment
+func (s *orderService) ConfirmCODDelivery(ctx context.Context, orderID uuid.UUID, otp string) error {
+	if err := s.codSvc.VerifyDeliveryOTP(ctx, orderID, otp); err != nil {
+		return err
 	}
 
-	return false
+	return s.UpdateOrderStatus(ctx, orderID, models.OrderDelivered)
 }
 
-func (s *orderService) CancelOrder(ctx context.Context, orderID, userID uuid.UUID) error {
-	// Get order and verify ownership
+// collectsCashOnDelivery reports whether the courier has cash to collect for
+// order; COD orders covered in full by gift cards do not
+func collectsCashOnDelivery(order *models.Order) bool {
+	return order.PaymentMethod == "cod" && order.AmountDue().IsPositive()
+}
+
+// UpdateItemFulfillment moves a single item of an order through fulfillment
+// and derives the order status from the state of all its items
+func (s *orderService) UpdateItemFulfillment(ctx context.Context, orderID, itemID uuid.UUID, req models.UpdateItemFulfillmentRequest) (*models.Order, error) {
 	order, err := s.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if order == nil {
-		return errors.New("order not found")
+		return nil, apperrors.NotFound("order not found")
 	}
 
-	if order.UserID != userID {
-		return errors.New("unauthorized to cancel this order")
+	if order.Status != models.OrderProcessing && order.Status != models.OrderPartiallyShipped && order.Status != models.OrderShipped {
+		return nil, apperrors.Conflict("order is not being fulfilled")
 	}
 
-	// Check if order can be cancelled
-	if order.Status != models.OrderPending && order.Status != models.OrderProcessing {
-		return errors.New("order cannot be cancelled at this stage")
+	var item *models.OrderItem
+	for i := range order.Items {
+		if order.Items[i].ID == itemID {
+			item = &order.Items[i]
+			break
+		}
 	}
 
-	// Start transaction to cancel order and restore stock
-	tx, err := s.orderRepo.BeginTx(ctx)
+	if item == nil {
+		return nil, apperrors.NotFound("order item not found")
+	}
+
+	if item.FulfillmentStatus != req.Status && !isValidFulfillmentTransition(item.FulfillmentStatus, req.Status) {
+		return nil, apperrors.Validationf("invalid fulfillment transition from %s to %s", item.FulfillmentStatus, req.Status)
+	}
+
+	var trackingNumber *string
+	if tracking := strings.TrimSpace(req.TrackingNumber); tracking != "" {
+		trackingNumber = &tracking
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.UpdateItemFulfillment(ctx, orderID, itemID, req.Status, trackingNumber); err != nil {
+			return err
+		}
+
+		updated, err := s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		// UpdateOrderStatus joins this transaction, so a rejected order
+		// transition (e.g. an unconfirmed COD delivery) undoes the item update
+		if status, ok := deriveOrderStatus(updated.Fulfillment); ok && status != updated.Status {
+			return s.UpdateOrderStatus(ctx, orderID, status)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, err
 	}
-	defer tx.Rollback(ctx)
 
-	// Restore stock for each item
-	for _, item := range order.Items {
-		err = s.productRepo.UpdateStock(ctx, item.ProductID, item.Quantity)
+	return s.orderRepo.GetByID(ctx, orderID)
+}
+
+// deriveOrderStatus maps the fulfillment state of an order's items onto an
+// order status; ok is false while nothing has shipped yet
+func deriveOrderStatus(summary models.FulfillmentSummary) (models.OrderStatus, bool) {
+	active := 0
+	for status, count := range summary {
+		if status != models.FulfillmentCancelled {
+			active += count
+		}
+	}
+
+	delivered := summary[models.FulfillmentDelivered]
+	shipped := summary[models.FulfillmentShipped] + delivered
+
+	switch {
+	case active == 0 || shipped == 0:
+		return "", false
+	case delivered == active:
+		return models.OrderDelivered, true
+	case shipped == active:
+		return models.OrderShipped, true
+	default:
+		return models.OrderPartiallyShipped, true
+	}
+}
+
+func isValidFulfillmentTransition(from, to models.FulfillmentStatus) bool {
+	transitions := map[models.FulfillmentStatus][]models.FulfillmentStatus{
+		models.FulfillmentPending:     {models.FulfillmentBackordered, models.FulfillmentShipped, models.FulfillmentDelivered},
+		models.FulfillmentBackordered: {models.FulfillmentPending, models.FulfillmentShipped, models.FulfillmentDelivered},
+		models.FulfillmentShipped:     {models.FulfillmentDelivered},
+	}
+
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isValidStatusTransition(from, to models.OrderStatus) bool {
+	transitions := map[models.OrderStatus][]models.OrderStatus{
+		models.OrderPending:          {models.OrderProcessing, models.OrderOnHold, models.OrderUnderReview, models.OrderCancelled},
+		models.OrderOnHold:           {models.OrderPending, models.OrderProcessing, models.OrderCancelled},
+		models.OrderUnderReview:      {models.OrderPending, models.OrderProcessing, models.OrderCancelled},
+		models.OrderProcessing:       {models.OrderPartiallyShipped, models.OrderShipped, models.OrderDelivered, models.OrderCompleted, models.OrderOnHold, models.OrderUnderReview, models.OrderCancelled},
+		models.OrderPartiallyShipped: {models.OrderShipped, models.OrderDelivered, models.OrderCompleted},
+		models.OrderShipped:          {models.OrderDelivered, models.OrderCompleted},
+		models.OrderDelivered:        {models.OrderCompleted},
+		models.OrderCompleted:        {},
+		models.OrderCancelled:        {},
+		models.OrderRefunded:         {},
+	}
+
+	allowed, ok := transitions[from]
+	if !ok {
+		return false
+	}
+
+	for _, s := range allowed {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BulkUpdateOrderStatus applies one status to many orders in a single
+// transaction. Each order runs in its own savepoint through the same checks as
+// UpdateOrderStatus, so an invalid transition fails only that order and the
+// rest still commit together.
+func (s *orderService) BulkUpdateOrderStatus(ctx context.Context, req models.BulkUpdateOrderStatusRequest) (*models.BulkUpdateOrderStatusResponse, error) {
+	response := &models.BulkUpdateOrderStatusResponse{
+		Results: make([]models.BulkOrderStatusResult, 0, len(req.OrderIDs)),
+	}
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		seen := make(map[uuid.UUID]bool, len(req.OrderIDs))
+		for _, orderID := range req.OrderIDs {
+			if seen[orderID] {
+				continue
+			}
+			seen[orderID] = true
+
+			result := models.BulkOrderStatusResult{OrderID: orderID, Success: true}
+			err := s.txManager.WithinSavepoint(ctx, func(ctx context.Context) error {
+				return s.UpdateOrderStatus(ctx, orderID, req.Status)
+			})
+			if err != nil {
+				status, code, message := apperrors.HTTPStatus(err)
+				if status >= 500 {
+					log.Printf("❌ Bulk status update of order %s failed: %v", orderID, err)
+				}
+				result = models.BulkOrderStatusResult{OrderID: orderID, Error: code, Message: message}
+				response.Failed++
+			} else {
+				response.Updated++
+			}
+			response.Results = append(response.Results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *orderService) CancelOrder(ctx context.Context, orderID, userID uuid.UUID) error {
+	// Get order and verify ownership
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if order == nil {
+		return apperrors.NotFound("order not found")
+	}
+
+	if order.UserID != userID {
+		return apperrors.Forbidden("unauthorized to cancel this order")
+	}
+
+	// Check if order can be cancelled
+	if order.Status != models.OrderPending && order.Status != models.OrderProcessing && !order.Status.IsHeld() {
+		return apperrors.Conflict("order cannot be cancelled at this stage")
+	}
+
+	// Paid orders can only be cancelled by the customer for a while after
+	// payment; later on support has to do it
+	if order.Status == models.OrderProcessing && s.cancelWindow > 0 {
+		payment, err := s.paymentSvc.GetPaymentByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		// A completed payment is last updated when it is captured
+		if payment != nil && payment.Status == models.PaymentCompleted && time.Since(payment.UpdatedAt) > s.cancelWindow {
+			return apperrors.Conflict("the cancellation window for this order has closed; please contact support")
+		}
+	}
+
+	return s.cancelOrder(ctx, order)
+}
+
+// CancelUnpaidOrders cancels orders that have waited longer than the unpaid
+// order TTL for payment, returning their stock, and reports how many it
+// cancelled. A TTL of 0 disables it.
+func (s *orderService) CancelUnpaidOrders(ctx context.Context) (int, error) {
+	if s.unpaidOrderTTL <= 0 {
+		return 0, nil
+	}
+
+	orderIDs, err := s.orderRepo.GetUnpaidBefore(ctx, s.unpaidOrderTTL, 100)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find unpaid orders: %w", err)
+	}
+
+	cancelled := 0
+	for _, orderID := range orderIDs {
+		order, err := s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return cancelled, err
+		}
+		if order == nil || order.Status != models.OrderPending {
+			continue
+		}
+
+		err = s.cancelOrder(ctx, order)
+		// Paid or cancelled since it was picked up
+		if errors.Is(err, apperrors.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return cancelled, fmt.Errorf("failed to cancel unpaid order %s: %w", order.OrderNumber, err)
+		}
+		cancelled++
+	}
+
+	return cancelled, nil
+}
+
+// ExpireCheckoutAttempts cancels checkout attempts whose payment was not
+// authorized within the authorization TTL, releasing the stock they held,
+// and reports how many it cancelled
+func (s *orderService) ExpireCheckoutAttempts(ctx context.Context) (int, error) {
+	orderIDs, err := s.orderRepo.GetUnauthorizedBefore(ctx, s.authorizationTTL, 100)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find unauthorized checkout attempts: %w", err)
+	}
+
+	expired := 0
+	for _, orderID := range orderIDs {
+		order, err := s.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return expired, err
+		}
+		if order == nil || order.Status != models.OrderAwaitingPayment {
+			continue
+		}
+
+		err = s.abandonAttempt(ctx, order)
+		// Authorized or cancelled since it was picked up
+		if errors.Is(err, apperrors.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return expired, fmt.Errorf("failed to expire checkout attempt %s: %w", order.OrderNumber, err)
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// cancelOrder cancels a pending, held or processing order, returning its
+// stock and any gift card tender. A checkout attempt only releases the
+// stock it held.
+func (s *orderService) cancelOrder(ctx context.Context, order *models.Order) error {
+	orderID := order.ID
+
+	// Cancel order and restore stock atomically
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Guards against a concurrent status change since the read above
+		if err := s.orderRepo.CancelOrder(ctx, orderID); err != nil {
+			return err
+		}
+
+		if order.Status.IsHeld() {
+			if err := s.holdRepo.ReleaseActive(ctx, orderID, nil, ""); err != nil {
+				return err
+			}
+		}
+
+		err := s.orderRepo.SetItemsFulfillment(ctx, orderID,
+			[]models.FulfillmentStatus{models.FulfillmentPending, models.FulfillmentBackordered},
+			models.FulfillmentCancelled)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == models.OrderAwaitingPayment {
+			if err := s.productRepo.ReleaseOrderReservations(ctx, orderID); err != nil {
+				return err
+			}
+		} else if err := s.warehouseSvc.RestockItems(ctx, order.Items); err != nil {
+			return err
+		}
+
+		// Put any gift card tender back on the cards
+		if order.GiftCardAmount.IsPositive() {
+			if _, err := s.giftCardSvc.RestoreForOrder(ctx, orderID, order.GiftCardAmount, nil); err != nil {
+				return err
+			}
+		}
+
+		return s.recordStatusChange(ctx, order, models.OrderCancelled)
+	})
+	if err != nil {
+		return err
+	}
+
+	notifyRestocked(ctx, s.backInStockSvc, order.Items)
+	return nil
+}
+
+// GetReviewQueue lists orders held by fraud screening, oldest first
+func (s *orderService) GetReviewQueue(ctx context.Context, page, limit int) ([]models.ReviewQueueItem, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return s.fraudSvc.GetReviewQueue(ctx, page, limit)
+}
+
+// ReviewOrder records an admin's decision on an order held by fraud
+// screening. Approved orders carry on as if never held: processing when
+// already paid, pending otherwise. Rejected orders are cancelled.
+func (s *orderService) ReviewOrder(ctx context.Context, orderID, reviewerID uuid.UUID, req models.ReviewOrderRequest) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+	if order.Status != models.OrderUnderReview {
+		return nil, apperrors.Conflict("order is not awaiting review")
+	}
+
+	if req.Decision == models.ReviewRejected {
+		err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := s.holdRepo.ReleaseActive(ctx, orderID, &reviewerID, req.Note); err != nil {
+				return err
+			}
+			if err := s.cancelOrder(ctx, order); err != nil {
+				return err
+			}
+			return s.fraudSvc.RecordDecision(ctx, orderID, req.Decision, req.Note, reviewerID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return s.orderRepo.GetByID(ctx, orderID)
+	}
+
+	status, err := s.resumeStatus(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.holdRepo.ReleaseActive(ctx, orderID, &reviewerID, req.Note); err != nil {
+			return err
+		}
+		if err := s.UpdateOrderStatus(ctx, orderID, status); err != nil {
+			return err
+		}
+		return s.fraudSvc.RecordDecision(ctx, orderID, req.Decision, req.Note, reviewerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.orderRepo.GetByID(ctx, orderID)
+}
+
+// resumeStatus is the status a held order goes back to on release: the one it
+// was held from, moved on to processing if payment completed in the meantime
+func (s *orderService) resumeStatus(ctx context.Context, orderID uuid.UUID) (models.OrderStatus, error) {
+	status := models.OrderPending
+
+	hold, err := s.holdRepo.GetActive(ctx, orderID)
+	if err != nil {
+		return "", err
+	}
+	if hold != nil && hold.PreviousStatus != "" {
+		status = hold.PreviousStatus
+	}
+
+	if status == models.OrderPending {
+		payment, err := s.paymentSvc.GetPaymentByOrderID(ctx, orderID)
 		if err != nil {
-			return fmt.Errorf("failed to restore stock for product %s: %w",
-				item.ProductID, err)
+			return "", err
+		}
+		if payment != nil && payment.Status == models.PaymentCompleted {
+			status = models.OrderProcessing
 		}
 	}
 
-	// Update order status
-	err = s.orderRepo.UpdateStatus(ctx, orderID, models.OrderCancelled)
+	return status, nil
+}
+
+// PlaceHold stops a pending or processing order from moving on until the hold
+// is released
+func (s *orderService) PlaceHold(ctx context.Context, orderID, adminID uuid.UUID, req models.PlaceOrderHoldRequest) (*models.OrderHold, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+	if order.Status.IsHeld() {
+		return nil, apperrors.Conflict("order is already held")
+	}
+	if order.Status != models.OrderPending && order.Status != models.OrderProcessing {
+		return nil, apperrors.Conflict("only pending or processing orders can be held")
+	}
+
+	hold := &models.OrderHold{
+		OrderID:        orderID,
+		OrderNumber:    order.OrderNumber,
+		Status:         req.Status,
+		PreviousStatus: order.Status,
+		Reason:         strings.TrimSpace(req.Reason),
+		PlacedBy:       &adminID,
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.holdRepo.Create(ctx, hold); err != nil {
+			return err
+		}
+		return s.UpdateOrderStatus(ctx, orderID, req.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// ReleaseHold ends the active hold on an order and returns it to the status it
+// was held from
+func (s *orderService) ReleaseHold(ctx context.Context, orderID, adminID uuid.UUID, req models.ReleaseOrderHoldRequest) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+	if !order.Status.IsHeld() {
+		return nil, apperrors.Conflict("order is not held")
+	}
+
+	status, err := s.resumeStatus(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.holdRepo.ReleaseActive(ctx, orderID, &adminID, strings.TrimSpace(req.Note)); err != nil {
+			return err
+		}
+		return s.UpdateOrderStatus(ctx, orderID, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.orderRepo.GetByID(ctx, orderID)
+}
+
+func (s *orderService) GetOrderHolds(ctx context.Context, orderID uuid.UUID) ([]models.OrderHold, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+	return s.holdRepo.GetByOrderID(ctx, orderID)
+}
+
+// GetActiveHolds lists the orders currently held, optionally only those in
+// one hold status
+func (s *orderService) GetActiveHolds(ctx context.Context, status models.OrderStatus, page, limit int) ([]models.OrderHold, int, error) {
+	if status != "" && !status.IsHeld() {
+		return nil, 0, apperrors.Validationf("invalid hold status %q", status)
+	}
+	return s.holdRepo.GetActiveHolds(ctx, status, page, limit)
+}
+
+// maxPickListOrders caps how many orders one pick list may select
+const maxPickListOrders = 200
+
+// GetPickList aggregates what to pick for the selected orders, or for every
+// order ready to pick, by warehouse and product. Selected orders that are not
+// ready to pick are left off.
+func (s *orderService) GetPickList(ctx context.Context, filter models.PickListFilter) (*models.PickList, error) {
+	if len(filter.OrderIDs) > maxPickListOrders {
+		return nil, apperrors.Validationf("a pick list covers at most %d orders", maxPickListOrders)
+	}
+
+	lines, err := s.orderRepo.GetPickList(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &models.PickList{
+		OrderNumbers: []string{},
+		Warehouses:   []models.PickListWarehouse{},
+		GeneratedAt:  time.Now(),
+	}
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		// Lines arrive grouped by warehouse
+		if n := len(list.Warehouses); n == 0 || list.Warehouses[n-1].WarehouseID != line.WarehouseID {
+			list.Warehouses = append(list.Warehouses, models.PickListWarehouse{
+				WarehouseID:   line.WarehouseID,
+				WarehouseCode: line.WarehouseCode,
+				WarehouseName: line.WarehouseName,
+				Items:         []models.PickListLine{},
+			})
+		}
+		warehouse := &list.Warehouses[len(list.Warehouses)-1]
+		warehouse.Items = append(warehouse.Items, line)
+		warehouse.TotalUnits += line.Quantity
+		list.TotalUnits += line.Quantity
+
+		for _, number := range line.OrderNumbers {
+			if !seen[number] {
+				seen[number] = true
+				list.OrderNumbers = append(list.OrderNumbers, number)
+			}
+		}
+	}
+	sort.Strings(list.OrderNumbers)
+
+	return list, nil
+}
+
+// GetPackingSlip lists what goes in an order's parcel, leaving out cancelled
+// items and prices
+func (s *orderService) GetPackingSlip(ctx context.Context, orderID uuid.UUID) (*models.PackingSlip, error) {
+	order, err := s.orderRepo.GetAdminByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	slip := &models.PackingSlip{
+		OrderID:        order.ID,
+		OrderNumber:    order.OrderNumber,
+		OrderDate:      order.CreatedAt,
+		ShippingMethod: order.ShippingMethod,
+		ShipTo:         order.ShippingAddress,
+		Items:          make([]models.PackingSlipItem, 0, len(order.Items)),
+	}
+	for _, item := range order.Items {
+		if item.FulfillmentStatus == models.FulfillmentCancelled {
+			continue
+		}
+		slip.Items = append(slip.Items, models.PackingSlipItem{
+			ProductName:       item.ProductName,
+			SKU:               item.ProductSKU,
+			VariantSKU:        item.VariantSKU,
+			Quantity:          item.Quantity,
+			FulfillmentStatus: item.FulfillmentStatus,
+			Components:        item.BundleComponents,
+			WeightGrams:       item.WeightGrams,
+			Dimensions:        item.Dimensions,
+		})
+		slip.TotalUnits += item.Quantity
+		if item.WeightGrams != nil {
+			slip.TotalWeight += *item.WeightGrams * item.Quantity
+		}
+	}
+
+	return slip, nil
+}
+
+// orderStatusMessages is the notification copy shown for each status change
+var orderStatusMessages = map[models.OrderStatus]string{
+	models.OrderProcessing:       "Your order %s is being prepared.",
+	models.OrderPartiallyShipped: "Part of your order %s has shipped.",
+	models.OrderShipped:          "Your order %s has shipped.",
+	models.OrderDelivered:        "Your order %s has been delivered.",
+	models.OrderCompleted:        "Your order %s is complete.",
+	models.OrderCancelled:        "Your order %s has been cancelled.",
+}
+
+// recordStatusChange publishes an OrderStatusChanged event for order moving to
+// status and notifies its owner; call it inside the transaction that performs
+// the update
+func (s *orderService) recordStatusChange(ctx context.Context, order *models.Order, status models.OrderStatus) error {
+	err := s.publisher.Publish(ctx, events.OrderStatusChanged, events.AggregateOrder, order.ID, events.OrderStatusChangedPayload{
+		OrderID:     order.ID,
+		OrderNumber: order.OrderNumber,
+		UserID:      order.UserID,
+		FromStatus:  order.Status,
+		ToStatus:    status,
+	})
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit(ctx)
+	message, ok := orderStatusMessages[status]
+	if !ok {
+		return nil
+	}
+
+	return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationOrderStatus,
+		"Order "+string(status),
+		fmt.Sprintf(message, order.OrderNumber),
+		map[string]interface{}{"order_id": order.ID, "status": status})
 }
 
 func (s *orderService) ProcessOrderReturn(ctx context.Context, orderID uuid.UUID, returnID uuid.UUID) error {
@@ -340,3 +1553,87 @@ func (s *orderService) ProcessOrderReturn(ctx context.Context, orderID uuid.UUID
 	// For now, just update order status to refunded
 	return s.orderRepo.UpdateStatus(ctx, orderID, models.OrderRefunded)
 }
+
+// CreateReplacementOrder places a free order shipping variantID in place of an
+// exchanged item, to the original order's address. There is nothing to pay,
+// so it goes straight to processing. Call it inside the approval transaction.
+func (s *orderService) CreateReplacementOrder(ctx context.Context, original *models.Order, item models.OrderItem, variantID uuid.UUID) (*models.Order, error) {
+	order := &models.Order{
+		ID:              uuid.New(),
+		UserID:          original.UserID,
+		Status:          models.OrderProcessing,
+		PaymentMethod:   original.PaymentMethod,
+		ShippingMethod:  models.ShippingStandard,
+		ShippingAddress: original.ShippingAddress,
+		BillingAddress:  original.BillingAddress,
+		Items: []models.OrderItem{{
+			ID:                uuid.New(),
+			ProductID:         item.ProductID,
+			Product:           item.Product,
+			VariantID:         &variantID,
+			Quantity:          item.Quantity,
+			FulfillmentStatus: models.FulfillmentPending,
+			CreatedAt:         time.Now(),
+		}},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.LockStock(ctx, orderProductIDs(order.Items)); err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		// The replacement has no cart, so the deduction leaves every cart's
+		// reservations in place
+		if _, err := s.productRepo.CommitReservation(ctx, item.ProductID, uuid.Nil, &variantID, item.Quantity); err != nil {
+			if errors.Is(err, apperrors.ErrValidation) {
+				return apperrors.Conflict("replacement variant is out of stock")
+			}
+			return err
+		}
+
+		if err := s.createNumbered(ctx, order); err != nil {
+			return fmt.Errorf("failed to create replacement order: %w", err)
+		}
+
+		if err := s.warehouseSvc.AllocateOrder(ctx, order); err != nil {
+			return err
+		}
+
+		err := s.publisher.Publish(ctx, events.OrderCreated, events.AggregateOrder, order.ID, events.OrderCreatedPayload{
+			OrderID:       order.ID,
+			OrderNumber:   order.OrderNumber,
+			UserID:        order.UserID,
+			TotalAmount:   order.TotalAmount,
+			PaymentMethod: order.PaymentMethod,
+			CreatedAt:     order.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationOrderStatus,
+			"Replacement on its way",
+			fmt.Sprintf("We're sending your replacement from order %s as order %s.", original.OrderNumber, order.OrderNumber),
+			map[string]interface{}{"order_id": order.ID, "original_order_id": original.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// orderProductIDs lists each product of the items once
+func orderProductIDs(items []models.OrderItem) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	ids := []uuid.UUID{}
+	for _, item := range items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			ids = append(ids, item.ProductID)
+		}
+	}
+	return ids
+}
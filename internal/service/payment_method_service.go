@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/gateway"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+// simulatedGateway is recorded on methods saved while payments are simulated
+const simulatedGateway = "simulated"
+
+type PaymentMethodService interface {
+	GetPaymentMethods(ctx context.Context, userID uuid.UUID) ([]models.SavedPaymentMethod, error)
+	GetPaymentMethod(ctx context.Context, userID, id uuid.UUID) (*models.SavedPaymentMethod, error)
+	AddPaymentMethod(ctx context.Context, userID uuid.UUID, req models.SavePaymentMethodRequest) (*models.SavedPaymentMethod, error)
+	SetDefault(ctx context.Context, userID, id uuid.UUID) (*models.SavedPaymentMethod, error)
+	DeletePaymentMethod(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type paymentMethodService struct {
+	methodRepo repository.PaymentMethodRepository
+	userRepo   repository.UserRepository
+	gateway    gateway.PaymentGateway
+	txManager  database.TxManager
+}
+
+// NewPaymentMethodService creates the saved payment method vault. When
+// paymentGateway is nil, tokens are stored as given without a gateway.
+func NewPaymentMethodService(
+	methodRepo repository.PaymentMethodRepository,
+	userRepo repository.UserRepository,
+	paymentGateway gateway.PaymentGateway,
+	txManager database.TxManager,
+) PaymentMethodService {
+	return &paymentMethodService{
+		methodRepo: methodRepo,
+		userRepo:   userRepo,
+		gateway:    paymentGateway,
+		txManager:  txManager,
+	}
+}
+
+func (s *paymentMethodService) gatewayName() string {
+	if s.gateway == nil {
+		return simulatedGateway
+	}
+	return s.gateway.Name()
+}
+
+func (s *paymentMethodService) GetPaymentMethods(ctx context.Context, userID uuid.UUID) ([]models.SavedPaymentMethod, error) {
+	return s.methodRepo.GetByUserID(ctx, userID)
+}
+
+// GetPaymentMethod returns one of the user's saved methods; methods of other
+// users are reported as not found
+func (s *paymentMethodService) GetPaymentMethod(ctx context.Context, userID, id uuid.UUID) (*models.SavedPaymentMethod, error) {
+	method, err := s.methodRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if method == nil || method.UserID != userID {
+		return nil, apperrors.NotFound("payment method not found")
+	}
+	return method, nil
+}
+
+// AddPaymentMethod saves a gateway token for the user. The first saved method
+// becomes the default.
+func (s *paymentMethodService) AddPaymentMethod(ctx context.Context, userID uuid.UUID, req models.SavePaymentMethodRequest) (*models.SavedPaymentMethod, error) {
+	existing, err := s.methodRepo.GetByToken(ctx, userID, s.gatewayName(), req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.Conflict("payment method is already saved")
+	}
+
+	method := &models.SavedPaymentMethod{
+		UserID:   userID,
+		Gateway:  s.gatewayName(),
+		Token:    req.Token,
+		Type:     req.Type,
+		Brand:    req.Brand,
+		Last4:    req.Last4,
+		ExpMonth: req.ExpMonth,
+		ExpYear:  req.ExpYear,
+	}
+
+	if s.gateway != nil {
+		customerID, err := s.ensureCustomer(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		card, err := s.gateway.AttachPaymentMethod(ctx, customerID, req.Token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save payment method: %w", err)
+		}
+		method.CustomerID = customerID
+		method.Brand = card.Brand
+		method.Last4 = card.Last4
+		method.ExpMonth = card.ExpMonth
+		method.ExpYear = card.ExpYear
+	}
+
+	saved, err := s.methodRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	makeDefault := req.MakeDefault || len(saved) == 0
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.methodRepo.Create(ctx, method); err != nil {
+			return err
+		}
+		if makeDefault {
+			method.IsDefault = true
+			return s.methodRepo.SetDefault(ctx, userID, method.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+// ensureCustomer returns the user's gateway customer, creating it on first use
+func (s *paymentMethodService) ensureCustomer(ctx context.Context, userID uuid.UUID) (string, error) {
+	customerID, err := s.methodRepo.GetCustomerID(ctx, userID, s.gateway.Name())
+	if err != nil || customerID != "" {
+		return customerID, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", apperrors.NotFound("user not found")
+	}
+
+	customerID, err = s.gateway.CreateCustomer(ctx, user.Email, map[string]string{
+		"user_id": userID.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create payment customer: %w", err)
+	}
+
+	if err := s.methodRepo.SaveCustomerID(ctx, userID, s.gateway.Name(), customerID); err != nil {
+		return "", err
+	}
+
+	// A concurrent request may have saved another customer first
+	return s.methodRepo.GetCustomerID(ctx, userID, s.gateway.Name())
+}
+
+func (s *paymentMethodService) SetDefault(ctx context.Context, userID, id uuid.UUID) (*models.SavedPaymentMethod, error) {
+	method, err := s.GetPaymentMethod(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return s.methodRepo.SetDefault(ctx, userID, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	method.IsDefault = true
+	return method, nil
+}
+
+// DeletePaymentMethod removes a saved method from the gateway and the vault.
+// When it was the default, the most recently saved remaining method takes
+// over.
+func (s *paymentMethodService) DeletePaymentMethod(ctx context.Context, userID, id uuid.UUID) error {
+	method, err := s.GetPaymentMethod(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if s.gateway != nil && method.Gateway == s.gateway.Name() {
+		// The local copy is removed even if the gateway no longer knows the token
+		if err := s.gateway.DetachPaymentMethod(ctx, method.Token); err != nil {
+			log.Printf("⚠️ Failed to detach payment method %s: %v", method.ID, err)
+		}
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.methodRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if !method.IsDefault {
+			return nil
+		}
+
+		remaining, err := s.methodRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		return s.methodRepo.SetDefault(ctx, userID, remaining[0].ID)
+	})
+}
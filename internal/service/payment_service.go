@@ -3,10 +3,18 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/gateway"
 	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
 
 	"github.com/google/uuid"
 )
@@ -14,20 +22,59 @@ import (
 type PaymentService interface {
 	CreatePayment(ctx context.Context, req models.CreatePaymentRequest, userID uuid.UUID) (*models.Payment, error)
 	VerifyPayment(ctx context.Context, req models.VerifyPaymentRequest) (*models.Payment, error)
-	ProcessRefund(ctx context.Context, paymentID uuid.UUID, amount float64) error
+	ProcessRefund(ctx context.Context, refund *models.Refund) error
 	GetPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (*models.Payment, error)
+	GetOrderRefunds(ctx context.Context, orderID, userID uuid.UUID) ([]models.Refund, error)
+	GetAllPayments(ctx context.Context, filter models.PaymentFilter, page, limit int) ([]models.AdminPayment, int, error)
+	ReconcilePayments(ctx context.Context, rangeDays int) (*models.PaymentReconciliation, error)
 	CreatePaymentForOrder(ctx context.Context, orderID uuid.UUID, method string, status models.PaymentStatus) (*models.Payment, error)
+	InitiateCardPayment(ctx context.Context, orderID uuid.UUID, method string, saved *models.SavedPaymentMethod) (*models.Payment, error)
+	HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) error
+	GetPaymentStatus(ctx context.Context, paymentID, userID uuid.UUID) (*models.PaymentStatusResponse, error)
+	ConfirmPayment(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error)
+	CaptureOrderPayment(ctx context.Context, orderID uuid.UUID) (*models.Payment, error)
+	VoidOrderPayment(ctx context.Context, orderID uuid.UUID) error
+	CapturePayment(ctx context.Context, paymentID, adminID uuid.UUID, req models.CapturePaymentRequest) (*models.PaymentCapture, error)
+	VoidPayment(ctx context.Context, paymentID, adminID uuid.UUID, req models.VoidPaymentRequest) (*models.PaymentCapture, error)
 }
 
 type paymentService struct {
-	paymentRepo repository.PaymentRepository
-	orderRepo   repository.OrderRepository
+	paymentRepo     repository.PaymentRepository
+	orderRepo       repository.OrderRepository
+	gateway         gateway.PaymentGateway
+	currency        string
+	txManager       database.TxManager
+	publisher       events.Publisher
+	notificationSvc NotificationService
+	simDelay        time.Duration
+	apiBaseURL      string
 }
 
-func NewPaymentService(paymentRepo repository.PaymentRepository, orderRepo repository.OrderRepository) PaymentService {
+// NewPaymentService creates a payment service. When paymentGateway is nil,
+// payments are simulated locally instead of going through a provider,
+// completing after simDelay. Customers who authenticate a payment with the
+// gateway return to the payment callback under apiBaseURL.
+func NewPaymentService(
+	paymentRepo repository.PaymentRepository,
+	orderRepo repository.OrderRepository,
+	paymentGateway gateway.PaymentGateway,
+	currency string,
+	txManager database.TxManager,
+	publisher events.Publisher,
+	notificationSvc NotificationService,
+	simDelay time.Duration,
+	apiBaseURL string,
+) PaymentService {
 	return &paymentService{
-		paymentRepo: paymentRepo,
-		orderRepo:   orderRepo,
+		paymentRepo:     paymentRepo,
+		orderRepo:       orderRepo,
+		gateway:         paymentGateway,
+		currency:        currency,
+		txManager:       txManager,
+		publisher:       publisher,
+		notificationSvc: notificationSvc,
+		simDelay:        simDelay,
+		apiBaseURL:      strings.TrimRight(apiBaseURL, "/"),
 	}
 }
 
@@ -39,18 +86,22 @@ func (s *paymentService) CreatePayment(ctx context.Context, req models.CreatePay
 	}
 
 	if order == nil {
-		return nil, errors.New("order not found")
+		return nil, apperrors.NotFound("order not found")
 	}
 
 	// Verify order belongs to user
 	if order.UserID != userID {
-		return nil, errors.New("unauthorized to create payment for this order")
+		return nil, apperrors.Forbidden("unauthorized to create payment for this order")
 	}
 
-	// Check if payment already exists
+	// Check if payment already exists (failed attempts may be retried)
 	existingPayment, err := s.paymentRepo.GetByOrderID(ctx, req.OrderID)
-	if err == nil && existingPayment != nil {
-		return nil, errors.New("payment already exists for this order")
+	if err == nil && existingPayment != nil && existingPayment.Status != models.PaymentFailed {
+		return nil, apperrors.Conflict("payment already exists for this order")
+	}
+
+	if s.gateway != nil {
+		return s.createGatewayPayment(ctx, order, req.PaymentMethod, nil)
 	}
 
 	// Create payment
@@ -58,7 +109,7 @@ func (s *paymentService) CreatePayment(ctx context.Context, req models.CreatePay
 	payment := &models.Payment{
 		ID:             uuid.New(),
 		OrderID:        req.OrderID,
-		Amount:         order.TotalAmount,
+		Amount:         order.AmountDue(),
 		Status:         models.PaymentPending,
 		PaymentMethod:  req.PaymentMethod,
 		TransactionID:  transactionID,
@@ -72,25 +123,213 @@ func (s *paymentService) CreatePayment(ctx context.Context, req models.CreatePay
 		return nil, err
 	}
 
-	// For demo purposes, simulate payment processing
-	go s.simulatePaymentProcessing(ctx, payment.ID)
+	// For demo purposes, simulate payment processing. The request context is
+	// cancelled once the response is written, so detach from it.
+	go s.simulatePaymentProcessing(context.WithoutCancel(ctx), payment.ID)
+
+	return payment, nil
+}
+
+// createGatewayPayment opens a payment intent with the configured gateway and
+// records a pending payment; the final status arrives through the webhook.
+// With a saved method the intent is confirmed off-session against it. An
+// order awaiting payment only has the amount authorized, to be captured once
+// the order is finalized.
+func (s *paymentService) createGatewayPayment(ctx context.Context, order *models.Order, method string, saved *models.SavedPaymentMethod) (*models.Payment, error) {
+	paymentID := uuid.New()
+	metadata := map[string]string{
+		"order_id":     order.ID.String(),
+		"order_number": order.OrderNumber,
+		"payment_id":   paymentID.String(),
+	}
+
+	var intent *gateway.PaymentIntent
+	var err error
+	if saved != nil {
+		if saved.Gateway != s.gateway.Name() {
+			return nil, apperrors.Validation("saved payment method cannot be used with the current payment provider")
+		}
+		intent, err = s.gateway.ChargePaymentMethod(ctx, order.AmountDue(), s.currency, paymentID.String(), saved.CustomerID, saved.Token, s.callbackURL(paymentID), capturesLater(order), metadata)
+	} else {
+		intent, err = s.gateway.CreatePaymentIntent(ctx, order.AmountDue(), s.currency, paymentID.String(), capturesLater(order), metadata)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	details := map[string]interface{}{
+		"gateway":       s.gateway.Name(),
+		"client_secret": intent.ClientSecret,
+		"intent_status": intent.Status,
+	}
+	if redirectURL := intent.RedirectURL(); redirectURL != "" {
+		details["redirect_url"] = redirectURL
+	}
+	addSavedMethodDetails(details, saved)
+
+	payment := &models.Payment{
+		ID:             paymentID,
+		OrderID:        order.ID,
+		Amount:         order.AmountDue(),
+		Status:         models.PaymentPending,
+		PaymentMethod:  method,
+		TransactionID:  intent.ID,
+		PaymentDetails: details,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
 
+	if err := s.paymentRepo.Create(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	payment.ClientAction = clientAction(payment)
 	return payment, nil
 }
 
+// capturesLater reports whether the order's payment is only authorized at
+// checkout, which is the case for orders placed in authorize-then-capture mode
+func capturesLater(order *models.Order) bool {
+	return order.Status == models.OrderAwaitingPayment
+}
+
+// viaGateway reports whether the payment went through the configured gateway
+// rather than being simulated
+func (s *paymentService) viaGateway(payment *models.Payment) bool {
+	name, _ := payment.PaymentDetails["gateway"].(string)
+	return s.gateway != nil && name == s.gateway.Name()
+}
+
+// callbackURL is where the gateway sends the customer after they
+// authenticate a payment
+func (s *paymentService) callbackURL(paymentID uuid.UUID) string {
+	return s.apiBaseURL + "/api/v1/payments/" + paymentID.String() + "/callback"
+}
+
+// clientAction derives what the client must do to finish a pending gateway
+// payment from the intent recorded with it
+func clientAction(payment *models.Payment) *models.PaymentClientAction {
+	if payment.Status != models.PaymentPending {
+		return nil
+	}
+
+	if redirectURL, _ := payment.PaymentDetails["redirect_url"].(string); redirectURL != "" {
+		return &models.PaymentClientAction{Type: models.ClientActionRedirect, RedirectURL: redirectURL}
+	}
+	if secret, _ := payment.PaymentDetails["client_secret"].(string); secret != "" {
+		return &models.PaymentClientAction{Type: models.ClientActionConfirm, ClientSecret: secret}
+	}
+	return nil
+}
+
 func (s *paymentService) simulatePaymentProcessing(ctx context.Context, paymentID uuid.UUID) {
 	// Simulate payment processing delay
-	time.Sleep(3 * time.Second)
+	time.Sleep(s.simDelay)
+
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil || payment == nil {
+		log.Printf("⚠️ Simulated payment %s not found: %v", paymentID, err)
+		return
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, payment.OrderID)
+	if err != nil || order == nil {
+		log.Printf("⚠️ Order of simulated payment %s not found: %v", paymentID, err)
+		return
+	}
 
 	// Simulate successful payment
-	transactionID := "TXN-" + uuid.New().String()[:8]
-	s.paymentRepo.UpdateStatus(ctx, paymentID, models.PaymentCompleted, transactionID)
+	payment.TransactionID = "TXN-" + uuid.New().String()[:8]
+	if capturesLater(order) {
+		err = s.authorizePayment(ctx, payment)
+	} else {
+		err = s.completePayment(ctx, payment)
+	}
+	if err != nil {
+		log.Printf("⚠️ Failed to complete simulated payment %s: %v", paymentID, err)
+	}
+}
+
+// unsettledPaymentStatuses are the statuses a payment waiting on the customer
+// or the gateway can be in
+var unsettledPaymentStatuses = []models.PaymentStatus{models.PaymentPending, models.PaymentProcessing}
+
+// authorizePayment marks an unsettled payment authorized and records a
+// PaymentAuthorized event, on which the order is finalized and the payment
+// captured. A payment something else settled first is left alone.
+func (s *paymentService) authorizePayment(ctx context.Context, payment *models.Payment) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		changed, err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentAuthorized, payment.TransactionID, unsettledPaymentStatuses)
+		if err != nil || !changed {
+			return err
+		}
+		payment.Status = models.PaymentAuthorized
+
+		return s.onPaymentAuthorized(ctx, payment)
+	})
+}
+
+// onPaymentAuthorized must be called inside the transaction that marked the
+// payment authorized
+func (s *paymentService) onPaymentAuthorized(ctx context.Context, payment *models.Payment) error {
+	return s.publisher.Publish(ctx, events.PaymentAuthorized, events.AggregatePayment, payment.ID, events.PaymentAuthorizedPayload{
+		PaymentID:     payment.ID,
+		OrderID:       payment.OrderID,
+		Amount:        payment.Amount,
+		PaymentMethod: payment.PaymentMethod,
+		TransactionID: payment.TransactionID,
+	})
+}
 
-	// Update order status
-	payment, _ := s.paymentRepo.GetByID(ctx, paymentID)
-	if payment != nil {
-		s.orderRepo.UpdateStatus(ctx, payment.OrderID, models.OrderProcessing)
+// completePayment marks a payment completed, moves a pending order into
+// processing and records a PaymentCompleted event in one transaction. A
+// failed payment can still complete, as the customer may retry the same
+// intent; one completed or authorized already is left alone.
+func (s *paymentService) completePayment(ctx context.Context, payment *models.Payment) error {
+	from := append([]models.PaymentStatus{models.PaymentFailed}, unsettledPaymentStatuses...)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		changed, err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentCompleted, payment.TransactionID, from)
+		if err != nil || !changed {
+			return err
+		}
+		payment.Status = models.PaymentCompleted
+
+		return s.onPaymentCompleted(ctx, payment)
+	})
+}
+
+// onPaymentCompleted runs the side effects of a captured payment. It must be
+// called inside the transaction that marked the payment completed.
+func (s *paymentService) onPaymentCompleted(ctx context.Context, payment *models.Payment) error {
+	order, err := s.orderRepo.GetByID(ctx, payment.OrderID)
+	if err != nil {
+		return err
 	}
+	if order != nil && order.Status == models.OrderPending {
+		if err := s.orderRepo.UpdateStatus(ctx, order.ID, models.OrderProcessing); err != nil {
+			return err
+		}
+	}
+
+	err = s.publisher.Publish(ctx, events.PaymentCompleted, events.AggregatePayment, payment.ID, events.PaymentCompletedPayload{
+		PaymentID:     payment.ID,
+		OrderID:       payment.OrderID,
+		Amount:        payment.Amount,
+		PaymentMethod: payment.PaymentMethod,
+		TransactionID: payment.TransactionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if order == nil {
+		return nil
+	}
+
+	return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationPayment,
+		"Payment received",
+		fmt.Sprintf("We've received your payment of %s for order %s.", payment.Amount, order.OrderNumber),
+		map[string]interface{}{"order_id": order.ID, "payment_id": payment.ID})
 }
 
 func (s *paymentService) VerifyPayment(ctx context.Context, req models.VerifyPaymentRequest) (*models.Payment, error) {
@@ -100,85 +339,572 @@ func (s *paymentService) VerifyPayment(ctx context.Context, req models.VerifyPay
 	}
 
 	if payment == nil {
-		return nil, errors.New("payment not found")
+		return nil, apperrors.NotFound("payment not found")
 	}
 
 	// Verify transaction
 	if payment.TransactionID != req.TransactionID {
-		return nil, errors.New("invalid transaction ID")
+		return nil, apperrors.Validation("invalid transaction ID")
 	}
 
 	return payment, nil
 }
 
-func (s *paymentService) ProcessRefund(ctx context.Context, paymentID uuid.UUID, amount float64) error {
-	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+// ProcessRefund refunds part or all of a payment. Refunds accumulate on the
+// payment and together may not exceed the amount paid; the order is marked
+// refunded once the payment is refunded in full.
+func (s *paymentService) ProcessRefund(ctx context.Context, refund *models.Refund) error {
+	payment, err := s.paymentRepo.GetByID(ctx, refund.PaymentID)
 	if err != nil {
 		return err
 	}
 
 	if payment == nil {
-		return errors.New("payment not found")
+		return apperrors.NotFound("payment not found")
 	}
 
-	if payment.Status != models.PaymentCompleted {
-		return errors.New("can only refund completed payments")
+	if payment.Status != models.PaymentCompleted && payment.Status != models.PaymentPartiallyRefunded {
+		return apperrors.Conflict("can only refund completed payments")
 	}
 
-	if amount > payment.Amount {
-		return errors.New("refund amount cannot exceed payment amount")
+	if !refund.Amount.IsPositive() {
+		return apperrors.Validation("refund amount must be greater than zero")
 	}
 
-	// Update payment status
-	err = s.paymentRepo.UpdateStatusWithRefund(ctx, paymentID, models.PaymentRefunded, amount)
-	if err != nil {
-		return err
+	if refund.Amount > payment.Refundable() {
+		return apperrors.Validationf("refund amount cannot exceed the remaining %s", payment.Refundable())
+	}
+
+	// The refund's ID is fixed before the gateway is asked so a retry of the
+	// same refund cannot pay it out twice
+	if refund.ID == uuid.Nil {
+		refund.ID = uuid.New()
 	}
 
-	// Update order status
-	return s.orderRepo.UpdateStatus(ctx, payment.OrderID, models.OrderRefunded)
+	if s.viaGateway(payment) {
+		if _, err := s.gateway.RefundPaymentIntent(ctx, payment.TransactionID, refund.Amount, "refund-"+refund.ID.String()); err != nil {
+			return fmt.Errorf("failed to refund payment: %w", err)
+		}
+	}
+
+	amount := refund.Amount
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Guards the cumulative total against concurrent refunds
+		if err := s.paymentRepo.RecordRefund(ctx, refund); err != nil {
+			return err
+		}
+
+		if amount == payment.Refundable() {
+			if err := s.orderRepo.UpdateStatus(ctx, payment.OrderID, models.OrderRefunded); err != nil {
+				return err
+			}
+		}
+
+		err := s.publisher.Publish(ctx, events.PaymentRefunded, events.AggregatePayment, payment.ID, events.PaymentRefundedPayload{
+			PaymentID:    payment.ID,
+			OrderID:      payment.OrderID,
+			RefundAmount: amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		order, err := s.orderRepo.GetByID(ctx, payment.OrderID)
+		if err != nil || order == nil {
+			return err
+		}
+
+		return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationRefund,
+			"Refund issued",
+			fmt.Sprintf("A refund of %s for order %s is on its way.", amount, order.OrderNumber),
+			map[string]interface{}{"order_id": order.ID, "payment_id": payment.ID, "amount": amount})
+	})
 }
 
 func (s *paymentService) GetPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
-	return s.paymentRepo.GetByOrderID(ctx, orderID)
+	payment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil || payment == nil {
+		return payment, err
+	}
+
+	payment.ClientAction = clientAction(payment)
+	return payment, nil
+}
+
+func (s *paymentService) GetOrderRefunds(ctx context.Context, orderID, userID uuid.UUID) ([]models.Refund, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	if order.UserID != userID {
+		return nil, apperrors.Forbidden("unauthorized to view this order")
+	}
+
+	return s.paymentRepo.GetRefundsByOrderID(ctx, orderID)
+}
+
+func (s *paymentService) GetAllPayments(ctx context.Context, filter models.PaymentFilter, page, limit int) ([]models.AdminPayment, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return s.paymentRepo.GetAll(ctx, filter, page, limit)
+}
+
+// ReconcilePayments flags orders whose status disagrees with their payment
+// records so they can be investigated
+func (s *paymentService) ReconcilePayments(ctx context.Context, rangeDays int) (*models.PaymentReconciliation, error) {
+	issues, err := s.paymentRepo.FindReconciliationIssues(ctx, rangeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{
+		models.MismatchPaidWithoutPayment:  0,
+		models.MismatchAmount:              0,
+		models.MismatchRefundWithoutReturn: 0,
+	}
+	for i := range issues {
+		issue := &issues[i]
+		counts[issue.Type]++
+
+		switch issue.Type {
+		case models.MismatchPaidWithoutPayment:
+			if issue.PaymentStatus == nil {
+				issue.Detail = fmt.Sprintf("Order is %s but has no payment", issue.OrderStatus)
+			} else {
+				issue.Detail = fmt.Sprintf("Order is %s but its payment is %s", issue.OrderStatus, *issue.PaymentStatus)
+			}
+		case models.MismatchAmount:
+			issue.Detail = fmt.Sprintf("Payment of %s does not match the %s due", *issue.PaymentAmount, issue.AmountDue)
+		case models.MismatchRefundWithoutReturn:
+			issue.Detail = fmt.Sprintf("%s refunded without a completed return", *issue.RefundedAmount)
+		}
+	}
+
+	return &models.PaymentReconciliation{
+		RangeDays: rangeDays,
+		Counts:    counts,
+		Issues:    issues,
+	}, nil
+}
+
+// addSavedMethodDetails records which saved method paid, so the payment can
+// be traced back to it without exposing the token
+func addSavedMethodDetails(details map[string]interface{}, saved *models.SavedPaymentMethod) {
+	if saved == nil {
+		return
+	}
+	details["saved_payment_method_id"] = saved.ID.String()
+	details["card_brand"] = saved.Brand
+	details["card_last4"] = saved.Last4
 }
 
 func (s *paymentService) CreatePaymentForOrder(ctx context.Context, orderID uuid.UUID, method string, status models.PaymentStatus) (*models.Payment, error) {
+	return s.createPaymentForOrder(ctx, orderID, method, status, make(map[string]interface{}))
+}
+
+func (s *paymentService) createPaymentForOrder(ctx context.Context, orderID uuid.UUID, method string, status models.PaymentStatus, details map[string]interface{}) (*models.Payment, error) {
 	// Get order
 	order, err := s.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
 		return nil, err
 	}
 	if order == nil {
-		return nil, errors.New("order not found")
+		return nil, apperrors.NotFound("order not found")
 	}
 
 	// Check if payment already exists
 	existingPayment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
 	if err == nil && existingPayment != nil {
-		return nil, errors.New("payment already exists for this order")
+		return nil, apperrors.Conflict("payment already exists for this order")
 	}
 
 	transactionID := "TXN-" + uuid.New().String()[:8]
 	payment := &models.Payment{
 		ID:             uuid.New(),
 		OrderID:        orderID,
-		Amount:         order.TotalAmount,
+		Amount:         order.AmountDue(),
 		Status:         status,
 		PaymentMethod:  method,
 		TransactionID:  transactionID,
-		PaymentDetails: make(map[string]interface{}),
+		PaymentDetails: details,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
 
-	if err := s.paymentRepo.Create(ctx, payment); err != nil {
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return err
+		}
+
+		switch status {
+		case models.PaymentCompleted:
+			return s.onPaymentCompleted(ctx, payment)
+		case models.PaymentAuthorized:
+			return s.onPaymentAuthorized(ctx, payment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// InitiateCardPayment starts paying for an order by card, charging saved
+// when given instead of waiting for the customer to enter their details
+func (s *paymentService) InitiateCardPayment(ctx context.Context, orderID uuid.UUID, method string, saved *models.SavedPaymentMethod) (*models.Payment, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	if s.gateway == nil {
+		// Without a gateway, card payments are treated as authorized or
+		// captured immediately
+		details := make(map[string]interface{})
+		addSavedMethodDetails(details, saved)
+		status := models.PaymentCompleted
+		if capturesLater(order) {
+			status = models.PaymentAuthorized
+		}
+		return s.createPaymentForOrder(ctx, orderID, method, status, details)
+	}
+
+	return s.createGatewayPayment(ctx, order, method, saved)
+}
+
+func (s *paymentService) HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) error {
+	if s.gateway == nil || s.gateway.Name() != provider {
+		return errors.New("payment gateway not configured")
+	}
+
+	event, err := s.gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	if event.PaymentIntentID == "" {
+		// Not a payment intent event; acknowledge and ignore
+		return nil
+	}
+
+	payment, err := s.paymentRepo.GetByTransactionID(ctx, event.PaymentIntentID)
+	if err != nil {
+		return err
+	}
+	if payment == nil {
+		log.Printf("⚠️ Webhook %s for unknown payment intent %s", event.ID, event.PaymentIntentID)
+		return nil
+	}
+
+	switch event.Type {
+	case gateway.EventPaymentProcessing:
+		if payment.Status == models.PaymentPending {
+			_, err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentProcessing, payment.TransactionID, []models.PaymentStatus{models.PaymentPending})
+			return err
+		}
+
+	case gateway.EventPaymentAuthorized:
+		if payment.Status != models.PaymentPending && payment.Status != models.PaymentProcessing {
+			return nil
+		}
+		return s.authorizePayment(ctx, payment)
+
+	case gateway.EventPaymentSucceeded:
+		// Authorized payments are captured through the API, which records
+		// the capture itself
+		if payment.Status == models.PaymentAuthorized || payment.Status == models.PaymentCompleted || payment.Status == models.PaymentPartiallyRefunded || payment.Status == models.PaymentRefunded {
+			return nil
+		}
+		return s.completePayment(ctx, payment)
+
+	case gateway.EventPaymentFailed, gateway.EventPaymentCanceled:
+		if payment.Status != models.PaymentPending && payment.Status != models.PaymentProcessing {
+			return nil
+		}
+		return s.failPayment(ctx, payment, event.FailureMessage)
+	}
+
+	return nil
+}
+
+// failPayment marks an unsettled payment failed and tells the customer, so
+// they can retry; the order stays pending. A payment that settled meanwhile,
+// e.g. was authorized, is left alone.
+func (s *paymentService) failPayment(ctx context.Context, payment *models.Payment, reason string) error {
+	if reason != "" {
+		log.Printf("⚠️ Payment %s failed: %s", payment.ID, reason)
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		changed, err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentFailed, payment.TransactionID, unsettledPaymentStatuses)
+		if err != nil || !changed {
+			return err
+		}
+		payment.Status = models.PaymentFailed
+
+		order, err := s.orderRepo.GetByID(ctx, payment.OrderID)
+		if err != nil || order == nil {
+			return err
+		}
+
+		err = s.publisher.Publish(ctx, events.PaymentFailed, events.AggregatePayment, payment.ID, events.PaymentFailedPayload{
+			PaymentID:     payment.ID,
+			OrderID:       order.ID,
+			OrderNumber:   order.OrderNumber,
+			Amount:        payment.Amount,
+			PaymentMethod: payment.PaymentMethod,
+			Reason:        reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationPayment,
+			"Payment failed",
+			fmt.Sprintf("Your payment for order %s didn't go through. Please try again.", order.OrderNumber),
+			map[string]interface{}{"order_id": order.ID, "payment_id": payment.ID})
+	})
+}
+
+// GetPaymentStatus reports a payment's progress to the customer who owns it,
+// for clients polling after checkout or authentication
+func (s *paymentService) GetPaymentStatus(ctx context.Context, paymentID, userID uuid.UUID) (*models.PaymentStatusResponse, error) {
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, payment.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil || order.UserID != userID {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	return &models.PaymentStatusResponse{
+		PaymentID:    payment.ID,
+		OrderID:      order.ID,
+		Status:       payment.Status,
+		OrderStatus:  order.Status,
+		ClientAction: clientAction(payment),
+	}, nil
+}
+
+// ConfirmPayment settles a payment after the customer returns from
+// authenticating it. The outcome is read from the gateway rather than the
+// callback request, and the order only moves on once the payment succeeded.
+func (s *paymentService) ConfirmPayment(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
 		return nil, err
 	}
+	if payment == nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
 
-	if status == models.PaymentCompleted {
-		_ = s.orderRepo.UpdateStatus(ctx, orderID, models.OrderProcessing)
+	// Already settled, e.g. by the webhook arriving first
+	if payment.Status != models.PaymentPending && payment.Status != models.PaymentProcessing {
+		return payment, nil
+	}
+	if s.gateway == nil {
+		return nil, apperrors.Validation("payment does not require confirmation")
 	}
 
+	intent, err := s.gateway.GetPaymentIntent(ctx, payment.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve payment intent: %w", err)
+	}
+
+	switch intent.Status {
+	case gateway.IntentSucceeded:
+		if err := s.completePayment(ctx, payment); err != nil {
+			return nil, err
+		}
+
+	case gateway.IntentRequiresCapture:
+		if err := s.authorizePayment(ctx, payment); err != nil {
+			return nil, err
+		}
+
+	case gateway.IntentProcessing:
+		if payment.Status == models.PaymentPending {
+			if _, err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentProcessing, payment.TransactionID, []models.PaymentStatus{models.PaymentPending}); err != nil {
+				return nil, err
+			}
+		}
+
+	case gateway.IntentRequiresPaymentMethod, gateway.IntentCanceled:
+		if err := s.failPayment(ctx, payment, "payment authentication failed"); err != nil {
+			return nil, err
+		}
+	}
+
+	// A webhook may have settled the payment first, so report what was stored
+	payment, err = s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	payment.ClientAction = clientAction(payment)
+	return payment, nil
+}
+
+// CaptureOrderPayment takes the funds of the order's authorized payment in
+// full, completing it. A payment captured already is returned as it is.
+func (s *paymentService) CaptureOrderPayment(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
+	payment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+	if payment.Status == models.PaymentCompleted {
+		return payment, nil
+	}
+
+	if _, err := s.settleAuthorization(ctx, payment, payment.Amount, "", nil); err != nil {
+		return nil, err
+	}
 	return payment, nil
 }
+
+// VoidOrderPayment calls off the order's payment before any funds are taken:
+// an authorization is released and an intent the customer never completed is
+// cancelled. Payments that already settled are left alone.
+func (s *paymentService) VoidOrderPayment(ctx context.Context, orderID uuid.UUID) error {
+	payment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil || payment == nil {
+		return err
+	}
+
+	switch payment.Status {
+	case models.PaymentAuthorized:
+		_, err := s.settleAuthorization(ctx, payment, 0, "", nil)
+		return err
+
+	case models.PaymentPending:
+		if s.viaGateway(payment) {
+			if _, err := s.gateway.CancelPaymentIntent(ctx, payment.TransactionID, "void-"+payment.ID.String()); err != nil {
+				return fmt.Errorf("failed to void payment: %w", err)
+			}
+		}
+		_, err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentVoided, payment.TransactionID, []models.PaymentStatus{models.PaymentPending})
+		return err
+	}
+
+	return nil
+}
+
+// CapturePayment lets an admin capture an authorized payment, in full or in
+// part; what is not captured is released back to the customer
+func (s *paymentService) CapturePayment(ctx context.Context, paymentID, adminID uuid.UUID, req models.CapturePaymentRequest) (*models.PaymentCapture, error) {
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	amount := payment.Amount
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if !amount.IsPositive() {
+		return nil, apperrors.Validation("capture amount must be greater than zero")
+	}
+
+	return s.settleAuthorization(ctx, payment, amount, req.Reason, &adminID)
+}
+
+// VoidPayment lets an admin release an authorized payment without taking any
+// of it. The order is left unpaid, so it is cancelled like any other once
+// the unpaid order TTL passes.
+func (s *paymentService) VoidPayment(ctx context.Context, paymentID, adminID uuid.UUID, req models.VoidPaymentRequest) (*models.PaymentCapture, error) {
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	return s.settleAuthorization(ctx, payment, 0, req.Reason, &adminID)
+}
+
+// settleAuthorization captures amount of an authorized payment with the
+// gateway, releasing the rest, and records it in the capture ledger. A zero
+// amount voids the authorization. Gateways settle an authorization once, so
+// a payment cannot be captured again afterwards.
+func (s *paymentService) settleAuthorization(ctx context.Context, payment *models.Payment, amount money.Money, reason string, adminID *uuid.UUID) (*models.PaymentCapture, error) {
+	if payment.Status != models.PaymentAuthorized {
+		return nil, apperrors.Conflictf("payment is %s and cannot be captured or voided", payment.Status)
+	}
+	if amount > payment.Amount {
+		return nil, apperrors.Validationf("capture amount cannot exceed the authorized %s", payment.Amount)
+	}
+
+	if s.viaGateway(payment) {
+		var err error
+		if amount.IsZero() {
+			_, err = s.gateway.CancelPaymentIntent(ctx, payment.TransactionID, "void-"+payment.ID.String())
+		} else {
+			_, err = s.gateway.CapturePaymentIntent(ctx, payment.TransactionID, amount, "capture-"+payment.ID.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to settle payment authorization: %w", err)
+		}
+	}
+
+	capture := &models.PaymentCapture{
+		PaymentID:      payment.ID,
+		CapturedAmount: amount,
+		ReleasedAmount: payment.Amount.Sub(amount),
+		Reason:         reason,
+		CreatedBy:      adminID,
+	}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Guards against the payment being settled concurrently
+		if err := s.paymentRepo.RecordCapture(ctx, capture); err != nil {
+			return err
+		}
+
+		if amount.IsZero() {
+			payment.Status = models.PaymentVoided
+			return nil
+		}
+
+		payment.Amount = amount
+		payment.Status = models.PaymentCompleted
+		return s.onPaymentCompleted(ctx, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return capture, nil
+}
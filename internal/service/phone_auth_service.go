@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/notifications"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// phoneOTPMaxAttempts is how many wrong codes are accepted before a code
+	// is locked and a new one must be requested
+	phoneOTPMaxAttempts = 5
+
+	// phoneOTPResendInterval is how soon another code may be texted to an
+	// account for the same purpose, which bounds SMS spend per account
+	phoneOTPResendInterval = time.Minute
+)
+
+type PhoneAuthService interface {
+	SetPhone(ctx context.Context, userID uuid.UUID, phone string) error
+	VerifyPhone(ctx context.Context, userID uuid.UUID, code string) (*models.User, error)
+	RequestLoginOTP(ctx context.Context, phone string) error
+	LoginWithOTP(ctx context.Context, phone, code string) (*models.LoginResponse, error)
+	RequestReauthOTP(ctx context.Context, userID uuid.UUID) error
+}
+
+type phoneAuthService struct {
+	userRepo    repository.UserRepository
+	otpRepo     repository.PhoneOTPRepository
+	txManager   database.TxManager
+	authService AuthService
+	sms         notifications.SMSSender
+	otpTTL      time.Duration
+}
+
+func NewPhoneAuthService(
+	userRepo repository.UserRepository,
+	otpRepo repository.PhoneOTPRepository,
+	txManager database.TxManager,
+	authService AuthService,
+	sms notifications.SMSSender,
+	otpTTL time.Duration,
+) PhoneAuthService {
+	return &phoneAuthService{
+		userRepo:    userRepo,
+		otpRepo:     otpRepo,
+		txManager:   txManager,
+		authService: authService,
+		sms:         sms,
+		otpTTL:      otpTTL,
+	}
+}
+
+// SetPhone changes the account's phone number and texts a code to verify it.
+// The number cannot sign in until it is verified.
+func (s *phoneAuthService) SetPhone(ctx context.Context, userID uuid.UUID, phone string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperrors.NotFound("user not found")
+	}
+	if user.PhoneVerified && user.Phone != nil && *user.Phone == phone {
+		return apperrors.Conflict("phone number already verified")
+	}
+
+	owner, err := s.userRepo.GetByVerifiedPhone(ctx, phone)
+	if err != nil {
+		return err
+	}
+	if owner != nil && owner.ID != userID {
+		return apperrors.Conflict("phone number is already in use")
+	}
+
+	if err := s.userRepo.SetPhone(ctx, userID, phone); err != nil {
+		return err
+	}
+
+	return s.sendCode(ctx, userID, phone, models.OTPPurposeVerifyPhone,
+		"%s is your verification code. It expires in %d minutes.")
+}
+
+// VerifyPhone marks the account's phone verified with the code texted to it
+func (s *phoneAuthService) VerifyPhone(ctx context.Context, userID uuid.UUID, code string) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperrors.NotFound("user not found")
+	}
+	if user.Phone == nil {
+		return nil, apperrors.Validation("no phone number to verify")
+	}
+	if user.PhoneVerified {
+		return nil, apperrors.Conflict("phone number already verified")
+	}
+
+	ok, err := checkPhoneOTP(ctx, s.otpRepo, userID, models.OTPPurposeVerifyPhone, *user.Phone, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apperrors.Validation("invalid or expired code")
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Another account may have verified the number since the code was sent
+		owner, err := s.userRepo.GetByVerifiedPhone(ctx, *user.Phone)
+		if err != nil {
+			return err
+		}
+		if owner != nil && owner.ID != userID {
+			return apperrors.Conflict("phone number is already in use")
+		}
+
+		return s.userRepo.MarkPhoneVerified(ctx, userID, *user.Phone)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.GetProfile(ctx, userID)
+}
+
+// RequestLoginOTP texts a sign-in code to a verified phone. It succeeds
+// whether or not an account has the number, so it cannot be used to find
+// out who is registered.
+func (s *phoneAuthService) RequestLoginOTP(ctx context.Context, phone string) error {
+	user, err := s.userRepo.GetByVerifiedPhone(ctx, phone)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive {
+		return nil
+	}
+
+	err = s.sendCode(ctx, user.ID, phone, models.OTPPurposeLogin,
+		"%s is your sign-in code. It expires in %d minutes. Never share it with anyone.")
+	if errors.Is(err, apperrors.ErrRateLimited) {
+		log.Printf("⚠️ Sign-in code for user %s not resent: requested too soon", user.ID)
+		return nil
+	}
+	return err
+}
+
+// LoginWithOTP signs in with the code texted to a verified phone, issuing the
+// same token as a password login
+func (s *phoneAuthService) LoginWithOTP(ctx context.Context, phone, code string) (*models.LoginResponse, error) {
+	user, err := s.userRepo.GetByVerifiedPhone(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperrors.Unauthorized("invalid phone number or code")
+	}
+
+	ok, err := checkPhoneOTP(ctx, s.otpRepo, user.ID, models.OTPPurposeLogin, phone, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apperrors.Unauthorized("invalid phone number or code")
+	}
+
+	if !user.IsActive {
+		return nil, apperrors.Forbidden("account is deactivated")
+	}
+
+	token, err := s.authService.GenerateToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordHash = ""
+	return &models.LoginResponse{
+		User:        user,
+		AccessToken: token,
+	}, nil
+}
+
+// RequestReauthOTP texts a code to the account's verified phone that confirms
+// it is still the account holder, for accounts without a password to re-enter
+// before a sensitive action
+func (s *phoneAuthService) RequestReauthOTP(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperrors.NotFound("user not found")
+	}
+	if user.Phone == nil || !user.PhoneVerified {
+		return apperrors.Validation("no verified phone number")
+	}
+
+	return s.sendCode(ctx, userID, *user.Phone, models.OTPPurposeReauth,
+		"%s is your code to confirm it's you. It expires in %d minutes. Never share it with anyone.")
+}
+
+// sendCode texts a fresh code, replacing any outstanding one for the same
+// purpose. message receives the code and its lifetime in minutes.
+func (s *phoneAuthService) sendCode(ctx context.Context, userID uuid.UUID, phone string, purpose models.OTPPurpose, message string) error {
+	previous, err := s.otpRepo.Get(ctx, userID, purpose)
+	if err != nil {
+		return err
+	}
+	if previous != nil && time.Since(previous.CreatedAt) < phoneOTPResendInterval {
+		return apperrors.RateLimited("a code was sent recently, wait a minute before requesting another")
+	}
+
+	code, err := generateOTP()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	otp := &models.PhoneOTP{
+		UserID:    userID,
+		Purpose:   purpose,
+		Phone:     phone,
+		OTPHash:   hashVerificationToken(code),
+		ExpiresAt: now.Add(s.otpTTL),
+		CreatedAt: now,
+	}
+	if err := s.otpRepo.Save(ctx, otp); err != nil {
+		return err
+	}
+
+	if err := s.sms.Send(ctx, phone, fmt.Sprintf(message, code, int(s.otpTTL.Minutes()))); err != nil {
+		return fmt.Errorf("failed to send code by SMS: %w", err)
+	}
+	return nil
+}
+
+// checkPhoneOTP reports whether code is the outstanding one for the purpose
+// and was sent to phone, using it up if so. Each attempt is counted before
+// the code is compared, and the code is locked after too many.
+func checkPhoneOTP(ctx context.Context, otpRepo repository.PhoneOTPRepository, userID uuid.UUID, purpose models.OTPPurpose, phone, code string) (bool, error) {
+	otp, err := otpRepo.Get(ctx, userID, purpose)
+	if err != nil {
+		return false, err
+	}
+	if otp == nil || otp.Phone != phone || time.Now().After(otp.ExpiresAt) {
+		return false, nil
+	}
+
+	otpHash, claimed, err := otpRepo.ClaimAttempt(ctx, otp.ID, phoneOTPMaxAttempts)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, apperrors.RateLimited("too many incorrect attempts, request a new code")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashVerificationToken(code)), []byte(otpHash)) != 1 {
+		return false, nil
+	}
+
+	if err := otpRepo.Delete(ctx, otp.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type PricingService interface {
+	SchedulePrice(ctx context.Context, productID uuid.UUID, req models.PriceScheduleRequest) (*models.PriceSchedule, error)
+	GetSchedules(ctx context.Context, productID uuid.UUID) ([]models.PriceSchedule, error)
+	DeleteSchedule(ctx context.Context, productID, scheduleID uuid.UUID) error
+	ApplyToProducts(ctx context.Context, products []models.Product) error
+	ApplyToCartItems(ctx context.Context, items []models.CartItem) error
+}
+
+type pricingService struct {
+	priceRepo   repository.PriceRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.VariantRepository
+}
+
+func NewPricingService(priceRepo repository.PriceRepository, productRepo repository.ProductRepository, variantRepo repository.VariantRepository) PricingService {
+	return &pricingService{
+		priceRepo:   priceRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+	}
+}
+
+// priceKey identifies what a schedule prices; uuid.Nil stands for the base product
+type priceKey struct {
+	productID uuid.UUID
+	variantID uuid.UUID
+}
+
+func newPriceKey(productID uuid.UUID, variantID *uuid.UUID) priceKey {
+	key := priceKey{productID: productID}
+	if variantID != nil {
+		key.variantID = *variantID
+	}
+	return key
+}
+
+func (s *pricingService) SchedulePrice(ctx context.Context, productID uuid.UUID, req models.PriceScheduleRequest) (*models.PriceSchedule, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	if req.VariantID != nil {
+		variant, err := s.variantRepo.GetByID(ctx, *req.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		if variant == nil || variant.ProductID != productID {
+			return nil, apperrors.NotFound("variant not found")
+		}
+	}
+
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, apperrors.Validation("ends_at must be after starts_at")
+	}
+	if !req.EndsAt.After(time.Now()) {
+		return nil, apperrors.Validation("ends_at must be in the future")
+	}
+
+	schedule := &models.PriceSchedule{
+		ProductID: productID,
+		VariantID: req.VariantID,
+		Price:     req.Price,
+		Label:     req.Label,
+		StartsAt:  req.StartsAt.UTC(),
+		EndsAt:    req.EndsAt.UTC(),
+	}
+	if err := s.priceRepo.Create(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *pricingService) GetSchedules(ctx context.Context, productID uuid.UUID) ([]models.PriceSchedule, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	return s.priceRepo.GetByProductID(ctx, productID)
+}
+
+func (s *pricingService) DeleteSchedule(ctx context.Context, productID, scheduleID uuid.UUID) error {
+	schedule, err := s.priceRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+	if schedule == nil || schedule.ProductID != productID {
+		return apperrors.NotFound("price schedule not found")
+	}
+
+	return s.priceRepo.Delete(ctx, scheduleID)
+}
+
+// activePrices loads the schedules currently in effect for the given products
+func (s *pricingService) activePrices(ctx context.Context, productIDs []uuid.UUID) (map[priceKey]models.PriceSchedule, error) {
+	schedules, err := s.priceRepo.GetActive(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[priceKey]models.PriceSchedule, len(schedules))
+	for _, schedule := range schedules {
+		active[newPriceKey(schedule.ProductID, schedule.VariantID)] = schedule
+	}
+	return active, nil
+}
+
+// ApplyToProducts replaces list prices with any scheduled price in effect and
+// attaches a sale badge holding the original price. Loaded variants are
+// priced too.
+func (s *pricingService) ApplyToProducts(ctx context.Context, products []models.Product) error {
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	active, err := s.activePrices(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		product := &products[i]
+		if schedule, ok := active[newPriceKey(product.ID, nil)]; ok {
+			product.Sale = saleInfo(product.Price, schedule)
+			product.Price = schedule.Price
+		}
+
+		for j := range product.Variants {
+			variant := &product.Variants[j]
+			if schedule, ok := active[newPriceKey(product.ID, &variant.ID)]; ok {
+				variant.Sale = saleInfo(variant.Price, schedule)
+				variant.Price = schedule.Price
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyToCartItems prices cart lines at the scheduled price in effect, so
+// CartItem.UnitPrice returns what the customer will pay
+func (s *pricingService) ApplyToCartItems(ctx context.Context, items []models.CartItem) error {
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	active, err := s.activePrices(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		item := &items[i]
+		schedule, ok := active[newPriceKey(item.ProductID, item.VariantID)]
+		if !ok {
+			continue
+		}
+
+		if item.Variant != nil {
+			item.Variant.Sale = saleInfo(item.Variant.Price, schedule)
+			item.Variant.Price = schedule.Price
+		} else {
+			item.Product.Sale = saleInfo(item.Product.Price, schedule)
+			item.Product.Price = schedule.Price
+		}
+	}
+
+	return nil
+}
+
+func saleInfo(originalPrice money.Money, schedule models.PriceSchedule) *models.SaleInfo {
+	return &models.SaleInfo{
+		OriginalPrice: originalPrice,
+		Label:         schedule.Label,
+		EndsAt:        schedule.EndsAt,
+	}
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/spreadsheet"
+	"ecommerce-backend/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	importBatchSize    = 500
+	maxImportRowErrors = 1000
+)
+
+// importColumnAliases maps accepted header names to product fields
+var importColumnAliases = map[string]string{
+	"sku":            "sku",
+	"name":           "name",
+	"description":    "description",
+	"price":          "price",
+	"stock":          "stock",
+	"stock_quantity": "stock",
+	"quantity":       "stock",
+	"category":       "category",
+	"image_url":      "image_url",
+	"image":          "image_url",
+}
+
+var requiredImportColumns = []string{"sku", "name", "price"}
+
+type ProductImportService interface {
+	StartImport(ctx context.Context, filename string, src io.Reader, userID uuid.UUID) (*models.ProductImportJob, error)
+	GetImportJob(ctx context.Context, jobID uuid.UUID) (*models.ProductImportJob, error)
+}
+
+type productImportService struct {
+	importRepo  repository.ProductImportRepository
+	productRepo repository.ProductRepository
+}
+
+func NewProductImportService(importRepo repository.ProductImportRepository, productRepo repository.ProductRepository) ProductImportService {
+	return &productImportService{
+		importRepo:  importRepo,
+		productRepo: productRepo,
+	}
+}
+
+// StartImport spools the upload to disk, records a pending job and processes
+// it in the background. Poll GetImportJob for progress and row errors.
+func (s *productImportService) StartImport(ctx context.Context, filename string, src io.Reader, userID uuid.UUID) (*models.ProductImportJob, error) {
+	format, err := spreadsheet.DetectFormat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "product-import-*"+filepath.Ext(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store upload: %w", err)
+	}
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("failed to store upload: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("failed to store upload: %w", err)
+	}
+
+	job := &models.ProductImportJob{
+		ID:        uuid.New(),
+		Filename:  filepath.Base(filename),
+		Status:    models.ImportJobPending,
+		RowErrors: []models.ImportRowError{},
+		CreatedBy: &userID,
+	}
+	if err := s.importRepo.Create(ctx, job); err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+
+	// The request context ends with the response; the import outlives it
+	go s.runImport(context.WithoutCancel(ctx), job, tmp.Name(), format)
+
+	return job, nil
+}
+
+func (s *productImportService) GetImportJob(ctx context.Context, jobID uuid.UUID) (*models.ProductImportJob, error) {
+	job, err := s.importRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job == nil {
+		return nil, apperrors.NotFound("import job not found")
+	}
+
+	return job, nil
+}
+
+// importBatch collects validated rows until they are flushed to the database
+type importBatch struct {
+	products []models.Product
+	rows     []int
+	skus     map[string]bool
+}
+
+func newImportBatch() *importBatch {
+	return &importBatch{
+		products: make([]models.Product, 0, importBatchSize),
+		rows:     make([]int, 0, importBatchSize),
+		skus:     make(map[string]bool, importBatchSize),
+	}
+}
+
+func (s *productImportService) runImport(ctx context.Context, job *models.ProductImportJob, path, format string) {
+	defer os.Remove(path)
+
+	log.Printf("📦 Product import %s started (%s)", job.ID, job.Filename)
+
+	job.Status = models.ImportJobProcessing
+	if err := s.importRepo.UpdateProgress(ctx, job); err != nil {
+		log.Printf("❌ Product import %s: failed to update job: %v", job.ID, err)
+	}
+
+	if err := s.processFile(ctx, job, path, format); err != nil {
+		message := err.Error()
+		job.Status = models.ImportJobFailed
+		job.ErrorMessage = &message
+		log.Printf("❌ Product import %s failed: %v", job.ID, err)
+	} else {
+		job.Status = models.ImportJobCompleted
+		log.Printf("✅ Product import %s finished: %d created, %d updated, %d failed",
+			job.ID, job.CreatedCount, job.UpdatedCount, job.FailedCount)
+	}
+
+	if err := s.importRepo.UpdateProgress(ctx, job); err != nil {
+		log.Printf("❌ Product import %s: failed to update job: %v", job.ID, err)
+	}
+}
+
+func (s *productImportService) processFile(ctx context.Context, job *models.ProductImportJob, path, format string) error {
+	reader, err := spreadsheet.Open(path, format)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	header, err := reader.Next()
+	if err == io.EOF {
+		return apperrors.Validation("file is empty")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns, err := mapImportColumns(header)
+	if err != nil {
+		return err
+	}
+
+	batch := newImportBatch()
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read row after %d: %w", reader.Row(), err)
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+		job.TotalRows++
+
+		row := reader.Row()
+		product, rowErrors := parseImportRow(record, columns, row)
+		if len(rowErrors) > 0 {
+			s.recordFailures(job, rowErrors)
+			continue
+		}
+
+		// A repeated SKU would hit the same row twice in one merge, so the
+		// earlier occurrence is written first and the later one wins
+		if batch.skus[product.SKU] {
+			if err := s.flushBatch(ctx, job, batch); err != nil {
+				return err
+			}
+			batch = newImportBatch()
+		}
+
+		batch.products = append(batch.products, product)
+		batch.rows = append(batch.rows, row)
+		batch.skus[product.SKU] = true
+
+		if len(batch.products) >= importBatchSize {
+			if err := s.flushBatch(ctx, job, batch); err != nil {
+				return err
+			}
+			batch = newImportBatch()
+		}
+	}
+
+	return s.flushBatch(ctx, job, batch)
+}
+
+// flushBatch upserts the batch and saves job progress. A batch the database
+// rejects is reported against each of its rows rather than failing the job.
+func (s *productImportService) flushBatch(ctx context.Context, job *models.ProductImportJob, batch *importBatch) error {
+	if len(batch.products) == 0 {
+		return nil
+	}
+
+	created, updated, err := s.productRepo.UpsertBySKU(ctx, batch.products)
+	if err != nil {
+		failures := make([]models.ImportRowError, len(batch.products))
+		for i, product := range batch.products {
+			failures[i] = models.ImportRowError{
+				Row:     batch.rows[i],
+				SKU:     product.SKU,
+				Message: "failed to save batch: " + err.Error(),
+			}
+		}
+		s.recordFailures(job, failures)
+	} else {
+		job.CreatedCount += created
+		job.UpdatedCount += updated
+	}
+
+	return s.importRepo.UpdateProgress(ctx, job)
+}
+
+func (s *productImportService) recordFailures(job *models.ProductImportJob, failures []models.ImportRowError) {
+	rows := make(map[int]bool, len(failures))
+	for _, failure := range failures {
+		rows[failure.Row] = true
+		if len(job.RowErrors) < maxImportRowErrors {
+			job.RowErrors = append(job.RowErrors, failure)
+		}
+	}
+	job.FailedCount += len(rows)
+}
+
+// mapImportColumns resolves header names to column positions
+func mapImportColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if field, ok := importColumnAliases[key]; ok {
+			if _, exists := columns[field]; !exists {
+				columns[field] = i
+			}
+		}
+	}
+
+	var missing []string
+	for _, field := range requiredImportColumns {
+		if _, ok := columns[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, apperrors.Validationf("missing required columns: %s", strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// parseImportRow converts a record into a product, applying the same
+// validation rules as the create product endpoint
+func parseImportRow(record []string, columns map[string]int, row int) (models.Product, []models.ImportRowError) {
+	get := func(field string) string {
+		idx, ok := columns[field]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	req := models.ProductRequest{
+		SKU:         get("sku"),
+		Name:        get("name"),
+		Description: get("description"),
+		Category:    get("category"),
+		ImageURL:    get("image_url"),
+	}
+
+	var rowErrors []models.ImportRowError
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, models.ImportRowError{Row: row, SKU: req.SKU, Field: field, Message: message})
+	}
+
+	if raw := get("price"); raw != "" {
+		price, err := money.Parse(raw)
+		if errors.Is(err, money.ErrPrecision) {
+			fail("price", "Price must have at most 2 decimal places")
+		} else if err != nil {
+			fail("price", "Price must be a number")
+		} else {
+			req.Price = price
+		}
+	}
+
+	if raw := get("stock"); raw != "" {
+		stock, err := strconv.Atoi(raw)
+		if err != nil {
+			fail("stock", "Stock must be a whole number")
+		} else {
+			req.Stock = stock
+		}
+	}
+
+	if errs := utils.ValidateStruct(req); errs != nil {
+		fields := make([]string, 0, len(errs))
+		for field := range errs {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			name := strings.ToLower(field)
+			if !hasFieldError(rowErrors, name) {
+				fail(name, errs[field])
+			}
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		return models.Product{}, rowErrors
+	}
+
+	return models.Product{
+		SKU:         req.SKU,
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       req.Price,
+		Stock:       req.Stock,
+		Category:    req.Category,
+		ImageURL:    req.ImageURL,
+	}, nil
+}
+
+func hasFieldError(rowErrors []models.ImportRowError, field string) bool {
+	for _, e := range rowErrors {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -2,11 +2,17 @@ package service
 
 import (
 	"context"
-	"errors"
+	"log"
+	"strings"
 	"time"
 
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/events"
 	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/internal/search"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/pagination"
 
 	"github.com/google/uuid"
 )
@@ -14,23 +20,75 @@ import (
 type ProductService interface {
 	CreateProduct(ctx context.Context, req models.ProductRequest) (*models.Product, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error)
-	GetProducts(ctx context.Context, page, limit int, category, search string) ([]models.Product, int, error)
-	GetAdminProducts(ctx context.Context, page, limit, rangeDays int) ([]models.Product, int, error)
+	GetProducts(ctx context.Context, page, limit int, filter models.ProductFilter) ([]models.Product, int, error)
+	GetProductsPage(ctx context.Context, filter models.ProductFilter, after *pagination.Cursor, limit int) ([]models.Product, *pagination.Cursor, error)
+	GetProductFacets(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error)
+	GetAdminProducts(ctx context.Context, page, limit, rangeDays int, status models.ProductStatus) ([]models.Product, int, error)
+	ExportProducts(ctx context.Context, rangeDays int, fn func(models.Product) error) error
 	GetTopProducts(ctx context.Context, limit, rangeDays int) ([]models.TopProductItem, error)
 	UpdateProduct(ctx context.Context, id uuid.UUID, req models.ProductUpdateRequest) (*models.Product, error)
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
-	CheckStock(ctx context.Context, productID uuid.UUID, quantity int) (bool, error)
-	CheckStockForCart(ctx context.Context, productID, cartID uuid.UUID, quantity int) (bool, error)
-	ReserveStock(ctx context.Context, productID, cartID uuid.UUID, quantity int) error
-	ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID) error
+	DuplicateProduct(ctx context.Context, id uuid.UUID, req models.DuplicateProductRequest) (*models.Product, error)
+	SetSchedule(ctx context.Context, id uuid.UUID, req models.ProductScheduleRequest) (*models.Product, error)
+	PublishScheduled(ctx context.Context) (int, int, error)
+	CheckStock(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error)
+	CheckStockForCart(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error)
+	ReserveStock(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error
+	SetReservationQuantity(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error
+	ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID) error
+	CreateVariant(ctx context.Context, productID uuid.UUID, req models.ProductVariantRequest) (*models.ProductVariant, error)
+	GetVariants(ctx context.Context, productID uuid.UUID) ([]models.ProductVariant, error)
+	GetVariant(ctx context.Context, productID, variantID uuid.UUID) (*models.ProductVariant, error)
+	UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, req models.ProductVariantUpdateRequest) (*models.ProductVariant, error)
+	DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error
+	GetByBarcode(ctx context.Context, code string) (*models.BarcodeMatch, error)
 }
 
+const defaultPriceBuckets = 5
+
+// duplicateSKUSuffix marks the SKUs of a copied product when no SKU is given
+const duplicateSKUSuffix = "-COPY"
+
 type productService struct {
-	productRepo repository.ProductRepository
+	productRepo    repository.ProductRepository
+	variantRepo    repository.VariantRepository
+	backInStockSvc BackInStockService
+	pricingSvc     PricingService
+	txManager      database.TxManager
+	settingsSvc    SettingsService
+	publisher      events.Publisher
+	relatedSvc     RelatedProductService
+	bundleRepo     repository.BundleRepository
+
+	// searchEngine resolves storefront searches; nil matches them in the
+	// database
+	searchEngine search.Engine
 }
 
-func NewProductService(productRepo repository.ProductRepository) ProductService {
-	return &productService{productRepo: productRepo}
+func NewProductService(
+	productRepo repository.ProductRepository,
+	variantRepo repository.VariantRepository,
+	backInStockSvc BackInStockService,
+	pricingSvc PricingService,
+	txManager database.TxManager,
+	settingsSvc SettingsService,
+	publisher events.Publisher,
+	relatedSvc RelatedProductService,
+	bundleRepo repository.BundleRepository,
+	searchEngine search.Engine,
+) ProductService {
+	return &productService{
+		productRepo:    productRepo,
+		variantRepo:    variantRepo,
+		backInStockSvc: backInStockSvc,
+		pricingSvc:     pricingSvc,
+		txManager:      txManager,
+		settingsSvc:    settingsSvc,
+		publisher:      publisher,
+		relatedSvc:     relatedSvc,
+		bundleRepo:     bundleRepo,
+		searchEngine:   searchEngine,
+	}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req models.ProductRequest) (*models.Product, error) {
@@ -41,21 +99,60 @@ func (s *productService) CreateProduct(ctx context.Context, req models.ProductRe
 	}
 
 	if existingProduct != nil {
-		return nil, errors.New("product with this SKU already exists")
+		return nil, apperrors.Conflict("product with this SKU already exists")
+	}
+
+	if err := validateSchedule(req.PublishAt, req.UnpublishAt); err != nil {
+		return nil, err
+	}
+	if err := validateDimensions(req.Dimensions); err != nil {
+		return nil, err
+	}
+
+	barcode, err := claimBarcode(ctx, s.productRepo, s.variantRepo, req.Barcode, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// A product with a launch time waits for it as a draft
+	status := req.Status
+	if status == "" && req.PublishAt != nil {
+		status = models.ProductDraft
+	} else if status == "" {
+		status = models.ProductActive
 	}
 
 	product := &models.Product{
 		ID:          uuid.New(),
 		SKU:         req.SKU,
+		Status:      status,
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
 		Stock:       req.Stock,
 		Category:    req.Category,
 		ImageURL:    req.ImageURL,
+		CostPrice:   req.CostPrice,
+
+		MaxPerOrder:    req.MaxPerOrder,
+		MaxPerCustomer: req.MaxPerCustomer,
+		PublishAt:      req.PublishAt,
+		UnpublishAt:    req.UnpublishAt,
+		WeightGrams:    req.WeightGrams,
+	}
+	if barcode != "" {
+		product.Barcode = &barcode
+	}
+	if req.Dimensions != nil && !req.Dimensions.IsZero() {
+		product.Dimensions = req.Dimensions
 	}
 
-	err = s.productRepo.Create(ctx, product)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		return s.publishChange(ctx, events.ProductCreated, product.ID)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -63,20 +160,42 @@ func (s *productService) CreateProduct(ctx context.Context, req models.ProductRe
 	return product, nil
 }
 
+// GetProduct returns a storefront product with the products shown alongside
+// it; drafts and archived products are not found
 func (s *productService) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
 	product, err := s.productRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if product == nil {
-		return nil, errors.New("product not found")
+	if product == nil || product.Status != models.ProductActive {
+		return nil, apperrors.NotFound("product not found")
 	}
 
-	return product, nil
+	variants, err := s.variantRepo.GetByProductID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	product.Variants = variants
+
+	if product.Bundle, err = s.bundleRepo.GetComponents(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if product.Related, err = s.relatedSvc.ForProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	// Show any scheduled sale price in place of the list price
+	priced := []models.Product{*product}
+	if err := s.pricingSvc.ApplyToProducts(ctx, priced); err != nil {
+		return nil, err
+	}
+
+	return &priced[0], nil
 }
 
-func (s *productService) GetProducts(ctx context.Context, page, limit int, category, search string) ([]models.Product, int, error) {
+func (s *productService) GetProducts(ctx context.Context, page, limit int, filter models.ProductFilter) ([]models.Product, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -85,10 +204,80 @@ func (s *productService) GetProducts(ctx context.Context, page, limit int, categ
 		limit = 10
 	}
 
-	return s.productRepo.GetAll(ctx, page, limit, category, search)
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, 0, apperrors.Validation("min_price cannot be greater than max_price")
+	}
+
+	s.matchSearch(ctx, &filter)
+	products, total, err := s.productRepo.GetAll(ctx, page, limit, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.pricingSvc.ApplyToProducts(ctx, products); err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
 }
 
-func (s *productService) GetAdminProducts(ctx context.Context, page, limit, rangeDays int) ([]models.Product, int, error) {
+func (s *productService) GetProductsPage(ctx context.Context, filter models.ProductFilter, after *pagination.Cursor, limit int) ([]models.Product, *pagination.Cursor, error) {
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, nil, apperrors.Validation("min_price cannot be greater than max_price")
+	}
+
+	s.matchSearch(ctx, &filter)
+	products, next, err := s.productRepo.GetPage(ctx, filter, after, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.pricingSvc.ApplyToProducts(ctx, products); err != nil {
+		return nil, nil, err
+	}
+
+	return products, next, nil
+}
+
+func (s *productService) GetProductFacets(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error) {
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, apperrors.Validation("min_price cannot be greater than max_price")
+	}
+
+	s.matchSearch(ctx, &filter)
+	return s.productRepo.GetFacets(ctx, filter, defaultPriceBuckets)
+}
+
+// matchSearch resolves the filter's search text through the search engine,
+// when there is one, so typos still match. Without an engine, or when it
+// fails, the database matches the text instead.
+func (s *productService) matchSearch(ctx context.Context, filter *models.ProductFilter) {
+	if s.searchEngine == nil || filter.Search == "" {
+		return
+	}
+
+	query := search.Query{Text: filter.Search}
+	if storeID, ok := database.StoreFromContext(ctx); ok {
+		query.StoreID = &storeID
+	}
+
+	ids, err := s.searchEngine.Search(ctx, query)
+	if err != nil {
+		log.Printf("⚠️ %s search failed, matching in the database: %v", s.searchEngine.Name(), err)
+		return
+	}
+
+	filter.MatchIDs = ids
+	if filter.Sort == "" {
+		filter.Sort = models.ProductSortRelevance
+	}
+}
+
+func (s *productService) GetAdminProducts(ctx context.Context, page, limit, rangeDays int, status models.ProductStatus) ([]models.Product, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -97,7 +286,11 @@ func (s *productService) GetAdminProducts(ctx context.Context, page, limit, rang
 		limit = 10
 	}
 
-	return s.productRepo.GetAllAdmin(ctx, page, limit, rangeDays)
+	return s.productRepo.GetAllAdmin(ctx, page, limit, rangeDays, status)
+}
+
+func (s *productService) ExportProducts(ctx context.Context, rangeDays int, fn func(models.Product) error) error {
+	return s.productRepo.ExportAll(ctx, rangeDays, fn)
 }
 
 func (s *productService) GetTopProducts(ctx context.Context, limit, rangeDays int) ([]models.TopProductItem, error) {
@@ -115,14 +308,35 @@ func (s *productService) UpdateProduct(ctx context.Context, id uuid.UUID, req mo
 	}
 
 	if existingProduct == nil {
-		return nil, errors.New("product not found")
+		return nil, apperrors.NotFound("product not found")
 	}
 
-	err = s.productRepo.Update(ctx, id, &req)
+	if err := validateDimensions(req.Dimensions); err != nil {
+		return nil, err
+	}
+
+	if req.Barcode != nil {
+		barcode, err := claimBarcode(ctx, s.productRepo, s.variantRepo, *req.Barcode, id)
+		if err != nil {
+			return nil, err
+		}
+		req.Barcode = &barcode
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.Update(ctx, id, &req); err != nil {
+			return err
+		}
+		return s.publishChange(ctx, events.ProductUpdated, id)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if req.Stock != nil && *req.Stock > existingProduct.Stock {
+		s.notifyBackInStock(ctx, id)
+	}
+
 	// Get updated product
 	return s.productRepo.GetByID(ctx, id)
 }
@@ -135,14 +349,192 @@ func (s *productService) DeleteProduct(ctx context.Context, id uuid.UUID) error
 	}
 
 	if existingProduct == nil {
-		return errors.New("product not found")
+		return apperrors.NotFound("product not found")
+	}
+
+	isComponent, err := s.bundleRepo.IsComponent(ctx, id)
+	if err != nil {
+		return err
+	}
+	if isComponent {
+		return apperrors.Conflict("product is a component of a bundle")
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.publishChange(ctx, events.ProductDeleted, id)
+	})
+}
+
+// DuplicateProduct copies a product and its variants, or a bundle's
+// components, into a new draft for quick catalog entry. Variant SKUs that
+// start with the product's SKU get the copy's SKU in its place; others get
+// -COPY appended. Stock, barcodes and scheduled prices are not copied.
+func (s *productService) DuplicateProduct(ctx context.Context, id uuid.UUID, req models.DuplicateProductRequest) (*models.Product, error) {
+	original, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if original == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	variants, err := s.variantRepo.GetByProductID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := s.bundleRepo.GetComponents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	product := &models.Product{
+		SKU:            req.SKU,
+		Status:         models.ProductDraft,
+		Name:           req.Name,
+		Description:    original.Description,
+		Price:          original.Price,
+		Category:       original.Category,
+		ImageURL:       original.ImageURL,
+		MaxPerOrder:    original.MaxPerOrder,
+		MaxPerCustomer: original.MaxPerCustomer,
+		WeightGrams:    original.WeightGrams,
+		Dimensions:     original.Dimensions,
+	}
+	if product.SKU == "" {
+		product.SKU = original.SKU + duplicateSKUSuffix
+	}
+	if product.Name == "" {
+		product.Name = original.Name + " (copy)"
+	}
+
+	skus := []string{product.SKU}
+	for _, variant := range variants {
+		sku := variant.SKU + duplicateSKUSuffix
+		if rest, ok := strings.CutPrefix(variant.SKU, original.SKU); ok {
+			sku = product.SKU + rest
+		}
+		product.Variants = append(product.Variants, models.ProductVariant{
+			SKU:        sku,
+			Price:      variant.Price,
+			Attributes: variant.Attributes,
+		})
+		skus = append(skus, sku)
+	}
+
+	// Variant SKUs share the namespace with product SKUs
+	for _, sku := range skus {
+		existingProduct, err := s.productRepo.GetBySKU(ctx, sku)
+		if err != nil {
+			return nil, err
+		}
+		existingVariant, err := s.variantRepo.GetBySKU(ctx, sku)
+		if err != nil {
+			return nil, err
+		}
+		if existingProduct != nil || existingVariant != nil {
+			return nil, apperrors.Conflict("product with SKU " + sku + " already exists")
+		}
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		for i := range product.Variants {
+			product.Variants[i].ProductID = product.ID
+			if err := s.variantRepo.Create(ctx, &product.Variants[i]); err != nil {
+				return err
+			}
+		}
+		// A copied bundle contains the same components
+		if len(components) > 0 {
+			if err := s.bundleRepo.ReplaceComponents(ctx, product.ID, components); err != nil {
+				return err
+			}
+		}
+		return s.publishChange(ctx, events.ProductCreated, product.ID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.productRepo.Delete(ctx, id)
+	return product, nil
+}
+
+// SetSchedule replaces when the product is next published and unpublished
+func (s *productService) SetSchedule(ctx context.Context, id uuid.UUID, req models.ProductScheduleRequest) (*models.Product, error) {
+	if err := validateSchedule(req.PublishAt, req.UnpublishAt); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	if err := s.productRepo.SetSchedule(ctx, id, req.PublishAt, req.UnpublishAt); err != nil {
+		return nil, err
+	}
+
+	return s.productRepo.GetByID(ctx, id)
+}
+
+func validateSchedule(publishAt, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return apperrors.Validation("unpublish_at must be after publish_at")
+	}
+	return nil
 }
 
-func (s *productService) CheckStock(ctx context.Context, productID uuid.UUID, quantity int) (bool, error) {
-	available, err := s.productRepo.GetAvailableStock(ctx, productID)
+// validateDimensions rejects a partial set of dimensions: cubic weight needs
+// all three, and all three 0 clears them
+func validateDimensions(dimensions *models.Dimensions) error {
+	if dimensions != nil && !dimensions.IsZero() && !dimensions.IsComplete() {
+		return apperrors.Validation("dimensions need a length, width and height")
+	}
+	return nil
+}
+
+// PublishScheduled applies the publish and unpublish times that have come,
+// across every store, and returns how many products were published and
+// unpublished. Publishing runs first, so a launch that has already ended
+// finishes as a draft.
+func (s *productService) PublishScheduled(ctx context.Context) (int, int, error) {
+	var published, unpublished []uuid.UUID
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if published, err = s.productRepo.PublishDue(ctx); err != nil {
+			return err
+		}
+		if unpublished, err = s.productRepo.UnpublishDue(ctx); err != nil {
+			return err
+		}
+
+		for _, id := range append(published, unpublished...) {
+			if err := s.publishChange(ctx, events.ProductUpdated, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return len(published), len(unpublished), nil
+}
+
+func (s *productService) CheckStock(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error) {
+	available, err := s.productRepo.GetAvailableStock(ctx, productID, variantID)
 	if err != nil {
 		return false, err
 	}
@@ -150,8 +542,8 @@ func (s *productService) CheckStock(ctx context.Context, productID uuid.UUID, qu
 	return available >= quantity, nil
 }
 
-func (s *productService) CheckStockForCart(ctx context.Context, productID, cartID uuid.UUID, quantity int) (bool, error) {
-	available, err := s.productRepo.GetAvailableStockExcludingCart(ctx, productID, cartID)
+func (s *productService) CheckStockForCart(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error) {
+	available, err := s.productRepo.GetAvailableStockExcludingCart(ctx, productID, cartID, variantID)
 	if err != nil {
 		return false, err
 	}
@@ -159,11 +551,181 @@ func (s *productService) CheckStockForCart(ctx context.Context, productID, cartI
 	return available >= quantity, nil
 }
 
-func (s *productService) ReserveStock(ctx context.Context, productID, cartID uuid.UUID, quantity int) error {
-	expiresAt := time.Now().Add(10 * time.Minute).Unix()
-	return s.productRepo.ReserveStock(ctx, productID, cartID, quantity, expiresAt)
+func (s *productService) ReserveStock(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error {
+	expiresAt := s.reservationExpiry(ctx)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return s.productRepo.ReserveStock(ctx, productID, cartID, variantID, quantity, expiresAt)
+	})
+}
+
+// SetReservationQuantity makes the cart's reservation cover exactly quantity
+// units, e.g. after the cart line changed
+func (s *productService) SetReservationQuantity(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error {
+	expiresAt := s.reservationExpiry(ctx)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return s.productRepo.SetReservationQuantity(ctx, productID, cartID, variantID, quantity, expiresAt)
+	})
+}
+
+// reservationExpiry is when a reservation made or changed now lapses: the
+// stock reservation TTL setting from now, as a Unix timestamp
+func (s *productService) reservationExpiry(ctx context.Context) int64 {
+	return time.Now().Add(s.settingsSvc.Duration(ctx, models.SettingStockReservationTTL)).Unix()
+}
+
+func (s *productService) ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID, variantID *uuid.UUID) error {
+	return s.productRepo.ReleaseStockReservation(ctx, productID, cartID, variantID)
 }
 
-func (s *productService) ReleaseStockReservation(ctx context.Context, productID, cartID uuid.UUID) error {
-	return s.productRepo.ReleaseStockReservation(ctx, productID, cartID)
+func (s *productService) CreateVariant(ctx context.Context, productID uuid.UUID, req models.ProductVariantRequest) (*models.ProductVariant, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	// Bundles are sold whole
+	components, err := s.bundleRepo.GetComponents(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) > 0 {
+		return nil, apperrors.Validation("a bundle cannot have variants")
+	}
+
+	// Variant SKUs share the namespace with product SKUs
+	existingVariant, err := s.variantRepo.GetBySKU(ctx, req.SKU)
+	if err != nil {
+		return nil, err
+	}
+	existingProduct, err := s.productRepo.GetBySKU(ctx, req.SKU)
+	if err != nil {
+		return nil, err
+	}
+	if existingVariant != nil || existingProduct != nil {
+		return nil, apperrors.Conflict("product with this SKU already exists")
+	}
+
+	barcode, err := claimBarcode(ctx, s.productRepo, s.variantRepo, req.Barcode, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := &models.ProductVariant{
+		ProductID:  productID,
+		SKU:        req.SKU,
+		Price:      req.Price,
+		Stock:      req.Stock,
+		Attributes: req.Attributes,
+	}
+	if barcode != "" {
+		variant.Barcode = &barcode
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.variantRepo.Create(ctx, variant); err != nil {
+			return err
+		}
+		return s.publishChange(ctx, events.ProductUpdated, productID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (s *productService) GetVariants(ctx context.Context, productID uuid.UUID) ([]models.ProductVariant, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil || product.Status != models.ProductActive {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	return s.variantRepo.GetByProductID(ctx, productID)
+}
+
+func (s *productService) GetVariant(ctx context.Context, productID, variantID uuid.UUID) (*models.ProductVariant, error) {
+	variant, err := s.variantRepo.GetByID(ctx, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if variant == nil || variant.ProductID != productID {
+		return nil, apperrors.NotFound("variant not found")
+	}
+
+	return variant, nil
+}
+
+func (s *productService) UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, req models.ProductVariantUpdateRequest) (*models.ProductVariant, error) {
+	existing, err := s.GetVariant(ctx, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Barcode != nil {
+		barcode, err := claimBarcode(ctx, s.productRepo, s.variantRepo, *req.Barcode, variantID)
+		if err != nil {
+			return nil, err
+		}
+		req.Barcode = &barcode
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.variantRepo.Update(ctx, variantID, &req); err != nil {
+			return err
+		}
+		return s.publishChange(ctx, events.ProductUpdated, productID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Stock != nil && *req.Stock > existing.Stock {
+		s.notifyBackInStock(ctx, productID)
+	}
+
+	return s.variantRepo.GetByID(ctx, variantID)
+}
+
+func (s *productService) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error {
+	if _, err := s.GetVariant(ctx, productID, variantID); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.variantRepo.Delete(ctx, variantID); err != nil {
+			return err
+		}
+		return s.publishChange(ctx, events.ProductUpdated, productID)
+	})
+}
+
+// GetByBarcode resolves a scanned EAN/UPC barcode to its product, and variant
+// if the barcode is a variant's
+func (s *productService) GetByBarcode(ctx context.Context, code string) (*models.BarcodeMatch, error) {
+	return resolveBarcode(ctx, s.productRepo, s.variantRepo, code)
+}
+
+// publishChange records a product event, e.g. for the search index, in the
+// caller's transaction
+func (s *productService) publishChange(ctx context.Context, eventType string, productID uuid.UUID) error {
+	return s.publisher.Publish(ctx, eventType, events.AggregateProduct, productID, events.ProductChangedPayload{
+		ProductID: productID,
+	})
+}
+
+// notifyBackInStock tells waiting subscribers about a restocked product. The
+// stock update has already been saved, so a failure here is only logged.
+func (s *productService) notifyBackInStock(ctx context.Context, productID uuid.UUID) {
+	if err := s.backInStockSvc.NotifyAvailable(ctx, &productID); err != nil {
+		log.Printf("⚠️ Back-in-stock notification failed for product %s: %v", productID, err)
+	}
 }
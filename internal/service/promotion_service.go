@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req models.PromotionRequest) (*models.Promotion, error)
+	GetPromotions(ctx context.Context) ([]models.Promotion, error)
+	GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error)
+	UpdatePromotion(ctx context.Context, id uuid.UUID, req models.PromotionRequest) (*models.Promotion, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+	Evaluate(ctx context.Context, userID uuid.UUID, items []models.CartItem) ([]models.AppliedPromotion, money.Money, error)
+}
+
+type promotionService struct {
+	promotionRepo repository.PromotionRepository
+	productRepo   repository.ProductRepository
+	flagSvc       FlagService
+}
+
+func NewPromotionService(promotionRepo repository.PromotionRepository, productRepo repository.ProductRepository, flagSvc FlagService) PromotionService {
+	return &promotionService{
+		promotionRepo: promotionRepo,
+		productRepo:   productRepo,
+		flagSvc:       flagSvc,
+	}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, req models.PromotionRequest) (*models.Promotion, error) {
+	promotion := &models.Promotion{}
+	if err := s.apply(ctx, promotion, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionRepo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	return s.promotionRepo.GetAll(ctx)
+}
+
+func (s *promotionService) GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	promotion, err := s.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if promotion == nil {
+		return nil, apperrors.NotFound("promotion not found")
+	}
+	return promotion, nil
+}
+
+func (s *promotionService) UpdatePromotion(ctx context.Context, id uuid.UUID, req models.PromotionRequest) (*models.Promotion, error) {
+	promotion, err := s.GetPromotion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, promotion, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionRepo.Update(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetPromotion(ctx, id); err != nil {
+		return err
+	}
+	return s.promotionRepo.Delete(ctx, id)
+}
+
+// apply validates req against the rules of its type and copies it onto
+// promotion, dropping the fields the type does not use
+func (s *promotionService) apply(ctx context.Context, promotion *models.Promotion, req models.PromotionRequest) error {
+	if (req.PercentOff == nil) == (req.AmountOff == nil) {
+		return apperrors.Validation("exactly one of percent_off and amount_off is required")
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return apperrors.Validation("ends_at must be after starts_at")
+	}
+
+	var category *string
+	if req.Category != nil {
+		if trimmed := strings.TrimSpace(*req.Category); trimmed != "" {
+			category = &trimmed
+		}
+	}
+
+	*promotion = models.Promotion{
+		ID:         promotion.ID,
+		Name:       strings.TrimSpace(req.Name),
+		Type:       req.Type,
+		PercentOff: req.PercentOff,
+		AmountOff:  req.AmountOff,
+		Priority:   req.Priority,
+		Stackable:  req.Stackable == nil || *req.Stackable,
+		IsActive:   req.IsActive == nil || *req.IsActive,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		CreatedAt:  promotion.CreatedAt,
+		UpdatedAt:  promotion.UpdatedAt,
+	}
+
+	switch req.Type {
+	case models.PromotionSpendThreshold:
+		if req.MinSubtotal == nil {
+			return apperrors.Validation("min_subtotal is required for spend_threshold promotions")
+		}
+		promotion.MinSubtotal = req.MinSubtotal
+	case models.PromotionCategory:
+		if category == nil {
+			return apperrors.Validation("category is required for category promotions")
+		}
+		promotion.Category = category
+	case models.PromotionBuyXGetY:
+		if req.BuyQuantity == nil || req.GetQuantity == nil {
+			return apperrors.Validation("buy_quantity and get_quantity are required for buy_x_get_y promotions")
+		}
+		if req.PercentOff == nil {
+			return apperrors.Validation("buy_x_get_y promotions take percent_off; 100 makes the items free")
+		}
+		if req.ProductID != nil {
+			product, err := s.productRepo.GetByID(ctx, *req.ProductID)
+			if err != nil {
+				return err
+			}
+			if product == nil {
+				return apperrors.NotFound("product not found")
+			}
+		}
+		promotion.BuyQuantity = req.BuyQuantity
+		promotion.GetQuantity = req.GetQuantity
+		promotion.ProductID = req.ProductID
+		promotion.Category = category
+	}
+
+	return nil
+}
+
+// Evaluate works out the promotions the priced cart lines qualify for and the
+// total discount they give, never more than the subtotal. Nothing applies
+// while the automatic_promotions flag is off for the user.
+func (s *promotionService) Evaluate(ctx context.Context, userID uuid.UUID, items []models.CartItem) ([]models.AppliedPromotion, money.Money, error) {
+	if len(items) == 0 || !s.flagSvc.IsEnabled(ctx, models.FlagAutomaticPromotions, userID.String()) {
+		return []models.AppliedPromotion{}, 0, nil
+	}
+
+	promotions, err := s.promotionRepo.GetActive(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	applied := applyPromotions(promotions, items)
+
+	var discount money.Money
+	for _, promotion := range applied {
+		discount = discount.Add(promotion.Amount)
+	}
+	return applied, discount, nil
+}
+
+// applyPromotions applies promotions in the order given. A non-stackable
+// promotion is skipped once another applied and ends evaluation when it
+// applies itself. Each discount is worked out on the full line prices and
+// capped to what is left of the subtotal.
+func applyPromotions(promotions []models.Promotion, items []models.CartItem) []models.AppliedPromotion {
+	var subtotal money.Money
+	for _, item := range items {
+		subtotal = subtotal.Add(item.UnitPrice().Mul(item.Quantity))
+	}
+
+	applied := []models.AppliedPromotion{}
+	remaining := subtotal
+	for _, promotion := range promotions {
+		if !promotion.Stackable && len(applied) > 0 {
+			continue
+		}
+
+		amount := min(promotionDiscount(promotion, items, subtotal), remaining)
+		if !amount.IsPositive() {
+			continue
+		}
+
+		id := promotion.ID
+		applied = append(applied, models.AppliedPromotion{
+			PromotionID: &id,
+			Name:        promotion.Name,
+			Amount:      amount,
+		})
+		remaining = remaining.Sub(amount)
+
+		if !promotion.Stackable {
+			break
+		}
+	}
+
+	return applied
+}
+
+// promotionDiscount is what one promotion takes off the cart on its own
+func promotionDiscount(promotion models.Promotion, items []models.CartItem, subtotal money.Money) money.Money {
+	switch promotion.Type {
+	case models.PromotionSpendThreshold:
+		if promotion.MinSubtotal == nil || subtotal < *promotion.MinSubtotal {
+			return 0
+		}
+		return discountOff(promotion, subtotal)
+
+	case models.PromotionCategory:
+		var base money.Money
+		for _, item := range items {
+			if promotion.Category != nil && strings.EqualFold(item.Product.Category, *promotion.Category) {
+				base = base.Add(item.UnitPrice().Mul(item.Quantity))
+			}
+		}
+		return discountOff(promotion, base)
+
+	case models.PromotionBuyXGetY:
+		if promotion.BuyQuantity == nil || promotion.GetQuantity == nil || promotion.PercentOff == nil {
+			return 0
+		}
+
+		// One price per matching unit, dearest first, so the cheapest units
+		// of each full group are the ones discounted
+		prices := []money.Money{}
+		for _, item := range items {
+			if buyXGetYMatches(promotion, item) {
+				for range item.Quantity {
+					prices = append(prices, item.UnitPrice())
+				}
+			}
+		}
+		sort.Slice(prices, func(i, j int) bool { return prices[i] > prices[j] })
+
+		group := *promotion.BuyQuantity + *promotion.GetQuantity
+		discounted := len(prices) / group * *promotion.GetQuantity
+
+		var base money.Money
+		for _, price := range prices[len(prices)-discounted:] {
+			base = base.Add(price)
+		}
+		return base.MulRat(int64(*promotion.PercentOff), 100)
+	}
+
+	return 0
+}
+
+func buyXGetYMatches(promotion models.Promotion, item models.CartItem) bool {
+	if promotion.ProductID != nil {
+		return item.ProductID == *promotion.ProductID
+	}
+	if promotion.Category != nil {
+		return strings.EqualFold(item.Product.Category, *promotion.Category)
+	}
+	return true
+}
+
+// discountOff takes the promotion's percentage or fixed amount off base
+func discountOff(promotion models.Promotion, base money.Money) money.Money {
+	if promotion.PercentOff != nil {
+		return base.MulRat(int64(*promotion.PercentOff), 100)
+	}
+	if promotion.AmountOff != nil {
+		return min(*promotion.AmountOff, base)
+	}
+	return 0
+}
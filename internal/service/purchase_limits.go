@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// checkPurchaseLimits returns an error when buying quantity units of product
+// in a single order would exceed its per-order or per-customer limit
+func checkPurchaseLimits(ctx context.Context, orderRepo repository.OrderRepository, userID uuid.UUID, product *models.Product, quantity int) error {
+	if product.MaxPerOrder != nil && quantity > *product.MaxPerOrder {
+		return apperrors.Validationf("%s is limited to %d per order", product.Name, *product.MaxPerOrder)
+	}
+
+	if product.MaxPerCustomer != nil {
+		purchased, err := orderRepo.GetPurchasedQuantity(ctx, userID, product.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check purchase history: %w", err)
+		}
+
+		if purchased+quantity > *product.MaxPerCustomer {
+			return apperrors.Validationf("%s is limited to %d per customer; you can buy %d more",
+				product.Name, *product.MaxPerCustomer, max(*product.MaxPerCustomer-purchased, 0))
+		}
+	}
+
+	return nil
+}
+
+// cartProductQuantity sums the quantity of a product across every cart line,
+// since limits apply to the product regardless of variant
+func cartProductQuantity(items []models.CartItem, productID uuid.UUID) int {
+	total := 0
+	for _, item := range items {
+		if item.ProductID == productID {
+			total += item.Quantity
+		}
+	}
+	return total
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// relatedListLimit caps each list of related products on a product page
+const relatedListLimit = 8
+
+type RelatedProductService interface {
+	AddRelated(ctx context.Context, productID uuid.UUID, req models.RelatedProductRequest) (*models.RelatedProduct, error)
+	GetRelated(ctx context.Context, productID uuid.UUID) ([]models.RelatedProduct, error)
+	UpdateRelated(ctx context.Context, productID, id uuid.UUID, req models.RelatedProductUpdateRequest) (*models.RelatedProduct, error)
+	RemoveRelated(ctx context.Context, productID, id uuid.UUID) error
+	ForProduct(ctx context.Context, product *models.Product) (*models.ProductRelations, error)
+}
+
+type relatedProductService struct {
+	relatedRepo repository.RelatedProductRepository
+	productRepo repository.ProductRepository
+	pricingSvc  PricingService
+}
+
+func NewRelatedProductService(relatedRepo repository.RelatedProductRepository, productRepo repository.ProductRepository, pricingSvc PricingService) RelatedProductService {
+	return &relatedProductService{
+		relatedRepo: relatedRepo,
+		productRepo: productRepo,
+		pricingSvc:  pricingSvc,
+	}
+}
+
+// AddRelated curates another product of the store to be shown with the
+// product. Drafts may be curated ahead of a launch; only active products are
+// shown.
+func (s *relatedProductService) AddRelated(ctx context.Context, productID uuid.UUID, req models.RelatedProductRequest) (*models.RelatedProduct, error) {
+	if req.RelatedProductID == productID {
+		return nil, apperrors.Validation("a product cannot be related to itself")
+	}
+
+	for _, id := range []uuid.UUID{productID, req.RelatedProductID} {
+		product, err := s.productRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			return nil, apperrors.NotFound("product not found")
+		}
+	}
+
+	exists, err := s.relatedRepo.Exists(ctx, productID, req.RelatedProductID, req.Type)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperrors.Conflict("product is already related as " + string(req.Type))
+	}
+
+	related := &models.RelatedProduct{
+		ProductID:        productID,
+		RelatedProductID: req.RelatedProductID,
+		Type:             req.Type,
+		Position:         req.Position,
+	}
+	if err := s.relatedRepo.Create(ctx, related); err != nil {
+		return nil, err
+	}
+
+	return s.relatedRepo.GetByID(ctx, related.ID)
+}
+
+func (s *relatedProductService) GetRelated(ctx context.Context, productID uuid.UUID) ([]models.RelatedProduct, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	return s.relatedRepo.GetByProductID(ctx, productID)
+}
+
+func (s *relatedProductService) UpdateRelated(ctx context.Context, productID, id uuid.UUID, req models.RelatedProductUpdateRequest) (*models.RelatedProduct, error) {
+	if _, err := s.get(ctx, productID, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.relatedRepo.UpdatePosition(ctx, id, req.Position); err != nil {
+		return nil, err
+	}
+
+	return s.relatedRepo.GetByID(ctx, id)
+}
+
+func (s *relatedProductService) RemoveRelated(ctx context.Context, productID, id uuid.UUID) error {
+	if _, err := s.get(ctx, productID, id); err != nil {
+		return err
+	}
+
+	return s.relatedRepo.Delete(ctx, id)
+}
+
+// get loads a curated link, not found unless it belongs to the product
+func (s *relatedProductService) get(ctx context.Context, productID, id uuid.UUID) (*models.RelatedProduct, error) {
+	related, err := s.relatedRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if related == nil || related.ProductID != productID {
+		return nil, apperrors.NotFound("related product not found")
+	}
+
+	return related, nil
+}
+
+// ForProduct returns the cross-sells and upsells shown with the product,
+// priced as the storefront shows them. A type with no active curated
+// products falls back to automatic picks.
+func (s *relatedProductService) ForProduct(ctx context.Context, product *models.Product) (*models.ProductRelations, error) {
+	crossSell, err := s.relatedRepo.GetCurated(ctx, product.ID, models.RelationCrossSell, relatedListLimit)
+	if err != nil {
+		return nil, err
+	}
+	if len(crossSell) == 0 {
+		if crossSell, err = s.relatedRepo.GetBoughtTogether(ctx, product.ID, relatedListLimit); err != nil {
+			return nil, err
+		}
+	}
+
+	upsell, err := s.relatedRepo.GetCurated(ctx, product.ID, models.RelationUpsell, relatedListLimit)
+	if err != nil {
+		return nil, err
+	}
+	if len(upsell) == 0 {
+		if upsell, err = s.relatedRepo.GetPricierInCategory(ctx, product, relatedListLimit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.pricingSvc.ApplyToProducts(ctx, crossSell); err != nil {
+		return nil, err
+	}
+	if err := s.pricingSvc.ApplyToProducts(ctx, upsell); err != nil {
+		return nil, err
+	}
+
+	return &models.ProductRelations{CrossSell: crossSell, Upsell: upsell}, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+)
+
+type ReservationCleanupService interface {
+	PurgeExpired(ctx context.Context) (*models.ReservationCleanupResult, error)
+	Start(ctx context.Context, interval time.Duration)
+	Stats() models.ReservationCleanupStats
+}
+
+type reservationCleanupService struct {
+	productRepo    repository.ProductRepository
+	backInStockSvc BackInStockService
+
+	mu    sync.Mutex
+	stats models.ReservationCleanupStats
+}
+
+func NewReservationCleanupService(productRepo repository.ProductRepository, backInStockSvc BackInStockService) ReservationCleanupService {
+	return &reservationCleanupService{
+		productRepo:    productRepo,
+		backInStockSvc: backInStockSvc,
+	}
+}
+
+func (s *reservationCleanupService) PurgeExpired(ctx context.Context) (*models.ReservationCleanupResult, error) {
+	count, quantity, err := s.productRepo.PurgeExpiredReservations(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Runs++
+	if err != nil {
+		s.stats.Failures++
+		s.stats.LastError = err.Error()
+		return nil, fmt.Errorf("failed to purge expired reservations: %w", err)
+	}
+
+	result := &models.ReservationCleanupResult{
+		PurgedReservations: count,
+		ReclaimedQuantity:  quantity,
+		RanAt:              time.Now().UTC(),
+	}
+	s.stats.TotalPurgedReservations += int64(count)
+	s.stats.TotalReclaimedQuantity += int64(quantity)
+	s.stats.LastResult = result
+	s.stats.LastError = ""
+
+	return result, nil
+}
+
+// Start runs the cleanup on a fixed interval until ctx is cancelled
+func (s *reservationCleanupService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⚠️ Stock reservation cleanup worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				result, err := s.PurgeExpired(ctx)
+				if err != nil {
+					log.Printf("⚠️ Stock reservation cleanup failed: %v", err)
+					continue
+				}
+				if result.PurgedReservations > 0 {
+					log.Printf("🧹 Purged %d expired stock reservations (%d units reclaimed)",
+						result.PurgedReservations, result.ReclaimedQuantity)
+
+					// Reclaimed units may bring sold-out products back
+					if err := s.backInStockSvc.NotifyAvailable(ctx, nil); err != nil {
+						log.Printf("⚠️ Back-in-stock notification failed: %v", err)
+					}
+				}
+			}
+		}
+	}()
+
+	log.Printf("🧹 Stock reservation cleanup worker running every %s", interval)
+}
+
+func (s *reservationCleanupService) Stats() models.ReservationCleanupStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/events"
 	"ecommerce-backend/internal/models"
 	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+	"ecommerce-backend/pkg/money"
 
 	"github.com/google/uuid"
 )
@@ -18,26 +22,55 @@ type ReturnService interface {
 	GetUserReturns(ctx context.Context, userID uuid.UUID, page, limit int) ([]models.Return, int, error)
 	GetAllReturns(ctx context.Context, page, limit int, status string, rangeDays int) ([]models.AdminReturn, int, error)
 	ProcessReturn(ctx context.Context, returnID uuid.UUID, req models.ProcessReturnRequest) (*models.Return, error)
+	ShipReturn(ctx context.Context, returnID, userID uuid.UUID, req models.ShipReturnRequest) (*models.Return, error)
+	MarkReturnReceived(ctx context.Context, returnID uuid.UUID) (*models.Return, error)
+	GetReturnLabel(ctx context.Context, returnID, userID uuid.UUID) (*models.ReturnLabel, error)
 }
 
 type returnService struct {
-	returnRepo  repository.ReturnRepository
-	orderRepo   repository.OrderRepository
-	paymentSvc  PaymentService
-	productRepo repository.ProductRepository
+	returnRepo   repository.ReturnRepository
+	orderRepo    repository.OrderRepository
+	variantRepo  repository.VariantRepository
+	orderSvc     OrderService
+	paymentSvc   PaymentService
+	warehouseSvc WarehouseService
+	txManager    database.TxManager
+	publisher    events.Publisher
+
+	notificationSvc NotificationService
+	backInStockSvc  BackInStockService
+	giftCardSvc     GiftCardService
+	returnAddress   string
 }
 
 func NewReturnService(
 	returnRepo repository.ReturnRepository,
 	orderRepo repository.OrderRepository,
+	variantRepo repository.VariantRepository,
+	orderSvc OrderService,
 	paymentSvc PaymentService,
-	productRepo repository.ProductRepository,
+	warehouseSvc WarehouseService,
+	txManager database.TxManager,
+	publisher events.Publisher,
+	notificationSvc NotificationService,
+	backInStockSvc BackInStockService,
+	giftCardSvc GiftCardService,
+	returnAddress string,
 ) ReturnService {
 	return &returnService{
-		returnRepo:  returnRepo,
-		orderRepo:   orderRepo,
-		paymentSvc:  paymentSvc,
-		productRepo: productRepo,
+		returnRepo:   returnRepo,
+		orderRepo:    orderRepo,
+		variantRepo:  variantRepo,
+		orderSvc:     orderSvc,
+		paymentSvc:   paymentSvc,
+		warehouseSvc: warehouseSvc,
+		txManager:    txManager,
+		publisher:    publisher,
+
+		notificationSvc: notificationSvc,
+		backInStockSvc:  backInStockSvc,
+		giftCardSvc:     giftCardSvc,
+		returnAddress:   returnAddress,
 	}
 }
 
@@ -49,23 +82,23 @@ func (s *returnService) CreateReturn(ctx context.Context, req models.CreateRetur
 	}
 
 	if order == nil {
-		return nil, errors.New("order not found")
+		return nil, apperrors.NotFound("order not found")
 	}
 
 	// Verify order belongs to user
 	if order.UserID != userID {
-		return nil, errors.New("unauthorized to create return for this order")
+		return nil, apperrors.Forbidden("unauthorized to create return for this order")
 	}
 
 	// Check if order can be returned
 	if order.Status != models.OrderDelivered && order.Status != models.OrderCompleted {
-		return nil, errors.New("order cannot be returned at this stage")
+		return nil, apperrors.Conflict("order cannot be returned at this stage")
 	}
 
 	// Check if return period has expired (14 days from delivery)
 	deliveryTime := order.CreatedAt.Add(7 * 24 * time.Hour) // Assuming 7 days for delivery
 	if time.Since(deliveryTime) > 14*24*time.Hour {
-		return nil, errors.New("return period has expired")
+		return nil, apperrors.Validation("return period has expired")
 	}
 
 	// Check if return already exists for this order
@@ -75,8 +108,9 @@ func (s *returnService) CreateReturn(ctx context.Context, req models.CreateRetur
 	}
 
 	for _, r := range existingReturns {
-		if r.Status == models.ReturnRequested || r.Status == models.ReturnApproved {
-			return nil, errors.New("return already requested for this order")
+		if r.Status == models.ReturnRequested || r.Status == models.ReturnApproved ||
+			r.Status == models.ReturnInTransit || r.Status == models.ReturnReceived {
+			return nil, apperrors.Conflict("return already requested for this order")
 		}
 	}
 
@@ -92,6 +126,16 @@ func (s *returnService) CreateReturn(ctx context.Context, req models.CreateRetur
 		UpdatedAt:    time.Now(),
 	}
 
+	// Exchanges are settled with the replacement, not a refund
+	if req.OrderItemID != nil {
+		if err := s.checkExchange(ctx, order, *req.OrderItemID, *req.ReplacementVariantID); err != nil {
+			return nil, err
+		}
+		returnReq.ExchangeItemID = req.OrderItemID
+		returnReq.ReplacementVariantID = req.ReplacementVariantID
+		returnReq.RefundAmount = 0
+	}
+
 	err = s.returnRepo.Create(ctx, returnReq)
 	if err != nil {
 		return nil, err
@@ -112,12 +156,12 @@ func (s *returnService) GetReturn(ctx context.Context, returnID uuid.UUID, userI
 	}
 
 	if returnReq == nil {
-		return nil, errors.New("return not found")
+		return nil, apperrors.NotFound("return not found")
 	}
 
 	// Check if user is authorized to view this return
 	if returnReq.UserID != userID {
-		return nil, errors.New("unauthorized to view this return")
+		return nil, apperrors.Forbidden("unauthorized to view this return")
 	}
 
 	return returnReq, nil
@@ -154,55 +198,349 @@ func (s *returnService) ProcessReturn(ctx context.Context, returnID uuid.UUID, r
 	}
 
 	if returnReq == nil {
-		return nil, errors.New("return not found")
+		return nil, apperrors.NotFound("return not found")
 	}
 
-	// Get order
-	order, err := s.orderRepo.GetByID(ctx, returnReq.OrderID)
-	if err != nil {
-		return nil, err
-	}
+	switch req.Status {
+	case models.ReturnApproved:
+		if returnReq.Status != models.ReturnRequested {
+			return nil, apperrors.Conflict("only requested returns can be approved")
+		}
+
+		// Approval issues the RMA the customer ships the parcel under
+		rmaNumber := generateRMANumber()
+		err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := s.returnRepo.Approve(ctx, returnID, rmaNumber); err != nil {
+				return err
+			}
+
+			// The replacement ships without waiting for the exchanged item
+			if returnReq.IsExchange() {
+				if err := s.createReplacement(ctx, returnReq); err != nil {
+					return err
+				}
+			}
+
+			err := s.publisher.Publish(ctx, events.ReturnApproved, events.AggregateReturn, returnID, events.ReturnApprovedPayload{
+				ReturnID:  returnID,
+				OrderID:   returnReq.OrderID,
+				UserID:    returnReq.UserID,
+				RMANumber: rmaNumber,
+			})
+			if err != nil {
+				return err
+			}
+
+			return s.notificationSvc.Notify(ctx, returnReq.UserID, models.NotificationReturn,
+				"Return approved",
+				fmt.Sprintf("Your return was approved. Ship it back using RMA %s.", rmaNumber),
+				map[string]interface{}{"return_id": returnID, "order_id": returnReq.OrderID, "rma_number": rmaNumber})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case models.ReturnRejected:
+		if returnReq.Status != models.ReturnRequested && returnReq.Status != models.ReturnApproved {
+			return nil, apperrors.Conflict("return can no longer be rejected")
+		}
+		if returnReq.ReplacementOrderID != nil {
+			return nil, apperrors.Conflict("replacement has already been sent; cancel the replacement order instead")
+		}
+
+		err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := s.returnRepo.UpdateStatus(ctx, returnID, req.Status, returnReq.RefundAmount); err != nil {
+				return err
+			}
+
+			return s.notificationSvc.Notify(ctx, returnReq.UserID, models.NotificationReturn,
+				"Return rejected",
+				"Your return request was not approved. Contact support if you have questions.",
+				map[string]interface{}{"return_id": returnID, "order_id": returnReq.OrderID})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case models.ReturnCompleted:
+		if returnReq.Status != models.ReturnReceived {
+			return nil, apperrors.Conflict("return items must be received before the refund is issued")
+		}
+
+		// The replacement settled the exchange, so nothing is refunded
+		if returnReq.IsExchange() {
+			if err := s.returnRepo.UpdateStatus(ctx, returnID, req.Status, 0); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		// Get order
+		order, err := s.orderRepo.GetByID(ctx, returnReq.OrderID)
+		if err != nil {
+			return nil, err
+		}
 
-	// Process based on status
-	if req.Status == models.ReturnApproved {
 		// Calculate refund amount (full refund for demo)
 		refundAmount := req.RefundAmount
 		if refundAmount == 0 {
 			refundAmount = order.TotalAmount
 		}
 
-		// Process refund through payment service
-		payment, err := s.paymentSvc.GetPaymentByOrderID(ctx, returnReq.OrderID)
+		if refundAmount > order.TotalAmount {
+			return nil, apperrors.Validation("refund amount cannot exceed order total")
+		}
+
+		// Refund and close the return together
+		err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if req.RefundMethod == models.RefundToStoreCredit {
+				if err := s.refundToStoreCredit(ctx, order, returnID, refundAmount); err != nil {
+					return err
+				}
+			} else if err := s.refundToOriginal(ctx, order, returnReq, refundAmount); err != nil {
+				return err
+			}
+
+			return s.returnRepo.UpdateStatus(ctx, returnID, req.Status, refundAmount)
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		if payment != nil {
-			err = s.paymentSvc.ProcessRefund(ctx, payment.ID, refundAmount)
-			if err != nil {
-				return nil, err
-			}
+	default:
+		return nil, apperrors.Validationf("unsupported return status: %s", req.Status)
+	}
+
+	// Get updated return
+	return s.returnRepo.GetByID(ctx, returnID)
+}
+
+// refundToOriginal pays the refund back the way the order was paid: to the
+// payment first, then any remainder onto the gift cards that were redeemed
+func (s *returnService) refundToOriginal(ctx context.Context, order *models.Order, returnReq *models.Return, amount money.Money) error {
+	payment, err := s.paymentSvc.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	remaining := amount
+	if payment != nil && payment.Refundable().IsPositive() {
+		toPayment := remaining
+		if toPayment > payment.Refundable() {
+			toPayment = payment.Refundable()
+		}
+
+		err := s.paymentSvc.ProcessRefund(ctx, &models.Refund{
+			PaymentID: payment.ID,
+			Amount:    toPayment,
+			ReturnID:  &returnReq.ID,
+			Reason:    returnReq.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		remaining = remaining.Sub(toPayment)
+	}
+
+	if !remaining.IsPositive() || !order.GiftCardAmount.IsPositive() {
+		return nil
+	}
+
+	if _, err := s.giftCardSvc.RestoreForOrder(ctx, order.ID, remaining, &returnReq.ID); err != nil {
+		return err
+	}
+
+	// ProcessRefund marks the order refunded when the payment is refunded in
+	// full; orders paid entirely by gift card are marked here
+	if remaining == order.TotalAmount {
+		return s.orderRepo.UpdateStatus(ctx, order.ID, models.OrderRefunded)
+	}
+	return nil
+}
+
+// refundToStoreCredit credits the whole refund to the customer's store credit
+// instead of going back through the payment gateway
+func (s *returnService) refundToStoreCredit(ctx context.Context, order *models.Order, returnID uuid.UUID, amount money.Money) error {
+	if err := s.giftCardSvc.CreditStoreCredit(ctx, order.UserID, amount, &order.ID, &returnID); err != nil {
+		return err
+	}
+
+	if amount == order.TotalAmount {
+		if err := s.orderRepo.UpdateStatus(ctx, order.ID, models.OrderRefunded); err != nil {
+			return err
 		}
+	}
+
+	return s.notificationSvc.Notify(ctx, order.UserID, models.NotificationRefund,
+		"Store credit issued",
+		fmt.Sprintf("%s from order %s has been added to your store credit.", amount, order.OrderNumber),
+		map[string]interface{}{"order_id": order.ID, "return_id": returnID, "amount": amount})
+}
+
+// checkExchange makes sure the item is on the order and the replacement is a
+// different variant of the same product
+func (s *returnService) checkExchange(ctx context.Context, order *models.Order, itemID, variantID uuid.UUID) error {
+	item := findOrderItem(order, itemID)
+	if item == nil {
+		return apperrors.Validation("order item not found on this order")
+	}
+
+	variant, err := s.variantRepo.GetByID(ctx, variantID)
+	if err != nil {
+		return err
+	}
+	if variant == nil || variant.ProductID != item.ProductID {
+		return apperrors.Validation("replacement must be a variant of the same product")
+	}
+	if item.VariantID != nil && *item.VariantID == variantID {
+		return apperrors.Validation("replacement must be a different variant")
+	}
 
-		// Restore stock for order items
+	return nil
+}
+
+// createReplacement places the free order that ships an exchange's
+// replacement variant and links it to the return
+func (s *returnService) createReplacement(ctx context.Context, returnReq *models.Return) error {
+	order, err := s.orderRepo.GetByID(ctx, returnReq.OrderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return apperrors.NotFound("order not found")
+	}
+
+	item := findOrderItem(order, *returnReq.ExchangeItemID)
+	if item == nil {
+		return apperrors.NotFound("exchanged item not found")
+	}
+
+	replacement, err := s.orderSvc.CreateReplacementOrder(ctx, order, *item, *returnReq.ReplacementVariantID)
+	if err != nil {
+		return err
+	}
+
+	return s.returnRepo.SetReplacementOrder(ctx, returnReq.ID, replacement.ID)
+}
+
+func findOrderItem(order *models.Order, itemID uuid.UUID) *models.OrderItem {
+	for i := range order.Items {
+		if order.Items[i].ID == itemID {
+			return &order.Items[i]
+		}
+	}
+	return nil
+}
+
+func (s *returnService) ShipReturn(ctx context.Context, returnID, userID uuid.UUID, req models.ShipReturnRequest) (*models.Return, error) {
+	returnReq, err := s.returnRepo.GetByID(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if returnReq == nil {
+		return nil, apperrors.NotFound("return not found")
+	}
+
+	if returnReq.UserID != userID {
+		return nil, apperrors.Forbidden("unauthorized to update this return")
+	}
+
+	if returnReq.Status != models.ReturnApproved && returnReq.Status != models.ReturnInTransit {
+		return nil, apperrors.Conflict("return must be approved before it can be shipped")
+	}
+
+	if err := s.returnRepo.MarkInTransit(ctx, returnID, req.Carrier, req.TrackingNumber); err != nil {
+		return nil, err
+	}
+
+	return s.returnRepo.GetByID(ctx, returnID)
+}
+
+func (s *returnService) MarkReturnReceived(ctx context.Context, returnID uuid.UUID) (*models.Return, error) {
+	returnReq, err := s.returnRepo.GetByID(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if returnReq == nil {
+		return nil, apperrors.NotFound("return not found")
+	}
+
+	// Parcels sometimes arrive without the customer entering tracking details
+	if returnReq.Status != models.ReturnApproved && returnReq.Status != models.ReturnInTransit {
+		return nil, apperrors.Conflict("return is not awaiting receipt")
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, returnReq.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	// An exchange only sends back the exchanged item
+	items := order.Items
+	if returnReq.IsExchange() {
+		items = nil
 		for _, item := range order.Items {
-			err = s.productRepo.UpdateStock(ctx, item.ProductID, item.Quantity)
-			if err != nil {
-				return nil, fmt.Errorf("failed to restore stock for product %s: %w",
-					item.ProductID, err)
+			if item.ID == *returnReq.ExchangeItemID {
+				items = append(items, item)
 			}
 		}
-
-		// Update return with refund amount
-		returnReq.RefundAmount = refundAmount
 	}
 
-	// Update return status
-	err = s.returnRepo.UpdateStatus(ctx, returnID, req.Status, returnReq.RefundAmount)
+	// Restock the returned items now that they are physically back. Marking
+	// the return received first makes a concurrent request fail before it
+	// restocks the same items again.
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.returnRepo.MarkReceived(ctx, returnID); err != nil {
+			return err
+		}
+
+		return s.warehouseSvc.RestockItems(ctx, items)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Get updated return
+	notifyRestocked(ctx, s.backInStockSvc, items)
+
 	return s.returnRepo.GetByID(ctx, returnID)
 }
+
+func (s *returnService) GetReturnLabel(ctx context.Context, returnID, userID uuid.UUID) (*models.ReturnLabel, error) {
+	returnReq, err := s.GetReturn(ctx, returnID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if returnReq.RMANumber == nil {
+		return nil, apperrors.Conflict("return label is available once the return is approved")
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, returnReq.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	return &models.ReturnLabel{
+		RMANumber:    *returnReq.RMANumber,
+		OrderNumber:  order.OrderNumber,
+		ShipFrom:     order.ShippingAddress,
+		ShipTo:       s.returnAddress,
+		Instructions: fmt.Sprintf("Write %s clearly on the outside of the parcel.", *returnReq.RMANumber),
+	}, nil
+}
+
+func generateRMANumber() string {
+	date := time.Now().Format("20060102")
+	random := strings.ToUpper(uuid.New().String()[:8])
+	return fmt.Sprintf("RMA-%s-%s", date, random)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ScheduledPublishingService launches and withdraws products at their
+// scheduled publish and unpublish times
+type ScheduledPublishingService interface {
+	Start(ctx context.Context, interval time.Duration)
+}
+
+type scheduledPublishingService struct {
+	productSvc ProductService
+}
+
+func NewScheduledPublishingService(productSvc ProductService) ScheduledPublishingService {
+	return &scheduledPublishingService{productSvc: productSvc}
+}
+
+// Start checks for due schedules on a fixed interval until ctx is cancelled
+func (s *scheduledPublishingService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⚠️ Scheduled product publishing worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				published, unpublished, err := s.productSvc.PublishScheduled(ctx)
+				if published > 0 || unpublished > 0 {
+					log.Printf("🗓️ Published %d and unpublished %d scheduled products", published, unpublished)
+				}
+				if err != nil {
+					log.Printf("⚠️ Scheduled product publishing failed: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("🗓️ Scheduled product publishing worker running every %s", interval)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type SegmentService interface {
+	CreateSegment(ctx context.Context, req models.CustomerSegmentRequest) (*models.CustomerSegment, error)
+	GetSegments(ctx context.Context) ([]models.CustomerSegment, error)
+	GetSegment(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error)
+	UpdateSegment(ctx context.Context, id uuid.UUID, req models.CustomerSegmentRequest) (*models.CustomerSegment, error)
+	DeleteSegment(ctx context.Context, id uuid.UUID) error
+	RefreshSegment(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error)
+	RefreshAll(ctx context.Context) (int, error)
+	GetMembers(ctx context.Context, id uuid.UUID, page, limit int) ([]models.SegmentMember, int, error)
+	ExportMembers(ctx context.Context, id uuid.UUID, fn func(models.SegmentMember) error) error
+	Start(ctx context.Context, interval time.Duration)
+}
+
+type segmentService struct {
+	segmentRepo repository.SegmentRepository
+}
+
+func NewSegmentService(segmentRepo repository.SegmentRepository) SegmentService {
+	return &segmentService{segmentRepo: segmentRepo}
+}
+
+// CreateSegment saves the segment and materializes its members right away,
+// so it is usable without waiting for the refresh worker
+func (s *segmentService) CreateSegment(ctx context.Context, req models.CustomerSegmentRequest) (*models.CustomerSegment, error) {
+	segment := &models.CustomerSegment{}
+	applySegmentRequest(segment, req)
+
+	if err := s.segmentRepo.Create(ctx, segment); err != nil {
+		return nil, err
+	}
+	if err := s.segmentRepo.Refresh(ctx, segment); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+func (s *segmentService) GetSegments(ctx context.Context) ([]models.CustomerSegment, error) {
+	return s.segmentRepo.GetAll(ctx)
+}
+
+func (s *segmentService) GetSegment(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error) {
+	segment, err := s.segmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if segment == nil {
+		return nil, apperrors.NotFound("segment not found")
+	}
+	return segment, nil
+}
+
+// UpdateSegment replaces the segment's filters and recomputes its members
+func (s *segmentService) UpdateSegment(ctx context.Context, id uuid.UUID, req models.CustomerSegmentRequest) (*models.CustomerSegment, error) {
+	segment, err := s.GetSegment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applySegmentRequest(segment, req)
+	if err := s.segmentRepo.Update(ctx, segment); err != nil {
+		return nil, err
+	}
+	if err := s.segmentRepo.Refresh(ctx, segment); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+func (s *segmentService) DeleteSegment(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetSegment(ctx, id); err != nil {
+		return err
+	}
+	return s.segmentRepo.Delete(ctx, id)
+}
+
+func (s *segmentService) RefreshSegment(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error) {
+	segment, err := s.GetSegment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.segmentRepo.Refresh(ctx, segment); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// RefreshAll recomputes the members of every segment the context can see and
+// reports how many it refreshed. A segment that fails does not stop the rest.
+func (s *segmentService) RefreshAll(ctx context.Context) (int, error) {
+	segments, err := s.segmentRepo.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list segments: %w", err)
+	}
+
+	refreshed := 0
+	var firstErr error
+	for i := range segments {
+		if err := s.segmentRepo.Refresh(ctx, &segments[i]); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to refresh segment %s: %w", segments[i].ID, err)
+			}
+			continue
+		}
+		refreshed++
+	}
+
+	return refreshed, firstErr
+}
+
+func (s *segmentService) GetMembers(ctx context.Context, id uuid.UUID, page, limit int) ([]models.SegmentMember, int, error) {
+	if _, err := s.GetSegment(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	return s.segmentRepo.GetMembers(ctx, id, page, limit)
+}
+
+// ExportMembers streams every member of the segment to fn. Check the segment
+// exists with GetSegment first when the caller must answer a missing one
+// before output starts.
+func (s *segmentService) ExportMembers(ctx context.Context, id uuid.UUID, fn func(models.SegmentMember) error) error {
+	return s.segmentRepo.ExportMembers(ctx, id, fn)
+}
+
+// Start refreshes every store's segments on a fixed interval until ctx is
+// cancelled
+func (s *segmentService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⚠️ Customer segment refresh worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refreshed, err := s.RefreshAll(ctx)
+				if refreshed > 0 {
+					log.Printf("👥 Refreshed %d customer segments", refreshed)
+				}
+				if err != nil {
+					log.Printf("⚠️ Customer segment refresh failed: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("👥 Customer segment refresh worker running every %s", interval)
+}
+
+func applySegmentRequest(segment *models.CustomerSegment, req models.CustomerSegmentRequest) {
+	segment.Name = strings.TrimSpace(req.Name)
+	segment.MinSpend = req.MinSpend
+	segment.InactiveDays = req.InactiveDays
+
+	segment.Category = nil
+	if req.Category != nil {
+		if category := strings.TrimSpace(*req.Category); category != "" {
+			segment.Category = &category
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// notServiceableCode is the error code checkout fails with when the shipping
+// address is outside every serviceable area
+const notServiceableCode = "postal_code_not_serviceable"
+
+type ServiceabilityService interface {
+	CreateArea(ctx context.Context, req models.CreateServiceableAreaRequest) (*models.ServiceableArea, error)
+	GetAreas(ctx context.Context) ([]models.ServiceableArea, error)
+	UpdateArea(ctx context.Context, id uuid.UUID, req models.UpdateServiceableAreaRequest) (*models.ServiceableArea, error)
+	DeleteArea(ctx context.Context, id uuid.UUID) error
+	Check(ctx context.Context, postalCode string) (*models.Serviceability, error)
+	EnsureServiceable(ctx context.Context, postalCode string) error
+}
+
+type serviceabilityService struct {
+	areaRepo repository.ServiceableAreaRepository
+}
+
+func NewServiceabilityService(areaRepo repository.ServiceableAreaRepository) ServiceabilityService {
+	return &serviceabilityService{areaRepo: areaRepo}
+}
+
+func (s *serviceabilityService) CreateArea(ctx context.Context, req models.CreateServiceableAreaRequest) (*models.ServiceableArea, error) {
+	postalCode := normalizePostalCode(req.PostalCode)
+	if postalCode == "" {
+		return nil, apperrors.Validation("postal code is required")
+	}
+
+	existing, err := s.areaRepo.GetByPostalCode(ctx, postalCode)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.Conflict("a serviceable area with this postal code already exists")
+	}
+
+	area := &models.ServiceableArea{
+		PostalCode: postalCode,
+		Name:       strings.TrimSpace(req.Name),
+		IsActive:   true,
+	}
+	if err := s.areaRepo.Create(ctx, area); err != nil {
+		return nil, err
+	}
+
+	return area, nil
+}
+
+func (s *serviceabilityService) GetAreas(ctx context.Context) ([]models.ServiceableArea, error) {
+	return s.areaRepo.GetAll(ctx)
+}
+
+func (s *serviceabilityService) getArea(ctx context.Context, id uuid.UUID) (*models.ServiceableArea, error) {
+	area, err := s.areaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if area == nil {
+		return nil, apperrors.NotFound("serviceable area not found")
+	}
+	return area, nil
+}
+
+func (s *serviceabilityService) UpdateArea(ctx context.Context, id uuid.UUID, req models.UpdateServiceableAreaRequest) (*models.ServiceableArea, error) {
+	area, err := s.getArea(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		area.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.IsActive != nil {
+		area.IsActive = *req.IsActive
+	}
+
+	if err := s.areaRepo.Update(ctx, area); err != nil {
+		return nil, err
+	}
+
+	return area, nil
+}
+
+func (s *serviceabilityService) DeleteArea(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getArea(ctx, id); err != nil {
+		return err
+	}
+	return s.areaRepo.Delete(ctx, id)
+}
+
+// Check reports whether orders can ship to postalCode. Until any area is
+// active every postal code is serviceable.
+func (s *serviceabilityService) Check(ctx context.Context, postalCode string) (*models.Serviceability, error) {
+	postalCode = normalizePostalCode(postalCode)
+	if postalCode == "" {
+		return nil, apperrors.Validation("postal_code is required")
+	}
+
+	result := &models.Serviceability{PostalCode: postalCode}
+
+	area, err := s.areaRepo.Match(ctx, postalCode)
+	if err != nil {
+		return nil, err
+	}
+	if area != nil {
+		result.Serviceable = true
+		result.Area = area
+		return result, nil
+	}
+
+	restricted, err := s.areaRepo.HasActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Serviceable = !restricted
+
+	return result, nil
+}
+
+// EnsureServiceable fails with the postal_code_not_serviceable error code
+// when orders cannot ship to postalCode
+func (s *serviceabilityService) EnsureServiceable(ctx context.Context, postalCode string) error {
+	result, err := s.Check(ctx, postalCode)
+	if err != nil {
+		return err
+	}
+	if !result.Serviceable {
+		return apperrors.Coded(apperrors.ErrValidation, notServiceableCode,
+			"we do not deliver to postal code "+result.PostalCode+" yet")
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+// Package servicemock holds generated mocks of the service interfaces, for
+// testing handlers without a database. Regenerate after changing a service
+// interface with make generate.
+package servicemock
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -pkg servicemock -out services.go .. AbandonedCartService AccountService AccountingService AdminNoteService AuditService AuthService BackInStockService BundleService CarrierService CartService CheckoutService CODService DeliveryService ERPSyncService FlagService FraudService GiftCardService NotificationService OrderImportService OrderMessageService OrderService PaymentMethodService PaymentService PhoneAuthService PricingService ProductImportService ProductService PromotionService RelatedProductService ReservationCleanupService ReturnService ScheduledPublishingService SegmentService ServiceabilityService SettingsService StoreService UnpaidOrderService WarehouseService
@@ -0,0 +1,14302 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package servicemock
+
+import (
+	"context"
+	"ecommerce-backend/internal/events"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/service"
+	"ecommerce-backend/pkg/money"
+	"ecommerce-backend/pkg/pagination"
+	"github.com/google/uuid"
+	"io"
+	"sync"
+	"time"
+)
+
+// Ensure, that AbandonedCartServiceMock does implement service.AbandonedCartService.
+// If this is not the case, regenerate this file with moq.
+var _ service.AbandonedCartService = &AbandonedCartServiceMock{}
+
+// AbandonedCartServiceMock is a mock implementation of service.AbandonedCartService.
+//
+//	func TestSomethingThatUsesAbandonedCartService(t *testing.T) {
+//
+//		// make and configure a mocked service.AbandonedCartService
+//		mockedAbandonedCartService := &AbandonedCartServiceMock{
+//			DetectAbandonedFunc: func(ctx context.Context) (*models.AbandonedCartDetectionResult, error) {
+//				panic("mock out the DetectAbandoned method")
+//			},
+//			GetStatsFunc: func(ctx context.Context, rangeDays int) (*models.AbandonedCartStats, error) {
+//				panic("mock out the GetStats method")
+//			},
+//			RegisterFunc: func(bus *events.Bus)  {
+//				panic("mock out the Register method")
+//			},
+//			StartFunc: func(ctx context.Context, interval time.Duration)  {
+//				panic("mock out the Start method")
+//			},
+//		}
+//
+//		// use mockedAbandonedCartService in code that requires service.AbandonedCartService
+//		// and then make assertions.
+//
+//	}
+type AbandonedCartServiceMock struct {
+	// DetectAbandonedFunc mocks the DetectAbandoned method.
+	DetectAbandonedFunc func(ctx context.Context) (*models.AbandonedCartDetectionResult, error)
+
+	// GetStatsFunc mocks the GetStats method.
+	GetStatsFunc func(ctx context.Context, rangeDays int) (*models.AbandonedCartStats, error)
+
+	// RegisterFunc mocks the Register method.
+	RegisterFunc func(bus *events.Bus)
+
+	// StartFunc mocks the Start method.
+	StartFunc func(ctx context.Context, interval time.Duration)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DetectAbandoned holds details about calls to the DetectAbandoned method.
+		DetectAbandoned []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetStats holds details about calls to the GetStats method.
+		GetStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+		}
+		// Register holds details about calls to the Register method.
+		Register []struct {
+			// Bus is the bus argument value.
+			Bus *events.Bus
+		}
+		// Start holds details about calls to the Start method.
+		Start []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Interval is the interval argument value.
+			Interval time.Duration
+		}
+	}
+	lockDetectAbandoned sync.RWMutex
+	lockGetStats        sync.RWMutex
+	lockRegister        sync.RWMutex
+	lockStart           sync.RWMutex
+}
+
+// DetectAbandoned calls DetectAbandonedFunc.
+func (mock *AbandonedCartServiceMock) DetectAbandoned(ctx context.Context) (*models.AbandonedCartDetectionResult, error) {
+	if mock.DetectAbandonedFunc == nil {
+		panic("AbandonedCartServiceMock.DetectAbandonedFunc: method is nil but AbandonedCartService.DetectAbandoned was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDetectAbandoned.Lock()
+	mock.calls.DetectAbandoned = append(mock.calls.DetectAbandoned, callInfo)
+	mock.lockDetectAbandoned.Unlock()
+	return mock.DetectAbandonedFunc(ctx)
+}
+
+// DetectAbandonedCalls gets all the calls that were made to DetectAbandoned.
+// Check the length with:
+//
+//	len(mockedAbandonedCartService.DetectAbandonedCalls())
+func (mock *AbandonedCartServiceMock) DetectAbandonedCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockDetectAbandoned.RLock()
+	calls = mock.calls.DetectAbandoned
+	mock.lockDetectAbandoned.RUnlock()
+	return calls
+}
+
+// GetStats calls GetStatsFunc.
+func (mock *AbandonedCartServiceMock) GetStats(ctx context.Context, rangeDays int) (*models.AbandonedCartStats, error) {
+	if mock.GetStatsFunc == nil {
+		panic("AbandonedCartServiceMock.GetStatsFunc: method is nil but AbandonedCartService.GetStats was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RangeDays int
+	}{
+		Ctx:       ctx,
+		RangeDays: rangeDays,
+	}
+	mock.lockGetStats.Lock()
+	mock.calls.GetStats = append(mock.calls.GetStats, callInfo)
+	mock.lockGetStats.Unlock()
+	return mock.GetStatsFunc(ctx, rangeDays)
+}
+
+// GetStatsCalls gets all the calls that were made to GetStats.
+// Check the length with:
+//
+//	len(mockedAbandonedCartService.GetStatsCalls())
+func (mock *AbandonedCartServiceMock) GetStatsCalls() []struct {
+	Ctx       context.Context
+	RangeDays int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RangeDays int
+	}
+	mock.lockGetStats.RLock()
+	calls = mock.calls.GetStats
+	mock.lockGetStats.RUnlock()
+	return calls
+}
+
+// Register calls RegisterFunc.
+func (mock *AbandonedCartServiceMock) Register(bus *events.Bus) {
+	if mock.RegisterFunc == nil {
+		panic("AbandonedCartServiceMock.RegisterFunc: method is nil but AbandonedCartService.Register was just called")
+	}
+	callInfo := struct {
+		Bus *events.Bus
+	}{
+		Bus: bus,
+	}
+	mock.lockRegister.Lock()
+	mock.calls.Register = append(mock.calls.Register, callInfo)
+	mock.lockRegister.Unlock()
+	mock.RegisterFunc(bus)
+}
+
+// RegisterCalls gets all the calls that were made to Register.
+// Check the length with:
+//
+//	len(mockedAbandonedCartService.RegisterCalls())
+func (mock *AbandonedCartServiceMock) RegisterCalls() []struct {
+	Bus *events.Bus
+} {
+	var calls []struct {
+		Bus *events.Bus
+	}
+	mock.lockRegister.RLock()
+	calls = mock.calls.Register
+	mock.lockRegister.RUnlock()
+	return calls
+}
+
+// Start calls StartFunc.
+func (mock *AbandonedCartServiceMock) Start(ctx context.Context, interval time.Duration) {
+	if mock.StartFunc == nil {
+		panic("AbandonedCartServiceMock.StartFunc: method is nil but AbandonedCartService.Start was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}{
+		Ctx:      ctx,
+		Interval: interval,
+	}
+	mock.lockStart.Lock()
+	mock.calls.Start = append(mock.calls.Start, callInfo)
+	mock.lockStart.Unlock()
+	mock.StartFunc(ctx, interval)
+}
+
+// StartCalls gets all the calls that were made to Start.
+// Check the length with:
+//
+//	len(mockedAbandonedCartService.StartCalls())
+func (mock *AbandonedCartServiceMock) StartCalls() []struct {
+	Ctx      context.Context
+	Interval time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}
+	mock.lockStart.RLock()
+	calls = mock.calls.Start
+	mock.lockStart.RUnlock()
+	return calls
+}
+
+// Ensure, that AccountServiceMock does implement service.AccountService.
+// If this is not the case, regenerate this file with moq.
+var _ service.AccountService = &AccountServiceMock{}
+
+// AccountServiceMock is a mock implementation of service.AccountService.
+//
+//	func TestSomethingThatUsesAccountService(t *testing.T) {
+//
+//		// make and configure a mocked service.AccountService
+//		mockedAccountService := &AccountServiceMock{
+//			ExportDataFunc: func(ctx context.Context, userID uuid.UUID, reauth models.ReauthRequest) (*models.AccountDataExport, error) {
+//				panic("mock out the ExportData method")
+//			},
+//		}
+//
+//		// use mockedAccountService in code that requires service.AccountService
+//		// and then make assertions.
+//
+//	}
+type AccountServiceMock struct {
+	// ExportDataFunc mocks the ExportData method.
+	ExportDataFunc func(ctx context.Context, userID uuid.UUID, reauth models.ReauthRequest) (*models.AccountDataExport, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ExportData holds details about calls to the ExportData method.
+		ExportData []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Reauth is the reauth argument value.
+			Reauth models.ReauthRequest
+		}
+	}
+	lockExportData sync.RWMutex
+}
+
+// ExportData calls ExportDataFunc.
+func (mock *AccountServiceMock) ExportData(ctx context.Context, userID uuid.UUID, reauth models.ReauthRequest) (*models.AccountDataExport, error) {
+	if mock.ExportDataFunc == nil {
+		panic("AccountServiceMock.ExportDataFunc: method is nil but AccountService.ExportData was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Reauth models.ReauthRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Reauth: reauth,
+	}
+	mock.lockExportData.Lock()
+	mock.calls.ExportData = append(mock.calls.ExportData, callInfo)
+	mock.lockExportData.Unlock()
+	return mock.ExportDataFunc(ctx, userID, reauth)
+}
+
+// ExportDataCalls gets all the calls that were made to ExportData.
+// Check the length with:
+//
+//	len(mockedAccountService.ExportDataCalls())
+func (mock *AccountServiceMock) ExportDataCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Reauth models.ReauthRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Reauth models.ReauthRequest
+	}
+	mock.lockExportData.RLock()
+	calls = mock.calls.ExportData
+	mock.lockExportData.RUnlock()
+	return calls
+}
+
+// Ensure, that AccountingServiceMock does implement service.AccountingService.
+// If this is not the case, regenerate this file with moq.
+var _ service.AccountingService = &AccountingServiceMock{}
+
+// AccountingServiceMock is a mock implementation of service.AccountingService.
+//
+//	func TestSomethingThatUsesAccountingService(t *testing.T) {
+//
+//		// make and configure a mocked service.AccountingService
+//		mockedAccountingService := &AccountingServiceMock{
+//			GetJournalFunc: func(ctx context.Context, filter models.AccountingFilter) ([]models.JournalLine, error) {
+//				panic("mock out the GetJournal method")
+//			},
+//		}
+//
+//		// use mockedAccountingService in code that requires service.AccountingService
+//		// and then make assertions.
+//
+//	}
+type AccountingServiceMock struct {
+	// GetJournalFunc mocks the GetJournal method.
+	GetJournalFunc func(ctx context.Context, filter models.AccountingFilter) ([]models.JournalLine, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetJournal holds details about calls to the GetJournal method.
+		GetJournal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.AccountingFilter
+		}
+	}
+	lockGetJournal sync.RWMutex
+}
+
+// GetJournal calls GetJournalFunc.
+func (mock *AccountingServiceMock) GetJournal(ctx context.Context, filter models.AccountingFilter) ([]models.JournalLine, error) {
+	if mock.GetJournalFunc == nil {
+		panic("AccountingServiceMock.GetJournalFunc: method is nil but AccountingService.GetJournal was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.AccountingFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockGetJournal.Lock()
+	mock.calls.GetJournal = append(mock.calls.GetJournal, callInfo)
+	mock.lockGetJournal.Unlock()
+	return mock.GetJournalFunc(ctx, filter)
+}
+
+// GetJournalCalls gets all the calls that were made to GetJournal.
+// Check the length with:
+//
+//	len(mockedAccountingService.GetJournalCalls())
+func (mock *AccountingServiceMock) GetJournalCalls() []struct {
+	Ctx    context.Context
+	Filter models.AccountingFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.AccountingFilter
+	}
+	mock.lockGetJournal.RLock()
+	calls = mock.calls.GetJournal
+	mock.lockGetJournal.RUnlock()
+	return calls
+}
+
+// Ensure, that AdminNoteServiceMock does implement service.AdminNoteService.
+// If this is not the case, regenerate this file with moq.
+var _ service.AdminNoteService = &AdminNoteServiceMock{}
+
+// AdminNoteServiceMock is a mock implementation of service.AdminNoteService.
+//
+//	func TestSomethingThatUsesAdminNoteService(t *testing.T) {
+//
+//		// make and configure a mocked service.AdminNoteService
+//		mockedAdminNoteService := &AdminNoteServiceMock{
+//			AddOrderNoteFunc: func(ctx context.Context, orderID uuid.UUID, authorID uuid.UUID, body string) (*models.AdminNote, error) {
+//				panic("mock out the AddOrderNote method")
+//			},
+//			AddOrderTagsFunc: func(ctx context.Context, orderID uuid.UUID, tags []string) ([]string, error) {
+//				panic("mock out the AddOrderTags method")
+//			},
+//			AddUserNoteFunc: func(ctx context.Context, userID uuid.UUID, authorID uuid.UUID, body string) (*models.AdminNote, error) {
+//				panic("mock out the AddUserNote method")
+//			},
+//			AddUserTagsFunc: func(ctx context.Context, userID uuid.UUID, tags []string) ([]string, error) {
+//				panic("mock out the AddUserTags method")
+//			},
+//			DeleteOrderNoteFunc: func(ctx context.Context, orderID uuid.UUID, noteID uuid.UUID) error {
+//				panic("mock out the DeleteOrderNote method")
+//			},
+//			DeleteUserNoteFunc: func(ctx context.Context, userID uuid.UUID, noteID uuid.UUID) error {
+//				panic("mock out the DeleteUserNote method")
+//			},
+//			GetOrderNotesFunc: func(ctx context.Context, orderID uuid.UUID) ([]models.AdminNote, error) {
+//				panic("mock out the GetOrderNotes method")
+//			},
+//			GetUserNotesFunc: func(ctx context.Context, userID uuid.UUID) ([]models.AdminNote, error) {
+//				panic("mock out the GetUserNotes method")
+//			},
+//			RemoveOrderTagFunc: func(ctx context.Context, orderID uuid.UUID, tag string) ([]string, error) {
+//				panic("mock out the RemoveOrderTag method")
+//			},
+//			RemoveUserTagFunc: func(ctx context.Context, userID uuid.UUID, tag string) ([]string, error) {
+//				panic("mock out the RemoveUserTag method")
+//			},
+//		}
+//
+//		// use mockedAdminNoteService in code that requires service.AdminNoteService
+//		// and then make assertions.
+//
+//	}
+type AdminNoteServiceMock struct {
+	// AddOrderNoteFunc mocks the AddOrderNote method.
+	AddOrderNoteFunc func(ctx context.Context, orderID uuid.UUID, authorID uuid.UUID, body string) (*models.AdminNote, error)
+
+	// AddOrderTagsFunc mocks the AddOrderTags method.
+	AddOrderTagsFunc func(ctx context.Context, orderID uuid.UUID, tags []string) ([]string, error)
+
+	// AddUserNoteFunc mocks the AddUserNote method.
+	AddUserNoteFunc func(ctx context.Context, userID uuid.UUID, authorID uuid.UUID, body string) (*models.AdminNote, error)
+
+	// AddUserTagsFunc mocks the AddUserTags method.
+	AddUserTagsFunc func(ctx context.Context, userID uuid.UUID, tags []string) ([]string, error)
+
+	// DeleteOrderNoteFunc mocks the DeleteOrderNote method.
+	DeleteOrderNoteFunc func(ctx context.Context, orderID uuid.UUID, noteID uuid.UUID) error
+
+	// DeleteUserNoteFunc mocks the DeleteUserNote method.
+	DeleteUserNoteFunc func(ctx context.Context, userID uuid.UUID, noteID uuid.UUID) error
+
+	// GetOrderNotesFunc mocks the GetOrderNotes method.
+	GetOrderNotesFunc func(ctx context.Context, orderID uuid.UUID) ([]models.AdminNote, error)
+
+	// GetUserNotesFunc mocks the GetUserNotes method.
+	GetUserNotesFunc func(ctx context.Context, userID uuid.UUID) ([]models.AdminNote, error)
+
+	// RemoveOrderTagFunc mocks the RemoveOrderTag method.
+	RemoveOrderTagFunc func(ctx context.Context, orderID uuid.UUID, tag string) ([]string, error)
+
+	// RemoveUserTagFunc mocks the RemoveUserTag method.
+	RemoveUserTagFunc func(ctx context.Context, userID uuid.UUID, tag string) ([]string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddOrderNote holds details about calls to the AddOrderNote method.
+		AddOrderNote []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// AuthorID is the authorID argument value.
+			AuthorID uuid.UUID
+			// Body is the body argument value.
+			Body string
+		}
+		// AddOrderTags holds details about calls to the AddOrderTags method.
+		AddOrderTags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Tags is the tags argument value.
+			Tags []string
+		}
+		// AddUserNote holds details about calls to the AddUserNote method.
+		AddUserNote []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// AuthorID is the authorID argument value.
+			AuthorID uuid.UUID
+			// Body is the body argument value.
+			Body string
+		}
+		// AddUserTags holds details about calls to the AddUserTags method.
+		AddUserTags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Tags is the tags argument value.
+			Tags []string
+		}
+		// DeleteOrderNote holds details about calls to the DeleteOrderNote method.
+		DeleteOrderNote []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// NoteID is the noteID argument value.
+			NoteID uuid.UUID
+		}
+		// DeleteUserNote holds details about calls to the DeleteUserNote method.
+		DeleteUserNote []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// NoteID is the noteID argument value.
+			NoteID uuid.UUID
+		}
+		// GetOrderNotes holds details about calls to the GetOrderNotes method.
+		GetOrderNotes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// GetUserNotes holds details about calls to the GetUserNotes method.
+		GetUserNotes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// RemoveOrderTag holds details about calls to the RemoveOrderTag method.
+		RemoveOrderTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Tag is the tag argument value.
+			Tag string
+		}
+		// RemoveUserTag holds details about calls to the RemoveUserTag method.
+		RemoveUserTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Tag is the tag argument value.
+			Tag string
+		}
+	}
+	lockAddOrderNote    sync.RWMutex
+	lockAddOrderTags    sync.RWMutex
+	lockAddUserNote     sync.RWMutex
+	lockAddUserTags     sync.RWMutex
+	lockDeleteOrderNote sync.RWMutex
+	lockDeleteUserNote  sync.RWMutex
+	lockGetOrderNotes   sync.RWMutex
+	lockGetUserNotes    sync.RWMutex
+	lockRemoveOrderTag  sync.RWMutex
+	lockRemoveUserTag   sync.RWMutex
+}
+
+// AddOrderNote calls AddOrderNoteFunc.
+func (mock *AdminNoteServiceMock) AddOrderNote(ctx context.Context, orderID uuid.UUID, authorID uuid.UUID, body string) (*models.AdminNote, error) {
+	if mock.AddOrderNoteFunc == nil {
+		panic("AdminNoteServiceMock.AddOrderNoteFunc: method is nil but AdminNoteService.AddOrderNote was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		AuthorID uuid.UUID
+		Body     string
+	}{
+		Ctx:      ctx,
+		OrderID:  orderID,
+		AuthorID: authorID,
+		Body:     body,
+	}
+	mock.lockAddOrderNote.Lock()
+	mock.calls.AddOrderNote = append(mock.calls.AddOrderNote, callInfo)
+	mock.lockAddOrderNote.Unlock()
+	return mock.AddOrderNoteFunc(ctx, orderID, authorID, body)
+}
+
+// AddOrderNoteCalls gets all the calls that were made to AddOrderNote.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.AddOrderNoteCalls())
+func (mock *AdminNoteServiceMock) AddOrderNoteCalls() []struct {
+	Ctx      context.Context
+	OrderID  uuid.UUID
+	AuthorID uuid.UUID
+	Body     string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		AuthorID uuid.UUID
+		Body     string
+	}
+	mock.lockAddOrderNote.RLock()
+	calls = mock.calls.AddOrderNote
+	mock.lockAddOrderNote.RUnlock()
+	return calls
+}
+
+// AddOrderTags calls AddOrderTagsFunc.
+func (mock *AdminNoteServiceMock) AddOrderTags(ctx context.Context, orderID uuid.UUID, tags []string) ([]string, error) {
+	if mock.AddOrderTagsFunc == nil {
+		panic("AdminNoteServiceMock.AddOrderTagsFunc: method is nil but AdminNoteService.AddOrderTags was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Tags    []string
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		Tags:    tags,
+	}
+	mock.lockAddOrderTags.Lock()
+	mock.calls.AddOrderTags = append(mock.calls.AddOrderTags, callInfo)
+	mock.lockAddOrderTags.Unlock()
+	return mock.AddOrderTagsFunc(ctx, orderID, tags)
+}
+
+// AddOrderTagsCalls gets all the calls that were made to AddOrderTags.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.AddOrderTagsCalls())
+func (mock *AdminNoteServiceMock) AddOrderTagsCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	Tags    []string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Tags    []string
+	}
+	mock.lockAddOrderTags.RLock()
+	calls = mock.calls.AddOrderTags
+	mock.lockAddOrderTags.RUnlock()
+	return calls
+}
+
+// AddUserNote calls AddUserNoteFunc.
+func (mock *AdminNoteServiceMock) AddUserNote(ctx context.Context, userID uuid.UUID, authorID uuid.UUID, body string) (*models.AdminNote, error) {
+	if mock.AddUserNoteFunc == nil {
+		panic("AdminNoteServiceMock.AddUserNoteFunc: method is nil but AdminNoteService.AddUserNote was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		AuthorID uuid.UUID
+		Body     string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		AuthorID: authorID,
+		Body:     body,
+	}
+	mock.lockAddUserNote.Lock()
+	mock.calls.AddUserNote = append(mock.calls.AddUserNote, callInfo)
+	mock.lockAddUserNote.Unlock()
+	return mock.AddUserNoteFunc(ctx, userID, authorID, body)
+}
+
+// AddUserNoteCalls gets all the calls that were made to AddUserNote.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.AddUserNoteCalls())
+func (mock *AdminNoteServiceMock) AddUserNoteCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	AuthorID uuid.UUID
+	Body     string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		AuthorID uuid.UUID
+		Body     string
+	}
+	mock.lockAddUserNote.RLock()
+	calls = mock.calls.AddUserNote
+	mock.lockAddUserNote.RUnlock()
+	return calls
+}
+
+// AddUserTags calls AddUserTagsFunc.
+func (mock *AdminNoteServiceMock) AddUserTags(ctx context.Context, userID uuid.UUID, tags []string) ([]string, error) {
+	if mock.AddUserTagsFunc == nil {
+		panic("AdminNoteServiceMock.AddUserTagsFunc: method is nil but AdminNoteService.AddUserTags was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Tags   []string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Tags:   tags,
+	}
+	mock.lockAddUserTags.Lock()
+	mock.calls.AddUserTags = append(mock.calls.AddUserTags, callInfo)
+	mock.lockAddUserTags.Unlock()
+	return mock.AddUserTagsFunc(ctx, userID, tags)
+}
+
+// AddUserTagsCalls gets all the calls that were made to AddUserTags.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.AddUserTagsCalls())
+func (mock *AdminNoteServiceMock) AddUserTagsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Tags   []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Tags   []string
+	}
+	mock.lockAddUserTags.RLock()
+	calls = mock.calls.AddUserTags
+	mock.lockAddUserTags.RUnlock()
+	return calls
+}
+
+// DeleteOrderNote calls DeleteOrderNoteFunc.
+func (mock *AdminNoteServiceMock) DeleteOrderNote(ctx context.Context, orderID uuid.UUID, noteID uuid.UUID) error {
+	if mock.DeleteOrderNoteFunc == nil {
+		panic("AdminNoteServiceMock.DeleteOrderNoteFunc: method is nil but AdminNoteService.DeleteOrderNote was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		NoteID  uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		NoteID:  noteID,
+	}
+	mock.lockDeleteOrderNote.Lock()
+	mock.calls.DeleteOrderNote = append(mock.calls.DeleteOrderNote, callInfo)
+	mock.lockDeleteOrderNote.Unlock()
+	return mock.DeleteOrderNoteFunc(ctx, orderID, noteID)
+}
+
+// DeleteOrderNoteCalls gets all the calls that were made to DeleteOrderNote.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.DeleteOrderNoteCalls())
+func (mock *AdminNoteServiceMock) DeleteOrderNoteCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	NoteID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		NoteID  uuid.UUID
+	}
+	mock.lockDeleteOrderNote.RLock()
+	calls = mock.calls.DeleteOrderNote
+	mock.lockDeleteOrderNote.RUnlock()
+	return calls
+}
+
+// DeleteUserNote calls DeleteUserNoteFunc.
+func (mock *AdminNoteServiceMock) DeleteUserNote(ctx context.Context, userID uuid.UUID, noteID uuid.UUID) error {
+	if mock.DeleteUserNoteFunc == nil {
+		panic("AdminNoteServiceMock.DeleteUserNoteFunc: method is nil but AdminNoteService.DeleteUserNote was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		NoteID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		NoteID: noteID,
+	}
+	mock.lockDeleteUserNote.Lock()
+	mock.calls.DeleteUserNote = append(mock.calls.DeleteUserNote, callInfo)
+	mock.lockDeleteUserNote.Unlock()
+	return mock.DeleteUserNoteFunc(ctx, userID, noteID)
+}
+
+// DeleteUserNoteCalls gets all the calls that were made to DeleteUserNote.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.DeleteUserNoteCalls())
+func (mock *AdminNoteServiceMock) DeleteUserNoteCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	NoteID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		NoteID uuid.UUID
+	}
+	mock.lockDeleteUserNote.RLock()
+	calls = mock.calls.DeleteUserNote
+	mock.lockDeleteUserNote.RUnlock()
+	return calls
+}
+
+// GetOrderNotes calls GetOrderNotesFunc.
+func (mock *AdminNoteServiceMock) GetOrderNotes(ctx context.Context, orderID uuid.UUID) ([]models.AdminNote, error) {
+	if mock.GetOrderNotesFunc == nil {
+		panic("AdminNoteServiceMock.GetOrderNotesFunc: method is nil but AdminNoteService.GetOrderNotes was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockGetOrderNotes.Lock()
+	mock.calls.GetOrderNotes = append(mock.calls.GetOrderNotes, callInfo)
+	mock.lockGetOrderNotes.Unlock()
+	return mock.GetOrderNotesFunc(ctx, orderID)
+}
+
+// GetOrderNotesCalls gets all the calls that were made to GetOrderNotes.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.GetOrderNotesCalls())
+func (mock *AdminNoteServiceMock) GetOrderNotesCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockGetOrderNotes.RLock()
+	calls = mock.calls.GetOrderNotes
+	mock.lockGetOrderNotes.RUnlock()
+	return calls
+}
+
+// GetUserNotes calls GetUserNotesFunc.
+func (mock *AdminNoteServiceMock) GetUserNotes(ctx context.Context, userID uuid.UUID) ([]models.AdminNote, error) {
+	if mock.GetUserNotesFunc == nil {
+		panic("AdminNoteServiceMock.GetUserNotesFunc: method is nil but AdminNoteService.GetUserNotes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetUserNotes.Lock()
+	mock.calls.GetUserNotes = append(mock.calls.GetUserNotes, callInfo)
+	mock.lockGetUserNotes.Unlock()
+	return mock.GetUserNotesFunc(ctx, userID)
+}
+
+// GetUserNotesCalls gets all the calls that were made to GetUserNotes.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.GetUserNotesCalls())
+func (mock *AdminNoteServiceMock) GetUserNotesCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetUserNotes.RLock()
+	calls = mock.calls.GetUserNotes
+	mock.lockGetUserNotes.RUnlock()
+	return calls
+}
+
+// RemoveOrderTag calls RemoveOrderTagFunc.
+func (mock *AdminNoteServiceMock) RemoveOrderTag(ctx context.Context, orderID uuid.UUID, tag string) ([]string, error) {
+	if mock.RemoveOrderTagFunc == nil {
+		panic("AdminNoteServiceMock.RemoveOrderTagFunc: method is nil but AdminNoteService.RemoveOrderTag was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Tag     string
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		Tag:     tag,
+	}
+	mock.lockRemoveOrderTag.Lock()
+	mock.calls.RemoveOrderTag = append(mock.calls.RemoveOrderTag, callInfo)
+	mock.lockRemoveOrderTag.Unlock()
+	return mock.RemoveOrderTagFunc(ctx, orderID, tag)
+}
+
+// RemoveOrderTagCalls gets all the calls that were made to RemoveOrderTag.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.RemoveOrderTagCalls())
+func (mock *AdminNoteServiceMock) RemoveOrderTagCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	Tag     string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Tag     string
+	}
+	mock.lockRemoveOrderTag.RLock()
+	calls = mock.calls.RemoveOrderTag
+	mock.lockRemoveOrderTag.RUnlock()
+	return calls
+}
+
+// RemoveUserTag calls RemoveUserTagFunc.
+func (mock *AdminNoteServiceMock) RemoveUserTag(ctx context.Context, userID uuid.UUID, tag string) ([]string, error) {
+	if mock.RemoveUserTagFunc == nil {
+		panic("AdminNoteServiceMock.RemoveUserTagFunc: method is nil but AdminNoteService.RemoveUserTag was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Tag    string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Tag:    tag,
+	}
+	mock.lockRemoveUserTag.Lock()
+	mock.calls.RemoveUserTag = append(mock.calls.RemoveUserTag, callInfo)
+	mock.lockRemoveUserTag.Unlock()
+	return mock.RemoveUserTagFunc(ctx, userID, tag)
+}
+
+// RemoveUserTagCalls gets all the calls that were made to RemoveUserTag.
+// Check the length with:
+//
+//	len(mockedAdminNoteService.RemoveUserTagCalls())
+func (mock *AdminNoteServiceMock) RemoveUserTagCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Tag    string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Tag    string
+	}
+	mock.lockRemoveUserTag.RLock()
+	calls = mock.calls.RemoveUserTag
+	mock.lockRemoveUserTag.RUnlock()
+	return calls
+}
+
+// Ensure, that AuditServiceMock does implement service.AuditService.
+// If this is not the case, regenerate this file with moq.
+var _ service.AuditService = &AuditServiceMock{}
+
+// AuditServiceMock is a mock implementation of service.AuditService.
+//
+//	func TestSomethingThatUsesAuditService(t *testing.T) {
+//
+//		// make and configure a mocked service.AuditService
+//		mockedAuditService := &AuditServiceMock{
+//			RecordFunc: func(ctx context.Context, entry *models.AuditEntry) error {
+//				panic("mock out the Record method")
+//			},
+//		}
+//
+//		// use mockedAuditService in code that requires service.AuditService
+//		// and then make assertions.
+//
+//	}
+type AuditServiceMock struct {
+	// RecordFunc mocks the Record method.
+	RecordFunc func(ctx context.Context, entry *models.AuditEntry) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Record holds details about calls to the Record method.
+		Record []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entry is the entry argument value.
+			Entry *models.AuditEntry
+		}
+	}
+	lockRecord sync.RWMutex
+}
+
+// Record calls RecordFunc.
+func (mock *AuditServiceMock) Record(ctx context.Context, entry *models.AuditEntry) error {
+	if mock.RecordFunc == nil {
+		panic("AuditServiceMock.RecordFunc: method is nil but AuditService.Record was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Entry *models.AuditEntry
+	}{
+		Ctx:   ctx,
+		Entry: entry,
+	}
+	mock.lockRecord.Lock()
+	mock.calls.Record = append(mock.calls.Record, callInfo)
+	mock.lockRecord.Unlock()
+	return mock.RecordFunc(ctx, entry)
+}
+
+// RecordCalls gets all the calls that were made to Record.
+// Check the length with:
+//
+//	len(mockedAuditService.RecordCalls())
+func (mock *AuditServiceMock) RecordCalls() []struct {
+	Ctx   context.Context
+	Entry *models.AuditEntry
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Entry *models.AuditEntry
+	}
+	mock.lockRecord.RLock()
+	calls = mock.calls.Record
+	mock.lockRecord.RUnlock()
+	return calls
+}
+
+// Ensure, that AuthServiceMock does implement service.AuthService.
+// If this is not the case, regenerate this file with moq.
+var _ service.AuthService = &AuthServiceMock{}
+
+// AuthServiceMock is a mock implementation of service.AuthService.
+//
+//	func TestSomethingThatUsesAuthService(t *testing.T) {
+//
+//		// make and configure a mocked service.AuthService
+//		mockedAuthService := &AuthServiceMock{
+//			BulkUpdateUserRolesFunc: func(ctx context.Context, actorID uuid.UUID, req models.BulkUpdateUserRolesRequest) (int64, error) {
+//				panic("mock out the BulkUpdateUserRoles method")
+//			},
+//			CloseAccountFunc: func(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error {
+//				panic("mock out the CloseAccount method")
+//			},
+//			DeleteUserFunc: func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			ExportUsersFunc: func(ctx context.Context, filter models.UserFilter, fn func(models.User) error) error {
+//				panic("mock out the ExportUsers method")
+//			},
+//			GenerateTokenFunc: func(user *models.User) (string, error) {
+//				panic("mock out the GenerateToken method")
+//			},
+//			GetProfileFunc: func(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+//				panic("mock out the GetProfile method")
+//			},
+//			ImpersonateFunc: func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*models.ImpersonationResponse, error) {
+//				panic("mock out the Impersonate method")
+//			},
+//			ListUsersFunc: func(ctx context.Context, filter models.UserFilter, page int, limit int) ([]models.User, int, error) {
+//				panic("mock out the ListUsers method")
+//			},
+//			LoginFunc: func(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, error) {
+//				panic("mock out the Login method")
+//			},
+//			LogoutFunc: func(ctx context.Context, tokenString string) error {
+//				panic("mock out the Logout method")
+//			},
+//			OAuthLoginFunc: func(ctx context.Context, provider string, req models.OAuthLoginRequest) (*models.LoginResponse, error) {
+//				panic("mock out the OAuthLogin method")
+//			},
+//			ReauthenticateFunc: func(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error {
+//				panic("mock out the Reauthenticate method")
+//			},
+//			RegisterFunc: func(ctx context.Context, req models.RegisterRequest) (*models.User, error) {
+//				panic("mock out the Register method")
+//			},
+//			ResendVerificationFunc: func(ctx context.Context, email string) error {
+//				panic("mock out the ResendVerification method")
+//			},
+//			RevokeSessionsFunc: func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error {
+//				panic("mock out the RevokeSessions method")
+//			},
+//			SetUserActiveFunc: func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, active bool) (*models.User, error) {
+//				panic("mock out the SetUserActive method")
+//			},
+//			UpdateUserRoleFunc: func(ctx context.Context, userID uuid.UUID, role string) (*models.User, string, error) {
+//				panic("mock out the UpdateUserRole method")
+//			},
+//			ValidateTokenFunc: func(ctx context.Context, tokenString string) (*models.User, error) {
+//				panic("mock out the ValidateToken method")
+//			},
+//			VerifyEmailFunc: func(ctx context.Context, token string) (*models.User, error) {
+//				panic("mock out the VerifyEmail method")
+//			},
+//		}
+//
+//		// use mockedAuthService in code that requires service.AuthService
+//		// and then make assertions.
+//
+//	}
+type AuthServiceMock struct {
+	// BulkUpdateUserRolesFunc mocks the BulkUpdateUserRoles method.
+	BulkUpdateUserRolesFunc func(ctx context.Context, actorID uuid.UUID, req models.BulkUpdateUserRolesRequest) (int64, error)
+
+	// CloseAccountFunc mocks the CloseAccount method.
+	CloseAccountFunc func(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error
+
+	// ExportUsersFunc mocks the ExportUsers method.
+	ExportUsersFunc func(ctx context.Context, filter models.UserFilter, fn func(models.User) error) error
+
+	// GenerateTokenFunc mocks the GenerateToken method.
+	GenerateTokenFunc func(user *models.User) (string, error)
+
+	// GetProfileFunc mocks the GetProfile method.
+	GetProfileFunc func(ctx context.Context, userID uuid.UUID) (*models.User, error)
+
+	// ImpersonateFunc mocks the Impersonate method.
+	ImpersonateFunc func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*models.ImpersonationResponse, error)
+
+	// ListUsersFunc mocks the ListUsers method.
+	ListUsersFunc func(ctx context.Context, filter models.UserFilter, page int, limit int) ([]models.User, int, error)
+
+	// LoginFunc mocks the Login method.
+	LoginFunc func(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, error)
+
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context, tokenString string) error
+
+	// OAuthLoginFunc mocks the OAuthLogin method.
+	OAuthLoginFunc func(ctx context.Context, provider string, req models.OAuthLoginRequest) (*models.LoginResponse, error)
+
+	// ReauthenticateFunc mocks the Reauthenticate method.
+	ReauthenticateFunc func(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error
+
+	// RegisterFunc mocks the Register method.
+	RegisterFunc func(ctx context.Context, req models.RegisterRequest) (*models.User, error)
+
+	// ResendVerificationFunc mocks the ResendVerification method.
+	ResendVerificationFunc func(ctx context.Context, email string) error
+
+	// RevokeSessionsFunc mocks the RevokeSessions method.
+	RevokeSessionsFunc func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error
+
+	// SetUserActiveFunc mocks the SetUserActive method.
+	SetUserActiveFunc func(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, active bool) (*models.User, error)
+
+	// UpdateUserRoleFunc mocks the UpdateUserRole method.
+	UpdateUserRoleFunc func(ctx context.Context, userID uuid.UUID, role string) (*models.User, string, error)
+
+	// ValidateTokenFunc mocks the ValidateToken method.
+	ValidateTokenFunc func(ctx context.Context, tokenString string) (*models.User, error)
+
+	// VerifyEmailFunc mocks the VerifyEmail method.
+	VerifyEmailFunc func(ctx context.Context, token string) (*models.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// BulkUpdateUserRoles holds details about calls to the BulkUpdateUserRoles method.
+		BulkUpdateUserRoles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ActorID is the actorID argument value.
+			ActorID uuid.UUID
+			// Req is the req argument value.
+			Req models.BulkUpdateUserRolesRequest
+		}
+		// CloseAccount holds details about calls to the CloseAccount method.
+		CloseAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.ReauthRequest
+		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ActorID is the actorID argument value.
+			ActorID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// ExportUsers holds details about calls to the ExportUsers method.
+		ExportUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.UserFilter
+			// Fn is the fn argument value.
+			Fn func(models.User) error
+		}
+		// GenerateToken holds details about calls to the GenerateToken method.
+		GenerateToken []struct {
+			// User is the user argument value.
+			User *models.User
+		}
+		// GetProfile holds details about calls to the GetProfile method.
+		GetProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Impersonate holds details about calls to the Impersonate method.
+		Impersonate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ActorID is the actorID argument value.
+			ActorID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Reason is the reason argument value.
+			Reason string
+		}
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.UserFilter
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// Login holds details about calls to the Login method.
+		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.LoginRequest
+		}
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenString is the tokenString argument value.
+			TokenString string
+		}
+		// OAuthLogin holds details about calls to the OAuthLogin method.
+		OAuthLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Req is the req argument value.
+			Req models.OAuthLoginRequest
+		}
+		// Reauthenticate holds details about calls to the Reauthenticate method.
+		Reauthenticate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.ReauthRequest
+		}
+		// Register holds details about calls to the Register method.
+		Register []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.RegisterRequest
+		}
+		// ResendVerification holds details about calls to the ResendVerification method.
+		ResendVerification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
+		// RevokeSessions holds details about calls to the RevokeSessions method.
+		RevokeSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ActorID is the actorID argument value.
+			ActorID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// SetUserActive holds details about calls to the SetUserActive method.
+		SetUserActive []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ActorID is the actorID argument value.
+			ActorID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Active is the active argument value.
+			Active bool
+		}
+		// UpdateUserRole holds details about calls to the UpdateUserRole method.
+		UpdateUserRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Role is the role argument value.
+			Role string
+		}
+		// ValidateToken holds details about calls to the ValidateToken method.
+		ValidateToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenString is the tokenString argument value.
+			TokenString string
+		}
+		// VerifyEmail holds details about calls to the VerifyEmail method.
+		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+	}
+	lockBulkUpdateUserRoles sync.RWMutex
+	lockCloseAccount        sync.RWMutex
+	lockDeleteUser          sync.RWMutex
+	lockExportUsers         sync.RWMutex
+	lockGenerateToken       sync.RWMutex
+	lockGetProfile          sync.RWMutex
+	lockImpersonate         sync.RWMutex
+	lockListUsers           sync.RWMutex
+	lockLogin               sync.RWMutex
+	lockLogout              sync.RWMutex
+	lockOAuthLogin          sync.RWMutex
+	lockReauthenticate      sync.RWMutex
+	lockRegister            sync.RWMutex
+	lockResendVerification  sync.RWMutex
+	lockRevokeSessions      sync.RWMutex
+	lockSetUserActive       sync.RWMutex
+	lockUpdateUserRole      sync.RWMutex
+	lockValidateToken       sync.RWMutex
+	lockVerifyEmail         sync.RWMutex
+}
+
+// BulkUpdateUserRoles calls BulkUpdateUserRolesFunc.
+func (mock *AuthServiceMock) BulkUpdateUserRoles(ctx context.Context, actorID uuid.UUID, req models.BulkUpdateUserRolesRequest) (int64, error) {
+	if mock.BulkUpdateUserRolesFunc == nil {
+		panic("AuthServiceMock.BulkUpdateUserRolesFunc: method is nil but AuthService.BulkUpdateUserRoles was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		Req     models.BulkUpdateUserRolesRequest
+	}{
+		Ctx:     ctx,
+		ActorID: actorID,
+		Req:     req,
+	}
+	mock.lockBulkUpdateUserRoles.Lock()
+	mock.calls.BulkUpdateUserRoles = append(mock.calls.BulkUpdateUserRoles, callInfo)
+	mock.lockBulkUpdateUserRoles.Unlock()
+	return mock.BulkUpdateUserRolesFunc(ctx, actorID, req)
+}
+
+// BulkUpdateUserRolesCalls gets all the calls that were made to BulkUpdateUserRoles.
+// Check the length with:
+//
+//	len(mockedAuthService.BulkUpdateUserRolesCalls())
+func (mock *AuthServiceMock) BulkUpdateUserRolesCalls() []struct {
+	Ctx     context.Context
+	ActorID uuid.UUID
+	Req     models.BulkUpdateUserRolesRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		Req     models.BulkUpdateUserRolesRequest
+	}
+	mock.lockBulkUpdateUserRoles.RLock()
+	calls = mock.calls.BulkUpdateUserRoles
+	mock.lockBulkUpdateUserRoles.RUnlock()
+	return calls
+}
+
+// CloseAccount calls CloseAccountFunc.
+func (mock *AuthServiceMock) CloseAccount(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error {
+	if mock.CloseAccountFunc == nil {
+		panic("AuthServiceMock.CloseAccountFunc: method is nil but AuthService.CloseAccount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.ReauthRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Req:    req,
+	}
+	mock.lockCloseAccount.Lock()
+	mock.calls.CloseAccount = append(mock.calls.CloseAccount, callInfo)
+	mock.lockCloseAccount.Unlock()
+	return mock.CloseAccountFunc(ctx, userID, req)
+}
+
+// CloseAccountCalls gets all the calls that were made to CloseAccount.
+// Check the length with:
+//
+//	len(mockedAuthService.CloseAccountCalls())
+func (mock *AuthServiceMock) CloseAccountCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Req    models.ReauthRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.ReauthRequest
+	}
+	mock.lockCloseAccount.RLock()
+	calls = mock.calls.CloseAccount
+	mock.lockCloseAccount.RUnlock()
+	return calls
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *AuthServiceMock) DeleteUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error {
+	if mock.DeleteUserFunc == nil {
+		panic("AuthServiceMock.DeleteUserFunc: method is nil but AuthService.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+	}{
+		Ctx:     ctx,
+		ActorID: actorID,
+		UserID:  userID,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, actorID, userID)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedAuthService.DeleteUserCalls())
+func (mock *AuthServiceMock) DeleteUserCalls() []struct {
+	Ctx     context.Context
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// ExportUsers calls ExportUsersFunc.
+func (mock *AuthServiceMock) ExportUsers(ctx context.Context, filter models.UserFilter, fn func(models.User) error) error {
+	if mock.ExportUsersFunc == nil {
+		panic("AuthServiceMock.ExportUsersFunc: method is nil but AuthService.ExportUsers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.UserFilter
+		Fn     func(models.User) error
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Fn:     fn,
+	}
+	mock.lockExportUsers.Lock()
+	mock.calls.ExportUsers = append(mock.calls.ExportUsers, callInfo)
+	mock.lockExportUsers.Unlock()
+	return mock.ExportUsersFunc(ctx, filter, fn)
+}
+
+// ExportUsersCalls gets all the calls that were made to ExportUsers.
+// Check the length with:
+//
+//	len(mockedAuthService.ExportUsersCalls())
+func (mock *AuthServiceMock) ExportUsersCalls() []struct {
+	Ctx    context.Context
+	Filter models.UserFilter
+	Fn     func(models.User) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.UserFilter
+		Fn     func(models.User) error
+	}
+	mock.lockExportUsers.RLock()
+	calls = mock.calls.ExportUsers
+	mock.lockExportUsers.RUnlock()
+	return calls
+}
+
+// GenerateToken calls GenerateTokenFunc.
+func (mock *AuthServiceMock) GenerateToken(user *models.User) (string, error) {
+	if mock.GenerateTokenFunc == nil {
+		panic("AuthServiceMock.GenerateTokenFunc: method is nil but AuthService.GenerateToken was just called")
+	}
+	callInfo := struct {
+		User *models.User
+	}{
+		User: user,
+	}
+	mock.lockGenerateToken.Lock()
+	mock.calls.GenerateToken = append(mock.calls.GenerateToken, callInfo)
+	mock.lockGenerateToken.Unlock()
+	return mock.GenerateTokenFunc(user)
+}
+
+// GenerateTokenCalls gets all the calls that were made to GenerateToken.
+// Check the length with:
+//
+//	len(mockedAuthService.GenerateTokenCalls())
+func (mock *AuthServiceMock) GenerateTokenCalls() []struct {
+	User *models.User
+} {
+	var calls []struct {
+		User *models.User
+	}
+	mock.lockGenerateToken.RLock()
+	calls = mock.calls.GenerateToken
+	mock.lockGenerateToken.RUnlock()
+	return calls
+}
+
+// GetProfile calls GetProfileFunc.
+func (mock *AuthServiceMock) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	if mock.GetProfileFunc == nil {
+		panic("AuthServiceMock.GetProfileFunc: method is nil but AuthService.GetProfile was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetProfile.Lock()
+	mock.calls.GetProfile = append(mock.calls.GetProfile, callInfo)
+	mock.lockGetProfile.Unlock()
+	return mock.GetProfileFunc(ctx, userID)
+}
+
+// GetProfileCalls gets all the calls that were made to GetProfile.
+// Check the length with:
+//
+//	len(mockedAuthService.GetProfileCalls())
+func (mock *AuthServiceMock) GetProfileCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetProfile.RLock()
+	calls = mock.calls.GetProfile
+	mock.lockGetProfile.RUnlock()
+	return calls
+}
+
+// Impersonate calls ImpersonateFunc.
+func (mock *AuthServiceMock) Impersonate(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*models.ImpersonationResponse, error) {
+	if mock.ImpersonateFunc == nil {
+		panic("AuthServiceMock.ImpersonateFunc: method is nil but AuthService.Impersonate was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+		Reason  string
+	}{
+		Ctx:     ctx,
+		ActorID: actorID,
+		UserID:  userID,
+		Reason:  reason,
+	}
+	mock.lockImpersonate.Lock()
+	mock.calls.Impersonate = append(mock.calls.Impersonate, callInfo)
+	mock.lockImpersonate.Unlock()
+	return mock.ImpersonateFunc(ctx, actorID, userID, reason)
+}
+
+// ImpersonateCalls gets all the calls that were made to Impersonate.
+// Check the length with:
+//
+//	len(mockedAuthService.ImpersonateCalls())
+func (mock *AuthServiceMock) ImpersonateCalls() []struct {
+	Ctx     context.Context
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+	Reason  string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+		Reason  string
+	}
+	mock.lockImpersonate.RLock()
+	calls = mock.calls.Impersonate
+	mock.lockImpersonate.RUnlock()
+	return calls
+}
+
+// ListUsers calls ListUsersFunc.
+func (mock *AuthServiceMock) ListUsers(ctx context.Context, filter models.UserFilter, page int, limit int) ([]models.User, int, error) {
+	if mock.ListUsersFunc == nil {
+		panic("AuthServiceMock.ListUsersFunc: method is nil but AuthService.ListUsers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.UserFilter
+		Page   int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Page:   page,
+		Limit:  limit,
+	}
+	mock.lockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	mock.lockListUsers.Unlock()
+	return mock.ListUsersFunc(ctx, filter, page, limit)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//
+//	len(mockedAuthService.ListUsersCalls())
+func (mock *AuthServiceMock) ListUsersCalls() []struct {
+	Ctx    context.Context
+	Filter models.UserFilter
+	Page   int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.UserFilter
+		Page   int
+		Limit  int
+	}
+	mock.lockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	mock.lockListUsers.RUnlock()
+	return calls
+}
+
+// Login calls LoginFunc.
+func (mock *AuthServiceMock) Login(ctx context.Context, req models.LoginRequest) (*models.LoginResponse, error) {
+	if mock.LoginFunc == nil {
+		panic("AuthServiceMock.LoginFunc: method is nil but AuthService.Login was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.LoginRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockLogin.Lock()
+	mock.calls.Login = append(mock.calls.Login, callInfo)
+	mock.lockLogin.Unlock()
+	return mock.LoginFunc(ctx, req)
+}
+
+// LoginCalls gets all the calls that were made to Login.
+// Check the length with:
+//
+//	len(mockedAuthService.LoginCalls())
+func (mock *AuthServiceMock) LoginCalls() []struct {
+	Ctx context.Context
+	Req models.LoginRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.LoginRequest
+	}
+	mock.lockLogin.RLock()
+	calls = mock.calls.Login
+	mock.lockLogin.RUnlock()
+	return calls
+}
+
+// Logout calls LogoutFunc.
+func (mock *AuthServiceMock) Logout(ctx context.Context, tokenString string) error {
+	if mock.LogoutFunc == nil {
+		panic("AuthServiceMock.LogoutFunc: method is nil but AuthService.Logout was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		TokenString string
+	}{
+		Ctx:         ctx,
+		TokenString: tokenString,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	return mock.LogoutFunc(ctx, tokenString)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedAuthService.LogoutCalls())
+func (mock *AuthServiceMock) LogoutCalls() []struct {
+	Ctx         context.Context
+	TokenString string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		TokenString string
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// OAuthLogin calls OAuthLoginFunc.
+func (mock *AuthServiceMock) OAuthLogin(ctx context.Context, provider string, req models.OAuthLoginRequest) (*models.LoginResponse, error) {
+	if mock.OAuthLoginFunc == nil {
+		panic("AuthServiceMock.OAuthLoginFunc: method is nil but AuthService.OAuthLogin was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Req      models.OAuthLoginRequest
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Req:      req,
+	}
+	mock.lockOAuthLogin.Lock()
+	mock.calls.OAuthLogin = append(mock.calls.OAuthLogin, callInfo)
+	mock.lockOAuthLogin.Unlock()
+	return mock.OAuthLoginFunc(ctx, provider, req)
+}
+
+// OAuthLoginCalls gets all the calls that were made to OAuthLogin.
+// Check the length with:
+//
+//	len(mockedAuthService.OAuthLoginCalls())
+func (mock *AuthServiceMock) OAuthLoginCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Req      models.OAuthLoginRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Req      models.OAuthLoginRequest
+	}
+	mock.lockOAuthLogin.RLock()
+	calls = mock.calls.OAuthLogin
+	mock.lockOAuthLogin.RUnlock()
+	return calls
+}
+
+// Reauthenticate calls ReauthenticateFunc.
+func (mock *AuthServiceMock) Reauthenticate(ctx context.Context, userID uuid.UUID, req models.ReauthRequest) error {
+	if mock.ReauthenticateFunc == nil {
+		panic("AuthServiceMock.ReauthenticateFunc: method is nil but AuthService.Reauthenticate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.ReauthRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Req:    req,
+	}
+	mock.lockReauthenticate.Lock()
+	mock.calls.Reauthenticate = append(mock.calls.Reauthenticate, callInfo)
+	mock.lockReauthenticate.Unlock()
+	return mock.ReauthenticateFunc(ctx, userID, req)
+}
+
+// ReauthenticateCalls gets all the calls that were made to Reauthenticate.
+// Check the length with:
+//
+//	len(mockedAuthService.ReauthenticateCalls())
+func (mock *AuthServiceMock) ReauthenticateCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Req    models.ReauthRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.ReauthRequest
+	}
+	mock.lockReauthenticate.RLock()
+	calls = mock.calls.Reauthenticate
+	mock.lockReauthenticate.RUnlock()
+	return calls
+}
+
+// Register calls RegisterFunc.
+func (mock *AuthServiceMock) Register(ctx context.Context, req models.RegisterRequest) (*models.User, error) {
+	if mock.RegisterFunc == nil {
+		panic("AuthServiceMock.RegisterFunc: method is nil but AuthService.Register was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.RegisterRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockRegister.Lock()
+	mock.calls.Register = append(mock.calls.Register, callInfo)
+	mock.lockRegister.Unlock()
+	return mock.RegisterFunc(ctx, req)
+}
+
+// RegisterCalls gets all the calls that were made to Register.
+// Check the length with:
+//
+//	len(mockedAuthService.RegisterCalls())
+func (mock *AuthServiceMock) RegisterCalls() []struct {
+	Ctx context.Context
+	Req models.RegisterRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.RegisterRequest
+	}
+	mock.lockRegister.RLock()
+	calls = mock.calls.Register
+	mock.lockRegister.RUnlock()
+	return calls
+}
+
+// ResendVerification calls ResendVerificationFunc.
+func (mock *AuthServiceMock) ResendVerification(ctx context.Context, email string) error {
+	if mock.ResendVerificationFunc == nil {
+		panic("AuthServiceMock.ResendVerificationFunc: method is nil but AuthService.ResendVerification was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockResendVerification.Lock()
+	mock.calls.ResendVerification = append(mock.calls.ResendVerification, callInfo)
+	mock.lockResendVerification.Unlock()
+	return mock.ResendVerificationFunc(ctx, email)
+}
+
+// ResendVerificationCalls gets all the calls that were made to ResendVerification.
+// Check the length with:
+//
+//	len(mockedAuthService.ResendVerificationCalls())
+func (mock *AuthServiceMock) ResendVerificationCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockResendVerification.RLock()
+	calls = mock.calls.ResendVerification
+	mock.lockResendVerification.RUnlock()
+	return calls
+}
+
+// RevokeSessions calls RevokeSessionsFunc.
+func (mock *AuthServiceMock) RevokeSessions(ctx context.Context, actorID uuid.UUID, userID uuid.UUID) error {
+	if mock.RevokeSessionsFunc == nil {
+		panic("AuthServiceMock.RevokeSessionsFunc: method is nil but AuthService.RevokeSessions was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+	}{
+		Ctx:     ctx,
+		ActorID: actorID,
+		UserID:  userID,
+	}
+	mock.lockRevokeSessions.Lock()
+	mock.calls.RevokeSessions = append(mock.calls.RevokeSessions, callInfo)
+	mock.lockRevokeSessions.Unlock()
+	return mock.RevokeSessionsFunc(ctx, actorID, userID)
+}
+
+// RevokeSessionsCalls gets all the calls that were made to RevokeSessions.
+// Check the length with:
+//
+//	len(mockedAuthService.RevokeSessionsCalls())
+func (mock *AuthServiceMock) RevokeSessionsCalls() []struct {
+	Ctx     context.Context
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+	}
+	mock.lockRevokeSessions.RLock()
+	calls = mock.calls.RevokeSessions
+	mock.lockRevokeSessions.RUnlock()
+	return calls
+}
+
+// SetUserActive calls SetUserActiveFunc.
+func (mock *AuthServiceMock) SetUserActive(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, active bool) (*models.User, error) {
+	if mock.SetUserActiveFunc == nil {
+		panic("AuthServiceMock.SetUserActiveFunc: method is nil but AuthService.SetUserActive was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+		Active  bool
+	}{
+		Ctx:     ctx,
+		ActorID: actorID,
+		UserID:  userID,
+		Active:  active,
+	}
+	mock.lockSetUserActive.Lock()
+	mock.calls.SetUserActive = append(mock.calls.SetUserActive, callInfo)
+	mock.lockSetUserActive.Unlock()
+	return mock.SetUserActiveFunc(ctx, actorID, userID, active)
+}
+
+// SetUserActiveCalls gets all the calls that were made to SetUserActive.
+// Check the length with:
+//
+//	len(mockedAuthService.SetUserActiveCalls())
+func (mock *AuthServiceMock) SetUserActiveCalls() []struct {
+	Ctx     context.Context
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+	Active  bool
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ActorID uuid.UUID
+		UserID  uuid.UUID
+		Active  bool
+	}
+	mock.lockSetUserActive.RLock()
+	calls = mock.calls.SetUserActive
+	mock.lockSetUserActive.RUnlock()
+	return calls
+}
+
+// UpdateUserRole calls UpdateUserRoleFunc.
+func (mock *AuthServiceMock) UpdateUserRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, string, error) {
+	if mock.UpdateUserRoleFunc == nil {
+		panic("AuthServiceMock.UpdateUserRoleFunc: method is nil but AuthService.UpdateUserRole was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Role   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Role:   role,
+	}
+	mock.lockUpdateUserRole.Lock()
+	mock.calls.UpdateUserRole = append(mock.calls.UpdateUserRole, callInfo)
+	mock.lockUpdateUserRole.Unlock()
+	return mock.UpdateUserRoleFunc(ctx, userID, role)
+}
+
+// UpdateUserRoleCalls gets all the calls that were made to UpdateUserRole.
+// Check the length with:
+//
+//	len(mockedAuthService.UpdateUserRoleCalls())
+func (mock *AuthServiceMock) UpdateUserRoleCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Role   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Role   string
+	}
+	mock.lockUpdateUserRole.RLock()
+	calls = mock.calls.UpdateUserRole
+	mock.lockUpdateUserRole.RUnlock()
+	return calls
+}
+
+// ValidateToken calls ValidateTokenFunc.
+func (mock *AuthServiceMock) ValidateToken(ctx context.Context, tokenString string) (*models.User, error) {
+	if mock.ValidateTokenFunc == nil {
+		panic("AuthServiceMock.ValidateTokenFunc: method is nil but AuthService.ValidateToken was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		TokenString string
+	}{
+		Ctx:         ctx,
+		TokenString: tokenString,
+	}
+	mock.lockValidateToken.Lock()
+	mock.calls.ValidateToken = append(mock.calls.ValidateToken, callInfo)
+	mock.lockValidateToken.Unlock()
+	return mock.ValidateTokenFunc(ctx, tokenString)
+}
+
+// ValidateTokenCalls gets all the calls that were made to ValidateToken.
+// Check the length with:
+//
+//	len(mockedAuthService.ValidateTokenCalls())
+func (mock *AuthServiceMock) ValidateTokenCalls() []struct {
+	Ctx         context.Context
+	TokenString string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		TokenString string
+	}
+	mock.lockValidateToken.RLock()
+	calls = mock.calls.ValidateToken
+	mock.lockValidateToken.RUnlock()
+	return calls
+}
+
+// VerifyEmail calls VerifyEmailFunc.
+func (mock *AuthServiceMock) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	if mock.VerifyEmailFunc == nil {
+		panic("AuthServiceMock.VerifyEmailFunc: method is nil but AuthService.VerifyEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockVerifyEmail.Lock()
+	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
+	mock.lockVerifyEmail.Unlock()
+	return mock.VerifyEmailFunc(ctx, token)
+}
+
+// VerifyEmailCalls gets all the calls that were made to VerifyEmail.
+// Check the length with:
+//
+//	len(mockedAuthService.VerifyEmailCalls())
+func (mock *AuthServiceMock) VerifyEmailCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockVerifyEmail.RLock()
+	calls = mock.calls.VerifyEmail
+	mock.lockVerifyEmail.RUnlock()
+	return calls
+}
+
+// Ensure, that BackInStockServiceMock does implement service.BackInStockService.
+// If this is not the case, regenerate this file with moq.
+var _ service.BackInStockService = &BackInStockServiceMock{}
+
+// BackInStockServiceMock is a mock implementation of service.BackInStockService.
+//
+//	func TestSomethingThatUsesBackInStockService(t *testing.T) {
+//
+//		// make and configure a mocked service.BackInStockService
+//		mockedBackInStockService := &BackInStockServiceMock{
+//			NotifyAvailableFunc: func(ctx context.Context, productID *uuid.UUID) error {
+//				panic("mock out the NotifyAvailable method")
+//			},
+//			SubscribeFunc: func(ctx context.Context, userID uuid.UUID, productID uuid.UUID, variantID *uuid.UUID) (*models.BackInStockSubscription, error) {
+//				panic("mock out the Subscribe method")
+//			},
+//		}
+//
+//		// use mockedBackInStockService in code that requires service.BackInStockService
+//		// and then make assertions.
+//
+//	}
+type BackInStockServiceMock struct {
+	// NotifyAvailableFunc mocks the NotifyAvailable method.
+	NotifyAvailableFunc func(ctx context.Context, productID *uuid.UUID) error
+
+	// SubscribeFunc mocks the Subscribe method.
+	SubscribeFunc func(ctx context.Context, userID uuid.UUID, productID uuid.UUID, variantID *uuid.UUID) (*models.BackInStockSubscription, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// NotifyAvailable holds details about calls to the NotifyAvailable method.
+		NotifyAvailable []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID *uuid.UUID
+		}
+		// Subscribe holds details about calls to the Subscribe method.
+		Subscribe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID *uuid.UUID
+		}
+	}
+	lockNotifyAvailable sync.RWMutex
+	lockSubscribe       sync.RWMutex
+}
+
+// NotifyAvailable calls NotifyAvailableFunc.
+func (mock *BackInStockServiceMock) NotifyAvailable(ctx context.Context, productID *uuid.UUID) error {
+	if mock.NotifyAvailableFunc == nil {
+		panic("BackInStockServiceMock.NotifyAvailableFunc: method is nil but BackInStockService.NotifyAvailable was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID *uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+	}
+	mock.lockNotifyAvailable.Lock()
+	mock.calls.NotifyAvailable = append(mock.calls.NotifyAvailable, callInfo)
+	mock.lockNotifyAvailable.Unlock()
+	return mock.NotifyAvailableFunc(ctx, productID)
+}
+
+// NotifyAvailableCalls gets all the calls that were made to NotifyAvailable.
+// Check the length with:
+//
+//	len(mockedBackInStockService.NotifyAvailableCalls())
+func (mock *BackInStockServiceMock) NotifyAvailableCalls() []struct {
+	Ctx       context.Context
+	ProductID *uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID *uuid.UUID
+	}
+	mock.lockNotifyAvailable.RLock()
+	calls = mock.calls.NotifyAvailable
+	mock.lockNotifyAvailable.RUnlock()
+	return calls
+}
+
+// Subscribe calls SubscribeFunc.
+func (mock *BackInStockServiceMock) Subscribe(ctx context.Context, userID uuid.UUID, productID uuid.UUID, variantID *uuid.UUID) (*models.BackInStockSubscription, error) {
+	if mock.SubscribeFunc == nil {
+		panic("BackInStockServiceMock.SubscribeFunc: method is nil but BackInStockService.Subscribe was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		ProductID uuid.UUID
+		VariantID *uuid.UUID
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		ProductID: productID,
+		VariantID: variantID,
+	}
+	mock.lockSubscribe.Lock()
+	mock.calls.Subscribe = append(mock.calls.Subscribe, callInfo)
+	mock.lockSubscribe.Unlock()
+	return mock.SubscribeFunc(ctx, userID, productID, variantID)
+}
+
+// SubscribeCalls gets all the calls that were made to Subscribe.
+// Check the length with:
+//
+//	len(mockedBackInStockService.SubscribeCalls())
+func (mock *BackInStockServiceMock) SubscribeCalls() []struct {
+	Ctx       context.Context
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		ProductID uuid.UUID
+		VariantID *uuid.UUID
+	}
+	mock.lockSubscribe.RLock()
+	calls = mock.calls.Subscribe
+	mock.lockSubscribe.RUnlock()
+	return calls
+}
+
+// Ensure, that BundleServiceMock does implement service.BundleService.
+// If this is not the case, regenerate this file with moq.
+var _ service.BundleService = &BundleServiceMock{}
+
+// BundleServiceMock is a mock implementation of service.BundleService.
+//
+//	func TestSomethingThatUsesBundleService(t *testing.T) {
+//
+//		// make and configure a mocked service.BundleService
+//		mockedBundleService := &BundleServiceMock{
+//			GetBundleFunc: func(ctx context.Context, productID uuid.UUID) ([]models.BundleComponent, error) {
+//				panic("mock out the GetBundle method")
+//			},
+//			SetBundleFunc: func(ctx context.Context, productID uuid.UUID, req models.SetBundleRequest) ([]models.BundleComponent, error) {
+//				panic("mock out the SetBundle method")
+//			},
+//		}
+//
+//		// use mockedBundleService in code that requires service.BundleService
+//		// and then make assertions.
+//
+//	}
+type BundleServiceMock struct {
+	// GetBundleFunc mocks the GetBundle method.
+	GetBundleFunc func(ctx context.Context, productID uuid.UUID) ([]models.BundleComponent, error)
+
+	// SetBundleFunc mocks the SetBundle method.
+	SetBundleFunc func(ctx context.Context, productID uuid.UUID, req models.SetBundleRequest) ([]models.BundleComponent, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetBundle holds details about calls to the GetBundle method.
+		GetBundle []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+		}
+		// SetBundle holds details about calls to the SetBundle method.
+		SetBundle []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// Req is the req argument value.
+			Req models.SetBundleRequest
+		}
+	}
+	lockGetBundle sync.RWMutex
+	lockSetBundle sync.RWMutex
+}
+
+// GetBundle calls GetBundleFunc.
+func (mock *BundleServiceMock) GetBundle(ctx context.Context, productID uuid.UUID) ([]models.BundleComponent, error) {
+	if mock.GetBundleFunc == nil {
+		panic("BundleServiceMock.GetBundleFunc: method is nil but BundleService.GetBundle was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+	}
+	mock.lockGetBundle.Lock()
+	mock.calls.GetBundle = append(mock.calls.GetBundle, callInfo)
+	mock.lockGetBundle.Unlock()
+	return mock.GetBundleFunc(ctx, productID)
+}
+
+// GetBundleCalls gets all the calls that were made to GetBundle.
+// Check the length with:
+//
+//	len(mockedBundleService.GetBundleCalls())
+func (mock *BundleServiceMock) GetBundleCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}
+	mock.lockGetBundle.RLock()
+	calls = mock.calls.GetBundle
+	mock.lockGetBundle.RUnlock()
+	return calls
+}
+
+// SetBundle calls SetBundleFunc.
+func (mock *BundleServiceMock) SetBundle(ctx context.Context, productID uuid.UUID, req models.SetBundleRequest) ([]models.BundleComponent, error) {
+	if mock.SetBundleFunc == nil {
+		panic("BundleServiceMock.SetBundleFunc: method is nil but BundleService.SetBundle was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.SetBundleRequest
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		Req:       req,
+	}
+	mock.lockSetBundle.Lock()
+	mock.calls.SetBundle = append(mock.calls.SetBundle, callInfo)
+	mock.lockSetBundle.Unlock()
+	return mock.SetBundleFunc(ctx, productID, req)
+}
+
+// SetBundleCalls gets all the calls that were made to SetBundle.
+// Check the length with:
+//
+//	len(mockedBundleService.SetBundleCalls())
+func (mock *BundleServiceMock) SetBundleCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	Req       models.SetBundleRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.SetBundleRequest
+	}
+	mock.lockSetBundle.RLock()
+	calls = mock.calls.SetBundle
+	mock.lockSetBundle.RUnlock()
+	return calls
+}
+
+// Ensure, that CarrierServiceMock does implement service.CarrierService.
+// If this is not the case, regenerate this file with moq.
+var _ service.CarrierService = &CarrierServiceMock{}
+
+// CarrierServiceMock is a mock implementation of service.CarrierService.
+//
+//	func TestSomethingThatUsesCarrierService(t *testing.T) {
+//
+//		// make and configure a mocked service.CarrierService
+//		mockedCarrierService := &CarrierServiceMock{
+//			GetEventsFunc: func(ctx context.Context, filter models.CarrierEventFilter, page int, limit int) ([]models.CarrierEvent, int, error) {
+//				panic("mock out the GetEvents method")
+//			},
+//			HandleWebhookFunc: func(ctx context.Context, carrier string, payload []byte, signature string) error {
+//				panic("mock out the HandleWebhook method")
+//			},
+//		}
+//
+//		// use mockedCarrierService in code that requires service.CarrierService
+//		// and then make assertions.
+//
+//	}
+type CarrierServiceMock struct {
+	// GetEventsFunc mocks the GetEvents method.
+	GetEventsFunc func(ctx context.Context, filter models.CarrierEventFilter, page int, limit int) ([]models.CarrierEvent, int, error)
+
+	// HandleWebhookFunc mocks the HandleWebhook method.
+	HandleWebhookFunc func(ctx context.Context, carrier string, payload []byte, signature string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetEvents holds details about calls to the GetEvents method.
+		GetEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.CarrierEventFilter
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// HandleWebhook holds details about calls to the HandleWebhook method.
+		HandleWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Carrier is the carrier argument value.
+			Carrier string
+			// Payload is the payload argument value.
+			Payload []byte
+			// Signature is the signature argument value.
+			Signature string
+		}
+	}
+	lockGetEvents     sync.RWMutex
+	lockHandleWebhook sync.RWMutex
+}
+
+// GetEvents calls GetEventsFunc.
+func (mock *CarrierServiceMock) GetEvents(ctx context.Context, filter models.CarrierEventFilter, page int, limit int) ([]models.CarrierEvent, int, error) {
+	if mock.GetEventsFunc == nil {
+		panic("CarrierServiceMock.GetEventsFunc: method is nil but CarrierService.GetEvents was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.CarrierEventFilter
+		Page   int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Page:   page,
+		Limit:  limit,
+	}
+	mock.lockGetEvents.Lock()
+	mock.calls.GetEvents = append(mock.calls.GetEvents, callInfo)
+	mock.lockGetEvents.Unlock()
+	return mock.GetEventsFunc(ctx, filter, page, limit)
+}
+
+// GetEventsCalls gets all the calls that were made to GetEvents.
+// Check the length with:
+//
+//	len(mockedCarrierService.GetEventsCalls())
+func (mock *CarrierServiceMock) GetEventsCalls() []struct {
+	Ctx    context.Context
+	Filter models.CarrierEventFilter
+	Page   int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.CarrierEventFilter
+		Page   int
+		Limit  int
+	}
+	mock.lockGetEvents.RLock()
+	calls = mock.calls.GetEvents
+	mock.lockGetEvents.RUnlock()
+	return calls
+}
+
+// HandleWebhook calls HandleWebhookFunc.
+func (mock *CarrierServiceMock) HandleWebhook(ctx context.Context, carrier string, payload []byte, signature string) error {
+	if mock.HandleWebhookFunc == nil {
+		panic("CarrierServiceMock.HandleWebhookFunc: method is nil but CarrierService.HandleWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Carrier   string
+		Payload   []byte
+		Signature string
+	}{
+		Ctx:       ctx,
+		Carrier:   carrier,
+		Payload:   payload,
+		Signature: signature,
+	}
+	mock.lockHandleWebhook.Lock()
+	mock.calls.HandleWebhook = append(mock.calls.HandleWebhook, callInfo)
+	mock.lockHandleWebhook.Unlock()
+	return mock.HandleWebhookFunc(ctx, carrier, payload, signature)
+}
+
+// HandleWebhookCalls gets all the calls that were made to HandleWebhook.
+// Check the length with:
+//
+//	len(mockedCarrierService.HandleWebhookCalls())
+func (mock *CarrierServiceMock) HandleWebhookCalls() []struct {
+	Ctx       context.Context
+	Carrier   string
+	Payload   []byte
+	Signature string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Carrier   string
+		Payload   []byte
+		Signature string
+	}
+	mock.lockHandleWebhook.RLock()
+	calls = mock.calls.HandleWebhook
+	mock.lockHandleWebhook.RUnlock()
+	return calls
+}
+
+// Ensure, that CartServiceMock does implement service.CartService.
+// If this is not the case, regenerate this file with moq.
+var _ service.CartService = &CartServiceMock{}
+
+// CartServiceMock is a mock implementation of service.CartService.
+//
+//	func TestSomethingThatUsesCartService(t *testing.T) {
+//
+//		// make and configure a mocked service.CartService
+//		mockedCartService := &CartServiceMock{
+//			AddToCartFunc: func(ctx context.Context, userID uuid.UUID, req models.AddToCartRequest) (*models.Cart, error) {
+//				panic("mock out the AddToCart method")
+//			},
+//			ClearCartFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the ClearCart method")
+//			},
+//			EnsureMinimumOrderFunc: func(ctx context.Context, subtotal money.Money) error {
+//				panic("mock out the EnsureMinimumOrder method")
+//			},
+//			GetCartFunc: func(ctx context.Context, userID uuid.UUID) (*models.Cart, error) {
+//				panic("mock out the GetCart method")
+//			},
+//			RemoveFromCartFunc: func(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*models.Cart, error) {
+//				panic("mock out the RemoveFromCart method")
+//			},
+//			ResolveCartFunc: func(ctx context.Context, userID uuid.UUID) (*models.CartResolution, error) {
+//				panic("mock out the ResolveCart method")
+//			},
+//			UpdateCartItemFunc: func(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, req models.UpdateCartItemRequest) (*models.Cart, error) {
+//				panic("mock out the UpdateCartItem method")
+//			},
+//			ValidateCartFunc: func(ctx context.Context, cartID uuid.UUID) (bool, []string, error) {
+//				panic("mock out the ValidateCart method")
+//			},
+//		}
+//
+//		// use mockedCartService in code that requires service.CartService
+//		// and then make assertions.
+//
+//	}
+type CartServiceMock struct {
+	// AddToCartFunc mocks the AddToCart method.
+	AddToCartFunc func(ctx context.Context, userID uuid.UUID, req models.AddToCartRequest) (*models.Cart, error)
+
+	// ClearCartFunc mocks the ClearCart method.
+	ClearCartFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// EnsureMinimumOrderFunc mocks the EnsureMinimumOrder method.
+	EnsureMinimumOrderFunc func(ctx context.Context, subtotal money.Money) error
+
+	// GetCartFunc mocks the GetCart method.
+	GetCartFunc func(ctx context.Context, userID uuid.UUID) (*models.Cart, error)
+
+	// RemoveFromCartFunc mocks the RemoveFromCart method.
+	RemoveFromCartFunc func(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*models.Cart, error)
+
+	// ResolveCartFunc mocks the ResolveCart method.
+	ResolveCartFunc func(ctx context.Context, userID uuid.UUID) (*models.CartResolution, error)
+
+	// UpdateCartItemFunc mocks the UpdateCartItem method.
+	UpdateCartItemFunc func(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, req models.UpdateCartItemRequest) (*models.Cart, error)
+
+	// ValidateCartFunc mocks the ValidateCart method.
+	ValidateCartFunc func(ctx context.Context, cartID uuid.UUID) (bool, []string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddToCart holds details about calls to the AddToCart method.
+		AddToCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.AddToCartRequest
+		}
+		// ClearCart holds details about calls to the ClearCart method.
+		ClearCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// EnsureMinimumOrder holds details about calls to the EnsureMinimumOrder method.
+		EnsureMinimumOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Subtotal is the subtotal argument value.
+			Subtotal money.Money
+		}
+		// GetCart holds details about calls to the GetCart method.
+		GetCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// RemoveFromCart holds details about calls to the RemoveFromCart method.
+		RemoveFromCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ItemID is the itemID argument value.
+			ItemID uuid.UUID
+		}
+		// ResolveCart holds details about calls to the ResolveCart method.
+		ResolveCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// UpdateCartItem holds details about calls to the UpdateCartItem method.
+		UpdateCartItem []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ItemID is the itemID argument value.
+			ItemID uuid.UUID
+			// Req is the req argument value.
+			Req models.UpdateCartItemRequest
+		}
+		// ValidateCart holds details about calls to the ValidateCart method.
+		ValidateCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CartID is the cartID argument value.
+			CartID uuid.UUID
+		}
+	}
+	lockAddToCart          sync.RWMutex
+	lockClearCart          sync.RWMutex
+	lockEnsureMinimumOrder sync.RWMutex
+	lockGetCart            sync.RWMutex
+	lockRemoveFromCart     sync.RWMutex
+	lockResolveCart        sync.RWMutex
+	lockUpdateCartItem     sync.RWMutex
+	lockValidateCart       sync.RWMutex
+}
+
+// AddToCart calls AddToCartFunc.
+func (mock *CartServiceMock) AddToCart(ctx context.Context, userID uuid.UUID, req models.AddToCartRequest) (*models.Cart, error) {
+	if mock.AddToCartFunc == nil {
+		panic("CartServiceMock.AddToCartFunc: method is nil but CartService.AddToCart was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.AddToCartRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Req:    req,
+	}
+	mock.lockAddToCart.Lock()
+	mock.calls.AddToCart = append(mock.calls.AddToCart, callInfo)
+	mock.lockAddToCart.Unlock()
+	return mock.AddToCartFunc(ctx, userID, req)
+}
+
+// AddToCartCalls gets all the calls that were made to AddToCart.
+// Check the length with:
+//
+//	len(mockedCartService.AddToCartCalls())
+func (mock *CartServiceMock) AddToCartCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Req    models.AddToCartRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.AddToCartRequest
+	}
+	mock.lockAddToCart.RLock()
+	calls = mock.calls.AddToCart
+	mock.lockAddToCart.RUnlock()
+	return calls
+}
+
+// ClearCart calls ClearCartFunc.
+func (mock *CartServiceMock) ClearCart(ctx context.Context, userID uuid.UUID) error {
+	if mock.ClearCartFunc == nil {
+		panic("CartServiceMock.ClearCartFunc: method is nil but CartService.ClearCart was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockClearCart.Lock()
+	mock.calls.ClearCart = append(mock.calls.ClearCart, callInfo)
+	mock.lockClearCart.Unlock()
+	return mock.ClearCartFunc(ctx, userID)
+}
+
+// ClearCartCalls gets all the calls that were made to ClearCart.
+// Check the length with:
+//
+//	len(mockedCartService.ClearCartCalls())
+func (mock *CartServiceMock) ClearCartCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockClearCart.RLock()
+	calls = mock.calls.ClearCart
+	mock.lockClearCart.RUnlock()
+	return calls
+}
+
+// EnsureMinimumOrder calls EnsureMinimumOrderFunc.
+func (mock *CartServiceMock) EnsureMinimumOrder(ctx context.Context, subtotal money.Money) error {
+	if mock.EnsureMinimumOrderFunc == nil {
+		panic("CartServiceMock.EnsureMinimumOrderFunc: method is nil but CartService.EnsureMinimumOrder was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Subtotal money.Money
+	}{
+		Ctx:      ctx,
+		Subtotal: subtotal,
+	}
+	mock.lockEnsureMinimumOrder.Lock()
+	mock.calls.EnsureMinimumOrder = append(mock.calls.EnsureMinimumOrder, callInfo)
+	mock.lockEnsureMinimumOrder.Unlock()
+	return mock.EnsureMinimumOrderFunc(ctx, subtotal)
+}
+
+// EnsureMinimumOrderCalls gets all the calls that were made to EnsureMinimumOrder.
+// Check the length with:
+//
+//	len(mockedCartService.EnsureMinimumOrderCalls())
+func (mock *CartServiceMock) EnsureMinimumOrderCalls() []struct {
+	Ctx      context.Context
+	Subtotal money.Money
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Subtotal money.Money
+	}
+	mock.lockEnsureMinimumOrder.RLock()
+	calls = mock.calls.EnsureMinimumOrder
+	mock.lockEnsureMinimumOrder.RUnlock()
+	return calls
+}
+
+// GetCart calls GetCartFunc.
+func (mock *CartServiceMock) GetCart(ctx context.Context, userID uuid.UUID) (*models.Cart, error) {
+	if mock.GetCartFunc == nil {
+		panic("CartServiceMock.GetCartFunc: method is nil but CartService.GetCart was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetCart.Lock()
+	mock.calls.GetCart = append(mock.calls.GetCart, callInfo)
+	mock.lockGetCart.Unlock()
+	return mock.GetCartFunc(ctx, userID)
+}
+
+// GetCartCalls gets all the calls that were made to GetCart.
+// Check the length with:
+//
+//	len(mockedCartService.GetCartCalls())
+func (mock *CartServiceMock) GetCartCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetCart.RLock()
+	calls = mock.calls.GetCart
+	mock.lockGetCart.RUnlock()
+	return calls
+}
+
+// RemoveFromCart calls RemoveFromCartFunc.
+func (mock *CartServiceMock) RemoveFromCart(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*models.Cart, error) {
+	if mock.RemoveFromCartFunc == nil {
+		panic("CartServiceMock.RemoveFromCartFunc: method is nil but CartService.RemoveFromCart was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ItemID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ItemID: itemID,
+	}
+	mock.lockRemoveFromCart.Lock()
+	mock.calls.RemoveFromCart = append(mock.calls.RemoveFromCart, callInfo)
+	mock.lockRemoveFromCart.Unlock()
+	return mock.RemoveFromCartFunc(ctx, userID, itemID)
+}
+
+// RemoveFromCartCalls gets all the calls that were made to RemoveFromCart.
+// Check the length with:
+//
+//	len(mockedCartService.RemoveFromCartCalls())
+func (mock *CartServiceMock) RemoveFromCartCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ItemID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ItemID uuid.UUID
+	}
+	mock.lockRemoveFromCart.RLock()
+	calls = mock.calls.RemoveFromCart
+	mock.lockRemoveFromCart.RUnlock()
+	return calls
+}
+
+// ResolveCart calls ResolveCartFunc.
+func (mock *CartServiceMock) ResolveCart(ctx context.Context, userID uuid.UUID) (*models.CartResolution, error) {
+	if mock.ResolveCartFunc == nil {
+		panic("CartServiceMock.ResolveCartFunc: method is nil but CartService.ResolveCart was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockResolveCart.Lock()
+	mock.calls.ResolveCart = append(mock.calls.ResolveCart, callInfo)
+	mock.lockResolveCart.Unlock()
+	return mock.ResolveCartFunc(ctx, userID)
+}
+
+// ResolveCartCalls gets all the calls that were made to ResolveCart.
+// Check the length with:
+//
+//	len(mockedCartService.ResolveCartCalls())
+func (mock *CartServiceMock) ResolveCartCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockResolveCart.RLock()
+	calls = mock.calls.ResolveCart
+	mock.lockResolveCart.RUnlock()
+	return calls
+}
+
+// UpdateCartItem calls UpdateCartItemFunc.
+func (mock *CartServiceMock) UpdateCartItem(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, req models.UpdateCartItemRequest) (*models.Cart, error) {
+	if mock.UpdateCartItemFunc == nil {
+		panic("CartServiceMock.UpdateCartItemFunc: method is nil but CartService.UpdateCartItem was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ItemID uuid.UUID
+		Req    models.UpdateCartItemRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ItemID: itemID,
+		Req:    req,
+	}
+	mock.lockUpdateCartItem.Lock()
+	mock.calls.UpdateCartItem = append(mock.calls.UpdateCartItem, callInfo)
+	mock.lockUpdateCartItem.Unlock()
+	return mock.UpdateCartItemFunc(ctx, userID, itemID, req)
+}
+
+// UpdateCartItemCalls gets all the calls that were made to UpdateCartItem.
+// Check the length with:
+//
+//	len(mockedCartService.UpdateCartItemCalls())
+func (mock *CartServiceMock) UpdateCartItemCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ItemID uuid.UUID
+	Req    models.UpdateCartItemRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ItemID uuid.UUID
+		Req    models.UpdateCartItemRequest
+	}
+	mock.lockUpdateCartItem.RLock()
+	calls = mock.calls.UpdateCartItem
+	mock.lockUpdateCartItem.RUnlock()
+	return calls
+}
+
+// ValidateCart calls ValidateCartFunc.
+func (mock *CartServiceMock) ValidateCart(ctx context.Context, cartID uuid.UUID) (bool, []string, error) {
+	if mock.ValidateCartFunc == nil {
+		panic("CartServiceMock.ValidateCartFunc: method is nil but CartService.ValidateCart was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		CartID uuid.UUID
+	}{
+		Ctx:    ctx,
+		CartID: cartID,
+	}
+	mock.lockValidateCart.Lock()
+	mock.calls.ValidateCart = append(mock.calls.ValidateCart, callInfo)
+	mock.lockValidateCart.Unlock()
+	return mock.ValidateCartFunc(ctx, cartID)
+}
+
+// ValidateCartCalls gets all the calls that were made to ValidateCart.
+// Check the length with:
+//
+//	len(mockedCartService.ValidateCartCalls())
+func (mock *CartServiceMock) ValidateCartCalls() []struct {
+	Ctx    context.Context
+	CartID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		CartID uuid.UUID
+	}
+	mock.lockValidateCart.RLock()
+	calls = mock.calls.ValidateCart
+	mock.lockValidateCart.RUnlock()
+	return calls
+}
+
+// Ensure, that CheckoutServiceMock does implement service.CheckoutService.
+// If this is not the case, regenerate this file with moq.
+var _ service.CheckoutService = &CheckoutServiceMock{}
+
+// CheckoutServiceMock is a mock implementation of service.CheckoutService.
+//
+//	func TestSomethingThatUsesCheckoutService(t *testing.T) {
+//
+//		// make and configure a mocked service.CheckoutService
+//		mockedCheckoutService := &CheckoutServiceMock{
+//			CreateSessionFunc: func(ctx context.Context, userID uuid.UUID, req models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error) {
+//				panic("mock out the CreateSession method")
+//			},
+//			GetSessionFunc: func(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (*models.CheckoutSession, error) {
+//				panic("mock out the GetSession method")
+//			},
+//		}
+//
+//		// use mockedCheckoutService in code that requires service.CheckoutService
+//		// and then make assertions.
+//
+//	}
+type CheckoutServiceMock struct {
+	// CreateSessionFunc mocks the CreateSession method.
+	CreateSessionFunc func(ctx context.Context, userID uuid.UUID, req models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error)
+
+	// GetSessionFunc mocks the GetSession method.
+	GetSessionFunc func(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (*models.CheckoutSession, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateSession holds details about calls to the CreateSession method.
+		CreateSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.CreateCheckoutSessionRequest
+		}
+		// GetSession holds details about calls to the GetSession method.
+		GetSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// SessionID is the sessionID argument value.
+			SessionID uuid.UUID
+		}
+	}
+	lockCreateSession sync.RWMutex
+	lockGetSession    sync.RWMutex
+}
+
+// CreateSession calls CreateSessionFunc.
+func (mock *CheckoutServiceMock) CreateSession(ctx context.Context, userID uuid.UUID, req models.CreateCheckoutSessionRequest) (*models.CheckoutSession, error) {
+	if mock.CreateSessionFunc == nil {
+		panic("CheckoutServiceMock.CreateSessionFunc: method is nil but CheckoutService.CreateSession was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.CreateCheckoutSessionRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Req:    req,
+	}
+	mock.lockCreateSession.Lock()
+	mock.calls.CreateSession = append(mock.calls.CreateSession, callInfo)
+	mock.lockCreateSession.Unlock()
+	return mock.CreateSessionFunc(ctx, userID, req)
+}
+
+// CreateSessionCalls gets all the calls that were made to CreateSession.
+// Check the length with:
+//
+//	len(mockedCheckoutService.CreateSessionCalls())
+func (mock *CheckoutServiceMock) CreateSessionCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Req    models.CreateCheckoutSessionRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.CreateCheckoutSessionRequest
+	}
+	mock.lockCreateSession.RLock()
+	calls = mock.calls.CreateSession
+	mock.lockCreateSession.RUnlock()
+	return calls
+}
+
+// GetSession calls GetSessionFunc.
+func (mock *CheckoutServiceMock) GetSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (*models.CheckoutSession, error) {
+	if mock.GetSessionFunc == nil {
+		panic("CheckoutServiceMock.GetSessionFunc: method is nil but CheckoutService.GetSession was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		SessionID uuid.UUID
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		SessionID: sessionID,
+	}
+	mock.lockGetSession.Lock()
+	mock.calls.GetSession = append(mock.calls.GetSession, callInfo)
+	mock.lockGetSession.Unlock()
+	return mock.GetSessionFunc(ctx, userID, sessionID)
+}
+
+// GetSessionCalls gets all the calls that were made to GetSession.
+// Check the length with:
+//
+//	len(mockedCheckoutService.GetSessionCalls())
+func (mock *CheckoutServiceMock) GetSessionCalls() []struct {
+	Ctx       context.Context
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		SessionID uuid.UUID
+	}
+	mock.lockGetSession.RLock()
+	calls = mock.calls.GetSession
+	mock.lockGetSession.RUnlock()
+	return calls
+}
+
+// Ensure, that CODServiceMock does implement service.CODService.
+// If this is not the case, regenerate this file with moq.
+var _ service.CODService = &CODServiceMock{}
+
+// CODServiceMock is a mock implementation of service.CODService.
+//
+//	func TestSomethingThatUsesCODService(t *testing.T) {
+//
+//		// make and configure a mocked service.CODService
+//		mockedCODService := &CODServiceMock{
+//			CheckCartEligibilityFunc: func(ctx context.Context, userID uuid.UUID, postalCode string) (*models.CODEligibility, error) {
+//				panic("mock out the CheckCartEligibility method")
+//			},
+//			CheckEligibilityFunc: func(ctx context.Context, amount money.Money, postalCode string) models.CODEligibility {
+//				panic("mock out the CheckEligibility method")
+//			},
+//			GetRemittanceReportFunc: func(ctx context.Context, status string, rangeDays int, page int, limit int) (*models.CODRemittanceReport, int, error) {
+//				panic("mock out the GetRemittanceReport method")
+//			},
+//			IsDeliveryVerifiedFunc: func(ctx context.Context, orderID uuid.UUID) (bool, error) {
+//				panic("mock out the IsDeliveryVerified method")
+//			},
+//			IssueDeliveryOTPFunc: func(ctx context.Context, order *models.Order) error {
+//				panic("mock out the IssueDeliveryOTP method")
+//			},
+//			MarkRemittedFunc: func(ctx context.Context, req models.RemitCODRequest) (int64, error) {
+//				panic("mock out the MarkRemitted method")
+//			},
+//			RecordCollectionFunc: func(ctx context.Context, payment *models.Payment) error {
+//				panic("mock out the RecordCollection method")
+//			},
+//			ResendDeliveryOTPFunc: func(ctx context.Context, orderID uuid.UUID) error {
+//				panic("mock out the ResendDeliveryOTP method")
+//			},
+//			VerifyDeliveryOTPFunc: func(ctx context.Context, orderID uuid.UUID, otp string) error {
+//				panic("mock out the VerifyDeliveryOTP method")
+//			},
+//		}
+//
+//		// use mockedCODService in code that requires service.CODService
+//		// and then make assertions.
+//
+//	}
+type CODServiceMock struct {
+	// CheckCartEligibilityFunc mocks the CheckCartEligibility method.
+	CheckCartEligibilityFunc func(ctx context.Context, userID uuid.UUID, postalCode string) (*models.CODEligibility, error)
+
+	// CheckEligibilityFunc mocks the CheckEligibility method.
+	CheckEligibilityFunc func(ctx context.Context, amount money.Money, postalCode string) models.CODEligibility
+
+	// GetRemittanceReportFunc mocks the GetRemittanceReport method.
+	GetRemittanceReportFunc func(ctx context.Context, status string, rangeDays int, page int, limit int) (*models.CODRemittanceReport, int, error)
+
+	// IsDeliveryVerifiedFunc mocks the IsDeliveryVerified method.
+	IsDeliveryVerifiedFunc func(ctx context.Context, orderID uuid.UUID) (bool, error)
+
+	// IssueDeliveryOTPFunc mocks the IssueDeliveryOTP method.
+	IssueDeliveryOTPFunc func(ctx context.Context, order *models.Order) error
+
+	// MarkRemittedFunc mocks the MarkRemitted method.
+	MarkRemittedFunc func(ctx context.Context, req models.RemitCODRequest) (int64, error)
+
+	// RecordCollectionFunc mocks the RecordCollection method.
+	RecordCollectionFunc func(ctx context.Context, payment *models.Payment) error
+
+	// ResendDeliveryOTPFunc mocks the ResendDeliveryOTP method.
+	ResendDeliveryOTPFunc func(ctx context.Context, orderID uuid.UUID) error
+
+	// VerifyDeliveryOTPFunc mocks the VerifyDeliveryOTP method.
+	VerifyDeliveryOTPFunc func(ctx context.Context, orderID uuid.UUID, otp string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CheckCartEligibility holds details about calls to the CheckCartEligibility method.
+		CheckCartEligibility []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// PostalCode is the postalCode argument value.
+			PostalCode string
+		}
+		// CheckEligibility holds details about calls to the CheckEligibility method.
+		CheckEligibility []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Amount is the amount argument value.
+			Amount money.Money
+			// PostalCode is the postalCode argument value.
+			PostalCode string
+		}
+		// GetRemittanceReport holds details about calls to the GetRemittanceReport method.
+		GetRemittanceReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Status is the status argument value.
+			Status string
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// IsDeliveryVerified holds details about calls to the IsDeliveryVerified method.
+		IsDeliveryVerified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// IssueDeliveryOTP holds details about calls to the IssueDeliveryOTP method.
+		IssueDeliveryOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Order is the order argument value.
+			Order *models.Order
+		}
+		// MarkRemitted holds details about calls to the MarkRemitted method.
+		MarkRemitted []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.RemitCODRequest
+		}
+		// RecordCollection holds details about calls to the RecordCollection method.
+		RecordCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payment is the payment argument value.
+			Payment *models.Payment
+		}
+		// ResendDeliveryOTP holds details about calls to the ResendDeliveryOTP method.
+		ResendDeliveryOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// VerifyDeliveryOTP holds details about calls to the VerifyDeliveryOTP method.
+		VerifyDeliveryOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Otp is the otp argument value.
+			Otp string
+		}
+	}
+	lockCheckCartEligibility sync.RWMutex
+	lockCheckEligibility     sync.RWMutex
+	lockGetRemittanceReport  sync.RWMutex
+	lockIsDeliveryVerified   sync.RWMutex
+	lockIssueDeliveryOTP     sync.RWMutex
+	lockMarkRemitted         sync.RWMutex
+	lockRecordCollection     sync.RWMutex
+	lockResendDeliveryOTP    sync.RWMutex
+	lockVerifyDeliveryOTP    sync.RWMutex
+}
+
+// CheckCartEligibility calls CheckCartEligibilityFunc.
+func (mock *CODServiceMock) CheckCartEligibility(ctx context.Context, userID uuid.UUID, postalCode string) (*models.CODEligibility, error) {
+	if mock.CheckCartEligibilityFunc == nil {
+		panic("CODServiceMock.CheckCartEligibilityFunc: method is nil but CODService.CheckCartEligibility was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		PostalCode string
+	}{
+		Ctx:        ctx,
+		UserID:     userID,
+		PostalCode: postalCode,
+	}
+	mock.lockCheckCartEligibility.Lock()
+	mock.calls.CheckCartEligibility = append(mock.calls.CheckCartEligibility, callInfo)
+	mock.lockCheckCartEligibility.Unlock()
+	return mock.CheckCartEligibilityFunc(ctx, userID, postalCode)
+}
+
+// CheckCartEligibilityCalls gets all the calls that were made to CheckCartEligibility.
+// Check the length with:
+//
+//	len(mockedCODService.CheckCartEligibilityCalls())
+func (mock *CODServiceMock) CheckCartEligibilityCalls() []struct {
+	Ctx        context.Context
+	UserID     uuid.UUID
+	PostalCode string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		PostalCode string
+	}
+	mock.lockCheckCartEligibility.RLock()
+	calls = mock.calls.CheckCartEligibility
+	mock.lockCheckCartEligibility.RUnlock()
+	return calls
+}
+
+// CheckEligibility calls CheckEligibilityFunc.
+func (mock *CODServiceMock) CheckEligibility(ctx context.Context, amount money.Money, postalCode string) models.CODEligibility {
+	if mock.CheckEligibilityFunc == nil {
+		panic("CODServiceMock.CheckEligibilityFunc: method is nil but CODService.CheckEligibility was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Amount     money.Money
+		PostalCode string
+	}{
+		Ctx:        ctx,
+		Amount:     amount,
+		PostalCode: postalCode,
+	}
+	mock.lockCheckEligibility.Lock()
+	mock.calls.CheckEligibility = append(mock.calls.CheckEligibility, callInfo)
+	mock.lockCheckEligibility.Unlock()
+	return mock.CheckEligibilityFunc(ctx, amount, postalCode)
+}
+
+// CheckEligibilityCalls gets all the calls that were made to CheckEligibility.
+// Check the length with:
+//
+//	len(mockedCODService.CheckEligibilityCalls())
+func (mock *CODServiceMock) CheckEligibilityCalls() []struct {
+	Ctx        context.Context
+	Amount     money.Money
+	PostalCode string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Amount     money.Money
+		PostalCode string
+	}
+	mock.lockCheckEligibility.RLock()
+	calls = mock.calls.CheckEligibility
+	mock.lockCheckEligibility.RUnlock()
+	return calls
+}
+
+// GetRemittanceReport calls GetRemittanceReportFunc.
+func (mock *CODServiceMock) GetRemittanceReport(ctx context.Context, status string, rangeDays int, page int, limit int) (*models.CODRemittanceReport, int, error) {
+	if mock.GetRemittanceReportFunc == nil {
+		panic("CODServiceMock.GetRemittanceReportFunc: method is nil but CODService.GetRemittanceReport was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Status    string
+		RangeDays int
+		Page      int
+		Limit     int
+	}{
+		Ctx:       ctx,
+		Status:    status,
+		RangeDays: rangeDays,
+		Page:      page,
+		Limit:     limit,
+	}
+	mock.lockGetRemittanceReport.Lock()
+	mock.calls.GetRemittanceReport = append(mock.calls.GetRemittanceReport, callInfo)
+	mock.lockGetRemittanceReport.Unlock()
+	return mock.GetRemittanceReportFunc(ctx, status, rangeDays, page, limit)
+}
+
+// GetRemittanceReportCalls gets all the calls that were made to GetRemittanceReport.
+// Check the length with:
+//
+//	len(mockedCODService.GetRemittanceReportCalls())
+func (mock *CODServiceMock) GetRemittanceReportCalls() []struct {
+	Ctx       context.Context
+	Status    string
+	RangeDays int
+	Page      int
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Status    string
+		RangeDays int
+		Page      int
+		Limit     int
+	}
+	mock.lockGetRemittanceReport.RLock()
+	calls = mock.calls.GetRemittanceReport
+	mock.lockGetRemittanceReport.RUnlock()
+	return calls
+}
+
+// IsDeliveryVerified calls IsDeliveryVerifiedFunc.
+func (mock *CODServiceMock) IsDeliveryVerified(ctx context.Context, orderID uuid.UUID) (bool, error) {
+	if mock.IsDeliveryVerifiedFunc == nil {
+		panic("CODServiceMock.IsDeliveryVerifiedFunc: method is nil but CODService.IsDeliveryVerified was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockIsDeliveryVerified.Lock()
+	mock.calls.IsDeliveryVerified = append(mock.calls.IsDeliveryVerified, callInfo)
+	mock.lockIsDeliveryVerified.Unlock()
+	return mock.IsDeliveryVerifiedFunc(ctx, orderID)
+}
+
+// IsDeliveryVerifiedCalls gets all the calls that were made to IsDeliveryVerified.
+// Check the length with:
+//
+//	len(mockedCODService.IsDeliveryVerifiedCalls())
+func (mock *CODServiceMock) IsDeliveryVerifiedCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockIsDeliveryVerified.RLock()
+	calls = mock.calls.IsDeliveryVerified
+	mock.lockIsDeliveryVerified.RUnlock()
+	return calls
+}
+
+// IssueDeliveryOTP calls IssueDeliveryOTPFunc.
+func (mock *CODServiceMock) IssueDeliveryOTP(ctx context.Context, order *models.Order) error {
+	if mock.IssueDeliveryOTPFunc == nil {
+		panic("CODServiceMock.IssueDeliveryOTPFunc: method is nil but CODService.IssueDeliveryOTP was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Order *models.Order
+	}{
+		Ctx:   ctx,
+		Order: order,
+	}
+	mock.lockIssueDeliveryOTP.Lock()
+	mock.calls.IssueDeliveryOTP = append(mock.calls.IssueDeliveryOTP, callInfo)
+	mock.lockIssueDeliveryOTP.Unlock()
+	return mock.IssueDeliveryOTPFunc(ctx, order)
+}
+
+// IssueDeliveryOTPCalls gets all the calls that were made to IssueDeliveryOTP.
+// Check the length with:
+//
+//	len(mockedCODService.IssueDeliveryOTPCalls())
+func (mock *CODServiceMock) IssueDeliveryOTPCalls() []struct {
+	Ctx   context.Context
+	Order *models.Order
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Order *models.Order
+	}
+	mock.lockIssueDeliveryOTP.RLock()
+	calls = mock.calls.IssueDeliveryOTP
+	mock.lockIssueDeliveryOTP.RUnlock()
+	return calls
+}
+
+// MarkRemitted calls MarkRemittedFunc.
+func (mock *CODServiceMock) MarkRemitted(ctx context.Context, req models.RemitCODRequest) (int64, error) {
+	if mock.MarkRemittedFunc == nil {
+		panic("CODServiceMock.MarkRemittedFunc: method is nil but CODService.MarkRemitted was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.RemitCODRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockMarkRemitted.Lock()
+	mock.calls.MarkRemitted = append(mock.calls.MarkRemitted, callInfo)
+	mock.lockMarkRemitted.Unlock()
+	return mock.MarkRemittedFunc(ctx, req)
+}
+
+// MarkRemittedCalls gets all the calls that were made to MarkRemitted.
+// Check the length with:
+//
+//	len(mockedCODService.MarkRemittedCalls())
+func (mock *CODServiceMock) MarkRemittedCalls() []struct {
+	Ctx context.Context
+	Req models.RemitCODRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.RemitCODRequest
+	}
+	mock.lockMarkRemitted.RLock()
+	calls = mock.calls.MarkRemitted
+	mock.lockMarkRemitted.RUnlock()
+	return calls
+}
+
+// RecordCollection calls RecordCollectionFunc.
+func (mock *CODServiceMock) RecordCollection(ctx context.Context, payment *models.Payment) error {
+	if mock.RecordCollectionFunc == nil {
+		panic("CODServiceMock.RecordCollectionFunc: method is nil but CODService.RecordCollection was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payment *models.Payment
+	}{
+		Ctx:     ctx,
+		Payment: payment,
+	}
+	mock.lockRecordCollection.Lock()
+	mock.calls.RecordCollection = append(mock.calls.RecordCollection, callInfo)
+	mock.lockRecordCollection.Unlock()
+	return mock.RecordCollectionFunc(ctx, payment)
+}
+
+// RecordCollectionCalls gets all the calls that were made to RecordCollection.
+// Check the length with:
+//
+//	len(mockedCODService.RecordCollectionCalls())
+func (mock *CODServiceMock) RecordCollectionCalls() []struct {
+	Ctx     context.Context
+	Payment *models.Payment
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payment *models.Payment
+	}
+	mock.lockRecordCollection.RLock()
+	calls = mock.calls.RecordCollection
+	mock.lockRecordCollection.RUnlock()
+	return calls
+}
+
+// ResendDeliveryOTP calls ResendDeliveryOTPFunc.
+func (mock *CODServiceMock) ResendDeliveryOTP(ctx context.Context, orderID uuid.UUID) error {
+	if mock.ResendDeliveryOTPFunc == nil {
+		panic("CODServiceMock.ResendDeliveryOTPFunc: method is nil but CODService.ResendDeliveryOTP was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockResendDeliveryOTP.Lock()
+	mock.calls.ResendDeliveryOTP = append(mock.calls.ResendDeliveryOTP, callInfo)
+	mock.lockResendDeliveryOTP.Unlock()
+	return mock.ResendDeliveryOTPFunc(ctx, orderID)
+}
+
+// ResendDeliveryOTPCalls gets all the calls that were made to ResendDeliveryOTP.
+// Check the length with:
+//
+//	len(mockedCODService.ResendDeliveryOTPCalls())
+func (mock *CODServiceMock) ResendDeliveryOTPCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockResendDeliveryOTP.RLock()
+	calls = mock.calls.ResendDeliveryOTP
+	mock.lockResendDeliveryOTP.RUnlock()
+	return calls
+}
+
+// VerifyDeliveryOTP calls VerifyDeliveryOTPFunc.
+func (mock *CODServiceMock) VerifyDeliveryOTP(ctx context.Context, orderID uuid.UUID, otp string) error {
+	if mock.VerifyDeliveryOTPFunc == nil {
+		panic("CODServiceMock.VerifyDeliveryOTPFunc: method is nil but CODService.VerifyDeliveryOTP was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Otp     string
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		Otp:     otp,
+	}
+	mock.lockVerifyDeliveryOTP.Lock()
+	mock.calls.VerifyDeliveryOTP = append(mock.calls.VerifyDeliveryOTP, callInfo)
+	mock.lockVerifyDeliveryOTP.Unlock()
+	return mock.VerifyDeliveryOTPFunc(ctx, orderID, otp)
+}
+
+// VerifyDeliveryOTPCalls gets all the calls that were made to VerifyDeliveryOTP.
+// Check the length with:
+//
+//	len(mockedCODService.VerifyDeliveryOTPCalls())
+func (mock *CODServiceMock) VerifyDeliveryOTPCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	Otp     string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Otp     string
+	}
+	mock.lockVerifyDeliveryOTP.RLock()
+	calls = mock.calls.VerifyDeliveryOTP
+	mock.lockVerifyDeliveryOTP.RUnlock()
+	return calls
+}
+
+// Ensure, that DeliveryServiceMock does implement service.DeliveryService.
+// If this is not the case, regenerate this file with moq.
+var _ service.DeliveryService = &DeliveryServiceMock{}
+
+// DeliveryServiceMock is a mock implementation of service.DeliveryService.
+//
+//	func TestSomethingThatUsesDeliveryService(t *testing.T) {
+//
+//		// make and configure a mocked service.DeliveryService
+//		mockedDeliveryService := &DeliveryServiceMock{
+//			EstimateCartFunc: func(ctx context.Context, userID uuid.UUID, req models.DeliveryEstimateRequest) (*models.DeliveryEstimateResponse, error) {
+//				panic("mock out the EstimateCart method")
+//			},
+//			EstimateOrderFunc: func(ctx context.Context, order *models.Order) (*models.DeliveryEstimate, error) {
+//				panic("mock out the EstimateOrder method")
+//			},
+//		}
+//
+//		// use mockedDeliveryService in code that requires service.DeliveryService
+//		// and then make assertions.
+//
+//	}
+type DeliveryServiceMock struct {
+	// EstimateCartFunc mocks the EstimateCart method.
+	EstimateCartFunc func(ctx context.Context, userID uuid.UUID, req models.DeliveryEstimateRequest) (*models.DeliveryEstimateResponse, error)
+
+	// EstimateOrderFunc mocks the EstimateOrder method.
+	EstimateOrderFunc func(ctx context.Context, order *models.Order) (*models.DeliveryEstimate, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EstimateCart holds details about calls to the EstimateCart method.
+		EstimateCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.DeliveryEstimateRequest
+		}
+		// EstimateOrder holds details about calls to the EstimateOrder method.
+		EstimateOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Order is the order argument value.
+			Order *models.Order
+		}
+	}
+	lockEstimateCart  sync.RWMutex
+	lockEstimateOrder sync.RWMutex
+}
+
+// EstimateCart calls EstimateCartFunc.
+func (mock *DeliveryServiceMock) EstimateCart(ctx context.Context, userID uuid.UUID, req models.DeliveryEstimateRequest) (*models.DeliveryEstimateResponse, error) {
+	if mock.EstimateCartFunc == nil {
+		panic("DeliveryServiceMock.EstimateCartFunc: method is nil but DeliveryService.EstimateCart was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.DeliveryEstimateRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Req:    req,
+	}
+	mock.lockEstimateCart.Lock()
+	mock.calls.EstimateCart = append(mock.calls.EstimateCart, callInfo)
+	mock.lockEstimateCart.Unlock()
+	return mock.EstimateCartFunc(ctx, userID, req)
+}
+
+// EstimateCartCalls gets all the calls that were made to EstimateCart.
+// Check the length with:
+//
+//	len(mockedDeliveryService.EstimateCartCalls())
+func (mock *DeliveryServiceMock) EstimateCartCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Req    models.DeliveryEstimateRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.DeliveryEstimateRequest
+	}
+	mock.lockEstimateCart.RLock()
+	calls = mock.calls.EstimateCart
+	mock.lockEstimateCart.RUnlock()
+	return calls
+}
+
+// EstimateOrder calls EstimateOrderFunc.
+func (mock *DeliveryServiceMock) EstimateOrder(ctx context.Context, order *models.Order) (*models.DeliveryEstimate, error) {
+	if mock.EstimateOrderFunc == nil {
+		panic("DeliveryServiceMock.EstimateOrderFunc: method is nil but DeliveryService.EstimateOrder was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Order *models.Order
+	}{
+		Ctx:   ctx,
+		Order: order,
+	}
+	mock.lockEstimateOrder.Lock()
+	mock.calls.EstimateOrder = append(mock.calls.EstimateOrder, callInfo)
+	mock.lockEstimateOrder.Unlock()
+	return mock.EstimateOrderFunc(ctx, order)
+}
+
+// EstimateOrderCalls gets all the calls that were made to EstimateOrder.
+// Check the length with:
+//
+//	len(mockedDeliveryService.EstimateOrderCalls())
+func (mock *DeliveryServiceMock) EstimateOrderCalls() []struct {
+	Ctx   context.Context
+	Order *models.Order
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Order *models.Order
+	}
+	mock.lockEstimateOrder.RLock()
+	calls = mock.calls.EstimateOrder
+	mock.lockEstimateOrder.RUnlock()
+	return calls
+}
+
+// Ensure, that ERPSyncServiceMock does implement service.ERPSyncService.
+// If this is not the case, regenerate this file with moq.
+var _ service.ERPSyncService = &ERPSyncServiceMock{}
+
+// ERPSyncServiceMock is a mock implementation of service.ERPSyncService.
+//
+//	func TestSomethingThatUsesERPSyncService(t *testing.T) {
+//
+//		// make and configure a mocked service.ERPSyncService
+//		mockedERPSyncService := &ERPSyncServiceMock{
+//			GetStockMessagesFunc: func(ctx context.Context, filter models.ERPStockMessageFilter, page int, limit int) ([]models.ERPStockMessage, int, error) {
+//				panic("mock out the GetStockMessages method")
+//			},
+//			HandleStockMessageFunc: func(ctx context.Context, body []byte) error {
+//				panic("mock out the HandleStockMessage method")
+//			},
+//			ReplayEventsFunc: func(ctx context.Context, req models.ReplayERPEventsRequest) (*models.ReplayERPEventsResult, error) {
+//				panic("mock out the ReplayEvents method")
+//			},
+//			ReplayStockMessageFunc: func(ctx context.Context, id uuid.UUID) (*models.ERPStockMessage, error) {
+//				panic("mock out the ReplayStockMessage method")
+//			},
+//		}
+//
+//		// use mockedERPSyncService in code that requires service.ERPSyncService
+//		// and then make assertions.
+//
+//	}
+type ERPSyncServiceMock struct {
+	// GetStockMessagesFunc mocks the GetStockMessages method.
+	GetStockMessagesFunc func(ctx context.Context, filter models.ERPStockMessageFilter, page int, limit int) ([]models.ERPStockMessage, int, error)
+
+	// HandleStockMessageFunc mocks the HandleStockMessage method.
+	HandleStockMessageFunc func(ctx context.Context, body []byte) error
+
+	// ReplayEventsFunc mocks the ReplayEvents method.
+	ReplayEventsFunc func(ctx context.Context, req models.ReplayERPEventsRequest) (*models.ReplayERPEventsResult, error)
+
+	// ReplayStockMessageFunc mocks the ReplayStockMessage method.
+	ReplayStockMessageFunc func(ctx context.Context, id uuid.UUID) (*models.ERPStockMessage, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetStockMessages holds details about calls to the GetStockMessages method.
+		GetStockMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.ERPStockMessageFilter
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// HandleStockMessage holds details about calls to the HandleStockMessage method.
+		HandleStockMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Body is the body argument value.
+			Body []byte
+		}
+		// ReplayEvents holds details about calls to the ReplayEvents method.
+		ReplayEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.ReplayERPEventsRequest
+		}
+		// ReplayStockMessage holds details about calls to the ReplayStockMessage method.
+		ReplayStockMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+	}
+	lockGetStockMessages   sync.RWMutex
+	lockHandleStockMessage sync.RWMutex
+	lockReplayEvents       sync.RWMutex
+	lockReplayStockMessage sync.RWMutex
+}
+
+// GetStockMessages calls GetStockMessagesFunc.
+func (mock *ERPSyncServiceMock) GetStockMessages(ctx context.Context, filter models.ERPStockMessageFilter, page int, limit int) ([]models.ERPStockMessage, int, error) {
+	if mock.GetStockMessagesFunc == nil {
+		panic("ERPSyncServiceMock.GetStockMessagesFunc: method is nil but ERPSyncService.GetStockMessages was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.ERPStockMessageFilter
+		Page   int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Page:   page,
+		Limit:  limit,
+	}
+	mock.lockGetStockMessages.Lock()
+	mock.calls.GetStockMessages = append(mock.calls.GetStockMessages, callInfo)
+	mock.lockGetStockMessages.Unlock()
+	return mock.GetStockMessagesFunc(ctx, filter, page, limit)
+}
+
+// GetStockMessagesCalls gets all the calls that were made to GetStockMessages.
+// Check the length with:
+//
+//	len(mockedERPSyncService.GetStockMessagesCalls())
+func (mock *ERPSyncServiceMock) GetStockMessagesCalls() []struct {
+	Ctx    context.Context
+	Filter models.ERPStockMessageFilter
+	Page   int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.ERPStockMessageFilter
+		Page   int
+		Limit  int
+	}
+	mock.lockGetStockMessages.RLock()
+	calls = mock.calls.GetStockMessages
+	mock.lockGetStockMessages.RUnlock()
+	return calls
+}
+
+// HandleStockMessage calls HandleStockMessageFunc.
+func (mock *ERPSyncServiceMock) HandleStockMessage(ctx context.Context, body []byte) error {
+	if mock.HandleStockMessageFunc == nil {
+		panic("ERPSyncServiceMock.HandleStockMessageFunc: method is nil but ERPSyncService.HandleStockMessage was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Body []byte
+	}{
+		Ctx:  ctx,
+		Body: body,
+	}
+	mock.lockHandleStockMessage.Lock()
+	mock.calls.HandleStockMessage = append(mock.calls.HandleStockMessage, callInfo)
+	mock.lockHandleStockMessage.Unlock()
+	return mock.HandleStockMessageFunc(ctx, body)
+}
+
+// HandleStockMessageCalls gets all the calls that were made to HandleStockMessage.
+// Check the length with:
+//
+//	len(mockedERPSyncService.HandleStockMessageCalls())
+func (mock *ERPSyncServiceMock) HandleStockMessageCalls() []struct {
+	Ctx  context.Context
+	Body []byte
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Body []byte
+	}
+	mock.lockHandleStockMessage.RLock()
+	calls = mock.calls.HandleStockMessage
+	mock.lockHandleStockMessage.RUnlock()
+	return calls
+}
+
+// ReplayEvents calls ReplayEventsFunc.
+func (mock *ERPSyncServiceMock) ReplayEvents(ctx context.Context, req models.ReplayERPEventsRequest) (*models.ReplayERPEventsResult, error) {
+	if mock.ReplayEventsFunc == nil {
+		panic("ERPSyncServiceMock.ReplayEventsFunc: method is nil but ERPSyncService.ReplayEvents was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.ReplayERPEventsRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockReplayEvents.Lock()
+	mock.calls.ReplayEvents = append(mock.calls.ReplayEvents, callInfo)
+	mock.lockReplayEvents.Unlock()
+	return mock.ReplayEventsFunc(ctx, req)
+}
+
+// ReplayEventsCalls gets all the calls that were made to ReplayEvents.
+// Check the length with:
+//
+//	len(mockedERPSyncService.ReplayEventsCalls())
+func (mock *ERPSyncServiceMock) ReplayEventsCalls() []struct {
+	Ctx context.Context
+	Req models.ReplayERPEventsRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.ReplayERPEventsRequest
+	}
+	mock.lockReplayEvents.RLock()
+	calls = mock.calls.ReplayEvents
+	mock.lockReplayEvents.RUnlock()
+	return calls
+}
+
+// ReplayStockMessage calls ReplayStockMessageFunc.
+func (mock *ERPSyncServiceMock) ReplayStockMessage(ctx context.Context, id uuid.UUID) (*models.ERPStockMessage, error) {
+	if mock.ReplayStockMessageFunc == nil {
+		panic("ERPSyncServiceMock.ReplayStockMessageFunc: method is nil but ERPSyncService.ReplayStockMessage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockReplayStockMessage.Lock()
+	mock.calls.ReplayStockMessage = append(mock.calls.ReplayStockMessage, callInfo)
+	mock.lockReplayStockMessage.Unlock()
+	return mock.ReplayStockMessageFunc(ctx, id)
+}
+
+// ReplayStockMessageCalls gets all the calls that were made to ReplayStockMessage.
+// Check the length with:
+//
+//	len(mockedERPSyncService.ReplayStockMessageCalls())
+func (mock *ERPSyncServiceMock) ReplayStockMessageCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockReplayStockMessage.RLock()
+	calls = mock.calls.ReplayStockMessage
+	mock.lockReplayStockMessage.RUnlock()
+	return calls
+}
+
+// Ensure, that FlagServiceMock does implement service.FlagService.
+// If this is not the case, regenerate this file with moq.
+var _ service.FlagService = &FlagServiceMock{}
+
+// FlagServiceMock is a mock implementation of service.FlagService.
+//
+//	func TestSomethingThatUsesFlagService(t *testing.T) {
+//
+//		// make and configure a mocked service.FlagService
+//		mockedFlagService := &FlagServiceMock{
+//			CreateFlagFunc: func(ctx context.Context, req models.CreateFeatureFlagRequest) (*models.FeatureFlag, error) {
+//				panic("mock out the CreateFlag method")
+//			},
+//			DeleteFlagFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the DeleteFlag method")
+//			},
+//			GetFlagFunc: func(ctx context.Context, key string) (*models.FeatureFlag, error) {
+//				panic("mock out the GetFlag method")
+//			},
+//			GetFlagsFunc: func(ctx context.Context) ([]models.FeatureFlag, error) {
+//				panic("mock out the GetFlags method")
+//			},
+//			IsEnabledFunc: func(ctx context.Context, key string, subject string) bool {
+//				panic("mock out the IsEnabled method")
+//			},
+//			UpdateFlagFunc: func(ctx context.Context, key string, req models.FeatureFlagRequest) (*models.FeatureFlag, error) {
+//				panic("mock out the UpdateFlag method")
+//			},
+//		}
+//
+//		// use mockedFlagService in code that requires service.FlagService
+//		// and then make assertions.
+//
+//	}
+type FlagServiceMock struct {
+	// CreateFlagFunc mocks the CreateFlag method.
+	CreateFlagFunc func(ctx context.Context, req models.CreateFeatureFlagRequest) (*models.FeatureFlag, error)
+
+	// DeleteFlagFunc mocks the DeleteFlag method.
+	DeleteFlagFunc func(ctx context.Context, key string) error
+
+	// GetFlagFunc mocks the GetFlag method.
+	GetFlagFunc func(ctx context.Context, key string) (*models.FeatureFlag, error)
+
+	// GetFlagsFunc mocks the GetFlags method.
+	GetFlagsFunc func(ctx context.Context) ([]models.FeatureFlag, error)
+
+	// IsEnabledFunc mocks the IsEnabled method.
+	IsEnabledFunc func(ctx context.Context, key string, subject string) bool
+
+	// UpdateFlagFunc mocks the UpdateFlag method.
+	UpdateFlagFunc func(ctx context.Context, key string, req models.FeatureFlagRequest) (*models.FeatureFlag, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateFlag holds details about calls to the CreateFlag method.
+		CreateFlag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CreateFeatureFlagRequest
+		}
+		// DeleteFlag holds details about calls to the DeleteFlag method.
+		DeleteFlag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// GetFlag holds details about calls to the GetFlag method.
+		GetFlag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// GetFlags holds details about calls to the GetFlags method.
+		GetFlags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// IsEnabled holds details about calls to the IsEnabled method.
+		IsEnabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Subject is the subject argument value.
+			Subject string
+		}
+		// UpdateFlag holds details about calls to the UpdateFlag method.
+		UpdateFlag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Req is the req argument value.
+			Req models.FeatureFlagRequest
+		}
+	}
+	lockCreateFlag sync.RWMutex
+	lockDeleteFlag sync.RWMutex
+	lockGetFlag    sync.RWMutex
+	lockGetFlags   sync.RWMutex
+	lockIsEnabled  sync.RWMutex
+	lockUpdateFlag sync.RWMutex
+}
+
+// CreateFlag calls CreateFlagFunc.
+func (mock *FlagServiceMock) CreateFlag(ctx context.Context, req models.CreateFeatureFlagRequest) (*models.FeatureFlag, error) {
+	if mock.CreateFlagFunc == nil {
+		panic("FlagServiceMock.CreateFlagFunc: method is nil but FlagService.CreateFlag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.CreateFeatureFlagRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateFlag.Lock()
+	mock.calls.CreateFlag = append(mock.calls.CreateFlag, callInfo)
+	mock.lockCreateFlag.Unlock()
+	return mock.CreateFlagFunc(ctx, req)
+}
+
+// CreateFlagCalls gets all the calls that were made to CreateFlag.
+// Check the length with:
+//
+//	len(mockedFlagService.CreateFlagCalls())
+func (mock *FlagServiceMock) CreateFlagCalls() []struct {
+	Ctx context.Context
+	Req models.CreateFeatureFlagRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.CreateFeatureFlagRequest
+	}
+	mock.lockCreateFlag.RLock()
+	calls = mock.calls.CreateFlag
+	mock.lockCreateFlag.RUnlock()
+	return calls
+}
+
+// DeleteFlag calls DeleteFlagFunc.
+func (mock *FlagServiceMock) DeleteFlag(ctx context.Context, key string) error {
+	if mock.DeleteFlagFunc == nil {
+		panic("FlagServiceMock.DeleteFlagFunc: method is nil but FlagService.DeleteFlag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDeleteFlag.Lock()
+	mock.calls.DeleteFlag = append(mock.calls.DeleteFlag, callInfo)
+	mock.lockDeleteFlag.Unlock()
+	return mock.DeleteFlagFunc(ctx, key)
+}
+
+// DeleteFlagCalls gets all the calls that were made to DeleteFlag.
+// Check the length with:
+//
+//	len(mockedFlagService.DeleteFlagCalls())
+func (mock *FlagServiceMock) DeleteFlagCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDeleteFlag.RLock()
+	calls = mock.calls.DeleteFlag
+	mock.lockDeleteFlag.RUnlock()
+	return calls
+}
+
+// GetFlag calls GetFlagFunc.
+func (mock *FlagServiceMock) GetFlag(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	if mock.GetFlagFunc == nil {
+		panic("FlagServiceMock.GetFlagFunc: method is nil but FlagService.GetFlag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetFlag.Lock()
+	mock.calls.GetFlag = append(mock.calls.GetFlag, callInfo)
+	mock.lockGetFlag.Unlock()
+	return mock.GetFlagFunc(ctx, key)
+}
+
+// GetFlagCalls gets all the calls that were made to GetFlag.
+// Check the length with:
+//
+//	len(mockedFlagService.GetFlagCalls())
+func (mock *FlagServiceMock) GetFlagCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetFlag.RLock()
+	calls = mock.calls.GetFlag
+	mock.lockGetFlag.RUnlock()
+	return calls
+}
+
+// GetFlags calls GetFlagsFunc.
+func (mock *FlagServiceMock) GetFlags(ctx context.Context) ([]models.FeatureFlag, error) {
+	if mock.GetFlagsFunc == nil {
+		panic("FlagServiceMock.GetFlagsFunc: method is nil but FlagService.GetFlags was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetFlags.Lock()
+	mock.calls.GetFlags = append(mock.calls.GetFlags, callInfo)
+	mock.lockGetFlags.Unlock()
+	return mock.GetFlagsFunc(ctx)
+}
+
+// GetFlagsCalls gets all the calls that were made to GetFlags.
+// Check the length with:
+//
+//	len(mockedFlagService.GetFlagsCalls())
+func (mock *FlagServiceMock) GetFlagsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetFlags.RLock()
+	calls = mock.calls.GetFlags
+	mock.lockGetFlags.RUnlock()
+	return calls
+}
+
+// IsEnabled calls IsEnabledFunc.
+func (mock *FlagServiceMock) IsEnabled(ctx context.Context, key string, subject string) bool {
+	if mock.IsEnabledFunc == nil {
+		panic("FlagServiceMock.IsEnabledFunc: method is nil but FlagService.IsEnabled was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Key     string
+		Subject string
+	}{
+		Ctx:     ctx,
+		Key:     key,
+		Subject: subject,
+	}
+	mock.lockIsEnabled.Lock()
+	mock.calls.IsEnabled = append(mock.calls.IsEnabled, callInfo)
+	mock.lockIsEnabled.Unlock()
+	return mock.IsEnabledFunc(ctx, key, subject)
+}
+
+// IsEnabledCalls gets all the calls that were made to IsEnabled.
+// Check the length with:
+//
+//	len(mockedFlagService.IsEnabledCalls())
+func (mock *FlagServiceMock) IsEnabledCalls() []struct {
+	Ctx     context.Context
+	Key     string
+	Subject string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Key     string
+		Subject string
+	}
+	mock.lockIsEnabled.RLock()
+	calls = mock.calls.IsEnabled
+	mock.lockIsEnabled.RUnlock()
+	return calls
+}
+
+// UpdateFlag calls UpdateFlagFunc.
+func (mock *FlagServiceMock) UpdateFlag(ctx context.Context, key string, req models.FeatureFlagRequest) (*models.FeatureFlag, error) {
+	if mock.UpdateFlagFunc == nil {
+		panic("FlagServiceMock.UpdateFlagFunc: method is nil but FlagService.UpdateFlag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+		Req models.FeatureFlagRequest
+	}{
+		Ctx: ctx,
+		Key: key,
+		Req: req,
+	}
+	mock.lockUpdateFlag.Lock()
+	mock.calls.UpdateFlag = append(mock.calls.UpdateFlag, callInfo)
+	mock.lockUpdateFlag.Unlock()
+	return mock.UpdateFlagFunc(ctx, key, req)
+}
+
+// UpdateFlagCalls gets all the calls that were made to UpdateFlag.
+// Check the length with:
+//
+//	len(mockedFlagService.UpdateFlagCalls())
+func (mock *FlagServiceMock) UpdateFlagCalls() []struct {
+	Ctx context.Context
+	Key string
+	Req models.FeatureFlagRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+		Req models.FeatureFlagRequest
+	}
+	mock.lockUpdateFlag.RLock()
+	calls = mock.calls.UpdateFlag
+	mock.lockUpdateFlag.RUnlock()
+	return calls
+}
+
+// Ensure, that FraudServiceMock does implement service.FraudService.
+// If this is not the case, regenerate this file with moq.
+var _ service.FraudService = &FraudServiceMock{}
+
+// FraudServiceMock is a mock implementation of service.FraudService.
+//
+//	func TestSomethingThatUsesFraudService(t *testing.T) {
+//
+//		// make and configure a mocked service.FraudService
+//		mockedFraudService := &FraudServiceMock{
+//			GetReviewQueueFunc: func(ctx context.Context, page int, limit int) ([]models.ReviewQueueItem, int, error) {
+//				panic("mock out the GetReviewQueue method")
+//			},
+//			RecordDecisionFunc: func(ctx context.Context, orderID uuid.UUID, decision string, note string, reviewerID uuid.UUID) error {
+//				panic("mock out the RecordDecision method")
+//			},
+//			SaveAssessmentFunc: func(ctx context.Context, assessment *models.RiskAssessment) error {
+//				panic("mock out the SaveAssessment method")
+//			},
+//			ScreenFunc: func(ctx context.Context, check models.FraudCheck) (*models.RiskAssessment, error) {
+//				panic("mock out the Screen method")
+//			},
+//		}
+//
+//		// use mockedFraudService in code that requires service.FraudService
+//		// and then make assertions.
+//
+//	}
+type FraudServiceMock struct {
+	// GetReviewQueueFunc mocks the GetReviewQueue method.
+	GetReviewQueueFunc func(ctx context.Context, page int, limit int) ([]models.ReviewQueueItem, int, error)
+
+	// RecordDecisionFunc mocks the RecordDecision method.
+	RecordDecisionFunc func(ctx context.Context, orderID uuid.UUID, decision string, note string, reviewerID uuid.UUID) error
+
+	// SaveAssessmentFunc mocks the SaveAssessment method.
+	SaveAssessmentFunc func(ctx context.Context, assessment *models.RiskAssessment) error
+
+	// ScreenFunc mocks the Screen method.
+	ScreenFunc func(ctx context.Context, check models.FraudCheck) (*models.RiskAssessment, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetReviewQueue holds details about calls to the GetReviewQueue method.
+		GetReviewQueue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// RecordDecision holds details about calls to the RecordDecision method.
+		RecordDecision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Decision is the decision argument value.
+			Decision string
+			// Note is the note argument value.
+			Note string
+			// ReviewerID is the reviewerID argument value.
+			ReviewerID uuid.UUID
+		}
+		// SaveAssessment holds details about calls to the SaveAssessment method.
+		SaveAssessment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Assessment is the assessment argument value.
+			Assessment *models.RiskAssessment
+		}
+		// Screen holds details about calls to the Screen method.
+		Screen []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Check is the check argument value.
+			Check models.FraudCheck
+		}
+	}
+	lockGetReviewQueue sync.RWMutex
+	lockRecordDecision sync.RWMutex
+	lockSaveAssessment sync.RWMutex
+	lockScreen         sync.RWMutex
+}
+
+// GetReviewQueue calls GetReviewQueueFunc.
+func (mock *FraudServiceMock) GetReviewQueue(ctx context.Context, page int, limit int) ([]models.ReviewQueueItem, int, error) {
+	if mock.GetReviewQueueFunc == nil {
+		panic("FraudServiceMock.GetReviewQueueFunc: method is nil but FraudService.GetReviewQueue was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}{
+		Ctx:   ctx,
+		Page:  page,
+		Limit: limit,
+	}
+	mock.lockGetReviewQueue.Lock()
+	mock.calls.GetReviewQueue = append(mock.calls.GetReviewQueue, callInfo)
+	mock.lockGetReviewQueue.Unlock()
+	return mock.GetReviewQueueFunc(ctx, page, limit)
+}
+
+// GetReviewQueueCalls gets all the calls that were made to GetReviewQueue.
+// Check the length with:
+//
+//	len(mockedFraudService.GetReviewQueueCalls())
+func (mock *FraudServiceMock) GetReviewQueueCalls() []struct {
+	Ctx   context.Context
+	Page  int
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}
+	mock.lockGetReviewQueue.RLock()
+	calls = mock.calls.GetReviewQueue
+	mock.lockGetReviewQueue.RUnlock()
+	return calls
+}
+
+// RecordDecision calls RecordDecisionFunc.
+func (mock *FraudServiceMock) RecordDecision(ctx context.Context, orderID uuid.UUID, decision string, note string, reviewerID uuid.UUID) error {
+	if mock.RecordDecisionFunc == nil {
+		panic("FraudServiceMock.RecordDecisionFunc: method is nil but FraudService.RecordDecision was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		OrderID    uuid.UUID
+		Decision   string
+		Note       string
+		ReviewerID uuid.UUID
+	}{
+		Ctx:        ctx,
+		OrderID:    orderID,
+		Decision:   decision,
+		Note:       note,
+		ReviewerID: reviewerID,
+	}
+	mock.lockRecordDecision.Lock()
+	mock.calls.RecordDecision = append(mock.calls.RecordDecision, callInfo)
+	mock.lockRecordDecision.Unlock()
+	return mock.RecordDecisionFunc(ctx, orderID, decision, note, reviewerID)
+}
+
+// RecordDecisionCalls gets all the calls that were made to RecordDecision.
+// Check the length with:
+//
+//	len(mockedFraudService.RecordDecisionCalls())
+func (mock *FraudServiceMock) RecordDecisionCalls() []struct {
+	Ctx        context.Context
+	OrderID    uuid.UUID
+	Decision   string
+	Note       string
+	ReviewerID uuid.UUID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		OrderID    uuid.UUID
+		Decision   string
+		Note       string
+		ReviewerID uuid.UUID
+	}
+	mock.lockRecordDecision.RLock()
+	calls = mock.calls.RecordDecision
+	mock.lockRecordDecision.RUnlock()
+	return calls
+}
+
+// SaveAssessment calls SaveAssessmentFunc.
+func (mock *FraudServiceMock) SaveAssessment(ctx context.Context, assessment *models.RiskAssessment) error {
+	if mock.SaveAssessmentFunc == nil {
+		panic("FraudServiceMock.SaveAssessmentFunc: method is nil but FraudService.SaveAssessment was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Assessment *models.RiskAssessment
+	}{
+		Ctx:        ctx,
+		Assessment: assessment,
+	}
+	mock.lockSaveAssessment.Lock()
+	mock.calls.SaveAssessment = append(mock.calls.SaveAssessment, callInfo)
+	mock.lockSaveAssessment.Unlock()
+	return mock.SaveAssessmentFunc(ctx, assessment)
+}
+
+// SaveAssessmentCalls gets all the calls that were made to SaveAssessment.
+// Check the length with:
+//
+//	len(mockedFraudService.SaveAssessmentCalls())
+func (mock *FraudServiceMock) SaveAssessmentCalls() []struct {
+	Ctx        context.Context
+	Assessment *models.RiskAssessment
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Assessment *models.RiskAssessment
+	}
+	mock.lockSaveAssessment.RLock()
+	calls = mock.calls.SaveAssessment
+	mock.lockSaveAssessment.RUnlock()
+	return calls
+}
+
+// Screen calls ScreenFunc.
+func (mock *FraudServiceMock) Screen(ctx context.Context, check models.FraudCheck) (*models.RiskAssessment, error) {
+	if mock.ScreenFunc == nil {
+		panic("FraudServiceMock.ScreenFunc: method is nil but FraudService.Screen was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Check models.FraudCheck
+	}{
+		Ctx:   ctx,
+		Check: check,
+	}
+	mock.lockScreen.Lock()
+	mock.calls.Screen = append(mock.calls.Screen, callInfo)
+	mock.lockScreen.Unlock()
+	return mock.ScreenFunc(ctx, check)
+}
+
+// ScreenCalls gets all the calls that were made to Screen.
+// Check the length with:
+//
+//	len(mockedFraudService.ScreenCalls())
+func (mock *FraudServiceMock) ScreenCalls() []struct {
+	Ctx   context.Context
+	Check models.FraudCheck
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Check models.FraudCheck
+	}
+	mock.lockScreen.RLock()
+	calls = mock.calls.Screen
+	mock.lockScreen.RUnlock()
+	return calls
+}
+
+// Ensure, that GiftCardServiceMock does implement service.GiftCardService.
+// If this is not the case, regenerate this file with moq.
+var _ service.GiftCardService = &GiftCardServiceMock{}
+
+// GiftCardServiceMock is a mock implementation of service.GiftCardService.
+//
+//	func TestSomethingThatUsesGiftCardService(t *testing.T) {
+//
+//		// make and configure a mocked service.GiftCardService
+//		mockedGiftCardService := &GiftCardServiceMock{
+//			CheckBalanceFunc: func(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCardBalance, error) {
+//				panic("mock out the CheckBalance method")
+//			},
+//			CreditStoreCreditFunc: func(ctx context.Context, userID uuid.UUID, amount money.Money, orderID *uuid.UUID, returnID *uuid.UUID) error {
+//				panic("mock out the CreditStoreCredit method")
+//			},
+//			GetGiftCardFunc: func(ctx context.Context, id uuid.UUID) (*models.GiftCardDetail, error) {
+//				panic("mock out the GetGiftCard method")
+//			},
+//			GetGiftCardsFunc: func(ctx context.Context, page int, limit int) ([]models.GiftCard, int, error) {
+//				panic("mock out the GetGiftCards method")
+//			},
+//			GetStoreCreditFunc: func(ctx context.Context, userID uuid.UUID) (*models.GiftCardDetail, error) {
+//				panic("mock out the GetStoreCredit method")
+//			},
+//			IssueGiftCardFunc: func(ctx context.Context, req models.IssueGiftCardRequest) (*models.GiftCard, error) {
+//				panic("mock out the IssueGiftCard method")
+//			},
+//			RedeemForOrderFunc: func(ctx context.Context, order *models.Order, code string, useStoreCredit bool) (money.Money, error) {
+//				panic("mock out the RedeemForOrder method")
+//			},
+//			RestoreForOrderFunc: func(ctx context.Context, orderID uuid.UUID, amount money.Money, returnID *uuid.UUID) (money.Money, error) {
+//				panic("mock out the RestoreForOrder method")
+//			},
+//		}
+//
+//		// use mockedGiftCardService in code that requires service.GiftCardService
+//		// and then make assertions.
+//
+//	}
+type GiftCardServiceMock struct {
+	// CheckBalanceFunc mocks the CheckBalance method.
+	CheckBalanceFunc func(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCardBalance, error)
+
+	// CreditStoreCreditFunc mocks the CreditStoreCredit method.
+	CreditStoreCreditFunc func(ctx context.Context, userID uuid.UUID, amount money.Money, orderID *uuid.UUID, returnID *uuid.UUID) error
+
+	// GetGiftCardFunc mocks the GetGiftCard method.
+	GetGiftCardFunc func(ctx context.Context, id uuid.UUID) (*models.GiftCardDetail, error)
+
+	// GetGiftCardsFunc mocks the GetGiftCards method.
+	GetGiftCardsFunc func(ctx context.Context, page int, limit int) ([]models.GiftCard, int, error)
+
+	// GetStoreCreditFunc mocks the GetStoreCredit method.
+	GetStoreCreditFunc func(ctx context.Context, userID uuid.UUID) (*models.GiftCardDetail, error)
+
+	// IssueGiftCardFunc mocks the IssueGiftCard method.
+	IssueGiftCardFunc func(ctx context.Context, req models.IssueGiftCardRequest) (*models.GiftCard, error)
+
+	// RedeemForOrderFunc mocks the RedeemForOrder method.
+	RedeemForOrderFunc func(ctx context.Context, order *models.Order, code string, useStoreCredit bool) (money.Money, error)
+
+	// RestoreForOrderFunc mocks the RestoreForOrder method.
+	RestoreForOrderFunc func(ctx context.Context, orderID uuid.UUID, amount money.Money, returnID *uuid.UUID) (money.Money, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CheckBalance holds details about calls to the CheckBalance method.
+		CheckBalance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+		}
+		// CreditStoreCredit holds details about calls to the CreditStoreCredit method.
+		CreditStoreCredit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Amount is the amount argument value.
+			Amount money.Money
+			// OrderID is the orderID argument value.
+			OrderID *uuid.UUID
+			// ReturnID is the returnID argument value.
+			ReturnID *uuid.UUID
+		}
+		// GetGiftCard holds details about calls to the GetGiftCard method.
+		GetGiftCard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetGiftCards holds details about calls to the GetGiftCards method.
+		GetGiftCards []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetStoreCredit holds details about calls to the GetStoreCredit method.
+		GetStoreCredit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// IssueGiftCard holds details about calls to the IssueGiftCard method.
+		IssueGiftCard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.IssueGiftCardRequest
+		}
+		// RedeemForOrder holds details about calls to the RedeemForOrder method.
+		RedeemForOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Order is the order argument value.
+			Order *models.Order
+			// Code is the code argument value.
+			Code string
+			// UseStoreCredit is the useStoreCredit argument value.
+			UseStoreCredit bool
+		}
+		// RestoreForOrder holds details about calls to the RestoreForOrder method.
+		RestoreForOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Amount is the amount argument value.
+			Amount money.Money
+			// ReturnID is the returnID argument value.
+			ReturnID *uuid.UUID
+		}
+	}
+	lockCheckBalance      sync.RWMutex
+	lockCreditStoreCredit sync.RWMutex
+	lockGetGiftCard       sync.RWMutex
+	lockGetGiftCards      sync.RWMutex
+	lockGetStoreCredit    sync.RWMutex
+	lockIssueGiftCard     sync.RWMutex
+	lockRedeemForOrder    sync.RWMutex
+	lockRestoreForOrder   sync.RWMutex
+}
+
+// CheckBalance calls CheckBalanceFunc.
+func (mock *GiftCardServiceMock) CheckBalance(ctx context.Context, userID uuid.UUID, code string) (*models.GiftCardBalance, error) {
+	if mock.CheckBalanceFunc == nil {
+		panic("GiftCardServiceMock.CheckBalanceFunc: method is nil but GiftCardService.CheckBalance was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockCheckBalance.Lock()
+	mock.calls.CheckBalance = append(mock.calls.CheckBalance, callInfo)
+	mock.lockCheckBalance.Unlock()
+	return mock.CheckBalanceFunc(ctx, userID, code)
+}
+
+// CheckBalanceCalls gets all the calls that were made to CheckBalance.
+// Check the length with:
+//
+//	len(mockedGiftCardService.CheckBalanceCalls())
+func (mock *GiftCardServiceMock) CheckBalanceCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}
+	mock.lockCheckBalance.RLock()
+	calls = mock.calls.CheckBalance
+	mock.lockCheckBalance.RUnlock()
+	return calls
+}
+
+// CreditStoreCredit calls CreditStoreCreditFunc.
+func (mock *GiftCardServiceMock) CreditStoreCredit(ctx context.Context, userID uuid.UUID, amount money.Money, orderID *uuid.UUID, returnID *uuid.UUID) error {
+	if mock.CreditStoreCreditFunc == nil {
+		panic("GiftCardServiceMock.CreditStoreCreditFunc: method is nil but GiftCardService.CreditStoreCredit was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Amount   money.Money
+		OrderID  *uuid.UUID
+		ReturnID *uuid.UUID
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Amount:   amount,
+		OrderID:  orderID,
+		ReturnID: returnID,
+	}
+	mock.lockCreditStoreCredit.Lock()
+	mock.calls.CreditStoreCredit = append(mock.calls.CreditStoreCredit, callInfo)
+	mock.lockCreditStoreCredit.Unlock()
+	return mock.CreditStoreCreditFunc(ctx, userID, amount, orderID, returnID)
+}
+
+// CreditStoreCreditCalls gets all the calls that were made to CreditStoreCredit.
+// Check the length with:
+//
+//	len(mockedGiftCardService.CreditStoreCreditCalls())
+func (mock *GiftCardServiceMock) CreditStoreCreditCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	Amount   money.Money
+	OrderID  *uuid.UUID
+	ReturnID *uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Amount   money.Money
+		OrderID  *uuid.UUID
+		ReturnID *uuid.UUID
+	}
+	mock.lockCreditStoreCredit.RLock()
+	calls = mock.calls.CreditStoreCredit
+	mock.lockCreditStoreCredit.RUnlock()
+	return calls
+}
+
+// GetGiftCard calls GetGiftCardFunc.
+func (mock *GiftCardServiceMock) GetGiftCard(ctx context.Context, id uuid.UUID) (*models.GiftCardDetail, error) {
+	if mock.GetGiftCardFunc == nil {
+		panic("GiftCardServiceMock.GetGiftCardFunc: method is nil but GiftCardService.GetGiftCard was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetGiftCard.Lock()
+	mock.calls.GetGiftCard = append(mock.calls.GetGiftCard, callInfo)
+	mock.lockGetGiftCard.Unlock()
+	return mock.GetGiftCardFunc(ctx, id)
+}
+
+// GetGiftCardCalls gets all the calls that were made to GetGiftCard.
+// Check the length with:
+//
+//	len(mockedGiftCardService.GetGiftCardCalls())
+func (mock *GiftCardServiceMock) GetGiftCardCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetGiftCard.RLock()
+	calls = mock.calls.GetGiftCard
+	mock.lockGetGiftCard.RUnlock()
+	return calls
+}
+
+// GetGiftCards calls GetGiftCardsFunc.
+func (mock *GiftCardServiceMock) GetGiftCards(ctx context.Context, page int, limit int) ([]models.GiftCard, int, error) {
+	if mock.GetGiftCardsFunc == nil {
+		panic("GiftCardServiceMock.GetGiftCardsFunc: method is nil but GiftCardService.GetGiftCards was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}{
+		Ctx:   ctx,
+		Page:  page,
+		Limit: limit,
+	}
+	mock.lockGetGiftCards.Lock()
+	mock.calls.GetGiftCards = append(mock.calls.GetGiftCards, callInfo)
+	mock.lockGetGiftCards.Unlock()
+	return mock.GetGiftCardsFunc(ctx, page, limit)
+}
+
+// GetGiftCardsCalls gets all the calls that were made to GetGiftCards.
+// Check the length with:
+//
+//	len(mockedGiftCardService.GetGiftCardsCalls())
+func (mock *GiftCardServiceMock) GetGiftCardsCalls() []struct {
+	Ctx   context.Context
+	Page  int
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}
+	mock.lockGetGiftCards.RLock()
+	calls = mock.calls.GetGiftCards
+	mock.lockGetGiftCards.RUnlock()
+	return calls
+}
+
+// GetStoreCredit calls GetStoreCreditFunc.
+func (mock *GiftCardServiceMock) GetStoreCredit(ctx context.Context, userID uuid.UUID) (*models.GiftCardDetail, error) {
+	if mock.GetStoreCreditFunc == nil {
+		panic("GiftCardServiceMock.GetStoreCreditFunc: method is nil but GiftCardService.GetStoreCredit was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetStoreCredit.Lock()
+	mock.calls.GetStoreCredit = append(mock.calls.GetStoreCredit, callInfo)
+	mock.lockGetStoreCredit.Unlock()
+	return mock.GetStoreCreditFunc(ctx, userID)
+}
+
+// GetStoreCreditCalls gets all the calls that were made to GetStoreCredit.
+// Check the length with:
+//
+//	len(mockedGiftCardService.GetStoreCreditCalls())
+func (mock *GiftCardServiceMock) GetStoreCreditCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetStoreCredit.RLock()
+	calls = mock.calls.GetStoreCredit
+	mock.lockGetStoreCredit.RUnlock()
+	return calls
+}
+
+// IssueGiftCard calls IssueGiftCardFunc.
+func (mock *GiftCardServiceMock) IssueGiftCard(ctx context.Context, req models.IssueGiftCardRequest) (*models.GiftCard, error) {
+	if mock.IssueGiftCardFunc == nil {
+		panic("GiftCardServiceMock.IssueGiftCardFunc: method is nil but GiftCardService.IssueGiftCard was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.IssueGiftCardRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockIssueGiftCard.Lock()
+	mock.calls.IssueGiftCard = append(mock.calls.IssueGiftCard, callInfo)
+	mock.lockIssueGiftCard.Unlock()
+	return mock.IssueGiftCardFunc(ctx, req)
+}
+
+// IssueGiftCardCalls gets all the calls that were made to IssueGiftCard.
+// Check the length with:
+//
+//	len(mockedGiftCardService.IssueGiftCardCalls())
+func (mock *GiftCardServiceMock) IssueGiftCardCalls() []struct {
+	Ctx context.Context
+	Req models.IssueGiftCardRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.IssueGiftCardRequest
+	}
+	mock.lockIssueGiftCard.RLock()
+	calls = mock.calls.IssueGiftCard
+	mock.lockIssueGiftCard.RUnlock()
+	return calls
+}
+
+// RedeemForOrder calls RedeemForOrderFunc.
+func (mock *GiftCardServiceMock) RedeemForOrder(ctx context.Context, order *models.Order, code string, useStoreCredit bool) (money.Money, error) {
+	if mock.RedeemForOrderFunc == nil {
+		panic("GiftCardServiceMock.RedeemForOrderFunc: method is nil but GiftCardService.RedeemForOrder was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Order          *models.Order
+		Code           string
+		UseStoreCredit bool
+	}{
+		Ctx:            ctx,
+		Order:          order,
+		Code:           code,
+		UseStoreCredit: useStoreCredit,
+	}
+	mock.lockRedeemForOrder.Lock()
+	mock.calls.RedeemForOrder = append(mock.calls.RedeemForOrder, callInfo)
+	mock.lockRedeemForOrder.Unlock()
+	return mock.RedeemForOrderFunc(ctx, order, code, useStoreCredit)
+}
+
+// RedeemForOrderCalls gets all the calls that were made to RedeemForOrder.
+// Check the length with:
+//
+//	len(mockedGiftCardService.RedeemForOrderCalls())
+func (mock *GiftCardServiceMock) RedeemForOrderCalls() []struct {
+	Ctx            context.Context
+	Order          *models.Order
+	Code           string
+	UseStoreCredit bool
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Order          *models.Order
+		Code           string
+		UseStoreCredit bool
+	}
+	mock.lockRedeemForOrder.RLock()
+	calls = mock.calls.RedeemForOrder
+	mock.lockRedeemForOrder.RUnlock()
+	return calls
+}
+
+// RestoreForOrder calls RestoreForOrderFunc.
+func (mock *GiftCardServiceMock) RestoreForOrder(ctx context.Context, orderID uuid.UUID, amount money.Money, returnID *uuid.UUID) (money.Money, error) {
+	if mock.RestoreForOrderFunc == nil {
+		panic("GiftCardServiceMock.RestoreForOrderFunc: method is nil but GiftCardService.RestoreForOrder was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		Amount   money.Money
+		ReturnID *uuid.UUID
+	}{
+		Ctx:      ctx,
+		OrderID:  orderID,
+		Amount:   amount,
+		ReturnID: returnID,
+	}
+	mock.lockRestoreForOrder.Lock()
+	mock.calls.RestoreForOrder = append(mock.calls.RestoreForOrder, callInfo)
+	mock.lockRestoreForOrder.Unlock()
+	return mock.RestoreForOrderFunc(ctx, orderID, amount, returnID)
+}
+
+// RestoreForOrderCalls gets all the calls that were made to RestoreForOrder.
+// Check the length with:
+//
+//	len(mockedGiftCardService.RestoreForOrderCalls())
+func (mock *GiftCardServiceMock) RestoreForOrderCalls() []struct {
+	Ctx      context.Context
+	OrderID  uuid.UUID
+	Amount   money.Money
+	ReturnID *uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		Amount   money.Money
+		ReturnID *uuid.UUID
+	}
+	mock.lockRestoreForOrder.RLock()
+	calls = mock.calls.RestoreForOrder
+	mock.lockRestoreForOrder.RUnlock()
+	return calls
+}
+
+// Ensure, that NotificationServiceMock does implement service.NotificationService.
+// If this is not the case, regenerate this file with moq.
+var _ service.NotificationService = &NotificationServiceMock{}
+
+// NotificationServiceMock is a mock implementation of service.NotificationService.
+//
+//	func TestSomethingThatUsesNotificationService(t *testing.T) {
+//
+//		// make and configure a mocked service.NotificationService
+//		mockedNotificationService := &NotificationServiceMock{
+//			GetUserNotificationsFunc: func(ctx context.Context, userID uuid.UUID, page int, limit int, unreadOnly bool) ([]models.Notification, int, int, error) {
+//				panic("mock out the GetUserNotifications method")
+//			},
+//			MarkAsReadFunc: func(ctx context.Context, notificationID uuid.UUID, userID uuid.UUID) (*models.Notification, error) {
+//				panic("mock out the MarkAsRead method")
+//			},
+//			NotifyFunc: func(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title string, message string, data map[string]interface{}) error {
+//				panic("mock out the Notify method")
+//			},
+//		}
+//
+//		// use mockedNotificationService in code that requires service.NotificationService
+//		// and then make assertions.
+//
+//	}
+type NotificationServiceMock struct {
+	// GetUserNotificationsFunc mocks the GetUserNotifications method.
+	GetUserNotificationsFunc func(ctx context.Context, userID uuid.UUID, page int, limit int, unreadOnly bool) ([]models.Notification, int, int, error)
+
+	// MarkAsReadFunc mocks the MarkAsRead method.
+	MarkAsReadFunc func(ctx context.Context, notificationID uuid.UUID, userID uuid.UUID) (*models.Notification, error)
+
+	// NotifyFunc mocks the Notify method.
+	NotifyFunc func(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title string, message string, data map[string]interface{}) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserNotifications holds details about calls to the GetUserNotifications method.
+		GetUserNotifications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+			// UnreadOnly is the unreadOnly argument value.
+			UnreadOnly bool
+		}
+		// MarkAsRead holds details about calls to the MarkAsRead method.
+		MarkAsRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// NotificationID is the notificationID argument value.
+			NotificationID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// Notify holds details about calls to the Notify method.
+		Notify []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// NotificationType is the notificationType argument value.
+			NotificationType models.NotificationType
+			// Title is the title argument value.
+			Title string
+			// Message is the message argument value.
+			Message string
+			// Data is the data argument value.
+			Data map[string]interface{}
+		}
+	}
+	lockGetUserNotifications sync.RWMutex
+	lockMarkAsRead           sync.RWMutex
+	lockNotify               sync.RWMutex
+}
+
+// GetUserNotifications calls GetUserNotificationsFunc.
+func (mock *NotificationServiceMock) GetUserNotifications(ctx context.Context, userID uuid.UUID, page int, limit int, unreadOnly bool) ([]models.Notification, int, int, error) {
+	if mock.GetUserNotificationsFunc == nil {
+		panic("NotificationServiceMock.GetUserNotificationsFunc: method is nil but NotificationService.GetUserNotifications was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		Page       int
+		Limit      int
+		UnreadOnly bool
+	}{
+		Ctx:        ctx,
+		UserID:     userID,
+		Page:       page,
+		Limit:      limit,
+		UnreadOnly: unreadOnly,
+	}
+	mock.lockGetUserNotifications.Lock()
+	mock.calls.GetUserNotifications = append(mock.calls.GetUserNotifications, callInfo)
+	mock.lockGetUserNotifications.Unlock()
+	return mock.GetUserNotificationsFunc(ctx, userID, page, limit, unreadOnly)
+}
+
+// GetUserNotificationsCalls gets all the calls that were made to GetUserNotifications.
+// Check the length with:
+//
+//	len(mockedNotificationService.GetUserNotificationsCalls())
+func (mock *NotificationServiceMock) GetUserNotificationsCalls() []struct {
+	Ctx        context.Context
+	UserID     uuid.UUID
+	Page       int
+	Limit      int
+	UnreadOnly bool
+} {
+	var calls []struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		Page       int
+		Limit      int
+		UnreadOnly bool
+	}
+	mock.lockGetUserNotifications.RLock()
+	calls = mock.calls.GetUserNotifications
+	mock.lockGetUserNotifications.RUnlock()
+	return calls
+}
+
+// MarkAsRead calls MarkAsReadFunc.
+func (mock *NotificationServiceMock) MarkAsRead(ctx context.Context, notificationID uuid.UUID, userID uuid.UUID) (*models.Notification, error) {
+	if mock.MarkAsReadFunc == nil {
+		panic("NotificationServiceMock.MarkAsReadFunc: method is nil but NotificationService.MarkAsRead was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		NotificationID uuid.UUID
+		UserID         uuid.UUID
+	}{
+		Ctx:            ctx,
+		NotificationID: notificationID,
+		UserID:         userID,
+	}
+	mock.lockMarkAsRead.Lock()
+	mock.calls.MarkAsRead = append(mock.calls.MarkAsRead, callInfo)
+	mock.lockMarkAsRead.Unlock()
+	return mock.MarkAsReadFunc(ctx, notificationID, userID)
+}
+
+// MarkAsReadCalls gets all the calls that were made to MarkAsRead.
+// Check the length with:
+//
+//	len(mockedNotificationService.MarkAsReadCalls())
+func (mock *NotificationServiceMock) MarkAsReadCalls() []struct {
+	Ctx            context.Context
+	NotificationID uuid.UUID
+	UserID         uuid.UUID
+} {
+	var calls []struct {
+		Ctx            context.Context
+		NotificationID uuid.UUID
+		UserID         uuid.UUID
+	}
+	mock.lockMarkAsRead.RLock()
+	calls = mock.calls.MarkAsRead
+	mock.lockMarkAsRead.RUnlock()
+	return calls
+}
+
+// Notify calls NotifyFunc.
+func (mock *NotificationServiceMock) Notify(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title string, message string, data map[string]interface{}) error {
+	if mock.NotifyFunc == nil {
+		panic("NotificationServiceMock.NotifyFunc: method is nil but NotificationService.Notify was just called")
+	}
+	callInfo := struct {
+		Ctx              context.Context
+		UserID           uuid.UUID
+		NotificationType models.NotificationType
+		Title            string
+		Message          string
+		Data             map[string]interface{}
+	}{
+		Ctx:              ctx,
+		UserID:           userID,
+		NotificationType: notificationType,
+		Title:            title,
+		Message:          message,
+		Data:             data,
+	}
+	mock.lockNotify.Lock()
+	mock.calls.Notify = append(mock.calls.Notify, callInfo)
+	mock.lockNotify.Unlock()
+	return mock.NotifyFunc(ctx, userID, notificationType, title, message, data)
+}
+
+// NotifyCalls gets all the calls that were made to Notify.
+// Check the length with:
+//
+//	len(mockedNotificationService.NotifyCalls())
+func (mock *NotificationServiceMock) NotifyCalls() []struct {
+	Ctx              context.Context
+	UserID           uuid.UUID
+	NotificationType models.NotificationType
+	Title            string
+	Message          string
+	Data             map[string]interface{}
+} {
+	var calls []struct {
+		Ctx              context.Context
+		UserID           uuid.UUID
+		NotificationType models.NotificationType
+		Title            string
+		Message          string
+		Data             map[string]interface{}
+	}
+	mock.lockNotify.RLock()
+	calls = mock.calls.Notify
+	mock.lockNotify.RUnlock()
+	return calls
+}
+
+// Ensure, that OrderImportServiceMock does implement service.OrderImportService.
+// If this is not the case, regenerate this file with moq.
+var _ service.OrderImportService = &OrderImportServiceMock{}
+
+// OrderImportServiceMock is a mock implementation of service.OrderImportService.
+//
+//	func TestSomethingThatUsesOrderImportService(t *testing.T) {
+//
+//		// make and configure a mocked service.OrderImportService
+//		mockedOrderImportService := &OrderImportServiceMock{
+//			ImportOrdersFunc: func(ctx context.Context, orders []models.ImportOrder) (*models.OrderImportResult, error) {
+//				panic("mock out the ImportOrders method")
+//			},
+//			ImportOrdersCSVFunc: func(ctx context.Context, src io.Reader) (*models.OrderImportResult, error) {
+//				panic("mock out the ImportOrdersCSV method")
+//			},
+//		}
+//
+//		// use mockedOrderImportService in code that requires service.OrderImportService
+//		// and then make assertions.
+//
+//	}
+type OrderImportServiceMock struct {
+	// ImportOrdersFunc mocks the ImportOrders method.
+	ImportOrdersFunc func(ctx context.Context, orders []models.ImportOrder) (*models.OrderImportResult, error)
+
+	// ImportOrdersCSVFunc mocks the ImportOrdersCSV method.
+	ImportOrdersCSVFunc func(ctx context.Context, src io.Reader) (*models.OrderImportResult, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ImportOrders holds details about calls to the ImportOrders method.
+		ImportOrders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Orders is the orders argument value.
+			Orders []models.ImportOrder
+		}
+		// ImportOrdersCSV holds details about calls to the ImportOrdersCSV method.
+		ImportOrdersCSV []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Src is the src argument value.
+			Src io.Reader
+		}
+	}
+	lockImportOrders    sync.RWMutex
+	lockImportOrdersCSV sync.RWMutex
+}
+
+// ImportOrders calls ImportOrdersFunc.
+func (mock *OrderImportServiceMock) ImportOrders(ctx context.Context, orders []models.ImportOrder) (*models.OrderImportResult, error) {
+	if mock.ImportOrdersFunc == nil {
+		panic("OrderImportServiceMock.ImportOrdersFunc: method is nil but OrderImportService.ImportOrders was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Orders []models.ImportOrder
+	}{
+		Ctx:    ctx,
+		Orders: orders,
+	}
+	mock.lockImportOrders.Lock()
+	mock.calls.ImportOrders = append(mock.calls.ImportOrders, callInfo)
+	mock.lockImportOrders.Unlock()
+	return mock.ImportOrdersFunc(ctx, orders)
+}
+
+// ImportOrdersCalls gets all the calls that were made to ImportOrders.
+// Check the length with:
+//
+//	len(mockedOrderImportService.ImportOrdersCalls())
+func (mock *OrderImportServiceMock) ImportOrdersCalls() []struct {
+	Ctx    context.Context
+	Orders []models.ImportOrder
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Orders []models.ImportOrder
+	}
+	mock.lockImportOrders.RLock()
+	calls = mock.calls.ImportOrders
+	mock.lockImportOrders.RUnlock()
+	return calls
+}
+
+// ImportOrdersCSV calls ImportOrdersCSVFunc.
+func (mock *OrderImportServiceMock) ImportOrdersCSV(ctx context.Context, src io.Reader) (*models.OrderImportResult, error) {
+	if mock.ImportOrdersCSVFunc == nil {
+		panic("OrderImportServiceMock.ImportOrdersCSVFunc: method is nil but OrderImportService.ImportOrdersCSV was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Src io.Reader
+	}{
+		Ctx: ctx,
+		Src: src,
+	}
+	mock.lockImportOrdersCSV.Lock()
+	mock.calls.ImportOrdersCSV = append(mock.calls.ImportOrdersCSV, callInfo)
+	mock.lockImportOrdersCSV.Unlock()
+	return mock.ImportOrdersCSVFunc(ctx, src)
+}
+
+// ImportOrdersCSVCalls gets all the calls that were made to ImportOrdersCSV.
+// Check the length with:
+//
+//	len(mockedOrderImportService.ImportOrdersCSVCalls())
+func (mock *OrderImportServiceMock) ImportOrdersCSVCalls() []struct {
+	Ctx context.Context
+	Src io.Reader
+} {
+	var calls []struct {
+		Ctx context.Context
+		Src io.Reader
+	}
+	mock.lockImportOrdersCSV.RLock()
+	calls = mock.calls.ImportOrdersCSV
+	mock.lockImportOrdersCSV.RUnlock()
+	return calls
+}
+
+// Ensure, that OrderMessageServiceMock does implement service.OrderMessageService.
+// If this is not the case, regenerate this file with moq.
+var _ service.OrderMessageService = &OrderMessageServiceMock{}
+
+// OrderMessageServiceMock is a mock implementation of service.OrderMessageService.
+//
+//	func TestSomethingThatUsesOrderMessageService(t *testing.T) {
+//
+//		// make and configure a mocked service.OrderMessageService
+//		mockedOrderMessageService := &OrderMessageServiceMock{
+//			GetSupportQueueFunc: func(ctx context.Context) ([]models.UnreadOrderMessages, error) {
+//				panic("mock out the GetSupportQueue method")
+//			},
+//			GetThreadFunc: func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, markRead bool) (*models.OrderMessageThread, error) {
+//				panic("mock out the GetThread method")
+//			},
+//			GetThreadAdminFunc: func(ctx context.Context, orderID uuid.UUID) (*models.OrderMessageThread, error) {
+//				panic("mock out the GetThreadAdmin method")
+//			},
+//			GetUnreadFunc: func(ctx context.Context, userID uuid.UUID) ([]models.UnreadOrderMessages, error) {
+//				panic("mock out the GetUnread method")
+//			},
+//			PostMessageFunc: func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, body string) (*models.OrderMessage, error) {
+//				panic("mock out the PostMessage method")
+//			},
+//			ReplyFunc: func(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, body string) (*models.OrderMessage, error) {
+//				panic("mock out the Reply method")
+//			},
+//		}
+//
+//		// use mockedOrderMessageService in code that requires service.OrderMessageService
+//		// and then make assertions.
+//
+//	}
+type OrderMessageServiceMock struct {
+	// GetSupportQueueFunc mocks the GetSupportQueue method.
+	GetSupportQueueFunc func(ctx context.Context) ([]models.UnreadOrderMessages, error)
+
+	// GetThreadFunc mocks the GetThread method.
+	GetThreadFunc func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, markRead bool) (*models.OrderMessageThread, error)
+
+	// GetThreadAdminFunc mocks the GetThreadAdmin method.
+	GetThreadAdminFunc func(ctx context.Context, orderID uuid.UUID) (*models.OrderMessageThread, error)
+
+	// GetUnreadFunc mocks the GetUnread method.
+	GetUnreadFunc func(ctx context.Context, userID uuid.UUID) ([]models.UnreadOrderMessages, error)
+
+	// PostMessageFunc mocks the PostMessage method.
+	PostMessageFunc func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, body string) (*models.OrderMessage, error)
+
+	// ReplyFunc mocks the Reply method.
+	ReplyFunc func(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, body string) (*models.OrderMessage, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetSupportQueue holds details about calls to the GetSupportQueue method.
+		GetSupportQueue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetThread holds details about calls to the GetThread method.
+		GetThread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// MarkRead is the markRead argument value.
+			MarkRead bool
+		}
+		// GetThreadAdmin holds details about calls to the GetThreadAdmin method.
+		GetThreadAdmin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// GetUnread holds details about calls to the GetUnread method.
+		GetUnread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// PostMessage holds details about calls to the PostMessage method.
+		PostMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Body is the body argument value.
+			Body string
+		}
+		// Reply holds details about calls to the Reply method.
+		Reply []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// AdminID is the adminID argument value.
+			AdminID uuid.UUID
+			// Body is the body argument value.
+			Body string
+		}
+	}
+	lockGetSupportQueue sync.RWMutex
+	lockGetThread       sync.RWMutex
+	lockGetThreadAdmin  sync.RWMutex
+	lockGetUnread       sync.RWMutex
+	lockPostMessage     sync.RWMutex
+	lockReply           sync.RWMutex
+}
+
+// GetSupportQueue calls GetSupportQueueFunc.
+func (mock *OrderMessageServiceMock) GetSupportQueue(ctx context.Context) ([]models.UnreadOrderMessages, error) {
+	if mock.GetSupportQueueFunc == nil {
+		panic("OrderMessageServiceMock.GetSupportQueueFunc: method is nil but OrderMessageService.GetSupportQueue was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetSupportQueue.Lock()
+	mock.calls.GetSupportQueue = append(mock.calls.GetSupportQueue, callInfo)
+	mock.lockGetSupportQueue.Unlock()
+	return mock.GetSupportQueueFunc(ctx)
+}
+
+// GetSupportQueueCalls gets all the calls that were made to GetSupportQueue.
+// Check the length with:
+//
+//	len(mockedOrderMessageService.GetSupportQueueCalls())
+func (mock *OrderMessageServiceMock) GetSupportQueueCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetSupportQueue.RLock()
+	calls = mock.calls.GetSupportQueue
+	mock.lockGetSupportQueue.RUnlock()
+	return calls
+}
+
+// GetThread calls GetThreadFunc.
+func (mock *OrderMessageServiceMock) GetThread(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, markRead bool) (*models.OrderMessageThread, error) {
+	if mock.GetThreadFunc == nil {
+		panic("OrderMessageServiceMock.GetThreadFunc: method is nil but OrderMessageService.GetThread was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		UserID   uuid.UUID
+		MarkRead bool
+	}{
+		Ctx:      ctx,
+		OrderID:  orderID,
+		UserID:   userID,
+		MarkRead: markRead,
+	}
+	mock.lockGetThread.Lock()
+	mock.calls.GetThread = append(mock.calls.GetThread, callInfo)
+	mock.lockGetThread.Unlock()
+	return mock.GetThreadFunc(ctx, orderID, userID, markRead)
+}
+
+// GetThreadCalls gets all the calls that were made to GetThread.
+// Check the length with:
+//
+//	len(mockedOrderMessageService.GetThreadCalls())
+func (mock *OrderMessageServiceMock) GetThreadCalls() []struct {
+	Ctx      context.Context
+	OrderID  uuid.UUID
+	UserID   uuid.UUID
+	MarkRead bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		UserID   uuid.UUID
+		MarkRead bool
+	}
+	mock.lockGetThread.RLock()
+	calls = mock.calls.GetThread
+	mock.lockGetThread.RUnlock()
+	return calls
+}
+
+// GetThreadAdmin calls GetThreadAdminFunc.
+func (mock *OrderMessageServiceMock) GetThreadAdmin(ctx context.Context, orderID uuid.UUID) (*models.OrderMessageThread, error) {
+	if mock.GetThreadAdminFunc == nil {
+		panic("OrderMessageServiceMock.GetThreadAdminFunc: method is nil but OrderMessageService.GetThreadAdmin was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockGetThreadAdmin.Lock()
+	mock.calls.GetThreadAdmin = append(mock.calls.GetThreadAdmin, callInfo)
+	mock.lockGetThreadAdmin.Unlock()
+	return mock.GetThreadAdminFunc(ctx, orderID)
+}
+
+// GetThreadAdminCalls gets all the calls that were made to GetThreadAdmin.
+// Check the length with:
+//
+//	len(mockedOrderMessageService.GetThreadAdminCalls())
+func (mock *OrderMessageServiceMock) GetThreadAdminCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockGetThreadAdmin.RLock()
+	calls = mock.calls.GetThreadAdmin
+	mock.lockGetThreadAdmin.RUnlock()
+	return calls
+}
+
+// GetUnread calls GetUnreadFunc.
+func (mock *OrderMessageServiceMock) GetUnread(ctx context.Context, userID uuid.UUID) ([]models.UnreadOrderMessages, error) {
+	if mock.GetUnreadFunc == nil {
+		panic("OrderMessageServiceMock.GetUnreadFunc: method is nil but OrderMessageService.GetUnread was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetUnread.Lock()
+	mock.calls.GetUnread = append(mock.calls.GetUnread, callInfo)
+	mock.lockGetUnread.Unlock()
+	return mock.GetUnreadFunc(ctx, userID)
+}
+
+// GetUnreadCalls gets all the calls that were made to GetUnread.
+// Check the length with:
+//
+//	len(mockedOrderMessageService.GetUnreadCalls())
+func (mock *OrderMessageServiceMock) GetUnreadCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetUnread.RLock()
+	calls = mock.calls.GetUnread
+	mock.lockGetUnread.RUnlock()
+	return calls
+}
+
+// PostMessage calls PostMessageFunc.
+func (mock *OrderMessageServiceMock) PostMessage(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, body string) (*models.OrderMessage, error) {
+	if mock.PostMessageFunc == nil {
+		panic("OrderMessageServiceMock.PostMessageFunc: method is nil but OrderMessageService.PostMessage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+		Body    string
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		UserID:  userID,
+		Body:    body,
+	}
+	mock.lockPostMessage.Lock()
+	mock.calls.PostMessage = append(mock.calls.PostMessage, callInfo)
+	mock.lockPostMessage.Unlock()
+	return mock.PostMessageFunc(ctx, orderID, userID, body)
+}
+
+// PostMessageCalls gets all the calls that were made to PostMessage.
+// Check the length with:
+//
+//	len(mockedOrderMessageService.PostMessageCalls())
+func (mock *OrderMessageServiceMock) PostMessageCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	UserID  uuid.UUID
+	Body    string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+		Body    string
+	}
+	mock.lockPostMessage.RLock()
+	calls = mock.calls.PostMessage
+	mock.lockPostMessage.RUnlock()
+	return calls
+}
+
+// Reply calls ReplyFunc.
+func (mock *OrderMessageServiceMock) Reply(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, body string) (*models.OrderMessage, error) {
+	if mock.ReplyFunc == nil {
+		panic("OrderMessageServiceMock.ReplyFunc: method is nil but OrderMessageService.Reply was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		AdminID uuid.UUID
+		Body    string
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		AdminID: adminID,
+		Body:    body,
+	}
+	mock.lockReply.Lock()
+	mock.calls.Reply = append(mock.calls.Reply, callInfo)
+	mock.lockReply.Unlock()
+	return mock.ReplyFunc(ctx, orderID, adminID, body)
+}
+
+// ReplyCalls gets all the calls that were made to Reply.
+// Check the length with:
+//
+//	len(mockedOrderMessageService.ReplyCalls())
+func (mock *OrderMessageServiceMock) ReplyCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	AdminID uuid.UUID
+	Body    string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		AdminID uuid.UUID
+		Body    string
+	}
+	mock.lockReply.RLock()
+	calls = mock.calls.Reply
+	mock.lockReply.RUnlock()
+	return calls
+}
+
+// Ensure, that OrderServiceMock does implement service.OrderService.
+// If this is not the case, regenerate this file with moq.
+var _ service.OrderService = &OrderServiceMock{}
+
+// OrderServiceMock is a mock implementation of service.OrderService.
+//
+//	func TestSomethingThatUsesOrderService(t *testing.T) {
+//
+//		// make and configure a mocked service.OrderService
+//		mockedOrderService := &OrderServiceMock{
+//			BulkUpdateOrderStatusFunc: func(ctx context.Context, req models.BulkUpdateOrderStatusRequest) (*models.BulkUpdateOrderStatusResponse, error) {
+//				panic("mock out the BulkUpdateOrderStatus method")
+//			},
+//			CancelOrderFunc: func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) error {
+//				panic("mock out the CancelOrder method")
+//			},
+//			CancelUnpaidOrdersFunc: func(ctx context.Context) (int, error) {
+//				panic("mock out the CancelUnpaidOrders method")
+//			},
+//			ConfirmCODDeliveryFunc: func(ctx context.Context, orderID uuid.UUID, otp string) error {
+//				panic("mock out the ConfirmCODDelivery method")
+//			},
+//			CreateOrderFunc: func(ctx context.Context, userID uuid.UUID, req models.CreateOrderRequest) (*models.Order, error) {
+//				panic("mock out the CreateOrder method")
+//			},
+//			CreateReplacementOrderFunc: func(ctx context.Context, original *models.Order, item models.OrderItem, variantID uuid.UUID) (*models.Order, error) {
+//				panic("mock out the CreateReplacementOrder method")
+//			},
+//			ExpireCheckoutAttemptsFunc: func(ctx context.Context) (int, error) {
+//				panic("mock out the ExpireCheckoutAttempts method")
+//			},
+//			ExportOrdersFunc: func(ctx context.Context, filter models.OrderFilter, fn func(models.OrderExportRow) error) error {
+//				panic("mock out the ExportOrders method")
+//			},
+//			GetActiveHoldsFunc: func(ctx context.Context, status models.OrderStatus, page int, limit int) ([]models.OrderHold, int, error) {
+//				panic("mock out the GetActiveHolds method")
+//			},
+//			GetAllOrdersFunc: func(ctx context.Context, page int, limit int, filter models.OrderFilter) ([]models.AdminOrder, int, error) {
+//				panic("mock out the GetAllOrders method")
+//			},
+//			GetAnalyticsFunc: func(ctx context.Context, rangeDays int) (*models.AdminAnalytics, error) {
+//				panic("mock out the GetAnalytics method")
+//			},
+//			GetCustomerAnalyticsFunc: func(ctx context.Context, rangeDays int, topLimit int) (*models.CustomerAnalytics, error) {
+//				panic("mock out the GetCustomerAnalytics method")
+//			},
+//			GetMarginReportFunc: func(ctx context.Context, rangeDays int, period string) (*models.MarginReport, error) {
+//				panic("mock out the GetMarginReport method")
+//			},
+//			GetOrderFunc: func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) (*models.Order, error) {
+//				panic("mock out the GetOrder method")
+//			},
+//			GetOrderAdminFunc: func(ctx context.Context, orderID uuid.UUID) (*models.AdminOrder, error) {
+//				panic("mock out the GetOrderAdmin method")
+//			},
+//			GetOrderHoldsFunc: func(ctx context.Context, orderID uuid.UUID) ([]models.OrderHold, error) {
+//				panic("mock out the GetOrderHolds method")
+//			},
+//			GetPackingSlipFunc: func(ctx context.Context, orderID uuid.UUID) (*models.PackingSlip, error) {
+//				panic("mock out the GetPackingSlip method")
+//			},
+//			GetPickListFunc: func(ctx context.Context, filter models.PickListFilter) (*models.PickList, error) {
+//				panic("mock out the GetPickList method")
+//			},
+//			GetPromotionAnalyticsFunc: func(ctx context.Context, rangeDays int) (*models.PromotionAnalytics, error) {
+//				panic("mock out the GetPromotionAnalytics method")
+//			},
+//			GetRecentOrdersFunc: func(ctx context.Context, limit int, rangeDays int) ([]models.AdminOrder, error) {
+//				panic("mock out the GetRecentOrders method")
+//			},
+//			GetReviewQueueFunc: func(ctx context.Context, page int, limit int) ([]models.ReviewQueueItem, int, error) {
+//				panic("mock out the GetReviewQueue method")
+//			},
+//			GetUserOrdersFunc: func(ctx context.Context, userID uuid.UUID, page int, limit int, includeItems bool) ([]models.Order, int, error) {
+//				panic("mock out the GetUserOrders method")
+//			},
+//			GetUserOrdersPageFunc: func(ctx context.Context, userID uuid.UUID, after *pagination.Cursor, limit int, includeItems bool) ([]models.Order, *pagination.Cursor, error) {
+//				panic("mock out the GetUserOrdersPage method")
+//			},
+//			PlaceHoldFunc: func(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, req models.PlaceOrderHoldRequest) (*models.OrderHold, error) {
+//				panic("mock out the PlaceHold method")
+//			},
+//			ProcessOrderReturnFunc: func(ctx context.Context, orderID uuid.UUID, returnID uuid.UUID) error {
+//				panic("mock out the ProcessOrderReturn method")
+//			},
+//			RegisterFunc: func(bus *events.Bus)  {
+//				panic("mock out the Register method")
+//			},
+//			ReleaseHoldFunc: func(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, req models.ReleaseOrderHoldRequest) (*models.Order, error) {
+//				panic("mock out the ReleaseHold method")
+//			},
+//			ReviewOrderFunc: func(ctx context.Context, orderID uuid.UUID, reviewerID uuid.UUID, req models.ReviewOrderRequest) (*models.Order, error) {
+//				panic("mock out the ReviewOrder method")
+//			},
+//			UpdateItemFulfillmentFunc: func(ctx context.Context, orderID uuid.UUID, itemID uuid.UUID, req models.UpdateItemFulfillmentRequest) (*models.Order, error) {
+//				panic("mock out the UpdateItemFulfillment method")
+//			},
+//			UpdateOrderStatusFunc: func(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error {
+//				panic("mock out the UpdateOrderStatus method")
+//			},
+//		}
+//
+//		// use mockedOrderService in code that requires service.OrderService
+//		// and then make assertions.
+//
+//	}
+type OrderServiceMock struct {
+	// BulkUpdateOrderStatusFunc mocks the BulkUpdateOrderStatus method.
+	BulkUpdateOrderStatusFunc func(ctx context.Context, req models.BulkUpdateOrderStatusRequest) (*models.BulkUpdateOrderStatusResponse, error)
+
+	// CancelOrderFunc mocks the CancelOrder method.
+	CancelOrderFunc func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) error
+
+	// CancelUnpaidOrdersFunc mocks the CancelUnpaidOrders method.
+	CancelUnpaidOrdersFunc func(ctx context.Context) (int, error)
+
+	// ConfirmCODDeliveryFunc mocks the ConfirmCODDelivery method.
+	ConfirmCODDeliveryFunc func(ctx context.Context, orderID uuid.UUID, otp string) error
+
+	// CreateOrderFunc mocks the CreateOrder method.
+	CreateOrderFunc func(ctx context.Context, userID uuid.UUID, req models.CreateOrderRequest) (*models.Order, error)
+
+	// CreateReplacementOrderFunc mocks the CreateReplacementOrder method.
+	CreateReplacementOrderFunc func(ctx context.Context, original *models.Order, item models.OrderItem, variantID uuid.UUID) (*models.Order, error)
+
+	// ExpireCheckoutAttemptsFunc mocks the ExpireCheckoutAttempts method.
+	ExpireCheckoutAttemptsFunc func(ctx context.Context) (int, error)
+
+	// ExportOrdersFunc mocks the ExportOrders method.
+	ExportOrdersFunc func(ctx context.Context, filter models.OrderFilter, fn func(models.OrderExportRow) error) error
+
+	// GetActiveHoldsFunc mocks the GetActiveHolds method.
+	GetActiveHoldsFunc func(ctx context.Context, status models.OrderStatus, page int, limit int) ([]models.OrderHold, int, error)
+
+	// GetAllOrdersFunc mocks the GetAllOrders method.
+	GetAllOrdersFunc func(ctx context.Context, page int, limit int, filter models.OrderFilter) ([]models.AdminOrder, int, error)
+
+	// GetAnalyticsFunc mocks the GetAnalytics method.
+	GetAnalyticsFunc func(ctx context.Context, rangeDays int) (*models.AdminAnalytics, error)
+
+	// GetCustomerAnalyticsFunc mocks the GetCustomerAnalytics method.
+	GetCustomerAnalyticsFunc func(ctx context.Context, rangeDays int, topLimit int) (*models.CustomerAnalytics, error)
+
+	// GetMarginReportFunc mocks the GetMarginReport method.
+	GetMarginReportFunc func(ctx context.Context, rangeDays int, period string) (*models.MarginReport, error)
+
+	// GetOrderFunc mocks the GetOrder method.
+	GetOrderFunc func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) (*models.Order, error)
+
+	// GetOrderAdminFunc mocks the GetOrderAdmin method.
+	GetOrderAdminFunc func(ctx context.Context, orderID uuid.UUID) (*models.AdminOrder, error)
+
+	// GetOrderHoldsFunc mocks the GetOrderHolds method.
+	GetOrderHoldsFunc func(ctx context.Context, orderID uuid.UUID) ([]models.OrderHold, error)
+
+	// GetPackingSlipFunc mocks the GetPackingSlip method.
+	GetPackingSlipFunc func(ctx context.Context, orderID uuid.UUID) (*models.PackingSlip, error)
+
+	// GetPickListFunc mocks the GetPickList method.
+	GetPickListFunc func(ctx context.Context, filter models.PickListFilter) (*models.PickList, error)
+
+	// GetPromotionAnalyticsFunc mocks the GetPromotionAnalytics method.
+	GetPromotionAnalyticsFunc func(ctx context.Context, rangeDays int) (*models.PromotionAnalytics, error)
+
+	// GetRecentOrdersFunc mocks the GetRecentOrders method.
+	GetRecentOrdersFunc func(ctx context.Context, limit int, rangeDays int) ([]models.AdminOrder, error)
+
+	// GetReviewQueueFunc mocks the GetReviewQueue method.
+	GetReviewQueueFunc func(ctx context.Context, page int, limit int) ([]models.ReviewQueueItem, int, error)
+
+	// GetUserOrdersFunc mocks the GetUserOrders method.
+	GetUserOrdersFunc func(ctx context.Context, userID uuid.UUID, page int, limit int, includeItems bool) ([]models.Order, int, error)
+
+	// GetUserOrdersPageFunc mocks the GetUserOrdersPage method.
+	GetUserOrdersPageFunc func(ctx context.Context, userID uuid.UUID, after *pagination.Cursor, limit int, includeItems bool) ([]models.Order, *pagination.Cursor, error)
+
+	// PlaceHoldFunc mocks the PlaceHold method.
+	PlaceHoldFunc func(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, req models.PlaceOrderHoldRequest) (*models.OrderHold, error)
+
+	// ProcessOrderReturnFunc mocks the ProcessOrderReturn method.
+	ProcessOrderReturnFunc func(ctx context.Context, orderID uuid.UUID, returnID uuid.UUID) error
+
+	// RegisterFunc mocks the Register method.
+	RegisterFunc func(bus *events.Bus)
+
+	// ReleaseHoldFunc mocks the ReleaseHold method.
+	ReleaseHoldFunc func(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, req models.ReleaseOrderHoldRequest) (*models.Order, error)
+
+	// ReviewOrderFunc mocks the ReviewOrder method.
+	ReviewOrderFunc func(ctx context.Context, orderID uuid.UUID, reviewerID uuid.UUID, req models.ReviewOrderRequest) (*models.Order, error)
+
+	// UpdateItemFulfillmentFunc mocks the UpdateItemFulfillment method.
+	UpdateItemFulfillmentFunc func(ctx context.Context, orderID uuid.UUID, itemID uuid.UUID, req models.UpdateItemFulfillmentRequest) (*models.Order, error)
+
+	// UpdateOrderStatusFunc mocks the UpdateOrderStatus method.
+	UpdateOrderStatusFunc func(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// BulkUpdateOrderStatus holds details about calls to the BulkUpdateOrderStatus method.
+		BulkUpdateOrderStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.BulkUpdateOrderStatusRequest
+		}
+		// CancelOrder holds details about calls to the CancelOrder method.
+		CancelOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// CancelUnpaidOrders holds details about calls to the CancelUnpaidOrders method.
+		CancelUnpaidOrders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ConfirmCODDelivery holds details about calls to the ConfirmCODDelivery method.
+		ConfirmCODDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Otp is the otp argument value.
+			Otp string
+		}
+		// CreateOrder holds details about calls to the CreateOrder method.
+		CreateOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.CreateOrderRequest
+		}
+		// CreateReplacementOrder holds details about calls to the CreateReplacementOrder method.
+		CreateReplacementOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Original is the original argument value.
+			Original *models.Order
+			// Item is the item argument value.
+			Item models.OrderItem
+			// VariantID is the variantID argument value.
+			VariantID uuid.UUID
+		}
+		// ExpireCheckoutAttempts holds details about calls to the ExpireCheckoutAttempts method.
+		ExpireCheckoutAttempts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ExportOrders holds details about calls to the ExportOrders method.
+		ExportOrders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.OrderFilter
+			// Fn is the fn argument value.
+			Fn func(models.OrderExportRow) error
+		}
+		// GetActiveHolds holds details about calls to the GetActiveHolds method.
+		GetActiveHolds []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Status is the status argument value.
+			Status models.OrderStatus
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetAllOrders holds details about calls to the GetAllOrders method.
+		GetAllOrders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+			// Filter is the filter argument value.
+			Filter models.OrderFilter
+		}
+		// GetAnalytics holds details about calls to the GetAnalytics method.
+		GetAnalytics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+		}
+		// GetCustomerAnalytics holds details about calls to the GetCustomerAnalytics method.
+		GetCustomerAnalytics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+			// TopLimit is the topLimit argument value.
+			TopLimit int
+		}
+		// GetMarginReport holds details about calls to the GetMarginReport method.
+		GetMarginReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+			// Period is the period argument value.
+			Period string
+		}
+		// GetOrder holds details about calls to the GetOrder method.
+		GetOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// GetOrderAdmin holds details about calls to the GetOrderAdmin method.
+		GetOrderAdmin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// GetOrderHolds holds details about calls to the GetOrderHolds method.
+		GetOrderHolds []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// GetPackingSlip holds details about calls to the GetPackingSlip method.
+		GetPackingSlip []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// GetPickList holds details about calls to the GetPickList method.
+		GetPickList []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.PickListFilter
+		}
+		// GetPromotionAnalytics holds details about calls to the GetPromotionAnalytics method.
+		GetPromotionAnalytics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+		}
+		// GetRecentOrders holds details about calls to the GetRecentOrders method.
+		GetRecentOrders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+		}
+		// GetReviewQueue holds details about calls to the GetReviewQueue method.
+		GetReviewQueue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetUserOrders holds details about calls to the GetUserOrders method.
+		GetUserOrders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+			// IncludeItems is the includeItems argument value.
+			IncludeItems bool
+		}
+		// GetUserOrdersPage holds details about calls to the GetUserOrdersPage method.
+		GetUserOrdersPage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// After is the after argument value.
+			After *pagination.Cursor
+			// Limit is the limit argument value.
+			Limit int
+			// IncludeItems is the includeItems argument value.
+			IncludeItems bool
+		}
+		// PlaceHold holds details about calls to the PlaceHold method.
+		PlaceHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// AdminID is the adminID argument value.
+			AdminID uuid.UUID
+			// Req is the req argument value.
+			Req models.PlaceOrderHoldRequest
+		}
+		// ProcessOrderReturn holds details about calls to the ProcessOrderReturn method.
+		ProcessOrderReturn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// ReturnID is the returnID argument value.
+			ReturnID uuid.UUID
+		}
+		// Register holds details about calls to the Register method.
+		Register []struct {
+			// Bus is the bus argument value.
+			Bus *events.Bus
+		}
+		// ReleaseHold holds details about calls to the ReleaseHold method.
+		ReleaseHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// AdminID is the adminID argument value.
+			AdminID uuid.UUID
+			// Req is the req argument value.
+			Req models.ReleaseOrderHoldRequest
+		}
+		// ReviewOrder holds details about calls to the ReviewOrder method.
+		ReviewOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// ReviewerID is the reviewerID argument value.
+			ReviewerID uuid.UUID
+			// Req is the req argument value.
+			Req models.ReviewOrderRequest
+		}
+		// UpdateItemFulfillment holds details about calls to the UpdateItemFulfillment method.
+		UpdateItemFulfillment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// ItemID is the itemID argument value.
+			ItemID uuid.UUID
+			// Req is the req argument value.
+			Req models.UpdateItemFulfillmentRequest
+		}
+		// UpdateOrderStatus holds details about calls to the UpdateOrderStatus method.
+		UpdateOrderStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Status is the status argument value.
+			Status models.OrderStatus
+		}
+	}
+	lockBulkUpdateOrderStatus  sync.RWMutex
+	lockCancelOrder            sync.RWMutex
+	lockCancelUnpaidOrders     sync.RWMutex
+	lockConfirmCODDelivery     sync.RWMutex
+	lockCreateOrder            sync.RWMutex
+	lockCreateReplacementOrder sync.RWMutex
+	lockExpireCheckoutAttempts sync.RWMutex
+	lockExportOrders           sync.RWMutex
+	lockGetActiveHolds         sync.RWMutex
+	lockGetAllOrders           sync.RWMutex
+	lockGetAnalytics           sync.RWMutex
+	lockGetCustomerAnalytics   sync.RWMutex
+	lockGetMarginReport        sync.RWMutex
+	lockGetOrder               sync.RWMutex
+	lockGetOrderAdmin          sync.RWMutex
+	lockGetOrderHolds          sync.RWMutex
+	lockGetPackingSlip         sync.RWMutex
+	lockGetPickList            sync.RWMutex
+	lockGetPromotionAnalytics  sync.RWMutex
+	lockGetRecentOrders        sync.RWMutex
+	lockGetReviewQueue         sync.RWMutex
+	lockGetUserOrders          sync.RWMutex
+	lockGetUserOrdersPage      sync.RWMutex
+	lockPlaceHold              sync.RWMutex
+	lockProcessOrderReturn     sync.RWMutex
+	lockRegister               sync.RWMutex
+	lockReleaseHold            sync.RWMutex
+	lockReviewOrder            sync.RWMutex
+	lockUpdateItemFulfillment  sync.RWMutex
+	lockUpdateOrderStatus      sync.RWMutex
+}
+
+// BulkUpdateOrderStatus calls BulkUpdateOrderStatusFunc.
+func (mock *OrderServiceMock) BulkUpdateOrderStatus(ctx context.Context, req models.BulkUpdateOrderStatusRequest) (*models.BulkUpdateOrderStatusResponse, error) {
+	if mock.BulkUpdateOrderStatusFunc == nil {
+		panic("OrderServiceMock.BulkUpdateOrderStatusFunc: method is nil but OrderService.BulkUpdateOrderStatus was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.BulkUpdateOrderStatusRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockBulkUpdateOrderStatus.Lock()
+	mock.calls.BulkUpdateOrderStatus = append(mock.calls.BulkUpdateOrderStatus, callInfo)
+	mock.lockBulkUpdateOrderStatus.Unlock()
+	return mock.BulkUpdateOrderStatusFunc(ctx, req)
+}
+
+// BulkUpdateOrderStatusCalls gets all the calls that were made to BulkUpdateOrderStatus.
+// Check the length with:
+//
+//	len(mockedOrderService.BulkUpdateOrderStatusCalls())
+func (mock *OrderServiceMock) BulkUpdateOrderStatusCalls() []struct {
+	Ctx context.Context
+	Req models.BulkUpdateOrderStatusRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.BulkUpdateOrderStatusRequest
+	}
+	mock.lockBulkUpdateOrderStatus.RLock()
+	calls = mock.calls.BulkUpdateOrderStatus
+	mock.lockBulkUpdateOrderStatus.RUnlock()
+	return calls
+}
+
+// CancelOrder calls CancelOrderFunc.
+func (mock *OrderServiceMock) CancelOrder(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) error {
+	if mock.CancelOrderFunc == nil {
+		panic("OrderServiceMock.CancelOrderFunc: method is nil but OrderService.CancelOrder was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		UserID:  userID,
+	}
+	mock.lockCancelOrder.Lock()
+	mock.calls.CancelOrder = append(mock.calls.CancelOrder, callInfo)
+	mock.lockCancelOrder.Unlock()
+	return mock.CancelOrderFunc(ctx, orderID, userID)
+}
+
+// CancelOrderCalls gets all the calls that were made to CancelOrder.
+// Check the length with:
+//
+//	len(mockedOrderService.CancelOrderCalls())
+func (mock *OrderServiceMock) CancelOrderCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	UserID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+	}
+	mock.lockCancelOrder.RLock()
+	calls = mock.calls.CancelOrder
+	mock.lockCancelOrder.RUnlock()
+	return calls
+}
+
+// CancelUnpaidOrders calls CancelUnpaidOrdersFunc.
+func (mock *OrderServiceMock) CancelUnpaidOrders(ctx context.Context) (int, error) {
+	if mock.CancelUnpaidOrdersFunc == nil {
+		panic("OrderServiceMock.CancelUnpaidOrdersFunc: method is nil but OrderService.CancelUnpaidOrders was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCancelUnpaidOrders.Lock()
+	mock.calls.CancelUnpaidOrders = append(mock.calls.CancelUnpaidOrders, callInfo)
+	mock.lockCancelUnpaidOrders.Unlock()
+	return mock.CancelUnpaidOrdersFunc(ctx)
+}
+
+// CancelUnpaidOrdersCalls gets all the calls that were made to CancelUnpaidOrders.
+// Check the length with:
+//
+//	len(mockedOrderService.CancelUnpaidOrdersCalls())
+func (mock *OrderServiceMock) CancelUnpaidOrdersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockCancelUnpaidOrders.RLock()
+	calls = mock.calls.CancelUnpaidOrders
+	mock.lockCancelUnpaidOrders.RUnlock()
+	return calls
+}
+
+// ConfirmCODDelivery calls ConfirmCODDeliveryFunc.
+func (mock *OrderServiceMock) ConfirmCODDelivery(ctx context.Context, orderID uuid.UUID, otp string) error {
+	if mock.ConfirmCODDeliveryFunc == nil {
+		panic("OrderServiceMock.ConfirmCODDeliveryFunc: method is nil but OrderService.ConfirmCODDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Otp     string
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		Otp:     otp,
+	}
+	mock.lockConfirmCODDelivery.Lock()
+	mock.calls.ConfirmCODDelivery = append(mock.calls.ConfirmCODDelivery, callInfo)
+	mock.lockConfirmCODDelivery.Unlock()
+	return mock.ConfirmCODDeliveryFunc(ctx, orderID, otp)
+}
+
+// ConfirmCODDeliveryCalls gets all the calls that were made to ConfirmCODDelivery.
+// Check the length with:
+//
+//	len(mockedOrderService.ConfirmCODDeliveryCalls())
+func (mock *OrderServiceMock) ConfirmCODDeliveryCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	Otp     string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Otp     string
+	}
+	mock.lockConfirmCODDelivery.RLock()
+	calls = mock.calls.ConfirmCODDelivery
+	mock.lockConfirmCODDelivery.RUnlock()
+	return calls
+}
+
+// CreateOrder calls CreateOrderFunc.
+func (mock *OrderServiceMock) CreateOrder(ctx context.Context, userID uuid.UUID, req models.CreateOrderRequest) (*models.Order, error) {
+	if mock.CreateOrderFunc == nil {
+		panic("OrderServiceMock.CreateOrderFunc: method is nil but OrderService.CreateOrder was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.CreateOrderRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Req:    req,
+	}
+	mock.lockCreateOrder.Lock()
+	mock.calls.CreateOrder = append(mock.calls.CreateOrder, callInfo)
+	mock.lockCreateOrder.Unlock()
+	return mock.CreateOrderFunc(ctx, userID, req)
+}
+
+// CreateOrderCalls gets all the calls that were made to CreateOrder.
+// Check the length with:
+//
+//	len(mockedOrderService.CreateOrderCalls())
+func (mock *OrderServiceMock) CreateOrderCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Req    models.CreateOrderRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.CreateOrderRequest
+	}
+	mock.lockCreateOrder.RLock()
+	calls = mock.calls.CreateOrder
+	mock.lockCreateOrder.RUnlock()
+	return calls
+}
+
+// CreateReplacementOrder calls CreateReplacementOrderFunc.
+func (mock *OrderServiceMock) CreateReplacementOrder(ctx context.Context, original *models.Order, item models.OrderItem, variantID uuid.UUID) (*models.Order, error) {
+	if mock.CreateReplacementOrderFunc == nil {
+		panic("OrderServiceMock.CreateReplacementOrderFunc: method is nil but OrderService.CreateReplacementOrder was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Original  *models.Order
+		Item      models.OrderItem
+		VariantID uuid.UUID
+	}{
+		Ctx:       ctx,
+		Original:  original,
+		Item:      item,
+		VariantID: variantID,
+	}
+	mock.lockCreateReplacementOrder.Lock()
+	mock.calls.CreateReplacementOrder = append(mock.calls.CreateReplacementOrder, callInfo)
+	mock.lockCreateReplacementOrder.Unlock()
+	return mock.CreateReplacementOrderFunc(ctx, original, item, variantID)
+}
+
+// CreateReplacementOrderCalls gets all the calls that were made to CreateReplacementOrder.
+// Check the length with:
+//
+//	len(mockedOrderService.CreateReplacementOrderCalls())
+func (mock *OrderServiceMock) CreateReplacementOrderCalls() []struct {
+	Ctx       context.Context
+	Original  *models.Order
+	Item      models.OrderItem
+	VariantID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Original  *models.Order
+		Item      models.OrderItem
+		VariantID uuid.UUID
+	}
+	mock.lockCreateReplacementOrder.RLock()
+	calls = mock.calls.CreateReplacementOrder
+	mock.lockCreateReplacementOrder.RUnlock()
+	return calls
+}
+
+// ExpireCheckoutAttempts calls ExpireCheckoutAttemptsFunc.
+func (mock *OrderServiceMock) ExpireCheckoutAttempts(ctx context.Context) (int, error) {
+	if mock.ExpireCheckoutAttemptsFunc == nil {
+		panic("OrderServiceMock.ExpireCheckoutAttemptsFunc: method is nil but OrderService.ExpireCheckoutAttempts was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockExpireCheckoutAttempts.Lock()
+	mock.calls.ExpireCheckoutAttempts = append(mock.calls.ExpireCheckoutAttempts, callInfo)
+	mock.lockExpireCheckoutAttempts.Unlock()
+	return mock.ExpireCheckoutAttemptsFunc(ctx)
+}
+
+// ExpireCheckoutAttemptsCalls gets all the calls that were made to ExpireCheckoutAttempts.
+// Check the length with:
+//
+//	len(mockedOrderService.ExpireCheckoutAttemptsCalls())
+func (mock *OrderServiceMock) ExpireCheckoutAttemptsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockExpireCheckoutAttempts.RLock()
+	calls = mock.calls.ExpireCheckoutAttempts
+	mock.lockExpireCheckoutAttempts.RUnlock()
+	return calls
+}
+
+// ExportOrders calls ExportOrdersFunc.
+func (mock *OrderServiceMock) ExportOrders(ctx context.Context, filter models.OrderFilter, fn func(models.OrderExportRow) error) error {
+	if mock.ExportOrdersFunc == nil {
+		panic("OrderServiceMock.ExportOrdersFunc: method is nil but OrderService.ExportOrders was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.OrderFilter
+		Fn     func(models.OrderExportRow) error
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Fn:     fn,
+	}
+	mock.lockExportOrders.Lock()
+	mock.calls.ExportOrders = append(mock.calls.ExportOrders, callInfo)
+	mock.lockExportOrders.Unlock()
+	return mock.ExportOrdersFunc(ctx, filter, fn)
+}
+
+// ExportOrdersCalls gets all the calls that were made to ExportOrders.
+// Check the length with:
+//
+//	len(mockedOrderService.ExportOrdersCalls())
+func (mock *OrderServiceMock) ExportOrdersCalls() []struct {
+	Ctx    context.Context
+	Filter models.OrderFilter
+	Fn     func(models.OrderExportRow) error
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.OrderFilter
+		Fn     func(models.OrderExportRow) error
+	}
+	mock.lockExportOrders.RLock()
+	calls = mock.calls.ExportOrders
+	mock.lockExportOrders.RUnlock()
+	return calls
+}
+
+// GetActiveHolds calls GetActiveHoldsFunc.
+func (mock *OrderServiceMock) GetActiveHolds(ctx context.Context, status models.OrderStatus, page int, limit int) ([]models.OrderHold, int, error) {
+	if mock.GetActiveHoldsFunc == nil {
+		panic("OrderServiceMock.GetActiveHoldsFunc: method is nil but OrderService.GetActiveHolds was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Status models.OrderStatus
+		Page   int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Status: status,
+		Page:   page,
+		Limit:  limit,
+	}
+	mock.lockGetActiveHolds.Lock()
+	mock.calls.GetActiveHolds = append(mock.calls.GetActiveHolds, callInfo)
+	mock.lockGetActiveHolds.Unlock()
+	return mock.GetActiveHoldsFunc(ctx, status, page, limit)
+}
+
+// GetActiveHoldsCalls gets all the calls that were made to GetActiveHolds.
+// Check the length with:
+//
+//	len(mockedOrderService.GetActiveHoldsCalls())
+func (mock *OrderServiceMock) GetActiveHoldsCalls() []struct {
+	Ctx    context.Context
+	Status models.OrderStatus
+	Page   int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Status models.OrderStatus
+		Page   int
+		Limit  int
+	}
+	mock.lockGetActiveHolds.RLock()
+	calls = mock.calls.GetActiveHolds
+	mock.lockGetActiveHolds.RUnlock()
+	return calls
+}
+
+// GetAllOrders calls GetAllOrdersFunc.
+func (mock *OrderServiceMock) GetAllOrders(ctx context.Context, page int, limit int, filter models.OrderFilter) ([]models.AdminOrder, int, error) {
+	if mock.GetAllOrdersFunc == nil {
+		panic("OrderServiceMock.GetAllOrdersFunc: method is nil but OrderService.GetAllOrders was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Page   int
+		Limit  int
+		Filter models.OrderFilter
+	}{
+		Ctx:    ctx,
+		Page:   page,
+		Limit:  limit,
+		Filter: filter,
+	}
+	mock.lockGetAllOrders.Lock()
+	mock.calls.GetAllOrders = append(mock.calls.GetAllOrders, callInfo)
+	mock.lockGetAllOrders.Unlock()
+	return mock.GetAllOrdersFunc(ctx, page, limit, filter)
+}
+
+// GetAllOrdersCalls gets all the calls that were made to GetAllOrders.
+// Check the length with:
+//
+//	len(mockedOrderService.GetAllOrdersCalls())
+func (mock *OrderServiceMock) GetAllOrdersCalls() []struct {
+	Ctx    context.Context
+	Page   int
+	Limit  int
+	Filter models.OrderFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Page   int
+		Limit  int
+		Filter models.OrderFilter
+	}
+	mock.lockGetAllOrders.RLock()
+	calls = mock.calls.GetAllOrders
+	mock.lockGetAllOrders.RUnlock()
+	return calls
+}
+
+// GetAnalytics calls GetAnalyticsFunc.
+func (mock *OrderServiceMock) GetAnalytics(ctx context.Context, rangeDays int) (*models.AdminAnalytics, error) {
+	if mock.GetAnalyticsFunc == nil {
+		panic("OrderServiceMock.GetAnalyticsFunc: method is nil but OrderService.GetAnalytics was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RangeDays int
+	}{
+		Ctx:       ctx,
+		RangeDays: rangeDays,
+	}
+	mock.lockGetAnalytics.Lock()
+	mock.calls.GetAnalytics = append(mock.calls.GetAnalytics, callInfo)
+	mock.lockGetAnalytics.Unlock()
+	return mock.GetAnalyticsFunc(ctx, rangeDays)
+}
+
+// GetAnalyticsCalls gets all the calls that were made to GetAnalytics.
+// Check the length with:
+//
+//	len(mockedOrderService.GetAnalyticsCalls())
+func (mock *OrderServiceMock) GetAnalyticsCalls() []struct {
+	Ctx       context.Context
+	RangeDays int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RangeDays int
+	}
+	mock.lockGetAnalytics.RLock()
+	calls = mock.calls.GetAnalytics
+	mock.lockGetAnalytics.RUnlock()
+	return calls
+}
+
+// GetCustomerAnalytics calls GetCustomerAnalyticsFunc.
+func (mock *OrderServiceMock) GetCustomerAnalytics(ctx context.Context, rangeDays int, topLimit int) (*models.CustomerAnalytics, error) {
+	if mock.GetCustomerAnalyticsFunc == nil {
+		panic("OrderServiceMock.GetCustomerAnalyticsFunc: method is nil but OrderService.GetCustomerAnalytics was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RangeDays int
+		TopLimit  int
+	}{
+		Ctx:       ctx,
+		RangeDays: rangeDays,
+		TopLimit:  topLimit,
+	}
+	mock.lockGetCustomerAnalytics.Lock()
+	mock.calls.GetCustomerAnalytics = append(mock.calls.GetCustomerAnalytics, callInfo)
+	mock.lockGetCustomerAnalytics.Unlock()
+	return mock.GetCustomerAnalyticsFunc(ctx, rangeDays, topLimit)
+}
+
+// GetCustomerAnalyticsCalls gets all the calls that were made to GetCustomerAnalytics.
+// Check the length with:
+//
+//	len(mockedOrderService.GetCustomerAnalyticsCalls())
+func (mock *OrderServiceMock) GetCustomerAnalyticsCalls() []struct {
+	Ctx       context.Context
+	RangeDays int
+	TopLimit  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RangeDays int
+		TopLimit  int
+	}
+	mock.lockGetCustomerAnalytics.RLock()
+	calls = mock.calls.GetCustomerAnalytics
+	mock.lockGetCustomerAnalytics.RUnlock()
+	return calls
+}
+
+// GetMarginReport calls GetMarginReportFunc.
+func (mock *OrderServiceMock) GetMarginReport(ctx context.Context, rangeDays int, period string) (*models.MarginReport, error) {
+	if mock.GetMarginReportFunc == nil {
+		panic("OrderServiceMock.GetMarginReportFunc: method is nil but OrderService.GetMarginReport was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RangeDays int
+		Period    string
+	}{
+		Ctx:       ctx,
+		RangeDays: rangeDays,
+		Period:    period,
+	}
+	mock.lockGetMarginReport.Lock()
+	mock.calls.GetMarginReport = append(mock.calls.GetMarginReport, callInfo)
+	mock.lockGetMarginReport.Unlock()
+	return mock.GetMarginReportFunc(ctx, rangeDays, period)
+}
+
+// GetMarginReportCalls gets all the calls that were made to GetMarginReport.
+// Check the length with:
+//
+//	len(mockedOrderService.GetMarginReportCalls())
+func (mock *OrderServiceMock) GetMarginReportCalls() []struct {
+	Ctx       context.Context
+	RangeDays int
+	Period    string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RangeDays int
+		Period    string
+	}
+	mock.lockGetMarginReport.RLock()
+	calls = mock.calls.GetMarginReport
+	mock.lockGetMarginReport.RUnlock()
+	return calls
+}
+
+// GetOrder calls GetOrderFunc.
+func (mock *OrderServiceMock) GetOrder(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) (*models.Order, error) {
+	if mock.GetOrderFunc == nil {
+		panic("OrderServiceMock.GetOrderFunc: method is nil but OrderService.GetOrder was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		UserID:  userID,
+	}
+	mock.lockGetOrder.Lock()
+	mock.calls.GetOrder = append(mock.calls.GetOrder, callInfo)
+	mock.lockGetOrder.Unlock()
+	return mock.GetOrderFunc(ctx, orderID, userID)
+}
+
+// GetOrderCalls gets all the calls that were made to GetOrder.
+// Check the length with:
+//
+//	len(mockedOrderService.GetOrderCalls())
+func (mock *OrderServiceMock) GetOrderCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	UserID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+	}
+	mock.lockGetOrder.RLock()
+	calls = mock.calls.GetOrder
+	mock.lockGetOrder.RUnlock()
+	return calls
+}
+
+// GetOrderAdmin calls GetOrderAdminFunc.
+func (mock *OrderServiceMock) GetOrderAdmin(ctx context.Context, orderID uuid.UUID) (*models.AdminOrder, error) {
+	if mock.GetOrderAdminFunc == nil {
+		panic("OrderServiceMock.GetOrderAdminFunc: method is nil but OrderService.GetOrderAdmin was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockGetOrderAdmin.Lock()
+	mock.calls.GetOrderAdmin = append(mock.calls.GetOrderAdmin, callInfo)
+	mock.lockGetOrderAdmin.Unlock()
+	return mock.GetOrderAdminFunc(ctx, orderID)
+}
+
+// GetOrderAdminCalls gets all the calls that were made to GetOrderAdmin.
+// Check the length with:
+//
+//	len(mockedOrderService.GetOrderAdminCalls())
+func (mock *OrderServiceMock) GetOrderAdminCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockGetOrderAdmin.RLock()
+	calls = mock.calls.GetOrderAdmin
+	mock.lockGetOrderAdmin.RUnlock()
+	return calls
+}
+
+// GetOrderHolds calls GetOrderHoldsFunc.
+func (mock *OrderServiceMock) GetOrderHolds(ctx context.Context, orderID uuid.UUID) ([]models.OrderHold, error) {
+	if mock.GetOrderHoldsFunc == nil {
+		panic("OrderServiceMock.GetOrderHoldsFunc: method is nil but OrderService.GetOrderHolds was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockGetOrderHolds.Lock()
+	mock.calls.GetOrderHolds = append(mock.calls.GetOrderHolds, callInfo)
+	mock.lockGetOrderHolds.Unlock()
+	return mock.GetOrderHoldsFunc(ctx, orderID)
+}
+
+// GetOrderHoldsCalls gets all the calls that were made to GetOrderHolds.
+// Check the length with:
+//
+//	len(mockedOrderService.GetOrderHoldsCalls())
+func (mock *OrderServiceMock) GetOrderHoldsCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockGetOrderHolds.RLock()
+	calls = mock.calls.GetOrderHolds
+	mock.lockGetOrderHolds.RUnlock()
+	return calls
+}
+
+// GetPackingSlip calls GetPackingSlipFunc.
+func (mock *OrderServiceMock) GetPackingSlip(ctx context.Context, orderID uuid.UUID) (*models.PackingSlip, error) {
+	if mock.GetPackingSlipFunc == nil {
+		panic("OrderServiceMock.GetPackingSlipFunc: method is nil but OrderService.GetPackingSlip was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockGetPackingSlip.Lock()
+	mock.calls.GetPackingSlip = append(mock.calls.GetPackingSlip, callInfo)
+	mock.lockGetPackingSlip.Unlock()
+	return mock.GetPackingSlipFunc(ctx, orderID)
+}
+
+// GetPackingSlipCalls gets all the calls that were made to GetPackingSlip.
+// Check the length with:
+//
+//	len(mockedOrderService.GetPackingSlipCalls())
+func (mock *OrderServiceMock) GetPackingSlipCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockGetPackingSlip.RLock()
+	calls = mock.calls.GetPackingSlip
+	mock.lockGetPackingSlip.RUnlock()
+	return calls
+}
+
+// GetPickList calls GetPickListFunc.
+func (mock *OrderServiceMock) GetPickList(ctx context.Context, filter models.PickListFilter) (*models.PickList, error) {
+	if mock.GetPickListFunc == nil {
+		panic("OrderServiceMock.GetPickListFunc: method is nil but OrderService.GetPickList was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.PickListFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockGetPickList.Lock()
+	mock.calls.GetPickList = append(mock.calls.GetPickList, callInfo)
+	mock.lockGetPickList.Unlock()
+	return mock.GetPickListFunc(ctx, filter)
+}
+
+// GetPickListCalls gets all the calls that were made to GetPickList.
+// Check the length with:
+//
+//	len(mockedOrderService.GetPickListCalls())
+func (mock *OrderServiceMock) GetPickListCalls() []struct {
+	Ctx    context.Context
+	Filter models.PickListFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.PickListFilter
+	}
+	mock.lockGetPickList.RLock()
+	calls = mock.calls.GetPickList
+	mock.lockGetPickList.RUnlock()
+	return calls
+}
+
+// GetPromotionAnalytics calls GetPromotionAnalyticsFunc.
+func (mock *OrderServiceMock) GetPromotionAnalytics(ctx context.Context, rangeDays int) (*models.PromotionAnalytics, error) {
+	if mock.GetPromotionAnalyticsFunc == nil {
+		panic("OrderServiceMock.GetPromotionAnalyticsFunc: method is nil but OrderService.GetPromotionAnalytics was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RangeDays int
+	}{
+		Ctx:       ctx,
+		RangeDays: rangeDays,
+	}
+	mock.lockGetPromotionAnalytics.Lock()
+	mock.calls.GetPromotionAnalytics = append(mock.calls.GetPromotionAnalytics, callInfo)
+	mock.lockGetPromotionAnalytics.Unlock()
+	return mock.GetPromotionAnalyticsFunc(ctx, rangeDays)
+}
+
+// GetPromotionAnalyticsCalls gets all the calls that were made to GetPromotionAnalytics.
+// Check the length with:
+//
+//	len(mockedOrderService.GetPromotionAnalyticsCalls())
+func (mock *OrderServiceMock) GetPromotionAnalyticsCalls() []struct {
+	Ctx       context.Context
+	RangeDays int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RangeDays int
+	}
+	mock.lockGetPromotionAnalytics.RLock()
+	calls = mock.calls.GetPromotionAnalytics
+	mock.lockGetPromotionAnalytics.RUnlock()
+	return calls
+}
+
+// GetRecentOrders calls GetRecentOrdersFunc.
+func (mock *OrderServiceMock) GetRecentOrders(ctx context.Context, limit int, rangeDays int) ([]models.AdminOrder, error) {
+	if mock.GetRecentOrdersFunc == nil {
+		panic("OrderServiceMock.GetRecentOrdersFunc: method is nil but OrderService.GetRecentOrders was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Limit     int
+		RangeDays int
+	}{
+		Ctx:       ctx,
+		Limit:     limit,
+		RangeDays: rangeDays,
+	}
+	mock.lockGetRecentOrders.Lock()
+	mock.calls.GetRecentOrders = append(mock.calls.GetRecentOrders, callInfo)
+	mock.lockGetRecentOrders.Unlock()
+	return mock.GetRecentOrdersFunc(ctx, limit, rangeDays)
+}
+
+// GetRecentOrdersCalls gets all the calls that were made to GetRecentOrders.
+// Check the length with:
+//
+//	len(mockedOrderService.GetRecentOrdersCalls())
+func (mock *OrderServiceMock) GetRecentOrdersCalls() []struct {
+	Ctx       context.Context
+	Limit     int
+	RangeDays int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Limit     int
+		RangeDays int
+	}
+	mock.lockGetRecentOrders.RLock()
+	calls = mock.calls.GetRecentOrders
+	mock.lockGetRecentOrders.RUnlock()
+	return calls
+}
+
+// GetReviewQueue calls GetReviewQueueFunc.
+func (mock *OrderServiceMock) GetReviewQueue(ctx context.Context, page int, limit int) ([]models.ReviewQueueItem, int, error) {
+	if mock.GetReviewQueueFunc == nil {
+		panic("OrderServiceMock.GetReviewQueueFunc: method is nil but OrderService.GetReviewQueue was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}{
+		Ctx:   ctx,
+		Page:  page,
+		Limit: limit,
+	}
+	mock.lockGetReviewQueue.Lock()
+	mock.calls.GetReviewQueue = append(mock.calls.GetReviewQueue, callInfo)
+	mock.lockGetReviewQueue.Unlock()
+	return mock.GetReviewQueueFunc(ctx, page, limit)
+}
+
+// GetReviewQueueCalls gets all the calls that were made to GetReviewQueue.
+// Check the length with:
+//
+//	len(mockedOrderService.GetReviewQueueCalls())
+func (mock *OrderServiceMock) GetReviewQueueCalls() []struct {
+	Ctx   context.Context
+	Page  int
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}
+	mock.lockGetReviewQueue.RLock()
+	calls = mock.calls.GetReviewQueue
+	mock.lockGetReviewQueue.RUnlock()
+	return calls
+}
+
+// GetUserOrders calls GetUserOrdersFunc.
+func (mock *OrderServiceMock) GetUserOrders(ctx context.Context, userID uuid.UUID, page int, limit int, includeItems bool) ([]models.Order, int, error) {
+	if mock.GetUserOrdersFunc == nil {
+		panic("OrderServiceMock.GetUserOrdersFunc: method is nil but OrderService.GetUserOrders was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		Page         int
+		Limit        int
+		IncludeItems bool
+	}{
+		Ctx:          ctx,
+		UserID:       userID,
+		Page:         page,
+		Limit:        limit,
+		IncludeItems: includeItems,
+	}
+	mock.lockGetUserOrders.Lock()
+	mock.calls.GetUserOrders = append(mock.calls.GetUserOrders, callInfo)
+	mock.lockGetUserOrders.Unlock()
+	return mock.GetUserOrdersFunc(ctx, userID, page, limit, includeItems)
+}
+
+// GetUserOrdersCalls gets all the calls that were made to GetUserOrders.
+// Check the length with:
+//
+//	len(mockedOrderService.GetUserOrdersCalls())
+func (mock *OrderServiceMock) GetUserOrdersCalls() []struct {
+	Ctx          context.Context
+	UserID       uuid.UUID
+	Page         int
+	Limit        int
+	IncludeItems bool
+} {
+	var calls []struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		Page         int
+		Limit        int
+		IncludeItems bool
+	}
+	mock.lockGetUserOrders.RLock()
+	calls = mock.calls.GetUserOrders
+	mock.lockGetUserOrders.RUnlock()
+	return calls
+}
+
+// GetUserOrdersPage calls GetUserOrdersPageFunc.
+func (mock *OrderServiceMock) GetUserOrdersPage(ctx context.Context, userID uuid.UUID, after *pagination.Cursor, limit int, includeItems bool) ([]models.Order, *pagination.Cursor, error) {
+	if mock.GetUserOrdersPageFunc == nil {
+		panic("OrderServiceMock.GetUserOrdersPageFunc: method is nil but OrderService.GetUserOrdersPage was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		After        *pagination.Cursor
+		Limit        int
+		IncludeItems bool
+	}{
+		Ctx:          ctx,
+		UserID:       userID,
+		After:        after,
+		Limit:        limit,
+		IncludeItems: includeItems,
+	}
+	mock.lockGetUserOrdersPage.Lock()
+	mock.calls.GetUserOrdersPage = append(mock.calls.GetUserOrdersPage, callInfo)
+	mock.lockGetUserOrdersPage.Unlock()
+	return mock.GetUserOrdersPageFunc(ctx, userID, after, limit, includeItems)
+}
+
+// GetUserOrdersPageCalls gets all the calls that were made to GetUserOrdersPage.
+// Check the length with:
+//
+//	len(mockedOrderService.GetUserOrdersPageCalls())
+func (mock *OrderServiceMock) GetUserOrdersPageCalls() []struct {
+	Ctx          context.Context
+	UserID       uuid.UUID
+	After        *pagination.Cursor
+	Limit        int
+	IncludeItems bool
+} {
+	var calls []struct {
+		Ctx          context.Context
+		UserID       uuid.UUID
+		After        *pagination.Cursor
+		Limit        int
+		IncludeItems bool
+	}
+	mock.lockGetUserOrdersPage.RLock()
+	calls = mock.calls.GetUserOrdersPage
+	mock.lockGetUserOrdersPage.RUnlock()
+	return calls
+}
+
+// PlaceHold calls PlaceHoldFunc.
+func (mock *OrderServiceMock) PlaceHold(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, req models.PlaceOrderHoldRequest) (*models.OrderHold, error) {
+	if mock.PlaceHoldFunc == nil {
+		panic("OrderServiceMock.PlaceHoldFunc: method is nil but OrderService.PlaceHold was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		AdminID uuid.UUID
+		Req     models.PlaceOrderHoldRequest
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		AdminID: adminID,
+		Req:     req,
+	}
+	mock.lockPlaceHold.Lock()
+	mock.calls.PlaceHold = append(mock.calls.PlaceHold, callInfo)
+	mock.lockPlaceHold.Unlock()
+	return mock.PlaceHoldFunc(ctx, orderID, adminID, req)
+}
+
+// PlaceHoldCalls gets all the calls that were made to PlaceHold.
+// Check the length with:
+//
+//	len(mockedOrderService.PlaceHoldCalls())
+func (mock *OrderServiceMock) PlaceHoldCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	AdminID uuid.UUID
+	Req     models.PlaceOrderHoldRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		AdminID uuid.UUID
+		Req     models.PlaceOrderHoldRequest
+	}
+	mock.lockPlaceHold.RLock()
+	calls = mock.calls.PlaceHold
+	mock.lockPlaceHold.RUnlock()
+	return calls
+}
+
+// ProcessOrderReturn calls ProcessOrderReturnFunc.
+func (mock *OrderServiceMock) ProcessOrderReturn(ctx context.Context, orderID uuid.UUID, returnID uuid.UUID) error {
+	if mock.ProcessOrderReturnFunc == nil {
+		panic("OrderServiceMock.ProcessOrderReturnFunc: method is nil but OrderService.ProcessOrderReturn was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		ReturnID uuid.UUID
+	}{
+		Ctx:      ctx,
+		OrderID:  orderID,
+		ReturnID: returnID,
+	}
+	mock.lockProcessOrderReturn.Lock()
+	mock.calls.ProcessOrderReturn = append(mock.calls.ProcessOrderReturn, callInfo)
+	mock.lockProcessOrderReturn.Unlock()
+	return mock.ProcessOrderReturnFunc(ctx, orderID, returnID)
+}
+
+// ProcessOrderReturnCalls gets all the calls that were made to ProcessOrderReturn.
+// Check the length with:
+//
+//	len(mockedOrderService.ProcessOrderReturnCalls())
+func (mock *OrderServiceMock) ProcessOrderReturnCalls() []struct {
+	Ctx      context.Context
+	OrderID  uuid.UUID
+	ReturnID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		OrderID  uuid.UUID
+		ReturnID uuid.UUID
+	}
+	mock.lockProcessOrderReturn.RLock()
+	calls = mock.calls.ProcessOrderReturn
+	mock.lockProcessOrderReturn.RUnlock()
+	return calls
+}
+
+// Register calls RegisterFunc.
+func (mock *OrderServiceMock) Register(bus *events.Bus) {
+	if mock.RegisterFunc == nil {
+		panic("OrderServiceMock.RegisterFunc: method is nil but OrderService.Register was just called")
+	}
+	callInfo := struct {
+		Bus *events.Bus
+	}{
+		Bus: bus,
+	}
+	mock.lockRegister.Lock()
+	mock.calls.Register = append(mock.calls.Register, callInfo)
+	mock.lockRegister.Unlock()
+	mock.RegisterFunc(bus)
+}
+
+// RegisterCalls gets all the calls that were made to Register.
+// Check the length with:
+//
+//	len(mockedOrderService.RegisterCalls())
+func (mock *OrderServiceMock) RegisterCalls() []struct {
+	Bus *events.Bus
+} {
+	var calls []struct {
+		Bus *events.Bus
+	}
+	mock.lockRegister.RLock()
+	calls = mock.calls.Register
+	mock.lockRegister.RUnlock()
+	return calls
+}
+
+// ReleaseHold calls ReleaseHoldFunc.
+func (mock *OrderServiceMock) ReleaseHold(ctx context.Context, orderID uuid.UUID, adminID uuid.UUID, req models.ReleaseOrderHoldRequest) (*models.Order, error) {
+	if mock.ReleaseHoldFunc == nil {
+		panic("OrderServiceMock.ReleaseHoldFunc: method is nil but OrderService.ReleaseHold was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		AdminID uuid.UUID
+		Req     models.ReleaseOrderHoldRequest
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		AdminID: adminID,
+		Req:     req,
+	}
+	mock.lockReleaseHold.Lock()
+	mock.calls.ReleaseHold = append(mock.calls.ReleaseHold, callInfo)
+	mock.lockReleaseHold.Unlock()
+	return mock.ReleaseHoldFunc(ctx, orderID, adminID, req)
+}
+
+// ReleaseHoldCalls gets all the calls that were made to ReleaseHold.
+// Check the length with:
+//
+//	len(mockedOrderService.ReleaseHoldCalls())
+func (mock *OrderServiceMock) ReleaseHoldCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	AdminID uuid.UUID
+	Req     models.ReleaseOrderHoldRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		AdminID uuid.UUID
+		Req     models.ReleaseOrderHoldRequest
+	}
+	mock.lockReleaseHold.RLock()
+	calls = mock.calls.ReleaseHold
+	mock.lockReleaseHold.RUnlock()
+	return calls
+}
+
+// ReviewOrder calls ReviewOrderFunc.
+func (mock *OrderServiceMock) ReviewOrder(ctx context.Context, orderID uuid.UUID, reviewerID uuid.UUID, req models.ReviewOrderRequest) (*models.Order, error) {
+	if mock.ReviewOrderFunc == nil {
+		panic("OrderServiceMock.ReviewOrderFunc: method is nil but OrderService.ReviewOrder was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		OrderID    uuid.UUID
+		ReviewerID uuid.UUID
+		Req        models.ReviewOrderRequest
+	}{
+		Ctx:        ctx,
+		OrderID:    orderID,
+		ReviewerID: reviewerID,
+		Req:        req,
+	}
+	mock.lockReviewOrder.Lock()
+	mock.calls.ReviewOrder = append(mock.calls.ReviewOrder, callInfo)
+	mock.lockReviewOrder.Unlock()
+	return mock.ReviewOrderFunc(ctx, orderID, reviewerID, req)
+}
+
+// ReviewOrderCalls gets all the calls that were made to ReviewOrder.
+// Check the length with:
+//
+//	len(mockedOrderService.ReviewOrderCalls())
+func (mock *OrderServiceMock) ReviewOrderCalls() []struct {
+	Ctx        context.Context
+	OrderID    uuid.UUID
+	ReviewerID uuid.UUID
+	Req        models.ReviewOrderRequest
+} {
+	var calls []struct {
+		Ctx        context.Context
+		OrderID    uuid.UUID
+		ReviewerID uuid.UUID
+		Req        models.ReviewOrderRequest
+	}
+	mock.lockReviewOrder.RLock()
+	calls = mock.calls.ReviewOrder
+	mock.lockReviewOrder.RUnlock()
+	return calls
+}
+
+// UpdateItemFulfillment calls UpdateItemFulfillmentFunc.
+func (mock *OrderServiceMock) UpdateItemFulfillment(ctx context.Context, orderID uuid.UUID, itemID uuid.UUID, req models.UpdateItemFulfillmentRequest) (*models.Order, error) {
+	if mock.UpdateItemFulfillmentFunc == nil {
+		panic("OrderServiceMock.UpdateItemFulfillmentFunc: method is nil but OrderService.UpdateItemFulfillment was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		ItemID  uuid.UUID
+		Req     models.UpdateItemFulfillmentRequest
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		ItemID:  itemID,
+		Req:     req,
+	}
+	mock.lockUpdateItemFulfillment.Lock()
+	mock.calls.UpdateItemFulfillment = append(mock.calls.UpdateItemFulfillment, callInfo)
+	mock.lockUpdateItemFulfillment.Unlock()
+	return mock.UpdateItemFulfillmentFunc(ctx, orderID, itemID, req)
+}
+
+// UpdateItemFulfillmentCalls gets all the calls that were made to UpdateItemFulfillment.
+// Check the length with:
+//
+//	len(mockedOrderService.UpdateItemFulfillmentCalls())
+func (mock *OrderServiceMock) UpdateItemFulfillmentCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	ItemID  uuid.UUID
+	Req     models.UpdateItemFulfillmentRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		ItemID  uuid.UUID
+		Req     models.UpdateItemFulfillmentRequest
+	}
+	mock.lockUpdateItemFulfillment.RLock()
+	calls = mock.calls.UpdateItemFulfillment
+	mock.lockUpdateItemFulfillment.RUnlock()
+	return calls
+}
+
+// UpdateOrderStatus calls UpdateOrderStatusFunc.
+func (mock *OrderServiceMock) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status models.OrderStatus) error {
+	if mock.UpdateOrderStatusFunc == nil {
+		panic("OrderServiceMock.UpdateOrderStatusFunc: method is nil but OrderService.UpdateOrderStatus was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Status  models.OrderStatus
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		Status:  status,
+	}
+	mock.lockUpdateOrderStatus.Lock()
+	mock.calls.UpdateOrderStatus = append(mock.calls.UpdateOrderStatus, callInfo)
+	mock.lockUpdateOrderStatus.Unlock()
+	return mock.UpdateOrderStatusFunc(ctx, orderID, status)
+}
+
+// UpdateOrderStatusCalls gets all the calls that were made to UpdateOrderStatus.
+// Check the length with:
+//
+//	len(mockedOrderService.UpdateOrderStatusCalls())
+func (mock *OrderServiceMock) UpdateOrderStatusCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	Status  models.OrderStatus
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Status  models.OrderStatus
+	}
+	mock.lockUpdateOrderStatus.RLock()
+	calls = mock.calls.UpdateOrderStatus
+	mock.lockUpdateOrderStatus.RUnlock()
+	return calls
+}
+
+// Ensure, that PaymentMethodServiceMock does implement service.PaymentMethodService.
+// If this is not the case, regenerate this file with moq.
+var _ service.PaymentMethodService = &PaymentMethodServiceMock{}
+
+// PaymentMethodServiceMock is a mock implementation of service.PaymentMethodService.
+//
+//	func TestSomethingThatUsesPaymentMethodService(t *testing.T) {
+//
+//		// make and configure a mocked service.PaymentMethodService
+//		mockedPaymentMethodService := &PaymentMethodServiceMock{
+//			AddPaymentMethodFunc: func(ctx context.Context, userID uuid.UUID, req models.SavePaymentMethodRequest) (*models.SavedPaymentMethod, error) {
+//				panic("mock out the AddPaymentMethod method")
+//			},
+//			DeletePaymentMethodFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+//				panic("mock out the DeletePaymentMethod method")
+//			},
+//			GetPaymentMethodFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*models.SavedPaymentMethod, error) {
+//				panic("mock out the GetPaymentMethod method")
+//			},
+//			GetPaymentMethodsFunc: func(ctx context.Context, userID uuid.UUID) ([]models.SavedPaymentMethod, error) {
+//				panic("mock out the GetPaymentMethods method")
+//			},
+//			SetDefaultFunc: func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*models.SavedPaymentMethod, error) {
+//				panic("mock out the SetDefault method")
+//			},
+//		}
+//
+//		// use mockedPaymentMethodService in code that requires service.PaymentMethodService
+//		// and then make assertions.
+//
+//	}
+type PaymentMethodServiceMock struct {
+	// AddPaymentMethodFunc mocks the AddPaymentMethod method.
+	AddPaymentMethodFunc func(ctx context.Context, userID uuid.UUID, req models.SavePaymentMethodRequest) (*models.SavedPaymentMethod, error)
+
+	// DeletePaymentMethodFunc mocks the DeletePaymentMethod method.
+	DeletePaymentMethodFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+
+	// GetPaymentMethodFunc mocks the GetPaymentMethod method.
+	GetPaymentMethodFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*models.SavedPaymentMethod, error)
+
+	// GetPaymentMethodsFunc mocks the GetPaymentMethods method.
+	GetPaymentMethodsFunc func(ctx context.Context, userID uuid.UUID) ([]models.SavedPaymentMethod, error)
+
+	// SetDefaultFunc mocks the SetDefault method.
+	SetDefaultFunc func(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*models.SavedPaymentMethod, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddPaymentMethod holds details about calls to the AddPaymentMethod method.
+		AddPaymentMethod []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.SavePaymentMethodRequest
+		}
+		// DeletePaymentMethod holds details about calls to the DeletePaymentMethod method.
+		DeletePaymentMethod []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetPaymentMethod holds details about calls to the GetPaymentMethod method.
+		GetPaymentMethod []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetPaymentMethods holds details about calls to the GetPaymentMethods method.
+		GetPaymentMethods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// SetDefault holds details about calls to the SetDefault method.
+		SetDefault []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+	}
+	lockAddPaymentMethod    sync.RWMutex
+	lockDeletePaymentMethod sync.RWMutex
+	lockGetPaymentMethod    sync.RWMutex
+	lockGetPaymentMethods   sync.RWMutex
+	lockSetDefault          sync.RWMutex
+}
+
+// AddPaymentMethod calls AddPaymentMethodFunc.
+func (mock *PaymentMethodServiceMock) AddPaymentMethod(ctx context.Context, userID uuid.UUID, req models.SavePaymentMethodRequest) (*models.SavedPaymentMethod, error) {
+	if mock.AddPaymentMethodFunc == nil {
+		panic("PaymentMethodServiceMock.AddPaymentMethodFunc: method is nil but PaymentMethodService.AddPaymentMethod was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.SavePaymentMethodRequest
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Req:    req,
+	}
+	mock.lockAddPaymentMethod.Lock()
+	mock.calls.AddPaymentMethod = append(mock.calls.AddPaymentMethod, callInfo)
+	mock.lockAddPaymentMethod.Unlock()
+	return mock.AddPaymentMethodFunc(ctx, userID, req)
+}
+
+// AddPaymentMethodCalls gets all the calls that were made to AddPaymentMethod.
+// Check the length with:
+//
+//	len(mockedPaymentMethodService.AddPaymentMethodCalls())
+func (mock *PaymentMethodServiceMock) AddPaymentMethodCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Req    models.SavePaymentMethodRequest
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Req    models.SavePaymentMethodRequest
+	}
+	mock.lockAddPaymentMethod.RLock()
+	calls = mock.calls.AddPaymentMethod
+	mock.lockAddPaymentMethod.RUnlock()
+	return calls
+}
+
+// DeletePaymentMethod calls DeletePaymentMethodFunc.
+func (mock *PaymentMethodServiceMock) DeletePaymentMethod(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if mock.DeletePaymentMethodFunc == nil {
+		panic("PaymentMethodServiceMock.DeletePaymentMethodFunc: method is nil but PaymentMethodService.DeletePaymentMethod was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockDeletePaymentMethod.Lock()
+	mock.calls.DeletePaymentMethod = append(mock.calls.DeletePaymentMethod, callInfo)
+	mock.lockDeletePaymentMethod.Unlock()
+	return mock.DeletePaymentMethodFunc(ctx, userID, id)
+}
+
+// DeletePaymentMethodCalls gets all the calls that were made to DeletePaymentMethod.
+// Check the length with:
+//
+//	len(mockedPaymentMethodService.DeletePaymentMethodCalls())
+func (mock *PaymentMethodServiceMock) DeletePaymentMethodCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockDeletePaymentMethod.RLock()
+	calls = mock.calls.DeletePaymentMethod
+	mock.lockDeletePaymentMethod.RUnlock()
+	return calls
+}
+
+// GetPaymentMethod calls GetPaymentMethodFunc.
+func (mock *PaymentMethodServiceMock) GetPaymentMethod(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*models.SavedPaymentMethod, error) {
+	if mock.GetPaymentMethodFunc == nil {
+		panic("PaymentMethodServiceMock.GetPaymentMethodFunc: method is nil but PaymentMethodService.GetPaymentMethod was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGetPaymentMethod.Lock()
+	mock.calls.GetPaymentMethod = append(mock.calls.GetPaymentMethod, callInfo)
+	mock.lockGetPaymentMethod.Unlock()
+	return mock.GetPaymentMethodFunc(ctx, userID, id)
+}
+
+// GetPaymentMethodCalls gets all the calls that were made to GetPaymentMethod.
+// Check the length with:
+//
+//	len(mockedPaymentMethodService.GetPaymentMethodCalls())
+func (mock *PaymentMethodServiceMock) GetPaymentMethodCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockGetPaymentMethod.RLock()
+	calls = mock.calls.GetPaymentMethod
+	mock.lockGetPaymentMethod.RUnlock()
+	return calls
+}
+
+// GetPaymentMethods calls GetPaymentMethodsFunc.
+func (mock *PaymentMethodServiceMock) GetPaymentMethods(ctx context.Context, userID uuid.UUID) ([]models.SavedPaymentMethod, error) {
+	if mock.GetPaymentMethodsFunc == nil {
+		panic("PaymentMethodServiceMock.GetPaymentMethodsFunc: method is nil but PaymentMethodService.GetPaymentMethods was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetPaymentMethods.Lock()
+	mock.calls.GetPaymentMethods = append(mock.calls.GetPaymentMethods, callInfo)
+	mock.lockGetPaymentMethods.Unlock()
+	return mock.GetPaymentMethodsFunc(ctx, userID)
+}
+
+// GetPaymentMethodsCalls gets all the calls that were made to GetPaymentMethods.
+// Check the length with:
+//
+//	len(mockedPaymentMethodService.GetPaymentMethodsCalls())
+func (mock *PaymentMethodServiceMock) GetPaymentMethodsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockGetPaymentMethods.RLock()
+	calls = mock.calls.GetPaymentMethods
+	mock.lockGetPaymentMethods.RUnlock()
+	return calls
+}
+
+// SetDefault calls SetDefaultFunc.
+func (mock *PaymentMethodServiceMock) SetDefault(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*models.SavedPaymentMethod, error) {
+	if mock.SetDefaultFunc == nil {
+		panic("PaymentMethodServiceMock.SetDefaultFunc: method is nil but PaymentMethodService.SetDefault was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockSetDefault.Lock()
+	mock.calls.SetDefault = append(mock.calls.SetDefault, callInfo)
+	mock.lockSetDefault.Unlock()
+	return mock.SetDefaultFunc(ctx, userID, id)
+}
+
+// SetDefaultCalls gets all the calls that were made to SetDefault.
+// Check the length with:
+//
+//	len(mockedPaymentMethodService.SetDefaultCalls())
+func (mock *PaymentMethodServiceMock) SetDefaultCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	ID     uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		ID     uuid.UUID
+	}
+	mock.lockSetDefault.RLock()
+	calls = mock.calls.SetDefault
+	mock.lockSetDefault.RUnlock()
+	return calls
+}
+
+// Ensure, that PaymentServiceMock does implement service.PaymentService.
+// If this is not the case, regenerate this file with moq.
+var _ service.PaymentService = &PaymentServiceMock{}
+
+// PaymentServiceMock is a mock implementation of service.PaymentService.
+//
+//	func TestSomethingThatUsesPaymentService(t *testing.T) {
+//
+//		// make and configure a mocked service.PaymentService
+//		mockedPaymentService := &PaymentServiceMock{
+//			CaptureOrderPaymentFunc: func(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
+//				panic("mock out the CaptureOrderPayment method")
+//			},
+//			CapturePaymentFunc: func(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, req models.CapturePaymentRequest) (*models.PaymentCapture, error) {
+//				panic("mock out the CapturePayment method")
+//			},
+//			ConfirmPaymentFunc: func(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+//				panic("mock out the ConfirmPayment method")
+//			},
+//			CreatePaymentFunc: func(ctx context.Context, req models.CreatePaymentRequest, userID uuid.UUID) (*models.Payment, error) {
+//				panic("mock out the CreatePayment method")
+//			},
+//			CreatePaymentForOrderFunc: func(ctx context.Context, orderID uuid.UUID, method string, status models.PaymentStatus) (*models.Payment, error) {
+//				panic("mock out the CreatePaymentForOrder method")
+//			},
+//			GetAllPaymentsFunc: func(ctx context.Context, filter models.PaymentFilter, page int, limit int) ([]models.AdminPayment, int, error) {
+//				panic("mock out the GetAllPayments method")
+//			},
+//			GetOrderRefundsFunc: func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) ([]models.Refund, error) {
+//				panic("mock out the GetOrderRefunds method")
+//			},
+//			GetPaymentByOrderIDFunc: func(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
+//				panic("mock out the GetPaymentByOrderID method")
+//			},
+//			GetPaymentStatusFunc: func(ctx context.Context, paymentID uuid.UUID, userID uuid.UUID) (*models.PaymentStatusResponse, error) {
+//				panic("mock out the GetPaymentStatus method")
+//			},
+//			HandleWebhookFunc: func(ctx context.Context, provider string, payload []byte, signature string) error {
+//				panic("mock out the HandleWebhook method")
+//			},
+//			InitiateCardPaymentFunc: func(ctx context.Context, orderID uuid.UUID, method string, saved *models.SavedPaymentMethod) (*models.Payment, error) {
+//				panic("mock out the InitiateCardPayment method")
+//			},
+//			ProcessRefundFunc: func(ctx context.Context, refund *models.Refund) error {
+//				panic("mock out the ProcessRefund method")
+//			},
+//			ReconcilePaymentsFunc: func(ctx context.Context, rangeDays int) (*models.PaymentReconciliation, error) {
+//				panic("mock out the ReconcilePayments method")
+//			},
+//			VerifyPaymentFunc: func(ctx context.Context, req models.VerifyPaymentRequest) (*models.Payment, error) {
+//				panic("mock out the VerifyPayment method")
+//			},
+//			VoidOrderPaymentFunc: func(ctx context.Context, orderID uuid.UUID) error {
+//				panic("mock out the VoidOrderPayment method")
+//			},
+//			VoidPaymentFunc: func(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, req models.VoidPaymentRequest) (*models.PaymentCapture, error) {
+//				panic("mock out the VoidPayment method")
+//			},
+//		}
+//
+//		// use mockedPaymentService in code that requires service.PaymentService
+//		// and then make assertions.
+//
+//	}
+type PaymentServiceMock struct {
+	// CaptureOrderPaymentFunc mocks the CaptureOrderPayment method.
+	CaptureOrderPaymentFunc func(ctx context.Context, orderID uuid.UUID) (*models.Payment, error)
+
+	// CapturePaymentFunc mocks the CapturePayment method.
+	CapturePaymentFunc func(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, req models.CapturePaymentRequest) (*models.PaymentCapture, error)
+
+	// ConfirmPaymentFunc mocks the ConfirmPayment method.
+	ConfirmPaymentFunc func(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error)
+
+	// CreatePaymentFunc mocks the CreatePayment method.
+	CreatePaymentFunc func(ctx context.Context, req models.CreatePaymentRequest, userID uuid.UUID) (*models.Payment, error)
+
+	// CreatePaymentForOrderFunc mocks the CreatePaymentForOrder method.
+	CreatePaymentForOrderFunc func(ctx context.Context, orderID uuid.UUID, method string, status models.PaymentStatus) (*models.Payment, error)
+
+	// GetAllPaymentsFunc mocks the GetAllPayments method.
+	GetAllPaymentsFunc func(ctx context.Context, filter models.PaymentFilter, page int, limit int) ([]models.AdminPayment, int, error)
+
+	// GetOrderRefundsFunc mocks the GetOrderRefunds method.
+	GetOrderRefundsFunc func(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) ([]models.Refund, error)
+
+	// GetPaymentByOrderIDFunc mocks the GetPaymentByOrderID method.
+	GetPaymentByOrderIDFunc func(ctx context.Context, orderID uuid.UUID) (*models.Payment, error)
+
+	// GetPaymentStatusFunc mocks the GetPaymentStatus method.
+	GetPaymentStatusFunc func(ctx context.Context, paymentID uuid.UUID, userID uuid.UUID) (*models.PaymentStatusResponse, error)
+
+	// HandleWebhookFunc mocks the HandleWebhook method.
+	HandleWebhookFunc func(ctx context.Context, provider string, payload []byte, signature string) error
+
+	// InitiateCardPaymentFunc mocks the InitiateCardPayment method.
+	InitiateCardPaymentFunc func(ctx context.Context, orderID uuid.UUID, method string, saved *models.SavedPaymentMethod) (*models.Payment, error)
+
+	// ProcessRefundFunc mocks the ProcessRefund method.
+	ProcessRefundFunc func(ctx context.Context, refund *models.Refund) error
+
+	// ReconcilePaymentsFunc mocks the ReconcilePayments method.
+	ReconcilePaymentsFunc func(ctx context.Context, rangeDays int) (*models.PaymentReconciliation, error)
+
+	// VerifyPaymentFunc mocks the VerifyPayment method.
+	VerifyPaymentFunc func(ctx context.Context, req models.VerifyPaymentRequest) (*models.Payment, error)
+
+	// VoidOrderPaymentFunc mocks the VoidOrderPayment method.
+	VoidOrderPaymentFunc func(ctx context.Context, orderID uuid.UUID) error
+
+	// VoidPaymentFunc mocks the VoidPayment method.
+	VoidPaymentFunc func(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, req models.VoidPaymentRequest) (*models.PaymentCapture, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CaptureOrderPayment holds details about calls to the CaptureOrderPayment method.
+		CaptureOrderPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// CapturePayment holds details about calls to the CapturePayment method.
+		CapturePayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PaymentID is the paymentID argument value.
+			PaymentID uuid.UUID
+			// AdminID is the adminID argument value.
+			AdminID uuid.UUID
+			// Req is the req argument value.
+			Req models.CapturePaymentRequest
+		}
+		// ConfirmPayment holds details about calls to the ConfirmPayment method.
+		ConfirmPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PaymentID is the paymentID argument value.
+			PaymentID uuid.UUID
+		}
+		// CreatePayment holds details about calls to the CreatePayment method.
+		CreatePayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CreatePaymentRequest
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// CreatePaymentForOrder holds details about calls to the CreatePaymentForOrder method.
+		CreatePaymentForOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Method is the method argument value.
+			Method string
+			// Status is the status argument value.
+			Status models.PaymentStatus
+		}
+		// GetAllPayments holds details about calls to the GetAllPayments method.
+		GetAllPayments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.PaymentFilter
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetOrderRefunds holds details about calls to the GetOrderRefunds method.
+		GetOrderRefunds []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// GetPaymentByOrderID holds details about calls to the GetPaymentByOrderID method.
+		GetPaymentByOrderID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// GetPaymentStatus holds details about calls to the GetPaymentStatus method.
+		GetPaymentStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PaymentID is the paymentID argument value.
+			PaymentID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// HandleWebhook holds details about calls to the HandleWebhook method.
+		HandleWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Payload is the payload argument value.
+			Payload []byte
+			// Signature is the signature argument value.
+			Signature string
+		}
+		// InitiateCardPayment holds details about calls to the InitiateCardPayment method.
+		InitiateCardPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+			// Method is the method argument value.
+			Method string
+			// Saved is the saved argument value.
+			Saved *models.SavedPaymentMethod
+		}
+		// ProcessRefund holds details about calls to the ProcessRefund method.
+		ProcessRefund []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Refund is the refund argument value.
+			Refund *models.Refund
+		}
+		// ReconcilePayments holds details about calls to the ReconcilePayments method.
+		ReconcilePayments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+		}
+		// VerifyPayment holds details about calls to the VerifyPayment method.
+		VerifyPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.VerifyPaymentRequest
+		}
+		// VoidOrderPayment holds details about calls to the VoidOrderPayment method.
+		VoidOrderPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrderID is the orderID argument value.
+			OrderID uuid.UUID
+		}
+		// VoidPayment holds details about calls to the VoidPayment method.
+		VoidPayment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PaymentID is the paymentID argument value.
+			PaymentID uuid.UUID
+			// AdminID is the adminID argument value.
+			AdminID uuid.UUID
+			// Req is the req argument value.
+			Req models.VoidPaymentRequest
+		}
+	}
+	lockCaptureOrderPayment   sync.RWMutex
+	lockCapturePayment        sync.RWMutex
+	lockConfirmPayment        sync.RWMutex
+	lockCreatePayment         sync.RWMutex
+	lockCreatePaymentForOrder sync.RWMutex
+	lockGetAllPayments        sync.RWMutex
+	lockGetOrderRefunds       sync.RWMutex
+	lockGetPaymentByOrderID   sync.RWMutex
+	lockGetPaymentStatus      sync.RWMutex
+	lockHandleWebhook         sync.RWMutex
+	lockInitiateCardPayment   sync.RWMutex
+	lockProcessRefund         sync.RWMutex
+	lockReconcilePayments     sync.RWMutex
+	lockVerifyPayment         sync.RWMutex
+	lockVoidOrderPayment      sync.RWMutex
+	lockVoidPayment           sync.RWMutex
+}
+
+// CaptureOrderPayment calls CaptureOrderPaymentFunc.
+func (mock *PaymentServiceMock) CaptureOrderPayment(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
+	if mock.CaptureOrderPaymentFunc == nil {
+		panic("PaymentServiceMock.CaptureOrderPaymentFunc: method is nil but PaymentService.CaptureOrderPayment was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockCaptureOrderPayment.Lock()
+	mock.calls.CaptureOrderPayment = append(mock.calls.CaptureOrderPayment, callInfo)
+	mock.lockCaptureOrderPayment.Unlock()
+	return mock.CaptureOrderPaymentFunc(ctx, orderID)
+}
+
+// CaptureOrderPaymentCalls gets all the calls that were made to CaptureOrderPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.CaptureOrderPaymentCalls())
+func (mock *PaymentServiceMock) CaptureOrderPaymentCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockCaptureOrderPayment.RLock()
+	calls = mock.calls.CaptureOrderPayment
+	mock.lockCaptureOrderPayment.RUnlock()
+	return calls
+}
+
+// CapturePayment calls CapturePaymentFunc.
+func (mock *PaymentServiceMock) CapturePayment(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, req models.CapturePaymentRequest) (*models.PaymentCapture, error) {
+	if mock.CapturePaymentFunc == nil {
+		panic("PaymentServiceMock.CapturePaymentFunc: method is nil but PaymentService.CapturePayment was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+		AdminID   uuid.UUID
+		Req       models.CapturePaymentRequest
+	}{
+		Ctx:       ctx,
+		PaymentID: paymentID,
+		AdminID:   adminID,
+		Req:       req,
+	}
+	mock.lockCapturePayment.Lock()
+	mock.calls.CapturePayment = append(mock.calls.CapturePayment, callInfo)
+	mock.lockCapturePayment.Unlock()
+	return mock.CapturePaymentFunc(ctx, paymentID, adminID, req)
+}
+
+// CapturePaymentCalls gets all the calls that were made to CapturePayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.CapturePaymentCalls())
+func (mock *PaymentServiceMock) CapturePaymentCalls() []struct {
+	Ctx       context.Context
+	PaymentID uuid.UUID
+	AdminID   uuid.UUID
+	Req       models.CapturePaymentRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+		AdminID   uuid.UUID
+		Req       models.CapturePaymentRequest
+	}
+	mock.lockCapturePayment.RLock()
+	calls = mock.calls.CapturePayment
+	mock.lockCapturePayment.RUnlock()
+	return calls
+}
+
+// ConfirmPayment calls ConfirmPaymentFunc.
+func (mock *PaymentServiceMock) ConfirmPayment(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+	if mock.ConfirmPaymentFunc == nil {
+		panic("PaymentServiceMock.ConfirmPaymentFunc: method is nil but PaymentService.ConfirmPayment was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+	}{
+		Ctx:       ctx,
+		PaymentID: paymentID,
+	}
+	mock.lockConfirmPayment.Lock()
+	mock.calls.ConfirmPayment = append(mock.calls.ConfirmPayment, callInfo)
+	mock.lockConfirmPayment.Unlock()
+	return mock.ConfirmPaymentFunc(ctx, paymentID)
+}
+
+// ConfirmPaymentCalls gets all the calls that were made to ConfirmPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.ConfirmPaymentCalls())
+func (mock *PaymentServiceMock) ConfirmPaymentCalls() []struct {
+	Ctx       context.Context
+	PaymentID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+	}
+	mock.lockConfirmPayment.RLock()
+	calls = mock.calls.ConfirmPayment
+	mock.lockConfirmPayment.RUnlock()
+	return calls
+}
+
+// CreatePayment calls CreatePaymentFunc.
+func (mock *PaymentServiceMock) CreatePayment(ctx context.Context, req models.CreatePaymentRequest, userID uuid.UUID) (*models.Payment, error) {
+	if mock.CreatePaymentFunc == nil {
+		panic("PaymentServiceMock.CreatePaymentFunc: method is nil but PaymentService.CreatePayment was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Req    models.CreatePaymentRequest
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		Req:    req,
+		UserID: userID,
+	}
+	mock.lockCreatePayment.Lock()
+	mock.calls.CreatePayment = append(mock.calls.CreatePayment, callInfo)
+	mock.lockCreatePayment.Unlock()
+	return mock.CreatePaymentFunc(ctx, req, userID)
+}
+
+// CreatePaymentCalls gets all the calls that were made to CreatePayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.CreatePaymentCalls())
+func (mock *PaymentServiceMock) CreatePaymentCalls() []struct {
+	Ctx    context.Context
+	Req    models.CreatePaymentRequest
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Req    models.CreatePaymentRequest
+		UserID uuid.UUID
+	}
+	mock.lockCreatePayment.RLock()
+	calls = mock.calls.CreatePayment
+	mock.lockCreatePayment.RUnlock()
+	return calls
+}
+
+// CreatePaymentForOrder calls CreatePaymentForOrderFunc.
+func (mock *PaymentServiceMock) CreatePaymentForOrder(ctx context.Context, orderID uuid.UUID, method string, status models.PaymentStatus) (*models.Payment, error) {
+	if mock.CreatePaymentForOrderFunc == nil {
+		panic("PaymentServiceMock.CreatePaymentForOrderFunc: method is nil but PaymentService.CreatePaymentForOrder was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Method  string
+		Status  models.PaymentStatus
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		Method:  method,
+		Status:  status,
+	}
+	mock.lockCreatePaymentForOrder.Lock()
+	mock.calls.CreatePaymentForOrder = append(mock.calls.CreatePaymentForOrder, callInfo)
+	mock.lockCreatePaymentForOrder.Unlock()
+	return mock.CreatePaymentForOrderFunc(ctx, orderID, method, status)
+}
+
+// CreatePaymentForOrderCalls gets all the calls that were made to CreatePaymentForOrder.
+// Check the length with:
+//
+//	len(mockedPaymentService.CreatePaymentForOrderCalls())
+func (mock *PaymentServiceMock) CreatePaymentForOrderCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	Method  string
+	Status  models.PaymentStatus
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Method  string
+		Status  models.PaymentStatus
+	}
+	mock.lockCreatePaymentForOrder.RLock()
+	calls = mock.calls.CreatePaymentForOrder
+	mock.lockCreatePaymentForOrder.RUnlock()
+	return calls
+}
+
+// GetAllPayments calls GetAllPaymentsFunc.
+func (mock *PaymentServiceMock) GetAllPayments(ctx context.Context, filter models.PaymentFilter, page int, limit int) ([]models.AdminPayment, int, error) {
+	if mock.GetAllPaymentsFunc == nil {
+		panic("PaymentServiceMock.GetAllPaymentsFunc: method is nil but PaymentService.GetAllPayments was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.PaymentFilter
+		Page   int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Page:   page,
+		Limit:  limit,
+	}
+	mock.lockGetAllPayments.Lock()
+	mock.calls.GetAllPayments = append(mock.calls.GetAllPayments, callInfo)
+	mock.lockGetAllPayments.Unlock()
+	return mock.GetAllPaymentsFunc(ctx, filter, page, limit)
+}
+
+// GetAllPaymentsCalls gets all the calls that were made to GetAllPayments.
+// Check the length with:
+//
+//	len(mockedPaymentService.GetAllPaymentsCalls())
+func (mock *PaymentServiceMock) GetAllPaymentsCalls() []struct {
+	Ctx    context.Context
+	Filter models.PaymentFilter
+	Page   int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.PaymentFilter
+		Page   int
+		Limit  int
+	}
+	mock.lockGetAllPayments.RLock()
+	calls = mock.calls.GetAllPayments
+	mock.lockGetAllPayments.RUnlock()
+	return calls
+}
+
+// GetOrderRefunds calls GetOrderRefundsFunc.
+func (mock *PaymentServiceMock) GetOrderRefunds(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) ([]models.Refund, error) {
+	if mock.GetOrderRefundsFunc == nil {
+		panic("PaymentServiceMock.GetOrderRefundsFunc: method is nil but PaymentService.GetOrderRefunds was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		UserID:  userID,
+	}
+	mock.lockGetOrderRefunds.Lock()
+	mock.calls.GetOrderRefunds = append(mock.calls.GetOrderRefunds, callInfo)
+	mock.lockGetOrderRefunds.Unlock()
+	return mock.GetOrderRefundsFunc(ctx, orderID, userID)
+}
+
+// GetOrderRefundsCalls gets all the calls that were made to GetOrderRefunds.
+// Check the length with:
+//
+//	len(mockedPaymentService.GetOrderRefundsCalls())
+func (mock *PaymentServiceMock) GetOrderRefundsCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	UserID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		UserID  uuid.UUID
+	}
+	mock.lockGetOrderRefunds.RLock()
+	calls = mock.calls.GetOrderRefunds
+	mock.lockGetOrderRefunds.RUnlock()
+	return calls
+}
+
+// GetPaymentByOrderID calls GetPaymentByOrderIDFunc.
+func (mock *PaymentServiceMock) GetPaymentByOrderID(ctx context.Context, orderID uuid.UUID) (*models.Payment, error) {
+	if mock.GetPaymentByOrderIDFunc == nil {
+		panic("PaymentServiceMock.GetPaymentByOrderIDFunc: method is nil but PaymentService.GetPaymentByOrderID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockGetPaymentByOrderID.Lock()
+	mock.calls.GetPaymentByOrderID = append(mock.calls.GetPaymentByOrderID, callInfo)
+	mock.lockGetPaymentByOrderID.Unlock()
+	return mock.GetPaymentByOrderIDFunc(ctx, orderID)
+}
+
+// GetPaymentByOrderIDCalls gets all the calls that were made to GetPaymentByOrderID.
+// Check the length with:
+//
+//	len(mockedPaymentService.GetPaymentByOrderIDCalls())
+func (mock *PaymentServiceMock) GetPaymentByOrderIDCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockGetPaymentByOrderID.RLock()
+	calls = mock.calls.GetPaymentByOrderID
+	mock.lockGetPaymentByOrderID.RUnlock()
+	return calls
+}
+
+// GetPaymentStatus calls GetPaymentStatusFunc.
+func (mock *PaymentServiceMock) GetPaymentStatus(ctx context.Context, paymentID uuid.UUID, userID uuid.UUID) (*models.PaymentStatusResponse, error) {
+	if mock.GetPaymentStatusFunc == nil {
+		panic("PaymentServiceMock.GetPaymentStatusFunc: method is nil but PaymentService.GetPaymentStatus was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+		UserID    uuid.UUID
+	}{
+		Ctx:       ctx,
+		PaymentID: paymentID,
+		UserID:    userID,
+	}
+	mock.lockGetPaymentStatus.Lock()
+	mock.calls.GetPaymentStatus = append(mock.calls.GetPaymentStatus, callInfo)
+	mock.lockGetPaymentStatus.Unlock()
+	return mock.GetPaymentStatusFunc(ctx, paymentID, userID)
+}
+
+// GetPaymentStatusCalls gets all the calls that were made to GetPaymentStatus.
+// Check the length with:
+//
+//	len(mockedPaymentService.GetPaymentStatusCalls())
+func (mock *PaymentServiceMock) GetPaymentStatusCalls() []struct {
+	Ctx       context.Context
+	PaymentID uuid.UUID
+	UserID    uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+		UserID    uuid.UUID
+	}
+	mock.lockGetPaymentStatus.RLock()
+	calls = mock.calls.GetPaymentStatus
+	mock.lockGetPaymentStatus.RUnlock()
+	return calls
+}
+
+// HandleWebhook calls HandleWebhookFunc.
+func (mock *PaymentServiceMock) HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) error {
+	if mock.HandleWebhookFunc == nil {
+		panic("PaymentServiceMock.HandleWebhookFunc: method is nil but PaymentService.HandleWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Provider  string
+		Payload   []byte
+		Signature string
+	}{
+		Ctx:       ctx,
+		Provider:  provider,
+		Payload:   payload,
+		Signature: signature,
+	}
+	mock.lockHandleWebhook.Lock()
+	mock.calls.HandleWebhook = append(mock.calls.HandleWebhook, callInfo)
+	mock.lockHandleWebhook.Unlock()
+	return mock.HandleWebhookFunc(ctx, provider, payload, signature)
+}
+
+// HandleWebhookCalls gets all the calls that were made to HandleWebhook.
+// Check the length with:
+//
+//	len(mockedPaymentService.HandleWebhookCalls())
+func (mock *PaymentServiceMock) HandleWebhookCalls() []struct {
+	Ctx       context.Context
+	Provider  string
+	Payload   []byte
+	Signature string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Provider  string
+		Payload   []byte
+		Signature string
+	}
+	mock.lockHandleWebhook.RLock()
+	calls = mock.calls.HandleWebhook
+	mock.lockHandleWebhook.RUnlock()
+	return calls
+}
+
+// InitiateCardPayment calls InitiateCardPaymentFunc.
+func (mock *PaymentServiceMock) InitiateCardPayment(ctx context.Context, orderID uuid.UUID, method string, saved *models.SavedPaymentMethod) (*models.Payment, error) {
+	if mock.InitiateCardPaymentFunc == nil {
+		panic("PaymentServiceMock.InitiateCardPaymentFunc: method is nil but PaymentService.InitiateCardPayment was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Method  string
+		Saved   *models.SavedPaymentMethod
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+		Method:  method,
+		Saved:   saved,
+	}
+	mock.lockInitiateCardPayment.Lock()
+	mock.calls.InitiateCardPayment = append(mock.calls.InitiateCardPayment, callInfo)
+	mock.lockInitiateCardPayment.Unlock()
+	return mock.InitiateCardPaymentFunc(ctx, orderID, method, saved)
+}
+
+// InitiateCardPaymentCalls gets all the calls that were made to InitiateCardPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.InitiateCardPaymentCalls())
+func (mock *PaymentServiceMock) InitiateCardPaymentCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+	Method  string
+	Saved   *models.SavedPaymentMethod
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+		Method  string
+		Saved   *models.SavedPaymentMethod
+	}
+	mock.lockInitiateCardPayment.RLock()
+	calls = mock.calls.InitiateCardPayment
+	mock.lockInitiateCardPayment.RUnlock()
+	return calls
+}
+
+// ProcessRefund calls ProcessRefundFunc.
+func (mock *PaymentServiceMock) ProcessRefund(ctx context.Context, refund *models.Refund) error {
+	if mock.ProcessRefundFunc == nil {
+		panic("PaymentServiceMock.ProcessRefundFunc: method is nil but PaymentService.ProcessRefund was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Refund *models.Refund
+	}{
+		Ctx:    ctx,
+		Refund: refund,
+	}
+	mock.lockProcessRefund.Lock()
+	mock.calls.ProcessRefund = append(mock.calls.ProcessRefund, callInfo)
+	mock.lockProcessRefund.Unlock()
+	return mock.ProcessRefundFunc(ctx, refund)
+}
+
+// ProcessRefundCalls gets all the calls that were made to ProcessRefund.
+// Check the length with:
+//
+//	len(mockedPaymentService.ProcessRefundCalls())
+func (mock *PaymentServiceMock) ProcessRefundCalls() []struct {
+	Ctx    context.Context
+	Refund *models.Refund
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Refund *models.Refund
+	}
+	mock.lockProcessRefund.RLock()
+	calls = mock.calls.ProcessRefund
+	mock.lockProcessRefund.RUnlock()
+	return calls
+}
+
+// ReconcilePayments calls ReconcilePaymentsFunc.
+func (mock *PaymentServiceMock) ReconcilePayments(ctx context.Context, rangeDays int) (*models.PaymentReconciliation, error) {
+	if mock.ReconcilePaymentsFunc == nil {
+		panic("PaymentServiceMock.ReconcilePaymentsFunc: method is nil but PaymentService.ReconcilePayments was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RangeDays int
+	}{
+		Ctx:       ctx,
+		RangeDays: rangeDays,
+	}
+	mock.lockReconcilePayments.Lock()
+	mock.calls.ReconcilePayments = append(mock.calls.ReconcilePayments, callInfo)
+	mock.lockReconcilePayments.Unlock()
+	return mock.ReconcilePaymentsFunc(ctx, rangeDays)
+}
+
+// ReconcilePaymentsCalls gets all the calls that were made to ReconcilePayments.
+// Check the length with:
+//
+//	len(mockedPaymentService.ReconcilePaymentsCalls())
+func (mock *PaymentServiceMock) ReconcilePaymentsCalls() []struct {
+	Ctx       context.Context
+	RangeDays int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RangeDays int
+	}
+	mock.lockReconcilePayments.RLock()
+	calls = mock.calls.ReconcilePayments
+	mock.lockReconcilePayments.RUnlock()
+	return calls
+}
+
+// VerifyPayment calls VerifyPaymentFunc.
+func (mock *PaymentServiceMock) VerifyPayment(ctx context.Context, req models.VerifyPaymentRequest) (*models.Payment, error) {
+	if mock.VerifyPaymentFunc == nil {
+		panic("PaymentServiceMock.VerifyPaymentFunc: method is nil but PaymentService.VerifyPayment was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.VerifyPaymentRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockVerifyPayment.Lock()
+	mock.calls.VerifyPayment = append(mock.calls.VerifyPayment, callInfo)
+	mock.lockVerifyPayment.Unlock()
+	return mock.VerifyPaymentFunc(ctx, req)
+}
+
+// VerifyPaymentCalls gets all the calls that were made to VerifyPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.VerifyPaymentCalls())
+func (mock *PaymentServiceMock) VerifyPaymentCalls() []struct {
+	Ctx context.Context
+	Req models.VerifyPaymentRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.VerifyPaymentRequest
+	}
+	mock.lockVerifyPayment.RLock()
+	calls = mock.calls.VerifyPayment
+	mock.lockVerifyPayment.RUnlock()
+	return calls
+}
+
+// VoidOrderPayment calls VoidOrderPaymentFunc.
+func (mock *PaymentServiceMock) VoidOrderPayment(ctx context.Context, orderID uuid.UUID) error {
+	if mock.VoidOrderPaymentFunc == nil {
+		panic("PaymentServiceMock.VoidOrderPaymentFunc: method is nil but PaymentService.VoidOrderPayment was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}{
+		Ctx:     ctx,
+		OrderID: orderID,
+	}
+	mock.lockVoidOrderPayment.Lock()
+	mock.calls.VoidOrderPayment = append(mock.calls.VoidOrderPayment, callInfo)
+	mock.lockVoidOrderPayment.Unlock()
+	return mock.VoidOrderPaymentFunc(ctx, orderID)
+}
+
+// VoidOrderPaymentCalls gets all the calls that were made to VoidOrderPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.VoidOrderPaymentCalls())
+func (mock *PaymentServiceMock) VoidOrderPaymentCalls() []struct {
+	Ctx     context.Context
+	OrderID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OrderID uuid.UUID
+	}
+	mock.lockVoidOrderPayment.RLock()
+	calls = mock.calls.VoidOrderPayment
+	mock.lockVoidOrderPayment.RUnlock()
+	return calls
+}
+
+// VoidPayment calls VoidPaymentFunc.
+func (mock *PaymentServiceMock) VoidPayment(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, req models.VoidPaymentRequest) (*models.PaymentCapture, error) {
+	if mock.VoidPaymentFunc == nil {
+		panic("PaymentServiceMock.VoidPaymentFunc: method is nil but PaymentService.VoidPayment was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+		AdminID   uuid.UUID
+		Req       models.VoidPaymentRequest
+	}{
+		Ctx:       ctx,
+		PaymentID: paymentID,
+		AdminID:   adminID,
+		Req:       req,
+	}
+	mock.lockVoidPayment.Lock()
+	mock.calls.VoidPayment = append(mock.calls.VoidPayment, callInfo)
+	mock.lockVoidPayment.Unlock()
+	return mock.VoidPaymentFunc(ctx, paymentID, adminID, req)
+}
+
+// VoidPaymentCalls gets all the calls that were made to VoidPayment.
+// Check the length with:
+//
+//	len(mockedPaymentService.VoidPaymentCalls())
+func (mock *PaymentServiceMock) VoidPaymentCalls() []struct {
+	Ctx       context.Context
+	PaymentID uuid.UUID
+	AdminID   uuid.UUID
+	Req       models.VoidPaymentRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		PaymentID uuid.UUID
+		AdminID   uuid.UUID
+		Req       models.VoidPaymentRequest
+	}
+	mock.lockVoidPayment.RLock()
+	calls = mock.calls.VoidPayment
+	mock.lockVoidPayment.RUnlock()
+	return calls
+}
+
+// Ensure, that PhoneAuthServiceMock does implement service.PhoneAuthService.
+// If this is not the case, regenerate this file with moq.
+var _ service.PhoneAuthService = &PhoneAuthServiceMock{}
+
+// PhoneAuthServiceMock is a mock implementation of service.PhoneAuthService.
+//
+//	func TestSomethingThatUsesPhoneAuthService(t *testing.T) {
+//
+//		// make and configure a mocked service.PhoneAuthService
+//		mockedPhoneAuthService := &PhoneAuthServiceMock{
+//			LoginWithOTPFunc: func(ctx context.Context, phone string, code string) (*models.LoginResponse, error) {
+//				panic("mock out the LoginWithOTP method")
+//			},
+//			RequestLoginOTPFunc: func(ctx context.Context, phone string) error {
+//				panic("mock out the RequestLoginOTP method")
+//			},
+//			RequestReauthOTPFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the RequestReauthOTP method")
+//			},
+//			SetPhoneFunc: func(ctx context.Context, userID uuid.UUID, phone string) error {
+//				panic("mock out the SetPhone method")
+//			},
+//			VerifyPhoneFunc: func(ctx context.Context, userID uuid.UUID, code string) (*models.User, error) {
+//				panic("mock out the VerifyPhone method")
+//			},
+//		}
+//
+//		// use mockedPhoneAuthService in code that requires service.PhoneAuthService
+//		// and then make assertions.
+//
+//	}
+type PhoneAuthServiceMock struct {
+	// LoginWithOTPFunc mocks the LoginWithOTP method.
+	LoginWithOTPFunc func(ctx context.Context, phone string, code string) (*models.LoginResponse, error)
+
+	// RequestLoginOTPFunc mocks the RequestLoginOTP method.
+	RequestLoginOTPFunc func(ctx context.Context, phone string) error
+
+	// RequestReauthOTPFunc mocks the RequestReauthOTP method.
+	RequestReauthOTPFunc func(ctx context.Context, userID uuid.UUID) error
+
+	// SetPhoneFunc mocks the SetPhone method.
+	SetPhoneFunc func(ctx context.Context, userID uuid.UUID, phone string) error
+
+	// VerifyPhoneFunc mocks the VerifyPhone method.
+	VerifyPhoneFunc func(ctx context.Context, userID uuid.UUID, code string) (*models.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// LoginWithOTP holds details about calls to the LoginWithOTP method.
+		LoginWithOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Phone is the phone argument value.
+			Phone string
+			// Code is the code argument value.
+			Code string
+		}
+		// RequestLoginOTP holds details about calls to the RequestLoginOTP method.
+		RequestLoginOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// RequestReauthOTP holds details about calls to the RequestReauthOTP method.
+		RequestReauthOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// SetPhone holds details about calls to the SetPhone method.
+		SetPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Phone is the phone argument value.
+			Phone string
+		}
+		// VerifyPhone holds details about calls to the VerifyPhone method.
+		VerifyPhone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Code is the code argument value.
+			Code string
+		}
+	}
+	lockLoginWithOTP     sync.RWMutex
+	lockRequestLoginOTP  sync.RWMutex
+	lockRequestReauthOTP sync.RWMutex
+	lockSetPhone         sync.RWMutex
+	lockVerifyPhone      sync.RWMutex
+}
+
+// LoginWithOTP calls LoginWithOTPFunc.
+func (mock *PhoneAuthServiceMock) LoginWithOTP(ctx context.Context, phone string, code string) (*models.LoginResponse, error) {
+	if mock.LoginWithOTPFunc == nil {
+		panic("PhoneAuthServiceMock.LoginWithOTPFunc: method is nil but PhoneAuthService.LoginWithOTP was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Phone string
+		Code  string
+	}{
+		Ctx:   ctx,
+		Phone: phone,
+		Code:  code,
+	}
+	mock.lockLoginWithOTP.Lock()
+	mock.calls.LoginWithOTP = append(mock.calls.LoginWithOTP, callInfo)
+	mock.lockLoginWithOTP.Unlock()
+	return mock.LoginWithOTPFunc(ctx, phone, code)
+}
+
+// LoginWithOTPCalls gets all the calls that were made to LoginWithOTP.
+// Check the length with:
+//
+//	len(mockedPhoneAuthService.LoginWithOTPCalls())
+func (mock *PhoneAuthServiceMock) LoginWithOTPCalls() []struct {
+	Ctx   context.Context
+	Phone string
+	Code  string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Phone string
+		Code  string
+	}
+	mock.lockLoginWithOTP.RLock()
+	calls = mock.calls.LoginWithOTP
+	mock.lockLoginWithOTP.RUnlock()
+	return calls
+}
+
+// RequestLoginOTP calls RequestLoginOTPFunc.
+func (mock *PhoneAuthServiceMock) RequestLoginOTP(ctx context.Context, phone string) error {
+	if mock.RequestLoginOTPFunc == nil {
+		panic("PhoneAuthServiceMock.RequestLoginOTPFunc: method is nil but PhoneAuthService.RequestLoginOTP was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Phone string
+	}{
+		Ctx:   ctx,
+		Phone: phone,
+	}
+	mock.lockRequestLoginOTP.Lock()
+	mock.calls.RequestLoginOTP = append(mock.calls.RequestLoginOTP, callInfo)
+	mock.lockRequestLoginOTP.Unlock()
+	return mock.RequestLoginOTPFunc(ctx, phone)
+}
+
+// RequestLoginOTPCalls gets all the calls that were made to RequestLoginOTP.
+// Check the length with:
+//
+//	len(mockedPhoneAuthService.RequestLoginOTPCalls())
+func (mock *PhoneAuthServiceMock) RequestLoginOTPCalls() []struct {
+	Ctx   context.Context
+	Phone string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Phone string
+	}
+	mock.lockRequestLoginOTP.RLock()
+	calls = mock.calls.RequestLoginOTP
+	mock.lockRequestLoginOTP.RUnlock()
+	return calls
+}
+
+// RequestReauthOTP calls RequestReauthOTPFunc.
+func (mock *PhoneAuthServiceMock) RequestReauthOTP(ctx context.Context, userID uuid.UUID) error {
+	if mock.RequestReauthOTPFunc == nil {
+		panic("PhoneAuthServiceMock.RequestReauthOTPFunc: method is nil but PhoneAuthService.RequestReauthOTP was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRequestReauthOTP.Lock()
+	mock.calls.RequestReauthOTP = append(mock.calls.RequestReauthOTP, callInfo)
+	mock.lockRequestReauthOTP.Unlock()
+	return mock.RequestReauthOTPFunc(ctx, userID)
+}
+
+// RequestReauthOTPCalls gets all the calls that were made to RequestReauthOTP.
+// Check the length with:
+//
+//	len(mockedPhoneAuthService.RequestReauthOTPCalls())
+func (mock *PhoneAuthServiceMock) RequestReauthOTPCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockRequestReauthOTP.RLock()
+	calls = mock.calls.RequestReauthOTP
+	mock.lockRequestReauthOTP.RUnlock()
+	return calls
+}
+
+// SetPhone calls SetPhoneFunc.
+func (mock *PhoneAuthServiceMock) SetPhone(ctx context.Context, userID uuid.UUID, phone string) error {
+	if mock.SetPhoneFunc == nil {
+		panic("PhoneAuthServiceMock.SetPhoneFunc: method is nil but PhoneAuthService.SetPhone was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Phone  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Phone:  phone,
+	}
+	mock.lockSetPhone.Lock()
+	mock.calls.SetPhone = append(mock.calls.SetPhone, callInfo)
+	mock.lockSetPhone.Unlock()
+	return mock.SetPhoneFunc(ctx, userID, phone)
+}
+
+// SetPhoneCalls gets all the calls that were made to SetPhone.
+// Check the length with:
+//
+//	len(mockedPhoneAuthService.SetPhoneCalls())
+func (mock *PhoneAuthServiceMock) SetPhoneCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Phone  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Phone  string
+	}
+	mock.lockSetPhone.RLock()
+	calls = mock.calls.SetPhone
+	mock.lockSetPhone.RUnlock()
+	return calls
+}
+
+// VerifyPhone calls VerifyPhoneFunc.
+func (mock *PhoneAuthServiceMock) VerifyPhone(ctx context.Context, userID uuid.UUID, code string) (*models.User, error) {
+	if mock.VerifyPhoneFunc == nil {
+		panic("PhoneAuthServiceMock.VerifyPhoneFunc: method is nil but PhoneAuthService.VerifyPhone was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockVerifyPhone.Lock()
+	mock.calls.VerifyPhone = append(mock.calls.VerifyPhone, callInfo)
+	mock.lockVerifyPhone.Unlock()
+	return mock.VerifyPhoneFunc(ctx, userID, code)
+}
+
+// VerifyPhoneCalls gets all the calls that were made to VerifyPhone.
+// Check the length with:
+//
+//	len(mockedPhoneAuthService.VerifyPhoneCalls())
+func (mock *PhoneAuthServiceMock) VerifyPhoneCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Code   string
+	}
+	mock.lockVerifyPhone.RLock()
+	calls = mock.calls.VerifyPhone
+	mock.lockVerifyPhone.RUnlock()
+	return calls
+}
+
+// Ensure, that PricingServiceMock does implement service.PricingService.
+// If this is not the case, regenerate this file with moq.
+var _ service.PricingService = &PricingServiceMock{}
+
+// PricingServiceMock is a mock implementation of service.PricingService.
+//
+//	func TestSomethingThatUsesPricingService(t *testing.T) {
+//
+//		// make and configure a mocked service.PricingService
+//		mockedPricingService := &PricingServiceMock{
+//			ApplyToCartItemsFunc: func(ctx context.Context, items []models.CartItem) error {
+//				panic("mock out the ApplyToCartItems method")
+//			},
+//			ApplyToProductsFunc: func(ctx context.Context, products []models.Product) error {
+//				panic("mock out the ApplyToProducts method")
+//			},
+//			DeleteScheduleFunc: func(ctx context.Context, productID uuid.UUID, scheduleID uuid.UUID) error {
+//				panic("mock out the DeleteSchedule method")
+//			},
+//			GetSchedulesFunc: func(ctx context.Context, productID uuid.UUID) ([]models.PriceSchedule, error) {
+//				panic("mock out the GetSchedules method")
+//			},
+//			SchedulePriceFunc: func(ctx context.Context, productID uuid.UUID, req models.PriceScheduleRequest) (*models.PriceSchedule, error) {
+//				panic("mock out the SchedulePrice method")
+//			},
+//		}
+//
+//		// use mockedPricingService in code that requires service.PricingService
+//		// and then make assertions.
+//
+//	}
+type PricingServiceMock struct {
+	// ApplyToCartItemsFunc mocks the ApplyToCartItems method.
+	ApplyToCartItemsFunc func(ctx context.Context, items []models.CartItem) error
+
+	// ApplyToProductsFunc mocks the ApplyToProducts method.
+	ApplyToProductsFunc func(ctx context.Context, products []models.Product) error
+
+	// DeleteScheduleFunc mocks the DeleteSchedule method.
+	DeleteScheduleFunc func(ctx context.Context, productID uuid.UUID, scheduleID uuid.UUID) error
+
+	// GetSchedulesFunc mocks the GetSchedules method.
+	GetSchedulesFunc func(ctx context.Context, productID uuid.UUID) ([]models.PriceSchedule, error)
+
+	// SchedulePriceFunc mocks the SchedulePrice method.
+	SchedulePriceFunc func(ctx context.Context, productID uuid.UUID, req models.PriceScheduleRequest) (*models.PriceSchedule, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ApplyToCartItems holds details about calls to the ApplyToCartItems method.
+		ApplyToCartItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Items is the items argument value.
+			Items []models.CartItem
+		}
+		// ApplyToProducts holds details about calls to the ApplyToProducts method.
+		ApplyToProducts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Products is the products argument value.
+			Products []models.Product
+		}
+		// DeleteSchedule holds details about calls to the DeleteSchedule method.
+		DeleteSchedule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// ScheduleID is the scheduleID argument value.
+			ScheduleID uuid.UUID
+		}
+		// GetSchedules holds details about calls to the GetSchedules method.
+		GetSchedules []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+		}
+		// SchedulePrice holds details about calls to the SchedulePrice method.
+		SchedulePrice []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// Req is the req argument value.
+			Req models.PriceScheduleRequest
+		}
+	}
+	lockApplyToCartItems sync.RWMutex
+	lockApplyToProducts  sync.RWMutex
+	lockDeleteSchedule   sync.RWMutex
+	lockGetSchedules     sync.RWMutex
+	lockSchedulePrice    sync.RWMutex
+}
+
+// ApplyToCartItems calls ApplyToCartItemsFunc.
+func (mock *PricingServiceMock) ApplyToCartItems(ctx context.Context, items []models.CartItem) error {
+	if mock.ApplyToCartItemsFunc == nil {
+		panic("PricingServiceMock.ApplyToCartItemsFunc: method is nil but PricingService.ApplyToCartItems was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Items []models.CartItem
+	}{
+		Ctx:   ctx,
+		Items: items,
+	}
+	mock.lockApplyToCartItems.Lock()
+	mock.calls.ApplyToCartItems = append(mock.calls.ApplyToCartItems, callInfo)
+	mock.lockApplyToCartItems.Unlock()
+	return mock.ApplyToCartItemsFunc(ctx, items)
+}
+
+// ApplyToCartItemsCalls gets all the calls that were made to ApplyToCartItems.
+// Check the length with:
+//
+//	len(mockedPricingService.ApplyToCartItemsCalls())
+func (mock *PricingServiceMock) ApplyToCartItemsCalls() []struct {
+	Ctx   context.Context
+	Items []models.CartItem
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Items []models.CartItem
+	}
+	mock.lockApplyToCartItems.RLock()
+	calls = mock.calls.ApplyToCartItems
+	mock.lockApplyToCartItems.RUnlock()
+	return calls
+}
+
+// ApplyToProducts calls ApplyToProductsFunc.
+func (mock *PricingServiceMock) ApplyToProducts(ctx context.Context, products []models.Product) error {
+	if mock.ApplyToProductsFunc == nil {
+		panic("PricingServiceMock.ApplyToProductsFunc: method is nil but PricingService.ApplyToProducts was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Products []models.Product
+	}{
+		Ctx:      ctx,
+		Products: products,
+	}
+	mock.lockApplyToProducts.Lock()
+	mock.calls.ApplyToProducts = append(mock.calls.ApplyToProducts, callInfo)
+	mock.lockApplyToProducts.Unlock()
+	return mock.ApplyToProductsFunc(ctx, products)
+}
+
+// ApplyToProductsCalls gets all the calls that were made to ApplyToProducts.
+// Check the length with:
+//
+//	len(mockedPricingService.ApplyToProductsCalls())
+func (mock *PricingServiceMock) ApplyToProductsCalls() []struct {
+	Ctx      context.Context
+	Products []models.Product
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Products []models.Product
+	}
+	mock.lockApplyToProducts.RLock()
+	calls = mock.calls.ApplyToProducts
+	mock.lockApplyToProducts.RUnlock()
+	return calls
+}
+
+// DeleteSchedule calls DeleteScheduleFunc.
+func (mock *PricingServiceMock) DeleteSchedule(ctx context.Context, productID uuid.UUID, scheduleID uuid.UUID) error {
+	if mock.DeleteScheduleFunc == nil {
+		panic("PricingServiceMock.DeleteScheduleFunc: method is nil but PricingService.DeleteSchedule was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ProductID  uuid.UUID
+		ScheduleID uuid.UUID
+	}{
+		Ctx:        ctx,
+		ProductID:  productID,
+		ScheduleID: scheduleID,
+	}
+	mock.lockDeleteSchedule.Lock()
+	mock.calls.DeleteSchedule = append(mock.calls.DeleteSchedule, callInfo)
+	mock.lockDeleteSchedule.Unlock()
+	return mock.DeleteScheduleFunc(ctx, productID, scheduleID)
+}
+
+// DeleteScheduleCalls gets all the calls that were made to DeleteSchedule.
+// Check the length with:
+//
+//	len(mockedPricingService.DeleteScheduleCalls())
+func (mock *PricingServiceMock) DeleteScheduleCalls() []struct {
+	Ctx        context.Context
+	ProductID  uuid.UUID
+	ScheduleID uuid.UUID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ProductID  uuid.UUID
+		ScheduleID uuid.UUID
+	}
+	mock.lockDeleteSchedule.RLock()
+	calls = mock.calls.DeleteSchedule
+	mock.lockDeleteSchedule.RUnlock()
+	return calls
+}
+
+// GetSchedules calls GetSchedulesFunc.
+func (mock *PricingServiceMock) GetSchedules(ctx context.Context, productID uuid.UUID) ([]models.PriceSchedule, error) {
+	if mock.GetSchedulesFunc == nil {
+		panic("PricingServiceMock.GetSchedulesFunc: method is nil but PricingService.GetSchedules was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+	}
+	mock.lockGetSchedules.Lock()
+	mock.calls.GetSchedules = append(mock.calls.GetSchedules, callInfo)
+	mock.lockGetSchedules.Unlock()
+	return mock.GetSchedulesFunc(ctx, productID)
+}
+
+// GetSchedulesCalls gets all the calls that were made to GetSchedules.
+// Check the length with:
+//
+//	len(mockedPricingService.GetSchedulesCalls())
+func (mock *PricingServiceMock) GetSchedulesCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}
+	mock.lockGetSchedules.RLock()
+	calls = mock.calls.GetSchedules
+	mock.lockGetSchedules.RUnlock()
+	return calls
+}
+
+// SchedulePrice calls SchedulePriceFunc.
+func (mock *PricingServiceMock) SchedulePrice(ctx context.Context, productID uuid.UUID, req models.PriceScheduleRequest) (*models.PriceSchedule, error) {
+	if mock.SchedulePriceFunc == nil {
+		panic("PricingServiceMock.SchedulePriceFunc: method is nil but PricingService.SchedulePrice was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.PriceScheduleRequest
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		Req:       req,
+	}
+	mock.lockSchedulePrice.Lock()
+	mock.calls.SchedulePrice = append(mock.calls.SchedulePrice, callInfo)
+	mock.lockSchedulePrice.Unlock()
+	return mock.SchedulePriceFunc(ctx, productID, req)
+}
+
+// SchedulePriceCalls gets all the calls that were made to SchedulePrice.
+// Check the length with:
+//
+//	len(mockedPricingService.SchedulePriceCalls())
+func (mock *PricingServiceMock) SchedulePriceCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	Req       models.PriceScheduleRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.PriceScheduleRequest
+	}
+	mock.lockSchedulePrice.RLock()
+	calls = mock.calls.SchedulePrice
+	mock.lockSchedulePrice.RUnlock()
+	return calls
+}
+
+// Ensure, that ProductImportServiceMock does implement service.ProductImportService.
+// If this is not the case, regenerate this file with moq.
+var _ service.ProductImportService = &ProductImportServiceMock{}
+
+// ProductImportServiceMock is a mock implementation of service.ProductImportService.
+//
+//	func TestSomethingThatUsesProductImportService(t *testing.T) {
+//
+//		// make and configure a mocked service.ProductImportService
+//		mockedProductImportService := &ProductImportServiceMock{
+//			GetImportJobFunc: func(ctx context.Context, jobID uuid.UUID) (*models.ProductImportJob, error) {
+//				panic("mock out the GetImportJob method")
+//			},
+//			StartImportFunc: func(ctx context.Context, filename string, src io.Reader, userID uuid.UUID) (*models.ProductImportJob, error) {
+//				panic("mock out the StartImport method")
+//			},
+//		}
+//
+//		// use mockedProductImportService in code that requires service.ProductImportService
+//		// and then make assertions.
+//
+//	}
+type ProductImportServiceMock struct {
+	// GetImportJobFunc mocks the GetImportJob method.
+	GetImportJobFunc func(ctx context.Context, jobID uuid.UUID) (*models.ProductImportJob, error)
+
+	// StartImportFunc mocks the StartImport method.
+	StartImportFunc func(ctx context.Context, filename string, src io.Reader, userID uuid.UUID) (*models.ProductImportJob, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetImportJob holds details about calls to the GetImportJob method.
+		GetImportJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID uuid.UUID
+		}
+		// StartImport holds details about calls to the StartImport method.
+		StartImport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filename is the filename argument value.
+			Filename string
+			// Src is the src argument value.
+			Src io.Reader
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+	}
+	lockGetImportJob sync.RWMutex
+	lockStartImport  sync.RWMutex
+}
+
+// GetImportJob calls GetImportJobFunc.
+func (mock *ProductImportServiceMock) GetImportJob(ctx context.Context, jobID uuid.UUID) (*models.ProductImportJob, error) {
+	if mock.GetImportJobFunc == nil {
+		panic("ProductImportServiceMock.GetImportJobFunc: method is nil but ProductImportService.GetImportJob was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		JobID uuid.UUID
+	}{
+		Ctx:   ctx,
+		JobID: jobID,
+	}
+	mock.lockGetImportJob.Lock()
+	mock.calls.GetImportJob = append(mock.calls.GetImportJob, callInfo)
+	mock.lockGetImportJob.Unlock()
+	return mock.GetImportJobFunc(ctx, jobID)
+}
+
+// GetImportJobCalls gets all the calls that were made to GetImportJob.
+// Check the length with:
+//
+//	len(mockedProductImportService.GetImportJobCalls())
+func (mock *ProductImportServiceMock) GetImportJobCalls() []struct {
+	Ctx   context.Context
+	JobID uuid.UUID
+} {
+	var calls []struct {
+		Ctx   context.Context
+		JobID uuid.UUID
+	}
+	mock.lockGetImportJob.RLock()
+	calls = mock.calls.GetImportJob
+	mock.lockGetImportJob.RUnlock()
+	return calls
+}
+
+// StartImport calls StartImportFunc.
+func (mock *ProductImportServiceMock) StartImport(ctx context.Context, filename string, src io.Reader, userID uuid.UUID) (*models.ProductImportJob, error) {
+	if mock.StartImportFunc == nil {
+		panic("ProductImportServiceMock.StartImportFunc: method is nil but ProductImportService.StartImport was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Filename string
+		Src      io.Reader
+		UserID   uuid.UUID
+	}{
+		Ctx:      ctx,
+		Filename: filename,
+		Src:      src,
+		UserID:   userID,
+	}
+	mock.lockStartImport.Lock()
+	mock.calls.StartImport = append(mock.calls.StartImport, callInfo)
+	mock.lockStartImport.Unlock()
+	return mock.StartImportFunc(ctx, filename, src, userID)
+}
+
+// StartImportCalls gets all the calls that were made to StartImport.
+// Check the length with:
+//
+//	len(mockedProductImportService.StartImportCalls())
+func (mock *ProductImportServiceMock) StartImportCalls() []struct {
+	Ctx      context.Context
+	Filename string
+	Src      io.Reader
+	UserID   uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Filename string
+		Src      io.Reader
+		UserID   uuid.UUID
+	}
+	mock.lockStartImport.RLock()
+	calls = mock.calls.StartImport
+	mock.lockStartImport.RUnlock()
+	return calls
+}
+
+// Ensure, that ProductServiceMock does implement service.ProductService.
+// If this is not the case, regenerate this file with moq.
+var _ service.ProductService = &ProductServiceMock{}
+
+// ProductServiceMock is a mock implementation of service.ProductService.
+//
+//	func TestSomethingThatUsesProductService(t *testing.T) {
+//
+//		// make and configure a mocked service.ProductService
+//		mockedProductService := &ProductServiceMock{
+//			CheckStockFunc: func(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error) {
+//				panic("mock out the CheckStock method")
+//			},
+//			CheckStockForCartFunc: func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error) {
+//				panic("mock out the CheckStockForCart method")
+//			},
+//			CreateProductFunc: func(ctx context.Context, req models.ProductRequest) (*models.Product, error) {
+//				panic("mock out the CreateProduct method")
+//			},
+//			CreateVariantFunc: func(ctx context.Context, productID uuid.UUID, req models.ProductVariantRequest) (*models.ProductVariant, error) {
+//				panic("mock out the CreateVariant method")
+//			},
+//			DeleteProductFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the DeleteProduct method")
+//			},
+//			DeleteVariantFunc: func(ctx context.Context, productID uuid.UUID, variantID uuid.UUID) error {
+//				panic("mock out the DeleteVariant method")
+//			},
+//			DuplicateProductFunc: func(ctx context.Context, id uuid.UUID, req models.DuplicateProductRequest) (*models.Product, error) {
+//				panic("mock out the DuplicateProduct method")
+//			},
+//			ExportProductsFunc: func(ctx context.Context, rangeDays int, fn func(models.Product) error) error {
+//				panic("mock out the ExportProducts method")
+//			},
+//			GetAdminProductsFunc: func(ctx context.Context, page int, limit int, rangeDays int, status models.ProductStatus) ([]models.Product, int, error) {
+//				panic("mock out the GetAdminProducts method")
+//			},
+//			GetByBarcodeFunc: func(ctx context.Context, code string) (*models.BarcodeMatch, error) {
+//				panic("mock out the GetByBarcode method")
+//			},
+//			GetProductFunc: func(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+//				panic("mock out the GetProduct method")
+//			},
+//			GetProductFacetsFunc: func(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error) {
+//				panic("mock out the GetProductFacets method")
+//			},
+//			GetProductsFunc: func(ctx context.Context, page int, limit int, filter models.ProductFilter) ([]models.Product, int, error) {
+//				panic("mock out the GetProducts method")
+//			},
+//			GetProductsPageFunc: func(ctx context.Context, filter models.ProductFilter, after *pagination.Cursor, limit int) ([]models.Product, *pagination.Cursor, error) {
+//				panic("mock out the GetProductsPage method")
+//			},
+//			GetTopProductsFunc: func(ctx context.Context, limit int, rangeDays int) ([]models.TopProductItem, error) {
+//				panic("mock out the GetTopProducts method")
+//			},
+//			GetVariantFunc: func(ctx context.Context, productID uuid.UUID, variantID uuid.UUID) (*models.ProductVariant, error) {
+//				panic("mock out the GetVariant method")
+//			},
+//			GetVariantsFunc: func(ctx context.Context, productID uuid.UUID) ([]models.ProductVariant, error) {
+//				panic("mock out the GetVariants method")
+//			},
+//			PublishScheduledFunc: func(ctx context.Context) (int, int, error) {
+//				panic("mock out the PublishScheduled method")
+//			},
+//			ReleaseStockReservationFunc: func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID) error {
+//				panic("mock out the ReleaseStockReservation method")
+//			},
+//			ReserveStockFunc: func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error {
+//				panic("mock out the ReserveStock method")
+//			},
+//			SetReservationQuantityFunc: func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error {
+//				panic("mock out the SetReservationQuantity method")
+//			},
+//			SetScheduleFunc: func(ctx context.Context, id uuid.UUID, req models.ProductScheduleRequest) (*models.Product, error) {
+//				panic("mock out the SetSchedule method")
+//			},
+//			UpdateProductFunc: func(ctx context.Context, id uuid.UUID, req models.ProductUpdateRequest) (*models.Product, error) {
+//				panic("mock out the UpdateProduct method")
+//			},
+//			UpdateVariantFunc: func(ctx context.Context, productID uuid.UUID, variantID uuid.UUID, req models.ProductVariantUpdateRequest) (*models.ProductVariant, error) {
+//				panic("mock out the UpdateVariant method")
+//			},
+//		}
+//
+//		// use mockedProductService in code that requires service.ProductService
+//		// and then make assertions.
+//
+//	}
+type ProductServiceMock struct {
+	// CheckStockFunc mocks the CheckStock method.
+	CheckStockFunc func(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error)
+
+	// CheckStockForCartFunc mocks the CheckStockForCart method.
+	CheckStockForCartFunc func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error)
+
+	// CreateProductFunc mocks the CreateProduct method.
+	CreateProductFunc func(ctx context.Context, req models.ProductRequest) (*models.Product, error)
+
+	// CreateVariantFunc mocks the CreateVariant method.
+	CreateVariantFunc func(ctx context.Context, productID uuid.UUID, req models.ProductVariantRequest) (*models.ProductVariant, error)
+
+	// DeleteProductFunc mocks the DeleteProduct method.
+	DeleteProductFunc func(ctx context.Context, id uuid.UUID) error
+
+	// DeleteVariantFunc mocks the DeleteVariant method.
+	DeleteVariantFunc func(ctx context.Context, productID uuid.UUID, variantID uuid.UUID) error
+
+	// DuplicateProductFunc mocks the DuplicateProduct method.
+	DuplicateProductFunc func(ctx context.Context, id uuid.UUID, req models.DuplicateProductRequest) (*models.Product, error)
+
+	// ExportProductsFunc mocks the ExportProducts method.
+	ExportProductsFunc func(ctx context.Context, rangeDays int, fn func(models.Product) error) error
+
+	// GetAdminProductsFunc mocks the GetAdminProducts method.
+	GetAdminProductsFunc func(ctx context.Context, page int, limit int, rangeDays int, status models.ProductStatus) ([]models.Product, int, error)
+
+	// GetByBarcodeFunc mocks the GetByBarcode method.
+	GetByBarcodeFunc func(ctx context.Context, code string) (*models.BarcodeMatch, error)
+
+	// GetProductFunc mocks the GetProduct method.
+	GetProductFunc func(ctx context.Context, id uuid.UUID) (*models.Product, error)
+
+	// GetProductFacetsFunc mocks the GetProductFacets method.
+	GetProductFacetsFunc func(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error)
+
+	// GetProductsFunc mocks the GetProducts method.
+	GetProductsFunc func(ctx context.Context, page int, limit int, filter models.ProductFilter) ([]models.Product, int, error)
+
+	// GetProductsPageFunc mocks the GetProductsPage method.
+	GetProductsPageFunc func(ctx context.Context, filter models.ProductFilter, after *pagination.Cursor, limit int) ([]models.Product, *pagination.Cursor, error)
+
+	// GetTopProductsFunc mocks the GetTopProducts method.
+	GetTopProductsFunc func(ctx context.Context, limit int, rangeDays int) ([]models.TopProductItem, error)
+
+	// GetVariantFunc mocks the GetVariant method.
+	GetVariantFunc func(ctx context.Context, productID uuid.UUID, variantID uuid.UUID) (*models.ProductVariant, error)
+
+	// GetVariantsFunc mocks the GetVariants method.
+	GetVariantsFunc func(ctx context.Context, productID uuid.UUID) ([]models.ProductVariant, error)
+
+	// PublishScheduledFunc mocks the PublishScheduled method.
+	PublishScheduledFunc func(ctx context.Context) (int, int, error)
+
+	// ReleaseStockReservationFunc mocks the ReleaseStockReservation method.
+	ReleaseStockReservationFunc func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID) error
+
+	// ReserveStockFunc mocks the ReserveStock method.
+	ReserveStockFunc func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error
+
+	// SetReservationQuantityFunc mocks the SetReservationQuantity method.
+	SetReservationQuantityFunc func(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error
+
+	// SetScheduleFunc mocks the SetSchedule method.
+	SetScheduleFunc func(ctx context.Context, id uuid.UUID, req models.ProductScheduleRequest) (*models.Product, error)
+
+	// UpdateProductFunc mocks the UpdateProduct method.
+	UpdateProductFunc func(ctx context.Context, id uuid.UUID, req models.ProductUpdateRequest) (*models.Product, error)
+
+	// UpdateVariantFunc mocks the UpdateVariant method.
+	UpdateVariantFunc func(ctx context.Context, productID uuid.UUID, variantID uuid.UUID, req models.ProductVariantUpdateRequest) (*models.ProductVariant, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CheckStock holds details about calls to the CheckStock method.
+		CheckStock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID *uuid.UUID
+			// Quantity is the quantity argument value.
+			Quantity int
+		}
+		// CheckStockForCart holds details about calls to the CheckStockForCart method.
+		CheckStockForCart []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// CartID is the cartID argument value.
+			CartID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID *uuid.UUID
+			// Quantity is the quantity argument value.
+			Quantity int
+		}
+		// CreateProduct holds details about calls to the CreateProduct method.
+		CreateProduct []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.ProductRequest
+		}
+		// CreateVariant holds details about calls to the CreateVariant method.
+		CreateVariant []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// Req is the req argument value.
+			Req models.ProductVariantRequest
+		}
+		// DeleteProduct holds details about calls to the DeleteProduct method.
+		DeleteProduct []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// DeleteVariant holds details about calls to the DeleteVariant method.
+		DeleteVariant []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID uuid.UUID
+		}
+		// DuplicateProduct holds details about calls to the DuplicateProduct method.
+		DuplicateProduct []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.DuplicateProductRequest
+		}
+		// ExportProducts holds details about calls to the ExportProducts method.
+		ExportProducts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+			// Fn is the fn argument value.
+			Fn func(models.Product) error
+		}
+		// GetAdminProducts holds details about calls to the GetAdminProducts method.
+		GetAdminProducts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+			// Status is the status argument value.
+			Status models.ProductStatus
+		}
+		// GetByBarcode holds details about calls to the GetByBarcode method.
+		GetByBarcode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// GetProduct holds details about calls to the GetProduct method.
+		GetProduct []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetProductFacets holds details about calls to the GetProductFacets method.
+		GetProductFacets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.ProductFilter
+		}
+		// GetProducts holds details about calls to the GetProducts method.
+		GetProducts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+			// Filter is the filter argument value.
+			Filter models.ProductFilter
+		}
+		// GetProductsPage holds details about calls to the GetProductsPage method.
+		GetProductsPage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.ProductFilter
+			// After is the after argument value.
+			After *pagination.Cursor
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetTopProducts holds details about calls to the GetTopProducts method.
+		GetTopProducts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+		}
+		// GetVariant holds details about calls to the GetVariant method.
+		GetVariant []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID uuid.UUID
+		}
+		// GetVariants holds details about calls to the GetVariants method.
+		GetVariants []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+		}
+		// PublishScheduled holds details about calls to the PublishScheduled method.
+		PublishScheduled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ReleaseStockReservation holds details about calls to the ReleaseStockReservation method.
+		ReleaseStockReservation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// CartID is the cartID argument value.
+			CartID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID *uuid.UUID
+		}
+		// ReserveStock holds details about calls to the ReserveStock method.
+		ReserveStock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// CartID is the cartID argument value.
+			CartID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID *uuid.UUID
+			// Quantity is the quantity argument value.
+			Quantity int
+		}
+		// SetReservationQuantity holds details about calls to the SetReservationQuantity method.
+		SetReservationQuantity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// CartID is the cartID argument value.
+			CartID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID *uuid.UUID
+			// Quantity is the quantity argument value.
+			Quantity int
+		}
+		// SetSchedule holds details about calls to the SetSchedule method.
+		SetSchedule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.ProductScheduleRequest
+		}
+		// UpdateProduct holds details about calls to the UpdateProduct method.
+		UpdateProduct []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.ProductUpdateRequest
+		}
+		// UpdateVariant holds details about calls to the UpdateVariant method.
+		UpdateVariant []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// VariantID is the variantID argument value.
+			VariantID uuid.UUID
+			// Req is the req argument value.
+			Req models.ProductVariantUpdateRequest
+		}
+	}
+	lockCheckStock              sync.RWMutex
+	lockCheckStockForCart       sync.RWMutex
+	lockCreateProduct           sync.RWMutex
+	lockCreateVariant           sync.RWMutex
+	lockDeleteProduct           sync.RWMutex
+	lockDeleteVariant           sync.RWMutex
+	lockDuplicateProduct        sync.RWMutex
+	lockExportProducts          sync.RWMutex
+	lockGetAdminProducts        sync.RWMutex
+	lockGetByBarcode            sync.RWMutex
+	lockGetProduct              sync.RWMutex
+	lockGetProductFacets        sync.RWMutex
+	lockGetProducts             sync.RWMutex
+	lockGetProductsPage         sync.RWMutex
+	lockGetTopProducts          sync.RWMutex
+	lockGetVariant              sync.RWMutex
+	lockGetVariants             sync.RWMutex
+	lockPublishScheduled        sync.RWMutex
+	lockReleaseStockReservation sync.RWMutex
+	lockReserveStock            sync.RWMutex
+	lockSetReservationQuantity  sync.RWMutex
+	lockSetSchedule             sync.RWMutex
+	lockUpdateProduct           sync.RWMutex
+	lockUpdateVariant           sync.RWMutex
+}
+
+// CheckStock calls CheckStockFunc.
+func (mock *ProductServiceMock) CheckStock(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error) {
+	if mock.CheckStockFunc == nil {
+		panic("ProductServiceMock.CheckStockFunc: method is nil but ProductService.CheckStock was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		VariantID: variantID,
+		Quantity:  quantity,
+	}
+	mock.lockCheckStock.Lock()
+	mock.calls.CheckStock = append(mock.calls.CheckStock, callInfo)
+	mock.lockCheckStock.Unlock()
+	return mock.CheckStockFunc(ctx, productID, variantID, quantity)
+}
+
+// CheckStockCalls gets all the calls that were made to CheckStock.
+// Check the length with:
+//
+//	len(mockedProductService.CheckStockCalls())
+func (mock *ProductServiceMock) CheckStockCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	Quantity  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}
+	mock.lockCheckStock.RLock()
+	calls = mock.calls.CheckStock
+	mock.lockCheckStock.RUnlock()
+	return calls
+}
+
+// CheckStockForCart calls CheckStockForCartFunc.
+func (mock *ProductServiceMock) CheckStockForCart(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) (bool, error) {
+	if mock.CheckStockForCartFunc == nil {
+		panic("ProductServiceMock.CheckStockForCartFunc: method is nil but ProductService.CheckStockForCart was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		CartID:    cartID,
+		VariantID: variantID,
+		Quantity:  quantity,
+	}
+	mock.lockCheckStockForCart.Lock()
+	mock.calls.CheckStockForCart = append(mock.calls.CheckStockForCart, callInfo)
+	mock.lockCheckStockForCart.Unlock()
+	return mock.CheckStockForCartFunc(ctx, productID, cartID, variantID, quantity)
+}
+
+// CheckStockForCartCalls gets all the calls that were made to CheckStockForCart.
+// Check the length with:
+//
+//	len(mockedProductService.CheckStockForCartCalls())
+func (mock *ProductServiceMock) CheckStockForCartCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	CartID    uuid.UUID
+	VariantID *uuid.UUID
+	Quantity  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}
+	mock.lockCheckStockForCart.RLock()
+	calls = mock.calls.CheckStockForCart
+	mock.lockCheckStockForCart.RUnlock()
+	return calls
+}
+
+// CreateProduct calls CreateProductFunc.
+func (mock *ProductServiceMock) CreateProduct(ctx context.Context, req models.ProductRequest) (*models.Product, error) {
+	if mock.CreateProductFunc == nil {
+		panic("ProductServiceMock.CreateProductFunc: method is nil but ProductService.CreateProduct was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.ProductRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateProduct.Lock()
+	mock.calls.CreateProduct = append(mock.calls.CreateProduct, callInfo)
+	mock.lockCreateProduct.Unlock()
+	return mock.CreateProductFunc(ctx, req)
+}
+
+// CreateProductCalls gets all the calls that were made to CreateProduct.
+// Check the length with:
+//
+//	len(mockedProductService.CreateProductCalls())
+func (mock *ProductServiceMock) CreateProductCalls() []struct {
+	Ctx context.Context
+	Req models.ProductRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.ProductRequest
+	}
+	mock.lockCreateProduct.RLock()
+	calls = mock.calls.CreateProduct
+	mock.lockCreateProduct.RUnlock()
+	return calls
+}
+
+// CreateVariant calls CreateVariantFunc.
+func (mock *ProductServiceMock) CreateVariant(ctx context.Context, productID uuid.UUID, req models.ProductVariantRequest) (*models.ProductVariant, error) {
+	if mock.CreateVariantFunc == nil {
+		panic("ProductServiceMock.CreateVariantFunc: method is nil but ProductService.CreateVariant was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.ProductVariantRequest
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		Req:       req,
+	}
+	mock.lockCreateVariant.Lock()
+	mock.calls.CreateVariant = append(mock.calls.CreateVariant, callInfo)
+	mock.lockCreateVariant.Unlock()
+	return mock.CreateVariantFunc(ctx, productID, req)
+}
+
+// CreateVariantCalls gets all the calls that were made to CreateVariant.
+// Check the length with:
+//
+//	len(mockedProductService.CreateVariantCalls())
+func (mock *ProductServiceMock) CreateVariantCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	Req       models.ProductVariantRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.ProductVariantRequest
+	}
+	mock.lockCreateVariant.RLock()
+	calls = mock.calls.CreateVariant
+	mock.lockCreateVariant.RUnlock()
+	return calls
+}
+
+// DeleteProduct calls DeleteProductFunc.
+func (mock *ProductServiceMock) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	if mock.DeleteProductFunc == nil {
+		panic("ProductServiceMock.DeleteProductFunc: method is nil but ProductService.DeleteProduct was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteProduct.Lock()
+	mock.calls.DeleteProduct = append(mock.calls.DeleteProduct, callInfo)
+	mock.lockDeleteProduct.Unlock()
+	return mock.DeleteProductFunc(ctx, id)
+}
+
+// DeleteProductCalls gets all the calls that were made to DeleteProduct.
+// Check the length with:
+//
+//	len(mockedProductService.DeleteProductCalls())
+func (mock *ProductServiceMock) DeleteProductCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDeleteProduct.RLock()
+	calls = mock.calls.DeleteProduct
+	mock.lockDeleteProduct.RUnlock()
+	return calls
+}
+
+// DeleteVariant calls DeleteVariantFunc.
+func (mock *ProductServiceMock) DeleteVariant(ctx context.Context, productID uuid.UUID, variantID uuid.UUID) error {
+	if mock.DeleteVariantFunc == nil {
+		panic("ProductServiceMock.DeleteVariantFunc: method is nil but ProductService.DeleteVariant was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		VariantID: variantID,
+	}
+	mock.lockDeleteVariant.Lock()
+	mock.calls.DeleteVariant = append(mock.calls.DeleteVariant, callInfo)
+	mock.lockDeleteVariant.Unlock()
+	return mock.DeleteVariantFunc(ctx, productID, variantID)
+}
+
+// DeleteVariantCalls gets all the calls that were made to DeleteVariant.
+// Check the length with:
+//
+//	len(mockedProductService.DeleteVariantCalls())
+func (mock *ProductServiceMock) DeleteVariantCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID uuid.UUID
+	}
+	mock.lockDeleteVariant.RLock()
+	calls = mock.calls.DeleteVariant
+	mock.lockDeleteVariant.RUnlock()
+	return calls
+}
+
+// DuplicateProduct calls DuplicateProductFunc.
+func (mock *ProductServiceMock) DuplicateProduct(ctx context.Context, id uuid.UUID, req models.DuplicateProductRequest) (*models.Product, error) {
+	if mock.DuplicateProductFunc == nil {
+		panic("ProductServiceMock.DuplicateProductFunc: method is nil but ProductService.DuplicateProduct was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.DuplicateProductRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockDuplicateProduct.Lock()
+	mock.calls.DuplicateProduct = append(mock.calls.DuplicateProduct, callInfo)
+	mock.lockDuplicateProduct.Unlock()
+	return mock.DuplicateProductFunc(ctx, id, req)
+}
+
+// DuplicateProductCalls gets all the calls that were made to DuplicateProduct.
+// Check the length with:
+//
+//	len(mockedProductService.DuplicateProductCalls())
+func (mock *ProductServiceMock) DuplicateProductCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.DuplicateProductRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.DuplicateProductRequest
+	}
+	mock.lockDuplicateProduct.RLock()
+	calls = mock.calls.DuplicateProduct
+	mock.lockDuplicateProduct.RUnlock()
+	return calls
+}
+
+// ExportProducts calls ExportProductsFunc.
+func (mock *ProductServiceMock) ExportProducts(ctx context.Context, rangeDays int, fn func(models.Product) error) error {
+	if mock.ExportProductsFunc == nil {
+		panic("ProductServiceMock.ExportProductsFunc: method is nil but ProductService.ExportProducts was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RangeDays int
+		Fn        func(models.Product) error
+	}{
+		Ctx:       ctx,
+		RangeDays: rangeDays,
+		Fn:        fn,
+	}
+	mock.lockExportProducts.Lock()
+	mock.calls.ExportProducts = append(mock.calls.ExportProducts, callInfo)
+	mock.lockExportProducts.Unlock()
+	return mock.ExportProductsFunc(ctx, rangeDays, fn)
+}
+
+// ExportProductsCalls gets all the calls that were made to ExportProducts.
+// Check the length with:
+//
+//	len(mockedProductService.ExportProductsCalls())
+func (mock *ProductServiceMock) ExportProductsCalls() []struct {
+	Ctx       context.Context
+	RangeDays int
+	Fn        func(models.Product) error
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RangeDays int
+		Fn        func(models.Product) error
+	}
+	mock.lockExportProducts.RLock()
+	calls = mock.calls.ExportProducts
+	mock.lockExportProducts.RUnlock()
+	return calls
+}
+
+// GetAdminProducts calls GetAdminProductsFunc.
+func (mock *ProductServiceMock) GetAdminProducts(ctx context.Context, page int, limit int, rangeDays int, status models.ProductStatus) ([]models.Product, int, error) {
+	if mock.GetAdminProductsFunc == nil {
+		panic("ProductServiceMock.GetAdminProductsFunc: method is nil but ProductService.GetAdminProducts was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Page      int
+		Limit     int
+		RangeDays int
+		Status    models.ProductStatus
+	}{
+		Ctx:       ctx,
+		Page:      page,
+		Limit:     limit,
+		RangeDays: rangeDays,
+		Status:    status,
+	}
+	mock.lockGetAdminProducts.Lock()
+	mock.calls.GetAdminProducts = append(mock.calls.GetAdminProducts, callInfo)
+	mock.lockGetAdminProducts.Unlock()
+	return mock.GetAdminProductsFunc(ctx, page, limit, rangeDays, status)
+}
+
+// GetAdminProductsCalls gets all the calls that were made to GetAdminProducts.
+// Check the length with:
+//
+//	len(mockedProductService.GetAdminProductsCalls())
+func (mock *ProductServiceMock) GetAdminProductsCalls() []struct {
+	Ctx       context.Context
+	Page      int
+	Limit     int
+	RangeDays int
+	Status    models.ProductStatus
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Page      int
+		Limit     int
+		RangeDays int
+		Status    models.ProductStatus
+	}
+	mock.lockGetAdminProducts.RLock()
+	calls = mock.calls.GetAdminProducts
+	mock.lockGetAdminProducts.RUnlock()
+	return calls
+}
+
+// GetByBarcode calls GetByBarcodeFunc.
+func (mock *ProductServiceMock) GetByBarcode(ctx context.Context, code string) (*models.BarcodeMatch, error) {
+	if mock.GetByBarcodeFunc == nil {
+		panic("ProductServiceMock.GetByBarcodeFunc: method is nil but ProductService.GetByBarcode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockGetByBarcode.Lock()
+	mock.calls.GetByBarcode = append(mock.calls.GetByBarcode, callInfo)
+	mock.lockGetByBarcode.Unlock()
+	return mock.GetByBarcodeFunc(ctx, code)
+}
+
+// GetByBarcodeCalls gets all the calls that were made to GetByBarcode.
+// Check the length with:
+//
+//	len(mockedProductService.GetByBarcodeCalls())
+func (mock *ProductServiceMock) GetByBarcodeCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockGetByBarcode.RLock()
+	calls = mock.calls.GetByBarcode
+	mock.lockGetByBarcode.RUnlock()
+	return calls
+}
+
+// GetProduct calls GetProductFunc.
+func (mock *ProductServiceMock) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	if mock.GetProductFunc == nil {
+		panic("ProductServiceMock.GetProductFunc: method is nil but ProductService.GetProduct was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetProduct.Lock()
+	mock.calls.GetProduct = append(mock.calls.GetProduct, callInfo)
+	mock.lockGetProduct.Unlock()
+	return mock.GetProductFunc(ctx, id)
+}
+
+// GetProductCalls gets all the calls that were made to GetProduct.
+// Check the length with:
+//
+//	len(mockedProductService.GetProductCalls())
+func (mock *ProductServiceMock) GetProductCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetProduct.RLock()
+	calls = mock.calls.GetProduct
+	mock.lockGetProduct.RUnlock()
+	return calls
+}
+
+// GetProductFacets calls GetProductFacetsFunc.
+func (mock *ProductServiceMock) GetProductFacets(ctx context.Context, filter models.ProductFilter) (*models.ProductFacets, error) {
+	if mock.GetProductFacetsFunc == nil {
+		panic("ProductServiceMock.GetProductFacetsFunc: method is nil but ProductService.GetProductFacets was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.ProductFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockGetProductFacets.Lock()
+	mock.calls.GetProductFacets = append(mock.calls.GetProductFacets, callInfo)
+	mock.lockGetProductFacets.Unlock()
+	return mock.GetProductFacetsFunc(ctx, filter)
+}
+
+// GetProductFacetsCalls gets all the calls that were made to GetProductFacets.
+// Check the length with:
+//
+//	len(mockedProductService.GetProductFacetsCalls())
+func (mock *ProductServiceMock) GetProductFacetsCalls() []struct {
+	Ctx    context.Context
+	Filter models.ProductFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.ProductFilter
+	}
+	mock.lockGetProductFacets.RLock()
+	calls = mock.calls.GetProductFacets
+	mock.lockGetProductFacets.RUnlock()
+	return calls
+}
+
+// GetProducts calls GetProductsFunc.
+func (mock *ProductServiceMock) GetProducts(ctx context.Context, page int, limit int, filter models.ProductFilter) ([]models.Product, int, error) {
+	if mock.GetProductsFunc == nil {
+		panic("ProductServiceMock.GetProductsFunc: method is nil but ProductService.GetProducts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Page   int
+		Limit  int
+		Filter models.ProductFilter
+	}{
+		Ctx:    ctx,
+		Page:   page,
+		Limit:  limit,
+		Filter: filter,
+	}
+	mock.lockGetProducts.Lock()
+	mock.calls.GetProducts = append(mock.calls.GetProducts, callInfo)
+	mock.lockGetProducts.Unlock()
+	return mock.GetProductsFunc(ctx, page, limit, filter)
+}
+
+// GetProductsCalls gets all the calls that were made to GetProducts.
+// Check the length with:
+//
+//	len(mockedProductService.GetProductsCalls())
+func (mock *ProductServiceMock) GetProductsCalls() []struct {
+	Ctx    context.Context
+	Page   int
+	Limit  int
+	Filter models.ProductFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Page   int
+		Limit  int
+		Filter models.ProductFilter
+	}
+	mock.lockGetProducts.RLock()
+	calls = mock.calls.GetProducts
+	mock.lockGetProducts.RUnlock()
+	return calls
+}
+
+// GetProductsPage calls GetProductsPageFunc.
+func (mock *ProductServiceMock) GetProductsPage(ctx context.Context, filter models.ProductFilter, after *pagination.Cursor, limit int) ([]models.Product, *pagination.Cursor, error) {
+	if mock.GetProductsPageFunc == nil {
+		panic("ProductServiceMock.GetProductsPageFunc: method is nil but ProductService.GetProductsPage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.ProductFilter
+		After  *pagination.Cursor
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		After:  after,
+		Limit:  limit,
+	}
+	mock.lockGetProductsPage.Lock()
+	mock.calls.GetProductsPage = append(mock.calls.GetProductsPage, callInfo)
+	mock.lockGetProductsPage.Unlock()
+	return mock.GetProductsPageFunc(ctx, filter, after, limit)
+}
+
+// GetProductsPageCalls gets all the calls that were made to GetProductsPage.
+// Check the length with:
+//
+//	len(mockedProductService.GetProductsPageCalls())
+func (mock *ProductServiceMock) GetProductsPageCalls() []struct {
+	Ctx    context.Context
+	Filter models.ProductFilter
+	After  *pagination.Cursor
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.ProductFilter
+		After  *pagination.Cursor
+		Limit  int
+	}
+	mock.lockGetProductsPage.RLock()
+	calls = mock.calls.GetProductsPage
+	mock.lockGetProductsPage.RUnlock()
+	return calls
+}
+
+// GetTopProducts calls GetTopProductsFunc.
+func (mock *ProductServiceMock) GetTopProducts(ctx context.Context, limit int, rangeDays int) ([]models.TopProductItem, error) {
+	if mock.GetTopProductsFunc == nil {
+		panic("ProductServiceMock.GetTopProductsFunc: method is nil but ProductService.GetTopProducts was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Limit     int
+		RangeDays int
+	}{
+		Ctx:       ctx,
+		Limit:     limit,
+		RangeDays: rangeDays,
+	}
+	mock.lockGetTopProducts.Lock()
+	mock.calls.GetTopProducts = append(mock.calls.GetTopProducts, callInfo)
+	mock.lockGetTopProducts.Unlock()
+	return mock.GetTopProductsFunc(ctx, limit, rangeDays)
+}
+
+// GetTopProductsCalls gets all the calls that were made to GetTopProducts.
+// Check the length with:
+//
+//	len(mockedProductService.GetTopProductsCalls())
+func (mock *ProductServiceMock) GetTopProductsCalls() []struct {
+	Ctx       context.Context
+	Limit     int
+	RangeDays int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Limit     int
+		RangeDays int
+	}
+	mock.lockGetTopProducts.RLock()
+	calls = mock.calls.GetTopProducts
+	mock.lockGetTopProducts.RUnlock()
+	return calls
+}
+
+// GetVariant calls GetVariantFunc.
+func (mock *ProductServiceMock) GetVariant(ctx context.Context, productID uuid.UUID, variantID uuid.UUID) (*models.ProductVariant, error) {
+	if mock.GetVariantFunc == nil {
+		panic("ProductServiceMock.GetVariantFunc: method is nil but ProductService.GetVariant was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		VariantID: variantID,
+	}
+	mock.lockGetVariant.Lock()
+	mock.calls.GetVariant = append(mock.calls.GetVariant, callInfo)
+	mock.lockGetVariant.Unlock()
+	return mock.GetVariantFunc(ctx, productID, variantID)
+}
+
+// GetVariantCalls gets all the calls that were made to GetVariant.
+// Check the length with:
+//
+//	len(mockedProductService.GetVariantCalls())
+func (mock *ProductServiceMock) GetVariantCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID uuid.UUID
+	}
+	mock.lockGetVariant.RLock()
+	calls = mock.calls.GetVariant
+	mock.lockGetVariant.RUnlock()
+	return calls
+}
+
+// GetVariants calls GetVariantsFunc.
+func (mock *ProductServiceMock) GetVariants(ctx context.Context, productID uuid.UUID) ([]models.ProductVariant, error) {
+	if mock.GetVariantsFunc == nil {
+		panic("ProductServiceMock.GetVariantsFunc: method is nil but ProductService.GetVariants was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+	}
+	mock.lockGetVariants.Lock()
+	mock.calls.GetVariants = append(mock.calls.GetVariants, callInfo)
+	mock.lockGetVariants.Unlock()
+	return mock.GetVariantsFunc(ctx, productID)
+}
+
+// GetVariantsCalls gets all the calls that were made to GetVariants.
+// Check the length with:
+//
+//	len(mockedProductService.GetVariantsCalls())
+func (mock *ProductServiceMock) GetVariantsCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}
+	mock.lockGetVariants.RLock()
+	calls = mock.calls.GetVariants
+	mock.lockGetVariants.RUnlock()
+	return calls
+}
+
+// PublishScheduled calls PublishScheduledFunc.
+func (mock *ProductServiceMock) PublishScheduled(ctx context.Context) (int, int, error) {
+	if mock.PublishScheduledFunc == nil {
+		panic("ProductServiceMock.PublishScheduledFunc: method is nil but ProductService.PublishScheduled was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPublishScheduled.Lock()
+	mock.calls.PublishScheduled = append(mock.calls.PublishScheduled, callInfo)
+	mock.lockPublishScheduled.Unlock()
+	return mock.PublishScheduledFunc(ctx)
+}
+
+// PublishScheduledCalls gets all the calls that were made to PublishScheduled.
+// Check the length with:
+//
+//	len(mockedProductService.PublishScheduledCalls())
+func (mock *ProductServiceMock) PublishScheduledCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPublishScheduled.RLock()
+	calls = mock.calls.PublishScheduled
+	mock.lockPublishScheduled.RUnlock()
+	return calls
+}
+
+// ReleaseStockReservation calls ReleaseStockReservationFunc.
+func (mock *ProductServiceMock) ReleaseStockReservation(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID) error {
+	if mock.ReleaseStockReservationFunc == nil {
+		panic("ProductServiceMock.ReleaseStockReservationFunc: method is nil but ProductService.ReleaseStockReservation was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		CartID:    cartID,
+		VariantID: variantID,
+	}
+	mock.lockReleaseStockReservation.Lock()
+	mock.calls.ReleaseStockReservation = append(mock.calls.ReleaseStockReservation, callInfo)
+	mock.lockReleaseStockReservation.Unlock()
+	return mock.ReleaseStockReservationFunc(ctx, productID, cartID, variantID)
+}
+
+// ReleaseStockReservationCalls gets all the calls that were made to ReleaseStockReservation.
+// Check the length with:
+//
+//	len(mockedProductService.ReleaseStockReservationCalls())
+func (mock *ProductServiceMock) ReleaseStockReservationCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	CartID    uuid.UUID
+	VariantID *uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+	}
+	mock.lockReleaseStockReservation.RLock()
+	calls = mock.calls.ReleaseStockReservation
+	mock.lockReleaseStockReservation.RUnlock()
+	return calls
+}
+
+// ReserveStock calls ReserveStockFunc.
+func (mock *ProductServiceMock) ReserveStock(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error {
+	if mock.ReserveStockFunc == nil {
+		panic("ProductServiceMock.ReserveStockFunc: method is nil but ProductService.ReserveStock was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		CartID:    cartID,
+		VariantID: variantID,
+		Quantity:  quantity,
+	}
+	mock.lockReserveStock.Lock()
+	mock.calls.ReserveStock = append(mock.calls.ReserveStock, callInfo)
+	mock.lockReserveStock.Unlock()
+	return mock.ReserveStockFunc(ctx, productID, cartID, variantID, quantity)
+}
+
+// ReserveStockCalls gets all the calls that were made to ReserveStock.
+// Check the length with:
+//
+//	len(mockedProductService.ReserveStockCalls())
+func (mock *ProductServiceMock) ReserveStockCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	CartID    uuid.UUID
+	VariantID *uuid.UUID
+	Quantity  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}
+	mock.lockReserveStock.RLock()
+	calls = mock.calls.ReserveStock
+	mock.lockReserveStock.RUnlock()
+	return calls
+}
+
+// SetReservationQuantity calls SetReservationQuantityFunc.
+func (mock *ProductServiceMock) SetReservationQuantity(ctx context.Context, productID uuid.UUID, cartID uuid.UUID, variantID *uuid.UUID, quantity int) error {
+	if mock.SetReservationQuantityFunc == nil {
+		panic("ProductServiceMock.SetReservationQuantityFunc: method is nil but ProductService.SetReservationQuantity was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		CartID:    cartID,
+		VariantID: variantID,
+		Quantity:  quantity,
+	}
+	mock.lockSetReservationQuantity.Lock()
+	mock.calls.SetReservationQuantity = append(mock.calls.SetReservationQuantity, callInfo)
+	mock.lockSetReservationQuantity.Unlock()
+	return mock.SetReservationQuantityFunc(ctx, productID, cartID, variantID, quantity)
+}
+
+// SetReservationQuantityCalls gets all the calls that were made to SetReservationQuantity.
+// Check the length with:
+//
+//	len(mockedProductService.SetReservationQuantityCalls())
+func (mock *ProductServiceMock) SetReservationQuantityCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	CartID    uuid.UUID
+	VariantID *uuid.UUID
+	Quantity  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		CartID    uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}
+	mock.lockSetReservationQuantity.RLock()
+	calls = mock.calls.SetReservationQuantity
+	mock.lockSetReservationQuantity.RUnlock()
+	return calls
+}
+
+// SetSchedule calls SetScheduleFunc.
+func (mock *ProductServiceMock) SetSchedule(ctx context.Context, id uuid.UUID, req models.ProductScheduleRequest) (*models.Product, error) {
+	if mock.SetScheduleFunc == nil {
+		panic("ProductServiceMock.SetScheduleFunc: method is nil but ProductService.SetSchedule was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.ProductScheduleRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockSetSchedule.Lock()
+	mock.calls.SetSchedule = append(mock.calls.SetSchedule, callInfo)
+	mock.lockSetSchedule.Unlock()
+	return mock.SetScheduleFunc(ctx, id, req)
+}
+
+// SetScheduleCalls gets all the calls that were made to SetSchedule.
+// Check the length with:
+//
+//	len(mockedProductService.SetScheduleCalls())
+func (mock *ProductServiceMock) SetScheduleCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.ProductScheduleRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.ProductScheduleRequest
+	}
+	mock.lockSetSchedule.RLock()
+	calls = mock.calls.SetSchedule
+	mock.lockSetSchedule.RUnlock()
+	return calls
+}
+
+// UpdateProduct calls UpdateProductFunc.
+func (mock *ProductServiceMock) UpdateProduct(ctx context.Context, id uuid.UUID, req models.ProductUpdateRequest) (*models.Product, error) {
+	if mock.UpdateProductFunc == nil {
+		panic("ProductServiceMock.UpdateProductFunc: method is nil but ProductService.UpdateProduct was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.ProductUpdateRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockUpdateProduct.Lock()
+	mock.calls.UpdateProduct = append(mock.calls.UpdateProduct, callInfo)
+	mock.lockUpdateProduct.Unlock()
+	return mock.UpdateProductFunc(ctx, id, req)
+}
+
+// UpdateProductCalls gets all the calls that were made to UpdateProduct.
+// Check the length with:
+//
+//	len(mockedProductService.UpdateProductCalls())
+func (mock *ProductServiceMock) UpdateProductCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.ProductUpdateRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.ProductUpdateRequest
+	}
+	mock.lockUpdateProduct.RLock()
+	calls = mock.calls.UpdateProduct
+	mock.lockUpdateProduct.RUnlock()
+	return calls
+}
+
+// UpdateVariant calls UpdateVariantFunc.
+func (mock *ProductServiceMock) UpdateVariant(ctx context.Context, productID uuid.UUID, variantID uuid.UUID, req models.ProductVariantUpdateRequest) (*models.ProductVariant, error) {
+	if mock.UpdateVariantFunc == nil {
+		panic("ProductServiceMock.UpdateVariantFunc: method is nil but ProductService.UpdateVariant was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID uuid.UUID
+		Req       models.ProductVariantUpdateRequest
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		VariantID: variantID,
+		Req:       req,
+	}
+	mock.lockUpdateVariant.Lock()
+	mock.calls.UpdateVariant = append(mock.calls.UpdateVariant, callInfo)
+	mock.lockUpdateVariant.Unlock()
+	return mock.UpdateVariantFunc(ctx, productID, variantID, req)
+}
+
+// UpdateVariantCalls gets all the calls that were made to UpdateVariant.
+// Check the length with:
+//
+//	len(mockedProductService.UpdateVariantCalls())
+func (mock *ProductServiceMock) UpdateVariantCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+	Req       models.ProductVariantUpdateRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		VariantID uuid.UUID
+		Req       models.ProductVariantUpdateRequest
+	}
+	mock.lockUpdateVariant.RLock()
+	calls = mock.calls.UpdateVariant
+	mock.lockUpdateVariant.RUnlock()
+	return calls
+}
+
+// Ensure, that PromotionServiceMock does implement service.PromotionService.
+// If this is not the case, regenerate this file with moq.
+var _ service.PromotionService = &PromotionServiceMock{}
+
+// PromotionServiceMock is a mock implementation of service.PromotionService.
+//
+//	func TestSomethingThatUsesPromotionService(t *testing.T) {
+//
+//		// make and configure a mocked service.PromotionService
+//		mockedPromotionService := &PromotionServiceMock{
+//			CreatePromotionFunc: func(ctx context.Context, req models.PromotionRequest) (*models.Promotion, error) {
+//				panic("mock out the CreatePromotion method")
+//			},
+//			DeletePromotionFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the DeletePromotion method")
+//			},
+//			EvaluateFunc: func(ctx context.Context, userID uuid.UUID, items []models.CartItem) ([]models.AppliedPromotion, money.Money, error) {
+//				panic("mock out the Evaluate method")
+//			},
+//			GetPromotionFunc: func(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+//				panic("mock out the GetPromotion method")
+//			},
+//			GetPromotionsFunc: func(ctx context.Context) ([]models.Promotion, error) {
+//				panic("mock out the GetPromotions method")
+//			},
+//			UpdatePromotionFunc: func(ctx context.Context, id uuid.UUID, req models.PromotionRequest) (*models.Promotion, error) {
+//				panic("mock out the UpdatePromotion method")
+//			},
+//		}
+//
+//		// use mockedPromotionService in code that requires service.PromotionService
+//		// and then make assertions.
+//
+//	}
+type PromotionServiceMock struct {
+	// CreatePromotionFunc mocks the CreatePromotion method.
+	CreatePromotionFunc func(ctx context.Context, req models.PromotionRequest) (*models.Promotion, error)
+
+	// DeletePromotionFunc mocks the DeletePromotion method.
+	DeletePromotionFunc func(ctx context.Context, id uuid.UUID) error
+
+	// EvaluateFunc mocks the Evaluate method.
+	EvaluateFunc func(ctx context.Context, userID uuid.UUID, items []models.CartItem) ([]models.AppliedPromotion, money.Money, error)
+
+	// GetPromotionFunc mocks the GetPromotion method.
+	GetPromotionFunc func(ctx context.Context, id uuid.UUID) (*models.Promotion, error)
+
+	// GetPromotionsFunc mocks the GetPromotions method.
+	GetPromotionsFunc func(ctx context.Context) ([]models.Promotion, error)
+
+	// UpdatePromotionFunc mocks the UpdatePromotion method.
+	UpdatePromotionFunc func(ctx context.Context, id uuid.UUID, req models.PromotionRequest) (*models.Promotion, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreatePromotion holds details about calls to the CreatePromotion method.
+		CreatePromotion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.PromotionRequest
+		}
+		// DeletePromotion holds details about calls to the DeletePromotion method.
+		DeletePromotion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// Evaluate holds details about calls to the Evaluate method.
+		Evaluate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Items is the items argument value.
+			Items []models.CartItem
+		}
+		// GetPromotion holds details about calls to the GetPromotion method.
+		GetPromotion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetPromotions holds details about calls to the GetPromotions method.
+		GetPromotions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UpdatePromotion holds details about calls to the UpdatePromotion method.
+		UpdatePromotion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.PromotionRequest
+		}
+	}
+	lockCreatePromotion sync.RWMutex
+	lockDeletePromotion sync.RWMutex
+	lockEvaluate        sync.RWMutex
+	lockGetPromotion    sync.RWMutex
+	lockGetPromotions   sync.RWMutex
+	lockUpdatePromotion sync.RWMutex
+}
+
+// CreatePromotion calls CreatePromotionFunc.
+func (mock *PromotionServiceMock) CreatePromotion(ctx context.Context, req models.PromotionRequest) (*models.Promotion, error) {
+	if mock.CreatePromotionFunc == nil {
+		panic("PromotionServiceMock.CreatePromotionFunc: method is nil but PromotionService.CreatePromotion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.PromotionRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreatePromotion.Lock()
+	mock.calls.CreatePromotion = append(mock.calls.CreatePromotion, callInfo)
+	mock.lockCreatePromotion.Unlock()
+	return mock.CreatePromotionFunc(ctx, req)
+}
+
+// CreatePromotionCalls gets all the calls that were made to CreatePromotion.
+// Check the length with:
+//
+//	len(mockedPromotionService.CreatePromotionCalls())
+func (mock *PromotionServiceMock) CreatePromotionCalls() []struct {
+	Ctx context.Context
+	Req models.PromotionRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.PromotionRequest
+	}
+	mock.lockCreatePromotion.RLock()
+	calls = mock.calls.CreatePromotion
+	mock.lockCreatePromotion.RUnlock()
+	return calls
+}
+
+// DeletePromotion calls DeletePromotionFunc.
+func (mock *PromotionServiceMock) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	if mock.DeletePromotionFunc == nil {
+		panic("PromotionServiceMock.DeletePromotionFunc: method is nil but PromotionService.DeletePromotion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeletePromotion.Lock()
+	mock.calls.DeletePromotion = append(mock.calls.DeletePromotion, callInfo)
+	mock.lockDeletePromotion.Unlock()
+	return mock.DeletePromotionFunc(ctx, id)
+}
+
+// DeletePromotionCalls gets all the calls that were made to DeletePromotion.
+// Check the length with:
+//
+//	len(mockedPromotionService.DeletePromotionCalls())
+func (mock *PromotionServiceMock) DeletePromotionCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDeletePromotion.RLock()
+	calls = mock.calls.DeletePromotion
+	mock.lockDeletePromotion.RUnlock()
+	return calls
+}
+
+// Evaluate calls EvaluateFunc.
+func (mock *PromotionServiceMock) Evaluate(ctx context.Context, userID uuid.UUID, items []models.CartItem) ([]models.AppliedPromotion, money.Money, error) {
+	if mock.EvaluateFunc == nil {
+		panic("PromotionServiceMock.EvaluateFunc: method is nil but PromotionService.Evaluate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Items  []models.CartItem
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Items:  items,
+	}
+	mock.lockEvaluate.Lock()
+	mock.calls.Evaluate = append(mock.calls.Evaluate, callInfo)
+	mock.lockEvaluate.Unlock()
+	return mock.EvaluateFunc(ctx, userID, items)
+}
+
+// EvaluateCalls gets all the calls that were made to Evaluate.
+// Check the length with:
+//
+//	len(mockedPromotionService.EvaluateCalls())
+func (mock *PromotionServiceMock) EvaluateCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Items  []models.CartItem
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Items  []models.CartItem
+	}
+	mock.lockEvaluate.RLock()
+	calls = mock.calls.Evaluate
+	mock.lockEvaluate.RUnlock()
+	return calls
+}
+
+// GetPromotion calls GetPromotionFunc.
+func (mock *PromotionServiceMock) GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	if mock.GetPromotionFunc == nil {
+		panic("PromotionServiceMock.GetPromotionFunc: method is nil but PromotionService.GetPromotion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetPromotion.Lock()
+	mock.calls.GetPromotion = append(mock.calls.GetPromotion, callInfo)
+	mock.lockGetPromotion.Unlock()
+	return mock.GetPromotionFunc(ctx, id)
+}
+
+// GetPromotionCalls gets all the calls that were made to GetPromotion.
+// Check the length with:
+//
+//	len(mockedPromotionService.GetPromotionCalls())
+func (mock *PromotionServiceMock) GetPromotionCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetPromotion.RLock()
+	calls = mock.calls.GetPromotion
+	mock.lockGetPromotion.RUnlock()
+	return calls
+}
+
+// GetPromotions calls GetPromotionsFunc.
+func (mock *PromotionServiceMock) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	if mock.GetPromotionsFunc == nil {
+		panic("PromotionServiceMock.GetPromotionsFunc: method is nil but PromotionService.GetPromotions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetPromotions.Lock()
+	mock.calls.GetPromotions = append(mock.calls.GetPromotions, callInfo)
+	mock.lockGetPromotions.Unlock()
+	return mock.GetPromotionsFunc(ctx)
+}
+
+// GetPromotionsCalls gets all the calls that were made to GetPromotions.
+// Check the length with:
+//
+//	len(mockedPromotionService.GetPromotionsCalls())
+func (mock *PromotionServiceMock) GetPromotionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetPromotions.RLock()
+	calls = mock.calls.GetPromotions
+	mock.lockGetPromotions.RUnlock()
+	return calls
+}
+
+// UpdatePromotion calls UpdatePromotionFunc.
+func (mock *PromotionServiceMock) UpdatePromotion(ctx context.Context, id uuid.UUID, req models.PromotionRequest) (*models.Promotion, error) {
+	if mock.UpdatePromotionFunc == nil {
+		panic("PromotionServiceMock.UpdatePromotionFunc: method is nil but PromotionService.UpdatePromotion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.PromotionRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockUpdatePromotion.Lock()
+	mock.calls.UpdatePromotion = append(mock.calls.UpdatePromotion, callInfo)
+	mock.lockUpdatePromotion.Unlock()
+	return mock.UpdatePromotionFunc(ctx, id, req)
+}
+
+// UpdatePromotionCalls gets all the calls that were made to UpdatePromotion.
+// Check the length with:
+//
+//	len(mockedPromotionService.UpdatePromotionCalls())
+func (mock *PromotionServiceMock) UpdatePromotionCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.PromotionRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.PromotionRequest
+	}
+	mock.lockUpdatePromotion.RLock()
+	calls = mock.calls.UpdatePromotion
+	mock.lockUpdatePromotion.RUnlock()
+	return calls
+}
+
+// Ensure, that RelatedProductServiceMock does implement service.RelatedProductService.
+// If this is not the case, regenerate this file with moq.
+var _ service.RelatedProductService = &RelatedProductServiceMock{}
+
+// RelatedProductServiceMock is a mock implementation of service.RelatedProductService.
+//
+//	func TestSomethingThatUsesRelatedProductService(t *testing.T) {
+//
+//		// make and configure a mocked service.RelatedProductService
+//		mockedRelatedProductService := &RelatedProductServiceMock{
+//			AddRelatedFunc: func(ctx context.Context, productID uuid.UUID, req models.RelatedProductRequest) (*models.RelatedProduct, error) {
+//				panic("mock out the AddRelated method")
+//			},
+//			ForProductFunc: func(ctx context.Context, product *models.Product) (*models.ProductRelations, error) {
+//				panic("mock out the ForProduct method")
+//			},
+//			GetRelatedFunc: func(ctx context.Context, productID uuid.UUID) ([]models.RelatedProduct, error) {
+//				panic("mock out the GetRelated method")
+//			},
+//			RemoveRelatedFunc: func(ctx context.Context, productID uuid.UUID, id uuid.UUID) error {
+//				panic("mock out the RemoveRelated method")
+//			},
+//			UpdateRelatedFunc: func(ctx context.Context, productID uuid.UUID, id uuid.UUID, req models.RelatedProductUpdateRequest) (*models.RelatedProduct, error) {
+//				panic("mock out the UpdateRelated method")
+//			},
+//		}
+//
+//		// use mockedRelatedProductService in code that requires service.RelatedProductService
+//		// and then make assertions.
+//
+//	}
+type RelatedProductServiceMock struct {
+	// AddRelatedFunc mocks the AddRelated method.
+	AddRelatedFunc func(ctx context.Context, productID uuid.UUID, req models.RelatedProductRequest) (*models.RelatedProduct, error)
+
+	// ForProductFunc mocks the ForProduct method.
+	ForProductFunc func(ctx context.Context, product *models.Product) (*models.ProductRelations, error)
+
+	// GetRelatedFunc mocks the GetRelated method.
+	GetRelatedFunc func(ctx context.Context, productID uuid.UUID) ([]models.RelatedProduct, error)
+
+	// RemoveRelatedFunc mocks the RemoveRelated method.
+	RemoveRelatedFunc func(ctx context.Context, productID uuid.UUID, id uuid.UUID) error
+
+	// UpdateRelatedFunc mocks the UpdateRelated method.
+	UpdateRelatedFunc func(ctx context.Context, productID uuid.UUID, id uuid.UUID, req models.RelatedProductUpdateRequest) (*models.RelatedProduct, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddRelated holds details about calls to the AddRelated method.
+		AddRelated []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// Req is the req argument value.
+			Req models.RelatedProductRequest
+		}
+		// ForProduct holds details about calls to the ForProduct method.
+		ForProduct []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Product is the product argument value.
+			Product *models.Product
+		}
+		// GetRelated holds details about calls to the GetRelated method.
+		GetRelated []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+		}
+		// RemoveRelated holds details about calls to the RemoveRelated method.
+		RemoveRelated []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// UpdateRelated holds details about calls to the UpdateRelated method.
+		UpdateRelated []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.RelatedProductUpdateRequest
+		}
+	}
+	lockAddRelated    sync.RWMutex
+	lockForProduct    sync.RWMutex
+	lockGetRelated    sync.RWMutex
+	lockRemoveRelated sync.RWMutex
+	lockUpdateRelated sync.RWMutex
+}
+
+// AddRelated calls AddRelatedFunc.
+func (mock *RelatedProductServiceMock) AddRelated(ctx context.Context, productID uuid.UUID, req models.RelatedProductRequest) (*models.RelatedProduct, error) {
+	if mock.AddRelatedFunc == nil {
+		panic("RelatedProductServiceMock.AddRelatedFunc: method is nil but RelatedProductService.AddRelated was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.RelatedProductRequest
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		Req:       req,
+	}
+	mock.lockAddRelated.Lock()
+	mock.calls.AddRelated = append(mock.calls.AddRelated, callInfo)
+	mock.lockAddRelated.Unlock()
+	return mock.AddRelatedFunc(ctx, productID, req)
+}
+
+// AddRelatedCalls gets all the calls that were made to AddRelated.
+// Check the length with:
+//
+//	len(mockedRelatedProductService.AddRelatedCalls())
+func (mock *RelatedProductServiceMock) AddRelatedCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	Req       models.RelatedProductRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		Req       models.RelatedProductRequest
+	}
+	mock.lockAddRelated.RLock()
+	calls = mock.calls.AddRelated
+	mock.lockAddRelated.RUnlock()
+	return calls
+}
+
+// ForProduct calls ForProductFunc.
+func (mock *RelatedProductServiceMock) ForProduct(ctx context.Context, product *models.Product) (*models.ProductRelations, error) {
+	if mock.ForProductFunc == nil {
+		panic("RelatedProductServiceMock.ForProductFunc: method is nil but RelatedProductService.ForProduct was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Product *models.Product
+	}{
+		Ctx:     ctx,
+		Product: product,
+	}
+	mock.lockForProduct.Lock()
+	mock.calls.ForProduct = append(mock.calls.ForProduct, callInfo)
+	mock.lockForProduct.Unlock()
+	return mock.ForProductFunc(ctx, product)
+}
+
+// ForProductCalls gets all the calls that were made to ForProduct.
+// Check the length with:
+//
+//	len(mockedRelatedProductService.ForProductCalls())
+func (mock *RelatedProductServiceMock) ForProductCalls() []struct {
+	Ctx     context.Context
+	Product *models.Product
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Product *models.Product
+	}
+	mock.lockForProduct.RLock()
+	calls = mock.calls.ForProduct
+	mock.lockForProduct.RUnlock()
+	return calls
+}
+
+// GetRelated calls GetRelatedFunc.
+func (mock *RelatedProductServiceMock) GetRelated(ctx context.Context, productID uuid.UUID) ([]models.RelatedProduct, error) {
+	if mock.GetRelatedFunc == nil {
+		panic("RelatedProductServiceMock.GetRelatedFunc: method is nil but RelatedProductService.GetRelated was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+	}
+	mock.lockGetRelated.Lock()
+	mock.calls.GetRelated = append(mock.calls.GetRelated, callInfo)
+	mock.lockGetRelated.Unlock()
+	return mock.GetRelatedFunc(ctx, productID)
+}
+
+// GetRelatedCalls gets all the calls that were made to GetRelated.
+// Check the length with:
+//
+//	len(mockedRelatedProductService.GetRelatedCalls())
+func (mock *RelatedProductServiceMock) GetRelatedCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}
+	mock.lockGetRelated.RLock()
+	calls = mock.calls.GetRelated
+	mock.lockGetRelated.RUnlock()
+	return calls
+}
+
+// RemoveRelated calls RemoveRelatedFunc.
+func (mock *RelatedProductServiceMock) RemoveRelated(ctx context.Context, productID uuid.UUID, id uuid.UUID) error {
+	if mock.RemoveRelatedFunc == nil {
+		panic("RelatedProductServiceMock.RemoveRelatedFunc: method is nil but RelatedProductService.RemoveRelated was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		ID        uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		ID:        id,
+	}
+	mock.lockRemoveRelated.Lock()
+	mock.calls.RemoveRelated = append(mock.calls.RemoveRelated, callInfo)
+	mock.lockRemoveRelated.Unlock()
+	return mock.RemoveRelatedFunc(ctx, productID, id)
+}
+
+// RemoveRelatedCalls gets all the calls that were made to RemoveRelated.
+// Check the length with:
+//
+//	len(mockedRelatedProductService.RemoveRelatedCalls())
+func (mock *RelatedProductServiceMock) RemoveRelatedCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	ID        uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		ID        uuid.UUID
+	}
+	mock.lockRemoveRelated.RLock()
+	calls = mock.calls.RemoveRelated
+	mock.lockRemoveRelated.RUnlock()
+	return calls
+}
+
+// UpdateRelated calls UpdateRelatedFunc.
+func (mock *RelatedProductServiceMock) UpdateRelated(ctx context.Context, productID uuid.UUID, id uuid.UUID, req models.RelatedProductUpdateRequest) (*models.RelatedProduct, error) {
+	if mock.UpdateRelatedFunc == nil {
+		panic("RelatedProductServiceMock.UpdateRelatedFunc: method is nil but RelatedProductService.UpdateRelated was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		ID        uuid.UUID
+		Req       models.RelatedProductUpdateRequest
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+		ID:        id,
+		Req:       req,
+	}
+	mock.lockUpdateRelated.Lock()
+	mock.calls.UpdateRelated = append(mock.calls.UpdateRelated, callInfo)
+	mock.lockUpdateRelated.Unlock()
+	return mock.UpdateRelatedFunc(ctx, productID, id, req)
+}
+
+// UpdateRelatedCalls gets all the calls that were made to UpdateRelated.
+// Check the length with:
+//
+//	len(mockedRelatedProductService.UpdateRelatedCalls())
+func (mock *RelatedProductServiceMock) UpdateRelatedCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+	ID        uuid.UUID
+	Req       models.RelatedProductUpdateRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+		ID        uuid.UUID
+		Req       models.RelatedProductUpdateRequest
+	}
+	mock.lockUpdateRelated.RLock()
+	calls = mock.calls.UpdateRelated
+	mock.lockUpdateRelated.RUnlock()
+	return calls
+}
+
+// Ensure, that ReservationCleanupServiceMock does implement service.ReservationCleanupService.
+// If this is not the case, regenerate this file with moq.
+var _ service.ReservationCleanupService = &ReservationCleanupServiceMock{}
+
+// ReservationCleanupServiceMock is a mock implementation of service.ReservationCleanupService.
+//
+//	func TestSomethingThatUsesReservationCleanupService(t *testing.T) {
+//
+//		// make and configure a mocked service.ReservationCleanupService
+//		mockedReservationCleanupService := &ReservationCleanupServiceMock{
+//			PurgeExpiredFunc: func(ctx context.Context) (*models.ReservationCleanupResult, error) {
+//				panic("mock out the PurgeExpired method")
+//			},
+//			StartFunc: func(ctx context.Context, interval time.Duration)  {
+//				panic("mock out the Start method")
+//			},
+//			StatsFunc: func() models.ReservationCleanupStats {
+//				panic("mock out the Stats method")
+//			},
+//		}
+//
+//		// use mockedReservationCleanupService in code that requires service.ReservationCleanupService
+//		// and then make assertions.
+//
+//	}
+type ReservationCleanupServiceMock struct {
+	// PurgeExpiredFunc mocks the PurgeExpired method.
+	PurgeExpiredFunc func(ctx context.Context) (*models.ReservationCleanupResult, error)
+
+	// StartFunc mocks the Start method.
+	StartFunc func(ctx context.Context, interval time.Duration)
+
+	// StatsFunc mocks the Stats method.
+	StatsFunc func() models.ReservationCleanupStats
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// PurgeExpired holds details about calls to the PurgeExpired method.
+		PurgeExpired []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Start holds details about calls to the Start method.
+		Start []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Interval is the interval argument value.
+			Interval time.Duration
+		}
+		// Stats holds details about calls to the Stats method.
+		Stats []struct {
+		}
+	}
+	lockPurgeExpired sync.RWMutex
+	lockStart        sync.RWMutex
+	lockStats        sync.RWMutex
+}
+
+// PurgeExpired calls PurgeExpiredFunc.
+func (mock *ReservationCleanupServiceMock) PurgeExpired(ctx context.Context) (*models.ReservationCleanupResult, error) {
+	if mock.PurgeExpiredFunc == nil {
+		panic("ReservationCleanupServiceMock.PurgeExpiredFunc: method is nil but ReservationCleanupService.PurgeExpired was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPurgeExpired.Lock()
+	mock.calls.PurgeExpired = append(mock.calls.PurgeExpired, callInfo)
+	mock.lockPurgeExpired.Unlock()
+	return mock.PurgeExpiredFunc(ctx)
+}
+
+// PurgeExpiredCalls gets all the calls that were made to PurgeExpired.
+// Check the length with:
+//
+//	len(mockedReservationCleanupService.PurgeExpiredCalls())
+func (mock *ReservationCleanupServiceMock) PurgeExpiredCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPurgeExpired.RLock()
+	calls = mock.calls.PurgeExpired
+	mock.lockPurgeExpired.RUnlock()
+	return calls
+}
+
+// Start calls StartFunc.
+func (mock *ReservationCleanupServiceMock) Start(ctx context.Context, interval time.Duration) {
+	if mock.StartFunc == nil {
+		panic("ReservationCleanupServiceMock.StartFunc: method is nil but ReservationCleanupService.Start was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}{
+		Ctx:      ctx,
+		Interval: interval,
+	}
+	mock.lockStart.Lock()
+	mock.calls.Start = append(mock.calls.Start, callInfo)
+	mock.lockStart.Unlock()
+	mock.StartFunc(ctx, interval)
+}
+
+// StartCalls gets all the calls that were made to Start.
+// Check the length with:
+//
+//	len(mockedReservationCleanupService.StartCalls())
+func (mock *ReservationCleanupServiceMock) StartCalls() []struct {
+	Ctx      context.Context
+	Interval time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}
+	mock.lockStart.RLock()
+	calls = mock.calls.Start
+	mock.lockStart.RUnlock()
+	return calls
+}
+
+// Stats calls StatsFunc.
+func (mock *ReservationCleanupServiceMock) Stats() models.ReservationCleanupStats {
+	if mock.StatsFunc == nil {
+		panic("ReservationCleanupServiceMock.StatsFunc: method is nil but ReservationCleanupService.Stats was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockStats.Lock()
+	mock.calls.Stats = append(mock.calls.Stats, callInfo)
+	mock.lockStats.Unlock()
+	return mock.StatsFunc()
+}
+
+// StatsCalls gets all the calls that were made to Stats.
+// Check the length with:
+//
+//	len(mockedReservationCleanupService.StatsCalls())
+func (mock *ReservationCleanupServiceMock) StatsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockStats.RLock()
+	calls = mock.calls.Stats
+	mock.lockStats.RUnlock()
+	return calls
+}
+
+// Ensure, that ReturnServiceMock does implement service.ReturnService.
+// If this is not the case, regenerate this file with moq.
+var _ service.ReturnService = &ReturnServiceMock{}
+
+// ReturnServiceMock is a mock implementation of service.ReturnService.
+//
+//	func TestSomethingThatUsesReturnService(t *testing.T) {
+//
+//		// make and configure a mocked service.ReturnService
+//		mockedReturnService := &ReturnServiceMock{
+//			CreateReturnFunc: func(ctx context.Context, req models.CreateReturnRequest, userID uuid.UUID) (*models.Return, error) {
+//				panic("mock out the CreateReturn method")
+//			},
+//			GetAllReturnsFunc: func(ctx context.Context, page int, limit int, status string, rangeDays int) ([]models.AdminReturn, int, error) {
+//				panic("mock out the GetAllReturns method")
+//			},
+//			GetReturnFunc: func(ctx context.Context, returnID uuid.UUID, userID uuid.UUID) (*models.Return, error) {
+//				panic("mock out the GetReturn method")
+//			},
+//			GetReturnLabelFunc: func(ctx context.Context, returnID uuid.UUID, userID uuid.UUID) (*models.ReturnLabel, error) {
+//				panic("mock out the GetReturnLabel method")
+//			},
+//			GetUserReturnsFunc: func(ctx context.Context, userID uuid.UUID, page int, limit int) ([]models.Return, int, error) {
+//				panic("mock out the GetUserReturns method")
+//			},
+//			MarkReturnReceivedFunc: func(ctx context.Context, returnID uuid.UUID) (*models.Return, error) {
+//				panic("mock out the MarkReturnReceived method")
+//			},
+//			ProcessReturnFunc: func(ctx context.Context, returnID uuid.UUID, req models.ProcessReturnRequest) (*models.Return, error) {
+//				panic("mock out the ProcessReturn method")
+//			},
+//			ShipReturnFunc: func(ctx context.Context, returnID uuid.UUID, userID uuid.UUID, req models.ShipReturnRequest) (*models.Return, error) {
+//				panic("mock out the ShipReturn method")
+//			},
+//		}
+//
+//		// use mockedReturnService in code that requires service.ReturnService
+//		// and then make assertions.
+//
+//	}
+type ReturnServiceMock struct {
+	// CreateReturnFunc mocks the CreateReturn method.
+	CreateReturnFunc func(ctx context.Context, req models.CreateReturnRequest, userID uuid.UUID) (*models.Return, error)
+
+	// GetAllReturnsFunc mocks the GetAllReturns method.
+	GetAllReturnsFunc func(ctx context.Context, page int, limit int, status string, rangeDays int) ([]models.AdminReturn, int, error)
+
+	// GetReturnFunc mocks the GetReturn method.
+	GetReturnFunc func(ctx context.Context, returnID uuid.UUID, userID uuid.UUID) (*models.Return, error)
+
+	// GetReturnLabelFunc mocks the GetReturnLabel method.
+	GetReturnLabelFunc func(ctx context.Context, returnID uuid.UUID, userID uuid.UUID) (*models.ReturnLabel, error)
+
+	// GetUserReturnsFunc mocks the GetUserReturns method.
+	GetUserReturnsFunc func(ctx context.Context, userID uuid.UUID, page int, limit int) ([]models.Return, int, error)
+
+	// MarkReturnReceivedFunc mocks the MarkReturnReceived method.
+	MarkReturnReceivedFunc func(ctx context.Context, returnID uuid.UUID) (*models.Return, error)
+
+	// ProcessReturnFunc mocks the ProcessReturn method.
+	ProcessReturnFunc func(ctx context.Context, returnID uuid.UUID, req models.ProcessReturnRequest) (*models.Return, error)
+
+	// ShipReturnFunc mocks the ShipReturn method.
+	ShipReturnFunc func(ctx context.Context, returnID uuid.UUID, userID uuid.UUID, req models.ShipReturnRequest) (*models.Return, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateReturn holds details about calls to the CreateReturn method.
+		CreateReturn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CreateReturnRequest
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// GetAllReturns holds details about calls to the GetAllReturns method.
+		GetAllReturns []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+			// Status is the status argument value.
+			Status string
+			// RangeDays is the rangeDays argument value.
+			RangeDays int
+		}
+		// GetReturn holds details about calls to the GetReturn method.
+		GetReturn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReturnID is the returnID argument value.
+			ReturnID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// GetReturnLabel holds details about calls to the GetReturnLabel method.
+		GetReturnLabel []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReturnID is the returnID argument value.
+			ReturnID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// GetUserReturns holds details about calls to the GetUserReturns method.
+		GetUserReturns []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// MarkReturnReceived holds details about calls to the MarkReturnReceived method.
+		MarkReturnReceived []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReturnID is the returnID argument value.
+			ReturnID uuid.UUID
+		}
+		// ProcessReturn holds details about calls to the ProcessReturn method.
+		ProcessReturn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReturnID is the returnID argument value.
+			ReturnID uuid.UUID
+			// Req is the req argument value.
+			Req models.ProcessReturnRequest
+		}
+		// ShipReturn holds details about calls to the ShipReturn method.
+		ShipReturn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReturnID is the returnID argument value.
+			ReturnID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Req is the req argument value.
+			Req models.ShipReturnRequest
+		}
+	}
+	lockCreateReturn       sync.RWMutex
+	lockGetAllReturns      sync.RWMutex
+	lockGetReturn          sync.RWMutex
+	lockGetReturnLabel     sync.RWMutex
+	lockGetUserReturns     sync.RWMutex
+	lockMarkReturnReceived sync.RWMutex
+	lockProcessReturn      sync.RWMutex
+	lockShipReturn         sync.RWMutex
+}
+
+// CreateReturn calls CreateReturnFunc.
+func (mock *ReturnServiceMock) CreateReturn(ctx context.Context, req models.CreateReturnRequest, userID uuid.UUID) (*models.Return, error) {
+	if mock.CreateReturnFunc == nil {
+		panic("ReturnServiceMock.CreateReturnFunc: method is nil but ReturnService.CreateReturn was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Req    models.CreateReturnRequest
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		Req:    req,
+		UserID: userID,
+	}
+	mock.lockCreateReturn.Lock()
+	mock.calls.CreateReturn = append(mock.calls.CreateReturn, callInfo)
+	mock.lockCreateReturn.Unlock()
+	return mock.CreateReturnFunc(ctx, req, userID)
+}
+
+// CreateReturnCalls gets all the calls that were made to CreateReturn.
+// Check the length with:
+//
+//	len(mockedReturnService.CreateReturnCalls())
+func (mock *ReturnServiceMock) CreateReturnCalls() []struct {
+	Ctx    context.Context
+	Req    models.CreateReturnRequest
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Req    models.CreateReturnRequest
+		UserID uuid.UUID
+	}
+	mock.lockCreateReturn.RLock()
+	calls = mock.calls.CreateReturn
+	mock.lockCreateReturn.RUnlock()
+	return calls
+}
+
+// GetAllReturns calls GetAllReturnsFunc.
+func (mock *ReturnServiceMock) GetAllReturns(ctx context.Context, page int, limit int, status string, rangeDays int) ([]models.AdminReturn, int, error) {
+	if mock.GetAllReturnsFunc == nil {
+		panic("ReturnServiceMock.GetAllReturnsFunc: method is nil but ReturnService.GetAllReturns was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Page      int
+		Limit     int
+		Status    string
+		RangeDays int
+	}{
+		Ctx:       ctx,
+		Page:      page,
+		Limit:     limit,
+		Status:    status,
+		RangeDays: rangeDays,
+	}
+	mock.lockGetAllReturns.Lock()
+	mock.calls.GetAllReturns = append(mock.calls.GetAllReturns, callInfo)
+	mock.lockGetAllReturns.Unlock()
+	return mock.GetAllReturnsFunc(ctx, page, limit, status, rangeDays)
+}
+
+// GetAllReturnsCalls gets all the calls that were made to GetAllReturns.
+// Check the length with:
+//
+//	len(mockedReturnService.GetAllReturnsCalls())
+func (mock *ReturnServiceMock) GetAllReturnsCalls() []struct {
+	Ctx       context.Context
+	Page      int
+	Limit     int
+	Status    string
+	RangeDays int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Page      int
+		Limit     int
+		Status    string
+		RangeDays int
+	}
+	mock.lockGetAllReturns.RLock()
+	calls = mock.calls.GetAllReturns
+	mock.lockGetAllReturns.RUnlock()
+	return calls
+}
+
+// GetReturn calls GetReturnFunc.
+func (mock *ReturnServiceMock) GetReturn(ctx context.Context, returnID uuid.UUID, userID uuid.UUID) (*models.Return, error) {
+	if mock.GetReturnFunc == nil {
+		panic("ReturnServiceMock.GetReturnFunc: method is nil but ReturnService.GetReturn was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		UserID   uuid.UUID
+	}{
+		Ctx:      ctx,
+		ReturnID: returnID,
+		UserID:   userID,
+	}
+	mock.lockGetReturn.Lock()
+	mock.calls.GetReturn = append(mock.calls.GetReturn, callInfo)
+	mock.lockGetReturn.Unlock()
+	return mock.GetReturnFunc(ctx, returnID, userID)
+}
+
+// GetReturnCalls gets all the calls that were made to GetReturn.
+// Check the length with:
+//
+//	len(mockedReturnService.GetReturnCalls())
+func (mock *ReturnServiceMock) GetReturnCalls() []struct {
+	Ctx      context.Context
+	ReturnID uuid.UUID
+	UserID   uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		UserID   uuid.UUID
+	}
+	mock.lockGetReturn.RLock()
+	calls = mock.calls.GetReturn
+	mock.lockGetReturn.RUnlock()
+	return calls
+}
+
+// GetReturnLabel calls GetReturnLabelFunc.
+func (mock *ReturnServiceMock) GetReturnLabel(ctx context.Context, returnID uuid.UUID, userID uuid.UUID) (*models.ReturnLabel, error) {
+	if mock.GetReturnLabelFunc == nil {
+		panic("ReturnServiceMock.GetReturnLabelFunc: method is nil but ReturnService.GetReturnLabel was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		UserID   uuid.UUID
+	}{
+		Ctx:      ctx,
+		ReturnID: returnID,
+		UserID:   userID,
+	}
+	mock.lockGetReturnLabel.Lock()
+	mock.calls.GetReturnLabel = append(mock.calls.GetReturnLabel, callInfo)
+	mock.lockGetReturnLabel.Unlock()
+	return mock.GetReturnLabelFunc(ctx, returnID, userID)
+}
+
+// GetReturnLabelCalls gets all the calls that were made to GetReturnLabel.
+// Check the length with:
+//
+//	len(mockedReturnService.GetReturnLabelCalls())
+func (mock *ReturnServiceMock) GetReturnLabelCalls() []struct {
+	Ctx      context.Context
+	ReturnID uuid.UUID
+	UserID   uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		UserID   uuid.UUID
+	}
+	mock.lockGetReturnLabel.RLock()
+	calls = mock.calls.GetReturnLabel
+	mock.lockGetReturnLabel.RUnlock()
+	return calls
+}
+
+// GetUserReturns calls GetUserReturnsFunc.
+func (mock *ReturnServiceMock) GetUserReturns(ctx context.Context, userID uuid.UUID, page int, limit int) ([]models.Return, int, error) {
+	if mock.GetUserReturnsFunc == nil {
+		panic("ReturnServiceMock.GetUserReturnsFunc: method is nil but ReturnService.GetUserReturns was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Page   int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Page:   page,
+		Limit:  limit,
+	}
+	mock.lockGetUserReturns.Lock()
+	mock.calls.GetUserReturns = append(mock.calls.GetUserReturns, callInfo)
+	mock.lockGetUserReturns.Unlock()
+	return mock.GetUserReturnsFunc(ctx, userID, page, limit)
+}
+
+// GetUserReturnsCalls gets all the calls that were made to GetUserReturns.
+// Check the length with:
+//
+//	len(mockedReturnService.GetUserReturnsCalls())
+func (mock *ReturnServiceMock) GetUserReturnsCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Page   int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Page   int
+		Limit  int
+	}
+	mock.lockGetUserReturns.RLock()
+	calls = mock.calls.GetUserReturns
+	mock.lockGetUserReturns.RUnlock()
+	return calls
+}
+
+// MarkReturnReceived calls MarkReturnReceivedFunc.
+func (mock *ReturnServiceMock) MarkReturnReceived(ctx context.Context, returnID uuid.UUID) (*models.Return, error) {
+	if mock.MarkReturnReceivedFunc == nil {
+		panic("ReturnServiceMock.MarkReturnReceivedFunc: method is nil but ReturnService.MarkReturnReceived was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+	}{
+		Ctx:      ctx,
+		ReturnID: returnID,
+	}
+	mock.lockMarkReturnReceived.Lock()
+	mock.calls.MarkReturnReceived = append(mock.calls.MarkReturnReceived, callInfo)
+	mock.lockMarkReturnReceived.Unlock()
+	return mock.MarkReturnReceivedFunc(ctx, returnID)
+}
+
+// MarkReturnReceivedCalls gets all the calls that were made to MarkReturnReceived.
+// Check the length with:
+//
+//	len(mockedReturnService.MarkReturnReceivedCalls())
+func (mock *ReturnServiceMock) MarkReturnReceivedCalls() []struct {
+	Ctx      context.Context
+	ReturnID uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+	}
+	mock.lockMarkReturnReceived.RLock()
+	calls = mock.calls.MarkReturnReceived
+	mock.lockMarkReturnReceived.RUnlock()
+	return calls
+}
+
+// ProcessReturn calls ProcessReturnFunc.
+func (mock *ReturnServiceMock) ProcessReturn(ctx context.Context, returnID uuid.UUID, req models.ProcessReturnRequest) (*models.Return, error) {
+	if mock.ProcessReturnFunc == nil {
+		panic("ReturnServiceMock.ProcessReturnFunc: method is nil but ReturnService.ProcessReturn was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		Req      models.ProcessReturnRequest
+	}{
+		Ctx:      ctx,
+		ReturnID: returnID,
+		Req:      req,
+	}
+	mock.lockProcessReturn.Lock()
+	mock.calls.ProcessReturn = append(mock.calls.ProcessReturn, callInfo)
+	mock.lockProcessReturn.Unlock()
+	return mock.ProcessReturnFunc(ctx, returnID, req)
+}
+
+// ProcessReturnCalls gets all the calls that were made to ProcessReturn.
+// Check the length with:
+//
+//	len(mockedReturnService.ProcessReturnCalls())
+func (mock *ReturnServiceMock) ProcessReturnCalls() []struct {
+	Ctx      context.Context
+	ReturnID uuid.UUID
+	Req      models.ProcessReturnRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		Req      models.ProcessReturnRequest
+	}
+	mock.lockProcessReturn.RLock()
+	calls = mock.calls.ProcessReturn
+	mock.lockProcessReturn.RUnlock()
+	return calls
+}
+
+// ShipReturn calls ShipReturnFunc.
+func (mock *ReturnServiceMock) ShipReturn(ctx context.Context, returnID uuid.UUID, userID uuid.UUID, req models.ShipReturnRequest) (*models.Return, error) {
+	if mock.ShipReturnFunc == nil {
+		panic("ReturnServiceMock.ShipReturnFunc: method is nil but ReturnService.ShipReturn was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		UserID   uuid.UUID
+		Req      models.ShipReturnRequest
+	}{
+		Ctx:      ctx,
+		ReturnID: returnID,
+		UserID:   userID,
+		Req:      req,
+	}
+	mock.lockShipReturn.Lock()
+	mock.calls.ShipReturn = append(mock.calls.ShipReturn, callInfo)
+	mock.lockShipReturn.Unlock()
+	return mock.ShipReturnFunc(ctx, returnID, userID, req)
+}
+
+// ShipReturnCalls gets all the calls that were made to ShipReturn.
+// Check the length with:
+//
+//	len(mockedReturnService.ShipReturnCalls())
+func (mock *ReturnServiceMock) ShipReturnCalls() []struct {
+	Ctx      context.Context
+	ReturnID uuid.UUID
+	UserID   uuid.UUID
+	Req      models.ShipReturnRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ReturnID uuid.UUID
+		UserID   uuid.UUID
+		Req      models.ShipReturnRequest
+	}
+	mock.lockShipReturn.RLock()
+	calls = mock.calls.ShipReturn
+	mock.lockShipReturn.RUnlock()
+	return calls
+}
+
+// Ensure, that ScheduledPublishingServiceMock does implement service.ScheduledPublishingService.
+// If this is not the case, regenerate this file with moq.
+var _ service.ScheduledPublishingService = &ScheduledPublishingServiceMock{}
+
+// ScheduledPublishingServiceMock is a mock implementation of service.ScheduledPublishingService.
+//
+//	func TestSomethingThatUsesScheduledPublishingService(t *testing.T) {
+//
+//		// make and configure a mocked service.ScheduledPublishingService
+//		mockedScheduledPublishingService := &ScheduledPublishingServiceMock{
+//			StartFunc: func(ctx context.Context, interval time.Duration)  {
+//				panic("mock out the Start method")
+//			},
+//		}
+//
+//		// use mockedScheduledPublishingService in code that requires service.ScheduledPublishingService
+//		// and then make assertions.
+//
+//	}
+type ScheduledPublishingServiceMock struct {
+	// StartFunc mocks the Start method.
+	StartFunc func(ctx context.Context, interval time.Duration)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Start holds details about calls to the Start method.
+		Start []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Interval is the interval argument value.
+			Interval time.Duration
+		}
+	}
+	lockStart sync.RWMutex
+}
+
+// Start calls StartFunc.
+func (mock *ScheduledPublishingServiceMock) Start(ctx context.Context, interval time.Duration) {
+	if mock.StartFunc == nil {
+		panic("ScheduledPublishingServiceMock.StartFunc: method is nil but ScheduledPublishingService.Start was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}{
+		Ctx:      ctx,
+		Interval: interval,
+	}
+	mock.lockStart.Lock()
+	mock.calls.Start = append(mock.calls.Start, callInfo)
+	mock.lockStart.Unlock()
+	mock.StartFunc(ctx, interval)
+}
+
+// StartCalls gets all the calls that were made to Start.
+// Check the length with:
+//
+//	len(mockedScheduledPublishingService.StartCalls())
+func (mock *ScheduledPublishingServiceMock) StartCalls() []struct {
+	Ctx      context.Context
+	Interval time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}
+	mock.lockStart.RLock()
+	calls = mock.calls.Start
+	mock.lockStart.RUnlock()
+	return calls
+}
+
+// Ensure, that SegmentServiceMock does implement service.SegmentService.
+// If this is not the case, regenerate this file with moq.
+var _ service.SegmentService = &SegmentServiceMock{}
+
+// SegmentServiceMock is a mock implementation of service.SegmentService.
+//
+//	func TestSomethingThatUsesSegmentService(t *testing.T) {
+//
+//		// make and configure a mocked service.SegmentService
+//		mockedSegmentService := &SegmentServiceMock{
+//			CreateSegmentFunc: func(ctx context.Context, req models.CustomerSegmentRequest) (*models.CustomerSegment, error) {
+//				panic("mock out the CreateSegment method")
+//			},
+//			DeleteSegmentFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the DeleteSegment method")
+//			},
+//			ExportMembersFunc: func(ctx context.Context, id uuid.UUID, fn func(models.SegmentMember) error) error {
+//				panic("mock out the ExportMembers method")
+//			},
+//			GetMembersFunc: func(ctx context.Context, id uuid.UUID, page int, limit int) ([]models.SegmentMember, int, error) {
+//				panic("mock out the GetMembers method")
+//			},
+//			GetSegmentFunc: func(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error) {
+//				panic("mock out the GetSegment method")
+//			},
+//			GetSegmentsFunc: func(ctx context.Context) ([]models.CustomerSegment, error) {
+//				panic("mock out the GetSegments method")
+//			},
+//			RefreshAllFunc: func(ctx context.Context) (int, error) {
+//				panic("mock out the RefreshAll method")
+//			},
+//			RefreshSegmentFunc: func(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error) {
+//				panic("mock out the RefreshSegment method")
+//			},
+//			StartFunc: func(ctx context.Context, interval time.Duration)  {
+//				panic("mock out the Start method")
+//			},
+//			UpdateSegmentFunc: func(ctx context.Context, id uuid.UUID, req models.CustomerSegmentRequest) (*models.CustomerSegment, error) {
+//				panic("mock out the UpdateSegment method")
+//			},
+//		}
+//
+//		// use mockedSegmentService in code that requires service.SegmentService
+//		// and then make assertions.
+//
+//	}
+type SegmentServiceMock struct {
+	// CreateSegmentFunc mocks the CreateSegment method.
+	CreateSegmentFunc func(ctx context.Context, req models.CustomerSegmentRequest) (*models.CustomerSegment, error)
+
+	// DeleteSegmentFunc mocks the DeleteSegment method.
+	DeleteSegmentFunc func(ctx context.Context, id uuid.UUID) error
+
+	// ExportMembersFunc mocks the ExportMembers method.
+	ExportMembersFunc func(ctx context.Context, id uuid.UUID, fn func(models.SegmentMember) error) error
+
+	// GetMembersFunc mocks the GetMembers method.
+	GetMembersFunc func(ctx context.Context, id uuid.UUID, page int, limit int) ([]models.SegmentMember, int, error)
+
+	// GetSegmentFunc mocks the GetSegment method.
+	GetSegmentFunc func(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error)
+
+	// GetSegmentsFunc mocks the GetSegments method.
+	GetSegmentsFunc func(ctx context.Context) ([]models.CustomerSegment, error)
+
+	// RefreshAllFunc mocks the RefreshAll method.
+	RefreshAllFunc func(ctx context.Context) (int, error)
+
+	// RefreshSegmentFunc mocks the RefreshSegment method.
+	RefreshSegmentFunc func(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error)
+
+	// StartFunc mocks the Start method.
+	StartFunc func(ctx context.Context, interval time.Duration)
+
+	// UpdateSegmentFunc mocks the UpdateSegment method.
+	UpdateSegmentFunc func(ctx context.Context, id uuid.UUID, req models.CustomerSegmentRequest) (*models.CustomerSegment, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateSegment holds details about calls to the CreateSegment method.
+		CreateSegment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CustomerSegmentRequest
+		}
+		// DeleteSegment holds details about calls to the DeleteSegment method.
+		DeleteSegment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// ExportMembers holds details about calls to the ExportMembers method.
+		ExportMembers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Fn is the fn argument value.
+			Fn func(models.SegmentMember) error
+		}
+		// GetMembers holds details about calls to the GetMembers method.
+		GetMembers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetSegment holds details about calls to the GetSegment method.
+		GetSegment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetSegments holds details about calls to the GetSegments method.
+		GetSegments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RefreshAll holds details about calls to the RefreshAll method.
+		RefreshAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RefreshSegment holds details about calls to the RefreshSegment method.
+		RefreshSegment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// Start holds details about calls to the Start method.
+		Start []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Interval is the interval argument value.
+			Interval time.Duration
+		}
+		// UpdateSegment holds details about calls to the UpdateSegment method.
+		UpdateSegment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.CustomerSegmentRequest
+		}
+	}
+	lockCreateSegment  sync.RWMutex
+	lockDeleteSegment  sync.RWMutex
+	lockExportMembers  sync.RWMutex
+	lockGetMembers     sync.RWMutex
+	lockGetSegment     sync.RWMutex
+	lockGetSegments    sync.RWMutex
+	lockRefreshAll     sync.RWMutex
+	lockRefreshSegment sync.RWMutex
+	lockStart          sync.RWMutex
+	lockUpdateSegment  sync.RWMutex
+}
+
+// CreateSegment calls CreateSegmentFunc.
+func (mock *SegmentServiceMock) CreateSegment(ctx context.Context, req models.CustomerSegmentRequest) (*models.CustomerSegment, error) {
+	if mock.CreateSegmentFunc == nil {
+		panic("SegmentServiceMock.CreateSegmentFunc: method is nil but SegmentService.CreateSegment was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.CustomerSegmentRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateSegment.Lock()
+	mock.calls.CreateSegment = append(mock.calls.CreateSegment, callInfo)
+	mock.lockCreateSegment.Unlock()
+	return mock.CreateSegmentFunc(ctx, req)
+}
+
+// CreateSegmentCalls gets all the calls that were made to CreateSegment.
+// Check the length with:
+//
+//	len(mockedSegmentService.CreateSegmentCalls())
+func (mock *SegmentServiceMock) CreateSegmentCalls() []struct {
+	Ctx context.Context
+	Req models.CustomerSegmentRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.CustomerSegmentRequest
+	}
+	mock.lockCreateSegment.RLock()
+	calls = mock.calls.CreateSegment
+	mock.lockCreateSegment.RUnlock()
+	return calls
+}
+
+// DeleteSegment calls DeleteSegmentFunc.
+func (mock *SegmentServiceMock) DeleteSegment(ctx context.Context, id uuid.UUID) error {
+	if mock.DeleteSegmentFunc == nil {
+		panic("SegmentServiceMock.DeleteSegmentFunc: method is nil but SegmentService.DeleteSegment was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteSegment.Lock()
+	mock.calls.DeleteSegment = append(mock.calls.DeleteSegment, callInfo)
+	mock.lockDeleteSegment.Unlock()
+	return mock.DeleteSegmentFunc(ctx, id)
+}
+
+// DeleteSegmentCalls gets all the calls that were made to DeleteSegment.
+// Check the length with:
+//
+//	len(mockedSegmentService.DeleteSegmentCalls())
+func (mock *SegmentServiceMock) DeleteSegmentCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDeleteSegment.RLock()
+	calls = mock.calls.DeleteSegment
+	mock.lockDeleteSegment.RUnlock()
+	return calls
+}
+
+// ExportMembers calls ExportMembersFunc.
+func (mock *SegmentServiceMock) ExportMembers(ctx context.Context, id uuid.UUID, fn func(models.SegmentMember) error) error {
+	if mock.ExportMembersFunc == nil {
+		panic("SegmentServiceMock.ExportMembersFunc: method is nil but SegmentService.ExportMembers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Fn  func(models.SegmentMember) error
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Fn:  fn,
+	}
+	mock.lockExportMembers.Lock()
+	mock.calls.ExportMembers = append(mock.calls.ExportMembers, callInfo)
+	mock.lockExportMembers.Unlock()
+	return mock.ExportMembersFunc(ctx, id, fn)
+}
+
+// ExportMembersCalls gets all the calls that were made to ExportMembers.
+// Check the length with:
+//
+//	len(mockedSegmentService.ExportMembersCalls())
+func (mock *SegmentServiceMock) ExportMembersCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Fn  func(models.SegmentMember) error
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Fn  func(models.SegmentMember) error
+	}
+	mock.lockExportMembers.RLock()
+	calls = mock.calls.ExportMembers
+	mock.lockExportMembers.RUnlock()
+	return calls
+}
+
+// GetMembers calls GetMembersFunc.
+func (mock *SegmentServiceMock) GetMembers(ctx context.Context, id uuid.UUID, page int, limit int) ([]models.SegmentMember, int, error) {
+	if mock.GetMembersFunc == nil {
+		panic("SegmentServiceMock.GetMembersFunc: method is nil but SegmentService.GetMembers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    uuid.UUID
+		Page  int
+		Limit int
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Page:  page,
+		Limit: limit,
+	}
+	mock.lockGetMembers.Lock()
+	mock.calls.GetMembers = append(mock.calls.GetMembers, callInfo)
+	mock.lockGetMembers.Unlock()
+	return mock.GetMembersFunc(ctx, id, page, limit)
+}
+
+// GetMembersCalls gets all the calls that were made to GetMembers.
+// Check the length with:
+//
+//	len(mockedSegmentService.GetMembersCalls())
+func (mock *SegmentServiceMock) GetMembersCalls() []struct {
+	Ctx   context.Context
+	ID    uuid.UUID
+	Page  int
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    uuid.UUID
+		Page  int
+		Limit int
+	}
+	mock.lockGetMembers.RLock()
+	calls = mock.calls.GetMembers
+	mock.lockGetMembers.RUnlock()
+	return calls
+}
+
+// GetSegment calls GetSegmentFunc.
+func (mock *SegmentServiceMock) GetSegment(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error) {
+	if mock.GetSegmentFunc == nil {
+		panic("SegmentServiceMock.GetSegmentFunc: method is nil but SegmentService.GetSegment was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetSegment.Lock()
+	mock.calls.GetSegment = append(mock.calls.GetSegment, callInfo)
+	mock.lockGetSegment.Unlock()
+	return mock.GetSegmentFunc(ctx, id)
+}
+
+// GetSegmentCalls gets all the calls that were made to GetSegment.
+// Check the length with:
+//
+//	len(mockedSegmentService.GetSegmentCalls())
+func (mock *SegmentServiceMock) GetSegmentCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetSegment.RLock()
+	calls = mock.calls.GetSegment
+	mock.lockGetSegment.RUnlock()
+	return calls
+}
+
+// GetSegments calls GetSegmentsFunc.
+func (mock *SegmentServiceMock) GetSegments(ctx context.Context) ([]models.CustomerSegment, error) {
+	if mock.GetSegmentsFunc == nil {
+		panic("SegmentServiceMock.GetSegmentsFunc: method is nil but SegmentService.GetSegments was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetSegments.Lock()
+	mock.calls.GetSegments = append(mock.calls.GetSegments, callInfo)
+	mock.lockGetSegments.Unlock()
+	return mock.GetSegmentsFunc(ctx)
+}
+
+// GetSegmentsCalls gets all the calls that were made to GetSegments.
+// Check the length with:
+//
+//	len(mockedSegmentService.GetSegmentsCalls())
+func (mock *SegmentServiceMock) GetSegmentsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetSegments.RLock()
+	calls = mock.calls.GetSegments
+	mock.lockGetSegments.RUnlock()
+	return calls
+}
+
+// RefreshAll calls RefreshAllFunc.
+func (mock *SegmentServiceMock) RefreshAll(ctx context.Context) (int, error) {
+	if mock.RefreshAllFunc == nil {
+		panic("SegmentServiceMock.RefreshAllFunc: method is nil but SegmentService.RefreshAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRefreshAll.Lock()
+	mock.calls.RefreshAll = append(mock.calls.RefreshAll, callInfo)
+	mock.lockRefreshAll.Unlock()
+	return mock.RefreshAllFunc(ctx)
+}
+
+// RefreshAllCalls gets all the calls that were made to RefreshAll.
+// Check the length with:
+//
+//	len(mockedSegmentService.RefreshAllCalls())
+func (mock *SegmentServiceMock) RefreshAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRefreshAll.RLock()
+	calls = mock.calls.RefreshAll
+	mock.lockRefreshAll.RUnlock()
+	return calls
+}
+
+// RefreshSegment calls RefreshSegmentFunc.
+func (mock *SegmentServiceMock) RefreshSegment(ctx context.Context, id uuid.UUID) (*models.CustomerSegment, error) {
+	if mock.RefreshSegmentFunc == nil {
+		panic("SegmentServiceMock.RefreshSegmentFunc: method is nil but SegmentService.RefreshSegment was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRefreshSegment.Lock()
+	mock.calls.RefreshSegment = append(mock.calls.RefreshSegment, callInfo)
+	mock.lockRefreshSegment.Unlock()
+	return mock.RefreshSegmentFunc(ctx, id)
+}
+
+// RefreshSegmentCalls gets all the calls that were made to RefreshSegment.
+// Check the length with:
+//
+//	len(mockedSegmentService.RefreshSegmentCalls())
+func (mock *SegmentServiceMock) RefreshSegmentCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockRefreshSegment.RLock()
+	calls = mock.calls.RefreshSegment
+	mock.lockRefreshSegment.RUnlock()
+	return calls
+}
+
+// Start calls StartFunc.
+func (mock *SegmentServiceMock) Start(ctx context.Context, interval time.Duration) {
+	if mock.StartFunc == nil {
+		panic("SegmentServiceMock.StartFunc: method is nil but SegmentService.Start was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}{
+		Ctx:      ctx,
+		Interval: interval,
+	}
+	mock.lockStart.Lock()
+	mock.calls.Start = append(mock.calls.Start, callInfo)
+	mock.lockStart.Unlock()
+	mock.StartFunc(ctx, interval)
+}
+
+// StartCalls gets all the calls that were made to Start.
+// Check the length with:
+//
+//	len(mockedSegmentService.StartCalls())
+func (mock *SegmentServiceMock) StartCalls() []struct {
+	Ctx      context.Context
+	Interval time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}
+	mock.lockStart.RLock()
+	calls = mock.calls.Start
+	mock.lockStart.RUnlock()
+	return calls
+}
+
+// UpdateSegment calls UpdateSegmentFunc.
+func (mock *SegmentServiceMock) UpdateSegment(ctx context.Context, id uuid.UUID, req models.CustomerSegmentRequest) (*models.CustomerSegment, error) {
+	if mock.UpdateSegmentFunc == nil {
+		panic("SegmentServiceMock.UpdateSegmentFunc: method is nil but SegmentService.UpdateSegment was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.CustomerSegmentRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockUpdateSegment.Lock()
+	mock.calls.UpdateSegment = append(mock.calls.UpdateSegment, callInfo)
+	mock.lockUpdateSegment.Unlock()
+	return mock.UpdateSegmentFunc(ctx, id, req)
+}
+
+// UpdateSegmentCalls gets all the calls that were made to UpdateSegment.
+// Check the length with:
+//
+//	len(mockedSegmentService.UpdateSegmentCalls())
+func (mock *SegmentServiceMock) UpdateSegmentCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.CustomerSegmentRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.CustomerSegmentRequest
+	}
+	mock.lockUpdateSegment.RLock()
+	calls = mock.calls.UpdateSegment
+	mock.lockUpdateSegment.RUnlock()
+	return calls
+}
+
+// Ensure, that ServiceabilityServiceMock does implement service.ServiceabilityService.
+// If this is not the case, regenerate this file with moq.
+var _ service.ServiceabilityService = &ServiceabilityServiceMock{}
+
+// ServiceabilityServiceMock is a mock implementation of service.ServiceabilityService.
+//
+//	func TestSomethingThatUsesServiceabilityService(t *testing.T) {
+//
+//		// make and configure a mocked service.ServiceabilityService
+//		mockedServiceabilityService := &ServiceabilityServiceMock{
+//			CheckFunc: func(ctx context.Context, postalCode string) (*models.Serviceability, error) {
+//				panic("mock out the Check method")
+//			},
+//			CreateAreaFunc: func(ctx context.Context, req models.CreateServiceableAreaRequest) (*models.ServiceableArea, error) {
+//				panic("mock out the CreateArea method")
+//			},
+//			DeleteAreaFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the DeleteArea method")
+//			},
+//			EnsureServiceableFunc: func(ctx context.Context, postalCode string) error {
+//				panic("mock out the EnsureServiceable method")
+//			},
+//			GetAreasFunc: func(ctx context.Context) ([]models.ServiceableArea, error) {
+//				panic("mock out the GetAreas method")
+//			},
+//			UpdateAreaFunc: func(ctx context.Context, id uuid.UUID, req models.UpdateServiceableAreaRequest) (*models.ServiceableArea, error) {
+//				panic("mock out the UpdateArea method")
+//			},
+//		}
+//
+//		// use mockedServiceabilityService in code that requires service.ServiceabilityService
+//		// and then make assertions.
+//
+//	}
+type ServiceabilityServiceMock struct {
+	// CheckFunc mocks the Check method.
+	CheckFunc func(ctx context.Context, postalCode string) (*models.Serviceability, error)
+
+	// CreateAreaFunc mocks the CreateArea method.
+	CreateAreaFunc func(ctx context.Context, req models.CreateServiceableAreaRequest) (*models.ServiceableArea, error)
+
+	// DeleteAreaFunc mocks the DeleteArea method.
+	DeleteAreaFunc func(ctx context.Context, id uuid.UUID) error
+
+	// EnsureServiceableFunc mocks the EnsureServiceable method.
+	EnsureServiceableFunc func(ctx context.Context, postalCode string) error
+
+	// GetAreasFunc mocks the GetAreas method.
+	GetAreasFunc func(ctx context.Context) ([]models.ServiceableArea, error)
+
+	// UpdateAreaFunc mocks the UpdateArea method.
+	UpdateAreaFunc func(ctx context.Context, id uuid.UUID, req models.UpdateServiceableAreaRequest) (*models.ServiceableArea, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Check holds details about calls to the Check method.
+		Check []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PostalCode is the postalCode argument value.
+			PostalCode string
+		}
+		// CreateArea holds details about calls to the CreateArea method.
+		CreateArea []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CreateServiceableAreaRequest
+		}
+		// DeleteArea holds details about calls to the DeleteArea method.
+		DeleteArea []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// EnsureServiceable holds details about calls to the EnsureServiceable method.
+		EnsureServiceable []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PostalCode is the postalCode argument value.
+			PostalCode string
+		}
+		// GetAreas holds details about calls to the GetAreas method.
+		GetAreas []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UpdateArea holds details about calls to the UpdateArea method.
+		UpdateArea []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.UpdateServiceableAreaRequest
+		}
+	}
+	lockCheck             sync.RWMutex
+	lockCreateArea        sync.RWMutex
+	lockDeleteArea        sync.RWMutex
+	lockEnsureServiceable sync.RWMutex
+	lockGetAreas          sync.RWMutex
+	lockUpdateArea        sync.RWMutex
+}
+
+// Check calls CheckFunc.
+func (mock *ServiceabilityServiceMock) Check(ctx context.Context, postalCode string) (*models.Serviceability, error) {
+	if mock.CheckFunc == nil {
+		panic("ServiceabilityServiceMock.CheckFunc: method is nil but ServiceabilityService.Check was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		PostalCode string
+	}{
+		Ctx:        ctx,
+		PostalCode: postalCode,
+	}
+	mock.lockCheck.Lock()
+	mock.calls.Check = append(mock.calls.Check, callInfo)
+	mock.lockCheck.Unlock()
+	return mock.CheckFunc(ctx, postalCode)
+}
+
+// CheckCalls gets all the calls that were made to Check.
+// Check the length with:
+//
+//	len(mockedServiceabilityService.CheckCalls())
+func (mock *ServiceabilityServiceMock) CheckCalls() []struct {
+	Ctx        context.Context
+	PostalCode string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		PostalCode string
+	}
+	mock.lockCheck.RLock()
+	calls = mock.calls.Check
+	mock.lockCheck.RUnlock()
+	return calls
+}
+
+// CreateArea calls CreateAreaFunc.
+func (mock *ServiceabilityServiceMock) CreateArea(ctx context.Context, req models.CreateServiceableAreaRequest) (*models.ServiceableArea, error) {
+	if mock.CreateAreaFunc == nil {
+		panic("ServiceabilityServiceMock.CreateAreaFunc: method is nil but ServiceabilityService.CreateArea was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.CreateServiceableAreaRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateArea.Lock()
+	mock.calls.CreateArea = append(mock.calls.CreateArea, callInfo)
+	mock.lockCreateArea.Unlock()
+	return mock.CreateAreaFunc(ctx, req)
+}
+
+// CreateAreaCalls gets all the calls that were made to CreateArea.
+// Check the length with:
+//
+//	len(mockedServiceabilityService.CreateAreaCalls())
+func (mock *ServiceabilityServiceMock) CreateAreaCalls() []struct {
+	Ctx context.Context
+	Req models.CreateServiceableAreaRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.CreateServiceableAreaRequest
+	}
+	mock.lockCreateArea.RLock()
+	calls = mock.calls.CreateArea
+	mock.lockCreateArea.RUnlock()
+	return calls
+}
+
+// DeleteArea calls DeleteAreaFunc.
+func (mock *ServiceabilityServiceMock) DeleteArea(ctx context.Context, id uuid.UUID) error {
+	if mock.DeleteAreaFunc == nil {
+		panic("ServiceabilityServiceMock.DeleteAreaFunc: method is nil but ServiceabilityService.DeleteArea was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteArea.Lock()
+	mock.calls.DeleteArea = append(mock.calls.DeleteArea, callInfo)
+	mock.lockDeleteArea.Unlock()
+	return mock.DeleteAreaFunc(ctx, id)
+}
+
+// DeleteAreaCalls gets all the calls that were made to DeleteArea.
+// Check the length with:
+//
+//	len(mockedServiceabilityService.DeleteAreaCalls())
+func (mock *ServiceabilityServiceMock) DeleteAreaCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDeleteArea.RLock()
+	calls = mock.calls.DeleteArea
+	mock.lockDeleteArea.RUnlock()
+	return calls
+}
+
+// EnsureServiceable calls EnsureServiceableFunc.
+func (mock *ServiceabilityServiceMock) EnsureServiceable(ctx context.Context, postalCode string) error {
+	if mock.EnsureServiceableFunc == nil {
+		panic("ServiceabilityServiceMock.EnsureServiceableFunc: method is nil but ServiceabilityService.EnsureServiceable was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		PostalCode string
+	}{
+		Ctx:        ctx,
+		PostalCode: postalCode,
+	}
+	mock.lockEnsureServiceable.Lock()
+	mock.calls.EnsureServiceable = append(mock.calls.EnsureServiceable, callInfo)
+	mock.lockEnsureServiceable.Unlock()
+	return mock.EnsureServiceableFunc(ctx, postalCode)
+}
+
+// EnsureServiceableCalls gets all the calls that were made to EnsureServiceable.
+// Check the length with:
+//
+//	len(mockedServiceabilityService.EnsureServiceableCalls())
+func (mock *ServiceabilityServiceMock) EnsureServiceableCalls() []struct {
+	Ctx        context.Context
+	PostalCode string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		PostalCode string
+	}
+	mock.lockEnsureServiceable.RLock()
+	calls = mock.calls.EnsureServiceable
+	mock.lockEnsureServiceable.RUnlock()
+	return calls
+}
+
+// GetAreas calls GetAreasFunc.
+func (mock *ServiceabilityServiceMock) GetAreas(ctx context.Context) ([]models.ServiceableArea, error) {
+	if mock.GetAreasFunc == nil {
+		panic("ServiceabilityServiceMock.GetAreasFunc: method is nil but ServiceabilityService.GetAreas was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAreas.Lock()
+	mock.calls.GetAreas = append(mock.calls.GetAreas, callInfo)
+	mock.lockGetAreas.Unlock()
+	return mock.GetAreasFunc(ctx)
+}
+
+// GetAreasCalls gets all the calls that were made to GetAreas.
+// Check the length with:
+//
+//	len(mockedServiceabilityService.GetAreasCalls())
+func (mock *ServiceabilityServiceMock) GetAreasCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAreas.RLock()
+	calls = mock.calls.GetAreas
+	mock.lockGetAreas.RUnlock()
+	return calls
+}
+
+// UpdateArea calls UpdateAreaFunc.
+func (mock *ServiceabilityServiceMock) UpdateArea(ctx context.Context, id uuid.UUID, req models.UpdateServiceableAreaRequest) (*models.ServiceableArea, error) {
+	if mock.UpdateAreaFunc == nil {
+		panic("ServiceabilityServiceMock.UpdateAreaFunc: method is nil but ServiceabilityService.UpdateArea was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.UpdateServiceableAreaRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockUpdateArea.Lock()
+	mock.calls.UpdateArea = append(mock.calls.UpdateArea, callInfo)
+	mock.lockUpdateArea.Unlock()
+	return mock.UpdateAreaFunc(ctx, id, req)
+}
+
+// UpdateAreaCalls gets all the calls that were made to UpdateArea.
+// Check the length with:
+//
+//	len(mockedServiceabilityService.UpdateAreaCalls())
+func (mock *ServiceabilityServiceMock) UpdateAreaCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.UpdateServiceableAreaRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.UpdateServiceableAreaRequest
+	}
+	mock.lockUpdateArea.RLock()
+	calls = mock.calls.UpdateArea
+	mock.lockUpdateArea.RUnlock()
+	return calls
+}
+
+// Ensure, that SettingsServiceMock does implement service.SettingsService.
+// If this is not the case, regenerate this file with moq.
+var _ service.SettingsService = &SettingsServiceMock{}
+
+// SettingsServiceMock is a mock implementation of service.SettingsService.
+//
+//	func TestSomethingThatUsesSettingsService(t *testing.T) {
+//
+//		// make and configure a mocked service.SettingsService
+//		mockedSettingsService := &SettingsServiceMock{
+//			AmountFunc: func(ctx context.Context, key string) money.Money {
+//				panic("mock out the Amount method")
+//			},
+//			BoolFunc: func(ctx context.Context, key string) bool {
+//				panic("mock out the Bool method")
+//			},
+//			DurationFunc: func(ctx context.Context, key string) time.Duration {
+//				panic("mock out the Duration method")
+//			},
+//			GetSettingFunc: func(ctx context.Context, key string) (*models.Setting, error) {
+//				panic("mock out the GetSetting method")
+//			},
+//			GetSettingsFunc: func(ctx context.Context) ([]models.Setting, error) {
+//				panic("mock out the GetSettings method")
+//			},
+//			IntFunc: func(ctx context.Context, key string) int {
+//				panic("mock out the Int method")
+//			},
+//			ResetSettingFunc: func(ctx context.Context, key string) (*models.Setting, error) {
+//				panic("mock out the ResetSetting method")
+//			},
+//			StringFunc: func(ctx context.Context, key string) string {
+//				panic("mock out the String method")
+//			},
+//			UpdateSettingFunc: func(ctx context.Context, adminID uuid.UUID, key string, req models.UpdateSettingRequest) (*models.Setting, error) {
+//				panic("mock out the UpdateSetting method")
+//			},
+//		}
+//
+//		// use mockedSettingsService in code that requires service.SettingsService
+//		// and then make assertions.
+//
+//	}
+type SettingsServiceMock struct {
+	// AmountFunc mocks the Amount method.
+	AmountFunc func(ctx context.Context, key string) money.Money
+
+	// BoolFunc mocks the Bool method.
+	BoolFunc func(ctx context.Context, key string) bool
+
+	// DurationFunc mocks the Duration method.
+	DurationFunc func(ctx context.Context, key string) time.Duration
+
+	// GetSettingFunc mocks the GetSetting method.
+	GetSettingFunc func(ctx context.Context, key string) (*models.Setting, error)
+
+	// GetSettingsFunc mocks the GetSettings method.
+	GetSettingsFunc func(ctx context.Context) ([]models.Setting, error)
+
+	// IntFunc mocks the Int method.
+	IntFunc func(ctx context.Context, key string) int
+
+	// ResetSettingFunc mocks the ResetSetting method.
+	ResetSettingFunc func(ctx context.Context, key string) (*models.Setting, error)
+
+	// StringFunc mocks the String method.
+	StringFunc func(ctx context.Context, key string) string
+
+	// UpdateSettingFunc mocks the UpdateSetting method.
+	UpdateSettingFunc func(ctx context.Context, adminID uuid.UUID, key string, req models.UpdateSettingRequest) (*models.Setting, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Amount holds details about calls to the Amount method.
+		Amount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Bool holds details about calls to the Bool method.
+		Bool []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Duration holds details about calls to the Duration method.
+		Duration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// GetSetting holds details about calls to the GetSetting method.
+		GetSetting []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// GetSettings holds details about calls to the GetSettings method.
+		GetSettings []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Int holds details about calls to the Int method.
+		Int []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// ResetSetting holds details about calls to the ResetSetting method.
+		ResetSetting []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// String holds details about calls to the String method.
+		String []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// UpdateSetting holds details about calls to the UpdateSetting method.
+		UpdateSetting []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AdminID is the adminID argument value.
+			AdminID uuid.UUID
+			// Key is the key argument value.
+			Key string
+			// Req is the req argument value.
+			Req models.UpdateSettingRequest
+		}
+	}
+	lockAmount        sync.RWMutex
+	lockBool          sync.RWMutex
+	lockDuration      sync.RWMutex
+	lockGetSetting    sync.RWMutex
+	lockGetSettings   sync.RWMutex
+	lockInt           sync.RWMutex
+	lockResetSetting  sync.RWMutex
+	lockString        sync.RWMutex
+	lockUpdateSetting sync.RWMutex
+}
+
+// Amount calls AmountFunc.
+func (mock *SettingsServiceMock) Amount(ctx context.Context, key string) money.Money {
+	if mock.AmountFunc == nil {
+		panic("SettingsServiceMock.AmountFunc: method is nil but SettingsService.Amount was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockAmount.Lock()
+	mock.calls.Amount = append(mock.calls.Amount, callInfo)
+	mock.lockAmount.Unlock()
+	return mock.AmountFunc(ctx, key)
+}
+
+// AmountCalls gets all the calls that were made to Amount.
+// Check the length with:
+//
+//	len(mockedSettingsService.AmountCalls())
+func (mock *SettingsServiceMock) AmountCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockAmount.RLock()
+	calls = mock.calls.Amount
+	mock.lockAmount.RUnlock()
+	return calls
+}
+
+// Bool calls BoolFunc.
+func (mock *SettingsServiceMock) Bool(ctx context.Context, key string) bool {
+	if mock.BoolFunc == nil {
+		panic("SettingsServiceMock.BoolFunc: method is nil but SettingsService.Bool was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockBool.Lock()
+	mock.calls.Bool = append(mock.calls.Bool, callInfo)
+	mock.lockBool.Unlock()
+	return mock.BoolFunc(ctx, key)
+}
+
+// BoolCalls gets all the calls that were made to Bool.
+// Check the length with:
+//
+//	len(mockedSettingsService.BoolCalls())
+func (mock *SettingsServiceMock) BoolCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockBool.RLock()
+	calls = mock.calls.Bool
+	mock.lockBool.RUnlock()
+	return calls
+}
+
+// Duration calls DurationFunc.
+func (mock *SettingsServiceMock) Duration(ctx context.Context, key string) time.Duration {
+	if mock.DurationFunc == nil {
+		panic("SettingsServiceMock.DurationFunc: method is nil but SettingsService.Duration was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDuration.Lock()
+	mock.calls.Duration = append(mock.calls.Duration, callInfo)
+	mock.lockDuration.Unlock()
+	return mock.DurationFunc(ctx, key)
+}
+
+// DurationCalls gets all the calls that were made to Duration.
+// Check the length with:
+//
+//	len(mockedSettingsService.DurationCalls())
+func (mock *SettingsServiceMock) DurationCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDuration.RLock()
+	calls = mock.calls.Duration
+	mock.lockDuration.RUnlock()
+	return calls
+}
+
+// GetSetting calls GetSettingFunc.
+func (mock *SettingsServiceMock) GetSetting(ctx context.Context, key string) (*models.Setting, error) {
+	if mock.GetSettingFunc == nil {
+		panic("SettingsServiceMock.GetSettingFunc: method is nil but SettingsService.GetSetting was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetSetting.Lock()
+	mock.calls.GetSetting = append(mock.calls.GetSetting, callInfo)
+	mock.lockGetSetting.Unlock()
+	return mock.GetSettingFunc(ctx, key)
+}
+
+// GetSettingCalls gets all the calls that were made to GetSetting.
+// Check the length with:
+//
+//	len(mockedSettingsService.GetSettingCalls())
+func (mock *SettingsServiceMock) GetSettingCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetSetting.RLock()
+	calls = mock.calls.GetSetting
+	mock.lockGetSetting.RUnlock()
+	return calls
+}
+
+// GetSettings calls GetSettingsFunc.
+func (mock *SettingsServiceMock) GetSettings(ctx context.Context) ([]models.Setting, error) {
+	if mock.GetSettingsFunc == nil {
+		panic("SettingsServiceMock.GetSettingsFunc: method is nil but SettingsService.GetSettings was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetSettings.Lock()
+	mock.calls.GetSettings = append(mock.calls.GetSettings, callInfo)
+	mock.lockGetSettings.Unlock()
+	return mock.GetSettingsFunc(ctx)
+}
+
+// GetSettingsCalls gets all the calls that were made to GetSettings.
+// Check the length with:
+//
+//	len(mockedSettingsService.GetSettingsCalls())
+func (mock *SettingsServiceMock) GetSettingsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetSettings.RLock()
+	calls = mock.calls.GetSettings
+	mock.lockGetSettings.RUnlock()
+	return calls
+}
+
+// Int calls IntFunc.
+func (mock *SettingsServiceMock) Int(ctx context.Context, key string) int {
+	if mock.IntFunc == nil {
+		panic("SettingsServiceMock.IntFunc: method is nil but SettingsService.Int was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockInt.Lock()
+	mock.calls.Int = append(mock.calls.Int, callInfo)
+	mock.lockInt.Unlock()
+	return mock.IntFunc(ctx, key)
+}
+
+// IntCalls gets all the calls that were made to Int.
+// Check the length with:
+//
+//	len(mockedSettingsService.IntCalls())
+func (mock *SettingsServiceMock) IntCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockInt.RLock()
+	calls = mock.calls.Int
+	mock.lockInt.RUnlock()
+	return calls
+}
+
+// ResetSetting calls ResetSettingFunc.
+func (mock *SettingsServiceMock) ResetSetting(ctx context.Context, key string) (*models.Setting, error) {
+	if mock.ResetSettingFunc == nil {
+		panic("SettingsServiceMock.ResetSettingFunc: method is nil but SettingsService.ResetSetting was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockResetSetting.Lock()
+	mock.calls.ResetSetting = append(mock.calls.ResetSetting, callInfo)
+	mock.lockResetSetting.Unlock()
+	return mock.ResetSettingFunc(ctx, key)
+}
+
+// ResetSettingCalls gets all the calls that were made to ResetSetting.
+// Check the length with:
+//
+//	len(mockedSettingsService.ResetSettingCalls())
+func (mock *SettingsServiceMock) ResetSettingCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockResetSetting.RLock()
+	calls = mock.calls.ResetSetting
+	mock.lockResetSetting.RUnlock()
+	return calls
+}
+
+// String calls StringFunc.
+func (mock *SettingsServiceMock) String(ctx context.Context, key string) string {
+	if mock.StringFunc == nil {
+		panic("SettingsServiceMock.StringFunc: method is nil but SettingsService.String was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockString.Lock()
+	mock.calls.String = append(mock.calls.String, callInfo)
+	mock.lockString.Unlock()
+	return mock.StringFunc(ctx, key)
+}
+
+// StringCalls gets all the calls that were made to String.
+// Check the length with:
+//
+//	len(mockedSettingsService.StringCalls())
+func (mock *SettingsServiceMock) StringCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockString.RLock()
+	calls = mock.calls.String
+	mock.lockString.RUnlock()
+	return calls
+}
+
+// UpdateSetting calls UpdateSettingFunc.
+func (mock *SettingsServiceMock) UpdateSetting(ctx context.Context, adminID uuid.UUID, key string, req models.UpdateSettingRequest) (*models.Setting, error) {
+	if mock.UpdateSettingFunc == nil {
+		panic("SettingsServiceMock.UpdateSettingFunc: method is nil but SettingsService.UpdateSetting was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AdminID uuid.UUID
+		Key     string
+		Req     models.UpdateSettingRequest
+	}{
+		Ctx:     ctx,
+		AdminID: adminID,
+		Key:     key,
+		Req:     req,
+	}
+	mock.lockUpdateSetting.Lock()
+	mock.calls.UpdateSetting = append(mock.calls.UpdateSetting, callInfo)
+	mock.lockUpdateSetting.Unlock()
+	return mock.UpdateSettingFunc(ctx, adminID, key, req)
+}
+
+// UpdateSettingCalls gets all the calls that were made to UpdateSetting.
+// Check the length with:
+//
+//	len(mockedSettingsService.UpdateSettingCalls())
+func (mock *SettingsServiceMock) UpdateSettingCalls() []struct {
+	Ctx     context.Context
+	AdminID uuid.UUID
+	Key     string
+	Req     models.UpdateSettingRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AdminID uuid.UUID
+		Key     string
+		Req     models.UpdateSettingRequest
+	}
+	mock.lockUpdateSetting.RLock()
+	calls = mock.calls.UpdateSetting
+	mock.lockUpdateSetting.RUnlock()
+	return calls
+}
+
+// Ensure, that StoreServiceMock does implement service.StoreService.
+// If this is not the case, regenerate this file with moq.
+var _ service.StoreService = &StoreServiceMock{}
+
+// StoreServiceMock is a mock implementation of service.StoreService.
+//
+//	func TestSomethingThatUsesStoreService(t *testing.T) {
+//
+//		// make and configure a mocked service.StoreService
+//		mockedStoreService := &StoreServiceMock{
+//			CreateStoreFunc: func(ctx context.Context, req models.CreateStoreRequest) (*models.Store, error) {
+//				panic("mock out the CreateStore method")
+//			},
+//			GetStoresFunc: func(ctx context.Context) ([]models.Store, error) {
+//				panic("mock out the GetStores method")
+//			},
+//			ResolveFunc: func(ctx context.Context, slug string, host string) (*models.Store, error) {
+//				panic("mock out the Resolve method")
+//			},
+//			UpdateStoreFunc: func(ctx context.Context, id uuid.UUID, req models.UpdateStoreRequest) (*models.Store, error) {
+//				panic("mock out the UpdateStore method")
+//			},
+//		}
+//
+//		// use mockedStoreService in code that requires service.StoreService
+//		// and then make assertions.
+//
+//	}
+type StoreServiceMock struct {
+	// CreateStoreFunc mocks the CreateStore method.
+	CreateStoreFunc func(ctx context.Context, req models.CreateStoreRequest) (*models.Store, error)
+
+	// GetStoresFunc mocks the GetStores method.
+	GetStoresFunc func(ctx context.Context) ([]models.Store, error)
+
+	// ResolveFunc mocks the Resolve method.
+	ResolveFunc func(ctx context.Context, slug string, host string) (*models.Store, error)
+
+	// UpdateStoreFunc mocks the UpdateStore method.
+	UpdateStoreFunc func(ctx context.Context, id uuid.UUID, req models.UpdateStoreRequest) (*models.Store, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateStore holds details about calls to the CreateStore method.
+		CreateStore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CreateStoreRequest
+		}
+		// GetStores holds details about calls to the GetStores method.
+		GetStores []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Resolve holds details about calls to the Resolve method.
+		Resolve []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Slug is the slug argument value.
+			Slug string
+			// Host is the host argument value.
+			Host string
+		}
+		// UpdateStore holds details about calls to the UpdateStore method.
+		UpdateStore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.UpdateStoreRequest
+		}
+	}
+	lockCreateStore sync.RWMutex
+	lockGetStores   sync.RWMutex
+	lockResolve     sync.RWMutex
+	lockUpdateStore sync.RWMutex
+}
+
+// CreateStore calls CreateStoreFunc.
+func (mock *StoreServiceMock) CreateStore(ctx context.Context, req models.CreateStoreRequest) (*models.Store, error) {
+	if mock.CreateStoreFunc == nil {
+		panic("StoreServiceMock.CreateStoreFunc: method is nil but StoreService.CreateStore was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.CreateStoreRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateStore.Lock()
+	mock.calls.CreateStore = append(mock.calls.CreateStore, callInfo)
+	mock.lockCreateStore.Unlock()
+	return mock.CreateStoreFunc(ctx, req)
+}
+
+// CreateStoreCalls gets all the calls that were made to CreateStore.
+// Check the length with:
+//
+//	len(mockedStoreService.CreateStoreCalls())
+func (mock *StoreServiceMock) CreateStoreCalls() []struct {
+	Ctx context.Context
+	Req models.CreateStoreRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.CreateStoreRequest
+	}
+	mock.lockCreateStore.RLock()
+	calls = mock.calls.CreateStore
+	mock.lockCreateStore.RUnlock()
+	return calls
+}
+
+// GetStores calls GetStoresFunc.
+func (mock *StoreServiceMock) GetStores(ctx context.Context) ([]models.Store, error) {
+	if mock.GetStoresFunc == nil {
+		panic("StoreServiceMock.GetStoresFunc: method is nil but StoreService.GetStores was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetStores.Lock()
+	mock.calls.GetStores = append(mock.calls.GetStores, callInfo)
+	mock.lockGetStores.Unlock()
+	return mock.GetStoresFunc(ctx)
+}
+
+// GetStoresCalls gets all the calls that were made to GetStores.
+// Check the length with:
+//
+//	len(mockedStoreService.GetStoresCalls())
+func (mock *StoreServiceMock) GetStoresCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetStores.RLock()
+	calls = mock.calls.GetStores
+	mock.lockGetStores.RUnlock()
+	return calls
+}
+
+// Resolve calls ResolveFunc.
+func (mock *StoreServiceMock) Resolve(ctx context.Context, slug string, host string) (*models.Store, error) {
+	if mock.ResolveFunc == nil {
+		panic("StoreServiceMock.ResolveFunc: method is nil but StoreService.Resolve was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Slug string
+		Host string
+	}{
+		Ctx:  ctx,
+		Slug: slug,
+		Host: host,
+	}
+	mock.lockResolve.Lock()
+	mock.calls.Resolve = append(mock.calls.Resolve, callInfo)
+	mock.lockResolve.Unlock()
+	return mock.ResolveFunc(ctx, slug, host)
+}
+
+// ResolveCalls gets all the calls that were made to Resolve.
+// Check the length with:
+//
+//	len(mockedStoreService.ResolveCalls())
+func (mock *StoreServiceMock) ResolveCalls() []struct {
+	Ctx  context.Context
+	Slug string
+	Host string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Slug string
+		Host string
+	}
+	mock.lockResolve.RLock()
+	calls = mock.calls.Resolve
+	mock.lockResolve.RUnlock()
+	return calls
+}
+
+// UpdateStore calls UpdateStoreFunc.
+func (mock *StoreServiceMock) UpdateStore(ctx context.Context, id uuid.UUID, req models.UpdateStoreRequest) (*models.Store, error) {
+	if mock.UpdateStoreFunc == nil {
+		panic("StoreServiceMock.UpdateStoreFunc: method is nil but StoreService.UpdateStore was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.UpdateStoreRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockUpdateStore.Lock()
+	mock.calls.UpdateStore = append(mock.calls.UpdateStore, callInfo)
+	mock.lockUpdateStore.Unlock()
+	return mock.UpdateStoreFunc(ctx, id, req)
+}
+
+// UpdateStoreCalls gets all the calls that were made to UpdateStore.
+// Check the length with:
+//
+//	len(mockedStoreService.UpdateStoreCalls())
+func (mock *StoreServiceMock) UpdateStoreCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.UpdateStoreRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.UpdateStoreRequest
+	}
+	mock.lockUpdateStore.RLock()
+	calls = mock.calls.UpdateStore
+	mock.lockUpdateStore.RUnlock()
+	return calls
+}
+
+// Ensure, that UnpaidOrderServiceMock does implement service.UnpaidOrderService.
+// If this is not the case, regenerate this file with moq.
+var _ service.UnpaidOrderService = &UnpaidOrderServiceMock{}
+
+// UnpaidOrderServiceMock is a mock implementation of service.UnpaidOrderService.
+//
+//	func TestSomethingThatUsesUnpaidOrderService(t *testing.T) {
+//
+//		// make and configure a mocked service.UnpaidOrderService
+//		mockedUnpaidOrderService := &UnpaidOrderServiceMock{
+//			StartFunc: func(ctx context.Context, interval time.Duration)  {
+//				panic("mock out the Start method")
+//			},
+//		}
+//
+//		// use mockedUnpaidOrderService in code that requires service.UnpaidOrderService
+//		// and then make assertions.
+//
+//	}
+type UnpaidOrderServiceMock struct {
+	// StartFunc mocks the Start method.
+	StartFunc func(ctx context.Context, interval time.Duration)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Start holds details about calls to the Start method.
+		Start []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Interval is the interval argument value.
+			Interval time.Duration
+		}
+	}
+	lockStart sync.RWMutex
+}
+
+// Start calls StartFunc.
+func (mock *UnpaidOrderServiceMock) Start(ctx context.Context, interval time.Duration) {
+	if mock.StartFunc == nil {
+		panic("UnpaidOrderServiceMock.StartFunc: method is nil but UnpaidOrderService.Start was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}{
+		Ctx:      ctx,
+		Interval: interval,
+	}
+	mock.lockStart.Lock()
+	mock.calls.Start = append(mock.calls.Start, callInfo)
+	mock.lockStart.Unlock()
+	mock.StartFunc(ctx, interval)
+}
+
+// StartCalls gets all the calls that were made to Start.
+// Check the length with:
+//
+//	len(mockedUnpaidOrderService.StartCalls())
+func (mock *UnpaidOrderServiceMock) StartCalls() []struct {
+	Ctx      context.Context
+	Interval time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Interval time.Duration
+	}
+	mock.lockStart.RLock()
+	calls = mock.calls.Start
+	mock.lockStart.RUnlock()
+	return calls
+}
+
+// Ensure, that WarehouseServiceMock does implement service.WarehouseService.
+// If this is not the case, regenerate this file with moq.
+var _ service.WarehouseService = &WarehouseServiceMock{}
+
+// WarehouseServiceMock is a mock implementation of service.WarehouseService.
+//
+//	func TestSomethingThatUsesWarehouseService(t *testing.T) {
+//
+//		// make and configure a mocked service.WarehouseService
+//		mockedWarehouseService := &WarehouseServiceMock{
+//			AllocateOrderFunc: func(ctx context.Context, order *models.Order) error {
+//				panic("mock out the AllocateOrder method")
+//			},
+//			CreateWarehouseFunc: func(ctx context.Context, req models.CreateWarehouseRequest) (*models.Warehouse, error) {
+//				panic("mock out the CreateWarehouse method")
+//			},
+//			GetProductStockFunc: func(ctx context.Context, productID uuid.UUID) (*models.ProductStock, error) {
+//				panic("mock out the GetProductStock method")
+//			},
+//			GetTransfersFunc: func(ctx context.Context, page int, limit int) ([]models.StockTransfer, int, error) {
+//				panic("mock out the GetTransfers method")
+//			},
+//			GetWarehouseFunc: func(ctx context.Context, id uuid.UUID) (*models.Warehouse, error) {
+//				panic("mock out the GetWarehouse method")
+//			},
+//			GetWarehouseStockFunc: func(ctx context.Context, id uuid.UUID, page int, limit int) ([]models.WarehouseStock, int, error) {
+//				panic("mock out the GetWarehouseStock method")
+//			},
+//			GetWarehousesFunc: func(ctx context.Context) ([]models.Warehouse, error) {
+//				panic("mock out the GetWarehouses method")
+//			},
+//			RestockItemsFunc: func(ctx context.Context, items []models.OrderItem) error {
+//				panic("mock out the RestockItems method")
+//			},
+//			ScanStockFunc: func(ctx context.Context, req models.ScanStockRequest) (*models.ScanStockResponse, error) {
+//				panic("mock out the ScanStock method")
+//			},
+//			SetStockFunc: func(ctx context.Context, warehouseID uuid.UUID, req models.SetWarehouseStockRequest) error {
+//				panic("mock out the SetStock method")
+//			},
+//			TransferStockFunc: func(ctx context.Context, req models.CreateStockTransferRequest) (*models.StockTransfer, error) {
+//				panic("mock out the TransferStock method")
+//			},
+//			UpdateWarehouseFunc: func(ctx context.Context, id uuid.UUID, req models.UpdateWarehouseRequest) (*models.Warehouse, error) {
+//				panic("mock out the UpdateWarehouse method")
+//			},
+//		}
+//
+//		// use mockedWarehouseService in code that requires service.WarehouseService
+//		// and then make assertions.
+//
+//	}
+type WarehouseServiceMock struct {
+	// AllocateOrderFunc mocks the AllocateOrder method.
+	AllocateOrderFunc func(ctx context.Context, order *models.Order) error
+
+	// CreateWarehouseFunc mocks the CreateWarehouse method.
+	CreateWarehouseFunc func(ctx context.Context, req models.CreateWarehouseRequest) (*models.Warehouse, error)
+
+	// GetProductStockFunc mocks the GetProductStock method.
+	GetProductStockFunc func(ctx context.Context, productID uuid.UUID) (*models.ProductStock, error)
+
+	// GetTransfersFunc mocks the GetTransfers method.
+	GetTransfersFunc func(ctx context.Context, page int, limit int) ([]models.StockTransfer, int, error)
+
+	// GetWarehouseFunc mocks the GetWarehouse method.
+	GetWarehouseFunc func(ctx context.Context, id uuid.UUID) (*models.Warehouse, error)
+
+	// GetWarehouseStockFunc mocks the GetWarehouseStock method.
+	GetWarehouseStockFunc func(ctx context.Context, id uuid.UUID, page int, limit int) ([]models.WarehouseStock, int, error)
+
+	// GetWarehousesFunc mocks the GetWarehouses method.
+	GetWarehousesFunc func(ctx context.Context) ([]models.Warehouse, error)
+
+	// RestockItemsFunc mocks the RestockItems method.
+	RestockItemsFunc func(ctx context.Context, items []models.OrderItem) error
+
+	// ScanStockFunc mocks the ScanStock method.
+	ScanStockFunc func(ctx context.Context, req models.ScanStockRequest) (*models.ScanStockResponse, error)
+
+	// SetStockFunc mocks the SetStock method.
+	SetStockFunc func(ctx context.Context, warehouseID uuid.UUID, req models.SetWarehouseStockRequest) error
+
+	// TransferStockFunc mocks the TransferStock method.
+	TransferStockFunc func(ctx context.Context, req models.CreateStockTransferRequest) (*models.StockTransfer, error)
+
+	// UpdateWarehouseFunc mocks the UpdateWarehouse method.
+	UpdateWarehouseFunc func(ctx context.Context, id uuid.UUID, req models.UpdateWarehouseRequest) (*models.Warehouse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AllocateOrder holds details about calls to the AllocateOrder method.
+		AllocateOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Order is the order argument value.
+			Order *models.Order
+		}
+		// CreateWarehouse holds details about calls to the CreateWarehouse method.
+		CreateWarehouse []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CreateWarehouseRequest
+		}
+		// GetProductStock holds details about calls to the GetProductStock method.
+		GetProductStock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProductID is the productID argument value.
+			ProductID uuid.UUID
+		}
+		// GetTransfers holds details about calls to the GetTransfers method.
+		GetTransfers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetWarehouse holds details about calls to the GetWarehouse method.
+		GetWarehouse []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// GetWarehouseStock holds details about calls to the GetWarehouseStock method.
+		GetWarehouseStock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Page is the page argument value.
+			Page int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetWarehouses holds details about calls to the GetWarehouses method.
+		GetWarehouses []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RestockItems holds details about calls to the RestockItems method.
+		RestockItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Items is the items argument value.
+			Items []models.OrderItem
+		}
+		// ScanStock holds details about calls to the ScanStock method.
+		ScanStock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.ScanStockRequest
+		}
+		// SetStock holds details about calls to the SetStock method.
+		SetStock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WarehouseID is the warehouseID argument value.
+			WarehouseID uuid.UUID
+			// Req is the req argument value.
+			Req models.SetWarehouseStockRequest
+		}
+		// TransferStock holds details about calls to the TransferStock method.
+		TransferStock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req models.CreateStockTransferRequest
+		}
+		// UpdateWarehouse holds details about calls to the UpdateWarehouse method.
+		UpdateWarehouse []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Req is the req argument value.
+			Req models.UpdateWarehouseRequest
+		}
+	}
+	lockAllocateOrder     sync.RWMutex
+	lockCreateWarehouse   sync.RWMutex
+	lockGetProductStock   sync.RWMutex
+	lockGetTransfers      sync.RWMutex
+	lockGetWarehouse      sync.RWMutex
+	lockGetWarehouseStock sync.RWMutex
+	lockGetWarehouses     sync.RWMutex
+	lockRestockItems      sync.RWMutex
+	lockScanStock         sync.RWMutex
+	lockSetStock          sync.RWMutex
+	lockTransferStock     sync.RWMutex
+	lockUpdateWarehouse   sync.RWMutex
+}
+
+// AllocateOrder calls AllocateOrderFunc.
+func (mock *WarehouseServiceMock) AllocateOrder(ctx context.Context, order *models.Order) error {
+	if mock.AllocateOrderFunc == nil {
+		panic("WarehouseServiceMock.AllocateOrderFunc: method is nil but WarehouseService.AllocateOrder was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Order *models.Order
+	}{
+		Ctx:   ctx,
+		Order: order,
+	}
+	mock.lockAllocateOrder.Lock()
+	mock.calls.AllocateOrder = append(mock.calls.AllocateOrder, callInfo)
+	mock.lockAllocateOrder.Unlock()
+	return mock.AllocateOrderFunc(ctx, order)
+}
+
+// AllocateOrderCalls gets all the calls that were made to AllocateOrder.
+// Check the length with:
+//
+//	len(mockedWarehouseService.AllocateOrderCalls())
+func (mock *WarehouseServiceMock) AllocateOrderCalls() []struct {
+	Ctx   context.Context
+	Order *models.Order
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Order *models.Order
+	}
+	mock.lockAllocateOrder.RLock()
+	calls = mock.calls.AllocateOrder
+	mock.lockAllocateOrder.RUnlock()
+	return calls
+}
+
+// CreateWarehouse calls CreateWarehouseFunc.
+func (mock *WarehouseServiceMock) CreateWarehouse(ctx context.Context, req models.CreateWarehouseRequest) (*models.Warehouse, error) {
+	if mock.CreateWarehouseFunc == nil {
+		panic("WarehouseServiceMock.CreateWarehouseFunc: method is nil but WarehouseService.CreateWarehouse was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.CreateWarehouseRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateWarehouse.Lock()
+	mock.calls.CreateWarehouse = append(mock.calls.CreateWarehouse, callInfo)
+	mock.lockCreateWarehouse.Unlock()
+	return mock.CreateWarehouseFunc(ctx, req)
+}
+
+// CreateWarehouseCalls gets all the calls that were made to CreateWarehouse.
+// Check the length with:
+//
+//	len(mockedWarehouseService.CreateWarehouseCalls())
+func (mock *WarehouseServiceMock) CreateWarehouseCalls() []struct {
+	Ctx context.Context
+	Req models.CreateWarehouseRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.CreateWarehouseRequest
+	}
+	mock.lockCreateWarehouse.RLock()
+	calls = mock.calls.CreateWarehouse
+	mock.lockCreateWarehouse.RUnlock()
+	return calls
+}
+
+// GetProductStock calls GetProductStockFunc.
+func (mock *WarehouseServiceMock) GetProductStock(ctx context.Context, productID uuid.UUID) (*models.ProductStock, error) {
+	if mock.GetProductStockFunc == nil {
+		panic("WarehouseServiceMock.GetProductStockFunc: method is nil but WarehouseService.GetProductStock was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}{
+		Ctx:       ctx,
+		ProductID: productID,
+	}
+	mock.lockGetProductStock.Lock()
+	mock.calls.GetProductStock = append(mock.calls.GetProductStock, callInfo)
+	mock.lockGetProductStock.Unlock()
+	return mock.GetProductStockFunc(ctx, productID)
+}
+
+// GetProductStockCalls gets all the calls that were made to GetProductStock.
+// Check the length with:
+//
+//	len(mockedWarehouseService.GetProductStockCalls())
+func (mock *WarehouseServiceMock) GetProductStockCalls() []struct {
+	Ctx       context.Context
+	ProductID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProductID uuid.UUID
+	}
+	mock.lockGetProductStock.RLock()
+	calls = mock.calls.GetProductStock
+	mock.lockGetProductStock.RUnlock()
+	return calls
+}
+
+// GetTransfers calls GetTransfersFunc.
+func (mock *WarehouseServiceMock) GetTransfers(ctx context.Context, page int, limit int) ([]models.StockTransfer, int, error) {
+	if mock.GetTransfersFunc == nil {
+		panic("WarehouseServiceMock.GetTransfersFunc: method is nil but WarehouseService.GetTransfers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}{
+		Ctx:   ctx,
+		Page:  page,
+		Limit: limit,
+	}
+	mock.lockGetTransfers.Lock()
+	mock.calls.GetTransfers = append(mock.calls.GetTransfers, callInfo)
+	mock.lockGetTransfers.Unlock()
+	return mock.GetTransfersFunc(ctx, page, limit)
+}
+
+// GetTransfersCalls gets all the calls that were made to GetTransfers.
+// Check the length with:
+//
+//	len(mockedWarehouseService.GetTransfersCalls())
+func (mock *WarehouseServiceMock) GetTransfersCalls() []struct {
+	Ctx   context.Context
+	Page  int
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Page  int
+		Limit int
+	}
+	mock.lockGetTransfers.RLock()
+	calls = mock.calls.GetTransfers
+	mock.lockGetTransfers.RUnlock()
+	return calls
+}
+
+// GetWarehouse calls GetWarehouseFunc.
+func (mock *WarehouseServiceMock) GetWarehouse(ctx context.Context, id uuid.UUID) (*models.Warehouse, error) {
+	if mock.GetWarehouseFunc == nil {
+		panic("WarehouseServiceMock.GetWarehouseFunc: method is nil but WarehouseService.GetWarehouse was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetWarehouse.Lock()
+	mock.calls.GetWarehouse = append(mock.calls.GetWarehouse, callInfo)
+	mock.lockGetWarehouse.Unlock()
+	return mock.GetWarehouseFunc(ctx, id)
+}
+
+// GetWarehouseCalls gets all the calls that were made to GetWarehouse.
+// Check the length with:
+//
+//	len(mockedWarehouseService.GetWarehouseCalls())
+func (mock *WarehouseServiceMock) GetWarehouseCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetWarehouse.RLock()
+	calls = mock.calls.GetWarehouse
+	mock.lockGetWarehouse.RUnlock()
+	return calls
+}
+
+// GetWarehouseStock calls GetWarehouseStockFunc.
+func (mock *WarehouseServiceMock) GetWarehouseStock(ctx context.Context, id uuid.UUID, page int, limit int) ([]models.WarehouseStock, int, error) {
+	if mock.GetWarehouseStockFunc == nil {
+		panic("WarehouseServiceMock.GetWarehouseStockFunc: method is nil but WarehouseService.GetWarehouseStock was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    uuid.UUID
+		Page  int
+		Limit int
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Page:  page,
+		Limit: limit,
+	}
+	mock.lockGetWarehouseStock.Lock()
+	mock.calls.GetWarehouseStock = append(mock.calls.GetWarehouseStock, callInfo)
+	mock.lockGetWarehouseStock.Unlock()
+	return mock.GetWarehouseStockFunc(ctx, id, page, limit)
+}
+
+// GetWarehouseStockCalls gets all the calls that were made to GetWarehouseStock.
+// Check the length with:
+//
+//	len(mockedWarehouseService.GetWarehouseStockCalls())
+func (mock *WarehouseServiceMock) GetWarehouseStockCalls() []struct {
+	Ctx   context.Context
+	ID    uuid.UUID
+	Page  int
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    uuid.UUID
+		Page  int
+		Limit int
+	}
+	mock.lockGetWarehouseStock.RLock()
+	calls = mock.calls.GetWarehouseStock
+	mock.lockGetWarehouseStock.RUnlock()
+	return calls
+}
+
+// GetWarehouses calls GetWarehousesFunc.
+func (mock *WarehouseServiceMock) GetWarehouses(ctx context.Context) ([]models.Warehouse, error) {
+	if mock.GetWarehousesFunc == nil {
+		panic("WarehouseServiceMock.GetWarehousesFunc: method is nil but WarehouseService.GetWarehouses was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetWarehouses.Lock()
+	mock.calls.GetWarehouses = append(mock.calls.GetWarehouses, callInfo)
+	mock.lockGetWarehouses.Unlock()
+	return mock.GetWarehousesFunc(ctx)
+}
+
+// GetWarehousesCalls gets all the calls that were made to GetWarehouses.
+// Check the length with:
+//
+//	len(mockedWarehouseService.GetWarehousesCalls())
+func (mock *WarehouseServiceMock) GetWarehousesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetWarehouses.RLock()
+	calls = mock.calls.GetWarehouses
+	mock.lockGetWarehouses.RUnlock()
+	return calls
+}
+
+// RestockItems calls RestockItemsFunc.
+func (mock *WarehouseServiceMock) RestockItems(ctx context.Context, items []models.OrderItem) error {
+	if mock.RestockItemsFunc == nil {
+		panic("WarehouseServiceMock.RestockItemsFunc: method is nil but WarehouseService.RestockItems was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Items []models.OrderItem
+	}{
+		Ctx:   ctx,
+		Items: items,
+	}
+	mock.lockRestockItems.Lock()
+	mock.calls.RestockItems = append(mock.calls.RestockItems, callInfo)
+	mock.lockRestockItems.Unlock()
+	return mock.RestockItemsFunc(ctx, items)
+}
+
+// RestockItemsCalls gets all the calls that were made to RestockItems.
+// Check the length with:
+//
+//	len(mockedWarehouseService.RestockItemsCalls())
+func (mock *WarehouseServiceMock) RestockItemsCalls() []struct {
+	Ctx   context.Context
+	Items []models.OrderItem
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Items []models.OrderItem
+	}
+	mock.lockRestockItems.RLock()
+	calls = mock.calls.RestockItems
+	mock.lockRestockItems.RUnlock()
+	return calls
+}
+
+// ScanStock calls ScanStockFunc.
+func (mock *WarehouseServiceMock) ScanStock(ctx context.Context, req models.ScanStockRequest) (*models.ScanStockResponse, error) {
+	if mock.ScanStockFunc == nil {
+		panic("WarehouseServiceMock.ScanStockFunc: method is nil but WarehouseService.ScanStock was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.ScanStockRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockScanStock.Lock()
+	mock.calls.ScanStock = append(mock.calls.ScanStock, callInfo)
+	mock.lockScanStock.Unlock()
+	return mock.ScanStockFunc(ctx, req)
+}
+
+// ScanStockCalls gets all the calls that were made to ScanStock.
+// Check the length with:
+//
+//	len(mockedWarehouseService.ScanStockCalls())
+func (mock *WarehouseServiceMock) ScanStockCalls() []struct {
+	Ctx context.Context
+	Req models.ScanStockRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.ScanStockRequest
+	}
+	mock.lockScanStock.RLock()
+	calls = mock.calls.ScanStock
+	mock.lockScanStock.RUnlock()
+	return calls
+}
+
+// SetStock calls SetStockFunc.
+func (mock *WarehouseServiceMock) SetStock(ctx context.Context, warehouseID uuid.UUID, req models.SetWarehouseStockRequest) error {
+	if mock.SetStockFunc == nil {
+		panic("WarehouseServiceMock.SetStockFunc: method is nil but WarehouseService.SetStock was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		WarehouseID uuid.UUID
+		Req         models.SetWarehouseStockRequest
+	}{
+		Ctx:         ctx,
+		WarehouseID: warehouseID,
+		Req:         req,
+	}
+	mock.lockSetStock.Lock()
+	mock.calls.SetStock = append(mock.calls.SetStock, callInfo)
+	mock.lockSetStock.Unlock()
+	return mock.SetStockFunc(ctx, warehouseID, req)
+}
+
+// SetStockCalls gets all the calls that were made to SetStock.
+// Check the length with:
+//
+//	len(mockedWarehouseService.SetStockCalls())
+func (mock *WarehouseServiceMock) SetStockCalls() []struct {
+	Ctx         context.Context
+	WarehouseID uuid.UUID
+	Req         models.SetWarehouseStockRequest
+} {
+	var calls []struct {
+		Ctx         context.Context
+		WarehouseID uuid.UUID
+		Req         models.SetWarehouseStockRequest
+	}
+	mock.lockSetStock.RLock()
+	calls = mock.calls.SetStock
+	mock.lockSetStock.RUnlock()
+	return calls
+}
+
+// TransferStock calls TransferStockFunc.
+func (mock *WarehouseServiceMock) TransferStock(ctx context.Context, req models.CreateStockTransferRequest) (*models.StockTransfer, error) {
+	if mock.TransferStockFunc == nil {
+		panic("WarehouseServiceMock.TransferStockFunc: method is nil but WarehouseService.TransferStock was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req models.CreateStockTransferRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockTransferStock.Lock()
+	mock.calls.TransferStock = append(mock.calls.TransferStock, callInfo)
+	mock.lockTransferStock.Unlock()
+	return mock.TransferStockFunc(ctx, req)
+}
+
+// TransferStockCalls gets all the calls that were made to TransferStock.
+// Check the length with:
+//
+//	len(mockedWarehouseService.TransferStockCalls())
+func (mock *WarehouseServiceMock) TransferStockCalls() []struct {
+	Ctx context.Context
+	Req models.CreateStockTransferRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req models.CreateStockTransferRequest
+	}
+	mock.lockTransferStock.RLock()
+	calls = mock.calls.TransferStock
+	mock.lockTransferStock.RUnlock()
+	return calls
+}
+
+// UpdateWarehouse calls UpdateWarehouseFunc.
+func (mock *WarehouseServiceMock) UpdateWarehouse(ctx context.Context, id uuid.UUID, req models.UpdateWarehouseRequest) (*models.Warehouse, error) {
+	if mock.UpdateWarehouseFunc == nil {
+		panic("WarehouseServiceMock.UpdateWarehouseFunc: method is nil but WarehouseService.UpdateWarehouse was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.UpdateWarehouseRequest
+	}{
+		Ctx: ctx,
+		ID:  id,
+		Req: req,
+	}
+	mock.lockUpdateWarehouse.Lock()
+	mock.calls.UpdateWarehouse = append(mock.calls.UpdateWarehouse, callInfo)
+	mock.lockUpdateWarehouse.Unlock()
+	return mock.UpdateWarehouseFunc(ctx, id, req)
+}
+
+// UpdateWarehouseCalls gets all the calls that were made to UpdateWarehouse.
+// Check the length with:
+//
+//	len(mockedWarehouseService.UpdateWarehouseCalls())
+func (mock *WarehouseServiceMock) UpdateWarehouseCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+	Req models.UpdateWarehouseRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+		Req models.UpdateWarehouseRequest
+	}
+	mock.lockUpdateWarehouse.RLock()
+	calls = mock.calls.UpdateWarehouse
+	mock.lockUpdateWarehouse.RUnlock()
+	return calls
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// settingsCacheTTL is how long stored settings are reused before they are
+// read again, so changes reach other instances within it
+const settingsCacheTTL = 30 * time.Second
+
+// settingDefinition describes a runtime setting. Min is the smallest integer
+// or number of minutes accepted; amounts must not be negative.
+type settingDefinition struct {
+	Type        models.SettingType
+	Description string
+	Min         int
+}
+
+var settingDefinitions = map[string]settingDefinition{
+	models.SettingStockReservationTTL: {
+		Type:        models.SettingTypeMinutes,
+		Description: "How long cart stock stays reserved after the cart last changed it",
+		Min:         1,
+	},
+	models.SettingRateLimit: {
+		Type:        models.SettingTypeInteger,
+		Description: "Requests per minute allowed per client IP and per user; 0 disables",
+	},
+	models.SettingAuthRateLimit: {
+		Type:        models.SettingTypeInteger,
+		Description: "Requests per minute allowed per client IP on login and registration; 0 disables",
+	},
+	models.SettingCODMaxOrderValue: {
+		Type:        models.SettingTypeAmount,
+		Description: "Largest order cash on delivery is offered for; 0 means no limit",
+	},
+	models.SettingShippingFee: {
+		Type:        models.SettingTypeAmount,
+		Description: "Flat shipping fee estimated on carts",
+	},
+	models.SettingShippingRatePerKg: {
+		Type:        models.SettingTypeAmount,
+		Description: "Shipping charged per started kilogram of a cart's chargeable weight, on top of the flat fee",
+	},
+	models.SettingFreeShippingMinimum: {
+		Type:        models.SettingTypeAmount,
+		Description: "Cart subtotal, after discounts, from which shipping is free; 0 disables",
+	},
+	models.SettingMinimumOrderValue: {
+		Type:        models.SettingTypeAmount,
+		Description: "Smallest cart subtotal, after discounts, that can be checked out; 0 disables",
+	},
+	models.SettingMaintenanceMode: {
+		Type:        models.SettingTypeBoolean,
+		Description: "Turns away customer writes with 503 while admin routes and health checks keep working",
+	},
+	models.SettingMaintenanceMessage: {
+		Type:        models.SettingTypeText,
+		Description: "Message shown to customers turned away during maintenance",
+	},
+}
+
+// SettingsService serves runtime settings: values operators change often,
+// stored in the database and cached in memory, falling back to defaults
+// from the environment
+type SettingsService interface {
+	GetSettings(ctx context.Context) ([]models.Setting, error)
+	GetSetting(ctx context.Context, key string) (*models.Setting, error)
+	UpdateSetting(ctx context.Context, adminID uuid.UUID, key string, req models.UpdateSettingRequest) (*models.Setting, error)
+	ResetSetting(ctx context.Context, key string) (*models.Setting, error)
+	Int(ctx context.Context, key string) int
+	Duration(ctx context.Context, key string) time.Duration
+	Amount(ctx context.Context, key string) money.Money
+	Bool(ctx context.Context, key string) bool
+	String(ctx context.Context, key string) string
+}
+
+type settingsService struct {
+	settingRepo repository.SettingRepository
+	defaults    map[string]string
+
+	mu        sync.Mutex
+	overrides map[string]models.SettingOverride
+	expiresAt time.Time
+}
+
+// NewSettingsService creates the settings service. defaults holds the value
+// of every setting in its text form, e.g. "10" minutes or "499.00".
+func NewSettingsService(settingRepo repository.SettingRepository, defaults map[string]string) SettingsService {
+	return &settingsService{
+		settingRepo: settingRepo,
+		defaults:    defaults,
+	}
+}
+
+// value is the setting's value in effect. When the stored settings cannot be
+// read the last ones read are used.
+func (s *settingsService) value(ctx context.Context, key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.overrides == nil || time.Now().After(s.expiresAt) {
+		overrides, err := s.settingRepo.GetAll(ctx)
+		if err != nil {
+			log.Printf("⚠️ Failed to load settings: %v", err)
+		} else {
+			s.overrides = make(map[string]models.SettingOverride, len(overrides))
+			for _, override := range overrides {
+				s.overrides[override.Key] = override
+			}
+			s.expiresAt = time.Now().Add(settingsCacheTTL)
+		}
+	}
+
+	if override, ok := s.overrides[key]; ok {
+		return override.Value
+	}
+	return s.defaults[key]
+}
+
+func (s *settingsService) invalidate() {
+	s.mu.Lock()
+	s.expiresAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *settingsService) Int(ctx context.Context, key string) int {
+	value, _ := strconv.Atoi(s.value(ctx, key))
+	return value
+}
+
+func (s *settingsService) Duration(ctx context.Context, key string) time.Duration {
+	return time.Duration(s.Int(ctx, key)) * time.Minute
+}
+
+func (s *settingsService) Amount(ctx context.Context, key string) money.Money {
+	value, _ := money.Parse(s.value(ctx, key))
+	return value
+}
+
+func (s *settingsService) Bool(ctx context.Context, key string) bool {
+	value, _ := strconv.ParseBool(s.value(ctx, key))
+	return value
+}
+
+func (s *settingsService) String(ctx context.Context, key string) string {
+	return s.value(ctx, key)
+}
+
+func (s *settingsService) GetSettings(ctx context.Context) ([]models.Setting, error) {
+	overrides, err := s.loadOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(settingDefinitions))
+	for key := range settingDefinitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]models.Setting, 0, len(keys))
+	for _, key := range keys {
+		settings = append(settings, s.describe(key, overrides))
+	}
+	return settings, nil
+}
+
+func (s *settingsService) GetSetting(ctx context.Context, key string) (*models.Setting, error) {
+	if _, ok := settingDefinitions[key]; !ok {
+		return nil, apperrors.NotFound("setting not found")
+	}
+
+	overrides, err := s.loadOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := s.describe(key, overrides)
+	return &setting, nil
+}
+
+// UpdateSetting overrides the setting. The new value is used at once by this
+// instance and by the others within the cache TTL.
+func (s *settingsService) UpdateSetting(ctx context.Context, adminID uuid.UUID, key string, req models.UpdateSettingRequest) (*models.Setting, error) {
+	definition, ok := settingDefinitions[key]
+	if !ok {
+		return nil, apperrors.NotFound("setting not found")
+	}
+
+	value, err := normalizeSettingValue(definition, req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	override := &models.SettingOverride{Key: key, Value: value, UpdatedBy: &adminID}
+	if err := s.settingRepo.Set(ctx, override); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return s.GetSetting(ctx, key)
+}
+
+// ResetSetting drops the override so the setting takes its default again
+func (s *settingsService) ResetSetting(ctx context.Context, key string) (*models.Setting, error) {
+	if _, ok := settingDefinitions[key]; !ok {
+		return nil, apperrors.NotFound("setting not found")
+	}
+
+	if err := s.settingRepo.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return s.GetSetting(ctx, key)
+}
+
+// loadOverrides reads the stored settings, bypassing the cache so admins see
+// exactly what is stored
+func (s *settingsService) loadOverrides(ctx context.Context) (map[string]models.SettingOverride, error) {
+	overrides, err := s.settingRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]models.SettingOverride, len(overrides))
+	for _, override := range overrides {
+		byKey[override.Key] = override
+	}
+	return byKey, nil
+}
+
+func (s *settingsService) describe(key string, overrides map[string]models.SettingOverride) models.Setting {
+	definition := settingDefinitions[key]
+	setting := models.Setting{
+		Key:          key,
+		Type:         definition.Type,
+		Description:  definition.Description,
+		Value:        s.defaults[key],
+		DefaultValue: s.defaults[key],
+	}
+
+	if override, ok := overrides[key]; ok {
+		setting.Value = override.Value
+		setting.Overridden = true
+		setting.UpdatedBy = override.UpdatedBy
+		updatedAt := override.UpdatedAt
+		setting.UpdatedAt = &updatedAt
+	}
+
+	return setting
+}
+
+// normalizeSettingValue checks value against the setting's type and range
+// and returns it in canonical form
+func normalizeSettingValue(definition settingDefinition, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch definition.Type {
+	case models.SettingTypeInteger, models.SettingTypeMinutes:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", apperrors.Validation("value must be a whole number")
+		}
+		if n < definition.Min {
+			return "", apperrors.Validationf("value must be at least %d", definition.Min)
+		}
+		return strconv.Itoa(n), nil
+	case models.SettingTypeAmount:
+		amount, err := money.Parse(value)
+		if err != nil {
+			return "", apperrors.Validation("value must be an amount")
+		}
+		if amount < 0 {
+			return "", apperrors.Validation("value must not be negative")
+		}
+		return amount.String(), nil
+	case models.SettingTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", apperrors.Validation("value must be true or false")
+		}
+		return strconv.FormatBool(b), nil
+	case models.SettingTypeText:
+		if value == "" {
+			return "", apperrors.Validation("value must not be blank")
+		}
+		return value, nil
+	}
+
+	return value, nil
+}
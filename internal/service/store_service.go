@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+// storeCacheTTL is how long a resolved store is reused before it is read
+// again; every request resolves one
+const storeCacheTTL = time.Minute
+
+var storeSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type StoreService interface {
+	Resolve(ctx context.Context, slug, host string) (*models.Store, error)
+	CreateStore(ctx context.Context, req models.CreateStoreRequest) (*models.Store, error)
+	GetStores(ctx context.Context) ([]models.Store, error)
+	UpdateStore(ctx context.Context, id uuid.UUID, req models.UpdateStoreRequest) (*models.Store, error)
+}
+
+type cachedStore struct {
+	store     *models.Store
+	expiresAt time.Time
+}
+
+type storeService struct {
+	storeRepo repository.StoreRepository
+
+	mu    sync.Mutex
+	cache map[string]cachedStore
+}
+
+func NewStoreService(storeRepo repository.StoreRepository) StoreService {
+	return &storeService{
+		storeRepo: storeRepo,
+		cache:     make(map[string]cachedStore),
+	}
+}
+
+// Resolve picks the store a request is for: the one named by the path slug,
+// else the one bound to the host, else the default store. A slug that names
+// no active store is not found rather than falling back.
+func (s *storeService) Resolve(ctx context.Context, slug, host string) (*models.Store, error) {
+	if slug != "" {
+		store, err := s.lookup(ctx, "slug:"+slug, func() (*models.Store, error) {
+			return s.storeRepo.GetBySlug(ctx, slug)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if store == nil || !store.IsActive {
+			return nil, apperrors.NotFound("store not found")
+		}
+		return store, nil
+	}
+
+	if host = normalizeHost(host); host != "" {
+		store, err := s.lookup(ctx, "host:"+host, func() (*models.Store, error) {
+			return s.storeRepo.GetByHost(ctx, host)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if store != nil && store.IsActive {
+			return store, nil
+		}
+	}
+
+	store, err := s.lookup(ctx, "id:"+models.DefaultStoreID.String(), func() (*models.Store, error) {
+		return s.storeRepo.GetByID(ctx, models.DefaultStoreID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, errors.New("default store is missing")
+	}
+	return store, nil
+}
+
+// lookup serves key from the cache, loading it on a miss. Misses are cached
+// too, so unknown hosts do not reach the database on every request.
+func (s *storeService) lookup(ctx context.Context, key string, load func() (*models.Store, error)) (*models.Store, error) {
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.store, nil
+	}
+
+	store, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedStore{store: store, expiresAt: time.Now().Add(storeCacheTTL)}
+	s.mu.Unlock()
+
+	return store, nil
+}
+
+func (s *storeService) invalidate() {
+	s.mu.Lock()
+	s.cache = make(map[string]cachedStore)
+	s.mu.Unlock()
+}
+
+// requireDefaultStore keeps store management with the admins of the default
+// store, who run the deployment
+func requireDefaultStore(ctx context.Context) error {
+	if storeID, ok := database.StoreFromContext(ctx); ok && storeID != models.DefaultStoreID {
+		return apperrors.Forbidden("stores are managed from the default store")
+	}
+	return nil
+}
+
+func (s *storeService) CreateStore(ctx context.Context, req models.CreateStoreRequest) (*models.Store, error) {
+	if err := requireDefaultStore(ctx); err != nil {
+		return nil, err
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !storeSlugPattern.MatchString(slug) {
+		return nil, apperrors.Validation("slug may only contain lowercase letters, digits and hyphens")
+	}
+
+	existing, err := s.storeRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.Conflict("a store with this slug already exists")
+	}
+
+	store := &models.Store{
+		Slug:              slug,
+		Name:              strings.TrimSpace(req.Name),
+		IsActive:          true,
+		OrderNumberPrefix: strings.ToUpper(req.OrderNumberPrefix),
+	}
+	if store.OrderNumberPrefix == "" {
+		store.OrderNumberPrefix = models.DefaultOrderNumberPrefix
+	}
+	if err := s.setHost(ctx, store, req.Host); err != nil {
+		return nil, err
+	}
+
+	if err := s.storeRepo.Create(ctx, store); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return store, nil
+}
+
+func (s *storeService) GetStores(ctx context.Context) ([]models.Store, error) {
+	if err := requireDefaultStore(ctx); err != nil {
+		return nil, err
+	}
+	return s.storeRepo.GetAll(ctx)
+}
+
+func (s *storeService) UpdateStore(ctx context.Context, id uuid.UUID, req models.UpdateStoreRequest) (*models.Store, error) {
+	if err := requireDefaultStore(ctx); err != nil {
+		return nil, err
+	}
+
+	store, err := s.storeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, apperrors.NotFound("store not found")
+	}
+
+	if req.Name != nil {
+		store.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Host != nil {
+		if err := s.setHost(ctx, store, req.Host); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		if !*req.IsActive && store.ID == models.DefaultStoreID {
+			return nil, apperrors.Validation("the default store cannot be deactivated")
+		}
+		store.IsActive = *req.IsActive
+	}
+	if req.OrderNumberPrefix != nil {
+		store.OrderNumberPrefix = strings.ToUpper(*req.OrderNumberPrefix)
+	}
+
+	if err := s.storeRepo.Update(ctx, store); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return store, nil
+}
+
+// setHost binds store to host; an empty host unbinds it
+func (s *storeService) setHost(ctx context.Context, store *models.Store, host *string) error {
+	if host == nil {
+		return nil
+	}
+
+	normalized := normalizeHost(*host)
+	if normalized == "" {
+		store.Host = nil
+		return nil
+	}
+
+	existing, err := s.storeRepo.GetByHost(ctx, normalized)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != store.ID {
+		return apperrors.Conflict("another store already uses this host")
+	}
+
+	store.Host = &normalized
+	return nil
+}
+
+// normalizeHost lowercases a host name and drops any port
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host
+}
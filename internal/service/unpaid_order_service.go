@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// UnpaidOrderService cancels orders whose payment never arrived, and checkout
+// attempts whose payment was never authorized, so the stock they hold goes
+// back on sale
+type UnpaidOrderService interface {
+	Start(ctx context.Context, interval time.Duration)
+}
+
+type unpaidOrderService struct {
+	orderSvc OrderService
+}
+
+func NewUnpaidOrderService(orderSvc OrderService) UnpaidOrderService {
+	return &unpaidOrderService{orderSvc: orderSvc}
+}
+
+// Start runs the cancellation on a fixed interval until ctx is cancelled
+func (s *unpaidOrderService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("⚠️ Unpaid order cancellation worker disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				cancelled, err := s.orderSvc.CancelUnpaidOrders(ctx)
+				if cancelled > 0 {
+					log.Printf("🧹 Cancelled %d unpaid orders", cancelled)
+				}
+				if err != nil {
+					log.Printf("⚠️ Unpaid order cancellation failed: %v", err)
+				}
+
+				expired, err := s.orderSvc.ExpireCheckoutAttempts(ctx)
+				if expired > 0 {
+					log.Printf("🧹 Expired %d unauthorized checkout attempts", expired)
+				}
+				if err != nil {
+					log.Printf("⚠️ Checkout attempt expiry failed: %v", err)
+				}
+			}
+		}
+	}()
+
+	log.Printf("🧹 Unpaid order cancellation worker running every %s", interval)
+}
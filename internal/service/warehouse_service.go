@@ -0,0 +1,655 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ecommerce-backend/internal/apperrors"
+	"ecommerce-backend/internal/models"
+	"ecommerce-backend/internal/repository"
+	"ecommerce-backend/pkg/database"
+
+	"github.com/google/uuid"
+)
+
+type WarehouseService interface {
+	CreateWarehouse(ctx context.Context, req models.CreateWarehouseRequest) (*models.Warehouse, error)
+	GetWarehouses(ctx context.Context) ([]models.Warehouse, error)
+	GetWarehouse(ctx context.Context, id uuid.UUID) (*models.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, id uuid.UUID, req models.UpdateWarehouseRequest) (*models.Warehouse, error)
+	GetWarehouseStock(ctx context.Context, id uuid.UUID, page, limit int) ([]models.WarehouseStock, int, error)
+	SetStock(ctx context.Context, warehouseID uuid.UUID, req models.SetWarehouseStockRequest) error
+	GetProductStock(ctx context.Context, productID uuid.UUID) (*models.ProductStock, error)
+	TransferStock(ctx context.Context, req models.CreateStockTransferRequest) (*models.StockTransfer, error)
+	GetTransfers(ctx context.Context, page, limit int) ([]models.StockTransfer, int, error)
+	AllocateOrder(ctx context.Context, order *models.Order) error
+	RestockItems(ctx context.Context, items []models.OrderItem) error
+	ScanStock(ctx context.Context, req models.ScanStockRequest) (*models.ScanStockResponse, error)
+}
+
+type warehouseService struct {
+	warehouseRepo  repository.WarehouseRepository
+	productRepo    repository.ProductRepository
+	variantRepo    repository.VariantRepository
+	txManager      database.TxManager
+	backInStockSvc BackInStockService
+}
+
+func NewWarehouseService(
+	warehouseRepo repository.WarehouseRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.VariantRepository,
+	txManager database.TxManager,
+	backInStockSvc BackInStockService,
+) WarehouseService {
+	return &warehouseService{
+		warehouseRepo:  warehouseRepo,
+		productRepo:    productRepo,
+		variantRepo:    variantRepo,
+		txManager:      txManager,
+		backInStockSvc: backInStockSvc,
+	}
+}
+
+func (s *warehouseService) CreateWarehouse(ctx context.Context, req models.CreateWarehouseRequest) (*models.Warehouse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+
+	existing, err := s.warehouseRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, apperrors.Conflict("warehouse code already exists")
+	}
+
+	warehouse := &models.Warehouse{
+		Code:       code,
+		Name:       strings.TrimSpace(req.Name),
+		Zones:      normalizeZones(req.Zones),
+		Priority:   req.Priority,
+		CutoffTime: req.CutoffTime,
+		IsActive:   true,
+		IsDefault:  req.IsDefault,
+	}
+	if warehouse.CutoffTime == "" {
+		warehouse.CutoffTime = defaultCutoffTime
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if warehouse.IsDefault {
+			if err := s.warehouseRepo.ClearDefault(ctx); err != nil {
+				return err
+			}
+		}
+		return s.warehouseRepo.Create(ctx, warehouse)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (s *warehouseService) GetWarehouses(ctx context.Context) ([]models.Warehouse, error) {
+	return s.warehouseRepo.GetAll(ctx)
+}
+
+func (s *warehouseService) GetWarehouse(ctx context.Context, id uuid.UUID) (*models.Warehouse, error) {
+	warehouse, err := s.warehouseRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if warehouse == nil {
+		return nil, apperrors.NotFound("warehouse not found")
+	}
+
+	return warehouse, nil
+}
+
+// UpdateWarehouse changes a warehouse's details. There is always exactly one
+// default warehouse, and a warehouse can only be deactivated once its stock
+// has been transferred out.
+func (s *warehouseService) UpdateWarehouse(ctx context.Context, id uuid.UUID, req models.UpdateWarehouseRequest) (*models.Warehouse, error) {
+	warehouse, err := s.GetWarehouse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		warehouse.Name = strings.TrimSpace(req.Name)
+	}
+
+	if req.Zones != nil {
+		warehouse.Zones = normalizeZones(*req.Zones)
+	}
+
+	if req.Priority != nil {
+		warehouse.Priority = *req.Priority
+	}
+
+	if req.CutoffTime != "" {
+		warehouse.CutoffTime = req.CutoffTime
+	}
+
+	makeDefault := false
+	if req.IsDefault != nil {
+		if !*req.IsDefault && warehouse.IsDefault {
+			return nil, apperrors.Validation("make another warehouse the default instead")
+		}
+		makeDefault = *req.IsDefault && !warehouse.IsDefault
+		warehouse.IsDefault = *req.IsDefault
+	}
+
+	if req.IsActive != nil {
+		if !*req.IsActive && warehouse.TotalUnits > 0 {
+			return nil, apperrors.Validation("transfer the warehouse's stock out before deactivating it")
+		}
+		warehouse.IsActive = *req.IsActive
+	}
+
+	if warehouse.IsDefault && !warehouse.IsActive {
+		return nil, apperrors.Validation("the default warehouse cannot be deactivated")
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if makeDefault {
+			if err := s.warehouseRepo.ClearDefault(ctx); err != nil {
+				return err
+			}
+		}
+		return s.warehouseRepo.Update(ctx, warehouse)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (s *warehouseService) GetWarehouseStock(ctx context.Context, id uuid.UUID, page, limit int) ([]models.WarehouseStock, int, error) {
+	if _, err := s.GetWarehouse(ctx, id); err != nil {
+		return nil, 0, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return s.warehouseRepo.GetStock(ctx, id, page, limit)
+}
+
+// SetStock records a stock count at one warehouse, e.g. after receiving a
+// delivery or a stocktake. The product's total moves by the same amount.
+func (s *warehouseService) SetStock(ctx context.Context, warehouseID uuid.UUID, req models.SetWarehouseStockRequest) error {
+	if _, err := s.GetWarehouse(ctx, warehouseID); err != nil {
+		return err
+	}
+
+	if err := s.checkStockItem(ctx, req.ProductID, req.VariantID); err != nil {
+		return err
+	}
+
+	var delta int
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// A count can lower stock, so it waits for checkouts of the product
+		if err := s.productRepo.LockStock(ctx, []uuid.UUID{req.ProductID}); err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		current, err := s.warehouseRepo.GetStockLevel(ctx, warehouseID, req.ProductID, req.VariantID)
+		if err != nil {
+			return err
+		}
+
+		delta = req.Quantity - current
+		if delta == 0 {
+			return nil
+		}
+
+		if err := s.warehouseRepo.AdjustStock(ctx, warehouseID, req.ProductID, req.VariantID, delta); err != nil {
+			return err
+		}
+
+		return s.adjustTotal(ctx, req.ProductID, req.VariantID, delta)
+	})
+	if err != nil {
+		return err
+	}
+
+	if delta > 0 {
+		notifyRestocked(ctx, s.backInStockSvc, []models.OrderItem{{ProductID: req.ProductID}})
+	}
+
+	return nil
+}
+
+// GetProductStock returns the product's total stock and that of each of its
+// variants, broken down by warehouse
+func (s *warehouseService) GetProductStock(ctx context.Context, productID uuid.UUID) (*models.ProductStock, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, apperrors.NotFound("product not found")
+	}
+
+	variants, err := s.variantRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := s.warehouseRepo.GetProductStock(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := s.productRepo.GetAvailableStock(ctx, productID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stock := &models.ProductStock{
+		ProductID:  product.ID,
+		ProductSKU: product.SKU,
+		Total:      product.Stock,
+		Levels: []models.StockLevel{{
+			Total:     product.Stock,
+			Available: available,
+			Locations: stockLocations(locations, nil),
+		}},
+	}
+
+	for _, variant := range variants {
+		variantID := variant.ID
+		variantSKU := variant.SKU
+
+		available, err := s.productRepo.GetAvailableStock(ctx, productID, &variantID)
+		if err != nil {
+			return nil, err
+		}
+
+		stock.Total += variant.Stock
+		stock.Levels = append(stock.Levels, models.StockLevel{
+			VariantID:  &variantID,
+			VariantSKU: &variantSKU,
+			Total:      variant.Stock,
+			Available:  available,
+			Locations:  stockLocations(locations, &variantID),
+		})
+	}
+
+	return stock, nil
+}
+
+// stockLocations picks the warehouse rows for the base product (variantID nil)
+// or for one variant
+func stockLocations(locations []models.WarehouseStock, variantID *uuid.UUID) []models.WarehouseStock {
+	matched := []models.WarehouseStock{}
+	for _, location := range locations {
+		if (variantID == nil && location.VariantID == nil) ||
+			(variantID != nil && location.VariantID != nil && *location.VariantID == *variantID) {
+			matched = append(matched, location)
+		}
+	}
+	return matched
+}
+
+// TransferStock moves units between warehouses; the product's total stock is
+// unchanged
+func (s *warehouseService) TransferStock(ctx context.Context, req models.CreateStockTransferRequest) (*models.StockTransfer, error) {
+	if req.FromWarehouseID == req.ToWarehouseID {
+		return nil, apperrors.Validation("source and destination warehouses must differ")
+	}
+
+	from, err := s.GetWarehouse(ctx, req.FromWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.GetWarehouse(ctx, req.ToWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !to.IsActive {
+		return nil, apperrors.Validation("destination warehouse is not active")
+	}
+
+	if err := s.checkStockItem(ctx, req.ProductID, req.VariantID); err != nil {
+		return nil, err
+	}
+
+	transfer := &models.StockTransfer{
+		FromWarehouseID:   from.ID,
+		FromWarehouseCode: from.Code,
+		ToWarehouseID:     to.ID,
+		ToWarehouseCode:   to.Code,
+		ProductID:         req.ProductID,
+		VariantID:         req.VariantID,
+		Quantity:          req.Quantity,
+		Note:              strings.TrimSpace(req.Note),
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.LockStock(ctx, []uuid.UUID{req.ProductID}); err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		if err := s.warehouseRepo.AdjustStock(ctx, from.ID, req.ProductID, req.VariantID, -req.Quantity); err != nil {
+			return err
+		}
+
+		if err := s.warehouseRepo.AdjustStock(ctx, to.ID, req.ProductID, req.VariantID, req.Quantity); err != nil {
+			return err
+		}
+
+		return s.warehouseRepo.CreateTransfer(ctx, transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (s *warehouseService) GetTransfers(ctx context.Context, page, limit int) ([]models.StockTransfer, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return s.warehouseRepo.GetTransfers(ctx, page, limit)
+}
+
+// AllocateOrder takes each item's units out of the warehouses that should ship
+// them and records where they came from. Bundles are allocated as their
+// components. Totals have already been reduced when the cart reservations were
+// committed. Call it inside the order transaction.
+func (s *warehouseService) AllocateOrder(ctx context.Context, order *models.Order) error {
+	postalCode := normalizePostalCode(order.ShippingAddress.PostalCode)
+
+	// Checkouts already hold these locks; taking them again is free and keeps
+	// warehouse levels from moving between reading candidates and taking
+	// units from them
+	if err := s.productRepo.LockStock(ctx, orderProductIDs(order.Items)); err != nil {
+		return fmt.Errorf("failed to lock stock: %w", err)
+	}
+
+	for _, item := range order.Items {
+		for _, unit := range item.StockUnits() {
+			candidates, err := s.warehouseRepo.GetAllocationCandidates(ctx, unit.ProductID, unit.VariantID, postalCode, unit.Quantity)
+			if err != nil {
+				return err
+			}
+
+			remaining := unit.Quantity
+			for _, candidate := range candidates {
+				if remaining == 0 {
+					break
+				}
+
+				take := min(remaining, candidate.Quantity)
+				if err := s.warehouseRepo.AdjustStock(ctx, candidate.WarehouseID, unit.ProductID, unit.VariantID, -take); err != nil {
+					return err
+				}
+
+				allocation := &models.OrderItemAllocation{
+					OrderItemID: item.ID,
+					WarehouseID: candidate.WarehouseID,
+					Quantity:    take,
+				}
+				if item.IsBundle() {
+					allocation.ComponentProductID = &unit.ProductID
+					allocation.ComponentVariantID = unit.VariantID
+				}
+				if err := s.warehouseRepo.CreateAllocation(ctx, allocation); err != nil {
+					return err
+				}
+
+				remaining -= take
+			}
+
+			if remaining > 0 {
+				return apperrors.Validationf("insufficient warehouse stock for product %s", unit.ProductID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RestockItems puts order items back into the warehouses they shipped from
+// and restores the product totals. Bundles restock the components they were
+// sold with. Items from before warehouses were tracked go back to the default
+// warehouse.
+func (s *warehouseService) RestockItems(ctx context.Context, items []models.OrderItem) error {
+	itemIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		allocations, err := s.warehouseRepo.GetAllocations(ctx, itemIDs)
+		if err != nil {
+			return err
+		}
+
+		byItem := make(map[uuid.UUID][]models.OrderItemAllocation)
+		for _, allocation := range allocations {
+			byItem[allocation.OrderItemID] = append(byItem[allocation.OrderItemID], allocation)
+		}
+
+		var defaultWarehouse *models.Warehouse
+		for _, item := range items {
+			itemAllocations := byItem[item.ID]
+			if len(itemAllocations) == 0 {
+				if defaultWarehouse == nil {
+					defaultWarehouse, err = s.warehouseRepo.GetDefault(ctx)
+					if err != nil {
+						return err
+					}
+					if defaultWarehouse == nil {
+						return errors.New("no default warehouse configured")
+					}
+				}
+				for _, unit := range item.StockUnits() {
+					allocation := models.OrderItemAllocation{WarehouseID: defaultWarehouse.ID, Quantity: unit.Quantity}
+					if item.IsBundle() {
+						allocation.ComponentProductID = &unit.ProductID
+						allocation.ComponentVariantID = unit.VariantID
+					}
+					itemAllocations = append(itemAllocations, allocation)
+				}
+			}
+
+			for _, allocation := range itemAllocations {
+				productID, variantID := item.ProductID, item.VariantID
+				if allocation.ComponentProductID != nil {
+					productID, variantID = *allocation.ComponentProductID, allocation.ComponentVariantID
+				}
+				if err := s.warehouseRepo.AdjustStock(ctx, allocation.WarehouseID, productID, variantID, allocation.Quantity); err != nil {
+					return err
+				}
+			}
+
+			for _, unit := range item.StockUnits() {
+				if err := s.adjustTotal(ctx, unit.ProductID, unit.VariantID, unit.Quantity); err != nil {
+					return fmt.Errorf("failed to restore stock for product %s: %w", unit.ProductID, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// ScanStock applies a batch of barcode scans to one warehouse's stock and the
+// product totals. The batch is all or nothing: an unknown barcode or a
+// removal of more than the warehouse holds rejects every scan in it.
+func (s *warehouseService) ScanStock(ctx context.Context, req models.ScanStockRequest) (*models.ScanStockResponse, error) {
+	var warehouse *models.Warehouse
+	var err error
+	if req.WarehouseID != nil {
+		warehouse, err = s.GetWarehouse(ctx, *req.WarehouseID)
+	} else {
+		warehouse, err = s.warehouseRepo.GetDefault(ctx)
+		if err == nil && warehouse == nil {
+			err = errors.New("no default warehouse configured")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ScanStockResponse{
+		WarehouseID:   warehouse.ID,
+		WarehouseCode: warehouse.Code,
+		Items:         make([]models.ScanStockResult, 0, len(req.Items)),
+	}
+	restocked := []models.OrderItem{}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		matches := make([]*models.BarcodeMatch, 0, len(req.Items))
+		productIDs := make([]uuid.UUID, 0, len(req.Items))
+		for _, item := range req.Items {
+			match, err := resolveBarcode(ctx, s.productRepo, s.variantRepo, item.Barcode)
+			if err != nil {
+				return err
+			}
+			matches = append(matches, match)
+			productIDs = append(productIDs, match.Product.ID)
+		}
+
+		// Removals must not take units a checkout is deducting at the same
+		// time or units held in carts, so every scanned product is locked
+		// before any level is read
+		if err := s.productRepo.LockStock(ctx, productIDs); err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		for i, item := range req.Items {
+			match := matches[i]
+			result := models.ScanStockResult{
+				Barcode:     match.Barcode,
+				ProductID:   match.Product.ID,
+				ProductName: match.Product.Name,
+				SKU:         match.Product.SKU,
+				Quantity:    item.Quantity,
+			}
+			if match.Variant != nil {
+				result.VariantID = &match.Variant.ID
+				result.SKU = match.Variant.SKU
+			}
+
+			// Locks the row, so the level below stays accurate
+			current, err := s.warehouseRepo.GetStockLevel(ctx, warehouse.ID, result.ProductID, result.VariantID)
+			if err != nil {
+				return err
+			}
+			if current+item.Quantity < 0 {
+				return apperrors.Validationf("cannot remove %d of %s: %s holds %d", -item.Quantity, result.SKU, warehouse.Code, current)
+			}
+			if item.Quantity < 0 {
+				available, err := s.productRepo.GetAvailableStock(ctx, result.ProductID, result.VariantID)
+				if err != nil {
+					return err
+				}
+				if available+item.Quantity < 0 {
+					return apperrors.Conflictf("cannot remove %d of %s: only %d are not reserved", -item.Quantity, result.SKU, max(available, 0))
+				}
+			}
+
+			if err := s.warehouseRepo.AdjustStock(ctx, warehouse.ID, result.ProductID, result.VariantID, item.Quantity); err != nil {
+				return err
+			}
+			result.WarehouseQuantity = current + item.Quantity
+
+			if result.VariantID != nil {
+				result.TotalStock, err = s.variantRepo.UpdateStock(ctx, *result.VariantID, item.Quantity)
+			} else {
+				result.TotalStock, err = s.productRepo.UpdateStock(ctx, result.ProductID, item.Quantity)
+			}
+			if err != nil {
+				return err
+			}
+
+			if item.Quantity > 0 {
+				restocked = append(restocked, models.OrderItem{ProductID: result.ProductID})
+			}
+			response.Items = append(response.Items, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notifyRestocked(ctx, s.backInStockSvc, restocked)
+	return response, nil
+}
+
+// adjustTotal moves the network-wide stock of a product or variant
+func (s *warehouseService) adjustTotal(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID, delta int) error {
+	var err error
+	if variantID != nil {
+		_, err = s.variantRepo.UpdateStock(ctx, *variantID, delta)
+	} else {
+		_, err = s.productRepo.UpdateStock(ctx, productID, delta)
+	}
+	return err
+}
+
+// checkStockItem makes sure the product exists and, when given, that the
+// variant belongs to it
+func (s *warehouseService) checkStockItem(ctx context.Context, productID uuid.UUID, variantID *uuid.UUID) error {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return apperrors.NotFound("product not found")
+	}
+
+	if variantID == nil {
+		return nil
+	}
+
+	variant, err := s.variantRepo.GetByID(ctx, *variantID)
+	if err != nil {
+		return err
+	}
+
+	if variant == nil || variant.ProductID != productID {
+		return apperrors.NotFound("variant not found")
+	}
+
+	return nil
+}
+
+func normalizeZones(zones []string) []string {
+	normalized := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if zone = normalizePostalCode(zone); zone != "" {
+			normalized = append(normalized, zone)
+		}
+	}
+	return normalized
+}
+
+func normalizePostalCode(postalCode string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(postalCode), " ", ""))
+}
@@ -0,0 +1,204 @@
+//go:build integration
+
+// Package testdb gives integration tests and benchmarks a PostgreSQL database
+// of their own. Each test binary creates a fresh database on the server named
+// by TEST_DATABASE_URL, applies every migration to it and drops it once the
+// binary's tests have run. Without TEST_DATABASE_URL it starts a PostgreSQL
+// container with testcontainers and removes it afterwards.
+//
+//	go test -tags integration ./...
+//	make db-up test-integration
+//
+// When neither is available the tests are skipped, except under CI, where
+// they fail so a run that tested nothing is not reported as passing.
+package testdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+// EnvURL names the server the test databases are created on. The user must
+// be allowed to create databases.
+const EnvURL = "TEST_DATABASE_URL"
+
+// image is the server started when EnvURL is unset, matching docker-compose
+const image = "postgres:15-alpine"
+
+// errNoServer means EnvURL is unset and no container could be started
+var errNoServer = errors.New("no test database server")
+
+var (
+	once      sync.Once
+	pool      *pgxpool.Pool
+	container *postgres.PostgresContainer
+	serverURL string
+	dbName    string
+	setupErr  error
+)
+
+// Main runs the package's tests and drops the database they used. Call it
+// from TestMain:
+//
+//	func TestMain(m *testing.M) { os.Exit(testdb.Main(m)) }
+func Main(m *testing.M) int {
+	code := m.Run()
+
+	if pool != nil {
+		pool.Close()
+	}
+	if container != nil {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to remove test database container: %v\n", err)
+		}
+	} else if dbName != "" {
+		if err := dropDatabase(serverURL, dbName); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to drop test database %s: %v\n", dbName, err)
+		}
+	}
+
+	return code
+}
+
+// Pool returns a pool on the binary's test database, creating and migrating
+// it on first use. Tests share it, so they create the rows they work on
+// rather than counting on an empty table.
+func Pool(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	once.Do(func() {
+		pool, setupErr = setup()
+	})
+	if errors.Is(setupErr, errNoServer) && os.Getenv("CI") == "" {
+		tb.Skipf("%v", setupErr)
+	}
+	if setupErr != nil {
+		tb.Fatalf("failed to set up test database: %v", setupErr)
+	}
+
+	return pool
+}
+
+func setup() (*pgxpool.Pool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	serverURL = os.Getenv(EnvURL)
+	if serverURL == "" {
+		url, err := startContainer(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s is not set and no container could be started: %v", errNoServer, EnvURL, err)
+		}
+		serverURL = url
+	}
+
+	admin, err := pgx.Connect(ctx, serverURL)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close(ctx)
+
+	dbName = fmt.Sprintf("ecommerce_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(ctx, "CREATE DATABASE "+pgx.Identifier{dbName}.Sanitize()); err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+
+	config, err := pgxpool.ParseConfig(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	config.ConnConfig.Database = dbName
+
+	if err := migrate(ctx, config.ConnConfig); err != nil {
+		return nil, err
+	}
+
+	return pgxpool.NewWithConfig(ctx, config)
+}
+
+// startContainer runs a throwaway PostgreSQL server and returns its URL
+func startContainer(ctx context.Context) (url string, err error) {
+	// testcontainers panics rather than failing when it finds no Docker host
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	ctr, err := postgres.Run(ctx, image, postgres.BasicWaitStrategies())
+	if ctr != nil {
+		container = ctr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return ctr.ConnectionString(ctx, "sslmode=disable")
+}
+
+// migrate applies migrations/*.sql in name order, as make migrate does
+func migrate(ctx context.Context, config *pgx.ConnConfig) error {
+	conn, err := pgx.ConnectConfig(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir(), "*.sql"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no migrations found in %s", migrationsDir())
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		// Without arguments pgx sends the file as one simple query, so it
+		// may hold several statements
+		if _, err := conn.Exec(ctx, string(sql)); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
+}
+
+// migrationsDir is the repository's migrations directory, found from this
+// file so tests work from any package
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations")
+}
+
+func dropDatabase(url, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	admin, err := pgx.Connect(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer admin.Close(ctx)
+
+	_, err = admin.Exec(ctx, "DROP DATABASE IF EXISTS "+pgx.Identifier{name}.Sanitize()+" WITH (FORCE)")
+	return err
+}
@@ -0,0 +1,236 @@
+// Package tokens signs and verifies the API's JWTs. Tokens are signed with
+// the HMAC secret (HS256) or with a private key (RS256 or EdDSA) whose public
+// half is published as a JWKS, so other services can verify tokens without
+// holding a secret that would also let them issue tokens.
+package tokens
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"ecommerce-backend/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	errUnknownKey  = errors.New("token signed with an unknown key")
+	errHMACRefused = errors.New("HS256 tokens are not accepted")
+)
+
+// verifyKey is a public key tokens may be signed with
+type verifyKey struct {
+	id     string
+	method jwt.SigningMethod
+	public crypto.PublicKey
+}
+
+// KeySet holds the key new tokens are signed with and every key a token may
+// still carry: the current key, the previous keys of a rotation and, while
+// migrating off it, the HMAC secret.
+type KeySet struct {
+	method     jwt.SigningMethod
+	signingKey interface{}
+	keyID      string
+
+	secret []byte
+	keys   []verifyKey
+}
+
+// NewKeySet loads the keys configured by JWT_ALGORITHM, JWT_SECRET,
+// JWT_PRIVATE_KEY, JWT_PREVIOUS_PUBLIC_KEYS and JWT_ACCEPT_HS256. A private
+// key is loaded and accepted even while tokens are still signed with HS256,
+// so instances can learn a new key before any of them signs with it.
+func NewKeySet(cfg *config.Config) (*KeySet, error) {
+	k := &KeySet{}
+	if cfg.JWTAlgorithm == config.JWTAlgorithmHS256 || cfg.JWTAcceptHS256 {
+		k.secret = []byte(cfg.JWTSecret)
+	}
+
+	if cfg.JWTPrivateKey != "" {
+		private, err := parsePrivateKey([]byte(cfg.JWTPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY: %w", err)
+		}
+		current, err := newVerifyKey(private.Public())
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY: %w", err)
+		}
+		k.keys = append(k.keys, current)
+
+		if cfg.JWTAlgorithm != config.JWTAlgorithmHS256 {
+			if current.method.Alg() != jwtAlgorithm(cfg.JWTAlgorithm) {
+				return nil, fmt.Errorf("JWT_PRIVATE_KEY is not a %s key", jwtAlgorithm(cfg.JWTAlgorithm))
+			}
+			k.method, k.signingKey, k.keyID = current.method, private, current.id
+		}
+	}
+
+	rest := []byte(cfg.JWTPreviousPublicKeys)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		public, err := parsePublicKey(block)
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PREVIOUS_PUBLIC_KEYS: %w", err)
+		}
+		previous, err := newVerifyKey(public)
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PREVIOUS_PUBLIC_KEYS: %w", err)
+		}
+		k.keys = append(k.keys, previous)
+	}
+
+	if k.method == nil {
+		k.method, k.signingKey = jwt.SigningMethodHS256, k.secret
+	}
+	return k, nil
+}
+
+func jwtAlgorithm(algorithm string) string {
+	if algorithm == config.JWTAlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA.Alg()
+	}
+	return jwt.SigningMethodRS256.Alg()
+}
+
+// Sign signs claims with the current key, naming it in the kid header
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.keyID != "" {
+		token.Header["kid"] = k.keyID
+	}
+	return token.SignedString(k.signingKey)
+}
+
+// Parse verifies tokenString against the key its header names
+func (k *KeySet) Parse(tokenString string) (*jwt.Token, error) {
+	methods := make([]string, 0, len(k.keys)+1)
+	if k.secret != nil {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	for _, key := range k.keys {
+		methods = append(methods, key.method.Alg())
+	}
+
+	return jwt.Parse(tokenString, k.keyFor, jwt.WithValidMethods(methods))
+}
+
+func (k *KeySet) keyFor(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+		if k.secret == nil {
+			return nil, errHMACRefused
+		}
+		return k.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range k.keys {
+		if key.id == kid && key.method.Alg() == token.Method.Alg() {
+			return key.public, nil
+		}
+	}
+	return nil, errUnknownKey
+}
+
+// JWK is a public key in JSON Web Key form (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS lists the public keys tokens may be signed with, the current one
+// first. The HMAC secret is never published.
+func (k *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(k.keys))}
+	for _, key := range k.keys {
+		jwk := JWK{Kid: key.id, Alg: key.method.Alg(), Use: "sig"}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// newVerifyKey derives the key's ID from a hash of its public half, so it is
+// the same on every instance without being configured
+func newVerifyKey(public crypto.PublicKey) (verifyKey, error) {
+	key := verifyKey{public: public}
+	switch public.(type) {
+	case *rsa.PublicKey:
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return key, fmt.Errorf("unsupported key type %T; use RSA or Ed25519", public)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return key, err
+	}
+	sum := sha256.Sum256(der)
+	key.id = base64.RawURLEncoding.EncodeToString(sum[:12])
+	return key, nil
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type %T", key)
+		}
+		return signer, nil
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+// parsePublicKey reads a public key or takes the public half of a private one
+func parsePublicKey(block *pem.Block) (crypto.PublicKey, error) {
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	private, err := parsePrivateKey(pem.EncodeToMemory(block))
+	if err != nil {
+		return nil, err
+	}
+	return private.Public(), nil
+}
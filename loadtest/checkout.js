@@ -0,0 +1,113 @@
+// Checkout scenario: each virtual user adds in-stock products to its cart,
+// places a card order and pays for it. This exercises the stock reservation
+// queries under contention.
+//
+// Run the server with PAYMENT_SIMULATION_DELAY_MS=0 so simulated payments
+// complete at once, and AUTH_RATE_LIMIT_PER_MINUTE=0 so setup can register
+// one account per virtual user:
+//
+//   k6 run loadtest/checkout.js
+//   k6 run -e VUS=50 -e DURATION=5m loadtest/checkout.js
+import http from 'k6/http';
+import { check, fail, sleep } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+const VUS = Number(__ENV.VUS || 20);
+const PASSWORD = 'LoadTest123';
+
+export const options = {
+  scenarios: {
+    checkout: {
+      executor: 'constant-vus',
+      vus: VUS,
+      duration: __ENV.DURATION || '1m',
+    },
+  },
+  thresholds: {
+    http_req_failed: ['rate<0.05'],
+    'http_req_duration{name:add_to_cart}': ['p(95)<300'],
+    'http_req_duration{name:create_order}': ['p(95)<800'],
+    'http_req_duration{name:create_payment}': ['p(95)<300'],
+  },
+};
+
+const address = {
+  full_name: 'Load Test',
+  street: '1 Main Street',
+  city: 'Pune',
+  state: 'Maharashtra',
+  country: 'India',
+  postal_code: '411001',
+  phone: '+919876543210',
+};
+
+function params(token, name) {
+  const headers = { 'Content-Type': 'application/json' };
+  if (token) {
+    headers.Authorization = `Bearer ${token}`;
+  }
+  return { headers, tags: { name } };
+}
+
+// setup registers one account per virtual user and picks the products to
+// buy, so the timed part of the run is checkout only
+export function setup() {
+  const run = Date.now();
+  const tokens = [];
+  for (let i = 0; i < VUS; i++) {
+    const res = http.post(`${BASE_URL}/api/v1/auth/register`, JSON.stringify({
+      email: `loadtest.${run}.${i}@example.com`,
+      password: PASSWORD,
+      first_name: 'Load',
+      last_name: `Test${i}`,
+    }), params(null, 'register'));
+    if (res.status !== 201) {
+      fail(`register failed: ${res.status} ${res.body}`);
+    }
+    tokens.push(res.json('data.access_token'));
+  }
+
+  const list = http.get(`${BASE_URL}/api/v1/products?page=1&limit=50&in_stock_only=true`);
+  const products = (list.json('data.products') || [])
+    .filter((p) => !p.variants || p.variants.length === 0)
+    .map((p) => p.id);
+  if (products.length === 0) {
+    fail('no in-stock products without variants; run cmd/seed first');
+  }
+
+  return { tokens, products };
+}
+
+export default function (data) {
+  const token = data.tokens[(__VU - 1) % data.tokens.length];
+
+  const items = 1 + Math.floor(Math.random() * 3);
+  for (let i = 0; i < items; i++) {
+    const productID = data.products[Math.floor(Math.random() * data.products.length)];
+    const res = http.post(`${BASE_URL}/api/v1/cart/items`, JSON.stringify({
+      product_id: productID,
+      quantity: 1,
+    }), params(token, 'add_to_cart'));
+    check(res, { 'add to cart 201': (r) => r.status === 201 });
+  }
+
+  const order = http.post(`${BASE_URL}/api/v1/orders`, JSON.stringify({
+    shipping_address: address,
+    billing_address: address,
+    payment_method: 'cc',
+  }), params(token, 'create_order'));
+  if (!check(order, { 'create order 201': (r) => r.status === 201 })) {
+    // Out of stock or similar; leave the cart empty for the next iteration
+    http.del(`${BASE_URL}/api/v1/cart`, null, params(token, 'clear_cart'));
+    sleep(1);
+    return;
+  }
+
+  const payment = http.post(`${BASE_URL}/api/v1/payments`, JSON.stringify({
+    order_id: order.json('data.id'),
+    payment_method: 'cc',
+  }), params(token, 'create_payment'));
+  check(payment, { 'create payment 201': (r) => r.status === 201 });
+
+  sleep(1);
+}
@@ -0,0 +1,55 @@
+// Product listing scenario: anonymous shoppers browsing the catalogue with
+// the filters, sorts and pages the storefront uses.
+//
+//   k6 run loadtest/products.js
+//   k6 run -e BASE_URL=http://staging:8080 -e VUS=100 -e DURATION=5m loadtest/products.js
+import http from 'k6/http';
+import { check, sleep } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+
+export const options = {
+  scenarios: {
+    browse: {
+      executor: 'constant-vus',
+      vus: Number(__ENV.VUS || 50),
+      duration: __ENV.DURATION || '1m',
+    },
+  },
+  thresholds: {
+    http_req_failed: ['rate<0.01'],
+    'http_req_duration{name:list}': ['p(95)<300'],
+    'http_req_duration{name:facets}': ['p(95)<400'],
+    'http_req_duration{name:detail}': ['p(95)<200'],
+  },
+};
+
+const queries = [
+  'page=1&limit=20',
+  'page=2&limit=20',
+  'page=1&limit=20&sort=price_asc',
+  'page=1&limit=20&sort=price_desc',
+  'page=1&limit=20&in_stock_only=true',
+  'page=1&limit=20&search=pro',
+  'page=1&limit=20&min_price=20&max_price=200',
+];
+
+export default function () {
+  const query = queries[Math.floor(Math.random() * queries.length)];
+  const list = http.get(`${BASE_URL}/api/v1/products?${query}`, { tags: { name: 'list' } });
+  check(list, { 'list 200': (r) => r.status === 200 });
+
+  if (Math.random() < 0.2) {
+    const facets = http.get(`${BASE_URL}/api/v1/products/facets`, { tags: { name: 'facets' } });
+    check(facets, { 'facets 200': (r) => r.status === 200 });
+  }
+
+  const products = list.status === 200 ? list.json('data.products') || [] : [];
+  if (products.length > 0) {
+    const product = products[Math.floor(Math.random() * products.length)];
+    const detail = http.get(`${BASE_URL}/api/v1/products/${product.id}`, { tags: { name: 'detail' } });
+    check(detail, { 'detail 200': (r) => r.status === 200 });
+  }
+
+  sleep(Math.random() * 2);
+}
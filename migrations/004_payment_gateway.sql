@@ -0,0 +1,2 @@
+-- Webhook events look payments up by the gateway's transaction reference
+CREATE INDEX IF NOT EXISTS idx_payments_transaction_id ON payments(transaction_id);
@@ -0,0 +1,11 @@
+-- Return lifecycle: RMA number, return shipment tracking and receipt
+ALTER TABLE returns DROP CONSTRAINT IF EXISTS returns_status_check;
+ALTER TABLE returns ADD CONSTRAINT returns_status_check CHECK (
+    status IN ('requested', 'approved', 'in_transit', 'received', 'rejected', 'completed')
+);
+
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS rma_number VARCHAR(50) UNIQUE;
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS return_carrier VARCHAR(100);
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS return_tracking_number VARCHAR(100);
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS shipped_at TIMESTAMP;
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS received_at TIMESTAMP;
@@ -0,0 +1,32 @@
+-- Abandoned cart detection and recovery tracking
+
+-- Any change to a cart's items counts as activity on the cart itself
+CREATE OR REPLACE FUNCTION touch_cart_from_items()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE carts SET updated_at = CURRENT_TIMESTAMP
+    WHERE id = COALESCE(NEW.cart_id, OLD.cart_id);
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS touch_cart_on_item_change ON cart_items;
+CREATE TRIGGER touch_cart_on_item_change AFTER INSERT OR UPDATE OR DELETE ON cart_items
+    FOR EACH ROW EXECUTE FUNCTION touch_cart_from_items();
+
+CREATE TABLE IF NOT EXISTS abandoned_carts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    cart_id UUID NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_count INTEGER NOT NULL,
+    cart_value DECIMAL(10, 2) NOT NULL,
+    last_activity_at TIMESTAMP NOT NULL,
+    nudged_at TIMESTAMP,
+    recovered_at TIMESTAMP,
+    recovered_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one open (unrecovered) record per cart
+CREATE UNIQUE INDEX IF NOT EXISTS idx_abandoned_carts_open ON abandoned_carts(cart_id) WHERE recovered_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_abandoned_carts_created_at ON abandoned_carts(created_at DESC);
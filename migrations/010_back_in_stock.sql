@@ -0,0 +1,15 @@
+-- Back-in-stock subscriptions ("notify me" on out-of-stock products)
+CREATE TABLE IF NOT EXISTS back_in_stock_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    notified_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One pending subscription per user and product/variant
+CREATE UNIQUE INDEX IF NOT EXISTS idx_back_in_stock_pending
+    ON back_in_stock_subscriptions(user_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE notified_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_back_in_stock_product ON back_in_stock_subscriptions(product_id) WHERE notified_at IS NULL;
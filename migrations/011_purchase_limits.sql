@@ -0,0 +1,6 @@
+-- Per-product purchase limits (NULL means unlimited)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS max_per_order INTEGER CHECK (max_per_order > 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS max_per_customer INTEGER CHECK (max_per_customer > 0);
+
+-- Lookups of a customer's past purchases of a product
+CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items(product_id);
@@ -0,0 +1,41 @@
+-- Gift cards and store credit. Store credit is a per-user card that refunds
+-- can be credited to; gift cards are issued by admins and redeemed by code.
+CREATE TABLE IF NOT EXISTS gift_cards (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(32) UNIQUE NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'gift_card' CHECK (kind IN ('gift_card', 'store_credit')),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    initial_balance DECIMAL(10, 2) NOT NULL CHECK (initial_balance >= 0),
+    balance DECIMAL(10, 2) NOT NULL CHECK (balance >= 0),
+    expires_at TIMESTAMP,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One store credit account per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gift_cards_store_credit_user
+    ON gift_cards(user_id) WHERE kind = 'store_credit';
+
+DROP TRIGGER IF EXISTS update_gift_cards_updated_at ON gift_cards;
+CREATE TRIGGER update_gift_cards_updated_at BEFORE UPDATE ON gift_cards
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Balance ledger; amount is negative for redemptions and positive otherwise
+CREATE TABLE IF NOT EXISTS gift_card_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    gift_card_id UUID NOT NULL REFERENCES gift_cards(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('issue', 'redeem', 'reversal', 'refund_credit')),
+    amount DECIMAL(10, 2) NOT NULL,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    return_id UUID REFERENCES returns(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_gift_card_transactions_card ON gift_card_transactions(gift_card_id);
+CREATE INDEX IF NOT EXISTS idx_gift_card_transactions_order ON gift_card_transactions(order_id);
+
+-- Portion of the order total tendered with gift cards or store credit; the
+-- payment covers the rest
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS gift_card_amount DECIMAL(10, 2) NOT NULL DEFAULT 0
+    CHECK (gift_card_amount >= 0);
@@ -0,0 +1,29 @@
+-- Refund ledger. A payment can be refunded in several parts; the running
+-- total is kept on the payment and may never exceed the amount paid.
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_status_check;
+ALTER TABLE payments ADD CONSTRAINT payments_status_check CHECK (
+    status IN ('pending', 'processing', 'completed', 'failed', 'partially_refunded', 'refunded')
+);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+-- Payments refunded before the ledger existed were always refunded in full
+UPDATE payments SET refunded_amount = amount WHERE status = 'refunded' AND refunded_amount = 0;
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_refunded_amount_check;
+ALTER TABLE payments ADD CONSTRAINT payments_refunded_amount_check CHECK (
+    refunded_amount >= 0 AND refunded_amount <= amount
+);
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    return_id UUID REFERENCES returns(id) ON DELETE SET NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds(payment_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds(order_id);
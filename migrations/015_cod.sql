@@ -0,0 +1,35 @@
+-- Cash on delivery: a one-time code the customer hands to the courier to
+-- confirm delivery, and the ledger of cash collected awaiting remittance.
+CREATE TABLE IF NOT EXISTS cod_verifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID UNIQUE NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    otp_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    verified_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_cod_verifications_updated_at ON cod_verifications;
+CREATE TRIGGER update_cod_verifications_updated_at BEFORE UPDATE ON cod_verifications
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS cod_remittances (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID UNIQUE NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    payment_id UUID REFERENCES payments(id) ON DELETE SET NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'remitted')),
+    reference VARCHAR(100),
+    collected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    remitted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cod_remittances_status ON cod_remittances(status, collected_at);
+
+DROP TRIGGER IF EXISTS update_cod_remittances_updated_at ON cod_remittances;
+CREATE TRIGGER update_cod_remittances_updated_at BEFORE UPDATE ON cod_remittances
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
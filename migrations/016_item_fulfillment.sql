@@ -0,0 +1,24 @@
+-- Split shipments: each order item carries its own fulfillment status and the
+-- order status is derived from them
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('pending', 'processing', 'partially_shipped', 'shipped', 'delivered', 'completed', 'cancelled', 'refunded', 'return_requested')
+);
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS fulfillment_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (fulfillment_status IN ('pending', 'backordered', 'shipped', 'delivered', 'cancelled'));
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tracking_number VARCHAR(100);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS shipped_at TIMESTAMP;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP;
+
+-- Bring existing items in line with the status of their order
+UPDATE order_items oi
+SET fulfillment_status = CASE
+        WHEN o.status = 'shipped' THEN 'shipped'
+        WHEN o.status = 'cancelled' THEN 'cancelled'
+        ELSE 'delivered'
+    END
+FROM orders o
+WHERE oi.order_id = o.id
+    AND oi.fulfillment_status = 'pending'
+    AND o.status IN ('shipped', 'delivered', 'completed', 'return_requested', 'cancelled');
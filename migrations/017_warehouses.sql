@@ -0,0 +1,81 @@
+-- Multi-warehouse inventory. products.stock_quantity and
+-- product_variants.stock_quantity stay the network-wide totals that carts and
+-- reservations check against; warehouse_stock breaks them down per location.
+CREATE TABLE IF NOT EXISTS warehouses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    -- Postal code prefixes the warehouse ships to first
+    zones TEXT[] NOT NULL DEFAULT '{}',
+    -- Lower ships first when several warehouses could fulfil an item
+    priority INTEGER NOT NULL DEFAULT 100 CHECK (priority >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Stock set directly on a product or variant lands in the default warehouse
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_default ON warehouses(is_default) WHERE is_default;
+
+DROP TRIGGER IF EXISTS update_warehouses_updated_at ON warehouses;
+CREATE TRIGGER update_warehouses_updated_at BEFORE UPDATE ON warehouses
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO warehouses (code, name, priority, is_default)
+SELECT 'MAIN', 'Main warehouse', 0, TRUE
+WHERE NOT EXISTS (SELECT 1 FROM warehouses WHERE is_default);
+
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouse_stock_location
+    ON warehouse_stock(warehouse_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid));
+CREATE INDEX IF NOT EXISTS idx_warehouse_stock_product ON warehouse_stock(product_id);
+
+-- Existing stock all sits in the default warehouse
+INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, p.id, NULL, p.stock_quantity
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.is_default
+ON CONFLICT DO NOTHING;
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, v.product_id, v.id, v.stock_quantity
+FROM product_variants v
+CROSS JOIN warehouses w
+WHERE w.is_default
+ON CONFLICT DO NOTHING;
+
+-- Which warehouse each order item was shipped from; an item may be split
+-- across several
+CREATE TABLE IF NOT EXISTS order_item_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_allocations_item ON order_item_allocations(order_item_id);
+
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    to_warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_warehouse_id <> to_warehouse_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_created ON stock_transfers(created_at);
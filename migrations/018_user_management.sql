@@ -0,0 +1,12 @@
+-- Admin user management: deactivated accounts cannot log in
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP;
+
+-- Supports searching users by name
+CREATE INDEX IF NOT EXISTS idx_users_name ON users(LOWER(first_name), LOWER(last_name));
+
+-- Orders and returns are kept for accounting when a user is deleted; they are
+-- handed to this placeholder account, which can never log in
+INSERT INTO users (id, email, password_hash, first_name, last_name, role, email_verified, is_active, deactivated_at)
+VALUES ('00000000-0000-0000-0000-000000000001', 'deleted-user@invalid', '!', 'Deleted', 'User', 'customer', FALSE, FALSE, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,3 @@
+-- Admin order search: order number prefix lookups
+CREATE INDEX IF NOT EXISTS idx_orders_order_number_prefix ON orders (lower(order_number) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_orders_total_amount ON orders(total_amount);
@@ -0,0 +1,18 @@
+-- Audit trail of privileged actions. Support impersonation records one row
+-- when an admin starts a session and one per request made with it.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- The admin who acted; kept if the account is deleted
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- The customer acted as, if any
+    subject_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    method VARCHAR(10),
+    path VARCHAR(500),
+    status_code INTEGER,
+    details TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_subject ON audit_log(subject_user_id, created_at DESC);
@@ -0,0 +1,18 @@
+-- Per-order message threads between the customer and support. Each side's
+-- messages are unread until the other side opens the thread.
+CREATE TABLE IF NOT EXISTS order_messages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    -- NULL once the author's account is deleted
+    sender_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    from_staff BOOLEAN NOT NULL DEFAULT FALSE,
+    body TEXT NOT NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_messages_order ON order_messages(order_id, created_at);
+
+-- Unread counts (the support queue and the customer badge) only scan unread rows
+CREATE INDEX IF NOT EXISTS idx_order_messages_unread ON order_messages(order_id)
+    WHERE read_at IS NULL;
@@ -0,0 +1,8 @@
+-- EAN/UPC barcodes for handheld scanners. Stored normalized (UPC-A as its
+-- EAN-13 form), unique across products and variants like SKUs; the
+-- application checks the cross-table part.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS barcode VARCHAR(14);
+ALTER TABLE product_variants ADD COLUMN IF NOT EXISTS barcode VARCHAR(14);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_barcode ON products(barcode) WHERE barcode IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_variants_barcode ON product_variants(barcode) WHERE barcode IS NOT NULL;
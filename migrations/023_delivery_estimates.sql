@@ -0,0 +1,8 @@
+-- Orders placed after a warehouse's daily cut-off (UTC) are dispatched the
+-- next business day
+ALTER TABLE warehouses ADD COLUMN IF NOT EXISTS cutoff_time TIME NOT NULL DEFAULT '15:00';
+
+-- The shipping method chosen at checkout and the delivery date estimated for it
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS shipping_method VARCHAR(20) NOT NULL DEFAULT 'standard',
+    ADD COLUMN IF NOT EXISTS estimated_delivery DATE;
@@ -0,0 +1,14 @@
+-- Postal codes orders can ship to, as prefixes (e.g. "4000" covers 400001).
+-- With no active areas every postal code is serviceable.
+CREATE TABLE IF NOT EXISTS serviceable_areas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    postal_code VARCHAR(10) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_serviceable_areas_updated_at ON serviceable_areas;
+CREATE TRIGGER update_serviceable_areas_updated_at BEFORE UPDATE ON serviceable_areas
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,28 @@
+-- Cards saved with the payment gateway. Only the gateway's token and display
+-- details are stored, never card numbers.
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    gateway VARCHAR(20) NOT NULL,
+    token VARCHAR(255) NOT NULL,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('cc', 'dc')),
+    brand VARCHAR(30) NOT NULL DEFAULT '',
+    last4 VARCHAR(4) NOT NULL DEFAULT '',
+    exp_month INT NOT NULL DEFAULT 0,
+    exp_year INT NOT NULL DEFAULT 0,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, gateway, token)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_methods_user ON payment_methods(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_methods_default ON payment_methods(user_id) WHERE is_default;
+
+-- The gateway customer saved methods are attached to, one per user and gateway
+CREATE TABLE IF NOT EXISTS payment_customers (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    gateway VARCHAR(20) NOT NULL,
+    customer_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, gateway)
+);
@@ -0,0 +1,23 @@
+-- Orders scoring at or above the fraud threshold wait in 'review' until an
+-- admin approves or rejects them
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('pending', 'review', 'processing', 'partially_shipped', 'shipped', 'delivered', 'completed', 'cancelled', 'refunded', 'return_requested')
+);
+
+-- One fraud screening result per order, with the rules that fired
+CREATE TABLE IF NOT EXISTS order_risk_assessments (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_ip VARCHAR(45) NOT NULL DEFAULT '',
+    score INT NOT NULL DEFAULT 0,
+    signals JSONB NOT NULL DEFAULT '[]'::jsonb,
+    held BOOLEAN NOT NULL DEFAULT FALSE,
+    decision VARCHAR(20) CHECK (decision IN ('approved', 'rejected')),
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_risk_assessments_ip ON order_risk_assessments(client_ip, created_at);
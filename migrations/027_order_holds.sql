@@ -0,0 +1,25 @@
+-- Orders can be put on hold or under review by admins; fraud screening's
+-- 'review' status becomes 'under_review'
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+UPDATE orders SET status = 'under_review' WHERE status = 'review';
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('pending', 'on_hold', 'under_review', 'processing', 'partially_shipped', 'shipped', 'delivered', 'completed', 'cancelled', 'refunded', 'return_requested')
+);
+
+-- Each hold remembers the status it interrupted so releasing it can resume
+-- the order. placed_by is NULL for holds placed by fraud screening.
+CREATE TABLE IF NOT EXISTS order_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('on_hold', 'under_review')),
+    previous_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    placed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    placed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    released_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    released_at TIMESTAMP,
+    release_note TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_holds_order ON order_holds(order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_holds_active ON order_holds(order_id) WHERE released_at IS NULL;
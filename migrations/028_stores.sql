@@ -0,0 +1,35 @@
+-- Storefronts served by one deployment. Requests pick a store by host or by
+-- the /stores/{slug} path prefix and fall back to the default store.
+CREATE TABLE IF NOT EXISTS stores (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    host VARCHAR(255) UNIQUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_stores_updated_at ON stores;
+CREATE TRIGGER update_stores_updated_at BEFORE UPDATE ON stores
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO stores (id, slug, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default', 'Default Store')
+ON CONFLICT (id) DO NOTHING;
+
+-- Existing rows belong to the default store
+ALTER TABLE products ADD COLUMN IF NOT EXISTS store_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES stores(id);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS store_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES stores(id);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS store_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES stores(id);
+
+CREATE INDEX IF NOT EXISTS idx_products_store ON products(store_id);
+CREATE INDEX IF NOT EXISTS idx_orders_store ON orders(store_id, created_at DESC);
+
+-- Accounts are per store, so the same email can sign up at each storefront.
+-- SKUs and barcodes stay unique across the deployment.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_store_email ON users(store_id, email);
@@ -0,0 +1,4 @@
+-- What a product costs to stock, and the cost of each order line when it was
+-- ordered, so margins stay right after cost prices change. NULL is unknown.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost_price DECIMAL(10, 2) CHECK (cost_price >= 0);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS cost_at_time DECIMAL(10, 2);
@@ -0,0 +1,4 @@
+-- Warehouse staff: tokens limited to the fulfillment admin endpoints
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('customer', 'admin', 'warehouse'));
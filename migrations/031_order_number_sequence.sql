@@ -0,0 +1,7 @@
+-- Order numbers are the store's prefix and a deployment-wide sequence, e.g.
+-- ORD-100042. orders.order_number stays unique; numbers taken by imported
+-- orders are skipped.
+CREATE SEQUENCE IF NOT EXISTS order_number_seq START WITH 100001;
+
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS order_number_prefix VARCHAR(10) NOT NULL DEFAULT 'ORD'
+    CHECK (order_number_prefix ~ '^[A-Z0-9]+$');
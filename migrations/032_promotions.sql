@@ -0,0 +1,52 @@
+-- Automatic promotions, applied while pricing carts and orders without any
+-- code. Like coupons they are shared by every store.
+--   spend_threshold: min_subtotal reached, discount off the subtotal
+--   category:        discount off the lines in category
+--   buy_x_get_y:     of every buy_quantity + get_quantity matching units, the
+--                    get_quantity cheapest get percent_off; units match
+--                    product_id, else category, else everything
+-- Promotions apply by priority, highest first. A non-stackable promotion only
+-- applies alone.
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('spend_threshold', 'category', 'buy_x_get_y')),
+    min_subtotal DECIMAL(10, 2) CHECK (min_subtotal >= 0),
+    category VARCHAR(100),
+    product_id UUID REFERENCES products(id) ON DELETE CASCADE,
+    buy_quantity INTEGER CHECK (buy_quantity > 0),
+    get_quantity INTEGER CHECK (get_quantity > 0),
+    percent_off INTEGER CHECK (percent_off BETWEEN 1 AND 100),
+    amount_off DECIMAL(10, 2) CHECK (amount_off > 0),
+    priority INTEGER NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT TRUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((percent_off IS NULL) <> (amount_off IS NULL)),
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_active ON promotions(priority DESC) WHERE is_active;
+
+DROP TRIGGER IF EXISTS update_promotions_updated_at ON promotions;
+CREATE TRIGGER update_promotions_updated_at BEFORE UPDATE ON promotions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- The discount an order was placed with; total_amount is already net of it
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0
+    CHECK (discount_amount >= 0);
+
+-- Which promotions made up the discount. The name is kept for orders whose
+-- promotion was since deleted.
+CREATE TABLE IF NOT EXISTS order_promotions (
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id UUID REFERENCES promotions(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_promotions_order ON order_promotions(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_promotions_promotion ON order_promotions(promotion_id);
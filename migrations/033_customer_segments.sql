@@ -0,0 +1,37 @@
+-- Customer segments: admin-defined filters over one store's active customers.
+-- A customer belongs to a segment when every filter it sets matches:
+--   min_spend:     lifetime spend on orders not cancelled or refunded is above it
+--   inactive_days: no order in that many days (or signed up that long ago
+--                  without ordering)
+--   category:      has bought from the category
+-- Membership is materialized by the refresh worker, so listing and exporting
+-- members never recomputes it.
+CREATE TABLE IF NOT EXISTS customer_segments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    store_id UUID NOT NULL REFERENCES stores(id),
+    name VARCHAR(100) NOT NULL,
+    min_spend DECIMAL(10, 2) CHECK (min_spend >= 0),
+    inactive_days INTEGER CHECK (inactive_days > 0),
+    category VARCHAR(100),
+    member_count INTEGER NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_customer_segments_store ON customer_segments(store_id);
+
+DROP TRIGGER IF EXISTS update_customer_segments_updated_at ON customer_segments;
+CREATE TRIGGER update_customer_segments_updated_at BEFORE UPDATE ON customer_segments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Members as of the segment's last refresh, with the order stats they
+-- qualified on
+CREATE TABLE IF NOT EXISTS customer_segment_members (
+    segment_id UUID NOT NULL REFERENCES customer_segments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    total_spent DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    order_count INTEGER NOT NULL DEFAULT 0,
+    last_order_at TIMESTAMP,
+    PRIMARY KEY (segment_id, user_id)
+);
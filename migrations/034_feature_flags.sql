@@ -0,0 +1,25 @@
+-- Feature flags switch code paths on and off without a deploy. A flag is on
+-- for a subject (a user, or a client IP before login) when it is enabled, the
+-- deployment's ENV is in environments (empty means every environment) and the
+-- subject hashes into the first rollout_percent of 100 buckets. Flags are
+-- shared by every store; a flag that does not exist is off.
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(100) PRIMARY KEY CHECK (key ~ '^[a-z0-9]+(_[a-z0-9]+)*$'),
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    rollout_percent INTEGER NOT NULL DEFAULT 100 CHECK (rollout_percent BETWEEN 0 AND 100),
+    environments TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP TRIGGER IF EXISTS update_feature_flags_updated_at ON feature_flags;
+CREATE TRIGGER update_feature_flags_updated_at BEFORE UPDATE ON feature_flags
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Flags the code checks, on everywhere so behaviour is unchanged until an
+-- admin turns one down
+INSERT INTO feature_flags (key, description, enabled) VALUES
+    ('api_v2', 'Serve the /api/v2 routes', TRUE),
+    ('automatic_promotions', 'Apply automatic promotions to carts and checkout', TRUE)
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,10 @@
+-- Runtime settings operators change without a redeploy. Only overridden
+-- settings have a row; the rest take their default from the environment.
+-- Values are stored as text and typed by the code that defines each key.
+-- Settings are shared by every store.
+CREATE TABLE IF NOT EXISTS settings (
+    key VARCHAR(100) PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,22 @@
+-- Phone numbers (E.164) for customers, verified by a code sent by SMS. A
+-- verified number belongs to one account per store and can be used to sign
+-- in with a one-time code.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone VARCHAR(20);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_verified BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMP;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_verified_phone ON users(store_id, phone) WHERE phone_verified;
+
+-- Outstanding one-time codes, one per user and purpose. Only a hash of the
+-- code is stored and the row is deleted once the code is used.
+CREATE TABLE IF NOT EXISTS phone_otps (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('login', 'verify_phone')),
+    phone VARCHAR(20) NOT NULL,
+    otp_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, purpose)
+);
@@ -0,0 +1,5 @@
+-- Exchanges: a return can swap one order item for another variant of the same
+-- product. Approval ships the replacement as a free order linked here.
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS exchange_item_id UUID REFERENCES order_items(id);
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS replacement_variant_id UUID REFERENCES product_variants(id);
+ALTER TABLE returns ADD COLUMN IF NOT EXISTS replacement_order_id UUID REFERENCES orders(id);
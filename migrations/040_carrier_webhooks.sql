@@ -0,0 +1,22 @@
+-- Tracking updates pushed by carriers. Each item keeps the latest status its
+-- carrier reported; every callback is kept verbatim for troubleshooting.
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS carrier_status VARCHAR(30);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS carrier_status_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_order_items_tracking_number ON order_items(tracking_number) WHERE tracking_number IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS carrier_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    store_id UUID REFERENCES stores(id),
+    carrier VARCHAR(50) NOT NULL,
+    tracking_number VARCHAR(100),
+    status VARCHAR(30),
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('applied', 'ignored', 'rejected', 'failed', 'invalid')),
+    detail TEXT,
+    payload TEXT NOT NULL,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_carrier_events_tracking ON carrier_events(tracking_number);
+CREATE INDEX IF NOT EXISTS idx_carrier_events_received ON carrier_events(received_at DESC);
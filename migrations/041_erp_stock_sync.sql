@@ -0,0 +1,23 @@
+-- Stock levels the ERP publishes to the message queue. Every message is kept
+-- verbatim with what it changed so it can be inspected and replayed; the
+-- ERP's message id makes redeliveries of a processed message no-ops.
+CREATE TABLE IF NOT EXISTS erp_stock_messages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id VARCHAR(100) UNIQUE,
+    sku VARCHAR(100),
+    warehouse_code VARCHAR(20),
+    quantity INTEGER,
+    changed_at TIMESTAMP,
+    product_id UUID REFERENCES products(id) ON DELETE SET NULL,
+    variant_id UUID REFERENCES product_variants(id) ON DELETE SET NULL,
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('applied', 'ignored', 'rejected', 'failed', 'invalid')),
+    detail TEXT,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_erp_stock_messages_sku ON erp_stock_messages(sku, warehouse_code, changed_at DESC) WHERE outcome = 'applied';
+CREATE INDEX IF NOT EXISTS idx_erp_stock_messages_received ON erp_stock_messages(received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_created ON outbox_events(created_at);
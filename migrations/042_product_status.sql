@@ -0,0 +1,6 @@
+-- Product lifecycle: drafts are being prepared, archived products are kept
+-- for order history; only active products are sold on the storefront.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('draft', 'active', 'archived'));
+
+CREATE INDEX IF NOT EXISTS idx_products_store_status ON products(store_id, status);
@@ -0,0 +1,7 @@
+-- Scheduled launches: a draft goes active at publish_at and an active product
+-- back to draft at unpublish_at. Each is cleared once applied.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_products_publish_at ON products(publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_products_unpublish_at ON products(unpublish_at) WHERE unpublish_at IS NOT NULL;
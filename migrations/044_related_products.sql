@@ -0,0 +1,14 @@
+-- Admin-curated products shown with a product: cross-sells complement it,
+-- upsells are pricier alternatives. Ordered by position within each type.
+CREATE TABLE IF NOT EXISTS related_products (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    related_product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    relation_type VARCHAR(20) NOT NULL CHECK (relation_type IN ('cross_sell', 'upsell')),
+    position INTEGER NOT NULL DEFAULT 0 CHECK (position >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, related_product_id, relation_type),
+    CHECK (product_id <> related_product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_related_products_product ON related_products(product_id, relation_type, position);
@@ -0,0 +1,62 @@
+-- Bundle products are sold at their own price but hold no stock of their own:
+-- each bundle sold takes quantity units of every component. A product with
+-- components is a bundle; components are never bundles themselves.
+CREATE TABLE IF NOT EXISTS bundle_components (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bundle_product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    component_variant_id UUID REFERENCES product_variants(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (bundle_product_id <> component_product_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bundle_components_unique ON bundle_components (
+    bundle_product_id, component_product_id,
+    COALESCE(component_variant_id, '00000000-0000-0000-0000-000000000000'::uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_bundle_components_component ON bundle_components(component_product_id);
+
+-- Units of a product, or of one of its variants, held by live cart
+-- reservations of bundles that contain it. Reservations of exclude_cart are
+-- left out.
+CREATE OR REPLACE FUNCTION bundle_reserved_stock(product_id UUID, variant_id UUID, exclude_cart UUID)
+RETURNS BIGINT AS $$
+    SELECT COALESCE(SUM(sr.quantity * bc.quantity), 0)
+    FROM bundle_components bc
+    JOIN stock_reservations sr ON sr.product_id = bc.bundle_product_id
+    WHERE bc.component_product_id = $1
+        AND bc.component_variant_id IS NOT DISTINCT FROM $2
+        AND sr.expires_at > NOW()
+        AND sr.cart_id IS DISTINCT FROM $3
+$$ LANGUAGE sql STABLE;
+
+-- How many complete bundles the unreserved stock of the components makes up,
+-- NULL for a product without components. Reservations of exclude_cart are
+-- treated as free.
+CREATE OR REPLACE FUNCTION bundle_available_stock(bundle_id UUID, exclude_cart UUID)
+RETURNS INTEGER AS $$
+    SELECT MIN(GREATEST(
+        COALESCE(v.stock_quantity, p.stock_quantity)
+        - (
+            SELECT COALESCE(SUM(sr.quantity), 0) FROM stock_reservations sr
+            WHERE sr.product_id = bc.component_product_id
+                AND sr.variant_id IS NOT DISTINCT FROM bc.component_variant_id
+                AND sr.expires_at > NOW()
+                AND sr.cart_id IS DISTINCT FROM $2
+        )
+        - bundle_reserved_stock(bc.component_product_id, bc.component_variant_id, $2),
+        0) / bc.quantity)::INTEGER
+    FROM bundle_components bc
+    JOIN products p ON p.id = bc.component_product_id
+    LEFT JOIN product_variants v ON v.id = bc.component_variant_id
+    WHERE bc.bundle_product_id = $1
+$$ LANGUAGE sql STABLE;
+
+-- What each bundle was made of when it was sold, so returns and
+-- cancellations restock the components that actually shipped
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS bundle_components JSONB;
+
+-- Allocations of a bundle item name the component taken from the warehouse
+ALTER TABLE order_item_allocations ADD COLUMN IF NOT EXISTS component_product_id UUID REFERENCES products(id);
+ALTER TABLE order_item_allocations ADD COLUMN IF NOT EXISTS component_variant_id UUID REFERENCES product_variants(id);
@@ -0,0 +1,12 @@
+-- Shipping attributes of a product as packed for dispatch: weight in grams
+-- and dimensions in millimetres. Dimensions are set together or not at all.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS weight_grams INTEGER CHECK (weight_grams > 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS length_mm INTEGER CHECK (length_mm > 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS width_mm INTEGER CHECK (width_mm > 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS height_mm INTEGER CHECK (height_mm > 0);
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_dimensions_complete;
+ALTER TABLE products ADD CONSTRAINT products_dimensions_complete CHECK (
+    (length_mm IS NULL AND width_mm IS NULL AND height_mm IS NULL)
+    OR (length_mm IS NOT NULL AND width_mm IS NOT NULL AND height_mm IS NOT NULL)
+);
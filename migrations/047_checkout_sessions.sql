@@ -0,0 +1,22 @@
+-- A checkout session snapshots the cart when checkout starts: its lines at
+-- the prices shown, the addresses, the shipping choice and the totals. The
+-- order is placed from the snapshot, so repricing between review and
+-- placement cannot change what the customer pays. A customer has at most one
+-- open session; starting another expires it.
+CREATE TABLE IF NOT EXISTS checkout_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    cart_id UUID NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed', 'expired')),
+    items JSONB NOT NULL,
+    totals JSONB NOT NULL,
+    shipping_address JSONB NOT NULL,
+    billing_address JSONB NOT NULL,
+    shipping_method VARCHAR(20) NOT NULL,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkout_sessions_open ON checkout_sessions(user_id) WHERE status = 'open';
@@ -0,0 +1,22 @@
+-- In authorize-then-capture checkout a card order starts as an attempt
+-- awaiting payment: its stock is held by reservations the order owns rather
+-- than deducted, and is only committed once the payment is authorized and
+-- captured. A reservation belongs to either a cart or an order.
+ALTER TABLE stock_reservations ALTER COLUMN cart_id DROP NOT NULL;
+ALTER TABLE stock_reservations ADD COLUMN IF NOT EXISTS order_id UUID REFERENCES orders(id) ON DELETE CASCADE;
+ALTER TABLE stock_reservations DROP CONSTRAINT IF EXISTS stock_reservations_owner_check;
+ALTER TABLE stock_reservations ADD CONSTRAINT stock_reservations_owner_check
+    CHECK ((cart_id IS NULL) <> (order_id IS NULL));
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_order ON stock_reservations(order_id) WHERE order_id IS NOT NULL;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('awaiting_payment', 'pending', 'on_hold', 'under_review', 'processing', 'partially_shipped', 'shipped', 'delivered', 'completed', 'cancelled', 'refunded', 'return_requested')
+);
+
+-- An authorized payment holds funds on the card until it is captured or
+-- voided
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_status_check;
+ALTER TABLE payments ADD CONSTRAINT payments_status_check CHECK (
+    status IN ('pending', 'processing', 'authorized', 'completed', 'failed', 'voided', 'partially_refunded', 'refunded')
+);
@@ -0,0 +1,17 @@
+-- Capture ledger. An authorized payment is settled once: captured_amount is
+-- taken from the card, possibly less than was authorized, and the rest of
+-- the authorization is released. A void captures nothing and releases it
+-- all. The payment's amount becomes what was captured.
+CREATE TABLE IF NOT EXISTS payment_captures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    captured_amount DECIMAL(10, 2) NOT NULL CHECK (captured_amount >= 0),
+    released_amount DECIMAL(10, 2) NOT NULL CHECK (released_amount >= 0),
+    reason TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_captures_payment_id ON payment_captures(payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_captures_order_id ON payment_captures(order_id);
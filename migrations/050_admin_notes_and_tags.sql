@@ -0,0 +1,20 @@
+-- Internal notes and tags staff keep on orders and customers, e.g. "VIP" or
+-- "chargeback-risk". Neither is ever shown to the customer. A note belongs
+-- to either an order or a user.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_orders_tags ON orders USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_users_tags ON users USING GIN (tags);
+
+CREATE TABLE IF NOT EXISTS admin_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT admin_notes_subject_check CHECK ((order_id IS NULL) <> (user_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_notes_order_id ON admin_notes(order_id) WHERE order_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_admin_notes_user_id ON admin_notes(user_id) WHERE user_id IS NOT NULL;
@@ -0,0 +1,8 @@
+-- Order lines must keep the variant that was bought, so a variant that has
+-- been ordered can no longer be deleted. Databases created before 003 was
+-- corrected still have the old ON DELETE SET NULL constraint.
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_variant_id_fkey;
+ALTER TABLE order_items ADD CONSTRAINT order_items_variant_id_fkey
+    FOREIGN KEY (variant_id) REFERENCES product_variants(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_order_items_variant_id ON order_items(variant_id) WHERE variant_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Codes texted to confirm it is still the account holder before a sensitive
+-- action, for accounts that have no password to re-enter
+ALTER TABLE phone_otps DROP CONSTRAINT IF EXISTS phone_otps_purpose_check;
+ALTER TABLE phone_otps ADD CONSTRAINT phone_otps_purpose_check
+    CHECK (purpose IN ('login', 'verify_phone', 'reauthenticate'));
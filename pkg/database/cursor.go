@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultCursorBatchSize is how many rows ForEachRow fetches per round trip
+const DefaultCursorBatchSize = 500
+
+// ForEachRow runs query through a server-side cursor and calls scan once per
+// row, fetching batchSize rows at a time so large result sets are never held
+// in memory. The cursor lives in its own transaction (a savepoint when ctx
+// already carries one). Returning an error from scan stops the iteration.
+func ForEachRow(ctx context.Context, db *pgxpool.Pool, batchSize int, query string, args []any, scan func(pgx.Rows) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultCursorBatchSize
+	}
+
+	tx, err := Conn(ctx, db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin cursor transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DECLARE row_cursor NO SCROLL CURSOR FOR "+query, args...); err != nil {
+		return fmt.Errorf("failed to declare cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM row_cursor", batchSize)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return err
+		}
+
+		fetched := 0
+		for rows.Next() {
+			fetched++
+			if err := scan(rows); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if fetched < batchSize {
+			break
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "CLOSE row_cursor"); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
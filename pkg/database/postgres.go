@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"ecommerce-backend/internal/config"
@@ -30,12 +31,7 @@ func InitDB(cfg *config.Config) (*pgxpool.Pool, error) {
 		return nil, fmt.Errorf("unable to parse config: %w", err)
 	}
 
-	// Configure connection pool
-	poolConfig.MaxConns = 25
-	poolConfig.MinConns = 5
-	poolConfig.MaxConnLifetime = time.Hour
-	poolConfig.MaxConnIdleTime = 30 * time.Minute
-	poolConfig.HealthCheckPeriod = time.Minute
+	configurePool(poolConfig, cfg, cfg.DBMinConns)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -60,6 +56,21 @@ func InitDB(cfg *config.Config) (*pgxpool.Pool, error) {
 	return DB, nil
 }
 
+// configurePool applies the pool settings from cfg. minConns is separate so
+// the replica can keep no connections open while it may be down.
+func configurePool(poolConfig *pgxpool.Config, cfg *config.Config, minConns int) {
+	poolConfig.MaxConns = int32(cfg.DBMaxConns)
+	poolConfig.MinConns = int32(minConns)
+	poolConfig.MaxConnLifetime = cfg.DBMaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.DBMaxConnIdleTime
+	poolConfig.HealthCheckPeriod = time.Minute
+	poolConfig.AfterConnect = prepareStatements
+
+	if cfg.DBStatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.DBStatementTimeout.Milliseconds(), 10)
+	}
+}
+
 func runMigrations(ctx context.Context) error {
 	// In production, use a proper migration tool like golang-migrate
 	// For now, we'll just log that migrations should be run manually
@@ -0,0 +1,203 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"ecommerce-backend/internal/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Reader is the read-only subset of Querier that replica-routed repository
+// methods use
+type Reader interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Replica is a read-replica pool that read-only queries may use instead of
+// the primary. It is marked down when a connection to it fails and back up
+// by the health check loop; while it is down reads go to the primary. A nil
+// *Replica means none is configured.
+type Replica struct {
+	pool *pgxpool.Pool
+	up   atomic.Bool
+}
+
+// InitReplica connects to the read replica configured by DB_REPLICA_HOST,
+// returning nil when none is. A replica that cannot be reached at startup is
+// not fatal: it starts out down and the health check brings it in later.
+func InitReplica(cfg *config.Config) (*Replica, error) {
+	if cfg.DBReplicaHost == "" {
+		return nil, nil
+	}
+
+	connString := fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		cfg.DBReplicaUser,
+		cfg.DBReplicaPassword,
+		cfg.DBReplicaHost,
+		cfg.DBReplicaPort,
+		cfg.DBName,
+		cfg.DBSSLMode,
+	)
+
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse replica config: %w", err)
+	}
+
+	configurePool(poolConfig, cfg, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create replica pool: %w", err)
+	}
+
+	replica := &Replica{pool: pool}
+	if err := pool.Ping(ctx); err != nil {
+		log.Printf("⚠️ Warning: read replica unreachable, reads use the primary until it recovers: %v", err)
+		return replica, nil
+	}
+
+	replica.up.Store(true)
+	log.Println("✅ Successfully connected to PostgreSQL read replica")
+	return replica, nil
+}
+
+// Available reports whether reads are currently routed to the replica
+func (r *Replica) Available() bool {
+	return r != nil && r.up.Load()
+}
+
+// Ping checks the replica answers without changing whether reads use it
+func (r *Replica) Ping(ctx context.Context) error {
+	return r.pool.Ping(ctx)
+}
+
+// Stat returns the replica pool's statistics, or nil when none is configured
+func (r *Replica) Stat() *pgxpool.Stat {
+	if r == nil {
+		return nil
+	}
+	return r.pool.Stat()
+}
+
+// Start pings the replica every interval until ctx is cancelled, routing
+// reads back to it once it answers again
+func (r *Replica) Start(ctx context.Context, interval time.Duration) {
+	if r == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.check(ctx)
+			}
+		}
+	}()
+}
+
+func (r *Replica) check(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := r.pool.Ping(pingCtx); err != nil {
+		r.markDown(err)
+		return
+	}
+
+	if r.up.CompareAndSwap(false, true) {
+		log.Println("✅ Read replica is back; routing reads to it")
+	}
+}
+
+func (r *Replica) markDown(err error) {
+	if r.up.CompareAndSwap(true, false) {
+		log.Printf("⚠️ Warning: read replica down, falling back to the primary: %v", err)
+	}
+}
+
+// Close closes the replica pool
+func (r *Replica) Close() {
+	if r != nil {
+		r.pool.Close()
+	}
+}
+
+// ReadConn returns where a read-only query should run: the transaction
+// carried by ctx, so a unit of work reads its own writes; otherwise the
+// replica while it is up; otherwise db. Queries that fail on the replica
+// because it could not be reached are retried on db.
+//
+// The replica may lag the primary, so only use it for reads that tolerate
+// slightly stale data, such as listings and analytics.
+func ReadConn(ctx context.Context, db *pgxpool.Pool, replica *Replica) Reader {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	if !replica.Available() {
+		return db
+	}
+	return &fallbackReader{replica: replica, primary: db}
+}
+
+// unreachable reports whether err means the replica could not be used at
+// all, as opposed to the query failing there
+func unreachable(err error) bool {
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err)
+}
+
+type fallbackReader struct {
+	replica *Replica
+	primary *pgxpool.Pool
+}
+
+func (f *fallbackReader) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := f.replica.pool.Query(ctx, sql, args...)
+	if err != nil && unreachable(err) && ctx.Err() == nil {
+		f.replica.markDown(err)
+		return f.primary.Query(ctx, sql, args...)
+	}
+	return rows, err
+}
+
+func (f *fallbackReader) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &fallbackRow{reader: f, ctx: ctx, sql: sql, args: args}
+}
+
+// fallbackRow defers the query to Scan, where pgx reports errors for
+// QueryRow, so a connection failure can still be retried on the primary
+type fallbackRow struct {
+	reader *fallbackReader
+	ctx    context.Context
+	sql    string
+	args   []any
+}
+
+func (r *fallbackRow) Scan(dest ...any) error {
+	err := r.reader.replica.pool.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	if err != nil && unreachable(err) && r.ctx.Err() == nil {
+		r.reader.replica.markDown(err)
+		return r.reader.primary.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	statementsMu sync.Mutex
+	statements   = map[string]string{}
+)
+
+// Statement registers a hot query to be prepared on every new connection and
+// returns its SQL. pgx uses the prepared statement whenever the same SQL is
+// run on that connection, inside a transaction or not, so callers pass the
+// returned string to Query/QueryRow as usual. If preparing fails, e.g. before
+// a migration has run, the query still works unprepared.
+//
+// Declare statements as package-level variables so they are registered
+// before the pools connect.
+func Statement(name, sql string) string {
+	statementsMu.Lock()
+	defer statementsMu.Unlock()
+
+	statements[name] = sql
+	return sql
+}
+
+// prepareStatements is the pools' AfterConnect hook
+func prepareStatements(ctx context.Context, conn *pgx.Conn) error {
+	statementsMu.Lock()
+	defer statementsMu.Unlock()
+
+	for name, sql := range statements {
+		// Keyed by its SQL, so plain queries with the same text pick it up
+		if _, err := conn.Prepare(ctx, sql, sql); err != nil {
+			log.Printf("⚠️ Warning: could not prepare statement %s: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type storeKey struct{}
+
+// WithStore scopes the repositories called with the returned context to one
+// store, the way WithinTx hands them a transaction. uuid.Nil lifts the scope.
+func WithStore(ctx context.Context, storeID uuid.UUID) context.Context {
+	return context.WithValue(ctx, storeKey{}, storeID)
+}
+
+// StoreFromContext returns the store the context is scoped to. Work outside a
+// storefront request, such as background jobs and gateway webhooks, is not
+// scoped and sees every store.
+func StoreFromContext(ctx context.Context) (uuid.UUID, bool) {
+	storeID, ok := ctx.Value(storeKey{}).(uuid.UUID)
+	return storeID, ok && storeID != uuid.Nil
+}
+
+// StoreArg is the store to bind in single-row lookups written as
+// ($n::uuid IS NULL OR store_id = $n): the scoped store, or nil for none
+func StoreArg(ctx context.Context) any {
+	if storeID, ok := StoreFromContext(ctx); ok {
+		return storeID
+	}
+	return nil
+}
+
+// ScopeStore restricts column to the context's store, if it has one
+func (w *Where) ScopeStore(ctx context.Context, column string) {
+	if storeID, ok := StoreFromContext(ctx); ok {
+		w.And(column+" = ?", storeID)
+	}
+}
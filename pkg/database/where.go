@@ -0,0 +1,52 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Where builds a WHERE clause and its arguments. Conditions are written with
+// ? placeholders, which are numbered $1, $2, ... in the order values are
+// bound, so list queries never count arguments by hand. The zero value is an
+// empty clause ready to use.
+type Where struct {
+	conds []string
+	args  []any
+}
+
+// And adds a condition, binding each ? in cond to the next of args. A
+// mismatch between placeholders and args is a programming error and panics.
+func (w *Where) And(cond string, args ...any) {
+	parts := strings.Split(cond, "?")
+	if len(parts)-1 != len(args) {
+		panic(fmt.Sprintf("database: condition %q has %d placeholders for %d args", cond, len(parts)-1, len(args)))
+	}
+
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for i, arg := range args {
+		b.WriteString(w.Bind(arg))
+		b.WriteString(parts[i+1])
+	}
+	w.conds = append(w.conds, b.String())
+}
+
+// Bind adds a value outside the conditions, such as a LIMIT, and returns its
+// placeholder
+func (w *Where) Bind(arg any) string {
+	w.args = append(w.args, arg)
+	return fmt.Sprintf("$%d", len(w.args))
+}
+
+// String renders the clause, or "" when there are no conditions
+func (w *Where) String() string {
+	if len(w.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(w.conds, " AND ")
+}
+
+// Args returns the values bound so far, in placeholder order
+func (w *Where) Args() []any {
+	return w.args
+}
@@ -0,0 +1,203 @@
+// Package money represents currency amounts exactly as integer minor units
+// (paise, cents) so totals and refunds never drift through float rounding.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Scale is the number of decimal places stored, matching the DECIMAL(10, 2)
+// amount columns
+const Scale = 2
+
+const minorPerMajor = 100
+
+var ErrPrecision = errors.New("amount has more than 2 decimal places")
+
+// Money is an amount in minor units. The zero value is zero.
+type Money int64
+
+// FromMinor builds an amount from minor units, e.g. FromMinor(1999) is 19.99
+func FromMinor(minor int64) Money {
+	return Money(minor)
+}
+
+// FromMajor builds an amount from whole units, e.g. FromMajor(20) is 20.00
+func FromMajor(major int64) Money {
+	return Money(major * minorPerMajor)
+}
+
+// FromFloat converts a float, rounding half away from zero to the nearest
+// minor unit. Use it only at boundaries that hand over floats.
+func FromFloat(f float64) Money {
+	return Money(math.Round(f * minorPerMajor))
+}
+
+// Parse reads a decimal string such as "19.99", "-5" or "1e2" exactly. More
+// than two decimal places is an error rather than a silent rounding.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty amount")
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	r.Mul(r, big.NewRat(minorPerMajor, 1))
+	if !r.IsInt() {
+		return 0, ErrPrecision
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("amount %q out of range", s)
+	}
+
+	return Money(r.Num().Int64()), nil
+}
+
+func (m Money) Minor() int64 {
+	return int64(m)
+}
+
+// Float64 is for display math only (ratios, charts); never feed it back into
+// an amount
+func (m Money) Float64() float64 {
+	return float64(m) / minorPerMajor
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// Mul multiplies by a quantity
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// MulRat scales by num/den, rounding half away from zero to a minor unit
+func (m Money) MulRat(num, den int64) Money {
+	if den == 0 {
+		return 0
+	}
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(num))
+	return Money(roundQuo(product, big.NewInt(den)).Int64())
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+// Neg returns the amount with its sign flipped
+func (m Money) Neg() Money {
+	return -m
+}
+
+// String formats the amount with exactly two decimals, e.g. "19.90"
+func (m Money) String() string {
+	minor := int64(m)
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorPerMajor, minor%minorPerMajor)
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals, which
+// clients can parse exactly
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a quoted decimal string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ScanNumeric reads a NUMERIC column without passing through float64
+func (m *Money) ScanNumeric(n pgtype.Numeric) error {
+	if !n.Valid {
+		return errors.New("cannot scan NULL into money.Money")
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return errors.New("cannot scan non-finite numeric into money.Money")
+	}
+
+	value := new(big.Int).Set(n.Int)
+	exp := int64(n.Exp) + Scale
+	switch {
+	case exp > 0:
+		value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	case exp < 0:
+		// Aggregates such as AVG carry extra digits; round to a minor unit
+		value = roundQuo(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(-exp), nil))
+	}
+
+	if !value.IsInt64() {
+		return errors.New("numeric value out of range for money.Money")
+	}
+	*m = Money(value.Int64())
+	return nil
+}
+
+// ScanFloat64 accepts float8 expressions, rounding to the nearest minor unit
+func (m *Money) ScanFloat64(f pgtype.Float8) error {
+	if !f.Valid {
+		return errors.New("cannot scan NULL into money.Money")
+	}
+	*m = FromFloat(f.Float64)
+	return nil
+}
+
+// NumericValue writes the amount to a NUMERIC parameter exactly
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(m)), Exp: -Scale, Valid: true}, nil
+}
+
+// roundQuo divides n by d, rounding half away from zero
+func roundQuo(n, d *big.Int) *big.Int {
+	quo, rem := new(big.Int).QuoRem(n, d, new(big.Int))
+	twiceRem := new(big.Int).Abs(new(big.Int).Mul(rem, big.NewInt(2)))
+	if twiceRem.Cmp(new(big.Int).Abs(d)) >= 0 {
+		if (n.Sign() < 0) != (d.Sign() < 0) {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
@@ -0,0 +1,27 @@
+// Package queue publishes messages to a message broker for other services to
+// consume.
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// Message is one message for the broker. Key routes it: the routing key on
+// RabbitMQ. ID lets consumers drop duplicates, since publishing is
+// at-least-once.
+type Message struct {
+	Key         string
+	ID          string
+	ContentType string
+	Headers     map[string]string
+	Timestamp   time.Time
+	Body        []byte
+}
+
+// Publisher sends messages to a broker. Publish returns once the broker has
+// accepted the message, so a nil error means it will not be lost.
+type Publisher interface {
+	Name() string
+	Publish(ctx context.Context, msg Message) error
+}
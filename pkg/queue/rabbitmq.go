@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// confirmTimeout bounds the wait for the broker to confirm a message
+const confirmTimeout = 10 * time.Second
+
+type rabbitMQPublisher struct {
+	url      string
+	exchange string
+
+	mu   sync.Mutex
+	conn *amqp.Connection
+	ch   *amqp.Channel
+}
+
+// NewRabbitMQPublisher publishes persistent messages to a durable topic
+// exchange, declared on first use. It dials lazily and again after the
+// broker drops the connection, so it can be created while the broker is down.
+func NewRabbitMQPublisher(url, exchange string) Publisher {
+	return &rabbitMQPublisher{url: url, exchange: exchange}
+}
+
+func (p *rabbitMQPublisher) Name() string {
+	return "rabbitmq"
+}
+
+func (p *rabbitMQPublisher) Publish(ctx context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ch, err := p.channel()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, confirmTimeout)
+	defer cancel()
+
+	headers := amqp.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, msg.Key, false, false, amqp.Publishing{
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.ID,
+		Timestamp:    msg.Timestamp,
+		Headers:      headers,
+		Body:         msg.Body,
+	})
+	if err != nil {
+		p.reset()
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		p.reset()
+		return err
+	}
+	if !acked {
+		return errors.New("broker rejected the message")
+	}
+
+	return nil
+}
+
+// channel returns the open confirm-mode channel, dialling the broker and
+// declaring the exchange when there is none. Callers hold p.mu.
+func (p *rabbitMQPublisher) channel() (*amqp.Channel, error) {
+	if p.ch != nil && !p.ch.IsClosed() {
+		return p.ch, nil
+	}
+	p.reset()
+
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err == nil {
+		err = ch.Confirm(false)
+	}
+	if err == nil {
+		err = ch.ExchangeDeclare(p.exchange, amqp.ExchangeTopic, true, false, false, false, nil)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	p.conn, p.ch = conn, ch
+	return ch, nil
+}
+
+// reset drops the connection so the next publish dials again
+func (p *rabbitMQPublisher) reset() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn, p.ch = nil, nil
+}
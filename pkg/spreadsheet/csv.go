@@ -0,0 +1,73 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+)
+
+type bomSkippingFile struct {
+	*bufio.Reader
+	file *os.File
+}
+
+func (f *bomSkippingFile) Close() error {
+	return f.file.Close()
+}
+
+// OpenCSV opens a CSV file, skipping the UTF-8 byte order mark Excel writes
+func OpenCSV(path string) (RowReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewReader(file)
+	if prefix, err := buffered.Peek(3); err == nil && bytes.Equal(prefix, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = buffered.Discard(3)
+	}
+
+	var r io.Reader = &bomSkippingFile{Reader: buffered, file: file}
+	return NewCSVReader(r), nil
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	closer io.Closer
+	row    int
+}
+
+// NewCSVReader reads comma-separated rows from r. Rows may have differing
+// field counts; callers validate the shape.
+func NewCSVReader(r io.Reader) RowReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	closer, _ := r.(io.Closer)
+	return &csvReader{reader: reader, closer: closer}
+}
+
+func (c *csvReader) Next() ([]string, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	c.row, _ = c.reader.FieldPos(0)
+	return record, nil
+}
+
+func (c *csvReader) Row() int {
+	return c.row
+}
+
+func (c *csvReader) Close() error {
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer.Close()
+}
@@ -0,0 +1,49 @@
+// Package spreadsheet reads tabular uploads (CSV and XLSX) one row at a time.
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Supported upload formats
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format, expected .csv or .xlsx")
+
+// RowReader streams rows from a spreadsheet. Next returns io.EOF after the
+// last row; Row reports the 1-based source row of the record last returned.
+type RowReader interface {
+	Next() ([]string, error)
+	Row() int
+	Close() error
+}
+
+// DetectFormat picks the format from the file extension
+func DetectFormat(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Open returns a reader for the file at path in the given format
+func Open(path, format string) (RowReader, error) {
+	switch format {
+	case FormatCSV:
+		return OpenCSV(path)
+	case FormatXLSX:
+		return OpenXLSX(path)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
@@ -0,0 +1,268 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxWorkbookPath      = "xl/workbook.xml"
+	xlsxWorkbookRelsPath  = "xl/_rels/workbook.xml.rels"
+	xlsxSharedStringsPath = "xl/sharedStrings.xml"
+	xlsxDefaultSheetPath  = "xl/worksheets/sheet1.xml"
+)
+
+type xlsxReader struct {
+	archive       *zip.ReadCloser
+	sheet         io.ReadCloser
+	decoder       *xml.Decoder
+	sharedStrings []string
+	row           int
+}
+
+// OpenXLSX streams the first worksheet of an .xlsx workbook. Only the shared
+// string table is held in memory; sheet rows are decoded as they are read.
+func OpenXLSX(filePath string) (RowReader, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+
+	reader := &xlsxReader{archive: archive}
+	if err := reader.init(); err != nil {
+		archive.Close()
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+func (x *xlsxReader) init() error {
+	files := make(map[string]*zip.File, len(x.archive.File))
+	for _, f := range x.archive.File {
+		files[f.Name] = f
+	}
+
+	if f, ok := files[xlsxSharedStringsPath]; ok {
+		strs, err := readSharedStrings(f)
+		if err != nil {
+			return err
+		}
+		x.sharedStrings = strs
+	}
+
+	sheetPath := firstSheetPath(files)
+	sheetFile, ok := files[sheetPath]
+	if !ok {
+		return errors.New("invalid xlsx file: worksheet not found")
+	}
+
+	sheet, err := sheetFile.Open()
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	x.decoder = xml.NewDecoder(sheet)
+	return nil
+}
+
+func (x *xlsxReader) Next() ([]string, error) {
+	var (
+		record  []string
+		inRow   bool
+		cellCol int
+		cellTyp string
+		value   strings.Builder
+		inValue bool
+	)
+
+	for {
+		token, err := x.decoder.Token()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid xlsx sheet: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				inRow = true
+				record = record[:0]
+				x.row++
+				if r := attr(t, "r"); r != "" {
+					if n, err := strconv.Atoi(r); err == nil {
+						x.row = n
+					}
+				}
+			case "c":
+				cellCol = len(record)
+				if ref := attr(t, "r"); ref != "" {
+					cellCol = columnIndex(ref)
+				}
+				cellTyp = attr(t, "t")
+				value.Reset()
+			case "v", "t":
+				inValue = inRow
+			}
+
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			}
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v", "t":
+				inValue = false
+			case "c":
+				for len(record) < cellCol {
+					record = append(record, "")
+				}
+				record = append(record, x.cellValue(cellTyp, value.String()))
+			case "row":
+				return record, nil
+			}
+		}
+	}
+}
+
+func (x *xlsxReader) cellValue(cellType, raw string) string {
+	switch cellType {
+	case "s":
+		idx, err := strconv.Atoi(raw)
+		if err != nil || idx < 0 || idx >= len(x.sharedStrings) {
+			return ""
+		}
+		return x.sharedStrings[idx]
+	case "b":
+		if raw == "1" {
+			return "true"
+		}
+		return "false"
+	default:
+		return raw
+	}
+}
+
+func (x *xlsxReader) Row() int {
+	return x.row
+}
+
+func (x *xlsxReader) Close() error {
+	if x.sheet != nil {
+		x.sheet.Close()
+	}
+	return x.archive.Close()
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var table struct {
+		Items []struct {
+			Text string `xml:"t"`
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"si"`
+	}
+	if err := xml.NewDecoder(rc).Decode(&table); err != nil {
+		return nil, fmt.Errorf("invalid xlsx shared strings: %w", err)
+	}
+
+	strs := make([]string, len(table.Items))
+	for i, item := range table.Items {
+		if len(item.Runs) == 0 {
+			strs[i] = item.Text
+			continue
+		}
+		var b strings.Builder
+		for _, run := range item.Runs {
+			b.WriteString(run.Text)
+		}
+		strs[i] = b.String()
+	}
+
+	return strs, nil
+}
+
+// firstSheetPath resolves the first sheet listed in the workbook, falling back
+// to the conventional sheet1 location
+func firstSheetPath(files map[string]*zip.File) string {
+	var workbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	if !decodeZipXML(files[xlsxWorkbookPath], &workbook) || len(workbook.Sheets) == 0 ||
+		!decodeZipXML(files[xlsxWorkbookRelsPath], &rels) {
+		return xlsxDefaultSheetPath
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+
+	return xlsxDefaultSheetPath
+}
+
+func decodeZipXML(f *zip.File, v interface{}) bool {
+	if f == nil {
+		return false
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return false
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v) == nil
+}
+
+func attr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" into a
+// zero-based column index
+func columnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// GinValidator makes gin's binding use the same validator and validate tags
+// as ValidateStruct. Install it with binding.Validator = utils.GinValidator{}.
+type GinValidator struct{}
+
+var _ binding.StructValidator = GinValidator{}
+
+func (GinValidator) ValidateStruct(obj any) error {
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return validate.Struct(obj)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := (GinValidator{}).ValidateStruct(value.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (GinValidator) Engine() any {
+	return validate
+}
+
+// BindJSON decodes and validates the request body into obj through gin's
+// binding, which must be using GinValidator. It responds with 400 for a
+// malformed body, 413 when it exceeds the body limit and 422 with per-field
+// errors when validation fails, and reports whether the handler should
+// continue.
+func BindJSON(c *gin.Context, obj any) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		GinValidationErrorResponse(c, validationErrors(fieldErrors))
+		return false
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		GinPayloadTooLargeResponse(c, tooLarge.Limit)
+		return false
+	}
+
+	GinBadRequestResponse(c, "Invalid request body", err)
+	return false
+}
+
+// ParseUUIDParam reads a UUID path parameter, responding with 422 when it is
+// malformed
+func ParseUUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		GinValidationErrorResponse(c, map[string]string{name: "Must be a valid UUID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
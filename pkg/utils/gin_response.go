@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -33,6 +35,16 @@ func GinCreatedResponse(c *gin.Context, message string, data interface{}) {
 	})
 }
 
+// GinAcceptedResponse sends a success response with 202 status code for work
+// that continues in the background
+func GinAcceptedResponse(c *gin.Context, message string, data interface{}) {
+	c.JSON(http.StatusAccepted, GinResponseData{
+		Success: true,
+		Message: message,
+		Data:    data,
+	})
+}
+
 // GinErrorResponse sends an error response
 func GinErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 	errorMsg := ""
@@ -46,9 +58,9 @@ func GinErrorResponse(c *gin.Context, statusCode int, message string, err error)
 	})
 }
 
-// GinValidationErrorResponse sends validation errors
+// GinValidationErrorResponse sends a 422 with errors keyed by field path
 func GinValidationErrorResponse(c *gin.Context, errors map[string]string) {
-	c.JSON(http.StatusBadRequest, GinResponseData{
+	c.JSON(http.StatusUnprocessableEntity, GinResponseData{
 		Success: false,
 		Message: "Validation failed",
 		Error:   "validation_error",
@@ -107,15 +119,51 @@ func GinConflictResponse(c *gin.Context, message string, err error) {
 	})
 }
 
-// GinInternalErrorResponse sends a 500 internal server error response
+// GinRequestTimeoutResponse sends a 408 when a request ran past its deadline
+func GinRequestTimeoutResponse(c *gin.Context) {
+	c.JSON(http.StatusRequestTimeout, GinResponseData{
+		Success: false,
+		Message: "Request timed out",
+		Error:   "request_timeout",
+	})
+}
+
+// GinPayloadTooLargeResponse sends a 413 for a request body over maxBytes
+func GinPayloadTooLargeResponse(c *gin.Context, maxBytes int64) {
+	c.JSON(http.StatusRequestEntityTooLarge, GinResponseData{
+		Success: false,
+		Message: fmt.Sprintf("Request body exceeds the %s limit", formatBytes(maxBytes)),
+		Error:   "payload_too_large",
+	})
+}
+
+// formatBytes renders a size in the largest whole unit, e.g. 20 MB
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%d KB", n>>10)
+	default:
+		return fmt.Sprintf("%d bytes", n)
+	}
+}
+
+// GinServiceUnavailableResponse sends a 503 while the service is temporarily
+// turned away, e.g. for maintenance
+func GinServiceUnavailableResponse(c *gin.Context, message string, err error) {
+	GinErrorResponse(c, http.StatusServiceUnavailable, message, err)
+}
+
+// GinInternalErrorResponse sends a 500 internal server error response. The
+// error is logged rather than returned so internal details do not leak.
 func GinInternalErrorResponse(c *gin.Context, message string, err error) {
-	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		log.Printf("❌ %s: %v", message, err)
 	}
 	c.JSON(http.StatusInternalServerError, GinResponseData{
 		Success: false,
 		Message: message,
-		Error:   errorMsg,
+		Error:   "internal_error",
 	})
 }
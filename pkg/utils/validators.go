@@ -2,45 +2,129 @@ package utils
 
 import (
 	"net/mail"
+	"reflect"
 	"regexp"
+	"strings"
 	"unicode"
 
 	"github.com/go-playground/validator/v10"
 )
 
-var validate = validator.New()
+var validate = newValidator()
 
+// phonePattern is E.164: an optional +, then up to 15 digits not starting with 0
+var phonePattern = regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
+
+// phoneSeparators are stripped before a phone number is checked
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+// newValidator builds the validator shared by request binding and
+// ValidateStruct. Fields are reported by their JSON names.
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		}
+		return name
+	})
+
+	v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return ValidatePassword(fl.Field().String())
+	})
+	v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		return ValidatePhone(fl.Field().String())
+	})
+	v.RegisterValidation("barcode", func(fl validator.FieldLevel) bool {
+		// Empty clears a barcode on update
+		_, ok := NormalizeBarcode(fl.Field().String())
+		return ok || fl.Field().String() == ""
+	})
+
+	return v
+}
+
+// ValidateStruct checks data against its validate tags. Errors are keyed by
+// the JSON path of the field, e.g. "shipping_address.postal_code" or
+// "items[0].quantity".
 func ValidateStruct(data interface{}) map[string]string {
 	err := validate.Struct(data)
 	if err != nil {
-		errors := make(map[string]string)
-		for _, err := range err.(validator.ValidationErrors) {
-			errors[err.Field()] = getValidationMessage(err)
-		}
-		return errors
+		return validationErrors(err)
 	}
 	return nil
 }
 
+func validationErrors(err error) map[string]string {
+	fieldErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return map[string]string{"": err.Error()}
+	}
+
+	errors := make(map[string]string)
+	for _, err := range fieldErrors {
+		errors[fieldPath(err)] = getValidationMessage(err)
+	}
+	return errors
+}
+
+// fieldPath drops the struct name the validator puts in front of every field
+func fieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldError.Field()
+}
+
 func getValidationMessage(fieldError validator.FieldError) string {
 	switch fieldError.Tag() {
-	case "required":
+	case "required", "required_without":
 		return "This field is required"
 	case "email":
 		return "Invalid email address"
+	case "password":
+		return "Must be at least 8 characters and contain upper and lower case letters and a number"
+	case "phone":
+		return "Invalid phone number"
+	case "barcode":
+		return "Must be an EAN-8, UPC-A, EAN-13 or GTIN-14 barcode"
+	case "uuid":
+		return "Must be a valid UUID"
+	case "oneof":
+		return "Must be one of: " + strings.ReplaceAll(fieldError.Param(), " ", ", ")
 	case "min":
-		return "Value is too short"
+		return "Must be at least " + fieldError.Param() + lengthUnit(fieldError)
 	case "max":
-		return "Value is too long"
+		return "Must be at most " + fieldError.Param() + lengthUnit(fieldError)
 	case "gte":
 		return "Value must be greater than or equal to " + fieldError.Param()
 	case "lte":
 		return "Value must be less than or equal to " + fieldError.Param()
+	case "gt":
+		return "Value must be greater than " + fieldError.Param()
 	default:
 		return "Invalid value"
 	}
 }
 
+// lengthUnit names what min and max count for the field's kind
+func lengthUnit(fieldError validator.FieldError) string {
+	switch fieldError.Kind() {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
 // Custom validations
 func ValidateEmail(email string) bool {
 	_, err := mail.ParseAddress(email)
@@ -67,8 +151,48 @@ func ValidatePassword(password string) bool {
 	return hasUpper && hasLower && hasNumber
 }
 
+// ValidatePhone accepts E.164 numbers, ignoring spaces, dashes, dots and
+// parentheses
 func ValidatePhone(phone string) bool {
-	// Simple phone validation - adjust as needed
-	re := regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
-	return re.MatchString(phone)
+	return phonePattern.MatchString(phoneSeparators.Replace(phone))
+}
+
+// NormalizeBarcode checks an EAN-8, UPC-A, EAN-13 or GTIN-14 barcode, ignoring
+// spaces and dashes, and returns it in the form it is stored and looked up
+// in. UPC-A codes and GTIN-14 codes with a leading zero are the same GTINs as
+// their EAN-13 forms, so they are stored as those; a scanner reading either
+// form finds the product.
+func NormalizeBarcode(code string) (string, bool) {
+	code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return "", false
+	}
+
+	// GS1 check digit: weights 3 and 1 alternate from the rightmost data digit
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := code[i]
+		if digit < '0' || digit > '9' {
+			return "", false
+		}
+		weight := 1
+		if (len(code)-2-i)%2 == 0 {
+			weight = 3
+		}
+		sum += int(digit-'0') * weight
+	}
+	check := code[len(code)-1]
+	if check < '0' || check > '9' || int(check-'0') != (10-sum%10)%10 {
+		return "", false
+	}
+
+	switch {
+	case len(code) == 12:
+		code = "0" + code
+	case len(code) == 14 && code[0] == '0':
+		code = code[1:]
+	}
+	return code, true
 }